@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EmulatedAccelerator is an accelerator model of an emulated node.
+type EmulatedAccelerator struct {
+	// Model is the accelerator model, as the GPU operator labels it on nodes (e.g. NVIDIA-A100-PCIE-80GB).
+	// +kubebuilder:validation:MinLength=1
+	Model string `json:"model"`
+
+	// Count is the number of physical GPUs of the model on the node.
+	// +kubebuilder:validation:Minimum=0
+	Count int `json:"count"`
+
+	// Memory is the memory of one GPU, in MB.
+	// +optional
+	Memory string `json:"memory,omitempty"`
+
+	// Shares is the number of containers each GPU can be shared by with time-slicing or MPS.
+	// Defaults to 1, GPUs that are not shared.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Shares int `json:"shares,omitempty"`
+
+	// Used is the number of physical GPUs of the model in use on the node.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Used int `json:"used,omitempty"`
+}
+
+// EmulatedNodeSpec defines the accelerators of an emulated node.
+type EmulatedNodeSpec struct {
+	// Accelerators are the accelerator models of the node.
+	// +listType=map
+	// +listMapKey=model
+	// +optional
+	Accelerators []EmulatedAccelerator `json:"accelerators,omitempty"`
+
+	// Zone is the topology.kubernetes.io/zone of the node.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Lost marks the node as lost, as when it fails or is removed: its accelerators
+	// leave the inventory until it is cleared.
+	// +optional
+	Lost bool `json:"lost,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=enode
+// +kubebuilder:printcolumn:name="Zone",type=string,JSONPath=".spec.zone"
+// +kubebuilder:printcolumn:name="Lost",type=boolean,JSONPath=".spec.lost"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// EmulatedNode is the Schema for the emulatednodes API.
+// It declares a node and its accelerators for emulated clusters, such as the Kind emulator,
+// whose nodes have no GPUs. With the EmulatedInventory feature gate, the controller reads
+// the accelerator inventory from EmulatedNodes instead of nodes, so that tests can script
+// heterogeneous pools, GPU usage and node loss declaratively.
+type EmulatedNode struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the accelerators of the node.
+	Spec EmulatedNodeSpec `json:"spec,omitempty"`
+}
+
+// EmulatedNodeList contains a list of EmulatedNode resources.
+// +kubebuilder:object:root=true
+type EmulatedNodeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of EmulatedNode resources.
+	Items []EmulatedNode `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EmulatedNode{}, &EmulatedNodeList{})
+}
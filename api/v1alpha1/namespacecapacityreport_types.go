@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultNamespaceCapacityReportName is the name of the NamespaceCapacityReport
+// the controller maintains in every namespace with active VariantAutoscalings.
+const DefaultNamespaceCapacityReportName = "wva-capacity"
+
+// NamespaceCapacityReportSpec is intentionally empty: the report is fully
+// controller-managed and carries all of its data in status.
+type NamespaceCapacityReportSpec struct{}
+
+// NamespaceCapacityReportStatus aggregates the capacity consumed by all
+// VariantAutoscalings in a namespace as of the last optimization run.
+type NamespaceCapacityReportStatus struct {
+	// LastUpdateTime is the timestamp of the optimization run that last changed this report.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// TotalGPUs is the number of GPUs consumed by the current replicas of all variants.
+	// +kubebuilder:validation:Minimum=0
+	TotalGPUs int `json:"totalGPUs"`
+
+	// TotalReplicas is the number of current replicas across all variants.
+	// +kubebuilder:validation:Minimum=0
+	TotalReplicas int `json:"totalReplicas"`
+
+	// AggregateSaturation is the replica-weighted average saturation (0.0-1.0)
+	// across all variants in the namespace.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	AggregateSaturation string `json:"aggregateSaturation,omitempty"`
+
+	// Cost is the total cost of the current replicas: the sum over the variants of
+	// their spec.variantCost times their current replicas, in the unit of variantCost.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	Cost string `json:"cost,omitempty"`
+
+	// Models breaks the namespace totals down per model.
+	// +listType=map
+	// +listMapKey=modelID
+	// +kubebuilder:validation:Optional
+	Models []ModelCapacitySummary `json:"models,omitempty"`
+}
+
+// ModelCapacitySummary holds the capacity consumed by all variants of one model.
+type ModelCapacitySummary struct {
+	// ModelID is the model identifier shared by the summarized variants.
+	// +kubebuilder:validation:MinLength=1
+	ModelID string `json:"modelID"`
+
+	// Replicas is the number of current replicas across all variants of the model.
+	// +kubebuilder:validation:Minimum=0
+	Replicas int `json:"replicas"`
+
+	// DesiredReplicas is the number of replicas the optimizer targets across all variants of the model.
+	// +kubebuilder:validation:Minimum=0
+	DesiredReplicas int `json:"desiredReplicas"`
+
+	// GPUs is the number of GPUs consumed by the current replicas of the model.
+	// +kubebuilder:validation:Minimum=0
+	GPUs int `json:"gpus"`
+
+	// Saturation is the replica-weighted average saturation (0.0-1.0) of the model's variants.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	Saturation string `json:"saturation,omitempty"`
+
+	// Cost is the cost of the model's current replicas, in the unit of spec.variantCost.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	Cost string `json:"cost,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ncr
+// +kubebuilder:printcolumn:name="GPUs",type=integer,JSONPath=".status.totalGPUs"
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=".status.totalReplicas"
+// +kubebuilder:printcolumn:name="Saturation",type=string,JSONPath=".status.aggregateSaturation"
+// +kubebuilder:printcolumn:name="Cost",type=string,JSONPath=".status.cost"
+// +kubebuilder:printcolumn:name="Updated",type=date,JSONPath=".status.lastUpdateTime"
+
+// NamespaceCapacityReport is the Schema for the namespacecapacityreports API.
+// It is maintained by the controller and summarizes the capacity consumed by
+// all VariantAutoscalings in its namespace, so a single
+// `kubectl get namespacecapacityreports -A` gives a cluster-wide capacity review.
+type NamespaceCapacityReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is empty; the report is fully controller-managed.
+	Spec NamespaceCapacityReportSpec `json:"spec,omitempty"`
+
+	// Status holds the aggregated capacity data for the namespace.
+	Status NamespaceCapacityReportStatus `json:"status,omitempty"`
+}
+
+// NamespaceCapacityReportList contains a list of NamespaceCapacityReport resources.
+// +kubebuilder:object:root=true
+type NamespaceCapacityReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of NamespaceCapacityReport resources.
+	Items []NamespaceCapacityReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceCapacityReport{}, &NamespaceCapacityReportList{})
+}
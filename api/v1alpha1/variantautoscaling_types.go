@@ -6,12 +6,21 @@ import (
 )
 
 // VariantAutoscalingSpec defines the desired state for autoscaling a model variant.
+// +kubebuilder:validation:XValidation:rule="has(self.scaleTargetSelector) != (has(self.scaleTargetRef) && self.scaleTargetRef.name != '')",message="exactly one of scaleTargetRef and scaleTargetSelector must be set"
 type VariantAutoscalingSpec struct {
 	// ScaleTargetRef references the scalable resource to manage.
 	// This follows the same pattern as HorizontalPodAutoscaler.
-	// +kubebuilder:validation:Required
+	// Exactly one of ScaleTargetRef and ScaleTargetSelector must be set.
+	// +kubebuilder:validation:Optional
 	ScaleTargetRef autoscalingv1.CrossVersionObjectReference `json:"scaleTargetRef"`
 
+	// ScaleTargetSelector selects the workload to manage by its labels, so that renaming
+	// the workload does not orphan the variant. It must select exactly one Deployment,
+	// StatefulSet or LeaderWorkerSet in the namespace of the variant; it is resolved again
+	// on every reconciliation.
+	// +kubebuilder:validation:Optional
+	ScaleTargetSelector *metav1.LabelSelector `json:"scaleTargetSelector,omitempty"`
+
 	// ModelID specifies the unique identifier of the model to be autoscaled.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:Required
@@ -22,18 +31,261 @@ type VariantAutoscalingSpec struct {
 	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
 	// +kubebuilder:default="10.0"
 	VariantCost string `json:"variantCost,omitempty"`
+
+	// AcceleratorPreferences is an ordered list of accelerator types acceptable for this variant,
+	// most preferred first, each with the performance profile of the model on that type.
+	// Without spec.fallback, the other types are the fallback classes of the variant: while its
+	// accelerator is exhausted, the replicas the GPU limiter cannot grant are recommended on the
+	// most preferred other type with GPUs available. Used when the AcceleratorFallback feature
+	// gate is enabled.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=atomic
+	AcceleratorPreferences []AcceleratorPreference `json:"acceleratorPreferences,omitempty"`
+
+	// Fallback is the standby accelerator class of this variant, with the performance profile
+	// of the model on it. While the accelerator of the variant is exhausted cluster-wide, the
+	// replicas the GPU limiter cannot grant are recommended on the fallback class instead, and
+	// moved back once the accelerator frees up. Used when the AcceleratorFallback feature gate
+	// is enabled.
+	// +kubebuilder:validation:Optional
+	Fallback *AcceleratorPreference `json:"fallback,omitempty"`
+
+	// Engine selects the scaling engine of this variant by the name it is registered with.
+	// Empty selects the built-in saturation engine.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	Engine string `json:"engine,omitempty"`
+
+	// EngineComposition combines the decisions of several scaling engines.
+	// When set, it takes precedence over Engine.
+	// +kubebuilder:validation:Optional
+	EngineComposition *EngineComposition `json:"engineComposition,omitempty"`
+
+	// MinReplicas overrides the fewest replicas the optimizer recommends for the variant.
+	// It is the spec replicas of the scale subresource, so the scale API writes it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Behavior configures the scaling of the variant in the up and down directions, like the
+	// behavior of a HorizontalPodAutoscaler: stabilization windows smooth the recommendations
+	// and policies limit the replicas added or removed per period. Unlike the HPA defaults,
+	// an unset direction is neither stabilized nor limited.
+	// +kubebuilder:validation:Optional
+	Behavior *ScalingBehavior `json:"behavior,omitempty"`
+}
+
+// EngineCombinationPolicy selects how the decisions of composed engines are combined.
+// +kubebuilder:validation:Enum=Max;Min;Priority
+type EngineCombinationPolicy string
+
+const (
+	// EngineCombinationMax takes the largest target replicas of the engines.
+	EngineCombinationMax EngineCombinationPolicy = "Max"
+	// EngineCombinationMin takes the smallest target replicas of the engines.
+	EngineCombinationMin EngineCombinationPolicy = "Min"
+	// EngineCombinationPriority takes the decision of the first engine, in list order,
+	// that makes one.
+	EngineCombinationPriority EngineCombinationPolicy = "Priority"
+)
+
+// EngineComposition combines the decisions of several registered scaling engines.
+type EngineComposition struct {
+	// Engines are the names of the composed engines, in priority order.
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MaxLength=63
+	// +listType=atomic
+	Engines []string `json:"engines"`
+
+	// Policy selects how the decisions of the engines are combined.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Max
+	Policy EngineCombinationPolicy `json:"policy,omitempty"`
+}
+
+// ScalingBehavior configures the scaling of a variant in each direction.
+type ScalingBehavior struct {
+	// ScaleUp is the scaling behavior when the optimizer recommends more replicas.
+	// +kubebuilder:validation:Optional
+	ScaleUp *ScalingRules `json:"scaleUp,omitempty"`
+
+	// ScaleDown is the scaling behavior when the optimizer recommends fewer replicas.
+	// +kubebuilder:validation:Optional
+	ScaleDown *ScalingRules `json:"scaleDown,omitempty"`
+}
+
+// ScalingPolicySelect selects which of several scaling policies applies.
+// +kubebuilder:validation:Enum=Max;Min;Disabled
+type ScalingPolicySelect string
+
+const (
+	// MaxChangePolicySelect selects the policy allowing the largest change.
+	MaxChangePolicySelect ScalingPolicySelect = "Max"
+	// MinChangePolicySelect selects the policy allowing the smallest change.
+	MinChangePolicySelect ScalingPolicySelect = "Min"
+	// DisabledPolicySelect disables the scaling in this direction.
+	DisabledPolicySelect ScalingPolicySelect = "Disabled"
+)
+
+// ScalingPolicyType is the unit of the change a scaling policy allows.
+// +kubebuilder:validation:Enum=Pods;Percent
+type ScalingPolicyType string
+
+const (
+	// PodsScalingPolicy allows a number of replicas to be added or removed.
+	PodsScalingPolicy ScalingPolicyType = "Pods"
+	// PercentScalingPolicy allows a percentage of the replicas to be added or removed.
+	PercentScalingPolicy ScalingPolicyType = "Percent"
+)
+
+// ScalingRules configures the scaling of a variant in one direction.
+type ScalingRules struct {
+	// StabilizationWindowSeconds is the number of seconds over which past recommendations
+	// are considered: the least replicas recommended over the window when scaling up,
+	// the most when scaling down. 0 applies each recommendation immediately.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	StabilizationWindowSeconds *int32 `json:"stabilizationWindowSeconds,omitempty"`
+
+	// SelectPolicy selects which of Policies applies. Defaults to Max.
+	// +kubebuilder:validation:Optional
+	SelectPolicy *ScalingPolicySelect `json:"selectPolicy,omitempty"`
+
+	// Policies limit the change in replicas per period. Without policies, the change
+	// is not limited.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=atomic
+	Policies []ScalingPolicy `json:"policies,omitempty"`
+}
+
+// ScalingPolicy limits the change in replicas of a variant over a period.
+type ScalingPolicy struct {
+	// Type is the unit of Value.
+	Type ScalingPolicyType `json:"type"`
+
+	// Value is the number or percentage of replicas that may be added or removed
+	// over the period.
+	// +kubebuilder:validation:Minimum=1
+	Value int32 `json:"value"`
+
+	// PeriodSeconds is the period the change is counted over.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1800
+	PeriodSeconds int32 `json:"periodSeconds"`
+}
+
+// AcceleratorPreference declares an acceptable accelerator type for a variant.
+type AcceleratorPreference struct {
+	// Accelerator is the name of the accelerator type (e.g., "H100").
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Accelerator string `json:"accelerator"`
+
+	// Profile describes the performance of the model on this accelerator type.
+	// +kubebuilder:validation:Required
+	Profile VariantProfile `json:"profile"`
+}
+
+// VariantProfile describes the performance of a model variant on an accelerator type.
+// Service parameters model the iteration time (msec) of a batch as
+// alpha + beta*computeTime + gamma*memoryAccessTime.
+type VariantProfile struct {
+	// AccCount is the number of accelerators per replica.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	AccCount int `json:"accCount,omitempty"`
+
+	// MaxBatchSize is the maximum batch size of a replica.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxBatchSize int `json:"maxBatchSize"`
+
+	// AtTokens is the average number of tokens per request assumed for MaxBatchSize.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	AtTokens int `json:"atTokens"`
+
+	// Alpha is the base of the iteration time.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +kubebuilder:validation:Required
+	Alpha string `json:"alpha"`
+
+	// Beta is the slope of the iteration time for compute time.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +kubebuilder:validation:Required
+	Beta string `json:"beta"`
+
+	// Gamma is the slope of the iteration time for memory access time.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +kubebuilder:validation:Required
+	Gamma string `json:"gamma"`
 }
 
 // VariantAutoscalingStatus represents the current status of autoscaling for a variant,
 // including the current allocation, desired optimized allocation, and actuation status.
 type VariantAutoscalingStatus struct {
 
+	// ScaleTargetRef is the workload spec.scaleTargetSelector resolved to, if set.
+	// +optional
+	ScaleTargetRef *autoscalingv1.CrossVersionObjectReference `json:"scaleTargetRef,omitempty"`
+
+	// ObservedGeneration is the generation of the spec most recently processed by the optimizer.
+	// The spec has changes the optimizer has not processed yet while it is lower than metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// DesiredOptimizedAlloc indicates the target optimized allocation based on autoscaling logic.
 	DesiredOptimizedAlloc OptimizedAlloc `json:"desiredOptimizedAlloc,omitempty"`
 
 	// Actuation provides details about the actuation process and its current status.
 	Actuation ActuationStatus `json:"actuation,omitempty"`
 
+	// LatencyBudget decomposes the time to first token of the variant into queueing and
+	// prefill time. Set when the model has a TTFT SLO in a service class and
+	// spec.acceleratorPreferences has the profile of the accelerator of the variant.
+	// +optional
+	LatencyBudget *LatencyBudget `json:"latencyBudget,omitempty"`
+
+	// Prediction is the performance the queueing model predicts for the target replicas of
+	// the variant, at the request rate its replicas serve. Set when the last decision changed
+	// replicas by more than one and spec.acceleratorPreferences has the profile of the
+	// accelerator of the variant.
+	// +optional
+	Prediction *Prediction `json:"prediction,omitempty"`
+
+	// RequestRate is the request rate of the model over its scale-to-zero retention period,
+	// as seen by idle detection. Set when scale-to-zero is enabled for the model.
+	// +optional
+	RequestRate *RequestRate `json:"requestRate,omitempty"`
+
+	// Overload tracks the episodes during which the model of the variant was overloaded:
+	// all its variants saturated and at the maxReplicas of their HorizontalPodAutoscaler,
+	// so that the gateway was signaled to shed load. Set when the OverloadSignal feature
+	// gate is enabled.
+	// +optional
+	Overload *OverloadStatus `json:"overload,omitempty"`
+
+	// Fallback is set while replicas of the variant are recommended on the fallback accelerator
+	// class of spec.fallback, because its accelerator is exhausted cluster-wide.
+	// +optional
+	Fallback *FallbackStatus `json:"fallback,omitempty"`
+
+	// ScaleLatency measures how fast the last scaling of the variant went through the
+	// autoscaling pipeline, from the metrics sample that called for it. Set once the
+	// optimizer decided a scaling.
+	// +optional
+	ScaleLatency *ScaleLatencyStatus `json:"scaleLatency,omitempty"`
+
+	// ScaleDownQuorum counts the recent optimization runs that proposed a scale-down of the
+	// variant, which is held until they reach the quorum. Set when WVA_SCALE_DOWN_QUORUM
+	// is above 1.
+	// +optional
+	ScaleDownQuorum *ScaleDownQuorumStatus `json:"scaleDownQuorum,omitempty"`
+
 	// Conditions represent the latest available observations of the VariantAutoscaling's state
 	// +kubebuilder:validation:Optional
 	// +patchMergeKey=type
@@ -55,6 +307,213 @@ type OptimizedAlloc struct {
 	// NumReplicas is the number of replicas for the optimized allocation.
 	// +kubebuilder:validation:Minimum=0
 	NumReplicas int `json:"numReplicas"`
+
+	// ConfigHash is the fingerprint of the effective configuration (thresholds, costs,
+	// optimizer settings) the optimized allocation was computed with, to trace it back to
+	// the configuration in effect, including after hot reloads.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// SLOViolation is the relative violation of the TTFT and ITL SLOs of the model predicted
+	// for the optimized allocation, the larger of TTFT/targetTTFT-1 and ITL/targetITL-1, e.g.
+	// "0.50" for latencies 50% above target. Set when status.prediction exceeds the SLOs.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	SLOViolation string `json:"sloViolation,omitempty"`
+
+	// ScaleUpGrant is set when the GPU limiter granted fewer additional replicas
+	// than the scale-up asked for.
+	// +optional
+	ScaleUpGrant *ScaleUpGrant `json:"scaleUpGrant,omitempty"`
+
+	// TrafficWeight is the recommended percentage of the traffic of the model to route to
+	// the variant, in proportion to the capacity of its replicas among the variants of the
+	// model. Set for models with several variants when the TrafficWeights feature gate is
+	// enabled, for the routing layer to split traffic as the variants are scaled.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	TrafficWeight *int `json:"trafficWeight,omitempty"`
+
+	// EngineOutputs records the decision of each engine of spec.engineComposition.
+	// +optional
+	// +listType=map
+	// +listMapKey=engine
+	EngineOutputs []EngineOutput `json:"engineOutputs,omitempty"`
+}
+
+// EngineOutput records the decision of a composed scaling engine.
+type EngineOutput struct {
+	// Engine is the name of the engine.
+	Engine string `json:"engine"`
+
+	// TargetReplicas is the number of replicas the engine recommended.
+	// Unset when the engine made no decision.
+	// +optional
+	TargetReplicas *int `json:"targetReplicas,omitempty"`
+
+	// Message explains the decision of the engine, or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScaleUpGrant records a scale-up that was only partially granted.
+type ScaleUpGrant struct {
+	// RequestedReplicas is the number of replicas the scale-up asked to add.
+	// +kubebuilder:validation:Minimum=0
+	RequestedReplicas int `json:"requestedReplicas"`
+
+	// GrantedReplicas is the number of replicas the GPU limiter allowed to add.
+	// +kubebuilder:validation:Minimum=0
+	GrantedReplicas int `json:"grantedReplicas"`
+}
+
+// LatencyComponent is a component of the time to first token.
+// +kubebuilder:validation:Enum=Queueing;Compute
+type LatencyComponent string
+
+const (
+	// LatencyComponentQueueing is the time requests wait before being scheduled on a replica.
+	// More replicas reduce it.
+	LatencyComponentQueueing LatencyComponent = "Queueing"
+	// LatencyComponentCompute is the prefill time of requests. A faster accelerator reduces it.
+	LatencyComponentCompute LatencyComponent = "Compute"
+)
+
+// LatencyBudget decomposes the time to first token (TTFT) of a variant against the TTFT SLO of its model.
+type LatencyBudget struct {
+	// TargetTTFT is the TTFT SLO of the service class of the model (msec).
+	TargetTTFT string `json:"targetTTFT"`
+
+	// ObservedTTFT is the average time to first token of the replicas (msec).
+	ObservedTTFT string `json:"observedTTFT"`
+
+	// QueueingTime is the estimated time requests wait in the queue of a replica (msec),
+	// from the queue depth and the service time given by the profile of the accelerator.
+	QueueingTime string `json:"queueingTime"`
+
+	// PrefillTime is the estimated prefill time of a request (msec), from the profile of the accelerator.
+	PrefillTime string `json:"prefillTime"`
+
+	// Dominant is the larger of the two components: Queueing calls for more replicas,
+	// Compute for a faster accelerator.
+	Dominant LatencyComponent `json:"dominant"`
+}
+
+// Prediction is the performance the queueing model predicts for a number of replicas of a variant.
+type Prediction struct {
+	// Replicas is the number of replicas the prediction is for.
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// ArrivalRate is the request rate of the variant (requests/sec), estimated from the
+	// running requests of its replicas and their service time.
+	ArrivalRate string `json:"arrivalRate"`
+
+	// TTFT is the predicted average time to first token (msec). Unset when Overloaded.
+	// +optional
+	TTFT string `json:"ttft,omitempty"`
+
+	// ITL is the predicted average inter-token latency (msec). Unset when Overloaded.
+	// +optional
+	ITL string `json:"itl,omitempty"`
+
+	// Utilization is the predicted utilization of a replica, from 0 to 1.
+	Utilization string `json:"utilization"`
+
+	// Overloaded is set when the replicas are predicted not to keep up with the request rate.
+	// +optional
+	Overloaded bool `json:"overloaded,omitempty"`
+}
+
+// RequestRate is the request rate of a model as seen by idle detection. All rates are in requests/min.
+type RequestRate struct {
+	// Observed is the average request rate of the model over the retention period.
+	Observed string `json:"observed"`
+
+	// NoiseFloor is the request rate at or below which the model is considered idle.
+	NoiseFloor string `json:"noiseFloor"`
+
+	// Filtered is the request rate idle detection uses: 0 when Observed is at or below
+	// NoiseFloor. The model scales to zero when it is 0.
+	Filtered string `json:"filtered"`
+}
+
+// OverloadStatus records the load shedding episodes of the model of a variant.
+type OverloadStatus struct {
+	// ShedEvents is the number of times the model was signaled overloaded.
+	// +kubebuilder:validation:Minimum=0
+	ShedEvents int32 `json:"shedEvents"`
+
+	// LastShedTime is when the model was last signaled overloaded.
+	LastShedTime metav1.Time `json:"lastShedTime"`
+}
+
+// FallbackStatus records the replicas recommended on the fallback accelerator class of a variant.
+type FallbackStatus struct {
+	// Accelerator is the accelerator type of the fallback class.
+	Accelerator string `json:"accelerator"`
+
+	// NumReplicas is the number of replicas recommended on the fallback class.
+	// +kubebuilder:validation:Minimum=0
+	NumReplicas int32 `json:"numReplicas"`
+
+	// Since is when replicas were first recommended on the fallback class.
+	Since metav1.Time `json:"since"`
+}
+
+// ScaleDirection is the direction of a scaling.
+// +kubebuilder:validation:Enum=Up;Down
+type ScaleDirection string
+
+const (
+	// ScaleDirectionUp adds replicas.
+	ScaleDirectionUp ScaleDirection = "Up"
+	// ScaleDirectionDown removes replicas.
+	ScaleDirectionDown ScaleDirection = "Down"
+)
+
+// ScaleLatencyStatus records the latencies of the last scaling of a variant.
+type ScaleLatencyStatus struct {
+	// Direction is the direction of the scaling.
+	Direction ScaleDirection `json:"direction"`
+
+	// FromReplicas is the number of replicas of the Deployment when the scaling was decided.
+	// +kubebuilder:validation:Minimum=0
+	FromReplicas int32 `json:"fromReplicas"`
+
+	// TargetReplicas is the number of replicas the scaling was decided to.
+	// +kubebuilder:validation:Minimum=0
+	TargetReplicas int32 `json:"targetReplicas"`
+
+	// SignalTime is when the metrics sample that crossed the scaling thresholds was collected.
+	SignalTime metav1.Time `json:"signalTime"`
+
+	// DesiredMetricLatency is the time from SignalTime until the desired replicas metric
+	// was updated for the HorizontalPodAutoscaler.
+	DesiredMetricLatency metav1.Duration `json:"desiredMetricLatency"`
+
+	// ReplicasLatency is the time from SignalTime until the replicas of the Deployment
+	// changed in the direction of the scaling. Unset until they change.
+	// +optional
+	ReplicasLatency *metav1.Duration `json:"replicasLatency,omitempty"`
+}
+
+// ScaleDownQuorumStatus records how many of the recent optimization runs proposed a
+// scale-down of a variant.
+type ScaleDownQuorumStatus struct {
+	// Approvals is the number of runs among the last Window that proposed a scale-down
+	// since the last one was applied.
+	// +kubebuilder:validation:Minimum=0
+	Approvals int32 `json:"approvals"`
+
+	// Window is the number of recent runs counted.
+	// +kubebuilder:validation:Minimum=0
+	Window int32 `json:"window"`
+
+	// Required is the number of approvals a scale-down needs to be applied.
+	// +kubebuilder:validation:Minimum=0
+	Required int32 `json:"required"`
 }
 
 // ActuationStatus provides details about the actuation process and its current status.
@@ -65,6 +524,7 @@ type ActuationStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.minReplicas,statuspath=.status.desiredOptimizedAlloc.numReplicas
 // +kubebuilder:resource:shortName=va
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=".spec.scaleTargetRef.name"
 // +kubebuilder:printcolumn:name="Model",type=string,JSONPath=".spec.modelID"
@@ -107,6 +567,37 @@ const (
 	TypeMetricsAvailable = "MetricsAvailable"
 	// TypeOptimizationReady indicates whether the optimization engine can run successfully
 	TypeOptimizationReady = "OptimizationReady"
+	// TypeCapacityCapped indicates whether the recommended replicas were capped at the
+	// number of replicas the cluster could place on the variant's accelerator type
+	TypeCapacityCapped = "CapacityCapped"
+	// TypeScaleUpLimited indicates whether the GPU limiter granted fewer replicas than recommended
+	TypeScaleUpLimited = "ScaleUpLimited"
+	// TypeScaleUpIneffective indicates whether a scale-up failed to reduce the variant's saturation
+	TypeScaleUpIneffective = "ScaleUpIneffective"
+	// TypeTargetUnschedulable indicates whether pods of the scale target are Pending for lack of GPUs
+	TypeTargetUnschedulable = "TargetUnschedulable"
+	// TypePDBConflict indicates whether a scale-down was clamped to keep the scale target's PodDisruptionBudget satisfiable
+	TypePDBConflict = "PDBConflict"
+	// TypeBoundsConflict indicates whether the bounds of the scale target's HorizontalPodAutoscaler clamp the recommendation
+	TypeBoundsConflict = "BoundsConflict"
+	// TypeSpecOutOfDate indicates whether the spec has changes the optimizer has not processed yet
+	TypeSpecOutOfDate = "SpecOutOfDate"
+	// TypeWarmingUp indicates whether the variant is in its warm-up period after creation,
+	// during which its target is held at the current replicas
+	TypeWarmingUp = "WarmingUp"
+	// TypeModelOverloaded indicates whether all the variants of the model are saturated and at
+	// their maxReplicas, so that the gateway is signaled to shed load
+	TypeModelOverloaded = "ModelOverloaded"
+	// TypeAcceleratorFallback indicates whether replicas of the variant are recommended on its
+	// fallback accelerator class because its accelerator is exhausted
+	TypeAcceleratorFallback = "AcceleratorFallback"
+	// TypePolicyCapped indicates whether the recommended replicas were reduced to keep the
+	// total replicas of the model within its replica limit, e.g. from licensing
+	TypePolicyCapped = "PolicyCapped"
+	// TypeCapacityConstrained indicates whether the GPU limiter reduced the target below the
+	// recommendation because the cluster could not provide the GPUs, as opposed to the
+	// recommendation not asking for more replicas
+	TypeCapacityConstrained = "CapacityConstrained"
 )
 
 // Condition Reasons for MetricsAvailable
@@ -133,24 +624,125 @@ const (
 	ReasonInvalidConfiguration = "InvalidConfiguration"
 	// ReasonSkippedProcessing indicates VA was skipped during processing
 	ReasonSkippedProcessing = "SkippedProcessing"
+	// ReasonSaturationSafetyOverride indicates the saturation analysis overrode the model-based recommendation
+	ReasonSaturationSafetyOverride = "SaturationSafetyOverride"
+	// ReasonSaturationOnlyMode indicates the recommendation is based on the saturation analysis only
+	ReasonSaturationOnlyMode = "SaturationOnlyMode"
+	// ReasonScaleFromZeroMode indicates the recommendation scales the variant up from zero replicas
+	ReasonScaleFromZeroMode = "ScaleFromZeroMode"
 
 	// ReasonTargetFound indicates the scale target was successfully resolved
 	ReasonTargetFound = "TargetFound"
 	// ReasonTargetNotFound indicates the scale target could not be found
 	ReasonTargetNotFound = "TargetNotFound"
+	// ReasonTargetAmbiguous indicates the scale target selector matches more than one workload
+	ReasonTargetAmbiguous = "TargetAmbiguous"
+)
+
+// Condition Reasons for CapacityCapped
+const (
+	// ReasonCapacityCeilingApplied indicates the recommendation exceeded the cluster capacity
+	// of the accelerator type and was capped
+	ReasonCapacityCeilingApplied = "CapacityCeilingApplied"
+	// ReasonWithinCapacity indicates the recommendation fits the cluster capacity of the accelerator type
+	ReasonWithinCapacity = "WithinCapacity"
 )
 
+// Condition Reasons for CapacityConstrained
+const (
+	// ReasonInventoryShortage indicates the accelerator type did not have enough free GPUs in the cluster
+	ReasonInventoryShortage = "InventoryShortage"
+	// ReasonQuota indicates the GPUs of the accelerator type beyond the tenant's fair share went to other tenants
+	ReasonQuota = "Quota"
+	// ReasonReservation indicates free GPUs of the accelerator type were held by other controller
+	// instances sharing the pool
+	ReasonReservation = "Reservation"
+	// ReasonCapacityAvailable indicates the cluster provided the GPUs for the recommendation
+	ReasonCapacityAvailable = "CapacityAvailable"
+)
+
+// Condition Reasons for ScaleUpLimited
+const (
+	// ReasonInsufficientCapacity indicates the accelerator type did not have enough free GPUs
+	ReasonInsufficientCapacity = "InsufficientCapacity"
+	// ReasonTenantQuota indicates the GPUs went to other tenants under fair sharing
+	ReasonTenantQuota = "TenantQuota"
+	// ReasonPriorityPreemption indicates the GPUs went to variants with higher limiter priority
+	ReasonPriorityPreemption = "PriorityPreemption"
+	// ReasonNotLimited indicates the limiter granted the recommended replicas
+	ReasonNotLimited = "NotLimited"
+	// ReasonNoSaturationImprovement indicates added replicas did not reduce saturation
+	ReasonNoSaturationImprovement = "NoSaturationImprovement"
+	// ReasonScaleUpRolledBack indicates an ineffective scale-up was reverted
+	ReasonScaleUpRolledBack = "ScaleUpRolledBack"
+	// ReasonScaleUpEffective indicates scale-ups are reducing saturation again
+	ReasonScaleUpEffective = "ScaleUpEffective"
+	// ReasonInsufficientGPU indicates pods of the scale target cannot be scheduled for lack of GPUs
+	ReasonInsufficientGPU = "InsufficientGPU"
+	// ReasonSchedulable indicates no pods of the scale target are unschedulable for lack of GPUs
+	ReasonSchedulable = "Schedulable"
+	// ReasonPDBMinAvailable indicates a scale-down was clamped at the minAvailable of a PodDisruptionBudget
+	ReasonPDBMinAvailable = "PDBMinAvailable"
+	// ReasonPDBSatisfiable indicates the recommendation keeps the PodDisruptionBudgets of the scale target satisfiable
+	ReasonPDBSatisfiable = "PDBSatisfiable"
+	// ReasonOutsideHPABounds indicates the recommendation is outside the minReplicas/maxReplicas of the HorizontalPodAutoscaler
+	ReasonOutsideHPABounds = "OutsideHPABounds"
+	// ReasonWithinHPABounds indicates the recommendation is within the bounds of the HorizontalPodAutoscaler
+	ReasonWithinHPABounds = "WithinHPABounds"
+	// ReasonSpecPending indicates the latest generation of the spec has not been processed by the optimizer yet
+	ReasonSpecPending = "SpecPending"
+	// ReasonSpecProcessed indicates the optimizer has processed the latest generation of the spec
+	ReasonSpecProcessed = "SpecProcessed"
+	// ReasonWarmUpPeriod indicates the variant was created less than the warm-up period ago
+	ReasonWarmUpPeriod = "WarmUpPeriod"
+	// ReasonWarmedUp indicates the warm-up period of the variant is over
+	ReasonWarmedUp = "WarmedUp"
+	// ReasonAllVariantsAtMaxReplicas indicates all the variants of the model are saturated and at their maxReplicas
+	ReasonAllVariantsAtMaxReplicas = "AllVariantsAtMaxReplicas"
+	// ReasonScalingHeadroom indicates a variant of the model can still scale up
+	ReasonScalingHeadroom = "ScalingHeadroom"
+	// ReasonPreferredAcceleratorExhausted indicates the accelerator of the variant is exhausted cluster-wide
+	ReasonPreferredAcceleratorExhausted = "PreferredAcceleratorExhausted"
+	// ReasonPreferredAcceleratorAvailable indicates the accelerator of the variant can host its replicas
+	ReasonPreferredAcceleratorAvailable = "PreferredAcceleratorAvailable"
+	// ReasonModelReplicaLimitReached indicates the model reached its replica limit and the recommendation was reduced
+	ReasonModelReplicaLimitReached = "ModelReplicaLimitReached"
+	// ReasonWithinModelReplicaLimit indicates the recommendation keeps the model within its replica limit
+	ReasonWithinModelReplicaLimit = "WithinModelReplicaLimit"
+)
+
+// ScaleTargetReference returns the reference of the scale target resource: spec.scaleTargetRef,
+// or the resolution of spec.scaleTargetSelector recorded in the status. It is empty while
+// the selector is not resolved to exactly one workload.
+func (va *VariantAutoscaling) ScaleTargetReference() autoscalingv1.CrossVersionObjectReference {
+	if va.Spec.ScaleTargetSelector == nil {
+		return va.Spec.ScaleTargetRef
+	}
+	if va.Status.ScaleTargetRef == nil {
+		return autoscalingv1.CrossVersionObjectReference{}
+	}
+	return *va.Status.ScaleTargetRef
+}
+
 // GetScaleTargetAPI returns the API of the scale target resource.
 func (va *VariantAutoscaling) GetScaleTargetAPI() string {
-	return va.Spec.ScaleTargetRef.APIVersion
+	return va.ScaleTargetReference().APIVersion
+}
+
+// GetMinReplicas returns the minimum replicas of spec.minReplicas, or 0 if unset.
+func (va *VariantAutoscaling) GetMinReplicas() int {
+	if va.Spec.MinReplicas == nil {
+		return 0
+	}
+	return int(*va.Spec.MinReplicas)
 }
 
 // GetScaleTargetName returns the name of the scale target resource.
 func (va *VariantAutoscaling) GetScaleTargetName() string {
-	return va.Spec.ScaleTargetRef.Name
+	return va.ScaleTargetReference().Name
 }
 
 // GetScaleTargetKind returns the kind of the scale target resource.
 func (va *VariantAutoscaling) GetScaleTargetKind() string {
-	return va.Spec.ScaleTargetRef.Kind
+	return va.ScaleTargetReference().Kind
 }
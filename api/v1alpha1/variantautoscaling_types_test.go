@@ -218,3 +218,39 @@ func jsonContainsKey(b []byte, key string) bool {
 	_, ok := m[key]
 	return ok
 }
+
+func TestGetMinReplicas(t *testing.T) {
+	va := makeValidVA()
+	if got := va.GetMinReplicas(); got != 0 {
+		t.Errorf("GetMinReplicas() = %d, want 0 when unset", got)
+	}
+
+	minReplicas := int32(2)
+	va.Spec.MinReplicas = &minReplicas
+	cp := va.DeepCopy()
+	*va.Spec.MinReplicas = 3
+	if got := cp.GetMinReplicas(); got != 2 {
+		t.Errorf("GetMinReplicas() of copy = %d, want 2 independent of the original", got)
+	}
+}
+
+func TestScaleTargetReference(t *testing.T) {
+	va := makeValidVA()
+	if got := va.GetScaleTargetName(); got != "va-sample-deployment" {
+		t.Errorf("GetScaleTargetName() = %q, want the name of scaleTargetRef", got)
+	}
+
+	va.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{}
+	va.Spec.ScaleTargetSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "va-sample"}}
+	if got := va.GetScaleTargetName(); got != "" {
+		t.Errorf("GetScaleTargetName() = %q, want empty while the selector is not resolved", got)
+	}
+
+	va.Status.ScaleTargetRef = &autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "va-sample-renamed"}
+	if got := va.GetScaleTargetName(); got != "va-sample-renamed" {
+		t.Errorf("GetScaleTargetName() = %q, want the resolved name", got)
+	}
+	if got := va.GetScaleTargetKind(); got != "Deployment" {
+		t.Errorf("GetScaleTargetKind() = %q, want Deployment", got)
+	}
+}
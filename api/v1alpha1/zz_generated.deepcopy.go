@@ -21,10 +21,27 @@ limitations under the License.
 package v1alpha1
 
 import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorPreference) DeepCopyInto(out *AcceleratorPreference) {
+	*out = *in
+	out.Profile = in.Profile
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorPreference.
+func (in *AcceleratorPreference) DeepCopy() *AcceleratorPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActuationStatus) DeepCopyInto(out *ActuationStatus) {
 	*out = *in
@@ -40,10 +57,301 @@ func (in *ActuationStatus) DeepCopy() *ActuationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmulatedAccelerator) DeepCopyInto(out *EmulatedAccelerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmulatedAccelerator.
+func (in *EmulatedAccelerator) DeepCopy() *EmulatedAccelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(EmulatedAccelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmulatedNode) DeepCopyInto(out *EmulatedNode) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmulatedNode.
+func (in *EmulatedNode) DeepCopy() *EmulatedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(EmulatedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmulatedNode) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmulatedNodeList) DeepCopyInto(out *EmulatedNodeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmulatedNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmulatedNodeList.
+func (in *EmulatedNodeList) DeepCopy() *EmulatedNodeList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmulatedNodeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmulatedNodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmulatedNodeSpec) DeepCopyInto(out *EmulatedNodeSpec) {
+	*out = *in
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]EmulatedAccelerator, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmulatedNodeSpec.
+func (in *EmulatedNodeSpec) DeepCopy() *EmulatedNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmulatedNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EngineComposition) DeepCopyInto(out *EngineComposition) {
+	*out = *in
+	if in.Engines != nil {
+		in, out := &in.Engines, &out.Engines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineComposition.
+func (in *EngineComposition) DeepCopy() *EngineComposition {
+	if in == nil {
+		return nil
+	}
+	out := new(EngineComposition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EngineOutput) DeepCopyInto(out *EngineOutput) {
+	*out = *in
+	if in.TargetReplicas != nil {
+		in, out := &in.TargetReplicas, &out.TargetReplicas
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineOutput.
+func (in *EngineOutput) DeepCopy() *EngineOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(EngineOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackStatus) DeepCopyInto(out *FallbackStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackStatus.
+func (in *FallbackStatus) DeepCopy() *FallbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCapacitySummary) DeepCopyInto(out *ModelCapacitySummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCapacitySummary.
+func (in *ModelCapacitySummary) DeepCopy() *ModelCapacitySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCapacitySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCapacityReport) DeepCopyInto(out *NamespaceCapacityReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCapacityReport.
+func (in *NamespaceCapacityReport) DeepCopy() *NamespaceCapacityReport {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCapacityReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceCapacityReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCapacityReportList) DeepCopyInto(out *NamespaceCapacityReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceCapacityReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCapacityReportList.
+func (in *NamespaceCapacityReportList) DeepCopy() *NamespaceCapacityReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCapacityReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceCapacityReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCapacityReportSpec) DeepCopyInto(out *NamespaceCapacityReportSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCapacityReportSpec.
+func (in *NamespaceCapacityReportSpec) DeepCopy() *NamespaceCapacityReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCapacityReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCapacityReportStatus) DeepCopyInto(out *NamespaceCapacityReportStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]ModelCapacitySummary, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCapacityReportStatus.
+func (in *NamespaceCapacityReportStatus) DeepCopy() *NamespaceCapacityReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCapacityReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyBudget) DeepCopyInto(out *LatencyBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyBudget.
+func (in *LatencyBudget) DeepCopy() *LatencyBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptimizedAlloc) DeepCopyInto(out *OptimizedAlloc) {
 	*out = *in
 	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+	if in.ScaleUpGrant != nil {
+		in, out := &in.ScaleUpGrant, &out.ScaleUpGrant
+		*out = new(ScaleUpGrant)
+		**out = **in
+	}
+	if in.TrafficWeight != nil {
+		in, out := &in.TrafficWeight, &out.TrafficWeight
+		*out = new(int)
+		**out = **in
+	}
+	if in.EngineOutputs != nil {
+		in, out := &in.EngineOutputs, &out.EngineOutputs
+		*out = make([]EngineOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptimizedAlloc.
@@ -56,12 +364,180 @@ func (in *OptimizedAlloc) DeepCopy() *OptimizedAlloc {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverloadStatus) DeepCopyInto(out *OverloadStatus) {
+	*out = *in
+	in.LastShedTime.DeepCopyInto(&out.LastShedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverloadStatus.
+func (in *OverloadStatus) DeepCopy() *OverloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OverloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prediction) DeepCopyInto(out *Prediction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prediction.
+func (in *Prediction) DeepCopy() *Prediction {
+	if in == nil {
+		return nil
+	}
+	out := new(Prediction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestRate) DeepCopyInto(out *RequestRate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestRate.
+func (in *RequestRate) DeepCopy() *RequestRate {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownQuorumStatus) DeepCopyInto(out *ScaleDownQuorumStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownQuorumStatus.
+func (in *ScaleDownQuorumStatus) DeepCopy() *ScaleDownQuorumStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownQuorumStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleLatencyStatus) DeepCopyInto(out *ScaleLatencyStatus) {
+	*out = *in
+	in.SignalTime.DeepCopyInto(&out.SignalTime)
+	out.DesiredMetricLatency = in.DesiredMetricLatency
+	if in.ReplicasLatency != nil {
+		in, out := &in.ReplicasLatency, &out.ReplicasLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleLatencyStatus.
+func (in *ScaleLatencyStatus) DeepCopy() *ScaleLatencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleLatencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleUpGrant) DeepCopyInto(out *ScaleUpGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleUpGrant.
+func (in *ScaleUpGrant) DeepCopy() *ScaleUpGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleUpGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingBehavior) DeepCopyInto(out *ScalingBehavior) {
+	*out = *in
+	if in.ScaleUp != nil {
+		in, out := &in.ScaleUp, &out.ScaleUp
+		*out = new(ScalingRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(ScalingRules)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingBehavior.
+func (in *ScalingBehavior) DeepCopy() *ScalingBehavior {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingBehavior)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingPolicy) DeepCopyInto(out *ScalingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingPolicy.
+func (in *ScalingPolicy) DeepCopy() *ScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingRules) DeepCopyInto(out *ScalingRules) {
+	*out = *in
+	if in.StabilizationWindowSeconds != nil {
+		in, out := &in.StabilizationWindowSeconds, &out.StabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SelectPolicy != nil {
+		in, out := &in.SelectPolicy, &out.SelectPolicy
+		*out = new(ScalingPolicySelect)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ScalingPolicy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingRules.
+func (in *ScalingRules) DeepCopy() *ScalingRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VariantAutoscaling) DeepCopyInto(out *VariantAutoscaling) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -119,6 +595,36 @@ func (in *VariantAutoscalingList) DeepCopyObject() runtime.Object {
 func (in *VariantAutoscalingSpec) DeepCopyInto(out *VariantAutoscalingSpec) {
 	*out = *in
 	out.ScaleTargetRef = in.ScaleTargetRef
+	if in.ScaleTargetSelector != nil {
+		in, out := &in.ScaleTargetSelector, &out.ScaleTargetSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AcceleratorPreferences != nil {
+		in, out := &in.AcceleratorPreferences, &out.AcceleratorPreferences
+		*out = make([]AcceleratorPreference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(AcceleratorPreference)
+		**out = **in
+	}
+	if in.EngineComposition != nil {
+		in, out := &in.EngineComposition, &out.EngineComposition
+		*out = new(EngineComposition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(ScalingBehavior)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantAutoscalingSpec.
@@ -134,8 +640,48 @@ func (in *VariantAutoscalingSpec) DeepCopy() *VariantAutoscalingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VariantAutoscalingStatus) DeepCopyInto(out *VariantAutoscalingStatus) {
 	*out = *in
+	if in.ScaleTargetRef != nil {
+		in, out := &in.ScaleTargetRef, &out.ScaleTargetRef
+		*out = new(autoscalingv1.CrossVersionObjectReference)
+		**out = **in
+	}
 	in.DesiredOptimizedAlloc.DeepCopyInto(&out.DesiredOptimizedAlloc)
 	out.Actuation = in.Actuation
+	if in.LatencyBudget != nil {
+		in, out := &in.LatencyBudget, &out.LatencyBudget
+		*out = new(LatencyBudget)
+		**out = **in
+	}
+	if in.Prediction != nil {
+		in, out := &in.Prediction, &out.Prediction
+		*out = new(Prediction)
+		**out = **in
+	}
+	if in.RequestRate != nil {
+		in, out := &in.RequestRate, &out.RequestRate
+		*out = new(RequestRate)
+		**out = **in
+	}
+	if in.Overload != nil {
+		in, out := &in.Overload, &out.Overload
+		*out = new(OverloadStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(FallbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleLatency != nil {
+		in, out := &in.ScaleLatency, &out.ScaleLatency
+		*out = new(ScaleLatencyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDownQuorum != nil {
+		in, out := &in.ScaleDownQuorum, &out.ScaleDownQuorum
+		*out = new(ScaleDownQuorumStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -154,3 +700,18 @@ func (in *VariantAutoscalingStatus) DeepCopy() *VariantAutoscalingStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariantProfile) DeepCopyInto(out *VariantProfile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantProfile.
+func (in *VariantProfile) DeepCopy() *VariantProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VariantProfile)
+	in.DeepCopyInto(out)
+	return out
+}
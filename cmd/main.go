@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -34,9 +35,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -45,18 +48,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/admin"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/alerting"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source/prometheus"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/controller"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/coordination"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/dryrun"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/heatmap"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/overload"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/scalefromzero"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/showback"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/snapshot"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/evaluation"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/indexers"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/prober"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/recommendations"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/simulator"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus/client_golang/api"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
@@ -81,12 +98,28 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == evaluation.Command {
+		if err := evaluation.Run(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Command-line flags
 
 	loggerVerbosity := flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
+	flag.String("module-verbosity", "",
+		"Comma-separated log level verbosity of modules overriding -v, e.g. solver=5,collector=1. "+
+			"Modules: "+strings.Join(logging.Modules, ", ")+". Can be changed at runtime with the logging ConfigMap.")
 
 	configFilePath := flag.String("config-file", "", "Path to the YAML configuration file. "+
 		"When set, the main configuration is read from this file instead of a Kubernetes ConfigMap.")
+	scalingConfigBundlePath := flag.String("scaling-config-bundle", "", "Path to a YAML scaling configuration bundle. "+
+		"When set, its sections replace the data of the scaling ConfigMaps of the controller at startup.")
+	exportScalingConfigBundlePath := flag.String("export-scaling-config-bundle", "", "Path to write the scaling "+
+		"configuration bundle of the scaling ConfigMaps of the controller to. When set, the controller exits after writing it.")
 
 	flag.String("metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -107,6 +140,14 @@ func main() {
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.String("watch-namespace", "",
 		"Namespace to watch for updates. If unspecified, all namespaces are watched.")
+	flag.Bool("simulator", false,
+		"If set, replaces Prometheus with synthetic metrics and the external autoscaler with a virtual cluster. "+
+			"Only use against an API server without workload controllers, e.g. with --simulator-apiserver.")
+	flag.String("simulator-scenario", "",
+		"Path to the YAML traffic scenario of simulator mode. If unspecified, a built-in ramp scenario is used.")
+	simulatorAPIServer := flag.Bool("simulator-apiserver", false,
+		"In simulator mode, start a local API server from the envtest binaries in KUBEBUILDER_ASSETS, "+
+			"with the CRDs of config/crd/bases installed, instead of connecting to a cluster.")
 
 	// Leader election timeout configuration flags
 	// These can be overridden in manager.yaml to tune for different environments
@@ -123,6 +164,10 @@ func main() {
 	flag.Duration("rest-client-timeout", 60*time.Second,
 		"The timeout for REST API calls to the Kubernetes API server. "+
 			"Increased from default ~30s to 60s for better resilience against network latency.")
+	flag.String("feature-gates", "", "Comma-separated list of Feature=true|false pairs enabling or disabling "+
+		"experimental features, e.g. LimitedMode=true,StateSnapshot=true.")
+	// Prometheus metrics cache settings and overrides of the default saturation scaling config
+	config.RegisterOverrideFlags(flag.CommandLine)
 
 	opts := ctrlzap.Options{
 		Development: true,
@@ -139,9 +184,6 @@ func main() {
 	setupLog := ctrl.Log.WithName("setup")
 	setupLog.Info("Logger initialized")
 
-	// Get REST config early (needed for config loading)
-	restConfig := ctrl.GetConfigOrDie()
-
 	// Load unified configuration (fail-fast if invalid)
 	// Viper resolves precedence: flags > env > config file > defaults
 	// For more information see:
@@ -153,6 +195,36 @@ func main() {
 	}
 	setupLog.Info("Configuration loaded successfully")
 
+	// Load the scaling configuration bundle before connecting (fail-fast if invalid)
+	var scalingConfigBundle *infernoConfig.ScalingConfigBundle
+	if *scalingConfigBundlePath != "" {
+		scalingConfigBundle, err = infernoConfig.LoadScalingConfigBundle(*scalingConfigBundlePath)
+		if err != nil {
+			setupLog.Error(err, "failed to load scaling config bundle", "path", *scalingConfigBundlePath)
+			os.Exit(1)
+		}
+	}
+	logging.SetModuleVerbosity(cfg.ModuleVerbosity())
+	logging.SetRedaction(cfg.LogRedaction())
+
+	// Get REST config, from a local API server in simulator mode if requested
+	var restConfig *rest.Config
+	if cfg.Simulator() && *simulatorAPIServer {
+		testEnv := &envtest.Environment{
+			CRDDirectoryPaths:     []string{filepath.Join("config", "crd", "bases")},
+			ErrorIfCRDPathMissing: true,
+		}
+		restConfig, err = testEnv.Start()
+		if err != nil {
+			setupLog.Error(err, "failed to start the local API server of simulator mode")
+			os.Exit(1)
+		}
+		defer testEnv.Stop() // nolint:errcheck
+		setupLog.Info("Local API server started for simulator mode", "host", restConfig.Host)
+	} else {
+		restConfig = ctrl.GetConfigOrDie()
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -204,6 +276,9 @@ func main() {
 	// More info:
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.20.4/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
+	// Cost and replica-hours of the variants, accounted by the saturation engine
+	showbackTracker := showback.NewTracker(cfg.ShowbackLabelKeys(), cfg.ShowbackPeriod())
+
 	metricsServerOptions := metricsserver.Options{
 		BindAddress:   cfg.MetricsAddr(),
 		SecureServing: cfg.SecureMetrics(),
@@ -216,6 +291,17 @@ func main() {
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
 		// https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.20.4/pkg/metrics/filters#WithAuthenticationAndAuthorization
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+
+		// Effective static configuration and the source of each value (flag, env, file or default),
+		// and the showback report of cost and replica-hours. They expose the configuration and
+		// costs of the controller, so they are only served behind the authn/authz of secure metrics.
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{
+			"/debug/config":        config.EffectiveConfigHandler(cfg),
+			"/debug/feature-gates": config.FeatureGatesHandler(cfg),
+			"/showback":            showback.Handler(showbackTracker),
+		}
+	} else {
+		setupLog.Info("Effective configuration, feature gates and showback endpoints not served: they require secure metrics")
 	}
 
 	// If the certificate is not specified, controller-runtime will automatically
@@ -310,12 +396,28 @@ func main() {
 	}
 	setupLog.Info("Indexes setup completed")
 
+	// Register custom metrics with the controller-runtime Prometheus registry
+	// This makes the metrics available for scraping by Prometheus and direct endpoint access.
+	// Registered before the runnables are set up, which read the controller instance.
+	setupLog.Info("Registering custom metrics with Prometheus registry")
+	if err := metrics.InitMetrics(crmetrics.Registry); err != nil {
+		setupLog.Error(err, "failed to initialize metrics")
+		os.Exit(1)
+	}
+
 	// Initialize metrics
 	setupLog.Info("Creating metrics emitter instance")
 	// Force initialization of metrics by creating a metrics emitter
 	_ = metrics.NewMetricsEmitter()
 	setupLog.Info("Metrics emitter created successfully")
 
+	// Desired replicas, saturation and conditions of all VariantAutoscalings, for capacity dashboards
+	if err := mgr.AddMetricsServerExtraHandler(recommendations.Path,
+		recommendations.Handler(mgr.GetClient(), metrics.GetControllerInstance())); err != nil {
+		setupLog.Error(err, "unable to add recommendations handler to metrics server")
+		os.Exit(1)
+	}
+
 	// Create ConfigMap reconciler for configuration management.
 	// Bootstrap uses the temporary uncached client so ConfigMap-backed settings
 	// are loaded before any manager runnables start.
@@ -329,77 +431,239 @@ func main() {
 
 	ctx := context.Background()
 	ctx = ctrl.LoggerInto(ctx, setupLog)
+
+	if *exportScalingConfigBundlePath != "" {
+		bundle, err := controller.ExportScalingConfigBundle(ctx, mgr.GetAPIReader())
+		if err != nil {
+			setupLog.Error(err, "failed to export scaling config bundle")
+			os.Exit(1)
+		}
+		data, err := bundle.Marshal()
+		if err == nil {
+			err = os.WriteFile(*exportScalingConfigBundlePath, data, 0o644)
+		}
+		if err != nil {
+			setupLog.Error(err, "failed to write scaling config bundle", "path", *exportScalingConfigBundlePath)
+			os.Exit(1)
+		}
+		setupLog.Info("Exported scaling config bundle", "path", *exportScalingConfigBundlePath)
+		os.Exit(0)
+	}
+
+	// Migrate the ConfigMaps with legacy names, so that the bootstrap below loads them
+	if err = controller.MigrateLegacyConfigMaps(ctx, mgr.GetAPIReader(), mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to migrate legacy ConfigMaps")
+		os.Exit(1)
+	}
+
+	// Seed the scaling ConfigMaps from the bundle, so that the bootstrap below loads them
+	if scalingConfigBundle != nil {
+		if err = controller.ApplyScalingConfigBundle(ctx, mgr.GetAPIReader(), mgr.GetClient(), scalingConfigBundle); err != nil {
+			setupLog.Error(err, "unable to apply scaling config bundle")
+			os.Exit(1)
+		}
+	}
+
 	if err = configMapReconciler.BootstrapInitialConfigMaps(ctx); err != nil {
 		setupLog.Error(err, "unable to bootstrap initial ConfigMaps")
 		os.Exit(1)
 	}
 	setupLog.Info("Initial ConfigMap bootstrap completed")
 
-	// Use Prometheus configuration from unified Config (already validated during Load())
-	if cfg.PrometheusBaseURL() == "" {
-		setupLog.Error(nil, "no Prometheus configuration found - this should not happen after validation")
-		os.Exit(1)
-	}
+	// In simulator mode, metrics are generated from a traffic scenario for the virtual
+	// replicas of a simulated cluster, instead of being queried from Prometheus
+	var promAPI promv1.API
+	var simScenario *simulator.Scenario
+	var simCluster *simulator.Cluster
+	if cfg.Simulator() {
+		simScenario, err = simulator.LoadScenario(cfg.SimulatorScenario())
+		if err != nil {
+			setupLog.Error(err, "failed to load simulator scenario")
+			os.Exit(1)
+		}
+		simCluster = simulator.NewCluster(mgr.GetClient(), simScenario)
+		if err := mgr.Add(simCluster); err != nil {
+			setupLog.Error(err, "unable to add simulated cluster to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Simulator mode enabled", "scenario", cfg.SimulatorScenario())
+	} else {
+		// Use Prometheus configuration from unified Config (already validated during Load())
+		if cfg.PrometheusBaseURL() == "" {
+			setupLog.Error(nil, "no Prometheus configuration found - this should not happen after validation")
+			os.Exit(1)
+		}
+
+		// Always validate TLS configuration since HTTPS is required
+		if err := utils.ValidateTLSConfig(cfg); err != nil {
+			setupLog.Error(err, "TLS configuration validation failed - HTTPS is required")
+			os.Exit(1)
+		}
 
-	// Always validate TLS configuration since HTTPS is required
-	if err := utils.ValidateTLSConfig(cfg); err != nil {
-		setupLog.Error(err, "TLS configuration validation failed - HTTPS is required")
+		setupLog.Info("Initializing Prometheus client",
+			"address", cfg.PrometheusBaseURL(),
+			"tlsEnabled", true,
+		)
+
+		// Create Prometheus client with TLS support
+		promClientConfig, err := utils.CreatePrometheusClientConfig(cfg)
+		if err != nil {
+			setupLog.Error(err, "failed to create prometheus client config")
+			os.Exit(1)
+		}
+
+		promClient, err := api.NewClient(*promClientConfig)
+		if err != nil {
+			setupLog.Error(err, "failed to create prometheus client")
+			os.Exit(1)
+		}
+
+		promAPI = promv1.NewAPI(promClient)
+
+		// Validate that the API is working by testing a simple query with retry logic
+		if err := utils.ValidatePrometheusAPI(context.Background(), promAPI); err != nil {
+			setupLog.Error(err, "CRITICAL: Failed to connect to Prometheus - WVA requires Prometheus connectivity for autoscaling decisions")
+			os.Exit(1)
+		}
+		setupLog.Info("Prometheus client and API wrapper initialized and validated successfully")
+	}
+
+	// Create the optimization engine up front, so that the replicas that are not the leader
+	// can keep its metrics caches warm for a fast failover
+	sourceRegistry := source.NewSourceRegistry()
+	setupLog.Info("Initializing metrics source registry")
+
+	// Prometheus cache configuration is loaded via unified Config during startup.
+	// The cache config is available in cfg.Dynamic.PrometheusCache and is updated
+	// automatically when the ConfigMap changes. We use the default config here
+	// as the unified Config system handles cache configuration loading.
+
+	// Register PrometheusSource with default config, or the synthetic source of
+	// simulator mode in its place
+	var promSource source.MetricsSource
+	if cfg.Simulator() {
+		promSource = simulator.NewSource(ctx, simScenario, simCluster)
+	} else {
+		promSourceConfig := prometheus.DefaultPrometheusSourceConfig()
+		promSourceConfig.AggregationPushdown = cfg.PrometheusAggregationPushdown()
+		// Query the metrics of namespaces with their own Prometheus, set in the Prometheus
+		// tenants ConfigMap, from it. Token Secrets are read uncached.
+		promSource = prometheus.NewTenantSource(ctx,
+			prometheus.NewPrometheusSource(ctx, promAPI, promSourceConfig),
+			cfg.PrometheusTenant,
+			func(tenant config.PrometheusTenantEntry) (promv1.API, error) {
+				tenantClientConfig, err := utils.CreateTenantPrometheusClientConfig(cfg, tenant, mgr.GetAPIReader())
+				if err != nil {
+					return nil, err
+				}
+				tenantClient, err := api.NewClient(*tenantClientConfig)
+				if err != nil {
+					return nil, err
+				}
+				return promv1.NewAPI(tenantClient), nil
+			})
+	}
+
+	// Register in global source registry
+	if err := sourceRegistry.Register("prometheus", promSource); err != nil {
+		setupLog.Error(err, "failed to register prometheus source in source registry")
 		os.Exit(1)
 	}
 
-	setupLog.Info("Initializing Prometheus client",
-		"address", cfg.PrometheusBaseURL(),
-		"tlsEnabled", true,
+	engine := saturation.NewEngine(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("workload-variant-autoscaler-saturation-engine"),
+		sourceRegistry,
+		cfg, // Pass unified Config to engine
 	)
-
-	// Create Prometheus client with TLS support
-	promClientConfig, err := utils.CreatePrometheusClientConfig(cfg)
-	if err != nil {
-		setupLog.Error(err, "failed to create prometheus client config")
-		os.Exit(1)
+	if cfg.StateSnapshotEnabled() {
+		// Read uncached: the manager cache may be restricted to the watched namespace
+		engine.SetSnapshotStore(snapshot.NewStore(mgr.GetAPIReader(), mgr.GetClient(),
+			config.SystemNamespace(), config.StateSnapshotConfigMapName()))
+	}
+	switch cfg.GPUPoolCoordination() {
+	case "quota":
+		engine.SetPoolCoordinator(coordination.NewQuotaSplit(cfg.GPUPoolQuota()))
+	case "lease":
+		leaseNamespace := cfg.GPUPoolLeaseNamespace()
+		if leaseNamespace == "" {
+			leaseNamespace = config.SystemNamespace()
+		}
+		engine.SetPoolCoordinator(coordination.NewGPUPoolLease(mgr.GetAPIReader(), mgr.GetClient(),
+			leaseNamespace, metrics.GetControllerInstance(), cfg.GPUPoolLeaseDuration()))
 	}
 
-	promClient, err := api.NewClient(*promClientConfig)
-	if err != nil {
-		setupLog.Error(err, "failed to create prometheus client")
-		os.Exit(1)
+	var showbackPublisher *showback.Publisher
+	if cfg.ShowbackConfigMapEnabled() {
+		showbackPublisher = showback.NewPublisher(mgr.GetAPIReader(), mgr.GetClient())
 	}
+	engine.SetShowback(showbackTracker, showbackPublisher)
 
-	promAPI := promv1.NewAPI(promClient)
+	// Replay candidate saturation scaling ConfigMaps against the metrics of recent runs
+	if cfg.DryRunRetention() > 0 {
+		history := dryrun.NewHistory(cfg.DryRunRetention())
+		engine.SetDryRunHistory(history)
+		if err := mgr.AddMetricsServerExtraHandler(dryrun.Path, dryrun.Handler(history, cfg)); err != nil {
+			setupLog.Error(err, "unable to add saturation config validation handler to metrics server")
+			os.Exit(1)
+		}
+	}
 
-	// Validate that the API is working by testing a simple query with retry logic
-	if err := utils.ValidatePrometheusAPI(context.Background(), promAPI); err != nil {
-		setupLog.Error(err, "CRITICAL: Failed to connect to Prometheus - WVA requires Prometheus connectivity for autoscaling decisions")
-		os.Exit(1)
+	// Saturation of each replica over time, for Grafana heatmaps of skewed routing
+	if cfg.SaturationHeatmapWindow() > 0 {
+		matrix := heatmap.NewMatrix(cfg.SaturationHeatmapWindow())
+		engine.SetSaturationHeatmap(matrix)
+		if err := mgr.AddMetricsServerExtraHandler(heatmap.Path, heatmap.Handler(matrix)); err != nil {
+			setupLog.Error(err, "unable to add saturation heatmap handler to metrics server")
+			os.Exit(1)
+		}
 	}
-	setupLog.Info("Prometheus client and API wrapper initialized and validated successfully")
 
-	// Register optimization engine loops with the manager. Only start when leader.
-	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
-		sourceRegistry := source.NewSourceRegistry()
-		setupLog.Info("Initializing metrics source registry")
+	// Trigger reconciles, flush the metrics caches, toggle the dry-run of variants and fetch the
+	// effective configuration at runtime. Changes the behavior of the controller, so it is only
+	// served behind the authn/authz of secure metrics.
+	if cfg.FeatureEnabled(config.AdminAPI) {
+		if cfg.SecureMetrics() {
+			handler := admin.Handler(mgr.GetAPIReader(), mgr.GetClient(), admin.NewAuthorizer(mgr.GetClient()),
+				mgr.Elected(), common.DecisionTrigger, sourceRegistry.FlushCaches, config.EffectiveConfigHandler(cfg))
+			if err := mgr.AddMetricsServerExtraHandler(admin.Path, handler); err != nil {
+				setupLog.Error(err, "unable to add admin API handler to metrics server")
+				os.Exit(1)
+			}
+		} else {
+			setupLog.Info("Admin API not served: it requires secure metrics")
+		}
+	}
 
-		// Prometheus cache configuration is loaded via unified Config during startup.
-		// The cache config is available in cfg.Dynamic.PrometheusCache and is updated
-		// automatically when the ConfigMap changes. We use the default config here
-		// as the unified Config system handles cache configuration loading.
+	// Keep the metrics caches warm until elected leader. Read-only: runs on every replica.
+	if cfg.StandbyWarmup() && cfg.EnableLeaderElection() {
+		if err := mgr.Add(saturation.NewStandbyWarmer(engine, mgr.Elected())); err != nil {
+			setupLog.Error(err, "unable to add standby cache warmer to manager")
+			os.Exit(1)
+		}
+	}
 
-		// Register PrometheusSource with default config
-		promSource := prometheus.NewPrometheusSource(ctx, promAPI, prometheus.DefaultPrometheusSourceConfig())
+	// Keep the PrometheusRule of the alerts in sync. Only runs when leader.
+	if cfg.FeatureEnabled(config.PrometheusRuleAlerts) {
+		if err := mgr.Add(alerting.NewManager(mgr.GetClient(), mgr.GetAPIReader(), cfg, metrics.GetControllerInstance())); err != nil {
+			setupLog.Error(err, "unable to add PrometheusRule manager to manager")
+			os.Exit(1)
+		}
+	}
 
-		// Register in global source registry
-		if err := sourceRegistry.Register("prometheus", promSource); err != nil {
-			setupLog.Error(err, "failed to register prometheus source in source registry")
+	// Synthetic probes of idle models, left out of scale-to-zero idle detection. Only runs on the leader.
+	if cfg.ProbeGatewayURL() != "" {
+		p := prober.New(mgr.GetClient(), cfg.ProbeGatewayURL(), cfg.ProbeTimeout(), metrics.GetControllerInstance())
+		engine.ScaleToZeroEnforcer.SetProbeCounter(p.ProbeCount)
+		if err := mgr.Add(p); err != nil {
+			setupLog.Error(err, "unable to add synthetic prober to manager")
 			os.Exit(1)
 		}
+	}
 
-		engine := saturation.NewEngine(
-			mgr.GetClient(),
-			mgr.GetScheme(),
-			mgr.GetEventRecorderFor("workload-variant-autoscaler-saturation-engine"),
-			sourceRegistry,
-			cfg, // Pass unified Config to engine
-		)
+	// Register optimization engine loops with the manager. Only start when leader.
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		go engine.StartOptimizeLoop(ctx)
 		return nil
 	}))
@@ -410,18 +674,21 @@ func main() {
 	}
 
 	// Register scale from zero engine loop with the manager. Only start when leader.
-	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
-		engine, err := scalefromzero.NewEngine(mgr.GetClient(), mgr.GetRESTMapper(), restConfig, ds, cfg)
+	// Not started in simulator mode, which does not simulate inference gateways.
+	if !cfg.Simulator() {
+		err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			engine, err := scalefromzero.NewEngine(mgr.GetClient(), mgr.GetRESTMapper(), restConfig, ds, cfg)
+			if err != nil {
+				return err
+			}
+			go engine.StartOptimizeLoop(ctx)
+			return nil
+		}))
+
 		if err != nil {
-			return err
+			setupLog.Error(err, "unable to add optimization engine loop to manager")
+			os.Exit(1)
 		}
-		go engine.StartOptimizeLoop(ctx)
-		return nil
-	}))
-
-	if err != nil {
-		setupLog.Error(err, "unable to add optimization engine loop to manager")
-		os.Exit(1)
 	}
 
 	// Create the reconciler with unified Config and datastore
@@ -458,6 +725,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Signal the overload of models to the gateway on the InferencePools serving them
+	if cfg.FeatureEnabled(config.OverloadSignal) {
+		engine.SetOverloadSignaler(overload.NewSignaler(mgr.GetClient(), ds.PoolList, poolGKNN.Group))
+	}
+
+	// Generate the VariantAutoscalings of the variants of annotated InferencePools
+	if cfg.VAGeneratorEnabled() {
+		generator := &controller.VariantAutoscalingGeneratorReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			PoolGKNN: poolGKNN,
+		}
+		if err = generator.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create variantautoscaling generator controller")
+			os.Exit(1)
+		}
+	}
+
 	if err = configMapReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create configmap controller")
 		os.Exit(1)
@@ -505,12 +790,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Register custom metrics with the controller-runtime Prometheus registry
-	// This makes the metrics available for scraping by Prometheus and direct endpoint access
-	setupLog.Info("Registering custom metrics with Prometheus registry")
-	if err := metrics.InitMetrics(crmetrics.Registry); err != nil {
-		setupLog.Error(err, "failed to initialize metrics")
-		os.Exit(1)
+	for _, gate := range cfg.FeatureGates() {
+		setupLog.Info("Feature gate", "name", gate.Name, "stage", gate.Stage, "enabled", gate.Enabled)
+		if err := metrics.NewMetricsEmitter().EmitFeatureGateMetrics(context.Background(), string(gate.Name), string(gate.Stage), gate.Enabled); err != nil {
+			setupLog.Error(err, "failed to emit feature gate metric", "name", gate.Name)
+		}
 	}
 
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
-	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -25,30 +27,48 @@ func NewActuator(k8sClient client.Client) *Actuator {
 	}
 }
 
-// GetCurrentDeploymentReplicas gets the real current replica count from the actual Deployment
+// GetCurrentDeploymentReplicas gets the real current replica count from the actual scale
+// target: a Deployment, or a LeaderWorkerSet whose replicas are its groups
 func (a *Actuator) GetCurrentDeploymentReplicas(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling) (int32, error) {
-	var deploy appsv1.Deployment
-	// Use ScaleTargetRef to get the deployment name
-	err := utils.GetDeploymentWithBackoff(ctx, a.Client, va.GetScaleTargetName(), va.Namespace, &deploy)
+	ctx = logging.IntoModule(ctx, logging.ModuleActuator)
+	// Use ScaleTargetRef to get the scale target
+	workload, err := utils.GetScaleTargetWorkload(ctx, a.Client, va)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get Deployment %s/%s: %w", va.Namespace, va.GetScaleTargetName(), err)
+		if apierrors.IsNotFound(err) {
+			err = fmt.Errorf("%w: %w", wvaerrors.ErrTargetNotFound, err)
+		}
+		return 0, fmt.Errorf("failed to get %s %s/%s: %w", scaleTargetKind(va), va.Namespace, va.GetScaleTargetName(), err)
 	}
 
 	// Prefer status replicas (actual current state)
-	if deploy.Status.Replicas >= 0 {
-		return deploy.Status.Replicas, nil
+	if replicas := workload.StatusReplicas(); replicas >= 0 {
+		return replicas, nil
 	}
 
 	// Fallback to spec if status not ready
-	if deploy.Spec.Replicas != nil {
-		return *deploy.Spec.Replicas, nil
+	if replicas := workload.SpecReplicas(); replicas != nil {
+		return *replicas, nil
 	}
 
 	// Final fallback
 	return 1, nil
 }
 
+// scaleTargetKind returns the kind of the scale target of a variant, Deployment if unset.
+func scaleTargetKind(va *llmdOptv1alpha1.VariantAutoscaling) string {
+	if kind := va.GetScaleTargetKind(); kind != "" {
+		return kind
+	}
+	return utils.DeploymentKind
+}
+
+// EmitReplicaMetrics publishes the given current and desired replicas of a variant.
+func (a *Actuator) EmitReplicaMetrics(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling, current, desired int32, acceleratorType string) error {
+	return a.MetricsEmitter.EmitReplicaMetrics(ctx, va, current, desired, acceleratorType)
+}
+
 func (a *Actuator) EmitMetrics(ctx context.Context, VariantAutoscaling *llmdOptv1alpha1.VariantAutoscaling) error {
+	ctx = logging.IntoModule(ctx, logging.ModuleActuator)
 	// Emit replica metrics with real-time data for external autoscalers
 	logger := log.FromContext(ctx)
 	if VariantAutoscaling.Status.DesiredOptimizedAlloc.NumReplicas >= 0 {
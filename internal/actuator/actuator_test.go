@@ -23,6 +23,7 @@ import (
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	ctrlutils "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
@@ -159,6 +160,7 @@ var _ = Describe("Actuator", func() {
 			_, err := actuator.GetCurrentDeploymentReplicas(ctx, nonExistentVA)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("failed to get Deployment"))
+			Expect(err).To(MatchError(wvaerrors.ErrTargetNotFound))
 		})
 	})
 
@@ -0,0 +1,183 @@
+// Package concurrency adjusts the max-num-seqs of vLLM replicas at runtime, as an
+// alternative to replica changes for small load deltas.
+//
+// Servers supporting dynamic configuration expose an admin endpoint taking the new
+// max-num-seqs as JSON ({"max_num_seqs": N}). When a variant needs a few more replicas
+// relative to its current ones, raising the concurrency of its running replicas absorbs
+// the delta without starting a replica. The two knobs are coordinated so they never change
+// in the same cycle: larger scale-ups change replicas and leave the concurrency as is, and
+// before a scale-down the concurrency is first reset to the one of the deployment args.
+package concurrency
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// requestTimeout bounds each request to the admin endpoint of a replica.
+const requestTimeout = 5 * time.Second
+
+// Plan returns the max-num-seqs of the replicas of a variant and whether its replicas
+// are held at the current ones, given the max-num-seqs of its deployment args (base),
+// the max-num-seqs currently applied, and the current and target replicas:
+//   - a scale-up by at most maxDelta of the current replicas raises the max-num-seqs
+//     in proportion and holds the replicas
+//   - a larger scale-up keeps the applied max-num-seqs and changes the replicas
+//   - a scale-down first resets a raised max-num-seqs to base and holds the replicas,
+//     then changes the replicas in a later cycle
+func Plan(base, applied int64, current, target int, maxDelta float64) (maxNumSeqs int64, holdReplicas bool) {
+	if base <= 0 || current <= 0 {
+		return applied, false
+	}
+	switch {
+	case target > current:
+		ratio := float64(target) / float64(current)
+		if ratio-1 > maxDelta {
+			return applied, false
+		}
+		return int64(math.Ceil(float64(base) * ratio)), true
+	case target < current:
+		return base, applied != base
+	}
+	return applied, false
+}
+
+// Manager sets the max-num-seqs of the replicas of scale targets through their admin
+// endpoint. Safe for concurrent use.
+type Manager struct {
+	client     client.Client
+	httpClient *http.Client
+	port       int32
+	path       string
+
+	mu sync.Mutex
+	// applied is the max-num-seqs set on each variant (namespace/name); variants
+	// absent run with the max-num-seqs of their deployment args
+	applied map[string]int64
+	// pods is the max-num-seqs set on each pod of each variant, by pod name
+	pods map[string]map[string]int64
+}
+
+// NewManager creates a Manager posting to the admin endpoint at the given port and path of the pods.
+func NewManager(c client.Client, port int32, path string) *Manager {
+	return &Manager{
+		client:     c,
+		httpClient: &http.Client{Timeout: requestTimeout},
+		port:       port,
+		path:       path,
+		applied:    make(map[string]int64),
+		pods:       make(map[string]map[string]int64),
+	}
+}
+
+// Applied returns the max-num-seqs set on a variant, or base if none was set.
+func (m *Manager) Applied(variantKey string, base int64) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if applied, ok := m.applied[variantKey]; ok {
+		return applied
+	}
+	return base
+}
+
+// Forget drops the max-num-seqs set on a variant no longer active.
+func (m *Manager) Forget(variantKey string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.applied, variantKey)
+	delete(m.pods, variantKey)
+}
+
+// Apply sets the max-num-seqs of the running pods of a Deployment. Pods already set to it
+// are skipped, as are pods never set when it is base, since they run with their args.
+// Returns the number of pods set. On error, the variant keeps its previous max-num-seqs.
+func (m *Manager) Apply(ctx context.Context, variantKey string, deploy *appsv1.Deployment, base, maxNumSeqs int64) (int, error) {
+	if deploy.Spec.Selector == nil {
+		return 0, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+
+	var pods corev1.PodList
+	if err := m.client.List(ctx, &pods, client.InNamespace(deploy.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("failed to list pods of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// pods that are gone are forgotten
+	previous := m.pods[variantKey]
+	podSeqs := make(map[string]int64, len(pods.Items))
+	m.pods[variantKey] = podSeqs
+	for _, pod := range pods.Items {
+		if seqs, ok := previous[pod.Name]; ok {
+			podSeqs[pod.Name] = seqs
+		}
+	}
+
+	set := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		current, ok := podSeqs[pod.Name]
+		if !ok {
+			current = base
+		}
+		if current == maxNumSeqs {
+			continue
+		}
+		if err := m.post(ctx, pod.Status.PodIP, maxNumSeqs); err != nil {
+			return set, fmt.Errorf("failed to set max-num-seqs of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		podSeqs[pod.Name] = maxNumSeqs
+		set++
+	}
+
+	if maxNumSeqs == base {
+		delete(m.applied, variantKey)
+	} else {
+		m.applied[variantKey] = maxNumSeqs
+	}
+	return set, nil
+}
+
+// post sends the max-num-seqs to the admin endpoint of a pod.
+func (m *Manager) post(ctx context.Context, podIP string, maxNumSeqs int64) error {
+	body, err := json.Marshal(map[string]int64{"max_num_seqs": maxNumSeqs})
+	if err != nil {
+		return err
+	}
+	url := "http://" + net.JoinHostPort(podIP, strconv.Itoa(int(m.port))) + m.path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("admin endpoint returned %s", resp.Status)
+	}
+	return nil
+}
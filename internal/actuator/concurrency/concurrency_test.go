@@ -0,0 +1,192 @@
+package concurrency
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		applied  int64
+		current  int
+		target   int
+		wantSeqs int64
+		wantHold bool
+	}{
+		{"no change", 256, 4, 4, 256, false},
+		{"no change keeps raised", 320, 4, 4, 320, false},
+		{"small scale-up raises", 256, 4, 5, 320, true},
+		{"small scale-up from raised", 320, 8, 9, 288, true},
+		{"large scale-up changes replicas", 256, 2, 3, 256, false},
+		{"large scale-up keeps raised", 320, 4, 6, 320, false},
+		{"scale-down resets raised first", 320, 4, 3, 256, true},
+		{"scale-down at base changes replicas", 256, 4, 3, 256, false},
+		{"no replicas", 256, 0, 1, 256, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seqs, hold := Plan(256, tt.applied, tt.current, tt.target, 0.25)
+			if seqs != tt.wantSeqs || hold != tt.wantHold {
+				t.Errorf("Plan(256, %d, %d, %d, 0.25) = %d, %v, want %d, %v",
+					tt.applied, tt.current, tt.target, seqs, hold, tt.wantSeqs, tt.wantHold)
+			}
+		})
+	}
+}
+
+func TestManager_Apply(t *testing.T) {
+	var mu sync.Mutex
+	var posted []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/max_num_seqs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			MaxNumSeqs int64 `json:"max_num_seqs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		posted = append(posted, body.MaxNumSeqs)
+		mu.Unlock()
+	}))
+	defer server.Close()
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to parse server address: %v", err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	pod := func(name string, phase corev1.PodPhase, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: host},
+		}
+	}
+	llama := map[string]string{"app": "llama"}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: llama}},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("running-a", corev1.PodRunning, llama),
+		pod("running-b", corev1.PodRunning, llama),
+		pod("pending", corev1.PodPending, llama),
+		pod("other", corev1.PodRunning, map[string]string{"app": "other"}),
+	).Build()
+	m := NewManager(c, int32(port), "/admin/max_num_seqs")
+	ctx := context.Background()
+
+	// pods never set run with their args
+	set, err := m.Apply(ctx, "ns/llama", deploy, 256, 256)
+	if err != nil || set != 0 {
+		t.Errorf("Apply() at base = %d, %v, want 0 pods set", set, err)
+	}
+
+	set, err = m.Apply(ctx, "ns/llama", deploy, 256, 320)
+	if err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if set != 2 {
+		t.Errorf("Expected 2 running pods set, got %d", set)
+	}
+	if got := m.Applied("ns/llama", 256); got != 320 {
+		t.Errorf("Applied() = %d, want 320", got)
+	}
+
+	// pods already set are skipped
+	if set, err = m.Apply(ctx, "ns/llama", deploy, 256, 320); err != nil || set != 0 {
+		t.Errorf("second Apply() = %d, %v, want 0 pods set", set, err)
+	}
+
+	// resetting to base sets the pods set before
+	if set, err = m.Apply(ctx, "ns/llama", deploy, 256, 256); err != nil || set != 2 {
+		t.Errorf("Apply() resetting = %d, %v, want 2 pods set", set, err)
+	}
+	if got := m.Applied("ns/llama", 256); got != 256 {
+		t.Errorf("Applied() after reset = %d, want 256", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []int64{320, 320, 256, 256}
+	if len(posted) != len(want) {
+		t.Fatalf("Expected posts %v, got %v", want, posted)
+	}
+	for i := range want {
+		if posted[i] != want[i] {
+			t.Errorf("Expected posts %v, got %v", want, posted)
+			break
+		}
+	}
+}
+
+func TestManager_Forget(t *testing.T) {
+	m := NewManager(nil, 8000, "/admin/max_num_seqs")
+	m.applied["ns/llama"] = 320
+	m.pods["ns/llama"] = map[string]int64{"llama-0": 320}
+	m.applied["ns/granite"] = 128
+
+	m.Forget("ns/llama")
+	if got := m.Applied("ns/llama", 256); got != 256 {
+		t.Errorf("Applied() after Forget = %d, want base 256", got)
+	}
+	if _, ok := m.pods["ns/llama"]; ok {
+		t.Error("Expected the pods of the forgotten variant to be dropped")
+	}
+	if got := m.Applied("ns/granite", 256); got != 128 {
+		t.Errorf("Applied() of another variant = %d, want 128", got)
+	}
+}
+
+func TestManager_ApplyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotImplemented)
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	llama := map[string]string{"app": "llama"}
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "ns", Labels: llama},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: host},
+	}).Build()
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: llama}},
+	}
+	m := NewManager(c, int32(port), "/admin/max_num_seqs")
+
+	if _, err := m.Apply(context.Background(), "ns/llama", deploy, 256, 320); err == nil {
+		t.Error("Expected Apply() to fail on servers without dynamic configuration")
+	}
+	if got := m.Applied("ns/llama", 256); got != 256 {
+		t.Errorf("Applied() after failure = %d, want 256", got)
+	}
+}
@@ -0,0 +1,106 @@
+// Package deletioncost steers which replicas a Deployment removes when it scales down,
+// by setting the controller.kubernetes.io/pod-deletion-cost annotation of its pods.
+//
+// The ReplicaSet controller deletes the pods with the lowest deletion cost first. The
+// cost of a replica grows with its age and the warmth of its prefix cache, so that the
+// newest and least-warmed replicas are terminated first and the replicas serving
+// requests from a warm cache are kept.
+package deletioncost
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+const (
+	// WarmupPeriod is the age at which a replica counts as warmed up by age alone
+	WarmupPeriod = 30 * time.Minute
+
+	// MaxCost is the deletion cost of a replica warmed up by both age and cache
+	MaxCost = 1000
+)
+
+// Cost returns the deletion cost of a replica of the given age whose prefix cache hit
+// rate is warmth (0.0-1.0). Age and warmth weigh equally: a replica older than
+// WarmupPeriod with a fully warm cache costs MaxCost, a new replica with a cold cache 0.
+func Cost(age time.Duration, warmth float64) int32 {
+	ageScore := min(max(age.Seconds()/WarmupPeriod.Seconds(), 0), 1)
+	warmth = min(max(warmth, 0), 1)
+	return int32(math.Round(MaxCost * (ageScore + warmth) / 2))
+}
+
+// Manager sets the deletion cost of the pods of scale targets.
+type Manager struct {
+	client client.Client
+	clock  func() time.Time
+}
+
+// NewManager creates a Manager.
+func NewManager(c client.Client) *Manager {
+	return &Manager{
+		client: c,
+		clock:  time.Now,
+	}
+}
+
+// Apply sets the deletion cost of the pods of a Deployment from their age and the prefix
+// cache hit rate in their replica metrics. Pods without metrics count as cold, and pods
+// being deleted are skipped. Pods whose cost is unchanged are not patched.
+// Returns the number of pods patched.
+func (m *Manager) Apply(ctx context.Context, deploy *appsv1.Deployment, replicaMetrics []interfaces.ReplicaMetrics) (int, error) {
+	if deploy.Spec.Selector == nil {
+		return 0, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+
+	var pods corev1.PodList
+	if err := m.client.List(ctx, &pods, client.InNamespace(deploy.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("failed to list pods of Deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+
+	warmth := make(map[string]float64, len(replicaMetrics))
+	for _, rm := range replicaMetrics {
+		warmth[rm.PodName] = rm.PrefixCacheHitRate
+	}
+
+	now := m.clock()
+	patched := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		started := pod.CreationTimestamp.Time
+		if pod.Status.StartTime != nil {
+			started = pod.Status.StartTime.Time
+		}
+		cost := strconv.Itoa(int(Cost(now.Sub(started), warmth[pod.Name])))
+		if pod.Annotations[corev1.PodDeletionCost] == cost {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[corev1.PodDeletionCost] = cost
+		if err := m.client.Patch(ctx, pod, patch); err != nil {
+			return patched, fmt.Errorf("failed to set deletion cost of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		patched++
+	}
+	return patched, nil
+}
@@ -0,0 +1,109 @@
+package deletioncost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestCost(t *testing.T) {
+	tests := []struct {
+		name   string
+		age    time.Duration
+		warmth float64
+		want   int32
+	}{
+		{"new and cold", 0, 0, 0},
+		{"warmed up by age only", time.Hour, 0, 500},
+		{"warm cache only", 0, 1, 500},
+		{"half warmed up", WarmupPeriod / 2, 0.5, 500},
+		{"fully warm", time.Hour, 1, MaxCost},
+		{"out of range", -time.Minute, 2, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cost(tt.age, tt.warmth); got != tt.want {
+				t.Errorf("Cost(%v, %v) = %d, want %d", tt.age, tt.warmth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Apply(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := func(name string, started time.Time, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ns",
+				Labels:            labels,
+				CreationTimestamp: metav1.NewTime(started),
+			},
+			Status: corev1.PodStatus{StartTime: &metav1.Time{Time: started}},
+		}
+	}
+	llama := map[string]string{"app": "llama"}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "ns"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: llama}},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("old-warm", now.Add(-2*time.Hour), llama),
+		pod("old-cold", now.Add(-2*time.Hour), llama),
+		pod("new", now.Add(-time.Minute), llama),
+		pod("other", now.Add(-2*time.Hour), map[string]string{"app": "other"}),
+	).Build()
+	m := NewManager(c)
+	m.clock = func() time.Time { return now }
+	ctx := context.Background()
+
+	metrics := []interfaces.ReplicaMetrics{
+		{PodName: "old-warm", PrefixCacheHitRate: 0.8},
+		{PodName: "old-cold", PrefixCacheHitRate: 0.1},
+	}
+	patched, err := m.Apply(ctx, deploy, metrics)
+	if err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	if patched != 3 {
+		t.Errorf("Expected 3 pods patched, got %d", patched)
+	}
+
+	costs := make(map[string]string)
+	for _, name := range []string{"old-warm", "old-cold", "new", "other"} {
+		var p corev1.Pod
+		if err := c.Get(ctx, client.ObjectKey{Namespace: "ns", Name: name}, &p); err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		costs[name] = p.Annotations[corev1.PodDeletionCost]
+	}
+	want := map[string]string{"old-warm": "900", "old-cold": "550", "new": "17", "other": ""}
+	for name, cost := range want {
+		if costs[name] != cost {
+			t.Errorf("Expected deletion cost %q for pod %s, got %q", cost, name, costs[name])
+		}
+	}
+
+	// Unchanged costs are not patched again
+	patched, err = m.Apply(ctx, deploy, metrics)
+	if err != nil {
+		t.Fatalf("second Apply() failed: %v", err)
+	}
+	if patched != 0 {
+		t.Errorf("Expected no pod patched, got %d", patched)
+	}
+}
@@ -19,6 +19,7 @@ package actuator
 import (
 	"context"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -35,8 +36,9 @@ import (
 )
 
 type DirectActuator struct {
-	scaleClient scale.ScalesGetter
-	Mapper      meta.RESTMapper
+	scaleClient   scale.ScalesGetter
+	dynamicClient dynamic.Interface
+	Mapper        meta.RESTMapper
 }
 
 func NewDirectActuator(config *rest.Config) (*DirectActuator, error) {
@@ -45,13 +47,20 @@ func NewDirectActuator(config *rest.Config) (*DirectActuator, error) {
 		return nil, err
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DirectActuator{
-		scaleClient: scaleClient,
-		Mapper:      mapper,
+		scaleClient:   scaleClient,
+		dynamicClient: dynamicClient,
+		Mapper:        mapper,
 	}, nil
 }
 
 func (da *DirectActuator) ScaleTargetObject(ctx context.Context, scaledObject *unstructured.Unstructured, replicas int32) error {
+	ctx = logging.IntoModule(ctx, logging.ModuleActuator)
 	logger := log.FromContext(ctx)
 	scale, gr, err := da.getScaleTargetScale(ctx, scaledObject)
 	if err != nil {
@@ -0,0 +1,176 @@
+package actuator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
+)
+
+// PlacementTopologyKeys returns the topology keys the replicas of a VariantAutoscaling
+// should be spread across, from its placement-spread annotation.
+func PlacementTopologyKeys(va *llmdOptv1alpha1.VariantAutoscaling) []string {
+	var keys []string
+	for _, key := range strings.Split(va.Annotations[constants.PlacementSpreadAnnotationKey], ",") {
+		if key = strings.TrimSpace(key); key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ApplyPlacementHints adds a topology spread constraint per topology key to the pod
+// template of a scale target, unless the template already spreads across that key.
+// The constraints select the pods of the template by its labels and are soft
+// (ScheduleAnyway): they steer the scheduler but never leave a replica Pending.
+// It returns whether obj was changed.
+func ApplyPlacementHints(obj *unstructured.Unstructured, topologyKeys []string) (bool, error) {
+	labels, found, err := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+	if err != nil {
+		return false, err
+	}
+	if !found || len(labels) == 0 {
+		return false, fmt.Errorf("pod template labels are missing for %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	constraints, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "topologySpreadConstraints")
+	if err != nil {
+		return false, err
+	}
+	spread := make(map[string]bool)
+	for _, c := range constraints {
+		if constraint, ok := c.(map[string]any); ok {
+			if key, ok := constraint["topologyKey"].(string); ok {
+				spread[key] = true
+			}
+		}
+	}
+
+	changed := false
+	for _, key := range topologyKeys {
+		if spread[key] {
+			continue
+		}
+		constraint, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       key,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+		})
+		if err != nil {
+			return false, err
+		}
+		constraints = append(constraints, constraint)
+		spread[key] = true
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, constraints, "spec", "template", "spec", "topologySpreadConstraints"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ApplyZoneAffinity restricts the pods of the template of a scale target to the given
+// zones, with a required node affinity on topology.kubernetes.io/zone added to each of its
+// node selector terms, unless the template already selects nodes by zone.
+// It returns whether obj was changed.
+func ApplyZoneAffinity(obj *unstructured.Unstructured, zones []string) (bool, error) {
+	if len(zones) == 0 {
+		return false, nil
+	}
+	raw, _, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec", "affinity")
+	if err != nil {
+		return false, err
+	}
+	affinity := &corev1.Affinity{}
+	if raw != nil {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, affinity); err != nil {
+			return false, err
+		}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for _, term := range required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelTopologyZone || expr.Key == corev1.LabelFailureDomainBetaZone {
+				return false, nil
+			}
+		}
+	}
+
+	// Terms are ORed, so each must be restricted to the zones
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelTopologyZone,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   slices.Clone(zones),
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, zoneRequirement)
+	}
+	updated, err := runtime.DefaultUnstructuredConverter.ToUnstructured(affinity)
+	if err != nil {
+		return false, err
+	}
+	if err := unstructured.SetNestedMap(obj.Object, updated, "spec", "template", "spec", "affinity"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EnsurePlacementHints adds the placement hints of the given topology keys and the
+// affinity to the given zones to the pod template of a scale target, updating it if any
+// were missing. Changing the pod template rolls out the pods of the scale target, so hints
+// are best ensured while it has no replicas, as when scaling from zero.
+func (da *DirectActuator) EnsurePlacementHints(ctx context.Context, scaledObject *unstructured.Unstructured, topologyKeys, zones []string) error {
+	if len(topologyKeys) == 0 && len(zones) == 0 {
+		return nil
+	}
+	obj := scaledObject.DeepCopy()
+	spread := false
+	if len(topologyKeys) > 0 {
+		var err error
+		if spread, err = ApplyPlacementHints(obj, topologyKeys); err != nil {
+			return err
+		}
+	}
+	restricted, err := ApplyZoneAffinity(obj, zones)
+	if err != nil || (!spread && !restricted) {
+		return err
+	}
+
+	gvr, err := poolutil.GetResourceForKind(da.Mapper, obj.GetAPIVersion(), obj.GetKind())
+	if err != nil {
+		return err
+	}
+	if _, err := da.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update placement hints of %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+	log.FromContext(ctx).Info("Added placement hints to scale target",
+		"kind", obj.GetKind(),
+		"namespace", obj.GetNamespace(),
+		"name", obj.GetName(),
+		"topologyKeys", topologyKeys,
+		"zones", zones)
+	return nil
+}
@@ -0,0 +1,215 @@
+package actuator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	unittestutil "github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils"
+)
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	u := &unstructured.Unstructured{}
+	u.SetUnstructuredContent(content)
+	u.SetAPIVersion("apps/v1")
+	u.SetKind("Deployment")
+	return u
+}
+
+func spreadConstraints(t *testing.T, obj *unstructured.Unstructured) []corev1.TopologySpreadConstraint {
+	t.Helper()
+	var deploy appsV1.Deployment
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy))
+	return deploy.Spec.Template.Spec.TopologySpreadConstraints
+}
+
+func TestPlacementTopologyKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation *string
+		want       []string
+	}{
+		{name: "No annotation", want: nil},
+		{name: "Empty annotation", annotation: ptr.To(""), want: nil},
+		{name: "Single key", annotation: ptr.To("topology.kubernetes.io/zone"), want: []string{"topology.kubernetes.io/zone"}},
+		{
+			name:       "Multiple keys with blanks and duplicates",
+			annotation: ptr.To(" topology.kubernetes.io/zone, kubernetes.io/hostname,,topology.kubernetes.io/zone"),
+			want:       []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			va := &llmdOptv1alpha1.VariantAutoscaling{}
+			if tt.annotation != nil {
+				va.Annotations = map[string]string{constants.PlacementSpreadAnnotationKey: *tt.annotation}
+			}
+			require.Equal(t, tt.want, PlacementTopologyKeys(va))
+		})
+	}
+}
+
+func TestApplyPlacementHints(t *testing.T) {
+	podLabels := map[string]string{"app": "vllm_v1"}
+
+	t.Run("Adds a soft constraint per topology key", func(t *testing.T) {
+		obj := toUnstructured(t, unittestutil.MakeDeployment("vllm", "default", 0, podLabels))
+
+		changed, err := ApplyPlacementHints(obj, []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"})
+		require.NoError(t, err)
+		require.True(t, changed)
+
+		constraints := spreadConstraints(t, obj)
+		require.Len(t, constraints, 2)
+		require.Equal(t, "topology.kubernetes.io/zone", constraints[0].TopologyKey)
+		require.Equal(t, "kubernetes.io/hostname", constraints[1].TopologyKey)
+		for _, c := range constraints {
+			require.Equal(t, int32(1), c.MaxSkew)
+			require.Equal(t, corev1.ScheduleAnyway, c.WhenUnsatisfiable)
+			require.Equal(t, podLabels, c.LabelSelector.MatchLabels)
+		}
+
+		changed, err = ApplyPlacementHints(obj, []string{"topology.kubernetes.io/zone"})
+		require.NoError(t, err)
+		require.False(t, changed, "Expected hints to be applied only once")
+	})
+
+	t.Run("Keeps existing constraints", func(t *testing.T) {
+		deploy := unittestutil.MakeDeployment("vllm", "default", 0, podLabels)
+		deploy.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+			MaxSkew:           2,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		}}
+		obj := toUnstructured(t, deploy)
+
+		changed, err := ApplyPlacementHints(obj, []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"})
+		require.NoError(t, err)
+		require.True(t, changed)
+
+		constraints := spreadConstraints(t, obj)
+		require.Len(t, constraints, 2)
+		require.Equal(t, int32(2), constraints[0].MaxSkew)
+		require.Equal(t, corev1.DoNotSchedule, constraints[0].WhenUnsatisfiable)
+		require.Equal(t, "kubernetes.io/hostname", constraints[1].TopologyKey)
+	})
+
+	t.Run("Fails without pod template labels", func(t *testing.T) {
+		obj := toUnstructured(t, unittestutil.MakeDeployment("vllm", "default", 0, nil))
+
+		_, err := ApplyPlacementHints(obj, []string{"topology.kubernetes.io/zone"})
+		require.Error(t, err)
+	})
+}
+
+func nodeSelectorTerms(t *testing.T, obj *unstructured.Unstructured) []corev1.NodeSelectorTerm {
+	t.Helper()
+	var deploy appsV1.Deployment
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy))
+	affinity := deploy.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	return affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+}
+
+func TestApplyZoneAffinity(t *testing.T) {
+	zones := []string{"zone-a", "zone-b"}
+	zoneRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: zones}
+
+	t.Run("Restricts a template without affinity", func(t *testing.T) {
+		obj := toUnstructured(t, unittestutil.MakeDeployment("vllm", "default", 0, map[string]string{"app": "vllm"}))
+
+		changed, err := ApplyZoneAffinity(obj, zones)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}}}, nodeSelectorTerms(t, obj))
+
+		changed, err = ApplyZoneAffinity(obj, zones)
+		require.NoError(t, err)
+		require.False(t, changed, "Expected the zones to be applied once")
+	})
+
+	t.Run("Restricts every node selector term", func(t *testing.T) {
+		deploy := unittestutil.MakeDeployment("vllm", "default", 0, map[string]string{"app": "vllm"})
+		gpuRequirement := corev1.NodeSelectorRequirement{Key: "nvidia.com/gpu.product", Operator: corev1.NodeSelectorOpExists}
+		deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{gpuRequirement}},
+				{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1"}}}},
+			}},
+		}}
+		obj := toUnstructured(t, deploy)
+
+		changed, err := ApplyZoneAffinity(obj, zones)
+		require.NoError(t, err)
+		require.True(t, changed)
+		terms := nodeSelectorTerms(t, obj)
+		require.Len(t, terms, 2)
+		require.Equal(t, []corev1.NodeSelectorRequirement{gpuRequirement, zoneRequirement}, terms[0].MatchExpressions)
+		require.Equal(t, []corev1.NodeSelectorRequirement{zoneRequirement}, terms[1].MatchExpressions)
+	})
+
+	t.Run("Keeps a template selecting zones", func(t *testing.T) {
+		deploy := unittestutil.MakeDeployment("vllm", "default", 0, map[string]string{"app": "vllm"})
+		deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"zone-c"}}}},
+			}},
+		}}
+		obj := toUnstructured(t, deploy)
+
+		changed, err := ApplyZoneAffinity(obj, zones)
+		require.NoError(t, err)
+		require.False(t, changed)
+	})
+}
+
+func TestEnsurePlacementHints(t *testing.T) {
+	ctx := context.Background()
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	deployment := unittestutil.MakeDeployment("vllm", "default", 0, map[string]string{"app": "vllm_v1"})
+	fakeDynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, deployment)
+	actuator := &DirectActuator{
+		dynamicClient: fakeDynamicClient,
+		Mapper:        testrestmapper.TestOnlyStaticRESTMapper(scheme, schema.GroupVersion{Group: "apps", Version: "v1"}),
+	}
+
+	obj, err := fakeDynamicClient.Resource(gvr).Namespace("default").Get(ctx, "vllm", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, actuator.EnsurePlacementHints(ctx, obj, []string{"kubernetes.io/hostname"}, nil))
+	require.Empty(t, spreadConstraints(t, obj), "Expected the given object to be left unchanged")
+
+	updated, err := fakeDynamicClient.Resource(gvr).Namespace("default").Get(ctx, "vllm", metav1.GetOptions{})
+	require.NoError(t, err)
+	constraints := spreadConstraints(t, updated)
+	require.Len(t, constraints, 1)
+	require.Equal(t, "kubernetes.io/hostname", constraints[0].TopologyKey)
+
+	// Ensuring the same hints again does not update the scale target
+	fakeDynamicClient.ClearActions()
+	require.NoError(t, actuator.EnsurePlacementHints(ctx, updated, []string{"kubernetes.io/hostname"}, nil))
+	require.Empty(t, fakeDynamicClient.Actions())
+}
@@ -0,0 +1,201 @@
+// Package prepull pulls the images of a variant's scale target on candidate nodes when
+// the variant scales up, so that its new replicas do not wait for large image pulls.
+//
+// Images are pulled by a DaemonSet per VariantAutoscaling, restricted to the nodes of
+// the variant's accelerator type. Each image runs as an init container that exits
+// immediately; a pause container then keeps the pod running so that it is not
+// restarted. The DaemonSet is owned by its VariantAutoscaling and is deleted once the
+// scale-up is done, or when its TTL expires.
+package prepull
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+const (
+	// nameSuffix is appended to the VariantAutoscaling name to name its DaemonSet
+	nameSuffix = "-prepull"
+
+	// managedByLabelValue is the app.kubernetes.io/managed-by label of pre-pull DaemonSets
+	managedByLabelValue = "workload-variant-autoscaler"
+)
+
+// Manager creates and cleans up image pre-pull DaemonSets.
+type Manager struct {
+	client     client.Client
+	ttl        time.Duration
+	pauseImage string
+	clock      func() time.Time
+}
+
+// NewManager creates a Manager whose DaemonSets run pauseImage once the images are
+// pulled and are deleted after ttl.
+func NewManager(c client.Client, ttl time.Duration, pauseImage string) *Manager {
+	return &Manager{
+		client:     c,
+		ttl:        ttl,
+		pauseImage: pauseImage,
+		clock:      time.Now,
+	}
+}
+
+// Enabled returns whether a VariantAutoscaling opted in to image pre-pulling.
+func Enabled(va *llmdOptv1alpha1.VariantAutoscaling) bool {
+	return va.Annotations[constants.PrepullAnnotationKey] == "true"
+}
+
+// DaemonSetName returns the name of the pre-pull DaemonSet of a VariantAutoscaling.
+func DaemonSetName(va *llmdOptv1alpha1.VariantAutoscaling) string {
+	return va.Name + nameSuffix
+}
+
+// Ensure creates the pre-pull DaemonSet of a VariantAutoscaling for the images of its
+// scale target, unless it already exists.
+func (m *Manager) Ensure(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling, deploy *appsv1.Deployment) error {
+	var existing appsv1.DaemonSet
+	err := m.client.Get(ctx, client.ObjectKey{Namespace: va.Namespace, Name: DaemonSetName(va)}, &existing)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get pre-pull DaemonSet of %s/%s: %w", va.Namespace, va.Name, err)
+	}
+
+	ds := BuildDaemonSet(va, deploy, m.pauseImage)
+	if err := controllerutil.SetControllerReference(va, ds, m.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner of pre-pull DaemonSet of %s/%s: %w", va.Namespace, va.Name, err)
+	}
+	if err := m.client.Create(ctx, ds); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pre-pull DaemonSet of %s/%s: %w", va.Namespace, va.Name, err)
+	}
+	log.FromContext(ctx).Info("Created image pre-pull DaemonSet",
+		"variant", va.Name,
+		"namespace", va.Namespace,
+		"daemonSet", ds.Name,
+		"images", len(ds.Spec.Template.Spec.InitContainers))
+	return nil
+}
+
+// Cleanup deletes the pre-pull DaemonSet of a VariantAutoscaling, if any.
+func (m *Manager) Cleanup(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling) error {
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: va.Namespace, Name: DaemonSetName(va)}}
+	if err := m.client.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete pre-pull DaemonSet of %s/%s: %w", va.Namespace, va.Name, err)
+	}
+	log.FromContext(ctx).Info("Deleted image pre-pull DaemonSet",
+		"variant", va.Name,
+		"namespace", va.Namespace)
+	return nil
+}
+
+// CleanupExpired deletes the pre-pull DaemonSets older than the TTL in all namespaces.
+func (m *Manager) CleanupExpired(ctx context.Context) error {
+	var list appsv1.DaemonSetList
+	if err := m.client.List(ctx, &list, client.HasLabels{constants.PrepullForLabelKey}); err != nil {
+		return fmt.Errorf("failed to list pre-pull DaemonSets: %w", err)
+	}
+
+	now := m.clock()
+	for i := range list.Items {
+		ds := &list.Items[i]
+		if now.Sub(ds.CreationTimestamp.Time) <= m.ttl {
+			continue
+		}
+		if err := m.client.Delete(ctx, ds, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete expired pre-pull DaemonSet %s/%s: %w", ds.Namespace, ds.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted expired image pre-pull DaemonSet",
+			"daemonSet", ds.Name,
+			"namespace", ds.Namespace,
+			"ttl", m.ttl)
+	}
+	return nil
+}
+
+// BuildDaemonSet builds the pre-pull DaemonSet of a VariantAutoscaling. Its pods pull
+// every image of the scale target's pod template, on nodes matching the template's node
+// selector and, when the accelerator of the variant is known, on nodes of that type.
+func BuildDaemonSet(va *llmdOptv1alpha1.VariantAutoscaling, deploy *appsv1.Deployment, pauseImage string) *appsv1.DaemonSet {
+	podLabels := map[string]string{constants.PrepullForLabelKey: va.Name}
+	template := deploy.Spec.Template.Spec
+
+	var initContainers []corev1.Container
+	seen := make(map[string]bool)
+	for _, c := range append(append([]corev1.Container{}, template.InitContainers...), template.Containers...) {
+		if c.Image == "" || seen[c.Image] {
+			continue
+		}
+		seen[c.Image] = true
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("prepull-%d", len(initContainers)),
+			Image:           c.Image,
+			ImagePullPolicy: c.ImagePullPolicy,
+			Command:         []string{"sh", "-c", "true"},
+		})
+	}
+
+	affinity := template.Affinity.DeepCopy()
+	if accelerator := utils.GetAcceleratorType(va); accelerator != "" {
+		affinity = &corev1.Affinity{NodeAffinity: acceleratorNodeAffinity(accelerator)}
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DaemonSetName(va),
+			Namespace: va.Namespace,
+			Labels: map[string]string{
+				constants.PrepullForLabelKey:   va.Name,
+				"app.kubernetes.io/managed-by": managedByLabelValue,
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					InitContainers:   initContainers,
+					Containers:       []corev1.Container{{Name: "pause", Image: pauseImage}},
+					ImagePullSecrets: template.ImagePullSecrets,
+					NodeSelector:     template.NodeSelector,
+					Affinity:         affinity,
+					Tolerations:      template.Tolerations,
+				},
+			},
+		},
+	}
+}
+
+// acceleratorNodeAffinity requires nodes whose GPU product label, for any vendor,
+// is the accelerator.
+func acceleratorNodeAffinity(accelerator string) *corev1.NodeAffinity {
+	terms := make([]corev1.NodeSelectorTerm, 0, len(utils.GPUVendors))
+	for _, vendor := range utils.GPUVendors {
+		terms = append(terms, corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      vendor + "/gpu.product",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{accelerator},
+			}},
+		})
+	}
+	return &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+	}
+}
@@ -0,0 +1,203 @@
+package prepull
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+func testVA(accelerator string) *llmdOptv1alpha1.VariantAutoscaling {
+	va := &llmdOptv1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "llama-a100",
+			Namespace:   "ns",
+			UID:         "va-uid",
+			Annotations: map[string]string{constants.PrepullAnnotationKey: "true"},
+		},
+	}
+	if accelerator != "" {
+		va.Labels = map[string]string{utils.AcceleratorNameLabel: accelerator}
+	}
+	return va
+}
+
+func testDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "model-loader", Image: "loader:v1"}},
+					Containers: []corev1.Container{
+						{Name: "vllm", Image: "vllm:v1", ImagePullPolicy: corev1.PullAlways},
+						{Name: "sidecar", Image: "loader:v1"},
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+					NodeSelector:     map[string]string{"pool": "gpu"},
+					Tolerations:      []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}},
+				},
+			},
+		},
+	}
+}
+
+func newTestManager(t *testing.T, objs ...client.Object) (*Manager, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if err := llmdOptv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewManager(c, 30*time.Minute, "pause:test"), c
+}
+
+func TestEnabled(t *testing.T) {
+	va := testVA("")
+	if !Enabled(va) {
+		t.Error("Expected pre-pull to be enabled by the annotation")
+	}
+	va.Annotations = nil
+	if Enabled(va) {
+		t.Error("Expected pre-pull to be disabled without the annotation")
+	}
+}
+
+func TestBuildDaemonSet(t *testing.T) {
+	ds := BuildDaemonSet(testVA("A100"), testDeployment(), "pause:test")
+
+	if ds.Name != "llama-a100-prepull" || ds.Namespace != "ns" {
+		t.Errorf("Unexpected DaemonSet %s/%s", ds.Namespace, ds.Name)
+	}
+	if ds.Labels[constants.PrepullForLabelKey] != "llama-a100" {
+		t.Errorf("Expected pre-pull label, got %v", ds.Labels)
+	}
+
+	spec := ds.Spec.Template.Spec
+	var images []string
+	for _, c := range spec.InitContainers {
+		images = append(images, c.Image)
+		if len(c.Resources.Requests) != 0 {
+			t.Errorf("Expected pre-pull container %s to request no resources", c.Name)
+		}
+	}
+	if len(images) != 2 || images[0] != "loader:v1" || images[1] != "vllm:v1" {
+		t.Errorf("Expected each image to be pulled once, got %v", images)
+	}
+	if spec.InitContainers[1].ImagePullPolicy != corev1.PullAlways {
+		t.Errorf("Expected the pull policy of the scale target, got %q", spec.InitContainers[1].ImagePullPolicy)
+	}
+	if len(spec.Containers) != 1 || spec.Containers[0].Image != "pause:test" {
+		t.Errorf("Expected a single pause container, got %v", spec.Containers)
+	}
+	if spec.NodeSelector["pool"] != "gpu" || len(spec.Tolerations) != 1 || len(spec.ImagePullSecrets) != 1 {
+		t.Error("Expected node selector, tolerations and pull secrets of the scale target")
+	}
+
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != len(utils.GPUVendors) {
+		t.Fatalf("Expected a node selector term per GPU vendor, got %d", len(terms))
+	}
+	if req := terms[0].MatchExpressions[0]; req.Key != "nvidia.com/gpu.product" || req.Values[0] != "A100" {
+		t.Errorf("Unexpected accelerator requirement %+v", req)
+	}
+}
+
+func TestBuildDaemonSet_NoAccelerator(t *testing.T) {
+	deploy := testDeployment()
+	deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}
+
+	ds := BuildDaemonSet(testVA(""), deploy, "pause:test")
+	if ds.Spec.Template.Spec.Affinity == nil || ds.Spec.Template.Spec.Affinity.NodeAffinity == nil {
+		t.Error("Expected the affinity of the scale target without a known accelerator")
+	}
+}
+
+func TestManager_EnsureAndCleanup(t *testing.T) {
+	va := testVA("A100")
+	m, c := newTestManager(t, va)
+	ctx := context.Background()
+
+	if err := m.Ensure(ctx, va, testDeployment()); err != nil {
+		t.Fatalf("Ensure() failed: %v", err)
+	}
+	var ds appsv1.DaemonSet
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "ns", Name: DaemonSetName(va)}, &ds); err != nil {
+		t.Fatalf("Expected pre-pull DaemonSet to be created: %v", err)
+	}
+	if len(ds.OwnerReferences) != 1 || ds.OwnerReferences[0].UID != va.UID {
+		t.Errorf("Expected the DaemonSet to be owned by the VA, got %v", ds.OwnerReferences)
+	}
+
+	// Ensuring again is a no-op
+	if err := m.Ensure(ctx, va, testDeployment()); err != nil {
+		t.Fatalf("second Ensure() failed: %v", err)
+	}
+
+	if err := m.Cleanup(ctx, va); err != nil {
+		t.Fatalf("Cleanup() failed: %v", err)
+	}
+	err := c.Get(ctx, client.ObjectKey{Namespace: "ns", Name: DaemonSetName(va)}, &ds)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected pre-pull DaemonSet to be deleted, got %v", err)
+	}
+
+	// Cleaning up again is a no-op
+	if err := m.Cleanup(ctx, va); err != nil {
+		t.Errorf("second Cleanup() failed: %v", err)
+	}
+}
+
+func TestManager_CleanupExpired(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	daemonSet := func(name string, labels map[string]string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "ns",
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+	}
+	m, c := newTestManager(t,
+		daemonSet("expired-prepull", map[string]string{constants.PrepullForLabelKey: "expired"}),
+		daemonSet("unrelated", map[string]string{"app": "other"}),
+	)
+	ctx := context.Background()
+
+	m.clock = func() time.Time { return created.Add(10 * time.Minute) }
+	if err := m.CleanupExpired(ctx); err != nil {
+		t.Fatalf("CleanupExpired() failed: %v", err)
+	}
+	var list appsv1.DaemonSetList
+	if err := c.List(ctx, &list); err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("Expected no DaemonSet to be deleted before the TTL, got %d left", len(list.Items))
+	}
+
+	m.clock = func() time.Time { return created.Add(time.Hour) }
+	if err := m.CleanupExpired(ctx); err != nil {
+		t.Fatalf("CleanupExpired() failed: %v", err)
+	}
+	if err := c.List(ctx, &list); err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "unrelated" {
+		t.Errorf("Expected only the expired pre-pull DaemonSet to be deleted, got %v", list.Items)
+	}
+}
@@ -0,0 +1,231 @@
+// Package admin serves the admin API of the controller on the metrics server, to trigger
+// reconciles, flush the metrics caches, put variants in dry-run and fetch the effective
+// configuration at runtime, without restarting the controller. The dry-run of a variant is stored in an annotation of its
+// VariantAutoscaling, so that any replica can serve the requests and the leader applies it.
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// Path is the path of the endpoints on the metrics server.
+const Path = "/admin/"
+
+const (
+	// ReconcilePath triggers the reconcile of a VA (POST)
+	ReconcilePath = Path + "reconcile"
+	// CacheFlushPath flushes the caches of the metrics sources (POST)
+	CacheFlushPath = Path + "cache/flush"
+	// DryRunPath lists (GET) or toggles (POST) the VAs in dry-run
+	DryRunPath = Path + "dry-run"
+	// ConfigPath serves the effective configuration (GET)
+	ConfigPath = Path + "config"
+)
+
+// Result is the response of the endpoints that change state.
+type Result struct {
+	// Action is what was done
+	Action string `json:"action"`
+	// Variant is the namespace/name of the VA acted on, if any
+	Variant string `json:"variant,omitempty"`
+	// FlushedEntries is the number of cached results removed by a cache flush
+	FlushedEntries int `json:"flushedEntries,omitempty"`
+	// DryRun is whether the VA is in dry-run after a toggle
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// DryRunList is the response of the dry-run listing.
+type DryRunList struct {
+	// Variants are the namespace/name of the VAs in dry-run, sorted
+	Variants []string `json:"variants"`
+}
+
+// Handler serves the admin API. Reconciles of the VAs read with reader are enqueued on
+// trigger, flush flushes the metrics caches and returns how many results it removed, the
+// dry-run annotation of the VAs is patched with writer for users authz allows to patch the
+// VAs of their namespace, and effectiveConfig serves the effective configuration.
+// Reconciles and cache flushes only take effect on the leader, so they are refused until
+// elected is closed.
+func Handler(reader client.Reader, writer client.Writer, authz Authorizer, elected <-chan struct{},
+	trigger chan<- event.GenericEvent, flush func() int, effectiveConfig http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(ConfigPath, func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodGet) {
+			return
+		}
+		effectiveConfig.ServeHTTP(w, r)
+	})
+
+	mux.HandleFunc(ReconcilePath, func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodPost) || !requireLeader(w, elected) {
+			return
+		}
+		va, ok := readVariant(w, r, reader)
+		if !ok {
+			return
+		}
+		// Do not block the handler when the reconciles are backed up
+		select {
+		case trigger <- event.GenericEvent{Object: va}:
+		default:
+			http.Error(w, "reconcile queue is full, retry later", http.StatusServiceUnavailable)
+			return
+		}
+		key := client.ObjectKeyFromObject(va).String()
+		ctrl.Log.Info("Admin API triggered reconcile", "variant", key)
+		writeJSON(w, Result{Action: "reconcile", Variant: key})
+	})
+
+	mux.HandleFunc(CacheFlushPath, func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodPost) || !requireLeader(w, elected) {
+			return
+		}
+		flushed := flush()
+		ctrl.Log.Info("Admin API flushed the metrics caches", "entries", flushed)
+		writeJSON(w, Result{Action: "cache-flush", FlushedEntries: flushed})
+	})
+
+	mux.HandleFunc(DryRunPath, func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+			return
+		}
+		if r.Method == http.MethodGet {
+			variants, err := listDryRunVariants(r, reader)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to list VariantAutoscalings: %v", err), http.StatusInternalServerError)
+				return
+			}
+			writeJSON(w, DryRunList{Variants: variants})
+			return
+		}
+		s := r.URL.Query().Get("enabled")
+		enabled, err := strconv.ParseBool(s)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid enabled %q: must be true or false", s), http.StatusBadRequest)
+			return
+		}
+		namespace := r.URL.Query().Get("namespace")
+		allowed, err := authz.CanPatchVariants(r, namespace)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to authorize: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, fmt.Sprintf("not allowed to patch VariantAutoscalings in namespace %q", namespace),
+				http.StatusForbidden)
+			return
+		}
+		va, ok := readVariant(w, r, reader)
+		if !ok {
+			return
+		}
+		key := client.ObjectKeyFromObject(va).String()
+		if err := patchDryRun(r, writer, va, enabled); err != nil {
+			http.Error(w, fmt.Sprintf("failed to patch VariantAutoscaling %s: %v", key, err), http.StatusInternalServerError)
+			return
+		}
+		ctrl.Log.Info("Admin API toggled dry-run", "variant", key, "dryRun", enabled)
+		writeJSON(w, Result{Action: "dry-run", Variant: key, DryRun: &enabled})
+	})
+
+	return mux
+}
+
+// allowMethods writes a 405 and returns false if the method of r is not one of methods.
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	for _, method := range methods {
+		w.Header().Add("Allow", method)
+	}
+	http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+	return false
+}
+
+// requireLeader writes a 503 and returns false if the replica is not the leader.
+func requireLeader(w http.ResponseWriter, elected <-chan struct{}) bool {
+	select {
+	case <-elected:
+		return true
+	default:
+		http.Error(w, "not the leader: send the request to the leader replica", http.StatusServiceUnavailable)
+		return false
+	}
+}
+
+// readVariant reads the VA of the namespace and name query parameters of r. It writes the
+// error and returns false if they are missing or the VA cannot be read.
+func readVariant(w http.ResponseWriter, r *http.Request, reader client.Reader) (*llmdVariantAutoscalingV1alpha1.VariantAutoscaling, bool) {
+	namespace := r.URL.Query().Get("namespace")
+	name := r.URL.Query().Get("name")
+	if namespace == "" || name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return nil, false
+	}
+	var va llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	if err := reader.Get(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, &va); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("VariantAutoscaling %s/%s not found", namespace, name), http.StatusNotFound)
+		} else {
+			http.Error(w, fmt.Sprintf("failed to read VariantAutoscaling %s/%s: %v", namespace, name, err),
+				http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+	return &va, true
+}
+
+// listDryRunVariants returns the namespace/name of the VAs in dry-run, sorted.
+func listDryRunVariants(r *http.Request, reader client.Reader) ([]string, error) {
+	var list llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := reader.List(r.Context(), &list); err != nil {
+		return nil, err
+	}
+	variants := make([]string, 0)
+	for i := range list.Items {
+		if list.Items[i].Annotations[constants.DryRunAnnotationKey] == "true" {
+			variants = append(variants, client.ObjectKeyFromObject(&list.Items[i]).String())
+		}
+	}
+	slices.Sort(variants)
+	return variants, nil
+}
+
+// patchDryRun sets the dry-run annotation of a VA, or removes it when disabled.
+func patchDryRun(r *http.Request, writer client.Writer, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, enabled bool) error {
+	patch := client.MergeFrom(va.DeepCopy())
+	if enabled {
+		if va.Annotations == nil {
+			va.Annotations = make(map[string]string)
+		}
+		va.Annotations[constants.DryRunAnnotationKey] = "true"
+	} else {
+		delete(va.Annotations, constants.DryRunAnnotationKey)
+	}
+	return writer.Patch(r.Context(), va, patch)
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
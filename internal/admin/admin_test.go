@@ -0,0 +1,171 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// namespaceAuthorizer allows patching the VAs of the namespaces it lists
+type namespaceAuthorizer map[string]bool
+
+func (a namespaceAuthorizer) CanPatchVariants(_ *http.Request, namespace string) (bool, error) {
+	return a[namespace], nil
+}
+
+// effectiveConfig serves a fixed effective configuration
+var effectiveConfig = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, []map[string]string{{"key": "WVA_ROLLOUT_STEP_TIMEOUT", "value": "5m", "source": "file"}})
+})
+
+// newHandler returns the handler of the leader over a VA prod/llama-h100, with the trigger
+// channel of the given size, and the client it reads and patches the VAs with. Users may
+// patch the VAs of prod only.
+func newHandler(t *testing.T, triggerSize int) (http.Handler, chan event.GenericEvent, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, llmdVariantAutoscalingV1alpha1.AddToScheme(scheme))
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-h100", Namespace: "prod"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(va).Build()
+	trigger := make(chan event.GenericEvent, triggerSize)
+	elected := make(chan struct{})
+	close(elected)
+	handler := Handler(c, c, namespaceAuthorizer{"prod": true}, elected, trigger, func() int { return 7 }, effectiveConfig)
+	return handler, trigger, c
+}
+
+// isDryRun returns whether the VA prod/llama-h100 has the dry-run annotation
+func isDryRun(t *testing.T, c client.Client) bool {
+	var va llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "prod", Name: "llama-h100"}, &va))
+	return va.Annotations[constants.DryRunAnnotationKey] == "true"
+}
+
+func serve(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestHandler_Reconcile(t *testing.T) {
+	handler, trigger, _ := newHandler(t, 1)
+
+	rec := serve(handler, http.MethodPost, ReconcilePath+"?namespace=prod&name=llama-h100")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var result Result
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, Result{Action: "reconcile", Variant: "prod/llama-h100"}, result)
+	require.Len(t, trigger, 1)
+	assert.Equal(t, "llama-h100", (<-trigger).Object.GetName())
+
+	// The queue is full
+	trigger <- event.GenericEvent{}
+	rec = serve(handler, http.MethodPost, ReconcilePath+"?namespace=prod&name=llama-h100")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHandler_ReconcileErrors(t *testing.T) {
+	handler, trigger, _ := newHandler(t, 1)
+
+	assert.Equal(t, http.StatusMethodNotAllowed,
+		serve(handler, http.MethodGet, ReconcilePath+"?namespace=prod&name=llama-h100").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(handler, http.MethodPost, ReconcilePath+"?namespace=prod").Code)
+	assert.Equal(t, http.StatusNotFound,
+		serve(handler, http.MethodPost, ReconcilePath+"?namespace=prod&name=missing").Code)
+	assert.Empty(t, trigger)
+}
+
+func TestHandler_CacheFlush(t *testing.T) {
+	handler, _, _ := newHandler(t, 1)
+
+	rec := serve(handler, http.MethodPost, CacheFlushPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var result Result
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, Result{Action: "cache-flush", FlushedEntries: 7}, result)
+}
+
+func TestHandler_DryRun(t *testing.T) {
+	handler, _, c := newHandler(t, 1)
+
+	rec := serve(handler, http.MethodPost, DryRunPath+"?namespace=prod&name=llama-h100&enabled=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, isDryRun(t, c))
+
+	rec = serve(handler, http.MethodGet, DryRunPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list DryRunList
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, []string{"prod/llama-h100"}, list.Variants)
+
+	rec = serve(handler, http.MethodPost, DryRunPath+"?namespace=prod&name=llama-h100&enabled=false")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, isDryRun(t, c))
+
+	rec = serve(handler, http.MethodGet, DryRunPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Empty(t, list.Variants)
+
+	assert.Equal(t, http.StatusBadRequest,
+		serve(handler, http.MethodPost, DryRunPath+"?namespace=prod&name=llama-h100&enabled=maybe").Code)
+	assert.Equal(t, http.StatusNotFound,
+		serve(handler, http.MethodPost, DryRunPath+"?namespace=prod&name=missing&enabled=true").Code)
+}
+
+func TestHandler_DryRunForbidden(t *testing.T) {
+	handler, _, c := newHandler(t, 1)
+
+	rec := serve(handler, http.MethodPost, DryRunPath+"?namespace=staging&name=llama-h100&enabled=true")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, isDryRun(t, c))
+}
+
+func TestHandler_Config(t *testing.T) {
+	handler, _, _ := newHandler(t, 1)
+
+	rec := serve(handler, http.MethodGet, ConfigPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var settings []map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &settings))
+	require.Len(t, settings, 1)
+	assert.Equal(t, "file", settings[0]["source"])
+
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodPost, ConfigPath).Code)
+}
+
+func TestHandler_NotLeader(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, llmdVariantAutoscalingV1alpha1.AddToScheme(scheme))
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-h100", Namespace: "prod"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(va).Build()
+	trigger := make(chan event.GenericEvent, 1)
+	handler := Handler(c, c, namespaceAuthorizer{"prod": true}, make(chan struct{}), trigger, func() int { return 7 },
+		effectiveConfig)
+
+	assert.Equal(t, http.StatusServiceUnavailable,
+		serve(handler, http.MethodPost, ReconcilePath+"?namespace=prod&name=llama-h100").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(handler, http.MethodPost, CacheFlushPath).Code)
+	assert.Empty(t, trigger)
+
+	// Dry-run is stored on the VA, so a standby can toggle it
+	rec := serve(handler, http.MethodPost, DryRunPath+"?namespace=prod&name=llama-h100&enabled=true")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, isDryRun(t, c))
+}
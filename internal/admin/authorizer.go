@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+// Authorizer decides whether the user making a request may act on the VariantAutoscalings
+// of a namespace. The authn/authz of secure metrics only checks access to the paths of the
+// admin API, which are the same for all namespaces.
+type Authorizer interface {
+	// CanPatchVariants returns whether the user of r may patch the VAs of namespace
+	CanPatchVariants(r *http.Request, namespace string) (bool, error)
+}
+
+// reviewAuthorizer identifies the user of a request by its bearer token with a TokenReview,
+// and checks its access with a SubjectAccessReview.
+type reviewAuthorizer struct {
+	client client.Client
+}
+
+// NewAuthorizer returns an Authorizer creating TokenReviews and SubjectAccessReviews with c,
+// as the authn/authz of secure metrics does.
+func NewAuthorizer(c client.Client) Authorizer {
+	return &reviewAuthorizer{client: c}
+}
+
+func (a *reviewAuthorizer) CanPatchVariants(r *http.Request, namespace string) (bool, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return false, nil
+	}
+
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.client.Create(r.Context(), review); err != nil {
+		return false, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return false, nil
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	access := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "patch",
+				Group:     llmdVariantAutoscalingV1alpha1.GroupVersion.Group,
+				Resource:  "variantautoscalings",
+			},
+		},
+	}
+	if err := a.client.Create(r.Context(), access); err != nil {
+		return false, fmt.Errorf("failed to review access of user %s: %w", user.Username, err)
+	}
+	return access.Status.Allowed, nil
+}
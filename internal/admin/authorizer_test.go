@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestAuthorizer_CanPatchVariants(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, authenticationv1.AddToScheme(scheme))
+	require.NoError(t, authorizationv1.AddToScheme(scheme))
+
+	// The API server knows the token of alice, who may patch the VAs of prod
+	var reviewed *authorizationv1.SubjectAccessReviewSpec
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "alice-token" {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"ops"}}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				reviewed = &review.Spec
+				review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Namespace == "prod"
+			}
+			return nil
+		},
+	}).Build()
+	authz := NewAuthorizer(c)
+
+	request := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, DryRunPath, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	allowed, err := authz.CanPatchVariants(request("alice-token"), "prod")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	require.NotNil(t, reviewed)
+	assert.Equal(t, []string{"ops"}, reviewed.Groups)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace: "prod", Verb: "patch", Group: "llmd.ai", Resource: "variantautoscalings",
+	}, reviewed.ResourceAttributes)
+
+	allowed, err = authz.CanPatchVariants(request("alice-token"), "staging")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = authz.CanPatchVariants(request("unknown-token"), "prod")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = authz.CanPatchVariants(request(""), "prod")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
@@ -0,0 +1,250 @@
+// Package alerting manages a PrometheusRule of curated alerts on the health of the
+// controller and its variants, so that installing WVA yields actionable alerting out of
+// the box.
+//
+// The leader creates the rule in the system namespace, owned by the main ConfigMap of the
+// controller so that it is deleted on uninstall, and restores it periodically when it is
+// edited or deleted.
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// RuleName is the name of the PrometheusRule, suffixed with the controller instance if any.
+const RuleName = "workload-variant-autoscaler-alerts"
+
+// Alerts of the PrometheusRule.
+const (
+	// AlertMetricsMissing fires when the metrics of a variant have been unavailable for 10 minutes.
+	AlertMetricsMissing = "WVAMetricsMissing"
+	// AlertActuationStalled fires when the replicas of a variant have not reached its desired
+	// replicas for 15 minutes.
+	AlertActuationStalled = "WVAActuationStalled"
+	// AlertSaturationAboveThreshold fires when the average KV cache usage of a model has been
+	// above the saturation threshold for 15 minutes.
+	AlertSaturationAboveThreshold = "WVASaturationAboveThreshold"
+	// AlertConfigInvalid fires when entries of a saturation scaling ConfigMap have been
+	// ignored as invalid for 5 minutes.
+	AlertConfigInvalid = "WVAConfigInvalid"
+)
+
+// defaultKvCacheThreshold is the saturation threshold of the alerts when the saturation
+// scaling config has no default entry
+const defaultKvCacheThreshold = 0.80
+
+// resyncInterval is how often the rule is restored
+const resyncInterval = 5 * time.Minute
+
+// NewRule returns the PrometheusRule of the alerts in namespace. The alerts on the metrics
+// of the controller are restricted to those of instance when set. Models are saturated
+// above kvCacheThreshold.
+func NewRule(namespace, instance string, kvCacheThreshold float64) *promoperator.PrometheusRule {
+	name := RuleName
+	if instance != "" {
+		name += "-" + instance
+	}
+
+	rules := []promoperator.Rule{
+		{
+			Alert: AlertMetricsMissing,
+			Expr:  intstr.FromString(fmt.Sprintf(`%s%s == 0`, constants.WVAConditionStatus, selector(instance, fmt.Sprintf(`%s=%q`, constants.LabelConditionType, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable)))),
+			For:   ptrDuration("10m"),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "Metrics of VariantAutoscaling {{ $labels.namespace }}/{{ $labels.variant_name }} are missing",
+				"description": "The saturation metrics of the variant have been unavailable for 10 minutes, so it is not scaled. Check the MetricsAvailable condition of the VariantAutoscaling and that Prometheus scrapes its pods.",
+			},
+		},
+		{
+			Alert: AlertActuationStalled,
+			Expr:  intstr.FromString(fmt.Sprintf(`%s%s != %s%s`, constants.WVADesiredReplicas, selector(instance), constants.WVACurrentReplicas, selector(instance))),
+			For:   ptrDuration("15m"),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "Scaling of VariantAutoscaling {{ $labels.namespace }}/{{ $labels.variant_name }} is stalled",
+				"description": "The replicas of the variant have not reached its desired replicas for 15 minutes. Check the HPA or ScaledObject of the scale target and whether its pods are schedulable.",
+			},
+		},
+		{
+			Alert: AlertSaturationAboveThreshold,
+			Expr:  intstr.FromString(fmt.Sprintf(`avg by (%s, %s) (%s) > %g`, constants.LabelNamespace, constants.LabelModelName, constants.VLLMKvCacheUsagePerc, kvCacheThreshold)),
+			For:   ptrDuration("15m"),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "Model {{ $labels.namespace }}/{{ $labels.model_name }} is saturated",
+				"description": fmt.Sprintf("The average KV cache usage of the replicas of the model has been above %g for 15 minutes. Check whether its scale-ups are limited by GPU capacity or held.", kvCacheThreshold),
+			},
+		},
+		{
+			Alert: AlertConfigInvalid,
+			Expr:  intstr.FromString(fmt.Sprintf(`%s%s > 0`, constants.WVAConfigInvalidEntries, selector(instance))),
+			For:   ptrDuration("5m"),
+			Labels: map[string]string{
+				"severity": "warning",
+			},
+			Annotations: map[string]string{
+				"summary":     "ConfigMap {{ $labels.namespace }}/{{ $labels.configmap }} has invalid entries",
+				"description": "{{ $value }} entries of the saturation scaling ConfigMap failed to parse or validate and are ignored. Check the controller logs for the errors.",
+			},
+		},
+	}
+
+	return &promoperator.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "workload-variant-autoscaler",
+				"app.kubernetes.io/managed-by": "workload-variant-autoscaler",
+			},
+		},
+		Spec: promoperator.PrometheusRuleSpec{
+			Groups: []promoperator.RuleGroup{{
+				Name:  "workload-variant-autoscaler",
+				Rules: rules,
+			}},
+		},
+	}
+}
+
+// selector returns the label matchers of the metrics of a controller instance, in braces
+// unless there are none
+func selector(instance string, matchers ...string) string {
+	if instance != "" {
+		matchers = append(matchers, fmt.Sprintf(`%s=%q`, constants.LabelControllerInstance, instance))
+	}
+	if len(matchers) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
+
+func ptrDuration(d promoperator.Duration) *promoperator.Duration {
+	return &d
+}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;update
+
+// Manager keeps the PrometheusRule of the alerts in sync with the config.
+type Manager struct {
+	client   client.Client
+	reader   client.Reader
+	cfg      *config.Config
+	instance string
+}
+
+// NewManager creates a Manager writing the rule with c. The rule and its owner are read
+// with reader, typically uncached since they are only read every few minutes.
+func NewManager(c client.Client, reader client.Reader, cfg *config.Config, instance string) *Manager {
+	return &Manager{client: c, reader: reader, cfg: cfg, instance: instance}
+}
+
+// Start ensures the rule until ctx is done. It implements manager.Runnable and only runs
+// on the leader.
+func (m *Manager) Start(ctx context.Context) error {
+	logger := ctrl.Log.WithName("alerting")
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.Ensure(ctx); err != nil {
+			if meta.IsNoMatchError(err) {
+				logger.Error(err, "PrometheusRule CRD not installed, alerts are not created")
+			} else {
+				logger.Error(err, "Failed to ensure the PrometheusRule of the alerts")
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ensure creates the rule, or restores its labels and alerts when they were edited.
+func (m *Manager) Ensure(ctx context.Context) error {
+	kvCacheThreshold := defaultKvCacheThreshold
+	if def, ok := m.cfg.SaturationConfig()["default"]; ok && def.KvCacheThreshold > 0 {
+		kvCacheThreshold = def.KvCacheThreshold
+	}
+	desired := NewRule(config.SystemNamespace(), m.instance, kvCacheThreshold)
+
+	var existing promoperator.PrometheusRule
+	err := m.reader.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if apierrors.IsNotFound(err) {
+		if err := m.setOwner(ctx, desired); err != nil {
+			return err
+		}
+		if err := m.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create PrometheusRule %s/%s: %w", desired.Namespace, desired.Name, err)
+		}
+		ctrl.Log.WithName("alerting").Info("Created the PrometheusRule of the alerts",
+			"namespace", desired.Namespace, "name", desired.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get PrometheusRule %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+
+	labelsInSync := true
+	for key, value := range desired.Labels {
+		if existing.Labels[key] != value {
+			labelsInSync = false
+		}
+	}
+	if labelsInSync && equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+		return nil
+	}
+	if existing.Labels == nil {
+		existing.Labels = make(map[string]string)
+	}
+	for key, value := range desired.Labels {
+		existing.Labels[key] = value
+	}
+	existing.Spec = desired.Spec
+	if err := m.client.Update(ctx, &existing); err != nil {
+		return fmt.Errorf("failed to update PrometheusRule %s/%s: %w", existing.Namespace, existing.Name, err)
+	}
+	return nil
+}
+
+// setOwner makes the main ConfigMap of the controller the owner of the rule, so that it
+// is deleted with the controller. The rule is left without owner when the ConfigMap
+// cannot be read.
+func (m *Manager) setOwner(ctx context.Context, rule *promoperator.PrometheusRule) error {
+	var owner corev1.ConfigMap
+	if err := m.reader.Get(ctx, client.ObjectKey{Namespace: rule.Namespace, Name: config.ConfigMapName()}, &owner); err != nil {
+		ctrl.Log.WithName("alerting").V(1).Info("Creating the PrometheusRule without owner",
+			"configMap", config.ConfigMapName(), "error", err.Error())
+		return nil
+	}
+	if err := controllerutil.SetOwnerReference(&owner, rule, m.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set the owner of PrometheusRule %s/%s: %w", rule.Namespace, rule.Name, err)
+	}
+	return nil
+}
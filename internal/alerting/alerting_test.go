@@ -0,0 +1,87 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+
+	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, promoperator.AddToScheme(scheme))
+	return scheme
+}
+
+func alertExprs(rule *promoperator.PrometheusRule) map[string]string {
+	exprs := make(map[string]string)
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			exprs[r.Alert] = r.Expr.String()
+		}
+	}
+	return exprs
+}
+
+func TestNewRule(t *testing.T) {
+	rule := NewRule("wva-system", "", 0.8)
+	assert.Equal(t, RuleName, rule.Name)
+	assert.Equal(t, map[string]string{
+		AlertMetricsMissing:           `wva_condition_status{condition_type="MetricsAvailable"} == 0`,
+		AlertActuationStalled:         `wva_desired_replicas != wva_current_replicas`,
+		AlertSaturationAboveThreshold: `avg by (namespace, model_name) (vllm:kv_cache_usage_perc) > 0.8`,
+		AlertConfigInvalid:            `wva_config_invalid_entries > 0`,
+	}, alertExprs(rule))
+
+	rule = NewRule("wva-system", "team-a", 0.9)
+	assert.Equal(t, RuleName+"-team-a", rule.Name)
+	exprs := alertExprs(rule)
+	assert.Equal(t, `wva_condition_status{condition_type="MetricsAvailable",controller_instance="team-a"} == 0`, exprs[AlertMetricsMissing])
+	assert.Equal(t, `wva_config_invalid_entries{controller_instance="team-a"} > 0`, exprs[AlertConfigInvalid])
+	assert.Equal(t, `avg by (namespace, model_name) (vllm:kv_cache_usage_perc) > 0.9`, exprs[AlertSaturationAboveThreshold])
+}
+
+func TestManagerEnsure(t *testing.T) {
+	ctx := context.Background()
+	namespace := config.SystemNamespace()
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.ConfigMapName(), Namespace: namespace, UID: "owner-uid"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(owner).Build()
+
+	cfg := config.NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]interfaces.SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.75, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+	})
+	m := NewManager(k8sClient, k8sClient, cfg, "")
+
+	// creates the rule, owned by the main ConfigMap
+	require.NoError(t, m.Ensure(ctx))
+	var rule promoperator.PrometheusRule
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: RuleName}, &rule))
+	require.Len(t, rule.OwnerReferences, 1)
+	assert.Equal(t, config.ConfigMapName(), rule.OwnerReferences[0].Name)
+	assert.Contains(t, alertExprs(&rule)[AlertSaturationAboveThreshold], "> 0.75")
+
+	// restores edited alerts
+	rule.Spec.Groups[0].Rules = rule.Spec.Groups[0].Rules[:1]
+	require.NoError(t, k8sClient.Update(ctx, &rule))
+	require.NoError(t, m.Ensure(ctx))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: RuleName}, &rule))
+	assert.Len(t, alertExprs(&rule), 4)
+
+	// recreates the deleted rule
+	require.NoError(t, k8sClient.Delete(ctx, &rule))
+	require.NoError(t, m.Ensure(ctx))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: RuleName}, &rule))
+}
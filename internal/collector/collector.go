@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
 type AcceleratorModelInfo = discovery.AcceleratorModelInfo
@@ -30,14 +31,14 @@ type AcceleratorModelInfo = discovery.AcceleratorModelInfo
 // Future work: Implement CollectInventoryK8S and capacity-aware allocation for limited mode.
 
 // CollectInventoryK8S provides accelerator inventory using the discovery mechanism.
+//
+// Deprecated: Use discovery.NewNodeInventoryProvider or another discovery.InventoryProvider.
 func CollectInventoryK8S(ctx context.Context, r interface{}) (map[string]map[string]AcceleratorModelInfo, error) {
+	ctx = logging.IntoModule(ctx, logging.ModuleCollector)
 	c, ok := r.(client.Client)
 	if !ok {
 		return nil, fmt.Errorf("invalid client type: expected client.Client")
 	}
 
-	// Use the K8sWithGpuOperator discovery mechanism
-	// TODO: Make this configurable or dynamic based on environment
-	disc := &discovery.K8sWithGpuOperator{Client: c}
-	return disc.Discover(ctx)
+	return discovery.NewNodeInventoryProvider(c).Discover(ctx)
 }
@@ -0,0 +1,73 @@
+// Package enrichment provides ReplicaMetrics enrichment hooks.
+//
+// Enrichers add custom fields (ReplicaMetrics.Custom) to the replica metrics of a model
+// before they are analyzed, for custom analyzers to consume. Enrichers are either
+// compiled in, registering themselves with Register from an init function, or a
+// webhook configured with WVA_ENRICHMENT_WEBHOOK_URL.
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+var (
+	mu         sync.RWMutex
+	registered []interfaces.ReplicaMetricsEnricher
+)
+
+// Register adds a compiled-in enricher. Call it from an init function of the package
+// implementing the enricher, and import that package in the controller binary.
+// Panics if an enricher with the same name is already registered.
+func Register(enricher interfaces.ReplicaMetricsEnricher) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range registered {
+		if e.Name() == enricher.Name() {
+			panic(fmt.Sprintf("replica metrics enricher %q already registered", enricher.Name()))
+		}
+	}
+	registered = append(registered, enricher)
+}
+
+// Enrichers returns the compiled-in enrichers, in registration order, followed by the
+// webhook enricher when a webhook URL is configured.
+func Enrichers(cfg *config.Config) []interfaces.ReplicaMetricsEnricher {
+	mu.RLock()
+	enrichers := append([]interfaces.ReplicaMetricsEnricher(nil), registered...)
+	mu.RUnlock()
+
+	if webhookURL := cfg.EnrichmentWebhookURL(); webhookURL != "" {
+		enrichers = append(enrichers, NewWebhookEnricher(webhookURL, cfg.EnrichmentWebhookTimeout()))
+	}
+	return enrichers
+}
+
+// Apply runs the enrichers in order on the replica metrics of a model. Enrichment is
+// best effort: a failing enricher is logged and skipped, and the custom fields it may
+// have added are kept.
+func Apply(ctx context.Context, enrichers []interfaces.ReplicaMetricsEnricher, modelID, namespace string, metrics []interfaces.ReplicaMetrics) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	for _, enricher := range enrichers {
+		if err := enricher.Enrich(ctx, modelID, namespace, metrics); err != nil {
+			logger.Info("Replica metrics enrichment failed, skipping enricher",
+				"enricher", enricher.Name(),
+				"modelID", modelID,
+				"namespace", namespace,
+				"error", err)
+			continue
+		}
+		logger.V(logging.DEBUG).Info("Enriched replica metrics",
+			"enricher", enricher.Name(),
+			"modelID", modelID,
+			"namespace", namespace)
+	}
+}
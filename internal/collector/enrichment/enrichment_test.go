@@ -0,0 +1,137 @@
+package enrichment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// fakeEnricher sets a custom field on every replica, or fails.
+type fakeEnricher struct {
+	name  string
+	field string
+	err   error
+}
+
+func (f *fakeEnricher) Name() string { return f.name }
+
+func (f *fakeEnricher) Enrich(_ context.Context, _, _ string, metrics []interfaces.ReplicaMetrics) error {
+	if f.err != nil {
+		return f.err
+	}
+	for i := range metrics {
+		if metrics[i].Custom == nil {
+			metrics[i].Custom = make(map[string]float64)
+		}
+		metrics[i].Custom[f.field] = 1
+	}
+	return nil
+}
+
+var _ = Describe("Enrichment", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Describe("Register", func() {
+		AfterEach(func() {
+			mu.Lock()
+			registered = nil
+			mu.Unlock()
+		})
+
+		It("should return compiled-in enrichers in registration order", func() {
+			Register(&fakeEnricher{name: "first"})
+			Register(&fakeEnricher{name: "second"})
+
+			enrichers := Enrichers(config.NewTestConfig())
+			Expect(enrichers).To(HaveLen(2))
+			Expect(enrichers[0].Name()).To(Equal("first"))
+			Expect(enrichers[1].Name()).To(Equal("second"))
+		})
+
+		It("should panic on duplicate names", func() {
+			Register(&fakeEnricher{name: "first"})
+			Expect(func() { Register(&fakeEnricher{name: "first"}) }).To(Panic())
+		})
+	})
+
+	Describe("Apply", func() {
+		It("should skip failing enrichers and run the others", func() {
+			metrics := []interfaces.ReplicaMetrics{{PodName: "pod-1"}}
+
+			Apply(ctx, []interfaces.ReplicaMetricsEnricher{
+				&fakeEnricher{name: "failing", err: errors.New("unavailable")},
+				&fakeEnricher{name: "load", field: "load_factor"},
+			}, "model", "default", metrics)
+
+			Expect(metrics[0].Custom).To(Equal(map[string]float64{"load_factor": 1}))
+		})
+	})
+
+	Describe("WebhookEnricher", func() {
+		var (
+			server  *httptest.Server
+			request WebhookRequest
+			status  int
+		)
+
+		BeforeEach(func() {
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					return
+				}
+				Expect(json.NewEncoder(w).Encode(WebhookResponse{Replicas: []WebhookReplica{
+					{PodName: "pod-1", Custom: map[string]float64{"business_load": 0.8}},
+					{PodName: "unknown-pod", Custom: map[string]float64{"business_load": 0.1}},
+				}})).To(Succeed())
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should merge the custom fields returned for each replica", func() {
+			metrics := []interfaces.ReplicaMetrics{
+				{PodName: "pod-1", VariantName: "v1", KvCacheUsage: 0.5, Custom: map[string]float64{"load_factor": 1}},
+				{PodName: "pod-2", VariantName: "v1", QueueLength: 3},
+			}
+
+			err := NewWebhookEnricher(server.URL, time.Second).Enrich(ctx, "model", "default", metrics)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(request.ModelID).To(Equal("model"))
+			Expect(request.Namespace).To(Equal("default"))
+			Expect(request.Replicas).To(HaveLen(2))
+			Expect(request.Replicas[0].Custom).To(HaveKeyWithValue("load_factor", 1.0))
+			Expect(request.Replicas[1].QueueLength).To(Equal(3))
+
+			Expect(metrics[0].Custom).To(Equal(map[string]float64{"load_factor": 1, "business_load": 0.8}))
+			Expect(metrics[1].Custom).To(BeNil())
+		})
+
+		It("should fail on non-OK responses", func() {
+			status = http.StatusInternalServerError
+			metrics := []interfaces.ReplicaMetrics{{PodName: "pod-1"}}
+
+			err := NewWebhookEnricher(server.URL, time.Second).Enrich(ctx, "model", "default", metrics)
+			Expect(err).To(HaveOccurred())
+			Expect(metrics[0].Custom).To(BeNil())
+		})
+	})
+})
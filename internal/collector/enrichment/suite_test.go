@@ -0,0 +1,13 @@
+package enrichment
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEnrichment(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Enrichment Suite")
+}
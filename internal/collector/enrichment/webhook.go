@@ -0,0 +1,130 @@
+package enrichment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// WebhookEnricherName is the name of the webhook enricher.
+const WebhookEnricherName = "webhook"
+
+// maxWebhookResponseBytes bounds the size of webhook responses.
+const maxWebhookResponseBytes = 1 << 20
+
+// WebhookRequest is the body POSTed to the enrichment webhook.
+type WebhookRequest struct {
+	ModelID   string           `json:"modelID"`
+	Namespace string           `json:"namespace"`
+	Replicas  []WebhookReplica `json:"replicas"`
+}
+
+// WebhookReplica describes a replica in a webhook request, and its custom fields in a
+// webhook response.
+type WebhookReplica struct {
+	PodName         string             `json:"podName"`
+	VariantName     string             `json:"variantName,omitempty"`
+	AcceleratorName string             `json:"acceleratorName,omitempty"`
+	KvCacheUsage    float64            `json:"kvCacheUsage"`
+	QueueLength     int                `json:"queueLength"`
+	GPUUtilization  float64            `json:"gpuUtilization"`
+	Custom          map[string]float64 `json:"custom,omitempty"`
+}
+
+// WebhookResponse is the body returned by the enrichment webhook. Only the podName and
+// custom fields of its replicas are used; replicas missing from it are left unchanged.
+type WebhookResponse struct {
+	Replicas []WebhookReplica `json:"replicas"`
+}
+
+// WebhookEnricher adds the custom fields returned by an HTTP webhook to replica metrics.
+type WebhookEnricher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookEnricher creates an enricher calling the webhook at url with the given timeout.
+func NewWebhookEnricher(url string, timeout time.Duration) *WebhookEnricher {
+	return &WebhookEnricher{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the enricher's identifier.
+func (w *WebhookEnricher) Name() string {
+	return WebhookEnricherName
+}
+
+// Enrich POSTs the replica metrics of a model to the webhook and merges the custom fields
+// of its response into them.
+func (w *WebhookEnricher) Enrich(ctx context.Context, modelID, namespace string, metrics []interfaces.ReplicaMetrics) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	request := WebhookRequest{
+		ModelID:   modelID,
+		Namespace: namespace,
+		Replicas:  make([]WebhookReplica, 0, len(metrics)),
+	}
+	for _, m := range metrics {
+		request.Replicas = append(request.Replicas, WebhookReplica{
+			PodName:         m.PodName,
+			VariantName:     m.VariantName,
+			AcceleratorName: m.AcceleratorName,
+			KvCacheUsage:    m.KvCacheUsage,
+			QueueLength:     m.QueueLength,
+			GPUUtilization:  m.GPUUtilization,
+			Custom:          m.Custom,
+		})
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook call failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	var response WebhookResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxWebhookResponseBytes)).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode webhook response: %w", err)
+	}
+
+	custom := make(map[string]map[string]float64, len(response.Replicas))
+	for _, r := range response.Replicas {
+		custom[r.PodName] = r.Custom
+	}
+	for i := range metrics {
+		for field, value := range custom[metrics[i].PodName] {
+			if metrics[i].Custom == nil {
+				metrics[i].Custom = make(map[string]float64)
+			}
+			metrics[i].Custom[field] = value
+		}
+	}
+	return nil
+}
+
+// Ensure WebhookEnricher implements interfaces.ReplicaMetricsEnricher.
+var _ interfaces.ReplicaMetricsEnricher = (*WebhookEnricher)(nil)
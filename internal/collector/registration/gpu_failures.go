@@ -0,0 +1,36 @@
+package registration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+)
+
+// CollectFailedGPUs returns the number of failing GPUs of each node, as reported by the
+// QueryGPUErrors query: one series per GPU with double-bit ECC or hardware fault Xid errors.
+// Series without a node label are ignored.
+func CollectFailedGPUs(ctx context.Context, metricsSource source.MetricsSource) (map[string]int, error) {
+	results, err := metricsSource.Refresh(ctx, source.RefreshSpec{
+		Queries: []string{QueryGPUErrors},
+		Params:  map[string]string{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GPU errors: %w", err)
+	}
+	result := results[QueryGPUErrors]
+	if result == nil {
+		return nil, nil
+	}
+	if result.HasError() {
+		return nil, fmt.Errorf("GPU errors query failed: %w", result.Error)
+	}
+
+	failed := make(map[string]int)
+	for _, value := range result.Values {
+		if node := source.NodeOf(value); node != "" {
+			failed[node]++
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,46 @@
+package registration
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source/prometheus"
+)
+
+var _ = Describe("CollectFailedGPUs", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should count the failing GPUs of each node", func() {
+		sample := func(value float64, labels model.LabelSet) *model.Sample {
+			return &model.Sample{Metric: model.Metric(labels), Value: model.SampleValue(value), Timestamp: model.TimeFromUnix(time.Now().Unix())}
+		}
+		mockAPI := &mockPrometheusAPI{
+			queryFunc: func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+				return model.Vector{
+					sample(79, model.LabelSet{"Hostname": "node-1", "gpu": "0"}),
+					sample(2, model.LabelSet{"Hostname": "node-1", "gpu": "3"}),
+					sample(48, model.LabelSet{"node": "node-2", "gpu": "1"}),
+					sample(94, model.LabelSet{"gpu": "0"}),
+				}, nil, nil
+			},
+		}
+		registry := source.NewSourceRegistry()
+		metricsSource := prometheus.NewPrometheusSource(ctx, mockAPI, prometheus.DefaultPrometheusSourceConfig())
+		Expect(registry.Register("prometheus", metricsSource)).To(Succeed())
+		RegisterSaturationQueries(registry)
+
+		failed, err := CollectFailedGPUs(ctx, metricsSource)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(Equal(map[string]int{"node-1": 2, "node-2": 1}))
+	})
+})
@@ -16,9 +16,33 @@ const (
 	QueryAvgInputTokens     = "avg_input_tokens"
 	QueryPrefixCacheHitRate = "prefix_cache_hit_rate"
 
+	// Token length distribution queries (per-pod histogram bucket rates by le)
+	QueryInputTokensHistogram  = "input_tokens_histogram"
+	QueryOutputTokensHistogram = "output_tokens_histogram"
+
+	// Latency budget queries (per-pod TTFT and batch size)
+	QueryReplicaTTFT     = "replica_ttft"
+	QueryRunningRequests = "running_requests"
+
+	// Error rate query (per-pod fraction of failed or aborted requests)
+	QueryReplicaErrorRate = "replica_error_rate"
+
 	// Scheduler flow control queries (model-level, from inference scheduler)
 	QuerySchedulerQueueSize  = "scheduler_queue_size"
 	QuerySchedulerQueueBytes = "scheduler_queue_bytes"
+
+	// Gateway request rates (model-level, from the llm-d inference gateway)
+	QueryGatewayRequestRate  = "gateway_request_rate"
+	QueryGatewayRejectedRate = "gateway_rejected_rate"
+
+	// Model-level performance queries (request rate and latencies across all pods)
+	QueryRequestRate = "request_rate"
+	QueryAvgTTFT     = "avg_ttft"
+	QueryAvgITL      = "avg_itl"
+
+	// Node-level GPU queries (labeled by node and GPU, joined to pods by the collector)
+	QueryGPUUtilization = "gpu_utilization"
+	QueryGPUErrors      = "gpu_errors"
 )
 
 // RegisterSaturationQueries registers queries used by the saturation analyzer.
@@ -78,6 +102,26 @@ func RegisterSaturationQueries(sourceRegistry *source.SourceRegistry) {
 		Description: "Average input tokens per completed request (5m rate)",
 	})
 
+	// Prompt token histogram bucket rates per pod (5m rate, cumulative by le)
+	// Turned into t-digest sketches by the collector: the p90 prompt length projects the
+	// KV demand of queued requests without underestimating long-prompt workloads
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryInputTokensHistogram,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum by (pod, le) (rate(vllm:request_prompt_tokens_bucket{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Prompt token histogram bucket rates per pod and le (5m rate)",
+	})
+
+	// Generation token histogram bucket rates per pod (5m rate, cumulative by le)
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryOutputTokensHistogram,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum by (pod, le) (rate(vllm:request_generation_tokens_bucket{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Generation token histogram bucket rates per pod and le (5m rate)",
+	})
+
 	// Prefix cache hit rate per pod (5m rate)
 	// Used to reduce estimated input token demand for scheduler-queued requests.
 	// Returns 0..1 where 1 means all prefix lookups were cache hits.
@@ -89,6 +133,39 @@ func RegisterSaturationQueries(sourceRegistry *source.SourceRegistry) {
 		Description: "Prefix cache hit rate per pod (0.0-1.0, 5m rate)",
 	})
 
+	// --- Latency budget queries ---
+
+	// Average time to first token per pod (seconds, 5m rate)
+	// Decomposed into queueing and prefill time for the latency budget of a variant
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryReplicaTTFT,
+		Type:        source.QueryTypePromQL,
+		Template:    `max by (pod) (rate(vllm:time_to_first_token_seconds_sum{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]) / rate(vllm:time_to_first_token_seconds_count{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Average time to first token per pod in seconds (5m rate)",
+	})
+
+	// Average number of running requests (batch size) per pod over last minute
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryRunningRequests,
+		Type:        source.QueryTypePromQL,
+		Template:    `max by (pod) (avg_over_time(vllm:num_requests_running{namespace="{{.namespace}}",model_name="{{.modelID}}"}[1m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Average number of running requests per pod over last minute",
+	})
+
+	// --- Error rate queries ---
+
+	// Fraction of requests finished by an error or abort per pod (0.0-1.0, 5m rate)
+	// Elevated error rates veto scale-downs: errors may indicate hidden overload
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryReplicaErrorRate,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum by (pod) (rate(vllm:request_success_total{namespace="{{.namespace}}",model_name="{{.modelID}}",finished_reason=~"abort|error"}[5m])) / sum by (pod) (rate(vllm:request_success_total{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Fraction of requests finished by an error or abort per pod (0.0-1.0, 5m rate)",
+	})
+
 	// --- Scheduler flow control queries (model-level) ---
 	// These come from the llm-d inference scheduler, not vLLM pods.
 	// They use target_model_name when available, falling back to model_name.
@@ -119,4 +196,83 @@ func RegisterSaturationQueries(sourceRegistry *source.SourceRegistry) {
 		Description: "Total bytes queued in scheduler flow control for this model",
 	})
 
+	// --- Gateway request queries (model-level) ---
+	// These come from the llm-d inference gateway (EPP), with the same model name
+	// labels and the same lack of namespace label as the flow control queries above.
+	// They correct the arrival rate for the requests shed during overload.
+
+	// Requests received by the gateway for this model (requests per minute, 1m rate),
+	// counting the requests it admitted and those it rejected
+	registry.MustRegister(source.QueryTemplate{
+		Name: QueryGatewayRequestRate,
+		Type: source.QueryTypePromQL,
+		Template: `sum(rate(inference_objective_request_total{target_model_name="{{.modelID}}"}[1m])) * 60` +
+			` or sum(rate(inference_objective_request_total{model_name="{{.modelID}}",target_model_name=""}[1m])) * 60`,
+		Params:      []string{source.ParamModelID},
+		Description: "Requests received by the gateway for this model in requests per minute (1m rate)",
+	})
+
+	// Requests the gateway rejected or shed because the model was overloaded
+	// (requests per minute, 1m rate)
+	registry.MustRegister(source.QueryTemplate{
+		Name: QueryGatewayRejectedRate,
+		Type: source.QueryTypePromQL,
+		Template: `sum(rate(inference_objective_request_error_total{target_model_name="{{.modelID}}",error_code=~"ResourceExhausted|InferencePoolResourceExhausted"}[1m])) * 60` +
+			` or sum(rate(inference_objective_request_error_total{model_name="{{.modelID}}",target_model_name="",error_code=~"ResourceExhausted|InferencePoolResourceExhausted"}[1m])) * 60`,
+		Params:      []string{source.ParamModelID},
+		Description: "Requests the gateway shed for this model in requests per minute (1m rate)",
+	})
+
+	// --- Model-level performance queries ---
+	// Aggregated across all pods serving the model, in the units of interfaces.OptimizerMetrics.
+
+	// Request arrival rate (requests per minute, 1m rate)
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryRequestRate,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum(rate(vllm:request_success_total{namespace="{{.namespace}}",model_name="{{.modelID}}"}[1m])) * 60`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Request arrival rate for the model in requests per minute (1m rate)",
+	})
+
+	// Average time to first token (seconds, 5m rate)
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryAvgTTFT,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum(rate(vllm:time_to_first_token_seconds_sum{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m])) / sum(rate(vllm:time_to_first_token_seconds_count{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Average time to first token for the model in seconds (5m rate)",
+	})
+
+	// Average inter-token latency (seconds per output token, 5m rate)
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryAvgITL,
+		Type:        source.QueryTypePromQL,
+		Template:    `sum(rate(vllm:time_per_output_token_seconds_sum{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m])) / sum(rate(vllm:time_per_output_token_seconds_count{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:      []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Average inter-token latency for the model in seconds (5m rate)",
+	})
+
+	// --- Node-level GPU queries ---
+	// DCGM exporter labels GPU metrics by node (Hostname) and GPU (gpu, UUID), and by pod
+	// only when its pod attribution is enabled. Series are returned per GPU and attributed
+	// to replicas with source.JoinPodsByNode.
+
+	// GPU utilization per GPU (percent)
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryGPUUtilization,
+		Type:        source.QueryTypePromQL,
+		Template:    `DCGM_FI_DEV_GPU_UTIL`,
+		Description: "GPU utilization per GPU in percent (0-100), labeled by node and GPU",
+	})
+
+	// Failing GPUs: double-bit ECC errors, or Xid errors of hardware faults (48 DBE, 74
+	// NVLink, 79 fallen off the bus, 94/95 contained/uncontained ECC). Application Xids,
+	// e.g. 13 or 43, are left out since they do not take the GPU down.
+	registry.MustRegister(source.QueryTemplate{
+		Name:        QueryGPUErrors,
+		Type:        source.QueryTypePromQL,
+		Template:    `DCGM_FI_DEV_ECC_DBE_VOL_TOTAL > 0 or DCGM_FI_DEV_XID_ERRORS == 48 or DCGM_FI_DEV_XID_ERRORS == 74 or DCGM_FI_DEV_XID_ERRORS == 79 or DCGM_FI_DEV_XID_ERRORS == 94 or DCGM_FI_DEV_XID_ERRORS == 95`,
+		Description: "GPUs with double-bit ECC or hardware fault Xid errors, labeled by node and GPU",
+	})
 }
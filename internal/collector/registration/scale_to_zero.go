@@ -44,6 +44,7 @@ func RegisterScaleToZeroQueries(sourceRegistry *source.SourceRegistry) {
 		Type:        source.QueryTypePromQL,
 		Template:    `sum(increase(vllm:request_success_total{namespace="{{.namespace}}",model_name="{{.modelID}}"}[{{.retentionPeriod}}]))`,
 		Params:      []string{source.ParamNamespace, source.ParamModelID, ParamRetentionPeriod},
+		WindowParam: ParamRetentionPeriod,
 		Description: "Total successful requests for a model over the retention period",
 	})
 }
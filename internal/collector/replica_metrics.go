@@ -25,36 +25,152 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"sync"
 	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/enrichment"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/sketch"
 )
 
+// DefaultBackfillWindow is how much metrics history is backfilled when a
+// VariantAutoscaling is collected for the first time.
+const DefaultBackfillWindow = 30 * time.Minute
+
+// DefaultSketchRetention is how long the token length sketches of a pod are kept for
+// cycles in which no request of the pod completed.
+const DefaultSketchRetention = 30 * time.Minute
+
 // ReplicaMetricsCollector collects replica-level metrics for saturation analysis
 // using the source infrastructure.
 type ReplicaMetricsCollector struct {
 	source      source.MetricsSource
 	k8sClient   client.Client
 	podVAMapper *source.PodVAMapper
+
+	// enrichers add custom fields to the collected metrics before analysis
+	enrichers []interfaces.ReplicaMetricsEnricher
+
+	// detectGPUFailures marks the replicas on failing GPUs, reported by the GPU errors
+	// query or by the gpuFailureNodeConditions of their node
+	detectGPUFailures        bool
+	gpuFailureNodeConditions []string
+
+	// freshness classifies the age of the samples of each replica
+	freshness config.FreshnessThresholds
+
+	// inputSketches and outputSketches hold the last token length sketches of each pod
+	// (namespace/pod), used while the histograms of the pod have no recent requests.
+	inputSketches  *sketch.Cache
+	outputSketches *sketch.Cache
+
+	// mu protects collected.
+	mu sync.Mutex
+	// collected holds when each VariantAutoscaling (namespace/name) was last collected,
+	// to detect newly created ones.
+	collected map[string]time.Time
 }
 
 // NewReplicaMetricsCollector creates a new replica metrics collector.
 func NewReplicaMetricsCollector(metricsSource source.MetricsSource, k8sClient client.Client) *ReplicaMetricsCollector {
 	return &ReplicaMetricsCollector{
-		source:      metricsSource,
-		k8sClient:   k8sClient,
-		podVAMapper: source.NewPodVAMapper(k8sClient),
+		source:         metricsSource,
+		k8sClient:      k8sClient,
+		podVAMapper:    source.NewPodVAMapper(k8sClient),
+		inputSketches:  sketch.NewCache(DefaultSketchRetention),
+		outputSketches: sketch.NewCache(DefaultSketchRetention),
+		freshness:      config.DefaultFreshnessThresholds(),
+		collected:      make(map[string]time.Time),
+	}
+}
+
+// SetEnrichers sets the enrichers run on the collected replica metrics of each model.
+// Call it before collection starts.
+func (c *ReplicaMetricsCollector) SetEnrichers(enrichers []interfaces.ReplicaMetricsEnricher) {
+	c.enrichers = enrichers
+}
+
+// SetGPUFailureDetection enables marking the replicas on failing GPUs as GPUFailed: GPUs
+// with Xid or ECC errors, or all GPUs of nodes with one of nodeConditions true.
+// Call it before collection starts.
+func (c *ReplicaMetricsCollector) SetGPUFailureDetection(nodeConditions []string) {
+	c.detectGPUFailures = true
+	c.gpuFailureNodeConditions = nodeConditions
+}
+
+// SetFreshnessThresholds sets the thresholds classifying the age of the samples of each
+// replica in its metadata. Call it before collection starts.
+func (c *ReplicaMetricsCollector) SetFreshnessThresholds(thresholds config.FreshnessThresholds) {
+	c.freshness = thresholds
+}
+
+// hasNewVariants records the VariantAutoscalings being collected and returns whether
+// any of them was not collected within the backfill window, as when it was just created.
+func (c *ReplicaMetricsCollector) hasNewVariants(variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for variantKey, lastCollected := range c.collected {
+		if now.Sub(lastCollected) > DefaultBackfillWindow {
+			delete(c.collected, variantKey)
+		}
+	}
+
+	hasNew := false
+	for variantKey := range variantAutoscalings {
+		if _, ok := c.collected[variantKey]; !ok {
+			hasNew = true
+		}
+		c.collected[variantKey] = now
+	}
+	return hasNew
+}
+
+// backfillPeaks replaces the KV cache and queue results with their peaks over the
+// backfill window, when the source keeps history. Used when a VariantAutoscaling is
+// created for an already-running deployment, so its first analysis is based on recent
+// history rather than on a single sample. Failed backfills keep the current results.
+func (c *ReplicaMetricsCollector) backfillPeaks(ctx context.Context, params map[string]string, results map[string]*source.MetricResult) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	historySource, ok := c.source.(source.HistorySource)
+	if !ok {
+		return
+	}
+	backfilled, err := historySource.Backfill(ctx, source.RefreshSpec{
+		Queries: []string{registration.QueryKvCacheUsage, registration.QueryQueueLength},
+		Params:  params,
+	}, DefaultBackfillWindow)
+	if err != nil {
+		logger.V(logging.DEBUG).Info("Failed to backfill metrics history", "error", err)
+		return
 	}
+	for queryName, result := range backfilled {
+		if result == nil || result.HasError() || len(result.Values) == 0 {
+			logger.V(logging.DEBUG).Info("No metrics history to backfill, using current values",
+				"query", queryName)
+			continue
+		}
+		results[queryName] = result
+	}
+	logger.Info("Backfilled metrics history for new VariantAutoscaling",
+		"modelID", params[source.ParamModelID],
+		"namespace", params[source.ParamNamespace],
+		"window", DefaultBackfillWindow)
 }
 
 // CollectReplicaMetrics collects KV cache and queue metrics for all replicas of a model
@@ -68,7 +184,7 @@ func NewReplicaMetricsCollector(metricsSource source.MetricsSource, k8sClient cl
 //   - ctx: Context for the operation
 //   - modelID: The model identifier to collect metrics for
 //   - namespace: The namespace where the model is deployed
-//   - deployments: Map of Deployment namespace/name to Deployment
+//   - workloads: Map of scale target namespace/name to scale target workload
 //   - variantAutoscalings: Map of VariantAutoscaling namespace/name to VariantAutoscaling object
 //   - variantCosts: Map of VariantAutoscaling namespace/name to cost value
 //
@@ -79,11 +195,14 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 	ctx context.Context,
 	modelID string,
 	namespace string,
-	deployments map[string]*appsv1.Deployment,
+	workloads map[string]interfaces.Workload,
 	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	variantCosts map[string]float64,
 ) ([]interfaces.ReplicaMetrics, error) {
+	ctx = logging.IntoModule(ctx, logging.ModuleCollector)
 	logger := ctrl.LoggerFrom(ctx)
+	// per-pod entries are logged only in cycles sampled for full detail
+	detailed := logging.Detailed(ctx)
 
 	params := map[string]string{
 		source.ParamModelID:   modelID,
@@ -97,7 +216,16 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		registration.QueryCacheConfigInfo,
 		registration.QueryAvgOutputTokens,
 		registration.QueryAvgInputTokens,
+		registration.QueryInputTokensHistogram,
+		registration.QueryOutputTokensHistogram,
 		registration.QueryPrefixCacheHitRate,
+		registration.QueryGPUUtilization,
+		registration.QueryReplicaTTFT,
+		registration.QueryRunningRequests,
+		registration.QueryReplicaErrorRate,
+	}
+	if c.detectGPUFailures {
+		queries = append(queries, registration.QueryGPUErrors)
 	}
 
 	results, err := c.source.Refresh(ctx, source.RefreshSpec{
@@ -105,7 +233,11 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		Params:  params,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to refresh saturation metrics: %w", err)
+		return nil, fmt.Errorf("failed to refresh saturation metrics: %w: %w", wvaerrors.ErrMetricsQueryFailed, err)
+	}
+
+	if c.hasNewVariants(variantAutoscalings) {
+		c.backfillPeaks(ctx, params, results)
 	}
 
 	// podMetricData holds per-pod metric values and timestamps
@@ -121,8 +253,17 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		blockSize          int64
 		avgOutputTokens    float64
 		avgInputTokens     float64
+		inputTokensSketch  *sketch.TDigest
+		outputTokensSketch *sketch.TDigest
 		prefixCacheHitRate float64
 		hasCacheConfig     bool
+		gpuUtilization     float64
+		gpuFailed          bool
+		// latency budget fields
+		avgTTFT         float64
+		runningRequests float64
+		// scale-down veto fields
+		errorRate float64
 	}
 
 	// Extract per-pod metrics from results
@@ -131,7 +272,7 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 	// Process KV cache results
 	if result := results[registration.QueryKvCacheUsage]; result != nil {
 		if result.HasError() {
-			return nil, fmt.Errorf("KV cache query failed: %w", result.Error)
+			return nil, fmt.Errorf("KV cache %w: %w", wvaerrors.ErrMetricsQueryFailed, result.Error)
 		}
 		for _, value := range result.Values {
 			podName := value.Labels["pod"]
@@ -149,17 +290,19 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			podData[podName].kvTimestamp = value.Timestamp
 			podData[podName].hasKv = true
 
-			logger.V(logging.DEBUG).Info("KV cache metric",
-				"pod", podName,
-				"usage", value.Value,
-				"usagePercent", value.Value*100)
+			if detailed {
+				logger.V(logging.DEBUG).Info("KV cache metric",
+					"pod", podName,
+					"usage", value.Value,
+					"usagePercent", value.Value*100)
+			}
 		}
 	}
 
 	// Process queue length results
 	if result := results[registration.QueryQueueLength]; result != nil {
 		if result.HasError() {
-			return nil, fmt.Errorf("queue length query failed: %w", result.Error)
+			return nil, fmt.Errorf("queue length %w: %w", wvaerrors.ErrMetricsQueryFailed, result.Error)
 		}
 		for _, value := range result.Values {
 			podName := value.Labels["pod"]
@@ -177,9 +320,11 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			podData[podName].queueTimestamp = value.Timestamp
 			podData[podName].hasQueue = true
 
-			logger.V(logging.DEBUG).Info("Queue metric",
-				"pod", podName,
-				"queueLength", int(value.Value))
+			if detailed {
+				logger.V(logging.DEBUG).Info("Queue metric",
+					"pod", podName,
+					"queueLength", int(value.Value))
+			}
 		}
 	}
 
@@ -214,10 +359,12 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 					podData[podName].hasCacheConfig = true
 				}
 
-				logger.V(logging.DEBUG).Info("Cache config info metric",
-					"pod", podName,
-					"numGpuBlocks", podData[podName].numGpuBlocks,
-					"blockSize", podData[podName].blockSize)
+				if detailed {
+					logger.V(logging.DEBUG).Info("Cache config info metric",
+						"pod", podName,
+						"numGpuBlocks", podData[podName].numGpuBlocks,
+						"blockSize", podData[podName].blockSize)
+				}
 			}
 		}
 	}
@@ -268,6 +415,16 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		}
 	}
 
+	// Build token length sketches from histogram results, falling back to the last
+	// sketches of pods without completed requests in the rate window
+	inputHistograms := histogramBuckets(results[registration.QueryInputTokensHistogram])
+	outputHistograms := histogramBuckets(results[registration.QueryOutputTokensHistogram])
+	for podName, data := range podData {
+		podKey := utils.GetNamespacedKey(namespace, podName)
+		data.inputTokensSketch = c.tokenSketch(c.inputSketches, podKey, inputHistograms[podName])
+		data.outputTokensSketch = c.tokenSketch(c.outputSketches, podKey, outputHistograms[podName])
+	}
+
 	// Process prefix cache hit rate results (V2)
 	if result := results[registration.QueryPrefixCacheHitRate]; result != nil {
 		if !result.HasError() {
@@ -291,10 +448,110 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		}
 	}
 
+	// Process average TTFT results (latency budget)
+	if result := results[registration.QueryReplicaTTFT]; result != nil {
+		if !result.HasError() {
+			for _, value := range result.Values {
+				podName := value.Labels["pod"]
+				if podName == "" {
+					podName = value.Labels["pod_name"]
+				}
+				if podName == "" {
+					continue
+				}
+
+				if podData[podName] == nil {
+					podData[podName] = &podMetricData{}
+				}
+				// NaN check: rate division by zero produces NaN when no requests completed
+				if !math.IsNaN(value.Value) && !math.IsInf(value.Value, 0) && value.Value >= 0 {
+					podData[podName].avgTTFT = value.Value
+				}
+			}
+		}
+	}
+
+	// Process running requests results (latency budget)
+	if result := results[registration.QueryRunningRequests]; result != nil {
+		if !result.HasError() {
+			for _, value := range result.Values {
+				podName := value.Labels["pod"]
+				if podName == "" {
+					podName = value.Labels["pod_name"]
+				}
+				if podName == "" {
+					continue
+				}
+
+				if podData[podName] == nil {
+					podData[podName] = &podMetricData{}
+				}
+				if !math.IsNaN(value.Value) && !math.IsInf(value.Value, 0) && value.Value >= 0 {
+					podData[podName].runningRequests = value.Value
+				}
+			}
+		}
+	}
+
+	// Process error rate results (scale-down veto)
+	if result := results[registration.QueryReplicaErrorRate]; result != nil {
+		if !result.HasError() {
+			for _, value := range result.Values {
+				podName := value.Labels["pod"]
+				if podName == "" {
+					podName = value.Labels["pod_name"]
+				}
+				if podName == "" {
+					continue
+				}
+
+				if podData[podName] == nil {
+					podData[podName] = &podMetricData{}
+				}
+				// NaN check: rate division by zero produces NaN when no requests finished
+				if !math.IsNaN(value.Value) && !math.IsInf(value.Value, 0) && value.Value >= 0 {
+					podData[podName].errorRate = math.Min(value.Value, 1)
+				}
+			}
+		}
+	}
+
+	// Attribute node-level GPU utilization to pods (DCGM labels GPUs by node, not pod)
+	if result := results[registration.QueryGPUUtilization]; result != nil && !result.HasError() && len(result.Values) > 0 {
+		podNames := make([]string, 0, len(podData))
+		for podName := range podData {
+			podNames = append(podNames, podName)
+		}
+		for podName, utilization := range source.JoinPodsByNode(ctx, c.k8sClient, namespace, podNames, result.Values) {
+			if !math.IsNaN(utilization) && !math.IsInf(utilization, 0) && utilization >= 0 {
+				podData[podName].gpuUtilization = math.Min(utilization/100, 1)
+			}
+		}
+	}
+
+	// Mark pods on failing GPUs
+	if c.detectGPUFailures {
+		podNames := make([]string, 0, len(podData))
+		for podName := range podData {
+			podNames = append(podNames, podName)
+		}
+		failed := c.failedGPUPods(ctx, namespace, podNames, results[registration.QueryGPUErrors])
+		for podName := range failed {
+			podData[podName].gpuFailed = true
+		}
+		if len(failed) > 0 {
+			logger.Info("Replicas on failing GPUs count without capacity",
+				"model", modelID,
+				"namespace", namespace,
+				"replicas", len(failed))
+		}
+	}
+
 	// Build replica metrics from pod data
 	replicaMetrics := make([]interfaces.ReplicaMetrics, 0, len(podData))
 	collectedAt := time.Now()
 
+	var missingKv, missingQueue, unmatched int
 	for podName, data := range podData {
 		// Skip pods that have no metrics at all
 		if !data.hasKv && !data.hasQueue {
@@ -305,27 +562,36 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		queueLen := data.queueLen
 
 		if !data.hasKv {
-			logger.Info("Pod missing KV cache metrics, using 0",
-				"pod", podName,
-				"model", modelID,
-				"namespace", namespace)
+			missingKv++
+			if detailed {
+				logger.Info("Pod missing KV cache metrics, using 0",
+					"pod", podName,
+					"model", modelID,
+					"namespace", namespace)
+			}
 			kvUsage = 0
 		}
 		if !data.hasQueue {
-			logger.Info("Pod missing queue metrics, using 0",
-				"pod", podName,
-				"model", modelID,
-				"namespace", namespace)
+			missingQueue++
+			if detailed {
+				logger.Info("Pod missing queue metrics, using 0",
+					"pod", podName,
+					"model", modelID,
+					"namespace", namespace)
+			}
 			queueLen = 0
 		}
 
 		// Match Pod to VariantAutoscaling using indexed lookup
-		vaName := c.podVAMapper.FindVAForPod(ctx, podName, namespace, deployments)
+		vaName := c.podVAMapper.FindVAForPod(ctx, podName, namespace, workloads)
 
 		if vaName == "" {
-			logger.Info("Skipping pod that doesn't match any deployment",
-				"pod", podName,
-				"deployments", getDeploymentNames(deployments))
+			unmatched++
+			if detailed {
+				logger.Info("Skipping pod that doesn't match any scale target",
+					"pod", podName,
+					"scaleTargets", getWorkloadNames(workloads))
+			}
 			continue
 		}
 		variantKey := utils.GetNamespacedKey(namespace, vaName)
@@ -383,17 +649,32 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			TokensInUse:           tokensInUse,
 			AvgOutputTokens:       data.avgOutputTokens,
 			AvgInputTokens:        data.avgInputTokens,
+			InputTokensSketch:     data.inputTokensSketch,
+			OutputTokensSketch:    data.outputTokensSketch,
 			PrefixCacheHitRate:    data.prefixCacheHitRate,
-			Metadata: &interfaces.ReplicaMetricsMetadata{
-				CollectedAt:     collectedAt,
-				Age:             0, // Fresh
-				FreshnessStatus: "fresh",
-			},
+			GPUUtilization:        data.gpuUtilization,
+			AvgTTFT:               data.avgTTFT,
+			RunningRequests:       data.runningRequests,
+			ErrorRate:             data.errorRate,
+			GPUFailed:             data.gpuFailed,
+			Metadata:              c.replicaMetadata(collectedAt, data.kvTimestamp, data.queueTimestamp),
 		}
 
 		replicaMetrics = append(replicaMetrics, metric)
 	}
 
+	// Let enrichers add custom fields for custom analyzers
+	enrichment.Apply(ctx, c.enrichers, modelID, namespace, replicaMetrics)
+
+	if !detailed && missingKv+missingQueue+unmatched > 0 {
+		logger.Info("Pods with incomplete metrics (details logged in sampled cycles)",
+			"model", modelID,
+			"namespace", namespace,
+			"missingKvCache", missingKv,
+			"missingQueue", missingQueue,
+			"unmatched", unmatched)
+	}
+
 	logger.V(logging.DEBUG).Info("Collected replica metrics",
 		"modelID", modelID,
 		"namespace", namespace,
@@ -402,6 +683,110 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 	return replicaMetrics, nil
 }
 
+// replicaMetadata returns the freshness of the metrics of a replica from the oldest of
+// their sample timestamps, so that a replica that stopped reporting is told apart from
+// replicas whose samples are current. Zero timestamps are ignored.
+func (c *ReplicaMetricsCollector) replicaMetadata(collectedAt time.Time, timestamps ...time.Time) *interfaces.ReplicaMetricsMetadata {
+	sampledAt := collectedAt
+	for _, ts := range timestamps {
+		if !ts.IsZero() && ts.Before(sampledAt) {
+			sampledAt = ts
+		}
+	}
+	age := collectedAt.Sub(sampledAt)
+	return &interfaces.ReplicaMetricsMetadata{
+		CollectedAt:     sampledAt,
+		Age:             age,
+		FreshnessStatus: c.freshness.DetermineStatus(age),
+	}
+}
+
+// failedGPUPods returns the pods on failing GPUs among the given pods of a namespace: the
+// pods a series of the GPU errors result is attributed to, as GPU utilization is, and the
+// pods on nodes with one of the GPU failure node conditions true.
+func (c *ReplicaMetricsCollector) failedGPUPods(ctx context.Context, namespace string, podNames []string, result *source.MetricResult) map[string]bool {
+	logger := ctrl.LoggerFrom(ctx)
+
+	failed := make(map[string]bool)
+	if result != nil && !result.HasError() && len(result.Values) > 0 {
+		for podName := range source.JoinPodsByNode(ctx, c.k8sClient, namespace, podNames, result.Values) {
+			failed[podName] = true
+		}
+	}
+	if len(c.gpuFailureNodeConditions) == 0 {
+		return failed
+	}
+
+	nodeFailing := make(map[string]bool)
+	for _, podName := range podNames {
+		if failed[podName] {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := c.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, pod); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to get pod to check its node", "pod", podName, "namespace", namespace, "error", err)
+			continue
+		}
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		failing, ok := nodeFailing[nodeName]
+		if !ok {
+			node := &corev1.Node{}
+			if err := c.k8sClient.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to get node to check its GPU conditions", "node", nodeName, "error", err)
+			} else {
+				failing = discovery.HasNodeCondition(node, c.gpuFailureNodeConditions)
+			}
+			nodeFailing[nodeName] = failing
+		}
+		if failing {
+			failed[podName] = true
+		}
+	}
+	return failed
+}
+
+// histogramBuckets groups the bucket rates of a token histogram result by pod.
+// Returns nil when the query failed or returned no values.
+func histogramBuckets(result *source.MetricResult) map[string][]sketch.Bucket {
+	if result == nil || result.HasError() {
+		return nil
+	}
+	buckets := make(map[string][]sketch.Bucket)
+	for _, value := range result.Values {
+		podName := value.Labels["pod"]
+		if podName == "" {
+			podName = value.Labels["pod_name"]
+		}
+		if podName == "" {
+			continue
+		}
+		upperBound, err := strconv.ParseFloat(value.Labels["le"], 64)
+		if err != nil {
+			continue
+		}
+		buckets[podName] = append(buckets[podName], sketch.Bucket{UpperBound: upperBound, Count: value.Value})
+	}
+	return buckets
+}
+
+// tokenSketch builds the token length sketch of a pod from its histogram buckets and
+// caches it. When the buckets hold no requests, returns the cached sketch of the pod,
+// or nil if there is none.
+func (c *ReplicaMetricsCollector) tokenSketch(cache *sketch.Cache, podKey string, buckets []sketch.Bucket) *sketch.TDigest {
+	now := time.Now()
+	if td := sketch.FromHistogram(buckets, sketch.DefaultCompression); td.Count() > 0 {
+		cache.Set(podKey, td, now)
+		return td
+	}
+	if td, ok := cache.Get(podKey, now); ok {
+		return td
+	}
+	return nil
+}
+
 // CollectSchedulerQueueMetrics collects model-level queue metrics from the
 // llm-d inference scheduler flow control layer. These metrics are not per-pod
 // but per-model, representing requests queued upstream before reaching vLLM.
@@ -410,6 +795,7 @@ func (c *ReplicaMetricsCollector) CollectSchedulerQueueMetrics(
 	ctx context.Context,
 	modelID string,
 ) *interfaces.SchedulerQueueMetrics {
+	ctx = logging.IntoModule(ctx, logging.ModuleCollector)
 	logger := ctrl.LoggerFrom(ctx)
 
 	params := map[string]string{
@@ -467,11 +853,74 @@ func (c *ReplicaMetricsCollector) CollectSchedulerQueueMetrics(
 	}
 }
 
-// getDeploymentNames extracts deployment names from the deployments map.
-func getDeploymentNames(deployments map[string]*appsv1.Deployment) []string {
-	names := make([]string, 0, len(deployments))
-	for _, deploy := range deployments {
-		names = append(names, deploy.Name)
+// CollectGatewayTraffic collects the model-level rates of requests the llm-d
+// inference gateway admitted and rejected. The gateway counts every request it
+// received, so the admitted rate is the received rate minus the rejected one.
+// Returns nil (not an error) when gateway metrics are unavailable.
+func (c *ReplicaMetricsCollector) CollectGatewayTraffic(
+	ctx context.Context,
+	modelID string,
+) *interfaces.GatewayTrafficMetrics {
+	ctx = logging.IntoModule(ctx, logging.ModuleCollector)
+	logger := ctrl.LoggerFrom(ctx)
+
+	results, err := c.source.Refresh(ctx, source.RefreshSpec{
+		Queries: []string{
+			registration.QueryGatewayRequestRate,
+			registration.QueryGatewayRejectedRate,
+		},
+		Params: map[string]string{
+			source.ParamModelID: modelID,
+		},
+	})
+	if err != nil {
+		logger.V(logging.DEBUG).Info("Gateway traffic metrics unavailable",
+			"modelID", modelID, "error", err)
+		return nil
+	}
+
+	received, ok := sumResult(results[registration.QueryGatewayRequestRate])
+	if !ok {
+		return nil
+	}
+	rejected, _ := sumResult(results[registration.QueryGatewayRejectedRate])
+	rejected = min(rejected, received)
+
+	logger.V(logging.DEBUG).Info("Collected gateway traffic metrics",
+		"modelID", modelID,
+		"receivedRate", received,
+		"rejectedRate", rejected)
+
+	return &interfaces.GatewayTrafficMetrics{
+		AcceptedRate: received - rejected,
+		RejectedRate: rejected,
+	}
+}
+
+// sumResult sums the finite values of a query result, reporting whether it had any.
+func sumResult(result *source.MetricResult) (float64, bool) {
+	if result == nil || result.HasError() {
+		return 0, false
+	}
+	var sum float64
+	hasData := false
+	for _, value := range result.Values {
+		if !math.IsNaN(value.Value) && !math.IsInf(value.Value, 0) {
+			sum += value.Value
+			hasData = true
+		}
+	}
+	return sum, hasData
+}
+
+// Ensure ReplicaMetricsCollector implements GatewayTrafficCollector
+var _ interfaces.GatewayTrafficCollector = (*ReplicaMetricsCollector)(nil)
+
+// getWorkloadNames extracts scale target names from the workloads map.
+func getWorkloadNames(workloads map[string]interfaces.Workload) []string {
+	names := make([]string, 0, len(workloads))
+	for _, w := range workloads {
+		names = append(names, w.GetName())
 	}
 	return names
 }
@@ -72,6 +72,15 @@ func (c *Cache) Set(key CacheKey, data MetricResult, ttl time.Duration) {
 	c.cache[key] = cached
 }
 
+// Clear removes all entries from the cache and returns how many were removed
+func (c *Cache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := len(c.cache)
+	c.cache = make(map[CacheKey]*CachedValue)
+	return count
+}
+
 // startCleanup runs a background goroutine to periodically clean up expired entries
 func (c *Cache) startCleanup(ctx context.Context) {
 	ticker := time.NewTicker(c.cleanupInterval)
@@ -0,0 +1,96 @@
+package source
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// NodeLabelNames are the labels naming the node of a node-level metric, in order of
+// preference. DCGM exporter sets Hostname to the node name.
+var NodeLabelNames = []string{"node", "Hostname"}
+
+// Pod attribution labels of node-level metrics, in order of preference. Prometheus
+// renames the pod and namespace labels of a metric to exported_pod and exported_namespace
+// when they collide with the labels of the scrape target (the exporter pod).
+var (
+	podLabelNames       = []string{"exported_pod", "pod"}
+	namespaceLabelNames = []string{"exported_namespace", "namespace"}
+)
+
+// NodeOf returns the node name of a metric value, or "" when it has no node label.
+func NodeOf(value MetricValue) string {
+	return firstLabel(value, NodeLabelNames)
+}
+
+// firstLabel returns the first non-empty value of the given labels.
+func firstLabel(value MetricValue, labelNames []string) string {
+	for _, label := range labelNames {
+		if v := value.Labels[label]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// JoinPodsByNode attributes node-level metric values, such as DCGM GPU metrics labeled
+// by node and GPU UUID, to the given pods of a namespace.
+//
+// A value labeled with one of the pods (as DCGM exporter does when pod attribution is
+// enabled) is attributed to that pod. Pods without such values get the other values of
+// the node they run on, resolved from the pod cache. Several values attributed to the
+// same pod (e.g. one per GPU) are averaged.
+//
+// Returns a map from pod name to value, without the pods no value could be attributed to.
+func JoinPodsByNode(ctx context.Context, c client.Client, namespace string, podNames []string, values []MetricValue) map[string]float64 {
+	logger := ctrl.LoggerFrom(ctx)
+
+	wanted := make(map[string]bool, len(podNames))
+	for _, podName := range podNames {
+		wanted[podName] = true
+	}
+
+	podSums := make(map[string]float64)
+	podCounts := make(map[string]int)
+	nodeSums := make(map[string]float64)
+	nodeCounts := make(map[string]int)
+	for _, value := range values {
+		podName := firstLabel(value, podLabelNames)
+		podNamespace := firstLabel(value, namespaceLabelNames)
+		if wanted[podName] && (podNamespace == "" || podNamespace == namespace) {
+			podSums[podName] += value.Value
+			podCounts[podName]++
+			continue
+		}
+		if node := NodeOf(value); node != "" {
+			nodeSums[node] += value.Value
+			nodeCounts[node]++
+		}
+	}
+
+	joined := make(map[string]float64, len(podNames))
+	for _, podName := range podNames {
+		if count := podCounts[podName]; count > 0 {
+			joined[podName] = podSums[podName] / float64(count)
+			continue
+		}
+		if len(nodeCounts) == 0 {
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, pod); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to get pod for node join", "pod", podName, "namespace", namespace, "error", err)
+			continue
+		}
+		node := pod.Spec.NodeName
+		if count := nodeCounts[node]; node != "" && count > 0 {
+			joined[podName] = nodeSums[node] / float64(count)
+		}
+	}
+	return joined
+}
@@ -0,0 +1,77 @@
+package source
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("JoinPodsByNode", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+	)
+
+	createPod := func(name, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: node},
+		}
+	}
+
+	gpuValue := func(value float64, labels map[string]string) MetricValue {
+		return MetricValue{Value: value, Labels: labels}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(createPod("vllm-a", "node-1"), createPod("vllm-b", "node-2"), createPod("vllm-c", "node-3")).
+			Build()
+	})
+
+	It("should average the GPUs of the node a pod runs on", func() {
+		values := []MetricValue{
+			gpuValue(40, map[string]string{"Hostname": "node-1", "gpu": "0"}),
+			gpuValue(80, map[string]string{"Hostname": "node-1", "gpu": "1"}),
+			gpuValue(10, map[string]string{"Hostname": "node-2", "gpu": "0"}),
+		}
+
+		joined := JoinPodsByNode(ctx, k8sClient, "default", []string{"vllm-a", "vllm-b", "vllm-c"}, values)
+
+		Expect(joined).To(Equal(map[string]float64{"vllm-a": 60, "vllm-b": 10}))
+	})
+
+	It("should prefer values labeled with the pod", func() {
+		values := []MetricValue{
+			// Attributed by DCGM, renamed by Prometheus to avoid the exporter pod labels
+			gpuValue(90, map[string]string{"Hostname": "node-1", "pod": "dcgm-exporter-x", "exported_pod": "vllm-a", "exported_namespace": "default"}),
+			// Idle GPU of the same node, labeled with the exporter pod only
+			gpuValue(0, map[string]string{"Hostname": "node-1", "pod": "dcgm-exporter-x", "namespace": "gpu-operator"}),
+			// Same pod name in another namespace
+			gpuValue(50, map[string]string{"Hostname": "node-2", "pod": "vllm-b", "namespace": "other"}),
+		}
+
+		joined := JoinPodsByNode(ctx, k8sClient, "default", []string{"vllm-a", "vllm-b"}, values)
+
+		Expect(joined).To(HaveKeyWithValue("vllm-a", 90.0))
+		Expect(joined).To(HaveKeyWithValue("vllm-b", 50.0), "the value of another namespace is only a node-level value")
+	})
+
+	It("should skip pods missing from the pod cache", func() {
+		values := []MetricValue{gpuValue(40, map[string]string{"node": "node-1"})}
+
+		joined := JoinPodsByNode(ctx, k8sClient, "default", []string{"vllm-missing"}, values)
+
+		Expect(joined).To(BeEmpty())
+	})
+})
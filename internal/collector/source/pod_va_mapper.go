@@ -4,12 +4,15 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/indexers"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
@@ -26,74 +29,91 @@ func NewPodVAMapper(k8sClient client.Client) *PodVAMapper {
 }
 
 // FindVAForPod finds the VariantAutoscaling object for a Pod by:
-// 1. finding the Deployment owning the Pod
-// 2. finding the VariantAutoscaling that targets that Deployment, using indexed lookups.
+// 1. finding the scale target workload owning the Pod: a Deployment or a LeaderWorkerSet
+// 2. finding the VariantAutoscaling that targets that workload, using indexed lookups.
 // Returns the VariantAutoscaling name if found, empty string otherwise.
 func (m *PodVAMapper) FindVAForPod(
 	ctx context.Context,
 	podName string,
 	namespace string,
-	deployments map[string]*appsv1.Deployment,
+	workloads map[string]interfaces.Workload,
 ) string {
 	logger := ctrl.LoggerFrom(ctx)
 
-	deploymentName := m.findDeploymentForPod(ctx, podName, namespace, deployments)
-	if deploymentName == "" {
+	ref, ok := m.findWorkloadForPod(ctx, podName, namespace, workloads)
+	if !ok {
 		return ""
 	}
 
-	// Use indexed lookup for VariantAutoscaling targeting this Deployment
-	va, err := indexers.FindVAForDeployment(ctx, m.k8sClient, deploymentName, namespace)
+	// Use indexed lookup for VariantAutoscaling targeting this workload
+	va, err := indexers.FindVAForScaleTarget(ctx, m.k8sClient, ref, namespace)
 	if err != nil {
-		logger.V(logging.DEBUG).Error(err, "failed to find VariantAutoscaling for deployment", "deployment", deploymentName, "namespace", namespace)
+		logger.V(logging.DEBUG).Error(err, "failed to find VariantAutoscaling for scale target", "kind", ref.Kind, "name", ref.Name, "namespace", namespace)
 		return ""
 	}
 
 	if va == nil {
-		logger.V(logging.DEBUG).Info("no VariantAutoscaling matched for deployment", "deployment", deploymentName, "namespace", namespace)
+		logger.V(logging.DEBUG).Info("no VariantAutoscaling matched for scale target", "kind", ref.Kind, "name", ref.Name, "namespace", namespace)
 		return ""
 	}
 
 	return va.Name
 }
 
-// findDeploymentForPod finds which Deployment owns a Pod by traversing owner references.
-func (m *PodVAMapper) findDeploymentForPod(
+// findWorkloadForPod finds which tracked workload owns a Pod: the LeaderWorkerSet named by
+// its labels, or else the StatefulSet or Deployment found by traversing owner references.
+func (m *PodVAMapper) findWorkloadForPod(
 	ctx context.Context,
 	podName string,
 	namespace string,
-	deployments map[string]*appsv1.Deployment,
-) string {
+	workloads map[string]interfaces.Workload,
+) (autoscalingv1.CrossVersionObjectReference, bool) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	pod := &corev1.Pod{}
 	if err := m.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, pod); err != nil {
 		logger.V(logging.DEBUG).Error(err, "failed to get pod", "pod", podName, "namespace", namespace)
-		return ""
-	}
-
-	owner := metav1.GetControllerOf(pod)
-	if owner == nil || owner.Kind != "ReplicaSet" {
-		logger.V(logging.DEBUG).Info("Pod has no ReplicaSet owner", "pod", podName, "namespace", namespace)
-		return ""
+		return autoscalingv1.CrossVersionObjectReference{}, false
 	}
 
-	rs := &appsv1.ReplicaSet{}
-	if err := m.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, rs); err != nil {
-		logger.V(logging.DEBUG).Error(err, "failed to get ReplicaSet", "replicaset", owner.Name, "namespace", namespace)
-		return ""
+	ref := autoscalingv1.CrossVersionObjectReference{}
+	if lwsName, ok := pod.Labels[constants.LeaderWorkerSetNameLabelKey]; ok {
+		ref = autoscalingv1.CrossVersionObjectReference{
+			APIVersion: constants.LeaderWorkerSetAPIVersion,
+			Kind:       constants.LeaderWorkerSetKind,
+			Name:       lwsName,
+		}
+	} else {
+		owner := metav1.GetControllerOf(pod)
+		if owner != nil && owner.Kind == "StatefulSet" {
+			ref = autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: owner.Name}
+			return ref, isTrackedWorkload(workloads, namespace, ref)
+		}
+		if owner == nil || owner.Kind != "ReplicaSet" {
+			logger.V(logging.DEBUG).Info("Pod has no ReplicaSet or StatefulSet owner", "pod", podName, "namespace", namespace)
+			return ref, false
+		}
+
+		rs := &appsv1.ReplicaSet{}
+		if err := m.k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, rs); err != nil {
+			logger.V(logging.DEBUG).Error(err, "failed to get ReplicaSet", "replicaset", owner.Name, "namespace", namespace)
+			return ref, false
+		}
+
+		rsOwner := metav1.GetControllerOf(rs)
+		if rsOwner == nil || rsOwner.Kind != "Deployment" {
+			logger.V(logging.DEBUG).Info("ReplicaSet has no Deployment owner", "replicaset", owner.Name, "namespace", namespace)
+			return ref, false
+		}
+		ref = autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: rsOwner.Name}
 	}
 
-	rsOwner := metav1.GetControllerOf(rs)
-	if rsOwner == nil || rsOwner.Kind != "Deployment" {
-		logger.V(logging.DEBUG).Info("ReplicaSet has no Deployment owner", "replicaset", owner.Name, "namespace", namespace)
-		return ""
-	}
+	return ref, isTrackedWorkload(workloads, namespace, ref)
+}
 
-	// Verify the Deployment is in our map of tracked Deployments
-	deploymentKey := namespace + "/" + rsOwner.Name
-	if deploy, ok := deployments[deploymentKey]; ok && deploy != nil && deploy.Namespace == namespace {
-		return rsOwner.Name
-	}
-	return ""
+// isTrackedWorkload reports whether the workload referenced by ref is in our map of
+// tracked workloads.
+func isTrackedWorkload(workloads map[string]interfaces.Workload, namespace string, ref autoscalingv1.CrossVersionObjectReference) bool {
+	w, ok := workloads[namespace+"/"+ref.Name]
+	return ok && w != nil && w.GetNamespace() == namespace && w.WorkloadKind() == ref.Kind
 }
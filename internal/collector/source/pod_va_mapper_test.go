@@ -9,24 +9,28 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	llmdv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/indexers"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 )
 
 var _ = Describe("PodVAMapper", func() {
 	var (
 		ctx         context.Context
-		deployments map[string]*appsv1.Deployment
+		deployments map[string]interfaces.Workload
 	)
 
 	BeforeEach(func() {
 		ctx = context.Background()
-		deployments = make(map[string]*appsv1.Deployment)
+		deployments = make(map[string]interfaces.Workload)
 	})
 
 	// Helper function to create a scheme with all required types
@@ -116,7 +120,7 @@ var _ = Describe("PodVAMapper", func() {
 					},
 				},
 			}
-			deployments["default/llama-deploy"] = deployment
+			deployments["default/llama-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			va := createVA("llama-va", "default", "llama-deploy")
 			rs := createReplicaSet("llama-deploy-abc123", "default", "llama-deploy")
@@ -130,6 +134,68 @@ var _ = Describe("PodVAMapper", func() {
 			Expect(result).To(Equal("llama-va"))
 		})
 
+		It("should find VA for a pod owned by a StatefulSet", func() {
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "llama-sts",
+					Namespace: "default",
+				},
+			}
+			deployments["default/llama-sts"] = utils.NewStatefulSetWorkload(statefulSet)
+
+			va := createVA("llama-va", "default", "llama-sts")
+			va.Spec.ScaleTargetRef.Kind = "StatefulSet"
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "llama-sts-0",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "apps/v1",
+							Kind:       "StatefulSet",
+							Name:       "llama-sts",
+							Controller: ptr.To(true),
+						},
+					},
+				},
+			}
+
+			scheme := createScheme()
+			fakeClient := createFakeClientWithIndex(scheme, pod, va)
+
+			mapper := NewPodVAMapper(fakeClient)
+			result := mapper.FindVAForPod(ctx, "llama-sts-0", "default", deployments)
+			Expect(result).To(Equal("llama-va"))
+		})
+
+		It("should find VA for a pod of a LeaderWorkerSet by its labels", func() {
+			lws := &unstructured.Unstructured{}
+			lws.SetGroupVersionKind(utils.LeaderWorkerSetGroupVersion.WithKind(constants.LeaderWorkerSetKind))
+			lws.SetName("deepseek")
+			lws.SetNamespace("default")
+			workload, err := utils.NewLeaderWorkerSetWorkload(lws)
+			Expect(err).NotTo(HaveOccurred())
+			deployments["default/deepseek"] = workload
+
+			va := createVA("deepseek-va", "default", "deepseek")
+			va.Spec.ScaleTargetRef.APIVersion = constants.LeaderWorkerSetAPIVersion
+			va.Spec.ScaleTargetRef.Kind = constants.LeaderWorkerSetKind
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deepseek-0",
+					Namespace: "default",
+					Labels:    map[string]string{constants.LeaderWorkerSetNameLabelKey: "deepseek"},
+				},
+			}
+
+			scheme := createScheme()
+			fakeClient := createFakeClientWithIndex(scheme, pod, va)
+
+			mapper := NewPodVAMapper(fakeClient)
+			result := mapper.FindVAForPod(ctx, "deepseek-0", "default", deployments)
+			Expect(result).To(Equal("deepseek-va"))
+		})
+
 		It("should return empty when pod has no matching deployment", func() {
 			scheme := createScheme()
 			fakeClient := createFakeClientWithIndex(scheme)
@@ -153,7 +219,7 @@ var _ = Describe("PodVAMapper", func() {
 					},
 				},
 			}
-			deployments["default/orphan-deploy"] = deployment
+			deployments["default/orphan-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			rs := createReplicaSet("orphan-deploy-abc123", "default", "orphan-deploy")
 			pod := createPod("orphan-deploy-abc123-xyz", "default", "orphan-deploy-abc123", map[string]string{"app": "orphan"})
@@ -180,7 +246,7 @@ var _ = Describe("PodVAMapper", func() {
 					},
 				},
 			}
-			deployments["default/llama-deploy"] = deployment
+			deployments["default/llama-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			// VA in different namespace should not match
 			va := createVA("llama-va", "production", "llama-deploy")
@@ -201,7 +267,7 @@ var _ = Describe("PodVAMapper", func() {
 			// Setup multiple deployments
 			var objects []client.Object
 			for _, name := range []string{"deploy-a", "deploy-b", "deploy-c"} {
-				deployments["default/"+name] = &appsv1.Deployment{
+				deployments["default/"+name] = utils.NewDeploymentWorkload(&appsv1.Deployment{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      name,
 						Namespace: "default",
@@ -213,7 +279,7 @@ var _ = Describe("PodVAMapper", func() {
 							},
 						},
 					},
-				}
+				})
 				rs := createReplicaSet(name+"-rs", "default", name)
 				objects = append(objects, rs)
 			}
@@ -246,7 +312,7 @@ var _ = Describe("PodVAMapper", func() {
 					},
 				},
 			}
-			deployments["default/cached-deploy"] = deployment
+			deployments["default/cached-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			va := createVA("cached-va", "default", "cached-deploy")
 			rs := createReplicaSet("cached-deploy-rs", "default", "cached-deploy")
@@ -280,7 +346,7 @@ var _ = Describe("PodVAMapper", func() {
 					},
 				},
 			}
-			deployments["default/removable-deploy"] = deployment
+			deployments["default/removable-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			va := createVA("removable-va", "default", "removable-deploy")
 			rs := createReplicaSet("removable-deploy-rs", "default", "removable-deploy")
@@ -310,7 +376,7 @@ var _ = Describe("PodVAMapper", func() {
 					Namespace: "default",
 				},
 			}
-			deployments["default/standalone-deploy"] = deployment
+			deployments["default/standalone-deploy"] = utils.NewDeploymentWorkload(deployment)
 
 			// Pod without owner references (standalone pod)
 			pod := &corev1.Pod{
@@ -336,7 +402,7 @@ var _ = Describe("PodVAMapper", func() {
 					Namespace: "namespace-a",
 				},
 			}
-			deployments["namespace-a/shared-deploy"] = deploymentA
+			deployments["namespace-a/shared-deploy"] = utils.NewDeploymentWorkload(deploymentA)
 
 			// Deployment in namespace-b (same deployment name, different namespace)
 			deploymentB := &appsv1.Deployment{
@@ -345,7 +411,7 @@ var _ = Describe("PodVAMapper", func() {
 					Namespace: "namespace-b",
 				},
 			}
-			deployments["namespace-b/shared-deploy"] = deploymentB
+			deployments["namespace-b/shared-deploy"] = utils.NewDeploymentWorkload(deploymentB)
 
 			// VA in namespace-a targeting shared-deploy
 			vaA := createVA("va-a", "namespace-a", "shared-deploy")
@@ -26,13 +26,26 @@ type PrometheusSourceConfig struct {
 	DefaultTTL time.Duration
 	// QueryTimeout is the timeout for individual Prometheus queries.
 	QueryTimeout time.Duration
+	// Downsampling lets queries over long windows read downsampled data when the backend
+	// is Thanos. Prometheus ignores it.
+	Downsampling bool
+	// AggregationPushdown computes the peak of each series over backfill windows in PromQL
+	// with a max_over_time subquery, so Prometheus returns one sample per series instead
+	// of every point of the window. Backfills fall back to range queries aggregated
+	// locally when the subquery fails, e.g. on backends without subquery support.
+	AggregationPushdown bool
 }
 
+// BackfillStep is the resolution of backfill range queries. Saturation queries report
+// peaks over 1m windows, so a 1m step covers every sample of the backfill window.
+const BackfillStep = time.Minute
+
 // DefaultPrometheusSourceConfig returns sensible defaults.
 func DefaultPrometheusSourceConfig() PrometheusSourceConfig {
 	return PrometheusSourceConfig{
 		DefaultTTL:   30 * time.Second,
 		QueryTimeout: 10 * time.Second,
+		Downsampling: true,
 	}
 }
 
@@ -113,6 +126,141 @@ func (p *PrometheusSource) Refresh(ctx context.Context, spec source.RefreshSpec)
 	return results, nil
 }
 
+// Backfill executes queries as range queries over the given window and caches, for each
+// series, its peak value over the window. It lets the first analysis of a model be based
+// on its recent history rather than on a single sample.
+func (p *PrometheusSource) Backfill(ctx context.Context, spec source.RefreshSpec, window time.Duration) (map[string]*source.MetricResult, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("backfill window must be positive, got %s", window)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queryNames := spec.Queries
+	if len(queryNames) == 0 {
+		queryNames = p.registry.List()
+	}
+
+	results := make(map[string]*source.MetricResult, len(queryNames))
+	for _, queryName := range queryNames {
+		result := p.executeRangeQuery(ctx, queryName, spec.Params, window)
+		results[queryName] = result
+		p.cache.Set(source.BuildCacheKey(queryName, spec.Params), *result, p.config.DefaultTTL)
+	}
+
+	ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Backfilled Prometheus metrics",
+		"window", window,
+		"queriesExecuted", len(queryNames),
+		"queriesSucceeded", countSuccessful(results))
+
+	return results, nil
+}
+
+// executeRangeQuery builds a single query and executes it over the given window,
+// reducing each series to its peak value.
+func (p *PrometheusSource) executeRangeQuery(ctx context.Context, queryName string, params map[string]string, window time.Duration) *source.MetricResult {
+	escapedParams := make(map[string]string, len(params))
+	for k, v := range params {
+		escapedParams[k] = source.EscapePromQLValue(v)
+	}
+
+	queryStr, err := p.registry.Build(queryName, escapedParams)
+	if err != nil {
+		return &source.MetricResult{
+			QueryName:   queryName,
+			CollectedAt: time.Now(),
+			Error:       fmt.Errorf("failed to build query: %w", err),
+		}
+	}
+
+	queryCtx := ctx
+	if p.config.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		queryCtx, cancel = context.WithTimeout(ctx, p.config.QueryTimeout)
+		defer cancel()
+	}
+
+	// Long backfill windows may read downsampled data
+	if p.config.Downsampling {
+		if resolution := utils.SelectMaxSourceResolution(window); resolution != utils.MaxSourceResolutionRaw {
+			queryCtx = utils.WithMaxSourceResolution(queryCtx, resolution)
+		}
+	}
+
+	end := time.Now()
+	if p.config.AggregationPushdown {
+		values, err := p.queryPeakPushdown(queryCtx, queryStr, window, end)
+		if err == nil {
+			return &source.MetricResult{
+				QueryName:   queryName,
+				Values:      values,
+				CollectedAt: time.Now(),
+			}
+		}
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Aggregation pushdown failed, aggregating range query locally",
+			"query", queryName,
+			"error", err)
+	}
+
+	val, warnings, err := p.api.QueryRange(queryCtx, queryStr, promv1.Range{
+		Start: end.Add(-window),
+		End:   end,
+		Step:  BackfillStep,
+	})
+	if err != nil {
+		return &source.MetricResult{
+			QueryName:   queryName,
+			CollectedAt: time.Now(),
+			Error:       fmt.Errorf("range query execution failed: %w", err),
+		}
+	}
+
+	if len(warnings) > 0 {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Prometheus range query warnings",
+			"query", queryName,
+			"warnings", warnings)
+	}
+
+	var values []source.MetricValue
+	if matrix, ok := val.(model.Matrix); ok {
+		values = p.parseMatrixPeak(matrix)
+	}
+
+	return &source.MetricResult{
+		QueryName:   queryName,
+		Values:      values,
+		CollectedAt: time.Now(),
+	}
+}
+
+// queryPeakPushdown returns the peak of each series of a query over the window ending at
+// end, computed by Prometheus with a max_over_time subquery at the resolution of range
+// backfills. Series without samples in the window are absent, as in range queries.
+func (p *PrometheusSource) queryPeakPushdown(ctx context.Context, queryStr string, window time.Duration, end time.Time) ([]source.MetricValue, error) {
+	pushdown := PeakPushdownQuery(queryStr, window)
+	val, warnings, err := p.api.Query(ctx, pushdown, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Prometheus pushdown query warnings",
+			"query", pushdown,
+			"warnings", warnings)
+	}
+	vec, ok := val.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %T of pushdown query", val)
+	}
+	return p.parseVector(vec), nil
+}
+
+// PeakPushdownQuery wraps a query in a max_over_time subquery over the window at the
+// resolution of backfill range queries.
+func PeakPushdownQuery(queryStr string, window time.Duration) string {
+	return fmt.Sprintf("max_over_time((%s)[%s:%s])", queryStr, model.Duration(window), model.Duration(BackfillStep))
+}
+
 // executeQuery builds and executes a single query.
 func (p *PrometheusSource) executeQuery(ctx context.Context, queryName string, params map[string]string) *source.MetricResult {
 	logger := ctrl.LoggerFrom(ctx)
@@ -141,6 +289,11 @@ func (p *PrometheusSource) executeQuery(ctx context.Context, queryName string, p
 		defer cancel()
 	}
 
+	// Let queries over long windows read downsampled data
+	if resolution := p.maxSourceResolution(queryName, params); resolution != utils.MaxSourceResolutionRaw {
+		queryCtx = utils.WithMaxSourceResolution(queryCtx, resolution)
+	}
+
 	// Execute query with backoff
 	val, warnings, err := utils.QueryPrometheusWithBackoff(queryCtx, p.api, queryStr)
 	if err != nil {
@@ -167,6 +320,23 @@ func (p *PrometheusSource) executeQuery(ctx context.Context, queryName string, p
 	}
 }
 
+// maxSourceResolution returns the coarsest resolution a query may read, selected from
+// the length of its range window. Queries without a window read raw data.
+func (p *PrometheusSource) maxSourceResolution(queryName string, params map[string]string) string {
+	if !p.config.Downsampling {
+		return utils.MaxSourceResolutionRaw
+	}
+	query := p.registry.Get(queryName)
+	if query == nil || query.WindowParam == "" {
+		return utils.MaxSourceResolutionRaw
+	}
+	window, err := model.ParseDuration(params[query.WindowParam])
+	if err != nil {
+		return utils.MaxSourceResolutionRaw
+	}
+	return utils.SelectMaxSourceResolution(time.Duration(window))
+}
+
 // parseResult converts Prometheus query result to source.MetricValues.
 func (p *PrometheusSource) parseResult(val model.Value) []source.MetricValue {
 	if val == nil {
@@ -249,6 +419,38 @@ func (p *PrometheusSource) parseMatrix(matrix model.Matrix) []source.MetricValue
 	return values
 }
 
+// parseMatrixPeak parses a Prometheus matrix result (range query).
+// Returns the peak value of each time series, stamped with its latest sample time.
+func (p *PrometheusSource) parseMatrixPeak(matrix model.Matrix) []source.MetricValue {
+	values := make([]source.MetricValue, 0, len(matrix))
+	for _, stream := range matrix {
+		if len(stream.Values) == 0 {
+			continue
+		}
+
+		peak := math.Inf(-1)
+		for _, sample := range stream.Values {
+			value := float64(sample.Value)
+			if !math.IsNaN(value) && value > peak {
+				peak = value
+			}
+		}
+		fixNaN(&peak)
+
+		labels := make(map[string]string)
+		for k, v := range stream.Metric {
+			labels[string(k)] = string(v)
+		}
+
+		values = append(values, source.MetricValue{
+			Value:     peak,
+			Timestamp: stream.Values[len(stream.Values)-1].Timestamp.Time(),
+			Labels:    labels,
+		})
+	}
+	return values
+}
+
 // Get retrieves a cached value for a query with given parameters.
 // The cache key is constructed from both queryName and params.
 // Returns nil if not cached or expired.
@@ -301,6 +503,17 @@ func (p *PrometheusSource) MustGet(ctx context.Context, queryName string, params
 	}
 }
 
+// FlushCache removes all cached query results.
+func (p *PrometheusSource) FlushCache() int {
+	return p.cache.Clear()
+}
+
+// Ensure PrometheusSource can backfill metrics history and flush its cache.
+var (
+	_ source.HistorySource   = (*PrometheusSource)(nil)
+	_ source.FlushableSource = (*PrometheusSource)(nil)
+)
+
 // --- Helpers ---
 
 // fixNaN replaces NaN and Inf values with 0.
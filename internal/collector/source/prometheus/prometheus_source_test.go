@@ -2,6 +2,7 @@ package prometheus
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"time"
 
@@ -15,7 +16,8 @@ import (
 
 // mockPrometheusAPI implements promv1.API for testing
 type mockPrometheusAPI struct {
-	queryFunc func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error)
+	queryFunc      func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error)
+	queryRangeFunc func(ctx context.Context, query string, r v1.Range, opts ...v1.Option) (model.Value, v1.Warnings, error)
 }
 
 func (m *mockPrometheusAPI) Query(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
@@ -58,6 +60,9 @@ func (m *mockPrometheusAPI) QueryExemplars(ctx context.Context, query string, st
 	return nil, nil
 }
 func (m *mockPrometheusAPI) QueryRange(ctx context.Context, query string, r v1.Range, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+	if m.queryRangeFunc != nil {
+		return m.queryRangeFunc(ctx, query, r, opts...)
+	}
 	return nil, nil, nil
 }
 func (m *mockPrometheusAPI) Rules(ctx context.Context) (v1.RulesResult, error) {
@@ -148,6 +153,123 @@ var _ = Describe("PrometheusSource", func() {
 		})
 	})
 
+	Describe("Backfill", func() {
+		var queriedRange v1.Range
+
+		BeforeEach(func() {
+			mockAPI = &mockPrometheusAPI{
+				queryRangeFunc: func(ctx context.Context, query string, r v1.Range, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+					queriedRange = r
+					now := model.TimeFromUnix(time.Now().Unix())
+					return model.Matrix{
+						&model.SampleStream{
+							Metric: model.Metric{"pod": "test-pod-1"},
+							Values: []model.SamplePair{
+								{Timestamp: now.Add(-2 * time.Minute), Value: 0.50},
+								{Timestamp: now.Add(-time.Minute), Value: 0.90},
+								{Timestamp: now, Value: 0.60},
+							},
+						},
+						&model.SampleStream{
+							Metric: model.Metric{"pod": "test-pod-2"},
+						},
+					}, nil, nil
+				},
+			}
+
+			source = NewPrometheusSource(context.Background(), mockAPI, PrometheusSourceConfig{
+				DefaultTTL:   30 * time.Second,
+				QueryTimeout: 5 * time.Second,
+			})
+			registry = source.QueryList()
+			Expect(registry.Register(sourcepkg.QueryTemplate{
+				Name:     "test_query",
+				Type:     sourcepkg.QueryTypePromQL,
+				Template: `test_metric{namespace="{{.namespace}}"}`,
+				Params:   []string{"namespace"},
+			})).To(Succeed())
+		})
+
+		It("should cache the peak of each series over the window", func() {
+			params := map[string]string{"namespace": "test-ns"}
+			results, err := source.Backfill(ctx, sourcepkg.RefreshSpec{Params: params}, 30*time.Minute)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queriedRange.End.Sub(queriedRange.Start)).To(Equal(30 * time.Minute))
+			Expect(queriedRange.Step).To(Equal(BackfillStep))
+
+			result := results["test_query"]
+			Expect(result.Error).NotTo(HaveOccurred())
+			Expect(result.Values).To(HaveLen(1))
+			Expect(result.Values[0].Value).To(Equal(0.90))
+			Expect(result.Values[0].Labels["pod"]).To(Equal("test-pod-1"))
+			Expect(result.Values[0].IsStale(time.Minute)).To(BeFalse())
+
+			cached := source.Get("test_query", params)
+			Expect(cached).NotTo(BeNil())
+			Expect(cached.Result.Values[0].Value).To(Equal(0.90))
+		})
+
+		It("should reject non-positive windows", func() {
+			_, err := source.Backfill(ctx, sourcepkg.RefreshSpec{}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should push the peak aggregation into PromQL", func() {
+			var pushdown string
+			mockAPI.queryFunc = func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+				pushdown = query
+				return model.Vector{
+					&model.Sample{
+						Metric:    model.Metric{"pod": "test-pod-1"},
+						Value:     0.90,
+						Timestamp: model.TimeFromUnix(ts.Unix()),
+					},
+				}, nil, nil
+			}
+			queriedRange = v1.Range{}
+			source = NewPrometheusSource(context.Background(), mockAPI, PrometheusSourceConfig{
+				DefaultTTL:          30 * time.Second,
+				AggregationPushdown: true,
+			})
+			Expect(source.QueryList().Register(sourcepkg.QueryTemplate{
+				Name:     "test_query",
+				Type:     sourcepkg.QueryTypePromQL,
+				Template: `test_metric{namespace="{{.namespace}}"}`,
+				Params:   []string{"namespace"},
+			})).To(Succeed())
+
+			results, err := source.Backfill(ctx, sourcepkg.RefreshSpec{Params: map[string]string{"namespace": "test-ns"}}, 30*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pushdown).To(Equal(`max_over_time((test_metric{namespace="test-ns"})[30m:1m])`))
+			Expect(queriedRange.Step).To(BeZero(), "no range query expected")
+			Expect(results["test_query"].Values).To(HaveLen(1))
+			Expect(results["test_query"].Values[0].Value).To(Equal(0.90))
+		})
+
+		It("should aggregate locally when the pushdown fails", func() {
+			mockAPI.queryFunc = func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+				return nil, nil, fmt.Errorf("bad_data: subqueries are not supported")
+			}
+			source = NewPrometheusSource(context.Background(), mockAPI, PrometheusSourceConfig{
+				DefaultTTL:          30 * time.Second,
+				AggregationPushdown: true,
+			})
+			Expect(source.QueryList().Register(sourcepkg.QueryTemplate{
+				Name:     "test_query",
+				Type:     sourcepkg.QueryTypePromQL,
+				Template: `test_metric{namespace="{{.namespace}}"}`,
+				Params:   []string{"namespace"},
+			})).To(Succeed())
+
+			results, err := source.Backfill(ctx, sourcepkg.RefreshSpec{Params: map[string]string{"namespace": "test-ns"}}, 30*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(queriedRange.Step).To(Equal(BackfillStep))
+			Expect(results["test_query"].Values).To(HaveLen(1))
+			Expect(results["test_query"].Values[0].Value).To(Equal(0.90))
+		})
+	})
+
 	Describe("Caching", func() {
 		var callCount int
 
@@ -0,0 +1,134 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+// TenantLookup returns the Prometheus of a namespace, and whether it has one other than
+// the default Prometheus.
+type TenantLookup func(namespace string) (config.PrometheusTenantEntry, bool)
+
+// TenantConnector returns the API of the Prometheus of a tenant.
+type TenantConnector func(tenant config.PrometheusTenantEntry) (promv1.API, error)
+
+// TenantSource is a MetricsSource that queries the metrics of namespaces with their own
+// Prometheus, as in multi-tenant setups where each team has one, from that Prometheus,
+// and all other metrics from the default source. Queries are routed by their namespace
+// parameter; queries without one go to the default source. The sources of tenants share
+// the query list of the default source, and are replaced when their tenant changes.
+type TenantSource struct {
+	ctx     context.Context
+	def     *PrometheusSource
+	lookup  TenantLookup
+	connect TenantConnector
+
+	mu      sync.Mutex
+	tenants map[string]*tenantSource // by namespace
+}
+
+// tenantSource is the source of the Prometheus of a tenant
+type tenantSource struct {
+	tenant config.PrometheusTenantEntry
+	source *PrometheusSource
+	cancel context.CancelFunc // stops the cache cleanup of source
+}
+
+// NewTenantSource creates a source routing the queries of the namespaces with a tenant
+// to the Prometheus of the tenant, and all other queries to def.
+func NewTenantSource(ctx context.Context, def *PrometheusSource, lookup TenantLookup, connect TenantConnector) *TenantSource {
+	return &TenantSource{
+		ctx:     ctx,
+		def:     def,
+		lookup:  lookup,
+		connect: connect,
+		tenants: make(map[string]*tenantSource),
+	}
+}
+
+// QueryList returns the query registry shared by the sources of all tenants.
+func (t *TenantSource) QueryList() *source.QueryList {
+	return t.def.QueryList()
+}
+
+// Refresh executes the queries on the Prometheus of the namespace of spec.
+func (t *TenantSource) Refresh(ctx context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	src, err := t.sourceFor(spec.Params)
+	if err != nil {
+		return nil, err
+	}
+	return src.Refresh(ctx, spec)
+}
+
+// Backfill executes the range queries on the Prometheus of the namespace of spec.
+func (t *TenantSource) Backfill(ctx context.Context, spec source.RefreshSpec, window time.Duration) (map[string]*source.MetricResult, error) {
+	src, err := t.sourceFor(spec.Params)
+	if err != nil {
+		return nil, err
+	}
+	return src.Backfill(ctx, spec, window)
+}
+
+// Get retrieves a cached value from the source of the namespace of params.
+func (t *TenantSource) Get(queryName string, params map[string]string) *source.CachedValue {
+	src, err := t.sourceFor(params)
+	if err != nil {
+		return nil
+	}
+	return src.Get(queryName, params)
+}
+
+// FlushCache removes the cached results of the default Prometheus and of the tenants.
+func (t *TenantSource) FlushCache() int {
+	flushed := t.def.FlushCache()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ts := range t.tenants {
+		flushed += ts.source.FlushCache()
+	}
+	return flushed
+}
+
+// sourceFor returns the source of the namespace parameter, connecting to the Prometheus
+// of its tenant on first use or when the tenant changed.
+func (t *TenantSource) sourceFor(params map[string]string) (*PrometheusSource, error) {
+	namespace := params[source.ParamNamespace]
+	if namespace == "" {
+		return t.def, nil
+	}
+	tenant, ok := t.lookup(namespace)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ts, exists := t.tenants[namespace]
+	if exists && ok && ts.tenant == tenant {
+		return ts.source, nil
+	}
+	if exists {
+		ts.cancel()
+		delete(t.tenants, namespace)
+	}
+	if !ok {
+		return t.def, nil
+	}
+
+	api, err := t.connect(tenant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the Prometheus of namespace %s: %w", namespace, err)
+	}
+	ctx, cancel := context.WithCancel(t.ctx)
+	src := NewPrometheusSource(ctx, api, t.def.config)
+	src.registry = t.def.registry
+	t.tenants[namespace] = &tenantSource{tenant: tenant, source: src, cancel: cancel}
+	ctrl.Log.Info("Connected to the Prometheus of namespace",
+		"namespace", namespace, "url", tenant.URL, "tenant", tenant.Tenant)
+	return src, nil
+}
@@ -0,0 +1,112 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	sourcepkg "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+var _ = Describe("TenantSource", func() {
+	var (
+		ctx      context.Context
+		tenants  map[string]config.PrometheusTenantEntry
+		connects int
+		source   *TenantSource
+	)
+
+	// apiReturning returns an API answering every query with one sample of value
+	apiReturning := func(value float64) *mockPrometheusAPI {
+		return &mockPrometheusAPI{
+			queryFunc: func(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+				return model.Vector{&model.Sample{
+					Metric:    model.Metric{"pod": "pod-1"},
+					Value:     model.SampleValue(value),
+					Timestamp: model.TimeFromUnix(time.Now().Unix()),
+				}}, nil, nil
+			},
+		}
+	}
+
+	refresh := func(namespace string) (float64, error) {
+		results, err := source.Refresh(ctx, sourcepkg.RefreshSpec{
+			Queries: []string{"test_query"},
+			Params:  map[string]string{sourcepkg.ParamNamespace: namespace},
+		})
+		if err != nil {
+			return 0, err
+		}
+		return results["test_query"].FirstValue().Value, nil
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tenants = map[string]config.PrometheusTenantEntry{}
+		connects = 0
+		def := NewPrometheusSource(ctx, apiReturning(1), DefaultPrometheusSourceConfig())
+		source = NewTenantSource(ctx, def,
+			func(namespace string) (config.PrometheusTenantEntry, bool) {
+				tenant, ok := tenants[namespace]
+				return tenant, ok
+			},
+			func(tenant config.PrometheusTenantEntry) (v1.API, error) {
+				connects++
+				if tenant.URL == "" {
+					return nil, errors.New("no URL")
+				}
+				return apiReturning(2), nil
+			})
+		Expect(source.QueryList().Register(sourcepkg.QueryTemplate{
+			Name:     "test_query",
+			Type:     sourcepkg.QueryTypePromQL,
+			Template: `test_metric{namespace="{{.namespace}}"}`,
+			Params:   []string{sourcepkg.ParamNamespace},
+		})).To(Succeed())
+	})
+
+	It("should query namespaces without a tenant from the default Prometheus", func() {
+		Expect(refresh("team-a")).To(Equal(1.0))
+		Expect(connects).To(Equal(0))
+	})
+
+	It("should query namespaces with a tenant from their Prometheus", func() {
+		tenants["team-a"] = config.PrometheusTenantEntry{Namespace: "team-a", URL: "https://prometheus.team-a:9090"}
+		Expect(refresh("team-a")).To(Equal(2.0))
+		Expect(refresh("team-b")).To(Equal(1.0))
+		Expect(source.Get("test_query", map[string]string{sourcepkg.ParamNamespace: "team-a"}).Result.FirstValue().Value).To(Equal(2.0))
+
+		// Connected once, again when the tenant changes
+		Expect(refresh("team-a")).To(Equal(2.0))
+		Expect(connects).To(Equal(1))
+		tenants["team-a"] = config.PrometheusTenantEntry{Namespace: "team-a", URL: "https://mimir:9090", Tenant: "a"}
+		Expect(refresh("team-a")).To(Equal(2.0))
+		Expect(connects).To(Equal(2))
+
+		delete(tenants, "team-a")
+		Expect(refresh("team-a")).To(Equal(1.0))
+	})
+
+	It("should flush the caches of the default Prometheus and of the tenants", func() {
+		tenants["team-a"] = config.PrometheusTenantEntry{Namespace: "team-a", URL: "https://prometheus.team-a:9090"}
+		Expect(refresh("team-a")).To(Equal(2.0))
+		Expect(refresh("team-b")).To(Equal(1.0))
+
+		Expect(source.FlushCache()).To(Equal(2))
+		Expect(source.Get("test_query", map[string]string{sourcepkg.ParamNamespace: "team-a"})).To(BeNil())
+		Expect(source.Get("test_query", map[string]string{sourcepkg.ParamNamespace: "team-b"})).To(BeNil())
+		Expect(source.FlushCache()).To(Equal(0))
+	})
+
+	It("should fail the queries of a tenant that cannot be connected to", func() {
+		tenants["team-a"] = config.PrometheusTenantEntry{Namespace: "team-a", Tenant: "a"}
+		_, err := refresh("team-a")
+		Expect(err).To(MatchError(ContainSubstring("namespace team-a")))
+	})
+})
@@ -44,15 +44,24 @@ type QueryTemplate struct {
 	Template string
 	// Params lists the parameter names required by this template (e.g., ["namespace", "modelID"]).
 	Params []string
+	// WindowParam optionally names the parameter holding the range window of the query
+	// (a Prometheus duration, e.g. "1h"). Backends that support downsampled data use it
+	// to read long windows at a coarser resolution.
+	WindowParam string
 	// Description documents what this query returns.
 	Description string
 }
 
+// TemplateOverrideFunc returns a template replacing the registered template of a query
+// for the given parameters, and whether there is one.
+type TemplateOverrideFunc func(queryName string, params map[string]string) (string, bool)
+
 // QueryList stores and manages query templates for a metrics source.
 // Each source (PrometheusSource, EPPSource, etc.) has its own QueryList.
 type QueryList struct {
-	mu      sync.RWMutex
-	queries map[string]QueryTemplate
+	mu       sync.RWMutex
+	queries  map[string]QueryTemplate
+	override TemplateOverrideFunc
 }
 
 // NewQueryList creates a new query registry.
@@ -100,11 +109,21 @@ func (r *QueryList) Get(name string) *QueryTemplate {
 	return nil
 }
 
+// SetTemplateOverride sets a function that may replace the registered template of a
+// query when it is built, e.g. to customize label matchers per model.
+// A nil function removes the override.
+func (r *QueryList) SetTemplateOverride(override TemplateOverrideFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.override = override
+}
+
 // Build constructs the final query string by substituting parameters.
 // Uses simple {{.paramName}} placeholder replacement.
 func (r *QueryList) Build(name string, params map[string]string) (string, error) {
 	r.mu.RLock()
 	query, ok := r.queries[name]
+	override := r.override
 	r.mu.RUnlock()
 
 	if !ok {
@@ -118,13 +137,26 @@ func (r *QueryList) Build(name string, params map[string]string) (string, error)
 		}
 	}
 
+	template := query.Template
+	overridden := false
+	if override != nil {
+		if t, ok := override(name, params); ok {
+			template, overridden = t, true
+		}
+	}
+
 	// Substitute parameters in template
-	result := query.Template
+	result := template
 	for key, value := range params {
 		placeholder := "{{." + key + "}}"
 		result = strings.ReplaceAll(result, placeholder, value)
 	}
 
+	// Overrides are user-provided and may reference parameters this query does not have
+	if overridden && strings.Contains(result, "{{.") {
+		return "", fmt.Errorf("template override for query %q references unknown parameters: %s", name, result)
+	}
+
 	return result, nil
 }
 
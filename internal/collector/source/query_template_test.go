@@ -0,0 +1,77 @@
+package source
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QueryList", func() {
+	var queries *QueryList
+
+	BeforeEach(func() {
+		queries = NewQueryList()
+		queries.MustRegister(QueryTemplate{
+			Name:     "queue_length",
+			Type:     QueryTypePromQL,
+			Template: `max by (pod) (vllm:num_requests_waiting{namespace="{{.namespace}}",model_name="{{.modelID}}"})`,
+			Params:   []string{ParamNamespace, ParamModelID},
+		})
+	})
+
+	Describe("Build", func() {
+		It("should substitute parameters in the registered template", func() {
+			query, err := queries.Build("queue_length", map[string]string{ParamNamespace: "ns", ParamModelID: "m"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query).To(Equal(`max by (pod) (vllm:num_requests_waiting{namespace="ns",model_name="m"})`))
+		})
+
+		It("should fail on missing parameters", func() {
+			_, err := queries.Build("queue_length", map[string]string{ParamNamespace: "ns"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail on unknown queries", func() {
+			_, err := queries.Build("unknown", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("SetTemplateOverride", func() {
+		It("should build the override template when there is one", func() {
+			queries.SetTemplateOverride(func(queryName string, params map[string]string) (string, bool) {
+				if queryName == "queue_length" && params[ParamModelID] == "m" {
+					return `sum(vllm:num_requests_waiting{job="custom",model_name="{{.modelID}}"})`, true
+				}
+				return "", false
+			})
+
+			query, err := queries.Build("queue_length", map[string]string{ParamNamespace: "ns", ParamModelID: "m"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query).To(Equal(`sum(vllm:num_requests_waiting{job="custom",model_name="m"})`))
+
+			query, err = queries.Build("queue_length", map[string]string{ParamNamespace: "ns", ParamModelID: "other"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query).To(Equal(`max by (pod) (vllm:num_requests_waiting{namespace="ns",model_name="other"})`))
+		})
+
+		It("should fail when the override references unknown parameters", func() {
+			queries.SetTemplateOverride(func(string, map[string]string) (string, bool) {
+				return `vllm:num_requests_waiting{pod="{{.pod}}"}`, true
+			})
+
+			_, err := queries.Build("queue_length", map[string]string{ParamNamespace: "ns", ParamModelID: "m"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should restore the registered templates when removed", func() {
+			queries.SetTemplateOverride(func(string, map[string]string) (string, bool) {
+				return "up", true
+			})
+			queries.SetTemplateOverride(nil)
+
+			query, err := queries.Build("queue_length", map[string]string{ParamNamespace: "ns", ParamModelID: "m"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(query).To(ContainSubstring("vllm:num_requests_waiting"))
+		})
+	})
+})
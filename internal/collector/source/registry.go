@@ -51,6 +51,21 @@ func (r *SourceRegistry) Get(name string) MetricsSource {
 	return r.sources[name]
 }
 
+// FlushCaches flushes the caches of the registered sources that support it, and returns
+// how many cached results were removed.
+func (r *SourceRegistry) FlushCaches() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flushed := 0
+	for _, s := range r.sources {
+		if flushable, ok := s.(FlushableSource); ok {
+			flushed += flushable.FlushCache()
+		}
+	}
+	return flushed
+}
+
 // List returns all registered source names.
 func (r *SourceRegistry) List() []string {
 	r.mu.RLock()
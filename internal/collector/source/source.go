@@ -28,6 +28,21 @@ type MetricsSource interface {
 	Get(queryName string, params map[string]string) *CachedValue
 }
 
+// HistorySource is implemented by metrics sources that can query past samples.
+type HistorySource interface {
+	// Backfill executes queries over a window of past samples and caches, for each
+	// series, its peak value over the window.
+	// Returns a map of query name to result.
+	Backfill(ctx context.Context, spec RefreshSpec, window time.Duration) (map[string]*MetricResult, error)
+}
+
+// FlushableSource is implemented by metrics sources whose cache can be flushed, so that
+// the next reads query the backend.
+type FlushableSource interface {
+	// FlushCache removes all cached results and returns how many were removed.
+	FlushCache() int
+}
+
 // MetricValue represents a single metric value with its metadata.
 type MetricValue struct {
 	// Value is the metric value (scalar).
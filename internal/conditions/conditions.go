@@ -0,0 +1,147 @@
+// Package conditions sets the conditions of VariantAutoscalings. Each condition type
+// has a fixed set of allowed reasons, and each status transition is logged with the
+// generation it was observed at and counted in the wva_condition_transitions_total metric.
+package conditions
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+// allowedReasons lists the reasons each condition type may be set with.
+var allowedReasons = map[string][]string{
+	v1alpha1.TypeTargetResolved: {
+		v1alpha1.ReasonTargetFound,
+		v1alpha1.ReasonTargetNotFound,
+		v1alpha1.ReasonTargetAmbiguous,
+	},
+	v1alpha1.TypeMetricsAvailable: {
+		v1alpha1.ReasonMetricsFound,
+		v1alpha1.ReasonMetricsMissing,
+		v1alpha1.ReasonMetricsStale,
+		v1alpha1.ReasonPrometheusError,
+	},
+	v1alpha1.TypeOptimizationReady: {
+		v1alpha1.ReasonOptimizationSucceeded,
+		v1alpha1.ReasonOptimizationFailed,
+		v1alpha1.ReasonMetricsUnavailable,
+		v1alpha1.ReasonInvalidConfiguration,
+		v1alpha1.ReasonSkippedProcessing,
+		v1alpha1.ReasonSaturationSafetyOverride,
+		v1alpha1.ReasonSaturationOnlyMode,
+		v1alpha1.ReasonScaleFromZeroMode,
+	},
+	v1alpha1.TypeCapacityCapped: {
+		v1alpha1.ReasonCapacityCeilingApplied,
+		v1alpha1.ReasonWithinCapacity,
+	},
+	v1alpha1.TypeScaleUpLimited: {
+		v1alpha1.ReasonInsufficientCapacity,
+		v1alpha1.ReasonTenantQuota,
+		v1alpha1.ReasonPriorityPreemption,
+		v1alpha1.ReasonNotLimited,
+	},
+	v1alpha1.TypeScaleUpIneffective: {
+		v1alpha1.ReasonNoSaturationImprovement,
+		v1alpha1.ReasonScaleUpRolledBack,
+		v1alpha1.ReasonScaleUpEffective,
+	},
+	v1alpha1.TypeTargetUnschedulable: {
+		v1alpha1.ReasonInsufficientGPU,
+		v1alpha1.ReasonSchedulable,
+	},
+	v1alpha1.TypePDBConflict: {
+		v1alpha1.ReasonPDBMinAvailable,
+		v1alpha1.ReasonPDBSatisfiable,
+	},
+	v1alpha1.TypeBoundsConflict: {
+		v1alpha1.ReasonOutsideHPABounds,
+		v1alpha1.ReasonWithinHPABounds,
+	},
+	v1alpha1.TypeSpecOutOfDate: {
+		v1alpha1.ReasonSpecPending,
+		v1alpha1.ReasonSpecProcessed,
+	},
+	v1alpha1.TypeWarmingUp: {
+		v1alpha1.ReasonWarmUpPeriod,
+		v1alpha1.ReasonWarmedUp,
+	},
+	v1alpha1.TypeModelOverloaded: {
+		v1alpha1.ReasonAllVariantsAtMaxReplicas,
+		v1alpha1.ReasonScalingHeadroom,
+	},
+	v1alpha1.TypeAcceleratorFallback: {
+		v1alpha1.ReasonPreferredAcceleratorExhausted,
+		v1alpha1.ReasonPreferredAcceleratorAvailable,
+	},
+	v1alpha1.TypePolicyCapped: {
+		v1alpha1.ReasonModelReplicaLimitReached,
+		v1alpha1.ReasonWithinModelReplicaLimit,
+	},
+	v1alpha1.TypeCapacityConstrained: {
+		v1alpha1.ReasonInventoryShortage,
+		v1alpha1.ReasonQuota,
+		v1alpha1.ReasonReservation,
+		v1alpha1.ReasonCapacityAvailable,
+	},
+}
+
+// Validate returns an error if the condition type is unknown or does not allow the reason.
+func Validate(conditionType, reason string) error {
+	reasons, ok := allowedReasons[conditionType]
+	if !ok {
+		return fmt.Errorf("unknown condition type %q", conditionType)
+	}
+	for _, allowed := range reasons {
+		if reason == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("reason %q is not allowed for condition type %s", reason, conditionType)
+}
+
+// Set sets a condition on a VariantAutoscaling, observed at its current generation.
+// A condition with a reason its type does not allow is not set, and the error is logged.
+// A transition, i.e. a new condition or a change of status, is logged and counted, and
+// the status of the condition is exported.
+func Set(ctx context.Context, va *v1alpha1.VariantAutoscaling, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	logger := ctrl.LoggerFrom(ctx)
+	if err := Validate(conditionType, reason); err != nil {
+		logger.Error(err, "Not setting condition", "variant", va.Name, "namespace", va.Namespace)
+		return
+	}
+
+	previous := v1alpha1.GetCondition(va, conditionType)
+	transition := previous == nil || previous.Status != status
+
+	v1alpha1.SetCondition(va, conditionType, status, reason, message)
+	if err := metrics.NewMetricsEmitter().EmitConditionStatusMetrics(ctx, va, conditionType, string(status)); err != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit condition status metric", "error", err.Error())
+	}
+	if !transition {
+		return
+	}
+
+	from := metav1.ConditionUnknown
+	if previous != nil {
+		from = previous.Status
+	}
+	logger.V(logging.DEBUG).Info("Condition transitioned",
+		"variant", va.Name,
+		"namespace", va.Namespace,
+		"type", conditionType,
+		"from", from,
+		"to", status,
+		"reason", reason,
+		"observedGeneration", va.Generation)
+	if err := metrics.NewMetricsEmitter().EmitConditionTransitionMetrics(ctx, va, conditionType, string(status), reason); err != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit condition transition metric", "error", err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditionType string
+		reason        string
+		wantErr       bool
+	}{
+		{"allowed", v1alpha1.TypeOptimizationReady, v1alpha1.ReasonScaleFromZeroMode, false},
+		{"reason of another type", v1alpha1.TypeTargetResolved, v1alpha1.ReasonMetricsFound, true},
+		{"capacity constrained by reservation", v1alpha1.TypeCapacityConstrained, v1alpha1.ReasonReservation, false},
+		{"ad-hoc reason", v1alpha1.TypeOptimizationReady, "Whatever", true},
+		{"unknown type", "Unknown", v1alpha1.ReasonTargetFound, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.conditionType, tt.reason); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if err := metrics.InitMetrics(registry); err != nil {
+		t.Fatalf("InitMetrics() error = %v", err)
+	}
+	ctx := context.Background()
+	va := &v1alpha1.VariantAutoscaling{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "prod", Generation: 3}}
+
+	transitions := func(status metav1.ConditionStatus, reason string) float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		for _, family := range families {
+			if family.GetName() != constants.WVAConditionTransitionsTotal {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels[constants.LabelConditionType] == v1alpha1.TypeTargetResolved &&
+					labels[constants.LabelStatus] == string(status) && labels[constants.LabelReason] == reason {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+
+	Set(ctx, va, v1alpha1.TypeTargetResolved, metav1.ConditionFalse, v1alpha1.ReasonTargetNotFound, "Deployment llama not found")
+	Set(ctx, va, v1alpha1.TypeTargetResolved, metav1.ConditionFalse, v1alpha1.ReasonTargetNotFound, "Deployment llama still not found")
+	Set(ctx, va, v1alpha1.TypeTargetResolved, metav1.ConditionTrue, v1alpha1.ReasonTargetFound, "Deployment llama found")
+
+	if got := transitions(metav1.ConditionFalse, v1alpha1.ReasonTargetNotFound); got != 1 {
+		t.Errorf("transitions to False = %v, want 1", got)
+	}
+	if got := transitions(metav1.ConditionTrue, v1alpha1.ReasonTargetFound); got != 1 {
+		t.Errorf("transitions to True = %v, want 1", got)
+	}
+
+	condition := v1alpha1.GetCondition(va, v1alpha1.TypeTargetResolved)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.ObservedGeneration != 3 {
+		t.Errorf("condition = %+v, want True observed at generation 3", condition)
+	}
+
+	Set(ctx, va, v1alpha1.TypeTargetResolved, metav1.ConditionFalse, "Gone", "not allowed")
+	if condition := v1alpha1.GetCondition(va, v1alpha1.TypeTargetResolved); condition.Reason != v1alpha1.ReasonTargetFound {
+		t.Errorf("condition reason = %s, want the disallowed reason not set", condition.Reason)
+	}
+}
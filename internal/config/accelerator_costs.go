@@ -0,0 +1,146 @@
+package config
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultAcceleratorCostConfigMapName is the name of the global ConfigMap that stores
+// the unit cost of each accelerator.
+const DefaultAcceleratorCostConfigMapName = "wva-accelerator-unit-costs"
+
+// AcceleratorCost is the configured cost of one accelerator.
+// Field naming follows the accelerator ConfigMap convention (device and cost keys).
+type AcceleratorCost struct {
+	// Device is the name of the device (card) as reported on the node object
+	Device string `yaml:"device,omitempty" json:"device,omitempty"`
+	// Cost is the cost of one device in cents/hour, as a decimal string
+	Cost string `yaml:"cost" json:"cost"`
+	// Zones overrides the cost of one device in some zones, as decimal strings keyed by
+	// topology.kubernetes.io/zone
+	Zones map[string]string `yaml:"zones,omitempty" json:"zones,omitempty"`
+}
+
+// AcceleratorCosts maps accelerator name (e.g. "A100") to its unit cost in cents/hour per device.
+type AcceleratorCosts map[string]float64
+
+// AcceleratorZoneCosts maps accelerator name to zone to its unit cost in cents/hour per
+// device in that zone, where it differs from the cluster-wide unit cost.
+type AcceleratorZoneCosts map[string]map[string]float64
+
+// ParseAcceleratorCostConfigMap parses the accelerator unit cost ConfigMap.
+// Each key is an accelerator name and each value a YAML AcceleratorCost.
+// Entries that cannot be parsed or have a negative cost are skipped.
+func ParseAcceleratorCostConfigMap(data map[string]string) AcceleratorCosts {
+	out := make(AcceleratorCosts, len(data))
+	for name, entry := range data {
+		var spec AcceleratorCost
+		if err := yaml.Unmarshal([]byte(entry), &spec); err != nil {
+			ctrl.Log.Info("Failed to parse accelerator cost entry, skipping", "accelerator", name, "error", err)
+			continue
+		}
+		cost, err := strconv.ParseFloat(spec.Cost, 64)
+		if err != nil || cost < 0 {
+			ctrl.Log.Info("Invalid accelerator cost, skipping", "accelerator", name, "cost", spec.Cost)
+			continue
+		}
+		out[name] = cost
+	}
+	return out
+}
+
+// ParseAcceleratorZoneCosts parses the per-zone costs of the accelerator unit cost
+// ConfigMap. Zone costs that cannot be parsed or are negative are skipped, as are the
+// entries skipped by ParseAcceleratorCostConfigMap.
+func ParseAcceleratorZoneCosts(data map[string]string) AcceleratorZoneCosts {
+	out := make(AcceleratorZoneCosts)
+	for name, entry := range data {
+		var spec AcceleratorCost
+		if err := yaml.Unmarshal([]byte(entry), &spec); err != nil || len(spec.Zones) == 0 {
+			continue
+		}
+		if cost, err := strconv.ParseFloat(spec.Cost, 64); err != nil || cost < 0 {
+			continue
+		}
+		for zone, value := range spec.Zones {
+			cost, err := strconv.ParseFloat(value, 64)
+			if err != nil || cost < 0 {
+				ctrl.Log.Info("Invalid accelerator zone cost, skipping", "accelerator", name, "zone", zone, "cost", value)
+				continue
+			}
+			if out[name] == nil {
+				out[name] = make(map[string]float64, len(spec.Zones))
+			}
+			out[name][zone] = cost
+		}
+	}
+	return out
+}
+
+// AcceleratorUnitCost returns the configured cost of one device of the given accelerator,
+// and whether a cost is configured.
+// Thread-safe.
+func (c *Config) AcceleratorUnitCost(accelerator string) (float64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cost, ok := c.acceleratorCosts[accelerator]
+	return cost, ok
+}
+
+// AcceleratorZonesUnitCost returns the cost of one device of the given accelerator for
+// replicas restricted to the given zones: the highest of the costs in these zones, so that
+// the cost of a variant is never underestimated. Zones without a cost of their own, or no
+// zones at all, cost the cluster-wide unit cost. It returns whether a cost is configured.
+// Thread-safe.
+func (c *Config) AcceleratorZonesUnitCost(accelerator string, zones []string) (float64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	base, ok := c.acceleratorCosts[accelerator]
+	if !ok {
+		return 0, false
+	}
+	if len(zones) == 0 {
+		return base, true
+	}
+	cost := 0.0
+	for _, zone := range zones {
+		zoneCost, ok := c.acceleratorZoneCosts[accelerator][zone]
+		if !ok {
+			zoneCost = base
+		}
+		cost = max(cost, zoneCost)
+	}
+	return cost, true
+}
+
+// UpdateAcceleratorZoneCosts replaces the per-zone accelerator unit costs.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdateAcceleratorZoneCosts(costs AcceleratorZoneCosts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newCosts := make(AcceleratorZoneCosts, len(costs))
+	for name, zones := range costs {
+		newCosts[name] = make(map[string]float64, len(zones))
+		for zone, cost := range zones {
+			newCosts[name][zone] = cost
+		}
+	}
+	c.acceleratorZoneCosts = newCosts
+}
+
+// UpdateAcceleratorCosts replaces the accelerator unit costs.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdateAcceleratorCosts(costs AcceleratorCosts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newCosts := make(AcceleratorCosts, len(costs))
+	for name, cost := range costs {
+		newCosts[name] = cost
+	}
+	if len(c.acceleratorCosts) != len(newCosts) {
+		ctrl.Log.Info("Updated accelerator unit costs", "oldEntries", len(c.acceleratorCosts), "newEntries", len(newCosts))
+	}
+	c.acceleratorCosts = newCosts
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceleratorCostConfigMap(t *testing.T) {
+	data := map[string]string{
+		"A100":     "device: NVIDIA-A100-PCIE-80GB\ncost: \"40.00\"",
+		"H100":     "device: NVIDIA-H100-SXM5-80GB\ncost: 75.5",
+		"L4":       "cost: not-a-number",
+		"MI300X":   "cost: \"-1\"",
+		"Gaudi-2":  "{invalid",
+		"Free-GPU": "cost: 0",
+	}
+
+	costs := ParseAcceleratorCostConfigMap(data)
+
+	assert.Equal(t, AcceleratorCosts{"A100": 40, "H100": 75.5, "Free-GPU": 0}, costs)
+	assert.Empty(t, ParseAcceleratorCostConfigMap(nil))
+}
+
+func TestConfig_AcceleratorUnitCost(t *testing.T) {
+	cfg := NewTestConfig()
+
+	_, ok := cfg.AcceleratorUnitCost("A100")
+	assert.False(t, ok, "no costs configured")
+
+	costs := AcceleratorCosts{"A100": 40}
+	cfg.UpdateAcceleratorCosts(costs)
+	costs["A100"] = 1 // must not affect the config
+
+	cost, ok := cfg.AcceleratorUnitCost("A100")
+	assert.True(t, ok)
+	assert.Equal(t, 40.0, cost)
+
+	cfg.UpdateAcceleratorCosts(nil)
+	_, ok = cfg.AcceleratorUnitCost("A100")
+	assert.False(t, ok, "costs removed")
+}
+
+func TestParseAcceleratorZoneCosts(t *testing.T) {
+	data := map[string]string{
+		"H100":   "cost: 75\nzones:\n  us-east1-b: \"60\"\n  us-east1-c: \"-1\"\n  us-west1-a: 90",
+		"A100":   "cost: 40",
+		"MI300X": "cost: \"-1\"\nzones:\n  us-east1-b: \"30\"",
+	}
+
+	assert.Equal(t, AcceleratorZoneCosts{"H100": {"us-east1-b": 60, "us-west1-a": 90}}, ParseAcceleratorZoneCosts(data))
+}
+
+func TestConfig_AcceleratorZonesUnitCost(t *testing.T) {
+	cfg := NewTestConfig()
+	cfg.UpdateAcceleratorCosts(AcceleratorCosts{"H100": 75})
+	cfg.UpdateAcceleratorZoneCosts(AcceleratorZoneCosts{"H100": {"us-east1-b": 60, "us-west1-a": 90}})
+
+	tests := []struct {
+		name  string
+		zones []string
+		want  float64
+	}{
+		{name: "unrestricted", want: 75},
+		{name: "cheaper zone", zones: []string{"us-east1-b"}, want: 60},
+		{name: "highest of the zones", zones: []string{"us-east1-b", "us-west1-a"}, want: 90},
+		{name: "zone without cost of its own", zones: []string{"us-east1-b", "us-east1-c"}, want: 75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, ok := cfg.AcceleratorZonesUnitCost("H100", tt.zones)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, cost)
+		})
+	}
+
+	_, ok := cfg.AcceleratorZonesUnitCost("A100", []string{"us-east1-b"})
+	assert.False(t, ok, "no cost configured")
+}
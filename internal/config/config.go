@@ -8,6 +8,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
 // Config is the unified configuration structure for the WVA controller.
@@ -21,9 +22,37 @@ type Config struct {
 	prometheus     prometheusConfig
 	epp            eppConfig
 	features       featureFlagsConfig
+	dampening      dampeningConfig
+	rollout        rolloutConfig
+	verification   scaleUpVerificationConfig
+	prepull        prepullConfig
+	enrichment     enrichmentConfig
+	backpressure   backpressureConfig
+	reconcileQueue reconcileQueueConfig
+	stateSnapshot  stateSnapshotConfig
+	standby        standbyConfig
+	vaGenerator    vaGeneratorConfig
+	showback       showbackConfig
+	concurrency    concurrencyConfig
+	gpuPool        gpuPoolConfig
+	errorBudget    errorBudgetConfig
+	gpuFailure     gpuFailureConfig
+	warmUp         warmUpConfig
+	dryRun         dryRunConfig
+	heatmap        heatmapConfig
+	probe          probeConfig
+	modelState     modelStateConfig
 	saturation     saturationConfig  // namespace-aware
 	scaleToZero    scaleToZeroConfig // namespace-aware
 
+	acceleratorCosts     AcceleratorCosts     // global only
+	acceleratorZoneCosts AcceleratorZoneCosts // global only
+	promqlTemplates      PromQLTemplates      // global only
+	prometheusTenants    PrometheusTenants    // global only
+	modelReplicaLimits   ModelReplicaLimits   // global only
+
+	featureGates map[Feature]bool // resolved state of the known feature gates
+	settings     []Setting        // effective static settings and their sources, as loaded
 }
 
 // configSyncState tracks configuration sync state used for startup/readiness checks.
@@ -47,7 +76,12 @@ type infrastructureConfig struct {
 	enableHTTP2          bool
 	watchNamespace       string
 	loggerVerbosity      int
+	moduleVerbosity      string
+	logDetailSampling    int
+	logRedaction         bool
 	optimizationInterval time.Duration
+	simulator            bool
+	simulatorScenario    string
 }
 
 // tlsConfig holds TLS certificate paths
@@ -72,6 +106,136 @@ type featureFlagsConfig struct {
 	scaleFromZeroMaxConcurrency int
 }
 
+// dampeningConfig holds scaling change dampening (anti-flapping) settings
+type dampeningConfig struct {
+	consecutiveRuns  int
+	replicaThreshold int
+	// scaleDownQuorum and scaleDownQuorumWindow hold scale-downs until quorum of the
+	// last window optimization runs proposed them
+	scaleDownQuorum       int
+	scaleDownQuorumWindow int
+}
+
+// rolloutConfig holds graduated rollout settings for large scale-ups
+type rolloutConfig struct {
+	maxStepReplicas int
+	stepTimeout     time.Duration
+}
+
+// scaleUpVerificationConfig holds scale-up verification and rollback settings
+type scaleUpVerificationConfig struct {
+	timeout        time.Duration
+	minImprovement float64
+	rollback       bool
+}
+
+// DefaultPrepullPauseImage is the image of the container that keeps image pre-pull pods running
+const DefaultPrepullPauseImage = "registry.k8s.io/pause:3.10"
+
+// prepullConfig holds image pre-pull settings for scale-ups
+type prepullConfig struct {
+	enabled    bool
+	ttl        time.Duration
+	pauseImage string
+}
+
+// enrichmentConfig holds replica metrics enrichment settings
+type enrichmentConfig struct {
+	webhookURL     string
+	webhookTimeout time.Duration
+}
+
+// backpressureConfig holds controller back-pressure settings for large fleets
+type backpressureConfig struct {
+	maxQueueDepth   int
+	maxQueryLatency time.Duration
+	maxSlowdown     int
+}
+
+// reconcileQueueConfig holds the prioritization settings of the reconcile queue
+type reconcileQueueConfig struct {
+	prioritySaturation float64
+}
+
+// stateSnapshotConfig holds the settings of the state snapshot handed over on leader change
+type stateSnapshotConfig struct {
+	enabled bool
+	maxAge  time.Duration
+}
+
+// standbyConfig holds the settings of replicas that are not the leader
+type standbyConfig struct {
+	warmup bool
+}
+
+// vaGeneratorConfig holds the settings of the VariantAutoscaling generator
+type vaGeneratorConfig struct {
+	enabled bool
+}
+
+// showbackConfig holds the settings of the showback report of cost and replica-hours
+type showbackConfig struct {
+	labelKeys        []string
+	period           time.Duration
+	configMapEnabled bool
+}
+
+// concurrencyConfig holds the settings of the runtime tuning of the max-num-seqs of replicas
+type concurrencyConfig struct {
+	adminPort int32
+	adminPath string
+	maxDelta  float64
+}
+
+// gpuPoolConfig holds the settings of the coordination of GPU pools shared with other
+// controller instances
+type gpuPoolConfig struct {
+	coordination   string
+	quota          float64
+	leaseNamespace string
+	leaseDuration  time.Duration
+}
+
+// errorBudgetConfig holds the settings of the SLO error budgets of the service classes
+type errorBudgetConfig struct {
+	window    time.Duration
+	objective float64
+	low       float64
+	high      float64
+	bias      float64
+}
+
+// gpuFailureConfig holds the settings of the detection of failing GPUs
+type gpuFailureConfig struct {
+	nodeConditions []string
+}
+
+// warmUpConfig holds the settings of the warm-up period of new VariantAutoscalings
+type warmUpConfig struct {
+	period time.Duration
+}
+
+// dryRunConfig holds the settings of the dry validation of saturation scaling ConfigMaps
+type dryRunConfig struct {
+	retention time.Duration
+}
+
+// heatmapConfig holds the settings of the export of the saturation heatmap of replicas
+type heatmapConfig struct {
+	window time.Duration
+}
+
+// modelStateConfig holds the settings of the garbage collection of the state kept per model
+type modelStateConfig struct {
+	retention time.Duration
+}
+
+// probeConfig holds the settings of the synthetic probes of idle models
+type probeConfig struct {
+	gatewayURL string
+	timeout    time.Duration
+}
+
 // SaturationScalingConfigPerModel represents saturation scaling configuration
 // for all models. Maps model ID (or "default" key) to its configuration.
 type SaturationScalingConfigPerModel map[string]interfaces.SaturationScalingConfig
@@ -83,6 +247,9 @@ type saturationConfig struct {
 
 	// Namespace-local configuration overrides (keyed by namespace name)
 	namespaceConfigs map[string]SaturationScalingConfigPerModel
+
+	// Flag/env overrides of the "default" entries (raw values keyed by field name)
+	defaultOverrides map[string]string
 }
 
 // scaleToZeroConfig holds scale-to-zero configuration (namespace-aware)
@@ -194,6 +361,24 @@ func (c *Config) WatchNamespace() string {
 	return c.infrastructure.watchNamespace
 }
 
+// Simulator returns whether the controller runs in simulator mode, with synthetic
+// metrics and a virtual cluster instead of Prometheus and real workloads.
+// Thread-safe.
+func (c *Config) Simulator() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.infrastructure.simulator
+}
+
+// SimulatorScenario returns the path of the simulator traffic scenario file
+// (empty = built-in scenario).
+// Thread-safe.
+func (c *Config) SimulatorScenario() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.infrastructure.simulatorScenario
+}
+
 // LoggerVerbosity returns the logger verbosity level.
 // Thread-safe.
 func (c *Config) LoggerVerbosity() int {
@@ -202,6 +387,33 @@ func (c *Config) LoggerVerbosity() int {
 	return c.infrastructure.loggerVerbosity
 }
 
+// ModuleVerbosity returns the logger verbosity of the modules that override the
+// logger verbosity level, e.g. {"solver": 5}. Invalid values are rejected by Validate.
+// Thread-safe.
+func (c *Config) ModuleVerbosity() map[string]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	levels, _ := logging.ParseModuleVerbosity(c.infrastructure.moduleVerbosity)
+	return levels
+}
+
+// LogDetailSampling returns N such that per-replica and per-variant detail is logged
+// one in every N optimization cycles; the other cycles log summaries (1 = every cycle).
+// Thread-safe.
+func (c *Config) LogDetailSampling() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.infrastructure.logDetailSampling
+}
+
+// LogRedaction returns true if namespace and model names are redacted from logs.
+// Thread-safe.
+func (c *Config) LogRedaction() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.infrastructure.logRedaction
+}
+
 // ============================================================================
 // TLS Getters (thread-safe)
 // ============================================================================
@@ -306,6 +518,398 @@ func (c *Config) ScaleFromZeroMaxConcurrency() int {
 	return c.features.scaleFromZeroMaxConcurrency
 }
 
+// DampeningConsecutiveRuns returns the number of consecutive optimization runs a
+// scaling change must be proposed for before it is applied (1 = no dampening).
+// Thread-safe.
+func (c *Config) DampeningConsecutiveRuns() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dampening.consecutiveRuns
+}
+
+// DampeningReplicaThreshold returns the change in replicas at or above which a
+// scaling change bypasses dampening (0 = never bypass).
+// Thread-safe.
+func (c *Config) DampeningReplicaThreshold() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dampening.replicaThreshold
+}
+
+// ScaleDownQuorum returns the number of the recent optimization runs that must have
+// proposed a scale-down before it is applied (0 or 1 = no quorum).
+// Thread-safe.
+func (c *Config) ScaleDownQuorum() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dampening.scaleDownQuorum
+}
+
+// ScaleDownQuorumWindow returns the number of recent optimization runs among which the
+// scale-down quorum is counted; a window smaller than the quorum requires consecutive runs.
+// Thread-safe.
+func (c *Config) ScaleDownQuorumWindow() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dampening.scaleDownQuorumWindow
+}
+
+// RolloutMaxStepReplicas returns the most replicas a scale-up may add in one
+// rollout step (0 = scale-ups are applied in a single step).
+// Thread-safe.
+func (c *Config) RolloutMaxStepReplicas() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rollout.maxStepReplicas
+}
+
+// RolloutStepTimeout returns how long the replicas added by a rollout step may take
+// to become healthy before further steps are aborted.
+// Thread-safe.
+func (c *Config) RolloutStepTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rollout.stepTimeout
+}
+
+// ScaleUpVerifyTimeout returns how long after a scale-up its effect on saturation is
+// verified (0 = no verification).
+// Thread-safe.
+func (c *Config) ScaleUpVerifyTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.verification.timeout
+}
+
+// ScaleUpVerifyMinImprovement returns the increase in spare capacity (0.0-1.0) a
+// scale-up must achieve to be considered effective.
+// Thread-safe.
+func (c *Config) ScaleUpVerifyMinImprovement() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.verification.minImprovement
+}
+
+// ScaleUpVerifyRollback returns whether an ineffective scale-up is reverted to the
+// replicas the variant had before it.
+// Thread-safe.
+func (c *Config) ScaleUpVerifyRollback() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.verification.rollback
+}
+
+// PrepullEnabled returns whether the images of opted-in variants are pre-pulled on
+// candidate nodes when they scale up.
+// Thread-safe.
+func (c *Config) PrepullEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prepull.enabled
+}
+
+// PrepullTTL returns how long an image pre-pull DaemonSet may exist before it is deleted.
+// Thread-safe.
+func (c *Config) PrepullTTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prepull.ttl
+}
+
+// PrepullPauseImage returns the image of the container that keeps pre-pull pods running.
+// Thread-safe.
+func (c *Config) PrepullPauseImage() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prepull.pauseImage
+}
+
+// EnrichmentWebhookURL returns the URL of the webhook that adds custom fields to replica
+// metrics before analysis, or "" when there is none.
+// Thread-safe.
+func (c *Config) EnrichmentWebhookURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enrichment.webhookURL
+}
+
+// EnrichmentWebhookTimeout returns the timeout of replica metrics enrichment webhook calls.
+// Thread-safe.
+func (c *Config) EnrichmentWebhookTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enrichment.webhookTimeout
+}
+
+// BackpressureMaxQueueDepth returns the total reconcile queue depth above which the
+// controller lengthens the interval of low-priority variants (0 = not checked).
+// Thread-safe.
+func (c *Config) BackpressureMaxQueueDepth() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.backpressure.maxQueueDepth
+}
+
+// BackpressureMaxQueryLatency returns the metrics query latency above which the
+// controller lengthens the interval of low-priority variants (0 = not checked).
+// Thread-safe.
+func (c *Config) BackpressureMaxQueryLatency() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.backpressure.maxQueryLatency
+}
+
+// BackpressureMaxSlowdown returns the maximum factor by which back-pressure lengthens
+// the interval of low-priority variants.
+// Thread-safe.
+func (c *Config) BackpressureMaxSlowdown() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.backpressure.maxSlowdown
+}
+
+// ReconcilePrioritySaturation returns the last known saturation (0.0-1.0) at or above
+// which a variant is reconciled ahead of the others; variants below it are reconciled last.
+// Thread-safe.
+func (c *Config) ReconcilePrioritySaturation() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.reconcileQueue.prioritySaturation
+}
+
+// StateSnapshotEnabled returns whether the leader saves a snapshot of its state after
+// every optimization cycle, for a new leader to restore.
+// Thread-safe.
+func (c *Config) StateSnapshotEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stateSnapshot.enabled
+}
+
+// StateSnapshotMaxAge returns the age above which a state snapshot is too old to be restored.
+// Thread-safe.
+func (c *Config) StateSnapshotMaxAge() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stateSnapshot.maxAge
+}
+
+// StandbyWarmup returns whether replicas that are not the leader keep the metrics caches
+// warm with read-only background fetching.
+// Thread-safe.
+func (c *Config) StandbyWarmup() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.standby.warmup
+}
+
+// VAGeneratorEnabled returns whether VariantAutoscalings are generated for the variants of
+// InferencePools annotated with a VariantAutoscaling template.
+// Thread-safe.
+func (c *Config) VAGeneratorEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.vaGenerator.enabled
+}
+
+// ShowbackLabelKeys returns the VariantAutoscaling label keys, e.g. a cost center, by
+// which the showback report groups cost and replica-hours within a namespace.
+// Thread-safe. Returns a copy to prevent external modifications.
+func (c *Config) ShowbackLabelKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.showback.labelKeys...)
+}
+
+// ShowbackPeriod returns the length of the periods over which the showback report
+// accumulates cost and replica-hours.
+// Thread-safe.
+func (c *Config) ShowbackPeriod() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.showback.period
+}
+
+// ShowbackConfigMapEnabled returns whether the showback report of each completed period
+// is written to a ConfigMap in every namespace with VariantAutoscalings.
+// Thread-safe.
+func (c *Config) ShowbackConfigMapEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.showback.configMapEnabled
+}
+
+// ConcurrencyAdminPort returns the port of the admin endpoint of the replicas that sets
+// their max-num-seqs at runtime.
+// Thread-safe.
+func (c *Config) ConcurrencyAdminPort() int32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.concurrency.adminPort
+}
+
+// ConcurrencyAdminPath returns the path of the admin endpoint of the replicas that sets
+// their max-num-seqs at runtime.
+// Thread-safe.
+func (c *Config) ConcurrencyAdminPath() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.concurrency.adminPath
+}
+
+// ConcurrencyMaxDelta returns the largest scale-up, as a fraction of the current replicas,
+// absorbed by raising the max-num-seqs of the replicas instead of adding replicas.
+// Thread-safe.
+func (c *Config) ConcurrencyMaxDelta() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.concurrency.maxDelta
+}
+
+// GPUPoolCoordination returns how the GPU limiter shares the GPU pools with the other
+// controller instances: "" (not shared), "quota" or "lease".
+// Thread-safe.
+func (c *Config) GPUPoolCoordination() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gpuPool.coordination
+}
+
+// GPUPoolQuota returns the fraction of each GPU pool the GPU limiter allocates from
+// with "quota" coordination.
+// Thread-safe.
+func (c *Config) GPUPoolQuota() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gpuPool.quota
+}
+
+// GPUPoolLeaseNamespace returns the namespace of the GPU pool lease ConfigMap shared by
+// the controller instances with "lease" coordination, or "" for the controller namespace.
+// Thread-safe.
+func (c *Config) GPUPoolLeaseNamespace() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gpuPool.leaseNamespace
+}
+
+// GPUPoolLeaseDuration returns how long the GPUs recorded by a controller instance in the
+// GPU pool lease are held without being renewed.
+// Thread-safe.
+func (c *Config) GPUPoolLeaseDuration() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gpuPool.leaseDuration
+}
+
+// ErrorBudgetWindow returns the window over which the SLO error budgets of the service
+// classes are tracked.
+// Thread-safe.
+func (c *Config) ErrorBudgetWindow() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.errorBudget.window
+}
+
+// ErrorBudgetObjective returns the fraction of time the SLOs of the service classes must
+// be met; the error budget is the rest of the window.
+// Thread-safe.
+func (c *Config) ErrorBudgetObjective() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.errorBudget.objective
+}
+
+// ErrorBudgetLow returns the remaining fraction of an error budget below which the
+// saturation thresholds of the service class are lowered.
+// Thread-safe.
+func (c *Config) ErrorBudgetLow() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.errorBudget.low
+}
+
+// ErrorBudgetHigh returns the remaining fraction of an error budget above which the
+// saturation thresholds of the service class are raised.
+// Thread-safe.
+func (c *Config) ErrorBudgetHigh() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.errorBudget.high
+}
+
+// ErrorBudgetBias returns the fraction by which the saturation thresholds are lowered or
+// raised by the error budget of the service class.
+// Thread-safe.
+func (c *Config) ErrorBudgetBias() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.errorBudget.bias
+}
+
+// GPUFailureNodeConditions returns the node condition types that mark all GPUs of a node
+// as failing when true, in addition to the GPUs reporting Xid or ECC errors.
+// Thread-safe.
+func (c *Config) GPUFailureNodeConditions() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.gpuFailure.nodeConditions...)
+}
+
+// WarmUpPeriod returns how long after its creation a VariantAutoscaling has its target
+// held at the current replicas while metrics are collected (0 disables the warm-up).
+// Thread-safe.
+func (c *Config) WarmUpPeriod() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.warmUp.period
+}
+
+// DryRunRetention returns how long the metrics of the optimization runs are kept to replay
+// candidate saturation scaling ConfigMaps against (0 disables the dry validation).
+// Thread-safe.
+func (c *Config) DryRunRetention() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dryRun.retention
+}
+
+// SaturationHeatmapWindow returns how long the saturation of each replica is kept for the
+// saturation heatmap endpoint (0 disables the endpoint).
+// Thread-safe.
+func (c *Config) SaturationHeatmapWindow() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.heatmap.window
+}
+
+// ModelStateRetention returns how long the state kept for a model, such as its scale-to-zero
+// request rate, learned capacity and last decisions, outlives the last VariantAutoscaling
+// referencing it (0 keeps it forever).
+// Thread-safe.
+func (c *Config) ModelStateRetention() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.modelState.retention
+}
+
+// ProbeGatewayURL returns the base URL of the inference gateway synthetic probes are sent
+// through ("" disables the prober).
+// Thread-safe.
+func (c *Config) ProbeGatewayURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.probe.gatewayURL
+}
+
+// ProbeTimeout returns the timeout of synthetic probe requests.
+// Thread-safe.
+func (c *Config) ProbeTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.probe.timeout
+}
+
 // SaturationConfig returns the current global saturation scaling configuration.
 // Thread-safe. Returns a copy to prevent external modifications.
 // For namespace-aware lookups, use SaturationConfigForNamespace instead.
@@ -361,7 +965,9 @@ func (c *Config) SaturationConfigForNamespace(namespace string) map[string]inter
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	sourceConfig := c.resolveSaturationConfig(namespace)
-	return copySaturationConfig(sourceConfig)
+	result := copySaturationConfig(sourceConfig)
+	applySaturationDefaultOverrides(result, c.saturation.defaultOverrides)
+	return result
 }
 
 // copySaturationConfig creates a deep copy of the saturation config map.
@@ -553,6 +1159,7 @@ func NewTestConfig() *Config {
 			enableHTTP2:          false,
 			watchNamespace:       "",
 			loggerVerbosity:      0,
+			logDetailSampling:    1,
 			optimizationInterval: 15 * time.Second,
 		},
 		tls: tlsConfig{
@@ -566,6 +1173,77 @@ func NewTestConfig() *Config {
 			limitedModeEnabled:          false,
 			scaleFromZeroMaxConcurrency: 10,
 		},
+		dampening: dampeningConfig{
+			consecutiveRuns:       1,
+			replicaThreshold:      0,
+			scaleDownQuorum:       0,
+			scaleDownQuorumWindow: 0,
+		},
+		rollout: rolloutConfig{
+			maxStepReplicas: 0,
+			stepTimeout:     10 * time.Minute,
+		},
+		verification: scaleUpVerificationConfig{
+			timeout:        0,
+			minImprovement: 0.05,
+			rollback:       false,
+		},
+		prepull: prepullConfig{
+			enabled:    false,
+			ttl:        30 * time.Minute,
+			pauseImage: DefaultPrepullPauseImage,
+		},
+		enrichment: enrichmentConfig{
+			webhookTimeout: 2 * time.Second,
+		},
+		backpressure: backpressureConfig{
+			maxSlowdown: 8,
+		},
+		reconcileQueue: reconcileQueueConfig{
+			prioritySaturation: 0.8,
+		},
+		stateSnapshot: stateSnapshotConfig{
+			enabled: false,
+			maxAge:  10 * time.Minute,
+		},
+		standby: standbyConfig{
+			warmup: false,
+		},
+		vaGenerator: vaGeneratorConfig{
+			enabled: false,
+		},
+		showback: showbackConfig{
+			period:           24 * time.Hour,
+			configMapEnabled: false,
+		},
+		concurrency: concurrencyConfig{
+			adminPort: 8000,
+			adminPath: "/admin/max_num_seqs",
+			maxDelta:  0.25,
+		},
+		gpuPool: gpuPoolConfig{
+			quota:         1.0,
+			leaseDuration: 2 * time.Minute,
+		},
+		errorBudget: errorBudgetConfig{
+			window:    time.Hour,
+			objective: 0.95,
+			low:       0.25,
+			high:      0.75,
+			bias:      0.1,
+		},
+		dryRun: dryRunConfig{
+			retention: 6 * time.Hour,
+		},
+		heatmap: heatmapConfig{
+			window: time.Hour,
+		},
+		probe: probeConfig{
+			timeout: 10 * time.Second,
+		},
+		modelState: modelStateConfig{
+			retention: time.Hour,
+		},
 		saturation: saturationConfig{
 			global:           make(SaturationScalingConfigPerModel),
 			namespaceConfigs: make(map[string]SaturationScalingConfigPerModel),
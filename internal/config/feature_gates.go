@@ -0,0 +1,196 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// Feature gates of the experimental capabilities.
+const (
+	// LimitedMode limits scale-ups to the GPU capacity available in the cluster.
+	LimitedMode Feature = "LimitedMode"
+	// ImagePrepull pre-pulls the images of scaling-up variants on nodes of their accelerator type.
+	ImagePrepull Feature = "ImagePrepull"
+	// StateSnapshot saves the engine state for a new leader to restore.
+	StateSnapshot Feature = "StateSnapshot"
+	// StandbyWarmup lets replicas that are not the leader keep the metrics caches warm.
+	StandbyWarmup Feature = "StandbyWarmup"
+	// VariantAutoscalingGenerator generates the VariantAutoscalings of annotated InferencePools.
+	VariantAutoscalingGenerator Feature = "VariantAutoscalingGenerator"
+	// PodDeletionCost steers scale-downs to the newest and least-warmed replicas with the pod deletion cost.
+	PodDeletionCost Feature = "PodDeletionCost"
+	// ConcurrencyTuning absorbs small scale-ups by raising the max-num-seqs of the replicas at runtime.
+	ConcurrencyTuning Feature = "ConcurrencyTuning"
+	// SLOErrorBudget biases the saturation thresholds of the models of service classes by their SLO error budget.
+	SLOErrorBudget Feature = "SLOErrorBudget"
+	// DrainAwareScaling surges replicas on cordoned and draining nodes ahead of their eviction.
+	DrainAwareScaling Feature = "DrainAwareScaling"
+	// GPUFailureDetection counts replicas on failing GPUs as zero-capacity and excludes
+	// failing GPUs from the limiter inventory.
+	GPUFailureDetection Feature = "GPUFailureDetection"
+	// TrafficWeights recommends the traffic weights of the variants of a model in the
+	// status of their VAs, for the routing layer to split traffic as capacity is scaled.
+	TrafficWeights Feature = "TrafficWeights"
+	// PrometheusRuleAlerts creates a PrometheusRule with curated alerts on the health of
+	// the controller and its variants.
+	PrometheusRuleAlerts Feature = "PrometheusRuleAlerts"
+	// OverloadSignal signals the gateway to shed the load of models whose variants are all
+	// saturated and at their maxReplicas.
+	OverloadSignal Feature = "OverloadSignal"
+	// AcceleratorFallback recommends the replicas the GPU limiter cannot grant on the fallback
+	// accelerator class of variants while their accelerator is exhausted.
+	AcceleratorFallback Feature = "AcceleratorFallback"
+	// EmulatedInventory reads the accelerator inventory from EmulatedNode resources instead
+	// of the cluster nodes, for emulated clusters whose nodes have no GPUs.
+	EmulatedInventory Feature = "EmulatedInventory"
+	// AdminAPI serves the admin API on the metrics server, to trigger reconciles, flush the
+	// metrics caches, put variants in dry-run and fetch the effective configuration at
+	// runtime. Requires secure metrics.
+	AdminAPI Feature = "AdminAPI"
+)
+
+// FeatureStage is the maturity of a feature.
+type FeatureStage string
+
+// Feature stages.
+const (
+	Alpha FeatureStage = "ALPHA"
+	Beta  FeatureStage = "BETA"
+	GA    FeatureStage = "GA"
+)
+
+// FeatureSpec describes a feature gate.
+type FeatureSpec struct {
+	// Default is whether the feature is enabled when neither its gate nor its legacy key is set.
+	Default bool
+	// Stage is the maturity of the feature. GA features cannot be disabled.
+	Stage FeatureStage
+	// LegacyKey is the deprecated setting that enabled the feature before its gate,
+	// honored when the gate is not set.
+	LegacyKey string
+}
+
+// FeatureGate is the state of a feature gate.
+type FeatureGate struct {
+	Name    Feature      `json:"name"`
+	Stage   FeatureStage `json:"stage"`
+	Enabled bool         `json:"enabled"`
+}
+
+// featureGatesKey is the viper key of the feature gates.
+const featureGatesKey = "WVA_FEATURE_GATES"
+
+// knownFeatures lists the feature gates and their specs.
+var knownFeatures = map[Feature]FeatureSpec{
+	LimitedMode:                 {Default: false, Stage: Alpha, LegacyKey: "WVA_LIMITED_MODE"},
+	ImagePrepull:                {Default: false, Stage: Alpha, LegacyKey: "WVA_PREPULL_ENABLED"},
+	StateSnapshot:               {Default: false, Stage: Alpha, LegacyKey: "WVA_STATE_SNAPSHOT_ENABLED"},
+	StandbyWarmup:               {Default: false, Stage: Alpha, LegacyKey: "WVA_STANDBY_WARMUP"},
+	VariantAutoscalingGenerator: {Default: false, Stage: Alpha, LegacyKey: "WVA_VA_GENERATOR_ENABLED"},
+	PodDeletionCost:             {Default: false, Stage: Alpha},
+	ConcurrencyTuning:           {Default: false, Stage: Alpha},
+	SLOErrorBudget:              {Default: false, Stage: Alpha},
+	DrainAwareScaling:           {Default: false, Stage: Alpha},
+	GPUFailureDetection:         {Default: false, Stage: Alpha},
+	TrafficWeights:              {Default: false, Stage: Alpha},
+	PrometheusRuleAlerts:        {Default: false, Stage: Alpha},
+	OverloadSignal:              {Default: false, Stage: Alpha},
+	AcceleratorFallback:         {Default: false, Stage: Alpha},
+	EmulatedInventory:           {Default: false, Stage: Alpha},
+	AdminAPI:                    {Default: false, Stage: Alpha},
+}
+
+// parseFeatureGates parses feature gates in the form "Feature1=true,Feature2=false".
+// Returns an error for an unknown feature, a value that is not a bool, or a GA feature disabled.
+func parseFeatureGates(s string) (map[Feature]bool, error) {
+	gates := make(map[Feature]bool)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("feature gate %q is not in the form Feature=true|false", entry)
+		}
+		feature := Feature(strings.TrimSpace(name))
+		spec, known := knownFeatures[feature]
+		if !known {
+			return nil, fmt.Errorf("unknown feature gate %q", feature)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of feature gate %q: %w", feature, err)
+		}
+		if spec.Stage == GA && !enabled {
+			return nil, fmt.Errorf("feature gate %q is GA and cannot be disabled", feature)
+		}
+		gates[feature] = enabled
+	}
+	return gates, nil
+}
+
+// resolveFeatureGates resolves the state of every known feature gate: the feature gates
+// setting, then the legacy key of the feature if set, then the default of the feature.
+func resolveFeatureGates(v *viper.Viper) (map[Feature]bool, error) {
+	gates, err := parseFeatureGates(v.GetString(featureGatesKey))
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[Feature]bool, len(knownFeatures))
+	for feature, spec := range knownFeatures {
+		if enabled, ok := gates[feature]; ok {
+			resolved[feature] = enabled
+			continue
+		}
+		if spec.LegacyKey != "" && v.GetBool(spec.LegacyKey) != spec.Default {
+			ctrl.Log.Info("Deprecated setting enables a feature, use the feature gate instead",
+				"setting", spec.LegacyKey, "featureGate", fmt.Sprintf("%s=%t", feature, v.GetBool(spec.LegacyKey)))
+			resolved[feature] = v.GetBool(spec.LegacyKey)
+			continue
+		}
+		resolved[feature] = spec.Default
+	}
+	return resolved, nil
+}
+
+// FeatureEnabled returns whether a feature gate is enabled. Unknown features are disabled.
+// Thread-safe.
+func (c *Config) FeatureEnabled(feature Feature) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.featureGates[feature]
+}
+
+// FeatureGates returns the state of all known feature gates, sorted by name.
+// Thread-safe.
+func (c *Config) FeatureGates() []FeatureGate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	gates := make([]FeatureGate, 0, len(knownFeatures))
+	for feature, spec := range knownFeatures {
+		gates = append(gates, FeatureGate{Name: feature, Stage: spec.Stage, Enabled: c.featureGates[feature]})
+	}
+	sort.Slice(gates, func(i, j int) bool { return gates[i].Name < gates[j].Name })
+	return gates
+}
+
+// FeatureGatesHandler serves the state of the feature gates as JSON.
+func FeatureGatesHandler(cfg *Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(cfg.FeatureGates())
+	})
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+func TestParseFeatureGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		gates   string
+		want    map[Feature]bool
+		wantErr bool
+	}{
+		{"empty", "", map[Feature]bool{}, false},
+		{"enabled and disabled", "LimitedMode=true, StateSnapshot=false", map[Feature]bool{LimitedMode: true, StateSnapshot: false}, false},
+		{"unknown feature", "Teleport=true", nil, true},
+		{"not a bool", "LimitedMode=yes please", nil, true},
+		{"no value", "LimitedMode", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFeatureGates(tt.gates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFeatureGates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseFeatureGates() = %v, want %v", got, tt.want)
+			}
+			for feature, enabled := range tt.want {
+				if got[feature] != enabled {
+					t.Errorf("parseFeatureGates()[%s] = %v, want %v", feature, got[feature], enabled)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeatureGates_GACannotBeDisabled(t *testing.T) {
+	knownFeatures["TestGAFeature"] = FeatureSpec{Default: true, Stage: GA}
+	defer delete(knownFeatures, "TestGAFeature")
+
+	if _, err := parseFeatureGates("TestGAFeature=false"); err == nil {
+		t.Error("Expected an error disabling a GA feature")
+	}
+	if _, err := parseFeatureGates("TestGAFeature=true"); err != nil {
+		t.Errorf("Expected no error enabling a GA feature, got %v", err)
+	}
+}
+
+func TestLoad_FeatureGates(t *testing.T) {
+	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
+	_ = os.Setenv("WVA_LIMITED_MODE", "true")
+	_ = os.Setenv("WVA_STATE_SNAPSHOT_ENABLED", "true")
+	defer func() {
+		_ = os.Unsetenv("PROMETHEUS_BASE_URL")
+		_ = os.Unsetenv("WVA_LIMITED_MODE")
+		_ = os.Unsetenv("WVA_STATE_SNAPSHOT_ENABLED")
+	}()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("feature-gates", "", "")
+	_ = fs.Set("feature-gates", "StateSnapshot=false,VariantAutoscalingGenerator=true")
+
+	cfg, err := Load(fs, "")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if !cfg.FeatureEnabled(LimitedMode) || !cfg.LimitedModeEnabled() {
+		t.Error("Expected LimitedMode to be enabled by its legacy env var")
+	}
+	if cfg.FeatureEnabled(StateSnapshot) || cfg.StateSnapshotEnabled() {
+		t.Error("Expected the StateSnapshot gate to take precedence over its legacy env var")
+	}
+	if !cfg.FeatureEnabled(VariantAutoscalingGenerator) || !cfg.VAGeneratorEnabled() {
+		t.Error("Expected VariantAutoscalingGenerator to be enabled by its gate")
+	}
+	if cfg.FeatureEnabled(ImagePrepull) {
+		t.Error("Expected ImagePrepull to be disabled by default")
+	}
+
+	gates := cfg.FeatureGates()
+	if len(gates) != len(knownFeatures) {
+		t.Fatalf("Expected %d feature gates, got %d", len(knownFeatures), len(gates))
+	}
+	for i := 1; i < len(gates); i++ {
+		if gates[i-1].Name >= gates[i].Name {
+			t.Errorf("Expected feature gates sorted by name, got %s before %s", gates[i-1].Name, gates[i].Name)
+		}
+	}
+}
+
+func TestLoad_Validation_FeatureGates(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "Teleport=true"`)
+
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for an unknown feature gate")
+	}
+}
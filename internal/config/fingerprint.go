@@ -0,0 +1,67 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// fingerprintLength is the number of hex digits of a config fingerprint
+const fingerprintLength = 16
+
+// fingerprintedConfig is the configuration a fingerprint covers
+type fingerprintedConfig struct {
+	Settings         []fingerprintedSetting                        `json:"settings"`
+	FeatureGates     map[Feature]bool                              `json:"featureGates"`
+	Saturation       map[string]interfaces.SaturationScalingConfig `json:"saturation"`
+	ScaleToZero      ScaleToZeroConfigData                         `json:"scaleToZero"`
+	AcceleratorCosts AcceleratorCosts                              `json:"acceleratorCosts"`
+	AcceleratorZones AcceleratorZoneCosts                          `json:"acceleratorZoneCosts,omitempty"`
+	PromQLTemplates  PromQLTemplates                               `json:"promqlTemplates"`
+	ReplicaLimits    ModelReplicaLimits                            `json:"modelReplicaLimits,omitempty"`
+}
+
+// fingerprintedSetting is a static setting without its source, so that moving a value
+// between a flag, env and file keeps the fingerprint
+type fingerprintedSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Fingerprint returns a hash of the effective configuration the decisions of the
+// variants in namespace are based on: the static settings, feature gates, accelerator
+// costs, PromQL templates and model replica limits, and the saturation scaling and
+// scale-to-zero configs resolved for the namespace. It changes whenever one of them is
+// hot-reloaded, so that a recommendation can be traced back to the configuration that
+// produced it.
+// Thread-safe.
+func (c *Config) Fingerprint(namespace string) string {
+	saturation := c.SaturationConfigForNamespace(namespace)
+	scaleToZero := c.ScaleToZeroConfigForNamespace(namespace)
+
+	c.mu.RLock()
+	fingerprinted := fingerprintedConfig{
+		Settings:         make([]fingerprintedSetting, 0, len(c.settings)),
+		FeatureGates:     c.featureGates,
+		Saturation:       saturation,
+		ScaleToZero:      scaleToZero,
+		AcceleratorCosts: c.acceleratorCosts,
+		AcceleratorZones: c.acceleratorZoneCosts,
+		PromQLTemplates:  c.promqlTemplates,
+		ReplicaLimits:    c.modelReplicaLimits,
+	}
+	for _, setting := range c.settings {
+		fingerprinted.Settings = append(fingerprinted.Settings, fingerprintedSetting{Key: setting.Key, Value: setting.Value})
+	}
+	// Maps are encoded with sorted keys, so the encoding is deterministic
+	data, err := json.Marshal(fingerprinted)
+	c.mu.RUnlock()
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestFingerprint(t *testing.T) {
+	cfg := NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]interfaces.SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+	})
+
+	initial := cfg.Fingerprint("prod")
+	if len(initial) != fingerprintLength {
+		t.Fatalf("Fingerprint() = %q, want %d hex digits", initial, fingerprintLength)
+	}
+	if got := cfg.Fingerprint("prod"); got != initial {
+		t.Errorf("Fingerprint() is not stable: %q then %q", initial, got)
+	}
+	if got := cfg.Fingerprint("dev"); got != initial {
+		t.Errorf("Fingerprint() of namespaces without overrides differ: %q and %q", initial, got)
+	}
+
+	// Hot reload of the global saturation config
+	cfg.UpdateSaturationConfig(map[string]interfaces.SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.7, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+	})
+	reloaded := cfg.Fingerprint("prod")
+	if reloaded == initial {
+		t.Error("Fingerprint() did not change with the saturation thresholds")
+	}
+
+	// Namespace-local override
+	cfg.UpdateSaturationConfigForNamespace("prod", map[string]interfaces.SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.9, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+	})
+	if cfg.Fingerprint("prod") == reloaded {
+		t.Error("Fingerprint() did not change with the namespace-local override")
+	}
+	if cfg.Fingerprint("dev") != reloaded {
+		t.Error("Fingerprint() of another namespace changed with the namespace-local override")
+	}
+
+	// Accelerator costs
+	before := cfg.Fingerprint("dev")
+	cfg.UpdateAcceleratorCosts(AcceleratorCosts{"H100": 400})
+	if cfg.Fingerprint("dev") == before {
+		t.Error("Fingerprint() did not change with the accelerator costs")
+	}
+}
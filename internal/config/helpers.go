@@ -11,8 +11,13 @@ import (
 const (
 	// DefaultConfigMapName is the default name of the ConfigMap containing autoscaler configuration
 	DefaultConfigMapName = "wva-variantautoscaling-config"
-	// DefaultSaturationConfigMapName is the default name of the ConfigMap for saturation scaling
-	DefaultSaturationConfigMapName = "wva-saturation-scaling-config"
+	// DefaultSaturationConfigMapName is the default name of the ConfigMap for saturation (model) scaling
+	DefaultSaturationConfigMapName = "wva-model-scaling-config"
+	// LegacySaturationConfigMapName is the former default name of the ConfigMap for saturation
+	// scaling, migrated to DefaultSaturationConfigMapName at startup
+	LegacySaturationConfigMapName = "wva-saturation-scaling-config"
+	// DefaultStateSnapshotConfigMapName is the default name of the ConfigMap holding the state snapshot
+	DefaultStateSnapshotConfigMapName = "wva-state-snapshot"
 	// DefaultNamespace is the default namespace for the controller
 	DefaultNamespace = "workload-variant-autoscaler-system"
 )
@@ -95,3 +100,12 @@ func SaturationConfigMapName() string {
 	}
 	return DefaultSaturationConfigMapName
 }
+
+// StateSnapshotConfigMapName returns the name of the ConfigMap in which the leader saves
+// its state snapshot, from environment variable or default.
+func StateSnapshotConfigMapName() string {
+	if name := os.Getenv("STATE_SNAPSHOT_CONFIG_MAP_NAME"); name != "" {
+		return name
+	}
+	return DefaultStateSnapshotConfigMapName
+}
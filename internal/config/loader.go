@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -10,6 +11,7 @@ import (
 )
 
 // flagBindings maps viper keys (= env var names = config file keys) to pflag names.
+// The flags of the saturation default overrides are added by reflection (see overrides.go).
 var flagBindings = map[string]string{
 	"METRICS_BIND_ADDRESS":           "metrics-bind-address",
 	"HEALTH_PROBE_BIND_ADDRESS":      "health-probe-bind-address",
@@ -22,12 +24,23 @@ var flagBindings = map[string]string{
 	"ENABLE_HTTP2":                   "enable-http2",
 	"WATCH_NAMESPACE":                "watch-namespace",
 	"V":                              "v",
+	"WVA_MODULE_VERBOSITY":           "module-verbosity",
 	"WEBHOOK_CERT_PATH":              "webhook-cert-path",
 	"WEBHOOK_CERT_NAME":              "webhook-cert-name",
 	"WEBHOOK_CERT_KEY":               "webhook-cert-key",
 	"METRICS_CERT_PATH":              "metrics-cert-path",
 	"METRICS_CERT_NAME":              "metrics-cert-name",
 	"METRICS_CERT_KEY":               "metrics-cert-key",
+	"WVA_SIMULATOR":                  "simulator",
+	"WVA_SIMULATOR_SCENARIO":         "simulator-scenario",
+	"WVA_FEATURE_GATES":              "feature-gates",
+
+	"PROMETHEUS_METRICS_CACHE_TTL":                   "prometheus-metrics-cache-ttl",
+	"PROMETHEUS_METRICS_CACHE_CLEANUP_INTERVAL":      "prometheus-metrics-cache-cleanup-interval",
+	"PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL":        "prometheus-metrics-cache-fetch-interval",
+	"PROMETHEUS_METRICS_CACHE_FRESH_THRESHOLD":       "prometheus-metrics-cache-fresh-threshold",
+	"PROMETHEUS_METRICS_CACHE_STALE_THRESHOLD":       "prometheus-metrics-cache-stale-threshold",
+	"PROMETHEUS_METRICS_CACHE_UNAVAILABLE_THRESHOLD": "prometheus-metrics-cache-unavailable-threshold",
 }
 
 // Load loads and validates the unified configuration.
@@ -74,6 +87,9 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 	v.SetDefault("ENABLE_HTTP2", false)
 	v.SetDefault("WATCH_NAMESPACE", "")
 	v.SetDefault("V", 0)
+	v.SetDefault("WVA_MODULE_VERBOSITY", "")
+	v.SetDefault("WVA_LOG_DETAIL_SAMPLING", 1)
+	v.SetDefault("WVA_LOG_REDACT", false)
 	v.SetDefault("WEBHOOK_CERT_PATH", "")
 	v.SetDefault("WEBHOOK_CERT_NAME", "tls.crt")
 	v.SetDefault("WEBHOOK_CERT_KEY", "tls.key")
@@ -83,8 +99,77 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 	v.SetDefault("WVA_SCALE_TO_ZERO", false)
 	v.SetDefault("WVA_LIMITED_MODE", false)
 	v.SetDefault("SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY", 10)
+	v.SetDefault("WVA_DAMPENING_CONSECUTIVE_RUNS", 1)
+	v.SetDefault("WVA_DAMPENING_REPLICA_THRESHOLD", 0)
+	v.SetDefault("WVA_SCALE_DOWN_QUORUM", 0)
+	v.SetDefault("WVA_SCALE_DOWN_QUORUM_WINDOW", 0)
+	v.SetDefault("WVA_ROLLOUT_MAX_STEP_REPLICAS", 0)
+	v.SetDefault("WVA_ROLLOUT_STEP_TIMEOUT", 10*time.Minute)
+	v.SetDefault("WVA_SCALE_UP_VERIFY_TIMEOUT", 0)
+	v.SetDefault("WVA_SCALE_UP_VERIFY_MIN_IMPROVEMENT", 0.05)
+	v.SetDefault("WVA_SCALE_UP_VERIFY_ROLLBACK", false)
+	v.SetDefault("WVA_PREPULL_ENABLED", false)
+	v.SetDefault("WVA_PREPULL_TTL", 30*time.Minute)
+	v.SetDefault("WVA_PREPULL_PAUSE_IMAGE", DefaultPrepullPauseImage)
+	v.SetDefault("WVA_ENRICHMENT_WEBHOOK_URL", "")
+	v.SetDefault("WVA_ENRICHMENT_WEBHOOK_TIMEOUT", 2*time.Second)
+	v.SetDefault("WVA_BACKPRESSURE_MAX_QUEUE_DEPTH", 0)
+	v.SetDefault("WVA_BACKPRESSURE_MAX_QUERY_LATENCY", 0)
+	v.SetDefault("WVA_BACKPRESSURE_MAX_SLOWDOWN", 8)
+	v.SetDefault("WVA_RECONCILE_PRIORITY_SATURATION", 0.8)
+	v.SetDefault("WVA_STATE_SNAPSHOT_ENABLED", false)
+	v.SetDefault("WVA_STATE_SNAPSHOT_MAX_AGE", 10*time.Minute)
+	v.SetDefault("WVA_STANDBY_WARMUP", false)
+	v.SetDefault("WVA_VA_GENERATOR_ENABLED", false)
+	v.SetDefault("WVA_SHOWBACK_LABELS", "")
+	v.SetDefault("WVA_SHOWBACK_PERIOD", 24*time.Hour)
+	v.SetDefault("WVA_SHOWBACK_CONFIGMAP_ENABLED", false)
+	v.SetDefault("WVA_CONCURRENCY_ADMIN_PORT", 8000)
+	v.SetDefault("WVA_CONCURRENCY_ADMIN_PATH", "/admin/max_num_seqs")
+	v.SetDefault("WVA_CONCURRENCY_MAX_DELTA", 0.25)
+	v.SetDefault("WVA_GPU_POOL_COORDINATION", "")
+	v.SetDefault("WVA_GPU_POOL_QUOTA", 1.0)
+	v.SetDefault("WVA_GPU_POOL_LEASE_NAMESPACE", "")
+	v.SetDefault("WVA_GPU_POOL_LEASE_DURATION", 2*time.Minute)
+	v.SetDefault("WVA_ERROR_BUDGET_WINDOW", time.Hour)
+	v.SetDefault("WVA_ERROR_BUDGET_OBJECTIVE", 0.95)
+	v.SetDefault("WVA_ERROR_BUDGET_LOW", 0.25)
+	v.SetDefault("WVA_ERROR_BUDGET_HIGH", 0.75)
+	v.SetDefault("WVA_ERROR_BUDGET_BIAS", 0.1)
+	v.SetDefault("WVA_GPU_FAILURE_NODE_CONDITIONS", "")
+	v.SetDefault("WVA_WARM_UP_PERIOD", 0)
+	v.SetDefault("WVA_DRY_RUN_RETENTION", 6*time.Hour)
+	v.SetDefault("WVA_SATURATION_HEATMAP_WINDOW", time.Hour)
+	v.SetDefault("WVA_MODEL_STATE_RETENTION", time.Hour)
+	v.SetDefault("WVA_PROBE_GATEWAY_URL", "")
+	v.SetDefault("WVA_PROBE_TIMEOUT", 10*time.Second)
 	v.SetDefault("EPP_METRIC_READER_BEARER_TOKEN", "")
+	v.SetDefault("PROMETHEUS_BASE_URL", "")
+	v.SetDefault("PROMETHEUS_BEARER_TOKEN", "")
+	v.SetDefault("PROMETHEUS_TOKEN_PATH", "")
+	v.SetDefault("PROMETHEUS_TLS_INSECURE_SKIP_VERIFY", false)
+	v.SetDefault("PROMETHEUS_CA_CERT_PATH", "")
+	v.SetDefault("PROMETHEUS_CLIENT_CERT_PATH", "")
+	v.SetDefault("PROMETHEUS_CLIENT_KEY_PATH", "")
+	v.SetDefault("PROMETHEUS_SERVER_NAME", "")
+	v.SetDefault("PROMETHEUS_AUTH_TYPE", "")
+	v.SetDefault("PROMETHEUS_SIGV4_REGION", "")
+	v.SetDefault("PROMETHEUS_SIGV4_SERVICE", "aps")
+	v.SetDefault("PROMETHEUS_AGGREGATION_PUSHDOWN", false)
+	cacheDefaults := defaultPrometheusCacheConfig()
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_TTL", cacheDefaults.TTL.String())
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_CLEANUP_INTERVAL", cacheDefaults.CleanupInterval.String())
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL", cacheDefaults.FetchInterval.String())
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_FRESH_THRESHOLD", cacheDefaults.FreshnessThresholds.FreshThreshold.String())
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_STALE_THRESHOLD", cacheDefaults.FreshnessThresholds.StaleThreshold.String())
+	v.SetDefault("PROMETHEUS_METRICS_CACHE_UNAVAILABLE_THRESHOLD", cacheDefaults.FreshnessThresholds.UnavailableThreshold.String())
+	for _, o := range saturationDefaultOverrides {
+		v.SetDefault(o.key, "")
+	}
 	v.SetDefault("GLOBAL_OPT_INTERVAL", "60s")
+	v.SetDefault("WVA_SIMULATOR", false)
+	v.SetDefault("WVA_SIMULATOR_SCENARIO", "")
+	v.SetDefault(featureGatesKey, "")
 
 	// Load from config file (mounted in the container) — sits between env and defaults in precedence
 	if configFilePath != "" {
@@ -107,6 +192,12 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 		}
 	}
 
+	featureGates, err := resolveFeatureGates(v)
+	if err != nil {
+		return err
+	}
+	cfg.featureGates = featureGates
+
 	// Read resolved values into Config
 	cfg.infrastructure = infrastructureConfig{
 		metricsAddr:          v.GetString("METRICS_BIND_ADDRESS"),
@@ -121,7 +212,12 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 		enableHTTP2:          v.GetBool("ENABLE_HTTP2"),
 		watchNamespace:       v.GetString("WATCH_NAMESPACE"),
 		loggerVerbosity:      v.GetInt("V"),
+		moduleVerbosity:      v.GetString("WVA_MODULE_VERBOSITY"),
+		logDetailSampling:    v.GetInt("WVA_LOG_DETAIL_SAMPLING"),
+		logRedaction:         v.GetBool("WVA_LOG_REDACT"),
 		optimizationInterval: v.GetDuration("GLOBAL_OPT_INTERVAL"),
+		simulator:            v.GetBool("WVA_SIMULATOR"),
+		simulatorScenario:    v.GetString("WVA_SIMULATOR_SCENARIO"),
 	}
 
 	cfg.tls = tlsConfig{
@@ -135,13 +231,122 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 
 	cfg.features = featureFlagsConfig{
 		scaleToZeroEnabled:          v.GetBool("WVA_SCALE_TO_ZERO"),
-		limitedModeEnabled:          v.GetBool("WVA_LIMITED_MODE"),
+		limitedModeEnabled:          featureGates[LimitedMode],
 		scaleFromZeroMaxConcurrency: v.GetInt("SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY"),
 	}
 
+	cfg.dampening = dampeningConfig{
+		consecutiveRuns:       v.GetInt("WVA_DAMPENING_CONSECUTIVE_RUNS"),
+		replicaThreshold:      v.GetInt("WVA_DAMPENING_REPLICA_THRESHOLD"),
+		scaleDownQuorum:       v.GetInt("WVA_SCALE_DOWN_QUORUM"),
+		scaleDownQuorumWindow: v.GetInt("WVA_SCALE_DOWN_QUORUM_WINDOW"),
+	}
+
+	cfg.rollout = rolloutConfig{
+		maxStepReplicas: v.GetInt("WVA_ROLLOUT_MAX_STEP_REPLICAS"),
+		stepTimeout:     v.GetDuration("WVA_ROLLOUT_STEP_TIMEOUT"),
+	}
+
+	cfg.verification = scaleUpVerificationConfig{
+		timeout:        v.GetDuration("WVA_SCALE_UP_VERIFY_TIMEOUT"),
+		minImprovement: v.GetFloat64("WVA_SCALE_UP_VERIFY_MIN_IMPROVEMENT"),
+		rollback:       v.GetBool("WVA_SCALE_UP_VERIFY_ROLLBACK"),
+	}
+
+	cfg.prepull = prepullConfig{
+		enabled:    featureGates[ImagePrepull],
+		ttl:        v.GetDuration("WVA_PREPULL_TTL"),
+		pauseImage: v.GetString("WVA_PREPULL_PAUSE_IMAGE"),
+	}
+
+	cfg.enrichment = enrichmentConfig{
+		webhookURL:     v.GetString("WVA_ENRICHMENT_WEBHOOK_URL"),
+		webhookTimeout: v.GetDuration("WVA_ENRICHMENT_WEBHOOK_TIMEOUT"),
+	}
+
+	cfg.backpressure = backpressureConfig{
+		maxQueueDepth:   v.GetInt("WVA_BACKPRESSURE_MAX_QUEUE_DEPTH"),
+		maxQueryLatency: v.GetDuration("WVA_BACKPRESSURE_MAX_QUERY_LATENCY"),
+		maxSlowdown:     v.GetInt("WVA_BACKPRESSURE_MAX_SLOWDOWN"),
+	}
+
+	cfg.reconcileQueue = reconcileQueueConfig{
+		prioritySaturation: v.GetFloat64("WVA_RECONCILE_PRIORITY_SATURATION"),
+	}
+
+	cfg.stateSnapshot = stateSnapshotConfig{
+		enabled: featureGates[StateSnapshot],
+		maxAge:  v.GetDuration("WVA_STATE_SNAPSHOT_MAX_AGE"),
+	}
+
+	cfg.standby = standbyConfig{
+		warmup: featureGates[StandbyWarmup],
+	}
+
+	cfg.vaGenerator = vaGeneratorConfig{
+		enabled: featureGates[VariantAutoscalingGenerator],
+	}
+
+	cfg.showback = showbackConfig{
+		labelKeys:        parseLabelKeys(v.GetString("WVA_SHOWBACK_LABELS")),
+		period:           v.GetDuration("WVA_SHOWBACK_PERIOD"),
+		configMapEnabled: v.GetBool("WVA_SHOWBACK_CONFIGMAP_ENABLED"),
+	}
+
+	cfg.concurrency = concurrencyConfig{
+		adminPort: v.GetInt32("WVA_CONCURRENCY_ADMIN_PORT"),
+		adminPath: v.GetString("WVA_CONCURRENCY_ADMIN_PATH"),
+		maxDelta:  v.GetFloat64("WVA_CONCURRENCY_MAX_DELTA"),
+	}
+
+	cfg.gpuPool = gpuPoolConfig{
+		coordination:   strings.TrimSpace(v.GetString("WVA_GPU_POOL_COORDINATION")),
+		quota:          v.GetFloat64("WVA_GPU_POOL_QUOTA"),
+		leaseNamespace: v.GetString("WVA_GPU_POOL_LEASE_NAMESPACE"),
+		leaseDuration:  v.GetDuration("WVA_GPU_POOL_LEASE_DURATION"),
+	}
+
+	cfg.errorBudget = errorBudgetConfig{
+		window:    v.GetDuration("WVA_ERROR_BUDGET_WINDOW"),
+		objective: v.GetFloat64("WVA_ERROR_BUDGET_OBJECTIVE"),
+		low:       v.GetFloat64("WVA_ERROR_BUDGET_LOW"),
+		high:      v.GetFloat64("WVA_ERROR_BUDGET_HIGH"),
+		bias:      v.GetFloat64("WVA_ERROR_BUDGET_BIAS"),
+	}
+
+	cfg.gpuFailure = gpuFailureConfig{
+		nodeConditions: parseLabelKeys(v.GetString("WVA_GPU_FAILURE_NODE_CONDITIONS")),
+	}
+
+	cfg.warmUp = warmUpConfig{
+		period: v.GetDuration("WVA_WARM_UP_PERIOD"),
+	}
+
+	cfg.dryRun = dryRunConfig{
+		retention: v.GetDuration("WVA_DRY_RUN_RETENTION"),
+	}
+
+	cfg.heatmap = heatmapConfig{
+		window: v.GetDuration("WVA_SATURATION_HEATMAP_WINDOW"),
+	}
+
+	cfg.modelState = modelStateConfig{
+		retention: v.GetDuration("WVA_MODEL_STATE_RETENTION"),
+	}
+
+	cfg.probe = probeConfig{
+		gatewayURL: v.GetString("WVA_PROBE_GATEWAY_URL"),
+		timeout:    v.GetDuration("WVA_PROBE_TIMEOUT"),
+	}
+
+	saturationDefaults, err := parseSaturationDefaultOverrides(v)
+	if err != nil {
+		return err
+	}
 	cfg.saturation = saturationConfig{
 		global:           make(SaturationScalingConfigPerModel),
 		namespaceConfigs: make(map[string]SaturationScalingConfigPerModel),
+		defaultOverrides: saturationDefaults,
 	}
 
 	cfg.scaleToZero = scaleToZeroConfig{
@@ -155,8 +360,9 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 	cfg.epp.metricReaderBearerToken = v.GetString("EPP_METRIC_READER_BEARER_TOKEN")
 
 	// Prometheus connection config from config file / env
+	// (not needed in simulator mode, where metrics are synthetic)
 	promBaseURL := v.GetString("PROMETHEUS_BASE_URL")
-	if promBaseURL == "" {
+	if promBaseURL == "" && !cfg.infrastructure.simulator {
 		return fmt.Errorf("prometheus configuration is required but not found. " +
 			"set PROMETHEUS_BASE_URL in config file or environment variable")
 	}
@@ -168,6 +374,12 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 	cfg.prometheus.clientCertPath = v.GetString("PROMETHEUS_CLIENT_CERT_PATH")
 	cfg.prometheus.clientKeyPath = v.GetString("PROMETHEUS_CLIENT_KEY_PATH")
 	cfg.prometheus.serverName = v.GetString("PROMETHEUS_SERVER_NAME")
+	cfg.prometheus.authType = strings.TrimSpace(v.GetString("PROMETHEUS_AUTH_TYPE"))
+	cfg.prometheus.sigV4Region = v.GetString("PROMETHEUS_SIGV4_REGION")
+	cfg.prometheus.sigV4Service = v.GetString("PROMETHEUS_SIGV4_SERVICE")
+	cfg.prometheus.aggregationPushdown = v.GetBool("PROMETHEUS_AGGREGATION_PUSHDOWN")
+
+	cfg.settings = effectiveSettings(v, flagSet)
 	return nil
 }
 
@@ -196,6 +408,17 @@ func parsePrometheusCacheConfigFromViper(v *viper.Viper) *CacheConfig {
 	return config
 }
 
+// parseLabelKeys parses a comma-separated list, e.g. of label keys, dropping empty entries.
+func parseLabelKeys(s string) []string {
+	var keys []string
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // parseDurationOrDefault parses a duration string and returns the default if parsing fails.
 func parseDurationOrDefault(s string, def time.Duration) time.Duration {
 	if s == "" {
@@ -46,6 +46,82 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.OptimizationInterval() != 60*time.Second {
 		t.Errorf("Expected OptimizationInterval default 60s, got %v", cfg.OptimizationInterval())
 	}
+	if cfg.DampeningConsecutiveRuns() != 1 {
+		t.Errorf("Expected DampeningConsecutiveRuns default 1, got %d", cfg.DampeningConsecutiveRuns())
+	}
+	if cfg.DampeningReplicaThreshold() != 0 {
+		t.Errorf("Expected DampeningReplicaThreshold default 0, got %d", cfg.DampeningReplicaThreshold())
+	}
+	if cfg.ScaleDownQuorum() != 0 {
+		t.Errorf("Expected ScaleDownQuorum default 0, got %d", cfg.ScaleDownQuorum())
+	}
+	if cfg.RolloutMaxStepReplicas() != 0 {
+		t.Errorf("Expected RolloutMaxStepReplicas default 0, got %d", cfg.RolloutMaxStepReplicas())
+	}
+	if cfg.RolloutStepTimeout() != 10*time.Minute {
+		t.Errorf("Expected RolloutStepTimeout default 10m, got %v", cfg.RolloutStepTimeout())
+	}
+	if cfg.ScaleUpVerifyTimeout() != 0 {
+		t.Errorf("Expected ScaleUpVerifyTimeout default 0, got %v", cfg.ScaleUpVerifyTimeout())
+	}
+	if cfg.ScaleUpVerifyMinImprovement() != 0.05 {
+		t.Errorf("Expected ScaleUpVerifyMinImprovement default 0.05, got %v", cfg.ScaleUpVerifyMinImprovement())
+	}
+	if cfg.ScaleUpVerifyRollback() {
+		t.Error("Expected ScaleUpVerifyRollback default false")
+	}
+	if cfg.PrepullEnabled() {
+		t.Error("Expected PrepullEnabled default false")
+	}
+	if cfg.PrepullTTL() != 30*time.Minute {
+		t.Errorf("Expected PrepullTTL default 30m, got %v", cfg.PrepullTTL())
+	}
+	if cfg.PrepullPauseImage() != DefaultPrepullPauseImage {
+		t.Errorf("Expected PrepullPauseImage default %q, got %q", DefaultPrepullPauseImage, cfg.PrepullPauseImage())
+	}
+	if cfg.EnrichmentWebhookURL() != "" {
+		t.Errorf("Expected EnrichmentWebhookURL default empty, got %q", cfg.EnrichmentWebhookURL())
+	}
+	if cfg.EnrichmentWebhookTimeout() != 2*time.Second {
+		t.Errorf("Expected EnrichmentWebhookTimeout default 2s, got %v", cfg.EnrichmentWebhookTimeout())
+	}
+	if cfg.LogDetailSampling() != 1 {
+		t.Errorf("Expected LogDetailSampling default 1, got %d", cfg.LogDetailSampling())
+	}
+	if cfg.LogRedaction() {
+		t.Error("Expected LogRedaction default false")
+	}
+	if cfg.BackpressureMaxQueueDepth() != 0 || cfg.BackpressureMaxQueryLatency() != 0 {
+		t.Errorf("Expected back-pressure limits disabled by default, got %d and %v",
+			cfg.BackpressureMaxQueueDepth(), cfg.BackpressureMaxQueryLatency())
+	}
+	if cfg.BackpressureMaxSlowdown() != 8 {
+		t.Errorf("Expected BackpressureMaxSlowdown default 8, got %d", cfg.BackpressureMaxSlowdown())
+	}
+	if cfg.ReconcilePrioritySaturation() != 0.8 {
+		t.Errorf("Expected ReconcilePrioritySaturation default 0.8, got %v", cfg.ReconcilePrioritySaturation())
+	}
+	if cfg.StateSnapshotEnabled() {
+		t.Error("Expected StateSnapshotEnabled default false")
+	}
+	if cfg.StateSnapshotMaxAge() != 10*time.Minute {
+		t.Errorf("Expected StateSnapshotMaxAge default 10m, got %v", cfg.StateSnapshotMaxAge())
+	}
+	if cfg.StandbyWarmup() {
+		t.Error("Expected StandbyWarmup default false")
+	}
+	if cfg.VAGeneratorEnabled() {
+		t.Error("Expected VAGeneratorEnabled default false")
+	}
+	if len(cfg.ShowbackLabelKeys()) != 0 {
+		t.Errorf("Expected no ShowbackLabelKeys by default, got %v", cfg.ShowbackLabelKeys())
+	}
+	if cfg.ShowbackPeriod() != 24*time.Hour {
+		t.Errorf("Expected ShowbackPeriod default 24h, got %v", cfg.ShowbackPeriod())
+	}
+	if cfg.ShowbackConfigMapEnabled() {
+		t.Error("Expected ShowbackConfigMapEnabled default false")
+	}
 }
 
 func TestLoad_FlagsPrecedence(t *testing.T) {
@@ -126,6 +202,76 @@ func TestLoad_PrometheusConfigRequired(t *testing.T) {
 	}
 }
 
+func TestLoad_SimulatorWithoutPrometheus(t *testing.T) {
+	configFile := writeTestConfigFile(t, `WVA_SIMULATOR: true
+WVA_SIMULATOR_SCENARIO: "/etc/wva/scenario.yaml"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if !cfg.Simulator() {
+		t.Error("Expected simulator mode to be enabled")
+	}
+	if cfg.SimulatorScenario() != "/etc/wva/scenario.yaml" {
+		t.Errorf("Expected simulator scenario from file, got %q", cfg.SimulatorScenario())
+	}
+}
+
+func TestLoad_ModuleVerbosityFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_MODULE_VERBOSITY: "solver=5, collector=1"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	levels := cfg.ModuleVerbosity()
+	if len(levels) != 2 || levels["solver"] != 5 || levels["collector"] != 1 {
+		t.Errorf("Expected module verbosity solver=5,collector=1, got %v", levels)
+	}
+}
+
+func TestLoad_LogSamplingAndRedactionFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_LOG_DETAIL_SAMPLING: 10
+WVA_LOG_REDACT: true`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.LogDetailSampling() != 10 {
+		t.Errorf("Expected LogDetailSampling 10, got %d", cfg.LogDetailSampling())
+	}
+	if !cfg.LogRedaction() {
+		t.Error("Expected LogRedaction to be enabled")
+	}
+}
+
+func TestLoad_Validation_LogDetailSampling(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_LOG_DETAIL_SAMPLING: 0`)
+
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for log detail sampling 0")
+	}
+}
+
+func TestLoad_Validation_ModuleVerbosity(t *testing.T) {
+	for _, value := range []string{"planner=3", "solver", "solver=-1", "solver=high"} {
+		configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_MODULE_VERBOSITY: "`+value+`"`)
+
+		if _, err := Load(nil, configFile); err == nil {
+			t.Errorf("Expected Load() to fail for module verbosity %q", value)
+		}
+	}
+}
+
 func TestLoad_PrometheusConfigFromEnv(t *testing.T) {
 	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus-env:9090")
 	defer func() { _ = os.Unsetenv("PROMETHEUS_BASE_URL") }()
@@ -199,6 +345,183 @@ SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY: "5"
 	}
 }
 
+func TestLoad_DampeningFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_DAMPENING_CONSECUTIVE_RUNS: "3"
+WVA_DAMPENING_REPLICA_THRESHOLD: "4"
+WVA_SCALE_DOWN_QUORUM: "3"
+WVA_SCALE_DOWN_QUORUM_WINDOW: "5"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.DampeningConsecutiveRuns() != 3 {
+		t.Errorf("Expected DampeningConsecutiveRuns 3, got %d", cfg.DampeningConsecutiveRuns())
+	}
+	if cfg.DampeningReplicaThreshold() != 4 {
+		t.Errorf("Expected DampeningReplicaThreshold 4, got %d", cfg.DampeningReplicaThreshold())
+	}
+	if cfg.ScaleDownQuorum() != 3 || cfg.ScaleDownQuorumWindow() != 5 {
+		t.Errorf("Expected scale-down quorum 3 of 5, got %d of %d", cfg.ScaleDownQuorum(), cfg.ScaleDownQuorumWindow())
+	}
+}
+
+func TestLoad_RolloutFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_ROLLOUT_MAX_STEP_REPLICAS: "2"
+WVA_ROLLOUT_STEP_TIMEOUT: "5m"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.RolloutMaxStepReplicas() != 2 {
+		t.Errorf("Expected RolloutMaxStepReplicas 2, got %d", cfg.RolloutMaxStepReplicas())
+	}
+	if cfg.RolloutStepTimeout() != 5*time.Minute {
+		t.Errorf("Expected RolloutStepTimeout 5m, got %v", cfg.RolloutStepTimeout())
+	}
+}
+
+func TestLoad_ScaleUpVerificationFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SCALE_UP_VERIFY_TIMEOUT: "3m"
+WVA_SCALE_UP_VERIFY_MIN_IMPROVEMENT: "0.1"
+WVA_SCALE_UP_VERIFY_ROLLBACK: "true"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.ScaleUpVerifyTimeout() != 3*time.Minute {
+		t.Errorf("Expected ScaleUpVerifyTimeout 3m, got %v", cfg.ScaleUpVerifyTimeout())
+	}
+	if cfg.ScaleUpVerifyMinImprovement() != 0.1 {
+		t.Errorf("Expected ScaleUpVerifyMinImprovement 0.1, got %v", cfg.ScaleUpVerifyMinImprovement())
+	}
+	if !cfg.ScaleUpVerifyRollback() {
+		t.Error("Expected ScaleUpVerifyRollback to be true")
+	}
+}
+
+func TestLoad_BackpressureFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_BACKPRESSURE_MAX_QUEUE_DEPTH: "500"
+WVA_BACKPRESSURE_MAX_QUERY_LATENCY: "5s"
+WVA_BACKPRESSURE_MAX_SLOWDOWN: "4"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if cfg.BackpressureMaxQueueDepth() != 500 {
+		t.Errorf("Expected BackpressureMaxQueueDepth 500, got %d", cfg.BackpressureMaxQueueDepth())
+	}
+	if cfg.BackpressureMaxQueryLatency() != 5*time.Second {
+		t.Errorf("Expected BackpressureMaxQueryLatency 5s, got %v", cfg.BackpressureMaxQueryLatency())
+	}
+	if cfg.BackpressureMaxSlowdown() != 4 {
+		t.Errorf("Expected BackpressureMaxSlowdown 4, got %d", cfg.BackpressureMaxSlowdown())
+	}
+}
+
+func TestLoad_Validation_Backpressure(t *testing.T) {
+	for _, value := range []string{
+		"WVA_BACKPRESSURE_MAX_QUEUE_DEPTH: -1",
+		"WVA_BACKPRESSURE_MAX_QUERY_LATENCY: -1s",
+		"WVA_BACKPRESSURE_MAX_SLOWDOWN: 0",
+	} {
+		configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+`+value)
+
+		if _, err := Load(nil, configFile); err == nil {
+			t.Errorf("Expected Load() to fail for %q", value)
+		}
+	}
+}
+
+func TestLoad_ReconcilePrioritySaturation(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_RECONCILE_PRIORITY_SATURATION: "0.6"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.ReconcilePrioritySaturation() != 0.6 {
+		t.Errorf("Expected ReconcilePrioritySaturation 0.6, got %v", cfg.ReconcilePrioritySaturation())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_RECONCILE_PRIORITY_SATURATION: "1.5"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for reconcile priority saturation 1.5")
+	}
+}
+
+func TestLoad_StateSnapshotFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_STATE_SNAPSHOT_ENABLED: "true"
+WVA_STATE_SNAPSHOT_MAX_AGE: "5m"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.StateSnapshotEnabled() {
+		t.Error("Expected StateSnapshotEnabled to be true")
+	}
+	if cfg.StateSnapshotMaxAge() != 5*time.Minute {
+		t.Errorf("Expected StateSnapshotMaxAge 5m, got %v", cfg.StateSnapshotMaxAge())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_STATE_SNAPSHOT_ENABLED: "true"
+WVA_STATE_SNAPSHOT_MAX_AGE: "0"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for state snapshot max age 0")
+	}
+}
+
+func TestLoad_StandbyWarmupFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_STANDBY_WARMUP: "true"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.StandbyWarmup() {
+		t.Error("Expected StandbyWarmup to be true")
+	}
+}
+
+func TestLoad_VAGeneratorFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_VA_GENERATOR_ENABLED: "true"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.VAGeneratorEnabled() {
+		t.Error("Expected VAGeneratorEnabled to be true")
+	}
+}
+
 func TestLoad_PrometheusCacheConfigFromFile(t *testing.T) {
 	configFile := writeTestConfigFile(t, `
 PROMETHEUS_BASE_URL: "https://prometheus:9090"
@@ -219,6 +542,130 @@ PROMETHEUS_METRICS_CACHE_TTL: "60s"
 	}
 }
 
+func TestLoad_PrometheusCacheConfigFromFlags(t *testing.T) {
+	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
+	_ = os.Setenv("PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL", "20s")
+	defer func() {
+		_ = os.Unsetenv("PROMETHEUS_BASE_URL")
+		_ = os.Unsetenv("PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL")
+	}()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterOverrideFlags(fs)
+	_ = fs.Set("prometheus-metrics-cache-fetch-interval", "10s")
+	_ = fs.Set("prometheus-metrics-cache-ttl", "45s")
+
+	cfg, err := Load(fs, "")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	cacheConfig := cfg.PrometheusCacheConfig()
+	if cacheConfig.FetchInterval != 10*time.Second {
+		t.Errorf("Expected cache fetch interval 10s (from flag), got %v", cacheConfig.FetchInterval)
+	}
+	if cacheConfig.TTL != 45*time.Second {
+		t.Errorf("Expected cache TTL 45s (from flag), got %v", cacheConfig.TTL)
+	}
+	if cacheConfig.CleanupInterval != time.Minute {
+		t.Errorf("Expected cache cleanup interval default 1m, got %v", cacheConfig.CleanupInterval)
+	}
+}
+
+func TestLoad_SaturationDefaultOverrides(t *testing.T) {
+	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
+	_ = os.Setenv("WVA_SATURATION_DEFAULT_KV_CACHE_THRESHOLD", "0.9")
+	_ = os.Setenv("WVA_SATURATION_DEFAULT_ENABLE_LIMITER", "true")
+	defer func() {
+		_ = os.Unsetenv("PROMETHEUS_BASE_URL")
+		_ = os.Unsetenv("WVA_SATURATION_DEFAULT_KV_CACHE_THRESHOLD")
+		_ = os.Unsetenv("WVA_SATURATION_DEFAULT_ENABLE_LIMITER")
+	}()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterOverrideFlags(fs)
+	_ = fs.Set("saturation-default-kv-cache-threshold", "0.95")
+
+	cfg, err := Load(fs, "")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	cfg.UpdateSaturationConfig(map[string]interfaces.SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.8, QueueLengthThreshold: 5},
+		"llama":   {ModelID: "llama", KvCacheThreshold: 0.7},
+	})
+
+	satConfig := cfg.SaturationConfig()
+	if got := satConfig["default"].KvCacheThreshold; got != 0.95 {
+		t.Errorf("Expected default KvCacheThreshold 0.95 (from flag), got %v", got)
+	}
+	if !satConfig["default"].EnableLimiter {
+		t.Error("Expected default EnableLimiter true (from env)")
+	}
+	if got := satConfig["default"].QueueLengthThreshold; got != 5 {
+		t.Errorf("Expected default QueueLengthThreshold 5 (from ConfigMap), got %v", got)
+	}
+	if got := satConfig["llama"].KvCacheThreshold; got != 0.7 {
+		t.Errorf("Expected per-model KvCacheThreshold 0.7 not to be overridden, got %v", got)
+	}
+}
+
+func TestLoad_Validation_SaturationDefaultOverrides(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SATURATION_DEFAULT_KV_CACHE_THRESHOLD: "high"`)
+
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a saturation default override that is not a number")
+	}
+}
+
+func TestLoad_EffectiveSettings(t *testing.T) {
+	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
+	_ = os.Setenv("PROMETHEUS_BEARER_TOKEN", "secret")
+	defer func() {
+		_ = os.Unsetenv("PROMETHEUS_BASE_URL")
+		_ = os.Unsetenv("PROMETHEUS_BEARER_TOKEN")
+	}()
+
+	configFile := writeTestConfigFile(t, `WVA_ROLLOUT_STEP_TIMEOUT: "5m"`)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("metrics-bind-address", "0", "")
+	_ = fs.Set("metrics-bind-address", ":8443")
+
+	cfg, err := Load(fs, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	settings := make(map[string]Setting)
+	for _, s := range cfg.EffectiveSettings() {
+		settings[s.Key] = s
+	}
+	tests := []struct {
+		key    string
+		value  string
+		source Source
+	}{
+		{"METRICS_BIND_ADDRESS", ":8443", SourceFlag},
+		{"PROMETHEUS_BASE_URL", "https://prometheus:9090", SourceEnv},
+		{"PROMETHEUS_BEARER_TOKEN", redactedValue, SourceEnv},
+		{"WVA_ROLLOUT_STEP_TIMEOUT", "5m", SourceFile},
+		{"PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL", "30s", SourceDefault},
+		{"WVA_SATURATION_DEFAULT_KV_CACHE_THRESHOLD", "", SourceDefault},
+	}
+	for _, tt := range tests {
+		s, ok := settings[tt.key]
+		if !ok {
+			t.Errorf("Expected setting %s in the effective settings", tt.key)
+			continue
+		}
+		if s.Value != tt.value || s.Source != tt.source {
+			t.Errorf("Expected %s = %q from %s, got %q from %s", tt.key, tt.value, tt.source, s.Value, s.Source)
+		}
+	}
+}
+
 func TestConfig_ThreadSafety(t *testing.T) {
 	_ = os.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
 	defer func() { _ = os.Unsetenv("PROMETHEUS_BASE_URL") }()
@@ -485,3 +932,339 @@ func TestLoad_DurationPrecedence(t *testing.T) {
 		}
 	})
 }
+
+func TestLoad_ShowbackFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SHOWBACK_LABELS: "cost-center, team,"
+WVA_SHOWBACK_PERIOD: "1h"
+WVA_SHOWBACK_CONFIGMAP_ENABLED: "true"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if keys := cfg.ShowbackLabelKeys(); len(keys) != 2 || keys[0] != "cost-center" || keys[1] != "team" {
+		t.Errorf("Expected ShowbackLabelKeys [cost-center team], got %v", keys)
+	}
+	if cfg.ShowbackPeriod() != time.Hour {
+		t.Errorf("Expected ShowbackPeriod 1h, got %v", cfg.ShowbackPeriod())
+	}
+	if !cfg.ShowbackConfigMapEnabled() {
+		t.Error("Expected ShowbackConfigMapEnabled to be true")
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SHOWBACK_PERIOD: "0"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for showback period 0")
+	}
+}
+
+func TestLoad_ConcurrencyTuningFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "ConcurrencyTuning=true"
+WVA_CONCURRENCY_ADMIN_PORT: "8080"
+WVA_CONCURRENCY_ADMIN_PATH: "/v1/admin/config"
+WVA_CONCURRENCY_MAX_DELTA: "0.5"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.FeatureEnabled(ConcurrencyTuning) {
+		t.Error("Expected ConcurrencyTuning to be enabled")
+	}
+	if cfg.ConcurrencyAdminPort() != 8080 {
+		t.Errorf("Expected ConcurrencyAdminPort 8080, got %d", cfg.ConcurrencyAdminPort())
+	}
+	if cfg.ConcurrencyAdminPath() != "/v1/admin/config" {
+		t.Errorf("Expected ConcurrencyAdminPath /v1/admin/config, got %q", cfg.ConcurrencyAdminPath())
+	}
+	if cfg.ConcurrencyMaxDelta() != 0.5 {
+		t.Errorf("Expected ConcurrencyMaxDelta 0.5, got %v", cfg.ConcurrencyMaxDelta())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "ConcurrencyTuning=true"
+WVA_CONCURRENCY_MAX_DELTA: "2"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for concurrency max delta 2")
+	}
+
+	// settings are only validated with the feature enabled
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_CONCURRENCY_ADMIN_PATH: "admin"`)
+	if _, err := Load(nil, configFile); err != nil {
+		t.Errorf("Load() failed with concurrency tuning disabled: %v", err)
+	}
+}
+
+func TestLoad_GPUPoolCoordinationFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_GPU_POOL_COORDINATION: "lease"
+WVA_GPU_POOL_LEASE_NAMESPACE: "wva-shared"
+WVA_GPU_POOL_LEASE_DURATION: "90s"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.GPUPoolCoordination() != "lease" {
+		t.Errorf("Expected GPUPoolCoordination lease, got %q", cfg.GPUPoolCoordination())
+	}
+	if cfg.GPUPoolLeaseNamespace() != "wva-shared" {
+		t.Errorf("Expected GPUPoolLeaseNamespace wva-shared, got %q", cfg.GPUPoolLeaseNamespace())
+	}
+	if cfg.GPUPoolLeaseDuration() != 90*time.Second {
+		t.Errorf("Expected GPUPoolLeaseDuration 90s, got %v", cfg.GPUPoolLeaseDuration())
+	}
+	if cfg.GPUPoolQuota() != 1.0 {
+		t.Errorf("Expected default GPUPoolQuota 1.0, got %v", cfg.GPUPoolQuota())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_GPU_POOL_COORDINATION: "quota"
+WVA_GPU_POOL_QUOTA: "1.5"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for GPU pool quota 1.5")
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_GPU_POOL_COORDINATION: "split"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for unknown GPU pool coordination")
+	}
+}
+
+func TestLoad_SLOErrorBudgetFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "SLOErrorBudget=true"
+WVA_ERROR_BUDGET_WINDOW: "30m"
+WVA_ERROR_BUDGET_OBJECTIVE: "0.99"
+WVA_ERROR_BUDGET_LOW: "0.2"
+WVA_ERROR_BUDGET_HIGH: "0.8"
+WVA_ERROR_BUDGET_BIAS: "0.15"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.FeatureEnabled(SLOErrorBudget) {
+		t.Error("Expected SLOErrorBudget to be enabled")
+	}
+	if cfg.ErrorBudgetWindow() != 30*time.Minute {
+		t.Errorf("Expected ErrorBudgetWindow 30m, got %v", cfg.ErrorBudgetWindow())
+	}
+	if cfg.ErrorBudgetObjective() != 0.99 {
+		t.Errorf("Expected ErrorBudgetObjective 0.99, got %v", cfg.ErrorBudgetObjective())
+	}
+	if cfg.ErrorBudgetLow() != 0.2 || cfg.ErrorBudgetHigh() != 0.8 {
+		t.Errorf("Expected ErrorBudgetLow 0.2 and ErrorBudgetHigh 0.8, got %v and %v", cfg.ErrorBudgetLow(), cfg.ErrorBudgetHigh())
+	}
+	if cfg.ErrorBudgetBias() != 0.15 {
+		t.Errorf("Expected ErrorBudgetBias 0.15, got %v", cfg.ErrorBudgetBias())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "SLOErrorBudget=true"
+WVA_ERROR_BUDGET_OBJECTIVE: "1"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for error budget objective 1")
+	}
+
+	// settings are only validated with the feature enabled
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_ERROR_BUDGET_LOW: "0.9"`)
+	if _, err := Load(nil, configFile); err != nil {
+		t.Errorf("Load() failed with error budgets disabled: %v", err)
+	}
+}
+
+func TestLoad_GPUFailureDetectionFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_FEATURE_GATES: "GPUFailureDetection=true"
+WVA_GPU_FAILURE_NODE_CONDITIONS: "GpuUnhealthy, XidCriticalError"`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.FeatureEnabled(GPUFailureDetection) {
+		t.Error("Expected GPUFailureDetection to be enabled")
+	}
+	if conditions := cfg.GPUFailureNodeConditions(); len(conditions) != 2 || conditions[0] != "GpuUnhealthy" || conditions[1] != "XidCriticalError" {
+		t.Errorf("Expected GPUFailureNodeConditions [GpuUnhealthy XidCriticalError], got %v", conditions)
+	}
+}
+
+func TestLoad_WarmUpPeriodFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.WarmUpPeriod() != 0 {
+		t.Errorf("Expected WarmUpPeriod default 0, got %v", cfg.WarmUpPeriod())
+	}
+
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_WARM_UP_PERIOD: "5m"`)
+	if cfg, err = Load(nil, configFile); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.WarmUpPeriod() != 5*time.Minute {
+		t.Errorf("Expected WarmUpPeriod 5m, got %v", cfg.WarmUpPeriod())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_WARM_UP_PERIOD: "-1m"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a negative warm-up period")
+	}
+}
+
+func TestLoad_DryRunRetentionFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.DryRunRetention() != 6*time.Hour {
+		t.Errorf("Expected DryRunRetention default 6h, got %v", cfg.DryRunRetention())
+	}
+
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_DRY_RUN_RETENTION: "0"`)
+	if cfg, err = Load(nil, configFile); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.DryRunRetention() != 0 {
+		t.Errorf("Expected DryRunRetention 0, got %v", cfg.DryRunRetention())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_DRY_RUN_RETENTION: "-1h"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a negative dry run retention")
+	}
+}
+
+func TestLoad_ModelStateRetentionFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.ModelStateRetention() != time.Hour {
+		t.Errorf("Expected ModelStateRetention default 1h, got %v", cfg.ModelStateRetention())
+	}
+
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_MODEL_STATE_RETENTION: "24h"`)
+	if cfg, err = Load(nil, configFile); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.ModelStateRetention() != 24*time.Hour {
+		t.Errorf("Expected ModelStateRetention 24h, got %v", cfg.ModelStateRetention())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_MODEL_STATE_RETENTION: "-1h"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a negative model state retention")
+	}
+}
+
+func TestLoad_SaturationHeatmapWindowFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SaturationHeatmapWindow() != time.Hour {
+		t.Errorf("Expected SaturationHeatmapWindow default 1h, got %v", cfg.SaturationHeatmapWindow())
+	}
+
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SATURATION_HEATMAP_WINDOW: "15m"`)
+	if cfg, err = Load(nil, configFile); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.SaturationHeatmapWindow() != 15*time.Minute {
+		t.Errorf("Expected SaturationHeatmapWindow 15m, got %v", cfg.SaturationHeatmapWindow())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_SATURATION_HEATMAP_WINDOW: "-1m"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a negative saturation heatmap window")
+	}
+}
+
+func TestLoad_ProbeFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.ProbeGatewayURL() != "" || cfg.ProbeTimeout() != 10*time.Second {
+		t.Errorf("Expected the prober disabled with a 10s timeout, got %q and %v", cfg.ProbeGatewayURL(), cfg.ProbeTimeout())
+	}
+
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_PROBE_GATEWAY_URL: "http://gateway.llm-d:80"
+WVA_PROBE_TIMEOUT: "5s"`)
+	if cfg, err = Load(nil, configFile); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.ProbeGatewayURL() != "http://gateway.llm-d:80" || cfg.ProbeTimeout() != 5*time.Second {
+		t.Errorf("Expected the gateway URL and a 5s timeout, got %q and %v", cfg.ProbeGatewayURL(), cfg.ProbeTimeout())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+WVA_PROBE_GATEWAY_URL: "gateway:80"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for a relative probe gateway URL")
+	}
+}
+
+func TestLoad_PrometheusAuthTypeFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1"
+PROMETHEUS_AUTH_TYPE: "sigv4"
+PROMETHEUS_SIGV4_REGION: "us-east-1"`)
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.PrometheusAuthType() != PrometheusAuthSigV4 || cfg.PrometheusSigV4Region() != "us-east-1" || cfg.PrometheusSigV4Service() != "aps" {
+		t.Errorf("Expected sigv4 auth for aps in us-east-1, got %q for %q in %q",
+			cfg.PrometheusAuthType(), cfg.PrometheusSigV4Service(), cfg.PrometheusSigV4Region())
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+PROMETHEUS_AUTH_TYPE: "azure"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for an unknown auth type")
+	}
+
+	configFile = writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+PROMETHEUS_AUTH_TYPE: "gcp"
+PROMETHEUS_BEARER_TOKEN: "token"`)
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected Load() to fail for gcp auth with a bearer token")
+	}
+}
+
+func TestLoad_PrometheusAggregationPushdownFromFile(t *testing.T) {
+	cfg, err := Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.PrometheusAggregationPushdown() {
+		t.Error("Expected PrometheusAggregationPushdown default false")
+	}
+
+	cfg, err = Load(nil, writeTestConfigFile(t, `PROMETHEUS_BASE_URL: "https://prometheus:9090"
+PROMETHEUS_AGGREGATION_PUSHDOWN: "true"`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.PrometheusAggregationPushdown() {
+		t.Error("Expected PrometheusAggregationPushdown true")
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// DefaultLoggingConfigMapName is the name of the global ConfigMap that sets the logger
+// verbosity of modules at runtime.
+const DefaultLoggingConfigMapName = "wva-logging-config"
+
+// ParseLoggingConfigMap parses the logging ConfigMap. Each key is a module (e.g. "solver")
+// and its value the verbosity of the module. Unknown modules and invalid verbosities are
+// skipped.
+func ParseLoggingConfigMap(data map[string]string) map[string]int {
+	levels := make(map[string]int, len(data))
+	for module, value := range data {
+		verbosity, err := logging.ParseVerbosity(module, strings.TrimSpace(value))
+		if err != nil {
+			ctrl.Log.Info("Invalid logging config entry, skipping", "key", module, "error", err)
+			continue
+		}
+		levels[module] = verbosity
+	}
+	return levels
+}
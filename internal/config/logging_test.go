@@ -0,0 +1,16 @@
+package config
+
+import "testing"
+
+func TestParseLoggingConfigMap(t *testing.T) {
+	levels := ParseLoggingConfigMap(map[string]string{
+		"solver":   "5",
+		"actuator": " 0 ",
+		"planner":  "3",
+		"debug":    "yes",
+	})
+
+	if len(levels) != 2 || levels["solver"] != 5 || levels["actuator"] != 0 {
+		t.Errorf("Expected solver=5 and actuator=0 with invalid entries skipped, got %v", levels)
+	}
+}
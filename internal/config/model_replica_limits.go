@@ -0,0 +1,90 @@
+package config
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultModelReplicaLimitsConfigMapName is the name of the global ConfigMap that limits
+// the total replicas of models, e.g. to the concurrent instances their license allows.
+const DefaultModelReplicaLimitsConfigMapName = "wva-model-replica-limits"
+
+// ModelReplicaLimitEntry is an entry of the model replica limits ConfigMap: the most
+// replicas a model may run across all its variants and namespaces.
+// Field naming follows wva-model-scale-to-zero-config convention (snake_case for YAML).
+type ModelReplicaLimitEntry struct {
+	// ModelID is the model the limit applies to (required)
+	ModelID string `yaml:"model_id" json:"model_id"`
+	// MaxReplicas is the most replicas of the model (required, 0 to stop the model)
+	MaxReplicas *int `yaml:"max_replicas" json:"max_replicas"`
+	// Reason explains the limit, e.g. the license it comes from (optional)
+	Reason string `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// ModelReplicaLimits holds the replica limits of models, keyed by model ID.
+type ModelReplicaLimits map[string]ModelReplicaLimitEntry
+
+// ParseModelReplicaLimitsConfigMap parses the model replica limits ConfigMap.
+// Each value is a YAML ModelReplicaLimitEntry; model IDs contain slashes, which ConfigMap
+// keys cannot. Entries that cannot be parsed, entries without a model ID and entries
+// without max_replicas or with a negative one are skipped. When several entries limit the
+// same model, the lowest limit wins.
+func ParseModelReplicaLimitsConfigMap(data map[string]string) ModelReplicaLimits {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(ModelReplicaLimits, len(data))
+	for _, key := range keys {
+		var entry ModelReplicaLimitEntry
+		if err := yaml.Unmarshal([]byte(data[key]), &entry); err != nil {
+			ctrl.Log.Info("Failed to parse model replica limit entry, skipping", "key", key, "error", err)
+			continue
+		}
+		if entry.ModelID == "" {
+			ctrl.Log.Info("Model replica limit entry missing model_id, skipping", "key", key)
+			continue
+		}
+		if entry.MaxReplicas == nil || *entry.MaxReplicas < 0 {
+			ctrl.Log.Info("Model replica limit entry needs a non-negative max_replicas, skipping", "key", key, "modelID", entry.ModelID)
+			continue
+		}
+		if existing, ok := out[entry.ModelID]; ok && *existing.MaxReplicas <= *entry.MaxReplicas {
+			ctrl.Log.Info("Model already has a lower replica limit, skipping", "key", key, "modelID", entry.ModelID)
+			continue
+		}
+		out[entry.ModelID] = entry
+	}
+	return out
+}
+
+// ModelReplicaLimits returns a copy of the replica limits of models.
+// Thread-safe.
+func (c *Config) ModelReplicaLimits() ModelReplicaLimits {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(ModelReplicaLimits, len(c.modelReplicaLimits))
+	for modelID, entry := range c.modelReplicaLimits {
+		out[modelID] = entry
+	}
+	return out
+}
+
+// UpdateModelReplicaLimits replaces the replica limits of models.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdateModelReplicaLimits(limits ModelReplicaLimits) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newLimits := make(ModelReplicaLimits, len(limits))
+	for modelID, entry := range limits {
+		newLimits[modelID] = entry
+	}
+	if len(c.modelReplicaLimits) != len(newLimits) {
+		ctrl.Log.Info("Updated model replica limits", "oldEntries", len(c.modelReplicaLimits), "newEntries", len(newLimits))
+	}
+	c.modelReplicaLimits = newLimits
+}
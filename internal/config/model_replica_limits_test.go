@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseModelReplicaLimitsConfigMap(t *testing.T) {
+	data := map[string]string{
+		"llama-70b":       "model_id: meta-llama/Llama-3.1-70B\nmax_replicas: 8\nreason: license allows 8 instances",
+		"llama-70b-lower": "model_id: meta-llama/Llama-3.1-70B\nmax_replicas: 6",
+		"granite":         "model_id: ibm/granite-8b\nmax_replicas: 0",
+		"negative":        "model_id: ibm/granite-3b\nmax_replicas: -1",
+		"no-max":          "model_id: ibm/granite-2b",
+		"missing-model":   "max_replicas: 4",
+		"invalid":         "{invalid",
+	}
+
+	limits := ParseModelReplicaLimitsConfigMap(data)
+
+	assert.Equal(t, ModelReplicaLimits{
+		"meta-llama/Llama-3.1-70B": {ModelID: "meta-llama/Llama-3.1-70B", MaxReplicas: ptr.To(6)},
+		"ibm/granite-8b":           {ModelID: "ibm/granite-8b", MaxReplicas: ptr.To(0)},
+	}, limits)
+	assert.Empty(t, ParseModelReplicaLimitsConfigMap(nil))
+}
+
+func TestConfig_ModelReplicaLimits(t *testing.T) {
+	cfg := NewTestConfig()
+	assert.Empty(t, cfg.ModelReplicaLimits())
+
+	limits := ModelReplicaLimits{"model-a": {ModelID: "model-a", MaxReplicas: ptr.To(4)}}
+	cfg.UpdateModelReplicaLimits(limits)
+	delete(limits, "model-a")
+
+	got := cfg.ModelReplicaLimits()
+	assert.Equal(t, 4, *got["model-a"].MaxReplicas)
+	delete(got, "model-a")
+	assert.Len(t, cfg.ModelReplicaLimits(), 1)
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Source is where the effective value of a setting comes from.
+type Source string
+
+// Sources of settings, from highest to lowest precedence.
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceFile    Source = "file"
+	SourceDefault Source = "default"
+)
+
+// Setting is the effective value of a static setting and where it comes from.
+type Setting struct {
+	Key    string `json:"key"`
+	Flag   string `json:"flag,omitempty"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+}
+
+// redactedValue replaces the value of secret settings in the effective configuration.
+const redactedValue = "<redacted>"
+
+// saturationDefaultKeyPrefix and saturationDefaultFlagPrefix prefix the env var and flag
+// overriding a field of the "default" saturation scaling entry.
+const (
+	saturationDefaultKeyPrefix  = "WVA_SATURATION_DEFAULT_"
+	saturationDefaultFlagPrefix = "saturation-default-"
+)
+
+// saturationDefaultOverride binds a field of interfaces.SaturationScalingConfig to the
+// viper key and flag overriding it in the "default" saturation scaling entry.
+type saturationDefaultOverride struct {
+	field reflect.StructField
+	key   string
+	flag  string
+}
+
+// saturationDefaultOverrides lists the overridable fields of interfaces.SaturationScalingConfig,
+// derived from their yaml tags, e.g. kvCacheThreshold is overridden by
+// WVA_SATURATION_DEFAULT_KV_CACHE_THRESHOLD and --saturation-default-kv-cache-threshold.
+// The fields only used in per-model entries are not overridable.
+var saturationDefaultOverrides = func() []saturationDefaultOverride {
+	var overrides []saturationDefaultOverride
+	t := reflect.TypeOf(interfaces.SaturationScalingConfig{})
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || name == "model_id" || name == "namespace" {
+			continue
+		}
+		words := splitCamelCase(name)
+		overrides = append(overrides, saturationDefaultOverride{
+			field: field,
+			key:   saturationDefaultKeyPrefix + strings.ToUpper(strings.Join(words, "_")),
+			flag:  saturationDefaultFlagPrefix + strings.Join(words, "-"),
+		})
+	}
+	return overrides
+}()
+
+// splitCamelCase splits a camelCase name into its lower-case words.
+func splitCamelCase(name string) []string {
+	var words []string
+	start := 0
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(name[start:]))
+}
+
+// prometheusCacheFlags lists the usage of the flags of the Prometheus metrics cache settings.
+var prometheusCacheFlags = map[string]string{
+	"PROMETHEUS_METRICS_CACHE_TTL":                   "Time cached Prometheus metrics are kept (e.g. 30s).",
+	"PROMETHEUS_METRICS_CACHE_CLEANUP_INTERVAL":      "Interval of the removal of expired cached Prometheus metrics (e.g. 1m).",
+	"PROMETHEUS_METRICS_CACHE_FETCH_INTERVAL":        "Interval of the background fetching of Prometheus metrics (0 = disabled).",
+	"PROMETHEUS_METRICS_CACHE_FRESH_THRESHOLD":       "Age below which cached Prometheus metrics are fresh (e.g. 1m).",
+	"PROMETHEUS_METRICS_CACHE_STALE_THRESHOLD":       "Age above which cached Prometheus metrics are stale (e.g. 2m).",
+	"PROMETHEUS_METRICS_CACHE_UNAVAILABLE_THRESHOLD": "Age above which cached Prometheus metrics are unavailable (e.g. 5m).",
+}
+
+func init() {
+	for _, o := range saturationDefaultOverrides {
+		flagBindings[o.key] = o.flag
+	}
+}
+
+// RegisterOverrideFlags registers the flags of the settings the manager does not register
+// itself: the Prometheus metrics cache settings and the saturation default overrides.
+// Unset, they fall through to env vars, the config file and defaults like the other flags.
+func RegisterOverrideFlags(flagSet *flag.FlagSet) {
+	for key, usage := range prometheusCacheFlags {
+		flagSet.String(flagBindings[key], "", usage)
+	}
+	for _, o := range saturationDefaultOverrides {
+		flagSet.String(o.flag, "", fmt.Sprintf("Overrides %s of the default saturation scaling config.", o.field.Name))
+	}
+}
+
+// parseSaturationDefaultOverrides reads the saturation default overrides that are set,
+// keyed by field name. Returns an error if a value does not parse as its field's type.
+func parseSaturationDefaultOverrides(v *viper.Viper) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, o := range saturationDefaultOverrides {
+		value := v.GetString(o.key)
+		if value == "" {
+			continue
+		}
+		var target interfaces.SaturationScalingConfig
+		if err := setField(reflect.ValueOf(&target).Elem().FieldByIndex(o.field.Index), value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", o.key, err)
+		}
+		overrides[o.field.Name] = value
+	}
+	return overrides, nil
+}
+
+// applySaturationDefaultOverrides overrides the fields of the "default" entry of a saturation
+// scaling config with the given overrides, keyed by field name. The config is modified in place.
+func applySaturationDefaultOverrides(config map[string]interfaces.SaturationScalingConfig, overrides map[string]string) {
+	if len(overrides) == 0 {
+		return
+	}
+	entry, ok := config["default"]
+	if !ok {
+		return
+	}
+	target := reflect.ValueOf(&entry).Elem()
+	for name, value := range overrides {
+		// values are validated when loaded
+		_ = setField(target.FieldByName(name), value)
+	}
+	config["default"] = entry
+}
+
+// setField parses a string into a field of a string, bool, int or float kind.
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
+
+// effectiveSettings returns the effective value and source of every setting known to viper,
+// sorted by key. Values of settings holding a token are redacted.
+func effectiveSettings(v *viper.Viper, flagSet *flag.FlagSet) []Setting {
+	keys := v.AllKeys()
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		// viper keys are case-insensitive and reported in lower case
+		name := strings.ToUpper(key)
+		setting := Setting{
+			Key:    name,
+			Flag:   flagBindings[name],
+			Value:  fmt.Sprint(v.Get(key)),
+			Source: SourceDefault,
+		}
+		if f := lookupFlag(flagSet, setting.Flag); f != nil && f.Changed {
+			setting.Source = SourceFlag
+		} else if env := os.Getenv(name); env != "" {
+			setting.Source = SourceEnv
+		} else if v.InConfig(key) {
+			setting.Source = SourceFile
+		}
+		if strings.HasSuffix(name, "_TOKEN") && setting.Value != "" {
+			setting.Value = redactedValue
+		}
+		settings = append(settings, setting)
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings
+}
+
+// lookupFlag returns the named flag of a flag set, or nil if the flag set or name is empty.
+func lookupFlag(flagSet *flag.FlagSet, name string) *flag.Flag {
+	if flagSet == nil || name == "" {
+		return nil
+	}
+	return flagSet.Lookup(name)
+}
+
+// EffectiveSettings returns the effective static settings as loaded, with their sources.
+// Thread-safe. Returns a copy.
+func (c *Config) EffectiveSettings() []Setting {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Setting(nil), c.settings...)
+}
+
+// EffectiveConfigHandler serves the effective static settings and their sources as JSON.
+func EffectiveConfigHandler(cfg *Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(cfg.EffectiveSettings())
+	})
+}
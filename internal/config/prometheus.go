@@ -8,19 +8,33 @@ import (
 // (both connection settings and cache config)
 type prometheusConfig struct {
 	// Immutable (set at startup)
-	baseURL            string
-	bearerToken        string
-	tokenPath          string
-	insecureSkipVerify bool
-	caCertPath         string
-	clientCertPath     string
-	clientKeyPath      string
-	serverName         string
+	baseURL             string
+	bearerToken         string
+	tokenPath           string
+	insecureSkipVerify  bool
+	caCertPath          string
+	clientCertPath      string
+	clientKeyPath       string
+	serverName          string
+	authType            string
+	sigV4Region         string
+	sigV4Service        string
+	aggregationPushdown bool
 
 	// Mutable (can change at runtime)
 	cache *CacheConfig
 }
 
+// Authentication types of managed Prometheus services, set with PROMETHEUS_AUTH_TYPE
+const (
+	// PrometheusAuthSigV4 signs requests with AWS Signature Version 4, e.g. for
+	// Amazon Managed Service for Prometheus
+	PrometheusAuthSigV4 = "sigv4"
+	// PrometheusAuthGCP authenticates requests with an OAuth access token of the Google
+	// service account of the pod, e.g. for Google Cloud Managed Service for Prometheus
+	PrometheusAuthGCP = "gcp"
+)
+
 // CacheConfig holds configuration for the metrics cache.
 // This is the shared configuration type used by all collector plugins (Prometheus, EPP, etc.).
 type CacheConfig struct {
@@ -128,6 +142,42 @@ func (c *Config) PrometheusServerName() string {
 	return c.prometheus.serverName
 }
 
+// PrometheusAuthType returns the authentication type of a managed Prometheus service
+// (PrometheusAuthSigV4 or PrometheusAuthGCP), empty for bearer token or no authentication.
+// Thread-safe.
+func (c *Config) PrometheusAuthType() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prometheus.authType
+}
+
+// PrometheusSigV4Region returns the AWS region requests are signed for with SigV4
+// authentication, empty for the region of the AWS_REGION environment variable.
+// Thread-safe.
+func (c *Config) PrometheusSigV4Region() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prometheus.sigV4Region
+}
+
+// PrometheusSigV4Service returns the AWS service name requests are signed for with
+// SigV4 authentication.
+// Thread-safe.
+func (c *Config) PrometheusSigV4Service() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prometheus.sigV4Service
+}
+
+// PrometheusAggregationPushdown returns whether the peaks of backfill windows are computed
+// in PromQL rather than from the points of range queries.
+// Thread-safe.
+func (c *Config) PrometheusAggregationPushdown() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.prometheus.aggregationPushdown
+}
+
 // PrometheusCacheConfig returns the current Prometheus cache configuration.
 // Thread-safe. Returns a copy to prevent external modifications.
 func (c *Config) PrometheusCacheConfig() *CacheConfig {
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"net/url"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultPrometheusTenantsConfigMapName is the name of the global ConfigMap that sets the
+// Prometheus of the namespaces whose metrics are not in the global Prometheus.
+const DefaultPrometheusTenantsConfigMapName = "wva-prometheus-tenants"
+
+// DefaultPrometheusTenantHeader is the header carrying the tenant of multi-tenant
+// Prometheus backends (Cortex, Mimir, Thanos Receive).
+const DefaultPrometheusTenantHeader = "X-Scope-OrgID"
+
+// SecretKeyRef is a key of a Secret in the namespace of the entry that refers to it.
+type SecretKeyRef struct {
+	Name string `yaml:"name" json:"name"`
+	Key  string `yaml:"key" json:"key"`
+}
+
+// PrometheusTenantEntry is an entry of the Prometheus tenants ConfigMap: the Prometheus
+// the metrics of a namespace are queried from.
+// Field naming follows wva-model-scale-to-zero-config convention (snake_case for YAML).
+type PrometheusTenantEntry struct {
+	// Namespace is the namespace of the VariantAutoscalings the entry applies to (required)
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// URL is the HTTPS URL of the Prometheus of the namespace (default: PROMETHEUS_BASE_URL)
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Tenant is the tenant of the namespace on a multi-tenant Prometheus (optional)
+	Tenant string `yaml:"tenant,omitempty" json:"tenant,omitempty"`
+	// TenantHeader is the header carrying Tenant (default: X-Scope-OrgID)
+	TenantHeader string `yaml:"tenant_header,omitempty" json:"tenant_header,omitempty"`
+	// TokenSecret is the Secret key, in Namespace, of the bearer token of the Prometheus of
+	// the namespace, which replaces the global Prometheus credentials (optional)
+	TokenSecret SecretKeyRef `yaml:"token_secret,omitempty" json:"token_secret,omitempty"`
+}
+
+// PrometheusTenants holds the Prometheus of namespaces, keyed by namespace.
+type PrometheusTenants map[string]PrometheusTenantEntry
+
+// ParsePrometheusTenantsConfigMap parses the Prometheus tenants ConfigMap.
+// Each value is a YAML PrometheusTenantEntry. Entries that cannot be parsed, entries
+// without a namespace or with a URL that is not HTTPS, entries that set neither a URL,
+// a tenant nor a token secret, and token secrets without a name or key are skipped.
+// When several entries set the same namespace, the first by ConfigMap key wins.
+func ParsePrometheusTenantsConfigMap(data map[string]string) PrometheusTenants {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make(PrometheusTenants, len(data))
+	for _, key := range keys {
+		var entry PrometheusTenantEntry
+		if err := yaml.Unmarshal([]byte(data[key]), &entry); err != nil {
+			ctrl.Log.Info("Failed to parse Prometheus tenant entry, skipping", "key", key, "error", err)
+			continue
+		}
+		if entry.Namespace == "" {
+			ctrl.Log.Info("Prometheus tenant entry missing namespace, skipping", "key", key)
+			continue
+		}
+		if entry.URL != "" {
+			if u, err := url.Parse(entry.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+				ctrl.Log.Info("Prometheus tenant entry URL must be an HTTPS URL, skipping", "key", key, "url", entry.URL)
+				continue
+			}
+		}
+		if (entry.TokenSecret.Name == "") != (entry.TokenSecret.Key == "") {
+			ctrl.Log.Info("Prometheus tenant entry token secret needs a name and a key, skipping", "key", key)
+			continue
+		}
+		if entry.URL == "" && entry.Tenant == "" && entry.TokenSecret.Name == "" {
+			ctrl.Log.Info("Prometheus tenant entry sets no url, tenant or token secret, skipping", "key", key)
+			continue
+		}
+		if _, exists := out[entry.Namespace]; exists {
+			ctrl.Log.Info("Duplicate Prometheus tenant entry for namespace, skipping", "key", key, "namespace", entry.Namespace)
+			continue
+		}
+		if entry.Tenant != "" && entry.TenantHeader == "" {
+			entry.TenantHeader = DefaultPrometheusTenantHeader
+		}
+		out[entry.Namespace] = entry
+	}
+	return out
+}
+
+// PrometheusTenant returns the Prometheus of a namespace, and whether the namespace has
+// one other than the global Prometheus.
+// Thread-safe.
+func (c *Config) PrometheusTenant(namespace string) (PrometheusTenantEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.prometheusTenants[namespace]
+	return entry, ok
+}
+
+// UpdatePrometheusTenants replaces the Prometheus of namespaces.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdatePrometheusTenants(tenants PrometheusTenants) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newTenants := make(PrometheusTenants, len(tenants))
+	for namespace, entry := range tenants {
+		newTenants[namespace] = entry
+	}
+	if len(c.prometheusTenants) != len(newTenants) {
+		ctrl.Log.Info("Updated Prometheus tenants", "oldEntries", len(c.prometheusTenants), "newEntries", len(newTenants))
+	}
+	c.prometheusTenants = newTenants
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrometheusTenantsConfigMap(t *testing.T) {
+	data := map[string]string{
+		"team-a":        "namespace: team-a\nurl: https://prometheus.team-a:9090\ntoken_secret:\n  name: prometheus-token\n  key: token",
+		"team-b":        "namespace: team-b\ntenant: b",
+		"team-b-dup":    "namespace: team-b\ntenant: other",
+		"team-c":        "namespace: team-c\ntenant: c\ntenant_header: X-Tenant",
+		"http":          "namespace: team-d\nurl: http://prometheus.team-d:9090",
+		"no-override":   "namespace: team-e",
+		"no-secret-key": "namespace: team-f\ntoken_secret:\n  name: prometheus-token",
+		"missing-ns":    "tenant: x",
+		"invalid":       "{invalid",
+	}
+
+	tenants := ParsePrometheusTenantsConfigMap(data)
+
+	assert.Equal(t, PrometheusTenants{
+		"team-a": {
+			Namespace:   "team-a",
+			URL:         "https://prometheus.team-a:9090",
+			TokenSecret: SecretKeyRef{Name: "prometheus-token", Key: "token"},
+		},
+		"team-b": {Namespace: "team-b", Tenant: "b", TenantHeader: DefaultPrometheusTenantHeader},
+		"team-c": {Namespace: "team-c", Tenant: "c", TenantHeader: "X-Tenant"},
+	}, tenants)
+	assert.Empty(t, ParsePrometheusTenantsConfigMap(nil))
+}
+
+func TestConfig_PrometheusTenant(t *testing.T) {
+	cfg := NewTestConfig()
+
+	_, ok := cfg.PrometheusTenant("team-a")
+	assert.False(t, ok)
+
+	cfg.UpdatePrometheusTenants(PrometheusTenants{"team-a": {Namespace: "team-a", Tenant: "a"}})
+	tenant, ok := cfg.PrometheusTenant("team-a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", tenant.Tenant)
+	_, ok = cfg.PrometheusTenant("team-b")
+	assert.False(t, ok)
+}
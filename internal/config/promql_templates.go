@@ -0,0 +1,124 @@
+package config
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultPromQLTemplatesConfigMapName is the name of the global ConfigMap that overrides
+// the PromQL templates of the queries run by the collector.
+const DefaultPromQLTemplatesConfigMapName = "wva-promql-templates"
+
+// PromQLTemplatesEntry is an entry of the PromQL templates ConfigMap.
+// Field naming follows wva-model-scale-to-zero-config convention (snake_case for YAML).
+type PromQLTemplatesEntry struct {
+	// ModelID is the model the templates apply to (required in override entries)
+	ModelID string `yaml:"model_id,omitempty" json:"model_id,omitempty"`
+	// Namespace restricts the templates to the model in this namespace (optional)
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	// Queries maps a query name (e.g. "kv_cache_usage") to the PromQL template replacing
+	// its built-in template. Templates use the same {{.param}} placeholders as the
+	// built-in ones (e.g. {{.namespace}}, {{.modelID}}).
+	Queries map[string]string `yaml:"queries" json:"queries"`
+}
+
+// PromQLTemplates holds the PromQL template overrides, keyed by ConfigMap key.
+// The GlobalDefaultsKey entry applies to all models.
+type PromQLTemplates map[string]PromQLTemplatesEntry
+
+// ParsePromQLTemplatesConfigMap parses the PromQL templates ConfigMap.
+// Each value is a YAML PromQLTemplatesEntry. Entries that cannot be parsed, override
+// entries without a model_id, and empty templates are skipped.
+func ParsePromQLTemplatesConfigMap(data map[string]string) PromQLTemplates {
+	out := make(PromQLTemplates, len(data))
+	for key, value := range data {
+		var entry PromQLTemplatesEntry
+		if err := yaml.Unmarshal([]byte(value), &entry); err != nil {
+			ctrl.Log.Info("Failed to parse PromQL templates entry, skipping", "key", key, "error", err)
+			continue
+		}
+		if key != GlobalDefaultsKey && entry.ModelID == "" {
+			ctrl.Log.Info("PromQL templates override entry missing model_id, skipping", "key", key)
+			continue
+		}
+		queries := make(map[string]string, len(entry.Queries))
+		for name, template := range entry.Queries {
+			if template == "" {
+				ctrl.Log.Info("Empty PromQL template, skipping", "key", key, "query", name)
+				continue
+			}
+			queries[name] = template
+		}
+		entry.Queries = queries
+		out[key] = entry
+	}
+	return out
+}
+
+// Lookup returns the template overriding a query for a model in a namespace, and whether
+// there is one. An entry for the model in the namespace takes precedence over an entry
+// for the model in any namespace, which takes precedence over the global defaults.
+// Among equally specific entries, the first by ConfigMap key wins.
+func (t PromQLTemplates) Lookup(queryName, namespace, modelID string) (string, bool) {
+	keys := make([]string, 0, len(t))
+	for key := range t {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var modelTemplate string
+	modelFound := false
+	for _, key := range keys {
+		entry := t[key]
+		if key == GlobalDefaultsKey || entry.ModelID != modelID {
+			continue
+		}
+		template, ok := entry.Queries[queryName]
+		if !ok {
+			continue
+		}
+		if entry.Namespace != "" && entry.Namespace == namespace {
+			return template, true
+		}
+		if entry.Namespace == "" && !modelFound {
+			modelTemplate, modelFound = template, true
+		}
+	}
+	if modelFound {
+		return modelTemplate, true
+	}
+
+	template, ok := t[GlobalDefaultsKey].Queries[queryName]
+	return template, ok
+}
+
+// PromQLTemplate returns the template overriding a query for a model in a namespace,
+// and whether there is one.
+// Thread-safe.
+func (c *Config) PromQLTemplate(queryName, namespace, modelID string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.promqlTemplates.Lookup(queryName, namespace, modelID)
+}
+
+// UpdatePromQLTemplates replaces the PromQL template overrides.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdatePromQLTemplates(templates PromQLTemplates) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	newTemplates := make(PromQLTemplates, len(templates))
+	for key, entry := range templates {
+		queries := make(map[string]string, len(entry.Queries))
+		for name, template := range entry.Queries {
+			queries[name] = template
+		}
+		entry.Queries = queries
+		newTemplates[key] = entry
+	}
+	if len(c.promqlTemplates) != len(newTemplates) {
+		ctrl.Log.Info("Updated PromQL templates", "oldEntries", len(c.promqlTemplates), "newEntries", len(newTemplates))
+	}
+	c.promqlTemplates = newTemplates
+}
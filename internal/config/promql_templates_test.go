@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePromQLTemplatesConfigMap(t *testing.T) {
+	data := map[string]string{
+		"default":          "queries:\n  queue_length: 'sum(vllm:num_requests_waiting{model_name=\"{{.modelID}}\"})'",
+		"llama":            "model_id: meta/llama-3.1-8b\nqueries:\n  kv_cache_usage: 'up'\n  queue_length: ''",
+		"missing-model-id": "queries:\n  kv_cache_usage: 'up'",
+		"invalid":          "{invalid",
+	}
+
+	templates := ParsePromQLTemplatesConfigMap(data)
+
+	assert.Equal(t, PromQLTemplates{
+		"default": {Queries: map[string]string{"queue_length": `sum(vllm:num_requests_waiting{model_name="{{.modelID}}"})`}},
+		"llama":   {ModelID: "meta/llama-3.1-8b", Queries: map[string]string{"kv_cache_usage": "up"}},
+	}, templates)
+	assert.Empty(t, ParsePromQLTemplatesConfigMap(nil))
+}
+
+func TestPromQLTemplates_Lookup(t *testing.T) {
+	templates := PromQLTemplates{
+		"default":      {Queries: map[string]string{"kv_cache_usage": "default", "queue_length": "default"}},
+		"llama":        {ModelID: "llama", Queries: map[string]string{"kv_cache_usage": "model"}},
+		"llama-team-a": {ModelID: "llama", Namespace: "team-a", Queries: map[string]string{"kv_cache_usage": "namespace"}},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		namespace string
+		modelID   string
+		want      string
+		wantOK    bool
+	}{
+		{name: "Model in namespace", query: "kv_cache_usage", namespace: "team-a", modelID: "llama", want: "namespace", wantOK: true},
+		{name: "Model in other namespace", query: "kv_cache_usage", namespace: "team-b", modelID: "llama", want: "model", wantOK: true},
+		{name: "Query not overridden for model", query: "queue_length", namespace: "team-a", modelID: "llama", want: "default", wantOK: true},
+		{name: "Other model", query: "kv_cache_usage", namespace: "team-a", modelID: "mistral", want: "default", wantOK: true},
+		{name: "Query not overridden", query: "avg_ttft", namespace: "team-a", modelID: "llama", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := templates.Lookup(tt.query, tt.namespace, tt.modelID)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_PromQLTemplate(t *testing.T) {
+	cfg := NewTestConfig()
+
+	_, ok := cfg.PromQLTemplate("kv_cache_usage", "default", "llama")
+	assert.False(t, ok, "no templates configured")
+
+	templates := PromQLTemplates{"default": {Queries: map[string]string{"kv_cache_usage": "up"}}}
+	cfg.UpdatePromQLTemplates(templates)
+	templates["default"].Queries["kv_cache_usage"] = "changed" // must not affect the config
+
+	template, ok := cfg.PromQLTemplate("kv_cache_usage", "default", "llama")
+	assert.True(t, ok)
+	assert.Equal(t, "up", template)
+
+	cfg.UpdatePromQLTemplates(nil)
+	_, ok = cfg.PromQLTemplate("kv_cache_usage", "default", "llama")
+	assert.False(t, ok, "templates removed")
+}
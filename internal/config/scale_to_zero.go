@@ -26,7 +26,7 @@ const (
 
 	// GlobalDefaultsKey is the key in the ConfigMap used to specify global defaults
 	// for all models. Models can override these defaults with their specific configuration.
-	// This follows the same pattern as wva-saturation-scaling-config.
+	// This follows the same pattern as wva-model-scaling-config.
 	GlobalDefaultsKey = "default"
 )
 
@@ -34,7 +34,7 @@ const (
 // Uses pointer for EnableScaleToZero to distinguish between "not set" (nil) and explicitly set to false.
 // This allows partial overrides where a model can inherit enableScaleToZero from global defaults
 // while overriding only the retentionPeriod.
-// Field naming follows wva-saturation-scaling-config convention (snake_case for YAML).
+// Field naming follows wva-model-scaling-config convention (snake_case for YAML).
 type ModelScaleToZeroConfig struct {
 	// ModelID is the unique identifier for the model (only used in override entries)
 	ModelID string `yaml:"model_id,omitempty" json:"model_id,omitempty"`
@@ -48,6 +48,10 @@ type ModelScaleToZeroConfig struct {
 	// This is stored as a string duration (e.g., "5m", "1h", "30s").
 	// Empty string = not set (inherit from defaults)
 	RetentionPeriod string `yaml:"retention_period,omitempty" json:"retention_period,omitempty"`
+	// RequestRateNoiseFloor is the request rate (requests/min) over the retention period at or
+	// below which the model is considered idle, so that health checks and synthetic probes do
+	// not keep it warm. nil = not set (inherit from defaults), 0 = any request keeps it warm.
+	RequestRateNoiseFloor *float64 `yaml:"request_rate_noise_floor,omitempty" json:"request_rate_noise_floor,omitempty"`
 }
 
 // ScaleToZeroConfigData holds pre-read scale-to-zero configuration data for all models.
@@ -147,6 +151,30 @@ func ScaleToZeroRetentionPeriod(configData ScaleToZeroConfigData, modelID string
 	return DefaultScaleToZeroRetentionPeriod
 }
 
+// ScaleToZeroNoiseFloor returns the request rate noise floor (requests/min) for scale-to-zero
+// for a specific model. Configuration priority (highest to lowest):
+// 1. Per-model noise floor in ConfigMap
+// 2. Global defaults noise floor in ConfigMap (under "default" key)
+// 3. System default (0: any request keeps the model warm)
+//
+// Negative noise floors are ignored.
+func ScaleToZeroNoiseFloor(configData ScaleToZeroConfigData, modelID string) float64 {
+	for _, key := range []string{modelID, GlobalDefaultsKey} {
+		config, exists := configData[key]
+		if !exists || config.RequestRateNoiseFloor == nil {
+			continue
+		}
+		if *config.RequestRateNoiseFloor < 0 {
+			ctrl.Log.Info("Invalid negative request rate noise floor, ignoring",
+				"key", key,
+				"requestRateNoiseFloor", *config.RequestRateNoiseFloor)
+			continue
+		}
+		return *config.RequestRateNoiseFloor
+	}
+	return 0
+}
+
 // MinNumReplicas returns the minimum number of replicas for a specific model based on
 // scale-to-zero configuration. Returns 0 if scale-to-zero is enabled, otherwise returns 1.
 func MinNumReplicas(configData ScaleToZeroConfigData, modelID string) int {
@@ -157,7 +185,7 @@ func MinNumReplicas(configData ScaleToZeroConfigData, modelID string) int {
 }
 
 // ParseScaleToZeroConfigMap parses scale-to-zero configuration from a ConfigMap's data.
-// The ConfigMap follows the same format as wva-saturation-scaling-config:
+// The ConfigMap follows the same format as wva-model-scaling-config:
 //   - "default": global defaults for all models
 //   - "<override-name>": per-model configuration with model_id field
 //
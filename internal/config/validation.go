@@ -2,18 +2,49 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
 // Validate performs validation on the loaded configuration.
 // It returns an error if any required configuration is missing or invalid.
 // This implements fail-fast behavior: the controller should not start with invalid configuration.
 func Validate(cfg *Config) error {
-	// Prometheus config is required
-	if cfg.PrometheusBaseURL() == "" {
+	// Prometheus config is required, except in simulator mode
+	if cfg.PrometheusBaseURL() == "" && !cfg.Simulator() {
 		return fmt.Errorf("prometheus BaseURL is required")
 	}
 
+	// Managed Prometheus authentication replaces bearer tokens
+	switch cfg.PrometheusAuthType() {
+	case "":
+	case PrometheusAuthSigV4, PrometheusAuthGCP:
+		if cfg.PrometheusBearerToken() != "" || cfg.PrometheusTokenPath() != "" {
+			return fmt.Errorf("prometheus auth type %q cannot be combined with a bearer token", cfg.PrometheusAuthType())
+		}
+		if cfg.PrometheusAuthType() == PrometheusAuthSigV4 && cfg.PrometheusSigV4Service() == "" {
+			return fmt.Errorf("prometheus SigV4 service is required with auth type %q", PrometheusAuthSigV4)
+		}
+	default:
+		return fmt.Errorf("prometheus auth type must be one of \"\", %q or %q, got %q",
+			PrometheusAuthSigV4, PrometheusAuthGCP, cfg.PrometheusAuthType())
+	}
+
+	// Module verbosity must name known modules
+	cfg.mu.RLock()
+	moduleVerbosity := cfg.infrastructure.moduleVerbosity
+	cfg.mu.RUnlock()
+	if _, err := logging.ParseModuleVerbosity(moduleVerbosity); err != nil {
+		return fmt.Errorf("invalid module verbosity: %w", err)
+	}
+
+	// Detail must be logged at least one in every N cycles
+	if cfg.LogDetailSampling() < 1 {
+		return fmt.Errorf("log detail sampling must be at least 1, got %d", cfg.LogDetailSampling())
+	}
+
 	// Optimization interval must be positive
 	interval := cfg.OptimizationInterval()
 	if interval <= 0 {
@@ -25,6 +56,160 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("scale-from-zero max concurrency must be positive, got %d", cfg.ScaleFromZeroMaxConcurrency())
 	}
 
+	// Dampening requires at least one run per change and a non-negative bypass threshold
+	if cfg.DampeningConsecutiveRuns() < 1 {
+		return fmt.Errorf("dampening consecutive runs must be at least 1, got %d", cfg.DampeningConsecutiveRuns())
+	}
+	if cfg.DampeningReplicaThreshold() < 0 {
+		return fmt.Errorf("dampening replica threshold must not be negative, got %d", cfg.DampeningReplicaThreshold())
+	}
+	if cfg.ScaleDownQuorum() < 0 {
+		return fmt.Errorf("scale-down quorum must not be negative, got %d", cfg.ScaleDownQuorum())
+	}
+	if cfg.ScaleDownQuorumWindow() < 0 {
+		return fmt.Errorf("scale-down quorum window must not be negative, got %d", cfg.ScaleDownQuorumWindow())
+	}
+
+	// Graduated rollout needs a non-negative step size and a positive step timeout
+	if cfg.RolloutMaxStepReplicas() < 0 {
+		return fmt.Errorf("rollout max step replicas must not be negative, got %d", cfg.RolloutMaxStepReplicas())
+	}
+	if cfg.RolloutStepTimeout() <= 0 {
+		return fmt.Errorf("rollout step timeout must be positive, got %v", cfg.RolloutStepTimeout())
+	}
+
+	// Scale-up verification needs a non-negative timeout and an improvement within (0, 1]
+	if cfg.ScaleUpVerifyTimeout() < 0 {
+		return fmt.Errorf("scale-up verification timeout must not be negative, got %v", cfg.ScaleUpVerifyTimeout())
+	}
+	if imp := cfg.ScaleUpVerifyMinImprovement(); imp <= 0 || imp > 1 {
+		return fmt.Errorf("scale-up verification min improvement must be in (0, 1], got %v", imp)
+	}
+
+	// The warm-up of new VariantAutoscalings is disabled with 0
+	if cfg.WarmUpPeriod() < 0 {
+		return fmt.Errorf("warm-up period must not be negative, got %v", cfg.WarmUpPeriod())
+	}
+
+	// The dry validation of saturation scaling ConfigMaps is disabled with 0
+	if cfg.DryRunRetention() < 0 {
+		return fmt.Errorf("dry run retention must not be negative, got %v", cfg.DryRunRetention())
+	}
+
+	// The state of unreferenced models is kept forever with 0
+	if cfg.ModelStateRetention() < 0 {
+		return fmt.Errorf("model state retention must not be negative, got %v", cfg.ModelStateRetention())
+	}
+
+	// The saturation heatmap endpoint is disabled with 0
+	if cfg.SaturationHeatmapWindow() < 0 {
+		return fmt.Errorf("saturation heatmap window must not be negative, got %v", cfg.SaturationHeatmapWindow())
+	}
+
+	// The prober needs an absolute HTTP(S) gateway URL and a positive timeout
+	if gatewayURL := cfg.ProbeGatewayURL(); gatewayURL != "" {
+		u, err := url.Parse(gatewayURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("probe gateway URL must be an absolute http(s) URL, got %q", gatewayURL)
+		}
+		if cfg.ProbeTimeout() <= 0 {
+			return fmt.Errorf("probe timeout must be positive, got %v", cfg.ProbeTimeout())
+		}
+	}
+
+	// Image pre-pulling needs a positive TTL and a pause image
+	if cfg.PrepullEnabled() {
+		if cfg.PrepullTTL() <= 0 {
+			return fmt.Errorf("prepull TTL must be positive, got %v", cfg.PrepullTTL())
+		}
+		if cfg.PrepullPauseImage() == "" {
+			return fmt.Errorf("prepull pause image is required when prepull is enabled")
+		}
+	}
+
+	// The enrichment webhook needs an absolute HTTP(S) URL and a positive timeout
+	if webhookURL := cfg.EnrichmentWebhookURL(); webhookURL != "" {
+		u, err := url.Parse(webhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("enrichment webhook URL must be an absolute http(s) URL, got %q", webhookURL)
+		}
+		if cfg.EnrichmentWebhookTimeout() <= 0 {
+			return fmt.Errorf("enrichment webhook timeout must be positive, got %v", cfg.EnrichmentWebhookTimeout())
+		}
+	}
+
+	// Back-pressure needs non-negative limits and a slowdown of at least 1
+	if cfg.BackpressureMaxQueueDepth() < 0 {
+		return fmt.Errorf("back-pressure max queue depth must not be negative, got %d", cfg.BackpressureMaxQueueDepth())
+	}
+	if cfg.BackpressureMaxQueryLatency() < 0 {
+		return fmt.Errorf("back-pressure max query latency must not be negative, got %v", cfg.BackpressureMaxQueryLatency())
+	}
+	if cfg.BackpressureMaxSlowdown() < 1 {
+		return fmt.Errorf("back-pressure max slowdown must be at least 1, got %d", cfg.BackpressureMaxSlowdown())
+	}
+
+	// The reconcile priority saturation is a saturation level
+	if s := cfg.ReconcilePrioritySaturation(); s < 0 || s > 1 {
+		return fmt.Errorf("reconcile priority saturation must be in [0, 1], got %v", s)
+	}
+
+	// A state snapshot needs a positive max age to ever be restored
+	if cfg.StateSnapshotEnabled() && cfg.StateSnapshotMaxAge() <= 0 {
+		return fmt.Errorf("state snapshot max age must be positive, got %v", cfg.StateSnapshotMaxAge())
+	}
+
+	// The showback report needs a positive period to ever complete one
+	if cfg.ShowbackPeriod() <= 0 {
+		return fmt.Errorf("showback period must be positive, got %v", cfg.ShowbackPeriod())
+	}
+
+	// Concurrency tuning posts to a port and path of the replicas, and absorbs scale-ups
+	// up to a fraction of the current replicas
+	if cfg.FeatureEnabled(ConcurrencyTuning) {
+		if p := cfg.ConcurrencyAdminPort(); p < 1 || p > 65535 {
+			return fmt.Errorf("concurrency admin port must be in [1, 65535], got %d", p)
+		}
+		if p := cfg.ConcurrencyAdminPath(); !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("concurrency admin path must start with /, got %q", p)
+		}
+		if d := cfg.ConcurrencyMaxDelta(); d <= 0 || d > 1 {
+			return fmt.Errorf("concurrency max delta must be in (0, 1], got %v", d)
+		}
+	}
+
+	// GPU pools are shared with other controller instances with a quota or a lease
+	switch cfg.GPUPoolCoordination() {
+	case "":
+	case "quota":
+		if q := cfg.GPUPoolQuota(); q <= 0 || q > 1 {
+			return fmt.Errorf("GPU pool quota must be in (0, 1], got %v", q)
+		}
+	case "lease":
+		if cfg.GPUPoolLeaseDuration() <= 0 {
+			return fmt.Errorf("GPU pool lease duration must be positive, got %v", cfg.GPUPoolLeaseDuration())
+		}
+	default:
+		return fmt.Errorf("GPU pool coordination must be one of \"\", \"quota\" or \"lease\", got %q", cfg.GPUPoolCoordination())
+	}
+
+	// Error budgets are tracked over a window against an objective, and bias the thresholds
+	// by a fraction when their remaining fraction is below low or above high
+	if cfg.FeatureEnabled(SLOErrorBudget) {
+		if cfg.ErrorBudgetWindow() <= 0 {
+			return fmt.Errorf("error budget window must be positive, got %v", cfg.ErrorBudgetWindow())
+		}
+		if o := cfg.ErrorBudgetObjective(); o <= 0 || o >= 1 {
+			return fmt.Errorf("error budget objective must be in (0, 1), got %v", o)
+		}
+		if low, high := cfg.ErrorBudgetLow(), cfg.ErrorBudgetHigh(); low < 0 || high > 1 || low > high {
+			return fmt.Errorf("error budget low and high must satisfy 0 <= low <= high <= 1, got %v and %v", low, high)
+		}
+		if b := cfg.ErrorBudgetBias(); b < 0 || b >= 1 {
+			return fmt.Errorf("error budget bias must be in [0, 1), got %v", b)
+		}
+	}
+
 	return nil
 }
 
@@ -14,6 +14,14 @@ const (
 	// even if no VariantAutoscaling resources exist in that namespace yet.
 	// This enables creating namespace-local ConfigMaps before VAs are created, avoiding race conditions.
 	NamespaceConfigEnabledLabelKey = "wva.llmd.ai/config-enabled"
+
+	// PrepullForLabelKey is the label key set on image pre-pull DaemonSets and their pods.
+	// Its value is the name of the VariantAutoscaling the images are pre-pulled for.
+	PrepullForLabelKey = "wva.llmd.ai/prepull-for"
+
+	// GeneratedByLabelKey is the label key set on VariantAutoscalings generated from the template
+	// of an InferencePool. Its value is the name of the InferencePool.
+	GeneratedByLabelKey = "wva.llmd.ai/generated-by"
 )
 
 // Kubernetes Annotation Keys
@@ -25,4 +33,90 @@ const (
 	// even if the namespace has VAs or opt-in labels.
 	// This provides explicit control to exclude namespaces from WVA management.
 	NamespaceExcludeAnnotationKey = "wva.llmd.ai/exclude"
+
+	// PrepullAnnotationKey is the annotation key used to opt a VariantAutoscaling in to
+	// image pre-pulling. When set to "true" and pre-pulling is enabled, the images of the
+	// scale target are pulled on candidate nodes whenever the variant scales up.
+	PrepullAnnotationKey = "wva.llmd.ai/prepull-images"
+
+	// PlacementSpreadAnnotationKey is the annotation key used to spread the replicas of a
+	// VariantAutoscaling across failure domains. Its value is a comma-separated list of node
+	// topology keys (e.g. "topology.kubernetes.io/zone,kubernetes.io/hostname"); a soft
+	// topology spread constraint per key is added to the scale target's pod template.
+	PlacementSpreadAnnotationKey = "wva.llmd.ai/placement-spread"
+
+	// ModelZonesAnnotationKey is the annotation key restricting the replicas of a
+	// VariantAutoscaling to zones, e.g. where a snapshot of its model is available. Its value
+	// is a comma-separated list of topology.kubernetes.io/zone values.
+	ModelZonesAnnotationKey = "wva.llmd.ai/model-zones"
+
+	// ModelVolumeAnnotationKey is the annotation key naming the PersistentVolumeClaim holding
+	// the model of a VariantAutoscaling. Its replicas are restricted to the zones the bound
+	// PersistentVolume is accessible from.
+	ModelVolumeAnnotationKey = "wva.llmd.ai/model-volume"
+
+	// ProbeIntervalAnnotationKey is the annotation key opting the model of a VariantAutoscaling
+	// in to synthetic probes. Its value is a duration (e.g. "30s"): while the model serves, a
+	// tiny request is sent through the gateway at that interval, so that latency measurements
+	// stay available while it is idle. The shortest interval of the VAs of a model applies.
+	ProbeIntervalAnnotationKey = "wva.llmd.ai/probe-interval"
+
+	// VATemplateAnnotationKey is the annotation key holding the VariantAutoscaling template of an
+	// InferencePool: a VariantAutoscaling spec in JSON, without scale target. When the generator
+	// is enabled, a VariantAutoscaling is generated from it for every Deployment of the pool.
+	VATemplateAnnotationKey = "wva.llmd.ai/variantautoscaling-template"
+
+	// VariantCostAnnotationKey is the annotation key overriding, on a Deployment of an
+	// InferencePool, the variantCost of the VariantAutoscaling generated for it.
+	VariantCostAnnotationKey = "wva.llmd.ai/variant-cost"
+
+	// OverloadedAnnotationKey is the annotation key set to "true" on an InferencePool while a
+	// model it serves is overloaded: all its variants are saturated and at their maxReplicas.
+	// The gateway can shed the load of the pool gracefully while it is set.
+	OverloadedAnnotationKey = "wva.llmd.ai/overloaded"
+
+	// MigratedFromAnnotationKey is the annotation key set on a ConfigMap the controller created
+	// by migrating a legacy ConfigMap. Its value is the name of the legacy ConfigMap.
+	MigratedFromAnnotationKey = "wva.llmd.ai/migrated-from"
+
+	// DeprecatedByAnnotationKey is the annotation key set on a legacy ConfigMap once migrated.
+	// Its value is the name of the ConfigMap replacing it; the controller ignores the legacy one.
+	DeprecatedByAnnotationKey = "wva.llmd.ai/deprecated-by"
+)
+
+// VariantAutoscaling priorities.
+const (
+	// PriorityAnnotationKey is the annotation key setting the priority of a VariantAutoscaling.
+	// When the controller is overloaded, the effective reconcile interval of the VAs whose
+	// priority is PriorityLow is lengthened, so that the others keep being optimized on time.
+	PriorityAnnotationKey = "wva.llmd.ai/priority"
+
+	// PriorityLow is the value of PriorityAnnotationKey for low-priority VAs.
+	PriorityLow = "low"
+)
+
+// VariantAutoscaling dry-run.
+const (
+	// DryRunAnnotationKey is the annotation key putting a VariantAutoscaling in dry-run when
+	// set to "true": its decisions are still computed and published in its status, but not
+	// emitted to its external autoscaler. Being stored on the VA, it applies whichever replica
+	// is the leader and survives restarts.
+	DryRunAnnotationKey = "wva.llmd.ai/dry-run"
+)
+
+// LeaderWorkerSet scale targets.
+const (
+	// LeaderWorkerSetAPIVersion is the API version of LeaderWorkerSets.
+	LeaderWorkerSetAPIVersion = "leaderworkerset.x-k8s.io/v1"
+
+	// LeaderWorkerSetKind is the kind of LeaderWorkerSets.
+	LeaderWorkerSetKind = "LeaderWorkerSet"
+
+	// LeaderWorkerSetNameLabelKey is the label the LeaderWorkerSet controller sets on the pods
+	// of a LeaderWorkerSet. Its value is the name of the LeaderWorkerSet.
+	LeaderWorkerSetNameLabelKey = "leaderworkerset.sigs.k8s.io/name"
+
+	// LeaderWorkerSetGroupIndexLabelKey is the label the LeaderWorkerSet controller sets on the
+	// pods of a LeaderWorkerSet. Its value is the index of the group (replica) of the pod.
+	LeaderWorkerSetGroupIndexLabelKey = "leaderworkerset.sigs.k8s.io/group-index"
 )
@@ -108,6 +108,95 @@ const (
 	// WVADesiredRatio is a gauge that tracks the ratio of desired to current replicas.
 	// Labels: variant_name, namespace, accelerator_type
 	WVADesiredRatio = "wva_desired_ratio"
+
+	// WVAAllocationFlapsTotal is a counter that tracks pending scaling changes that were
+	// withdrawn or reversed before the change dampener applied them.
+	// Labels: variant_name, namespace
+	WVAAllocationFlapsTotal = "wva_allocation_flaps_total"
+
+	// WVADampenedChangesTotal is a counter that tracks scaling changes held back by the
+	// change dampener because they were not yet stable across optimization runs.
+	// Labels: variant_name, namespace
+	WVADampenedChangesTotal = "wva_dampened_changes_total"
+
+	// WVATenantGPUShortfall is a gauge that tracks the GPUs requested by a tenant's
+	// scale-up decisions that the GPU limiter could not grant in the last run.
+	// Labels: tenant
+	WVATenantGPUShortfall = "wva_tenant_gpu_shortfall"
+
+	// WVACapacityShortfallReplicas is a gauge that tracks the scale-up replicas of a
+	// variant that the GPU limiter could not grant in the last run.
+	// Labels: variant_name, namespace, accelerator_type
+	WVACapacityShortfallReplicas = "wva_capacity_shortfall_replicas"
+
+	// WVAControllerQueueDepth is a gauge that tracks the total depth of the controller's
+	// reconcile work queues, sampled once per optimization cycle.
+	WVAControllerQueueDepth = "wva_controller_reconcile_queue_depth"
+
+	// WVAControllerQueryLatencySeconds is a gauge that tracks the highest latency of the
+	// controller's metrics queries in the last optimization cycle.
+	WVAControllerQueryLatencySeconds = "wva_controller_query_latency_seconds"
+
+	// WVAControllerBackpressureSlowdown is a gauge that tracks the factor by which
+	// back-pressure lengthens the effective reconcile interval of low-priority variants.
+	WVAControllerBackpressureSlowdown = "wva_controller_backpressure_slowdown"
+
+	// WVAControllerDeferredVariants is a gauge that tracks the low-priority variants
+	// deferred by back-pressure in the last optimization cycle.
+	WVAControllerDeferredVariants = "wva_controller_deferred_variants"
+
+	// WVAControllerTrackedModels is a gauge that tracks the models the controller keeps
+	// state for, including those no longer referenced by a VA until their state is collected.
+	WVAControllerTrackedModels = "wva_controller_tracked_models"
+
+	// WVAConditionTransitionsTotal is a counter that tracks the status transitions of
+	// the conditions of each variant.
+	// Labels: variant_name, namespace, condition_type, status, reason
+	WVAConditionTransitionsTotal = "wva_condition_transitions_total"
+
+	// WVAConditionStatus is a gauge that tracks whether each condition of each variant is
+	// True (1) or not (0).
+	// Labels: variant_name, namespace, condition_type
+	WVAConditionStatus = "wva_condition_status"
+
+	// WVAConfigInvalidEntries is a gauge that tracks the entries of each saturation scaling
+	// ConfigMap that failed to parse or validate, and are ignored.
+	// Labels: configmap, namespace
+	WVAConfigInvalidEntries = "wva_config_invalid_entries"
+
+	// WVASLOErrorBudgetRemaining is a gauge that tracks the remaining fraction of the SLO
+	// error budget of each service class over the tracking window.
+	// Labels: service_class
+	WVASLOErrorBudgetRemaining = "wva_slo_error_budget_remaining"
+
+	// WVASLOErrorBudgetBurnRate is a gauge that tracks the rate at which the SLO error budget
+	// of each service class is consumed: 1 consumes the budget exactly over the window.
+	// Labels: service_class
+	WVASLOErrorBudgetBurnRate = "wva_slo_error_budget_burn_rate"
+
+	// WVAFeatureEnabled is a gauge that tracks whether each feature gate is enabled (1) or not (0).
+	// Labels: name, stage
+	WVAFeatureEnabled = "wva_feature_enabled"
+
+	// WVAModelOverloaded is a gauge that tracks whether each model is overloaded (1) or not (0):
+	// all its variants are saturated and at their maxReplicas, so the gateway should shed load.
+	// Labels: model_name, namespace
+	WVAModelOverloaded = "wva_model_overloaded"
+
+	// WVAFallbackReplicas is a gauge that tracks the replicas of each variant recommended on
+	// its fallback accelerator class while its accelerator is exhausted.
+	// Labels: variant_name, namespace, accelerator_type
+	WVAFallbackReplicas = "wva_fallback_replicas"
+
+	// WVAScaleDecisionLatencySeconds is a histogram that tracks the time from the metrics sample
+	// that crossed the scaling thresholds of a variant to the update of its desired replicas metric.
+	// Labels: variant_name, namespace, direction (up/down)
+	WVAScaleDecisionLatencySeconds = "wva_scale_decision_latency_seconds"
+
+	// WVAScaleActuationLatencySeconds is a histogram that tracks the time from the metrics sample
+	// that crossed the scaling thresholds of a variant to the change of the replicas of its Deployment.
+	// Labels: variant_name, namespace, direction (up/down)
+	WVAScaleActuationLatencySeconds = "wva_scale_actuation_latency_seconds"
 )
 
 // Metric Label Names
@@ -120,4 +209,11 @@ const (
 	LabelReason             = "reason"
 	LabelAcceleratorType    = "accelerator_type"
 	LabelControllerInstance = "controller_instance"
+	LabelTenant             = "tenant"
+	LabelConditionType      = "condition_type"
+	LabelStatus             = "status"
+	LabelFeatureName        = "name"
+	LabelFeatureStage       = "stage"
+	LabelServiceClass       = "service_class"
+	LabelConfigMap          = "configmap"
 )
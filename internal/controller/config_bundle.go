@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// ApplyScalingConfigBundle writes the sections of a scaling configuration bundle to the global
+// ConfigMaps of the controller, replacing their data: modelScaling to the saturation scaling
+// ConfigMap, scaleToZero to the scale-to-zero ConfigMap and acceleratorCosts to the accelerator
+// unit cost ConfigMap. Sections the bundle does not set leave their ConfigMap alone.
+// The entries are validated as the ConfigMap reconciler would parse them, and nothing is written
+// if one is invalid. The serviceClasses and optimizer sections are not used by the controller.
+func ApplyScalingConfigBundle(ctx context.Context, reader client.Reader, writer client.Writer, bundle *infernoConfig.ScalingConfigBundle) error {
+	logger := log.FromContext(ctx)
+
+	sections := make(map[string]map[string]string)
+	if bundle.ModelScaling != nil {
+		data, err := bundleEntriesToConfigMapData(bundle.ModelScaling, validateSaturationEntry)
+		if err != nil {
+			return fmt.Errorf("invalid modelScaling section: %w", err)
+		}
+		sections[config.SaturationConfigMapName()] = data
+	}
+	if bundle.ScaleToZero != nil {
+		data, err := bundleEntriesToConfigMapData(bundle.ScaleToZero, validateScaleToZeroEntry)
+		if err != nil {
+			return fmt.Errorf("invalid scaleToZero section: %w", err)
+		}
+		sections[config.DefaultScaleToZeroConfigMapName] = data
+	}
+	if bundle.AcceleratorCosts != nil {
+		data := make(map[string]string, len(bundle.AcceleratorCosts))
+		for name, cost := range bundle.AcceleratorCosts {
+			var zones map[string]string
+			if len(cost.Zones) > 0 {
+				zones = make(map[string]string, len(cost.Zones))
+				for zone, zoneCost := range cost.Zones {
+					zones[zone] = strconv.FormatFloat(zoneCost, 'f', -1, 64)
+				}
+			}
+			entry, err := yaml.Marshal(config.AcceleratorCost{
+				Device: cost.Device,
+				Cost:   strconv.FormatFloat(cost.Cost, 'f', -1, 64),
+				Zones:  zones,
+			})
+			if err != nil {
+				return fmt.Errorf("invalid acceleratorCosts section: %w", err)
+			}
+			data[name] = string(entry)
+		}
+		sections[config.DefaultAcceleratorCostConfigMapName] = data
+	}
+	if len(bundle.ServiceClasses) > 0 || bundle.Optimizer != nil {
+		logger.Info("Ignoring the serviceClasses and optimizer sections of the scaling config bundle, not used by the controller")
+	}
+
+	namespace := config.SystemNamespace()
+	for name, data := range sections {
+		if err := writeConfigMapData(ctx, reader, writer, name, namespace, data); err != nil {
+			return err
+		}
+		logger.Info("Applied scaling config bundle to ConfigMap", "name", name, "namespace", namespace, "entries", len(data))
+	}
+	return nil
+}
+
+// ExportScalingConfigBundle reads the global ConfigMaps of the controller into a scaling
+// configuration bundle. ConfigMaps that do not exist leave their section unset.
+func ExportScalingConfigBundle(ctx context.Context, reader client.Reader) (*infernoConfig.ScalingConfigBundle, error) {
+	bundle := infernoConfig.NewScalingConfigBundle()
+	namespace := config.SystemNamespace()
+
+	data, err := readConfigMapData(ctx, reader, config.SaturationConfigMapName(), namespace)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.ModelScaling, err = configMapDataToBundleEntries(data); err != nil {
+		return nil, fmt.Errorf("failed to export ConfigMap %s/%s: %w", namespace, config.SaturationConfigMapName(), err)
+	}
+
+	data, err = readConfigMapData(ctx, reader, config.DefaultScaleToZeroConfigMapName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.ScaleToZero, err = configMapDataToBundleEntries(data); err != nil {
+		return nil, fmt.Errorf("failed to export ConfigMap %s/%s: %w", namespace, config.DefaultScaleToZeroConfigMapName, err)
+	}
+
+	data, err = readConfigMapData(ctx, reader, config.DefaultAcceleratorCostConfigMapName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		bundle.AcceleratorCosts = make(map[string]infernoConfig.AcceleratorCostSpec, len(data))
+		for name, entry := range data {
+			var cost config.AcceleratorCost
+			if err := yaml.Unmarshal([]byte(entry), &cost); err != nil {
+				return nil, fmt.Errorf("failed to export accelerator cost %q: %w", name, err)
+			}
+			value, err := strconv.ParseFloat(cost.Cost, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to export accelerator cost %q: %w", name, err)
+			}
+			spec := infernoConfig.AcceleratorCostSpec{Device: cost.Device, Cost: value}
+			for zone, zoneValue := range cost.Zones {
+				zoneCost, err := strconv.ParseFloat(zoneValue, 64)
+				if err != nil {
+					return nil, fmt.Errorf("failed to export accelerator cost %q in zone %q: %w", name, zone, err)
+				}
+				if spec.Zones == nil {
+					spec.Zones = make(map[string]float64, len(cost.Zones))
+				}
+				spec.Zones[zone] = zoneCost
+			}
+			bundle.AcceleratorCosts[name] = spec
+		}
+	}
+
+	return bundle, bundle.Validate()
+}
+
+// validateSaturationEntry validates a saturation scaling ConfigMap entry.
+func validateSaturationEntry(entry string) error {
+	var satConfig interfaces.SaturationScalingConfig
+	if err := yaml.Unmarshal([]byte(entry), &satConfig); err != nil {
+		return err
+	}
+	return satConfig.Validate()
+}
+
+// validateScaleToZeroEntry validates a scale-to-zero ConfigMap entry.
+func validateScaleToZeroEntry(entry string) error {
+	var scaleToZero config.ModelScaleToZeroConfig
+	if err := yaml.Unmarshal([]byte(entry), &scaleToZero); err != nil {
+		return err
+	}
+	if scaleToZero.RetentionPeriod != "" {
+		if _, err := config.ValidateRetentionPeriod(scaleToZero.RetentionPeriod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleEntriesToConfigMapData renders the entries of a bundle section as ConfigMap data,
+// validating each rendered entry.
+func bundleEntriesToConfigMapData(entries map[string]infernoConfig.ConfigEntry, validate func(string) error) (map[string]string, error) {
+	data := make(map[string]string, len(entries))
+	for key, entry := range entries {
+		rendered, err := yaml.Marshal(map[string]any(entry))
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", key, err)
+		}
+		if err := validate(string(rendered)); err != nil {
+			return nil, fmt.Errorf("entry %q: %w", key, err)
+		}
+		data[key] = string(rendered)
+	}
+	return data, nil
+}
+
+// configMapDataToBundleEntries parses ConfigMap data into the entries of a bundle section.
+func configMapDataToBundleEntries(data map[string]string) (map[string]infernoConfig.ConfigEntry, error) {
+	if data == nil {
+		return nil, nil
+	}
+	entries := make(map[string]infernoConfig.ConfigEntry, len(data))
+	for key, value := range data {
+		var entry map[string]any
+		if err := yaml.Unmarshal([]byte(value), &entry); err != nil {
+			return nil, fmt.Errorf("entry %q: %w", key, err)
+		}
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
+// readConfigMapData returns the data of a ConfigMap, or nil if it does not exist.
+func readConfigMapData(ctx context.Context, reader client.Reader, name, namespace string) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	if cm.Data == nil {
+		return map[string]string{}, nil
+	}
+	return cm.Data, nil
+}
+
+// writeConfigMapData creates a ConfigMap with the given data, or replaces the data of the existing one.
+func writeConfigMapData(ctx context.Context, reader client.Reader, writer client.Writer, name, namespace string, data map[string]string) error {
+	cm := &corev1.ConfigMap{}
+	err := reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}
+		if err := writer.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	cm.Data = data
+	if err := writer.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
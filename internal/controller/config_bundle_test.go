@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+func TestApplyAndExportScalingConfigBundle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	stale := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.DefaultAcceleratorCostConfigMapName, Namespace: config.SystemNamespace()},
+		Data:       map[string]string{"A100": "cost: \"20\""},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale).Build()
+	ctx := context.Background()
+
+	bundle := infernoConfig.NewScalingConfigBundle()
+	bundle.ModelScaling = map[string]infernoConfig.ConfigEntry{
+		"default": {"kvCacheThreshold": 0.8, "queueLengthThreshold": 5},
+	}
+	bundle.AcceleratorCosts = map[string]infernoConfig.AcceleratorCostSpec{
+		"H100": {Device: "NVIDIA-H100-80GB-HBM3", Cost: 40},
+	}
+	require.NoError(t, ApplyScalingConfigBundle(ctx, fakeClient, fakeClient, bundle))
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.SaturationConfigMapName(), Namespace: config.SystemNamespace()}, cm))
+	configs, count := parseSaturationConfig(cm.Data, logr.Discard())
+	assert.Equal(t, 1, count, "the modelScaling entry should be a valid saturation config entry")
+	assert.Equal(t, 0.8, configs["default"].KvCacheThreshold)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.DefaultAcceleratorCostConfigMapName, Namespace: config.SystemNamespace()}, cm))
+	assert.Equal(t, config.AcceleratorCosts{"H100": 40}, config.ParseAcceleratorCostConfigMap(cm.Data),
+		"the acceleratorCosts section should replace the data of the ConfigMap")
+
+	exported, err := ExportScalingConfigBundle(ctx, fakeClient)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.AcceleratorCosts, exported.AcceleratorCosts)
+	assert.Equal(t, 0.8, exported.ModelScaling["default"]["kvCacheThreshold"])
+	assert.Nil(t, exported.ScaleToZero, "a missing ConfigMap should leave its section unset")
+}
+
+func TestApplyScalingConfigBundleInvalidEntry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	bundle := infernoConfig.NewScalingConfigBundle()
+	bundle.ModelScaling = map[string]infernoConfig.ConfigEntry{"default": {"kvCacheThreshold": 2.0}}
+	bundle.AcceleratorCosts = map[string]infernoConfig.AcceleratorCostSpec{"H100": {Cost: 40}}
+	assert.Error(t, ApplyScalingConfigBundle(ctx, fakeClient, fakeClient, bundle))
+
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, fakeClient.List(ctx, cms))
+	assert.Empty(t, cms.Items, "nothing should be written when an entry is invalid")
+}
@@ -30,6 +30,11 @@ func (r *ConfigMapReconciler) BootstrapInitialConfigMaps(ctx context.Context) er
 	}{
 		{name: config.SaturationConfigMapName(), namespace: systemNamespace, isGlobal: true},
 		{name: config.DefaultScaleToZeroConfigMapName, namespace: systemNamespace, isGlobal: true},
+		{name: config.DefaultAcceleratorCostConfigMapName, namespace: systemNamespace, isGlobal: true},
+		{name: config.DefaultPromQLTemplatesConfigMapName, namespace: systemNamespace, isGlobal: true},
+		{name: config.DefaultPrometheusTenantsConfigMapName, namespace: systemNamespace, isGlobal: true},
+		{name: config.DefaultModelReplicaLimitsConfigMapName, namespace: systemNamespace, isGlobal: true},
+		{name: config.DefaultLoggingConfigMapName, namespace: systemNamespace, isGlobal: true},
 	}
 
 	if watchNamespace := r.Config.WatchNamespace(); watchNamespace != "" && watchNamespace != systemNamespace {
@@ -75,6 +80,16 @@ func (r *ConfigMapReconciler) bootstrapConfigMap(ctx context.Context, name, name
 		r.handleSaturationConfigMap(ctx, cm, namespace, isGlobal)
 	case config.DefaultScaleToZeroConfigMapName:
 		r.handleScaleToZeroConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultAcceleratorCostConfigMapName:
+		r.handleAcceleratorCostConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultPromQLTemplatesConfigMapName:
+		r.handlePromQLTemplatesConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultPrometheusTenantsConfigMapName:
+		r.handlePrometheusTenantsConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultModelReplicaLimitsConfigMapName:
+		r.handleModelReplicaLimitsConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultLoggingConfigMapName:
+		r.handleLoggingConfigMap(ctx, cm, namespace, isGlobal)
 	default:
 		logger.V(1).Info("Ignoring unrecognized bootstrap ConfigMap", "name", name, "namespace", namespace)
 	}
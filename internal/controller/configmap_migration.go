@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// legacyConfigMap is a ConfigMap of the controller that was renamed.
+type legacyConfigMap struct {
+	// legacyName is the former name of the ConfigMap
+	legacyName string
+	// name returns the current name of the ConfigMap
+	name func() string
+	// convert converts the data of the legacy ConfigMap to the format of the current one
+	convert func(data map[string]string) map[string]string
+}
+
+// legacyConfigMaps lists the renamed ConfigMaps of the controller.
+var legacyConfigMaps = []legacyConfigMap{
+	{
+		legacyName: config.LegacySaturationConfigMapName,
+		name:       config.SaturationConfigMapName,
+		convert:    convertSaturationConfigMapData,
+	},
+}
+
+// MigrateLegacyConfigMaps migrates the renamed ConfigMaps of the controller in all namespaces,
+// so that clusters configured with their legacy names keep their configuration without manual
+// edits. For each legacy ConfigMap not migrated yet, a ConfigMap with the current name and the
+// converted data is created, annotated with the legacy name, and the legacy ConfigMap is then
+// annotated as deprecated by it. A ConfigMap that already exists with the current name is not
+// overwritten, since it already replaces the legacy one. The legacy ConfigMap is kept, so that
+// the controllers of the previous version keep reading it during a rollout. Migration is
+// idempotent, so that every replica of the controller can run it at startup.
+func MigrateLegacyConfigMaps(ctx context.Context, reader client.Reader, writer client.Writer) error {
+	logger := log.FromContext(ctx)
+
+	for _, legacy := range legacyConfigMaps {
+		name := legacy.name()
+		if name == legacy.legacyName {
+			// The controller is configured to keep using the legacy name
+			continue
+		}
+
+		var cms corev1.ConfigMapList
+		if err := reader.List(ctx, &cms, client.MatchingFields{"metadata.name": legacy.legacyName}); err != nil {
+			return fmt.Errorf("failed to list legacy ConfigMaps %s: %w", legacy.legacyName, err)
+		}
+		for i := range cms.Items {
+			cm := &cms.Items[i]
+			if _, ok := cm.Annotations[constants.DeprecatedByAnnotationKey]; ok {
+				continue
+			}
+
+			migrated := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   cm.Namespace,
+					Labels:      cm.Labels,
+					Annotations: map[string]string{constants.MigratedFromAnnotationKey: cm.Name},
+				},
+				Data: legacy.convert(cm.Data),
+			}
+			err := writer.Create(ctx, migrated)
+			switch {
+			case err == nil:
+				logger.Info("Migrated legacy ConfigMap",
+					"legacyName", cm.Name,
+					"name", name,
+					"namespace", cm.Namespace,
+					"entries", len(migrated.Data))
+			case apierrors.IsAlreadyExists(err):
+				logger.Info("ConfigMap already replaces legacy ConfigMap, not migrating it",
+					"legacyName", cm.Name,
+					"name", name,
+					"namespace", cm.Namespace)
+			default:
+				return fmt.Errorf("failed to create ConfigMap %s/%s from legacy ConfigMap %s: %w",
+					cm.Namespace, name, cm.Name, err)
+			}
+
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string, 1)
+			}
+			cm.Annotations[constants.DeprecatedByAnnotationKey] = name
+			if err := writer.Update(ctx, cm); err != nil && !apierrors.IsConflict(err) {
+				return fmt.Errorf("failed to mark legacy ConfigMap %s/%s deprecated: %w", cm.Namespace, cm.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// convertSaturationConfigMapData converts the entries of a legacy saturation scaling ConfigMap,
+// rendering them as the scaling configuration bundle does. Entries that cannot be converted are
+// kept as is, so that the ConfigMap reconciler treats them as it did before.
+func convertSaturationConfigMapData(data map[string]string) map[string]string {
+	out := make(map[string]string, len(data))
+	for key, value := range data {
+		out[key] = value
+		entries, err := configMapDataToBundleEntries(map[string]string{key: value})
+		if err != nil {
+			continue
+		}
+		if converted, err := bundleEntriesToConfigMapData(entries, validateSaturationEntry); err == nil {
+			out[key] = converted[key]
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func TestMigrateLegacyConfigMaps(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	legacy := func(namespace string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.LegacySaturationConfigMapName, Namespace: namespace},
+			Data:       data,
+		}
+	}
+	current := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.DefaultSaturationConfigMapName, Namespace: "team-b"},
+		Data:       map[string]string{"default": "kvCacheThreshold: 0.7\n"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithIndex(&corev1.ConfigMap{}, "metadata.name", func(obj client.Object) []string {
+			return []string{obj.GetName()}
+		}).
+		WithObjects(
+			legacy(config.SystemNamespace(), map[string]string{
+				"default": "kvCacheThreshold: 0.8\nqueueLengthThreshold: 5\n",
+				"invalid": "{invalid",
+			}),
+			legacy("team-b", map[string]string{"default": "kvCacheThreshold: 0.9\n"}),
+			current,
+		).Build()
+	ctx := context.Background()
+
+	require.NoError(t, MigrateLegacyConfigMaps(ctx, fakeClient, fakeClient))
+
+	// The legacy ConfigMap is converted to the current name and marked deprecated
+	migrated := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.DefaultSaturationConfigMapName, Namespace: config.SystemNamespace()}, migrated))
+	assert.Equal(t, config.LegacySaturationConfigMapName, migrated.Annotations[constants.MigratedFromAnnotationKey])
+	assert.Contains(t, migrated.Data["default"], "kvCacheThreshold: 0.8")
+	assert.Equal(t, "{invalid", migrated.Data["invalid"])
+
+	deprecated := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.LegacySaturationConfigMapName, Namespace: config.SystemNamespace()}, deprecated))
+	assert.Equal(t, config.DefaultSaturationConfigMapName, deprecated.Annotations[constants.DeprecatedByAnnotationKey])
+
+	// An existing ConfigMap with the current name is not overwritten
+	kept := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.DefaultSaturationConfigMapName, Namespace: "team-b"}, kept))
+	assert.Equal(t, current.Data, kept.Data)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.LegacySaturationConfigMapName, Namespace: "team-b"}, deprecated))
+	assert.Equal(t, config.DefaultSaturationConfigMapName, deprecated.Annotations[constants.DeprecatedByAnnotationKey])
+
+	// Migrated ConfigMaps are not migrated again
+	require.NoError(t, fakeClient.Delete(ctx, migrated))
+	require.NoError(t, MigrateLegacyConfigMaps(ctx, fakeClient, fakeClient))
+	err := fakeClient.Get(ctx, client.ObjectKey{Name: config.DefaultSaturationConfigMapName, Namespace: config.SystemNamespace()}, migrated)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestMigrateLegacyConfigMaps_LegacyNameConfigured(t *testing.T) {
+	t.Setenv("SATURATION_CONFIG_MAP_NAME", config.LegacySaturationConfigMapName)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	legacy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.LegacySaturationConfigMapName, Namespace: config.SystemNamespace()},
+		Data:       map[string]string{"default": "kvCacheThreshold: 0.8\n"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(legacy).Build()
+	ctx := context.Background()
+
+	require.NoError(t, MigrateLegacyConfigMaps(ctx, fakeClient, fakeClient))
+	kept := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(legacy), kept))
+	assert.Empty(t, kept.Annotations)
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,7 +29,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 )
 
 // ConfigMapReconciler reconciles ConfigMaps to update the unified configuration.
@@ -82,6 +86,18 @@ func (r *ConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		r.handleSaturationConfigMap(ctx, cm, namespace, isGlobal)
 	case config.DefaultScaleToZeroConfigMapName:
 		r.handleScaleToZeroConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultAcceleratorCostConfigMapName:
+		r.handleAcceleratorCostConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultPromQLTemplatesConfigMapName:
+		r.handlePromQLTemplatesConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultPrometheusTenantsConfigMapName:
+		r.handlePrometheusTenantsConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultModelReplicaLimitsConfigMapName:
+		r.handleModelReplicaLimitsConfigMap(ctx, cm, namespace, isGlobal)
+	case config.DefaultLoggingConfigMapName:
+		r.handleLoggingConfigMap(ctx, cm, namespace, isGlobal)
+	case config.LegacySaturationConfigMapName:
+		r.handleLegacyConfigMap(ctx, cm, config.SaturationConfigMapName())
 	default:
 		logger.V(1).Info("Ignoring unrecognized ConfigMap", "name", name, "namespace", namespace)
 	}
@@ -102,8 +118,13 @@ func (r *ConfigMapReconciler) handleConfigMapDeletion(ctx context.Context, name,
 	logger := log.FromContext(ctx)
 	systemNamespace := config.SystemNamespace()
 
-	// Only handle namespace-local ConfigMap deletions (not global)
+	// Only handle namespace-local ConfigMap deletions (not global), except for the
+	// logging ConfigMap, whose deletion restores the module verbosity of the flags
 	if namespace == systemNamespace {
+		if name == config.DefaultLoggingConfigMapName && r.Config != nil {
+			logging.SetModuleVerbosity(r.Config.ModuleVerbosity())
+			logger.Info("Restored module log verbosity on ConfigMap deletion", "modules", logging.ModuleVerbosity())
+		}
 		return
 	}
 
@@ -156,6 +177,9 @@ func (r *ConfigMapReconciler) handleSaturationConfigMap(ctx context.Context, cm
 
 	// Parse saturation scaling config entries
 	configs, count := parseSaturationConfig(cm.Data, logger)
+	if err := metrics.NewMetricsEmitter().EmitConfigInvalidEntriesMetrics(ctx, cm.GetName(), namespace, len(cm.Data)-count); err != nil {
+		logger.V(1).Info("Failed to emit invalid config entries metric", "error", err.Error())
+	}
 
 	// Update global or namespace-local config
 	if isGlobal {
@@ -192,3 +216,104 @@ func (r *ConfigMapReconciler) handleScaleToZeroConfigMap(ctx context.Context, cm
 		logger.Info("Updated namespace-local scale-to-zero config from ConfigMap", "namespace", namespace, "modelCount", len(scaleToZeroConfig))
 	}
 }
+
+// handleAcceleratorCostConfigMap handles updates to the accelerator unit cost ConfigMap.
+// Accelerator costs are cluster-wide, so only the global ConfigMap is used.
+func (r *ConfigMapReconciler) handleAcceleratorCostConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	if !isGlobal {
+		logger.V(1).Info("Ignoring namespace-local accelerator cost ConfigMap", "name", cm.GetName(), "namespace", namespace)
+		return
+	}
+
+	costs := config.ParseAcceleratorCostConfigMap(cm.Data)
+	r.Config.UpdateAcceleratorCosts(costs)
+	r.Config.UpdateAcceleratorZoneCosts(config.ParseAcceleratorZoneCosts(cm.Data))
+	logger.Info("Updated accelerator unit costs from ConfigMap", "entries", len(costs))
+}
+
+// handlePromQLTemplatesConfigMap handles updates to the PromQL templates ConfigMap.
+// The collector's query templates are shared by all namespaces, so only the global
+// ConfigMap is used; per-model overrides are entries of it.
+func (r *ConfigMapReconciler) handlePromQLTemplatesConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	if !isGlobal {
+		logger.V(1).Info("Ignoring namespace-local PromQL templates ConfigMap", "name", cm.GetName(), "namespace", namespace)
+		return
+	}
+
+	templates := config.ParsePromQLTemplatesConfigMap(cm.Data)
+	r.Config.UpdatePromQLTemplates(templates)
+	logger.Info("Updated PromQL templates from ConfigMap", "entries", len(templates))
+}
+
+// handlePrometheusTenantsConfigMap handles updates to the Prometheus tenants ConfigMap.
+// The Prometheus of a namespace is set by the cluster operator, not by the namespace,
+// so only the global ConfigMap is used.
+func (r *ConfigMapReconciler) handlePrometheusTenantsConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	if !isGlobal {
+		logger.V(1).Info("Ignoring namespace-local Prometheus tenants ConfigMap", "name", cm.GetName(), "namespace", namespace)
+		return
+	}
+
+	tenants := config.ParsePrometheusTenantsConfigMap(cm.Data)
+	r.Config.UpdatePrometheusTenants(tenants)
+	logger.Info("Updated Prometheus tenants from ConfigMap", "entries", len(tenants))
+}
+
+// handleModelReplicaLimitsConfigMap handles updates to the model replica limits ConfigMap.
+// The limits apply to the replicas of a model across all namespaces, so only the global
+// ConfigMap is used; a namespace cannot lift the limits of its models.
+func (r *ConfigMapReconciler) handleModelReplicaLimitsConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	if !isGlobal {
+		logger.V(1).Info("Ignoring namespace-local model replica limits ConfigMap", "name", cm.GetName(), "namespace", namespace)
+		return
+	}
+
+	limits := config.ParseModelReplicaLimitsConfigMap(cm.Data)
+	r.Config.UpdateModelReplicaLimits(limits)
+	logger.Info("Updated model replica limits from ConfigMap", "entries", len(limits))
+}
+
+// handleLegacyConfigMap warns that a ConfigMap with a legacy name is ignored: it is migrated
+// to its current name at startup, after which edits must go to the current one.
+func (r *ConfigMapReconciler) handleLegacyConfigMap(ctx context.Context, cm *corev1.ConfigMap, name string) {
+	logger := log.FromContext(ctx)
+
+	message := fmt.Sprintf("ConfigMap %s is deprecated and ignored; edit ConfigMap %s instead", cm.GetName(), name)
+	if _, ok := cm.Annotations[constants.DeprecatedByAnnotationKey]; !ok {
+		message = fmt.Sprintf("ConfigMap %s is deprecated and ignored; it is migrated to ConfigMap %s when the controller restarts", cm.GetName(), name)
+	}
+	logger.Info("Ignoring legacy ConfigMap", "name", cm.GetName(), "namespace", cm.GetNamespace(), "replacement", name)
+	if r.Recorder != nil {
+		r.Recorder.Event(cm, corev1.EventTypeWarning, "DeprecatedConfigMap", message)
+	}
+}
+
+// handleLoggingConfigMap handles updates to the logging ConfigMap.
+// Logger verbosity is process-wide, so only the global ConfigMap is used; its
+// entries override the module verbosity set by flags.
+func (r *ConfigMapReconciler) handleLoggingConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	if !isGlobal {
+		logger.V(1).Info("Ignoring namespace-local logging ConfigMap", "name", cm.GetName(), "namespace", namespace)
+		return
+	}
+
+	levels := r.Config.ModuleVerbosity()
+	if levels == nil {
+		levels = map[string]int{}
+	}
+	for module, verbosity := range config.ParseLoggingConfigMap(cm.Data) {
+		levels[module] = verbosity
+	}
+	logging.SetModuleVerbosity(levels)
+	logger.Info("Updated module log verbosity from ConfigMap", "modules", levels)
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/conditions"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+// errorCondition is the condition an error is reported with.
+type errorCondition struct {
+	conditionType string
+	reason        string
+}
+
+// errorConditions maps the sentinel errors of pkg/errors to the condition they are reported with.
+var errorConditions = map[error]errorCondition{
+	wvaerrors.ErrTargetNotFound:       {v1alpha1.TypeTargetResolved, v1alpha1.ReasonTargetNotFound},
+	wvaerrors.ErrTargetAmbiguous:      {v1alpha1.TypeTargetResolved, v1alpha1.ReasonTargetAmbiguous},
+	wvaerrors.ErrInvalidConfiguration: {v1alpha1.TypeOptimizationReady, v1alpha1.ReasonInvalidConfiguration},
+	wvaerrors.ErrMetricsQueryFailed:   {v1alpha1.TypeMetricsAvailable, v1alpha1.ReasonPrometheusError},
+	wvaerrors.ErrMetricsNotAvailable:  {v1alpha1.TypeMetricsAvailable, v1alpha1.ReasonMetricsMissing},
+	wvaerrors.ErrNoFeasibleSolution:   {v1alpha1.TypeOptimizationReady, v1alpha1.ReasonOptimizationFailed},
+}
+
+// conditionForError returns the condition an error is reported with: the condition of the
+// sentinel error it wraps, or OptimizationReady=False with reason OptimizationFailed for
+// errors matching none.
+func conditionForError(err error) errorCondition {
+	if c, ok := errorConditions[wvaerrors.Kind(err)]; ok {
+		return c
+	}
+	return errorCondition{v1alpha1.TypeOptimizationReady, v1alpha1.ReasonOptimizationFailed}
+}
+
+// setErrorCondition reports an error on a VariantAutoscaling as a False condition.
+func setErrorCondition(ctx context.Context, va *v1alpha1.VariantAutoscaling, err error) {
+	c := conditionForError(err)
+	conditions.Set(ctx, va, c.conditionType, metav1.ConditionFalse, c.reason, err.Error())
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+var _ = Describe("Error conditions", func() {
+	DescribeTable("should map errors to conditions",
+		func(err error, conditionType, reason string) {
+			Expect(conditionForError(err)).To(Equal(errorCondition{conditionType, reason}))
+		},
+		Entry("target not found",
+			fmt.Errorf("%w: Deployment llama", wvaerrors.ErrTargetNotFound),
+			llmdVariantAutoscalingV1alpha1.TypeTargetResolved, llmdVariantAutoscalingV1alpha1.ReasonTargetNotFound),
+		Entry("target ambiguous",
+			fmt.Errorf("%w: Deployments [a b] match selector app=llama", wvaerrors.ErrTargetAmbiguous),
+			llmdVariantAutoscalingV1alpha1.TypeTargetResolved, llmdVariantAutoscalingV1alpha1.ReasonTargetAmbiguous),
+		Entry("metrics query failed in a model",
+			&wvaerrors.ModelError{ModelID: "llama", Namespace: "prod", Err: fmt.Errorf("%w: timeout", wvaerrors.ErrMetricsQueryFailed)},
+			llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable, llmdVariantAutoscalingV1alpha1.ReasonPrometheusError),
+		Entry("metrics not available",
+			wvaerrors.ErrMetricsNotAvailable,
+			llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable, llmdVariantAutoscalingV1alpha1.ReasonMetricsMissing),
+		Entry("invalid configuration",
+			fmt.Errorf("%w: no default", wvaerrors.ErrInvalidConfiguration),
+			llmdVariantAutoscalingV1alpha1.TypeOptimizationReady, llmdVariantAutoscalingV1alpha1.ReasonInvalidConfiguration),
+		Entry("no feasible solution",
+			wvaerrors.ErrNoFeasibleSolution,
+			llmdVariantAutoscalingV1alpha1.TypeOptimizationReady, llmdVariantAutoscalingV1alpha1.ReasonOptimizationFailed),
+		Entry("unclassified error",
+			errors.New("boom"),
+			llmdVariantAutoscalingV1alpha1.TypeOptimizationReady, llmdVariantAutoscalingV1alpha1.ReasonOptimizationFailed),
+	)
+
+	It("should report an error as a False condition with the error as message", func() {
+		va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+		setErrorCondition(context.Background(), va, fmt.Errorf("%w: Deployment llama", wvaerrors.ErrTargetNotFound))
+
+		condition := llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeTargetResolved)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonTargetNotFound))
+		Expect(condition.Message).To(Equal("scale target not found: Deployment llama"))
+	})
+})
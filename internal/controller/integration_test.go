@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils/resources"
+)
+
+// The integration specs drive the full reconcile path against the envtest API server:
+// an optimization cycle with a fake collector and a fake solver writes the decisions
+// of the VAs to a decision cache, and the Reconciler reconciles them into the VA
+// status. They are the home of regression tests of decision logic that would
+// otherwise need a Kind cluster with live metrics.
+
+// fakeCollector serves canned replica metrics of the VAs, keyed by namespace/name,
+// in place of the Prometheus collector.
+type fakeCollector map[string][]interfaces.ReplicaMetrics
+
+// fakeSolver recommends the replicas of a variant from its current replicas and replica metrics.
+type fakeSolver func(current int, replicas []interfaces.ReplicaMetrics) int
+
+// fakeEngine runs optimization cycles with a fake collector and solver, reading the
+// current replicas of the scale targets with the actuator.
+type fakeEngine struct {
+	client    client.Client
+	collector fakeCollector
+	solver    fakeSolver
+	decisions *common.InternalDecisionCache
+}
+
+func newFakeEngine(c client.Client, collector fakeCollector, solver fakeSolver) *fakeEngine {
+	return &fakeEngine{client: c, collector: collector, solver: solver, decisions: common.NewDecisionCache()}
+}
+
+// optimize decides the replicas of the VAs of a namespace. VAs without replica metrics
+// get a partial decision reporting the missing metrics, as the engine does.
+func (e *fakeEngine) optimize(ctx context.Context, namespace string) error {
+	var vas llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := e.client.List(ctx, &vas, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	act := actuator.NewActuator(e.client)
+	for i := range vas.Items {
+		va := &vas.Items[i]
+		decision := interfaces.VariantDecision{
+			VariantName:        va.Name,
+			Namespace:          va.Namespace,
+			ModelID:            va.Spec.ModelID,
+			ObservedGeneration: va.Generation,
+		}
+		replicas, ok := e.collector[utils.GetNamespacedKey(va.Namespace, va.Name)]
+		if !ok {
+			decision.MetricsReason = llmdVariantAutoscalingV1alpha1.ReasonMetricsMissing
+			decision.MetricsMessage = "No replica metrics collected"
+			e.decisions.Set(va.Name, va.Namespace, decision)
+			continue
+		}
+		current, err := act.GetCurrentDeploymentReplicas(ctx, va)
+		if err != nil {
+			return fmt.Errorf("failed to get current replicas of %s: %w", va.Name, err)
+		}
+		decision.MetricsAvailable = true
+		decision.MetricsReason = llmdVariantAutoscalingV1alpha1.ReasonMetricsFound
+		decision.MetricsMessage = "Replica metrics collected"
+		decision.AcceleratorName = replicas[0].AcceleratorName
+		decision.CurrentReplicas = int(current)
+		decision.TargetReplicas = e.solver(int(current), replicas)
+		e.decisions.Set(va.Name, va.Namespace, decision)
+	}
+	return nil
+}
+
+var _ = Describe("Reconcile path integration", func() {
+	const namespace = "default"
+
+	var (
+		ctx        context.Context
+		engine     *fakeEngine
+		reconciler *VariantAutoscalingReconciler
+		created    []client.Object
+	)
+
+	// saturationSolver adds a replica when the average KV cache usage is above 80%
+	// and removes one when it is below 20%.
+	saturationSolver := func(current int, replicas []interfaces.ReplicaMetrics) int {
+		var usage float64
+		for _, rm := range replicas {
+			usage += rm.KvCacheUsage
+		}
+		usage /= float64(len(replicas))
+		switch {
+		case usage > 0.8:
+			return current + 1
+		case usage < 0.2 && current > 1:
+			return current - 1
+		}
+		return current
+	}
+
+	createVariant := func(name string, replicas int32) {
+		deployment := resources.CreateLlmdSimDeployment(namespace, name, "integration-model", name, "8000", 0, 0, replicas)
+		Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+		// No Deployment controller runs in envtest: report the replicas as running
+		deployment.Status.Replicas = replicas
+		Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+		va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+				ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+				ModelID:        "integration-model",
+			},
+		}
+		Expect(k8sClient.Create(ctx, va)).To(Succeed())
+		created = append(created, deployment, va)
+	}
+
+	reconcileVariant := func(name string) *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+		key := types.NamespacedName{Name: name, Namespace: namespace}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+		Expect(k8sClient.Get(ctx, key, va)).To(Succeed())
+		return va
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		created = nil
+		engine = newFakeEngine(k8sClient, fakeCollector{}, saturationSolver)
+		reconciler = &VariantAutoscalingReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Recorder:  record.NewFakeRecorder(100),
+			Config:    config.NewTestConfig(),
+			Datastore: datastore.NewDatastore(config.NewTestConfig()),
+			Decisions: engine.decisions,
+		}
+	})
+
+	AfterEach(func() {
+		for _, obj := range created {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+		}
+	})
+
+	It("should reconcile the decision of a saturated variant into its desired allocation", func() {
+		createVariant("integration-saturated", 2)
+		engine.collector[utils.GetNamespacedKey(namespace, "integration-saturated")] = []interfaces.ReplicaMetrics{
+			{PodName: "pod-a", AcceleratorName: "H100", KvCacheUsage: 0.9},
+			{PodName: "pod-b", AcceleratorName: "H100", KvCacheUsage: 0.95},
+		}
+
+		Expect(engine.optimize(ctx, namespace)).To(Succeed())
+		va := reconcileVariant("integration-saturated")
+
+		Expect(va.Status.DesiredOptimizedAlloc.NumReplicas).To(Equal(3))
+		Expect(va.Status.DesiredOptimizedAlloc.Accelerator).To(Equal("H100"))
+		condition := llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		condition = llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeSpecOutOfDate)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should keep the desired allocation of an idle variant at its minimum", func() {
+		createVariant("integration-idle", 1)
+		engine.collector[utils.GetNamespacedKey(namespace, "integration-idle")] = []interfaces.ReplicaMetrics{
+			{PodName: "pod-a", AcceleratorName: "A100", KvCacheUsage: 0.05},
+		}
+
+		Expect(engine.optimize(ctx, namespace)).To(Succeed())
+		va := reconcileVariant("integration-idle")
+
+		Expect(va.Status.DesiredOptimizedAlloc.NumReplicas).To(Equal(1))
+		Expect(va.Status.DesiredOptimizedAlloc.Accelerator).To(Equal("A100"))
+	})
+
+	It("should report missing metrics without a desired allocation", func() {
+		createVariant("integration-no-metrics", 1)
+
+		Expect(engine.optimize(ctx, namespace)).To(Succeed())
+		va := reconcileVariant("integration-no-metrics")
+
+		Expect(va.Status.DesiredOptimizedAlloc.Accelerator).To(BeEmpty())
+		condition := llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonMetricsMissing))
+	})
+
+	It("should not read the decisions of the shared cache", func() {
+		createVariant("integration-isolated", 1)
+		common.DecisionCache.Set("integration-isolated", namespace, interfaces.VariantDecision{
+			VariantName: "integration-isolated", Namespace: namespace,
+			AcceleratorName: "H100", TargetReplicas: 7, MetricsAvailable: true,
+		})
+
+		va := reconcileVariant("integration-isolated")
+
+		Expect(va.Status.DesiredOptimizedAlloc.Accelerator).To(BeEmpty())
+		Expect(llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable)).To(BeNil())
+	})
+})
@@ -2,11 +2,14 @@ package controller
 
 import (
 	"context"
+	"maps"
 
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -36,9 +39,15 @@ func ConfigMapPredicate(ds datastore.Datastore, cfg *config.Config) predicate.Pr
 
 		// Well-known ConfigMap names
 		wellKnownNames := map[string]bool{
-			config.ConfigMapName():                 true,
-			config.SaturationConfigMapName():       true,
-			config.DefaultScaleToZeroConfigMapName: true,
+			config.ConfigMapName():                        true,
+			config.SaturationConfigMapName():              true,
+			config.DefaultScaleToZeroConfigMapName:        true,
+			config.DefaultAcceleratorCostConfigMapName:    true,
+			config.DefaultPromQLTemplatesConfigMapName:    true,
+			config.DefaultPrometheusTenantsConfigMapName:  true,
+			config.DefaultModelReplicaLimitsConfigMapName: true,
+			config.LegacySaturationConfigMapName:          true,
+			config.DefaultLoggingConfigMapName:            true,
 		}
 
 		// Check if this is a well-known ConfigMap name
@@ -89,6 +98,7 @@ func ServiceMonitorPredicate() predicate.Predicate {
 // It allows:
 //   - All Create events
 //   - Update events for ConfigMap (needed to trigger reconcile on config changes)
+//   - Update events for Deployment when its labels changed (needed to re-resolve target selectors)
 //   - Update events for ServiceMonitor when deletionTimestamp is set (finalizers cause deletion to emit Update events)
 //   - Delete events for ServiceMonitor (for immediate deletion detection)
 //
@@ -119,9 +129,22 @@ func EventFilter() predicate.Funcs {
 					}
 				}
 			}
-			// Block Update events for VariantAutoscaling resource.
+			// Allow Update events for Deployment when its labels changed,
+			// so that VAs selecting their target by labels re-resolve it, or
+			// when its replicas changed, to measure the latency of scalings.
+			// The same applies to StatefulSets, for their replicas.
+			switch e.ObjectNew.(type) {
+			case *appsv1.Deployment, *appsv1.StatefulSet:
+				return labelsChanged(e) || replicasChanged(e)
+			}
+			// Allow Update events for VariantAutoscaling when its spec changed (generation bumped),
+			// so that the SpecOutOfDate condition is reported until the optimizer processes the change.
+			if _, ok := e.ObjectNew.(*llmdVariantAutoscalingV1alpha1.VariantAutoscaling); ok {
+				return e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration()
+			}
+			// Block other Update events for VariantAutoscaling resource.
 			// The controller reconciles all VariantAutoscaling resources periodically (every 60s by default),
-			// so individual resource update events would only cause unnecessary reconciles without benefit.
+			// so status-only update events would only cause unnecessary reconciles without benefit.
 			return false
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
@@ -146,6 +169,11 @@ func EventFilter() predicate.Funcs {
 // It allows Create and Delete events for all Deployments to trigger VA reconciliation:
 // - Create: handles the race condition where VA is created before its target deployment
 // - Delete: allows VA to update status and clear metrics when target deployment is removed
+//
+// It also allows Update events that change a Deployment's labels, so that VAs selecting
+// their target by labels re-resolve it when a Deployment starts or stops matching, and
+// its replicas, so that the scale latency of its VA is measured when they change.
+// StatefulSet events are filtered alike.
 func DeploymentPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -153,7 +181,7 @@ func DeploymentPredicate() predicate.Predicate {
 			return true
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return false
+			return labelsChanged(e) || replicasChanged(e)
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			// Allow all Deployment delete events to trigger reconciliation
@@ -241,3 +269,23 @@ func VariantAutoscalingPredicate(k8sClient client.Client, cfg *config.Config) pr
 		return hasLabel && vaInstance == controllerInstance
 	})
 }
+
+// labelsChanged returns true if an update changed the labels of the object.
+func labelsChanged(e event.UpdateEvent) bool {
+	return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+}
+
+// replicasChanged returns true if an update changed the replicas of a Deployment or a
+// StatefulSet.
+func replicasChanged(e event.UpdateEvent) bool {
+	switch oldObj := e.ObjectOld.(type) {
+	case *appsv1.Deployment:
+		newDeploy, ok := e.ObjectNew.(*appsv1.Deployment)
+		return ok && oldObj.Status.Replicas != newDeploy.Status.Replicas
+	case *appsv1.StatefulSet:
+		newSts, ok := e.ObjectNew.(*appsv1.StatefulSet)
+		return ok && oldObj.Status.Replicas != newSts.Status.Replicas
+	default:
+		return false
+	}
+}
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -438,3 +439,52 @@ var _ = Describe("VariantAutoscalingPredicate", func() {
 		})
 	})
 })
+
+var _ = Describe("DeploymentPredicate", func() {
+	deployment := func(labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", Labels: labels}}
+	}
+
+	It("should allow Update events that change the labels", func() {
+		e := event.UpdateEvent{
+			ObjectOld: deployment(map[string]string{"app": "llama"}),
+			ObjectNew: deployment(map[string]string{"app": "llama-v2"}),
+		}
+		Expect(DeploymentPredicate().Update(e)).To(BeTrue())
+		Expect(EventFilter().Update(e)).To(BeTrue())
+	})
+
+	It("should allow Update events that change the replicas", func() {
+		scaled := deployment(map[string]string{"app": "llama"})
+		scaled.Status.Replicas = 3
+		e := event.UpdateEvent{
+			ObjectOld: deployment(map[string]string{"app": "llama"}),
+			ObjectNew: scaled,
+		}
+		Expect(DeploymentPredicate().Update(e)).To(BeTrue())
+		Expect(EventFilter().Update(e)).To(BeTrue())
+	})
+
+	It("should block Update events that keep the labels and replicas", func() {
+		e := event.UpdateEvent{
+			ObjectOld: deployment(map[string]string{"app": "llama"}),
+			ObjectNew: deployment(map[string]string{"app": "llama"}),
+		}
+		Expect(DeploymentPredicate().Update(e)).To(BeFalse())
+		Expect(EventFilter().Update(e)).To(BeFalse())
+	})
+
+	It("should allow Update events that change the replicas of a StatefulSet", func() {
+		statefulSet := func(replicas int32) *appsv1.StatefulSet {
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"}}
+			sts.Status.Replicas = replicas
+			return sts
+		}
+		e := event.UpdateEvent{ObjectOld: statefulSet(1), ObjectNew: statefulSet(3)}
+		Expect(DeploymentPredicate().Update(e)).To(BeTrue())
+		Expect(EventFilter().Update(e)).To(BeTrue())
+
+		e = event.UpdateEvent{ObjectOld: statefulSet(3), ObjectNew: statefulSet(3)}
+		Expect(EventFilter().Update(e)).To(BeFalse())
+	})
+})
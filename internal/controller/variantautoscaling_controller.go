@@ -18,30 +18,45 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/conditions"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/indexers"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
 )
 
 // VariantAutoscalingReconciler reconciles a variantAutoscaling object
@@ -52,21 +67,48 @@ type VariantAutoscalingReconciler struct {
 	Recorder  record.EventRecorder
 	Config    *config.Config      // Unified configuration (injected from main.go)
 	Datastore datastore.Datastore // Datastore for namespace tracking and InferencePool data
+
+	// Decisions are the decisions of the optimization engine reconciled into the VA
+	// status. Defaults to common.DecisionCache, which the engine writes; tests inject
+	// their own to drive the reconcile path without running the engine.
+	Decisions DecisionReader
+}
+
+// DecisionReader reads the latest decision of the optimization engine for a VA.
+type DecisionReader interface {
+	Get(name, namespace string) (interfaces.VariantDecision, bool)
+}
+
+// decisions returns the injected decisions, or the cache the engine writes.
+func (r *VariantAutoscalingReconciler) decisions() DecisionReader {
+	if r.Decisions != nil {
+		return r.Decisions
+	}
+	return common.DecisionCache
 }
 
 // +kubebuilder:rbac:groups=llmd.ai,resources=variantautoscalings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=llmd.ai,resources=variantautoscalings/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=llmd.ai,resources=variantautoscalings/finalizers,verbs=update
+// +kubebuilder:rbac:groups=llmd.ai,resources=namespacecapacityreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=llmd.ai,resources=namespacecapacityreports/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=llmd.ai,resources=emulatednodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=nodes/status,verbs=get;list;update;patch;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;create;delete
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims;persistentvolumes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;update;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;update;list;watch;create
 // Note: The broad ConfigMap permission above is required for namespace-local ConfigMap overrides.
-// The controller filters by well-known names (wva-saturation-scaling-config, wva-model-scale-to-zero-config)
+// The controller filters by well-known names (wva-model-scaling-config, wva-model-scale-to-zero-config)
 // in its predicate logic, providing effective access control.
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // Note: Namespace watch permission is required for label-based namespace opt-in for namespace-local ConfigMaps.
@@ -76,6 +118,11 @@ type VariantAutoscalingReconciler struct {
 const (
 	// ServiceMonitor constants for watching controller's own metrics ServiceMonitor
 	defaultServiceMonitorName = "workload-variant-autoscaler-controller-manager-metrics-monitor"
+
+	// saturatedPriority is the reconcile queue priority of the Engine decisions for VAs
+	// whose last known saturation is at or above the priority saturation. Decisions for
+	// the other VAs get handler.LowPriority, behind spec changes (priority 0).
+	saturatedPriority = 100
 )
 
 var (
@@ -132,54 +179,96 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 		"namespace", va.Namespace,
 		"modelID", va.Spec.ModelID)
 
+	// Resolve scaleTargetSelector to the one workload it selects, on every reconciliation,
+	// so that a renamed workload is followed and an ambiguous selector is reported
+	if va.Spec.ScaleTargetSelector != nil {
+		ref, err := utils.ResolveScaleTargetSelector(ctx, r.Client, &va)
+		if err != nil {
+			if wvaerrors.Kind(err) == nil {
+				logger.Error(err, "Failed to resolve scale target selector")
+				return ctrl.Result{}, err
+			}
+			logger.Info("Scale target selector does not resolve to one workload",
+				"namespace", va.Namespace,
+				"error", err.Error())
+
+			// Stop scaling the previously resolved target until the selector is fixed
+			va.Status.ScaleTargetRef = nil
+			setErrorCondition(ctx, &va, err)
+
+			if err := r.Status().Patch(ctx, &va, client.MergeFrom(fullDesiredAllocPatchBase(originalVA, &va))); err != nil {
+				logger.Error(err, "Failed to update VariantAutoscaling status")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		va.Status.ScaleTargetRef = &ref
+	}
+
 	// Attempts to resolve the target model variant using scaleTargetRef
 
-	// Fetch scale target Deployment
+	// Fetch scale target: a Deployment, a StatefulSet or a LeaderWorkerSet
 	scaleTargetName := va.GetScaleTargetName()
+	scaleTargetKind := va.GetScaleTargetKind()
+	if scaleTargetKind == "" {
+		scaleTargetKind = utils.DeploymentKind
+	}
 
-	var deployment appsv1.Deployment
-	if err := utils.GetDeploymentWithBackoff(ctx, r.Client, scaleTargetName, va.Namespace, &deployment); err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.Info("Scale target Deployment not found, waiting for deployment watch",
+	workload, err := utils.GetScaleTargetWorkload(ctx, r.Client, &va)
+	if err != nil {
+		if apierrors.IsNotFound(err) || errors.Is(err, wvaerrors.ErrInvalidConfiguration) {
+			logger.Info("Scale target not found or not supported, waiting for it to be created",
+				"kind", scaleTargetKind,
 				"name", scaleTargetName,
-				"namespace", va.Namespace)
+				"namespace", va.Namespace,
+				"error", err.Error())
 
 			// Update status to reflect target not found
-			llmdVariantAutoscalingV1alpha1.SetCondition(&va,
-				llmdVariantAutoscalingV1alpha1.TypeTargetResolved,
-				metav1.ConditionFalse,
-				llmdVariantAutoscalingV1alpha1.ReasonTargetNotFound,
-				fmt.Sprintf("Scale target Deployment %s not found", scaleTargetName))
+			if apierrors.IsNotFound(err) {
+				err = fmt.Errorf("%w: %s %s", wvaerrors.ErrTargetNotFound, scaleTargetKind, scaleTargetName)
+			}
+			setErrorCondition(ctx, &va, err)
 
 			if err := r.Status().Patch(ctx, &va, client.MergeFrom(fullDesiredAllocPatchBase(originalVA, &va))); err != nil {
 				logger.Error(err, "Failed to update VariantAutoscaling status")
 				return ctrl.Result{}, err
 			}
 
-			// Don't requeue - the deployment watch will trigger reconciliation
-			// when the target deployment is created
+			// Don't requeue - the Deployment and StatefulSet watches, or the engine for
+			// other kinds, will trigger reconciliation when the target is created
 			return ctrl.Result{}, nil
 		}
-		logger.Error(err, "Failed to get scale target Deployment",
+		logger.Error(err, "Failed to get scale target",
+			"kind", scaleTargetKind,
 			"name", scaleTargetName,
 			"namespace", va.Namespace)
 		return ctrl.Result{}, err
 	}
 
 	// Target found
-	llmdVariantAutoscalingV1alpha1.SetCondition(&va,
+	conditions.Set(ctx, &va,
 		llmdVariantAutoscalingV1alpha1.TypeTargetResolved,
 		metav1.ConditionTrue,
 		llmdVariantAutoscalingV1alpha1.ReasonTargetFound,
-		fmt.Sprintf("Scale target Deployment %s found", scaleTargetName))
+		fmt.Sprintf("Scale target %s %s found", workload.WorkloadKind(), scaleTargetName))
 
 	logger.V(logging.DEBUG).Info(
-		fmt.Sprintf("Scale target Deployment found: name=%s, namespace=%s", scaleTargetName, va.Namespace),
+		fmt.Sprintf("Scale target %s found: name=%s, namespace=%s", workload.WorkloadKind(), scaleTargetName, va.Namespace),
 	)
 
+	// Default the accelerator of the VA from the pod template of its scale target, so that it
+	// need not be duplicated in the accelerator label
+	if err := r.defaultAccelerator(ctx, &va, originalVA, workload); err != nil {
+		logger.Error(err, "Failed to default the accelerator of the VariantAutoscaling")
+		return ctrl.Result{}, err
+	}
+
+	// Measure the latency of the last scaling, completing it once the replicas of the scale target changed
+	recordScaleLatency(ctx, &va, workload)
+
 	// Process Engine Decisions from Shared Cache
 	// This mechanism allows the Engine to trigger updates without touching the API server directly.
-	if decision, ok := common.DecisionCache.Get(va.Name, va.Namespace); ok {
+	if decision, ok := r.decisions().Get(va.Name, va.Namespace); ok {
 		logger.Info("Found decision in cache", "va", va.Name, "namespace", va.Namespace, "metricsAvailable", decision.MetricsAvailable)
 		// Only apply if the decision is fresher than the last one applied or if we haven't applied it
 		// Note: We blindly apply for now, assuming the Engine acts as the source of truth for "Desired" state
@@ -193,7 +282,12 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 				NumReplicas: numReplicas,
 				Accelerator: accelerator,
 				LastRunTime: lastRunTime,
+				ConfigHash:  decision.ConfigHash,
 			}
+			va.Status.DesiredOptimizedAlloc.ScaleUpGrant = common.DecisionToScaleUpGrant(decision)
+			va.Status.DesiredOptimizedAlloc.TrafficWeight = common.DecisionToTrafficWeight(decision)
+			va.Status.DesiredOptimizedAlloc.EngineOutputs = common.DecisionToEngineOutputs(decision)
+			va.Status.DesiredOptimizedAlloc.SLOViolation = common.DecisionToSLOViolation(decision)
 		} else {
 			// When we have a partial decision (no accelerator yet), explicitly preserve
 			// the existing DesiredOptimizedAlloc from the fetched object to avoid
@@ -201,23 +295,296 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 			va.Status.DesiredOptimizedAlloc = originalVA.Status.DesiredOptimizedAlloc
 		}
 
+		// Record the generation of the spec the decision was based on
+		if decision.ObservedGeneration > va.Status.ObservedGeneration {
+			va.Status.ObservedGeneration = decision.ObservedGeneration
+		}
+
+		// Report the decomposition of the TTFT of the variant, cleared when it has no TTFT SLO
+		va.Status.LatencyBudget = common.DecisionToLatencyBudget(decision)
+
+		// Report the performance predicted for the target, cleared when the decision has none
+		va.Status.Prediction = common.DecisionToPrediction(decision)
+
+		// Report the request rate seen by idle detection, cleared when scale-to-zero is disabled
+		va.Status.RequestRate = common.DecisionToRequestRate(decision)
+
+		// Report the scale-down approvals, cleared when no quorum is required
+		va.Status.ScaleDownQuorum = common.DecisionToScaleDownQuorum(decision)
+
 		// Always apply MetricsAvailable condition from cache
 		metricsStatus := metav1.ConditionFalse
 		if decision.MetricsAvailable {
 			metricsStatus = metav1.ConditionTrue
 		}
-		llmdVariantAutoscalingV1alpha1.SetCondition(&va,
+		conditions.Set(ctx, &va,
 			llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable,
 			metricsStatus,
 			decision.MetricsReason,
 			decision.MetricsMessage)
 
+		// Report the error that kept the variant from being optimized, if any, or how the
+		// engine optimized it, and clear a previously reported optimization failure otherwise
+		if decision.Error != nil {
+			setErrorCondition(ctx, &va, decision.Error)
+		} else if decision.OptimizationReason != "" {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
+				metav1.ConditionTrue,
+				decision.OptimizationReason,
+				decision.OptimizationMessage)
+		} else if cond := llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeOptimizationReady); cond != nil && cond.Status == metav1.ConditionFalse {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonOptimizationSucceeded,
+				"Optimization succeeded")
+		}
+
+		// Apply CapacityCapped condition when the capacity ceiling is known
+		if decision.CapacityCeiling > 0 {
+			if decision.CappedByCapacity {
+				conditions.Set(ctx, &va,
+					llmdVariantAutoscalingV1alpha1.TypeCapacityCapped,
+					metav1.ConditionTrue,
+					llmdVariantAutoscalingV1alpha1.ReasonCapacityCeilingApplied,
+					fmt.Sprintf("Recommendation capped at %d replicas, the most the cluster can place on %s",
+						decision.CapacityCeiling, decision.AcceleratorName))
+			} else {
+				conditions.Set(ctx, &va,
+					llmdVariantAutoscalingV1alpha1.TypeCapacityCapped,
+					metav1.ConditionFalse,
+					llmdVariantAutoscalingV1alpha1.ReasonWithinCapacity,
+					fmt.Sprintf("Recommendation within the cluster capacity of %d replicas on %s",
+						decision.CapacityCeiling, decision.AcceleratorName))
+			}
+		}
+
+		// Apply PolicyCapped condition when the replica limit of the model reduced the
+		// recommendation, and clear a previously reported one otherwise
+		if decision.PolicyCapped {
+			message := fmt.Sprintf("Recommendation capped at %d replicas: model %s is limited to %d replicas across its variants",
+				decision.TargetReplicas, va.Spec.ModelID, decision.PolicyMaxReplicas)
+			if decision.PolicyReason != "" {
+				message += " (" + decision.PolicyReason + ")"
+			}
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypePolicyCapped,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonModelReplicaLimitReached,
+				message)
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypePolicyCapped) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypePolicyCapped,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonWithinModelReplicaLimit,
+				"Recommendation keeps the model within its replica limit")
+		}
+
+		// Apply ScaleUpLimited condition when the limiter reduced the recommendation,
+		// and clear a previously reported limit otherwise
+		if decision.WasLimited {
+			reason := string(decision.LimitReason)
+			if reason == "" || decision.LimitReason == interfaces.LimitReasonPoolReservation {
+				reason = llmdVariantAutoscalingV1alpha1.ReasonInsufficientCapacity
+			}
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeScaleUpLimited,
+				metav1.ConditionTrue,
+				reason,
+				fmt.Sprintf("Scale-up limited by %s: %s", decision.LimitedBy, decision.LimitMessage))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeScaleUpLimited) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeScaleUpLimited,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonNotLimited,
+				"Recommendation not limited by available GPUs")
+		}
+
+		// Apply CapacityConstrained condition when the cluster could not provide the GPUs
+		// the recommendation asked for, and clear a previously reported constraint otherwise
+		if decision.WasLimited {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeCapacityConstrained,
+				metav1.ConditionTrue,
+				capacityConstrainedReason(decision.LimitReason),
+				fmt.Sprintf("Target reduced below the recommendation by %s: %s", decision.LimitedBy, decision.LimitMessage))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeCapacityConstrained) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeCapacityConstrained,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonCapacityAvailable,
+				"The cluster provided the GPUs for the recommendation")
+		}
+
+		// Apply TargetUnschedulable condition when pods of the scale target are Pending
+		// for lack of GPUs, and clear a previously reported one otherwise
+		if decision.TargetUnschedulable {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeTargetUnschedulable,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonInsufficientGPU,
+				fmt.Sprintf("%d pods of the scale target are Pending for lack of GPUs; scale-ups are held",
+					decision.UnschedulableReplicas))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeTargetUnschedulable) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeTargetUnschedulable,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonSchedulable,
+				"No pods of the scale target are Pending for lack of GPUs")
+		}
+
+		// Apply PDBConflict condition when a scale-down was clamped by a PodDisruptionBudget,
+		// and clear a previously reported one otherwise
+		if decision.PDBConflict {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypePDBConflict,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonPDBMinAvailable,
+				fmt.Sprintf("Scale-down clamped at %d replicas to keep PodDisruptionBudget %s (minAvailable %d) satisfiable",
+					decision.TargetReplicas, decision.PDBName, decision.PDBMinReplicas))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypePDBConflict) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypePDBConflict,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonPDBSatisfiable,
+				"Recommendation keeps the PodDisruptionBudgets of the scale target satisfiable")
+		}
+
+		// Apply BoundsConflict condition when the HPA bounds clamp the recommendation,
+		// and clear a previously reported one otherwise
+		if decision.BoundsConflict {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeBoundsConflict,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonOutsideHPABounds,
+				fmt.Sprintf("Recommended %d replicas are outside the bounds [%d, %d] of HorizontalPodAutoscaler %s",
+					decision.TargetReplicas, decision.HPAMinReplicas, decision.HPAMaxReplicas, decision.HPAName))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeBoundsConflict) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeBoundsConflict,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonWithinHPABounds,
+				"Recommendation is within the bounds of the HorizontalPodAutoscaler")
+		}
+
+		// Apply WarmingUp condition while the target of a new variant is held at its
+		// current replicas, and clear a previously reported one otherwise
+		if decision.WarmingUp {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeWarmingUp,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonWarmUpPeriod,
+				"Variant is in its warm-up period: metrics are collected and the recommendation is held at the current replicas")
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeWarmingUp) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeWarmingUp,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonWarmedUp,
+				"Warm-up period is over")
+		}
+
+		// Apply ModelOverloaded condition while the gateway is signaled to shed the load of
+		// the model, counting each new episode as a shed event, and clear a previously
+		// reported one otherwise
+		if decision.ModelOverloaded {
+			if cond := llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeModelOverloaded); cond == nil || cond.Status != metav1.ConditionTrue {
+				shedEvents := int32(1)
+				if va.Status.Overload != nil {
+					shedEvents = va.Status.Overload.ShedEvents + 1
+				}
+				va.Status.Overload = &llmdVariantAutoscalingV1alpha1.OverloadStatus{
+					ShedEvents:   shedEvents,
+					LastShedTime: metav1.Now(),
+				}
+			}
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeModelOverloaded,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonAllVariantsAtMaxReplicas,
+				"All variants of the model are saturated and at their maxReplicas; the gateway is signaled to shed load")
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeModelOverloaded) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeModelOverloaded,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonScalingHeadroom,
+				"A variant of the model can scale up")
+		}
+
+		// Apply AcceleratorFallback condition while replicas are recommended on the fallback
+		// accelerator class of the variant, eventing each transition, and clear a previously
+		// reported one otherwise
+		if decision.FallbackReplicas > 0 {
+			since := metav1.Now()
+			previous := va.Status.Fallback
+			switch {
+			case previous == nil || previous.Accelerator != decision.FallbackAccelerator:
+				r.eventf(&va, corev1.EventTypeNormal, "AcceleratorFallbackStarted",
+					"Accelerator %s is exhausted: recommending %d replicas on fallback accelerator %s",
+					decision.AcceleratorName, decision.FallbackReplicas, decision.FallbackAccelerator)
+			case previous.NumReplicas != int32(decision.FallbackReplicas):
+				since = previous.Since
+				r.eventf(&va, corev1.EventTypeNormal, "AcceleratorFallbackScaled",
+					"Recommending %d replicas on fallback accelerator %s, previously %d",
+					decision.FallbackReplicas, decision.FallbackAccelerator, previous.NumReplicas)
+			default:
+				since = previous.Since
+			}
+			va.Status.Fallback = &llmdVariantAutoscalingV1alpha1.FallbackStatus{
+				Accelerator: decision.FallbackAccelerator,
+				NumReplicas: int32(decision.FallbackReplicas),
+				Since:       since,
+			}
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeAcceleratorFallback,
+				metav1.ConditionTrue,
+				llmdVariantAutoscalingV1alpha1.ReasonPreferredAcceleratorExhausted,
+				fmt.Sprintf("Accelerator %s is exhausted: %d replicas are recommended on fallback accelerator %s",
+					decision.AcceleratorName, decision.FallbackReplicas, decision.FallbackAccelerator))
+		} else {
+			if previous := va.Status.Fallback; previous != nil {
+				r.eventf(&va, corev1.EventTypeNormal, "AcceleratorFallbackEnded",
+					"Accelerator %s can host the replicas again: released %d replicas on fallback accelerator %s",
+					decision.AcceleratorName, previous.NumReplicas, previous.Accelerator)
+				va.Status.Fallback = nil
+			}
+			if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeAcceleratorFallback) != nil {
+				conditions.Set(ctx, &va,
+					llmdVariantAutoscalingV1alpha1.TypeAcceleratorFallback,
+					metav1.ConditionFalse,
+					llmdVariantAutoscalingV1alpha1.ReasonPreferredAcceleratorAvailable,
+					"No replicas are recommended on the fallback accelerator class")
+			}
+		}
+
+		// Apply ScaleUpIneffective condition when a scale-up did not reduce saturation,
+		// and clear a previously reported one otherwise
+		if decision.ScaleUpIneffective {
+			reason := llmdVariantAutoscalingV1alpha1.ReasonNoSaturationImprovement
+			if decision.ScaleUpRolledBack {
+				reason = llmdVariantAutoscalingV1alpha1.ReasonScaleUpRolledBack
+			}
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeScaleUpIneffective,
+				metav1.ConditionTrue,
+				reason,
+				fmt.Sprintf("Further scale-ups held: %s", decision.ScaleUpMessage))
+		} else if llmdVariantAutoscalingV1alpha1.GetCondition(&va, llmdVariantAutoscalingV1alpha1.TypeScaleUpIneffective) != nil {
+			conditions.Set(ctx, &va,
+				llmdVariantAutoscalingV1alpha1.TypeScaleUpIneffective,
+				metav1.ConditionFalse,
+				llmdVariantAutoscalingV1alpha1.ReasonScaleUpEffective,
+				"No ineffective scale-up is holding the recommendation")
+		}
+
 		// Note: CurrentAlloc is removed from Status.
 		// Internal allocation state is managed by the Engine and Actuator.
 	} else {
 		logger.Info("No decision found in cache for VA", "va", va.Name, "namespace", va.Namespace)
 	}
 
+	setSpecOutOfDateCondition(ctx, &va)
+
 	// Patch status — use fullDesiredAllocPatchBase to ensure the complete
 	// desiredOptimizedAlloc object is always included in the merge patch.
 	// Without this, MergeFrom only includes changed fields within the struct,
@@ -234,6 +601,94 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return ctrl.Result{}, nil
 }
 
+// setSpecOutOfDateCondition reports whether the spec has changes the optimizer has not
+// processed yet, i.e. whether its generation is ahead of the observed generation.
+func setSpecOutOfDateCondition(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) {
+	if va.Generation > va.Status.ObservedGeneration {
+		conditions.Set(ctx, va,
+			llmdVariantAutoscalingV1alpha1.TypeSpecOutOfDate,
+			metav1.ConditionTrue,
+			llmdVariantAutoscalingV1alpha1.ReasonSpecPending,
+			fmt.Sprintf("Generation %d of the spec has not been processed by the optimizer yet (last processed: %d)",
+				va.Generation, va.Status.ObservedGeneration))
+		return
+	}
+	conditions.Set(ctx, va,
+		llmdVariantAutoscalingV1alpha1.TypeSpecOutOfDate,
+		metav1.ConditionFalse,
+		llmdVariantAutoscalingV1alpha1.ReasonSpecProcessed,
+		fmt.Sprintf("Generation %d of the spec has been processed by the optimizer", va.Generation))
+}
+
+// capacityConstrainedReason returns the CapacityConstrained reason of a limit of the GPU limiter:
+// the tenant quota, the GPUs held by other controller instances, or else a shortage of GPUs.
+func capacityConstrainedReason(limitReason interfaces.LimitReason) string {
+	switch limitReason {
+	case interfaces.LimitReasonTenantQuota:
+		return llmdVariantAutoscalingV1alpha1.ReasonQuota
+	case interfaces.LimitReasonPoolReservation:
+		return llmdVariantAutoscalingV1alpha1.ReasonReservation
+	default:
+		return llmdVariantAutoscalingV1alpha1.ReasonInventoryShortage
+	}
+}
+
+// recordScaleLatency completes the scaling of a VA in flight when the replicas of its
+// scale target changed in its direction, observing its actuation latency, and records the
+// latest scaling of the VA in its status.
+func recordScaleLatency(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, workload interfaces.Workload) {
+	if scaling, ok := common.ScaleLatency.Replicas(va.Name, va.Namespace, int(workload.StatusReplicas()), time.Now()); ok {
+		if err := metrics.NewMetricsEmitter().EmitScaleActuationLatencyMetrics(ctx, va.Name, va.Namespace,
+			strings.ToLower(string(scaling.Direction)), scaling.ReplicasLatency.Duration); err != nil {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit scale actuation latency metrics",
+				"va", va.Name,
+				"error", err.Error())
+		}
+	}
+	if scaling, ok := common.ScaleLatency.Get(va.Name, va.Namespace); ok {
+		va.Status.ScaleLatency = scaling
+	}
+}
+
+// eventf records an event on obj when the reconciler has an event recorder.
+func (r *VariantAutoscalingReconciler) eventf(obj runtime.Object, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder != nil {
+		r.Recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+	}
+}
+
+// defaultAccelerator sets the accelerator label of a VA without one to the accelerator the
+// pod template of its scale target is scheduled on, when it can be told. The number of
+// accelerators per replica needs no defaulting: it is read from the GPU requests of the pod
+// template. The label is patched on a copy of the VA, leaving the status being reconciled
+// untouched, and set on both the VA and its original, so that the status patch excludes it.
+func (r *VariantAutoscalingReconciler) defaultAccelerator(ctx context.Context,
+	va, originalVA *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, workload interfaces.Workload) error {
+	if va.Labels[utils.AcceleratorNameLabel] != "" {
+		return nil
+	}
+	accelerator := utils.PodTemplateAccelerator(workload.PodTemplate())
+	if accelerator == "" {
+		return nil
+	}
+
+	patched := originalVA.DeepCopy()
+	if patched.Labels == nil {
+		patched.Labels = make(map[string]string)
+	}
+	patched.Labels[utils.AcceleratorNameLabel] = accelerator
+	if err := r.Patch(ctx, patched, client.MergeFrom(originalVA)); err != nil {
+		return err
+	}
+	va.Labels = patched.Labels
+	originalVA.Labels = maps.Clone(patched.Labels)
+	ctrl.LoggerFrom(ctx).Info("Defaulted the accelerator from the scale target",
+		"accelerator", accelerator,
+		"kind", workload.WorkloadKind(),
+		"name", workload.GetName())
+	return nil
+}
+
 // fullDesiredAllocPatchBase returns a patch base that forces the full
 // desiredOptimizedAlloc object into the JSON merge patch. Without this,
 // MergeFrom only includes changed fields within nested structs, and the
@@ -263,15 +718,18 @@ func (r *VariantAutoscalingReconciler) handleDeploymentEvent(ctx context.Context
 
 	logger := ctrl.LoggerFrom(ctx)
 
+	// VAs selecting their scale target by labels are re-resolved on any matching Deployment event
+	requests := r.selectingVARequests(ctx, deploy)
+
 	// Use indexed lookup for VA targeting this Deployment
 	va, err := indexers.FindVAForDeployment(ctx, r.Client, deploy.Name, deploy.Namespace)
 	if err != nil {
 		logger.Error(err, "Failed to find VA for deployment event using index")
-		return nil
+		return requests
 	}
 
 	if va == nil {
-		return nil
+		return requests
 	}
 
 	logger.V(logging.DEBUG).Info("Deployment created, triggering VA reconciliation",
@@ -279,12 +737,108 @@ func (r *VariantAutoscalingReconciler) handleDeploymentEvent(ctx context.Context
 		"va", va.Name,
 		"namespace", deploy.Namespace)
 
-	return []reconcile.Request{{
+	request := reconcile.Request{
 		NamespacedName: client.ObjectKey{
 			Namespace: deploy.Namespace,
 			Name:      va.Name,
 		},
-	}}
+	}
+	for _, existing := range requests {
+		if existing == request {
+			return requests
+		}
+	}
+	return append(requests, request)
+}
+
+// handleStatefulSetEvent maps StatefulSet events to the reconcile request of the VA that
+// targets the StatefulSet, as handleDeploymentEvent does for Deployments.
+func (r *VariantAutoscalingReconciler) handleStatefulSetEvent(ctx context.Context, obj client.Object) []reconcile.Request {
+	sts, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		return nil
+	}
+
+	// VAs selecting their scale target by labels are re-resolved on any matching StatefulSet event
+	requests := r.selectingVARequests(ctx, sts)
+
+	va, err := indexers.FindVAForScaleTarget(ctx, r.Client, autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       utils.StatefulSetKind,
+		Name:       sts.Name,
+	}, sts.Namespace)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to find VA for statefulset event using index")
+		return requests
+	}
+	if va == nil {
+		return requests
+	}
+
+	request := reconcile.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: sts.Namespace,
+			Name:      va.Name,
+		},
+	}
+	for _, existing := range requests {
+		if existing == request {
+			return requests
+		}
+	}
+	return append(requests, request)
+}
+
+// selectingVARequests returns the reconcile requests of the VAs in the namespace of a
+// workload whose scaleTargetSelector matches its labels. Only the VAs with a selector are
+// listed, through their index, so namespaces without any cost a single index lookup.
+func (r *VariantAutoscalingReconciler) selectingVARequests(ctx context.Context, workload client.Object) []reconcile.Request {
+	vas, err := indexers.FindVAsWithScaleTargetSelector(ctx, r.Client, workload.GetNamespace())
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to find VAs with a scale target selector for workload event using index")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range vas {
+		va := &vas[i]
+		selector, err := metav1.LabelSelectorAsSelector(va.Spec.ScaleTargetSelector)
+		if err != nil || !selector.Matches(labels.Set(workload.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{Namespace: va.Namespace, Name: va.Name},
+		})
+	}
+	return requests
+}
+
+// decisionPriority returns the reconcile queue priority of an Engine decision for a VA:
+// saturated VAs first, then VAs whose saturation is unknown, then idle VAs.
+func (r *VariantAutoscalingReconciler) decisionPriority(name, namespace string) int {
+	saturation, ok := common.SaturationCache.Get(name, namespace)
+	if !ok || r.Config == nil {
+		return 0
+	}
+	if saturation >= r.Config.ReconcilePrioritySaturation() {
+		return saturatedPriority
+	}
+	return handler.LowPriority
+}
+
+// enqueueDecision enqueues the VA of an Engine decision at its decision priority, so that
+// scale-up-critical decisions are not starved behind a long tail of quiescent VAs.
+func (r *VariantAutoscalingReconciler) enqueueDecision(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if e.Object == nil {
+		return
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.Object)}
+	pq, ok := q.(priorityqueue.PriorityQueue[reconcile.Request])
+	if !ok {
+		q.Add(req)
+		return
+	}
+	pq.AddWithOpts(priorityqueue.AddOpts{Priority: ptr.To(r.decisionPriority(req.Name, req.Namespace))}, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -308,11 +862,20 @@ func (r *VariantAutoscalingReconciler) SetupWithManager(mgr ctrl.Manager) error
 			handler.EnqueueRequestsFromMapFunc(r.handleDeploymentEvent),
 			builder.WithPredicates(DeploymentPredicate()),
 		).
+		// Watch StatefulSets for the same reason, for VAs targeting StatefulSets
+		Watches(
+			&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(r.handleStatefulSetEvent),
+			builder.WithPredicates(DeploymentPredicate()),
+		).
 		// Watch DecisionTrigger channel for Engine decisions
 		// This enables the Engine to trigger reconciliation without updating the object in API server
+		// Decisions are enqueued by the last known saturation of their VA
 		WatchesRawSource(
-			source.Channel(common.DecisionTrigger, &handler.EnqueueRequestForObject{}),
+			source.Channel(common.DecisionTrigger, handler.Funcs{GenericFunc: r.enqueueDecision}),
 		).
+		// A priority queue lets saturated VAs be reconciled ahead of idle ones
+		WithOptions(controller.Options{UsePriorityQueue: ptr.To(true)}).
 		Named("variantAutoscaling").
 		WithEventFilter(EventFilter()).
 		Complete(r)
@@ -29,6 +29,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +42,7 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	testutils "github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils/resources"
 )
@@ -363,6 +367,52 @@ var _ = Describe("VariantAutoscalings Controller", func() {
 			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 			Expect(k8sClient.Delete(ctx, deployment)).To(Succeed())
 		})
+
+		It("should default the accelerator from the node selector of the deployment", func() {
+			const name = "test-accelerator-defaulting"
+
+			By("Creating a deployment scheduled on H100 nodes")
+			deployment := resources.CreateLlmdSimDeployment("default", name, "default-default", "default", "8000", 0, 0, 1)
+			deployment.Spec.Template.Spec.NodeSelector = map[string]string{"nvidia.com/gpu.product": "NVIDIA-H100-80GB-HBM3"}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+
+			By("Creating VariantAutoscaling without accelerator label")
+			resource := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+					ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+						Kind: "Deployment",
+						Name: name,
+					},
+					ModelID: "default-default",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+			controllerReconciler := &VariantAutoscalingReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				Datastore: datastore.NewDatastore(config.NewTestConfig()),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			fetchedResource := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, fetchedResource)).To(Succeed())
+			Expect(fetchedResource.Labels).To(HaveKeyWithValue(utils.AcceleratorNameLabel, "NVIDIA-H100-80GB-HBM3"))
+			condition := llmdVariantAutoscalingV1alpha1.GetCondition(fetchedResource, llmdVariantAutoscalingV1alpha1.TypeTargetResolved)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, deployment)).To(Succeed())
+		})
 	})
 
 	Context("When handling partial decisions from cache", func() {
@@ -447,7 +497,7 @@ var _ = Describe("VariantAutoscalings Controller", func() {
 				VariantName:      resourceName,
 				Namespace:        "default",
 				MetricsAvailable: false,
-				MetricsReason:    "MetricsUnavailable",
+				MetricsReason:    llmdVariantAutoscalingV1alpha1.ReasonMetricsMissing,
 				MetricsMessage:   "Metrics are not yet available for this variant",
 				// AcceleratorName and TargetReplicas are left at zero values (empty string and 0)
 			}
@@ -476,7 +526,7 @@ var _ = Describe("VariantAutoscalings Controller", func() {
 				condition := llmdVariantAutoscalingV1alpha1.GetCondition(resource, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable)
 				g.Expect(condition).NotTo(BeNil(), "MetricsAvailable condition should be set")
 				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse), "MetricsAvailable should be False")
-				g.Expect(condition.Reason).To(Equal("MetricsUnavailable"), "Reason should match partial decision")
+				g.Expect(condition.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonMetricsMissing), "Reason should match partial decision")
 			}, 5*time.Second, 500*time.Millisecond).Should(Succeed())
 
 			By("Verifying DesiredOptimizedAlloc is not modified (remains empty)")
@@ -490,6 +540,89 @@ var _ = Describe("VariantAutoscalings Controller", func() {
 		})
 	})
 
+	Context("When enqueueing Engine decisions", func() {
+		It("should reconcile saturated VAs first and idle VAs last", func() {
+			common.SaturationCache.Set("priority-saturated", "default", 0.95)
+			common.SaturationCache.Set("priority-idle", "default", 0.1)
+
+			r := &VariantAutoscalingReconciler{Config: config.NewTestConfig()}
+			queue := priorityqueue.New[reconcile.Request]("decision-priority-test")
+			defer queue.ShutDown()
+
+			for _, name := range []string{"priority-idle", "priority-unknown", "priority-saturated"} {
+				va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				}
+				r.enqueueDecision(ctx, event.GenericEvent{Object: va}, queue)
+			}
+
+			for _, expected := range []struct {
+				name     string
+				priority int
+			}{
+				{"priority-saturated", saturatedPriority},
+				{"priority-unknown", 0},
+				{"priority-idle", handler.LowPriority},
+			} {
+				req, priority, shutdown := queue.GetWithPriority()
+				Expect(shutdown).To(BeFalse())
+				Expect(req.Name).To(Equal(expected.name))
+				Expect(priority).To(Equal(expected.priority))
+				queue.Done(req)
+			}
+		})
+	})
+
+	Context("When the GPU limiter reduces a recommendation", func() {
+		It("should report why the cluster could not provide the GPUs", func() {
+			Expect(capacityConstrainedReason(interfaces.LimitReasonInsufficientCapacity)).
+				To(Equal(llmdVariantAutoscalingV1alpha1.ReasonInventoryShortage))
+			Expect(capacityConstrainedReason(interfaces.LimitReasonPriorityPreemption)).
+				To(Equal(llmdVariantAutoscalingV1alpha1.ReasonInventoryShortage))
+			Expect(capacityConstrainedReason("")).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonInventoryShortage))
+			Expect(capacityConstrainedReason(interfaces.LimitReasonTenantQuota)).
+				To(Equal(llmdVariantAutoscalingV1alpha1.ReasonQuota))
+			Expect(capacityConstrainedReason(interfaces.LimitReasonPoolReservation)).
+				To(Equal(llmdVariantAutoscalingV1alpha1.ReasonReservation))
+		})
+	})
+
+	Context("When the spec changes", func() {
+		It("should report the spec out of date until the optimizer processes it", func() {
+			va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Name: "spec-drift", Namespace: "default", Generation: 2},
+			}
+			va.Status.ObservedGeneration = 1
+
+			setSpecOutOfDateCondition(ctx, va)
+			condition := llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeSpecOutOfDate)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonSpecPending))
+
+			va.Status.ObservedGeneration = 2
+			setSpecOutOfDateCondition(ctx, va)
+			condition = llmdVariantAutoscalingV1alpha1.GetCondition(va, llmdVariantAutoscalingV1alpha1.TypeSpecOutOfDate)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonSpecProcessed))
+			Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+		})
+
+		It("should reconcile spec updates but not status updates", func() {
+			oldVA := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Name: "spec-drift", Namespace: "default", Generation: 1},
+			}
+			statusUpdate := oldVA.DeepCopy()
+			statusUpdate.Status.ObservedGeneration = 1
+			specUpdate := oldVA.DeepCopy()
+			specUpdate.Generation = 2
+
+			filter := EventFilter()
+			Expect(filter.Update(event.UpdateEvent{ObjectOld: oldVA, ObjectNew: statusUpdate})).To(BeFalse())
+			Expect(filter.Update(event.UpdateEvent{ObjectOld: oldVA, ObjectNew: specUpdate})).To(BeTrue())
+		})
+	})
+
 	// ConfigMap-related tests have been moved to configmap_handler_test.go
 
 })
@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	poolutils "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
+)
+
+// +kubebuilder:rbac:groups=inference.networking.k8s.io;inference.networking.x-k8s.io,resources=inferencepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=inference.networking.k8s.io;inference.networking.x-k8s.io,resources=inferencepools/finalizers,verbs=update
+
+// VariantAutoscalingGeneratorReconciler generates the VariantAutoscalings of InferencePools
+// annotated with a VariantAutoscaling template (constants.VATemplateAnnotationKey).
+// A VariantAutoscaling is generated for every variant of the pool, i.e. every Deployment whose
+// pod template matches the selector of the pool, and named after it. Generated VariantAutoscalings
+// are controlled by the pool, so that the garbage collector deletes them with it; they are kept
+// in sync with the template and the Deployment, and deleted when the Deployment no longer matches.
+type VariantAutoscalingGeneratorReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	PoolGKNN common.GKNN
+}
+
+func (r *VariantAutoscalingGeneratorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	pool, err := r.newPool()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		// A deleted pool's VariantAutoscalings are deleted by the garbage collector
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !pool.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	generated, err := r.generatedVariantAutoscalings(ctx, pool)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Without a template, the VariantAutoscalings generated earlier are deleted
+	desired := make(map[string]bool)
+	if template, ok := pool.GetAnnotations()[constants.VATemplateAnnotationKey]; ok {
+		spec, err := parseVATemplate(template)
+		if err != nil {
+			// Keep the generated VariantAutoscalings until the template is fixed
+			logger.Error(err, "Invalid VariantAutoscaling template", "pool", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+
+		deployments, err := r.variantDeployments(ctx, pool)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range deployments {
+			if err := r.syncVariantAutoscaling(ctx, pool, &deployments[i], spec); err != nil {
+				return ctrl.Result{}, err
+			}
+			desired[deployments[i].Name] = true
+		}
+	}
+
+	for i := range generated {
+		va := &generated[i]
+		if desired[va.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, va); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete generated VariantAutoscaling %s/%s: %w", va.Namespace, va.Name, err)
+		}
+		logger.Info("Deleted generated VariantAutoscaling", "pool", req.NamespacedName, "variant", va.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// syncVariantAutoscaling creates or updates the VariantAutoscaling generated for a Deployment of a pool.
+// A VariantAutoscaling of the same name that the pool does not control is left alone.
+func (r *VariantAutoscalingGeneratorReconciler) syncVariantAutoscaling(ctx context.Context, pool client.Object,
+	deploy *appsv1.Deployment, template llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(deploy), va)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get VariantAutoscaling %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+	if err == nil && !metav1.IsControlledBy(va, pool) {
+		logger.V(logging.DEBUG).Info("Not generating VariantAutoscaling: one that was not generated exists",
+			"pool", client.ObjectKeyFromObject(pool), "variant", va.Name)
+		return nil
+	}
+
+	va.Name = deploy.Name
+	va.Namespace = deploy.Namespace
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, va, func() error {
+		applyVATemplate(va, pool, deploy, template)
+		return controllerutil.SetControllerReference(pool, va, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate VariantAutoscaling %s/%s: %w", deploy.Namespace, deploy.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("Generated VariantAutoscaling", "pool", client.ObjectKeyFromObject(pool), "variant", va.Name, "operation", op)
+	}
+	return nil
+}
+
+// applyVATemplate sets the spec and labels of the VariantAutoscaling generated for a Deployment
+// of a pool: the template targeting the Deployment, with the variant cost of the Deployment's
+// annotation and the accelerator of its labels. The variant cost and minReplicas already set
+// are kept if neither the template nor the Deployment sets them, so that the defaults of the
+// API server and the writes of the scale subresource are not reverted.
+func applyVATemplate(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, pool client.Object,
+	deploy *appsv1.Deployment, template llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec) {
+	spec := *template.DeepCopy()
+	spec.ScaleTargetRef = autoscalingTargetRef(deploy)
+	if cost, ok := deploy.Annotations[constants.VariantCostAnnotationKey]; ok {
+		spec.VariantCost = cost
+	}
+	if spec.VariantCost == "" {
+		spec.VariantCost = va.Spec.VariantCost
+	}
+	if spec.MinReplicas == nil {
+		spec.MinReplicas = va.Spec.MinReplicas
+	}
+	va.Spec = spec
+
+	if va.Labels == nil {
+		va.Labels = make(map[string]string)
+	}
+	va.Labels[constants.GeneratedByLabelKey] = pool.GetName()
+	if accelerator := deploymentAccelerator(deploy); accelerator != "" {
+		va.Labels[utils.AcceleratorNameLabel] = accelerator
+	} else {
+		delete(va.Labels, utils.AcceleratorNameLabel)
+	}
+	if controllerInstance := metrics.GetControllerInstance(); controllerInstance != "" {
+		va.Labels[constants.ControllerInstanceLabelKey] = controllerInstance
+	}
+}
+
+// parseVATemplate parses a VariantAutoscaling template. It must set the model and must not
+// set the scale target, which is the Deployment of each variant.
+func parseVATemplate(template string) (llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec, error) {
+	var spec llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec
+	if err := json.Unmarshal([]byte(template), &spec); err != nil {
+		return spec, fmt.Errorf("failed to parse %s: %w", constants.VATemplateAnnotationKey, err)
+	}
+	if spec.ModelID == "" {
+		return spec, fmt.Errorf("%s must set modelID", constants.VATemplateAnnotationKey)
+	}
+	if spec.ScaleTargetRef.Name != "" || spec.ScaleTargetSelector != nil {
+		return spec, fmt.Errorf("%s must not set the scale target", constants.VATemplateAnnotationKey)
+	}
+	return spec, nil
+}
+
+// autoscalingTargetRef returns the reference of a Deployment as scale target.
+func autoscalingTargetRef(deploy *appsv1.Deployment) autoscalingv1.CrossVersionObjectReference {
+	return autoscalingv1.CrossVersionObjectReference{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       "Deployment",
+		Name:       deploy.Name,
+	}
+}
+
+// deploymentAccelerator returns the accelerator of a Deployment, from its pod template or, if
+// it cannot be told from there, from the accelerator label of the Deployment.
+func deploymentAccelerator(deploy *appsv1.Deployment) string {
+	if accelerator := utils.PodTemplateAccelerator(&deploy.Spec.Template); accelerator != "" {
+		return accelerator
+	}
+	return deploy.Labels[utils.AcceleratorNameLabel]
+}
+
+// variantDeployments returns the Deployments whose pod template matches the selector of a pool.
+func (r *VariantAutoscalingGeneratorReconciler) variantDeployments(ctx context.Context, pool client.Object) ([]appsv1.Deployment, error) {
+	selector := poolSelector(pool)
+	if len(selector) == 0 {
+		return nil, nil
+	}
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(pool.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list Deployments in %s: %w", pool.GetNamespace(), err)
+	}
+	var variants []appsv1.Deployment
+	for _, deploy := range deployments.Items {
+		if deploy.DeletionTimestamp.IsZero() && poolutils.IsSubset(selector, deploy.Spec.Template.Labels) {
+			variants = append(variants, deploy)
+		}
+	}
+	return variants, nil
+}
+
+// generatedVariantAutoscalings returns the VariantAutoscalings generated for a pool.
+func (r *VariantAutoscalingGeneratorReconciler) generatedVariantAutoscalings(ctx context.Context,
+	pool client.Object) ([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, error) {
+	var vas llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := r.List(ctx, &vas, client.InNamespace(pool.GetNamespace()),
+		client.MatchingLabels{constants.GeneratedByLabelKey: pool.GetName()}); err != nil {
+		return nil, fmt.Errorf("failed to list VariantAutoscalings generated for %s/%s: %w", pool.GetNamespace(), pool.GetName(), err)
+	}
+	var generated []llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	for _, va := range vas.Items {
+		if metav1.IsControlledBy(&va, pool) {
+			generated = append(generated, va)
+		}
+	}
+	return generated, nil
+}
+
+// poolsForDeployment maps a Deployment to the annotated pools whose selector matches it.
+func (r *VariantAutoscalingGeneratorReconciler) poolsForDeployment(ctx context.Context, obj client.Object) []reconcile.Request {
+	deploy, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	pools, err := r.listPools(ctx, deploy.Namespace)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list InferencePools", "namespace", deploy.Namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, pool := range pools {
+		if _, ok := pool.GetAnnotations()[constants.VATemplateAnnotationKey]; !ok {
+			continue
+		}
+		selector := poolSelector(pool)
+		if len(selector) > 0 && poolutils.IsSubset(selector, deploy.Spec.Template.Labels) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pool)})
+		}
+	}
+	return requests
+}
+
+// newPool returns an empty InferencePool of the API group of the reconciler.
+func (r *VariantAutoscalingGeneratorReconciler) newPool() (client.Object, error) {
+	switch r.PoolGKNN.Group {
+	case v1.GroupName:
+		return &v1.InferencePool{}, nil
+	case v1alpha2.GroupName:
+		return &v1alpha2.InferencePool{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported InferencePool API group: %s", r.PoolGKNN.Group)
+	}
+}
+
+// listPools lists the InferencePools of the API group of the reconciler in a namespace.
+func (r *VariantAutoscalingGeneratorReconciler) listPools(ctx context.Context, namespace string) ([]client.Object, error) {
+	var pools []client.Object
+	switch r.PoolGKNN.Group {
+	case v1.GroupName:
+		var list v1.InferencePoolList
+		if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			pools = append(pools, &list.Items[i])
+		}
+	case v1alpha2.GroupName:
+		var list v1alpha2.InferencePoolList
+		if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			pools = append(pools, &list.Items[i])
+		}
+	default:
+		return nil, fmt.Errorf("unsupported InferencePool API group: %s", r.PoolGKNN.Group)
+	}
+	return pools, nil
+}
+
+// poolSelector returns the labels selecting the model server pods of an InferencePool.
+func poolSelector(pool client.Object) map[string]string {
+	selector := make(map[string]string)
+	switch p := pool.(type) {
+	case *v1.InferencePool:
+		for k, v := range p.Spec.Selector.MatchLabels {
+			selector[string(k)] = string(v)
+		}
+	case *v1alpha2.InferencePool:
+		for k, v := range p.Spec.Selector {
+			selector[string(k)] = string(v)
+		}
+	}
+	return selector
+}
+
+func (r *VariantAutoscalingGeneratorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	pool, err := r.newPool()
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("variantautoscaling-generator").
+		For(pool).
+		Owns(&llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}).
+		Watches(
+			&appsv1.Deployment{},
+			handler.EnqueueRequestsFromMapFunc(r.poolsForDeployment),
+			// Variant cost and accelerator are read from the annotations and labels of the Deployment
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{})),
+		).
+		Complete(r)
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+func makeVariantDeployment(name string, podLabels, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "pool-ns", Annotations: annotations},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+		},
+	}
+}
+
+func TestVariantAutoscalingGeneratorReconcile(t *testing.T) {
+	pool := utiltest.MakeInferencePool("llama").
+		Namespace("pool-ns").
+		Selector(map[string]string{"llm-d.ai/model": "llama"}).
+		TargetPorts(8080).
+		EndpointPickerRef("epp-svc").ObjRef()
+	pool.Annotations = map[string]string{
+		constants.VATemplateAnnotationKey: `{"modelID": "meta/llama-3.1-8b", "variantCost": "10.0"}`,
+	}
+
+	h100 := makeVariantDeployment("llama-h100",
+		map[string]string{"llm-d.ai/model": "llama", utils.AcceleratorNameLabel: "H100"},
+		map[string]string{constants.VariantCostAnnotationKey: "40.0"})
+	a100 := makeVariantDeployment("llama-a100", map[string]string{"llm-d.ai/model": "llama"}, nil)
+	other := makeVariantDeployment("granite", map[string]string{"llm-d.ai/model": "granite"}, nil)
+	manual := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-a100", Namespace: "pool-ns"},
+		Spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{ModelID: "manual"},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.Install(scheme)
+	_ = llmdVariantAutoscalingV1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, h100, a100, other, manual).
+		Build()
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}}
+	generator := &VariantAutoscalingGeneratorReconciler{
+		Client: fakeClient,
+		Scheme: scheme,
+		PoolGKNN: common.GKNN{
+			NamespacedName: req.NamespacedName,
+			GroupKind:      schema.GroupKind{Group: v1.GroupName, Kind: "InferencePool"},
+		},
+	}
+
+	_, err := generator.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	var va llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "llama-h100", Namespace: "pool-ns"}, &va))
+	assert.Equal(t, "meta/llama-3.1-8b", va.Spec.ModelID)
+	assert.Equal(t, "40.0", va.Spec.VariantCost, "the Deployment's annotation should override the template's cost")
+	assert.Equal(t, "llama-h100", va.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, "H100", va.Labels[utils.AcceleratorNameLabel])
+	assert.Equal(t, "llama", va.Labels[constants.GeneratedByLabelKey])
+	assert.True(t, metav1.IsControlledBy(&va, pool), "the generated VariantAutoscaling should be controlled by the pool")
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "llama-a100", Namespace: "pool-ns"}, &va))
+	assert.Equal(t, "manual", va.Spec.ModelID, "a VariantAutoscaling that was not generated should be left alone")
+
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: "granite", Namespace: "pool-ns"}, &va)
+	assert.True(t, apierrors.IsNotFound(err), "no VariantAutoscaling should be generated for a Deployment outside the pool")
+
+	// Removing the template deletes the generated VariantAutoscalings
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, pool))
+	pool.Annotations = nil
+	require.NoError(t, fakeClient.Update(ctx, pool))
+	_, err = generator.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: "llama-h100", Namespace: "pool-ns"}, &va)
+	assert.True(t, apierrors.IsNotFound(err), "the generated VariantAutoscaling should be deleted")
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "llama-a100", Namespace: "pool-ns"}, &va))
+}
+
+func TestParseVATemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"valid", `{"modelID": "meta/llama-3.1-8b", "engine": "saturation"}`, false},
+		{"not JSON", `modelID: meta/llama-3.1-8b`, true},
+		{"no model", `{"variantCost": "10.0"}`, true},
+		{"scale target", `{"modelID": "meta/llama-3.1-8b", "scaleTargetRef": {"kind": "Deployment", "name": "llama"}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseVATemplate(tt.template)
+			assert.Equal(t, tt.wantErr, err != nil, "parseVATemplate() error = %v", err)
+		})
+	}
+}
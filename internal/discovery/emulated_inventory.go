@@ -0,0 +1,114 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+// EmulatedInventory is an InventoryProvider backed by EmulatedNode resources, for
+// emulated clusters whose nodes have no GPUs, such as the Kind emulator. Tests script
+// heterogeneous pools, GPU usage and node loss by creating and editing EmulatedNodes.
+type EmulatedInventory struct {
+	client client.Reader
+}
+
+// NewEmulatedInventory creates an EmulatedInventory reading EmulatedNodes with c.
+func NewEmulatedInventory(c client.Reader) *EmulatedInventory {
+	return &EmulatedInventory{client: c}
+}
+
+// Discover returns the accelerators of the EmulatedNodes that are not lost, keyed by
+// node name. Accelerators without shares are not shared.
+func (e *EmulatedInventory) Discover(ctx context.Context) (map[string]map[string]AcceleratorModelInfo, error) {
+	nodes, err := e.listNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := make(map[string]map[string]AcceleratorModelInfo, len(nodes))
+	for _, node := range nodes {
+		accelerators := make(map[string]AcceleratorModelInfo, len(node.Spec.Accelerators))
+		for _, acc := range node.Spec.Accelerators {
+			shares := acc.Shares
+			if shares < 1 {
+				shares = 1
+			}
+			accelerators[acc.Model] = AcceleratorModelInfo{
+				Count:  acc.Count,
+				Memory: acc.Memory,
+				Shares: shares,
+				Zone:   node.Spec.Zone,
+			}
+		}
+		inv[node.Name] = accelerators
+	}
+	return inv, nil
+}
+
+// DiscoverUsage returns the used GPUs of the EmulatedNodes that are not lost, per
+// accelerator type.
+func (e *EmulatedInventory) DiscoverUsage(ctx context.Context) (map[string]int, error) {
+	usageByZone, err := e.DiscoverUsageByZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usageByType := make(map[string]int, len(usageByZone))
+	for gpuType, zones := range usageByZone {
+		for _, count := range zones {
+			usageByType[gpuType] += count
+		}
+	}
+	return usageByType, nil
+}
+
+// DiscoverUsageByZone returns the used GPUs of the EmulatedNodes that are not lost, per
+// accelerator type and zone. The used GPUs of a node are capped at its GPUs.
+func (e *EmulatedInventory) DiscoverUsageByZone(ctx context.Context) (map[string]map[string]int, error) {
+	nodes, err := e.listNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usageByZone := make(map[string]map[string]int)
+	for _, node := range nodes {
+		for _, acc := range node.Spec.Accelerators {
+			used := min(acc.Used, acc.Count)
+			if used <= 0 {
+				continue
+			}
+			if usageByZone[acc.Model] == nil {
+				usageByZone[acc.Model] = make(map[string]int)
+			}
+			usageByZone[acc.Model][node.Spec.Zone] += used
+		}
+	}
+	return usageByZone, nil
+}
+
+// listNodes returns the EmulatedNodes that are not lost.
+func (e *EmulatedInventory) listNodes(ctx context.Context) ([]llmdv1alpha1.EmulatedNode, error) {
+	var list llmdv1alpha1.EmulatedNodeList
+	if err := e.client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list emulated nodes: %w", err)
+	}
+
+	nodes := make([]llmdv1alpha1.EmulatedNode, 0, len(list.Items))
+	for _, node := range list.Items {
+		if node.Spec.Lost {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// Ensure EmulatedInventory implements InventoryProvider and ZoneUsageDiscovery
+var (
+	_ InventoryProvider  = (*EmulatedInventory)(nil)
+	_ ZoneUsageDiscovery = (*EmulatedInventory)(nil)
+)
@@ -0,0 +1,71 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+func TestEmulatedInventory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, llmdv1alpha1.AddToScheme(scheme))
+	node := func(name, zone string, lost bool, accelerators ...llmdv1alpha1.EmulatedAccelerator) *llmdv1alpha1.EmulatedNode {
+		return &llmdv1alpha1.EmulatedNode{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: llmdv1alpha1.EmulatedNodeSpec{
+				Accelerators: accelerators,
+				Zone:         zone,
+				Lost:         lost,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		node("node-1", "zone-a", false,
+			llmdv1alpha1.EmulatedAccelerator{Model: "H100", Count: 8, Memory: "81920", Used: 3}),
+		node("node-2", "zone-b", false,
+			llmdv1alpha1.EmulatedAccelerator{Model: "H100", Count: 4, Used: 10},
+			llmdv1alpha1.EmulatedAccelerator{Model: "L4", Count: 2, Shares: 4, Used: 1}),
+		node("node-3", "zone-a", true,
+			llmdv1alpha1.EmulatedAccelerator{Model: "H100", Count: 8, Used: 8}),
+	).Build()
+	inventory := NewEmulatedInventory(c)
+
+	inv, err := inventory.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]AcceleratorModelInfo{
+		"node-1": {"H100": {Count: 8, Memory: "81920", Shares: 1, Zone: "zone-a"}},
+		"node-2": {
+			"H100": {Count: 4, Shares: 1, Zone: "zone-b"},
+			"L4":   {Count: 2, Shares: 4, Zone: "zone-b"},
+		},
+	}, inv)
+
+	used, err := inventory.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"H100": 7, "L4": 1}, used)
+
+	usedByZone, err := inventory.DiscoverUsageByZone(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]int{
+		"H100": {"zone-a": 3, "zone-b": 4},
+		"L4":   {"zone-b": 1},
+	}, usedByZone)
+}
+
+func TestEmulatedInventory_ListError(t *testing.T) {
+	// Without the EmulatedNode kind in the scheme, listing fails
+	c := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	inventory := NewEmulatedInventory(c)
+
+	_, err := inventory.Discover(context.Background())
+	assert.Error(t, err)
+	_, err = inventory.DiscoverUsage(context.Background())
+	assert.Error(t, err)
+}
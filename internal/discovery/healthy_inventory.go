@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FailedGPUsFunc returns the number of failing GPUs of each node.
+type FailedGPUsFunc func(ctx context.Context) (map[string]int, error)
+
+// HealthyInventory is an InventoryProvider leaving the failing GPUs out of the inventory
+// of another: all GPUs of nodes with one of the given conditions true, and the GPUs
+// reported by failedGPUs on the other nodes. Usage is the one of the other provider.
+type HealthyInventory struct {
+	InventoryProvider
+	client         client.Client
+	nodeConditions []string
+	failedGPUs     FailedGPUsFunc
+}
+
+// NewHealthyInventory creates a HealthyInventory over provider. nodeConditions may be
+// empty and failedGPUs nil.
+func NewHealthyInventory(provider InventoryProvider, c client.Client, nodeConditions []string, failedGPUs FailedGPUsFunc) *HealthyInventory {
+	return &HealthyInventory{
+		InventoryProvider: provider,
+		client:            c,
+		nodeConditions:    nodeConditions,
+		failedGPUs:        failedGPUs,
+	}
+}
+
+// Discover returns the inventory of the provider without the failing GPUs. Failures to
+// read the failing GPUs or the nodes are logged and leave the GPUs in the inventory.
+func (h *HealthyInventory) Discover(ctx context.Context) (map[string]map[string]AcceleratorModelInfo, error) {
+	inv, err := h.InventoryProvider.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	var failed map[string]int
+	if h.failedGPUs != nil {
+		if failed, err = h.failedGPUs(ctx); err != nil {
+			logger.Error(err, "Failed to read failing GPUs, keeping them in the inventory")
+		}
+	}
+
+	for nodeName, accelerators := range inv {
+		excluded := failed[nodeName]
+		if len(h.nodeConditions) > 0 {
+			var node corev1.Node
+			if err := h.client.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+				logger.Error(err, "Failed to get node to check its GPU conditions", "node", nodeName)
+			} else if HasNodeCondition(&node, h.nodeConditions) {
+				excluded = -1
+			}
+		}
+		if excluded == 0 {
+			continue
+		}
+		for model, info := range accelerators {
+			switch {
+			case excluded < 0 || excluded >= info.Count:
+				excluded -= info.Count
+				info.Count = 0
+			default:
+				info.Count -= excluded
+				excluded = 0
+			}
+			accelerators[model] = info
+		}
+	}
+	return inv, nil
+}
+
+// DiscoverUsageByZone returns the usage per zone of the provider, nil if it does not
+// break its usage down by zone.
+func (h *HealthyInventory) DiscoverUsageByZone(ctx context.Context) (map[string]map[string]int, error) {
+	if zoned, ok := h.InventoryProvider.(ZoneUsageDiscovery); ok {
+		return zoned.DiscoverUsageByZone(ctx)
+	}
+	return nil, nil
+}
+
+// HasNodeCondition returns whether any of the given condition types is true on a node.
+func HasNodeCondition(node *corev1.Node, conditionTypes []string) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		for _, conditionType := range conditionTypes {
+			if string(condition.Type) == conditionType {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHealthyInventory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	node := func(name string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: conditions},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		node("node-1"),
+		node("node-2", corev1.NodeCondition{Type: "GpuUnhealthy", Status: corev1.ConditionTrue}),
+		node("node-3", corev1.NodeCondition{Type: "GpuUnhealthy", Status: corev1.ConditionFalse}),
+	).Build()
+
+	provider := NewStaticInventory(map[string]map[string]AcceleratorModelInfo{
+		"node-1": {"H100": {Count: 8}},
+		"node-2": {"H100": {Count: 8}},
+		"node-3": {"H100": {Count: 8}},
+	}, map[string]int{"H100": 5})
+	failedGPUs := func(ctx context.Context) (map[string]int, error) {
+		return map[string]int{"node-1": 2, "node-3": 10}, nil
+	}
+	healthy := NewHealthyInventory(provider, c, []string{"GpuUnhealthy"}, failedGPUs)
+
+	inv, err := healthy.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 6, inv["node-1"]["H100"].Count)
+	assert.Equal(t, 0, inv["node-2"]["H100"].Count)
+	assert.Equal(t, 0, inv["node-3"]["H100"].Count)
+
+	used, err := healthy.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"H100": 5}, used)
+}
+
+func TestHealthyInventory_FailedGPUsError(t *testing.T) {
+	provider := NewStaticInventory(map[string]map[string]AcceleratorModelInfo{
+		"node-1": {"H100": {Count: 8}},
+	}, nil)
+	failedGPUs := func(ctx context.Context) (map[string]int, error) {
+		return nil, errors.New("prometheus unavailable")
+	}
+	healthy := NewHealthyInventory(provider, nil, nil, failedGPUs)
+
+	inv, err := healthy.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 8, inv["node-1"]["H100"].Count)
+}
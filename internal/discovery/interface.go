@@ -20,8 +20,24 @@ type UsageDiscovery interface {
 	DiscoverUsage(ctx context.Context) (map[string]int, error)
 }
 
+// ZoneUsageDiscovery is implemented by UsageDiscovery implementations that can also
+// break the GPU usage down by zone.
+type ZoneUsageDiscovery interface {
+	// DiscoverUsageByZone returns a map of accelerator type to zone to used GPU count.
+	// GPUs of nodes without a zone label are counted under the empty zone.
+	DiscoverUsageByZone(ctx context.Context) (map[string]map[string]int, error)
+}
+
 // FullDiscovery combines capacity and usage discovery for complete inventory tracking.
 type FullDiscovery interface {
 	CapacityDiscovery
 	UsageDiscovery
 }
+
+// InventoryProvider is the source of accelerator inventory consumed by the GPU limiter
+// and the limited-mode optimizer. Implementations read it from cluster nodes
+// (NewNodeInventoryProvider), from static configuration (StaticInventory), or from
+// test-controlled state (FakeInventory), so consumers can run without a cluster.
+type InventoryProvider interface {
+	FullDiscovery
+}
@@ -0,0 +1,152 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewNodeInventoryProvider returns an InventoryProvider backed by the cluster nodes.
+// With a manager client, nodes and pods are read from the informer cache, so each
+// call reflects the latest state of the node watch without querying the API server.
+func NewNodeInventoryProvider(c client.Client) InventoryProvider {
+	return NewK8sWithGpuOperator(c)
+}
+
+// StaticInventory is an InventoryProvider with a fixed inventory, e.g. taken from
+// configuration for clusters whose accelerators are not labeled by the GPU operator.
+type StaticInventory struct {
+	nodes map[string]map[string]AcceleratorModelInfo
+	usage map[string]int
+}
+
+// NewStaticInventory creates a StaticInventory from per-node accelerators and
+// per-type usage. The maps are copied; usage may be nil.
+func NewStaticInventory(nodes map[string]map[string]AcceleratorModelInfo, usage map[string]int) *StaticInventory {
+	return &StaticInventory{
+		nodes: copyNodeInventory(nodes),
+		usage: copyUsage(usage),
+	}
+}
+
+// Discover returns a copy of the configured per-node inventory.
+func (s *StaticInventory) Discover(ctx context.Context) (map[string]map[string]AcceleratorModelInfo, error) {
+	return copyNodeInventory(s.nodes), nil
+}
+
+// DiscoverUsage returns a copy of the configured usage per accelerator type.
+func (s *StaticInventory) DiscoverUsage(ctx context.Context) (map[string]int, error) {
+	return copyUsage(s.usage), nil
+}
+
+// FakeInventory is an InventoryProvider whose inventory, usage, and errors are set
+// by tests. It is safe for concurrent use.
+type FakeInventory struct {
+	mu       sync.Mutex
+	nodes    map[string]map[string]AcceleratorModelInfo
+	usage    map[string]int
+	discErr  error
+	usageErr error
+	calls    int
+}
+
+// NewFakeInventory creates an empty FakeInventory.
+func NewFakeInventory() *FakeInventory {
+	return &FakeInventory{
+		nodes: make(map[string]map[string]AcceleratorModelInfo),
+		usage: make(map[string]int),
+	}
+}
+
+// SetNode sets the accelerators of a node, replacing any previous ones.
+func (f *FakeInventory) SetNode(node string, accelerators map[string]AcceleratorModelInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nodes[node] = make(map[string]AcceleratorModelInfo, len(accelerators))
+	for model, info := range accelerators {
+		f.nodes[node][model] = info
+	}
+}
+
+// RemoveNode removes a node from the inventory.
+func (f *FakeInventory) RemoveNode(node string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.nodes, node)
+}
+
+// SetUsage sets the used count per accelerator type.
+func (f *FakeInventory) SetUsage(usage map[string]int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.usage = copyUsage(usage)
+}
+
+// SetDiscoverError makes Discover fail with err; nil clears it.
+func (f *FakeInventory) SetDiscoverError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.discErr = err
+}
+
+// SetUsageError makes DiscoverUsage fail with err; nil clears it.
+func (f *FakeInventory) SetUsageError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.usageErr = err
+}
+
+// DiscoverCalls returns the number of Discover calls so far.
+func (f *FakeInventory) DiscoverCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// Discover returns a copy of the current per-node inventory.
+func (f *FakeInventory) Discover(ctx context.Context) (map[string]map[string]AcceleratorModelInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.discErr != nil {
+		return nil, f.discErr
+	}
+	return copyNodeInventory(f.nodes), nil
+}
+
+// DiscoverUsage returns a copy of the current usage per accelerator type.
+func (f *FakeInventory) DiscoverUsage(ctx context.Context) (map[string]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.usageErr != nil {
+		return nil, f.usageErr
+	}
+	return copyUsage(f.usage), nil
+}
+
+func copyNodeInventory(nodes map[string]map[string]AcceleratorModelInfo) map[string]map[string]AcceleratorModelInfo {
+	out := make(map[string]map[string]AcceleratorModelInfo, len(nodes))
+	for node, accelerators := range nodes {
+		out[node] = make(map[string]AcceleratorModelInfo, len(accelerators))
+		for model, info := range accelerators {
+			out[node][model] = info
+		}
+	}
+	return out
+}
+
+func copyUsage(usage map[string]int) map[string]int {
+	out := make(map[string]int, len(usage))
+	for accType, count := range usage {
+		out[accType] = count
+	}
+	return out
+}
+
+// Ensure the providers implement InventoryProvider
+var (
+	_ InventoryProvider = (*K8sWithGpuOperator)(nil)
+	_ InventoryProvider = (*StaticInventory)(nil)
+	_ InventoryProvider = (*FakeInventory)(nil)
+)
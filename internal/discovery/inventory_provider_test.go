@@ -0,0 +1,99 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticInventory(t *testing.T) {
+	nodes := map[string]map[string]AcceleratorModelInfo{
+		"node-1": {"NVIDIA-A100-PCIE-80GB": {Count: 4, Memory: "81920"}},
+		"node-2": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Memory: "81920"}},
+	}
+	usage := map[string]int{"NVIDIA-A100-PCIE-80GB": 2}
+	provider := NewStaticInventory(nodes, usage)
+
+	// mutating the inputs does not change the provider
+	nodes["node-1"]["NVIDIA-A100-PCIE-80GB"] = AcceleratorModelInfo{Count: 1}
+	usage["NVIDIA-A100-PCIE-80GB"] = 4
+
+	inv, err := provider.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, inv, 2)
+	assert.Equal(t, 4, inv["node-1"]["NVIDIA-A100-PCIE-80GB"].Count)
+	assert.Equal(t, 8, inv["node-2"]["NVIDIA-H100-SXM5-80GB"].Count)
+
+	used, err := provider.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"NVIDIA-A100-PCIE-80GB": 2}, used)
+
+	// mutating a result does not change the provider either
+	inv["node-1"]["NVIDIA-A100-PCIE-80GB"] = AcceleratorModelInfo{Count: 0}
+	inv, err = provider.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, inv["node-1"]["NVIDIA-A100-PCIE-80GB"].Count)
+}
+
+func TestStaticInventory_NilUsage(t *testing.T) {
+	provider := NewStaticInventory(nil, nil)
+
+	inv, err := provider.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, inv)
+
+	used, err := provider.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, used)
+}
+
+func TestFakeInventory(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeInventory()
+
+	inv, err := fake.Discover(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, inv)
+
+	fake.SetNode("node-1", map[string]AcceleratorModelInfo{"H100": {Count: 8}})
+	fake.SetNode("node-2", map[string]AcceleratorModelInfo{"H100": {Count: 8}})
+	fake.SetUsage(map[string]int{"H100": 3})
+
+	inv, err = fake.Discover(ctx)
+	require.NoError(t, err)
+	assert.Len(t, inv, 2)
+	used, err := fake.DiscoverUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, used["H100"])
+
+	// a node going away is seen on the next discovery
+	fake.RemoveNode("node-2")
+	inv, err = fake.Discover(ctx)
+	require.NoError(t, err)
+	assert.Len(t, inv, 1)
+	assert.Equal(t, 3, fake.DiscoverCalls())
+}
+
+func TestFakeInventory_Errors(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeInventory()
+	discErr := errors.New("nodes unavailable")
+	usageErr := errors.New("pods unavailable")
+
+	fake.SetDiscoverError(discErr)
+	fake.SetUsageError(usageErr)
+	_, err := fake.Discover(ctx)
+	assert.ErrorIs(t, err, discErr)
+	_, err = fake.DiscoverUsage(ctx)
+	assert.ErrorIs(t, err, usageErr)
+
+	fake.SetDiscoverError(nil)
+	fake.SetUsageError(nil)
+	_, err = fake.Discover(ctx)
+	assert.NoError(t, err)
+	_, err = fake.DiscoverUsage(ctx)
+	assert.NoError(t, err)
+}
@@ -3,7 +3,9 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -79,9 +81,11 @@ func (d *K8sWithGpuOperator) Discover(ctx context.Context) (map[string]map[strin
 			}
 
 			mem := node.Labels[memKey]
+			// Shared GPUs are advertised once per share
+			shares := gpuShares(&node, vendor)
 			count := 0
 			if cap, ok := node.Status.Allocatable[corev1.ResourceName(vendor+"/gpu")]; ok {
-				count = int(cap.Value())
+				count = int(cap.Value()) / shares
 			}
 
 			if inv[nodeName] == nil {
@@ -91,6 +95,8 @@ func (d *K8sWithGpuOperator) Discover(ctx context.Context) (map[string]map[strin
 			inv[nodeName][model] = AcceleratorModelInfo{
 				Count:  count,
 				Memory: mem,
+				Zone:   node.Labels[corev1.LabelTopologyZone],
+				Shares: shares,
 			}
 		}
 	}
@@ -101,8 +107,27 @@ func (d *K8sWithGpuOperator) Discover(ctx context.Context) (map[string]map[strin
 // DiscoverUsage calculates current GPU usage by summing GPU requests from running pods.
 // Returns a map of accelerator type to used GPU count.
 func (d *K8sWithGpuOperator) DiscoverUsage(ctx context.Context) (map[string]int, error) {
-	// First, build a map of node name -> GPU type
-	nodeGPUType, err := d.discoverNodeGPUTypes(ctx)
+	usageByZone, err := d.DiscoverUsageByZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usageByType := make(map[string]int, len(usageByZone))
+	for gpuType, zones := range usageByZone {
+		for _, count := range zones {
+			usageByType[gpuType] += count
+		}
+	}
+	return usageByType, nil
+}
+
+// DiscoverUsageByZone calculates current GPU usage by summing GPU requests from running
+// pods, per accelerator type and zone of their node. On nodes sharing their GPUs, the
+// requests of a node are converted to the physical GPUs they occupy at least: a GPU with
+// any of its shares requested counts as used.
+func (d *K8sWithGpuOperator) DiscoverUsageByZone(ctx context.Context) (map[string]map[string]int, error) {
+	// First, build a map of node name -> GPU type and zone
+	nodeGPUs, err := d.discoverNodeGPUs(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover node GPU types: %w", err)
 	}
@@ -113,8 +138,8 @@ func (d *K8sWithGpuOperator) DiscoverUsage(ctx context.Context) (map[string]int,
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	// Aggregate GPU requests by accelerator type
-	usageByType := make(map[string]int)
+	// Aggregate GPU requests by node first, since GPUs are shared per node
+	requestsByNode := make(map[string]int)
 
 	for _, pod := range podList.Items {
 		// Skip pods that aren't scheduled or are completed/failed
@@ -125,27 +150,55 @@ func (d *K8sWithGpuOperator) DiscoverUsage(ctx context.Context) (map[string]int,
 			continue
 		}
 
-		// Get the GPU type for this node
-		gpuType, ok := nodeGPUType[pod.Spec.NodeName]
-		if !ok {
+		if _, ok := nodeGPUs[pod.Spec.NodeName]; !ok {
 			// Node doesn't have GPUs, skip
 			continue
 		}
 
 		// Sum GPU requests from all containers
-		gpuCount := getPodGPURequests(&pod)
-		if gpuCount > 0 {
-			usageByType[gpuType] += gpuCount
+		requestsByNode[pod.Spec.NodeName] += getPodGPURequests(&pod)
+	}
+
+	// Aggregate GPU usage by accelerator type and zone
+	usageByZone := make(map[string]map[string]int)
+	for nodeName, requests := range requestsByNode {
+		if requests <= 0 {
+			continue
 		}
+		node := nodeGPUs[nodeName]
+		if usageByZone[node.model] == nil {
+			usageByZone[node.model] = make(map[string]int)
+		}
+		usageByZone[node.model][node.zone] += int(math.Ceil(float64(requests) / float64(node.shares)))
 	}
 
-	return usageByType, nil
+	return usageByZone, nil
+}
+
+// nodeGPU is the GPU type (model name), zone and GPU sharing factor of a node.
+type nodeGPU struct {
+	model  string
+	zone   string
+	shares int
 }
 
 // discoverNodeGPUTypes returns a map of node name to GPU type (model name).
-// It queries nodes for each GPU vendor separately to support multi-vendor clusters.
 func (d *K8sWithGpuOperator) discoverNodeGPUTypes(ctx context.Context) (map[string]string, error) {
-	nodeGPUType := make(map[string]string)
+	nodeGPUs, err := d.discoverNodeGPUs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeGPUType := make(map[string]string, len(nodeGPUs))
+	for name, node := range nodeGPUs {
+		nodeGPUType[name] = node.model
+	}
+	return nodeGPUType, nil
+}
+
+// discoverNodeGPUs returns a map of node name to GPU type, zone and sharing factor.
+// It queries nodes for each GPU vendor separately to support multi-vendor clusters.
+func (d *K8sWithGpuOperator) discoverNodeGPUs(ctx context.Context) (map[string]nodeGPU, error) {
+	nodeGPUs := make(map[string]nodeGPU)
 
 	// Parse WVA_NODE_SELECTOR once for reuse across vendor queries
 	var userRequirements []labels.Requirement
@@ -179,12 +232,27 @@ func (d *K8sWithGpuOperator) discoverNodeGPUTypes(ctx context.Context) (map[stri
 
 		for _, node := range nodeList.Items {
 			if model, ok := node.Labels[prodKey]; ok {
-				nodeGPUType[node.Name] = model
+				nodeGPUs[node.Name] = nodeGPU{
+					model:  model,
+					zone:   node.Labels[corev1.LabelTopologyZone],
+					shares: gpuShares(&node, vendor),
+				}
 			}
 		}
 	}
 
-	return nodeGPUType, nil
+	return nodeGPUs, nil
+}
+
+// gpuShares returns the number of containers each GPU of a vendor on a node can be shared
+// by, from the <vendor>/gpu.replicas label GPU Feature Discovery sets when time-slicing or
+// MPS is configured. Returns 1 if the label is missing or invalid.
+func gpuShares(node *corev1.Node, vendor string) int {
+	shares, err := strconv.Atoi(node.Labels[vendor+"/gpu.replicas"])
+	if err != nil || shares < 1 {
+		return 1
+	}
+	return shares
 }
 
 // getPodGPURequests returns the total GPU requests for a pod across all containers.
@@ -227,5 +295,8 @@ func getPodGPURequests(pod *corev1.Pod) int {
 	return regularTotal
 }
 
-// Ensure K8sWithGpuOperator implements FullDiscovery
-var _ FullDiscovery = (*K8sWithGpuOperator)(nil)
+// Ensure K8sWithGpuOperator implements FullDiscovery and ZoneUsageDiscovery
+var (
+	_ FullDiscovery      = (*K8sWithGpuOperator)(nil)
+	_ ZoneUsageDiscovery = (*K8sWithGpuOperator)(nil)
+)
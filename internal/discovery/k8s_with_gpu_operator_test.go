@@ -336,6 +336,110 @@ func TestDiscoverUsage_MixedVendors(t *testing.T) {
 	assert.Equal(t, 4, result["AMD-MI300X-192G"])
 }
 
+func TestDiscoverUsageByZone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	gpuNode := func(name, zone string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"nvidia.com/gpu.product": "NVIDIA-H100-SXM5-80GB",
+					corev1.LabelTopologyZone: zone,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+			},
+		}
+	}
+	gpuPod := func(name, nodeName string, gpus string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "vllm",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse(gpus)},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		gpuNode("node-a1", "zone-a"), gpuNode("node-a2", "zone-a"), gpuNode("node-b", "zone-b"),
+		gpuPod("pod-1", "node-a1", "2"), gpuPod("pod-2", "node-a2", "4"), gpuPod("pod-3", "node-b", "1"),
+	).Build()
+	discoverer := NewK8sWithGpuOperator(client)
+
+	inventory, err := discoverer.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "zone-a", inventory["node-a1"]["NVIDIA-H100-SXM5-80GB"].Zone)
+	assert.Equal(t, "zone-b", inventory["node-b"]["NVIDIA-H100-SXM5-80GB"].Zone)
+
+	usage, err := discoverer.DiscoverUsageByZone(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]int{"NVIDIA-H100-SXM5-80GB": {"zone-a": 6, "zone-b": 1}}, usage)
+
+	total, err := discoverer.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"NVIDIA-H100-SXM5-80GB": 7}, total)
+}
+
+func TestDiscover_SharedGPUs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	// Time-slicing advertises each of the 2 GPUs of the node 4 times
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-shared",
+			Labels: map[string]string{
+				"nvidia.com/gpu.product":  "NVIDIA-H100-SXM5-80GB",
+				"nvidia.com/gpu.replicas": "4",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+		},
+	}
+	// Two replicas of two containers requesting a share each, and one single-share replica
+	pod := func(name string, containers int) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-shared"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")},
+				},
+			})
+		}
+		return p
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		node, pod("pod-1", 2), pod("pod-2", 2), pod("pod-3", 1),
+	).Build()
+	discoverer := NewK8sWithGpuOperator(client)
+
+	inventory, err := discoverer.Discover(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, inventory["node-shared"]["NVIDIA-H100-SXM5-80GB"].Count)
+	assert.Equal(t, 4, inventory["node-shared"]["NVIDIA-H100-SXM5-80GB"].Shares)
+
+	// 5 shares of 4 occupy at least 2 GPUs
+	usage, err := discoverer.DiscoverUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"NVIDIA-H100-SXM5-80GB": 2}, usage)
+}
+
 func TestDiscoverNodeGPUTypes_MixedVendors(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, corev1.AddToScheme(scheme))
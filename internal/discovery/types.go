@@ -2,6 +2,13 @@ package discovery
 
 // AcceleratorModelInfo contains details about a discovered accelerator model on a node.
 type AcceleratorModelInfo struct {
+	// Count is the number of physical GPUs of the model on the node
 	Count  int
 	Memory string
+	// Shares is the number of containers each GPU of the node can be shared by with
+	// time-slicing or MPS, from the <vendor>/gpu.replicas label of GPU Feature Discovery;
+	// 1 if the GPUs are not shared
+	Shares int
+	// Zone is the topology.kubernetes.io/zone label of the node, empty if it has none
+	Zone string
 }
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	ctrl "sigs.k8s.io/controller-runtime"
+
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/sketch"
 )
 
 // SaturationAnalyzer implements the interfaces.Analyzer interface using a
@@ -55,15 +60,43 @@ func (a *SaturationAnalyzer) EvictStaleHistory(timeout time.Duration) int {
 	return evicted
 }
 
+// ForgetModel removes the k2 history of a model, once no VA in any namespace references
+// it. Returns the number of entries removed.
+func (a *SaturationAnalyzer) ForgetModel(modelID string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	prefix := modelID + "|"
+	forgotten := 0
+	for key := range a.computeCapacityHistory {
+		if strings.HasPrefix(key, prefix) {
+			delete(a.computeCapacityHistory, key)
+			forgotten++
+		}
+	}
+	return forgotten
+}
+
 // Analyze computes capacity signals for a model across all its variants.
+// Replicas with unavailable metrics are left out while others have fresher ones.
 func (a *SaturationAnalyzer) Analyze(ctx context.Context, input interfaces.AnalyzerInput) (*interfaces.AnalyzerResult, error) {
+	ctx = logging.IntoModule(ctx, logging.ModuleSaturation)
 	satConfig, ok := input.Config.(*interfaces.SaturationScalingConfig)
 	if !ok {
 		return nil, fmt.Errorf("expected *SaturationScalingConfig, got %T", input.Config)
 	}
 
+	// Replicas whose samples are much older than the others' no longer report their load
+	var discarded int
+	input.ReplicaMetrics, discarded = interfaces.DiscardUnavailableReplicas(input.ReplicaMetrics)
+	if discarded > 0 {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Discarded replicas with unavailable metrics",
+			"modelID", input.ModelID,
+			"namespace", input.Namespace,
+			"discarded", discarded)
+	}
+
 	// Build GPU count lookup from variant states
-	gpusByVariant := make(map[string]int, len(input.VariantStates))
+	gpusByVariant := make(map[string]float64, len(input.VariantStates))
 	for _, vs := range input.VariantStates {
 		gpusByVariant[vs.VariantName] = vs.GPUsPerReplica
 	}
@@ -76,6 +109,18 @@ func (a *SaturationAnalyzer) Analyze(ctx context.Context, input interfaces.Analy
 			return nil, ctx.Err()
 		default:
 		}
+		// Replicas on failing GPUs have no capacity; their demand moves to the others
+		if rm.GPUFailed {
+			replicaCapacities = append(replicaCapacities, ReplicaCapacity{
+				PodName:         rm.PodName,
+				VariantName:     rm.VariantName,
+				AcceleratorName: rm.AcceleratorName,
+				TokensInUse:     rm.TokensInUse,
+				ReplicaDemand:   replicaDemand(rm),
+				Failed:          true,
+			})
+			continue
+		}
 		gpuCount := gpusByVariant[rm.VariantName]
 		rc := a.computeReplicaCapacity(rm, satConfig, input.ModelID, input.Namespace, gpuCount)
 		if rc != nil {
@@ -96,6 +141,17 @@ func (a *SaturationAnalyzer) Analyze(ctx context.Context, input interfaces.Analy
 		totalAnticipatedSupply += anticipatedCapacity
 	}
 
+	// Scale the demand of the admitted requests by the requests the gateway shed
+	if correction := input.GatewayTraffic.DemandCorrection(satConfig.GetMaxLoadSheddingCorrection()); correction > 1 {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Corrected demand for requests shed by the gateway",
+			"modelID", input.ModelID,
+			"namespace", input.Namespace,
+			"acceptedRate", input.GatewayTraffic.AcceptedRate,
+			"rejectedRate", input.GatewayTraffic.RejectedRate,
+			"correction", correction)
+		totalDemand *= correction
+	}
+
 	// Add scheduler queue demand (requests queued upstream in llm-d flow control)
 	totalDemand += estimateSchedulerQueueDemand(input.SchedulerQueue, input.ReplicaMetrics)
 
@@ -143,17 +199,13 @@ func (a *SaturationAnalyzer) computeReplicaCapacity(
 	rm interfaces.ReplicaMetrics,
 	config *interfaces.SaturationScalingConfig,
 	modelID, namespace string,
-	gpuCount int,
+	gpuCount float64,
 ) *ReplicaCapacity {
 	if rm.TotalKvCapacityTokens <= 0 {
 		return nil
 	}
 
-	// Compute demand
-	replicaDemand := rm.TokensInUse
-	if rm.AvgInputTokens > 0 {
-		replicaDemand += int64(rm.QueueLength) * int64(rm.AvgInputTokens)
-	}
+	replicaDemand := replicaDemand(rm)
 
 	// k1: memory-bound capacity
 	k1 := int64(float64(rm.TotalKvCapacityTokens) * config.KvCacheThreshold)
@@ -210,6 +262,16 @@ func (a *SaturationAnalyzer) computeReplicaCapacity(
 	}
 }
 
+// replicaDemand returns the token demand of a replica: the tokens in use plus the
+// projected input tokens of its queued requests.
+func replicaDemand(rm interfaces.ReplicaMetrics) int64 {
+	demand := rm.TokensInUse
+	if inputTokens := projectedInputTokens(rm); inputTokens > 0 {
+		demand += int64(rm.QueueLength) * int64(inputTokens)
+	}
+	return demand
+}
+
 // computeK2 determines the compute-bound capacity using a priority chain:
 // 1. Observed (queue saturated) → use tokensInUse as k2
 // 2. Historical → rolling average from previous observations
@@ -299,18 +361,25 @@ func (a *SaturationAnalyzer) aggregateByVariant(
 		accelerator := variantAccel[vs.VariantName]
 		cost := variantCost[vs.VariantName]
 
-		readyCount := vs.CurrentReplicas - vs.PendingReplicas
+		// Replicas on failing GPUs add demand but no capacity
+		capacities := make([]int64, 0, len(replicas))
+		failedCount := 0
+		for _, rc := range replicas {
+			totalDemand += float64(rc.ReplicaDemand)
+			if rc.Failed {
+				failedCount++
+				continue
+			}
+			capacities = append(capacities, rc.EffectiveCapacity)
+		}
+
+		readyCount := vs.CurrentReplicas - vs.PendingReplicas - failedCount
 		if readyCount < 0 {
 			readyCount = 0
 		}
 
-		if len(replicas) > 0 {
+		if len(capacities) > 0 {
 			// Use median effective capacity from ready pods
-			capacities := make([]int64, 0, len(replicas))
-			for _, rc := range replicas {
-				capacities = append(capacities, rc.EffectiveCapacity)
-				totalDemand += float64(rc.ReplicaDemand)
-			}
 			perReplicaCapacity = float64(median(capacities))
 			if accelerator == "" {
 				accelerator = replicas[0].AcceleratorName
@@ -337,6 +406,7 @@ func (a *SaturationAnalyzer) aggregateByVariant(
 			Cost:               cost,
 			ReplicaCount:       readyCount,
 			PendingReplicas:    vs.PendingReplicas,
+			FailedReplicas:     failedCount,
 			PerReplicaCapacity: perReplicaCapacity,
 			TotalCapacity:      totalCapacity,
 			TotalDemand:        totalDemand,
@@ -353,7 +423,7 @@ func (a *SaturationAnalyzer) aggregateByVariant(
 // capacity estimation for zero-replica variants that have no prior data.
 // The search is cross-namespace since capacity depends on hardware + config,
 // not namespace.
-func (a *SaturationAnalyzer) lookupCompatibleCapacity(namespace, modelID, variantName, accelerator string, gpuCount int) *CapacityRecord {
+func (a *SaturationAnalyzer) lookupCompatibleCapacity(namespace, modelID, variantName, accelerator string, gpuCount float64) *CapacityRecord {
 	// Get VLLMParams for this variant (from deployment-derived record)
 	rec := a.capacityStore.Get(namespace, modelID, variantName)
 	if rec == nil || rec.VLLMParams == nil {
@@ -458,13 +528,37 @@ func computeModelWorkloadAverages(replicaMetrics []interfaces.ReplicaMetrics) (a
 	return avgInput, avgOutput, avgHitRate
 }
 
+// projectedInputTokens returns the prompt length projecting the KV demand of the requests
+// queued on a replica: the InputTokensQuantile of its prompt length sketch, or its
+// average prompt length without sketch.
+func projectedInputTokens(rm interfaces.ReplicaMetrics) float64 {
+	if rm.InputTokensSketch != nil && rm.InputTokensSketch.Count() > 0 {
+		return rm.InputTokensSketch.Quantile(InputTokensQuantile)
+	}
+	return rm.AvgInputTokens
+}
+
+// modelProjectedInputTokens returns the model-level prompt length projecting the KV
+// demand of queued requests: the InputTokensQuantile of the merged prompt length
+// sketches of the replicas, or avgInput when no replica has a sketch.
+func modelProjectedInputTokens(replicaMetrics []interfaces.ReplicaMetrics, avgInput float64) float64 {
+	merged := sketch.New(sketch.DefaultCompression)
+	for _, rm := range replicaMetrics {
+		merged.Merge(rm.InputTokensSketch)
+	}
+	if merged.Count() == 0 {
+		return avgInput
+	}
+	return merged.Quantile(InputTokensQuantile)
+}
+
 // estimateSchedulerQueueDemand estimates the token demand from requests queued
 // in the llm-d inference scheduler's flow control layer.
 //
 // These requests have not yet reached any vLLM pod, so we estimate their
 // token footprint using two independent signals:
 //
-//	inputTokens = max(queueBytes / BytesPerToken, queueSize * avgInputTokens)
+//	inputTokens = max(queueBytes / BytesPerToken, queueSize * p90InputTokens)
 //	             * (1 - prefixCacheHitRate)
 //	outputTokens = queueSize * avgOutputTokens
 //	demand = inputTokens + outputTokens
@@ -473,6 +567,9 @@ func computeModelWorkloadAverages(replicaMetrics []interfaces.ReplicaMetrics) (a
 // a fraction of prompt tokens will hit the prefix cache and reuse existing
 // KV blocks. This does NOT apply to the local vLLM queue (num_requests_waiting)
 // because those requests have not yet had prefix cache lookup performed.
+//
+// p90InputTokens is the p90 of the merged prompt length sketches of the replicas,
+// or the average input tokens when no replica has a sketch.
 func estimateSchedulerQueueDemand(
 	sq *interfaces.SchedulerQueueMetrics,
 	replicaMetrics []interfaces.ReplicaMetrics,
@@ -486,7 +583,7 @@ func estimateSchedulerQueueDemand(
 
 	// Estimate input tokens from two signals, take the max for robustness
 	tokensFromBytes := float64(sq.QueueBytes) / BytesPerToken
-	tokensFromCount := float64(sq.QueueSize) * avgInput
+	tokensFromCount := float64(sq.QueueSize) * modelProjectedInputTokens(replicaMetrics, avgInput)
 	inputTokens := tokensFromBytes
 	if tokensFromCount > inputTokens {
 		inputTokens = tokensFromCount
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/sketch"
 )
 
 var _ = Describe("SaturationAnalyzer", func() {
@@ -105,6 +106,11 @@ var _ = Describe("SaturationAnalyzer", func() {
 			ra, ok := analyzer.computeCapacityHistory[histKey]
 			Expect(ok).To(BeTrue())
 			Expect(ra.Average()).To(Equal(float64(8000)))
+
+			// Forgotten once no VA references the model
+			Expect(analyzer.ForgetModel("other-model")).To(Equal(0))
+			Expect(analyzer.ForgetModel("test-model")).To(Equal(1))
+			Expect(analyzer.computeCapacityHistory).NotTo(HaveKey(histKey))
 		})
 
 		It("should use historical k2 when queue drops below threshold", func() {
@@ -262,6 +268,34 @@ var _ = Describe("SaturationAnalyzer", func() {
 		})
 	})
 
+	Describe("Replicas on failing GPUs", func() {
+		It("should count them without capacity and move their demand to the others", func() {
+			failed := makeReplicaMetrics("pod-2", "variant-a", "H100", 10.0,
+				8000, 16000, 0, 100, 50)
+			failed.GPUFailed = true
+			input := makeAnalyzerInput(
+				[]interfaces.ReplicaMetrics{
+					makeReplicaMetrics("pod-1", "variant-a", "H100", 10.0,
+						8000, 16000, 0, 100, 50),
+					failed,
+				},
+				[]interfaces.VariantReplicaState{
+					{VariantName: "variant-a", CurrentReplicas: 2, GPUsPerReplica: 1},
+				},
+			)
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			vc := result.VariantCapacities[0]
+			Expect(vc.ReplicaCount).To(Equal(1))
+			Expect(vc.FailedReplicas).To(Equal(1))
+			// supply = 1 * 12800, demand = 16000: both replicas' demand on one replica
+			Expect(result.TotalSupply).To(Equal(float64(12800)))
+			Expect(result.TotalDemand).To(Equal(float64(16000)))
+			Expect(result.RequiredCapacity).To(BeNumerically(">", 0))
+		})
+	})
+
 	Describe("Zero-replica variants", func() {
 		It("should use stored live capacity directly when variant has zero replicas", func() {
 			store.Update("test-ns", "test-model", "variant-a", CapacityRecord{
@@ -643,6 +677,119 @@ var _ = Describe("SaturationAnalyzer", func() {
 		})
 	})
 
+	Describe("Load shedding correction", func() {
+		makeInput := func() interfaces.AnalyzerInput {
+			return makeAnalyzerInput(
+				[]interfaces.ReplicaMetrics{
+					makeReplicaMetrics("pod-1", "variant-a", "H100", 10.0,
+						5000, 16000, 0, 100, 50),
+				},
+				[]interfaces.VariantReplicaState{
+					{VariantName: "variant-a", CurrentReplicas: 1, GPUsPerReplica: 1},
+				},
+			)
+		}
+
+		It("should scale demand by the requests the gateway received over those it admitted", func() {
+			input := makeInput()
+			input.GatewayTraffic = &interfaces.GatewayTrafficMetrics{AcceptedRate: 60, RejectedRate: 30}
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			// Demand = 5000 * (60 + 30) / 60 = 7500
+			Expect(result.TotalDemand).To(Equal(float64(7500)))
+		})
+
+		It("should cap the correction", func() {
+			input := makeInput()
+			input.GatewayTraffic = &interfaces.GatewayTrafficMetrics{AcceptedRate: 10, RejectedRate: 90}
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			// Correction of 10 capped at the default 3
+			Expect(result.TotalDemand).To(Equal(float64(15000)))
+			// The admitted demand alone (5000) would not require scaling up
+			Expect(result.RequiredCapacity).To(BeNumerically(">", 0))
+		})
+
+		It("should not correct demand when no request was rejected", func() {
+			input := makeInput()
+			input.GatewayTraffic = &interfaces.GatewayTrafficMetrics{AcceptedRate: 60}
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.TotalDemand).To(Equal(float64(5000)))
+		})
+	})
+
+	Describe("Prompt length distribution", func() {
+		// 80% of prompts have 100 tokens and 20% have 2000: average 480, p90 2000
+		longTailSketch := func() *sketch.TDigest {
+			td := sketch.New(sketch.DefaultCompression)
+			td.Add(100, 80)
+			td.Add(2000, 20)
+			return td
+		}
+
+		It("should project the demand of queued requests at the p90 prompt length", func() {
+			rm := makeReplicaMetrics("pod-1", "variant-a", "H100", 10.0,
+				5000, 16000, 2, 480, 50)
+			rm.InputTokensSketch = longTailSketch()
+			input := makeAnalyzerInput(
+				[]interfaces.ReplicaMetrics{rm},
+				[]interfaces.VariantReplicaState{
+					{VariantName: "variant-a", CurrentReplicas: 1, GPUsPerReplica: 1},
+				},
+			)
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			// Replica demand = 5000 + 2 * 2000 (p90, not 480 average) = 9000
+			Expect(result.TotalDemand).To(Equal(float64(9000)))
+		})
+
+		It("should project scheduler queue demand at the p90 of the merged sketches", func() {
+			withSketch := makeReplicaMetrics("pod-1", "variant-a", "H100", 10.0,
+				5000, 16000, 0, 480, 50)
+			withSketch.InputTokensSketch = longTailSketch()
+			input := makeAnalyzerInput(
+				[]interfaces.ReplicaMetrics{
+					withSketch,
+					// replicas without sketch do not dilute the distribution
+					makeReplicaMetrics("pod-2", "variant-a", "H100", 10.0,
+						5000, 16000, 0, 480, 50),
+				},
+				[]interfaces.VariantReplicaState{
+					{VariantName: "variant-a", CurrentReplicas: 2, GPUsPerReplica: 1},
+				},
+			)
+			input.SchedulerQueue = &interfaces.SchedulerQueueMetrics{QueueSize: 10}
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			// Input = 10 * 2000 = 20000, output = 10 * 50 = 500
+			// Total = 2 * 5000 + 20500 = 30500
+			Expect(result.TotalDemand).To(Equal(float64(30500)))
+		})
+
+		It("should fall back to the average prompt length without sketch", func() {
+			input := makeAnalyzerInput(
+				[]interfaces.ReplicaMetrics{
+					makeReplicaMetrics("pod-1", "variant-a", "H100", 10.0,
+						5000, 16000, 2, 480, 50),
+				},
+				[]interfaces.VariantReplicaState{
+					{VariantName: "variant-a", CurrentReplicas: 1, GPUsPerReplica: 1},
+				},
+			)
+
+			result, err := analyzer.Analyze(ctx, input)
+			Expect(err).NotTo(HaveOccurred())
+			// Replica demand = 5000 + 2 * 480 = 5960
+			Expect(result.TotalDemand).To(Equal(float64(5960)))
+		})
+	})
+
 	Describe("median helper", func() {
 		It("should return 0 for empty slice", func() {
 			Expect(median([]int64{})).To(Equal(int64(0)))
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // CapacityRecord holds cached capacity knowledge for a specific variant.
@@ -15,7 +16,7 @@ import (
 // a compatible variant via FindCompatible.
 type CapacityRecord struct {
 	AcceleratorName       string
-	GpuCount              int
+	GpuCount              float64 // GPUs per replica, fractional when shared
 	NumGpuBlocks          int64
 	BlockSize             int64
 	TotalKvCapacityTokens int64
@@ -83,10 +84,20 @@ func (s *CapacityKnowledgeStore) IsStale(namespace, modelID, variantName string)
 // LoadFromDeployment parses vLLM args from a Deployment and stores an
 // estimated capacity record for the variant. It does NOT overwrite an
 // existing "live" record — deployment-derived data is a fallback only.
-func (s *CapacityKnowledgeStore) LoadFromDeployment(namespace, modelID, variantName, accelerator string, gpuCount int, deploy *appsv1.Deployment) {
+func (s *CapacityKnowledgeStore) LoadFromDeployment(namespace, modelID, variantName, accelerator string, gpuCount float64, deploy *appsv1.Deployment) {
 	if deploy == nil {
 		return
 	}
+	s.LoadFromPodTemplate(namespace, modelID, variantName, accelerator, gpuCount, &deploy.Spec.Template)
+}
+
+// LoadFromPodTemplate parses vLLM args from the pod template of the model
+// server of a scale target, e.g. the leader of a LeaderWorkerSet, and stores
+// an estimated capacity record for the variant, as LoadFromDeployment does.
+func (s *CapacityKnowledgeStore) LoadFromPodTemplate(namespace, modelID, variantName, accelerator string, gpuCount float64, template *corev1.PodTemplateSpec) {
+	if template == nil {
+		return
+	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -98,7 +109,7 @@ func (s *CapacityKnowledgeStore) LoadFromDeployment(namespace, modelID, variantN
 		return
 	}
 
-	params := ParseVLLMArgs(deploy)
+	params := ParseVLLMPodTemplate(template)
 	record := &CapacityRecord{
 		AcceleratorName: accelerator,
 		GpuCount:        gpuCount,
@@ -142,6 +153,22 @@ func (s *CapacityKnowledgeStore) EvictStale(timeout time.Duration) int {
 	return evicted
 }
 
+// ForgetModel removes the capacity records of the variants of a model in a namespace,
+// once no VA references the model. Returns the number of records removed.
+func (s *CapacityKnowledgeStore) ForgetModel(namespace, modelID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prefix := storeKey(namespace, modelID, "")
+	forgotten := 0
+	for key := range s.records {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.records, key)
+			forgotten++
+		}
+	}
+	return forgotten
+}
+
 // FindCompatible searches across all namespaces for a capacity record from
 // another variant with matching configuration: same model, accelerator type,
 // GPU count, and compatible vLLM parameters (as defined by IsCapacityCompatible).
@@ -150,7 +177,7 @@ func (s *CapacityKnowledgeStore) EvictStale(timeout time.Duration) int {
 //
 // Returns the best match (preferring "live" records over "deployment" records),
 // or nil if no compatible record exists.
-func (s *CapacityKnowledgeStore) FindCompatible(modelID, accelerator string, gpuCount int, params *VLLMEngineParams) *CapacityRecord {
+func (s *CapacityKnowledgeStore) FindCompatible(modelID, accelerator string, gpuCount float64, params *VLLMEngineParams) *CapacityRecord {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -185,3 +212,51 @@ func (s *CapacityKnowledgeStore) FindCompatible(modelID, accelerator string, gpu
 
 	return best
 }
+
+// VariantCapacityRecord is a capacity record with the variant it was learned for.
+type VariantCapacityRecord struct {
+	Namespace   string
+	ModelID     string
+	VariantName string
+	CapacityRecord
+}
+
+// LiveRecords returns a copy of the records learned from live metrics, which take the
+// longest to rebuild, e.g. to carry them over to a new leader.
+func (s *CapacityKnowledgeStore) LiveRecords() []VariantCapacityRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var records []VariantCapacityRecord
+	for key, rec := range s.records {
+		if rec.LearnedFrom != "live" {
+			continue
+		}
+		parts := strings.SplitN(key, "|", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		records = append(records, VariantCapacityRecord{
+			Namespace:      parts[0],
+			ModelID:        parts[1],
+			VariantName:    parts[2],
+			CapacityRecord: *rec,
+		})
+	}
+	return records
+}
+
+// Restore stores records exported by LiveRecords, keeping the time they were learned
+// at so that they go stale as if they had never left the store. Records already in the
+// store and learned later are kept.
+func (s *CapacityKnowledgeStore) Restore(records []VariantCapacityRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		key := storeKey(r.Namespace, r.ModelID, r.VariantName)
+		if existing, ok := s.records[key]; ok && existing.LearnedAt.After(r.LearnedAt) {
+			continue
+		}
+		record := r.CapacityRecord
+		s.records[key] = &record
+	}
+}
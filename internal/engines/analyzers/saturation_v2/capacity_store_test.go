@@ -35,7 +35,7 @@ var _ = Describe("CapacityKnowledgeStore", func() {
 			Expect(got).NotTo(BeNil())
 			Expect(got.TotalKvCapacityTokens).To(Equal(int64(16000)))
 			Expect(got.AcceleratorName).To(Equal("H100"))
-			Expect(got.GpuCount).To(Equal(1))
+			Expect(got.GpuCount).To(Equal(1.0))
 			Expect(got.LearnedFrom).To(Equal("live"))
 		})
 
@@ -54,6 +54,21 @@ var _ = Describe("CapacityKnowledgeStore", func() {
 		})
 	})
 
+	Describe("ForgetModel", func() {
+		It("should remove the records of the model in the namespace only", func() {
+			store.Update("ns-1", "model-a", "variant-h100", CapacityRecord{LearnedFrom: "live"})
+			store.Update("ns-1", "model-a", "variant-a100", CapacityRecord{LearnedFrom: "live"})
+			store.Update("ns-2", "model-a", "variant-h100", CapacityRecord{LearnedFrom: "live"})
+			store.Update("ns-1", "model-ab", "variant-h100", CapacityRecord{LearnedFrom: "live"})
+
+			Expect(store.ForgetModel("ns-1", "model-a")).To(Equal(2))
+			Expect(store.Get("ns-1", "model-a", "variant-h100")).To(BeNil())
+			Expect(store.Get("ns-1", "model-a", "variant-a100")).To(BeNil())
+			Expect(store.Get("ns-2", "model-a", "variant-h100")).NotTo(BeNil())
+			Expect(store.Get("ns-1", "model-ab", "variant-h100")).NotTo(BeNil())
+		})
+	})
+
 	Describe("Get missing key", func() {
 		It("should return nil for a key that does not exist", func() {
 			Expect(store.Get("ns-1", "nonexistent", "variant")).To(BeNil())
@@ -71,7 +86,7 @@ var _ = Describe("CapacityKnowledgeStore", func() {
 			Expect(got.VLLMParams.GpuMemoryUtilization).To(Equal(0.85))
 			Expect(got.VLLMParams.MaxNumBatchedTokens).To(Equal(int64(4096)))
 			Expect(got.AcceleratorName).To(Equal("A100"))
-			Expect(got.GpuCount).To(Equal(2))
+			Expect(got.GpuCount).To(Equal(2.0))
 			Expect(got.LearnedFrom).To(Equal("deployment"))
 		})
 
@@ -356,6 +371,44 @@ var _ = Describe("CapacityKnowledgeStore", func() {
 			Expect(found).To(BeNil())
 		})
 	})
+
+	Describe("LiveRecords and Restore", func() {
+		It("should carry live records over to another store with their learn time", func() {
+			store.Update("ns-1", "model-a", "variant-h100", CapacityRecord{
+				AcceleratorName:   "H100",
+				GpuCount:          1,
+				EffectiveCapacity: 14000,
+				LearnedFrom:       "live",
+			})
+			store.LoadFromDeployment("ns-1", "model-a", "variant-a100", "A100", 1, makeTestDeployment())
+
+			records := store.LiveRecords()
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Namespace).To(Equal("ns-1"))
+			Expect(records[0].ModelID).To(Equal("model-a"))
+			Expect(records[0].VariantName).To(Equal("variant-h100"))
+
+			records[0].LearnedAt = time.Now().Add(-2 * CapacityStalenessTimeout)
+			restored := NewCapacityKnowledgeStore()
+			restored.Restore(records)
+			rec := restored.Get("ns-1", "model-a", "variant-h100")
+			Expect(rec).NotTo(BeNil())
+			Expect(rec.EffectiveCapacity).To(Equal(int64(14000)))
+			Expect(restored.IsStale("ns-1", "model-a", "variant-h100")).To(BeTrue())
+			Expect(restored.Get("ns-1", "model-a", "variant-a100")).To(BeNil())
+		})
+
+		It("should keep records learned after the restored ones", func() {
+			store.Update("ns-1", "model-a", "variant-h100", CapacityRecord{EffectiveCapacity: 20000, LearnedFrom: "live"})
+			store.Restore([]VariantCapacityRecord{{
+				Namespace:      "ns-1",
+				ModelID:        "model-a",
+				VariantName:    "variant-h100",
+				CapacityRecord: CapacityRecord{EffectiveCapacity: 14000, LearnedFrom: "live", LearnedAt: time.Now().Add(-time.Hour)},
+			}})
+			Expect(store.Get("ns-1", "model-a", "variant-h100").EffectiveCapacity).To(Equal(int64(20000)))
+		})
+	})
 })
 
 // makeTestDeployment creates a minimal Deployment with the given vLLM args.
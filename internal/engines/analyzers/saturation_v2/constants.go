@@ -31,6 +31,11 @@ const (
 	// bound on token count) since modern tokenizers achieve 5-6 chars/token.
 	BytesPerToken = 4
 
+	// InputTokensQuantile is the quantile of the prompt length distribution used
+	// to project the KV demand of queued requests. A tail quantile rather than
+	// the average keeps long-prompt workloads from being underestimated.
+	InputTokensQuantile = 0.9
+
 	// ShortOutputThreshold is the upper bound (exclusive) for the "short"
 	// output-length bucket used for k2 history keying.
 	ShortOutputThreshold = 100
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // VLLMEngineParams holds vLLM configuration parameters parsed from a
@@ -53,13 +54,22 @@ func defaultVLLMEngineParams() VLLMEngineParams {
 //   - Boolean flags: --enforce-eager (no value)
 //   - VLLM_USE_V1 environment variable for V1 engine detection
 func ParseVLLMArgs(deploy *appsv1.Deployment) VLLMEngineParams {
+	if deploy == nil {
+		return ParseVLLMPodTemplate(nil)
+	}
+	return ParseVLLMPodTemplate(&deploy.Spec.Template)
+}
+
+// ParseVLLMPodTemplate scans the containers of a pod template for vLLM CLI
+// arguments and environment variables, as ParseVLLMArgs does for Deployments.
+func ParseVLLMPodTemplate(template *corev1.PodTemplateSpec) VLLMEngineParams {
 	params := defaultVLLMEngineParams()
-	if deploy == nil || len(deploy.Spec.Template.Spec.Containers) == 0 {
+	if template == nil || len(template.Spec.Containers) == 0 {
 		resolveEffectiveMaxBatchedTokens(&params)
 		return params
 	}
 
-	for _, container := range deploy.Spec.Template.Spec.Containers {
+	for _, container := range template.Spec.Containers {
 		// Check environment variables first
 		for _, env := range container.Env {
 			if env.Name == "VLLM_USE_V1" {
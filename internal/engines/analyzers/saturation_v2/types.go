@@ -13,7 +13,8 @@ type ReplicaCapacity struct {
 	ComputeBoundCapacity  int64 // k2: compute/scheduling-limited capacity
 	EffectiveCapacity     int64 // min(k1, k2)
 	IsSaturated           bool
-	ReplicaDemand         int64 // tokensInUse + queueLength * avgInputTokens
+	ReplicaDemand         int64 // tokensInUse + queueLength * p90InputTokens
+	Failed                bool  // on a failing GPU: no capacity, demand only
 }
 
 // classifyOutputLength returns a workload bucket name based on average
@@ -0,0 +1,208 @@
+// Package backpressure protects the controller from overload in large fleets.
+//
+// A Monitor samples the load of the controller once per optimization cycle: the depth
+// of the reconcile work queues, and the latency of the metrics queries of the cycle.
+// While either exceeds its limit, the Monitor doubles a slowdown factor N (up to a
+// maximum) and VariantAutoscalings annotated with wva.llmd.ai/priority: "low" are only
+// optimized one in every N cycles, i.e. their effective reconcile interval is N times
+// longer. Once the load is back under half of the limits, the factor halves again.
+package backpressure
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// workqueueDepthMetric is the gauge of the controller-runtime work queue depths.
+const workqueueDepthMetric = "workqueue_depth"
+
+// Limits configures back-pressure. A zero limit is not checked; with both limits zero,
+// back-pressure is disabled and the load is only reported.
+type Limits struct {
+	// MaxQueueDepth is the total reconcile queue depth above which the controller is overloaded.
+	MaxQueueDepth int
+	// MaxQueryLatency is the latency of metrics queries above which the controller is overloaded.
+	MaxQueryLatency time.Duration
+	// MaxSlowdown is the maximum factor by which the interval of low-priority VAs is lengthened.
+	MaxSlowdown int
+}
+
+// Load is the load of the controller sampled in a cycle.
+type Load struct {
+	// QueueDepth is the total depth of the reconcile work queues.
+	QueueDepth int
+	// QueryLatency is the highest latency of the metrics queries since the previous cycle.
+	QueryLatency time.Duration
+	// Slowdown is the factor by which the interval of low-priority VAs is lengthened.
+	Slowdown int
+	// Deferred is the number of low-priority VAs deferred in the cycle.
+	Deferred int
+}
+
+// Monitor samples the load of the controller and defers low-priority VAs under overload.
+type Monitor struct {
+	limits   Limits
+	gatherer prometheus.Gatherer
+
+	mu           sync.Mutex
+	queryLatency time.Duration // highest latency since the previous cycle
+	slowdown     int
+	cycle        uint64
+}
+
+// NewMonitor creates a Monitor reading the reconcile queue depth from the gatherer,
+// typically the controller-runtime metrics registry.
+func NewMonitor(limits Limits, gatherer prometheus.Gatherer) *Monitor {
+	if limits.MaxSlowdown < 1 {
+		limits.MaxSlowdown = 1
+	}
+	return &Monitor{limits: limits, gatherer: gatherer, slowdown: 1}
+}
+
+// Enabled returns true if a limit is set.
+func (m *Monitor) Enabled() bool {
+	return m.limits.MaxQueueDepth > 0 || m.limits.MaxQueryLatency > 0
+}
+
+// ObserveQueryLatency records the latency of a metrics query.
+func (m *Monitor) ObserveQueryLatency(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queryLatency = max(m.queryLatency, latency)
+}
+
+// Filter starts a cycle: it samples the load, adapts the slowdown factor, and returns
+// the VAs to optimize in the cycle, without the deferred low-priority ones.
+func (m *Monitor) Filter(ctx context.Context, vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling) ([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, Load) {
+	logger := ctrl.LoggerFrom(ctx)
+	queueDepth := m.queueDepth(ctx)
+
+	m.mu.Lock()
+	load := Load{QueueDepth: queueDepth, QueryLatency: m.queryLatency}
+	m.queryLatency = 0
+	cycle := m.cycle
+	m.cycle++
+	previous := m.slowdown
+	if m.Enabled() {
+		switch {
+		case m.overloaded(load, 1):
+			m.slowdown = min(m.slowdown*2, m.limits.MaxSlowdown)
+		case !m.overloaded(load, 0.5):
+			m.slowdown = max(m.slowdown/2, 1)
+		}
+	}
+	slowdown := m.slowdown
+	m.mu.Unlock()
+
+	load.Slowdown = slowdown
+	if slowdown != previous {
+		logger.Info("Controller back-pressure changed the interval of low-priority VAs",
+			"slowdown", slowdown,
+			"previousSlowdown", previous,
+			"queueDepth", load.QueueDepth,
+			"queryLatency", load.QueryLatency)
+	}
+	if slowdown == 1 {
+		return vas, load
+	}
+
+	kept := make([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, 0, len(vas))
+	for _, va := range vas {
+		if IsLowPriority(&va) && !dueInCycle(va.Namespace+"/"+va.Name, cycle, slowdown) {
+			load.Deferred++
+			continue
+		}
+		kept = append(kept, va)
+	}
+	if load.Deferred > 0 {
+		logger.V(logging.VERBOSE).Info("Deferred low-priority VAs under back-pressure",
+			"deferred", load.Deferred,
+			"slowdown", slowdown)
+	}
+	return kept, load
+}
+
+// overloaded returns true if the load exceeds a fraction of a limit.
+func (m *Monitor) overloaded(load Load, fraction float64) bool {
+	if m.limits.MaxQueueDepth > 0 && float64(load.QueueDepth) > fraction*float64(m.limits.MaxQueueDepth) {
+		return true
+	}
+	return m.limits.MaxQueryLatency > 0 && float64(load.QueryLatency) > fraction*float64(m.limits.MaxQueryLatency)
+}
+
+// queueDepth returns the total depth of the reconcile work queues, 0 if unavailable.
+func (m *Monitor) queueDepth(ctx context.Context) int {
+	if m.gatherer == nil {
+		return 0
+	}
+	families, err := m.gatherer.Gather()
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to gather work queue depth", "error", err.Error())
+	}
+	depth := 0
+	for _, family := range families {
+		if family.GetName() != workqueueDepthMetric {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			depth += int(metric.GetGauge().GetValue())
+		}
+	}
+	return depth
+}
+
+// dueInCycle spreads the VAs deferred by a slowdown factor evenly over the cycles.
+func dueInCycle(key string, cycle uint64, slowdown int) bool {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return (uint64(hash.Sum32())+cycle)%uint64(slowdown) == 0
+}
+
+// IsLowPriority returns true if a VA is annotated as low priority.
+func IsLowPriority(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) bool {
+	return va.Annotations[constants.PriorityAnnotationKey] == constants.PriorityLow
+}
+
+// InstrumentSource returns a metrics source reporting the latency of its refreshes
+// and backfills to the monitor. It keeps the HistorySource capability of the source.
+func InstrumentSource(metricsSource source.MetricsSource, monitor *Monitor) source.MetricsSource {
+	instrumented := &instrumentedSource{MetricsSource: metricsSource, monitor: monitor}
+	if history, ok := metricsSource.(source.HistorySource); ok {
+		return &instrumentedHistorySource{instrumentedSource: instrumented, history: history}
+	}
+	return instrumented
+}
+
+type instrumentedSource struct {
+	source.MetricsSource
+	monitor *Monitor
+}
+
+func (s *instrumentedSource) Refresh(ctx context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	start := time.Now()
+	results, err := s.MetricsSource.Refresh(ctx, spec)
+	s.monitor.ObserveQueryLatency(time.Since(start))
+	return results, err
+}
+
+type instrumentedHistorySource struct {
+	*instrumentedSource
+	history source.HistorySource
+}
+
+func (s *instrumentedHistorySource) Backfill(ctx context.Context, spec source.RefreshSpec, window time.Duration) (map[string]*source.MetricResult, error) {
+	start := time.Now()
+	results, err := s.history.Backfill(ctx, spec, window)
+	s.monitor.ObserveQueryLatency(time.Since(start))
+	return results, err
+}
@@ -0,0 +1,158 @@
+package backpressure
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func newVAs(n int, priority string) []llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+	vas := make([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, n)
+	for i := range vas {
+		vas[i].ObjectMeta = metav1.ObjectMeta{Name: fmt.Sprintf("va-%d", i), Namespace: "default"}
+		if priority != "" {
+			vas[i].Annotations = map[string]string{constants.PriorityAnnotationKey: priority}
+		}
+	}
+	return vas
+}
+
+func newQueueRegistry(t *testing.T) (*prometheus.Registry, *prometheus.GaugeVec) {
+	registry := prometheus.NewRegistry()
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: workqueueDepthMetric}, []string{"name"})
+	require.NoError(t, registry.Register(depth))
+	return registry, depth
+}
+
+func TestFilterQueueDepth(t *testing.T) {
+	registry, depth := newQueueRegistry(t)
+	monitor := NewMonitor(Limits{MaxQueueDepth: 100, MaxSlowdown: 4}, registry)
+	ctx := context.Background()
+	vas := append(newVAs(2, ""), newVAs(20, constants.PriorityLow)...)
+
+	// the depth of all queues adds up
+	depth.WithLabelValues("variantautoscaling").Set(30)
+	depth.WithLabelValues("configmap").Set(20)
+	kept, load := monitor.Filter(ctx, vas)
+	assert.Len(t, kept, len(vas))
+	assert.Equal(t, Load{QueueDepth: 50, Slowdown: 1}, load)
+
+	// overloaded: the slowdown doubles up to the maximum
+	depth.WithLabelValues("variantautoscaling").Set(200)
+	_, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 2, load.Slowdown)
+	_, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 4, load.Slowdown)
+	kept, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 4, load.Slowdown)
+	assert.Equal(t, len(vas)-len(kept), load.Deferred)
+	assert.Positive(t, load.Deferred)
+	for _, va := range kept[:2] {
+		assert.False(t, IsLowPriority(&va), "VAs without priority must never be deferred")
+	}
+
+	// between half the limit and the limit: the slowdown holds
+	depth.WithLabelValues("variantautoscaling").Set(60)
+	_, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 4, load.Slowdown)
+
+	// under half the limit: the slowdown halves
+	depth.WithLabelValues("variantautoscaling").Set(10)
+	_, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 2, load.Slowdown)
+	kept, load = monitor.Filter(ctx, vas)
+	assert.Equal(t, 1, load.Slowdown)
+	assert.Len(t, kept, len(vas))
+}
+
+func TestFilterSpreadsDeferredVAs(t *testing.T) {
+	monitor := NewMonitor(Limits{MaxQueryLatency: time.Second, MaxSlowdown: 2}, nil)
+	ctx := context.Background()
+	vas := newVAs(10, constants.PriorityLow)
+
+	// every low-priority VA is optimized exactly once in every N cycles
+	processed := map[string]int{}
+	for range 4 {
+		monitor.ObserveQueryLatency(2 * time.Second)
+		kept, load := monitor.Filter(ctx, vas)
+		if load.Slowdown < 2 {
+			continue
+		}
+		for _, va := range kept {
+			processed[va.Name]++
+		}
+	}
+	require.Len(t, processed, len(vas))
+	for name, count := range processed {
+		assert.Equal(t, 2, count, "VA %s", name)
+	}
+}
+
+func TestFilterDisabled(t *testing.T) {
+	registry, depth := newQueueRegistry(t)
+	monitor := NewMonitor(Limits{MaxSlowdown: 8}, registry)
+	assert.False(t, monitor.Enabled())
+
+	depth.WithLabelValues("variantautoscaling").Set(1000)
+	monitor.ObserveQueryLatency(time.Minute)
+	kept, load := monitor.Filter(context.Background(), newVAs(5, constants.PriorityLow))
+	assert.Len(t, kept, 5)
+	assert.Equal(t, Load{QueueDepth: 1000, QueryLatency: time.Minute, Slowdown: 1}, load)
+}
+
+type fakeSource struct {
+	delay time.Duration
+}
+
+func (f *fakeSource) QueryList() *source.QueryList { return nil }
+
+func (f *fakeSource) Refresh(context.Context, source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	time.Sleep(f.delay)
+	return nil, nil
+}
+
+func (f *fakeSource) Get(string, map[string]string) *source.CachedValue { return nil }
+
+type fakeHistorySource struct {
+	fakeSource
+}
+
+func (f *fakeHistorySource) Backfill(context.Context, source.RefreshSpec, time.Duration) (map[string]*source.MetricResult, error) {
+	time.Sleep(f.delay)
+	return nil, nil
+}
+
+func TestInstrumentSource(t *testing.T) {
+	monitor := NewMonitor(Limits{MaxQueryLatency: time.Second}, nil)
+	ctx := context.Background()
+
+	instrumented := InstrumentSource(&fakeSource{delay: 10 * time.Millisecond}, monitor)
+	_, isHistory := instrumented.(source.HistorySource)
+	assert.False(t, isHistory)
+	_, err := instrumented.Refresh(ctx, source.RefreshSpec{})
+	require.NoError(t, err)
+	_, load := monitor.Filter(ctx, nil)
+	assert.GreaterOrEqual(t, load.QueryLatency, 10*time.Millisecond)
+
+	// the latency is reset every cycle
+	_, load = monitor.Filter(ctx, nil)
+	assert.Zero(t, load.QueryLatency)
+
+	instrumented = InstrumentSource(&fakeHistorySource{fakeSource{delay: 10 * time.Millisecond}}, monitor)
+	history, isHistory := instrumented.(source.HistorySource)
+	require.True(t, isHistory, "the HistorySource capability must be kept")
+	_, err = history.Backfill(ctx, source.RefreshSpec{}, time.Hour)
+	require.NoError(t, err)
+	_, load = monitor.Filter(ctx, nil)
+	assert.GreaterOrEqual(t, load.QueryLatency, 10*time.Millisecond)
+}
@@ -1,11 +1,14 @@
 package common
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
@@ -36,9 +39,53 @@ func (c *InternalDecisionCache) Get(name, namespace string) (interfaces.VariantD
 	return val, ok
 }
 
+// Delete removes the decision of a VA.
+func (c *InternalDecisionCache) Delete(name, namespace string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.items, cacheKey(name, namespace))
+}
+
+// NewDecisionCache creates an empty decision cache.
+func NewDecisionCache() *InternalDecisionCache {
+	return &InternalDecisionCache{
+		items: make(map[string]interfaces.VariantDecision),
+	}
+}
+
 // Global cache instance
-var DecisionCache = &InternalDecisionCache{
-	items: make(map[string]interfaces.VariantDecision),
+var DecisionCache = NewDecisionCache()
+
+// InternalSaturationCache holds the last known saturation of VAs, from 0.0 (idle) to
+// 1.0 (saturated). The Controller uses it to reconcile saturated VAs first.
+type InternalSaturationCache struct {
+	sync.RWMutex
+	items map[string]float64
+}
+
+func (c *InternalSaturationCache) Set(name, namespace string, saturation float64) {
+	c.Lock()
+	defer c.Unlock()
+	c.items[cacheKey(name, namespace)] = saturation
+}
+
+func (c *InternalSaturationCache) Get(name, namespace string) (float64, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	val, ok := c.items[cacheKey(name, namespace)]
+	return val, ok
+}
+
+// Delete removes the saturation of a VA.
+func (c *InternalSaturationCache) Delete(name, namespace string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.items, cacheKey(name, namespace))
+}
+
+// Global saturation cache instance
+var SaturationCache = &InternalSaturationCache{
+	items: make(map[string]float64),
 }
 
 // DecisionTrigger is a channel to trigger reconciliation for VAs.
@@ -52,5 +99,126 @@ func DecisionToOptimizedAlloc(d interfaces.VariantDecision) (int, string, metav1
 	return d.TargetReplicas, d.AcceleratorName, metav1.NewTime(time.Now())
 }
 
+// DecisionToScaleUpGrant returns the partial scale-up grant of a limited decision,
+// or nil when the decision was not limited or its scale-up was granted in full.
+func DecisionToScaleUpGrant(d interfaces.VariantDecision) *llmdVariantAutoscalingV1alpha1.ScaleUpGrant {
+	if !d.WasLimited {
+		return nil
+	}
+	requested, granted := d.ScaleUpGrant()
+	if granted >= requested {
+		return nil
+	}
+	return &llmdVariantAutoscalingV1alpha1.ScaleUpGrant{
+		RequestedReplicas: requested,
+		GrantedReplicas:   granted,
+	}
+}
+
+// DecisionToTrafficWeight returns the recommended traffic weight of a decision, or nil
+// when none was recommended.
+func DecisionToTrafficWeight(d interfaces.VariantDecision) *int {
+	if !d.HasTrafficWeight {
+		return nil
+	}
+	weight := d.TrafficWeight
+	return &weight
+}
+
+// DecisionToEngineOutputs returns the per-engine outputs of a composite engine's
+// decision, or nil when the decision was not made by a composite engine.
+func DecisionToEngineOutputs(d interfaces.VariantDecision) []llmdVariantAutoscalingV1alpha1.EngineOutput {
+	if len(d.EngineOutputs) == 0 {
+		return nil
+	}
+	outputs := make([]llmdVariantAutoscalingV1alpha1.EngineOutput, 0, len(d.EngineOutputs))
+	for _, o := range d.EngineOutputs {
+		output := llmdVariantAutoscalingV1alpha1.EngineOutput{
+			Engine:  o.Engine,
+			Message: o.Message,
+		}
+		if o.HasDecision {
+			output.TargetReplicas = ptr.To(o.TargetReplicas)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+// DecisionToLatencyBudget returns the latency budget of a decision, with the dominant
+// component of its TTFT, or nil when the decision has no latency budget.
+func DecisionToLatencyBudget(d interfaces.VariantDecision) *llmdVariantAutoscalingV1alpha1.LatencyBudget {
+	if d.LatencyBudget == nil {
+		return nil
+	}
+	b := d.LatencyBudget
+	dominant := llmdVariantAutoscalingV1alpha1.LatencyComponentCompute
+	if b.QueueingTime > b.PrefillTime {
+		dominant = llmdVariantAutoscalingV1alpha1.LatencyComponentQueueing
+	}
+	return &llmdVariantAutoscalingV1alpha1.LatencyBudget{
+		TargetTTFT:   fmt.Sprintf("%.2f", b.TargetTTFT),
+		ObservedTTFT: fmt.Sprintf("%.2f", b.ObservedTTFT),
+		QueueingTime: fmt.Sprintf("%.2f", b.QueueingTime),
+		PrefillTime:  fmt.Sprintf("%.2f", b.PrefillTime),
+		Dominant:     dominant,
+	}
+}
+
+// DecisionToPrediction returns the performance predicted for the target replicas of a
+// decision, or nil when the decision has no prediction.
+func DecisionToPrediction(d interfaces.VariantDecision) *llmdVariantAutoscalingV1alpha1.Prediction {
+	if d.Prediction == nil {
+		return nil
+	}
+	p := d.Prediction
+	prediction := &llmdVariantAutoscalingV1alpha1.Prediction{
+		Replicas:    int32(p.Replicas),
+		ArrivalRate: fmt.Sprintf("%.2f", p.ArrivalRate),
+		Utilization: fmt.Sprintf("%.2f", p.Utilization),
+		Overloaded:  p.Overloaded,
+	}
+	if !p.Overloaded {
+		prediction.TTFT = fmt.Sprintf("%.2f", p.TTFT)
+		prediction.ITL = fmt.Sprintf("%.2f", p.ITL)
+	}
+	return prediction
+}
+
+// DecisionToSLOViolation returns the relative SLO violation predicted for the target of
+// a decision, or "" when the prediction meets the SLOs or the decision has none.
+func DecisionToSLOViolation(d interfaces.VariantDecision) string {
+	if d.Prediction == nil || d.Prediction.SLOViolation <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", d.Prediction.SLOViolation)
+}
+
+// DecisionToRequestRate returns the request rate idle detection saw for the model of a
+// decision, or nil when scale-to-zero is disabled for the model.
+func DecisionToRequestRate(d interfaces.VariantDecision) *llmdVariantAutoscalingV1alpha1.RequestRate {
+	if d.RequestRate == nil {
+		return nil
+	}
+	return &llmdVariantAutoscalingV1alpha1.RequestRate{
+		Observed:   fmt.Sprintf("%.2f", d.RequestRate.Observed),
+		NoiseFloor: fmt.Sprintf("%.2f", d.RequestRate.NoiseFloor),
+		Filtered:   fmt.Sprintf("%.2f", d.RequestRate.Filtered),
+	}
+}
+
+// DecisionToScaleDownQuorum returns the scale-down approvals of a decision, or nil when
+// no scale-down quorum is required.
+func DecisionToScaleDownQuorum(d interfaces.VariantDecision) *llmdVariantAutoscalingV1alpha1.ScaleDownQuorumStatus {
+	if d.ScaleDownQuorum == nil {
+		return nil
+	}
+	return &llmdVariantAutoscalingV1alpha1.ScaleDownQuorumStatus{
+		Approvals: int32(d.ScaleDownQuorum.Approvals),
+		Window:    int32(d.ScaleDownQuorum.Window),
+		Required:  int32(d.ScaleDownQuorum.Required),
+	}
+}
+
 // GlobalConfig and Config singleton have been removed in favor of unified Config
 // from internal/config package. All components now receive Config via dependency injection.
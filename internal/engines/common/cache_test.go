@@ -4,6 +4,7 @@ import (
 	"sync"
 	"testing"
 
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 )
 
@@ -36,6 +37,12 @@ func TestInternalDecisionCache(t *testing.T) {
 		t.Error("Expected non-existent item to not be found")
 	}
 
+	// Test Delete
+	cache.Delete("test-variant", "test-ns")
+	if _, ok := cache.Get("test-variant", "test-ns"); ok {
+		t.Error("Expected deleted item to not be found")
+	}
+
 	// Test Concurrency
 	var wg sync.WaitGroup
 	for i := 0; i < 100; i++ {
@@ -49,6 +56,28 @@ func TestInternalDecisionCache(t *testing.T) {
 	wg.Wait()
 }
 
+func TestInternalSaturationCache(t *testing.T) {
+	cache := &InternalSaturationCache{
+		items: make(map[string]float64),
+	}
+
+	cache.Set("test-variant", "test-ns", 0.9)
+
+	saturation, ok := cache.Get("test-variant", "test-ns")
+	if !ok || saturation != 0.9 {
+		t.Errorf("Expected saturation 0.9, got %v (found: %v)", saturation, ok)
+	}
+
+	if _, ok := cache.Get("test-variant", "other-ns"); ok {
+		t.Error("Expected saturation of another namespace to not be found")
+	}
+
+	cache.Delete("test-variant", "test-ns")
+	if _, ok := cache.Get("test-variant", "test-ns"); ok {
+		t.Error("Expected deleted saturation to not be found")
+	}
+}
+
 // TestGlobalConfig removed - GlobalConfig has been removed in favor of unified Config
 // from internal/config package. Config functionality is now tested in internal/config/loader_test.go
 
@@ -67,3 +96,203 @@ func TestDecisionToOptimizedAlloc(t *testing.T) {
 		t.Errorf("Expected H100 accelerator, got %s", acc)
 	}
 }
+
+func TestDecisionToScaleUpGrant(t *testing.T) {
+	tests := []struct {
+		name      string
+		decision  interfaces.VariantDecision
+		wantNil   bool
+		requested int
+		granted   int
+	}{
+		{
+			name: "partial grant",
+			decision: interfaces.VariantDecision{
+				CurrentReplicas:        2,
+				OriginalTargetReplicas: 6,
+				TargetReplicas:         3,
+				WasLimited:             true,
+			},
+			requested: 4,
+			granted:   1,
+		},
+		{
+			name: "nothing granted",
+			decision: interfaces.VariantDecision{
+				CurrentReplicas:        2,
+				OriginalTargetReplicas: 4,
+				TargetReplicas:         2,
+				WasLimited:             true,
+			},
+			requested: 2,
+			granted:   0,
+		},
+		{
+			name: "not limited",
+			decision: interfaces.VariantDecision{
+				CurrentReplicas:        2,
+				OriginalTargetReplicas: 6,
+				TargetReplicas:         6,
+			},
+			wantNil: true,
+		},
+		{
+			name: "limited scale-down",
+			decision: interfaces.VariantDecision{
+				CurrentReplicas:        4,
+				OriginalTargetReplicas: 2,
+				TargetReplicas:         2,
+				WasLimited:             true,
+			},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant := DecisionToScaleUpGrant(tt.decision)
+			if tt.wantNil {
+				if grant != nil {
+					t.Errorf("Expected no grant, got %+v", *grant)
+				}
+				return
+			}
+			if grant == nil {
+				t.Fatal("Expected a grant, got nil")
+			}
+			if grant.RequestedReplicas != tt.requested || grant.GrantedReplicas != tt.granted {
+				t.Errorf("Expected %d of %d replicas granted, got %d of %d",
+					tt.granted, tt.requested, grant.GrantedReplicas, grant.RequestedReplicas)
+			}
+		})
+	}
+}
+
+func TestDecisionToEngineOutputs(t *testing.T) {
+	if outputs := DecisionToEngineOutputs(interfaces.VariantDecision{TargetReplicas: 3}); outputs != nil {
+		t.Errorf("Expected no engine outputs, got %+v", outputs)
+	}
+
+	outputs := DecisionToEngineOutputs(interfaces.VariantDecision{
+		EngineOutputs: []interfaces.EngineOutput{
+			{Engine: "queueing", HasDecision: true, TargetReplicas: 0, Message: "idle"},
+			{Engine: "forecast", Message: "no decision"},
+		},
+	})
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 engine outputs, got %d", len(outputs))
+	}
+	if outputs[0].Engine != "queueing" || outputs[0].TargetReplicas == nil || *outputs[0].TargetReplicas != 0 || outputs[0].Message != "idle" {
+		t.Errorf("Unexpected output of an engine with a decision: %+v", outputs[0])
+	}
+	if outputs[1].Engine != "forecast" || outputs[1].TargetReplicas != nil || outputs[1].Message != "no decision" {
+		t.Errorf("Unexpected output of an engine without decision: %+v", outputs[1])
+	}
+}
+
+func TestDecisionToTrafficWeight(t *testing.T) {
+	if weight := DecisionToTrafficWeight(interfaces.VariantDecision{TargetReplicas: 3}); weight != nil {
+		t.Errorf("Expected no traffic weight, got %d", *weight)
+	}
+	weight := DecisionToTrafficWeight(interfaces.VariantDecision{TrafficWeight: 0, HasTrafficWeight: true})
+	if weight == nil || *weight != 0 {
+		t.Errorf("Expected traffic weight 0, got %v", weight)
+	}
+}
+
+func TestDecisionToRequestRate(t *testing.T) {
+	if rate := DecisionToRequestRate(interfaces.VariantDecision{TargetReplicas: 3}); rate != nil {
+		t.Errorf("Expected no request rate, got %+v", rate)
+	}
+	rate := DecisionToRequestRate(interfaces.VariantDecision{
+		RequestRate: &interfaces.RequestRate{Observed: 0.5, NoiseFloor: 1, Filtered: 0},
+	})
+	if rate == nil || rate.Observed != "0.50" || rate.NoiseFloor != "1.00" || rate.Filtered != "0.00" {
+		t.Errorf("Unexpected request rate: %+v", rate)
+	}
+}
+
+func TestDecisionToScaleDownQuorum(t *testing.T) {
+	if quorum := DecisionToScaleDownQuorum(interfaces.VariantDecision{TargetReplicas: 3}); quorum != nil {
+		t.Errorf("Expected no scale-down quorum, got %+v", quorum)
+	}
+	quorum := DecisionToScaleDownQuorum(interfaces.VariantDecision{
+		ScaleDownQuorum: &interfaces.ScaleDownQuorum{Approvals: 2, Window: 5, Required: 3},
+	})
+	if quorum == nil || quorum.Approvals != 2 || quorum.Window != 5 || quorum.Required != 3 {
+		t.Errorf("Unexpected scale-down quorum: %+v", quorum)
+	}
+}
+
+func TestDecisionToPrediction(t *testing.T) {
+	if prediction := DecisionToPrediction(interfaces.VariantDecision{TargetReplicas: 3}); prediction != nil {
+		t.Errorf("Expected no prediction, got %+v", prediction)
+	}
+
+	prediction := DecisionToPrediction(interfaces.VariantDecision{
+		Prediction: &interfaces.Prediction{Replicas: 2, ArrivalRate: 12.345, TTFT: 210.5, ITL: 24.125, Utilization: 0.8},
+	})
+	if prediction == nil || prediction.Replicas != 2 || prediction.ArrivalRate != "12.35" ||
+		prediction.TTFT != "210.50" || prediction.ITL != "24.12" || prediction.Utilization != "0.80" {
+		t.Errorf("Unexpected prediction: %+v", prediction)
+	}
+
+	overloaded := DecisionToPrediction(interfaces.VariantDecision{
+		Prediction: &interfaces.Prediction{Replicas: 1, ArrivalRate: 50, Utilization: 1, Overloaded: true},
+	})
+	if overloaded == nil || !overloaded.Overloaded || overloaded.TTFT != "" || overloaded.ITL != "" {
+		t.Errorf("Expected an overloaded prediction without latencies, got %+v", overloaded)
+	}
+}
+
+func TestDecisionToSLOViolation(t *testing.T) {
+	if violation := DecisionToSLOViolation(interfaces.VariantDecision{TargetReplicas: 3}); violation != "" {
+		t.Errorf("Expected no SLO violation without a prediction, got %q", violation)
+	}
+	met := interfaces.VariantDecision{Prediction: &interfaces.Prediction{Replicas: 3}}
+	if violation := DecisionToSLOViolation(met); violation != "" {
+		t.Errorf("Expected no SLO violation when the SLOs are met, got %q", violation)
+	}
+	violated := interfaces.VariantDecision{Prediction: &interfaces.Prediction{Replicas: 3, SLOViolation: 0.504}}
+	if violation := DecisionToSLOViolation(violated); violation != "0.50" {
+		t.Errorf("DecisionToSLOViolation = %q, want 0.50", violation)
+	}
+}
+
+func TestDecisionToLatencyBudget(t *testing.T) {
+	if budget := DecisionToLatencyBudget(interfaces.VariantDecision{TargetReplicas: 3}); budget != nil {
+		t.Errorf("Expected no latency budget, got %+v", budget)
+	}
+
+	tests := []struct {
+		name         string
+		queueingTime float64
+		prefillTime  float64
+		want         llmdVariantAutoscalingV1alpha1.LatencyComponent
+	}{
+		{"queueing dominates", 420, 80, llmdVariantAutoscalingV1alpha1.LatencyComponentQueueing},
+		{"compute dominates", 20, 80, llmdVariantAutoscalingV1alpha1.LatencyComponentCompute},
+		{"tie is compute", 80, 80, llmdVariantAutoscalingV1alpha1.LatencyComponentCompute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := DecisionToLatencyBudget(interfaces.VariantDecision{
+				LatencyBudget: &interfaces.LatencyBudget{
+					TargetTTFT:   500,
+					ObservedTTFT: 512.345,
+					QueueingTime: tt.queueingTime,
+					PrefillTime:  tt.prefillTime,
+				},
+			})
+			if budget == nil {
+				t.Fatal("Expected a latency budget")
+			}
+			if budget.Dominant != tt.want {
+				t.Errorf("Dominant = %s, want %s", budget.Dominant, tt.want)
+			}
+			if budget.TargetTTFT != "500.00" || budget.ObservedTTFT != "512.35" {
+				t.Errorf("Unexpected formatting of the latency budget: %+v", budget)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+// InternalScaleLatencyTracker measures how fast the scalings of VAs go through the
+// autoscaling pipeline: from the metrics sample that crossed the scaling thresholds to the
+// update of the desired replicas metric by the Engine, and to the change of the replicas of
+// the Deployment seen by the Engine or the Controller, whichever sees it first.
+type InternalScaleLatencyTracker struct {
+	sync.Mutex
+	// inFlight are the scalings whose Deployment replicas did not change yet, and last
+	// the last scalings completed, keyed by VA namespace/name
+	inFlight map[string]*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus
+	last     map[string]*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus
+}
+
+// NewScaleLatencyTracker creates a tracker without scalings.
+func NewScaleLatencyTracker() *InternalScaleLatencyTracker {
+	return &InternalScaleLatencyTracker{
+		inFlight: make(map[string]*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus),
+		last:     make(map[string]*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus),
+	}
+}
+
+// Global scale latency tracker instance
+var ScaleLatency = NewScaleLatencyTracker()
+
+// Desired records that the desired replicas metric of a VA was updated at updatedAt to
+// target, while its Deployment ran current replicas, from a metrics sample collected at
+// signalTime (updatedAt when unknown). A scaling starts when target differs from current
+// and no scaling in the same direction is in flight; Desired then returns it, with its
+// desired metric latency. A scaling in flight whose target went back to the current
+// replicas before they changed is dropped.
+func (t *InternalScaleLatencyTracker) Desired(name, namespace string, current, target int, signalTime, updatedAt time.Time) (*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus, bool) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+
+	if target == current {
+		delete(t.inFlight, key)
+		return nil, false
+	}
+	direction := llmdVariantAutoscalingV1alpha1.ScaleDirectionUp
+	if target < current {
+		direction = llmdVariantAutoscalingV1alpha1.ScaleDirectionDown
+	}
+	if scaling, ok := t.inFlight[key]; ok && scaling.Direction == direction {
+		return nil, false
+	}
+
+	if signalTime.IsZero() || signalTime.After(updatedAt) {
+		signalTime = updatedAt
+	}
+	scaling := &llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus{
+		Direction:            direction,
+		FromReplicas:         int32(current),
+		TargetReplicas:       int32(target),
+		SignalTime:           metav1.NewTime(signalTime),
+		DesiredMetricLatency: metav1.Duration{Duration: updatedAt.Sub(signalTime)},
+	}
+	t.inFlight[key] = scaling
+	return scaling.DeepCopy(), true
+}
+
+// Replicas records that the Deployment of a VA ran replicas at observedAt. When they
+// changed from the replicas the scaling in flight started from, in its direction, the
+// scaling completes and Replicas returns it, with its replicas latency.
+func (t *InternalScaleLatencyTracker) Replicas(name, namespace string, replicas int, observedAt time.Time) (*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus, bool) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+
+	scaling, ok := t.inFlight[key]
+	if !ok {
+		return nil, false
+	}
+	switch scaling.Direction {
+	case llmdVariantAutoscalingV1alpha1.ScaleDirectionUp:
+		ok = replicas > int(scaling.FromReplicas)
+	case llmdVariantAutoscalingV1alpha1.ScaleDirectionDown:
+		ok = replicas < int(scaling.FromReplicas)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	scaling.ReplicasLatency = &metav1.Duration{Duration: max(observedAt.Sub(scaling.SignalTime.Time), 0)}
+	delete(t.inFlight, key)
+	t.last[key] = scaling
+	return scaling.DeepCopy(), true
+}
+
+// Get returns the latest scaling of a VA: the one in flight, or else the last one completed.
+func (t *InternalScaleLatencyTracker) Get(name, namespace string) (*llmdVariantAutoscalingV1alpha1.ScaleLatencyStatus, bool) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	if scaling, ok := t.inFlight[key]; ok {
+		return scaling.DeepCopy(), true
+	}
+	if scaling, ok := t.last[key]; ok {
+		return scaling.DeepCopy(), true
+	}
+	return nil, false
+}
+
+// Delete forgets the scalings of a VA.
+func (t *InternalScaleLatencyTracker) Delete(name, namespace string) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	delete(t.inFlight, key)
+	delete(t.last, key)
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+func TestScaleLatencyTracker(t *testing.T) {
+	tracker := NewScaleLatencyTracker()
+	signal := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := tracker.Get("va", "ns"); ok {
+		t.Fatal("Expected no scaling before a decision")
+	}
+
+	// A scale-up starts with the latency of the desired metric
+	scaling, ok := tracker.Desired("va", "ns", 2, 4, signal, signal.Add(20*time.Second))
+	if !ok {
+		t.Fatal("Expected a scale-up to start")
+	}
+	if scaling.Direction != llmdVariantAutoscalingV1alpha1.ScaleDirectionUp || scaling.FromReplicas != 2 || scaling.TargetReplicas != 4 {
+		t.Errorf("Unexpected scaling %+v", scaling)
+	}
+	if scaling.DesiredMetricLatency.Duration != 20*time.Second {
+		t.Errorf("Expected a desired metric latency of 20s, got %v", scaling.DesiredMetricLatency.Duration)
+	}
+
+	// The next runs of the same scaling do not restart it
+	if _, ok := tracker.Desired("va", "ns", 2, 5, signal.Add(30*time.Second), signal.Add(50*time.Second)); ok {
+		t.Error("Expected the scaling in flight to continue")
+	}
+	if _, ok := tracker.Replicas("va", "ns", 2, signal.Add(60*time.Second)); ok {
+		t.Error("Expected the scaling to complete only once the replicas change")
+	}
+	if latest, _ := tracker.Get("va", "ns"); latest.ReplicasLatency != nil {
+		t.Error("Expected no replicas latency while the scaling is in flight")
+	}
+
+	// The scaling completes when the replicas change in its direction
+	scaling, ok = tracker.Replicas("va", "ns", 3, signal.Add(90*time.Second))
+	if !ok {
+		t.Fatal("Expected the scaling to complete")
+	}
+	if scaling.ReplicasLatency == nil || scaling.ReplicasLatency.Duration != 90*time.Second {
+		t.Errorf("Expected a replicas latency of 90s, got %v", scaling.ReplicasLatency)
+	}
+	if _, ok := tracker.Replicas("va", "ns", 4, signal.Add(120*time.Second)); ok {
+		t.Error("Expected a completed scaling to complete once")
+	}
+
+	// A scale-down withdrawn before the replicas change is dropped
+	if _, ok := tracker.Desired("va", "ns", 4, 3, signal.Add(time.Hour), signal.Add(time.Hour)); !ok {
+		t.Fatal("Expected a scale-down to start")
+	}
+	if _, ok := tracker.Desired("va", "ns", 4, 4, signal.Add(time.Hour), signal.Add(time.Hour)); ok {
+		t.Error("Expected no scaling without a change of replicas")
+	}
+	latest, ok := tracker.Get("va", "ns")
+	if !ok || latest.Direction != llmdVariantAutoscalingV1alpha1.ScaleDirectionUp || latest.ReplicasLatency == nil {
+		t.Errorf("Expected the last completed scale-up, got %+v", latest)
+	}
+}
@@ -0,0 +1,127 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Composite combines the decisions of several engines with a combination policy, and
+// records the decision of each engine in the combined decision.
+type Composite struct {
+	policy  llmdVariantAutoscalingV1alpha1.EngineCombinationPolicy
+	engines []Engine
+}
+
+// NewComposite creates a composite engine of the given engines, in priority order.
+// An empty policy selects EngineCombinationMax.
+func NewComposite(policy llmdVariantAutoscalingV1alpha1.EngineCombinationPolicy, engines []Engine) *Composite {
+	if policy == "" {
+		policy = llmdVariantAutoscalingV1alpha1.EngineCombinationMax
+	}
+	return &Composite{policy: policy, engines: engines}
+}
+
+// Name returns the engine's identifier.
+func (c *Composite) Name() string {
+	return CompositeEngineName
+}
+
+// AnalyzeAndRecommend asks every engine for its decision and combines them.
+// Engines that fail or make no decision are skipped; an error is returned only when
+// all engines fail.
+func (c *Composite) AnalyzeAndRecommend(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, metrics []interfaces.ReplicaMetrics) (*VariantDecision, error) {
+	outputs := make([]interfaces.EngineOutput, 0, len(c.engines))
+	var (
+		chosen       *VariantDecision
+		chosenEngine string
+		errs         []error
+	)
+
+	for _, engine := range c.engines {
+		output := interfaces.EngineOutput{Engine: engine.Name()}
+		decision, err := engine.AnalyzeAndRecommend(ctx, va, metrics)
+		switch {
+		case err != nil:
+			output.Message = err.Error()
+			errs = append(errs, fmt.Errorf("engine %s: %w", engine.Name(), err))
+		case decision == nil:
+			output.Message = "no decision"
+		default:
+			output.HasDecision = true
+			output.TargetReplicas = decision.TargetReplicas
+			output.Message = decision.Reason
+			if c.prefers(decision, chosen) {
+				chosen = decision
+				chosenEngine = engine.Name()
+			}
+		}
+		outputs = append(outputs, output)
+	}
+
+	if chosen == nil {
+		if len(errs) == len(c.engines) {
+			return nil, errors.Join(errs...)
+		}
+		return nil, nil
+	}
+
+	combined := *chosen
+	combined.Reason = fmt.Sprintf("%s of %d engines: engine %s", c.policy, len(c.engines), chosenEngine)
+	if chosen.Reason != "" {
+		combined.Reason += ": " + chosen.Reason
+	}
+	combined.EngineOutputs = outputs
+	return &combined, nil
+}
+
+// prefers reports whether a decision replaces the decision chosen so far.
+func (c *Composite) prefers(decision, chosen *VariantDecision) bool {
+	if chosen == nil {
+		return true
+	}
+	switch c.policy {
+	case llmdVariantAutoscalingV1alpha1.EngineCombinationMin:
+		return decision.TargetReplicas < chosen.TargetReplicas
+	case llmdVariantAutoscalingV1alpha1.EngineCombinationPriority:
+		return false
+	default:
+		return decision.TargetReplicas > chosen.TargetReplicas
+	}
+}
+
+// ForVariant returns the custom engine selected by a VariantAutoscaling, or nil when it
+// selects the saturation engine. A spec.engineComposition is resolved to a Composite.
+// Returns an error when a selected engine is not registered.
+func ForVariant(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) (Engine, error) {
+	if composition := va.Spec.EngineComposition; composition != nil {
+		members := make([]Engine, 0, len(composition.Engines))
+		for _, name := range composition.Engines {
+			if name == SaturationEngineName {
+				return nil, fmt.Errorf("the %s engine cannot be composed", SaturationEngineName)
+			}
+			engine, ok := Get(name)
+			if !ok {
+				return nil, fmt.Errorf("scaling engine %q not registered", name)
+			}
+			members = append(members, engine)
+		}
+		return NewComposite(composition.Policy, members), nil
+	}
+
+	name := va.Spec.Engine
+	if name == "" || name == SaturationEngineName {
+		return nil, nil
+	}
+	engine, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("scaling engine %q not registered", name)
+	}
+	return engine, nil
+}
+
+// Ensure Composite implements Engine.
+var _ Engine = (*Composite)(nil)
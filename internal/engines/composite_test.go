@@ -0,0 +1,112 @@
+package engines
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func recommending(name string, target int) *fakeEngine {
+	return &fakeEngine{name: name, decision: &VariantDecision{TargetReplicas: target, Reason: name + " reason"}}
+}
+
+func TestComposite_Policies(t *testing.T) {
+	members := []Engine{
+		&fakeEngine{name: "undecided"},
+		recommending("queueing", 3),
+		recommending("forecast", 5),
+		&fakeEngine{name: "broken", err: errors.New("no model")},
+	}
+
+	tests := []struct {
+		policy llmdVariantAutoscalingV1alpha1.EngineCombinationPolicy
+		target int
+		reason string
+	}{
+		{policy: "", target: 5, reason: "Max of 4 engines: engine forecast: forecast reason"},
+		{policy: llmdVariantAutoscalingV1alpha1.EngineCombinationMax, target: 5, reason: "Max of 4 engines: engine forecast: forecast reason"},
+		{policy: llmdVariantAutoscalingV1alpha1.EngineCombinationMin, target: 3, reason: "Min of 4 engines: engine queueing: queueing reason"},
+		{policy: llmdVariantAutoscalingV1alpha1.EngineCombinationPriority, target: 3, reason: "Priority of 4 engines: engine queueing: queueing reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			decision, err := NewComposite(tt.policy, members).AnalyzeAndRecommend(context.Background(), nil, nil)
+			require.NoError(t, err)
+			require.NotNil(t, decision)
+			assert.Equal(t, tt.target, decision.TargetReplicas)
+			assert.Equal(t, tt.reason, decision.Reason)
+			assert.Equal(t, []interfaces.EngineOutput{
+				{Engine: "undecided", Message: "no decision"},
+				{Engine: "queueing", HasDecision: true, TargetReplicas: 3, Message: "queueing reason"},
+				{Engine: "forecast", HasDecision: true, TargetReplicas: 5, Message: "forecast reason"},
+				{Engine: "broken", Message: "no model"},
+			}, decision.EngineOutputs)
+		})
+	}
+}
+
+func TestComposite_NoDecision(t *testing.T) {
+	decision, err := NewComposite("", []Engine{&fakeEngine{name: "undecided"}, &fakeEngine{name: "broken", err: errors.New("no model")}}).
+		AnalyzeAndRecommend(context.Background(), nil, nil)
+	assert.NoError(t, err, "an engine without decision is not a failure")
+	assert.Nil(t, decision)
+
+	_, err = NewComposite("", []Engine{&fakeEngine{name: "broken", err: errors.New("no model")}, &fakeEngine{name: "down", err: errors.New("timeout")}}).
+		AnalyzeAndRecommend(context.Background(), nil, nil)
+	assert.ErrorContains(t, err, "engine broken: no model")
+	assert.ErrorContains(t, err, "engine down: timeout")
+}
+
+func TestForVariant(t *testing.T) {
+	register(recommending("queueing", 3))
+	register(recommending("forecast", 5))
+
+	va := func(spec llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec) *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+		return &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{Spec: spec}
+	}
+
+	engine, err := ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{}))
+	assert.NoError(t, err)
+	assert.Nil(t, engine, "empty engine selects the saturation engine")
+
+	engine, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{Engine: SaturationEngineName}))
+	assert.NoError(t, err)
+	assert.Nil(t, engine)
+
+	engine, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{Engine: "queueing"}))
+	require.NoError(t, err)
+	assert.Equal(t, "queueing", engine.Name())
+
+	_, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{Engine: "unknown"}))
+	assert.Error(t, err)
+
+	engine, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+		Engine: "queueing",
+		EngineComposition: &llmdVariantAutoscalingV1alpha1.EngineComposition{
+			Engines: []string{"queueing", "forecast"},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CompositeEngineName, engine.Name(), "the composition takes precedence over the engine")
+
+	_, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+		EngineComposition: &llmdVariantAutoscalingV1alpha1.EngineComposition{
+			Engines: []string{"queueing", SaturationEngineName},
+		},
+	}))
+	assert.Error(t, err)
+
+	_, err = ForVariant(va(llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+		EngineComposition: &llmdVariantAutoscalingV1alpha1.EngineComposition{
+			Engines: []string{"queueing", "unknown"},
+		},
+	}))
+	assert.Error(t, err)
+}
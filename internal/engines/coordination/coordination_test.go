@@ -0,0 +1,75 @@
+package coordination
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestQuotaSplit(t *testing.T) {
+	held, err := NewQuotaSplit(0.5).Reserve(context.Background(),
+		map[string]int{"H100": 8, "A100": 5}, nil, map[string]int{"H100": 8})
+	require.NoError(t, err)
+	// the quota is rounded down
+	assert.Equal(t, map[string]int{"H100": 4, "A100": 3}, held)
+}
+
+func TestGPUPoolLease(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().Build()
+	now := time.Now().Truncate(time.Second)
+	clock := func() time.Time { return now }
+
+	a := NewGPUPoolLease(k8sClient, k8sClient, "wva-system", "a", time.Minute)
+	a.clock = clock
+	b := NewGPUPoolLease(k8sClient, k8sClient, "wva-system", "", time.Minute)
+	b.clock = clock
+	limits := map[string]int{"H100": 8}
+
+	// the first instance gets the free GPUs it requests
+	held, err := a.Reserve(ctx, limits, map[string]int{"H100": 2}, map[string]int{"H100": 4})
+	require.NoError(t, err)
+	assert.Empty(t, held)
+
+	// the second sees them held and reserves what is left
+	held, err = b.Reserve(ctx, limits, nil, map[string]int{"H100": 4})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"H100": 6}, held)
+	holdings := loadHoldings(t, k8sClient)
+	assert.Equal(t, map[string]int{"H100": 2}, holdings[DefaultInstance].Granted)
+
+	// the first is granted less than it reserved
+	require.NoError(t, a.Commit(ctx, map[string]int{"H100": 2}, map[string]int{"H100": 1}))
+	held, err = b.Reserve(ctx, limits, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"H100": 3}, held)
+
+	// the GPUs of an instance that stopped renewing are freed
+	now = now.Add(2 * time.Minute)
+	held, err = b.Reserve(ctx, limits, nil, map[string]int{"H100": 8})
+	require.NoError(t, err)
+	assert.Empty(t, held)
+	holdings = loadHoldings(t, k8sClient)
+	assert.NotContains(t, holdings, "a")
+	assert.Equal(t, map[string]int{"H100": 8}, holdings[DefaultInstance].Granted)
+}
+
+func loadHoldings(t *testing.T, c client.Client) map[string]Holding {
+	t.Helper()
+	var cm corev1.ConfigMap
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "wva-system", Name: LeaseConfigMapName}, &cm))
+	holdings := make(map[string]Holding, len(cm.Data))
+	for instance, raw := range cm.Data {
+		var h Holding
+		require.NoError(t, json.Unmarshal([]byte(raw), &h))
+		holdings[instance] = h
+	}
+	return holdings
+}
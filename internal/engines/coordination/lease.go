@@ -0,0 +1,170 @@
+package coordination
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+)
+
+const (
+	// LeaseConfigMapName is the name of the ConfigMap of the GPU pool lease.
+	LeaseConfigMapName = "wva-gpu-pool-lease"
+	// DefaultInstance is the key of the controller instance without CONTROLLER_INSTANCE.
+	DefaultInstance = "default"
+)
+
+// Holding is the entry of a controller instance in the lease: the GPUs it uses and the
+// ones granted to it, per accelerator type.
+type Holding struct {
+	// RenewTime is the last time the instance recorded its GPUs.
+	RenewTime time.Time `json:"renewTime"`
+	// Used are the GPUs of the current replicas of the instance.
+	Used map[string]int `json:"used,omitempty"`
+	// Granted are the GPUs reserved or allocated for the scale-ups of the instance.
+	Granted map[string]int `json:"granted,omitempty"`
+}
+
+// GPUPoolLease is a PoolCoordinator sharing the GPU pools through a ConfigMap with one
+// data key per controller instance. The GPUs of the other instances are held as long as
+// they renew their entry within the lease duration, so the GPUs of a stopped instance are
+// freed once it expires. Updates are compare-and-swap on the ConfigMap, so concurrent
+// reservations of two instances never grant the same GPUs.
+type GPUPoolLease struct {
+	reader    client.Reader
+	writer    client.Client
+	namespace string
+	instance  string
+	duration  time.Duration
+	clock     func() time.Time
+}
+
+// NewGPUPoolLease creates a lease in the ConfigMap LeaseConfigMapName of the namespace
+// for the given controller instance. The ConfigMap is read with the reader, typically
+// uncached since the namespace may be outside of the manager cache.
+func NewGPUPoolLease(reader client.Reader, writer client.Client, namespace, instance string, duration time.Duration) *GPUPoolLease {
+	if instance == "" {
+		instance = DefaultInstance
+	}
+	return &GPUPoolLease{
+		reader:    reader,
+		writer:    writer,
+		namespace: namespace,
+		instance:  instance,
+		duration:  duration,
+		clock:     time.Now,
+	}
+}
+
+// Name returns the coordinator identifier for logging/metrics.
+func (l *GPUPoolLease) Name() string {
+	return "gpu-pool-lease"
+}
+
+// Reserve returns the GPUs used and granted by the other live instances, and records the
+// GPUs used by this instance together with the requested ones still free in the pools.
+func (l *GPUPoolLease) Reserve(ctx context.Context, limits, used, requested map[string]int) (map[string]int, error) {
+	var held map[string]int
+	err := l.update(ctx, func(holdings map[string]Holding) Holding {
+		held = make(map[string]int)
+		for _, h := range holdings {
+			for accType, gpus := range h.Used {
+				held[accType] += gpus
+			}
+			for accType, gpus := range h.Granted {
+				held[accType] += gpus
+			}
+		}
+		reserved := make(map[string]int, len(requested))
+		for accType, gpus := range requested {
+			if free := limits[accType] - used[accType] - held[accType]; free > 0 {
+				reserved[accType] = min(gpus, free)
+			}
+		}
+		return Holding{Used: used, Granted: reserved}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return held, nil
+}
+
+// Commit records the GPUs used by this instance and the ones granted by the allocation,
+// replacing the ones reserved.
+func (l *GPUPoolLease) Commit(ctx context.Context, used, granted map[string]int) error {
+	return l.update(ctx, func(map[string]Holding) Holding {
+		return Holding{Used: used, Granted: granted}
+	})
+}
+
+// update sets the entry of this instance to the one returned by entry, given the live
+// entries of the other instances. Expired entries are removed. Retried on conflict.
+func (l *GPUPoolLease) update(ctx context.Context, entry func(others map[string]Holding) Holding) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var cm corev1.ConfigMap
+		err := l.reader.Get(ctx, client.ObjectKey{Namespace: l.namespace, Name: LeaseConfigMapName}, &cm)
+		found := err == nil
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get GPU pool lease ConfigMap %s/%s: %w", l.namespace, LeaseConfigMapName, err)
+		}
+
+		now := l.clock()
+		others := make(map[string]Holding)
+		data := make(map[string]string, len(cm.Data)+1)
+		for instance, raw := range cm.Data {
+			if instance == l.instance {
+				continue
+			}
+			var h Holding
+			// entries that cannot be decoded are dropped like expired ones
+			if err := json.Unmarshal([]byte(raw), &h); err != nil || now.Sub(h.RenewTime) > l.duration {
+				continue
+			}
+			others[instance] = h
+			data[instance] = raw
+		}
+
+		h := entry(others)
+		h.RenewTime = now
+		raw, err := json.Marshal(h)
+		if err != nil {
+			return fmt.Errorf("failed to encode GPU pool lease entry: %w", err)
+		}
+		data[l.instance] = string(raw)
+
+		if !found {
+			cm = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: l.namespace, Name: LeaseConfigMapName},
+				Data:       data,
+			}
+			if err := l.writer.Create(ctx, &cm); err != nil {
+				if apierrors.IsAlreadyExists(err) {
+					// created by another instance meanwhile: retry as an update
+					return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, LeaseConfigMapName, err)
+				}
+				return fmt.Errorf("failed to create GPU pool lease ConfigMap %s/%s: %w", l.namespace, LeaseConfigMapName, err)
+			}
+			return nil
+		}
+
+		cm.Data = data
+		if err := l.writer.Update(ctx, &cm); err != nil {
+			if apierrors.IsConflict(err) {
+				return err
+			}
+			return fmt.Errorf("failed to update GPU pool lease ConfigMap %s/%s: %w", l.namespace, LeaseConfigMapName, err)
+		}
+		return nil
+	})
+}
+
+var _ pipeline.PoolCoordinator = (*GPUPoolLease)(nil)
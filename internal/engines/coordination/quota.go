@@ -0,0 +1,50 @@
+// Package coordination shares the GPU pools of a cluster between the controller
+// instances (CONTROLLER_INSTANCE) whose variants allocate from the same pools, so that
+// in limited mode they do not each allocate the same free GPUs.
+//
+// Two coordinators are provided:
+//   - QuotaSplit statically splits each pool: every instance allocates from its own
+//     fraction of the GPUs, with no communication between instances
+//   - GPUPoolLease records the GPUs used and granted by each instance in a ConfigMap
+//     shared by the instances, and counts those of the other instances as used
+package coordination
+
+import (
+	"context"
+	"math"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+)
+
+// QuotaSplit is a PoolCoordinator allocating from a fixed fraction of each GPU pool. The
+// rest of the pool is held for the other instances. Instances sharing pools are given
+// quotas adding up to at most 1.
+type QuotaSplit struct {
+	quota float64
+}
+
+// NewQuotaSplit creates a QuotaSplit allocating from the given fraction (0.0-1.0] of each pool.
+func NewQuotaSplit(quota float64) *QuotaSplit {
+	return &QuotaSplit{quota: quota}
+}
+
+// Name returns the coordinator identifier for logging/metrics.
+func (q *QuotaSplit) Name() string {
+	return "quota-split"
+}
+
+// Reserve returns the GPUs of each pool outside of the quota as held by the other instances.
+func (q *QuotaSplit) Reserve(_ context.Context, limits, _, _ map[string]int) (map[string]int, error) {
+	held := make(map[string]int, len(limits))
+	for accType, limit := range limits {
+		held[accType] = limit - int(math.Floor(float64(limit)*q.quota))
+	}
+	return held, nil
+}
+
+// Commit does nothing: quotas do not depend on what the instances allocate.
+func (q *QuotaSplit) Commit(context.Context, map[string]int, map[string]int) error {
+	return nil
+}
+
+var _ pipeline.PoolCoordinator = (*QuotaSplit)(nil)
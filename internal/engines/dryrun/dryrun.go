@@ -0,0 +1,312 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+)
+
+// Path is the path of the endpoint on the metrics server.
+const Path = "/validate/saturation-config"
+
+const (
+	// defaultWindow is the window replayed when the hours query parameter is not set
+	defaultWindow = time.Hour
+	// maxDifferences is the number of differences reported per model, the most recent ones
+	maxDifferences = 50
+	// maxBodySize is the largest candidate ConfigMap accepted
+	maxBodySize = 1 << 20
+)
+
+// Report is the response of the endpoint.
+type Report struct {
+	// GeneratedAt is when the report was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Window is how far back the samples were replayed
+	Window string `json:"window"`
+	// InvalidEntries are the entries of the candidate ConfigMap that failed to parse or
+	// validate, with their error; the controller would ignore them
+	InvalidEntries map[string]string `json:"invalidEntries,omitempty"`
+	// Models are the replays of the models, sorted by namespace and model ID
+	Models []ModelReport `json:"models"`
+}
+
+// ModelReport compares the decisions of a model under the current and the candidate
+// configuration.
+type ModelReport struct {
+	ModelID   string `json:"modelID"`
+	Namespace string `json:"namespace"`
+	// Samples is the number of optimization runs replayed, and FailedSamples those the
+	// analysis failed on under either configuration
+	Samples       int `json:"samples"`
+	FailedSamples int `json:"failedSamples,omitempty"`
+	// Decisions is the number of variant decisions replayed, and Changed those whose
+	// target differs under the candidate configuration
+	Decisions int `json:"decisions"`
+	Changed   int `json:"changed"`
+	// Current and Candidate count the decisions by action under each configuration
+	Current   ActionCounts `json:"current"`
+	Candidate ActionCounts `json:"candidate"`
+	// Differences are the most recent decisions whose target differs
+	Differences []Difference `json:"differences,omitempty"`
+	// Error is set when the model could not be replayed
+	Error string `json:"error,omitempty"`
+}
+
+// ActionCounts counts decisions by scaling action.
+type ActionCounts struct {
+	ScaleUp   int `json:"scaleUp"`
+	ScaleDown int `json:"scaleDown"`
+	NoChange  int `json:"noChange"`
+}
+
+// add counts the action of a decision from current to target replicas
+func (c *ActionCounts) add(current, target int) {
+	switch {
+	case target > current:
+		c.ScaleUp++
+	case target < current:
+		c.ScaleDown++
+	default:
+		c.NoChange++
+	}
+}
+
+// Difference is a decision whose target differs under the candidate configuration.
+type Difference struct {
+	Time            time.Time `json:"time"`
+	Variant         string    `json:"variant"`
+	CurrentReplicas int       `json:"currentReplicas"`
+	CurrentTarget   int       `json:"currentTarget"`
+	CandidateTarget int       `json:"candidateTarget"`
+}
+
+// configMap is the part of a ConfigMap the endpoint reads, in YAML or JSON
+type configMap struct {
+	Data map[string]string `yaml:"data"`
+}
+
+// Handler replays the candidate saturation scaling ConfigMap in the body of POST requests
+// over the samples of history, next to the configuration in effect in cfg. The hours query
+// parameter sets how many hours are replayed (1 by default, at most the retention of
+// history), and the namespace and modelID query parameters restrict the replayed models.
+// The "default" entry of the candidate replaces the effective one of every replayed model.
+func Handler(history *History, cfg *config.Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "POST a saturation scaling ConfigMap", http.StatusMethodNotAllowed)
+			return
+		}
+
+		window := defaultWindow
+		if s := r.URL.Query().Get("hours"); s != "" {
+			hours, err := strconv.ParseFloat(s, 64)
+			if err != nil || hours <= 0 {
+				http.Error(w, fmt.Sprintf("invalid hours %q: must be a positive number", s), http.StatusBadRequest)
+				return
+			}
+			window = time.Duration(hours * float64(time.Hour))
+		}
+		window = min(window, history.Retention())
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err != nil {
+			http.Error(w, "failed to read the candidate ConfigMap", http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxBodySize {
+			http.Error(w, "candidate ConfigMap too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		var cm configMap
+		if err := yaml.Unmarshal(body, &cm); err != nil {
+			http.Error(w, fmt.Sprintf("invalid ConfigMap: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		report := Report{
+			GeneratedAt: time.Now().UTC(),
+			Window:      window.String(),
+			Models:      []ModelReport{},
+		}
+		candidates, invalid := parseEntries(cm.Data)
+		if len(invalid) > 0 {
+			report.InvalidEntries = invalid
+		}
+		candidate, ok := candidates["default"]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusUnprocessableEntity
+		} else {
+			namespace := r.URL.Query().Get("namespace")
+			modelID := r.URL.Query().Get("modelID")
+			// The analyzers log every decision
+			ctx := ctrl.LoggerInto(r.Context(), logr.Discard())
+			for model, samples := range history.Since(time.Now().Add(-window)) {
+				if (namespace != "" && model.Namespace != namespace) || (modelID != "" && model.ModelID != modelID) {
+					continue
+				}
+				current, ok := cfg.SaturationConfigForNamespace(model.Namespace)["default"]
+				if !ok {
+					report.Models = append(report.Models, ModelReport{
+						ModelID:   model.ModelID,
+						Namespace: model.Namespace,
+						Error:     "no default saturation scaling config in effect",
+					})
+					continue
+				}
+				report.Models = append(report.Models, Replay(ctx, model, samples, current, candidate))
+			}
+		}
+		sort.Slice(report.Models, func(i, j int) bool {
+			a, b := report.Models[i], report.Models[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.ModelID < b.ModelID
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(report)
+	})
+}
+
+// parseEntries parses and validates the entries of a saturation scaling ConfigMap like the
+// controller does, and returns the valid entries and the errors of the invalid ones.
+func parseEntries(data map[string]string) (map[string]interfaces.SaturationScalingConfig, map[string]string) {
+	valid := make(map[string]interfaces.SaturationScalingConfig, len(data))
+	invalid := make(map[string]string)
+	for key, yamlStr := range data {
+		var entry interfaces.SaturationScalingConfig
+		if err := yaml.Unmarshal([]byte(yamlStr), &entry); err != nil {
+			invalid[key] = fmt.Sprintf("failed to parse: %v", err)
+			continue
+		}
+		if err := entry.Validate(); err != nil {
+			invalid[key] = err.Error()
+			continue
+		}
+		valid[key] = entry
+	}
+	return valid, invalid
+}
+
+// Replay replays the samples of a model with the analyzer selected by each of the current
+// and candidate configurations, and compares the targets of the variants. Replays are
+// open-loop: the replicas of later samples are the recorded ones, whatever was decided.
+func Replay(ctx context.Context, model Model, samples []Sample, current, candidate interfaces.SaturationScalingConfig) ModelReport {
+	report := ModelReport{
+		ModelID:   model.ModelID,
+		Namespace: model.Namespace,
+	}
+	current.ApplyDefaults()
+	candidate.ApplyDefaults()
+	currentTargets := newTargeter(current)
+	candidateTargets := newTargeter(candidate)
+
+	for _, sample := range samples {
+		report.Samples++
+		before, err := currentTargets(ctx, model, sample)
+		if err != nil {
+			report.FailedSamples++
+			continue
+		}
+		after, err := candidateTargets(ctx, model, sample)
+		if err != nil {
+			report.FailedSamples++
+			continue
+		}
+		for _, state := range sample.VariantStates {
+			currentTarget, ok := before[state.VariantName]
+			if !ok {
+				currentTarget = state.CurrentReplicas
+			}
+			candidateTarget, ok := after[state.VariantName]
+			if !ok {
+				candidateTarget = state.CurrentReplicas
+			}
+			report.Decisions++
+			report.Current.add(state.CurrentReplicas, currentTarget)
+			report.Candidate.add(state.CurrentReplicas, candidateTarget)
+			if currentTarget == candidateTarget {
+				continue
+			}
+			report.Changed++
+			report.Differences = append(report.Differences, Difference{
+				Time:            sample.Time,
+				Variant:         state.VariantName,
+				CurrentReplicas: state.CurrentReplicas,
+				CurrentTarget:   currentTarget,
+				CandidateTarget: candidateTarget,
+			})
+		}
+	}
+	if len(report.Differences) > maxDifferences {
+		report.Differences = report.Differences[len(report.Differences)-maxDifferences:]
+	}
+	return report
+}
+
+// targeter returns the target replicas of the variants of a model for a sample
+type targeter func(ctx context.Context, model Model, sample Sample) (map[string]int, error)
+
+// newTargeter returns a targeter with the analyzer selected by satConfig: the V2 token-based
+// analyzer with the cost-aware optimizer, or the V1 percentage-based analyzer. The V2
+// analyzer keeps its capacity history across the samples of a replay.
+func newTargeter(satConfig interfaces.SaturationScalingConfig) targeter {
+	if satConfig.GetAnalyzerName() == "saturation" {
+		analyzer := saturation_v2.NewSaturationAnalyzer(saturation_v2.NewCapacityKnowledgeStore())
+		optimizer := pipeline.NewCostAwareOptimizer()
+		return func(ctx context.Context, model Model, sample Sample) (map[string]int, error) {
+			result, err := analyzer.Analyze(ctx, interfaces.AnalyzerInput{
+				ModelID:        model.ModelID,
+				Namespace:      model.Namespace,
+				ReplicaMetrics: sample.ReplicaMetrics,
+				VariantStates:  sample.VariantStates,
+				Config:         &satConfig,
+			})
+			if err != nil {
+				return nil, err
+			}
+			decisions := optimizer.Optimize(ctx, []pipeline.ModelScalingRequest{{
+				ModelID:       model.ModelID,
+				Namespace:     model.Namespace,
+				Result:        result,
+				VariantStates: sample.VariantStates,
+			}}, nil)
+			targets := make(map[string]int, len(decisions))
+			for _, d := range decisions {
+				targets[d.VariantName] = d.TargetReplicas
+			}
+			return targets, nil
+		}
+	}
+
+	return func(ctx context.Context, model Model, sample Sample) (map[string]int, error) {
+		analyzer := saturation.NewAnalyzer()
+		analysis, err := analyzer.AnalyzeModelSaturation(ctx, model.ModelID, model.Namespace, sample.ReplicaMetrics, satConfig)
+		if err != nil {
+			return nil, err
+		}
+		return analyzer.CalculateSaturationTargets(ctx, analysis, sample.VariantStates), nil
+	}
+}
@@ -0,0 +1,195 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var llama = Model{ModelID: "meta/llama", Namespace: "prod"}
+
+// sample returns the inputs of a run of a variant with 2 replicas at kvCacheUsage
+func sample(kvCacheUsage float64) ([]interfaces.ReplicaMetrics, []interfaces.VariantReplicaState) {
+	var metrics []interfaces.ReplicaMetrics
+	for _, pod := range []string{"llama-0", "llama-1"} {
+		metrics = append(metrics, interfaces.ReplicaMetrics{
+			PodName:         pod,
+			VariantName:     "llama-h100",
+			Namespace:       llama.Namespace,
+			ModelID:         llama.ModelID,
+			AcceleratorName: "H100",
+			Cost:            10,
+			KvCacheUsage:    kvCacheUsage,
+		})
+	}
+	states := []interfaces.VariantReplicaState{{
+		VariantName:       "llama-h100",
+		CurrentReplicas:   2,
+		GPUsPerReplica:    1,
+		ReportingReplicas: 2,
+	}}
+	return metrics, states
+}
+
+func defaultConfig() interfaces.SaturationScalingConfig {
+	return interfaces.SaturationScalingConfig{
+		KvCacheThreshold:     0.80,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.10,
+		QueueSpareTrigger:    3,
+	}
+}
+
+func TestHistory_Retention(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := NewHistory(time.Hour)
+	history.now = func() time.Time { return now }
+
+	metrics, states := sample(0.5)
+	history.Record(llama.ModelID, llama.Namespace, metrics, states)
+	now = now.Add(30 * time.Minute)
+	history.Record(llama.ModelID, llama.Namespace, metrics, states)
+	history.Record("other", "dev", metrics, states)
+
+	assert.Len(t, history.Since(now.Add(-time.Hour))[llama], 2)
+	assert.Len(t, history.Since(now.Add(-time.Minute))[llama], 1)
+
+	// Samples older than the retention are dropped
+	now = now.Add(45 * time.Minute)
+	history.Record("other", "dev", metrics, states)
+	recent := history.Since(time.Time{})
+	assert.Len(t, recent[llama], 1)
+	assert.Len(t, recent[Model{ModelID: "other", Namespace: "dev"}], 2)
+
+	now = now.Add(2 * time.Hour)
+	history.Record("other", "dev", metrics, states)
+	assert.NotContains(t, history.Since(time.Time{}), llama)
+}
+
+func TestHistory_ForgetModel(t *testing.T) {
+	history := NewHistory(time.Hour)
+	metrics, states := sample(0.5)
+	history.Record(llama.ModelID, llama.Namespace, metrics, states)
+	history.Record("other", "dev", metrics, states)
+
+	history.ForgetModel(llama.ModelID, llama.Namespace)
+	recent := history.Since(time.Time{})
+	assert.NotContains(t, recent, llama)
+	assert.Contains(t, recent, Model{ModelID: "other", Namespace: "dev"})
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	var samples []Sample
+	for i, usage := range []float64{0.5, 0.75, 0.75} {
+		metrics, states := sample(usage)
+		samples = append(samples, Sample{
+			Time:           time.Date(2026, 1, 1, 0, i, 0, 0, time.UTC),
+			ReplicaMetrics: metrics,
+			VariantStates:  states,
+		})
+	}
+
+	// The same configuration decides the same
+	report := Replay(ctx, llama, samples, defaultConfig(), defaultConfig())
+	assert.Equal(t, 3, report.Samples)
+	assert.Equal(t, 3, report.Decisions)
+	assert.Zero(t, report.Changed)
+	assert.Equal(t, report.Current, report.Candidate)
+
+	// A higher threshold leaves spare capacity at 75% KV cache usage
+	candidate := defaultConfig()
+	candidate.KvCacheThreshold = 0.90
+	report = Replay(ctx, llama, samples, defaultConfig(), candidate)
+	assert.Equal(t, 2, report.Current.ScaleUp)
+	assert.Zero(t, report.Candidate.ScaleUp)
+	assert.Equal(t, 2, report.Changed)
+	require.Len(t, report.Differences, 2)
+	assert.Equal(t, Difference{
+		Time:            samples[1].Time,
+		Variant:         "llama-h100",
+		CurrentReplicas: 2,
+		CurrentTarget:   3,
+		CandidateTarget: 2,
+	}, report.Differences[0])
+}
+
+func post(t *testing.T, handler http.Handler, target, body string) (*httptest.ResponseRecorder, Report) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+	var report Report
+	if rec.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	}
+	return rec, report
+}
+
+func TestHandler(t *testing.T) {
+	cfg := config.NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]interfaces.SaturationScalingConfig{"default": defaultConfig()})
+	history := NewHistory(6 * time.Hour)
+	metrics, states := sample(0.75)
+	history.Record(llama.ModelID, llama.Namespace, metrics, states)
+	history.Record("other", "dev", nil, nil)
+	handler := Handler(history, cfg)
+
+	candidate := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: saturation-scaling-config
+data:
+  default: |
+    kvCacheThreshold: 0.90
+    queueLengthThreshold: 5
+    kvSpareTrigger: 0.10
+    queueSpareTrigger: 3
+  broken: |
+    kvCacheThreshold: 2
+`
+
+	t.Run("reports the differences of the candidate", func(t *testing.T) {
+		rec, report := post(t, handler, Path+"?hours=2&namespace=prod", candidate)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2h0m0s", report.Window)
+		assert.Contains(t, report.InvalidEntries, "broken")
+		require.Len(t, report.Models, 1)
+		assert.Equal(t, "meta/llama", report.Models[0].ModelID)
+		assert.Equal(t, 1, report.Models[0].Changed)
+	})
+
+	t.Run("caps the window at the retention", func(t *testing.T) {
+		_, report := post(t, handler, Path+"?hours=24", candidate)
+		assert.Equal(t, "6h0m0s", report.Window)
+		assert.Len(t, report.Models, 2)
+	})
+
+	t.Run("rejects a candidate without a valid default entry", func(t *testing.T) {
+		rec, report := post(t, handler, Path, "data:\n  default: |\n    kvCacheThreshold: 2\n")
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, report.InvalidEntries, "default")
+		assert.Empty(t, report.Models)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+		rec, _ = post(t, handler, Path+"?hours=-1", candidate)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec, _ = post(t, handler, Path, "data: [")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
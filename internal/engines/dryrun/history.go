@@ -0,0 +1,116 @@
+// Package dryrun validates candidate saturation scaling ConfigMaps before they are applied.
+//
+// The engine records in a History the metrics and replica states of the models it analyzes
+// in each optimization run. A candidate ConfigMap posted to the endpoint is replayed over the
+// recent History with the analyzer it selects, next to the configuration in effect, and the
+// response reports how the decisions would have differed, de-risking threshold changes.
+package dryrun
+
+import (
+	"sync"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// Sample is the input of the analysis of a model in one optimization run.
+type Sample struct {
+	// Time is when the metrics were collected
+	Time time.Time
+	// ReplicaMetrics are the metrics of the replicas of the variants of the model
+	ReplicaMetrics []interfaces.ReplicaMetrics
+	// VariantStates are the replica states of the variants of the model
+	VariantStates []interfaces.VariantReplicaState
+}
+
+// Model identifies a model.
+type Model struct {
+	ModelID   string
+	Namespace string
+}
+
+// History keeps the samples of the models analyzed over a retention period.
+// It is safe for concurrent use.
+type History struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	models  map[string]Model
+	samples map[string][]Sample
+}
+
+// NewHistory returns a History keeping the samples of the last retention.
+func NewHistory(retention time.Duration) *History {
+	return &History{
+		retention: retention,
+		now:       time.Now,
+		models:    make(map[string]Model),
+		samples:   make(map[string][]Sample),
+	}
+}
+
+// Retention returns how long samples are kept.
+func (h *History) Retention() time.Duration {
+	return h.retention
+}
+
+// Record adds a sample of a model collected now, and drops the samples of all models
+// older than the retention.
+func (h *History) Record(modelID, namespace string, replicaMetrics []interfaces.ReplicaMetrics, variantStates []interfaces.VariantReplicaState) {
+	now := h.now()
+	sample := Sample{
+		Time:           now,
+		ReplicaMetrics: append([]interfaces.ReplicaMetrics(nil), replicaMetrics...),
+		VariantStates:  append([]interfaces.VariantReplicaState(nil), variantStates...),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := utils.GetNamespacedKey(namespace, modelID)
+	h.models[key] = Model{ModelID: modelID, Namespace: namespace}
+	h.samples[key] = append(h.samples[key], sample)
+
+	cutoff := now.Add(-h.retention)
+	for key, samples := range h.samples {
+		i := 0
+		for i < len(samples) && samples[i].Time.Before(cutoff) {
+			i++
+		}
+		if i == len(samples) {
+			delete(h.samples, key)
+			delete(h.models, key)
+			continue
+		}
+		h.samples[key] = samples[i:]
+	}
+}
+
+// ForgetModel drops the samples of a model no longer referenced by a VA.
+func (h *History) ForgetModel(modelID, namespace string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := utils.GetNamespacedKey(namespace, modelID)
+	delete(h.samples, key)
+	delete(h.models, key)
+}
+
+// Since returns the samples of each model collected since a time, in time order.
+func (h *History) Since(since time.Time) map[Model][]Sample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := make(map[Model][]Sample, len(h.samples))
+	for key, samples := range h.samples {
+		var recent []Sample
+		for _, sample := range samples {
+			if !sample.Time.Before(since) {
+				recent = append(recent, sample)
+			}
+		}
+		if len(recent) > 0 {
+			result[h.models[key]] = recent
+		}
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+// Package engines defines the plugin interface for scaling engines.
+//
+// The built-in saturation engine analyzes all VariantAutoscalings that do not select
+// another engine. Custom engines implement Engine, register themselves with Register
+// from an init function of a package imported by the controller binary, and are
+// selected per VariantAutoscaling with spec.engine. The interface and registry are those
+// of the public engine API in pkg/api/engines, which out-of-tree engines build against.
+package engines
+
+import (
+	apiengines "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/api/engines"
+)
+
+// Names reserved for the engines of the controller.
+const (
+	SaturationEngineName = apiengines.SaturationEngineName
+	CompositeEngineName  = apiengines.CompositeEngineName
+)
+
+// Engine computes the scaling decision of a single VariantAutoscaling.
+type Engine = apiengines.Engine
+
+// VariantDecision is the scaling decision an engine recommends for a VariantAutoscaling.
+type VariantDecision = apiengines.VariantDecision
+
+// Register adds a custom engine. Panics if the name is empty, reserved for the
+// saturation or composite engines, or already registered.
+func Register(engine Engine) {
+	apiengines.Register(engine)
+}
+
+// Get returns the custom engine registered with the given name.
+func Get(name string) (Engine, bool) {
+	return apiengines.Get(name)
+}
+
+// Names returns the names of the registered custom engines, sorted.
+func Names() []string {
+	return apiengines.Names()
+}
@@ -0,0 +1,28 @@
+package engines
+
+import (
+	"context"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+type fakeEngine struct {
+	name     string
+	decision *VariantDecision
+	err      error
+}
+
+func (f *fakeEngine) Name() string { return f.name }
+
+func (f *fakeEngine) AnalyzeAndRecommend(context.Context, *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, []interfaces.ReplicaMetrics) (*VariantDecision, error) {
+	return f.decision, f.err
+}
+
+// register registers an engine unless an engine of its name is, as the registry is
+// shared by the tests of the package
+func register(engine Engine) {
+	if _, ok := Get(engine.Name()); !ok {
+		Register(engine)
+	}
+}
@@ -0,0 +1,263 @@
+// Package heatmap exports the saturation of each replica over time, as a matrix that a
+// Grafana heatmap panel can render with a replica per row and a run per column, making
+// replicas left idle or saturated by skewed routing visible at a glance.
+//
+// The engine records in a Matrix the metrics of the replicas of the models it analyzes in
+// each optimization run, over a bounded window. The endpoint serves the matrix of each model.
+package heatmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// Path is the path of the endpoint on the metrics server.
+const Path = "/saturation/heatmap"
+
+// Metric selects the saturation signal of the cells of the matrix.
+type Metric string
+
+const (
+	// MetricKvCacheUsage is the KV cache utilization of the replica (0.0-1.0).
+	MetricKvCacheUsage Metric = "kvCacheUsage"
+	// MetricQueueLength is the number of requests waiting on the replica.
+	MetricQueueLength Metric = "queueLength"
+)
+
+// cell is the saturation of a replica in one run.
+type cell struct {
+	kvCacheUsage float64
+	queueLength  int
+}
+
+// column is the saturation of the replicas of a model in one run.
+type column struct {
+	time time.Time
+	// cells is keyed by the replica
+	cells map[Replica]cell
+}
+
+// Model identifies a model.
+type Model struct {
+	ModelID   string `json:"modelID"`
+	Namespace string `json:"namespace"`
+}
+
+// Replica identifies a replica of a model.
+type Replica struct {
+	Pod     string `json:"pod"`
+	Variant string `json:"variant"`
+}
+
+// Matrix keeps the saturation of the replicas of the models analyzed over a window.
+// It is safe for concurrent use.
+type Matrix struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	models  map[string]Model
+	columns map[string][]column
+}
+
+// NewMatrix returns a Matrix keeping the saturation of the last window.
+func NewMatrix(window time.Duration) *Matrix {
+	return &Matrix{
+		window:  window,
+		now:     time.Now,
+		models:  make(map[string]Model),
+		columns: make(map[string][]column),
+	}
+}
+
+// Window returns how long the saturation of the replicas is kept.
+func (m *Matrix) Window() time.Duration {
+	return m.window
+}
+
+// Record adds the saturation of the replicas of a model collected now, and drops the
+// saturation of all models older than the window.
+func (m *Matrix) Record(modelID, namespace string, replicaMetrics []interfaces.ReplicaMetrics) {
+	now := m.now()
+	col := column{time: now, cells: make(map[Replica]cell, len(replicaMetrics))}
+	for _, rm := range replicaMetrics {
+		col.cells[Replica{Pod: rm.PodName, Variant: rm.VariantName}] = cell{
+			kvCacheUsage: rm.KvCacheUsage,
+			queueLength:  rm.QueueLength,
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := utils.GetNamespacedKey(namespace, modelID)
+	m.models[key] = Model{ModelID: modelID, Namespace: namespace}
+	m.columns[key] = append(m.columns[key], col)
+
+	cutoff := now.Add(-m.window)
+	for key, columns := range m.columns {
+		i := 0
+		for i < len(columns) && columns[i].time.Before(cutoff) {
+			i++
+		}
+		if i == len(columns) {
+			delete(m.columns, key)
+			delete(m.models, key)
+			continue
+		}
+		m.columns[key] = columns[i:]
+	}
+}
+
+// ForgetModel drops the saturation of a model no longer referenced by a VA.
+func (m *Matrix) ForgetModel(modelID, namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := utils.GetNamespacedKey(namespace, modelID)
+	delete(m.columns, key)
+	delete(m.models, key)
+}
+
+// Document is the response of the endpoint.
+type Document struct {
+	// GeneratedAt is when the document was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Window is how far back the matrices go
+	Window string `json:"window"`
+	// Metric is the saturation signal of the cells
+	Metric Metric `json:"metric"`
+	// Models are the matrices of the models, sorted by namespace and model ID
+	Models []ModelMatrix `json:"models"`
+}
+
+// ModelMatrix is the saturation of the replicas of a model over time.
+type ModelMatrix struct {
+	Model
+	// Times are the times of the runs, the columns of the matrix, in time order
+	Times []time.Time `json:"times"`
+	// Replicas are the rows of the matrix, sorted by variant and pod
+	Replicas []ReplicaRow `json:"replicas"`
+}
+
+// ReplicaRow is the saturation of a replica in each run.
+type ReplicaRow struct {
+	Replica
+	// Values are aligned with the times of the matrix; null for the runs the replica
+	// did not report in
+	Values []*float64 `json:"values"`
+}
+
+// Since returns the matrix of each model since a time, with the cells of a metric.
+func (m *Matrix) Since(since time.Time, metric Metric) []ModelMatrix {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]ModelMatrix, 0, len(m.columns))
+	for key, columns := range m.columns {
+		var recent []column
+		for _, col := range columns {
+			if !col.time.Before(since) {
+				recent = append(recent, col)
+			}
+		}
+		if len(recent) > 0 {
+			result = append(result, buildMatrix(m.models[key], recent, metric))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.ModelID < b.ModelID
+	})
+	return result
+}
+
+// buildMatrix lays out the columns of a model as a row per replica.
+func buildMatrix(model Model, columns []column, metric Metric) ModelMatrix {
+	matrix := ModelMatrix{Model: model, Times: make([]time.Time, len(columns))}
+	rows := make(map[Replica]*ReplicaRow)
+	for i, col := range columns {
+		matrix.Times[i] = col.time.UTC()
+		for replica, c := range col.cells {
+			row, ok := rows[replica]
+			if !ok {
+				row = &ReplicaRow{Replica: replica, Values: make([]*float64, len(columns))}
+				rows[replica] = row
+			}
+			value := c.kvCacheUsage
+			if metric == MetricQueueLength {
+				value = float64(c.queueLength)
+			}
+			row.Values[i] = &value
+		}
+	}
+
+	matrix.Replicas = make([]ReplicaRow, 0, len(rows))
+	for _, row := range rows {
+		matrix.Replicas = append(matrix.Replicas, *row)
+	}
+	sort.Slice(matrix.Replicas, func(i, j int) bool {
+		a, b := matrix.Replicas[i], matrix.Replicas[j]
+		if a.Variant != b.Variant {
+			return a.Variant < b.Variant
+		}
+		return a.Pod < b.Pod
+	})
+	return matrix
+}
+
+// Handler serves the matrices of matrix. The minutes query parameter sets how far back they
+// go (the whole window by default, at most the window), the metric query parameter selects
+// the signal of the cells (kvCacheUsage by default, or queueLength), and the namespace and
+// modelID query parameters restrict the served models.
+func Handler(matrix *Matrix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		window := matrix.Window()
+		if s := r.URL.Query().Get("minutes"); s != "" {
+			minutes, err := strconv.ParseFloat(s, 64)
+			if err != nil || minutes <= 0 {
+				http.Error(w, fmt.Sprintf("invalid minutes %q: must be a positive number", s), http.StatusBadRequest)
+				return
+			}
+			window = min(time.Duration(minutes*float64(time.Minute)), window)
+		}
+
+		metric := MetricKvCacheUsage
+		if s := r.URL.Query().Get("metric"); s != "" {
+			metric = Metric(s)
+			if metric != MetricKvCacheUsage && metric != MetricQueueLength {
+				http.Error(w, fmt.Sprintf("invalid metric %q: must be %s or %s", s, MetricKvCacheUsage, MetricQueueLength),
+					http.StatusBadRequest)
+				return
+			}
+		}
+
+		doc := Document{
+			GeneratedAt: time.Now().UTC(),
+			Window:      window.String(),
+			Metric:      metric,
+			Models:      []ModelMatrix{},
+		}
+		namespace := r.URL.Query().Get("namespace")
+		modelID := r.URL.Query().Get("modelID")
+		for _, model := range matrix.Since(time.Now().Add(-window), metric) {
+			if (namespace != "" && model.Namespace != namespace) || (modelID != "" && model.ModelID != modelID) {
+				continue
+			}
+			doc.Models = append(doc.Models, model)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(doc)
+	})
+}
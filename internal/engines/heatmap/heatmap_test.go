@@ -0,0 +1,98 @@
+package heatmap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// replicas returns the metrics of the replicas of a model at a KV cache usage per pod
+func replicas(modelID string, kvCacheUsage map[string]float64) []interfaces.ReplicaMetrics {
+	var metrics []interfaces.ReplicaMetrics
+	for pod, usage := range kvCacheUsage {
+		metrics = append(metrics, interfaces.ReplicaMetrics{
+			PodName:      pod,
+			VariantName:  "llama-h100",
+			Namespace:    "prod",
+			ModelID:      modelID,
+			KvCacheUsage: usage,
+			QueueLength:  int(usage * 10),
+		})
+	}
+	return metrics
+}
+
+func TestMatrix(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	matrix := NewMatrix(time.Hour)
+	matrix.now = func() time.Time { return now }
+
+	matrix.Record("meta/llama", "prod", replicas("meta/llama", map[string]float64{"llama-0": 0.9, "llama-1": 0.1}))
+	now = now.Add(time.Minute)
+	matrix.Record("meta/llama", "prod", replicas("meta/llama", map[string]float64{"llama-0": 0.8, "llama-2": 0.2}))
+
+	models := matrix.Since(time.Time{}, MetricKvCacheUsage)
+	require.Len(t, models, 1)
+	model := models[0]
+	assert.Equal(t, Model{ModelID: "meta/llama", Namespace: "prod"}, model.Model)
+	assert.Len(t, model.Times, 2)
+	require.Len(t, model.Replicas, 3)
+
+	// Rows are sorted by pod, with no value for the runs the replica did not report in
+	assert.Equal(t, "llama-0", model.Replicas[0].Pod)
+	assert.InDelta(t, 0.9, *model.Replicas[0].Values[0], 1e-9)
+	assert.InDelta(t, 0.8, *model.Replicas[0].Values[1], 1e-9)
+	assert.Nil(t, model.Replicas[1].Values[1])
+	assert.Nil(t, model.Replicas[2].Values[0])
+
+	queue := matrix.Since(now, MetricQueueLength)
+	require.Len(t, queue, 1)
+	assert.Len(t, queue[0].Times, 1)
+	assert.InDelta(t, 8, *queue[0].Replicas[0].Values[0], 1e-9)
+
+	// Runs older than the window are dropped
+	now = now.Add(90 * time.Minute)
+	matrix.Record("other", "dev", replicas("other", map[string]float64{"other-0": 0.5}))
+	models = matrix.Since(time.Time{}, MetricKvCacheUsage)
+	require.Len(t, models, 1)
+	assert.Equal(t, "other", models[0].ModelID)
+}
+
+func TestMatrix_ForgetModel(t *testing.T) {
+	matrix := NewMatrix(time.Hour)
+	matrix.Record("meta/llama", "prod", replicas("meta/llama", map[string]float64{"llama-0": 0.9}))
+	matrix.Record("other", "dev", replicas("other", map[string]float64{"other-0": 0.5}))
+
+	matrix.ForgetModel("meta/llama", "prod")
+	models := matrix.Since(time.Time{}, MetricKvCacheUsage)
+	require.Len(t, models, 1)
+	assert.Equal(t, "other", models[0].ModelID)
+}
+
+func TestHandler(t *testing.T) {
+	matrix := NewMatrix(time.Hour)
+	matrix.Record("meta/llama", "prod", replicas("meta/llama", map[string]float64{"llama-0": 0.5}))
+	matrix.Record("granite", "prod", replicas("granite", map[string]float64{"granite-0": 0.5}))
+
+	rec := httptest.NewRecorder()
+	Handler(matrix).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?modelID=granite&metric=queueLength", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var doc Document
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, MetricQueueLength, doc.Metric)
+	require.Len(t, doc.Models, 1)
+	assert.Equal(t, "granite", doc.Models[0].ModelID)
+
+	for _, query := range []string{"?metric=ttft", "?minutes=0"} {
+		rec = httptest.NewRecorder()
+		Handler(matrix).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -0,0 +1,129 @@
+// Package overload signals back-pressure to the gateway. A model is overloaded when all
+// its variants are saturated and at their maxReplicas: scaling cannot absorb more load,
+// so requests only queue up. While a model is overloaded, the InferencePools serving it
+// are annotated, for the gateway to shed their load gracefully (e.g. with 429 responses)
+// instead of letting latency degrade for every request.
+package overload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
+)
+
+// +kubebuilder:rbac:groups=inference.networking.k8s.io;inference.networking.x-k8s.io,resources=inferencepools,verbs=patch
+
+// Variant is a variant served by the InferencePools selecting the labels of its pods.
+type Variant struct {
+	Namespace string
+	// PodLabels are the labels of the pod template of the scale target
+	PodLabels map[string]string
+	// Overloaded is whether the model of the variant is overloaded
+	Overloaded bool
+}
+
+// Signaler annotates the InferencePools serving overloaded models.
+type Signaler struct {
+	client  client.Client
+	pools   func() []*poolutil.EndpointPool
+	poolGVK schema.GroupVersionKind
+	// signaled is the signal last written on each pool
+	signaled map[types.NamespacedName]bool
+}
+
+// NewSignaler creates a Signaler patching the InferencePools of the API group poolGroup
+// with c. The pools and their selectors are listed with pools, typically from the
+// datastore.
+func NewSignaler(c client.Client, pools func() []*poolutil.EndpointPool, poolGroup string) *Signaler {
+	gv := v1alpha2.SchemeGroupVersion
+	if poolGroup == v1.GroupName {
+		gv = v1.SchemeGroupVersion
+	}
+	return &Signaler{
+		client:   c,
+		pools:    pools,
+		poolGVK:  gv.WithKind("InferencePool"),
+		signaled: make(map[types.NamespacedName]bool),
+	}
+}
+
+// Signal annotates the pools serving a variant of an overloaded model, and clears the
+// annotation of the other pools serving variants or signaled before. A pool is only
+// patched when its signal changes, or when first seen, so that a new leader clears the
+// signals of its predecessor. It returns the pools signaled overloaded.
+func (s *Signaler) Signal(ctx context.Context, variants []Variant) ([]types.NamespacedName, error) {
+	desired := make(map[types.NamespacedName]bool)
+	for _, pool := range s.pools() {
+		key := types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}
+		for _, v := range variants {
+			if v.Namespace != pool.Namespace || len(pool.Selector) == 0 || !poolutil.IsSubset(pool.Selector, v.PodLabels) {
+				continue
+			}
+			desired[key] = desired[key] || v.Overloaded
+		}
+	}
+	for key, overloaded := range s.signaled {
+		if _, ok := desired[key]; !ok && overloaded {
+			desired[key] = false
+		}
+	}
+
+	var overloaded []types.NamespacedName
+	var errs []error
+	for key, signal := range desired {
+		if last, ok := s.signaled[key]; !ok || last != signal {
+			if err := s.patch(ctx, key, signal); err != nil {
+				delete(s.signaled, key)
+				if !apierrors.IsNotFound(err) {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			s.signaled[key] = signal
+			ctrl.LoggerFrom(ctx).Info("Signaled InferencePool overload",
+				"pool", key.String(),
+				"overloaded", signal)
+		}
+		if signal {
+			overloaded = append(overloaded, key)
+		}
+	}
+	if len(errs) > 0 {
+		return overloaded, fmt.Errorf("failed to signal the overload of %d InferencePools: %w", len(errs), errs[0])
+	}
+	return overloaded, nil
+}
+
+// patch sets or removes the overloaded annotation of a pool.
+func (s *Signaler) patch(ctx context.Context, key types.NamespacedName, overloaded bool) error {
+	var value any
+	if overloaded {
+		value = "true"
+	}
+	data, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{constants.OverloadedAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	pool := &metav1.PartialObjectMetadata{}
+	pool.SetGroupVersionKind(s.poolGVK)
+	pool.SetNamespace(key.Namespace)
+	pool.SetName(key.Name)
+	return s.client.Patch(ctx, pool, client.RawPatch(types.MergePatchType, data))
+}
@@ -0,0 +1,79 @@
+package overload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
+)
+
+func newPool(name string) *v1.InferencePool {
+	return &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+}
+
+func annotation(t *testing.T, c client.Client, name string) (string, bool) {
+	var pool v1.InferencePool
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: name}, &pool))
+	value, ok := pool.Annotations[constants.OverloadedAnnotationKey]
+	return value, ok
+}
+
+func TestSignal(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.Install(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newPool("llama"), newPool("qwen")).Build()
+
+	pools := []*poolutil.EndpointPool{
+		{Name: "llama", Namespace: "ns", Selector: map[string]string{"app": "llama"}},
+		{Name: "qwen", Namespace: "ns", Selector: map[string]string{"app": "qwen"}},
+		{Name: "gone", Namespace: "ns", Selector: map[string]string{"app": "gone"}},
+	}
+	s := NewSignaler(c, func() []*poolutil.EndpointPool { return pools }, v1.GroupName)
+
+	variants := []Variant{
+		{Namespace: "ns", PodLabels: map[string]string{"app": "llama", "variant": "h100"}, Overloaded: true},
+		{Namespace: "ns", PodLabels: map[string]string{"app": "llama", "variant": "a100"}, Overloaded: true},
+		{Namespace: "ns", PodLabels: map[string]string{"app": "qwen"}},
+		{Namespace: "other", PodLabels: map[string]string{"app": "qwen"}, Overloaded: true},
+		{Namespace: "ns", PodLabels: map[string]string{"app": "gone"}, Overloaded: true},
+	}
+
+	// signals the pools of overloaded models; deleted pools are skipped
+	overloaded, err := s.Signal(ctx, variants)
+	require.NoError(t, err)
+	assert.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "llama"}}, overloaded)
+	value, ok := annotation(t, c, "llama")
+	assert.True(t, ok)
+	assert.Equal(t, "true", value)
+	_, ok = annotation(t, c, "qwen")
+	assert.False(t, ok)
+
+	// clears the signal once the model has headroom
+	variants[0].Overloaded = false
+	variants[1].Overloaded = false
+	overloaded, err = s.Signal(ctx, variants)
+	require.NoError(t, err)
+	assert.Empty(t, overloaded)
+	_, ok = annotation(t, c, "llama")
+	assert.False(t, ok)
+
+	// clears signaled pools whose variants are gone
+	variants[0].Overloaded = true
+	_, err = s.Signal(ctx, variants)
+	require.NoError(t, err)
+	_, err = s.Signal(ctx, nil)
+	require.NoError(t, err)
+	_, ok = annotation(t, c, "llama")
+	assert.False(t, ok)
+}
@@ -0,0 +1,114 @@
+package pipeline
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// FallbackClass is the standby accelerator class of a variant.
+type FallbackClass struct {
+	// Accelerator is the accelerator type of the fallback class
+	Accelerator string
+	// GPUsPerReplica is the number of GPUs of a replica on the fallback class
+	GPUsPerReplica float64
+	// ReplicaRatio is the number of fallback replicas serving the load of one replica
+	// on the accelerator of the variant (1 if the profiles are unknown)
+	ReplicaRatio float64
+	// Replicas is the number of replicas recommended on the fallback class in the
+	// previous cycle
+	Replicas int
+}
+
+// ApplyAcceleratorFallback recommends the scale-up replicas the GPU limiter did not grant
+// on the fallback class of their variant, keyed by namespace/name in classes, within the
+// GPUs available per accelerator type. It must run after the GPU limiter, and deducts the
+// GPUs it recommends from available.
+//
+// When the accelerator of the variant frees up, the shortfall shrinks and the variant
+// migrates back: the fallback replicas are only released once the replicas replacing them
+// on the accelerator of the variant are ready, so that capacity never drops in between.
+// It returns the variants with replicas on their fallback class.
+func ApplyAcceleratorFallback(
+	ctx context.Context,
+	decisions []*interfaces.VariantDecision,
+	classes map[string]FallbackClass,
+	available map[string]float64,
+) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	// Process variants in a stable order, so that scarce fallback GPUs go to the same variants
+	sorted := make([]*interfaces.VariantDecision, len(decisions))
+	copy(sorted, decisions)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].VariantName < sorted[j].VariantName
+	})
+
+	var onFallback []types.NamespacedName
+	for _, d := range sorted {
+		d.FallbackAccelerator = ""
+		d.FallbackReplicas = 0
+		class, ok := classes[utils.GetNamespacedKey(d.Namespace, d.VariantName)]
+		if !ok || class.Accelerator == "" {
+			continue
+		}
+		d.FallbackAccelerator = class.Accelerator
+
+		replicas := class.Replicas
+		if d.Error == nil {
+			replicas = fallbackReplicas(d, class)
+		}
+		gpus := class.GPUsPerReplica
+		if gpus <= 0 {
+			gpus = 1
+		}
+		// The GPUs of the previous fallback replicas are already in use by them
+		capacity := replicasFor(available[class.Accelerator]+float64(class.Replicas)*gpus, gpus)
+		replicas = min(replicas, max(capacity, 0))
+		available[class.Accelerator] -= float64(replicas-class.Replicas) * gpus
+		d.FallbackReplicas = replicas
+
+		if replicas != class.Replicas {
+			logger.Info("Fallback replicas changed",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"accelerator", d.AcceleratorName,
+				"fallbackAccelerator", class.Accelerator,
+				"previousReplicas", class.Replicas,
+				"replicas", replicas)
+		}
+		if replicas > 0 {
+			onFallback = append(onFallback, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+		}
+	}
+	return onFallback
+}
+
+// fallbackReplicas returns the replicas needed on the fallback class to serve the scale-up
+// replicas the GPU limiter did not grant. While migrating back, the previous replicas are
+// held until the pending replicas on the accelerator of the variant are ready.
+func fallbackReplicas(d *interfaces.VariantDecision, class FallbackClass) int {
+	ratio := class.ReplicaRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	requested, granted := d.ScaleUpGrant()
+	shortfall := requested - granted
+	want := int(math.Ceil(float64(shortfall) * ratio))
+	if class.Replicas > want {
+		pending := max(d.TargetReplicas-d.ReadyReplicas, 0)
+		want = min(class.Replicas, int(math.Ceil(float64(shortfall+pending)*ratio)))
+	}
+	return want
+}
@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ApplyAcceleratorFallback", func() {
+	var ctx context.Context
+
+	// limited returns a decision asking for target replicas of which the GPU limiter
+	// granted up to granted
+	limited := func(name string, current, target, granted int) *interfaces.VariantDecision {
+		return &interfaces.VariantDecision{
+			VariantName:            name,
+			Namespace:              "ns",
+			AcceleratorName:        "H100",
+			GPUsPerReplica:         1,
+			CurrentReplicas:        current,
+			ReadyReplicas:          current,
+			OriginalTargetReplicas: target,
+			TargetReplicas:         granted,
+		}
+	}
+
+	class := func(previous int) FallbackClass {
+		return FallbackClass{Accelerator: "A100", GPUsPerReplica: 1, ReplicaRatio: 1, Replicas: previous}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should recommend the replicas the limiter did not grant on the fallback class", func() {
+		d := limited("a", 2, 6, 3)
+		available := map[string]float64{"A100": 8}
+		Expect(ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d},
+			map[string]FallbackClass{"ns/a": class(0)}, available)).
+			To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "a"}))
+		Expect(d.FallbackAccelerator).To(Equal("A100"))
+		Expect(d.FallbackReplicas).To(Equal(3))
+		Expect(available["A100"]).To(Equal(5.0))
+	})
+
+	It("should scale the fallback replicas with the replica ratio of the profiles", func() {
+		d := limited("a", 2, 5, 2)
+		c := class(0)
+		c.ReplicaRatio = 2
+		c.GPUsPerReplica = 2
+		available := map[string]float64{"A100": 20}
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d}, map[string]FallbackClass{"ns/a": c}, available)
+		Expect(d.FallbackReplicas).To(Equal(6))
+		Expect(available["A100"]).To(Equal(8.0))
+	})
+
+	It("should stay within the GPUs available on the fallback class", func() {
+		a := limited("a", 2, 6, 2)
+		b := limited("b", 2, 6, 2)
+		available := map[string]float64{"A100": 5}
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{b, a},
+			map[string]FallbackClass{"ns/a": class(0), "ns/b": class(0)}, available)
+		Expect(a.FallbackReplicas).To(Equal(4))
+		Expect(b.FallbackReplicas).To(Equal(1))
+		Expect(available["A100"]).To(Equal(0.0))
+	})
+
+	It("should keep the GPUs of the previous fallback replicas", func() {
+		d := limited("a", 2, 6, 2)
+		available := map[string]float64{"A100": 0}
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d},
+			map[string]FallbackClass{"ns/a": class(3)}, available)
+		Expect(d.FallbackReplicas).To(Equal(3))
+		Expect(available["A100"]).To(Equal(0.0))
+	})
+
+	It("should hold the fallback replicas until the replacing replicas are ready", func() {
+		// The accelerator freed up: the limiter granted the whole scale-up
+		d := limited("a", 6, 6, 6)
+		d.ReadyReplicas = 4
+		available := map[string]float64{"A100": 0}
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d},
+			map[string]FallbackClass{"ns/a": class(4)}, available)
+		Expect(d.FallbackReplicas).To(Equal(2))
+		Expect(available["A100"]).To(Equal(2.0))
+
+		d.ReadyReplicas = 6
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d},
+			map[string]FallbackClass{"ns/a": class(2)}, available)
+		Expect(d.FallbackReplicas).To(BeZero())
+		Expect(d.FallbackAccelerator).To(Equal("A100"))
+	})
+
+	It("should hold the previous fallback replicas of a variant with an error", func() {
+		d := limited("a", 2, 2, 2)
+		d.Error = errors.New("no metrics")
+		ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d},
+			map[string]FallbackClass{"ns/a": class(3)}, map[string]float64{})
+		Expect(d.FallbackReplicas).To(Equal(3))
+	})
+
+	It("should clear the fallback of variants without a fallback class", func() {
+		d := limited("a", 2, 6, 2)
+		d.FallbackAccelerator = "A100"
+		d.FallbackReplicas = 2
+		Expect(ApplyAcceleratorFallback(ctx, []*interfaces.VariantDecision{d}, nil, map[string]float64{})).To(BeEmpty())
+		Expect(d.FallbackAccelerator).To(BeEmpty())
+		Expect(d.FallbackReplicas).To(BeZero())
+	})
+})
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// CapacityCeilingStepName is the DecisionStep name recorded when a target is capped.
+const CapacityCeilingStepName = "capacity-ceiling"
+
+// CapacityCeiling caps scaling targets at the number of replicas the cluster could
+// possibly place on the variant's accelerator type, regardless of current usage.
+//
+// Unlike a Limiter, which shares the GPUs still available among scale-up decisions,
+// the ceiling only uses the total capacity of each accelerator type. It is meant for
+// unlimited mode, where no limiter runs, so that emitted targets never exceed what
+// the scheduler could place:
+//
+//	ceiling = capacity of accelerator type / GPUs per replica
+//
+// Targets are never capped below the current replicas, and decisions whose
+// accelerator type is not in the inventory are left unchanged.
+type CapacityCeiling struct {
+	inventory Inventory
+}
+
+// NewCapacityCeiling creates a capacity ceiling backed by the given inventory.
+func NewCapacityCeiling(inventory Inventory) *CapacityCeiling {
+	return &CapacityCeiling{inventory: inventory}
+}
+
+// Apply caps decisions in place: a capped decision gets its TargetReplicas reduced,
+// CapacityCeiling set, CappedByCapacity set, and a DecisionStep explaining why.
+func (c *CapacityCeiling) Apply(ctx context.Context, decisions []*interfaces.VariantDecision) error {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	if len(decisions) == 0 {
+		return nil
+	}
+	if err := c.inventory.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to refresh inventory: %w", err)
+	}
+	pools := c.inventory.GetResourcePools()
+
+	for _, d := range decisions {
+		pool, ok := pools[d.AcceleratorName]
+		if !ok {
+			continue
+		}
+		gpusPerReplica := d.ReplicaGPUs()
+		ceiling := max(replicasFor(float64(pool.Limit), gpusPerReplica), d.CurrentReplicas)
+		d.CapacityCeiling = ceiling
+		if d.TargetReplicas <= ceiling {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = ceiling
+		d.CappedByCapacity = true
+		switch {
+		case d.TargetReplicas > d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleUp
+		case d.TargetReplicas < d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleDown
+		default:
+			d.Action = interfaces.ActionNoChange
+		}
+		d.AddDecisionStep(CapacityCeilingStepName,
+			fmt.Sprintf("capped at %d replicas (proposed %d): cluster has %d %s GPUs, %s per replica",
+				ceiling, proposed, pool.Limit, d.AcceleratorName, formatGPUs(gpusPerReplica)),
+			true)
+	}
+	return nil
+}
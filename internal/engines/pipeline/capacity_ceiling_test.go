@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("CapacityCeiling", func() {
+	var (
+		ctx       context.Context
+		inventory *mockInventory
+		ceiling   *CapacityCeiling
+	)
+
+	decision := func(acc string, current, target int, gpusPerReplica float64) *interfaces.VariantDecision {
+		return &interfaces.VariantDecision{
+			VariantName:     "v1",
+			Namespace:       "ns",
+			AcceleratorName: acc,
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			GPUsPerReplica:  gpusPerReplica,
+			Action:          interfaces.ActionScaleUp,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		inventory = newMockInventory("test", map[string]int{"A100": 8, "H100": 4})
+		ceiling = NewCapacityCeiling(inventory)
+	})
+
+	It("should cap targets at the replicas the accelerator type can hold", func() {
+		d := decision("A100", 2, 6, 2)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).To(Succeed())
+
+		Expect(d.TargetReplicas).To(Equal(4))
+		Expect(d.CapacityCeiling).To(Equal(4))
+		Expect(d.CappedByCapacity).To(BeTrue())
+		Expect(d.Action).To(Equal(interfaces.ActionScaleUp))
+		Expect(d.DecisionSteps).To(HaveLen(1))
+		Expect(d.DecisionSteps[0].Name).To(Equal(CapacityCeilingStepName))
+		Expect(d.DecisionSteps[0].WasConstrained).To(BeTrue())
+	})
+
+	It("should cap targets of replicas sharing GPUs at the shares of the accelerator type", func() {
+		d := decision("H100", 2, 12, 0.5)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).To(Succeed())
+
+		Expect(d.TargetReplicas).To(Equal(8))
+		Expect(d.CapacityCeiling).To(Equal(8))
+		Expect(d.DecisionSteps[0].Reason).To(ContainSubstring("0.5 per replica"))
+	})
+
+	It("should ignore current usage", func() {
+		inventory.SetUsed(map[string]float64{"H100": 4})
+		d := decision("H100", 1, 3, 1)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).To(Succeed())
+
+		Expect(d.TargetReplicas).To(Equal(3))
+		Expect(d.CapacityCeiling).To(Equal(4))
+		Expect(d.CappedByCapacity).To(BeFalse())
+		Expect(d.DecisionSteps).To(BeEmpty())
+	})
+
+	It("should never cap below the current replicas", func() {
+		d := decision("H100", 5, 7, 1)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).To(Succeed())
+
+		Expect(d.TargetReplicas).To(Equal(5))
+		Expect(d.CappedByCapacity).To(BeTrue())
+		Expect(d.Action).To(Equal(interfaces.ActionNoChange))
+	})
+
+	It("should leave decisions on unknown accelerator types unchanged", func() {
+		d := decision("MI300X", 1, 10, 1)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).To(Succeed())
+
+		Expect(d.TargetReplicas).To(Equal(10))
+		Expect(d.CapacityCeiling).To(Equal(0))
+		Expect(d.CappedByCapacity).To(BeFalse())
+	})
+
+	It("should return an error when the inventory cannot be refreshed", func() {
+		inventory.refreshErr = errors.New("discovery failed")
+		d := decision("A100", 1, 10, 1)
+		Expect(ceiling.Apply(ctx, []*interfaces.VariantDecision{d})).NotTo(Succeed())
+		Expect(d.TargetReplicas).To(Equal(10))
+	})
+})
@@ -41,6 +41,7 @@ func (o *CostAwareOptimizer) Optimize(
 	requests []ModelScalingRequest,
 	constraints []*ResourceConstraints,
 ) []interfaces.VariantDecision {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
 	logger := ctrl.LoggerFrom(ctx)
 	var allDecisions []interfaces.VariantDecision
 
@@ -261,24 +262,47 @@ func buildDecisions(
 		}
 
 		decisions = append(decisions, interfaces.VariantDecision{
-			VariantName:     name,
-			ModelID:         req.ModelID,
-			Namespace:       req.Namespace,
-			AcceleratorName: vc.AcceleratorName,
-			Cost:            vc.Cost,
-			CurrentReplicas: state.CurrentReplicas,
-			TargetReplicas:  target,
-			Action:          action,
-			Reason:          reason,
+			VariantName:           name,
+			ModelID:               req.ModelID,
+			Namespace:             req.Namespace,
+			AcceleratorName:       vc.AcceleratorName,
+			Cost:                  vc.Cost,
+			CurrentReplicas:       state.CurrentReplicas,
+			TargetReplicas:        target,
+			ReadyReplicas:         state.CurrentReplicas - state.PendingReplicas,
+			ReportingReplicas:     state.ReportingReplicas,
+			UnschedulableReplicas: state.UnschedulableReplicas,
+			DrainingReplicas:      state.DrainingReplicas,
+			PDBMinReplicas:        state.PDBMinReplicas,
+			PDBName:               state.PDBName,
+			HPAName:               state.HPAName,
+			HPAMinReplicas:        state.HPAMinReplicas,
+			HPAMaxReplicas:        state.HPAMaxReplicas,
+			Zones:                 state.Zones,
+			MinReplicas:           state.MinReplicas,
+			Behavior:              state.Behavior,
+			CreatedAt:             state.CreatedAt,
+			ErrorRate:             state.ErrorRate,
+			GPUsPerReplica:        state.GPUsPerReplica,
+			SpareCapacity:         spareCapacity(vc),
+			PerReplicaCapacity:    vc.PerReplicaCapacity,
+			Action:                action,
+			Reason:                reason,
 		})
 	}
 	return decisions
 }
 
+// spareCapacity converts a variant's utilization into the 0.0 (saturated) to
+// 1.0 (idle) scale used by VariantDecision.SpareCapacity.
+func spareCapacity(vc interfaces.VariantCapacity) float64 {
+	return math.Min(1, math.Max(0, 1-vc.Utilization))
+}
+
 // mergeConstraints combines constraints from multiple providers.
 // Currently unused in CostAwareOptimizer but available for limited mode.
-func mergeConstraints(constraints []*ResourceConstraints) map[string]int {
-	merged := make(map[string]int)
+func mergeConstraints(constraints []*ResourceConstraints) map[string]float64 {
+	merged := make(map[string]float64)
 	for _, c := range constraints {
 		if c == nil {
 			continue
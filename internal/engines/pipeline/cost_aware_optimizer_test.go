@@ -421,8 +421,8 @@ var _ = Describe("CostAwareOptimizer", func() {
 
 			merged := mergeConstraints(constraints)
 
-			Expect(merged["A100"]).To(Equal(6))
-			Expect(merged["H100"]).To(Equal(4))
+			Expect(merged["A100"]).To(Equal(6.0))
+			Expect(merged["H100"]).To(Equal(4.0))
 		})
 	})
 })
@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/solver"
+)
+
+// DampenerStepName is the DecisionStep name recorded when a change is dampened.
+const DampenerStepName = "dampener"
+
+// ChangeDampener suppresses scaling decisions that have not been stable across
+// optimization runs, so that noisy saturation signals do not cause replica flapping.
+// Each decision is tracked as an AllocationDiff (current → target) per variant:
+// a change is only applied once the same target has been proposed for a number of
+// consecutive runs, or immediately if its magnitude reaches the replica threshold.
+// An applied change is not dampened again while the current replicas catch up with it.
+// Variants without a decision in a run keep their pending or applied change.
+//
+// ChangeDampener keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type ChangeDampener struct {
+	dampener *solver.Dampener
+
+	// variants seen by the dampener, by namespace/variant key
+	variants map[string]types.NamespacedName
+}
+
+// NewChangeDampener creates a dampener applying changes after consecutiveRuns runs,
+// or immediately when they change replicas by at least replicaThreshold (0 disables
+// the magnitude bypass). consecutiveRuns <= 1 disables dampening.
+func NewChangeDampener(consecutiveRuns, replicaThreshold int) *ChangeDampener {
+	return &ChangeDampener{
+		dampener: solver.NewDampener(consecutiveRuns, replicaThreshold),
+		variants: make(map[string]types.NamespacedName),
+	}
+}
+
+// DampenResult reports what the dampener did in one run.
+type DampenResult struct {
+	// Dampened lists the variants whose change was held back.
+	Dampened []types.NamespacedName
+	// Flapped lists the variants whose pending change was withdrawn or reversed.
+	Flapped []types.NamespacedName
+}
+
+// Dampen holds back unstable changes in place: a dampened decision keeps its current
+// replicas and records a DecisionStep explaining why.
+func (c *ChangeDampener) Dampen(ctx context.Context, decisions []interfaces.VariantDecision) DampenResult {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	diffs := make(map[string]*core.AllocationDiff, len(decisions))
+	for i := range decisions {
+		d := &decisions[i]
+		key := dampenerKey(d)
+		c.variants[key] = types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName}
+		diffs[key] = core.CreateAllocationDiff(
+			core.AllocationFromData(&infernoConfig.AllocationData{Accelerator: d.AcceleratorName, NumReplicas: d.CurrentReplicas}),
+			core.AllocationFromData(&infernoConfig.AllocationData{Accelerator: d.AcceleratorName, NumReplicas: d.TargetReplicas}))
+	}
+
+	apply, flapped := c.dampener.Dampen(diffs)
+
+	var result DampenResult
+	for _, key := range flapped {
+		result.Flapped = append(result.Flapped, c.variants[key])
+	}
+	for i := range decisions {
+		d := &decisions[i]
+		key := dampenerKey(d)
+		if d.TargetReplicas == d.CurrentReplicas {
+			continue
+		}
+		if _, ok := apply[key]; ok {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		runs := c.dampener.PendingRuns(key)
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		d.AddDecisionStep(DampenerStepName,
+			fmt.Sprintf("change to %d replicas dampened (proposed for %d consecutive run(s))", proposed, runs),
+			true)
+		result.Dampened = append(result.Dampened, c.variants[key])
+
+		logger.V(logging.DEBUG).Info("Scaling change dampened",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"current", d.CurrentReplicas,
+			"proposed", proposed,
+			"consecutiveRuns", runs)
+	}
+	return result
+}
+
+// FlapCount returns the cumulative number of flaps of a variant.
+func (c *ChangeDampener) FlapCount(namespace, variantName string) int {
+	return c.dampener.FlapCount(utils.GetNamespacedKey(namespace, variantName))
+}
+
+// Forget drops the pending and applied changes and the flap count of a variant no longer active.
+func (c *ChangeDampener) Forget(namespace, variantName string) {
+	key := utils.GetNamespacedKey(namespace, variantName)
+	delete(c.variants, key)
+	c.dampener.Remove(key)
+}
+
+func dampenerKey(d *interfaces.VariantDecision) string {
+	return utils.GetNamespacedKey(d.Namespace, d.VariantName)
+}
@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ChangeDampener", func() {
+	var ctx context.Context
+
+	decision := func(current, target int) []interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		if target > current {
+			action = interfaces.ActionScaleUp
+		} else if target < current {
+			action = interfaces.ActionScaleDown
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			AcceleratorName: "A100",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			Action:          action,
+		}}
+	}
+	variant := types.NamespacedName{Namespace: "ns", Name: "variant-a"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass changes through when dampening is disabled", func() {
+		dampener := NewChangeDampener(1, 0)
+		decisions := decision(2, 3)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+	})
+
+	It("should hold back a change until it repeats for the configured runs", func() {
+		dampener := NewChangeDampener(2, 0)
+
+		decisions := decision(2, 3)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].DecisionSteps).To(HaveLen(1))
+		Expect(decisions[0].DecisionSteps[0].Name).To(Equal(DampenerStepName))
+		Expect(decisions[0].DecisionSteps[0].WasConstrained).To(BeTrue())
+
+		decisions = decision(2, 3)
+		result = dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should keep an applied change while the current replicas catch up", func() {
+		dampener := NewChangeDampener(2, 0)
+		dampener.Dampen(ctx, decision(2, 3))
+		decisions := decision(2, 3)
+		dampener.Dampen(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+
+		// the scale-up is applied but the deployment has not reported it yet
+		for run := 0; run < 3; run++ {
+			decisions = decision(2, 3)
+			result := dampener.Dampen(ctx, decisions)
+			Expect(result.Dampened).To(BeEmpty())
+			Expect(result.Flapped).To(BeEmpty())
+			Expect(decisions[0].TargetReplicas).To(Equal(3))
+			Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+		}
+
+		// once the current replicas reach the target, new changes are dampened again
+		dampener.Dampen(ctx, decision(3, 3))
+		decisions = decision(3, 4)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should apply large changes immediately", func() {
+		dampener := NewChangeDampener(5, 2)
+		decisions := decision(1, 4)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+	})
+
+	It("should report flaps when a pending change reverses", func() {
+		dampener := NewChangeDampener(3, 0)
+		dampener.Dampen(ctx, decision(2, 3))
+		result := dampener.Dampen(ctx, decision(2, 1))
+		Expect(result.Flapped).To(ConsistOf(variant))
+		Expect(dampener.FlapCount("ns", "variant-a")).To(Equal(1))
+	})
+
+	It("should keep the pending change of a variant missing from a run", func() {
+		dampener := NewChangeDampener(2, 0)
+		dampener.Dampen(ctx, decision(2, 3))
+
+		// the variant has no decision in this run, e.g. its metrics are unavailable
+		result := dampener.Dampen(ctx, nil)
+		Expect(result.Flapped).To(BeEmpty())
+		Expect(dampener.FlapCount("ns", "variant-a")).To(BeZero())
+
+		decisions := decision(2, 3)
+		result = dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should restart the count of a forgotten variant", func() {
+		dampener := NewChangeDampener(2, 0)
+		dampener.Dampen(ctx, decision(2, 3))
+		dampener.Dampen(ctx, decision(2, 1))
+		Expect(dampener.FlapCount("ns", "variant-a")).To(Equal(1))
+
+		dampener.Forget("ns", "variant-a")
+		Expect(dampener.FlapCount("ns", "variant-a")).To(BeZero())
+		Expect(dampener.variants).To(BeEmpty())
+
+		decisions := decision(2, 1)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+	})
+
+	It("should not touch steady-state decisions", func() {
+		dampener := NewChangeDampener(3, 0)
+		decisions := decision(2, 2)
+		result := dampener.Dampen(ctx, decisions)
+		Expect(result.Dampened).To(BeEmpty())
+		Expect(result.Flapped).To(BeEmpty())
+		Expect(decisions[0].DecisionSteps).To(BeEmpty())
+	})
+})
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	ctrl "sigs.k8s.io/controller-runtime"
+
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
 // DefaultLimiter combines an Inventory with an AllocationAlgorithm to constrain
@@ -16,10 +19,16 @@ import (
 //  3. Create allocator with available resources
 //  4. Run allocation algorithm to distribute resources
 //  5. Update decision metadata (WasLimited, LimitedBy, DecisionSteps)
+//
+// With a PoolCoordinator, the GPUs held by other controller instances sharing the
+// pools are counted as used in step 2, and the GPUs granted are committed after step 4.
+// Decisions limited for lack of capacity of a type with held GPUs are reported as limited
+// by the reservations of the other instances.
 type DefaultLimiter struct {
-	name      string
-	inventory Inventory
-	algorithm AllocationAlgorithm
+	name        string
+	inventory   Inventory
+	algorithm   AllocationAlgorithm
+	coordinator PoolCoordinator
 }
 
 // NewDefaultLimiter creates a limiter that combines inventory tracking with
@@ -32,6 +41,12 @@ func NewDefaultLimiter(name string, inventory Inventory, algorithm AllocationAlg
 	}
 }
 
+// SetCoordinator sets the coordinator of the GPU pools shared with other controller
+// instances. A nil coordinator allocates from the whole pools.
+func (l *DefaultLimiter) SetCoordinator(coordinator PoolCoordinator) {
+	l.coordinator = coordinator
+}
+
 // Name returns the limiter identifier for logging/metrics.
 func (l *DefaultLimiter) Name() string {
 	return l.name
@@ -40,6 +55,7 @@ func (l *DefaultLimiter) Name() string {
 // Limit applies resource constraints to scaling decisions.
 // Modifies decisions in place - may reduce TargetReplicas based on available resources.
 func (l *DefaultLimiter) Limit(ctx context.Context, decisions []*interfaces.VariantDecision) error {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
 	if len(decisions) == 0 {
 		return nil
 	}
@@ -51,7 +67,13 @@ func (l *DefaultLimiter) Limit(ctx context.Context, decisions []*interfaces.Vari
 
 	// Step 2: Calculate current GPU usage from decisions
 	usedByType := l.calculateUsedGPUs(decisions)
-	l.inventory.SetUsed(usedByType)
+	var held map[string]int
+	if l.coordinator != nil {
+		held = l.reserveGPUs(ctx, decisions, usedByType)
+		l.inventory.SetUsed(addHeldGPUs(usedByType, held))
+	} else {
+		l.inventory.SetUsed(usedByType)
+	}
 
 	// Step 3: Create allocator with available resources
 	allocator := l.inventory.CreateAllocator(ctx)
@@ -62,24 +84,122 @@ func (l *DefaultLimiter) Limit(ctx context.Context, decisions []*interfaces.Vari
 	}
 
 	// Step 5: Update decision metadata
+	explainReservations(decisions, held)
 	l.updateDecisionMetadata(decisions)
 
+	if l.coordinator != nil {
+		if err := l.coordinator.Commit(ctx, coordinatedGPUs(usedByType), coordinatedGPUs(l.calculateGrantedGPUs(decisions))); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to commit granted GPUs to the pool coordinator",
+				"limiter", l.name, "coordinator", l.coordinator.Name())
+		}
+	}
+
 	return nil
 }
 
+// reserveGPUs returns the GPUs held by the other controller instances per accelerator type,
+// as reported by the coordinator. If the coordinator fails, all the GPUs of the pools not
+// used by the decisions are deemed held, so scale-ups are held rather than risk allocating
+// GPUs another instance allocates.
+func (l *DefaultLimiter) reserveGPUs(ctx context.Context, decisions []*interfaces.VariantDecision, usedByType map[string]float64) map[string]int {
+	pools := l.inventory.GetResourcePools()
+	limits := make(map[string]int, len(pools))
+	for accType, pool := range pools {
+		limits[accType] = pool.Limit
+	}
+
+	used := coordinatedGPUs(usedByType)
+	held, err := l.coordinator.Reserve(ctx, limits, used, coordinatedGPUs(l.calculateRequestedGPUs(decisions)))
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to reserve GPUs with the pool coordinator, holding scale-ups",
+			"limiter", l.name, "coordinator", l.coordinator.Name())
+		held = make(map[string]int, len(limits))
+		for accType, limit := range limits {
+			held[accType] = max(limit-used[accType], 0)
+		}
+	}
+	return held
+}
+
+// addHeldGPUs returns the GPUs used by the decisions plus the GPUs held by the other
+// controller instances.
+func addHeldGPUs(usedByType map[string]float64, held map[string]int) map[string]float64 {
+	total := make(map[string]float64, len(usedByType)+len(held))
+	for accType, used := range usedByType {
+		total[accType] = used
+	}
+	for accType, gpus := range held {
+		total[accType] += float64(gpus)
+	}
+	return total
+}
+
+// explainReservations reports the decisions limited for lack of capacity of an accelerator
+// type some of whose GPUs are held by other controller instances as limited by their
+// reservations.
+func explainReservations(decisions []*interfaces.VariantDecision, held map[string]int) {
+	for _, d := range decisions {
+		if !d.WasLimited || held[d.AcceleratorName] == 0 ||
+			(d.LimitReason != "" && d.LimitReason != interfaces.LimitReasonInsufficientCapacity) {
+			continue
+		}
+		d.LimitReason = interfaces.LimitReasonPoolReservation
+		message := fmt.Sprintf("%d %s GPUs are held by other controller instances", held[d.AcceleratorName], d.AcceleratorName)
+		if d.LimitMessage != "" {
+			message = d.LimitMessage + "; " + message
+		}
+		d.LimitMessage = message
+	}
+}
+
+// coordinatedGPUs rounds the GPUs per accelerator type up to the whole GPUs coordinated
+// with the other controller instances.
+func coordinatedGPUs(gpusByType map[string]float64) map[string]int {
+	whole := make(map[string]int, len(gpusByType))
+	for accType, gpus := range gpusByType {
+		whole[accType] = wholeGPUs(gpus)
+	}
+	return whole
+}
+
 // calculateUsedGPUs computes current GPU usage per accelerator type.
-// Uses CurrentReplicas * GPUsPerReplica for each decision.
-func (l *DefaultLimiter) calculateUsedGPUs(decisions []*interfaces.VariantDecision) map[string]int {
-	usedByType := make(map[string]int)
+// Uses CurrentReplicas * GPUsPerReplica for each decision, aggregating the fractional
+// GPUs of replicas sharing GPUs.
+func (l *DefaultLimiter) calculateUsedGPUs(decisions []*interfaces.VariantDecision) map[string]float64 {
+	usedByType := make(map[string]float64)
 	for _, d := range decisions {
 		if d.AcceleratorName == "" {
 			continue
 		}
-		usedByType[d.AcceleratorName] += d.CurrentReplicas * d.GPUsPerReplica
+		usedByType[d.AcceleratorName] += float64(d.CurrentReplicas) * d.GPUsPerReplica
 	}
 	return usedByType
 }
 
+// calculateRequestedGPUs computes the GPUs requested by scale-ups per accelerator type.
+func (l *DefaultLimiter) calculateRequestedGPUs(decisions []*interfaces.VariantDecision) map[string]float64 {
+	requestedByType := make(map[string]float64)
+	for _, d := range decisions {
+		if d.AcceleratorName == "" || d.TargetReplicas <= d.CurrentReplicas {
+			continue
+		}
+		requestedByType[d.AcceleratorName] += float64(d.TargetReplicas-d.CurrentReplicas) * d.GPUsPerReplica
+	}
+	return requestedByType
+}
+
+// calculateGrantedGPUs computes the GPUs allocated to the decisions per accelerator type.
+func (l *DefaultLimiter) calculateGrantedGPUs(decisions []*interfaces.VariantDecision) map[string]float64 {
+	grantedByType := make(map[string]float64)
+	for _, d := range decisions {
+		if d.AcceleratorName == "" || d.GPUsAllocated <= 0 {
+			continue
+		}
+		grantedByType[d.AcceleratorName] += d.GPUsAllocated
+	}
+	return grantedByType
+}
+
 // updateDecisionMetadata sets LimitedBy and adds DecisionSteps.
 // Note: WasLimited is set by the algorithm during allocation.
 func (l *DefaultLimiter) updateDecisionMetadata(decisions []*interfaces.VariantDecision) {
@@ -102,16 +222,19 @@ func (l *DefaultLimiter) buildStepReason(d *interfaces.VariantDecision) string {
 	if replicaChange <= 0 {
 		return fmt.Sprintf("no scale-up (target=%d, current=%d)", d.TargetReplicas, d.CurrentReplicas)
 	}
+	if d.WasLimited && d.LimitReason != "" {
+		return fmt.Sprintf("limited (%s): allocated %s GPUs for +%d replicas", d.LimitReason, formatGPUs(d.GPUsAllocated), replicaChange)
+	}
 	if d.WasLimited {
-		return fmt.Sprintf("limited: allocated %d GPUs for +%d replicas", d.GPUsAllocated, replicaChange)
+		return fmt.Sprintf("limited: allocated %s GPUs for +%d replicas", formatGPUs(d.GPUsAllocated), replicaChange)
 	}
-	return fmt.Sprintf("allocated %d GPUs for +%d replicas", d.GPUsAllocated, replicaChange)
+	return fmt.Sprintf("allocated %s GPUs for +%d replicas", formatGPUs(d.GPUsAllocated), replicaChange)
 }
 
 // ComputeConstraints refreshes the inventory and returns per-type resource availability.
 // This is the V2 path: expose constraints for the optimizer instead of modifying
 // decisions directly (which is what Limit() does for the V1 path).
-func (l *DefaultLimiter) ComputeConstraints(ctx context.Context, currentUsage map[string]int) (*ResourceConstraints, error) {
+func (l *DefaultLimiter) ComputeConstraints(ctx context.Context, currentUsage map[string]float64) (*ResourceConstraints, error) {
 	// Step 1: Refresh inventory (same as Limit step 1)
 	if err := l.inventory.Refresh(ctx); err != nil {
 		return nil, fmt.Errorf("failed to refresh inventory: %w", err)
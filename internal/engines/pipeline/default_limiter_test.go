@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -13,7 +14,7 @@ import (
 type mockInventory struct {
 	name        string
 	limitByType map[string]int
-	usedByType  map[string]int
+	usedByType  map[string]float64
 	refreshErr  error
 }
 
@@ -21,7 +22,7 @@ func newMockInventory(name string, limitByType map[string]int) *mockInventory {
 	return &mockInventory{
 		name:        name,
 		limitByType: limitByType,
-		usedByType:  make(map[string]int),
+		usedByType:  make(map[string]float64),
 	}
 }
 
@@ -33,15 +34,15 @@ func (m *mockInventory) Refresh(ctx context.Context) error {
 	return m.refreshErr
 }
 
-func (m *mockInventory) SetUsed(usedByType map[string]int) {
+func (m *mockInventory) SetUsed(usedByType map[string]float64) {
 	m.usedByType = usedByType
 }
 
 func (m *mockInventory) CreateAllocator(ctx context.Context) ResourceAllocator {
-	availableByType := make(map[string]int)
+	availableByType := make(map[string]float64)
 	for t, limit := range m.limitByType {
 		used := m.usedByType[t]
-		availableByType[t] = limit - used
+		availableByType[t] = float64(limit) - used
 	}
 	return &mockTypeAllocator{availableByType: availableByType}
 }
@@ -54,23 +55,23 @@ func (m *mockInventory) TotalLimit() int {
 	return total
 }
 
-func (m *mockInventory) TotalUsed() int {
-	total := 0
+func (m *mockInventory) TotalUsed() float64 {
+	total := 0.0
 	for _, v := range m.usedByType {
 		total += v
 	}
 	return total
 }
 
-func (m *mockInventory) TotalAvailable() int {
-	return m.TotalLimit() - m.TotalUsed()
+func (m *mockInventory) TotalAvailable() float64 {
+	return float64(m.TotalLimit()) - m.TotalUsed()
 }
 
 func (m *mockInventory) GetResourcePools() map[string]ResourcePool {
 	pools := make(map[string]ResourcePool, len(m.limitByType))
 	for accType, limit := range m.limitByType {
 		used := m.usedByType[accType]
-		avail := limit - used
+		avail := float64(limit) - used
 		if avail < 0 {
 			avail = 0
 		}
@@ -81,10 +82,10 @@ func (m *mockInventory) GetResourcePools() map[string]ResourcePool {
 
 // mockTypeAllocator implements ResourceAllocator for testing
 type mockTypeAllocator struct {
-	availableByType map[string]int
+	availableByType map[string]float64
 }
 
-func (m *mockTypeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested int) (int, error) {
+func (m *mockTypeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested float64) (float64, error) {
 	accelType := decision.AcceleratorName
 	if accelType == "" {
 		accelType = "default"
@@ -101,8 +102,8 @@ func (m *mockTypeAllocator) TryAllocate(decision *interfaces.VariantDecision, gp
 	return allocated, nil
 }
 
-func (m *mockTypeAllocator) Remaining() int {
-	total := 0
+func (m *mockTypeAllocator) Remaining() float64 {
+	total := 0.0
 	for _, v := range m.availableByType {
 		total += v
 	}
@@ -169,7 +170,7 @@ var _ = Describe("DefaultLimiter", func() {
 					allocateFunc: func(ctx context.Context, decisions []*interfaces.VariantDecision, allocator ResourceAllocator) error {
 						for _, d := range decisions {
 							if d.TargetReplicas > d.CurrentReplicas {
-								gpusNeeded := (d.TargetReplicas - d.CurrentReplicas) * d.GPUsPerReplica
+								gpusNeeded := float64(d.TargetReplicas-d.CurrentReplicas) * d.GPUsPerReplica
 								allocated, _ := allocator.TryAllocate(d, gpusNeeded)
 								d.GPUsAllocated = allocated
 							}
@@ -195,7 +196,7 @@ var _ = Describe("DefaultLimiter", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// Current usage: 2 replicas * 2 GPUs = 4 GPUs used
-				Expect(inventory.usedByType["A100"]).To(Equal(4))
+				Expect(inventory.usedByType["A100"]).To(Equal(4.0))
 			})
 
 			It("should allocate GPUs and update decision", func() {
@@ -203,7 +204,7 @@ var _ = Describe("DefaultLimiter", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// Available: 8 - 4 = 4 GPUs, needed: 2 replicas * 2 GPUs = 4 GPUs
-				Expect(decisions[0].GPUsAllocated).To(Equal(4))
+				Expect(decisions[0].GPUsAllocated).To(Equal(4.0))
 			})
 
 			It("should add decision step", func() {
@@ -226,14 +227,11 @@ var _ = Describe("DefaultLimiter", func() {
 						for _, d := range decisions {
 							if d.TargetReplicas > d.CurrentReplicas {
 								replicasNeeded := d.TargetReplicas - d.CurrentReplicas
-								gpusNeeded := replicasNeeded * d.GPUsPerReplica
+								gpusNeeded := float64(replicasNeeded) * d.GPUsPerReplica
 								allocated, _ := allocator.TryAllocate(d, gpusNeeded)
 								// Calculate how many replicas we can actually add
-								replicasCanAdd := 0
-								if d.GPUsPerReplica > 0 {
-									replicasCanAdd = allocated / d.GPUsPerReplica
-								}
-								d.GPUsAllocated = replicasCanAdd * d.GPUsPerReplica
+								replicasCanAdd := replicasFor(allocated, d.GPUsPerReplica)
+								d.GPUsAllocated = float64(replicasCanAdd) * d.GPUsPerReplica
 								d.TargetReplicas = d.CurrentReplicas + replicasCanAdd
 								// Mark as limited if we couldn't get all requested replicas
 								if replicasCanAdd < replicasNeeded {
@@ -286,7 +284,7 @@ var _ = Describe("DefaultLimiter", func() {
 					allocateFunc: func(ctx context.Context, decisions []*interfaces.VariantDecision, allocator ResourceAllocator) error {
 						for _, d := range decisions {
 							if d.TargetReplicas > d.CurrentReplicas {
-								gpusNeeded := (d.TargetReplicas - d.CurrentReplicas) * d.GPUsPerReplica
+								gpusNeeded := float64(d.TargetReplicas-d.CurrentReplicas) * d.GPUsPerReplica
 								allocated, _ := allocator.TryAllocate(d, gpusNeeded)
 								d.GPUsAllocated = allocated
 							}
@@ -318,8 +316,8 @@ var _ = Describe("DefaultLimiter", func() {
 				err := limiter.Limit(ctx, decisions)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(inventory.usedByType["A100"]).To(Equal(4)) // 2 replicas * 2 GPUs
-				Expect(inventory.usedByType["H100"]).To(Equal(2)) // 1 replica * 2 GPUs
+				Expect(inventory.usedByType["A100"]).To(Equal(4.0)) // 2 replicas * 2 GPUs
+				Expect(inventory.usedByType["H100"]).To(Equal(2.0)) // 1 replica * 2 GPUs
 			})
 
 			It("should allocate from correct type pools", func() {
@@ -327,10 +325,142 @@ var _ = Describe("DefaultLimiter", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// A100: 8 - 4 = 4 available, needs 2, gets 2
-				Expect(decisions[0].GPUsAllocated).To(Equal(2))
+				Expect(decisions[0].GPUsAllocated).To(Equal(2.0))
 				// H100: 4 - 2 = 2 available, needs 2, gets 2
-				Expect(decisions[1].GPUsAllocated).To(Equal(2))
+				Expect(decisions[1].GPUsAllocated).To(Equal(2.0))
+			})
+		})
+
+		Context("with a pool coordinator", func() {
+			var coordinator *mockCoordinator
+
+			BeforeEach(func() {
+				inventory = newMockInventory("type-inv", map[string]int{"A100": 8})
+				algorithm = &mockAlgorithm{
+					name: "pass-through",
+					allocateFunc: func(ctx context.Context, decisions []*interfaces.VariantDecision, allocator ResourceAllocator) error {
+						for _, d := range decisions {
+							if d.TargetReplicas > d.CurrentReplicas {
+								needed := float64(d.TargetReplicas-d.CurrentReplicas) * d.GPUsPerReplica
+								allocated, _ := allocator.TryAllocate(d, needed)
+								d.GPUsAllocated = allocated
+								if allocated < needed {
+									d.WasLimited = true
+									d.LimitReason = interfaces.LimitReasonInsufficientCapacity
+									d.LimitMessage = "insufficient capacity of type A100"
+								}
+							}
+						}
+						return nil
+					},
+				}
+				coordinator = &mockCoordinator{held: map[string]int{"A100": 2}}
+				limiter = NewDefaultLimiter("gpu-limiter", inventory, algorithm)
+				limiter.SetCoordinator(coordinator)
+
+				decisions = []*interfaces.VariantDecision{
+					{
+						VariantName:     "v1",
+						AcceleratorName: "A100",
+						CurrentReplicas: 2,
+						TargetReplicas:  5, // wants +3
+						GPUsPerReplica:  2,
+					},
+				}
+			})
+
+			It("should count the GPUs held by other instances as used", func() {
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(coordinator.limits).To(Equal(map[string]int{"A100": 8}))
+				Expect(coordinator.requested).To(Equal(map[string]int{"A100": 6}))
+				// 4 used + 2 held
+				Expect(inventory.usedByType["A100"]).To(Equal(6.0))
+				Expect(decisions[0].GPUsAllocated).To(Equal(2.0))
+			})
+
+			It("should commit the used and granted GPUs", func() {
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(coordinator.committedUsed).To(Equal(map[string]int{"A100": 4}))
+				Expect(coordinator.committedGranted).To(Equal(map[string]int{"A100": 2}))
+			})
+
+			It("should coordinate shared GPUs in whole GPUs", func() {
+				decisions[0].CurrentReplicas = 3
+				decisions[0].TargetReplicas = 6
+				decisions[0].GPUsPerReplica = 0.5
+
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				// 1.5 GPUs used and 1.5 requested are rounded up
+				Expect(coordinator.requested).To(Equal(map[string]int{"A100": 2}))
+				Expect(inventory.usedByType["A100"]).To(Equal(3.5))
+				Expect(decisions[0].GPUsAllocated).To(Equal(1.5))
+				Expect(coordinator.committedUsed).To(Equal(map[string]int{"A100": 2}))
+				Expect(coordinator.committedGranted).To(Equal(map[string]int{"A100": 2}))
+			})
+
+			It("should hold scale-ups when the coordinator fails", func() {
+				coordinator.reserveErr = errors.New("lease unavailable")
+
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inventory.usedByType["A100"]).To(Equal(8.0))
+				Expect(decisions[0].GPUsAllocated).To(Equal(0.0))
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonPoolReservation))
+			})
+
+			It("should report limits on types with held GPUs as pool reservations", func() {
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(decisions[0].WasLimited).To(BeTrue())
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonPoolReservation))
+				Expect(decisions[0].LimitMessage).To(Equal("insufficient capacity of type A100; 2 A100 GPUs are held by other controller instances"))
+			})
+
+			It("should keep the limit reason when no GPUs are held", func() {
+				coordinator.held = map[string]int{}
+
+				err := limiter.Limit(ctx, decisions)
+				Expect(err).NotTo(HaveOccurred())
+
+				// 4 used, +6 requested of 8
+				Expect(decisions[0].WasLimited).To(BeTrue())
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonInsufficientCapacity))
 			})
 		})
 	})
 })
+
+// mockCoordinator implements PoolCoordinator for testing
+type mockCoordinator struct {
+	held       map[string]int
+	reserveErr error
+
+	limits           map[string]int
+	requested        map[string]int
+	committedUsed    map[string]int
+	committedGranted map[string]int
+}
+
+func (m *mockCoordinator) Name() string {
+	return "mock-coordinator"
+}
+
+func (m *mockCoordinator) Reserve(_ context.Context, limits, _, requested map[string]int) (map[string]int, error) {
+	m.limits = limits
+	m.requested = requested
+	return m.held, m.reserveErr
+}
+
+func (m *mockCoordinator) Commit(_ context.Context, used, granted map[string]int) error {
+	m.committedUsed = used
+	m.committedGranted = granted
+	return nil
+}
@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// DrainSurgeStepName is the DecisionStep name recorded when a target is raised to cover
+// the replicas about to be evicted from cordoned or draining nodes.
+const DrainSurgeStepName = "drain-surge"
+
+// SurgeDrainingReplicas raises the targets of variants with replicas on cordoned or
+// draining nodes by the number of those replicas, so that their replacements start
+// before the evictions instead of after them. Once the drain completes the evicted
+// replicas are gone, DrainingReplicas drops to 0 and the target settles back to the one
+// of the analysis. Variants scaling to zero are not surged. It returns the variants
+// whose target was raised.
+func SurgeDrainingReplicas(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var surged []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.DrainingReplicas <= 0 || d.TargetReplicas <= 0 {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = proposed + d.DrainingReplicas
+		d.DrainSurge = d.DrainingReplicas
+		switch {
+		case d.TargetReplicas > d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleUp
+		case d.TargetReplicas == d.CurrentReplicas:
+			d.Action = interfaces.ActionNoChange
+		}
+		d.AddDecisionStep(DrainSurgeStepName,
+			fmt.Sprintf("target %d raised to %d to cover %d replicas on cordoned or draining nodes",
+				proposed, d.TargetReplicas, d.DrainingReplicas),
+			true)
+		surged = append(surged, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.Info("Target surged ahead of node drain",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"draining", d.DrainingReplicas,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return surged
+}
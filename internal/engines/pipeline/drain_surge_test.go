@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("SurgeDrainingReplicas", func() {
+	var ctx context.Context
+
+	decision := func(current, target, draining int) []interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		switch {
+		case target > current:
+			action = interfaces.ActionScaleUp
+		case target < current:
+			action = interfaces.ActionScaleDown
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:      "variant-a",
+			Namespace:        "ns",
+			CurrentReplicas:  current,
+			TargetReplicas:   target,
+			DrainingReplicas: draining,
+			Action:           action,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass targets through without draining replicas", func() {
+		decisions := decision(3, 3, 0)
+		Expect(SurgeDrainingReplicas(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].DrainSurge).To(Equal(0))
+	})
+
+	It("should raise the target by the draining replicas", func() {
+		decisions := decision(3, 3, 2)
+		surged := SurgeDrainingReplicas(ctx, decisions)
+		Expect(surged).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+		Expect(decisions[0].DrainSurge).To(Equal(2))
+		Expect(decisions[0].LastStep().Name).To(Equal(DrainSurgeStepName))
+	})
+
+	It("should hold a scale-down the surge cancels out", func() {
+		decisions := decision(4, 3, 1)
+		Expect(SurgeDrainingReplicas(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+	})
+
+	It("should not surge variants scaling to zero", func() {
+		decisions := decision(2, 0, 1)
+		Expect(SurgeDrainingReplicas(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(0))
+	})
+})
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -17,18 +18,67 @@ import (
 // and makes the function signature reusable across the codebase.
 type RequestCountFuncType func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error)
 
+// ProbeCountFuncType is the signature for functions that return the number of synthetic
+// probe requests sent to a model since a point in time.
+type ProbeCountFuncType func(modelID, namespace string, since time.Time) float64
+
 // Enforcer applies scale-to-zero and minimum replica enforcement after saturation analysis.
 type Enforcer struct {
 	// requestCountFunc is a function that returns the total request count for a model.
 	// Injected for testability.
 	requestCountFunc RequestCountFuncType
+
+	// probeCountFunc returns the synthetic probes sent to a model, which idle detection
+	// does not count as traffic. Nil without prober.
+	probeCountFunc ProbeCountFuncType
+
+	mu sync.Mutex
+	// requestRates is the last request rate idle detection saw for each model
+	// with scale-to-zero enabled, by namespace/modelID
+	requestRates map[string]interfaces.RequestRate
 }
 
 // NewEnforcer creates a new scale-to-zero enforcer.
 func NewEnforcer(requestCountFunc RequestCountFuncType) *Enforcer {
 	return &Enforcer{
 		requestCountFunc: requestCountFunc,
+		requestRates:     make(map[string]interfaces.RequestRate),
+	}
+}
+
+// SetProbeCounter makes idle detection leave out of the request count of models the
+// synthetic probes counted by probeCount, so that probing does not keep idle models warm.
+func (e *Enforcer) SetProbeCounter(probeCount ProbeCountFuncType) {
+	e.probeCountFunc = probeCount
+}
+
+// RequestRate returns the request rate of a model that idle detection saw when the policy
+// was last enforced, or nil if scale-to-zero is disabled for the model or its request
+// count is unknown. Safe for concurrent use.
+func (e *Enforcer) RequestRate(modelID, namespace string) *interfaces.RequestRate {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rate, ok := e.requestRates[namespace+"/"+modelID]
+	if !ok {
+		return nil
+	}
+	return &rate
+}
+
+// setRequestRate records the request rate of a model, or forgets it if rate is nil.
+func (e *Enforcer) setRequestRate(modelID, namespace string, rate *interfaces.RequestRate) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if rate == nil {
+		delete(e.requestRates, namespace+"/"+modelID)
+		return
 	}
+	e.requestRates[namespace+"/"+modelID] = *rate
+}
+
+// ForgetModel forgets the request rate of a model no longer referenced by a VA.
+func (e *Enforcer) ForgetModel(modelID, namespace string) {
+	e.setRequestRate(modelID, namespace, nil)
 }
 
 // EnforcePolicy applies scale-to-zero and minimum replica enforcement to saturation targets.
@@ -36,8 +86,9 @@ func NewEnforcer(requestCountFunc RequestCountFuncType) *Enforcer {
 // The logic is:
 // 1. If scale-to-zero is enabled for the model:
 //   - Query request count over retention period
-//   - If no requests: set all variant targets to 0
-//   - If requests exist: keep saturation targets unchanged
+//   - If the request rate is at or below the noise floor of the model (no requests by
+//     default): set all variant targets to 0
+//   - Otherwise: keep saturation targets unchanged
 //
 // 2. If scale-to-zero is disabled:
 //   - Ensure at least 1 replica across all variants
@@ -60,6 +111,7 @@ func (e *Enforcer) EnforcePolicy(
 	variantAnalyses []interfaces.VariantSaturationAnalysis,
 	scaleToZeroConfig config.ScaleToZeroConfigData,
 ) (map[string]int, bool) {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Check if scale-to-zero is enabled for this model
@@ -75,6 +127,7 @@ func (e *Enforcer) EnforcePolicy(
 	}
 
 	// Scale-to-zero disabled: ensure minimum replicas
+	e.setRequestRate(modelID, namespace, nil)
 	targets, applied := e.ensureMinimumReplicas(ctx, modelID, saturationTargets, variantAnalyses)
 	logger.V(logging.DEBUG).Info("Minimum replica policy enforced",
 		"modelID", modelID,
@@ -83,7 +136,8 @@ func (e *Enforcer) EnforcePolicy(
 	return targets, applied
 }
 
-// applyScaleToZero checks if the model has had any requests and scales to zero if idle.
+// applyScaleToZero checks if the model has had requests above its noise floor and scales to
+// zero if idle.
 func (e *Enforcer) applyScaleToZero(
 	ctx context.Context,
 	modelID string,
@@ -102,22 +156,43 @@ func (e *Enforcer) applyScaleToZero(
 		logger.Error(err, "Failed to get request count, keeping current targets",
 			"modelID", modelID,
 			"namespace", namespace)
+		e.setRequestRate(modelID, namespace, nil)
 		return targets, false
 	}
 
+	// Leave out the synthetic probes of the controller
+	if e.probeCountFunc != nil {
+		if probes := e.probeCountFunc(modelID, namespace, time.Now().Add(-retentionPeriod)); probes > 0 {
+			logger.V(logging.DEBUG).Info("Leaving synthetic probes out of the request count",
+				"modelID", modelID,
+				"namespace", namespace,
+				"requestCount", requestCount,
+				"probes", probes)
+			requestCount = max(requestCount-probes, 0)
+		}
+	}
+
+	// Treat traffic at or below the noise floor, e.g. health checks, as no traffic
+	rate := FilterRequestRate(requestCount/retentionPeriod.Minutes(),
+		config.ScaleToZeroNoiseFloor(scaleToZeroConfig, modelID))
+	e.setRequestRate(modelID, namespace, &rate)
+
 	// If there were requests in the retention period, keep saturation targets
-	if requestCount > 0 {
+	if rate.Filtered > 0 {
 		logger.V(logging.DEBUG).Info("Model has recent requests, keeping saturation targets",
 			"modelID", modelID,
 			"requestCount", requestCount,
+			"requestRate", rate.Observed,
 			"retentionPeriod", retentionPeriod)
 		return targets, false
 	}
 
 	// No requests: scale to zero
-	logger.Info("No requests in retention period, scaling to zero",
+	logger.Info("No requests above the noise floor in retention period, scaling to zero",
 		"modelID", modelID,
 		"namespace", namespace,
+		"requestRate", rate.Observed,
+		"noiseFloor", rate.NoiseFloor,
 		"retentionPeriod", retentionPeriod)
 
 	for variant := range targets {
@@ -127,6 +202,16 @@ func (e *Enforcer) applyScaleToZero(
 	return targets, true
 }
 
+// FilterRequestRate returns the request rate (requests/min) idle detection uses: the
+// observed rate, or 0 when it is at or below the noise floor.
+func FilterRequestRate(observed, noiseFloor float64) interfaces.RequestRate {
+	rate := interfaces.RequestRate{Observed: observed, NoiseFloor: noiseFloor, Filtered: observed}
+	if noiseFloor > 0 && observed <= noiseFloor {
+		rate.Filtered = 0
+	}
+	return rate
+}
+
 // ensureMinimumReplicas ensures at least 1 replica exists across all variants when scale-to-zero is disabled.
 func (e *Enforcer) ensureMinimumReplicas(
 	ctx context.Context,
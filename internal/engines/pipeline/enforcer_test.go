@@ -17,6 +17,21 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+var _ = Describe("FilterRequestRate", func() {
+	It("should keep rates above the noise floor", func() {
+		Expect(FilterRequestRate(2.5, 1).Filtered).To(Equal(2.5))
+	})
+
+	It("should zero rates at or below the noise floor", func() {
+		Expect(FilterRequestRate(1, 1).Filtered).To(Equal(0.0))
+		Expect(FilterRequestRate(0.2, 1).Filtered).To(Equal(0.0))
+	})
+
+	It("should keep any rate without a noise floor", func() {
+		Expect(FilterRequestRate(0.01, 0).Filtered).To(Equal(0.01))
+	})
+})
+
 var _ = Describe("Enforcer", func() {
 	var (
 		ctx             context.Context
@@ -107,6 +122,98 @@ var _ = Describe("Enforcer", func() {
 				})
 			})
 
+			Context("and requests are at or below the noise floor", func() {
+				BeforeEach(func() {
+					// 10 requests in 10 minutes: 1 request/min, e.g. health checks
+					enforcer = NewEnforcer(func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
+						return 10, nil
+					})
+					targets = map[string]int{
+						"variant-a": 2,
+						"variant-b": 1,
+					}
+				})
+
+				It("should scale all variants to zero and record the filtered rate", func() {
+					noiseFloor := 1.0
+					scaleToZeroConfig := config.ScaleToZeroConfigData{
+						config.GlobalDefaultsKey: {
+							RequestRateNoiseFloor: &noiseFloor,
+						},
+						"test-model": {
+							EnableScaleToZero: boolPtr(true),
+							RetentionPeriod:   "10m",
+						},
+					}
+
+					result, applied := enforcer.EnforcePolicy(
+						ctx,
+						"test-model",
+						"test-ns",
+						targets,
+						variantAnalyses,
+						scaleToZeroConfig,
+					)
+
+					Expect(applied).To(BeTrue())
+					Expect(result["variant-a"]).To(Equal(0))
+					Expect(enforcer.RequestRate("test-model", "test-ns")).To(Equal(&interfaces.RequestRate{
+						Observed:   1,
+						NoiseFloor: 1,
+						Filtered:   0,
+					}))
+
+					// the rate is forgotten once scale-to-zero is disabled
+					scaleToZeroConfig["test-model"] = config.ModelScaleToZeroConfig{EnableScaleToZero: boolPtr(false)}
+					enforcer.EnforcePolicy(ctx, "test-model", "test-ns", targets, variantAnalyses, scaleToZeroConfig)
+					Expect(enforcer.RequestRate("test-model", "test-ns")).To(BeNil())
+				})
+
+				It("should forget the rate of a model no longer referenced", func() {
+					scaleToZeroConfig := config.ScaleToZeroConfigData{
+						"test-model": {
+							EnableScaleToZero: boolPtr(true),
+							RetentionPeriod:   "10m",
+						},
+					}
+					enforcer.EnforcePolicy(ctx, "test-model", "test-ns", targets, variantAnalyses, scaleToZeroConfig)
+					Expect(enforcer.RequestRate("test-model", "test-ns")).NotTo(BeNil())
+
+					enforcer.ForgetModel("test-model", "test-ns")
+					Expect(enforcer.RequestRate("test-model", "test-ns")).To(BeNil())
+				})
+			})
+
+			Context("and all requests are synthetic probes", func() {
+				BeforeEach(func() {
+					enforcer = NewEnforcer(func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
+						return 20, nil
+					})
+					enforcer.SetProbeCounter(func(modelID, namespace string, since time.Time) float64 {
+						return 20
+					})
+					targets = map[string]int{
+						"variant-a": 2,
+						"variant-b": 1,
+					}
+				})
+
+				It("should scale all variants to zero", func() {
+					scaleToZeroConfig := config.ScaleToZeroConfigData{
+						"test-model": {
+							EnableScaleToZero: boolPtr(true),
+							RetentionPeriod:   "10m",
+						},
+					}
+
+					result, applied := enforcer.EnforcePolicy(ctx, "test-model", "test-ns", targets, variantAnalyses, scaleToZeroConfig)
+
+					Expect(applied).To(BeTrue())
+					Expect(result["variant-a"]).To(Equal(0))
+					Expect(enforcer.RequestRate("test-model", "test-ns").Observed).To(BeZero())
+				})
+			})
+
 			Context("and request count query fails", func() {
 				BeforeEach(func() {
 					enforcer = NewEnforcer(func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
@@ -0,0 +1,160 @@
+package pipeline
+
+import (
+	"math"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// ErrorBudget is the error budget of a service class over the tracking window.
+type ErrorBudget struct {
+	// ViolatedFraction is the fraction of the observed time the SLOs were violated (0.0-1.0).
+	ViolatedFraction float64
+	// BurnRate is the violated fraction relative to the one the objective allows:
+	// at 1, the budget is consumed exactly over the window.
+	BurnRate float64
+	// Remaining is the fraction of the budget of the window left (0.0-1.0).
+	Remaining float64
+}
+
+// ErrorBudgetTracker tracks the fraction of time the SLOs of each service class are
+// violated over a sliding window, against an objective: the fraction of time the SLOs
+// must be met. The budget of a window is the time the objective allows in violation.
+//
+// ErrorBudgetTracker keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type ErrorBudgetTracker struct {
+	window    time.Duration
+	objective float64
+	clock     func() time.Time
+
+	// samples of each service class, oldest first
+	samples map[string][]sloSample
+}
+
+// sloSample is the state of the SLOs of a service class, holding until the next sample.
+type sloSample struct {
+	at       time.Time
+	violated bool
+}
+
+// NewErrorBudgetTracker creates a tracker of the error budgets over the window, for
+// SLOs to be met the objective fraction of time (0.0-1.0).
+func NewErrorBudgetTracker(window time.Duration, objective float64) *ErrorBudgetTracker {
+	return &ErrorBudgetTracker{
+		window:    window,
+		objective: objective,
+		clock:     time.Now,
+		samples:   make(map[string][]sloSample),
+	}
+}
+
+// Record records whether the SLOs of a service class are violated. The state holds
+// until the next record of the class.
+func (t *ErrorBudgetTracker) Record(class string, violated bool) {
+	now := t.clock()
+	samples := append(t.samples[class], sloSample{at: now, violated: violated})
+
+	// keep the last sample before the window, whose state holds at its start
+	start := now.Add(-t.window)
+	first := 0
+	for first+1 < len(samples) && !samples[first+1].at.After(start) {
+		first++
+	}
+	t.samples[class] = samples[first:]
+}
+
+// Budget returns the error budget of a service class over the window, or false if the
+// class was not observed in the window.
+func (t *ErrorBudgetTracker) Budget(class string) (ErrorBudget, bool) {
+	samples := t.samples[class]
+	now := t.clock()
+	start := now.Add(-t.window)
+
+	var observed, violated time.Duration
+	for i, s := range samples {
+		from := s.at
+		if from.Before(start) {
+			from = start
+		}
+		to := now
+		if i+1 < len(samples) {
+			to = samples[i+1].at
+		}
+		if !to.After(from) {
+			continue
+		}
+		observed += to.Sub(from)
+		if s.violated {
+			violated += to.Sub(from)
+		}
+	}
+	if observed <= 0 {
+		return ErrorBudget{}, false
+	}
+
+	allowed := 1 - t.objective
+	budget := ErrorBudget{ViolatedFraction: float64(violated) / float64(observed)}
+	if allowed <= 0 {
+		if violated == 0 {
+			budget.Remaining = 1
+		}
+		return budget, true
+	}
+	budget.BurnRate = budget.ViolatedFraction / allowed
+	budget.Remaining = math.Max(0, 1-float64(violated)/(allowed*float64(t.window)))
+	return budget, true
+}
+
+// Classes returns the service classes with recorded samples.
+func (t *ErrorBudgetTracker) Classes() []string {
+	classes := make([]string, 0, len(t.samples))
+	for class := range t.samples {
+		classes = append(classes, class)
+	}
+	return classes
+}
+
+// Forget drops the samples of a service class no longer configured.
+func (t *ErrorBudgetTracker) Forget(class string) {
+	delete(t.samples, class)
+}
+
+// ErrorBudgetBias returns the factor scaling the saturation thresholds of a service class
+// given the remaining fraction of its error budget: 1-bias when it is below low, so that
+// the variants are over-provisioned before the budget runs out, 1+bias when it is above
+// high, so that ample budgets are spent on lower cost, and 1 otherwise.
+func ErrorBudgetBias(remaining, low, high, bias float64) float64 {
+	switch {
+	case remaining < low:
+		return 1 - bias
+	case remaining > high:
+		return 1 + bias
+	}
+	return 1
+}
+
+// BiasSaturationThresholds returns the config with its saturation thresholds scaled by
+// factor: below 1, replicas saturate and scale up earlier and scale down later. Thresholds
+// are kept within their valid ranges: utilization thresholds at most 1, the KV cache
+// threshold at least the KV spare trigger, and the scale-down boundary below the scale-up
+// threshold.
+func BiasSaturationThresholds(cfg interfaces.SaturationScalingConfig, factor float64) interfaces.SaturationScalingConfig {
+	if factor == 1 {
+		return cfg
+	}
+	biased := cfg
+	biased.KvCacheThreshold = math.Max(math.Min(cfg.KvCacheThreshold*factor, 1), cfg.KvSpareTrigger)
+	biased.QueueLengthThreshold = cfg.QueueLengthThreshold * factor
+	if cfg.ScaleUpThreshold > 0 {
+		biased.ScaleUpThreshold = math.Min(cfg.ScaleUpThreshold*factor, 1)
+	}
+	if cfg.ScaleDownBoundary > 0 {
+		biased.ScaleDownBoundary = cfg.ScaleDownBoundary * factor
+		if biased.ScaleUpThreshold > 0 && biased.ScaleDownBoundary >= biased.ScaleUpThreshold {
+			biased.ScaleDownBoundary = cfg.ScaleDownBoundary
+		}
+	}
+	return biased
+}
@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ErrorBudgetTracker", func() {
+	var (
+		now     time.Time
+		tracker *ErrorBudgetTracker
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		// 10% of the time in violation allowed: 6 minutes per hour
+		tracker = NewErrorBudgetTracker(time.Hour, 0.9)
+		tracker.clock = func() time.Time { return now }
+	})
+
+	It("should report no budget for classes not observed", func() {
+		_, ok := tracker.Budget("premium")
+		Expect(ok).To(BeFalse())
+
+		tracker.Record("premium", true)
+		_, ok = tracker.Budget("premium")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should consume the budget with the time in violation", func() {
+		tracker.Record("premium", false)
+		now = now.Add(9 * time.Minute)
+		tracker.Record("premium", true)
+		now = now.Add(3 * time.Minute)
+
+		budget, ok := tracker.Budget("premium")
+		Expect(ok).To(BeTrue())
+		Expect(budget.ViolatedFraction).To(BeNumerically("~", 0.25, 1e-9))
+		Expect(budget.BurnRate).To(BeNumerically("~", 2.5, 1e-9))
+		// 3 of the 6 minutes allowed per hour
+		Expect(budget.Remaining).To(BeNumerically("~", 0.5, 1e-9))
+
+		now = now.Add(10 * time.Minute)
+		budget, _ = tracker.Budget("premium")
+		Expect(budget.Remaining).To(Equal(0.0))
+	})
+
+	It("should only count the time within the window", func() {
+		tracker.Record("premium", true)
+		now = now.Add(30 * time.Minute)
+		tracker.Record("premium", false)
+		now = now.Add(50 * time.Minute)
+		tracker.Record("premium", false)
+
+		// 10 of the 30 minutes in violation are within the last hour
+		budget, ok := tracker.Budget("premium")
+		Expect(ok).To(BeTrue())
+		Expect(budget.ViolatedFraction).To(BeNumerically("~", 10.0/60, 1e-9))
+
+		// samples whose state no longer holds within the window are dropped
+		now = now.Add(20 * time.Minute)
+		tracker.Record("premium", false)
+		Expect(tracker.samples["premium"]).To(HaveLen(3))
+	})
+
+	It("should forget the samples of a forgotten class", func() {
+		tracker.Record("premium", true)
+		tracker.Record("standard", false)
+		Expect(tracker.Classes()).To(ConsistOf("premium", "standard"))
+
+		tracker.Forget("premium")
+		Expect(tracker.Classes()).To(ConsistOf("standard"))
+		now = now.Add(time.Minute)
+		_, ok := tracker.Budget("premium")
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("ErrorBudgetBias", func() {
+	It("should bias toward over-provisioning when the budget is nearly consumed", func() {
+		Expect(ErrorBudgetBias(0.1, 0.25, 0.75, 0.1)).To(Equal(0.9))
+	})
+
+	It("should bias toward cost when the budget is ample", func() {
+		Expect(ErrorBudgetBias(0.9, 0.25, 0.75, 0.1)).To(Equal(1.1))
+	})
+
+	It("should not bias in between", func() {
+		Expect(ErrorBudgetBias(0.5, 0.25, 0.75, 0.1)).To(Equal(1.0))
+	})
+})
+
+var _ = Describe("BiasSaturationThresholds", func() {
+	cfg := interfaces.SaturationScalingConfig{
+		KvCacheThreshold:     0.8,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.1,
+		ScaleUpThreshold:     0.95,
+		ScaleDownBoundary:    0.7,
+	}
+
+	It("should lower the thresholds", func() {
+		biased := BiasSaturationThresholds(cfg, 0.5)
+		Expect(biased.KvCacheThreshold).To(BeNumerically("~", 0.4, 1e-9))
+		Expect(biased.QueueLengthThreshold).To(BeNumerically("~", 2.5, 1e-9))
+		Expect(biased.ScaleUpThreshold).To(BeNumerically("~", 0.475, 1e-9))
+		Expect(biased.ScaleDownBoundary).To(BeNumerically("~", 0.35, 1e-9))
+		Expect(biased.KvSpareTrigger).To(Equal(0.1))
+	})
+
+	It("should keep the thresholds within their valid ranges", func() {
+		biased := BiasSaturationThresholds(cfg, 1.5)
+		Expect(biased.KvCacheThreshold).To(Equal(1.0))
+		Expect(biased.ScaleUpThreshold).To(Equal(1.0))
+		// 1.05 would reach the scale-up threshold
+		Expect(biased.ScaleDownBoundary).To(Equal(0.7))
+
+		biased = BiasSaturationThresholds(cfg, 0.1)
+		Expect(biased.KvCacheThreshold).To(Equal(0.1))
+	})
+})
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// ErrorRateVetoStepName is the DecisionStep name recorded when a scale-down is vetoed
+// because the error rate of the variant is elevated.
+const ErrorRateVetoStepName = "error-rate-veto"
+
+// VetoErrorRateScaleDowns holds the replicas of variants that would scale down while the
+// fraction of their requests finished by an error or abort exceeds threshold: requests
+// failing or aborted by clients on timeouts may indicate overload that the saturation
+// metrics do not show. A vetoed decision keeps the current replicas, is marked
+// ErrorRateVeto, and gets the veto as its reason. It returns the vetoed variants.
+func VetoErrorRateScaleDowns(ctx context.Context, decisions []interfaces.VariantDecision, threshold float64) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var vetoed []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.TargetReplicas >= d.CurrentReplicas || d.ErrorRate <= threshold {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		d.ErrorRateVeto = true
+		d.Reason = fmt.Sprintf("scale-down to %d replicas vetoed: error rate %.1f%% exceeds %.1f%%",
+			proposed, d.ErrorRate*100, threshold*100)
+		d.AddDecisionStep(ErrorRateVetoStepName, d.Reason, true)
+		vetoed = append(vetoed, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.Info("Scale-down vetoed by elevated error rate",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"errorRate", d.ErrorRate,
+			"threshold", threshold,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return vetoed
+}
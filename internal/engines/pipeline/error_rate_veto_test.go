@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("VetoErrorRateScaleDowns", func() {
+	var ctx context.Context
+
+	decision := func(current, target int, errorRate float64) []interfaces.VariantDecision {
+		action := interfaces.ActionScaleDown
+		if target > current {
+			action = interfaces.ActionScaleUp
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			ErrorRate:       errorRate,
+			Action:          action,
+			Reason:          "saturation-only mode: " + string(action),
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass scale-downs through with a low error rate", func() {
+		decisions := decision(4, 2, 0.01)
+		Expect(VetoErrorRateScaleDowns(ctx, decisions, 0.05)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].ErrorRateVeto).To(BeFalse())
+	})
+
+	It("should veto a scale-down with an elevated error rate", func() {
+		decisions := decision(4, 2, 0.12)
+		vetoed := VetoErrorRateScaleDowns(ctx, decisions, 0.05)
+		Expect(vetoed).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].ErrorRateVeto).To(BeTrue())
+		Expect(decisions[0].Reason).To(ContainSubstring("error rate 12.0%"))
+		Expect(decisions[0].LastStep().Name).To(Equal(ErrorRateVetoStepName))
+		Expect(decisions[0].LastStep().WasConstrained).To(BeTrue())
+	})
+
+	It("should not veto at exactly the threshold", func() {
+		decisions := decision(4, 2, 0.05)
+		Expect(VetoErrorRateScaleDowns(ctx, decisions, 0.05)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+	})
+
+	It("should leave scale-ups alone", func() {
+		decisions := decision(2, 4, 0.5)
+		Expect(VetoErrorRateScaleDowns(ctx, decisions, 0.05)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].ErrorRateVeto).To(BeFalse())
+	})
+})
@@ -41,9 +41,12 @@ func (g *GreedyBySaturation) Allocate(
 	g.sortByPriority(candidates)
 
 	// Allocate GPUs to each candidate in priority order
+	requested := make(map[*interfaces.VariantDecision]int, len(candidates))
 	for _, d := range candidates {
+		requested[d] = d.TargetReplicas
 		g.allocateForDecision(d, allocator)
 	}
+	explainLimits(candidates, requested, false)
 
 	return nil
 }
@@ -81,22 +84,17 @@ func (g *GreedyBySaturation) allocateForDecision(d *interfaces.VariantDecision,
 		return
 	}
 
-	gpusPerReplica := d.GPUsPerReplica
-	if gpusPerReplica <= 0 {
-		gpusPerReplica = 1 // Default to 1 GPU per replica if not specified
-	}
+	// Default to 1 GPU per replica if not specified
+	gpusPerReplica := d.ReplicaGPUs()
 
-	gpusRequested := replicasNeeded * gpusPerReplica
+	gpusRequested := float64(replicasNeeded) * gpusPerReplica
 	gpusAllocated, _ := allocator.TryAllocate(d, gpusRequested)
 
 	// Calculate how many replicas we can actually add
-	replicasAllocated := 0
-	if gpusPerReplica > 0 {
-		replicasAllocated = gpusAllocated / gpusPerReplica
-	}
+	replicasAllocated := replicasFor(gpusAllocated, gpusPerReplica)
 
 	// Update decision with actual allocation
-	d.GPUsAllocated = replicasAllocated * gpusPerReplica // Only count full replicas
+	d.GPUsAllocated = float64(replicasAllocated) * gpusPerReplica // Only count full replicas
 	d.TargetReplicas = d.CurrentReplicas + replicasAllocated
 
 	// Mark as limited if we couldn't allocate all requested
@@ -11,10 +11,10 @@ import (
 
 // simpleAllocator implements ResourceAllocator with a single pool
 type simpleAllocator struct {
-	remaining int
+	remaining float64
 }
 
-func (s *simpleAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested int) (int, error) {
+func (s *simpleAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested float64) (float64, error) {
 	if gpusRequested <= 0 {
 		return 0, nil
 	}
@@ -27,7 +27,7 @@ func (s *simpleAllocator) TryAllocate(decision *interfaces.VariantDecision, gpus
 	return allocated, nil
 }
 
-func (s *simpleAllocator) Remaining() int {
+func (s *simpleAllocator) Remaining() float64 {
 	return s.remaining
 }
 
@@ -69,9 +69,9 @@ var _ = Describe("GreedyBySaturation", func() {
 				err := algorithm.Allocate(ctx, decisions, allocator)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(decisions[0].GPUsAllocated).To(Equal(4)) // 2 replicas * 2 GPUs
+				Expect(decisions[0].GPUsAllocated).To(Equal(4.0)) // 2 replicas * 2 GPUs
 				Expect(decisions[0].TargetReplicas).To(Equal(4))
-				Expect(allocator.Remaining()).To(Equal(6)) // 10 - 4
+				Expect(allocator.Remaining()).To(Equal(6.0)) // 10 - 4
 			})
 		})
 
@@ -128,17 +128,17 @@ var _ = Describe("GreedyBySaturation", func() {
 				}
 
 				// Most saturated gets full allocation
-				Expect(v2.GPUsAllocated).To(Equal(2))
+				Expect(v2.GPUsAllocated).To(Equal(2.0))
 				Expect(v2.TargetReplicas).To(Equal(2))
 				Expect(v2.WasLimited).To(BeFalse())
 
 				// Second most saturated gets full allocation
-				Expect(v1.GPUsAllocated).To(Equal(2))
+				Expect(v1.GPUsAllocated).To(Equal(2.0))
 				Expect(v1.TargetReplicas).To(Equal(2))
 				Expect(v1.WasLimited).To(BeFalse())
 
 				// Least saturated gets remaining (2 GPUs)
-				Expect(v3.GPUsAllocated).To(Equal(2))
+				Expect(v3.GPUsAllocated).To(Equal(2.0))
 				Expect(v3.TargetReplicas).To(Equal(2))
 			})
 		})
@@ -162,8 +162,8 @@ var _ = Describe("GreedyBySaturation", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// Only 3 GPUs available, 2 GPUs per replica = 1 replica can be added
-				Expect(decisions[0].GPUsAllocated).To(Equal(2))  // Only full replicas count
-				Expect(decisions[0].TargetReplicas).To(Equal(2)) // 1 + 1 replica
+				Expect(decisions[0].GPUsAllocated).To(Equal(2.0)) // Only full replicas count
+				Expect(decisions[0].TargetReplicas).To(Equal(2))  // 1 + 1 replica
 				Expect(decisions[0].WasLimited).To(BeTrue())
 			})
 		})
@@ -193,9 +193,9 @@ var _ = Describe("GreedyBySaturation", func() {
 				err := algorithm.Allocate(ctx, decisions, allocator)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(decisions[0].GPUsAllocated).To(Equal(0))
-				Expect(decisions[1].GPUsAllocated).To(Equal(0))
-				Expect(allocator.Remaining()).To(Equal(10)) // No GPUs consumed
+				Expect(decisions[0].GPUsAllocated).To(Equal(0.0))
+				Expect(decisions[1].GPUsAllocated).To(Equal(0.0))
+				Expect(allocator.Remaining()).To(Equal(10.0)) // No GPUs consumed
 			})
 		})
 
@@ -236,11 +236,11 @@ var _ = Describe("GreedyBySaturation", func() {
 				}
 
 				// Cheaper variant gets full allocation first
-				Expect(cheap.GPUsAllocated).To(Equal(2))
+				Expect(cheap.GPUsAllocated).To(Equal(2.0))
 				Expect(cheap.TargetReplicas).To(Equal(2))
 
 				// Expensive variant gets remaining
-				Expect(expensive.GPUsAllocated).To(Equal(2))
+				Expect(expensive.GPUsAllocated).To(Equal(2.0))
 				Expect(expensive.TargetReplicas).To(Equal(2))
 			})
 		})
@@ -264,11 +264,46 @@ var _ = Describe("GreedyBySaturation", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				// Should allocate 1 GPU for the +1 replica
-				Expect(decisions[0].GPUsAllocated).To(Equal(1))
+				Expect(decisions[0].GPUsAllocated).To(Equal(1.0))
 				Expect(decisions[0].TargetReplicas).To(Equal(2))
 			})
 		})
 
+		Context("with replicas sharing GPUs", func() {
+			BeforeEach(func() {
+				allocator = &simpleAllocator{remaining: 1.7}
+				decisions = []*interfaces.VariantDecision{
+					{
+						VariantName:     "v1",
+						CurrentReplicas: 1,
+						TargetReplicas:  6, // wants +5 (5/3 GPUs with 3 containers per GPU)
+						GPUsPerReplica:  1.0 / 3,
+						SpareCapacity:   0.1,
+					},
+					{
+						VariantName:     "v2",
+						CurrentReplicas: 1,
+						TargetReplicas:  2, // wants +1 (0.5 GPUs with 2 containers per GPU)
+						GPUsPerReplica:  0.5,
+						SpareCapacity:   0.2,
+					},
+				}
+			})
+
+			It("should aggregate fractional GPUs without rounding", func() {
+				err := algorithm.Allocate(ctx, decisions, allocator)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(decisions[0].TargetReplicas).To(Equal(6))
+				Expect(decisions[0].GPUsAllocated).To(BeNumerically("~", 5.0/3, 1e-9))
+				Expect(decisions[0].WasLimited).To(BeFalse())
+				// Only 0.03 GPUs remain: not enough for half a GPU
+				Expect(decisions[1].TargetReplicas).To(Equal(1))
+				Expect(decisions[1].GPUsAllocated).To(Equal(0.0))
+				Expect(decisions[1].WasLimited).To(BeTrue())
+			})
+		})
+
 		Context("with empty decisions", func() {
 			It("should return without error", func() {
 				allocator = &simpleAllocator{remaining: 10}
@@ -276,5 +311,48 @@ var _ = Describe("GreedyBySaturation", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("explaining limits", func() {
+			decision := func(name string, spare float64) *interfaces.VariantDecision {
+				return &interfaces.VariantDecision{
+					VariantName:     name,
+					AcceleratorName: "A100",
+					CurrentReplicas: 1,
+					TargetReplicas:  4, // wants +3
+					GPUsPerReplica:  1,
+					SpareCapacity:   spare,
+				}
+			}
+
+			It("should report insufficient capacity when no other variant got the GPUs", func() {
+				decisions = []*interfaces.VariantDecision{decision("v1", 0.1)}
+				Expect(algorithm.Allocate(ctx, decisions, &simpleAllocator{remaining: 1})).To(Succeed())
+
+				Expect(decisions[0].WasLimited).To(BeTrue())
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonInsufficientCapacity))
+				Expect(decisions[0].LimitMessage).To(Equal("granted 1 of 3 replicas: insufficient capacity of type A100"))
+			})
+
+			It("should report priority preemption when a more saturated variant got the GPUs", func() {
+				decisions = []*interfaces.VariantDecision{decision("idle", 0.5), decision("busy", 0.0)}
+				Expect(algorithm.Allocate(ctx, decisions, &simpleAllocator{remaining: 4})).To(Succeed())
+
+				Expect(decisions[1].WasLimited).To(BeFalse())
+				Expect(decisions[1].LimitReason).To(BeEmpty())
+				Expect(decisions[0].WasLimited).To(BeTrue())
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonPriorityPreemption))
+				Expect(decisions[0].LimitMessage).To(ContainSubstring("1 higher-priority variants"))
+			})
+
+			It("should not attribute limits to variants of other accelerator types", func() {
+				other := decision("h100", 0.0)
+				other.AcceleratorName = "H100"
+				decisions = []*interfaces.VariantDecision{decision("v1", 0.5), other}
+				Expect(algorithm.Allocate(ctx, decisions, &simpleAllocator{remaining: 4})).To(Succeed())
+
+				Expect(decisions[0].WasLimited).To(BeTrue())
+				Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonInsufficientCapacity))
+			})
+		})
 	})
 })
@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// HPABoundsStepName is the DecisionStep name recorded when the bounds of the
+// HorizontalPodAutoscaler of the scale target clamp the target.
+const HPABoundsStepName = "hpa-bounds"
+
+// CheckHPABounds marks decisions whose target lies outside the minReplicas/maxReplicas of
+// the HorizontalPodAutoscaler of their scale target, e.g. because the bounds were edited
+// after deployment. The HPA would silently clamp such a target, so it is flagged as a
+// BoundsConflict instead. The target itself is left unchanged: it remains the
+// recommendation emitted to the HPA. It returns the variants in conflict.
+func CheckHPABounds(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var conflicts []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.HPAName == "" {
+			continue
+		}
+		clamped := min(max(d.TargetReplicas, d.HPAMinReplicas), d.HPAMaxReplicas)
+		if clamped == d.TargetReplicas {
+			continue
+		}
+
+		d.BoundsConflict = true
+		d.AddDecisionStep(HPABoundsStepName,
+			fmt.Sprintf("HorizontalPodAutoscaler %s bounds [%d, %d] clamp the target to %d replicas",
+				d.HPAName, d.HPAMinReplicas, d.HPAMaxReplicas, clamped),
+			false)
+		conflicts = append(conflicts, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.Info("Target outside HorizontalPodAutoscaler bounds",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"hpa", d.HPAName,
+			"minReplicas", d.HPAMinReplicas,
+			"maxReplicas", d.HPAMaxReplicas,
+			"target", d.TargetReplicas)
+	}
+	return conflicts
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("CheckHPABounds", func() {
+	var ctx context.Context
+
+	decision := func(target, hpaMin, hpaMax int) []interfaces.VariantDecision {
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: 3,
+			TargetReplicas:  target,
+			HPAName:         "variant-a-hpa",
+			HPAMinReplicas:  hpaMin,
+			HPAMaxReplicas:  hpaMax,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should ignore variants without an HPA", func() {
+		decisions := decision(20, 1, 10)
+		decisions[0].HPAName = ""
+		Expect(CheckHPABounds(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].BoundsConflict).To(BeFalse())
+	})
+
+	It("should accept targets within the bounds", func() {
+		for _, target := range []int{1, 5, 10} {
+			decisions := decision(target, 1, 10)
+			Expect(CheckHPABounds(ctx, decisions)).To(BeEmpty())
+			Expect(decisions[0].BoundsConflict).To(BeFalse())
+		}
+	})
+
+	It("should flag a target above maxReplicas", func() {
+		decisions := decision(12, 1, 10)
+		conflicts := CheckHPABounds(ctx, decisions)
+		Expect(conflicts).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].BoundsConflict).To(BeTrue())
+		Expect(decisions[0].TargetReplicas).To(Equal(12))
+		Expect(decisions[0].LastStep().Name).To(Equal(HPABoundsStepName))
+		Expect(decisions[0].LastStep().Reason).To(ContainSubstring("to 10 replicas"))
+	})
+
+	It("should flag a target below minReplicas", func() {
+		decisions := decision(0, 1, 10)
+		Expect(CheckHPABounds(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].BoundsConflict).To(BeTrue())
+		Expect(decisions[0].TargetReplicas).To(Equal(0))
+	})
+})
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// explainLimits sets LimitReason and LimitMessage on the candidates an allocation
+// algorithm limited, given the candidates in allocation order and their requested targets.
+//
+// A limited candidate is explained by the other candidates that were granted GPUs of its
+// accelerator type: by other tenants if byTenant is set (TenantQuota), otherwise by
+// candidates allocated before it (PriorityPreemption). If there are none, the accelerator
+// type simply did not have enough free GPUs (InsufficientCapacity).
+func explainLimits(candidates []*interfaces.VariantDecision, requested map[*interfaces.VariantDecision]int, byTenant bool) {
+	for i, d := range candidates {
+		if !d.WasLimited {
+			continue
+		}
+		granted := d.TargetReplicas - d.CurrentReplicas
+		wanted := requested[d] - d.CurrentReplicas
+
+		otherTenants, earlier := 0, 0
+		for j, other := range candidates {
+			if other == d || other.AcceleratorName != d.AcceleratorName || other.GPUsAllocated == 0 {
+				continue
+			}
+			if DecisionTenant(other) != DecisionTenant(d) {
+				otherTenants++
+			}
+			if j < i {
+				earlier++
+			}
+		}
+
+		switch {
+		case byTenant && otherTenants > 0:
+			d.LimitReason = interfaces.LimitReasonTenantQuota
+			d.LimitMessage = fmt.Sprintf("granted %d of %d replicas: fair share of %s GPUs reached, %d variants of other tenants were allocated",
+				granted, wanted, d.AcceleratorName, otherTenants)
+		case earlier > 0:
+			d.LimitReason = interfaces.LimitReasonPriorityPreemption
+			d.LimitMessage = fmt.Sprintf("granted %d of %d replicas: %s GPUs went to %d higher-priority variants",
+				granted, wanted, d.AcceleratorName, earlier)
+		default:
+			d.LimitReason = interfaces.LimitReasonInsufficientCapacity
+			d.LimitMessage = fmt.Sprintf("granted %d of %d replicas: insufficient capacity of type %s",
+				granted, wanted, d.AcceleratorName)
+		}
+	}
+}
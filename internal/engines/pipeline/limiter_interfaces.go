@@ -110,6 +110,9 @@ type AllocationAlgorithm interface {
 	// Algorithms write to decisions:
 	//   - TargetReplicas: may be reduced if allocation is partial
 	//   - GPUsAllocated: number of GPUs actually allocated
+	//
+	// GPUs are fractional when replicas share GPUs: algorithms must aggregate them
+	// without rounding, and only count full replicas from the GPUs allocated.
 	Allocate(
 		ctx context.Context,
 		decisions []*interfaces.VariantDecision,
@@ -122,6 +125,9 @@ type AllocationAlgorithm interface {
 // Created by Inventory to handle granularity-specific allocation logic.
 // Algorithms use this interface without knowing the underlying inventory type.
 //
+// GPUs are physical GPUs, fractional when the replicas of a variant share GPUs with
+// time-slicing or MPS.
+//
 // For example:
 //   - ClusterInventory creates an allocator that tracks total GPUs
 //   - TypeInventory creates an allocator that tracks GPUs per accelerator type
@@ -132,17 +138,17 @@ type ResourceAllocator interface {
 	//
 	// The decision parameter provides context (AcceleratorName, GPUsPerReplica,
 	// ScaleTargetRef) that some allocators need for type-aware or node-aware allocation.
-	TryAllocate(decision *interfaces.VariantDecision, gpusRequested int) (gpusAllocated int, err error)
+	TryAllocate(decision *interfaces.VariantDecision, gpusRequested float64) (gpusAllocated float64, err error)
 
 	// Remaining returns total remaining allocatable GPUs across all resources.
-	Remaining() int
+	Remaining() float64
 }
 
 // ResourcePool represents available resources for one accelerator type.
 type ResourcePool struct {
-	Limit     int // total capacity (from cluster discovery)
-	Used      int // currently in use
-	Available int // Limit - Used
+	Limit     int     // total capacity (from cluster discovery)
+	Used      float64 // currently in use, fractional with shared GPUs
+	Available float64 // Limit - Used
 }
 
 // ResourceConstraints represents hard resource constraints from a single provider.
@@ -151,8 +157,8 @@ type ResourceConstraints struct {
 	ProviderName string                  // e.g., "gpu-limiter", "quota-limiter"
 	Pools        map[string]ResourcePool // accelerator type → pool
 	TotalLimit   int
-	TotalUsed    int
-	TotalAvail   int
+	TotalUsed    float64
+	TotalAvail   float64
 }
 
 // ConstraintProvider exposes hard constraints for the optimizer.
@@ -167,7 +173,29 @@ type ConstraintProvider interface {
 
 	// ComputeConstraints refreshes resource data and returns hard constraints.
 	// currentUsage maps accelerator type → GPUs currently in use.
-	ComputeConstraints(ctx context.Context, currentUsage map[string]int) (*ResourceConstraints, error)
+	ComputeConstraints(ctx context.Context, currentUsage map[string]float64) (*ResourceConstraints, error)
+}
+
+// PoolCoordinator shares GPU pools with other controller instances allocating from them.
+//
+// Inventory limits are discovered cluster-wide, while the used GPUs a limiter computes are
+// only those of its own decisions: without coordination, each instance sharing a pool
+// would allocate the same free GPUs. A PoolCoordinator reports the GPUs of each pool held
+// by the other instances, which the limiter counts as used. GPUs are coordinated in whole
+// GPUs: the limiter rounds the shared GPUs it uses and requests up.
+type PoolCoordinator interface {
+	// Name returns coordinator identifier for logging/metrics.
+	Name() string
+
+	// Reserve returns the GPUs held by the other instances per accelerator type, given
+	// the limits of the pools and the GPUs used and requested by this instance. The GPUs
+	// requested are held for this instance until Commit, so that concurrent reservations
+	// of the other instances see them.
+	Reserve(ctx context.Context, limits, used, requested map[string]int) (held map[string]int, err error)
+
+	// Commit records the GPUs used by this instance and the ones granted by the allocation,
+	// held until the next Reserve.
+	Commit(ctx context.Context, used, granted map[string]int) error
 }
 
 // Inventory provides resource availability and creates allocators.
@@ -199,8 +227,8 @@ type Inventory interface {
 
 	// SetUsed updates the used GPU counts.
 	// This should be called with current usage before creating an allocator.
-	// The usedByType map contains accelerator type -> used GPU count.
-	SetUsed(usedByType map[string]int)
+	// The usedByType map contains accelerator type -> used GPUs, fractional with shared GPUs.
+	SetUsed(usedByType map[string]float64)
 
 	// CreateAllocator returns a ResourceAllocator for this inventory.
 	// The allocator encapsulates granularity-specific allocation logic.
@@ -211,10 +239,10 @@ type Inventory interface {
 	TotalLimit() int
 
 	// TotalUsed returns total GPUs currently in use across all resources.
-	TotalUsed() int
+	TotalUsed() float64
 
 	// TotalAvailable returns total available GPUs (Limit - Used).
-	TotalAvailable() int
+	TotalAvailable() float64
 
 	// GetResourcePools returns per-type resource availability.
 	// Each key is an accelerator type (e.g., "A100", "H100").
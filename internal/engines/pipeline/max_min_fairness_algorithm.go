@@ -0,0 +1,154 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// MaxMinFairness shares scarce resources across tenants with max-min fairness.
+//
+// Algorithm (progressive filling at replica granularity):
+//  1. Filter decisions that need scale-up (TargetReplicas > CurrentReplicas)
+//  2. Group them by tenant (Tenant, falling back to Namespace)
+//  3. Repeatedly grant one replica to the tenant with the fewest GPUs granted so far
+//  4. Within a tenant, replicas go to the most saturated variant first (as GreedyBySaturation)
+//  5. A tenant drops out once its demand is met or none of its variants can be allocated
+//
+// Tenants asking for less than their fair share are fully served; the remaining
+// tenants are shrunk to equal aggregate allocations, so no single tenant's
+// high-priority variants can starve everyone else.
+type MaxMinFairness struct{}
+
+// NewMaxMinFairness creates a new max-min fairness algorithm.
+func NewMaxMinFairness() *MaxMinFairness {
+	return &MaxMinFairness{}
+}
+
+// Name returns the algorithm identifier.
+func (m *MaxMinFairness) Name() string {
+	return "max-min-fairness"
+}
+
+// tenantShare tracks the allocation state of one tenant during Allocate.
+type tenantShare struct {
+	name       string
+	candidates []*interfaces.VariantDecision // in greedy-by-saturation order
+	granted    float64                       // GPUs granted so far
+}
+
+// Allocate distributes available resources across tenants with max-min fairness.
+func (m *MaxMinFairness) Allocate(
+	ctx context.Context,
+	decisions []*interfaces.VariantDecision,
+	allocator ResourceAllocator,
+) error {
+	greedy := NewGreedyBySaturation()
+	candidates := greedy.filterScaleUpCandidates(decisions)
+	greedy.sortByPriority(candidates)
+
+	// Start from the current replicas; replicas are granted one at a time
+	requested := make(map[*interfaces.VariantDecision]int, len(candidates))
+	tenants := make(map[string]*tenantShare)
+	for _, d := range candidates {
+		requested[d] = d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.GPUsAllocated = 0
+
+		name := DecisionTenant(d)
+		if _, ok := tenants[name]; !ok {
+			tenants[name] = &tenantShare{name: name}
+		}
+		tenants[name].candidates = append(tenants[name].candidates, d)
+	}
+
+	active := make([]*tenantShare, 0, len(tenants))
+	for _, t := range tenants {
+		active = append(active, t)
+	}
+
+	for len(active) > 0 && allocator.Remaining() > gpuEpsilon {
+		// Least-served tenant first (ties by name for deterministic results)
+		sort.Slice(active, func(i, j int) bool {
+			if active[i].granted != active[j].granted {
+				return active[i].granted < active[j].granted
+			}
+			return active[i].name < active[j].name
+		})
+
+		t := active[0]
+		if !m.grantReplica(t, requested, allocator) {
+			active = active[1:]
+		}
+	}
+
+	for _, d := range candidates {
+		if d.TargetReplicas < requested[d] {
+			d.WasLimited = true
+		}
+	}
+	explainLimits(candidates, requested, true)
+	return nil
+}
+
+// grantReplica allocates one more replica to the first variant of the tenant that
+// still needs one. Variants that cannot get a full replica are dropped from the
+// tenant. Returns false if nothing could be granted to the tenant.
+func (m *MaxMinFairness) grantReplica(
+	t *tenantShare,
+	requested map[*interfaces.VariantDecision]int,
+	allocator ResourceAllocator,
+) bool {
+	for len(t.candidates) > 0 {
+		d := t.candidates[0]
+		if d.TargetReplicas >= requested[d] {
+			t.candidates = t.candidates[1:]
+			continue
+		}
+
+		// Default to 1 GPU per replica if not specified
+		gpusPerReplica := d.ReplicaGPUs()
+		allocated, _ := allocator.TryAllocate(d, gpusPerReplica)
+		if replicasFor(allocated, gpusPerReplica) < 1 {
+			// Partial allocations cannot host a replica
+			t.candidates = t.candidates[1:]
+			continue
+		}
+
+		d.TargetReplicas++
+		d.GPUsAllocated += gpusPerReplica
+		t.granted += gpusPerReplica
+		return true
+	}
+	return false
+}
+
+// DecisionTenant returns the tenant a decision is accounted to for fair sharing.
+func DecisionTenant(d *interfaces.VariantDecision) string {
+	if d.Tenant != "" {
+		return d.Tenant
+	}
+	return d.Namespace
+}
+
+// TenantShortfalls returns, per tenant, the GPUs requested by scale-up decisions
+// that the limiter could not grant. Tenants without a shortfall are included with 0
+// so that a previously reported shortfall can be cleared. Shortfalls are fractional
+// when the replicas of a variant share GPUs.
+func TenantShortfalls(decisions []*interfaces.VariantDecision) map[string]float64 {
+	shortfalls := make(map[string]float64)
+	for _, d := range decisions {
+		tenant := DecisionTenant(d)
+		if _, ok := shortfalls[tenant]; !ok {
+			shortfalls[tenant] = 0
+		}
+		if missing := d.OriginalTargetReplicas - d.TargetReplicas; d.WasLimited && missing > 0 {
+			shortfalls[tenant] += float64(missing) * d.ReplicaGPUs()
+		}
+	}
+	return shortfalls
+}
+
+// Ensure MaxMinFairness implements AllocationAlgorithm interface
+var _ AllocationAlgorithm = (*MaxMinFairness)(nil)
@@ -0,0 +1,185 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("MaxMinFairness", func() {
+	var (
+		ctx       context.Context
+		algorithm *MaxMinFairness
+	)
+
+	decision := func(name, tenant string, current, target int, gpusPerReplica, spare float64) *interfaces.VariantDecision {
+		return &interfaces.VariantDecision{
+			VariantName:            name,
+			Namespace:              "ns-" + tenant,
+			Tenant:                 tenant,
+			CurrentReplicas:        current,
+			TargetReplicas:         target,
+			OriginalTargetReplicas: target,
+			GPUsPerReplica:         gpusPerReplica,
+			SpareCapacity:          spare,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		algorithm = NewMaxMinFairness()
+	})
+
+	It("should return the algorithm name", func() {
+		Expect(algorithm.Name()).To(Equal("max-min-fairness"))
+	})
+
+	It("should grant all requests when capacity is sufficient", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 1, 3, 1, 0.1),
+			decision("b1", "team-b", 1, 2, 2, 0.2),
+		}
+		allocator := &simpleAllocator{remaining: 10}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].GPUsAllocated).To(Equal(2.0))
+		Expect(decisions[1].TargetReplicas).To(Equal(2))
+		Expect(decisions[1].GPUsAllocated).To(Equal(2.0))
+		Expect(decisions[0].WasLimited).To(BeFalse())
+		Expect(decisions[1].WasLimited).To(BeFalse())
+		Expect(allocator.Remaining()).To(Equal(6.0))
+	})
+
+	It("should not let one tenant's saturated variants starve another tenant", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 0, 6, 1, 0.0), // most saturated
+			decision("a2", "team-a", 0, 4, 1, 0.05),
+			decision("b1", "team-b", 0, 4, 1, 0.5),
+		}
+		allocator := &simpleAllocator{remaining: 6}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		// team-a and team-b share 6 GPUs equally; within team-a the most saturated goes first
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[1].TargetReplicas).To(Equal(0))
+		Expect(decisions[2].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].WasLimited).To(BeTrue())
+		Expect(decisions[2].WasLimited).To(BeTrue())
+	})
+
+	It("should give unused share of small tenants to the others", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 0, 8, 1, 0.0),
+			decision("b1", "team-b", 0, 1, 1, 0.1),
+			decision("c1", "team-c", 0, 8, 1, 0.2),
+		}
+		allocator := &simpleAllocator{remaining: 9}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[1].TargetReplicas).To(Equal(1))
+		Expect(decisions[1].WasLimited).To(BeFalse())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[2].TargetReplicas).To(Equal(4))
+	})
+
+	It("should fall back to the namespace when no tenant is set", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "", 0, 4, 1, 0.0),
+			decision("b1", "", 0, 4, 1, 0.1),
+		}
+		decisions[0].Namespace = "ns-a"
+		decisions[1].Namespace = "ns-b"
+		allocator := &simpleAllocator{remaining: 4}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[1].TargetReplicas).To(Equal(2))
+	})
+
+	It("should only grant whole replicas", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 1, 3, 4, 0.0),
+		}
+		allocator := &simpleAllocator{remaining: 6}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].GPUsAllocated).To(Equal(4.0))
+		Expect(decisions[0].WasLimited).To(BeTrue())
+	})
+
+	It("should leave scale-down and no-change decisions untouched", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 3, 1, 1, 0.9),
+			decision("b1", "team-b", 2, 2, 1, 0.5),
+		}
+		allocator := &simpleAllocator{remaining: 0}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+		Expect(decisions[1].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].WasLimited).To(BeFalse())
+	})
+
+	It("should explain limits caused by other tenants' fair share as a tenant quota", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 0, 6, 1, 0.0),
+			decision("b1", "team-b", 0, 6, 1, 0.1),
+		}
+		allocator := &simpleAllocator{remaining: 4}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		for _, d := range decisions {
+			Expect(d.WasLimited).To(BeTrue())
+			Expect(d.LimitReason).To(Equal(interfaces.LimitReasonTenantQuota))
+			Expect(d.LimitMessage).To(HavePrefix("granted 2 of 6 replicas: fair share"))
+		}
+	})
+
+	It("should explain a lone limited tenant as insufficient capacity", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 0, 6, 1, 0.0),
+		}
+		allocator := &simpleAllocator{remaining: 2}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		Expect(decisions[0].LimitReason).To(Equal(interfaces.LimitReasonInsufficientCapacity))
+	})
+
+	It("should share fractional GPUs of replicas sharing GPUs", func() {
+		decisions := []*interfaces.VariantDecision{
+			decision("a1", "team-a", 0, 4, 0.5, 0.0),
+			decision("b1", "team-b", 0, 4, 0.25, 0.1),
+		}
+		allocator := &simpleAllocator{remaining: 1.75}
+		Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+		// team-b is granted quarter GPUs until it catches up with the half GPUs of team-a
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].GPUsAllocated).To(Equal(1.0))
+		Expect(decisions[1].TargetReplicas).To(Equal(3))
+		Expect(decisions[1].GPUsAllocated).To(Equal(0.75))
+		Expect(allocator.Remaining()).To(Equal(0.0))
+	})
+
+	Describe("TenantShortfalls", func() {
+		It("should report the GPUs not granted per tenant", func() {
+			decisions := []*interfaces.VariantDecision{
+				decision("a1", "team-a", 0, 6, 2, 0.0),
+				decision("a2", "team-a", 0, 2, 1, 0.1),
+				decision("b1", "team-b", 0, 1, 1, 0.2),
+			}
+			allocator := &simpleAllocator{remaining: 5}
+			Expect(algorithm.Allocate(ctx, decisions, allocator)).To(Succeed())
+
+			shortfalls := TenantShortfalls(decisions)
+			Expect(shortfalls).To(HaveKeyWithValue("team-b", 0.0))
+			// team-a got 4 GPUs: 2 replicas of a1; a1 misses 4 replicas, a2 misses 2
+			Expect(shortfalls).To(HaveKeyWithValue("team-a", 10.0))
+		})
+	})
+})
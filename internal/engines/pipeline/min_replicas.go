@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// MinReplicasStepName is the DecisionStep name recorded when a target is raised to the
+// spec.minReplicas of its VariantAutoscaling.
+const MinReplicasStepName = "min-replicas"
+
+// ApplyMinReplicas raises targets below the spec.minReplicas of their VariantAutoscaling,
+// which users and generic tools write through the scale subresource. The override takes
+// precedence over the scale-to-zero enforcer and the stages holding targets, but not over
+// resource limiting, which runs after it. It returns the variants whose target was raised.
+func ApplyMinReplicas(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var raised []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.TargetReplicas >= d.MinReplicas {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.MinReplicas
+		switch {
+		case d.TargetReplicas > d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleUp
+		case d.TargetReplicas == d.CurrentReplicas:
+			d.Action = interfaces.ActionNoChange
+		}
+		d.AddDecisionStep(MinReplicasStepName,
+			fmt.Sprintf("target of %d replicas raised to spec.minReplicas %d", proposed, d.MinReplicas),
+			true)
+		raised = append(raised, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.V(logging.DEBUG).Info("Target raised to minimum replicas",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"minReplicas", d.MinReplicas,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return raised
+}
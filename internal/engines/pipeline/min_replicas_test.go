@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ApplyMinReplicas", func() {
+	var ctx context.Context
+
+	decision := func(current, target, minReplicas int) []interfaces.VariantDecision {
+		action := interfaces.ActionScaleDown
+		if target > current {
+			action = interfaces.ActionScaleUp
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			MinReplicas:     minReplicas,
+			Action:          action,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass targets through without minimum replicas", func() {
+		decisions := decision(2, 0, 0)
+		Expect(ApplyMinReplicas(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(0))
+	})
+
+	It("should pass targets through at or above the minimum replicas", func() {
+		decisions := decision(2, 3, 3)
+		Expect(ApplyMinReplicas(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should raise a scale-down to the minimum replicas", func() {
+		decisions := decision(4, 1, 2)
+		raised := ApplyMinReplicas(ctx, decisions)
+		Expect(raised).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleDown))
+		Expect(decisions[0].LastStep().Name).To(Equal(MinReplicasStepName))
+	})
+
+	It("should scale up to minimum replicas above the current replicas", func() {
+		decisions := decision(1, 0, 3)
+		ApplyMinReplicas(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+	})
+
+	It("should hold at the current replicas when they are the minimum", func() {
+		decisions := decision(2, 1, 2)
+		ApplyMinReplicas(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+	})
+
+	It("should leave minimum replicas exceeding the available GPUs to the GPU limiter", func() {
+		decisions := decision(1, 1, 4)
+		decisions[0].AcceleratorName = "A100"
+		decisions[0].GPUsPerReplica = 1
+		limiter := NewDefaultLimiter("gpu-limiter", newMockInventory("test", map[string]int{"A100": 2}), NewGreedyBySaturation())
+
+		// The floor is applied before limiting, so the limiter accounts for it
+		ApplyMinReplicas(ctx, decisions)
+		decisions[0].OriginalTargetReplicas = decisions[0].TargetReplicas
+		Expect(limiter.Limit(ctx, []*interfaces.VariantDecision{&decisions[0]})).To(Succeed())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].WasLimited).To(BeTrue())
+	})
+})
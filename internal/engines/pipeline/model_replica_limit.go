@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// ModelReplicaLimitStepName is the DecisionStep name recorded when a target is reduced to
+// keep its model within its replica limit.
+const ModelReplicaLimitStepName = "model-replica-limit"
+
+// ApplyModelReplicaLimits keeps the total targets of models with a replica limit, summed
+// across all their variants and namespaces, within the limit, e.g. the concurrent
+// instances a license allows. The limit is a policy the cluster operator sets, so it takes
+// precedence over the stages raising targets (spec.minReplicas, PodDisruptionBudgets and
+// drain surges) and must run after them. Within the limit, each variant first keeps up to
+// its current replicas, so that scale-ups never take replicas from running variants; the
+// rest goes to scale-ups, one replica at a time to the variant missing the most replicas.
+// When the current replicas alone exceed the limit, replicas are taken one at a time from
+// the variant keeping the most. Reduced decisions are marked PolicyCapped. It returns the
+// variants whose target was reduced.
+func ApplyModelReplicaLimits(ctx context.Context, decisions []interfaces.VariantDecision,
+	limits config.ModelReplicaLimits) []types.NamespacedName {
+
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	models := make(map[string][]int)
+	var modelIDs []string
+	for i := range decisions {
+		d := &decisions[i]
+		d.PolicyCapped = false
+		if _, ok := limits[d.ModelID]; !ok || d.ModelID == "" {
+			continue
+		}
+		if _, ok := models[d.ModelID]; !ok {
+			modelIDs = append(modelIDs, d.ModelID)
+		}
+		models[d.ModelID] = append(models[d.ModelID], i)
+	}
+
+	var capped []types.NamespacedName
+	for _, modelID := range modelIDs {
+		limit := limits[modelID]
+		maxReplicas := *limit.MaxReplicas
+		indices := models[modelID]
+		proposed := 0
+		for _, i := range indices {
+			proposed += decisions[i].TargetReplicas
+		}
+		if proposed <= maxReplicas {
+			continue
+		}
+
+		// Break ties by variant, so that the same variants are reduced in every run
+		sort.Slice(indices, func(a, b int) bool {
+			da, db := &decisions[indices[a]], &decisions[indices[b]]
+			if da.Namespace != db.Namespace {
+				return da.Namespace < db.Namespace
+			}
+			return da.VariantName < db.VariantName
+		})
+		granted := shareModelReplicas(decisions, indices, maxReplicas)
+		for k, i := range indices {
+			d := &decisions[i]
+			if granted[k] >= d.TargetReplicas {
+				continue
+			}
+
+			target := d.TargetReplicas
+			d.TargetReplicas = granted[k]
+			d.PolicyCapped = true
+			d.PolicyMaxReplicas = maxReplicas
+			d.PolicyReason = limit.Reason
+			switch {
+			case d.TargetReplicas > d.CurrentReplicas:
+				d.Action = interfaces.ActionScaleUp
+			case d.TargetReplicas < d.CurrentReplicas:
+				d.Action = interfaces.ActionScaleDown
+			default:
+				d.Action = interfaces.ActionNoChange
+			}
+			d.AddDecisionStep(ModelReplicaLimitStepName,
+				fmt.Sprintf("capped at %d replicas (proposed %d): model %s is limited to %d replicas across its variants, %d proposed",
+					d.TargetReplicas, target, modelID, maxReplicas, proposed),
+				true)
+			capped = append(capped, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+		}
+		logger.Info("Model replica limit reached",
+			"modelID", modelID,
+			"maxReplicas", maxReplicas,
+			"proposed", proposed,
+			"reason", limit.Reason)
+	}
+	return capped
+}
+
+// shareModelReplicas shares maxReplicas among the decisions at indices, returning the
+// replicas granted to each. It expects their targets to exceed maxReplicas in total.
+func shareModelReplicas(decisions []interfaces.VariantDecision, indices []int, maxReplicas int) []int {
+	granted := make([]int, len(indices))
+	kept := 0
+	for k, i := range indices {
+		granted[k] = min(decisions[i].TargetReplicas, decisions[i].CurrentReplicas)
+		kept += granted[k]
+	}
+
+	// Scale down the variants keeping the most replicas
+	for ; kept > maxReplicas; kept-- {
+		most := 0
+		for k := range granted {
+			if granted[k] > granted[most] {
+				most = k
+			}
+		}
+		granted[most]--
+	}
+
+	// Scale up the variants missing the most replicas of their target
+	for ; kept < maxReplicas; kept++ {
+		most, missing := -1, 0
+		for k, i := range indices {
+			if m := decisions[i].TargetReplicas - granted[k]; m > missing {
+				most, missing = k, m
+			}
+		}
+		if most < 0 {
+			break
+		}
+		granted[most]++
+	}
+	return granted
+}
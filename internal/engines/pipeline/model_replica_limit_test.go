@@ -0,0 +1,112 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ApplyModelReplicaLimits", func() {
+	var ctx context.Context
+
+	decision := func(namespace, name, modelID string, current, target int) interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		switch {
+		case target > current:
+			action = interfaces.ActionScaleUp
+		case target < current:
+			action = interfaces.ActionScaleDown
+		}
+		return interfaces.VariantDecision{
+			VariantName:     name,
+			Namespace:       namespace,
+			ModelID:         modelID,
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			Action:          action,
+		}
+	}
+
+	limits := func(maxReplicas int) config.ModelReplicaLimits {
+		return config.ModelReplicaLimits{
+			"model-a": {ModelID: "model-a", MaxReplicas: ptr.To(maxReplicas), Reason: "license"},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass targets through without a limit", func() {
+		decisions := []interfaces.VariantDecision{decision("ns", "variant-a", "model-b", 2, 10)}
+		Expect(ApplyModelReplicaLimits(ctx, decisions, limits(4))).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(10))
+		Expect(decisions[0].PolicyCapped).To(BeFalse())
+	})
+
+	It("should pass targets through within the limit", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("ns-1", "variant-a", "model-a", 2, 3),
+			decision("ns-2", "variant-b", "model-a", 2, 3),
+		}
+		decisions[0].PolicyCapped = true
+		Expect(ApplyModelReplicaLimits(ctx, decisions, limits(6))).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].PolicyCapped).To(BeFalse())
+	})
+
+	It("should share the scale-ups left within the limit across namespaces", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("ns-1", "variant-a", "model-a", 2, 6),
+			decision("ns-2", "variant-b", "model-a", 2, 4),
+		}
+		capped := ApplyModelReplicaLimits(ctx, decisions, limits(8))
+		Expect(capped).To(ConsistOf(
+			types.NamespacedName{Namespace: "ns-1", Name: "variant-a"},
+			types.NamespacedName{Namespace: "ns-2", Name: "variant-b"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[1].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].PolicyCapped).To(BeTrue())
+		Expect(decisions[0].PolicyMaxReplicas).To(Equal(8))
+		Expect(decisions[0].PolicyReason).To(Equal("license"))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+		Expect(decisions[0].LastStep().Name).To(Equal(ModelReplicaLimitStepName))
+	})
+
+	It("should not take replicas from running variants for scale-ups", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("ns", "variant-a", "model-a", 4, 4),
+			decision("ns", "variant-b", "model-a", 1, 4),
+		}
+		ApplyModelReplicaLimits(ctx, decisions, limits(5))
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[1].TargetReplicas).To(Equal(1))
+		Expect(decisions[1].Action).To(Equal(interfaces.ActionNoChange))
+	})
+
+	It("should scale down the variants with the most replicas when the current replicas exceed the limit", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("ns", "variant-b", "model-a", 5, 5),
+			decision("ns", "variant-a", "model-a", 2, 2),
+		}
+		decisions[0].MinReplicas = 5
+		capped := ApplyModelReplicaLimits(ctx, decisions, limits(4))
+		Expect(capped).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-b"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleDown))
+		Expect(decisions[1].TargetReplicas).To(Equal(2))
+	})
+
+	It("should stop a model with a limit of 0", func() {
+		decisions := []interfaces.VariantDecision{decision("ns", "variant-a", "model-a", 2, 2)}
+		ApplyModelReplicaLimits(ctx, decisions, limits(0))
+		Expect(decisions[0].TargetReplicas).To(Equal(0))
+		Expect(decisions[0].PolicyCapped).To(BeTrue())
+	})
+})
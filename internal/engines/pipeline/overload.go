@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// DetectOverloadedModels marks the decisions of overloaded models: models whose variants
+// are all saturated and at their maxReplicas. A variant is so when it already runs the
+// maxReplicas of its HorizontalPodAutoscaler while its target asks for more, which
+// CheckHPABounds flags as a BoundsConflict; it must run first. Such a model cannot take
+// more load, so the gateway should shed it instead of queueing it. Models with a variant
+// without HPA or with an error are never overloaded. It returns the variants of the
+// overloaded models.
+func DetectOverloadedModels(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	models := make(map[string][]int)
+	var keys []string
+	for i := range decisions {
+		d := &decisions[i]
+		d.ModelOverloaded = false
+		if d.ModelID == "" {
+			continue
+		}
+		key := d.Namespace + "/" + d.ModelID
+		if _, ok := models[key]; !ok {
+			keys = append(keys, key)
+		}
+		models[key] = append(models[key], i)
+	}
+
+	var overloaded []types.NamespacedName
+	for _, key := range keys {
+		indices := models[key]
+		atMax := true
+		for _, i := range indices {
+			if !saturatedAtMaxReplicas(&decisions[i]) {
+				atMax = false
+				break
+			}
+		}
+		if !atMax {
+			continue
+		}
+
+		for _, i := range indices {
+			d := &decisions[i]
+			d.ModelOverloaded = true
+			overloaded = append(overloaded, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+		}
+		logger.Info("Model overloaded: all variants are saturated and at their maxReplicas",
+			"modelID", decisions[indices[0]].ModelID,
+			"namespace", decisions[indices[0]].Namespace,
+			"variants", len(indices))
+	}
+	return overloaded
+}
+
+// saturatedAtMaxReplicas reports whether a variant runs the maxReplicas of its HPA while
+// its target asks for more.
+func saturatedAtMaxReplicas(d *interfaces.VariantDecision) bool {
+	return d.Error == nil && d.HPAName != "" && d.BoundsConflict &&
+		d.CurrentReplicas >= d.HPAMaxReplicas && d.TargetReplicas > d.HPAMaxReplicas
+}
@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("DetectOverloadedModels", func() {
+	var ctx context.Context
+
+	variant := func(name string, current, target, hpaMax int) interfaces.VariantDecision {
+		return interfaces.VariantDecision{
+			VariantName:     name,
+			Namespace:       "ns",
+			ModelID:         "llama",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			HPAName:         name + "-hpa",
+			HPAMinReplicas:  1,
+			HPAMaxReplicas:  hpaMax,
+		}
+	}
+
+	detect := func(decisions []interfaces.VariantDecision) []types.NamespacedName {
+		CheckHPABounds(ctx, decisions)
+		return DetectOverloadedModels(ctx, decisions)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should mark a model whose variants are all saturated at maxReplicas", func() {
+		decisions := []interfaces.VariantDecision{
+			variant("a", 10, 12, 10),
+			variant("b", 4, 5, 4),
+		}
+		Expect(detect(decisions)).To(ConsistOf(
+			types.NamespacedName{Namespace: "ns", Name: "a"},
+			types.NamespacedName{Namespace: "ns", Name: "b"},
+		))
+		Expect(decisions[0].ModelOverloaded).To(BeTrue())
+		Expect(decisions[1].ModelOverloaded).To(BeTrue())
+	})
+
+	It("should not mark a model with a variant below maxReplicas", func() {
+		decisions := []interfaces.VariantDecision{
+			variant("a", 10, 12, 10),
+			variant("b", 2, 3, 4),
+		}
+		Expect(detect(decisions)).To(BeEmpty())
+		Expect(decisions[0].ModelOverloaded).To(BeFalse())
+	})
+
+	It("should not mark a model at maxReplicas that is not saturated", func() {
+		decisions := []interfaces.VariantDecision{variant("a", 10, 10, 10)}
+		Expect(detect(decisions)).To(BeEmpty())
+	})
+
+	It("should not mark a model with a variant without HPA or with an error", func() {
+		decisions := []interfaces.VariantDecision{variant("a", 10, 12, 10), variant("b", 4, 5, 4)}
+		decisions[1].HPAName = ""
+		Expect(detect(decisions)).To(BeEmpty())
+
+		decisions = []interfaces.VariantDecision{variant("a", 10, 12, 10)}
+		decisions[0].Error = errors.New("no metrics")
+		Expect(detect(decisions)).To(BeEmpty())
+	})
+
+	It("should clear the mark once the model has headroom", func() {
+		decisions := []interfaces.VariantDecision{variant("a", 10, 12, 10)}
+		Expect(detect(decisions)).To(HaveLen(1))
+		decisions[0].HPAMaxReplicas = 15
+		decisions[0].BoundsConflict = false
+		Expect(detect(decisions)).To(BeEmpty())
+		Expect(decisions[0].ModelOverloaded).To(BeFalse())
+	})
+})
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// PDBGuardStepName is the DecisionStep name recorded when a scale-down is clamped to
+// keep the PodDisruptionBudget of the scale target satisfiable.
+const PDBGuardStepName = "pdb-guard"
+
+// ClampPDBScaleDowns keeps scale-downs from going below the minAvailable of the
+// PodDisruptionBudgets selecting the pods of the scale target: with fewer replicas the
+// budget can never be met, which blocks every voluntary eviction such as node drains.
+// A clamped decision scales down to PDBMinReplicas at most, never scales up, and is
+// marked PDBConflict. It returns the variants whose scale-down was clamped.
+func ClampPDBScaleDowns(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var clamped []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.TargetReplicas >= d.CurrentReplicas || d.TargetReplicas >= d.PDBMinReplicas {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = min(d.CurrentReplicas, d.PDBMinReplicas)
+		if d.TargetReplicas == d.CurrentReplicas {
+			d.Action = interfaces.ActionNoChange
+		}
+		d.PDBConflict = true
+		d.AddDecisionStep(PDBGuardStepName,
+			fmt.Sprintf("scale-down to %d replicas clamped to %d: PodDisruptionBudget %s requires minAvailable %d",
+				proposed, d.TargetReplicas, d.PDBName, d.PDBMinReplicas),
+			true)
+		clamped = append(clamped, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.Info("Scale-down clamped by PodDisruptionBudget",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"pdb", d.PDBName,
+			"minAvailable", d.PDBMinReplicas,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return clamped
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ClampPDBScaleDowns", func() {
+	var ctx context.Context
+
+	decision := func(current, target, pdbMin int) []interfaces.VariantDecision {
+		action := interfaces.ActionScaleDown
+		if target > current {
+			action = interfaces.ActionScaleUp
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			PDBMinReplicas:  pdbMin,
+			PDBName:         "variant-a-pdb",
+			Action:          action,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass scale-downs through without a PodDisruptionBudget", func() {
+		decisions := decision(4, 0, 0)
+		Expect(ClampPDBScaleDowns(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(0))
+		Expect(decisions[0].PDBConflict).To(BeFalse())
+	})
+
+	It("should pass scale-downs through that keep the budget satisfiable", func() {
+		decisions := decision(4, 2, 2)
+		Expect(ClampPDBScaleDowns(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].PDBConflict).To(BeFalse())
+	})
+
+	It("should clamp a scale-down at the minAvailable of the budget", func() {
+		decisions := decision(4, 1, 2)
+		clamped := ClampPDBScaleDowns(ctx, decisions)
+		Expect(clamped).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleDown))
+		Expect(decisions[0].PDBConflict).To(BeTrue())
+		Expect(decisions[0].LastStep().Name).To(Equal(PDBGuardStepName))
+		Expect(decisions[0].LastStep().Reason).To(ContainSubstring("variant-a-pdb"))
+	})
+
+	It("should hold at the current replicas rather than scale up", func() {
+		decisions := decision(2, 0, 3)
+		ClampPDBScaleDowns(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].PDBConflict).To(BeTrue())
+	})
+
+	It("should leave scale-ups alone", func() {
+		decisions := decision(1, 2, 3)
+		Expect(ClampPDBScaleDowns(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].PDBConflict).To(BeFalse())
+	})
+})
@@ -0,0 +1,161 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// RolloutStepName is the DecisionStep name recorded when a scale-up is held to a rollout step.
+const RolloutStepName = "rollout"
+
+// GraduatedRollout spreads large scale-ups over several steps of at most maxStepReplicas
+// replicas each. A step is only followed by the next one once all replicas up to the
+// step's target are Ready and reporting metrics. If they do not become healthy within
+// the step timeout, the rollout is aborted: the variant is held at the step's target
+// until its replicas recover or the scale-up is withdrawn.
+//
+// GraduatedRollout keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type GraduatedRollout struct {
+	maxStepReplicas int
+	stepTimeout     time.Duration
+	clock           func() time.Time
+
+	// rollouts in progress, by namespace/variant key
+	rollouts map[string]*rollout
+}
+
+// rollout is the state of an in-progress graduated scale-up of a variant.
+type rollout struct {
+	stepTarget int       // replicas the current step scales to
+	stepStart  time.Time // when the current step was first emitted
+	aborted    bool      // whether the current step timed out
+}
+
+// NewGraduatedRollout creates a rollout stage adding at most maxStepReplicas replicas
+// per step and aborting steps whose replicas are not healthy within stepTimeout.
+// maxStepReplicas <= 0 disables graduated rollout.
+func NewGraduatedRollout(maxStepReplicas int, stepTimeout time.Duration) *GraduatedRollout {
+	return &GraduatedRollout{
+		maxStepReplicas: maxStepReplicas,
+		stepTimeout:     stepTimeout,
+		clock:           time.Now,
+		rollouts:        make(map[string]*rollout),
+	}
+}
+
+// RolloutResult reports what the rollout stage did in one run.
+type RolloutResult struct {
+	// Stepped lists the variants whose scale-up was held to a rollout step.
+	Stepped []types.NamespacedName
+	// Aborted lists the variants whose rollout was aborted in this run.
+	Aborted []types.NamespacedName
+}
+
+// Apply holds large scale-ups to their current rollout step in place, recording a
+// DecisionStep explaining why. Scale-ups of at most maxStepReplicas replicas, and
+// the last step of a rollout, pass through unchanged.
+func (r *GraduatedRollout) Apply(ctx context.Context, decisions []interfaces.VariantDecision) RolloutResult {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	var result RolloutResult
+	if r.maxStepReplicas <= 0 {
+		return result
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	now := r.clock()
+
+	for i := range decisions {
+		d := &decisions[i]
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		variant := types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName}
+
+		if d.TargetReplicas <= d.CurrentReplicas {
+			// Not a scale-up (anymore): any rollout in progress is over
+			delete(r.rollouts, key)
+			continue
+		}
+
+		state, active := r.rollouts[key]
+		switch {
+		case !active:
+			if d.TargetReplicas-d.CurrentReplicas <= r.maxStepReplicas {
+				continue
+			}
+			state = &rollout{stepTarget: d.CurrentReplicas + r.maxStepReplicas, stepStart: now}
+			r.rollouts[key] = state
+		case stepHealthy(d, state.stepTarget):
+			base := max(state.stepTarget, d.CurrentReplicas)
+			if d.TargetReplicas-base <= r.maxStepReplicas {
+				delete(r.rollouts, key)
+				logger.V(logging.DEBUG).Info("Graduated rollout completed",
+					"variant", d.VariantName,
+					"namespace", d.Namespace,
+					"target", d.TargetReplicas)
+				continue
+			}
+			if state.aborted {
+				logger.Info("Graduated rollout resumed: step replicas became healthy",
+					"variant", d.VariantName,
+					"namespace", d.Namespace,
+					"stepTarget", state.stepTarget)
+			}
+			state.stepTarget = base + r.maxStepReplicas
+			state.stepStart = now
+			state.aborted = false
+		case !state.aborted && now.Sub(state.stepStart) > r.stepTimeout:
+			state.aborted = true
+			result.Aborted = append(result.Aborted, variant)
+			logger.Info("Graduated rollout aborted: step replicas not healthy in time",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"stepTarget", state.stepTarget,
+				"ready", d.ReadyReplicas,
+				"reporting", d.ReportingReplicas,
+				"timeout", r.stepTimeout)
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = max(min(proposed, state.stepTarget), d.CurrentReplicas)
+		if d.TargetReplicas == d.CurrentReplicas {
+			d.Action = interfaces.ActionNoChange
+		}
+		var reason string
+		if state.aborted {
+			reason = fmt.Sprintf("rollout to %d replicas aborted at step of %d replicas: %d ready, %d reporting metrics",
+				proposed, state.stepTarget, d.ReadyReplicas, d.ReportingReplicas)
+		} else {
+			reason = fmt.Sprintf("rollout to %d replicas in steps of %d: at step of %d replicas",
+				proposed, r.maxStepReplicas, state.stepTarget)
+		}
+		d.AddDecisionStep(RolloutStepName, reason, true)
+		result.Stepped = append(result.Stepped, variant)
+
+		logger.V(logging.DEBUG).Info("Scale-up held to rollout step",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"current", d.CurrentReplicas,
+			"proposed", proposed,
+			"stepTarget", state.stepTarget,
+			"aborted", state.aborted)
+	}
+	return result
+}
+
+// Forget drops the rollout in progress of a variant no longer active.
+func (r *GraduatedRollout) Forget(namespace, variantName string) {
+	delete(r.rollouts, utils.GetNamespacedKey(namespace, variantName))
+}
+
+// stepHealthy returns whether all replicas up to a step's target are Ready and
+// reporting metrics.
+func stepHealthy(d *interfaces.VariantDecision, stepTarget int) bool {
+	return d.ReadyReplicas >= stepTarget && d.ReportingReplicas >= stepTarget
+}
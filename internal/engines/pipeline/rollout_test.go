@@ -0,0 +1,135 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("GraduatedRollout", func() {
+	var (
+		ctx     context.Context
+		now     time.Time
+		rollout *GraduatedRollout
+	)
+
+	// decision builds a scale-up decision where all current replicas are healthy
+	// unless ready/reporting say otherwise
+	decision := func(current, target, ready, reporting int) []interfaces.VariantDecision {
+		return []interfaces.VariantDecision{{
+			VariantName:       "variant-a",
+			Namespace:         "ns",
+			AcceleratorName:   "A100",
+			CurrentReplicas:   current,
+			TargetReplicas:    target,
+			ReadyReplicas:     ready,
+			ReportingReplicas: reporting,
+			Action:            interfaces.ActionScaleUp,
+		}}
+	}
+	variant := types.NamespacedName{Namespace: "ns", Name: "variant-a"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		rollout = NewGraduatedRollout(3, 5*time.Minute)
+		rollout.clock = func() time.Time { return now }
+	})
+
+	It("should pass scale-ups through when disabled", func() {
+		rollout = NewGraduatedRollout(0, 5*time.Minute)
+		decisions := decision(2, 10, 2, 2)
+		result := rollout.Apply(ctx, decisions)
+		Expect(result.Stepped).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(10))
+	})
+
+	It("should pass small scale-ups through", func() {
+		decisions := decision(2, 5, 2, 2)
+		result := rollout.Apply(ctx, decisions)
+		Expect(result.Stepped).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].DecisionSteps).To(BeEmpty())
+	})
+
+	It("should forget the rollout of a forgotten variant", func() {
+		result := rollout.Apply(ctx, decision(2, 10, 2, 2))
+		Expect(result.Stepped).To(ConsistOf(variant))
+		Expect(rollout.rollouts).To(HaveLen(1))
+
+		rollout.Forget("ns", "variant-a")
+		Expect(rollout.rollouts).To(BeEmpty())
+	})
+
+	It("should spread a large scale-up over health-checked steps", func() {
+		decisions := decision(2, 10, 2, 2)
+		result := rollout.Apply(ctx, decisions)
+		Expect(result.Stepped).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+		Expect(decisions[0].LastStep().Name).To(Equal(RolloutStepName))
+		Expect(decisions[0].LastStep().WasConstrained).To(BeTrue())
+
+		By("holding the step while its replicas start")
+		decisions = decision(5, 10, 3, 3)
+		rollout.Apply(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+
+		By("moving to the next step once they are Ready and reporting metrics")
+		decisions = decision(5, 10, 5, 5)
+		rollout.Apply(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(8))
+
+		By("passing the last step through")
+		decisions = decision(8, 10, 8, 8)
+		result = rollout.Apply(ctx, decisions)
+		Expect(result.Stepped).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(10))
+	})
+
+	It("should not move on while new replicas are Ready but not reporting metrics", func() {
+		rollout.Apply(ctx, decision(2, 10, 2, 2))
+		decisions := decision(5, 10, 5, 2)
+		rollout.Apply(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+	})
+
+	It("should abort further steps when new replicas fail to become healthy in time", func() {
+		rollout.Apply(ctx, decision(2, 10, 2, 2))
+
+		now = now.Add(6 * time.Minute)
+		decisions := decision(5, 10, 3, 3)
+		result := rollout.Apply(ctx, decisions)
+		Expect(result.Aborted).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].LastStep().Reason).To(ContainSubstring("aborted"))
+
+		By("reporting the abort only once")
+		now = now.Add(time.Minute)
+		decisions = decision(5, 10, 3, 3)
+		result = rollout.Apply(ctx, decisions)
+		Expect(result.Aborted).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+
+		By("resuming once the replicas recover")
+		decisions = decision(5, 10, 5, 5)
+		rollout.Apply(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(8))
+	})
+
+	It("should end the rollout when the scale-up is withdrawn", func() {
+		rollout.Apply(ctx, decision(2, 10, 2, 2))
+		rollout.Apply(ctx, decision(5, 5, 3, 3))
+
+		decisions := decision(5, 7, 5, 5)
+		result := rollout.Apply(ctx, decisions)
+		Expect(result.Stepped).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(7))
+	})
+})
@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// ScaleDownQuorumStepName is the DecisionStep name recorded when a scale-down is held
+// for lack of quorum.
+const ScaleDownQuorumStepName = "scale-down-quorum"
+
+// ScaleDownQuorum holds scale-downs until enough recent optimization runs proposed them:
+// a scale-down is applied once quorum of the last window runs independently proposed a
+// scale-down of the variant, whatever its target. Unlike the ChangeDampener, the runs
+// need not be consecutive nor propose the same target, so a single noisy run does not
+// restart the count. Once a scale-down is applied, the runs are counted anew.
+//
+// ScaleDownQuorum keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type ScaleDownQuorum struct {
+	quorum int
+	window int
+
+	// proposals records, per namespace/variant key, whether each of the last window
+	// runs proposed a scale-down, oldest first
+	proposals map[string][]bool
+}
+
+// NewScaleDownQuorum creates a quorum applying scale-downs proposed by quorum of the last
+// window runs. A window smaller than quorum is raised to quorum, requiring consecutive
+// runs; quorum <= 1 disables the quorum.
+func NewScaleDownQuorum(quorum, window int) *ScaleDownQuorum {
+	return &ScaleDownQuorum{
+		quorum:    quorum,
+		window:    max(window, quorum),
+		proposals: make(map[string][]bool),
+	}
+}
+
+// Apply records whether each decision proposes a scale-down and holds, in place, the
+// scale-downs without quorum at the current replicas. Every decision reports its count
+// of approvals. It returns the variants whose scale-down was held.
+func (q *ScaleDownQuorum) Apply(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	if q.quorum <= 1 {
+		return nil
+	}
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	seen := make(map[string]bool, len(decisions))
+	var held []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		seen[key] = true
+
+		scaleDown := d.TargetReplicas < d.CurrentReplicas
+		proposals := append(q.proposals[key], scaleDown)
+		if len(proposals) > q.window {
+			proposals = proposals[len(proposals)-q.window:]
+		}
+		q.proposals[key] = proposals
+
+		approvals := 0
+		for _, approved := range proposals {
+			if approved {
+				approvals++
+			}
+		}
+		d.ScaleDownQuorum = &interfaces.ScaleDownQuorum{Approvals: approvals, Window: q.window, Required: q.quorum}
+		if !scaleDown {
+			continue
+		}
+		if approvals >= q.quorum {
+			// The scale-down goes through: the next one needs a quorum of its own
+			delete(q.proposals, key)
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		d.AddDecisionStep(ScaleDownQuorumStepName,
+			fmt.Sprintf("scale-down to %d replicas held: proposed by %d of the last %d run(s), %d required",
+				proposed, approvals, q.window, q.quorum),
+			true)
+		held = append(held, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.V(logging.DEBUG).Info("Scale-down held for lack of quorum",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"current", d.CurrentReplicas,
+			"proposed", proposed,
+			"approvals", approvals,
+			"quorum", q.quorum,
+			"window", q.window)
+	}
+
+	// Forget the variants no longer optimized
+	for key := range q.proposals {
+		if !seen[key] {
+			delete(q.proposals, key)
+		}
+	}
+	return held
+}
+
+// Forget drops the scale-down proposals of a variant no longer active.
+func (q *ScaleDownQuorum) Forget(namespace, variantName string) {
+	delete(q.proposals, utils.GetNamespacedKey(namespace, variantName))
+}
@@ -0,0 +1,109 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ScaleDownQuorum", func() {
+	var ctx context.Context
+
+	decision := func(current, target int) []interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		if target < current {
+			action = interfaces.ActionScaleDown
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			Action:          action,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should hold scale-downs until quorum of the last runs proposed one", func() {
+		quorum := NewScaleDownQuorum(3, 5)
+
+		decisions := decision(4, 2)
+		Expect(quorum.Apply(ctx, decisions)).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].LastStep().Name).To(Equal(ScaleDownQuorumStepName))
+		Expect(decisions[0].ScaleDownQuorum).To(Equal(&interfaces.ScaleDownQuorum{Approvals: 1, Window: 5, Required: 3}))
+
+		// A run without scale-down does not restart the count
+		decisions = decision(4, 4)
+		Expect(quorum.Apply(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(1))
+
+		decisions = decision(4, 3)
+		Expect(quorum.Apply(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(2))
+
+		decisions = decision(4, 2)
+		Expect(quorum.Apply(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(3))
+
+		// The next scale-down needs a quorum of its own
+		decisions = decision(2, 1)
+		Expect(quorum.Apply(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(1))
+	})
+
+	It("should forget the proposals of a forgotten variant", func() {
+		quorum := NewScaleDownQuorum(3, 5)
+		quorum.Apply(ctx, decision(4, 2))
+		quorum.Apply(ctx, decision(4, 2))
+
+		quorum.Forget("ns", "variant-a")
+		Expect(quorum.proposals).To(BeEmpty())
+
+		decisions := decision(4, 2)
+		Expect(quorum.Apply(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(1))
+	})
+
+	It("should only count the runs of the window", func() {
+		quorum := NewScaleDownQuorum(2, 3)
+
+		Expect(quorum.Apply(ctx, decision(4, 2))).To(HaveLen(1))
+		Expect(quorum.Apply(ctx, decision(4, 4))).To(BeEmpty())
+		Expect(quorum.Apply(ctx, decision(4, 4))).To(BeEmpty())
+
+		// The first proposal slid out of the window
+		decisions := decision(4, 2)
+		Expect(quorum.Apply(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(1))
+	})
+
+	It("should require consecutive runs with a window smaller than the quorum", func() {
+		quorum := NewScaleDownQuorum(2, 0)
+
+		decisions := decision(4, 2)
+		Expect(quorum.Apply(ctx, decisions)).To(HaveLen(1))
+		Expect(decisions[0].ScaleDownQuorum.Window).To(Equal(2))
+		Expect(quorum.Apply(ctx, decision(4, 2))).To(BeEmpty())
+	})
+
+	It("should pass scale-ups and all decisions when disabled", func() {
+		decisions := decision(2, 4)
+		Expect(NewScaleDownQuorum(3, 5).Apply(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+
+		decisions = decision(4, 2)
+		Expect(NewScaleDownQuorum(1, 5).Apply(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].ScaleDownQuorum).To(BeNil())
+	})
+})
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// ScaleUpVerifierStepName is the DecisionStep name recorded when a scale-up is held
+// because a previous one was ineffective.
+const ScaleUpVerifierStepName = "scale-up-verifier"
+
+// ScaleUpVerifier checks that scale-ups actually reduce saturation. Each scale-up is
+// recorded with the variant's spare capacity at the time; if, once the timeout has
+// passed, the added replicas are serving but spare capacity has not grown by at least
+// minImprovement (e.g. because of an upstream bottleneck), the scale-up is ineffective.
+// Further scale-ups of the variant are then held, and optionally the scale-up is
+// reverted, until its spare capacity recovers or it stops asking for replicas.
+//
+// ScaleUpVerifier keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type ScaleUpVerifier struct {
+	timeout        time.Duration
+	minImprovement float64
+	rollback       bool
+	clock          func() time.Time
+
+	// scale-ups being verified or found ineffective, by namespace/variant key
+	scaleUps map[string]*scaleUp
+}
+
+// scaleUp is a scale-up of a variant under verification.
+type scaleUp struct {
+	fromReplicas  int       // replicas before the scale-up
+	toReplicas    int       // replicas the scale-up asked for
+	baselineSpare float64   // spare capacity before the scale-up
+	start         time.Time // when the scale-up was emitted
+	ineffective   bool      // whether the scale-up did not reduce saturation
+	rolledBack    bool      // whether the scale-up was reverted
+}
+
+// NewScaleUpVerifier creates a verifier that checks scale-ups timeout after they are
+// emitted, requiring spare capacity to grow by minImprovement. With rollback set,
+// ineffective scale-ups are reverted. timeout <= 0 disables verification.
+func NewScaleUpVerifier(timeout time.Duration, minImprovement float64, rollback bool) *ScaleUpVerifier {
+	return &ScaleUpVerifier{
+		timeout:        timeout,
+		minImprovement: minImprovement,
+		rollback:       rollback,
+		clock:          time.Now,
+		scaleUps:       make(map[string]*scaleUp),
+	}
+}
+
+// VerifyResult reports what the verifier found in one run.
+type VerifyResult struct {
+	// Ineffective lists the variants whose scale-up was found ineffective in this run.
+	Ineffective []types.NamespacedName
+	// RolledBack lists the variants whose scale-up was reverted in this run.
+	RolledBack []types.NamespacedName
+}
+
+// Verify checks earlier scale-ups, holds decisions of variants with an ineffective
+// scale-up in place, and records new scale-ups for verification.
+func (v *ScaleUpVerifier) Verify(ctx context.Context, decisions []interfaces.VariantDecision) VerifyResult {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	var result VerifyResult
+	if v.timeout <= 0 {
+		return result
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	now := v.clock()
+
+	for i := range decisions {
+		d := &decisions[i]
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		variant := types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName}
+
+		state, tracked := v.scaleUps[key]
+		detected := false
+		if tracked {
+			improved := d.SpareCapacity-state.baselineSpare >= v.minImprovement
+			switch {
+			case improved:
+				if state.ineffective {
+					logger.Info("Scale-up effective again: spare capacity recovered",
+						"variant", d.VariantName,
+						"namespace", d.Namespace,
+						"spareCapacity", d.SpareCapacity)
+				}
+				delete(v.scaleUps, key)
+				tracked = false
+			case state.ineffective:
+				if d.TargetReplicas <= d.CurrentReplicas {
+					delete(v.scaleUps, key)
+					tracked = false
+				}
+			case now.Sub(state.start) <= v.timeout:
+				// Still verifying: let the decision through without recording a new scale-up
+				continue
+			case d.CurrentReplicas >= state.toReplicas && d.ReadyReplicas >= state.toReplicas:
+				state.ineffective = true
+				detected = true
+				result.Ineffective = append(result.Ineffective, variant)
+				logger.Info("Scale-up ineffective: saturation did not drop",
+					"variant", d.VariantName,
+					"namespace", d.Namespace,
+					"from", state.fromReplicas,
+					"to", state.toReplicas,
+					"baselineSpare", state.baselineSpare,
+					"spareCapacity", d.SpareCapacity)
+			default:
+				// The added replicas never became ready, so their effect cannot be judged
+				delete(v.scaleUps, key)
+				tracked = false
+			}
+		}
+
+		if !tracked {
+			if d.TargetReplicas > d.CurrentReplicas {
+				v.scaleUps[key] = &scaleUp{
+					fromReplicas:  d.CurrentReplicas,
+					toReplicas:    d.TargetReplicas,
+					baselineSpare: d.SpareCapacity,
+					start:         now,
+				}
+			}
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = min(proposed, d.CurrentReplicas)
+		if detected && v.rollback && state.fromReplicas < d.CurrentReplicas {
+			d.TargetReplicas = state.fromReplicas
+			state.rolledBack = true
+			result.RolledBack = append(result.RolledBack, variant)
+		}
+		switch {
+		case d.TargetReplicas < d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleDown
+		case d.TargetReplicas == d.CurrentReplicas:
+			d.Action = interfaces.ActionNoChange
+		}
+
+		d.ScaleUpIneffective = true
+		d.ScaleUpRolledBack = state.rolledBack
+		d.ScaleUpMessage = fmt.Sprintf("scale-up from %d to %d replicas did not reduce saturation within %v (spare capacity %.2f, was %.2f)",
+			state.fromReplicas, state.toReplicas, v.timeout, d.SpareCapacity, state.baselineSpare)
+		reason := fmt.Sprintf("scale-up to %d replicas held: %s", proposed, d.ScaleUpMessage)
+		if detected && state.rolledBack {
+			reason = fmt.Sprintf("reverted to %d replicas: %s", d.TargetReplicas, d.ScaleUpMessage)
+		}
+		d.AddDecisionStep(ScaleUpVerifierStepName, reason, true)
+
+		logger.V(logging.DEBUG).Info("Scale-up held after ineffective scale-up",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"current", d.CurrentReplicas,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return result
+}
+
+// Forget drops the scale-up under verification of a variant no longer active.
+func (v *ScaleUpVerifier) Forget(namespace, variantName string) {
+	delete(v.scaleUps, utils.GetNamespacedKey(namespace, variantName))
+}
@@ -0,0 +1,160 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ScaleUpVerifier", func() {
+	var (
+		ctx      context.Context
+		now      time.Time
+		verifier *ScaleUpVerifier
+	)
+
+	// decision builds a decision whose current replicas are all ready
+	decision := func(current, target int, spare float64) []interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		if target > current {
+			action = interfaces.ActionScaleUp
+		} else if target < current {
+			action = interfaces.ActionScaleDown
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			AcceleratorName: "A100",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			ReadyReplicas:   current,
+			SpareCapacity:   spare,
+			Action:          action,
+		}}
+	}
+	variant := types.NamespacedName{Namespace: "ns", Name: "variant-a"}
+
+	newVerifier := func(rollback bool) *ScaleUpVerifier {
+		v := NewScaleUpVerifier(5*time.Minute, 0.1, rollback)
+		v.clock = func() time.Time { return now }
+		return v
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		verifier = newVerifier(false)
+	})
+
+	It("should pass decisions through when disabled", func() {
+		verifier = NewScaleUpVerifier(0, 0.1, true)
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		decisions := decision(4, 6, 0.0)
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(6))
+	})
+
+	It("should let scale-ups through while they are being verified", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(2 * time.Minute)
+		decisions := decision(4, 6, 0.0)
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(6))
+		Expect(decisions[0].ScaleUpIneffective).To(BeFalse())
+	})
+
+	It("should forget the scale-up of a forgotten variant", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		Expect(verifier.scaleUps).To(HaveLen(1))
+
+		verifier.Forget("ns", "variant-a")
+		Expect(verifier.scaleUps).To(BeEmpty())
+	})
+
+	It("should accept a scale-up that reduced saturation", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(6 * time.Minute)
+		decisions := decision(4, 6, 0.2)
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(6))
+	})
+
+	It("should hold further scale-ups after an ineffective one", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(6 * time.Minute)
+		decisions := decision(4, 6, 0.05)
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(ConsistOf(variant))
+		Expect(result.RolledBack).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].ScaleUpIneffective).To(BeTrue())
+		Expect(decisions[0].ScaleUpMessage).To(ContainSubstring("from 2 to 4 replicas"))
+		Expect(decisions[0].LastStep().Name).To(Equal(ScaleUpVerifierStepName))
+
+		By("keeping the hold on later runs")
+		now = now.Add(time.Minute)
+		decisions = decision(4, 8, 0.0)
+		result = verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].ScaleUpIneffective).To(BeTrue())
+
+		By("releasing it once spare capacity recovers")
+		decisions = decision(4, 5, 0.3)
+		verifier.Verify(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].ScaleUpIneffective).To(BeFalse())
+	})
+
+	It("should revert an ineffective scale-up when rollback is enabled", func() {
+		verifier = newVerifier(true)
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(6 * time.Minute)
+		decisions := decision(4, 6, 0.0)
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.RolledBack).To(ConsistOf(variant))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleDown))
+		Expect(decisions[0].ScaleUpRolledBack).To(BeTrue())
+
+		By("holding at the previous replicas afterwards")
+		decisions = decision(2, 4, 0.0)
+		result = verifier.Verify(ctx, decisions)
+		Expect(result.RolledBack).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].ScaleUpRolledBack).To(BeTrue())
+	})
+
+	It("should not judge a scale-up whose replicas never became ready", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(6 * time.Minute)
+		decisions := decision(4, 6, 0.0)
+		decisions[0].ReadyReplicas = 2
+		result := verifier.Verify(ctx, decisions)
+		Expect(result.Ineffective).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(6))
+	})
+
+	It("should end the hold when the variant stops asking for replicas", func() {
+		verifier.Verify(ctx, decision(2, 4, 0.0))
+		now = now.Add(6 * time.Minute)
+		verifier.Verify(ctx, decision(4, 6, 0.0))
+
+		decisions := decision(4, 4, 0.0)
+		verifier.Verify(ctx, decisions)
+		Expect(decisions[0].ScaleUpIneffective).To(BeFalse())
+
+		decisions = decision(4, 6, 0.0)
+		verifier.Verify(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(6))
+	})
+})
@@ -0,0 +1,293 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// ScalingBehaviorStepName is the DecisionStep name recorded when a target is stabilized
+// or rate limited by the spec.behavior of its VariantAutoscaling.
+const ScalingBehaviorStepName = "scaling-behavior"
+
+// timedReplicas is a number of replicas recommended, or added (removed if negative),
+// at a point in time.
+type timedReplicas struct {
+	replicas int
+	at       time.Time
+}
+
+// behaviorState is the history of the recommendations and replica changes of a variant.
+type behaviorState struct {
+	recommendations []timedReplicas
+	changes         []timedReplicas
+	lastReplicas    int
+}
+
+// ScalingBehavior applies the spec.behavior of VariantAutoscalings to their targets the way
+// the HorizontalPodAutoscaler applies its behavior: the target is first stabilized over the
+// recommendations of the stabilization window of its direction, then limited by the scaling
+// policies of that direction to the replicas they allow to be added or removed over their
+// periods. Replica changes are counted from the current replicas seen by each run, so they
+// include the scalings of other actors.
+//
+// ScalingBehavior keeps state across runs and is not safe for concurrent use;
+// the engine's optimization loop is its only caller.
+type ScalingBehavior struct {
+	// states holds the history of each namespace/variant key with a behavior
+	states map[string]*behaviorState
+}
+
+// NewScalingBehavior creates a ScalingBehavior without history.
+func NewScalingBehavior() *ScalingBehavior {
+	return &ScalingBehavior{states: make(map[string]*behaviorState)}
+}
+
+// Apply stabilizes and rate limits, in place, the targets of the decisions whose VA has a
+// spec.behavior. Scale-ups from zero replicas are not limited, so that idle variants can
+// serve again. It returns the variants whose target was changed.
+func (b *ScalingBehavior) Apply(ctx context.Context, decisions []interfaces.VariantDecision, now time.Time) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	seen := make(map[string]bool, len(decisions))
+	var changed []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.Behavior == nil {
+			continue
+		}
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		seen[key] = true
+
+		state, ok := b.states[key]
+		if !ok {
+			state = &behaviorState{lastReplicas: d.CurrentReplicas}
+			b.states[key] = state
+		}
+		if d.CurrentReplicas != state.lastReplicas {
+			state.changes = append(state.changes, timedReplicas{replicas: d.CurrentReplicas - state.lastReplicas, at: now})
+			state.lastReplicas = d.CurrentReplicas
+		}
+		state.recommendations = append(state.recommendations, timedReplicas{replicas: d.TargetReplicas, at: now})
+		state.prune(d.Behavior, now)
+
+		proposed := d.TargetReplicas
+		target, reason := state.stabilize(d.Behavior, d.CurrentReplicas, proposed, now)
+		if d.CurrentReplicas > 0 {
+			if limited, limitReason := state.limit(d.Behavior, d.CurrentReplicas, target, now); limited != target {
+				target, reason = limited, limitReason
+			}
+		}
+		if target == proposed {
+			continue
+		}
+
+		d.TargetReplicas = target
+		switch {
+		case target > d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleUp
+		case target < d.CurrentReplicas:
+			d.Action = interfaces.ActionScaleDown
+		default:
+			d.Action = interfaces.ActionNoChange
+		}
+		d.AddDecisionStep(ScalingBehaviorStepName,
+			fmt.Sprintf("target of %d replicas %s", proposed, reason),
+			true)
+		changed = append(changed, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.V(logging.DEBUG).Info("Target changed by scaling behavior",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"current", d.CurrentReplicas,
+			"proposed", proposed,
+			"target", target)
+	}
+
+	// Forget the variants no longer optimized or without behavior
+	for key := range b.states {
+		if !seen[key] {
+			delete(b.states, key)
+		}
+	}
+	return changed
+}
+
+// Forget drops the history of a variant no longer active.
+func (b *ScalingBehavior) Forget(namespace, variantName string) {
+	delete(b.states, utils.GetNamespacedKey(namespace, variantName))
+}
+
+// stabilize returns the target stabilized over the recommendations of the stabilization
+// windows: at most the least recommended over the scale-up window and at least the most
+// recommended over the scale-down window, the current replicas in between.
+func (s *behaviorState) stabilize(behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior, current, proposed int, now time.Time) (int, string) {
+	upWindow := stabilizationWindow(behavior.ScaleUp)
+	downWindow := stabilizationWindow(behavior.ScaleDown)
+
+	up, down := proposed, proposed
+	for _, r := range s.recommendations {
+		if !r.at.Before(now.Add(-upWindow)) {
+			up = min(up, r.replicas)
+		}
+		if !r.at.Before(now.Add(-downWindow)) {
+			down = max(down, r.replicas)
+		}
+	}
+
+	switch {
+	case current < up:
+		return up, fmt.Sprintf("stabilized to %d over the %s scale-up window", up, upWindow)
+	case current > down:
+		return down, fmt.Sprintf("stabilized to %d over the %s scale-down window", down, downWindow)
+	case proposed > current:
+		return current, fmt.Sprintf("stabilized to %d over the %s scale-up window", current, upWindow)
+	case proposed < current:
+		return current, fmt.Sprintf("stabilized to %d over the %s scale-down window", current, downWindow)
+	}
+	return current, ""
+}
+
+// limit returns the target limited by the scaling policies of its direction, counting the
+// replicas added and removed over the period of each policy.
+func (s *behaviorState) limit(behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior, current, target int, now time.Time) (int, string) {
+	switch {
+	case target > current && behavior.ScaleUp != nil:
+		if selectPolicy(behavior.ScaleUp) == llmdVariantAutoscalingV1alpha1.DisabledPolicySelect {
+			return current, "held: scale-up disabled"
+		}
+		if limit, ok := s.scaleUpLimit(behavior.ScaleUp, current, now); ok && target > limit {
+			return limit, fmt.Sprintf("limited to %d by the scale-up policies", limit)
+		}
+	case target < current && behavior.ScaleDown != nil:
+		if selectPolicy(behavior.ScaleDown) == llmdVariantAutoscalingV1alpha1.DisabledPolicySelect {
+			return current, "held: scale-down disabled"
+		}
+		if limit, ok := s.scaleDownLimit(behavior.ScaleDown, current, now); ok && target < limit {
+			return limit, fmt.Sprintf("limited to %d by the scale-down policies", limit)
+		}
+	}
+	return target, ""
+}
+
+// scaleUpLimit returns the most replicas the scale-up policies allow, false without policies.
+func (s *behaviorState) scaleUpLimit(rules *llmdVariantAutoscalingV1alpha1.ScalingRules, current int, now time.Time) (int, bool) {
+	if len(rules.Policies) == 0 {
+		return 0, false
+	}
+	selectMin := selectPolicy(rules) == llmdVariantAutoscalingV1alpha1.MinChangePolicySelect
+	limit := math.MinInt
+	if selectMin {
+		limit = math.MaxInt
+	}
+	for _, policy := range rules.Policies {
+		added, removed := s.changesSince(now.Add(-time.Duration(policy.PeriodSeconds) * time.Second))
+		periodStart := current - added + removed
+		allowed := periodStart + int(policy.Value)
+		if policy.Type == llmdVariantAutoscalingV1alpha1.PercentScalingPolicy {
+			allowed = int(math.Ceil(float64(periodStart) * (1 + float64(policy.Value)/100)))
+		}
+		if selectMin {
+			limit = min(limit, allowed)
+		} else {
+			limit = max(limit, allowed)
+		}
+	}
+	return max(limit, current), true
+}
+
+// scaleDownLimit returns the fewest replicas the scale-down policies allow, false without
+// policies.
+func (s *behaviorState) scaleDownLimit(rules *llmdVariantAutoscalingV1alpha1.ScalingRules, current int, now time.Time) (int, bool) {
+	if len(rules.Policies) == 0 {
+		return 0, false
+	}
+	selectMin := selectPolicy(rules) == llmdVariantAutoscalingV1alpha1.MinChangePolicySelect
+	limit := math.MaxInt
+	if selectMin {
+		limit = math.MinInt
+	}
+	for _, policy := range rules.Policies {
+		added, removed := s.changesSince(now.Add(-time.Duration(policy.PeriodSeconds) * time.Second))
+		periodStart := current + removed - added
+		allowed := periodStart - int(policy.Value)
+		if policy.Type == llmdVariantAutoscalingV1alpha1.PercentScalingPolicy {
+			allowed = int(math.Ceil(float64(periodStart) * (1 - float64(policy.Value)/100)))
+		}
+		if selectMin {
+			limit = max(limit, allowed)
+		} else {
+			limit = min(limit, allowed)
+		}
+	}
+	return min(max(limit, 0), current), true
+}
+
+// changesSince returns the replicas added and removed since a point in time.
+func (s *behaviorState) changesSince(since time.Time) (added, removed int) {
+	for _, c := range s.changes {
+		if c.at.Before(since) {
+			continue
+		}
+		if c.replicas > 0 {
+			added += c.replicas
+		} else {
+			removed -= c.replicas
+		}
+	}
+	return added, removed
+}
+
+// prune drops the recommendations older than the stabilization windows and the replica
+// changes older than the periods of the policies.
+func (s *behaviorState) prune(behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior, now time.Time) {
+	window := max(stabilizationWindow(behavior.ScaleUp), stabilizationWindow(behavior.ScaleDown))
+	s.recommendations = dropBefore(s.recommendations, now.Add(-window))
+
+	var period time.Duration
+	for _, rules := range []*llmdVariantAutoscalingV1alpha1.ScalingRules{behavior.ScaleUp, behavior.ScaleDown} {
+		if rules == nil {
+			continue
+		}
+		for _, policy := range rules.Policies {
+			period = max(period, time.Duration(policy.PeriodSeconds)*time.Second)
+		}
+	}
+	s.changes = dropBefore(s.changes, now.Add(-period))
+}
+
+// dropBefore drops the entries, in time order, older than a point in time.
+func dropBefore(entries []timedReplicas, before time.Time) []timedReplicas {
+	for i, e := range entries {
+		if !e.at.Before(before) {
+			return entries[i:]
+		}
+	}
+	return nil
+}
+
+// stabilizationWindow returns the stabilization window of scaling rules, 0 if unset.
+func stabilizationWindow(rules *llmdVariantAutoscalingV1alpha1.ScalingRules) time.Duration {
+	if rules == nil || rules.StabilizationWindowSeconds == nil {
+		return 0
+	}
+	return time.Duration(*rules.StabilizationWindowSeconds) * time.Second
+}
+
+// selectPolicy returns the policy selection of scaling rules, Max if unset.
+func selectPolicy(rules *llmdVariantAutoscalingV1alpha1.ScalingRules) llmdVariantAutoscalingV1alpha1.ScalingPolicySelect {
+	if rules.SelectPolicy == nil {
+		return llmdVariantAutoscalingV1alpha1.MaxChangePolicySelect
+	}
+	return *rules.SelectPolicy
+}
@@ -0,0 +1,140 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("ScalingBehavior", func() {
+	var (
+		ctx context.Context
+		now time.Time
+	)
+
+	decision := func(behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior, current, target int) []interfaces.VariantDecision {
+		action := interfaces.ActionNoChange
+		switch {
+		case target > current:
+			action = interfaces.ActionScaleUp
+		case target < current:
+			action = interfaces.ActionScaleDown
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			Action:          action,
+			Behavior:        behavior,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Now()
+	})
+
+	It("should keep the most replicas recommended over the scale-down window", func() {
+		behavior := &llmdVariantAutoscalingV1alpha1.ScalingBehavior{
+			ScaleDown: &llmdVariantAutoscalingV1alpha1.ScalingRules{StabilizationWindowSeconds: ptr.To[int32](300)},
+		}
+		b := NewScalingBehavior()
+
+		Expect(b.Apply(ctx, decision(behavior, 6, 5), now)).To(BeEmpty())
+
+		decisions := decision(behavior, 6, 3)
+		changed := b.Apply(ctx, decisions, now.Add(time.Minute))
+		Expect(changed).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleDown))
+		Expect(decisions[0].LastStep().Name).To(Equal(ScalingBehaviorStepName))
+
+		// The recommendation of 5 replicas slid out of the window
+		decisions = decision(behavior, 5, 3)
+		Expect(b.Apply(ctx, decisions, now.Add(6*time.Minute))).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should forget the recommendations of a forgotten variant", func() {
+		behavior := &llmdVariantAutoscalingV1alpha1.ScalingBehavior{
+			ScaleDown: &llmdVariantAutoscalingV1alpha1.ScalingRules{StabilizationWindowSeconds: ptr.To[int32](300)},
+		}
+		b := NewScalingBehavior()
+		b.Apply(ctx, decision(behavior, 6, 5), now)
+
+		b.Forget("ns", "variant-a")
+		Expect(b.states).To(BeEmpty())
+
+		decisions := decision(behavior, 6, 3)
+		Expect(b.Apply(ctx, decisions, now.Add(time.Minute))).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+	})
+
+	It("should limit scale-ups by the policy allowing the largest change", func() {
+		behavior := &llmdVariantAutoscalingV1alpha1.ScalingBehavior{
+			ScaleUp: &llmdVariantAutoscalingV1alpha1.ScalingRules{Policies: []llmdVariantAutoscalingV1alpha1.ScalingPolicy{
+				{Type: llmdVariantAutoscalingV1alpha1.PodsScalingPolicy, Value: 2, PeriodSeconds: 60},
+				{Type: llmdVariantAutoscalingV1alpha1.PercentScalingPolicy, Value: 50, PeriodSeconds: 60},
+			}},
+		}
+		b := NewScalingBehavior()
+
+		decisions := decision(behavior, 6, 20)
+		Expect(b.Apply(ctx, decisions, now)).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(9))
+
+		// The 3 replicas added count against the period
+		decisions = decision(behavior, 9, 20)
+		Expect(b.Apply(ctx, decisions, now.Add(30*time.Second))).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(9))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+
+		decisions = decision(behavior, 9, 20)
+		Expect(b.Apply(ctx, decisions, now.Add(2*time.Minute))).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(14))
+	})
+
+	It("should limit scale-downs by the policy allowing the smallest change with Min", func() {
+		behavior := &llmdVariantAutoscalingV1alpha1.ScalingBehavior{
+			ScaleDown: &llmdVariantAutoscalingV1alpha1.ScalingRules{
+				SelectPolicy: ptr.To(llmdVariantAutoscalingV1alpha1.MinChangePolicySelect),
+				Policies: []llmdVariantAutoscalingV1alpha1.ScalingPolicy{
+					{Type: llmdVariantAutoscalingV1alpha1.PodsScalingPolicy, Value: 4, PeriodSeconds: 60},
+					{Type: llmdVariantAutoscalingV1alpha1.PercentScalingPolicy, Value: 10, PeriodSeconds: 60},
+				},
+			},
+		}
+		decisions := decision(behavior, 10, 2)
+		Expect(NewScalingBehavior().Apply(ctx, decisions, now)).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(9))
+	})
+
+	It("should hold scale-ups of a disabled direction except from zero", func() {
+		behavior := &llmdVariantAutoscalingV1alpha1.ScalingBehavior{
+			ScaleUp: &llmdVariantAutoscalingV1alpha1.ScalingRules{
+				SelectPolicy: ptr.To(llmdVariantAutoscalingV1alpha1.DisabledPolicySelect),
+			},
+		}
+		decisions := decision(behavior, 2, 4)
+		Expect(NewScalingBehavior().Apply(ctx, decisions, now)).To(HaveLen(1))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+
+		decisions = decision(behavior, 0, 1)
+		Expect(NewScalingBehavior().Apply(ctx, decisions, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+	})
+
+	It("should pass decisions of VAs without behavior", func() {
+		decisions := decision(nil, 10, 1)
+		Expect(NewScalingBehavior().Apply(ctx, decisions, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+	})
+})
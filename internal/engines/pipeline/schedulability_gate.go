@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// SchedulabilityGateStepName is the DecisionStep name recorded when a scale-up is held
+// because pods of the scale target cannot be scheduled.
+const SchedulabilityGateStepName = "schedulability-gate"
+
+// GateUnschedulableScaleUps holds scale-ups of variants whose scale target already has
+// Pending pods the scheduler could not place for lack of GPUs: asking for more replicas
+// would only add more Pending pods. A held decision keeps the higher of its current
+// replicas and its previous recommendation, and is marked TargetUnschedulable.
+// It returns the variants whose scale-up was held.
+func GateUnschedulableScaleUps(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var held []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.UnschedulableReplicas <= 0 {
+			continue
+		}
+		d.TargetUnschedulable = true
+		if d.TargetReplicas <= d.CurrentReplicas {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = min(proposed, max(d.CurrentReplicas, d.DesiredReplicas))
+		if d.TargetReplicas == d.CurrentReplicas {
+			d.Action = interfaces.ActionNoChange
+		}
+		d.AddDecisionStep(SchedulabilityGateStepName,
+			fmt.Sprintf("scale-up to %d replicas held: %d pods Pending for lack of GPUs", proposed, d.UnschedulableReplicas),
+			true)
+		held = append(held, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.Info("Scale-up held: pods of scale target are unschedulable",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"unschedulable", d.UnschedulableReplicas,
+			"proposed", proposed,
+			"target", d.TargetReplicas)
+	}
+	return held
+}
@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("GateUnschedulableScaleUps", func() {
+	var ctx context.Context
+
+	decision := func(current, desired, target, unschedulable int) []interfaces.VariantDecision {
+		return []interfaces.VariantDecision{{
+			VariantName:           "variant-a",
+			Namespace:             "ns",
+			CurrentReplicas:       current,
+			DesiredReplicas:       desired,
+			TargetReplicas:        target,
+			UnschedulableReplicas: unschedulable,
+			Action:                interfaces.ActionScaleUp,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should pass scale-ups through when all pods are schedulable", func() {
+		decisions := decision(3, 3, 5, 0)
+		Expect(GateUnschedulableScaleUps(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(5))
+		Expect(decisions[0].TargetUnschedulable).To(BeFalse())
+	})
+
+	It("should hold a scale-up at the current replicas when pods are unschedulable", func() {
+		decisions := decision(4, 4, 6, 1)
+		held := GateUnschedulableScaleUps(ctx, decisions)
+		Expect(held).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].TargetUnschedulable).To(BeTrue())
+		Expect(decisions[0].LastStep().Name).To(Equal(SchedulabilityGateStepName))
+	})
+
+	It("should keep the previous recommendation rather than lowering it", func() {
+		decisions := decision(3, 4, 6, 1)
+		GateUnschedulableScaleUps(ctx, decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(4))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+	})
+
+	It("should let scale-downs through but still report the unschedulable target", func() {
+		decisions := decision(4, 4, 2, 1)
+		decisions[0].Action = interfaces.ActionScaleDown
+		Expect(GateUnschedulableScaleUps(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].TargetUnschedulable).To(BeTrue())
+	})
+})
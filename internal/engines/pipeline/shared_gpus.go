@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"math"
+	"strconv"
+)
+
+// gpuEpsilon absorbs the rounding errors of sums of fractional GPUs, such as three
+// replicas of a third of a GPU.
+const gpuEpsilon = 1e-9
+
+// replicasFor returns the full replicas of gpusPerReplica GPUs that gpus can host.
+func replicasFor(gpus, gpusPerReplica float64) int {
+	if gpusPerReplica <= 0 {
+		return 0
+	}
+	return int(math.Floor(gpus/gpusPerReplica + gpuEpsilon))
+}
+
+// wholeGPUs rounds GPUs up to whole GPUs, ignoring rounding errors: a GPU with any of its
+// shares in use is in use.
+func wholeGPUs(gpus float64) int {
+	return int(math.Ceil(gpus - gpuEpsilon))
+}
+
+// formatGPUs formats GPUs for decision steps and limit messages, with up to two decimals
+// for shared GPUs.
+func formatGPUs(gpus float64) string {
+	return strconv.FormatFloat(math.Round(gpus*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// RecommendTrafficWeights sets the recommended traffic weights of the variants of models
+// with several variants: the percentage of the traffic of the model each variant should
+// receive so that the traffic split follows the capacity the targets provision. A variant
+// weighs its serving replicas (the target without the drain surge) times its per-replica
+// capacity, or its serving replicas alone when a variant of the model has no measured
+// capacity. Routed this way, the variants are equally utilized, so the cheaper variants
+// the optimizer scales up take the traffic they were scaled for. Weights sum to 100.
+// Models whose targets are all 0 and decisions with errors get no weights. It returns the
+// variants whose weight was set.
+func RecommendTrafficWeights(ctx context.Context, decisions []interfaces.VariantDecision) []types.NamespacedName {
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	models := make(map[string][]int)
+	var keys []string
+	for i := range decisions {
+		d := &decisions[i]
+		if d.Error != nil || d.ModelID == "" {
+			continue
+		}
+		key := d.Namespace + "/" + d.ModelID
+		if _, ok := models[key]; !ok {
+			keys = append(keys, key)
+		}
+		models[key] = append(models[key], i)
+	}
+
+	var weighted []types.NamespacedName
+	for _, key := range keys {
+		indices := models[key]
+		if len(indices) < 2 {
+			continue
+		}
+
+		capacities := make([]float64, len(indices))
+		byReplicas := false
+		for _, i := range indices {
+			if decisions[i].PerReplicaCapacity <= 0 {
+				byReplicas = true
+			}
+		}
+		total := 0.0
+		for j, i := range indices {
+			d := &decisions[i]
+			capacities[j] = float64(max(d.TargetReplicas-d.DrainSurge, 0))
+			if !byReplicas {
+				capacities[j] *= d.PerReplicaCapacity
+			}
+			total += capacities[j]
+		}
+		if total <= 0 {
+			continue
+		}
+
+		weights := largestRemainder(capacities, total, 100)
+		for j, i := range indices {
+			d := &decisions[i]
+			d.TrafficWeight = weights[j]
+			d.HasTrafficWeight = true
+			weighted = append(weighted, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+			logger.V(logging.DEBUG).Info("Traffic weight recommended",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"modelID", d.ModelID,
+				"target", d.TargetReplicas,
+				"weight", d.TrafficWeight)
+		}
+	}
+	return weighted
+}
+
+// largestRemainder apportions sum among shares in proportion to them: each gets the floor
+// of its quota, and the units left go to the largest remainders, ties to the earlier share.
+func largestRemainder(shares []float64, total float64, sum int) []int {
+	result := make([]int, len(shares))
+	remainders := make([]float64, len(shares))
+	assigned := 0
+	for i, share := range shares {
+		quota := share / total * float64(sum)
+		result[i] = int(math.Floor(quota))
+		remainders[i] = quota - float64(result[i])
+		assigned += result[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for k := 0; assigned < sum && k < len(order); k++ {
+		result[order[k]]++
+		assigned++
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("RecommendTrafficWeights", func() {
+	var ctx context.Context
+
+	decision := func(name string, target int, perReplica float64) interfaces.VariantDecision {
+		return interfaces.VariantDecision{
+			VariantName:        name,
+			Namespace:          "ns",
+			ModelID:            "llama",
+			CurrentReplicas:    target,
+			TargetReplicas:     target,
+			PerReplicaCapacity: perReplica,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("should weigh variants by the capacity of their targets", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("a100", 3, 1000),
+			decision("h100", 1, 2000),
+		}
+		Expect(RecommendTrafficWeights(ctx, decisions)).To(HaveLen(2))
+		Expect(decisions[0].TrafficWeight).To(Equal(60))
+		Expect(decisions[1].TrafficWeight).To(Equal(40))
+		Expect(decisions[0].HasTrafficWeight).To(BeTrue())
+	})
+
+	It("should weigh variants by replicas without measured capacity and sum to 100", func() {
+		decisions := []interfaces.VariantDecision{
+			decision("a", 1, 0),
+			decision("b", 1, 1000),
+			decision("c", 1, 1000),
+		}
+		RecommendTrafficWeights(ctx, decisions)
+		Expect(decisions[0].TrafficWeight).To(Equal(34))
+		Expect(decisions[1].TrafficWeight).To(Equal(33))
+		Expect(decisions[2].TrafficWeight).To(Equal(33))
+	})
+
+	It("should not count the drain surge", func() {
+		surged := decision("a", 3, 0)
+		surged.DrainSurge = 2
+		decisions := []interfaces.VariantDecision{surged, decision("b", 1, 0), decision("c", 0, 0)}
+		RecommendTrafficWeights(ctx, decisions)
+		Expect(decisions[0].TrafficWeight).To(Equal(50))
+		Expect(decisions[1].TrafficWeight).To(Equal(50))
+		Expect(decisions[2].TrafficWeight).To(Equal(0))
+		Expect(decisions[2].HasTrafficWeight).To(BeTrue())
+	})
+
+	It("should skip single variants, idle models and failed decisions", func() {
+		failed := decision("b", 2, 0)
+		failed.Error = errors.New("no metrics")
+		decisions := []interfaces.VariantDecision{decision("a", 2, 0), failed}
+		Expect(RecommendTrafficWeights(ctx, decisions)).To(BeEmpty())
+
+		decisions = []interfaces.VariantDecision{decision("a", 0, 0), decision("b", 0, 0)}
+		Expect(RecommendTrafficWeights(ctx, decisions)).To(BeEmpty())
+		Expect(decisions[0].HasTrafficWeight).To(BeFalse())
+	})
+})
@@ -1,8 +1,10 @@
 package pipeline
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -77,6 +79,10 @@ func normalizeAcceleratorName(fullName string) string {
 //   - Used: GPUs currently in use (discovered from pods or set manually)
 //   - Available: Limit - Used (computed)
 //
+// Limits are whole physical GPUs. Used and available GPUs are fractional when the
+// replicas of variants share GPUs with time-slicing or MPS: the GPU sharing factor of
+// each accelerator type is discovered along with its capacity (see GPUShares).
+//
 // This is essential for heterogeneous clusters where workloads have specific
 // hardware requirements that cannot be satisfied by other accelerator types.
 type TypeInventory struct {
@@ -87,12 +93,21 @@ type TypeInventory struct {
 	mu sync.RWMutex
 	// limitByType maps accelerator type (e.g., "H100", "A100") to total GPU capacity
 	limitByType map[string]int
-	// usedByType maps accelerator type to currently used GPU count
-	usedByType map[string]int
+	// usedByType maps accelerator type to currently used GPUs
+	usedByType map[string]float64
+	// sharesByType maps accelerator type to the number of containers each of its GPUs
+	// can be shared by, the smallest across its nodes
+	sharesByType map[string]int
 	// totalLimit is the sum of all GPU capacity across types
 	totalLimit int
 	// totalUsed is the sum of all used GPUs across types
-	totalUsed int
+	totalUsed float64
+	// limitByZone maps accelerator type to zone to GPU capacity; nodes without a zone
+	// label are counted under the empty zone
+	limitByZone map[string]map[string]int
+	// usedByZone maps accelerator type to zone to used GPU count (empty if the usage
+	// discovery does not break usage down by zone)
+	usedByZone map[string]map[string]int
 }
 
 // NewTypeInventory creates a TypeInventory that tracks GPUs per accelerator type.
@@ -107,7 +122,7 @@ func NewTypeInventory(name string, disc discovery.CapacityDiscovery) *TypeInvent
 		name:        name,
 		discovery:   disc,
 		limitByType: make(map[string]int),
-		usedByType:  make(map[string]int),
+		usedByType:  make(map[string]float64),
 	}
 }
 
@@ -125,7 +140,7 @@ func NewTypeInventoryWithUsage(name string, disc discovery.FullDiscovery) *TypeI
 		discovery:      disc,
 		usageDiscovery: disc,
 		limitByType:    make(map[string]int),
-		usedByType:     make(map[string]int),
+		usedByType:     make(map[string]float64),
 	}
 }
 
@@ -157,7 +172,20 @@ func (i *TypeInventory) RefreshAll(ctx context.Context) error {
 	}
 
 	// Update usage
-	i.SetUsed(usedByType)
+	used := make(map[string]float64, len(usedByType))
+	for accType, count := range usedByType {
+		used[accType] = float64(count)
+	}
+	i.SetUsed(used)
+
+	// Break usage down by zone if the discovery can
+	if zoned, ok := i.usageDiscovery.(discovery.ZoneUsageDiscovery); ok {
+		usedByZone, err := zoned.DiscoverUsageByZone(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to discover GPU usage by zone: %w", err)
+		}
+		i.SetUsedByZone(usedByZone)
+	}
 
 	return nil
 }
@@ -168,6 +196,7 @@ func (i *TypeInventory) RefreshAll(ctx context.Context) error {
 // Accelerator names are normalized from full model names (e.g., "NVIDIA-A100-PCIE-80GB")
 // to short names (e.g., "A100") to match VA label conventions.
 // Should be called before CreateAllocator to ensure fresh data.
+// The GPU sharing factor of each type is refreshed along with its limit.
 // Note: This only updates limits; call SetUsed or RefreshAll to update usage.
 func (i *TypeInventory) Refresh(ctx context.Context) error {
 	// Discover node -> accelerator type -> count
@@ -179,6 +208,8 @@ func (i *TypeInventory) Refresh(ctx context.Context) error {
 	// Aggregate by accelerator type across all nodes
 	// Normalize full model names to short names for matching with VA labels
 	byType := make(map[string]int)
+	byZone := make(map[string]map[string]int)
+	sharesByType := make(map[string]int)
 	total := 0
 
 	for _, accelerators := range nodeInventory {
@@ -186,27 +217,39 @@ func (i *TypeInventory) Refresh(ctx context.Context) error {
 			// Normalize "NVIDIA-A100-PCIE-80GB" -> "A100"
 			shortName := normalizeAcceleratorName(fullModelName)
 			byType[shortName] += info.Count
+			if byZone[shortName] == nil {
+				byZone[shortName] = make(map[string]int)
+			}
+			byZone[shortName][info.Zone] += info.Count
 			total += info.Count
+			// Replicas may land on any node of the type: the least shared GPUs are the
+			// largest share of a GPU a container may get
+			shares := max(info.Shares, 1)
+			if existing, ok := sharesByType[shortName]; !ok || shares < existing {
+				sharesByType[shortName] = shares
+			}
 		}
 	}
 
 	i.mu.Lock()
 	i.limitByType = byType
+	i.limitByZone = byZone
+	i.sharesByType = sharesByType
 	i.totalLimit = total
 	i.mu.Unlock()
 
 	return nil
 }
 
-// SetUsed updates the used GPU counts per accelerator type.
+// SetUsed updates the used GPUs per accelerator type.
 // This should be called with current usage (e.g., from replica counts) before creating an allocator.
-func (i *TypeInventory) SetUsed(usedByType map[string]int) {
+func (i *TypeInventory) SetUsed(usedByType map[string]float64) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
 	// Copy the map to avoid external mutations
-	i.usedByType = make(map[string]int, len(usedByType))
-	total := 0
+	i.usedByType = make(map[string]float64, len(usedByType))
+	total := 0.0
 	for accType, count := range usedByType {
 		i.usedByType[accType] = count
 		total += count
@@ -214,6 +257,24 @@ func (i *TypeInventory) SetUsed(usedByType map[string]int) {
 	i.totalUsed = total
 }
 
+// SetUsedByZone updates the used GPU counts per accelerator type and zone. Accelerator
+// names are normalized as the limits are.
+func (i *TypeInventory) SetUsedByZone(usedByZone map[string]map[string]int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.usedByZone = make(map[string]map[string]int, len(usedByZone))
+	for fullModelName, zones := range usedByZone {
+		shortName := normalizeAcceleratorName(fullModelName)
+		if i.usedByZone[shortName] == nil {
+			i.usedByZone[shortName] = make(map[string]int, len(zones))
+		}
+		for zone, count := range zones {
+			i.usedByZone[shortName][zone] += count
+		}
+	}
+}
+
 // CreateAllocator returns a ResourceAllocator that allocates from type-specific pools.
 //
 // The returned allocator ensures that allocations for a given accelerator type
@@ -224,11 +285,11 @@ func (i *TypeInventory) CreateAllocator(ctx context.Context) ResourceAllocator {
 	defer i.mu.RUnlock()
 
 	// Compute available = limit - used for each type
-	remaining := make(map[string]int, len(i.limitByType))
-	total := 0
+	remaining := make(map[string]float64, len(i.limitByType))
+	total := 0.0
 	for accType, limit := range i.limitByType {
 		used := i.usedByType[accType]
-		available := limit - used
+		available := float64(limit) - used
 		if available < 0 {
 			available = 0 // Don't go negative if over-allocated
 		}
@@ -236,8 +297,17 @@ func (i *TypeInventory) CreateAllocator(ctx context.Context) ResourceAllocator {
 		total += available
 	}
 
+	remainingByZone := make(map[string]map[string]float64, len(i.limitByZone))
+	for accType, zones := range i.limitByZone {
+		remainingByZone[accType] = make(map[string]float64, len(zones))
+		for zone, limit := range zones {
+			remainingByZone[accType][zone] = float64(max(limit-i.usedByZone[accType][zone], 0))
+		}
+	}
+
 	return &typeAllocator{
 		remainingByType: remaining,
+		remainingByZone: remainingByZone,
 		totalRemaining:  total,
 	}
 }
@@ -250,17 +320,17 @@ func (i *TypeInventory) TotalLimit() int {
 }
 
 // TotalUsed returns total GPUs currently in use across all types.
-func (i *TypeInventory) TotalUsed() int {
+func (i *TypeInventory) TotalUsed() float64 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 	return i.totalUsed
 }
 
 // TotalAvailable returns total available GPUs (Limit - Used) across all types.
-func (i *TypeInventory) TotalAvailable() int {
+func (i *TypeInventory) TotalAvailable() float64 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
-	available := i.totalLimit - i.totalUsed
+	available := float64(i.totalLimit) - i.totalUsed
 	if available < 0 {
 		return 0
 	}
@@ -274,18 +344,18 @@ func (i *TypeInventory) LimitByType(accType string) int {
 	return i.limitByType[accType]
 }
 
-// UsedByType returns the used GPU count for a specific accelerator type.
-func (i *TypeInventory) UsedByType(accType string) int {
+// UsedByType returns the used GPUs for a specific accelerator type.
+func (i *TypeInventory) UsedByType(accType string) float64 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 	return i.usedByType[accType]
 }
 
 // AvailableByType returns available GPUs (Limit - Used) for a specific accelerator type.
-func (i *TypeInventory) AvailableByType(accType string) int {
+func (i *TypeInventory) AvailableByType(accType string) float64 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
-	available := i.limitByType[accType] - i.usedByType[accType]
+	available := float64(i.limitByType[accType]) - i.usedByType[accType]
 	if available < 0 {
 		return 0
 	}
@@ -300,7 +370,7 @@ func (i *TypeInventory) GetResourcePools() map[string]ResourcePool {
 	pools := make(map[string]ResourcePool, len(i.limitByType))
 	for accType, limit := range i.limitByType {
 		used := i.usedByType[accType]
-		avail := limit - used
+		avail := float64(limit) - used
 		if avail < 0 {
 			avail = 0
 		}
@@ -313,6 +383,32 @@ func (i *TypeInventory) GetResourcePools() map[string]ResourcePool {
 	return pools
 }
 
+// AvailableByZone returns available GPUs (Limit - Used) per zone for a specific
+// accelerator type.
+func (i *TypeInventory) AvailableByZone(accType string) map[string]int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	available := make(map[string]int, len(i.limitByZone[accType]))
+	for zone, limit := range i.limitByZone[accType] {
+		available[zone] = max(limit-i.usedByZone[accType][zone], 0)
+	}
+	return available
+}
+
+// GPUShares returns the number of containers each GPU of an accelerator type can be
+// shared by with time-slicing or MPS, as of the last Refresh: the device requests of a
+// container are that many times its share of physical GPUs. Returns 1 for GPUs that are
+// not shared and unknown types.
+func (i *TypeInventory) GPUShares(accType string) int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if shares, ok := i.sharesByType[accType]; ok {
+		return shares
+	}
+	return 1
+}
+
 // AcceleratorTypes returns all known accelerator types.
 func (i *TypeInventory) AcceleratorTypes() []string {
 	i.mu.RLock()
@@ -334,17 +430,21 @@ func (i *TypeInventory) AcceleratorTypes() []string {
 // - Each accelerator type has its own independent pool
 // - Allocations are tracked per-type
 // - Cross-type allocation is prevented
+// - Variants restricted to zones only allocate from the GPUs of these zones
 type typeAllocator struct {
-	remainingByType map[string]int
-	totalRemaining  int
+	remainingByType map[string]float64
+	// remainingByZone maps accelerator type to zone to remaining GPUs
+	remainingByZone map[string]map[string]float64
+	totalRemaining  float64
 }
 
 // TryAllocate attempts to allocate GPUs from the type-specific pool.
 //
 // The accelerator type is determined from the decision's AcceleratorName field.
-// Returns the actual GPUs allocated (may be less than requested if the type's
-// pool is exhausted).
-func (a *typeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested int) (int, error) {
+// When the decision is restricted to Zones, only the GPUs of these zones are
+// allocated. Returns the actual GPUs allocated (may be less than requested if
+// the type's pool is exhausted), fractional GPUs included.
+func (a *typeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRequested float64) (float64, error) {
 	if gpusRequested <= 0 {
 		return 0, nil
 	}
@@ -356,7 +456,21 @@ func (a *typeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRe
 	}
 
 	available := a.remainingByType[accType]
-	if available <= 0 {
+	// Zone restrictions only apply when the nodes of the type are labeled with zones
+	restricted := len(decision.Zones) > 0 && a.hasZones(accType)
+	var allowed []string
+	if restricted {
+		allowed = decision.Zones
+	}
+	zones := a.allocatableZones(accType, allowed)
+	if restricted {
+		inZones := 0.0
+		for _, zone := range zones {
+			inZones += a.remainingByZone[accType][zone]
+		}
+		available = min(available, inZones)
+	}
+	if available <= gpuEpsilon {
 		return 0, nil // No GPUs available for this type
 	}
 
@@ -369,16 +483,52 @@ func (a *typeAllocator) TryAllocate(decision *interfaces.VariantDecision, gpusRe
 	a.remainingByType[accType] -= allocated
 	a.totalRemaining -= allocated
 
+	// Take the GPUs from the zones with the most remaining first
+	toTake := allocated
+	for _, zone := range zones {
+		taken := min(toTake, a.remainingByZone[accType][zone])
+		a.remainingByZone[accType][zone] -= taken
+		toTake -= taken
+	}
+
 	return allocated, nil
 }
 
+// hasZones returns whether the GPUs of an accelerator type are known by zone.
+func (a *typeAllocator) hasZones(accType string) bool {
+	for zone := range a.remainingByZone[accType] {
+		if zone != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// allocatableZones returns the zones of an accelerator type GPUs can be allocated from,
+// the allowed ones if any, by decreasing remaining GPUs.
+func (a *typeAllocator) allocatableZones(accType string, allowed []string) []string {
+	var zones []string
+	for zone := range a.remainingByZone[accType] {
+		if len(allowed) == 0 || slices.Contains(allowed, zone) {
+			zones = append(zones, zone)
+		}
+	}
+	slices.SortFunc(zones, func(x, y string) int {
+		if c := cmp.Compare(a.remainingByZone[accType][y], a.remainingByZone[accType][x]); c != 0 {
+			return c
+		}
+		return cmp.Compare(x, y)
+	})
+	return zones
+}
+
 // Remaining returns total remaining GPUs across all types.
-func (a *typeAllocator) Remaining() int {
+func (a *typeAllocator) Remaining() float64 {
 	return a.totalRemaining
 }
 
 // RemainingForType returns remaining GPUs for a specific accelerator type.
-func (a *typeAllocator) RemainingForType(accType string) int {
+func (a *typeAllocator) RemainingForType(accType string) float64 {
 	return a.remainingByType[accType]
 }
 
@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -23,12 +24,14 @@ func (m *mockDiscovery) Discover(ctx context.Context) (map[string]map[string]dis
 	return m.inventory, nil
 }
 
-// mockFullDiscovery implements discovery.FullDiscovery for testing.
+// mockFullDiscovery implements discovery.FullDiscovery and discovery.ZoneUsageDiscovery
+// for testing.
 type mockFullDiscovery struct {
-	inventory map[string]map[string]discovery.AcceleratorModelInfo
-	usage     map[string]int
-	discErr   error
-	usageErr  error
+	inventory   map[string]map[string]discovery.AcceleratorModelInfo
+	usage       map[string]int
+	usageByZone map[string]map[string]int
+	discErr     error
+	usageErr    error
 }
 
 func (m *mockFullDiscovery) Discover(ctx context.Context) (map[string]map[string]discovery.AcceleratorModelInfo, error) {
@@ -45,6 +48,13 @@ func (m *mockFullDiscovery) DiscoverUsage(ctx context.Context) (map[string]int,
 	return m.usage, nil
 }
 
+func (m *mockFullDiscovery) DiscoverUsageByZone(ctx context.Context) (map[string]map[string]int, error) {
+	if m.usageErr != nil {
+		return nil, m.usageErr
+	}
+	return m.usageByZone, nil
+}
+
 var _ = Describe("TypeInventory", func() {
 	var ctx context.Context
 
@@ -68,9 +78,9 @@ var _ = Describe("TypeInventory", func() {
 				}
 
 				// With no usage set, available should equal limits
-				Expect(inv.TotalAvailable()).To(Equal(expectedTotal))
+				Expect(inv.TotalAvailable()).To(Equal(float64(expectedTotal)))
 				for accType, expected := range expectedLimits {
-					Expect(inv.AvailableByType(accType)).To(Equal(expected))
+					Expect(inv.AvailableByType(accType)).To(Equal(float64(expected)))
 				}
 			},
 			Entry("single node single type",
@@ -115,6 +125,40 @@ var _ = Describe("TypeInventory", func() {
 		)
 	})
 
+	Describe("GPUShares", func() {
+		It("should track the least shared GPUs of each type", func() {
+			disc := &mockDiscovery{
+				inventory: map[string]map[string]discovery.AcceleratorModelInfo{
+					"node-1": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Shares: 4}},
+					"node-2": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Shares: 2}},
+					"node-3": {"NVIDIA-A100-PCIE-80GB": {Count: 8}},
+				},
+			}
+			inv := NewTypeInventory("test", disc)
+			Expect(inv.Refresh(ctx)).To(Succeed())
+
+			Expect(inv.GPUShares("H100")).To(Equal(2))
+			Expect(inv.GPUShares("A100")).To(Equal(1))
+			Expect(inv.GPUShares("L40S")).To(Equal(1))
+			Expect(inv.LimitByType("H100")).To(Equal(16))
+		})
+
+		It("should aggregate fractional usage", func() {
+			disc := &mockDiscovery{
+				inventory: map[string]map[string]discovery.AcceleratorModelInfo{
+					"node-1": {"H100": {Count: 2, Shares: 2}},
+				},
+			}
+			inv := NewTypeInventory("test", disc)
+			Expect(inv.Refresh(ctx)).To(Succeed())
+
+			// 3 replicas of half a GPU
+			inv.SetUsed(map[string]float64{"H100": 1.5})
+			Expect(inv.AvailableByType("H100")).To(Equal(0.5))
+			Expect(inv.GetResourcePools()).To(HaveKeyWithValue("H100", ResourcePool{Limit: 2, Used: 1.5, Available: 0.5}))
+		})
+	})
+
 	Describe("SetUsed", func() {
 		It("should track GPU usage and update available capacity", func() {
 			disc := &mockDiscovery{
@@ -130,25 +174,25 @@ var _ = Describe("TypeInventory", func() {
 
 			// Initially: limit=24, used=0, available=24
 			Expect(inv.TotalLimit()).To(Equal(24))
-			Expect(inv.TotalUsed()).To(Equal(0))
-			Expect(inv.TotalAvailable()).To(Equal(24))
+			Expect(inv.TotalUsed()).To(Equal(0.0))
+			Expect(inv.TotalAvailable()).To(Equal(24.0))
 
 			// Set some usage
-			inv.SetUsed(map[string]int{"H100": 4, "A100": 2})
+			inv.SetUsed(map[string]float64{"H100": 4, "A100": 2})
 
 			// Now: limit=24, used=6, available=18
 			Expect(inv.TotalLimit()).To(Equal(24))
-			Expect(inv.TotalUsed()).To(Equal(6))
-			Expect(inv.TotalAvailable()).To(Equal(18))
+			Expect(inv.TotalUsed()).To(Equal(6.0))
+			Expect(inv.TotalAvailable()).To(Equal(18.0))
 
 			// Per-type checks
 			Expect(inv.LimitByType("H100")).To(Equal(16))
-			Expect(inv.UsedByType("H100")).To(Equal(4))
-			Expect(inv.AvailableByType("H100")).To(Equal(12))
+			Expect(inv.UsedByType("H100")).To(Equal(4.0))
+			Expect(inv.AvailableByType("H100")).To(Equal(12.0))
 
 			Expect(inv.LimitByType("A100")).To(Equal(8))
-			Expect(inv.UsedByType("A100")).To(Equal(2))
-			Expect(inv.AvailableByType("A100")).To(Equal(6))
+			Expect(inv.UsedByType("A100")).To(Equal(2.0))
+			Expect(inv.AvailableByType("A100")).To(Equal(6.0))
 		})
 	})
 
@@ -165,13 +209,13 @@ var _ = Describe("TypeInventory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Set usage greater than limit (shouldn't happen but handle gracefully)
-			inv.SetUsed(map[string]int{"H100": 12})
+			inv.SetUsed(map[string]float64{"H100": 12})
 
 			// Available should be 0, not negative
 			Expect(inv.TotalLimit()).To(Equal(8))
-			Expect(inv.TotalUsed()).To(Equal(12))
-			Expect(inv.TotalAvailable()).To(Equal(0))
-			Expect(inv.AvailableByType("H100")).To(Equal(0))
+			Expect(inv.TotalUsed()).To(Equal(12.0))
+			Expect(inv.TotalAvailable()).To(Equal(0.0))
+			Expect(inv.AvailableByType("H100")).To(Equal(0.0))
 		})
 	})
 
@@ -196,14 +240,14 @@ var _ = Describe("TypeInventory", func() {
 				Expect(inv.LimitByType("A100")).To(Equal(8))
 
 				// Check usage (auto-discovered)
-				Expect(inv.TotalUsed()).To(Equal(6))
-				Expect(inv.UsedByType("H100")).To(Equal(4))
-				Expect(inv.UsedByType("A100")).To(Equal(2))
+				Expect(inv.TotalUsed()).To(Equal(6.0))
+				Expect(inv.UsedByType("H100")).To(Equal(4.0))
+				Expect(inv.UsedByType("A100")).To(Equal(2.0))
 
 				// Check available
-				Expect(inv.TotalAvailable()).To(Equal(18))
-				Expect(inv.AvailableByType("H100")).To(Equal(12))
-				Expect(inv.AvailableByType("A100")).To(Equal(6))
+				Expect(inv.TotalAvailable()).To(Equal(18.0))
+				Expect(inv.AvailableByType("H100")).To(Equal(12.0))
+				Expect(inv.AvailableByType("A100")).To(Equal(6.0))
 			})
 		})
 
@@ -237,18 +281,18 @@ var _ = Describe("TypeInventory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Set current usage
-			inv.SetUsed(map[string]int{"H100": 4, "A100": 4})
+			inv.SetUsed(map[string]float64{"H100": 4, "A100": 4})
 
 			// Verify inventory state: limit=24, used=8, available=16
 			Expect(inv.TotalLimit()).To(Equal(24))
-			Expect(inv.TotalUsed()).To(Equal(8))
-			Expect(inv.TotalAvailable()).To(Equal(16))
+			Expect(inv.TotalUsed()).To(Equal(8.0))
+			Expect(inv.TotalAvailable()).To(Equal(16.0))
 
 			// Create allocator - should get available (limit - used)
 			allocator := inv.CreateAllocator(ctx)
 
 			// Allocator should have available GPUs (not limits)
-			Expect(allocator.Remaining()).To(Equal(16)) // H100: 16-4=12, A100: 8-4=4
+			Expect(allocator.Remaining()).To(Equal(16.0)) // H100: 16-4=12, A100: 8-4=4
 
 			// Allocate from H100 pool
 			allocated, err := allocator.TryAllocate(&interfaces.VariantDecision{
@@ -257,12 +301,12 @@ var _ = Describe("TypeInventory", func() {
 				AcceleratorName: "H100",
 			}, 4)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(allocated).To(Equal(4))
-			Expect(allocator.Remaining()).To(Equal(12)) // 16 - 4 = 12
+			Expect(allocated).To(Equal(4.0))
+			Expect(allocator.Remaining()).To(Equal(12.0)) // 16 - 4 = 12
 
 			// Original inventory should be unchanged
-			Expect(inv.TotalAvailable()).To(Equal(16))
-			Expect(inv.AvailableByType("H100")).To(Equal(12))
+			Expect(inv.TotalAvailable()).To(Equal(16.0))
+			Expect(inv.AvailableByType("H100")).To(Equal(12.0))
 		})
 
 		It("should handle partial allocation when resources exhausted", func() {
@@ -277,10 +321,10 @@ var _ = Describe("TypeInventory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Set high usage - only 2 GPUs available
-			inv.SetUsed(map[string]int{"H100": 6})
+			inv.SetUsed(map[string]float64{"H100": 6})
 
 			allocator := inv.CreateAllocator(ctx)
-			Expect(allocator.Remaining()).To(Equal(2))
+			Expect(allocator.Remaining()).To(Equal(2.0))
 
 			// Request more than available - should get partial allocation
 			allocated, err := allocator.TryAllocate(&interfaces.VariantDecision{
@@ -289,8 +333,8 @@ var _ = Describe("TypeInventory", func() {
 				AcceleratorName: "H100",
 			}, 4)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(allocated).To(Equal(2)) // Only 2 available
-			Expect(allocator.Remaining()).To(Equal(0))
+			Expect(allocated).To(Equal(2.0)) // Only 2 available
+			Expect(allocator.Remaining()).To(Equal(0.0))
 		})
 	})
 })
@@ -305,9 +349,9 @@ var _ = Describe("TypeAllocator", func() {
 
 	Describe("TryAllocate", func() {
 		DescribeTable("should allocate GPUs correctly",
-			func(initialByType map[string]int, decision *interfaces.VariantDecision, gpusRequested int, expectedAllocated int, expectedRemaining map[string]int, expectError bool) {
+			func(initialByType map[string]float64, decision *interfaces.VariantDecision, gpusRequested, expectedAllocated float64, expectedRemaining map[string]float64, expectError bool) {
 				// Calculate initial total
-				total := 0
+				total := 0.0
 				for _, count := range initialByType {
 					total += count
 				}
@@ -332,52 +376,59 @@ var _ = Describe("TypeAllocator", func() {
 				}
 			},
 			Entry("allocate from available pool",
-				map[string]int{"H100": 16, "A100": 8},
+				map[string]float64{"H100": 16, "A100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
-				4, 4,
-				map[string]int{"H100": 12, "A100": 8},
+				4.0, 4.0,
+				map[string]float64{"H100": 12, "A100": 8},
 				false,
 			),
 			Entry("allocate entire pool",
-				map[string]int{"H100": 8},
+				map[string]float64{"H100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
-				8, 8,
-				map[string]int{"H100": 0},
+				8.0, 8.0,
+				map[string]float64{"H100": 0},
 				false,
 			),
 			Entry("partial allocation when pool exhausted",
-				map[string]int{"H100": 4},
+				map[string]float64{"H100": 4},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
-				8, 4,
-				map[string]int{"H100": 0},
+				8.0, 4.0,
+				map[string]float64{"H100": 0},
 				false,
 			),
 			Entry("no allocation when type not available",
-				map[string]int{"A100": 8},
+				map[string]float64{"A100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
-				4, 0,
-				map[string]int{"A100": 8},
+				4.0, 0.0,
+				map[string]float64{"A100": 8},
 				false,
 			),
 			Entry("error when accelerator name not specified",
-				map[string]int{"H100": 8},
+				map[string]float64{"H100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default"},
-				4, 0,
+				4.0, 0.0,
 				nil,
 				true,
 			),
 			Entry("zero request returns zero",
-				map[string]int{"H100": 8},
+				map[string]float64{"H100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
-				0, 0,
-				map[string]int{"H100": 8},
+				0.0, 0.0,
+				map[string]float64{"H100": 8},
+				false,
+			),
+			Entry("fractional allocation of shared GPUs",
+				map[string]float64{"H100": 1},
+				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "H100"},
+				1.5, 1.0,
+				map[string]float64{"H100": 0},
 				false,
 			),
 			Entry("types are isolated",
-				map[string]int{"H100": 4, "A100": 8},
+				map[string]float64{"H100": 4, "A100": 8},
 				&interfaces.VariantDecision{VariantName: "model-a", Namespace: "default", AcceleratorName: "A100"},
-				6, 6,
-				map[string]int{"H100": 4, "A100": 2},
+				6.0, 6.0,
+				map[string]float64{"H100": 4, "A100": 2},
 				false,
 			),
 		)
@@ -386,7 +437,7 @@ var _ = Describe("TypeAllocator", func() {
 	Describe("Multiple Allocations", func() {
 		It("should track state across multiple allocations", func() {
 			allocator := &typeAllocator{
-				remainingByType: map[string]int{"H100": 16, "A100": 8},
+				remainingByType: map[string]float64{"H100": 16, "A100": 8},
 				totalRemaining:  24,
 			}
 
@@ -397,10 +448,10 @@ var _ = Describe("TypeAllocator", func() {
 				AcceleratorName: "H100",
 			}, 4)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(allocated).To(Equal(4))
-			Expect(allocator.RemainingForType("H100")).To(Equal(12))
-			Expect(allocator.RemainingForType("A100")).To(Equal(8))
-			Expect(allocator.Remaining()).To(Equal(20))
+			Expect(allocated).To(Equal(4.0))
+			Expect(allocator.RemainingForType("H100")).To(Equal(12.0))
+			Expect(allocator.RemainingForType("A100")).To(Equal(8.0))
+			Expect(allocator.Remaining()).To(Equal(20.0))
 
 			// Second allocation: 6 A100 GPUs
 			allocated, err = allocator.TryAllocate(&interfaces.VariantDecision{
@@ -409,10 +460,10 @@ var _ = Describe("TypeAllocator", func() {
 				AcceleratorName: "A100",
 			}, 6)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(allocated).To(Equal(6))
-			Expect(allocator.RemainingForType("H100")).To(Equal(12))
-			Expect(allocator.RemainingForType("A100")).To(Equal(2))
-			Expect(allocator.Remaining()).To(Equal(14))
+			Expect(allocated).To(Equal(6.0))
+			Expect(allocator.RemainingForType("H100")).To(Equal(12.0))
+			Expect(allocator.RemainingForType("A100")).To(Equal(2.0))
+			Expect(allocator.Remaining()).To(Equal(14.0))
 
 			// Third allocation: more H100 than available
 			allocated, err = allocator.TryAllocate(&interfaces.VariantDecision{
@@ -421,17 +472,83 @@ var _ = Describe("TypeAllocator", func() {
 				AcceleratorName: "H100",
 			}, 20)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(allocated).To(Equal(12)) // Only 12 remaining
-			Expect(allocator.RemainingForType("H100")).To(Equal(0))
-			Expect(allocator.RemainingForType("A100")).To(Equal(2))
-			Expect(allocator.Remaining()).To(Equal(2))
+			Expect(allocated).To(Equal(12.0)) // Only 12 remaining
+			Expect(allocator.RemainingForType("H100")).To(Equal(0.0))
+			Expect(allocator.RemainingForType("A100")).To(Equal(2.0))
+			Expect(allocator.Remaining()).To(Equal(2.0))
+		})
+	})
+})
+
+var _ = Describe("Zone-restricted allocation", func() {
+	var (
+		ctx context.Context
+		inv *TypeInventory
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		inv = NewTypeInventoryWithUsage("test", &mockFullDiscovery{
+			inventory: map[string]map[string]discovery.AcceleratorModelInfo{
+				"node-a": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Zone: "zone-a"}},
+				"node-b": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Zone: "zone-b"}},
+				"node-c": {"NVIDIA-H100-SXM5-80GB": {Count: 8, Zone: "zone-c"}},
+			},
+			usage:       map[string]int{"H100": 6},
+			usageByZone: map[string]map[string]int{"NVIDIA-H100-SXM5-80GB": {"zone-a": 6}},
+		})
+		Expect(inv.RefreshAll(ctx)).To(Succeed())
+	})
+
+	It("should track capacity and usage per zone", func() {
+		Expect(inv.AvailableByZone("H100")).To(Equal(map[string]int{"zone-a": 2, "zone-b": 8, "zone-c": 8}))
+	})
+
+	It("should only allocate the GPUs of the allowed zones", func() {
+		allocator := inv.CreateAllocator(ctx)
+		restricted := &interfaces.VariantDecision{VariantName: "model-a", AcceleratorName: "H100", Zones: []string{"zone-a", "zone-b"}}
+
+		allocated, err := allocator.TryAllocate(restricted, 12)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocated).To(Equal(10.0))
+
+		// the rest of the type is left to unrestricted variants
+		allocated, err = allocator.TryAllocate(&interfaces.VariantDecision{VariantName: "model-b", AcceleratorName: "H100"}, 12)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocated).To(Equal(8.0))
+	})
+
+	It("should share the zones with unrestricted variants", func() {
+		allocator := inv.CreateAllocator(ctx)
+
+		// unrestricted variants take the zones with the most GPUs first
+		allocated, err := allocator.TryAllocate(&interfaces.VariantDecision{VariantName: "model-b", AcceleratorName: "H100"}, 14)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocated).To(Equal(14.0))
+
+		allocated, err = allocator.TryAllocate(&interfaces.VariantDecision{VariantName: "model-a", AcceleratorName: "H100", Zones: []string{"zone-a"}}, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocated).To(Equal(2.0))
+	})
+
+	It("should not restrict allocation when nodes have no zone", func() {
+		unzoned := NewTypeInventory("test", &mockDiscovery{
+			inventory: map[string]map[string]discovery.AcceleratorModelInfo{
+				"node-a": {"H100": {Count: 8}},
+			},
 		})
+		Expect(unzoned.Refresh(ctx)).To(Succeed())
+
+		allocated, err := unzoned.CreateAllocator(ctx).TryAllocate(
+			&interfaces.VariantDecision{VariantName: "model-a", AcceleratorName: "H100", Zones: []string{"zone-a"}}, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allocated).To(Equal(4.0))
 	})
 })
 
-// copyMap creates a copy of a map[string]int
-func copyMap(m map[string]int) map[string]int {
-	result := make(map[string]int, len(m))
+// copyMap creates a copy of a map[string]float64
+func copyMap(m map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(m))
 	for k, v := range m {
 		result[k] = v
 	}
@@ -478,3 +595,69 @@ var _ = Describe("normalizeAcceleratorName", func() {
 		})
 	})
 })
+
+var _ = Describe("DefaultLimiter with a fake inventory provider", func() {
+	var (
+		ctx      context.Context
+		provider *discovery.FakeInventory
+		limiter  *DefaultLimiter
+	)
+
+	newDecision := func() *interfaces.VariantDecision {
+		return &interfaces.VariantDecision{
+			VariantName:     "v1",
+			Namespace:       "default",
+			AcceleratorName: "A100",
+			CurrentReplicas: 2,
+			TargetReplicas:  5,
+			GPUsPerReplica:  2,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		provider = discovery.NewFakeInventory()
+		provider.SetNode("node-1", map[string]discovery.AcceleratorModelInfo{
+			"NVIDIA-A100-PCIE-80GB": {Count: 8, Memory: "81920"},
+		})
+		inventory := NewTypeInventoryWithUsage("fake-inventory", provider)
+		limiter = NewDefaultLimiter("gpu-limiter", inventory, NewGreedyBySaturation())
+	})
+
+	It("should limit scale-up to the provided capacity", func() {
+		decision := newDecision()
+		Expect(limiter.Limit(ctx, []*interfaces.VariantDecision{decision})).To(Succeed())
+
+		// 8 GPUs, 4 used by current replicas: room for 2 more replicas
+		Expect(decision.TargetReplicas).To(Equal(4))
+		Expect(decision.GPUsAllocated).To(Equal(4.0))
+		Expect(decision.WasLimited).To(BeTrue())
+		Expect(decision.LimitedBy).To(Equal("gpu-limiter"))
+	})
+
+	It("should see capacity changes on the next run", func() {
+		provider.SetNode("node-2", map[string]discovery.AcceleratorModelInfo{
+			"NVIDIA-A100-PCIE-80GB": {Count: 8, Memory: "81920"},
+		})
+
+		decision := newDecision()
+		Expect(limiter.Limit(ctx, []*interfaces.VariantDecision{decision})).To(Succeed())
+
+		Expect(decision.TargetReplicas).To(Equal(5))
+		Expect(decision.WasLimited).To(BeFalse())
+		Expect(provider.DiscoverCalls()).To(Equal(1))
+	})
+
+	It("should expose the provided capacity as constraints", func() {
+		constraints, err := limiter.ComputeConstraints(ctx, map[string]float64{"A100": 6})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(constraints.Pools).To(HaveKeyWithValue("A100", ResourcePool{Limit: 8, Used: 6, Available: 2}))
+	})
+
+	It("should fail when the provider fails", func() {
+		provider.SetDiscoverError(errors.New("nodes unavailable"))
+
+		err := limiter.Limit(ctx, []*interfaces.VariantDecision{newDecision()})
+		Expect(err).To(MatchError(ContainSubstring("nodes unavailable")))
+	})
+})
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// WarmUpStepName is the DecisionStep name recorded when the target of a VariantAutoscaling
+// in its warm-up period is held at the current replicas.
+const WarmUpStepName = "warm-up"
+
+// HoldWarmingUpVariants holds the targets of VariantAutoscalings created less than period
+// ago at their current replicas. Right after creation the metrics of a variant cover a
+// fraction of the analysis window and its deployment may still be rolling out, so a first
+// decision would be half-informed and fight the rollout. Decisions in the warm-up period
+// are marked WarmingUp while their status is populated as usual. Variants at 0 replicas
+// are not held, so that they can scale up from zero. A period of 0 disables the warm-up.
+// It returns the variants whose target was held.
+func HoldWarmingUpVariants(ctx context.Context, decisions []interfaces.VariantDecision,
+	period time.Duration, now time.Time) []types.NamespacedName {
+
+	if period <= 0 {
+		return nil
+	}
+	ctx = logging.IntoModule(ctx, logging.ModuleSolver)
+	logger := ctrl.LoggerFrom(ctx)
+
+	var held []types.NamespacedName
+	for i := range decisions {
+		d := &decisions[i]
+		if d.CreatedAt.IsZero() || now.Sub(d.CreatedAt) >= period {
+			continue
+		}
+		d.WarmingUp = true
+		if d.CurrentReplicas == 0 || d.TargetReplicas == d.CurrentReplicas {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		remaining := d.CreatedAt.Add(period).Sub(now).Round(time.Second)
+		d.AddDecisionStep(WarmUpStepName,
+			fmt.Sprintf("target of %d replicas held at %d for the warm-up period (%v left)",
+				proposed, d.TargetReplicas, remaining),
+			true)
+		held = append(held, types.NamespacedName{Namespace: d.Namespace, Name: d.VariantName})
+
+		logger.V(logging.DEBUG).Info("Target held during warm-up",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"proposed", proposed,
+			"target", d.TargetReplicas,
+			"remaining", remaining)
+	}
+	return held
+}
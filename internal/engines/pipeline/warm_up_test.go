@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("HoldWarmingUpVariants", func() {
+	var (
+		ctx context.Context
+		now time.Time
+	)
+
+	decision := func(age time.Duration, current, target int) []interfaces.VariantDecision {
+		action := interfaces.ActionScaleDown
+		if target > current {
+			action = interfaces.ActionScaleUp
+		}
+		return []interfaces.VariantDecision{{
+			VariantName:     "variant-a",
+			Namespace:       "ns",
+			CurrentReplicas: current,
+			TargetReplicas:  target,
+			CreatedAt:       now.Add(-age),
+			Action:          action,
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Now()
+	})
+
+	It("should hold the target of a new variant at the current replicas", func() {
+		decisions := decision(time.Minute, 2, 5)
+		held := HoldWarmingUpVariants(ctx, decisions, 5*time.Minute, now)
+		Expect(held).To(ConsistOf(types.NamespacedName{Namespace: "ns", Name: "variant-a"}))
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].WarmingUp).To(BeTrue())
+		Expect(decisions[0].LastStep().Name).To(Equal(WarmUpStepName))
+	})
+
+	It("should mark steady variants in the warm-up period without holding them", func() {
+		decisions := decision(time.Minute, 2, 2)
+		Expect(HoldWarmingUpVariants(ctx, decisions, 5*time.Minute, now)).To(BeEmpty())
+		Expect(decisions[0].WarmingUp).To(BeTrue())
+	})
+
+	It("should let variants scale up from zero", func() {
+		decisions := decision(time.Minute, 0, 1)
+		Expect(HoldWarmingUpVariants(ctx, decisions, 5*time.Minute, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+	})
+
+	It("should pass targets through after the warm-up period or when disabled", func() {
+		decisions := decision(10*time.Minute, 4, 1)
+		Expect(HoldWarmingUpVariants(ctx, decisions, 5*time.Minute, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+		Expect(decisions[0].WarmingUp).To(BeFalse())
+
+		decisions = decision(time.Minute, 4, 1)
+		Expect(HoldWarmingUpVariants(ctx, decisions, 0, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+
+		decisions = decision(time.Minute, 4, 1)
+		decisions[0].CreatedAt = time.Time{}
+		Expect(HoldWarmingUpVariants(ctx, decisions, 5*time.Minute, now)).To(BeEmpty())
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// updateNamespaceCapacityReports writes one NamespaceCapacityReport per namespace
+// summarizing the decisions of all its VariantAutoscalings, and deletes the reports of
+// namespaces left without any VariantAutoscaling. VAs without a decision in the current
+// optimization run, e.g. whose metrics are unavailable, are counted with their last
+// decision. Failures are logged per namespace and never fail the optimization loop:
+// the report is informational.
+func (e *Engine) updateNamespaceCapacityReports(ctx context.Context, decisions []interfaces.VariantDecision) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	reports := utils.BuildNamespaceCapacityReports(e.withLastDecisions(ctx, decisions))
+	for namespace, status := range reports {
+		if err := e.writeNamespaceCapacityReport(ctx, namespace, status); err != nil {
+			logger.Error(err, "Failed to update namespace capacity report",
+				"namespace", namespace)
+		}
+	}
+
+	if err := e.deleteStaleNamespaceCapacityReports(ctx, reports); err != nil {
+		logger.Error(err, "Failed to delete stale namespace capacity reports")
+	}
+}
+
+// withLastDecisions records the decisions of the run as the last decisions of their VAs,
+// and returns them completed with the last decision of the existing VAs without one in
+// this run. When the VAs cannot be listed, it returns the decisions of the run.
+func (e *Engine) withLastDecisions(ctx context.Context, decisions []interfaces.VariantDecision) []interfaces.VariantDecision {
+	if e.reportedDecisions == nil {
+		e.reportedDecisions = make(map[string]interfaces.VariantDecision)
+	}
+	decided := make(map[string]bool, len(decisions))
+	for _, d := range decisions {
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		decided[key] = true
+		e.reportedDecisions[key] = d
+	}
+
+	vas, err := utils.ReadyVariantAutoscaling(ctx, e.client)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list VariantAutoscalings, reporting the capacity of the decisions of this run only")
+		return decisions
+	}
+	all := append(make([]interfaces.VariantDecision, 0, len(vas)), decisions...)
+	for _, va := range vas {
+		key := utils.GetNamespacedKey(va.Namespace, va.Name)
+		if decided[key] {
+			continue
+		}
+		if last, ok := e.reportedDecisions[key]; ok {
+			all = append(all, last)
+		}
+	}
+	return all
+}
+
+// deleteStaleNamespaceCapacityReports deletes the reports of the namespaces without a
+// report in this run whose last VariantAutoscaling is gone. The reports of namespaces
+// whose VAs made no decision in this run, e.g. VAs of other controller instances, are kept.
+func (e *Engine) deleteStaleNamespaceCapacityReports(
+	ctx context.Context,
+	reports map[string]llmdVariantAutoscalingV1alpha1.NamespaceCapacityReportStatus,
+) error {
+	var existing llmdVariantAutoscalingV1alpha1.NamespaceCapacityReportList
+	if err := e.client.List(ctx, &existing); err != nil {
+		return fmt.Errorf("failed to list namespace capacity reports: %w", err)
+	}
+
+	for i := range existing.Items {
+		report := &existing.Items[i]
+		if report.Name != llmdVariantAutoscalingV1alpha1.DefaultNamespaceCapacityReportName {
+			continue
+		}
+		if _, ok := reports[report.Namespace]; ok {
+			continue
+		}
+		var vas llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+		if err := e.client.List(ctx, &vas, client.InNamespace(report.Namespace), client.Limit(1)); err != nil {
+			return fmt.Errorf("failed to list VariantAutoscalings in namespace %s: %w", report.Namespace, err)
+		}
+		if len(vas.Items) > 0 {
+			continue
+		}
+		if err := e.client.Delete(ctx, report); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete namespace capacity report of namespace %s: %w", report.Namespace, err)
+		}
+		ctrl.LoggerFrom(ctx).Info("Deleted the capacity report of a namespace without VariantAutoscalings",
+			"namespace", report.Namespace)
+	}
+	return nil
+}
+
+// writeNamespaceCapacityReport creates the namespace's report if it does not exist
+// yet and replaces its status, unless the status is unchanged.
+func (e *Engine) writeNamespaceCapacityReport(
+	ctx context.Context,
+	namespace string,
+	status llmdVariantAutoscalingV1alpha1.NamespaceCapacityReportStatus,
+) error {
+	key := client.ObjectKey{Namespace: namespace, Name: llmdVariantAutoscalingV1alpha1.DefaultNamespaceCapacityReportName}
+
+	var report llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport
+	if err := e.client.Get(ctx, key, &report); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get namespace capacity report: %w", err)
+		}
+		report = llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		}
+		if err := e.client.Create(ctx, &report); err != nil {
+			return fmt.Errorf("failed to create namespace capacity report: %w", err)
+		}
+	}
+
+	status.LastUpdateTime = report.Status.LastUpdateTime
+	if equality.Semantic.DeepEqual(report.Status, status) {
+		return nil
+	}
+	status.LastUpdateTime = metav1.Now()
+	report.Status = status
+	if err := e.client.Status().Update(ctx, &report); err != nil {
+		return fmt.Errorf("failed to update namespace capacity report status: %w", err)
+	}
+	return nil
+}
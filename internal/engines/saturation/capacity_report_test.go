@@ -0,0 +1,95 @@
+package saturation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("Namespace capacity reports", func() {
+	var (
+		ctx    context.Context
+		c      client.Client
+		engine *Engine
+	)
+
+	report := func(namespace string) *llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport {
+		return &llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      llmdVariantAutoscalingV1alpha1.DefaultNamespaceCapacityReportName,
+				Namespace: namespace,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(llmdVariantAutoscalingV1alpha1.AddToScheme(scheme)).To(Succeed())
+		va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle-va", Namespace: "ncr-idle"},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(report("ncr-gone"), report("ncr-idle"), va).
+			WithStatusSubresource(&llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport{}).
+			Build()
+		engine = &Engine{client: c}
+	})
+
+	It("should delete the reports of namespaces whose last VA is gone", func() {
+		engine.updateNamespaceCapacityReports(ctx, []interfaces.VariantDecision{{
+			VariantName:     "llama",
+			Namespace:       "ncr-active",
+			ModelID:         "meta/llama",
+			CurrentReplicas: 2,
+			TargetReplicas:  2,
+		}})
+
+		var updated llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(report("ncr-active")), &updated)).To(Succeed())
+		Expect(updated.Status.TotalReplicas).To(Equal(2))
+
+		By("keeping the report of a namespace whose VAs made no decision")
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(report("ncr-idle")), &updated)).To(Succeed())
+
+		err := c.Get(ctx, client.ObjectKeyFromObject(report("ncr-gone")), &updated)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should count the VAs without a decision in the run with their last decision", func() {
+		decision := func(name string, replicas int) interfaces.VariantDecision {
+			return interfaces.VariantDecision{
+				VariantName:     name,
+				Namespace:       "ncr-idle",
+				ModelID:         "meta/llama",
+				CurrentReplicas: replicas,
+				TargetReplicas:  replicas,
+			}
+		}
+		Expect(c.Create(ctx, &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "busy-va", Namespace: "ncr-idle"},
+		})).To(Succeed())
+		engine.updateNamespaceCapacityReports(ctx, []interfaces.VariantDecision{decision("idle-va", 2), decision("busy-va", 1)})
+
+		By("keeping the replicas of the VA whose metrics are unavailable in the next run")
+		engine.updateNamespaceCapacityReports(ctx, []interfaces.VariantDecision{decision("busy-va", 3)})
+		var updated llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(report("ncr-idle")), &updated)).To(Succeed())
+		Expect(updated.Status.TotalReplicas).To(Equal(5))
+
+		By("not writing an unchanged report")
+		engine.updateNamespaceCapacityReports(ctx, []interfaces.VariantDecision{decision("busy-va", 3)})
+		var unchanged llmdVariantAutoscalingV1alpha1.NamespaceCapacityReport
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(report("ncr-idle")), &unchanged)).To(Succeed())
+		Expect(unchanged.ResourceVersion).To(Equal(updated.ResourceVersion))
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator/concurrency"
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// ConcurrencyTuningStepName is the decision step of replica changes held by concurrency tuning.
+const ConcurrencyTuningStepName = "concurrency-tuning"
+
+// tuneConcurrency sets the max-num-seqs of the replicas of the variants as planned by
+// concurrency.Plan from their decisions, and holds the replica changes the concurrency
+// absorbs. The base max-num-seqs of a variant is the one of its deployment args. Targets
+// surged ahead of node drains are left as they are, since evicted replicas take their
+// concurrency with them. Failures are logged and leave the replica change of the decision
+// as it is.
+func (e *Engine) tuneConcurrency(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if e.concurrencyTuner == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	maxDelta := e.Config.ConcurrencyMaxDelta()
+
+	for i := range decisions {
+		d := &decisions[i]
+		if d.Error != nil || d.CurrentReplicas <= 0 || d.DrainSurge > 0 {
+			continue
+		}
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		va, ok := vaMap[key]
+		if !ok || !targetsDeployment(va) {
+			continue
+		}
+		var deploy appsv1.Deployment
+		if err := utils.GetDeploymentWithBackoff(ctx, e.client, va.GetScaleTargetName(), va.Namespace, &deploy); err != nil {
+			logger.Error(err, "Failed to get scale target to tune concurrency", "variant", va.Name)
+			continue
+		}
+
+		base := saturation_v2.ParseVLLMArgs(&deploy).MaxNumSeqs
+		applied := e.concurrencyTuner.Applied(key, base)
+		maxNumSeqs, hold := concurrency.Plan(base, applied, d.CurrentReplicas, d.TargetReplicas, maxDelta)
+		// replicas started since the last change run with their args: apply while raised
+		if maxNumSeqs == base && applied == base {
+			continue
+		}
+		if _, err := e.concurrencyTuner.Apply(ctx, key, &deploy, base, maxNumSeqs); err != nil {
+			logger.Error(err, "Failed to tune concurrency, keeping the replica change", "variant", va.Name)
+			continue
+		}
+		if maxNumSeqs != base {
+			d.MaxNumSeqs = maxNumSeqs
+		}
+		if !hold {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		d.ConcurrencyAbsorbed = true
+		if proposed > d.CurrentReplicas {
+			d.Reason = fmt.Sprintf("scale-up to %d replicas absorbed by max-num-seqs %d (deployment args: %d)",
+				proposed, maxNumSeqs, base)
+		} else {
+			d.Reason = fmt.Sprintf("scale-down to %d replicas held while max-num-seqs is reset from %d to %d",
+				proposed, applied, maxNumSeqs)
+		}
+		d.AddDecisionStep(ConcurrencyTuningStepName, d.Reason, true)
+
+		logger.Info("Replica change held by concurrency tuning",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"proposed", proposed,
+			"target", d.TargetReplicas,
+			"maxNumSeqs", maxNumSeqs,
+			"previousMaxNumSeqs", applied)
+	}
+}
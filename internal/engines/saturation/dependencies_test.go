@@ -0,0 +1,112 @@
+package saturation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// fakeCollector serves canned replica metrics.
+type fakeCollector struct {
+	replicaMetrics []interfaces.ReplicaMetrics
+}
+
+func (c *fakeCollector) CollectReplicaMetrics(
+	_ context.Context, _, _ string,
+	_ map[string]interfaces.Workload,
+	_ map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	_ map[string]float64,
+) ([]interfaces.ReplicaMetrics, error) {
+	return c.replicaMetrics, nil
+}
+
+// fakeActuator records the emitted desired replicas of the variants.
+type fakeActuator struct {
+	current int32
+	desired map[string]int32
+}
+
+func (a *fakeActuator) EmitMetrics(_ context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) error {
+	a.desired[va.Name] = int32(va.Status.DesiredOptimizedAlloc.NumReplicas)
+	return nil
+}
+
+func (a *fakeActuator) EmitReplicaMetrics(_ context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, _, desired int32, _ string) error {
+	a.desired[va.Name] = desired
+	return nil
+}
+
+func (a *fakeActuator) GetCurrentDeploymentReplicas(context.Context, *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) (int32, error) {
+	return a.current, nil
+}
+
+var _ = Describe("Engine dependencies", func() {
+	var sourceRegistry *source.SourceRegistry
+
+	BeforeEach(func() {
+		sourceRegistry = source.NewSourceRegistry()
+		sourceRegistry.Register("prometheus", source.NewNoOpSource()) // nolint:errcheck
+	})
+
+	It("should use the default components when none are injected", func() {
+		engine := NewEngineWithDependencies(k8sClient, k8sClient.Scheme(), nil, sourceRegistry, config.NewTestConfig(), Dependencies{})
+
+		Expect(engine.ReplicaMetricsCollector).NotTo(BeNil())
+		Expect(engine.optimizer.Name()).To(Equal(pipeline.NewCostAwareOptimizer().Name()))
+		Expect(engine.GPULimiter).NotTo(BeNil())
+		Expect(engine.FairGPULimiter).NotTo(BeNil())
+		Expect(engine.actuator).To(BeAssignableToTypeOf(&actuator.Actuator{}))
+	})
+
+	It("should use the injected components", func() {
+		collector := &fakeCollector{replicaMetrics: []interfaces.ReplicaMetrics{{PodName: "pod-a"}}}
+		act := &fakeActuator{current: 2, desired: make(map[string]int32)}
+		engine := NewEngineWithDependencies(k8sClient, k8sClient.Scheme(), nil, sourceRegistry, config.NewTestConfig(), Dependencies{
+			Collector: collector,
+			Actuator:  act,
+		})
+
+		Expect(engine.ReplicaMetricsCollector).To(BeIdenticalTo(collector))
+
+		By("emitting the safety net metrics with the injected actuator")
+		va := llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "injected-va", Namespace: "default"},
+		}
+		va.Status.DesiredOptimizedAlloc = llmdVariantAutoscalingV1alpha1.OptimizedAlloc{NumReplicas: 3, Accelerator: "H100"}
+		engine.emitSafetyNetMetrics(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{va}, nil)
+
+		Expect(act.desired).To(HaveKeyWithValue("injected-va", int32(3)))
+	})
+
+	It("should not emit the metrics of variants in dry-run", func() {
+		act := &fakeActuator{current: 2, desired: make(map[string]int32)}
+		engine := NewEngineWithDependencies(k8sClient, k8sClient.Scheme(), nil, sourceRegistry, config.NewTestConfig(), Dependencies{
+			Actuator: act,
+		})
+		va := llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "dry-run-va",
+				Namespace:   "default",
+				Annotations: map[string]string{constants.DryRunAnnotationKey: "true"},
+			},
+		}
+		va.Status.DesiredOptimizedAlloc = llmdVariantAutoscalingV1alpha1.OptimizedAlloc{NumReplicas: 3, Accelerator: "H100"}
+		engine.emitSafetyNetMetrics(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{va}, nil)
+		Expect(act.desired).To(BeEmpty())
+
+		By("emitting them again once out of dry-run")
+		delete(va.Annotations, constants.DryRunAnnotationKey)
+		engine.emitSafetyNetMetrics(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{va}, nil)
+		Expect(act.desired).To(HaveKeyWithValue("dry-run-va", int32(3)))
+	})
+})
@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/dryrun"
+)
+
+// SetDryRunHistory makes the engine record the metrics of the models it analyzes in history,
+// to replay candidate saturation scaling ConfigMaps against.
+func (e *Engine) SetDryRunHistory(history *dryrun.History) {
+	e.dryRunHistory = history
+}
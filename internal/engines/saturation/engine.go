@@ -23,29 +23,42 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	actuator "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator/concurrency"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator/deletioncost"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator/prepull"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/enrichment"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/backpressure"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/dryrun"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/executor"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/heatmap"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/overload"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/showback"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/snapshot"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
-	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
 )
 
 // Constants for MetricsAvailable condition
@@ -59,6 +72,9 @@ const (
 	MetricsMessageUnavailable = "No saturation metrics available - pods may not be ready or metrics not yet scraped"
 )
 
+// errNoDefaultSaturationConfig is the error of a namespace without default saturation scaling config
+var errNoDefaultSaturationConfig = fmt.Errorf("%w: default saturation scaling config not found", wvaerrors.ErrInvalidConfiguration)
+
 type Engine struct {
 	client   client.Client
 	scheme   *runtime.Scheme
@@ -68,7 +84,7 @@ type Engine struct {
 	Config   *config.Config // Unified configuration (injected from main.go)
 
 	// ReplicaMetricsCollector is the collector for replica metrics using the source infrastructure
-	ReplicaMetricsCollector *collector.ReplicaMetricsCollector
+	ReplicaMetricsCollector interfaces.ReplicaMetricsCollector
 
 	// ScaleToZeroEnforcer applies scale-to-zero and minimum replica enforcement
 	ScaleToZeroEnforcer *pipeline.Enforcer
@@ -77,6 +93,14 @@ type Engine struct {
 	// Only applied when EnableLimiter is true in the saturation config.
 	GPULimiter pipeline.Limiter
 
+	// CapacityCeiling caps scaling targets at the replicas the cluster could place on
+	// each accelerator type. Applied when the GPU limiter is not.
+	CapacityCeiling *pipeline.CapacityCeiling
+
+	// FairGPULimiter shares available GPU resources across tenants with max-min fairness.
+	// Used instead of GPULimiter when LimiterPolicy is "max-min-fairness".
+	FairGPULimiter pipeline.Limiter
+
 	// metricsRegistry is used to access metrics sources for request count queries
 	metricsRegistry *source.SourceRegistry
 
@@ -90,40 +114,227 @@ type Engine struct {
 	// AnalyzerResults. Selected at engine init: CostAwareOptimizer (unlimited)
 	// or GreedyBySaturationOptimizer (limited).
 	optimizer pipeline.ScalingOptimizer
+
+	// inventoryProvider supplies the accelerator inventory for the GPU limiter,
+	// the capacity ceiling, and limited-mode inventory collection.
+	inventoryProvider discovery.InventoryProvider
+
+	// gpuInventory is the accelerator inventory the GPU limiter allocates from, also
+	// used to place the replicas of exhausted variants on their fallback class.
+	gpuInventory *pipeline.TypeInventory
+
+	// dampener holds back scaling changes that are not yet stable across
+	// optimization runs (anti-flapping). Keeps state across runs.
+	dampener *pipeline.ChangeDampener
+
+	// scaleDownQuorum holds scale-downs until enough recent optimization runs
+	// proposed them. Keeps state across runs.
+	scaleDownQuorum *pipeline.ScaleDownQuorum
+
+	// behavior applies the stabilization windows and scaling policies of the
+	// spec.behavior of VAs. Keeps state across runs.
+	behavior *pipeline.ScalingBehavior
+
+	// rollout spreads large scale-ups over health-checked steps.
+	// Keeps state across runs.
+	rollout *pipeline.GraduatedRollout
+
+	// verifier holds scale-ups of variants whose previous scale-up did not
+	// reduce saturation. Keeps state across runs.
+	verifier *pipeline.ScaleUpVerifier
+
+	// prepuller pre-pulls images of opted-in variants on scale-up (nil when disabled)
+	prepuller *prepull.Manager
+
+	// deletionCoster sets the pod deletion cost of variants that scale down, so the
+	// newest and least-warmed replicas go first (nil when disabled)
+	deletionCoster *deletioncost.Manager
+
+	// concurrencyTuner absorbs small scale-ups by raising the max-num-seqs of the
+	// replicas at runtime (nil when disabled)
+	concurrencyTuner *concurrency.Manager
+
+	// logSampler selects the optimization cycles logging per-replica and
+	// per-variant detail; the others log summaries.
+	logSampler *logging.DetailSampler
+
+	// actuator reads the current replicas of the scale targets and emits the metrics
+	// external autoscalers scale on
+	actuator interfaces.Actuator
+
+	// backpressure defers low-priority variants while the controller is overloaded.
+	// Keeps state across runs.
+	backpressure *backpressure.Monitor
+
+	// snapshotStore hands the engine state over on leader change (nil when disabled)
+	snapshotStore *snapshot.Store
+
+	// showbackTracker accumulates the cost and replica-hours of the variants (nil when
+	// not set), and showbackPublisher writes completed reports to ConfigMaps (nil when disabled)
+	showbackTracker   *showback.Tracker
+	showbackPublisher *showback.Publisher
+
+	// overloadSignaler signals the overload of models to the gateway on their
+	// InferencePools (nil when disabled)
+	overloadSignaler *overload.Signaler
+
+	// dryRunHistory records the metrics of the analyzed models, to replay candidate
+	// saturation scaling ConfigMaps against (nil when disabled)
+	dryRunHistory *dryrun.History
+
+	// saturationHeatmap records the saturation of each replica of the analyzed models,
+	// for the saturation heatmap endpoint (nil when disabled)
+	saturationHeatmap *heatmap.Matrix
+
+	// ttftSLOs and itlSLOs are the TTFT and inter-token latency SLOs (msec) of the models
+	// of the service classes, latencyBudgets the latency budgets of the variants with one,
+	// and variantLoads the loads of the variants to simulate their targets at, keyed by VA
+	// namespace/name. All are refreshed in each optimization run.
+	ttftSLOs       map[string]float64
+	itlSLOs        map[string]float64
+	latencyBudgets map[string]*interfaces.LatencyBudget
+	variantLoads   map[string]*variantLoad
+
+	// signalTimes are when the newest replica metrics of the models of the variants were
+	// sampled in the current optimization run, keyed by VA namespace/name
+	signalTimes map[string]time.Time
+
+	// configHashes are the fingerprints of the effective configuration of the namespaces
+	// of the active VAs, taken at the start of the current optimization run
+	configHashes map[string]string
+
+	// serviceClasses are the service classes read in the current optimization run
+	serviceClasses []interfaces.ServiceClass
+
+	// errorBudgets tracks the SLO error budgets of the service classes across runs, and
+	// observedTTFTs are the average TTFTs (msec) of the models observed in the current
+	// run (both nil when disabled)
+	errorBudgets  *pipeline.ErrorBudgetTracker
+	observedTTFTs map[string]float64
+
+	// variantReplicaMetrics are the replica metrics of the variants collected in the
+	// current optimization run, keyed by VA namespace/name (nil when deletionCoster is nil)
+	variantReplicaMetrics map[string][]interfaces.ReplicaMetrics
+
+	// trackedModels are the models the engine keeps state for, keyed by namespace/modelID,
+	// until their state is collected once no VA references them
+	trackedModels map[string]*trackedModel
+
+	// reportedDecisions are the last decisions of the VAs counted in the namespace capacity
+	// reports, keyed by VA namespace/name, standing in for VAs without a decision in a run
+	reportedDecisions map[string]interfaces.VariantDecision
+}
+
+// Dependencies are the components of the engine that can be replaced, e.g. by tests
+// driving the engine with canned metrics. Nil fields get the default component.
+type Dependencies struct {
+	// Collector collects replica metrics (default: from the prometheus source)
+	Collector interfaces.ReplicaMetricsCollector
+	// Optimizer produces the decisions of the V2 path (default: CostAwareOptimizer)
+	Optimizer pipeline.ScalingOptimizer
+	// GPULimiter and FairGPULimiter constrain decisions to the available GPUs
+	// (default: greedy by saturation and max-min fairness over the inventory)
+	GPULimiter     pipeline.Limiter
+	FairGPULimiter pipeline.Limiter
+	// Actuator reads current replicas and emits metrics (default: actuator.Actuator)
+	Actuator interfaces.Actuator
+	// InventoryProvider supplies the accelerator inventory (default: the cluster nodes)
+	InventoryProvider discovery.InventoryProvider
 }
 
 // NewEngine creates a new instance of the saturation engine.
 // Config must be non-nil (validated in main.go before engine creation).
 // Panics if cfg is nil to fail fast on programming errors.
 func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, metricsRegistry *source.SourceRegistry, cfg *config.Config) *Engine {
+	return NewEngineWithDependencies(client, scheme, recorder, metricsRegistry, cfg, Dependencies{})
+}
+
+// NewEngineWithInventory creates a saturation engine that reads accelerator inventory
+// from the given provider instead of the cluster nodes.
+func NewEngineWithInventory(client client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, metricsRegistry *source.SourceRegistry, cfg *config.Config, inventoryProvider discovery.InventoryProvider) *Engine {
+	return NewEngineWithDependencies(client, scheme, recorder, metricsRegistry, cfg, Dependencies{InventoryProvider: inventoryProvider})
+}
+
+// NewEngineWithDependencies creates a saturation engine with the given components in
+// place of the default ones.
+func NewEngineWithDependencies(client client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, metricsRegistry *source.SourceRegistry, cfg *config.Config, deps Dependencies) *Engine {
 	if cfg == nil {
 		panic("config is nil in NewEngine - this should not happen (validated in main.go before engine creation)")
 	}
-	promSource := metricsRegistry.Get("prometheus") // assume prometheus source is registered
+	inventoryProvider := deps.InventoryProvider
+	switch {
+	case inventoryProvider != nil:
+	case cfg.FeatureEnabled(config.EmulatedInventory):
+		// Emulated clusters declare their accelerators with EmulatedNodes
+		inventoryProvider = discovery.NewEmulatedInventory(client)
+	default:
+		inventoryProvider = discovery.NewNodeInventoryProvider(client)
+	}
+	// Time the queries of the prometheus source (assumed registered) for back-pressure
+	backpressureMonitor := backpressure.NewMonitor(backpressure.Limits{
+		MaxQueueDepth:   cfg.BackpressureMaxQueueDepth(),
+		MaxQueryLatency: cfg.BackpressureMaxQueryLatency(),
+		MaxSlowdown:     cfg.BackpressureMaxSlowdown(),
+	}, crmetrics.Registry)
+	promSource := backpressure.InstrumentSource(metricsRegistry.Get("prometheus"), backpressureMonitor)
 
 	// Create request count function wrapper for scale-to-zero enforcer
 	requestCountFunc := func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
 		return registration.CollectModelRequestCount(ctx, promSource, modelID, namespace, retentionPeriod)
 	}
 
+	// Exclude failing GPUs from the limiter inventory
+	if cfg.FeatureEnabled(config.GPUFailureDetection) {
+		inventoryProvider = discovery.NewHealthyInventory(inventoryProvider, client, cfg.GPUFailureNodeConditions(),
+			func(ctx context.Context) (map[string]int, error) {
+				return registration.CollectFailedGPUs(ctx, promSource)
+			})
+	}
+
 	// Create GPU limiter with TypeInventory and GreedyBySaturation algorithm
-	gpuDiscovery := discovery.NewK8sWithGpuOperator(client)
-	gpuInventory := pipeline.NewTypeInventoryWithUsage("cluster-gpu-inventory", gpuDiscovery)
-	gpuAlgorithm := pipeline.NewGreedyBySaturation()
-	gpuLimiter := pipeline.NewDefaultLimiter("gpu-limiter", gpuInventory, gpuAlgorithm)
+	gpuInventory := pipeline.NewTypeInventoryWithUsage("cluster-gpu-inventory", inventoryProvider)
+	gpuLimiter := deps.GPULimiter
+	if gpuLimiter == nil {
+		gpuLimiter = pipeline.NewDefaultLimiter("gpu-limiter", gpuInventory, pipeline.NewGreedyBySaturation())
+	}
+	fairGPULimiter := deps.FairGPULimiter
+	if fairGPULimiter == nil {
+		fairGPULimiter = pipeline.NewDefaultLimiter("gpu-limiter", gpuInventory, pipeline.NewMaxMinFairness())
+	}
 
 	capacityStore := saturation_v2.NewCapacityKnowledgeStore()
 
-	// Select optimizer at init time based on global config.
+	// Select optimizer at init time based on global config, unless injected.
 	// CostAwareOptimizer (unlimited mode) is the default.
 	// When limited mode is enabled, a GPU-constrained optimizer will be used
 	// (GreedyBySaturationOptimizer, added in a follow-up).
-	var scalingOptimizer pipeline.ScalingOptimizer
-	if cfg.LimitedModeEnabled() {
-		// TODO: use GreedyBySaturationOptimizer when available
-		scalingOptimizer = pipeline.NewCostAwareOptimizer()
-	} else {
-		scalingOptimizer = pipeline.NewCostAwareOptimizer()
+	scalingOptimizer := deps.Optimizer
+	if scalingOptimizer == nil {
+		if cfg.LimitedModeEnabled() {
+			// TODO: use GreedyBySaturationOptimizer when available
+			scalingOptimizer = pipeline.NewCostAwareOptimizer()
+		} else {
+			scalingOptimizer = pipeline.NewCostAwareOptimizer()
+		}
+	}
+
+	replicaMetricsCollector := deps.Collector
+	if replicaMetricsCollector == nil {
+		// Let compiled-in and webhook enrichers add custom fields to replica metrics
+		defaultCollector := collector.NewReplicaMetricsCollector(promSource, client)
+		defaultCollector.SetEnrichers(enrichment.Enrichers(cfg))
+		if cacheConfig := cfg.PrometheusCacheConfig(); cacheConfig != nil {
+			defaultCollector.SetFreshnessThresholds(cacheConfig.FreshnessThresholds)
+		}
+		if cfg.FeatureEnabled(config.GPUFailureDetection) {
+			defaultCollector.SetGPUFailureDetection(cfg.GPUFailureNodeConditions())
+		}
+		replicaMetricsCollector = defaultCollector
+	}
+
+	act := deps.Actuator
+	if act == nil {
+		act = actuator.NewActuator(client)
 	}
 
 	engine := Engine{
@@ -131,13 +342,41 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 		scheme:                  scheme,
 		Recorder:                recorder,
 		Config:                  cfg,
-		ReplicaMetricsCollector: collector.NewReplicaMetricsCollector(promSource, client),
+		ReplicaMetricsCollector: replicaMetricsCollector,
 		ScaleToZeroEnforcer:     pipeline.NewEnforcer(requestCountFunc),
 		GPULimiter:              gpuLimiter,
+		FairGPULimiter:          fairGPULimiter,
+		CapacityCeiling:         pipeline.NewCapacityCeiling(gpuInventory),
 		metricsRegistry:         metricsRegistry,
 		saturationV2Analyzer:    saturation_v2.NewSaturationAnalyzer(capacityStore),
 		capacityStore:           capacityStore,
 		optimizer:               scalingOptimizer,
+		inventoryProvider:       inventoryProvider,
+		gpuInventory:            gpuInventory,
+		dampener:                pipeline.NewChangeDampener(cfg.DampeningConsecutiveRuns(), cfg.DampeningReplicaThreshold()),
+		scaleDownQuorum:         pipeline.NewScaleDownQuorum(cfg.ScaleDownQuorum(), cfg.ScaleDownQuorumWindow()),
+		behavior:                pipeline.NewScalingBehavior(),
+		rollout:                 pipeline.NewGraduatedRollout(cfg.RolloutMaxStepReplicas(), cfg.RolloutStepTimeout()),
+		verifier:                pipeline.NewScaleUpVerifier(cfg.ScaleUpVerifyTimeout(), cfg.ScaleUpVerifyMinImprovement(), cfg.ScaleUpVerifyRollback()),
+		logSampler:              logging.NewDetailSampler(cfg.LogDetailSampling()),
+		actuator:                act,
+		backpressure:            backpressureMonitor,
+	}
+
+	if cfg.PrepullEnabled() {
+		engine.prepuller = prepull.NewManager(client, cfg.PrepullTTL(), cfg.PrepullPauseImage())
+	}
+
+	if cfg.FeatureEnabled(config.PodDeletionCost) {
+		engine.deletionCoster = deletioncost.NewManager(client)
+	}
+
+	if cfg.FeatureEnabled(config.SLOErrorBudget) {
+		engine.errorBudgets = pipeline.NewErrorBudgetTracker(cfg.ErrorBudgetWindow(), cfg.ErrorBudgetObjective())
+	}
+
+	if cfg.FeatureEnabled(config.ConcurrencyTuning) {
+		engine.concurrencyTuner = concurrency.NewManager(client, cfg.ConcurrencyAdminPort(), cfg.ConcurrencyAdminPath())
 	}
 
 	engine.executor = executor.NewPollingExecutor(executor.PollingConfig{
@@ -158,17 +397,27 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 	// Register scale-to-zero queries in the metrics registry
 	registration.RegisterScaleToZeroQueries(metricsRegistry)
 
+	// Let the PromQL templates ConfigMap override the registered templates per model
+	promSource.QueryList().SetTemplateOverride(func(queryName string, params map[string]string) (string, bool) {
+		return cfg.PromQLTemplate(queryName, params[source.ParamNamespace], params[source.ParamModelID])
+	})
+
 	return &engine
 }
 
 // StartOptimizeLoop starts the optimization loop for the saturation engine.
 // It runs until the context is cancelled.
+// When a snapshot store is set, the state saved by the previous leader is restored first.
 func (e *Engine) StartOptimizeLoop(ctx context.Context) {
+	if e.snapshotStore != nil {
+		e.restoreSnapshot(ctx)
+	}
 	e.executor.Start(ctx)
 }
 
 // optimize performs the optimization logic.
 func (e *Engine) optimize(ctx context.Context) error {
+	ctx = e.logSampler.Next(ctx)
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Get optimization interval from Config (already a time.Duration)
@@ -188,12 +437,19 @@ func (e *Engine) optimize(ctx context.Context) error {
 		logger.Info("Scaling to zero is enabled")
 	}
 
+	// Forget the state of the models no longer referenced by any VA, including VAs scaled to zero
+	e.collectModelState(ctx)
+
 	activeVAs, err := utils.ActiveVariantAutoscaling(ctx, e.client)
 	if err != nil {
 		logger.Error(err, "Unable to get active variant autoscalings")
 		return err
 	}
 
+	// Under back-pressure, low-priority VAs are only optimized one in every N cycles
+	activeVAs, load := e.backpressure.Filter(ctx, activeVAs)
+	e.emitControllerLoadMetrics(ctx, load)
+
 	if len(activeVAs) == 0 {
 		logger.Info("No active VariantAutoscalings found, skipping optimization")
 		return nil
@@ -201,7 +457,7 @@ func (e *Engine) optimize(ctx context.Context) error {
 
 	// Collected accelerator inventory (only in limited mode)
 	if e.Config.LimitedModeEnabled() {
-		inventory, err := collector.CollectInventoryK8S(ctx, e.client)
+		inventory, err := e.inventoryProvider.Discover(ctx)
 		if err != nil {
 			logger.Error(err, "Failed to collect cluster inventory")
 			// do not proceed to optimization if inventory collection fails in limited mode
@@ -211,11 +467,57 @@ func (e *Engine) optimize(ctx context.Context) error {
 		logger.Info("Collected cluster accelerator inventory (Limited Mode)", "inventory", inventory)
 	}
 
+	// The GPUs per replica of variants sharing GPUs depend on the GPU sharing of their
+	// accelerator, discovered with the capacity of the accelerator types
+	if e.gpuInventory != nil {
+		if err := e.gpuInventory.Refresh(ctx); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to refresh the GPU inventory, using the last GPU sharing discovered",
+				"error", err.Error())
+		}
+	}
+
+	// Pin the configuration the decisions of this run are based on, for post-incident
+	// analysis even across hot reloads
+	previousHashes := e.configHashes
+	e.configHashes = make(map[string]string)
+	for i := range activeVAs {
+		namespace := activeVAs[i].Namespace
+		if _, ok := e.configHashes[namespace]; ok {
+			continue
+		}
+		e.configHashes[namespace] = e.Config.Fingerprint(namespace)
+		if previous, ok := previousHashes[namespace]; ok && previous != e.configHashes[namespace] {
+			logger.Info("Effective configuration changed",
+				"namespace", namespace,
+				"previousConfigHash", previous,
+				"configHash", e.configHashes[namespace],
+				"saturationConfig", e.Config.SaturationConfigForNamespace(namespace))
+		}
+	}
+
+	// Decompose the TTFT of variants of models with a TTFT SLO while their metrics are collected
+	e.serviceClasses = e.loadServiceClasses(ctx)
+	e.ttftSLOs = ttftSLOs(e.serviceClasses)
+	e.itlSLOs = itlSLOs(e.serviceClasses)
+	e.latencyBudgets = make(map[string]*interfaces.LatencyBudget)
+	e.variantLoads = make(map[string]*variantLoad)
+	e.signalTimes = make(map[string]time.Time)
+	if e.errorBudgets != nil {
+		e.observedTTFTs = make(map[string]float64)
+	}
+	if e.deletionCoster != nil {
+		e.variantReplicaMetrics = make(map[string][]interfaces.ReplicaMetrics)
+	}
+
+	// VAs selecting custom engines with spec.engine or spec.engineComposition are analyzed by them
+	saturationVAs, pluginVAs, selectedEngines := partitionByEngine(ctx, activeVAs)
+
 	// Group VAs by model for per-model capacity analysis
-	modelGroups := utils.GroupVariantAutoscalingByModel(activeVAs)
+	modelGroups := utils.GroupVariantAutoscalingByModel(saturationVAs)
 	logger.Info("Grouped VAs by model",
 		"modelCount", len(modelGroups),
-		"totalVAs", len(activeVAs))
+		"totalVAs", len(activeVAs),
+		"customEngineVAs", len(pluginVAs))
 
 	// Create VA lookup map for applySaturationDecisions (used to access VA status and update decisions)
 	// Use namespace/vaName as key to avoid collisions when multiple namespaces have same VA name
@@ -234,23 +536,126 @@ func (e *Engine) optimize(ctx context.Context) error {
 	// empty/other values use the V1 percentage-based analyzer.
 	globalSatCfgMap := e.Config.SaturationConfig()
 	useV2 := false
+	errorRateThreshold := interfaces.DefaultScaleDownErrorRateThreshold
 	if cfg, ok := globalSatCfgMap["default"]; ok {
 		cfg.ApplyDefaults()
 		useV2 = cfg.AnalyzerName == "saturation"
+		errorRateThreshold = cfg.GetScaleDownErrorRateThreshold()
 	}
 
 	var allDecisions []interfaces.VariantDecision
+	// Errors that kept VAs from being optimized, by VA namespace/name
+	failures := make(map[string]error)
 
 	// V1 and V2 have separate optimize paths because they use fundamentally
 	// different analysis types and target-building flows:
-	//   - V1: saturation.Analyzer → ModelSaturationAnalysis → CalculateSaturationTargets → Enforcer → Limiter
+	//   - V1: saturation.Analyzer → ModelSaturationAnalysis → CalculateSaturationTargets → Enforcer
 	//   - V2: saturation_v2.Analyzer → AnalyzerResult → Optimizer.Optimize → Enforcer bridge
 	// V1 will be deprecated once V2 is fully validated, at which point the
 	// V1 path and the saturation.Analyzer can be removed.
 	if useV2 {
-		allDecisions = e.optimizeV2(ctx, modelGroups, currentAllocations)
+		allDecisions = e.optimizeV2(ctx, modelGroups, currentAllocations, failures)
 	} else {
-		allDecisions = e.optimizeV1(ctx, modelGroups, currentAllocations)
+		allDecisions = e.optimizeV1(ctx, modelGroups, currentAllocations, failures)
+	}
+	// Only the decisions of the saturation engine, ahead of those of custom engines, are resource limited
+	saturationDecisions := len(allDecisions)
+	allDecisions = append(allDecisions, e.optimizePlugins(ctx, pluginVAs, selectedEngines)...)
+
+	// Track the SLO error budgets of the service classes with the TTFTs observed in this run
+	e.recordErrorBudgets(ctx)
+
+	// Hold scale-ups of targets that already have pods Pending for lack of GPUs
+	if held := pipeline.GateUnschedulableScaleUps(ctx, allDecisions); len(held) > 0 {
+		logger.Info("Held scale-ups of unschedulable targets", "held", len(held))
+	}
+
+	// Hold the targets of new VAs at their current replicas until they are warmed up
+	if held := pipeline.HoldWarmingUpVariants(ctx, allDecisions, e.Config.WarmUpPeriod(), time.Now()); len(held) > 0 {
+		logger.Info("Held targets of warming-up variants", "held", len(held))
+	}
+
+	// Hold scale-downs until a quorum of the recent runs proposed them
+	if held := e.scaleDownQuorum.Apply(ctx, allDecisions); len(held) > 0 {
+		logger.Info("Held scale-downs without quorum", "held", len(held))
+	}
+
+	// Hold back changes that have not been stable across runs (anti-flapping)
+	dampening := e.dampener.Dampen(ctx, allDecisions)
+	e.emitDampeningMetrics(ctx, dampening)
+
+	// Spread large scale-ups over steps, verifying new replicas between steps
+	if rollout := e.rollout.Apply(ctx, allDecisions); len(rollout.Stepped) > 0 {
+		logger.Info("Graduated rollout held back scale-ups",
+			"stepped", len(rollout.Stepped),
+			"aborted", len(rollout.Aborted))
+	}
+
+	// Hold scale-ups of variants whose last scale-up did not reduce saturation
+	if verification := e.verifier.Verify(ctx, allDecisions); len(verification.Ineffective) > 0 {
+		logger.Info("Scale-up verification found ineffective scale-ups",
+			"ineffective", len(verification.Ineffective),
+			"rolledBack", len(verification.RolledBack))
+	}
+
+	// Hold scale-downs of variants with elevated error rates, which may indicate hidden overload
+	if vetoed := pipeline.VetoErrorRateScaleDowns(ctx, allDecisions, errorRateThreshold); len(vetoed) > 0 {
+		logger.Info("Vetoed scale-downs of variants with elevated error rates", "vetoed", len(vetoed))
+	}
+
+	// Stabilize and rate limit targets by the scaling behavior of their VA
+	if changed := e.behavior.Apply(ctx, allDecisions, time.Now()); len(changed) > 0 {
+		logger.Info("Applied the scaling behavior of VAs", "changed", len(changed))
+	}
+
+	// Keep targets at or above the minimum replicas of their VA
+	if raised := pipeline.ApplyMinReplicas(ctx, allDecisions); len(raised) > 0 {
+		logger.Info("Raised targets to the minimum replicas of their VA", "raised", len(raised))
+	}
+
+	// Keep scale-downs from breaking the PodDisruptionBudgets of their targets
+	if clamped := pipeline.ClampPDBScaleDowns(ctx, allDecisions); len(clamped) > 0 {
+		logger.Info("Clamped scale-downs conflicting with PodDisruptionBudgets", "clamped", len(clamped))
+	}
+
+	// Cover the replicas about to be evicted from cordoned or draining nodes
+	if surged := pipeline.SurgeDrainingReplicas(ctx, allDecisions); len(surged) > 0 {
+		logger.Info("Surged targets ahead of node drains", "surged", len(surged))
+	}
+
+	// Keep the total replicas of models within their replica limits, over the stages above
+	if capped := pipeline.ApplyModelReplicaLimits(ctx, allDecisions, e.Config.ModelReplicaLimits()); len(capped) > 0 {
+		logger.Info("Capped targets at the replica limits of their model", "capped", len(capped))
+	}
+
+	// Limit the targets that survived the stages above to the available resources
+	e.limitDecisions(ctx, allDecisions[:saturationDecisions], modelGroups)
+
+	// Predict the performance of multi-replica changes, holding scale-downs predicted to violate SLOs
+	if held := e.simulateDecisions(ctx, allDecisions[:saturationDecisions]); len(held) > 0 {
+		logger.Info("Held scale-downs predicted to violate their SLOs", "held", len(held))
+	}
+
+	// Flag targets the bounds of their HPA would silently clamp
+	if conflicts := pipeline.CheckHPABounds(ctx, allDecisions); len(conflicts) > 0 {
+		logger.Info("Targets outside HorizontalPodAutoscaler bounds", "conflicts", len(conflicts))
+	}
+
+	// Flag models whose variants are all saturated and at their maxReplicas
+	if e.overloadSignaler != nil {
+		if overloaded := pipeline.DetectOverloadedModels(ctx, allDecisions); len(overloaded) > 0 {
+			logger.Info("Variants of overloaded models", "variants", len(overloaded))
+		}
+	}
+
+	// Absorb small scale-ups with the concurrency of the replicas instead of new replicas
+	e.tuneConcurrency(ctx, allDecisions, vaMap)
+
+	// Recommend the traffic split of multi-variant models that follows their targets
+	if e.Config.FeatureEnabled(config.TrafficWeights) {
+		if weighted := pipeline.RecommendTrafficWeights(ctx, allDecisions); len(weighted) > 0 {
+			logger.Info("Recommended traffic weights", "variants", len(weighted))
+		}
 	}
 
 	// STEP 3: Apply decisions and update VA status
@@ -263,11 +668,31 @@ func (e *Engine) optimize(ctx context.Context) error {
 	} else {
 		logger.Info("No scaling decisions to apply, updating VA status with metrics")
 	}
-	if err := e.applySaturationDecisions(ctx, allDecisions, vaMap, currentAllocations); err != nil {
+	if err := e.applySaturationDecisions(ctx, allDecisions, vaMap, currentAllocations, failures); err != nil {
 		logger.Error(err, "Failed to apply saturation decisions")
 		return err
 	}
 
+	// Pre-pull images of scaling-up variants and clean up after finished scale-ups
+	e.orchestratePrepull(ctx, allDecisions, vaMap)
+
+	// Steer scale-downs to the newest and least-warmed replicas
+	e.orchestrateDeletionCost(ctx, allDecisions, vaMap)
+
+	// Publish per-namespace capacity totals for capacity reviews
+	e.updateNamespaceCapacityReports(ctx, allDecisions)
+
+	// Signal the gateway to shed the load of overloaded models
+	e.signalOverload(ctx, allDecisions, vaMap)
+
+	// Account the cost and replica-hours of the variants for showback
+	e.recordShowback(ctx, allDecisions, vaMap)
+
+	// Save the state for the next leader
+	if e.snapshotStore != nil {
+		e.saveSnapshot(ctx, vaMap)
+	}
+
 	logger.Info("Optimization completed successfully",
 		"mode", "saturation-only",
 		"modelsProcessed", len(modelGroups),
@@ -277,15 +702,26 @@ func (e *Engine) optimize(ctx context.Context) error {
 }
 
 // optimizeV1 runs the V1 percentage-based saturation analysis path (saturation-percentage-based).
-// Processes each model independently: analyze → enforce → convert → limiter.
+// Processes each model independently: analyze → enforce → convert.
+// Models that cannot be optimized have their error recorded in failures.
 func (e *Engine) optimizeV1(
 	ctx context.Context,
 	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	currentAllocations map[string]*interfaces.Allocation,
+	failures map[string]error,
 ) []interfaces.VariantDecision {
 	logger := ctrl.LoggerFrom(ctx)
 	var allDecisions []interfaces.VariantDecision
 
+	// Note: the tenant label comes from the global saturation config, like the limiter settings
+	globalSaturationConfigMap := e.Config.SaturationConfig()
+	var globalSaturationConfig interfaces.SaturationScalingConfig
+	if len(globalSaturationConfigMap) > 0 {
+		if cfg, ok := globalSaturationConfigMap["default"]; ok {
+			globalSaturationConfig = cfg
+		}
+	}
+
 	for groupKey, modelVAs := range modelGroups {
 		modelID := modelVAs[0].Spec.ModelID
 		namespace := modelVAs[0].Namespace
@@ -309,12 +745,16 @@ func (e *Engine) optimizeV1(
 			logger.Info("Default saturation scaling config not found for namespace, skipping model",
 				"namespace", namespace,
 				"modelID", modelID)
+			recordModelFailure(failures, modelVAs, errNoDefaultSaturationConfig)
 			continue
 		}
 
+		saturationConfig = e.biasForErrorBudget(ctx, modelID, saturationConfig)
+
 		saturationTargets, saturationAnalysis, variantStates, err := e.RunSaturationAnalysis(ctx, modelID, modelVAs, saturationConfig, e.client)
 		if err != nil {
 			logger.Error(err, "Saturation analysis failed", "modelID", modelID)
+			recordModelFailure(failures, modelVAs, err)
 			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations)
 			continue
 		}
@@ -348,6 +788,7 @@ func (e *Engine) optimizeV1(
 			saturationTargets = enforcedTargets
 
 			finalDecisions = e.convertSaturationTargetsToDecisions(ctx, saturationTargets, saturationAnalysis, variantStates)
+			setDecisionTenants(finalDecisions, modelVAs, globalSaturationConfig.GetTenantLabel())
 			logger.Info("Saturation-only decisions made for model",
 				"modelID", modelID,
 				"decisionCount", len(finalDecisions))
@@ -355,39 +796,7 @@ func (e *Engine) optimizeV1(
 		} else {
 			logger.V(logging.DEBUG).Info("Skipping decision application for model: saturation analysis is nil (likely no metrics)",
 				"modelID", modelID)
-		}
-	}
-
-	// Apply GPU limiter if enabled
-	// Note: Limiter uses global saturation config since it's applied globally to all decisions
-	globalSaturationConfigMap := e.Config.SaturationConfig()
-	var globalSaturationConfig interfaces.SaturationScalingConfig
-	if len(globalSaturationConfigMap) > 0 {
-		if cfg, ok := globalSaturationConfigMap["default"]; ok {
-			globalSaturationConfig = cfg
-		}
-	}
-	if globalSaturationConfig.EnableLimiter && len(allDecisions) > 0 {
-		logger.Info("Applying GPU limiter to scaling decisions",
-			"decisionCount", len(allDecisions))
-
-		decisionPtrs := make([]*interfaces.VariantDecision, len(allDecisions))
-		for i := range allDecisions {
-			decisionPtrs[i] = &allDecisions[i]
-		}
-
-		if err := e.GPULimiter.Limit(ctx, decisionPtrs); err != nil {
-			logger.Error(err, "GPU limiter failed, proceeding with original decisions")
-		} else {
-			for _, d := range decisionPtrs {
-				if d.WasLimited {
-					logger.Info("Decision was limited by GPU availability",
-						"variant", d.VariantName,
-						"originalTarget", d.OriginalTargetReplicas,
-						"limitedTarget", d.TargetReplicas,
-						"limitedBy", d.LimitedBy)
-				}
-			}
+			recordModelFailure(failures, modelVAs, wvaerrors.ErrMetricsNotAvailable)
 		}
 	}
 
@@ -396,10 +805,12 @@ func (e *Engine) optimizeV1(
 
 // optimizeV2 runs the V2 token-based optimizer path (saturation-token-based).
 // Collects AnalyzerResults for all models, calls the optimizer once, then applies enforcer per-model.
+// Models that cannot be optimized have their error recorded in failures.
 func (e *Engine) optimizeV2(
 	ctx context.Context,
 	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	currentAllocations map[string]*interfaces.Allocation,
+	failures map[string]error,
 ) []interfaces.VariantDecision {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -426,26 +837,31 @@ func (e *Engine) optimizeV2(
 		if !ok {
 			logger.Info("Default saturation scaling config not found for namespace, skipping model",
 				"namespace", namespace, "modelID", modelID)
+			recordModelFailure(failures, modelVAs, errNoDefaultSaturationConfig)
 			continue
 		}
 		saturationConfig.ApplyDefaults()
+		saturationConfig = e.biasForErrorBudget(ctx, modelID, saturationConfig)
 
 		data, err := e.prepareModelData(ctx, modelID, modelVAs, e.client)
 		if err != nil {
 			logger.Error(err, "Model data preparation failed", "modelID", modelID)
+			recordModelFailure(failures, modelVAs, err)
 			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations)
 			continue
 		}
 		if data == nil {
 			logger.V(logging.DEBUG).Info("Skipping model: no metrics available", "modelID", modelID)
+			recordModelFailure(failures, modelVAs, wvaerrors.ErrMetricsNotAvailable)
 			continue
 		}
 
 		req, err := e.collectV2ModelRequest(ctx, modelID, namespace,
 			data.replicaMetrics, saturationConfig, data.variantStates,
-			data.deployments, data.variantAutoscalings)
+			data.workloads, data.variantAutoscalings)
 		if err != nil {
 			logger.Error(err, "V2 analysis failed", "modelID", modelID)
+			recordModelFailure(failures, modelVAs, err)
 			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations)
 			continue
 		}
@@ -484,6 +900,17 @@ func (e *Engine) optimizeV2(
 		allDecisions = applyEnforcedTargetsToDecisions(allDecisions, enforcedTargets, req.ModelID, req.Namespace, e.optimizer.Name())
 	}
 
+	// Stage 4: Set the tenants the GPU limiter shares the GPUs between, as in the V1 path
+	var tenantLabel string
+	if cfg, ok := e.Config.SaturationConfig()["default"]; ok {
+		tenantLabel = cfg.GetTenantLabel()
+	}
+	var vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	for _, modelVAs := range modelGroups {
+		vas = append(vas, modelVAs...)
+	}
+	setDecisionTenants(allDecisions, vas, tenantLabel)
+
 	return allDecisions
 }
 
@@ -491,44 +918,43 @@ func (e *Engine) optimizeV2(
 func (e *Engine) BuildVariantStates(
 	ctx context.Context,
 	vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
-	deployments map[string]*appsv1.Deployment,
+	workloads map[string]interfaces.Workload,
 	k8sClient client.Client,
 ) []interfaces.VariantReplicaState {
 	states := make([]interfaces.VariantReplicaState, 0, len(vas))
 
 	for _, va := range vas {
-		// Get current replicas from deployment using ScaleTargetRef
-		// Get current replicas from deployment using ScaleTargetRef
-		var deploy *appsv1.Deployment
+		// Get current replicas from the scale target using ScaleTargetRef
+		var workload interfaces.Workload
 		var found bool
 
 		// Try to look up in provided map first (optimization)
-		if deployments != nil {
-			deploy, found = deployments[utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())]
+		if workloads != nil {
+			workload, found = workloads[utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())]
 		}
 
 		if !found {
 			// Fallback to API call
-			fetchedDeploy := &appsv1.Deployment{}
-			if err := utils.GetDeploymentWithBackoff(ctx, k8sClient, va.GetScaleTargetName(), va.Namespace, fetchedDeploy); err != nil {
-				ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not get deployment for VA, skipping",
+			fetched, err := utils.GetScaleTargetWorkload(ctx, k8sClient, &va)
+			if err != nil {
+				ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not get scale target for VA, skipping",
 					"variant", va.Name,
 					"error", err)
 				continue
 			}
-			deploy = fetchedDeploy
-			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("BuildVariantStates fallback lookup", "variant", va.Name, "deployName", deploy.Name, "specReplicas", deploy.Spec.Replicas, "statusReplicas", deploy.Status.Replicas, "readyReplicas", deploy.Status.ReadyReplicas)
+			workload = fetched
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("BuildVariantStates fallback lookup", "variant", va.Name, "kind", workload.WorkloadKind(), "name", workload.GetName(), "specReplicas", workload.SpecReplicas(), "statusReplicas", workload.StatusReplicas(), "readyReplicas", workload.ReadyReplicas())
 		} else {
-			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("BuildVariantStates map lookup", "variant", va.Name, "deployName", deploy.Name, "specReplicas", deploy.Spec.Replicas, "statusReplicas", deploy.Status.Replicas, "readyReplicas", deploy.Status.ReadyReplicas)
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("BuildVariantStates map lookup", "variant", va.Name, "kind", workload.WorkloadKind(), "name", workload.GetName(), "specReplicas", workload.SpecReplicas(), "statusReplicas", workload.StatusReplicas(), "readyReplicas", workload.ReadyReplicas())
 		}
 
-		currentReplicas := int(deploy.Status.Replicas)
-		if currentReplicas == 0 && deploy.Spec.Replicas != nil {
-			currentReplicas = int(*deploy.Spec.Replicas)
+		currentReplicas := int(workload.StatusReplicas())
+		if currentReplicas == 0 && workload.SpecReplicas() != nil {
+			currentReplicas = int(*workload.SpecReplicas())
 		}
 
 		// Calculate pending replicas (not yet ready)
-		readyReplicas := int(deploy.Status.ReadyReplicas)
+		readyReplicas := int(workload.ReadyReplicas())
 		pendingReplicas := currentReplicas - readyReplicas
 		if pendingReplicas < 0 {
 			// This indicates an unexpected state where readyReplicas exceeds currentReplicas.
@@ -538,50 +964,136 @@ func (e *Engine) BuildVariantStates(
 			pendingReplicas = 0
 		}
 
-		// Extract GPUs per replica from deployment's pod template
-		gpusPerReplica := getDeploymentGPUsPerReplica(deploy)
+		// Extract GPUs per replica from the pod templates of the scale target
+		gpusPerReplica := e.gpusPerReplica(&va, workload)
+
+		// Count pending pods that cannot be scheduled for lack of GPUs
+		unschedulableReplicas := 0
+		if pendingReplicas > 0 {
+			count, err := utils.CountUnschedulableGPUReplicas(ctx, k8sClient, workload)
+			if err != nil {
+				ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not check pods of deployment for schedulability",
+					"variant", va.Name,
+					"error", err)
+			}
+			unschedulableReplicas = count
+		}
+
+		// Count replicas about to be evicted from cordoned or draining nodes
+		drainingReplicas := 0
+		if e.Config != nil && e.Config.FeatureEnabled(config.DrainAwareScaling) {
+			count, err := utils.CountDrainingReplicas(ctx, k8sClient, workload)
+			if err != nil {
+				ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not check nodes of deployment pods for draining",
+					"variant", va.Name,
+					"error", err)
+			}
+			drainingReplicas = count
+		}
+
+		// Find the fewest replicas keeping the PodDisruptionBudgets of the deployment satisfiable
+		pdbMinReplicas, pdbName, err := utils.PDBMinReplicas(ctx, k8sClient, workload)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not check PodDisruptionBudgets of deployment",
+				"variant", va.Name,
+				"error", err)
+		}
+
+		// Find the bounds of the HPA scaling the deployment, if any
+		var hpaName string
+		var hpaMinReplicas, hpaMaxReplicas int
+		hpa, err := utils.FindScaleTargetHPA(ctx, k8sClient, va.Namespace, va.GetScaleTargetKind(), va.GetScaleTargetName())
+		if err != nil {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not look up HorizontalPodAutoscaler of deployment",
+				"variant", va.Name,
+				"error", err)
+		} else if hpa != nil {
+			hpaName, hpaMinReplicas, hpaMaxReplicas = hpa.Name, utils.HPAMinReplicas(hpa), int(hpa.Spec.MaxReplicas)
+		}
+
+		// Find the zones the storage of the model restricts the replicas to
+		zones, err := utils.ModelZones(ctx, k8sClient, &va)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not resolve the model zones of VA",
+				"variant", va.Name,
+				"error", err)
+		}
 
 		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("BuildVariantStates result", "variant", va.Name, "currentReplicas", currentReplicas, "readyReplicas", readyReplicas, "pendingReplicas", pendingReplicas, "gpusPerReplica", gpusPerReplica)
 
 		states = append(states, interfaces.VariantReplicaState{
-			VariantName:     va.Name,
-			CurrentReplicas: currentReplicas,
-			DesiredReplicas: va.Status.DesiredOptimizedAlloc.NumReplicas,
-			PendingReplicas: pendingReplicas,
-			GPUsPerReplica:  gpusPerReplica,
+			VariantName:           va.Name,
+			CurrentReplicas:       currentReplicas,
+			DesiredReplicas:       va.Status.DesiredOptimizedAlloc.NumReplicas,
+			PendingReplicas:       pendingReplicas,
+			GPUsPerReplica:        gpusPerReplica,
+			UnschedulableReplicas: unschedulableReplicas,
+			DrainingReplicas:      drainingReplicas,
+			PDBMinReplicas:        pdbMinReplicas,
+			PDBName:               pdbName,
+			HPAName:               hpaName,
+			HPAMinReplicas:        hpaMinReplicas,
+			HPAMaxReplicas:        hpaMaxReplicas,
+			Zones:                 zones,
+			MinReplicas:           va.GetMinReplicas(),
+			Behavior:              va.Spec.Behavior,
+			CreatedAt:             va.CreationTimestamp.Time,
 		})
 	}
 
 	return states
 }
 
-// gpuVendors lists the resource name prefixes for GPU vendors
-var gpuVendors = []string{"nvidia.com", "amd.com", "intel.com"}
+// setReportingReplicas sets the number of replicas of each variant that reported metrics,
+// and the average error rate of these replicas.
+func setReportingReplicas(states []interfaces.VariantReplicaState, replicaMetrics []interfaces.ReplicaMetrics) {
+	reporting := make(map[string]int)
+	errorRates := make(map[string]float64)
+	for _, rm := range replicaMetrics {
+		reporting[rm.VariantName]++
+		errorRates[rm.VariantName] += rm.ErrorRate
+	}
+	for i := range states {
+		states[i].ReportingReplicas = reporting[states[i].VariantName]
+		if n := reporting[states[i].VariantName]; n > 0 {
+			states[i].ErrorRate = errorRates[states[i].VariantName] / float64(n)
+		}
+	}
+}
 
-// getDeploymentGPUsPerReplica extracts the total GPU requests from a deployment's pod template.
-// It sums GPU requests across all containers for supported vendors (nvidia.com, amd.com, intel.com).
+// getWorkloadGPUsPerReplica returns the GPU requests of the pods of a replica of a scale
+// target, across all containers for supported vendors (nvidia.com, amd.com, intel.com).
 // Returns 1 as default if no GPU requests are found (assumes at least 1 GPU for inference workloads).
-func getDeploymentGPUsPerReplica(deploy *appsv1.Deployment) int {
-	if deploy == nil {
+func getWorkloadGPUsPerReplica(workload interfaces.Workload) int {
+	if workload == nil {
 		return 1
 	}
 
-	total := 0
-	for _, container := range deploy.Spec.Template.Spec.Containers {
-		for _, vendor := range gpuVendors {
-			resName := corev1.ResourceName(vendor + "/gpu")
-			if qty, ok := container.Resources.Requests[resName]; ok {
-				total += int(qty.Value())
-			}
-		}
-	}
-
 	// Default to 1 GPU if no explicit requests found
 	// (common for inference workloads that may not have resource requests)
-	if total == 0 {
-		return 1
+	if total := workload.GPUsPerReplica(); total > 0 {
+		return total
+	}
+	return 1
+}
+
+// gpusPerReplica returns the physical GPUs of a replica of a VA: the GPU requests of the
+// pods of a replica of its scale target, divided by the number of containers each GPU of
+// its accelerator is shared by with time-slicing or MPS. Replicas sharing GPUs thus require
+// fractional GPUs.
+func (e *Engine) gpusPerReplica(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, workload interfaces.Workload) float64 {
+	gpus := float64(getWorkloadGPUsPerReplica(workload))
+	if e.gpuInventory == nil {
+		return gpus
 	}
-	return total
+	return gpus / float64(e.gpuInventory.GPUShares(utils.GetAcceleratorType(va)))
+}
+
+// targetsDeployment reports whether the scale target of a VA is a Deployment, for the
+// features acting on the pods of Deployments only.
+func targetsDeployment(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) bool {
+	kind := va.GetScaleTargetKind()
+	return kind == "" || kind == utils.DeploymentKind
 }
 
 // convertSaturationTargetsToDecisions converts saturation-only targets to VariantDecisions.
@@ -635,6 +1147,20 @@ func (e *Engine) convertSaturationTargetsToDecisions(
 			TargetReplicas:         targetReplicas,
 			OriginalTargetReplicas: targetReplicas, // Store original before limiter modifies it
 			DesiredReplicas:        state.DesiredReplicas,
+			ReadyReplicas:          state.CurrentReplicas - state.PendingReplicas,
+			ReportingReplicas:      state.ReportingReplicas,
+			UnschedulableReplicas:  state.UnschedulableReplicas,
+			DrainingReplicas:       state.DrainingReplicas,
+			PDBMinReplicas:         state.PDBMinReplicas,
+			PDBName:                state.PDBName,
+			HPAName:                state.HPAName,
+			HPAMinReplicas:         state.HPAMinReplicas,
+			HPAMaxReplicas:         state.HPAMaxReplicas,
+			Zones:                  state.Zones,
+			MinReplicas:            state.MinReplicas,
+			Behavior:               state.Behavior,
+			CreatedAt:              state.CreatedAt,
+			ErrorRate:              state.ErrorRate,
 			Action:                 action,
 			SaturationBased:        true,
 			SaturationOnly:         true,
@@ -665,7 +1191,7 @@ type modelData struct {
 	modelID             string
 	namespace           string
 	replicaMetrics      []interfaces.ReplicaMetrics
-	deployments         map[string]*appsv1.Deployment
+	workloads           map[string]interfaces.Workload
 	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling
 	variantCosts        map[string]float64
 	variantStates       []interfaces.VariantReplicaState
@@ -688,34 +1214,26 @@ func (e *Engine) prepareModelData(
 	namespace := modelVAs[0].Namespace
 
 	variantCosts := make(map[string]float64)
-	deployments := make(map[string]*appsv1.Deployment)
+	workloads := make(map[string]interfaces.Workload)
 	variantAutoscalings := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
 
 	for i := range modelVAs {
 		va := &modelVAs[i]
 
-		var deploy appsv1.Deployment
-		err := utils.GetDeploymentWithBackoff(ctx, k8sClient, va.GetScaleTargetName(), va.Namespace, &deploy)
+		workload, err := utils.GetScaleTargetWorkload(ctx, k8sClient, va)
 		if err != nil {
-			logger.V(logging.DEBUG).Info("Could not get deployment for VA",
+			logger.V(logging.DEBUG).Info("Could not get scale target for VA",
 				"variant", va.Name,
-				"deployment", va.GetScaleTargetName(),
+				"kind", va.GetScaleTargetKind(),
+				"scaleTarget", va.GetScaleTargetName(),
 				"error", err)
 			continue
 		}
 
-		cost := saturation.DefaultVariantCost
-		if va.Spec.VariantCost != "" {
-			if parsedCost, err := strconv.ParseFloat(va.Spec.VariantCost, 64); err == nil {
-				cost = parsedCost
-			} else {
-				logger.V(logging.DEBUG).Info("Failed to parse variant cost, using default",
-					"variant", va.Name, "variantCost", va.Spec.VariantCost, "default", cost, "error", err)
-			}
-		}
+		cost := e.variantCost(ctx, va, workload)
 
-		deploymentKey := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
-		deployments[deploymentKey] = &deploy
+		workloadKey := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
+		workloads[workloadKey] = workload
 
 		variantKey := utils.GetNamespacedKey(va.Namespace, va.Name)
 		variantAutoscalings[variantKey] = va
@@ -725,7 +1243,7 @@ func (e *Engine) prepareModelData(
 	logger.V(logging.DEBUG).Info("Using source infrastructure for replica metrics",
 		"modelID", modelID,
 		"namespace", namespace)
-	replicaMetrics, err := e.ReplicaMetricsCollector.CollectReplicaMetrics(ctx, modelID, namespace, deployments, variantAutoscalings, variantCosts)
+	replicaMetrics, err := e.ReplicaMetricsCollector.CollectReplicaMetrics(ctx, modelID, namespace, workloads, variantAutoscalings, variantCosts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect Saturation metrics for model %s: %w", modelID, err)
 	}
@@ -742,17 +1260,66 @@ func (e *Engine) prepareModelData(
 		return nil, nil // nil modelData signals skip
 	}
 
-	variantStates := e.BuildVariantStates(ctx, modelVAs, deployments, k8sClient)
+	variantStates := e.BuildVariantStates(ctx, modelVAs, workloads, k8sClient)
+	setReportingReplicas(variantStates, replicaMetrics)
 
-	return &modelData{
+	data := &modelData{
 		modelID:             modelID,
 		namespace:           namespace,
 		replicaMetrics:      replicaMetrics,
-		deployments:         deployments,
+		workloads:           workloads,
 		variantAutoscalings: variantAutoscalings,
 		variantCosts:        variantCosts,
 		variantStates:       variantStates,
-	}, nil
+	}
+	e.computeLatencyBudgets(modelID, data)
+	e.recordVariantLoads(data)
+	e.recordSignalTimes(data)
+	if e.dryRunHistory != nil {
+		e.dryRunHistory.Record(modelID, namespace, replicaMetrics, variantStates)
+	}
+	if e.saturationHeatmap != nil {
+		e.saturationHeatmap.Record(modelID, namespace, replicaMetrics)
+	}
+	e.observeTTFT(modelID, replicaMetrics)
+	if e.variantReplicaMetrics != nil {
+		for _, rm := range replicaMetrics {
+			key := utils.GetNamespacedKey(rm.Namespace, rm.VariantName)
+			e.variantReplicaMetrics[key] = append(e.variantReplicaMetrics[key], rm)
+		}
+	}
+	return data, nil
+}
+
+// variantCost returns the per-replica cost of a variant.
+// An explicit VariantCost in the VA spec takes precedence. Otherwise, in limited mode, the cost is
+// the configured unit cost of the VA's accelerator times the GPUs per replica, so that the limiter
+// and optimizer compare variants by actual accelerator prices. The unit cost of a variant restricted
+// to the zones of its model storage is the highest of these zones. The default cost is used otherwise.
+func (e *Engine) variantCost(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, workload interfaces.Workload) float64 {
+	logger := ctrl.LoggerFrom(ctx)
+
+	cost := saturation.DefaultVariantCost
+	if va.Spec.VariantCost != "" {
+		parsedCost, err := strconv.ParseFloat(va.Spec.VariantCost, 64)
+		if err == nil {
+			return parsedCost
+		}
+		logger.V(logging.DEBUG).Info("Failed to parse variant cost, using default",
+			"variant", va.Name, "variantCost", va.Spec.VariantCost, "default", cost, "error", err)
+	}
+
+	if e.Config.LimitedModeEnabled() {
+		zones, err := utils.ModelZones(ctx, e.client, va)
+		if err != nil {
+			logger.V(logging.DEBUG).Info("Could not resolve the model zones of VA, using its resolved zones",
+				"variant", va.Name, "zones", zones, "error", err)
+		}
+		if unitCost, ok := e.Config.AcceleratorZonesUnitCost(va.Labels[utils.AcceleratorNameLabel], zones); ok {
+			return unitCost * e.gpusPerReplica(va, workload)
+		}
+	}
+	return cost
 }
 
 // RunSaturationAnalysis performs V1 saturation analysis for a model and returns targets.
@@ -802,13 +1369,18 @@ func (e *Engine) RunSaturationAnalysis(
 }
 
 // applySaturationDecisions updates VA status and emits metrics based on Saturation decisions.
+// The errors of VAs that could not be optimized, by VA namespace/name, are passed on to the controller.
 func (e *Engine) applySaturationDecisions(
 	ctx context.Context,
 	decisions []interfaces.VariantDecision,
 	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	currentAllocations map[string]*interfaces.Allocation,
+	failures map[string]error,
 ) error {
 	logger := ctrl.LoggerFrom(ctx)
+	// per-variant entries are logged only in cycles sampled for full detail
+	detailed := logging.Detailed(ctx)
+	actions := make(map[interfaces.SaturationAction]int)
 	// Create a map of decisions for O(1) lookup
 	// Use namespace/variantName as key to match vaMap and avoid collisions
 	decisionMap := make(map[string]interfaces.VariantDecision)
@@ -821,12 +1393,15 @@ func (e *Engine) applySaturationDecisions(
 		decision, hasDecision := decisionMap[vaName]
 
 		if hasDecision {
+			actions[decision.Action]++
+		}
+		if hasDecision && detailed {
 			logger.Info("Processing decision for VA",
 				"variant", vaName,
 				"action", decision.Action,
 				"current", decision.CurrentReplicas,
 				"target", decision.TargetReplicas)
-		} else {
+		} else if detailed {
 			logger.V(logging.DEBUG).Info("No scaling decision for VA, but updating status to trigger reconcile",
 				"variant", vaName)
 		}
@@ -889,11 +1464,13 @@ func (e *Engine) applySaturationDecisions(
 			// TargetReplicas and AcceleratorName are left at zero values since we don't
 			// have enough information to set them.
 			common.DecisionCache.Set(va.Name, va.Namespace, interfaces.VariantDecision{
-				VariantName:      vaName,
-				Namespace:        va.Namespace,
-				MetricsAvailable: false,
-				MetricsReason:    MetricsReasonUnavailable,
-				MetricsMessage:   MetricsMessageUnavailable,
+				VariantName:        vaName,
+				Namespace:          va.Namespace,
+				MetricsAvailable:   false,
+				MetricsReason:      MetricsReasonUnavailable,
+				MetricsMessage:     MetricsMessageUnavailable,
+				ObservedGeneration: va.Generation,
+				Error:              failures[vaName],
 			})
 			// Trigger reconciler to apply the condition
 			common.DecisionTrigger <- event.GenericEvent{
@@ -908,42 +1485,34 @@ func (e *Engine) applySaturationDecisions(
 			NumReplicas: targetReplicas,
 			Accelerator: acceleratorName,
 			LastRunTime: metav1.Now(),
+			ConfigHash:  e.configHashes[va.Namespace],
+		}
+		if hasDecision {
+			updateVa.Status.DesiredOptimizedAlloc.ScaleUpGrant = common.DecisionToScaleUpGrant(decision)
+			updateVa.Status.DesiredOptimizedAlloc.TrafficWeight = common.DecisionToTrafficWeight(decision)
+			updateVa.Status.DesiredOptimizedAlloc.EngineOutputs = common.DecisionToEngineOutputs(decision)
+			updateVa.Status.DesiredOptimizedAlloc.SLOViolation = common.DecisionToSLOViolation(decision)
 		}
 		updateVa.Status.Actuation.Applied = false // Reset applied status until Actuator handles it (if needed)
 
-		// Set condition based on decision characteristics (or lack thereof)
+		// Describe the decision for the OptimizationReady condition, which the controller sets
+		optimizationReason := llmdVariantAutoscalingV1alpha1.ReasonOptimizationSucceeded
+		optimizationMessage := "Optimization loop ran (no scaling change needed)"
 		if hasDecision {
 			if decision.SafetyOverride {
-				llmdVariantAutoscalingV1alpha1.SetCondition(&updateVa,
-					llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
-					metav1.ConditionTrue,
-					"SaturationSafetyOverride",
-					fmt.Sprintf("saturation safety override: %s", reason))
+				optimizationReason = llmdVariantAutoscalingV1alpha1.ReasonSaturationSafetyOverride
+				optimizationMessage = fmt.Sprintf("saturation safety override: %s", reason)
 			} else if decision.SaturationOnly {
-				llmdVariantAutoscalingV1alpha1.SetCondition(&updateVa,
-					llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
-					metav1.ConditionTrue,
-					"SaturationOnlyMode",
-					fmt.Sprintf("saturation-only decision: %s (target: %d replicas)", reason, targetReplicas))
+				optimizationReason = llmdVariantAutoscalingV1alpha1.ReasonSaturationOnlyMode
+				optimizationMessage = fmt.Sprintf("saturation-only decision: %s (target: %d replicas)", reason, targetReplicas)
 			} else {
-				llmdVariantAutoscalingV1alpha1.SetCondition(&updateVa,
-					llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
-					metav1.ConditionTrue,
-					llmdVariantAutoscalingV1alpha1.ReasonOptimizationSucceeded,
-					fmt.Sprintf("Hybrid mode: %s (target: %d replicas)", reason, targetReplicas))
+				optimizationMessage = fmt.Sprintf("Hybrid mode: %s (target: %d replicas)", reason, targetReplicas)
 			}
-		} else {
-			// No active decision (just refreshing)
-			llmdVariantAutoscalingV1alpha1.SetCondition(&updateVa,
-				llmdVariantAutoscalingV1alpha1.TypeOptimizationReady,
-				metav1.ConditionTrue,
-				llmdVariantAutoscalingV1alpha1.ReasonOptimizationSucceeded,
-				"Optimization loop ran (no scaling change needed)")
 		}
 
 		// Emit metrics for external autoscalers (Important: Actuator emits these)
 		// We should emit metrics even if no decision changed, to keep HPA alive
-		act := actuator.NewActuator(e.client)
+		act := e.actuator
 		/*
 		   NOTE: emitSafetyNetMetrics handles cases where optimization FAILS.
 		   Here we are in the success path (optimization ran, even if no change).
@@ -957,18 +1526,28 @@ func (e *Engine) applySaturationDecisions(
 		// 	isSaturationOnly = decision.SaturationOnly
 		// }
 
-		if err := act.EmitMetrics(ctx, &updateVa); err != nil {
+		if isDryRunVariant(&updateVa) {
+			if hasDecision && detailed {
+				logger.Info("Dry-run variant, not emitting metrics",
+					"variant", updateVa.Name,
+					"target", targetReplicas,
+					"accelerator", acceleratorName)
+			}
+		} else if err := act.EmitMetrics(ctx, &updateVa); err != nil {
 			logger.Error(err, "Failed to emit metrics for external autoscalers",
 				"variant", updateVa.Name)
 		} else {
 			// Only log detail if we had a decision or periodically (to avoid spamming logs on every loop for no-ops)
-			if hasDecision {
+			if hasDecision && detailed {
 				logger.Info("Successfully emitted metrics",
 					"variant", updateVa.Name,
 					"target", targetReplicas,
 					"accelerator", acceleratorName)
 			}
 			updateVa.Status.Actuation.Applied = true
+			if hasDecision {
+				e.trackScaleLatency(ctx, vaName, decision, targetReplicas, time.Now())
+			}
 		}
 
 		// Update Shared State and Trigger Reconcile via Channel
@@ -990,34 +1569,361 @@ func (e *Engine) applySaturationDecisions(
 		}
 
 		common.DecisionCache.Set(va.Name, va.Namespace, interfaces.VariantDecision{
-			VariantName:       vaName,
-			Namespace:         va.Namespace,
-			TargetReplicas:    targetReplicas,
-			AcceleratorName:   acceleratorName,
-			LastRunTime:       metav1.Now(),
-			CurrentAllocation: currentAllocations[vaName],
-			MetricsAvailable:  metricsAvailable,
-			MetricsReason:     metricsReason,
-			MetricsMessage:    metricsMessage,
+			VariantName:            vaName,
+			Namespace:              va.Namespace,
+			CurrentReplicas:        decision.CurrentReplicas,
+			TargetReplicas:         targetReplicas,
+			OriginalTargetReplicas: decision.OriginalTargetReplicas,
+			AcceleratorName:        acceleratorName,
+			LastRunTime:            metav1.Now(),
+			CurrentAllocation:      currentAllocations[vaName],
+			MetricsAvailable:       metricsAvailable,
+			MetricsReason:          metricsReason,
+			MetricsMessage:         metricsMessage,
+			CapacityCeiling:        decision.CapacityCeiling,
+			CappedByCapacity:       decision.CappedByCapacity,
+			PolicyCapped:           decision.PolicyCapped,
+			PolicyMaxReplicas:      decision.PolicyMaxReplicas,
+			PolicyReason:           decision.PolicyReason,
+			WasLimited:             decision.WasLimited,
+			LimitedBy:              decision.LimitedBy,
+			LimitReason:            decision.LimitReason,
+			LimitMessage:           decision.LimitMessage,
+			UnschedulableReplicas:  decision.UnschedulableReplicas,
+			TargetUnschedulable:    decision.TargetUnschedulable,
+			PDBMinReplicas:         decision.PDBMinReplicas,
+			PDBName:                decision.PDBName,
+			PDBConflict:            decision.PDBConflict,
+			HPAName:                decision.HPAName,
+			HPAMinReplicas:         decision.HPAMinReplicas,
+			HPAMaxReplicas:         decision.HPAMaxReplicas,
+			BoundsConflict:         decision.BoundsConflict,
+			ModelOverloaded:        decision.ModelOverloaded,
+			FallbackAccelerator:    decision.FallbackAccelerator,
+			FallbackReplicas:       decision.FallbackReplicas,
+			WarmingUp:              decision.WarmingUp,
+			ScaleUpIneffective:     decision.ScaleUpIneffective,
+			ScaleUpRolledBack:      decision.ScaleUpRolledBack,
+			ScaleUpMessage:         decision.ScaleUpMessage,
+			TrafficWeight:          decision.TrafficWeight,
+			HasTrafficWeight:       decision.HasTrafficWeight,
+			LatencyBudget:          e.latencyBudgets[vaName],
+			Prediction:             decision.Prediction,
+			RequestRate:            e.ScaleToZeroEnforcer.RequestRate(va.Spec.ModelID, va.Namespace),
+			ObservedGeneration:     va.Generation,
+			ConfigHash:             e.configHashes[va.Namespace],
+			OptimizationReason:     optimizationReason,
+			OptimizationMessage:    optimizationMessage,
+			Error:                  failures[vaName],
 		})
 
+		// Record the saturation the decision was based on, so the reconciler handles
+		// saturated VAs first; without metrics, the last known saturation is kept
+		if hasDecision && hasAllocation {
+			common.SaturationCache.Set(va.Name, va.Namespace, 1-decision.SpareCapacity)
+		}
+
 		// 2. Trigger Reconciler
 		common.DecisionTrigger <- event.GenericEvent{
 			Object: &updateVa,
 		}
 
-		if hasDecision {
+		if hasDecision && detailed {
 			logger.Info("Applied saturation decision via shared cache",
 				"variant", vaName,
 				"action", decision.Action,
 				"target", targetReplicas,
-				"reason", reason)
+				"reason", reason,
+				"configHash", e.configHashes[va.Namespace])
 		}
 	}
 
+	if !detailed {
+		logger.Info("Applied saturation decisions (details logged in sampled cycles)",
+			"variants", len(vaMap),
+			"decisions", len(decisions),
+			"actions", actions)
+	}
+
 	return nil
 }
 
+// limitDecisions limits the targets of the saturation engine, of the V1 and V2 paths
+// alike, to the resources available to them: with the GPU limiter when it is enabled,
+// otherwise at the capacity ceiling. It runs after the stages holding and raising targets,
+// so that GPUs are granted only to the scale-ups surviving them.
+func (e *Engine) limitDecisions(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	for i := range decisions {
+		decisions[i].OriginalTargetReplicas = decisions[i].TargetReplicas
+	}
+
+	// Note: Limiter uses global saturation config since it's applied globally to all decisions
+	var globalSaturationConfig interfaces.SaturationScalingConfig
+	if cfg, ok := e.Config.SaturationConfig()["default"]; ok {
+		globalSaturationConfig = cfg
+	}
+
+	// Apply GPU limiter if enabled
+	if globalSaturationConfig.EnableLimiter && len(decisions) > 0 {
+		limiter := e.GPULimiter
+		if globalSaturationConfig.LimiterPolicy == interfaces.LimiterPolicyMaxMinFairness {
+			limiter = e.FairGPULimiter
+		}
+		logger.Info("Applying GPU limiter to scaling decisions",
+			"decisionCount", len(decisions),
+			"policy", globalSaturationConfig.LimiterPolicy)
+
+		decisionPtrs := make([]*interfaces.VariantDecision, len(decisions))
+		for i := range decisions {
+			decisionPtrs[i] = &decisions[i]
+		}
+
+		if err := limiter.Limit(ctx, decisionPtrs); err != nil {
+			logger.Error(err, "GPU limiter failed, proceeding with original decisions")
+		} else {
+			for _, d := range decisionPtrs {
+				if d.WasLimited {
+					logger.Info("Decision was limited by GPU availability",
+						"variant", d.VariantName,
+						"tenant", d.Tenant,
+						"originalTarget", d.OriginalTargetReplicas,
+						"limitedTarget", d.TargetReplicas,
+						"limitedBy", d.LimitedBy,
+						"reason", d.LimitReason,
+						"message", d.LimitMessage)
+				}
+			}
+			e.emitTenantShortfallMetrics(ctx, pipeline.TenantShortfalls(decisionPtrs))
+			e.emitCapacityShortfallMetrics(ctx, decisionPtrs)
+
+			// Recommend the replicas the limiter did not grant on the fallback class of their variant
+			e.recommendFallback(ctx, decisionPtrs, modelGroups)
+		}
+	} else {
+		e.applyCapacityCeiling(ctx, decisions)
+	}
+}
+
+// applyCapacityCeiling caps decisions at the replicas the cluster could place on their
+// accelerator type. Decisions are left unchanged if the inventory cannot be refreshed.
+func (e *Engine) applyCapacityCeiling(ctx context.Context, decisions []interfaces.VariantDecision) {
+	if e.CapacityCeiling == nil || len(decisions) == 0 {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	decisionPtrs := make([]*interfaces.VariantDecision, len(decisions))
+	for i := range decisions {
+		decisionPtrs[i] = &decisions[i]
+	}
+	if err := e.CapacityCeiling.Apply(ctx, decisionPtrs); err != nil {
+		logger.Error(err, "Capacity ceiling failed, proceeding with uncapped decisions")
+		return
+	}
+	for _, d := range decisionPtrs {
+		if d.CappedByCapacity {
+			logger.Info("Decision was capped at cluster capacity",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"accelerator", d.AcceleratorName,
+				"ceiling", d.CapacityCeiling)
+		}
+	}
+}
+
+// setDecisionTenants sets the tenant of each decision from the tenant label of its
+// VariantAutoscaling, falling back to the namespace when the label is missing.
+func setDecisionTenants(decisions []interfaces.VariantDecision, modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling, tenantLabel string) {
+	tenants := make(map[string]string, len(modelVAs))
+	for _, va := range modelVAs {
+		tenant := va.Labels[tenantLabel]
+		if tenant == "" {
+			tenant = va.Namespace
+		}
+		tenants[utils.GetNamespacedKey(va.Namespace, va.Name)] = tenant
+	}
+	for i := range decisions {
+		d := &decisions[i]
+		if tenant, ok := tenants[utils.GetNamespacedKey(d.Namespace, d.VariantName)]; ok {
+			d.Tenant = tenant
+		} else {
+			d.Tenant = d.Namespace
+		}
+	}
+}
+
+// emitTenantShortfallMetrics emits the per-tenant GPU shortfall left by the GPU limiter.
+func (e *Engine) emitTenantShortfallMetrics(ctx context.Context, shortfalls map[string]float64) {
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+	for tenant, gpus := range shortfalls {
+		if gpus > 0 {
+			logger.Info("Tenant GPU demand not granted by limiter",
+				"tenant", tenant,
+				"shortfallGPUs", gpus)
+		}
+		if err := emitter.EmitTenantShortfallMetrics(ctx, tenant, gpus); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit tenant shortfall metrics",
+				"tenant", tenant,
+				"error", err.Error())
+		}
+	}
+}
+
+// orchestrateDeletionCost sets the pod deletion cost of the variants scaling down from
+// the age and cache warmth of their replicas, so that the newest and least-warmed
+// replicas are terminated first. Failures are logged: deletion costs only pick the
+// replicas to terminate and never block scale-downs.
+func (e *Engine) orchestrateDeletionCost(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if e.deletionCoster == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	for _, d := range decisions {
+		if d.TargetReplicas >= d.CurrentReplicas {
+			continue
+		}
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		va, ok := vaMap[key]
+		if !ok || !targetsDeployment(va) {
+			continue
+		}
+		var deploy appsv1.Deployment
+		if err := utils.GetDeploymentWithBackoff(ctx, e.client, va.GetScaleTargetName(), va.Namespace, &deploy); err != nil {
+			logger.Error(err, "Failed to get scale target to set pod deletion costs", "variant", va.Name)
+			continue
+		}
+		patched, err := e.deletionCoster.Apply(ctx, &deploy, e.variantReplicaMetrics[key])
+		if err != nil {
+			logger.Error(err, "Failed to set pod deletion costs", "variant", va.Name)
+			continue
+		}
+		if patched > 0 {
+			logger.Info("Set pod deletion costs of scaling-down variant",
+				"variant", va.Name,
+				"namespace", va.Namespace,
+				"pods", patched,
+				"current", d.CurrentReplicas,
+				"target", d.TargetReplicas)
+		}
+	}
+}
+
+// orchestratePrepull creates image pre-pull DaemonSets for opted-in variants that scale
+// up, and deletes them once the new replicas are ready or their TTL expired.
+// Failures are logged: pre-pulling only speeds up scale-ups and never blocks them.
+func (e *Engine) orchestratePrepull(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if e.prepuller == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	for _, d := range decisions {
+		va, ok := vaMap[utils.GetNamespacedKey(d.Namespace, d.VariantName)]
+		if !ok || !prepull.Enabled(va) || !targetsDeployment(va) {
+			continue
+		}
+		switch {
+		case d.TargetReplicas > d.CurrentReplicas:
+			var deploy appsv1.Deployment
+			if err := utils.GetDeploymentWithBackoff(ctx, e.client, va.GetScaleTargetName(), va.Namespace, &deploy); err != nil {
+				logger.V(logging.DEBUG).Info("Could not get deployment for image pre-pull",
+					"variant", va.Name,
+					"error", err)
+				continue
+			}
+			if err := e.prepuller.Ensure(ctx, va, &deploy); err != nil {
+				logger.Error(err, "Failed to pre-pull images for scale-up", "variant", va.Name)
+			}
+		case d.ReadyReplicas >= d.CurrentReplicas:
+			if err := e.prepuller.Cleanup(ctx, va); err != nil {
+				logger.Error(err, "Failed to clean up image pre-pull", "variant", va.Name)
+			}
+		}
+	}
+
+	if err := e.prepuller.CleanupExpired(ctx); err != nil {
+		logger.Error(err, "Failed to clean up expired image pre-pulls")
+	}
+}
+
+// emitCapacityShortfallMetrics emits, per variant, the scale-up replicas the GPU limiter
+// did not grant. Variants without a shortfall report 0 to clear a previous one.
+func (e *Engine) emitCapacityShortfallMetrics(ctx context.Context, decisions []*interfaces.VariantDecision) {
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+	for _, d := range decisions {
+		requested, granted := d.ScaleUpGrant()
+		if err := emitter.EmitCapacityShortfallMetrics(ctx, d.VariantName, d.Namespace, d.AcceleratorName, requested-granted); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit capacity shortfall metrics",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"error", err.Error())
+		}
+	}
+}
+
+// emitControllerLoadMetrics emits the load of the controller and the back-pressure
+// applied in this run.
+func (e *Engine) emitControllerLoadMetrics(ctx context.Context, load backpressure.Load) {
+	if err := metrics.NewMetricsEmitter().EmitControllerLoadMetrics(ctx, load.QueueDepth, load.QueryLatency, load.Slowdown, load.Deferred); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit controller load metrics",
+			"error", err.Error())
+	}
+}
+
+// emitDampeningMetrics emits flap and dampened-change counts for the variants
+// the change dampener acted on in this run.
+func (e *Engine) emitDampeningMetrics(ctx context.Context, result pipeline.DampenResult) {
+	if len(result.Flapped) == 0 && len(result.Dampened) == 0 {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	logger.Info("Change dampener held back scaling decisions",
+		"dampened", len(result.Dampened),
+		"flapped", len(result.Flapped))
+
+	type dampeningCounts struct{ flaps, dampened int }
+	counts := make(map[types.NamespacedName]*dampeningCounts)
+	countsFor := func(variant types.NamespacedName) *dampeningCounts {
+		if _, ok := counts[variant]; !ok {
+			counts[variant] = &dampeningCounts{}
+		}
+		return counts[variant]
+	}
+	for _, variant := range result.Flapped {
+		countsFor(variant).flaps++
+	}
+	for _, variant := range result.Dampened {
+		countsFor(variant).dampened++
+	}
+
+	emitter := metrics.NewMetricsEmitter()
+	for variant, c := range counts {
+		if err := emitter.EmitDampeningMetrics(ctx, variant.Name, variant.Namespace, c.flaps, c.dampened); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit dampening metrics",
+				"variant", variant.Name,
+				"namespace", variant.Namespace,
+				"error", err.Error())
+		}
+	}
+}
+
 // emitSafetyNetMetrics emits fallback metrics when saturation analysis fails.
 func (e *Engine) emitSafetyNetMetrics(
 	ctx context.Context,
@@ -1025,9 +1931,14 @@ func (e *Engine) emitSafetyNetMetrics(
 	currentAllocations map[string]*interfaces.Allocation,
 ) {
 	logger := ctrl.LoggerFrom(ctx)
-	act := actuator.NewActuator(e.client)
+	act := e.actuator
 
 	for _, va := range modelVAs {
+		// The desired replicas in the status of a VA in dry-run were never applied
+		if isDryRunVariant(&va) {
+			continue
+		}
+
 		// Determine desired replicas
 		var desiredReplicas int32
 		var fallbackSource string
@@ -1073,7 +1984,7 @@ func (e *Engine) emitSafetyNetMetrics(
 		}
 
 		// Emit safety net metrics
-		if err := act.MetricsEmitter.EmitReplicaMetrics(
+		if err := act.EmitReplicaMetrics(
 			ctx,
 			&va,
 			currentReplicas,
@@ -1093,3 +2004,11 @@ func (e *Engine) emitSafetyNetMetrics(
 			"fallbackSource", fallbackSource)
 	}
 }
+
+// recordModelFailure records the error that kept the VAs of a model from being optimized.
+func recordModelFailure(failures map[string]error, modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling, err error) {
+	modelErr := &wvaerrors.ModelError{ModelID: modelVAs[0].Spec.ModelID, Namespace: modelVAs[0].Namespace, Err: err}
+	for i := range modelVAs {
+		failures[utils.GetNamespacedKey(modelVAs[i].Namespace, modelVAs[i].Name)] = modelErr
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -28,12 +29,15 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source/prometheus"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/snapshot"
 	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	utils "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
@@ -297,6 +301,88 @@ data:
 		})
 	})
 
+	Context("State snapshot", func() {
+		var engine *Engine
+		var store *snapshot.Store
+
+		BeforeEach(func() {
+			logging.NewTestLogger()
+
+			ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: config.SystemNamespace()}}
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, ns))).NotTo(HaveOccurred())
+
+			sourceRegistry := source.NewSourceRegistry()
+			sourceRegistry.Register("prometheus", source.NewNoOpSource()) // nolint:errcheck
+			engine = NewEngine(k8sClient, k8sClient.Scheme(), nil, sourceRegistry, config.NewTestConfig())
+			store = snapshot.NewStore(k8sClient, k8sClient, config.SystemNamespace(), "wva-state-snapshot-test")
+			engine.SetSnapshotStore(store)
+		})
+
+		It("should restore a recent snapshot and save the state for the next leader", func() {
+			Expect(store.Save(ctx, &snapshot.Snapshot{
+				TakenAt:   time.Now().Add(-time.Minute),
+				Decisions: []snapshot.Decision{{Name: "restored-va", Namespace: "snapshot-ns", TargetReplicas: 2, Accelerator: "H100", Saturation: ptr.To(0.9)}},
+				Capacities: []snapshot.Capacity{{
+					Namespace: "snapshot-ns", ModelID: "meta/llama", VariantName: "restored-va",
+					AcceleratorName: "H100", GpuCount: 1, EffectiveCapacity: 14000, LearnedAt: time.Now().Add(-time.Minute),
+				}},
+			})).To(Succeed())
+
+			By("Restoring the snapshot")
+			engine.restoreSnapshot(ctx)
+			saturation, ok := common.SaturationCache.Get("restored-va", "snapshot-ns")
+			Expect(ok).To(BeTrue())
+			Expect(saturation).To(Equal(0.9))
+			Expect(engine.capacityStore.LiveRecords()).To(HaveLen(1))
+
+			By("Saving the state of the optimized VAs")
+			va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Name: "restored-va", Namespace: "snapshot-ns"},
+			}
+			common.DecisionCache.Set(va.Name, va.Namespace, interfaces.VariantDecision{TargetReplicas: 3, AcceleratorName: "H100"})
+			engine.saveSnapshot(ctx, map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling{"snapshot-ns/restored-va": va})
+
+			saved, err := store.Load(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved.Decisions).To(ConsistOf(snapshot.Decision{
+				Name: "restored-va", Namespace: "snapshot-ns", TargetReplicas: 3, Accelerator: "H100", Saturation: ptr.To(0.9),
+			}))
+			Expect(saved.Capacities).To(HaveLen(1))
+		})
+
+		It("should not restore a snapshot older than the max age", func() {
+			Expect(store.Save(ctx, &snapshot.Snapshot{
+				TakenAt:   time.Now().Add(-time.Hour),
+				Decisions: []snapshot.Decision{{Name: "stale-va", Namespace: "snapshot-ns", TargetReplicas: 2, Accelerator: "H100", Saturation: ptr.To(0.9)}},
+			})).To(Succeed())
+
+			engine.restoreSnapshot(ctx)
+			_, ok := common.SaturationCache.Get("stale-va", "snapshot-ns")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("Standby warmer", func() {
+		BeforeEach(func() {
+			logging.NewTestLogger()
+		})
+
+		It("should warm the caches without applying decisions until elected", func() {
+			sourceRegistry := source.NewSourceRegistry()
+			sourceRegistry.Register("prometheus", source.NewNoOpSource()) // nolint:errcheck
+			engine := NewEngine(k8sClient, k8sClient.Scheme(), nil, sourceRegistry, config.NewTestConfig())
+
+			elected := make(chan struct{})
+			warmer := NewStandbyWarmer(engine, elected)
+			Expect(warmer.NeedLeaderElection()).To(BeFalse())
+
+			done := make(chan error)
+			go func() { done <- warmer.Start(ctx) }()
+			close(elected)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
+
 	Context("Source Infrastructure Optimization Tests", func() {
 		const totalVAs = 3
 		const configMapName = "wva-variantautoscaling-config"
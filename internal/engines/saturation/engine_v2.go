@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	appsv1 "k8s.io/api/apps/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
@@ -23,25 +22,25 @@ func (e *Engine) runV2AnalysisOnly(
 	replicaMetrics []interfaces.ReplicaMetrics,
 	config interfaces.SaturationScalingConfig,
 	variantStates []interfaces.VariantReplicaState,
-	deployments map[string]*appsv1.Deployment,
+	workloads map[string]interfaces.Workload,
 	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 ) (*interfaces.AnalyzerResult, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	// 1. Pre-populate capacity store with deployment-derived params
+	// 1. Pre-populate capacity store with params derived from the scale targets
 	for _, va := range variantAutoscalings {
-		deployKey := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
-		deploy := deployments[deployKey]
-		if deploy == nil {
-			logger.V(logging.DEBUG).Info("No deployment found for VA, skipping capacity store pre-population",
-				"variant", va.Name, "deployKey", deployKey)
+		workloadKey := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
+		workload := workloads[workloadKey]
+		if workload == nil {
+			logger.V(logging.DEBUG).Info("No scale target found for VA, skipping capacity store pre-population",
+				"variant", va.Name, "workloadKey", workloadKey)
 			continue
 		}
 		accelerator := utils.GetAcceleratorType(va)
-		gpuCount := getDeploymentGPUsPerReplica(deploy)
-		e.capacityStore.LoadFromDeployment(namespace, modelID, va.Name, accelerator, gpuCount, deploy)
-		logger.V(logging.DEBUG).Info("Pre-populated capacity store from deployment",
-			"variant", va.Name, "accelerator", accelerator, "gpuCount", gpuCount)
+		gpuCount := e.gpusPerReplica(va, workload)
+		e.capacityStore.LoadFromPodTemplate(namespace, modelID, va.Name, accelerator, gpuCount, workload.PodTemplate())
+		logger.V(logging.DEBUG).Info("Pre-populated capacity store from scale target",
+			"variant", va.Name, "kind", workload.WorkloadKind(), "accelerator", accelerator, "gpuCount", gpuCount)
 	}
 
 	// 2. Build AnalyzerInput
@@ -54,6 +53,13 @@ func (e *Engine) runV2AnalysisOnly(
 		// TODO: populate SchedulerQueue when flow control metrics are collected
 	}
 
+	// Correct the demand for the requests the gateway shed, when enabled
+	if config.LoadSheddingCorrection {
+		if gc, ok := e.ReplicaMetricsCollector.(interfaces.GatewayTrafficCollector); ok {
+			input.GatewayTraffic = gc.CollectGatewayTraffic(ctx, modelID)
+		}
+	}
+
 	// 3. Run V2 analyzer
 	result, err := e.saturationV2Analyzer.Analyze(ctx, input)
 	if err != nil {
@@ -134,11 +140,11 @@ func (e *Engine) collectV2ModelRequest(
 	replicaMetrics []interfaces.ReplicaMetrics,
 	config interfaces.SaturationScalingConfig,
 	variantStates []interfaces.VariantReplicaState,
-	deployments map[string]*appsv1.Deployment,
+	workloads map[string]interfaces.Workload,
 	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 ) (*pipeline.ModelScalingRequest, error) {
 	result, err := e.runV2AnalysisOnly(ctx, modelID, namespace, replicaMetrics, config,
-		variantStates, deployments, variantAutoscalings)
+		variantStates, workloads, variantAutoscalings)
 	if err != nil {
 		return nil, fmt.Errorf("collecting V2 model request for %s/%s: %w", namespace, modelID, err)
 	}
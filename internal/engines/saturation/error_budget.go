@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+// observeTTFT records the average TTFT (msec) of the replicas of a model reporting one.
+// A model analyzed in several namespaces keeps its highest average.
+func (e *Engine) observeTTFT(modelID string, replicaMetrics []interfaces.ReplicaMetrics) {
+	if e.observedTTFTs == nil {
+		return
+	}
+	var total float64
+	reporting := 0
+	for _, rm := range replicaMetrics {
+		if rm.AvgTTFT > 0 {
+			total += rm.AvgTTFT * 1000 // sec to msec
+			reporting++
+		}
+	}
+	if reporting == 0 {
+		return
+	}
+	if avg := total / float64(reporting); avg > e.observedTTFTs[modelID] {
+		e.observedTTFTs[modelID] = avg
+	}
+}
+
+// recordErrorBudgets records whether the TTFT SLOs of each service class are violated in
+// this run, i.e. whether the average TTFT of any of its models exceeds its SLO, and emits
+// the error budgets. Service classes with none of their models observed are not recorded,
+// and the budgets of service classes no longer configured are forgotten.
+func (e *Engine) recordErrorBudgets(ctx context.Context) {
+	if e.errorBudgets == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+
+	// nil service classes could not be read: keep the budgets until they can
+	if e.serviceClasses != nil {
+		configured := make(map[string]bool, len(e.serviceClasses))
+		for _, sc := range e.serviceClasses {
+			configured[sc.Name] = true
+		}
+		for _, class := range e.errorBudgets.Classes() {
+			if !configured[class] {
+				e.errorBudgets.Forget(class)
+				logger.V(logging.DEBUG).Info("Forgot the error budget of a service class no longer configured",
+					"serviceClass", class)
+			}
+		}
+	}
+
+	for _, sc := range e.serviceClasses {
+		observed, violated := false, false
+		for _, entry := range sc.Data {
+			ttft, ok := e.observedTTFTs[entry.Model]
+			if entry.SLOTTFT <= 0 || !ok {
+				continue
+			}
+			observed = true
+			violated = violated || ttft > float64(entry.SLOTTFT)
+		}
+		if sc.Name == "" || !observed {
+			continue
+		}
+
+		e.errorBudgets.Record(sc.Name, violated)
+		budget, ok := e.errorBudgets.Budget(sc.Name)
+		if !ok {
+			continue
+		}
+		logger.V(logging.DEBUG).Info("SLO error budget",
+			"serviceClass", sc.Name,
+			"violated", violated,
+			"remaining", budget.Remaining,
+			"burnRate", budget.BurnRate)
+		if err := emitter.EmitErrorBudgetMetrics(ctx, sc.Name, budget.Remaining, budget.BurnRate); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit error budget metrics",
+				"serviceClass", sc.Name,
+				"error", err.Error())
+		}
+	}
+}
+
+// biasForErrorBudget returns the saturation config of a model with its thresholds biased
+// by the error budgets of the service classes with a TTFT SLO for the model: lowered when
+// a budget is nearly consumed, raised when it is ample (see pipeline.ErrorBudgetBias).
+// A model in several service classes gets the lowest thresholds.
+func (e *Engine) biasForErrorBudget(ctx context.Context, modelID string, cfg interfaces.SaturationScalingConfig) interfaces.SaturationScalingConfig {
+	if e.errorBudgets == nil {
+		return cfg
+	}
+
+	factor, class := 0.0, ""
+	var remaining float64
+	for _, sc := range e.serviceClasses {
+		if !hasTTFTSLO(sc, modelID) {
+			continue
+		}
+		budget, ok := e.errorBudgets.Budget(sc.Name)
+		if !ok {
+			continue
+		}
+		f := pipeline.ErrorBudgetBias(budget.Remaining,
+			e.Config.ErrorBudgetLow(), e.Config.ErrorBudgetHigh(), e.Config.ErrorBudgetBias())
+		if class == "" || f < factor {
+			factor, class, remaining = f, sc.Name, budget.Remaining
+		}
+	}
+	if class == "" || factor == 1 {
+		return cfg
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Biasing saturation thresholds by SLO error budget",
+		"modelID", modelID,
+		"serviceClass", class,
+		"remaining", remaining,
+		"factor", factor)
+	return pipeline.BiasSaturationThresholds(cfg, factor)
+}
+
+// hasTTFTSLO returns whether a service class has a TTFT SLO for a model.
+func hasTTFTSLO(sc interfaces.ServiceClass, modelID string) bool {
+	for _, entry := range sc.Data {
+		if entry.Model == modelID && entry.SLOTTFT > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("SLO error budget", func() {
+	var (
+		ctx    context.Context
+		engine *Engine
+		cfg    interfaces.SaturationScalingConfig
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		engine = &Engine{
+			Config:        config.NewTestConfig(),
+			errorBudgets:  pipeline.NewErrorBudgetTracker(time.Hour, 0.95),
+			observedTTFTs: make(map[string]float64),
+			serviceClasses: []interfaces.ServiceClass{{
+				Name: "Premium",
+				Data: []interfaces.ServiceClassEntry{{Model: "model-a", SLOTTFT: 200}},
+			}},
+		}
+		cfg = interfaces.SaturationScalingConfig{KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1}
+	})
+
+	It("should keep the highest average TTFT of a model", func() {
+		engine.observeTTFT("model-a", []interfaces.ReplicaMetrics{{AvgTTFT: 0.1}, {AvgTTFT: 0.3}, {}})
+		engine.observeTTFT("model-a", []interfaces.ReplicaMetrics{{AvgTTFT: 0.15}})
+		Expect(engine.observedTTFTs["model-a"]).To(BeNumerically("~", 200, 1e-9))
+	})
+
+	It("should not bias models before their service class is observed", func() {
+		Expect(engine.biasForErrorBudget(ctx, "model-a", cfg)).To(Equal(cfg))
+
+		// no TTFT observed for the models of the class
+		engine.recordErrorBudgets(ctx)
+		Expect(engine.biasForErrorBudget(ctx, "model-a", cfg)).To(Equal(cfg))
+	})
+
+	It("should raise the thresholds of models of service classes with an ample budget", func() {
+		engine.observedTTFTs["model-a"] = 150
+		engine.recordErrorBudgets(ctx)
+		// let the recorded state hold for some time
+		time.Sleep(time.Millisecond)
+
+		biased := engine.biasForErrorBudget(ctx, "model-a", cfg)
+		Expect(biased.KvCacheThreshold).To(BeNumerically("~", 0.88, 1e-9))
+		Expect(biased.QueueLengthThreshold).To(BeNumerically("~", 5.5, 1e-9))
+
+		// models outside of the service classes are not biased
+		Expect(engine.biasForErrorBudget(ctx, "model-b", cfg)).To(Equal(cfg))
+	})
+})
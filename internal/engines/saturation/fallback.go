@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// recommendFallback recommends the scale-up replicas the GPU limiter did not grant on the
+// fallback accelerator class of their variant, spec.fallback or another type of its
+// accelerator preferences, within the GPUs of the fallback class left after the limiter,
+// and emits the replicas recommended on each fallback class.
+func (e *Engine) recommendFallback(
+	ctx context.Context,
+	decisions []*interfaces.VariantDecision,
+	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if !e.Config.FeatureEnabled(config.AcceleratorFallback) || e.gpuInventory == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	vas := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
+	for _, modelVAs := range modelGroups {
+		for i := range modelVAs {
+			va := &modelVAs[i]
+			if va.Spec.Fallback != nil || len(va.Spec.AcceleratorPreferences) > 0 {
+				vas[utils.GetNamespacedKey(va.Namespace, va.Name)] = va
+			}
+		}
+	}
+	if len(vas) == 0 {
+		return
+	}
+
+	candidates := make(map[string][]llmdVariantAutoscalingV1alpha1.AcceleratorPreference, len(vas))
+	available := make(map[string]float64)
+	for _, d := range decisions {
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		va, ok := vas[key]
+		if !ok {
+			continue
+		}
+		candidates[key] = fallbackCandidates(va, d.AcceleratorName)
+		for _, candidate := range candidates[key] {
+			if _, ok := available[candidate.Accelerator]; !ok {
+				available[candidate.Accelerator] = e.gpuInventory.AvailableByType(candidate.Accelerator)
+			}
+		}
+	}
+	// Deduct the GPUs the limiter granted to scale-ups on the fallback classes
+	for _, d := range decisions {
+		if _, ok := available[d.AcceleratorName]; ok {
+			_, granted := d.ScaleUpGrant()
+			available[d.AcceleratorName] -= float64(granted) * d.ReplicaGPUs()
+		}
+	}
+
+	classes := make(map[string]pipeline.FallbackClass, len(candidates))
+	for _, d := range decisions {
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		va, ok := vas[key]
+		if !ok {
+			continue
+		}
+		fallback := chooseFallback(va, candidates[key], available)
+		if fallback == nil {
+			continue
+		}
+		classes[key] = fallbackClass(va, fallback, d.AcceleratorName, e.gpuInventory.GPUShares(fallback.Accelerator))
+	}
+
+	if onFallback := pipeline.ApplyAcceleratorFallback(ctx, decisions, classes, available); len(onFallback) > 0 {
+		logger.Info("Recommended replicas on fallback accelerator classes", "variants", len(onFallback))
+	}
+
+	emitter := metrics.NewMetricsEmitter()
+	for _, d := range decisions {
+		if d.FallbackAccelerator == "" {
+			continue
+		}
+		if err := emitter.EmitFallbackReplicasMetrics(ctx, d.VariantName, d.Namespace, d.FallbackAccelerator, d.FallbackReplicas); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit fallback replicas metrics",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"error", err.Error())
+		}
+	}
+}
+
+// fallbackCandidates returns the accelerator classes a variant running on accelerator may
+// fall back to: spec.fallback when set, otherwise the other types of its accelerator
+// preferences, most preferred first.
+func fallbackCandidates(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	accelerator string,
+) []llmdVariantAutoscalingV1alpha1.AcceleratorPreference {
+	if va.Spec.Fallback != nil {
+		return []llmdVariantAutoscalingV1alpha1.AcceleratorPreference{*va.Spec.Fallback}
+	}
+	var candidates []llmdVariantAutoscalingV1alpha1.AcceleratorPreference
+	for _, pref := range va.Spec.AcceleratorPreferences {
+		if pref.Accelerator != accelerator {
+			candidates = append(candidates, pref)
+		}
+	}
+	return candidates
+}
+
+// chooseFallback returns the fallback class of a variant among its candidates: the one its
+// replicas are recommended on, so that they are not split across classes, otherwise the
+// first with GPUs available, otherwise the first. Returns nil without candidates.
+func chooseFallback(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	candidates []llmdVariantAutoscalingV1alpha1.AcceleratorPreference,
+	available map[string]float64,
+) *llmdVariantAutoscalingV1alpha1.AcceleratorPreference {
+	if len(candidates) == 0 {
+		return nil
+	}
+	if status := va.Status.Fallback; status != nil {
+		for i := range candidates {
+			if candidates[i].Accelerator == status.Accelerator {
+				return &candidates[i]
+			}
+		}
+	}
+	for i := range candidates {
+		if available[candidates[i].Accelerator] > 0 {
+			return &candidates[i]
+		}
+	}
+	return &candidates[0]
+}
+
+// fallbackClass returns the fallback class of a variant running on accelerator. The replica
+// ratio follows the max batch sizes of the profiles of the accelerator and the fallback class,
+// and is 1 when the accelerator has no profile in the accelerator preferences. The GPUs of a
+// replica are shared by shares containers on the fallback class.
+func fallbackClass(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	fallback *llmdVariantAutoscalingV1alpha1.AcceleratorPreference,
+	accelerator string,
+	shares int,
+) pipeline.FallbackClass {
+	class := pipeline.FallbackClass{
+		Accelerator:    fallback.Accelerator,
+		GPUsPerReplica: float64(max(fallback.Profile.AccCount, 1)) / float64(max(shares, 1)),
+		ReplicaRatio:   1,
+	}
+	for _, pref := range va.Spec.AcceleratorPreferences {
+		if pref.Accelerator == accelerator && pref.Profile.MaxBatchSize > 0 && fallback.Profile.MaxBatchSize > 0 {
+			class.ReplicaRatio = float64(pref.Profile.MaxBatchSize) / float64(fallback.Profile.MaxBatchSize)
+			break
+		}
+	}
+	if status := va.Status.Fallback; status != nil && status.Accelerator == fallback.Accelerator {
+		class.Replicas = int(status.NumReplicas)
+	}
+	return class
+}
@@ -0,0 +1,102 @@
+package saturation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+var _ = Describe("Accelerator fallback", func() {
+	var va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+
+	preference := func(accelerator string, maxBatchSize int) llmdVariantAutoscalingV1alpha1.AcceleratorPreference {
+		return llmdVariantAutoscalingV1alpha1.AcceleratorPreference{
+			Accelerator: accelerator,
+			Profile: llmdVariantAutoscalingV1alpha1.VariantProfile{
+				AccCount: 1, MaxBatchSize: maxBatchSize, AtTokens: 512, Alpha: "10", Beta: "0.1", Gamma: "0",
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		va = &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "va", Namespace: "ns"},
+			Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+				AcceleratorPreferences: []llmdVariantAutoscalingV1alpha1.AcceleratorPreference{
+					preference("H100", 64), preference("A100", 32), preference("L40S", 16),
+				},
+			},
+		}
+	})
+
+	accelerators := func(prefs []llmdVariantAutoscalingV1alpha1.AcceleratorPreference) []string {
+		names := make([]string, len(prefs))
+		for i, pref := range prefs {
+			names[i] = pref.Accelerator
+		}
+		return names
+	}
+
+	Context("fallbackCandidates", func() {
+
+		It("should list the other accelerator preferences in order", func() {
+			Expect(accelerators(fallbackCandidates(va, "H100"))).To(Equal([]string{"A100", "L40S"}))
+			Expect(accelerators(fallbackCandidates(va, "A100"))).To(Equal([]string{"H100", "L40S"}))
+		})
+
+		It("should only list spec.fallback when set", func() {
+			fallback := preference("MI300X", 128)
+			va.Spec.Fallback = &fallback
+			Expect(accelerators(fallbackCandidates(va, "H100"))).To(Equal([]string{"MI300X"}))
+		})
+
+		It("should list none with a single accelerator preference", func() {
+			va.Spec.AcceleratorPreferences = va.Spec.AcceleratorPreferences[:1]
+			Expect(fallbackCandidates(va, "H100")).To(BeEmpty())
+		})
+	})
+
+	Context("chooseFallback", func() {
+
+		It("should choose the most preferred candidate with GPUs available", func() {
+			candidates := fallbackCandidates(va, "H100")
+			fallback := chooseFallback(va, candidates, map[string]float64{"A100": 0, "L40S": 4})
+			Expect(fallback).NotTo(BeNil())
+			Expect(fallback.Accelerator).To(Equal("L40S"))
+		})
+
+		It("should keep the candidate its replicas are recommended on", func() {
+			va.Status.Fallback = &llmdVariantAutoscalingV1alpha1.FallbackStatus{Accelerator: "L40S", NumReplicas: 2}
+			candidates := fallbackCandidates(va, "H100")
+			fallback := chooseFallback(va, candidates, map[string]float64{"A100": 8, "L40S": 0})
+			Expect(fallback).NotTo(BeNil())
+			Expect(fallback.Accelerator).To(Equal("L40S"))
+		})
+
+		It("should choose the most preferred candidate when none has GPUs available", func() {
+			fallback := chooseFallback(va, fallbackCandidates(va, "H100"), map[string]float64{})
+			Expect(fallback).NotTo(BeNil())
+			Expect(fallback.Accelerator).To(Equal("A100"))
+		})
+
+		It("should return nil without candidates", func() {
+			Expect(chooseFallback(va, nil, map[string]float64{"A100": 8})).To(BeNil())
+		})
+	})
+
+	Context("fallbackClass", func() {
+
+		It("should convert replicas with the max batch sizes of the profiles", func() {
+			fallback := fallbackCandidates(va, "H100")[0]
+			va.Status.Fallback = &llmdVariantAutoscalingV1alpha1.FallbackStatus{Accelerator: "A100", NumReplicas: 3}
+
+			class := fallbackClass(va, &fallback, "H100", 1)
+			Expect(class.Accelerator).To(Equal("A100"))
+			Expect(class.ReplicaRatio).To(Equal(2.0))
+			Expect(class.GPUsPerReplica).To(Equal(1.0))
+			Expect(class.Replicas).To(Equal(3))
+		})
+	})
+})
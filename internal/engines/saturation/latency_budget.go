@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// serviceClassesConfigMapName is the ConfigMap listing the service classes and the SLOs of their models.
+const serviceClassesConfigMapName = "service-classes-config"
+
+// loadServiceClasses reads the service classes. Returns none when they are not configured,
+// and nil when they cannot be read: latency and error budgets never fail the optimization loop.
+func (e *Engine) loadServiceClasses(ctx context.Context) []interfaces.ServiceClass {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var cm corev1.ConfigMap
+	key := client.ObjectKey{Namespace: config.SystemNamespace(), Name: serviceClassesConfigMapName}
+	if err := e.client.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []interfaces.ServiceClass{}
+		}
+		logger.Error(err, "Failed to read service classes, skipping latency and error budgets")
+		return nil
+	}
+	return parseServiceClasses(ctx, cm.Data)
+}
+
+// parseServiceClasses parses the service classes in the data of the service classes
+// ConfigMap. Service classes that do not parse are skipped.
+func parseServiceClasses(ctx context.Context, data map[string]string) []interfaces.ServiceClass {
+	classes := make([]interfaces.ServiceClass, 0, len(data))
+	for key, val := range data {
+		var sc interfaces.ServiceClass
+		if err := yaml.Unmarshal([]byte(val), &sc); err != nil {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Skipping service class that does not parse",
+				"key", key, "error", err)
+			continue
+		}
+		classes = append(classes, sc)
+	}
+	return classes
+}
+
+// ttftSLOs returns the TTFT SLOs (msec) of the models of the service classes.
+// A model listed in several service classes gets the strictest SLO.
+func ttftSLOs(classes []interfaces.ServiceClass) map[string]float64 {
+	return strictestSLOs(classes, func(entry interfaces.ServiceClassEntry) int { return entry.SLOTTFT })
+}
+
+// itlSLOs returns the inter-token latency (TPOT) SLOs (msec) of the models of the service
+// classes. A model listed in several service classes gets the strictest SLO.
+func itlSLOs(classes []interfaces.ServiceClass) map[string]float64 {
+	return strictestSLOs(classes, func(entry interfaces.ServiceClassEntry) int { return entry.SLOTPOT })
+}
+
+// strictestSLOs returns the smallest positive SLO of each model of the service classes.
+func strictestSLOs(classes []interfaces.ServiceClass, sloOf func(interfaces.ServiceClassEntry) int) map[string]float64 {
+	slos := make(map[string]float64)
+	for _, sc := range classes {
+		for _, entry := range sc.Data {
+			if sloOf(entry) <= 0 {
+				continue
+			}
+			if slo, ok := slos[entry.Model]; !ok || float64(sloOf(entry)) < slo {
+				slos[entry.Model] = float64(sloOf(entry))
+			}
+		}
+	}
+	return slos
+}
+
+// computeLatencyBudgets records the latency budgets of the variants of a model with a
+// TTFT SLO, keyed by VA namespace/name, for applySaturationDecisions to attach to their decisions.
+func (e *Engine) computeLatencyBudgets(modelID string, data *modelData) {
+	targetTTFT, ok := e.ttftSLOs[modelID]
+	if !ok {
+		return
+	}
+	replicasByVariant := groupReplicasByVariant(data.replicaMetrics)
+	for key, va := range data.variantAutoscalings {
+		if budget := latencyBudget(va, replicasByVariant[key], targetTTFT); budget != nil {
+			e.latencyBudgets[key] = budget
+		}
+	}
+}
+
+// groupReplicasByVariant groups replica metrics by the namespace/name of their variant.
+func groupReplicasByVariant(replicaMetrics []interfaces.ReplicaMetrics) map[string][]interfaces.ReplicaMetrics {
+	replicasByVariant := make(map[string][]interfaces.ReplicaMetrics)
+	for _, rm := range replicaMetrics {
+		key := utils.GetNamespacedKey(rm.Namespace, rm.VariantName)
+		replicasByVariant[key] = append(replicasByVariant[key], rm)
+	}
+	return replicasByVariant
+}
+
+// latencyBudget decomposes the average TTFT of the replicas of a variant into the time
+// requests wait in the queue of a replica and their prefill time, estimated with the
+// profile of the accelerator of the variant:
+//
+//	prefill  = PrefillTime(batch)
+//	queueing = queueLength * (prefill + outputTokens * DecodeTime(batch)) / batch
+//
+// i.e. a queued request waits for queueLength requests ahead of it, which a replica
+// completes batch at a time. Returns nil when the accelerator has no profile or no
+// replica reports its TTFT.
+func latencyBudget(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	replicas []interfaces.ReplicaMetrics,
+	targetTTFT float64,
+) *interfaces.LatencyBudget {
+	var budget interfaces.LatencyBudget
+	reporting := 0
+	for _, rm := range replicas {
+		if rm.AvgTTFT <= 0 {
+			continue
+		}
+		parms := profileServiceParms(va, rm.AcceleratorName)
+		if parms == nil {
+			return nil
+		}
+		request := &analyzer.RequestSize{
+			AvgInputTokens:  float32(rm.AvgInputTokens),
+			AvgOutputTokens: float32(rm.AvgOutputTokens),
+		}
+		batch := float32(max(rm.RunningRequests, 1))
+		prefill := parms.PrefillTime(request, batch)
+		serviceTime := prefill + request.AvgOutputTokens*parms.DecodeTime(request, batch)
+
+		budget.ObservedTTFT += rm.AvgTTFT * 1000 // sec to msec
+		budget.PrefillTime += float64(prefill)
+		budget.QueueingTime += float64(rm.QueueLength) * float64(serviceTime) / float64(batch)
+		reporting++
+	}
+	if reporting == 0 {
+		return nil
+	}
+	n := float64(reporting)
+	return &interfaces.LatencyBudget{
+		TargetTTFT:   targetTTFT,
+		ObservedTTFT: budget.ObservedTTFT / n,
+		QueueingTime: budget.QueueingTime / n,
+		PrefillTime:  budget.PrefillTime / n,
+	}
+}
+
+// profileServiceParms returns the service parameters of the profile of an accelerator
+// in the accelerator preferences of a variant, or nil if it has none or it does not parse.
+func profileServiceParms(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, accelerator string) *analyzer.ServiceParms {
+	profile := acceleratorProfile(va, accelerator)
+	if profile == nil {
+		return nil
+	}
+	var parms [3]float32
+	for i, val := range []string{profile.Alpha, profile.Beta, profile.Gamma} {
+		parm, err := strconv.ParseFloat(val, 32)
+		if err != nil || parm < 0 {
+			return nil
+		}
+		parms[i] = float32(parm)
+	}
+	return &analyzer.ServiceParms{Alpha: parms[0], Beta: parms[1], Gamma: parms[2]}
+}
+
+// acceleratorProfile returns the profile of an accelerator in the accelerator preferences
+// of a variant, or nil if it has none.
+func acceleratorProfile(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, accelerator string) *llmdVariantAutoscalingV1alpha1.VariantProfile {
+	for i := range va.Spec.AcceleratorPreferences {
+		if va.Spec.AcceleratorPreferences[i].Accelerator == accelerator {
+			return &va.Spec.AcceleratorPreferences[i].Profile
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package saturation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("Latency budget", func() {
+
+	Context("ttftSLOs", func() {
+
+		It("should keep the strictest TTFT SLO of each model", func() {
+			slos := ttftSLOs(parseServiceClasses(context.Background(), map[string]string{
+				"premium.yaml": `name: Premium
+priority: 1
+data:
+  - model: model-a
+    slo-ttft: 200
+`,
+				"freemium.yaml": `name: Freemium
+priority: 10
+data:
+  - model: model-a
+    slo-ttft: 1000
+  - model: model-b
+    slo-ttft: 1500
+  - model: model-c
+    slo-tpot: 50
+`,
+				"broken.yaml": `{`,
+			}))
+
+			Expect(slos).To(HaveLen(2))
+			Expect(slos["model-a"]).To(Equal(200.0))
+			Expect(slos["model-b"]).To(Equal(1500.0))
+		})
+
+		It("should keep the strictest ITL SLO of each model", func() {
+			slos := itlSLOs(parseServiceClasses(context.Background(), map[string]string{
+				"premium.yaml": `name: Premium
+priority: 1
+data:
+  - model: model-a
+    slo-tpot: 24
+    slo-ttft: 200
+`,
+				"freemium.yaml": `name: Freemium
+priority: 10
+data:
+  - model: model-a
+    slo-tpot: 150
+  - model: model-b
+    slo-ttft: 1500
+`,
+			}))
+
+			Expect(slos).To(HaveLen(1))
+			Expect(slos["model-a"]).To(Equal(24.0))
+		})
+	})
+
+	Context("latencyBudget", func() {
+		var va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+
+		BeforeEach(func() {
+			va = &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Name: "va", Namespace: "ns"},
+				Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+					AcceleratorPreferences: []llmdVariantAutoscalingV1alpha1.AcceleratorPreference{{
+						Accelerator: "H100",
+						Profile: llmdVariantAutoscalingV1alpha1.VariantProfile{
+							MaxBatchSize: 64, AtTokens: 512, Alpha: "10", Beta: "0.1", Gamma: "0",
+						},
+					}},
+				},
+			}
+		})
+
+		replica := func(queueLength int, ttft float64) interfaces.ReplicaMetrics {
+			return interfaces.ReplicaMetrics{
+				VariantName: "va", Namespace: "ns", AcceleratorName: "H100",
+				QueueLength: queueLength, AvgTTFT: ttft, RunningRequests: 4,
+				AvgInputTokens: 100, AvgOutputTokens: 10,
+			}
+		}
+
+		It("should attribute a long queue to queueing", func() {
+			budget := latencyBudget(va, []interfaces.ReplicaMetrics{replica(8, 0.5)}, 300)
+
+			Expect(budget).NotTo(BeNil())
+			Expect(budget.TargetTTFT).To(Equal(300.0))
+			Expect(budget.ObservedTTFT).To(BeNumerically("~", 500, 1e-6))
+			// iteration = 10 + 4*0.1*10 = 14; prefill = 14 + 0.1*100 = 24; decode = 14 + 0.1 = 14.1
+			Expect(budget.PrefillTime).To(BeNumerically("~", 24, 1e-3))
+			// queueing = 8 * (24 + 10*14.1) / 4
+			Expect(budget.QueueingTime).To(BeNumerically("~", 330, 1e-3))
+			Expect(budget.QueueingTime).To(BeNumerically(">", budget.PrefillTime))
+		})
+
+		It("should attribute an empty queue to compute", func() {
+			budget := latencyBudget(va, []interfaces.ReplicaMetrics{replica(0, 0.03)}, 300)
+
+			Expect(budget).NotTo(BeNil())
+			Expect(budget.QueueingTime).To(BeZero())
+			Expect(budget.PrefillTime).To(BeNumerically(">", 0))
+		})
+
+		It("should average over the replicas reporting their TTFT", func() {
+			budget := latencyBudget(va, []interfaces.ReplicaMetrics{replica(8, 0.5), replica(0, 0.1), replica(4, 0)}, 300)
+
+			Expect(budget).NotTo(BeNil())
+			Expect(budget.ObservedTTFT).To(BeNumerically("~", 300, 1e-6))
+			Expect(budget.QueueingTime).To(BeNumerically("~", 165, 1e-3))
+		})
+
+		It("should return nil without TTFT metrics", func() {
+			Expect(latencyBudget(va, []interfaces.ReplicaMetrics{replica(8, 0)}, 300)).To(BeNil())
+		})
+
+		It("should return nil without a profile of the accelerator", func() {
+			va.Spec.AcceleratorPreferences[0].Accelerator = "A100"
+			Expect(latencyBudget(va, []interfaces.ReplicaMetrics{replica(8, 0.5)}, 300)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// trackedModel is a model the engine keeps state for, with when it and each of its VAs
+// were last referenced.
+type trackedModel struct {
+	modelID        string
+	namespace      string
+	lastReferenced time.Time
+	// variants are when the VAs of the model were last referenced, keyed by VA name
+	variants map[string]time.Time
+}
+
+// collectModelState records the models referenced by the VAs, and forgets the state kept
+// for the models and VAs unreferenced for longer than the model state retention: the
+// scale-to-zero request rates, learned capacity, dry-run history and saturation heatmap
+// of the models, and the decisions, saturation, scale latencies, runtime max-num-seqs and
+// the state of the decision pipeline stages of the VAs. Without it, controllers serving churny model
+// fleets would keep the state of every model they ever saw. VAs scaled to zero still
+// reference their model, whose state they scale from zero with.
+func (e *Engine) collectModelState(ctx context.Context) {
+	vas, err := utils.ReadyVariantAutoscaling(ctx, e.client)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Unable to list VariantAutoscalings, not collecting the state of unreferenced models")
+		return
+	}
+	e.trackModels(ctx, vas, time.Now())
+}
+
+// trackModels records that the VAs reference their model at now, and forgets the state of
+// the models and VAs last referenced before the retention.
+func (e *Engine) trackModels(ctx context.Context, vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling, now time.Time) {
+	if e.trackedModels == nil {
+		e.trackedModels = make(map[string]*trackedModel)
+	}
+	for i := range vas {
+		va := &vas[i]
+		key := utils.GetNamespacedKey(va.Namespace, va.Spec.ModelID)
+		model, ok := e.trackedModels[key]
+		if !ok {
+			model = &trackedModel{modelID: va.Spec.ModelID, namespace: va.Namespace, variants: make(map[string]time.Time)}
+			e.trackedModels[key] = model
+		}
+		model.lastReferenced = now
+		model.variants[va.Name] = now
+	}
+
+	if retention := e.Config.ModelStateRetention(); retention > 0 {
+		cutoff := now.Add(-retention)
+		var forgotten []*trackedModel
+		for key, model := range e.trackedModels {
+			for name, lastReferenced := range model.variants {
+				if lastReferenced.Before(cutoff) {
+					e.forgetVariantState(name, model.namespace)
+					delete(model.variants, name)
+				}
+			}
+			if model.lastReferenced.Before(cutoff) {
+				forgotten = append(forgotten, model)
+				delete(e.trackedModels, key)
+			}
+		}
+		for _, model := range forgotten {
+			e.forgetModelState(ctx, model)
+		}
+	}
+
+	if err := metrics.NewMetricsEmitter().EmitTrackedModelsMetrics(ctx, len(e.trackedModels)); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit tracked models metric",
+			"error", err.Error())
+	}
+}
+
+// forgetModelState forgets the state kept for a model no longer referenced by a VA. The
+// k2 history of the V2 analyzer is not per namespace, so it is only forgotten when the
+// model is no longer referenced in any namespace.
+func (e *Engine) forgetModelState(ctx context.Context, model *trackedModel) {
+	if e.ScaleToZeroEnforcer != nil {
+		e.ScaleToZeroEnforcer.ForgetModel(model.modelID, model.namespace)
+	}
+	if e.dryRunHistory != nil {
+		e.dryRunHistory.ForgetModel(model.modelID, model.namespace)
+	}
+	if e.saturationHeatmap != nil {
+		e.saturationHeatmap.ForgetModel(model.modelID, model.namespace)
+	}
+	capacityRecords := 0
+	if e.capacityStore != nil {
+		capacityRecords = e.capacityStore.ForgetModel(model.namespace, model.modelID)
+	}
+	historyEntries := 0
+	if e.saturationV2Analyzer != nil && !e.isModelTracked(model.modelID) {
+		historyEntries = e.saturationV2Analyzer.ForgetModel(model.modelID)
+	}
+	ctrl.LoggerFrom(ctx).Info("Forgot the state of a model no longer referenced by a VariantAutoscaling",
+		"modelID", model.modelID,
+		"namespace", model.namespace,
+		"lastReferenced", model.lastReferenced,
+		"capacityRecords", capacityRecords,
+		"historyEntries", historyEntries)
+}
+
+// forgetVariantState forgets the state kept for a VA no longer active, including the state
+// the stages of the decision pipeline keep across runs.
+func (e *Engine) forgetVariantState(name, namespace string) {
+	common.DecisionCache.Delete(name, namespace)
+	common.SaturationCache.Delete(name, namespace)
+	common.ScaleLatency.Delete(name, namespace)
+	delete(e.reportedDecisions, utils.GetNamespacedKey(namespace, name))
+	if e.dampener != nil {
+		e.dampener.Forget(namespace, name)
+	}
+	if e.scaleDownQuorum != nil {
+		e.scaleDownQuorum.Forget(namespace, name)
+	}
+	if e.behavior != nil {
+		e.behavior.Forget(namespace, name)
+	}
+	if e.rollout != nil {
+		e.rollout.Forget(namespace, name)
+	}
+	if e.verifier != nil {
+		e.verifier.Forget(namespace, name)
+	}
+	if e.concurrencyTuner != nil {
+		e.concurrencyTuner.Forget(utils.GetNamespacedKey(namespace, name))
+	}
+}
+
+// isModelTracked returns whether the engine keeps state for a model in any namespace.
+func (e *Engine) isModelTracked(modelID string) bool {
+	for _, model := range e.trackedModels {
+		if model.modelID == modelID {
+			return true
+		}
+	}
+	return false
+}
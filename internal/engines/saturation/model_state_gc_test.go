@@ -0,0 +1,113 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/dryrun"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/heatmap"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("Model state garbage collection", func() {
+	var (
+		ctx    context.Context
+		engine *Engine
+		start  time.Time
+	)
+
+	va := func(name, namespace, modelID string) llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+		return llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{ModelID: modelID},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		store := saturation_v2.NewCapacityKnowledgeStore()
+		engine = &Engine{
+			Config:               config.NewTestConfig(),
+			capacityStore:        store,
+			saturationV2Analyzer: saturation_v2.NewSaturationAnalyzer(store),
+		}
+		start = time.Now()
+	})
+
+	It("should forget the state of models unreferenced for longer than the retention", func() {
+		llama := va("gc-llama-h100", "gc-ns", "meta/llama")
+		granite := va("gc-granite-h100", "gc-ns", "ibm/granite")
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{llama, granite}, start)
+		Expect(engine.trackedModels).To(HaveLen(2))
+
+		engine.capacityStore.Update("gc-ns", "meta/llama", "gc-llama-h100", saturation_v2.CapacityRecord{LearnedFrom: "live"})
+		engine.capacityStore.Update("gc-ns", "ibm/granite", "gc-granite-h100", saturation_v2.CapacityRecord{LearnedFrom: "live"})
+		common.DecisionCache.Set("gc-llama-h100", "gc-ns", interfaces.VariantDecision{TargetReplicas: 2})
+		common.SaturationCache.Set("gc-llama-h100", "gc-ns", 0.5)
+
+		By("keeping the state of the llama model while within the retention")
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{granite}, start.Add(30*time.Minute))
+		Expect(engine.trackedModels).To(HaveLen(2))
+		Expect(engine.capacityStore.Get("gc-ns", "meta/llama", "gc-llama-h100")).NotTo(BeNil())
+
+		By("forgetting it once unreferenced for longer")
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{granite}, start.Add(2*time.Hour))
+		Expect(engine.trackedModels).To(HaveLen(1))
+		Expect(engine.trackedModels).To(HaveKey("gc-ns/ibm/granite"))
+		Expect(engine.capacityStore.Get("gc-ns", "meta/llama", "gc-llama-h100")).To(BeNil())
+		Expect(engine.capacityStore.Get("gc-ns", "ibm/granite", "gc-granite-h100")).NotTo(BeNil())
+		_, ok := common.DecisionCache.Get("gc-llama-h100", "gc-ns")
+		Expect(ok).To(BeFalse())
+		_, ok = common.SaturationCache.Get("gc-llama-h100", "gc-ns")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should forget the state of deleted VAs of models still referenced", func() {
+		a := va("gc-llama-a", "gc-ns", "meta/llama")
+		b := va("gc-llama-b", "gc-ns", "meta/llama")
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{a, b}, start)
+		common.DecisionCache.Set("gc-llama-b", "gc-ns", interfaces.VariantDecision{TargetReplicas: 2})
+
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{a}, start.Add(2*time.Hour))
+		Expect(engine.trackedModels["gc-ns/meta/llama"].variants).To(HaveLen(1))
+		_, ok := common.DecisionCache.Get("gc-llama-b", "gc-ns")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should forget the state of the decision pipeline stages of deleted VAs", func() {
+		engine.dampener = pipeline.NewChangeDampener(3, 0)
+		engine.scaleDownQuorum = pipeline.NewScaleDownQuorum(3, 5)
+		engine.dryRunHistory = dryrun.NewHistory(24 * time.Hour)
+		engine.saturationHeatmap = heatmap.NewMatrix(24 * time.Hour)
+
+		a := va("gc-llama-a", "gc-ns", "meta/llama")
+		b := va("gc-granite-b", "gc-ns", "ibm/granite")
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{a, b}, start)
+		scaleDown := func() []interfaces.VariantDecision {
+			return []interfaces.VariantDecision{{VariantName: "gc-granite-b", Namespace: "gc-ns", CurrentReplicas: 4, TargetReplicas: 2}}
+		}
+		engine.dampener.Dampen(ctx, scaleDown())
+		engine.dampener.Dampen(ctx, []interfaces.VariantDecision{{VariantName: "gc-granite-b", Namespace: "gc-ns", CurrentReplicas: 4, TargetReplicas: 6}})
+		Expect(engine.dampener.FlapCount("gc-ns", "gc-granite-b")).To(Equal(1))
+		engine.scaleDownQuorum.Apply(ctx, scaleDown())
+		engine.dryRunHistory.Record("ibm/granite", "gc-ns", nil, nil)
+		engine.saturationHeatmap.Record("ibm/granite", "gc-ns", nil)
+
+		engine.trackModels(ctx, []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{a}, start.Add(2*time.Hour))
+		Expect(engine.dampener.FlapCount("gc-ns", "gc-granite-b")).To(BeZero())
+		decisions := scaleDown()
+		engine.scaleDownQuorum.Apply(ctx, decisions)
+		Expect(decisions[0].ScaleDownQuorum.Approvals).To(Equal(1))
+		Expect(engine.dryRunHistory.Since(time.Time{})).To(BeEmpty())
+		Expect(engine.saturationHeatmap.Since(time.Time{}, heatmap.MetricKvCacheUsage)).To(BeEmpty())
+	})
+})
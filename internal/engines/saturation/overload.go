@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/overload"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// SetOverloadSignaler makes the engine detect overloaded models after every optimization
+// cycle, and signal their overload to the gateway on their InferencePools with signaler.
+func (e *Engine) SetOverloadSignaler(signaler *overload.Signaler) {
+	e.overloadSignaler = signaler
+}
+
+// signalOverload emits whether the models of the decisions are overloaded, and annotates
+// the InferencePools serving them. Failures are logged: the signal is advisory and never
+// fails the optimization loop.
+func (e *Engine) signalOverload(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if e.overloadSignaler == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+
+	emitted := make(map[string]bool)
+	variants := make([]overload.Variant, 0, len(decisions))
+	for _, d := range decisions {
+		if d.ModelID != "" && !emitted[d.Namespace+"/"+d.ModelID] {
+			emitted[d.Namespace+"/"+d.ModelID] = true
+			if err := emitter.EmitModelOverloadedMetrics(ctx, d.ModelID, d.Namespace, d.ModelOverloaded); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to emit model overloaded metrics",
+					"modelID", d.ModelID,
+					"namespace", d.Namespace,
+					"error", err.Error())
+			}
+		}
+
+		va, ok := vaMap[utils.GetNamespacedKey(d.Namespace, d.VariantName)]
+		if !ok || !targetsDeployment(va) {
+			continue
+		}
+		var deploy appsv1.Deployment
+		if err := e.client.Get(ctx, client.ObjectKey{Namespace: va.Namespace, Name: va.GetScaleTargetName()}, &deploy); err != nil {
+			logger.V(logging.DEBUG).Info("Could not get deployment for overload signal",
+				"variant", va.Name,
+				"error", err.Error())
+			continue
+		}
+		variants = append(variants, overload.Variant{
+			Namespace:  d.Namespace,
+			PodLabels:  deploy.Spec.Template.Labels,
+			Overloaded: d.ModelOverloaded,
+		})
+	}
+
+	if _, err := e.overloadSignaler.Signal(ctx, variants); err != nil {
+		logger.Error(err, "Failed to signal the overload of models to the gateway")
+	}
+}
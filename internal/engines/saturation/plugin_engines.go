@@ -0,0 +1,185 @@
+package saturation
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// partitionByEngine splits VAs between the saturation engine and the custom engines
+// selected by their spec.engine or spec.engineComposition, returned keyed by VA.
+// VAs selecting an engine that is not registered are logged and analyzed by the
+// saturation engine.
+func partitionByEngine(
+	ctx context.Context,
+	vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) (saturationVAs, pluginVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling, selected map[string]engines.Engine) {
+	logger := ctrl.LoggerFrom(ctx)
+	selected = make(map[string]engines.Engine)
+
+	for i := range vas {
+		va := &vas[i]
+		engine, err := engines.ForVariant(va)
+		if err != nil {
+			logger.Info("Scaling engine not available, using the saturation engine",
+				"variant", va.Name,
+				"namespace", va.Namespace,
+				"registered", engines.Names(),
+				"error", err.Error())
+		}
+		if engine == nil {
+			saturationVAs = append(saturationVAs, *va)
+			continue
+		}
+		pluginVAs = append(pluginVAs, *va)
+		selected[utils.GetNamespacedKey(va.Namespace, va.Name)] = engine
+	}
+	return saturationVAs, pluginVAs, selected
+}
+
+// optimizePlugins asks the custom engine of each VA for its decision.
+// Metrics are collected per model as for the saturation engine, and the decisions go
+// through the same pipeline stages, except the GPU limiter and scale-to-zero enforcement.
+func (e *Engine) optimizePlugins(
+	ctx context.Context,
+	pluginVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	selected map[string]engines.Engine,
+) []interfaces.VariantDecision {
+	if len(pluginVAs) == 0 {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	var tenantLabel string
+	if cfg, ok := e.Config.SaturationConfig()["default"]; ok {
+		tenantLabel = cfg.GetTenantLabel()
+	}
+
+	var allDecisions []interfaces.VariantDecision
+	for groupKey, modelVAs := range utils.GroupVariantAutoscalingByModel(pluginVAs) {
+		modelID := modelVAs[0].Spec.ModelID
+		data, err := e.prepareModelData(ctx, modelID, modelVAs, e.client)
+		if err != nil {
+			logger.Error(err, "Failed to prepare model data for custom engines", "groupKey", groupKey)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+
+		stateMap := make(map[string]interfaces.VariantReplicaState, len(data.variantStates))
+		for _, state := range data.variantStates {
+			stateMap[state.VariantName] = state
+		}
+
+		var decisions []interfaces.VariantDecision
+		for i := range modelVAs {
+			va := &modelVAs[i]
+			state, ok := stateMap[va.Name]
+			if !ok {
+				continue
+			}
+			variantKey := utils.GetNamespacedKey(va.Namespace, va.Name)
+			engine := selected[variantKey]
+
+			var metrics []interfaces.ReplicaMetrics
+			for _, m := range data.replicaMetrics {
+				if m.VariantName == va.Name {
+					metrics = append(metrics, m)
+				}
+			}
+
+			decision, err := engine.AnalyzeAndRecommend(ctx, va, metrics)
+			if err != nil {
+				logger.Error(err, "Custom engine analysis failed",
+					"engine", engine.Name(),
+					"variant", va.Name,
+					"namespace", va.Namespace)
+				continue
+			}
+			if decision == nil {
+				logger.V(logging.DEBUG).Info("Custom engine made no decision",
+					"engine", engine.Name(),
+					"variant", va.Name,
+					"namespace", va.Namespace)
+				continue
+			}
+
+			decisions = append(decisions, completePluginDecision(*decision, engine.Name(), va, state, data.variantCosts[variantKey]))
+		}
+
+		setDecisionTenants(decisions, modelVAs, tenantLabel)
+		logger.Info("Custom engine decisions made for model",
+			"modelID", modelID,
+			"decisionCount", len(decisions))
+		allDecisions = append(allDecisions, decisions...)
+	}
+	return allDecisions
+}
+
+// completePluginDecision builds the decision of the pipeline from a custom engine's
+// recommendation, keeping its target and reason, with the identity and current state of
+// the variant.
+func completePluginDecision(
+	recommendation engines.VariantDecision,
+	engineName string,
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	state interfaces.VariantReplicaState,
+	cost float64,
+) interfaces.VariantDecision {
+	targetReplicas := max(recommendation.TargetReplicas, 0)
+
+	var action interfaces.SaturationAction
+	if targetReplicas > state.CurrentReplicas {
+		action = interfaces.ActionScaleUp
+	} else if targetReplicas < state.CurrentReplicas {
+		action = interfaces.ActionScaleDown
+	} else {
+		action = interfaces.ActionNoChange
+	}
+
+	gpusPerReplica := state.GPUsPerReplica
+	if gpusPerReplica <= 0 {
+		gpusPerReplica = 1 // Fallback default
+	}
+
+	reason := recommendation.Reason
+	if reason == "" {
+		reason = "engine " + engineName + ": " + string(action)
+	}
+
+	return interfaces.VariantDecision{
+		VariantName:            va.Name,
+		Namespace:              va.Namespace,
+		ModelID:                va.Spec.ModelID,
+		AcceleratorName:        va.Labels[utils.AcceleratorNameLabel],
+		Cost:                   cost,
+		Action:                 action,
+		CurrentReplicas:        state.CurrentReplicas,
+		TargetReplicas:         targetReplicas,
+		OriginalTargetReplicas: targetReplicas,
+		DesiredReplicas:        state.DesiredReplicas,
+		ReadyReplicas:          state.CurrentReplicas - state.PendingReplicas,
+		ReportingReplicas:      state.ReportingReplicas,
+		UnschedulableReplicas:  state.UnschedulableReplicas,
+		DrainingReplicas:       state.DrainingReplicas,
+		PDBMinReplicas:         state.PDBMinReplicas,
+		PDBName:                state.PDBName,
+		HPAName:                state.HPAName,
+		HPAMinReplicas:         state.HPAMinReplicas,
+		HPAMaxReplicas:         state.HPAMaxReplicas,
+		Zones:                  state.Zones,
+		MinReplicas:            state.MinReplicas,
+		Behavior:               state.Behavior,
+		ErrorRate:              state.ErrorRate,
+		GPUsPerReplica:         gpusPerReplica,
+		EngineOutputs:          recommendation.EngineOutputs,
+		Reason:                 reason,
+	}
+}
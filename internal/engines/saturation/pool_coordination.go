@@ -0,0 +1,33 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+)
+
+// SetPoolCoordinator sets the coordinator of the GPU pools shared with other controller
+// instances on the GPU limiters of the engine, so that in limited mode the GPUs held by
+// the other instances are not allocated. Injected limiters other than DefaultLimiter are
+// left as they are.
+func (e *Engine) SetPoolCoordinator(coordinator pipeline.PoolCoordinator) {
+	for _, limiter := range []pipeline.Limiter{e.GPULimiter, e.FairGPULimiter} {
+		if l, ok := limiter.(*pipeline.DefaultLimiter); ok {
+			l.SetCoordinator(coordinator)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+// recordSignalTimes records when the newest replica metrics of a model were sampled, for
+// each of its VAs keyed by namespace/name: the time of the signal its decisions act on.
+// Saturation is analyzed per model, so the replicas of any variant may call for a scaling.
+func (e *Engine) recordSignalTimes(data *modelData) {
+	if e.signalTimes == nil {
+		return
+	}
+	var newest time.Time
+	for _, rm := range data.replicaMetrics {
+		if rm.Metadata != nil && rm.Metadata.CollectedAt.After(newest) {
+			newest = rm.Metadata.CollectedAt
+		}
+	}
+	for key := range data.variantAutoscalings {
+		e.signalTimes[key] = newest
+	}
+}
+
+// trackScaleLatency records that the desired replicas metric of the variant of a decision
+// was updated at updatedAt to target replicas, and observes the scale latencies of the
+// scalings it starts or completes.
+func (e *Engine) trackScaleLatency(ctx context.Context, key string, d interfaces.VariantDecision, target int, updatedAt time.Time) {
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+
+	// The Engine sees the replicas of the Deployment once per run: a scaling in flight may
+	// have completed since the last one, unless the Controller saw it first
+	if scaling, ok := common.ScaleLatency.Replicas(d.VariantName, d.Namespace, d.CurrentReplicas, updatedAt); ok {
+		if err := emitter.EmitScaleActuationLatencyMetrics(ctx, d.VariantName, d.Namespace,
+			strings.ToLower(string(scaling.Direction)), scaling.ReplicasLatency.Duration); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit scale actuation latency metrics",
+				"variant", d.VariantName,
+				"namespace", d.Namespace,
+				"error", err.Error())
+		}
+	}
+
+	scaling, ok := common.ScaleLatency.Desired(d.VariantName, d.Namespace, d.CurrentReplicas, target, e.signalTimes[key], updatedAt)
+	if !ok {
+		return
+	}
+	if err := emitter.EmitScaleDecisionLatencyMetrics(ctx, d.VariantName, d.Namespace,
+		strings.ToLower(string(scaling.Direction)), scaling.DesiredMetricLatency.Duration); err != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit scale decision latency metrics",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"error", err.Error())
+	}
+}
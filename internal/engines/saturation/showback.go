@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/showback"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// SetShowback makes the engine account the cost and replica-hours of the variants in the
+// tracker after every optimization cycle, and write the reports of completed periods with
+// the publisher, if not nil.
+func (e *Engine) SetShowback(tracker *showback.Tracker, publisher *showback.Publisher) {
+	e.showbackTracker = tracker
+	e.showbackPublisher = publisher
+}
+
+// recordShowback records the current replicas and cost per replica of the variants of the
+// decisions. Failures to publish a completed report are logged: showback is informational
+// and never fails the optimization loop.
+func (e *Engine) recordShowback(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	if e.showbackTracker == nil {
+		return
+	}
+
+	samples := make([]showback.Sample, 0, len(decisions))
+	for _, d := range decisions {
+		sample := showback.Sample{
+			Namespace: d.Namespace,
+			Name:      d.VariantName,
+			Replicas:  d.CurrentReplicas,
+			Cost:      d.Cost,
+		}
+		if va, ok := vaMap[utils.GetNamespacedKey(d.Namespace, d.VariantName)]; ok {
+			sample.Labels = va.Labels
+		}
+		samples = append(samples, sample)
+	}
+
+	completed := e.showbackTracker.Record(time.Now(), samples)
+	if completed == nil || e.showbackPublisher == nil {
+		return
+	}
+	if err := e.showbackPublisher.Publish(ctx, completed); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to publish showback report")
+	}
+}
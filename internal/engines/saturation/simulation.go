@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// SimulationStepName is the decision step of scale-downs held by the simulation of their target.
+const SimulationStepName = "simulation"
+
+// variantLoad is the load served by the replicas of a variant, with the queueing model of
+// a replica on its accelerator, to simulate other numbers of replicas.
+type variantLoad struct {
+	config  analyzer.Configuration
+	request analyzer.RequestSize
+	// arrivalRate is the request rate served by all replicas of the variant (requests/sec)
+	arrivalRate float32
+}
+
+// recordVariantLoads records the loads of the variants of a model, keyed by VA
+// namespace/name, for simulateDecisions to simulate their targets.
+func (e *Engine) recordVariantLoads(data *modelData) {
+	if e.variantLoads == nil {
+		return
+	}
+	replicasByVariant := groupReplicasByVariant(data.replicaMetrics)
+	for key, va := range data.variantAutoscalings {
+		if load := newVariantLoad(va, replicasByVariant[key]); load != nil {
+			e.variantLoads[key] = load
+		}
+	}
+}
+
+// newVariantLoad estimates the load served by the replicas of a variant with the profile
+// of its accelerator. By Little's law, a replica running b requests, each for a service
+// time of
+//
+//	serviceTime = PrefillTime(b) + outputTokens * DecodeTime(b)
+//
+// completes b / serviceTime requests per msec. The batch size of a replica is the
+// maxBatchSize of the profile scaled from atTokens to the average output tokens, as in the
+// optimizer of pkg/core. Returns nil when the accelerator has no profile or no replica
+// reports its request size.
+func newVariantLoad(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	replicas []interfaces.ReplicaMetrics,
+) *variantLoad {
+	var load variantLoad
+	var profile *llmdVariantAutoscalingV1alpha1.VariantProfile
+	var parms *analyzer.ServiceParms
+	reporting := 0
+	for _, rm := range replicas {
+		if rm.AvgOutputTokens < 1 {
+			continue
+		}
+		if parms == nil {
+			profile = acceleratorProfile(va, rm.AcceleratorName)
+			if parms = profileServiceParms(va, rm.AcceleratorName); parms == nil {
+				return nil
+			}
+		}
+		request := &analyzer.RequestSize{
+			AvgInputTokens:  float32(rm.AvgInputTokens),
+			AvgOutputTokens: float32(rm.AvgOutputTokens),
+		}
+		batch := float32(max(rm.RunningRequests, 1))
+		serviceTime := parms.PrefillTime(request, batch) + request.AvgOutputTokens*parms.DecodeTime(request, batch)
+		if serviceTime > 0 {
+			load.arrivalRate += float32(rm.RunningRequests) / serviceTime * 1000 // per msec to per sec
+		}
+		load.request.AvgInputTokens += request.AvgInputTokens
+		load.request.AvgOutputTokens += request.AvgOutputTokens
+		reporting++
+	}
+	if reporting == 0 {
+		return nil
+	}
+	load.request.AvgInputTokens /= float32(reporting)
+	load.request.AvgOutputTokens /= float32(reporting)
+
+	maxBatchSize := max(profile.MaxBatchSize*profile.AtTokens/max(int(load.request.AvgOutputTokens), 1), 1)
+	load.config = analyzer.Configuration{
+		MaxBatchSize: maxBatchSize,
+		MaxQueueSize: maxBatchSize * infernoConfig.MaxQueueToBatchRatio,
+		ServiceParms: parms,
+	}
+	return &load
+}
+
+// predict returns the performance the queueing model predicts for replicas of the variant
+// sharing its load evenly, with its violation of the TTFT and ITL SLOs (msec, 0 for none),
+// or nil if the model cannot be solved.
+func (l *variantLoad) predict(replicas int, targetTTFT, targetITL float64) *interfaces.Prediction {
+	config, request := l.config, l.request
+	qa, err := analyzer.NewQueueAnalyzer(&config, &request)
+	if err != nil {
+		return nil
+	}
+	prediction := &interfaces.Prediction{Replicas: replicas, ArrivalRate: float64(l.arrivalRate)}
+	rate := l.arrivalRate / float32(replicas)
+	if rate > qa.RateRange.Max {
+		prediction.Overloaded = true
+		prediction.Utilization = 1
+		return prediction
+	}
+	// idle replicas are modeled at the lowest rate the model is stable at
+	metrics, err := qa.Analyze(max(rate, qa.RateRange.Min))
+	if err != nil {
+		return nil
+	}
+	prediction.TTFT = float64(metrics.AvgWaitTime + metrics.AvgPrefillTime)
+	prediction.ITL = float64(metrics.AvgTokenTime)
+	prediction.Utilization = float64(metrics.Rho)
+	if targetTTFT > 0 {
+		prediction.SLOViolation = max(prediction.SLOViolation, prediction.TTFT/targetTTFT-1)
+	}
+	if targetITL > 0 {
+		prediction.SLOViolation = max(prediction.SLOViolation, prediction.ITL/targetITL-1)
+	}
+	return prediction
+}
+
+// violatedSLO describes the SLO a prediction violates, or returns "" when it meets them.
+// A target of 0 is no SLO.
+func violatedSLO(p *interfaces.Prediction, targetTTFT, targetITL float64) string {
+	switch {
+	case p.Overloaded:
+		return fmt.Sprintf("%d replicas predicted overloaded at %.2f requests/sec", p.Replicas, p.ArrivalRate)
+	case targetTTFT > 0 && p.TTFT > targetTTFT:
+		return fmt.Sprintf("predicted TTFT %.2fms of %d replicas exceeds the SLO of %.0fms", p.TTFT, p.Replicas, targetTTFT)
+	case targetITL > 0 && p.ITL > targetITL:
+		return fmt.Sprintf("predicted ITL %.2fms of %d replicas exceeds the SLO of %.0fms", p.ITL, p.Replicas, targetITL)
+	}
+	return ""
+}
+
+// simulateDecisions predicts with the queueing model the performance of the targets of the
+// decisions that change replicas by more than one, at the load their replicas serve, and
+// attaches it to the decisions. Scale-downs predicted to overload the remaining replicas or
+// to violate the TTFT or ITL SLO of their model are held at the current replicas, with the
+// prediction of the current replicas: saturation thresholds tuned for one load can remove
+// more replicas than the rest absorb at another. Scale-ups are not held, and neither are
+// scale-downs capped at the replica limit of their model. Returns the variants whose
+// scale-down was held.
+func (e *Engine) simulateDecisions(ctx context.Context, decisions []interfaces.VariantDecision) []string {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var held []string
+	for i := range decisions {
+		d := &decisions[i]
+		if d.Error != nil || d.TargetReplicas <= 0 || max(d.TargetReplicas-d.CurrentReplicas, d.CurrentReplicas-d.TargetReplicas) <= 1 {
+			continue
+		}
+		key := utils.GetNamespacedKey(d.Namespace, d.VariantName)
+		load, ok := e.variantLoads[key]
+		if !ok {
+			continue
+		}
+		targetTTFT, targetITL := e.ttftSLOs[d.ModelID], e.itlSLOs[d.ModelID]
+		d.Prediction = load.predict(d.TargetReplicas, targetTTFT, targetITL)
+		if d.Prediction == nil || d.TargetReplicas > d.CurrentReplicas || d.PolicyCapped {
+			continue
+		}
+		violation := violatedSLO(d.Prediction, targetTTFT, targetITL)
+		if violation == "" {
+			continue
+		}
+
+		proposed := d.TargetReplicas
+		d.TargetReplicas = d.CurrentReplicas
+		d.Action = interfaces.ActionNoChange
+		d.Prediction = load.predict(d.CurrentReplicas, targetTTFT, targetITL)
+		d.Reason = fmt.Sprintf("scale-down to %d replicas held: %s", proposed, violation)
+		d.AddDecisionStep(SimulationStepName, d.Reason, true)
+		held = append(held, key)
+
+		logger.Info("Scale-down held by simulation",
+			"variant", d.VariantName,
+			"namespace", d.Namespace,
+			"proposed", proposed,
+			"target", d.TargetReplicas,
+			"violation", violation)
+	}
+	return held
+}
@@ -0,0 +1,190 @@
+package saturation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("Simulation", func() {
+	var va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+
+	BeforeEach(func() {
+		va = &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "va", Namespace: "ns"},
+			Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+				AcceleratorPreferences: []llmdVariantAutoscalingV1alpha1.AcceleratorPreference{{
+					Accelerator: "H100",
+					Profile: llmdVariantAutoscalingV1alpha1.VariantProfile{
+						MaxBatchSize: 8, AtTokens: 10, Alpha: "10", Beta: "0.1", Gamma: "0",
+					},
+				}},
+			},
+		}
+	})
+
+	// replicas returns n replicas each running 4 requests of 100 input and 10 output tokens:
+	// iteration = 10 + 4*0.1*10 = 14; prefill = 14 + 0.1*100 = 24; decode = 14 + 0.1 = 14.1
+	replicas := func(n int) []interfaces.ReplicaMetrics {
+		rms := make([]interfaces.ReplicaMetrics, n)
+		for i := range rms {
+			rms[i] = interfaces.ReplicaMetrics{
+				VariantName: "va", Namespace: "ns", AcceleratorName: "H100",
+				RunningRequests: 4, AvgInputTokens: 100, AvgOutputTokens: 10,
+			}
+		}
+		return rms
+	}
+
+	Context("newVariantLoad", func() {
+
+		It("should estimate the request rate served by the replicas", func() {
+			load := newVariantLoad(va, replicas(6))
+
+			Expect(load).NotTo(BeNil())
+			// 6 replicas * 4 requests / (24 + 10*14.1) msec
+			Expect(load.arrivalRate).To(BeNumerically("~", 145.45, 0.01))
+			Expect(load.config.MaxBatchSize).To(Equal(8))
+			Expect(load.request.AvgOutputTokens).To(BeNumerically("~", 10, 1e-6))
+		})
+
+		It("should return nil without a profile of the accelerator", func() {
+			va.Spec.AcceleratorPreferences[0].Accelerator = "A100"
+			Expect(newVariantLoad(va, replicas(6))).To(BeNil())
+		})
+
+		It("should return nil when no replica reports its request size", func() {
+			rms := replicas(2)
+			for i := range rms {
+				rms[i].AvgOutputTokens = 0
+			}
+			Expect(newVariantLoad(va, rms)).To(BeNil())
+		})
+	})
+
+	Context("predict", func() {
+
+		It("should predict higher latencies and utilization for fewer replicas", func() {
+			load := newVariantLoad(va, replicas(6))
+
+			fewer, current := load.predict(4, 0, 0), load.predict(6, 0, 0)
+			Expect(fewer).NotTo(BeNil())
+			Expect(current).NotTo(BeNil())
+			Expect(fewer.Replicas).To(Equal(4))
+			Expect(fewer.Overloaded).To(BeFalse())
+			Expect(fewer.TTFT).To(BeNumerically(">", current.TTFT))
+			Expect(fewer.ITL).To(BeNumerically(">", current.ITL))
+			Expect(fewer.Utilization).To(BeNumerically(">", current.Utilization))
+		})
+
+		It("should predict the relative violation of the SLOs", func() {
+			load := newVariantLoad(va, replicas(6))
+			prediction := load.predict(4, 200, 1000)
+
+			Expect(prediction).NotTo(BeNil())
+			Expect(prediction.SLOViolation).To(BeNumerically("~", prediction.TTFT/200-1, 1e-9))
+			Expect(load.predict(4, 1000, prediction.ITL/2).SLOViolation).To(BeNumerically("~", 1, 1e-6))
+			Expect(load.predict(4, 0, 0).SLOViolation).To(BeZero())
+			Expect(load.predict(10, 200, 1000).SLOViolation).To(BeZero())
+		})
+
+		It("should predict replicas that cannot keep up with the load overloaded", func() {
+			prediction := newVariantLoad(va, replicas(6)).predict(2, 0, 0)
+
+			Expect(prediction).NotTo(BeNil())
+			Expect(prediction.Overloaded).To(BeTrue())
+			Expect(prediction.Utilization).To(Equal(1.0))
+			Expect(prediction.TTFT).To(BeZero())
+		})
+	})
+
+	Context("simulateDecisions", func() {
+		var e *Engine
+
+		BeforeEach(func() {
+			e = &Engine{
+				ttftSLOs:     map[string]float64{"model": 200},
+				itlSLOs:      map[string]float64{},
+				variantLoads: map[string]*variantLoad{"ns/va": newVariantLoad(va, replicas(6))},
+			}
+		})
+
+		decision := func(current, target int) interfaces.VariantDecision {
+			action := interfaces.ActionScaleDown
+			if target > current {
+				action = interfaces.ActionScaleUp
+			}
+			return interfaces.VariantDecision{
+				VariantName: "va", Namespace: "ns", ModelID: "model",
+				Action: action, CurrentReplicas: current, TargetReplicas: target,
+			}
+		}
+
+		It("should hold a scale-down predicted to violate the TTFT SLO", func() {
+			decisions := []interfaces.VariantDecision{decision(6, 4)}
+
+			held := e.simulateDecisions(context.Background(), decisions)
+
+			Expect(held).To(ConsistOf("ns/va"))
+			Expect(decisions[0].TargetReplicas).To(Equal(6))
+			Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+			Expect(decisions[0].Reason).To(ContainSubstring("predicted TTFT"))
+			Expect(decisions[0].Prediction).NotTo(BeNil())
+			Expect(decisions[0].Prediction.Replicas).To(Equal(6))
+			Expect(decisions[0].Prediction.SLOViolation).To(BeZero())
+			Expect(decisions[0].LastStep().Name).To(Equal(SimulationStepName))
+		})
+
+		It("should hold a scale-down predicted to overload the remaining replicas", func() {
+			e.ttftSLOs = map[string]float64{}
+			decisions := []interfaces.VariantDecision{decision(6, 2)}
+
+			Expect(e.simulateDecisions(context.Background(), decisions)).To(ConsistOf("ns/va"))
+			Expect(decisions[0].TargetReplicas).To(Equal(6))
+			Expect(decisions[0].Reason).To(ContainSubstring("overloaded"))
+		})
+
+		It("should keep a scale-down predicted to meet the SLOs, with its prediction", func() {
+			e.ttftSLOs["model"] = 1000
+			decisions := []interfaces.VariantDecision{decision(6, 4)}
+
+			Expect(e.simulateDecisions(context.Background(), decisions)).To(BeEmpty())
+			Expect(decisions[0].TargetReplicas).To(Equal(4))
+			Expect(decisions[0].Prediction).NotTo(BeNil())
+			Expect(decisions[0].Prediction.Replicas).To(Equal(4))
+		})
+
+		It("should only predict scale-ups", func() {
+			e.variantLoads["ns/va"] = newVariantLoad(va, replicas(2))
+			decisions := []interfaces.VariantDecision{decision(2, 6)}
+
+			Expect(e.simulateDecisions(context.Background(), decisions)).To(BeEmpty())
+			Expect(decisions[0].TargetReplicas).To(Equal(6))
+			Expect(decisions[0].Prediction).NotTo(BeNil())
+			Expect(decisions[0].Prediction.Replicas).To(Equal(6))
+		})
+
+		It("should not hold a scale-down capped at the replica limit of its model", func() {
+			decisions := []interfaces.VariantDecision{decision(6, 4)}
+			decisions[0].PolicyCapped = true
+
+			Expect(e.simulateDecisions(context.Background(), decisions)).To(BeEmpty())
+			Expect(decisions[0].TargetReplicas).To(Equal(4))
+		})
+
+		It("should not simulate changes of a single replica or variants without a load", func() {
+			decisions := []interfaces.VariantDecision{decision(6, 5), decision(6, 2)}
+			decisions[1].VariantName = "other"
+
+			Expect(e.simulateDecisions(context.Background(), decisions)).To(BeEmpty())
+			Expect(decisions[0].Prediction).To(BeNil())
+			Expect(decisions[1].Prediction).To(BeNil())
+			Expect(decisions[1].TargetReplicas).To(Equal(2))
+		})
+	})
+})
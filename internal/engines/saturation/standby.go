@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// StandbyWarmer keeps the metrics caches of the engine warm on replicas that are not
+// the leader, so that a new leader does not start cold. It only reads: status writes
+// and desired-replica metrics are left to the leader.
+type StandbyWarmer struct {
+	engine  *Engine
+	elected <-chan struct{}
+}
+
+// NewStandbyWarmer creates a warmer of the engine, running until the elected channel
+// is closed, as the manager does once the replica becomes the leader.
+func NewStandbyWarmer(engine *Engine, elected <-chan struct{}) *StandbyWarmer {
+	return &StandbyWarmer{engine: engine, elected: elected}
+}
+
+// NeedLeaderElection returns false: the warmer runs on every replica.
+func (w *StandbyWarmer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start warms the caches every optimization interval until the replica becomes the
+// leader or the context is cancelled.
+func (w *StandbyWarmer) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.elected:
+			logger.Info("Elected leader, stopping standby cache warming")
+			return nil
+		default:
+		}
+
+		if err := w.engine.warm(ctx); err != nil {
+			logger.Error(err, "Failed to warm caches on standby")
+		}
+
+		interval := w.engine.Config.OptimizationInterval()
+		if interval <= 0 {
+			interval = 30 * time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.elected:
+			logger.Info("Elected leader, stopping standby cache warming")
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// warm runs the read-only part of an optimization cycle: the metrics of the active VAs
+// are collected, refreshing the metrics source cache and backfilling the history of
+// new VAs, and analyzed by the V2 analyzer to learn their capacities. No decision is
+// made or applied.
+func (e *Engine) warm(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	activeVAs, err := utils.ActiveVariantAutoscaling(ctx, e.client)
+	if err != nil {
+		return err
+	}
+	saturationVAs, _, _ := partitionByEngine(ctx, activeVAs)
+
+	useV2 := false
+	if cfg, ok := e.Config.SaturationConfig()["default"]; ok {
+		cfg.ApplyDefaults()
+		useV2 = cfg.AnalyzerName == "saturation"
+	}
+
+	warmed := 0
+	for _, modelVAs := range utils.GroupVariantAutoscalingByModel(saturationVAs) {
+		modelID := modelVAs[0].Spec.ModelID
+		namespace := modelVAs[0].Namespace
+
+		data, err := e.prepareModelData(ctx, modelID, modelVAs, e.client)
+		if err != nil {
+			logger.V(logging.DEBUG).Info("Failed to collect metrics on standby", "modelID", modelID, "error", err.Error())
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		warmed++
+
+		if !useV2 {
+			continue
+		}
+		saturationConfig, ok := e.Config.SaturationConfigForNamespace(namespace)["default"]
+		if !ok {
+			continue
+		}
+		saturationConfig.ApplyDefaults()
+		if _, err := e.runV2AnalysisOnly(ctx, modelID, namespace, data.replicaMetrics,
+			saturationConfig, data.variantStates, data.workloads, data.variantAutoscalings); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to analyze metrics on standby", "modelID", modelID, "error", err.Error())
+		}
+	}
+
+	logger.Info("Warmed caches on standby", "models", warmed, "activeVAs", len(activeVAs))
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/snapshot"
+)
+
+// SetSnapshotStore makes the engine restore its state from the store when it starts,
+// and save it to the store after every optimization cycle.
+func (e *Engine) SetSnapshotStore(store *snapshot.Store) {
+	e.snapshotStore = store
+}
+
+// restoreSnapshot restores the state saved by the previous leader, if recent enough:
+// the learned capacities and last saturations are seeded, and the last desired replicas
+// are emitted right away so external autoscalers keep their target until the first
+// optimization cycle completes.
+func (e *Engine) restoreSnapshot(ctx context.Context) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	snap, err := e.snapshotStore.Load(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to load state snapshot, starting cold")
+		return
+	}
+	if snap == nil {
+		logger.Info("No state snapshot to restore, starting cold")
+		return
+	}
+	if age := time.Since(snap.TakenAt); age > e.Config.StateSnapshotMaxAge() {
+		logger.Info("State snapshot too old to restore, starting cold",
+			"age", age, "maxAge", e.Config.StateSnapshotMaxAge())
+		return
+	}
+
+	e.capacityStore.Restore(snap.CapacityRecords())
+
+	act := e.actuator
+	emitted := 0
+	for _, d := range snap.Decisions {
+		if d.Saturation != nil {
+			common.SaturationCache.Set(d.Name, d.Namespace, *d.Saturation)
+		}
+
+		var va llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+		if err := e.client.Get(ctx, client.ObjectKey{Namespace: d.Namespace, Name: d.Name}, &va); err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to get VariantAutoscaling of restored decision",
+					"variant", d.Name, "namespace", d.Namespace)
+			}
+			continue
+		}
+		va.Status.DesiredOptimizedAlloc = llmdVariantAutoscalingV1alpha1.OptimizedAlloc{
+			NumReplicas: d.TargetReplicas,
+			Accelerator: d.Accelerator,
+			LastRunTime: metav1.NewTime(snap.TakenAt),
+		}
+		if err := act.EmitMetrics(ctx, &va); err != nil {
+			logger.Error(err, "Failed to emit metrics of restored decision",
+				"variant", d.Name, "namespace", d.Namespace)
+			continue
+		}
+		emitted++
+	}
+
+	logger.Info("Restored state snapshot",
+		"age", time.Since(snap.TakenAt),
+		"decisions", emitted,
+		"capacities", len(snap.Capacities))
+}
+
+// saveSnapshot saves the last decisions of the optimized VAs and the learned capacities
+// for the next leader. Failures are logged and do not fail the optimization cycle.
+func (e *Engine) saveSnapshot(ctx context.Context, vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling) {
+	snap := &snapshot.Snapshot{
+		TakenAt:    time.Now(),
+		Capacities: snapshot.FromCapacityRecords(e.capacityStore.LiveRecords()),
+	}
+	for _, va := range vaMap {
+		decision, ok := common.DecisionCache.Get(va.Name, va.Namespace)
+		if !ok || decision.AcceleratorName == "" {
+			continue
+		}
+		d := snapshot.Decision{
+			Name:           va.Name,
+			Namespace:      va.Namespace,
+			TargetReplicas: decision.TargetReplicas,
+			Accelerator:    decision.AcceleratorName,
+		}
+		if saturation, ok := common.SaturationCache.Get(va.Name, va.Namespace); ok {
+			d.Saturation = &saturation
+		}
+		snap.Decisions = append(snap.Decisions, d)
+	}
+
+	if err := e.snapshotStore.Save(ctx, snap); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to save state snapshot")
+	}
+}
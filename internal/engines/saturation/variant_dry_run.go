@@ -0,0 +1,29 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package saturation
+
+import (
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// isDryRunVariant returns whether the VA is in dry-run. The decisions of a VA in dry-run
+// are still computed and published in its status, but not emitted to its external
+// autoscaler, which keeps the last desired replicas it read.
+func isDryRunVariant(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) bool {
+	return va.Annotations[constants.DryRunAnnotationKey] == "true"
+}
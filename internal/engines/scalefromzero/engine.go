@@ -49,7 +49,7 @@ import (
 
 // Constants for condition
 const (
-	MetricsReasonAvailable            = "ScaleFromZero"
+	MetricsReasonAvailable            = wvav1alpha1.ReasonMetricsFound
 	MetricsMessageAvailable           = "Scaled from zero due to pending requests"
 	reason                            = "scalefromzero mode: pending request - scale-up"
 	targetEPPMetricName               = "inference_extension_flow_control_queue_size"
@@ -268,6 +268,21 @@ func (e *Engine) processInactiveVariant(ctx context.Context, va wvav1alpha1.Vari
 		return nil
 	}
 
+	// Spread the replicas across failure domains and keep them in the zones of the model
+	// storage if the VA asks for it. The pod template is updated before the first replica is
+	// created, so no pods are rolled out. Placement hints are best effort and never block the
+	// scale-up.
+	topologyKeys := actuator.PlacementTopologyKeys(&va)
+	zones, err := utils.ModelZones(ctx, e.client, &va)
+	if err != nil {
+		logger.Error(err, "Error resolving the model zones of Target Workload", "variant", va.Name)
+	}
+	if len(topologyKeys) > 0 || len(zones) > 0 {
+		if err := e.Actuator.EnsurePlacementHints(ctx, unstructuredObj, topologyKeys, zones); err != nil {
+			logger.Error(err, "Error adding placement hints to Target Workload", "variant", va.Name, "topologyKeys", topologyKeys, "zones", zones)
+		}
+	}
+
 	// 1.  Scale up from zero to one
 	// TODO: Right now we are scaling all the VA for the same target model. We need to scale only the VA that has the lowest cost.
 	err = e.Actuator.ScaleTargetObject(ctx, unstructuredObj, int32(targetWorkloadReplicas))
@@ -296,22 +311,25 @@ func (e *Engine) processInactiveVariant(ctx context.Context, va wvav1alpha1.Vari
 			return err
 		}
 		common.DecisionCache.Set(va.Name, va.Namespace, interfaces.VariantDecision{
-			VariantName:        va.Name,
-			Namespace:          va.Namespace,
-			ModelID:            va.Spec.ModelID,
-			Cost:               cost,
-			TargetReplicas:     targetWorkloadReplicas, // Scale up to 1 replica
-			CurrentReplicas:    targetWorkloadReplicas,
-			DesiredReplicas:    targetWorkloadReplicas,
-			LastRunTime:        metav1.Now(),
-			SaturationBased:    false,
-			SafetyOverride:     false,
-			ModelBasedDecision: false,
-			AcceleratorName:    accelerator,
-			Reason:             reason, // Reason for scaling up
-			MetricsAvailable:   true,
-			MetricsReason:      MetricsReasonAvailable,
-			MetricsMessage:     MetricsMessageAvailable,
+			VariantName:         va.Name,
+			Namespace:           va.Namespace,
+			ModelID:             va.Spec.ModelID,
+			Cost:                cost,
+			TargetReplicas:      targetWorkloadReplicas, // Scale up to 1 replica
+			CurrentReplicas:     targetWorkloadReplicas,
+			DesiredReplicas:     targetWorkloadReplicas,
+			LastRunTime:         metav1.Now(),
+			SaturationBased:     false,
+			SafetyOverride:      false,
+			ModelBasedDecision:  false,
+			AcceleratorName:     accelerator,
+			Reason:              reason, // Reason for scaling up
+			MetricsAvailable:    true,
+			MetricsReason:       MetricsReasonAvailable,
+			MetricsMessage:      MetricsMessageAvailable,
+			ObservedGeneration:  va.Generation,
+			OptimizationReason:  wvav1alpha1.ReasonScaleFromZeroMode,
+			OptimizationMessage: fmt.Sprintf("scalefromzero decision: %s", reason),
 		})
 	} else {
 		if decision.CurrentReplicas == 0 {
@@ -327,6 +345,9 @@ func (e *Engine) processInactiveVariant(ctx context.Context, va wvav1alpha1.Vari
 			decision.MetricsAvailable = true
 			decision.MetricsReason = MetricsReasonAvailable
 			decision.MetricsMessage = MetricsMessageAvailable
+			decision.ObservedGeneration = va.Generation
+			decision.OptimizationReason = wvav1alpha1.ReasonScaleFromZeroMode
+			decision.OptimizationMessage = fmt.Sprintf("scalefromzero decision: %s", reason)
 			common.DecisionCache.Set(va.Name, va.Namespace, decision)
 		} else {
 			logger.Info("Target variant decision.CurrentReplicas is not zero", "value", decision.CurrentReplicas)
@@ -340,13 +361,6 @@ func (e *Engine) processInactiveVariant(ctx context.Context, va wvav1alpha1.Vari
 		Accelerator: accelerator,
 	}
 
-	// Set condition based on decision characteristics
-	wvav1alpha1.SetCondition(&va,
-		wvav1alpha1.TypeOptimizationReady,
-		metav1.ConditionTrue,
-		"ScaleFromZeroMode",
-		fmt.Sprintf("scalefromzero decision: %s", reason))
-
 	va.Status.Actuation.Applied = true
 
 	// 4. Trigger Reconciler
@@ -0,0 +1,313 @@
+// Package showback accounts the cost and replica-hours of the variants, for teams to see
+// what their models cost without a billing system.
+//
+// After every optimization cycle, the engine records the replicas and cost per replica of
+// each variant. The replicas observed in a cycle are accounted for the time until the
+// next cycle. Usage is grouped by namespace and by the values of configurable
+// VariantAutoscaling label keys, typically a cost center, and accumulated over periods of
+// configurable length. The report of the current period and of the last completed one
+// are served over HTTP, and completed reports can be written to a ConfigMap per namespace.
+package showback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap holding the last completed report of a namespace.
+	ConfigMapName = "wva-showback"
+
+	// DataKey is the key of the report in the data of the ConfigMap.
+	DataKey = "report.json"
+)
+
+// Sample is the usage of a variant observed in an optimization cycle.
+type Sample struct {
+	Namespace string
+	Name      string
+	// Labels are the labels of the VariantAutoscaling of the variant.
+	Labels map[string]string
+	// Replicas is the current number of replicas of the variant.
+	Replicas int
+	// Cost is the cost of a replica of the variant per hour (spec.variantCost).
+	Cost float64
+}
+
+// Report is the cost and replica-hours of the variants over a period.
+type Report struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	// Complete is whether the period is over.
+	Complete bool `json:"complete"`
+	// LabelKeys are the label keys the groups are keyed by, in addition to the namespace.
+	LabelKeys []string `json:"labelKeys,omitempty"`
+	Groups    []Group  `json:"groups"`
+}
+
+// Group is the usage of the variants of a namespace with the same values of the label keys.
+type Group struct {
+	Namespace string `json:"namespace"`
+	// Labels are the values of the label keys; variants without a label have an empty value.
+	Labels       map[string]string `json:"labels,omitempty"`
+	ReplicaHours float64           `json:"replicaHours"`
+	Cost         float64           `json:"cost"`
+	Variants     []VariantUsage    `json:"variants"`
+}
+
+// VariantUsage is the usage of a variant over a period.
+type VariantUsage struct {
+	Name         string  `json:"name"`
+	ReplicaHours float64 `json:"replicaHours"`
+	Cost         float64 `json:"cost"`
+}
+
+// ForNamespace returns the report restricted to the groups of a namespace.
+func (r *Report) ForNamespace(namespace string) *Report {
+	filtered := *r
+	filtered.Groups = nil
+	for _, g := range r.Groups {
+		if g.Namespace == namespace {
+			filtered.Groups = append(filtered.Groups, g)
+		}
+	}
+	return &filtered
+}
+
+// Namespaces returns the sorted namespaces of the groups of the report.
+func (r *Report) Namespaces() []string {
+	var namespaces []string
+	for _, g := range r.Groups {
+		if len(namespaces) == 0 || namespaces[len(namespaces)-1] != g.Namespace {
+			namespaces = append(namespaces, g.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// usage is the accumulated usage of a variant in the current period.
+type usage struct {
+	namespace    string
+	name         string
+	labels       map[string]string
+	replicaHours float64
+	cost         float64
+}
+
+// Tracker accumulates the usage of the variants over periods. Safe for concurrent use.
+type Tracker struct {
+	mu          sync.Mutex
+	labelKeys   []string
+	period      time.Duration
+	periodStart time.Time
+	lastRecord  time.Time
+	// running are the samples of the last cycle, accounted until the next one
+	running []Sample
+	usage   map[string]*usage
+	// previous is the report of the last completed period (nil until one completes)
+	previous *Report
+}
+
+// NewTracker creates a Tracker grouping usage by the given label keys over periods of the
+// given length.
+func NewTracker(labelKeys []string, period time.Duration) *Tracker {
+	return &Tracker{
+		labelKeys: append([]string(nil), labelKeys...),
+		period:    period,
+		usage:     make(map[string]*usage),
+	}
+}
+
+// Record accounts the samples of the previous cycle for the time since then, and keeps
+// the samples of this cycle for the next one. The first record starts the first period.
+// Returns the report of the period that completed with this record, if any.
+func (t *Tracker) Record(now time.Time, samples []Sample) *Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.periodStart.IsZero() {
+		t.periodStart = now
+	}
+	if hours := now.Sub(t.lastRecord).Hours(); !t.lastRecord.IsZero() && hours > 0 {
+		for _, s := range t.running {
+			key := s.Namespace + "/" + s.Name
+			u, ok := t.usage[key]
+			if !ok {
+				u = &usage{namespace: s.Namespace, name: s.Name}
+				t.usage[key] = u
+			}
+			u.labels = t.groupLabels(s.Labels)
+			u.replicaHours += float64(s.Replicas) * hours
+			u.cost += float64(s.Replicas) * s.Cost * hours
+		}
+	}
+	t.lastRecord = now
+	t.running = append(t.running[:0], samples...)
+
+	if now.Sub(t.periodStart) < t.period {
+		return nil
+	}
+	completed := t.report(now, true)
+	t.previous = completed
+	t.periodStart = now
+	t.usage = make(map[string]*usage)
+	return completed
+}
+
+// Current returns the report of the current period up to the last record.
+func (t *Tracker) Current() *Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.report(t.lastRecord, false)
+}
+
+// Previous returns the report of the last completed period, or nil if none completed yet.
+func (t *Tracker) Previous() *Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.previous
+}
+
+// groupLabels returns the values of the label keys in the labels of a variant.
+func (t *Tracker) groupLabels(labels map[string]string) map[string]string {
+	if len(t.labelKeys) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(t.labelKeys))
+	for _, key := range t.labelKeys {
+		values[key] = labels[key]
+	}
+	return values
+}
+
+// report builds the report of the current period, with groups sorted by namespace and
+// label values and variants sorted by name. Must be called with the lock held.
+func (t *Tracker) report(end time.Time, complete bool) *Report {
+	groups := make(map[string]*Group)
+	for _, u := range t.usage {
+		values := make([]string, 0, len(t.labelKeys)+1)
+		values = append(values, u.namespace)
+		for _, key := range t.labelKeys {
+			values = append(values, u.labels[key])
+		}
+		key := strings.Join(values, "\x00")
+		g, ok := groups[key]
+		if !ok {
+			g = &Group{Namespace: u.namespace, Labels: u.labels}
+			groups[key] = g
+		}
+		g.ReplicaHours += u.replicaHours
+		g.Cost += u.cost
+		g.Variants = append(g.Variants, VariantUsage{Name: u.name, ReplicaHours: u.replicaHours, Cost: u.cost})
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := &Report{
+		PeriodStart: t.periodStart,
+		PeriodEnd:   end,
+		Complete:    complete,
+		LabelKeys:   t.labelKeys,
+		Groups:      make([]Group, 0, len(keys)),
+	}
+	for _, key := range keys {
+		g := groups[key]
+		sort.Slice(g.Variants, func(i, j int) bool { return g.Variants[i].Name < g.Variants[j].Name })
+		report.Groups = append(report.Groups, *g)
+	}
+	return report
+}
+
+// Handler serves the reports of the current and last completed periods as JSON. The
+// namespace query parameter restricts the reports to the groups of a namespace.
+func Handler(t *Tracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reports := struct {
+			Current  *Report `json:"current"`
+			Previous *Report `json:"previous,omitempty"`
+		}{
+			Current:  t.Current(),
+			Previous: t.Previous(),
+		}
+		if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+			reports.Current = reports.Current.ForNamespace(namespace)
+			if reports.Previous != nil {
+				reports.Previous = reports.Previous.ForNamespace(namespace)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(reports)
+	})
+}
+
+// Publisher writes completed reports to a ConfigMap per namespace.
+type Publisher struct {
+	reader client.Reader
+	writer client.Client
+}
+
+// NewPublisher creates a Publisher. ConfigMaps are read with the reader, typically
+// uncached since they are only read when a period completes.
+func NewPublisher(reader client.Reader, writer client.Client) *Publisher {
+	return &Publisher{reader: reader, writer: writer}
+}
+
+// Publish writes the groups of each namespace of a report to the ConfigMap of the
+// namespace, creating it if needed.
+func (p *Publisher) Publish(ctx context.Context, report *Report) error {
+	for _, namespace := range report.Namespaces() {
+		if err := p.publish(ctx, namespace, report.ForNamespace(namespace)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) publish(ctx context.Context, namespace string, report *Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode showback report: %w", err)
+	}
+
+	var cm corev1.ConfigMap
+	err = p.reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ConfigMapName}, &cm)
+	if apierrors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: ConfigMapName},
+			Data:       map[string]string{DataKey: string(data)},
+		}
+		if err := p.writer.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to create showback ConfigMap %s/%s: %w", namespace, ConfigMapName, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get showback ConfigMap %s/%s: %w", namespace, ConfigMapName, err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[DataKey] = string(data)
+	if err := p.writer.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("failed to update showback ConfigMap %s/%s: %w", namespace, ConfigMapName, err)
+	}
+	return nil
+}
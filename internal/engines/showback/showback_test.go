@@ -0,0 +1,137 @@
+package showback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTrackerRecord(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker([]string{"cost-center"}, 24*time.Hour)
+	samples := []Sample{
+		{Namespace: "prod", Name: "llama-h100", Labels: map[string]string{"cost-center": "search"}, Replicas: 2, Cost: 10},
+		{Namespace: "prod", Name: "llama-a100", Labels: map[string]string{"cost-center": "search"}, Replicas: 1, Cost: 5},
+		{Namespace: "prod", Name: "granite", Replicas: 1, Cost: 10},
+		{Namespace: "dev", Name: "llama-h100", Labels: map[string]string{"cost-center": "search"}, Replicas: 1, Cost: 10},
+	}
+
+	// the first record only starts the period
+	assert.Nil(t, tracker.Record(start, samples))
+	assert.Empty(t, tracker.Current().Groups)
+
+	// the replicas of a cycle are accounted until the next one
+	samples[0].Replicas = 4
+	assert.Nil(t, tracker.Record(start.Add(time.Hour), samples))
+	assert.Nil(t, tracker.Record(start.Add(90*time.Minute), samples))
+
+	current := tracker.Current()
+	assert.Equal(t, start, current.PeriodStart)
+	assert.Equal(t, start.Add(90*time.Minute), current.PeriodEnd)
+	assert.False(t, current.Complete)
+	require.Len(t, current.Groups, 3)
+
+	assert.Equal(t, "dev", current.Groups[0].Namespace)
+	assert.InDelta(t, 1.5, current.Groups[0].ReplicaHours, 1e-9)
+
+	assert.Equal(t, "prod", current.Groups[1].Namespace)
+	assert.Equal(t, map[string]string{"cost-center": ""}, current.Groups[1].Labels)
+	assert.InDelta(t, 1.5, current.Groups[1].ReplicaHours, 1e-9)
+	assert.InDelta(t, 15, current.Groups[1].Cost, 1e-9)
+
+	search := current.Groups[2]
+	assert.Equal(t, map[string]string{"cost-center": "search"}, search.Labels)
+	// llama-h100: 2 replicas for 1h then 4 for 30m; llama-a100: 1 replica for 1h30m
+	assert.InDelta(t, 5.5, search.ReplicaHours, 1e-9)
+	assert.InDelta(t, 47.5, search.Cost, 1e-9)
+	require.Len(t, search.Variants, 2)
+	assert.Equal(t, "llama-a100", search.Variants[0].Name)
+	assert.InDelta(t, 4, search.Variants[1].ReplicaHours, 1e-9)
+
+	assert.Nil(t, tracker.Previous())
+}
+
+func TestTrackerPeriodRollover(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker(nil, time.Hour)
+	samples := []Sample{{Namespace: "prod", Name: "llama", Replicas: 2, Cost: 10}}
+
+	assert.Nil(t, tracker.Record(start, samples))
+	assert.Nil(t, tracker.Record(start.Add(30*time.Minute), samples))
+	completed := tracker.Record(start.Add(time.Hour), samples)
+	require.NotNil(t, completed)
+	assert.True(t, completed.Complete)
+	assert.Equal(t, start.Add(time.Hour), completed.PeriodEnd)
+	require.Len(t, completed.Groups, 1)
+	assert.Nil(t, completed.Groups[0].Labels)
+	assert.InDelta(t, 2, completed.Groups[0].ReplicaHours, 1e-9)
+	assert.InDelta(t, 20, completed.Groups[0].Cost, 1e-9)
+	assert.Equal(t, completed, tracker.Previous())
+
+	// the next period starts empty
+	current := tracker.Current()
+	assert.Equal(t, start.Add(time.Hour), current.PeriodStart)
+	assert.Empty(t, current.Groups)
+}
+
+func TestHandler(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker(nil, 24*time.Hour)
+	samples := []Sample{
+		{Namespace: "prod", Name: "llama", Replicas: 2, Cost: 10},
+		{Namespace: "dev", Name: "llama", Replicas: 1, Cost: 10},
+	}
+	tracker.Record(start, samples)
+	tracker.Record(start.Add(time.Hour), samples)
+
+	rec := httptest.NewRecorder()
+	Handler(tracker).ServeHTTP(rec, httptest.NewRequest("GET", "/showback?namespace=prod", nil))
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var reports struct {
+		Current  *Report `json:"current"`
+		Previous *Report `json:"previous"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	require.NotNil(t, reports.Current)
+	assert.Nil(t, reports.Previous)
+	require.Len(t, reports.Current.Groups, 1)
+	assert.Equal(t, "prod", reports.Current.Groups[0].Namespace)
+	assert.InDelta(t, 2, reports.Current.Groups[0].ReplicaHours, 1e-9)
+}
+
+func TestPublisherPublish(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().Build()
+	publisher := NewPublisher(k8sClient, k8sClient)
+	report := &Report{
+		Complete: true,
+		Groups: []Group{
+			{Namespace: "dev", ReplicaHours: 1, Cost: 10},
+			{Namespace: "prod", ReplicaHours: 2, Cost: 20},
+		},
+	}
+
+	// the first publish creates the ConfigMaps, the next ones update them
+	require.NoError(t, publisher.Publish(ctx, report))
+	report.Groups[1].Cost = 30
+	require.NoError(t, publisher.Publish(ctx, report))
+
+	for namespace, cost := range map[string]float64{"dev": 10, "prod": 30} {
+		var cm corev1.ConfigMap
+		require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ConfigMapName}, &cm))
+		var published Report
+		require.NoError(t, json.Unmarshal([]byte(cm.Data[DataKey]), &published))
+		require.Len(t, published.Groups, 1)
+		assert.Equal(t, namespace, published.Groups[0].Namespace)
+		assert.InDelta(t, cost, published.Groups[0].Cost, 1e-9)
+	}
+}
@@ -0,0 +1,169 @@
+// Package snapshot persists the state of the saturation engine that takes minutes to
+// rebuild, so that a new leader resumes where the previous one stopped instead of
+// starting cold.
+//
+// The leader saves a compact snapshot in a ConfigMap after every optimization cycle: the
+// last decision and saturation of each variant, and the capacities learned from live
+// metrics. On leader change, the new leader restores the snapshot if it is recent enough.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+)
+
+// DataKey is the key of the snapshot in the data of the ConfigMap.
+const DataKey = "snapshot.json"
+
+// Snapshot is the state of the saturation engine at the end of an optimization cycle.
+type Snapshot struct {
+	// TakenAt is the time the snapshot was taken.
+	TakenAt time.Time `json:"takenAt"`
+	// Decisions are the last decisions of the variants.
+	Decisions []Decision `json:"decisions,omitempty"`
+	// Capacities are the capacities learned from live metrics.
+	Capacities []Capacity `json:"capacities,omitempty"`
+}
+
+// Decision is the last decision of a variant.
+type Decision struct {
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	TargetReplicas int    `json:"targetReplicas"`
+	Accelerator    string `json:"accelerator"`
+	// Saturation is the last known saturation of the variant (0.0-1.0), if any.
+	Saturation *float64 `json:"saturation,omitempty"`
+}
+
+// Capacity is the capacity of a variant learned from live metrics.
+type Capacity struct {
+	Namespace             string                          `json:"namespace"`
+	ModelID               string                          `json:"modelID"`
+	VariantName           string                          `json:"variantName"`
+	AcceleratorName       string                          `json:"accelerator"`
+	GpuCount              float64                         `json:"gpuCount"`
+	NumGpuBlocks          int64                           `json:"numGpuBlocks,omitempty"`
+	BlockSize             int64                           `json:"blockSize,omitempty"`
+	TotalKvCapacityTokens int64                           `json:"totalKvCapacityTokens,omitempty"`
+	EffectiveCapacity     int64                           `json:"effectiveCapacity,omitempty"`
+	VLLMParams            *saturation_v2.VLLMEngineParams `json:"vllmParams,omitempty"`
+	LearnedAt             time.Time                       `json:"learnedAt"`
+}
+
+// FromCapacityRecords converts the live records of a capacity store.
+func FromCapacityRecords(records []saturation_v2.VariantCapacityRecord) []Capacity {
+	capacities := make([]Capacity, 0, len(records))
+	for _, r := range records {
+		capacities = append(capacities, Capacity{
+			Namespace:             r.Namespace,
+			ModelID:               r.ModelID,
+			VariantName:           r.VariantName,
+			AcceleratorName:       r.AcceleratorName,
+			GpuCount:              r.GpuCount,
+			NumGpuBlocks:          r.NumGpuBlocks,
+			BlockSize:             r.BlockSize,
+			TotalKvCapacityTokens: r.TotalKvCapacityTokens,
+			EffectiveCapacity:     r.EffectiveCapacity,
+			VLLMParams:            r.VLLMParams,
+			LearnedAt:             r.LearnedAt,
+		})
+	}
+	return capacities
+}
+
+// CapacityRecords converts the capacities back to records of a capacity store.
+func (s *Snapshot) CapacityRecords() []saturation_v2.VariantCapacityRecord {
+	records := make([]saturation_v2.VariantCapacityRecord, 0, len(s.Capacities))
+	for _, c := range s.Capacities {
+		records = append(records, saturation_v2.VariantCapacityRecord{
+			Namespace:   c.Namespace,
+			ModelID:     c.ModelID,
+			VariantName: c.VariantName,
+			CapacityRecord: saturation_v2.CapacityRecord{
+				AcceleratorName:       c.AcceleratorName,
+				GpuCount:              c.GpuCount,
+				NumGpuBlocks:          c.NumGpuBlocks,
+				BlockSize:             c.BlockSize,
+				TotalKvCapacityTokens: c.TotalKvCapacityTokens,
+				EffectiveCapacity:     c.EffectiveCapacity,
+				VLLMParams:            c.VLLMParams,
+				LearnedFrom:           "live",
+				LearnedAt:             c.LearnedAt,
+			},
+		})
+	}
+	return records
+}
+
+// Store saves and loads snapshots in a ConfigMap.
+type Store struct {
+	reader    client.Reader
+	writer    client.Client
+	namespace string
+	name      string
+}
+
+// NewStore creates a store of snapshots in the ConfigMap namespace/name. Snapshots are
+// loaded with the reader, typically uncached since they are only read on leader change.
+func NewStore(reader client.Reader, writer client.Client, namespace, name string) *Store {
+	return &Store{reader: reader, writer: writer, namespace: namespace, name: name}
+}
+
+// Save writes a snapshot, creating the ConfigMap if needed.
+func (s *Store) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode state snapshot: %w", err)
+	}
+
+	var cm corev1.ConfigMap
+	err = s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, &cm)
+	if apierrors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+			Data:       map[string]string{DataKey: string(data)},
+		}
+		if err := s.writer.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to create state snapshot ConfigMap %s/%s: %w", s.namespace, s.name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get state snapshot ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	cm.Data = map[string]string{DataKey: string(data)}
+	if err := s.writer.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("failed to update state snapshot ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// Load reads the last snapshot, or returns nil if there is none.
+func (s *Store) Load(ctx context.Context) (*Snapshot, error) {
+	var cm corev1.ConfigMap
+	if err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get state snapshot ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	data, ok := cm.Data[DataKey]
+	if !ok {
+		return nil, nil
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode state snapshot ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,89 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+)
+
+func TestStoreSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().Build()
+	store := NewStore(k8sClient, k8sClient, "wva-system", "wva-state-snapshot")
+
+	// no snapshot yet
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	takenAt := time.Now().Truncate(time.Second)
+	snapshot := &Snapshot{
+		TakenAt: takenAt,
+		Decisions: []Decision{
+			{Name: "llama-h100", Namespace: "prod", TargetReplicas: 3, Accelerator: "H100", Saturation: ptr.To(0.9)},
+			{Name: "llama-a100", Namespace: "prod", TargetReplicas: 1, Accelerator: "A100"},
+		},
+	}
+
+	// the first save creates the ConfigMap, the next ones update it
+	require.NoError(t, store.Save(ctx, snapshot))
+	snapshot.Decisions[0].TargetReplicas = 4
+	require.NoError(t, store.Save(ctx, snapshot))
+
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.True(t, loaded.TakenAt.Equal(takenAt))
+	assert.Equal(t, snapshot.Decisions, loaded.Decisions)
+}
+
+func TestStoreLoadInvalid(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "wva-system", Name: "wva-state-snapshot"},
+		Data:       map[string]string{DataKey: "{"},
+	}).Build()
+	store := NewStore(k8sClient, k8sClient, "wva-system", "wva-state-snapshot")
+
+	_, err := store.Load(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCapacityRecords(t *testing.T) {
+	learnedAt := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	records := []saturation_v2.VariantCapacityRecord{{
+		Namespace:   "prod",
+		ModelID:     "meta/llama",
+		VariantName: "llama-h100",
+		CapacityRecord: saturation_v2.CapacityRecord{
+			AcceleratorName:   "H100",
+			GpuCount:          1,
+			EffectiveCapacity: 14000,
+			VLLMParams:        &saturation_v2.VLLMEngineParams{BlockSize: 16, MaxNumSeqs: 256},
+			LearnedFrom:       "live",
+			LearnedAt:         learnedAt,
+		},
+	}}
+
+	// capacities survive a save and load
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().Build()
+	store := NewStore(k8sClient, k8sClient, "wva-system", "wva-state-snapshot")
+	require.NoError(t, store.Save(ctx, &Snapshot{TakenAt: time.Now(), Capacities: FromCapacityRecords(records)}))
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+
+	restored := loaded.CapacityRecords()
+	require.Len(t, restored, 1)
+	assert.True(t, restored[0].LearnedAt.Equal(learnedAt))
+	restored[0].LearnedAt = learnedAt
+	assert.Equal(t, records, restored)
+}
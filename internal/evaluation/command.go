@@ -0,0 +1,81 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	flag "github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Command is the name of the evaluation subcommand of the controller binary.
+const Command = "evaluate"
+
+// Run runs the evaluation subcommand on the dataset files of args and writes a report for
+// each to out. It fails when a report is below the minimum accuracy or precision set by
+// flags, so that it can gate algorithmic changes in CI.
+func Run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	format := flags.String("format", "text", "Output format of the reports: text or json.")
+	minAccuracy := flags.Float64("min-accuracy", 0,
+		"Minimum fraction of decisions taking the labeled action; lower accuracy fails the evaluation.")
+	minPrecision := flags.Float64("min-precision", 0,
+		"Minimum precision of the scale-up and scale-down decisions; lower precision fails the evaluation.")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] DATASET...\n\n"+
+			"Replays labeled datasets through the engine and reports the accuracy of its decisions.\n\n", Command)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no dataset given")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q: must be text or json", *format)
+	}
+
+	// The analyzers log every decision
+	ctrl.SetLogger(logr.Discard())
+
+	reports := make([]*Report, 0, flags.NArg())
+	var failures []string
+	for _, path := range flags.Args() {
+		dataset, err := LoadDataset(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		report, err := Evaluate(ctx, dataset)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		reports = append(reports, report)
+		if report.Accuracy() < *minAccuracy {
+			failures = append(failures, fmt.Sprintf("%s: accuracy %.3f below %.3f", path, report.Accuracy(), *minAccuracy))
+		}
+		if report.MinPrecision() < *minPrecision {
+			failures = append(failures, fmt.Sprintf("%s: precision %.3f below %.3f", path, report.MinPrecision(), *minPrecision))
+		}
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, report := range reports {
+			report.WriteText(out)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("evaluation failed: %v", failures)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package evaluation measures the accuracy of the scaling decisions of the engine.
+//
+// A Dataset is a labeled replay of the inputs of the engine for a model: the metrics of
+// the replicas of its variants at a series of timestamps, and for each variant the
+// scaling action that was right at that time. Evaluate replays it through the analyzer
+// and optimizer of the current build and compares their actions to the labels, so that
+// algorithmic changes can be gated on their accuracy over recorded incidents.
+package evaluation
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Dataset is a labeled replay of the inputs of the engine for one model.
+type Dataset struct {
+	// ModelID and Namespace identify the model.
+	ModelID   string `yaml:"modelID"`
+	Namespace string `yaml:"namespace"`
+	// Config is the saturation scaling config the model is evaluated with; its
+	// analyzerName selects the V1 or V2 (token-based) analyzer.
+	Config interfaces.SaturationScalingConfig `yaml:"config"`
+	// Samples are the inputs of successive optimization runs, in time order.
+	Samples []Sample `yaml:"samples"`
+}
+
+// Sample is the input of one optimization run.
+type Sample struct {
+	// Time is the offset of the sample from the start of the replay.
+	Time time.Duration `yaml:"time"`
+	// Variants are the variants of the model.
+	Variants []Variant `yaml:"variants"`
+}
+
+// Variant is the state of a variant in a sample, and the right action for it.
+type Variant struct {
+	Name           string  `yaml:"name"`
+	Accelerator    string  `yaml:"accelerator"`
+	Cost           float64 `yaml:"cost"`
+	GPUsPerReplica float64 `yaml:"gpusPerReplica"`
+	// Replicas is the number of replicas, and PendingReplicas those not ready yet.
+	Replicas        int `yaml:"replicas"`
+	PendingReplicas int `yaml:"pendingReplicas"`
+	// Pods are the metrics of the ready replicas.
+	Pods []Pod `yaml:"pods"`
+	// Expected is the right action: scale-up, scale-down, or no-change.
+	Expected interfaces.SaturationAction `yaml:"expected"`
+}
+
+// Pod is the metrics of a replica.
+type Pod struct {
+	KvCacheUsage    float64 `yaml:"kvCacheUsage"`
+	QueueLength     int     `yaml:"queueLength"`
+	NumGPUBlocks    int64   `yaml:"numGpuBlocks"`
+	BlockSize       int64   `yaml:"blockSize"`
+	AvgInputTokens  float64 `yaml:"avgInputTokens"`
+	AvgOutputTokens float64 `yaml:"avgOutputTokens"`
+}
+
+// defaultConfig is the default entry of the saturation scaling ConfigMap shipped with the
+// controller, which the config of datasets overrides
+var defaultConfig = interfaces.SaturationScalingConfig{
+	KvCacheThreshold:     0.80,
+	QueueLengthThreshold: 5,
+	KvSpareTrigger:       0.10,
+	QueueSpareTrigger:    3,
+}
+
+// LoadDataset reads and validates a dataset file.
+func LoadDataset(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	return ParseDataset(data)
+}
+
+// ParseDataset parses and validates a YAML dataset.
+func ParseDataset(data []byte) (*Dataset, error) {
+	dataset := Dataset{Config: defaultConfig}
+	if err := yaml.Unmarshal(data, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	if dataset.ModelID == "" {
+		return nil, fmt.Errorf("dataset has no modelID")
+	}
+	if len(dataset.Samples) == 0 {
+		return nil, fmt.Errorf("dataset has no samples")
+	}
+	for i, sample := range dataset.Samples {
+		if i > 0 && sample.Time <= dataset.Samples[i-1].Time {
+			return nil, fmt.Errorf("sample %d: time %s is not after the previous sample", i, sample.Time)
+		}
+		for _, v := range sample.Variants {
+			if v.Name == "" {
+				return nil, fmt.Errorf("sample %d: variant has no name", i)
+			}
+			switch v.Expected {
+			case interfaces.ActionScaleUp, interfaces.ActionScaleDown, interfaces.ActionNoChange:
+			default:
+				return nil, fmt.Errorf("sample %d: variant %s: unknown expected action %q", i, v.Name, v.Expected)
+			}
+		}
+	}
+	dataset.Config.ApplyDefaults()
+	if err := dataset.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &dataset, nil
+}
@@ -0,0 +1,300 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+)
+
+// Analyzer names of reports
+const (
+	AnalyzerV1 = "saturation-percentage-based"
+	AnalyzerV2 = "saturation-token-based"
+)
+
+// ActionStats counts the decisions of an action.
+type ActionStats struct {
+	// Expected is the number of decisions labeled with the action
+	Expected int `json:"expected"`
+	// Decided is the number of decisions the engine took the action in
+	Decided int `json:"decided"`
+	// Correct is the number of decisions labeled with the action the engine took it in
+	Correct int `json:"correct"`
+}
+
+// Precision is the fraction of the decisions taking the action that were right, or 1
+// when the action was never taken.
+func (s ActionStats) Precision() float64 {
+	if s.Decided == 0 {
+		return 1
+	}
+	return float64(s.Correct) / float64(s.Decided)
+}
+
+// Recall is the fraction of the decisions labeled with the action that took it, or 1
+// when no decision was labeled with it.
+func (s ActionStats) Recall() float64 {
+	if s.Expected == 0 {
+		return 1
+	}
+	return float64(s.Correct) / float64(s.Expected)
+}
+
+// LatencyStats measures how long the engine took to scale in episodes, the runs of
+// successive samples of a variant labeled with the same scaling action.
+type LatencyStats struct {
+	// Episodes is the number of episodes, and Missed those the engine never scaled in
+	Episodes int `json:"episodes"`
+	Missed   int `json:"missed"`
+	// Mean and Max are the times from the start of the episodes to the first decision
+	// taking their action, over the episodes that were not missed
+	Mean time.Duration `json:"mean"`
+	Max  time.Duration `json:"max"`
+
+	total time.Duration
+}
+
+// add records an episode, scaled after latency or missed when latency is negative
+func (s *LatencyStats) add(latency time.Duration) {
+	s.Episodes++
+	if latency < 0 {
+		s.Missed++
+		return
+	}
+	s.total += latency
+	s.Max = max(s.Max, latency)
+	s.Mean = s.total / time.Duration(s.Episodes-s.Missed)
+}
+
+// Report is the accuracy of the decisions of the engine over a dataset.
+type Report struct {
+	ModelID   string `json:"modelID"`
+	Namespace string `json:"namespace"`
+	Analyzer  string `json:"analyzer"`
+	// Decisions is the number of variant decisions, and Correct those taking the
+	// labeled action
+	Decisions int `json:"decisions"`
+	Correct   int `json:"correct"`
+	// Actions are the decision counts by action
+	Actions map[interfaces.SaturationAction]*ActionStats `json:"actions"`
+	// Latency is the latency to scale up and down
+	Latency map[interfaces.SaturationAction]*LatencyStats `json:"latency"`
+}
+
+// Accuracy is the fraction of the decisions taking the labeled action.
+func (r *Report) Accuracy() float64 {
+	if r.Decisions == 0 {
+		return 1
+	}
+	return float64(r.Correct) / float64(r.Decisions)
+}
+
+// MinPrecision is the lowest precision of the scaling actions.
+func (r *Report) MinPrecision() float64 {
+	return math.Min(r.Actions[interfaces.ActionScaleUp].Precision(), r.Actions[interfaces.ActionScaleDown].Precision())
+}
+
+// WriteText writes the report as a table.
+func (r *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Model %s/%s (%s): %d decisions, accuracy %.3f\n",
+		r.Namespace, r.ModelID, r.Analyzer, r.Decisions, r.Accuracy())
+	fmt.Fprintf(w, "  %-11s %9s %8s %8s %10s %7s\n", "ACTION", "EXPECTED", "DECIDED", "CORRECT", "PRECISION", "RECALL")
+	for _, action := range []interfaces.SaturationAction{interfaces.ActionScaleUp, interfaces.ActionScaleDown, interfaces.ActionNoChange} {
+		s := r.Actions[action]
+		fmt.Fprintf(w, "  %-11s %9d %8d %8d %10.3f %7.3f\n", action, s.Expected, s.Decided, s.Correct, s.Precision(), s.Recall())
+	}
+	fmt.Fprintf(w, "  %-11s %9s %8s %8s %8s\n", "EPISODES", "COUNT", "MISSED", "MEAN", "MAX")
+	for _, action := range []interfaces.SaturationAction{interfaces.ActionScaleUp, interfaces.ActionScaleDown} {
+		s := r.Latency[action]
+		fmt.Fprintf(w, "  %-11s %9d %8d %8s %8s\n", action, s.Episodes, s.Missed, s.Mean, s.Max)
+	}
+}
+
+// decider returns the action of each variant of a sample
+type decider func(ctx context.Context, dataset *Dataset, sample Sample) (map[string]interfaces.SaturationAction, error)
+
+// Evaluate replays a dataset through the analyzer selected by its config and the
+// optimizer of that analyzer, and compares their action for each variant of each sample
+// to the labeled one. Replays are open-loop: the replicas of later samples are the
+// recorded ones, whatever the engine decided.
+func Evaluate(ctx context.Context, dataset *Dataset) (*Report, error) {
+	report := &Report{
+		ModelID:   dataset.ModelID,
+		Namespace: dataset.Namespace,
+		Actions: map[interfaces.SaturationAction]*ActionStats{
+			interfaces.ActionScaleUp:   {},
+			interfaces.ActionScaleDown: {},
+			interfaces.ActionNoChange:  {},
+		},
+		Latency: map[interfaces.SaturationAction]*LatencyStats{
+			interfaces.ActionScaleUp:   {},
+			interfaces.ActionScaleDown: {},
+		},
+	}
+
+	var decide decider
+	if dataset.Config.GetAnalyzerName() == "saturation" {
+		report.Analyzer = AnalyzerV2
+		decide = v2Decider()
+	} else {
+		report.Analyzer = AnalyzerV1
+		decide = decideV1
+	}
+
+	// open episodes by variant
+	type episode struct {
+		action  interfaces.SaturationAction
+		start   time.Duration
+		latency time.Duration // negative until scaled
+	}
+	episodes := make(map[string]*episode)
+	closeEpisode := func(name string) {
+		if e := episodes[name]; e != nil {
+			report.Latency[e.action].add(e.latency)
+			delete(episodes, name)
+		}
+	}
+
+	for _, sample := range dataset.Samples {
+		actions, err := decide(ctx, dataset, sample)
+		if err != nil {
+			return nil, fmt.Errorf("sample at %s: %w", sample.Time, err)
+		}
+		for _, v := range sample.Variants {
+			decided, ok := actions[v.Name]
+			if !ok {
+				decided = interfaces.ActionNoChange
+			}
+			report.Decisions++
+			report.Actions[v.Expected].Expected++
+			report.Actions[decided].Decided++
+			if decided == v.Expected {
+				report.Correct++
+				report.Actions[decided].Correct++
+			}
+
+			if e := episodes[v.Name]; e != nil && e.action != v.Expected {
+				closeEpisode(v.Name)
+			}
+			if _, tracked := report.Latency[v.Expected]; tracked && episodes[v.Name] == nil {
+				episodes[v.Name] = &episode{action: v.Expected, start: sample.Time, latency: -1}
+			}
+			if e := episodes[v.Name]; e != nil && e.latency < 0 && decided == e.action {
+				e.latency = sample.Time - e.start
+			}
+		}
+	}
+	names := make([]string, 0, len(episodes))
+	for name := range episodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		closeEpisode(name)
+	}
+	return report, nil
+}
+
+// replicaMetrics returns the replica metrics and variant states of a sample, as collected
+func replicaMetrics(dataset *Dataset, sample Sample) ([]interfaces.ReplicaMetrics, []interfaces.VariantReplicaState) {
+	var metrics []interfaces.ReplicaMetrics
+	states := make([]interfaces.VariantReplicaState, 0, len(sample.Variants))
+	for _, v := range sample.Variants {
+		gpus := v.GPUsPerReplica
+		if gpus <= 0 {
+			gpus = 1
+		}
+		states = append(states, interfaces.VariantReplicaState{
+			VariantName:       v.Name,
+			CurrentReplicas:   v.Replicas,
+			PendingReplicas:   v.PendingReplicas,
+			GPUsPerReplica:    gpus,
+			ReportingReplicas: len(v.Pods),
+		})
+		for i, pod := range v.Pods {
+			totalKvCapacityTokens := pod.NumGPUBlocks * pod.BlockSize
+			metrics = append(metrics, interfaces.ReplicaMetrics{
+				PodName:               fmt.Sprintf("%s-%d", v.Name, i),
+				VariantName:           v.Name,
+				Namespace:             dataset.Namespace,
+				ModelID:               dataset.ModelID,
+				AcceleratorName:       v.Accelerator,
+				Cost:                  v.Cost,
+				KvCacheUsage:          pod.KvCacheUsage,
+				QueueLength:           pod.QueueLength,
+				NumGpuBlocks:          pod.NumGPUBlocks,
+				BlockSize:             pod.BlockSize,
+				TotalKvCapacityTokens: totalKvCapacityTokens,
+				TokensInUse:           int64(math.Round(pod.KvCacheUsage * float64(totalKvCapacityTokens))),
+				AvgInputTokens:        pod.AvgInputTokens,
+				AvgOutputTokens:       pod.AvgOutputTokens,
+			})
+		}
+	}
+	return metrics, states
+}
+
+// decideV1 decides with the V1 percentage-based analyzer, like RunSaturationAnalysis
+func decideV1(ctx context.Context, dataset *Dataset, sample Sample) (map[string]interfaces.SaturationAction, error) {
+	metrics, states := replicaMetrics(dataset, sample)
+	analyzer := saturation.NewAnalyzer()
+	analysis, err := analyzer.AnalyzeModelSaturation(ctx, dataset.ModelID, dataset.Namespace, metrics, dataset.Config)
+	if err != nil {
+		return nil, err
+	}
+	targets := analyzer.CalculateSaturationTargets(ctx, analysis, states)
+
+	actions := make(map[string]interfaces.SaturationAction, len(states))
+	for _, state := range states {
+		target, ok := targets[state.VariantName]
+		switch {
+		case !ok || target == state.CurrentReplicas:
+			actions[state.VariantName] = interfaces.ActionNoChange
+		case target > state.CurrentReplicas:
+			actions[state.VariantName] = interfaces.ActionScaleUp
+		default:
+			actions[state.VariantName] = interfaces.ActionScaleDown
+		}
+	}
+	return actions, nil
+}
+
+// v2Decider returns a decider with the V2 token-based analyzer and the cost-aware
+// optimizer. The analyzer keeps its capacity history across the samples of a replay.
+func v2Decider() decider {
+	analyzer := saturation_v2.NewSaturationAnalyzer(saturation_v2.NewCapacityKnowledgeStore())
+	optimizer := pipeline.NewCostAwareOptimizer()
+	return func(ctx context.Context, dataset *Dataset, sample Sample) (map[string]interfaces.SaturationAction, error) {
+		metrics, states := replicaMetrics(dataset, sample)
+		result, err := analyzer.Analyze(ctx, interfaces.AnalyzerInput{
+			ModelID:        dataset.ModelID,
+			Namespace:      dataset.Namespace,
+			ReplicaMetrics: metrics,
+			VariantStates:  states,
+			Config:         &dataset.Config,
+		})
+		if err != nil {
+			return nil, err
+		}
+		decisions := optimizer.Optimize(ctx, []pipeline.ModelScalingRequest{{
+			ModelID:       dataset.ModelID,
+			Namespace:     dataset.Namespace,
+			Result:        result,
+			VariantStates: states,
+		}}, nil)
+
+		actions := make(map[string]interfaces.SaturationAction, len(decisions))
+		for _, d := range decisions {
+			actions[d.VariantName] = d.Action
+		}
+		return actions, nil
+	}
+}
@@ -0,0 +1,157 @@
+package evaluation
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+func init() {
+	// Initialize logger for tests
+	logging.NewTestLogger()
+}
+
+// datasetYAML has a scale-up episode detected 30s late, and a scale-down episode of a
+// saturated model that is missed
+const datasetYAML = `
+modelID: meta/llama-3.1-8b
+namespace: llm
+samples:
+- time: 0s
+  variants:
+  - name: llama-a100
+    cost: 40
+    replicas: 2
+    pods: [{kvCacheUsage: 0.40}, {kvCacheUsage: 0.40}]
+    expected: no-change
+- time: 30s
+  variants:
+  - name: llama-a100
+    cost: 40
+    replicas: 2
+    pods: [{kvCacheUsage: 0.60}, {kvCacheUsage: 0.60}]
+    expected: scale-up
+- time: 60s
+  variants:
+  - name: llama-a100
+    cost: 40
+    replicas: 2
+    pods: [{kvCacheUsage: 0.90, queueLength: 6}, {kvCacheUsage: 0.90, queueLength: 6}]
+    expected: scale-up
+- time: 90s
+  variants:
+  - name: llama-a100
+    cost: 40
+    replicas: 3
+    pods: [{kvCacheUsage: 0.90}, {kvCacheUsage: 0.90}, {kvCacheUsage: 0.90}]
+    expected: scale-down
+`
+
+func TestEvaluate(t *testing.T) {
+	dataset, err := ParseDataset([]byte(datasetYAML))
+	if err != nil {
+		t.Fatalf("ParseDataset() failed: %v", err)
+	}
+	if dataset.Config.KvCacheThreshold != 0.80 {
+		t.Errorf("expected the default kvCacheThreshold 0.80, got %v", dataset.Config.KvCacheThreshold)
+	}
+
+	report, err := Evaluate(context.Background(), dataset)
+	if err != nil {
+		t.Fatalf("Evaluate() failed: %v", err)
+	}
+	if report.Analyzer != AnalyzerV1 {
+		t.Errorf("expected analyzer %s, got %s", AnalyzerV1, report.Analyzer)
+	}
+	if report.Decisions != 4 || report.Correct != 2 {
+		t.Errorf("expected 2 of 4 decisions correct, got %d of %d", report.Correct, report.Decisions)
+	}
+	up := report.Actions[interfaces.ActionScaleUp]
+	if up.Expected != 2 || up.Decided != 2 || up.Correct != 1 {
+		t.Errorf("unexpected scale-up stats %+v", *up)
+	}
+	if up.Precision() != 0.5 || up.Recall() != 0.5 {
+		t.Errorf("expected scale-up precision and recall 0.5, got %v and %v", up.Precision(), up.Recall())
+	}
+
+	upLatency := report.Latency[interfaces.ActionScaleUp]
+	if upLatency.Episodes != 1 || upLatency.Missed != 0 || upLatency.Mean != 30*time.Second {
+		t.Errorf("expected one scale-up episode detected after 30s, got %+v", *upLatency)
+	}
+	downLatency := report.Latency[interfaces.ActionScaleDown]
+	if downLatency.Episodes != 1 || downLatency.Missed != 1 {
+		t.Errorf("expected one missed scale-down episode, got %+v", *downLatency)
+	}
+}
+
+func TestEvaluate_TokenBasedAnalyzer(t *testing.T) {
+	dataset, err := ParseDataset([]byte(`
+modelID: meta/llama-3.1-8b
+namespace: llm
+config:
+  analyzerName: saturation
+samples:
+- time: 0s
+  variants:
+  - name: llama-a100
+    cost: 40
+    replicas: 1
+    pods: [{kvCacheUsage: 0.95, queueLength: 10, numGpuBlocks: 8192, blockSize: 16, avgInputTokens: 512, avgOutputTokens: 256}]
+    expected: scale-up
+`))
+	if err != nil {
+		t.Fatalf("ParseDataset() failed: %v", err)
+	}
+	report, err := Evaluate(context.Background(), dataset)
+	if err != nil {
+		t.Fatalf("Evaluate() failed: %v", err)
+	}
+	if report.Analyzer != AnalyzerV2 || report.Decisions != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestParseDataset_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no model":        "samples: [{time: 0s}]",
+		"no samples":      "modelID: m",
+		"unordered":       "modelID: m\nsamples: [{time: 30s}, {time: 0s}]",
+		"unknown action":  "modelID: m\nsamples: [{time: 0s, variants: [{name: v, expected: grow}]}]",
+		"invalid config":  "modelID: m\nconfig: {kvCacheThreshold: 2}\nsamples: [{time: 0s}]",
+		"unnamed variant": "modelID: m\nsamples: [{time: 0s, variants: [{expected: no-change}]}]",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDataset([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRun_MinAccuracy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.yaml")
+	if err := os.WriteFile(path, []byte(datasetYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Run(context.Background(), []string{path}, &out); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "accuracy 0.500") {
+		t.Errorf("expected the accuracy in the report, got:\n%s", out.String())
+	}
+
+	err := Run(context.Background(), []string{"--min-accuracy", "0.9", path}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "accuracy 0.500 below 0.900") {
+		t.Errorf("expected the evaluation to fail on accuracy, got %v", err)
+	}
+}
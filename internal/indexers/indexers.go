@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,6 +35,13 @@ const (
 	// (e.g., "default/apps/v1/Deployment/my-app") to uniquely identify scale targets across namespaces
 	// and avoid collisions between different resource types and API versions.
 	VAScaleTargetKey = ".spec.scaleTargetRef.nsAPIVersionKindName"
+
+	// VAScaleTargetSelectorKey is the index field name for looking up the VariantAutoscalings
+	// selecting their scale target by labels. Only those VAs are indexed, with the value
+	// scaleTargetSelectorIndexValue, so that other VAs are never listed on workload events.
+	VAScaleTargetSelectorKey = ".spec.scaleTargetSelector.set"
+
+	scaleTargetSelectorIndexValue = "true"
 )
 
 // scaleTargetIndexKey returns the composite index key for a scale target reference.
@@ -42,9 +50,12 @@ func scaleTargetIndexKey(namespace string, ref autoscalingv1.CrossVersionObjectR
 
 	if ref.APIVersion == "" {
 		switch ref.Kind {
-		case "Deployment":
+		case "Deployment", "StatefulSet":
 			ref.APIVersion = "apps/v1"
 
+		case constants.LeaderWorkerSetKind:
+			ref.APIVersion = constants.LeaderWorkerSetAPIVersion
+
 		// Note: add other Kinds when support to other scaleTargetRefs is added
 		// By default, assume 'apps/v1' for unsupported Kinds
 		default:
@@ -62,16 +73,43 @@ func SetupIndexes(ctx context.Context, mgr manager.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}, VAScaleTargetKey, VAScaleTargetIndexFunc); err != nil {
 		return fmt.Errorf("failed to set up index by scale target for VariantAutoscaling: %w", err)
 	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}, VAScaleTargetSelectorKey, VAScaleTargetSelectorIndexFunc); err != nil {
+		return fmt.Errorf("failed to set up index by scale target selector for VariantAutoscaling: %w", err)
+	}
 	return nil
 }
 
 // VAScaleTargetIndexFunc is the index function for VariantAutoscaling by scale target.
 func VAScaleTargetIndexFunc(o client.Object) []string {
 	va := o.(*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
-	if va.Spec.ScaleTargetRef.Kind == "" || va.Spec.ScaleTargetRef.Name == "" {
+	ref := va.ScaleTargetReference()
+	if ref.Kind == "" || ref.Name == "" {
+		return nil
+	}
+	return []string{scaleTargetIndexKey(va.Namespace, ref)}
+}
+
+// VAScaleTargetSelectorIndexFunc is the index function for VariantAutoscaling by presence of
+// a scale target selector.
+func VAScaleTargetSelectorIndexFunc(o client.Object) []string {
+	va := o.(*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
+	if va.Spec.ScaleTargetSelector == nil {
 		return nil
 	}
-	return []string{scaleTargetIndexKey(va.Namespace, va.Spec.ScaleTargetRef)}
+	return []string{scaleTargetSelectorIndexValue}
+}
+
+// FindVAsWithScaleTargetSelector returns the VariantAutoscalings of a namespace that select
+// their scale target by labels.
+func FindVAsWithScaleTargetSelector(ctx context.Context, c client.Client, namespace string) ([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, error) {
+	var vaList llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := c.List(ctx, &vaList,
+		client.InNamespace(namespace),
+		client.MatchingFields{VAScaleTargetSelectorKey: scaleTargetSelectorIndexValue},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list VariantAutoscalings with a scale target selector in %s: %w", namespace, err)
+	}
+	return vaList.Items, nil
 }
 
 // FindVAForScaleTarget returns the VariantAutoscaling that targets the given scale resource.
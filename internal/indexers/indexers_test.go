@@ -390,4 +390,57 @@ var _ = Describe("Indexers", Ordered, func() {
 			}).Should(Equal("va-without-apiversion"))
 		})
 	})
+
+	Describe("FindVAsWithScaleTargetSelector", func() {
+		It("should only return the VAs selecting their scale target by labels", func() {
+			selecting := &llmdv1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "va-with-selector",
+					Namespace: namespace,
+				},
+				Spec: llmdv1alpha1.VariantAutoscalingSpec{
+					ScaleTargetSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "llama"},
+					},
+					ModelID: "model-selector",
+				},
+			}
+			referencing := &llmdv1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "va-with-ref",
+					Namespace: namespace,
+				},
+				Spec: llmdv1alpha1.VariantAutoscalingSpec{
+					ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "test-deploy-ref",
+					},
+					ModelID: "model-ref",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, selecting)).To(Succeed())
+			Expect(k8sClient.Create(testCtx, referencing)).To(Succeed())
+			defer func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(testCtx, selecting))).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(testCtx, referencing))).To(Succeed())
+			}()
+
+			Eventually(func() []string {
+				vas, err := FindVAsWithScaleTargetSelector(testCtx, mgrClient, namespace)
+				if err != nil {
+					return nil
+				}
+				names := make([]string, 0, len(vas))
+				for _, va := range vas {
+					names = append(names, va.Name)
+				}
+				return names
+			}).Should(ConsistOf("va-with-selector"))
+
+			vas, err := FindVAsWithScaleTargetSelector(testCtx, mgrClient, "other-namespace")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vas).To(BeEmpty())
+		})
+	})
 })
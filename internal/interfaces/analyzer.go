@@ -21,6 +21,18 @@ type Analyzer interface {
 	Analyze(ctx context.Context, input AnalyzerInput) (*AnalyzerResult, error)
 }
 
+// ReplicaMetricsEnricher adds custom fields (ReplicaMetrics.Custom) to the replica
+// metrics of a model before they are analyzed, e.g. business-specific load factors
+// for custom analyzers to consume.
+type ReplicaMetricsEnricher interface {
+	// Name returns the enricher's identifier, used in logs.
+	Name() string
+
+	// Enrich adds custom fields to the metrics of the replicas of a model in a namespace.
+	// It must only modify the Custom field of the given metrics.
+	Enrich(ctx context.Context, modelID, namespace string, metrics []ReplicaMetrics) error
+}
+
 // AnalyzerConfig is the interface for analyzer-specific configuration.
 // Each analyzer defines its own config type that implements this interface.
 type AnalyzerConfig interface {
@@ -41,6 +53,11 @@ type AnalyzerInput struct {
 	// before reaching any vLLM pod and contribute to demand estimation.
 	// Nil when flow control is disabled or metrics are unavailable.
 	SchedulerQueue *SchedulerQueueMetrics
+
+	// GatewayTraffic holds the model-level rates of requests the gateway admitted and
+	// rejected, correcting the demand for the requests shed during overload.
+	// Nil when load shedding correction is disabled or metrics are unavailable.
+	GatewayTraffic *GatewayTrafficMetrics
 }
 
 // SchedulerQueueMetrics holds model-level queue metrics from the llm-d
@@ -64,6 +81,34 @@ type SchedulerQueueMetrics struct {
 	QueueBytes int64
 }
 
+// GatewayTrafficMetrics holds the model-level request rates of the llm-d inference
+// gateway (inference_objective_request_*). When the gateway rejects or sheds requests
+// during overload, the arrival rate seen by vLLM only counts the admitted requests and
+// understates the demand.
+//
+// TODO(#2309): As for SchedulerQueueMetrics, the upstream metrics lack a namespace label.
+type GatewayTrafficMetrics struct {
+	// AcceptedRate is the rate of requests the gateway admitted, in requests per minute.
+	AcceptedRate float64
+
+	// RejectedRate is the rate of requests the gateway rejected or shed because the
+	// model was overloaded, in requests per minute.
+	RejectedRate float64
+}
+
+// DemandCorrection returns the factor scaling the demand of the admitted requests to the
+// demand of all requests, (accepted + rejected) / accepted, capped at maxFactor. It is 1
+// when no request was rejected, and maxFactor when all were.
+func (g *GatewayTrafficMetrics) DemandCorrection(maxFactor float64) float64 {
+	if g == nil || g.RejectedRate <= 0 {
+		return 1
+	}
+	if g.AcceptedRate <= 0 {
+		return maxFactor
+	}
+	return min((g.AcceptedRate+g.RejectedRate)/g.AcceptedRate, maxFactor)
+}
+
 // AnalyzerResult is the common output produced by all analyzers.
 // The engine consumes these results to build scaling plans.
 type AnalyzerResult struct {
@@ -98,6 +143,10 @@ type VariantCapacity struct {
 	ReplicaCount    int
 	PendingReplicas int
 
+	// FailedReplicas is the number of replicas on failing GPUs. They have no capacity
+	// and are not counted in ReplicaCount.
+	FailedReplicas int
+
 	// PerReplicaCapacity is the representative capacity per replica.
 	// For saturation V2: median(effectiveCapacity) in tokens across ready replicas.
 	PerReplicaCapacity float64
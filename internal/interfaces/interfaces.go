@@ -23,6 +23,8 @@ type ModelAnalyzer interface {
 	) (*ModelAnalyzeResponse, error)
 }
 
+// Actuator reads the current replicas of scale targets and publishes the metrics
+// external autoscalers scale them on.
 type Actuator interface {
 	// EmitMetrics publishes metrics for external autoscalers (e.g., HPA, KEDA).
 	// This includes real-time current state and Inferno's optimization targets.
@@ -30,4 +32,42 @@ type Actuator interface {
 		ctx context.Context,
 		VariantAutoscalings *llmdOptv1alpha1.VariantAutoscaling,
 	) error
+
+	// EmitReplicaMetrics publishes the given current and desired replicas of a variant,
+	// e.g. fallback targets when optimization failed.
+	EmitReplicaMetrics(
+		ctx context.Context,
+		va *llmdOptv1alpha1.VariantAutoscaling,
+		current, desired int32,
+		acceleratorType string,
+	) error
+
+	// GetCurrentDeploymentReplicas returns the current replicas of the scale target of a variant.
+	GetCurrentDeploymentReplicas(
+		ctx context.Context,
+		va *llmdOptv1alpha1.VariantAutoscaling,
+	) (int32, error)
+}
+
+// ReplicaMetricsCollector collects the metrics of the replicas of the variants of a model.
+type ReplicaMetricsCollector interface {
+	// CollectReplicaMetrics collects the metrics of the replicas of the given variants.
+	// Scale target workloads are keyed by their namespace/name, variants and their costs
+	// by VA namespace/name.
+	CollectReplicaMetrics(
+		ctx context.Context,
+		modelID string,
+		namespace string,
+		workloads map[string]Workload,
+		variantAutoscalings map[string]*llmdOptv1alpha1.VariantAutoscaling,
+		variantCosts map[string]float64,
+	) ([]ReplicaMetrics, error)
+}
+
+// GatewayTrafficCollector is implemented by ReplicaMetricsCollectors that also collect the
+// requests the gateway admitted and rejected for a model.
+type GatewayTrafficCollector interface {
+	// CollectGatewayTraffic returns the gateway request rates of a model, or nil when the
+	// gateway metrics are unavailable.
+	CollectGatewayTraffic(ctx context.Context, modelID string) *GatewayTrafficMetrics
 }
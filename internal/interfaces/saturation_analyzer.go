@@ -6,104 +6,29 @@ import (
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-)
-
-// ReplicaMetrics holds capacity-related metrics for a single replica
-type ReplicaMetrics struct {
-	PodName         string
-	KvCacheUsage    float64 // KV cache utilization (0.0-1.0)
-	QueueLength     int     // Number of requests waiting
-	VariantName     string  // Name of the variant this replica belongs to
-	Namespace       string
-	ModelID         string  // Model ID for grouping variants
-	AcceleratorName string  // Accelerator type for this variant
-	Cost            float64 // Cost per replica (from CRD spec, default 10)
-	// Metadata contains freshness information (optional)
-	Metadata *ReplicaMetricsMetadata `json:"metadata,omitempty"`
-
-	// --- New fields for Saturation Analyzer V2 ---
-
-	// NumGpuBlocks is the total number of KV cache blocks allocated on GPU.
-	// Sourced from vllm:cache_config_info label "num_gpu_blocks".
-	// Zero value means cache_config_info metric is not available.
-	NumGpuBlocks int64
-
-	// BlockSize is the number of tokens per KV cache block.
-	// Sourced from vllm:cache_config_info label "block_size".
-	// Zero value means cache_config_info metric is not available.
-	BlockSize int64
-
-	// TotalKvCapacityTokens is NumGpuBlocks × BlockSize (total token slots).
-	// Computed by the collector after parsing cache_config_info labels.
-	// Zero value means capacity data is unavailable.
-	TotalKvCapacityTokens int64
-
-	// TokensInUse is the derived current token demand on this replica.
-	// Computed as KvCacheUsage × TotalKvCapacityTokens.
-	// Zero when TotalKvCapacityTokens is unavailable.
-	TokensInUse int64
-
-	// AvgOutputTokens is the average generation tokens per request on this replica.
-	// Derived from rate(generation_tokens_sum) / rate(generation_tokens_count).
-	// Zero when metrics are unavailable.
-	AvgOutputTokens float64
-
-	// AvgInputTokens is the average prompt tokens per request on this replica.
-	// Derived from rate(prompt_tokens_sum) / rate(prompt_tokens_count).
-	// Zero when metrics are unavailable.
-	AvgInputTokens float64
-
-	// PrefixCacheHitRate is the fraction of prefix cache queries that were hits (0.0-1.0).
-	// Derived from rate(vllm:prefix_cache_hits[5m]) / rate(vllm:prefix_cache_queries[5m]).
-	// Used to reduce estimated input token demand for scheduler-queued requests.
-	// Zero when prefix caching is disabled or metrics are unavailable.
-	PrefixCacheHitRate float64
-}
-
-// ReplicaMetricsMetadata contains freshness information for replica metrics
-type ReplicaMetricsMetadata struct {
-	// CollectedAt is when the metrics were collected
-	CollectedAt time.Time
-	// Age is the age of the metrics
-	Age time.Duration
-	// FreshnessStatus indicates freshness: "fresh", "stale", "unavailable"
-	FreshnessStatus string
-}
-
-// ModelSaturationAnalysis holds saturation analysis results for a model (across all variants)
-type ModelSaturationAnalysis struct {
-	ModelID    string
-	Namespace  string
-	AnalyzedAt time.Time // Timestamp when analysis was performed
 
-	// Aggregated metrics across all variants of this model
-	TotalReplicas       int
-	NonSaturatedCount   int // Replicas below saturation thresholds
-	AvgSpareKvCapacity  float64
-	AvgSpareQueueLength float64
-
-	// Scale decision recommendations
-	ShouldScaleUp bool
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/api/engines"
+)
 
-	ScaleUpReason string
-	ScaleDownSafe bool // Indicates if scale-down simulation passed
+// The replica metrics and the outputs of composite engines are part of the public engine API.
+type (
+	ReplicaMetrics         = engines.ReplicaMetrics
+	ReplicaMetricsMetadata = engines.ReplicaMetricsMetadata
+	EngineOutput           = engines.EngineOutput
+)
 
-	// Detailed variant breakdown
-	VariantAnalyses []VariantSaturationAnalysis
-}
+// Freshness statuses of replica metrics
+const (
+	FreshnessFresh       = engines.FreshnessFresh
+	FreshnessStale       = engines.FreshnessStale
+	FreshnessUnavailable = engines.FreshnessUnavailable
+)
 
-// VariantSaturationAnalysis holds saturation analysis for a single variant
-type VariantSaturationAnalysis struct {
-	VariantName         string
-	AcceleratorName     string
-	Cost                float64 // Cost per replica for this variant
-	ReplicaCount        int
-	NonSaturatedCount   int
-	MaxKvCacheUsage     float64
-	MaxQueueLength      int
-	AvgSpareKvCapacity  float64
-	AvgSpareQueueLength float64
-	SaturatedReplicas   []string // Pod names of saturated replicas
+// DiscardUnavailableReplicas returns the replica metrics without those of unavailable
+// freshness, and the number of replicas discarded.
+func DiscardUnavailableReplicas(replicaMetrics []ReplicaMetrics) ([]ReplicaMetrics, int) {
+	return engines.DiscardUnavailableReplicas(replicaMetrics)
 }
 
 // DecisionStep represents a single step in the decision pipeline.
@@ -140,6 +65,9 @@ type VariantDecision struct {
 	ModelID         string
 	AcceleratorName string
 	Cost            float64
+	// Tenant groups decisions for fair sharing of scarce resources
+	// (from the tenant label of the VariantAutoscaling, falling back to its namespace)
+	Tenant string
 
 	// --- Scaling state ---
 	Action                 SaturationAction
@@ -147,15 +75,24 @@ type VariantDecision struct {
 	TargetReplicas         int // Current target (modified by pipeline stages)
 	OriginalTargetReplicas int // Original target before resource limiting (for logging)
 	DesiredReplicas        int // Original desired replicas from optimizer (from CRD status)
+	ReadyReplicas          int // Replicas ready to serve traffic
+	ReportingReplicas      int // Replicas reporting saturation metrics
+	UnschedulableReplicas  int // Pending replicas that cannot be scheduled for lack of GPUs
+	DrainingReplicas       int // Replicas on cordoned or draining nodes, about to be evicted
 
 	// --- Resource requirements (for resource limiting) ---
-	GPUsPerReplica int // GPUs required per replica
+	// GPUsPerReplica is the number of physical GPUs required per replica, fractional when
+	// the containers of a replica share GPUs with time-slicing or MPS
+	GPUsPerReplica float64
 	// SpareCapacity indicates how much spare capacity this variant has.
 	// 0.0 = fully saturated, 1.0 = completely idle.
 	// Used by allocation algorithms to prioritize saturated variants.
 	SpareCapacity float64
 	// ScaleTargetRef references the Deployment/StatefulSet for scheduling constraints
 	ScaleTargetRef *autoscalingv1.CrossVersionObjectReference
+	// PerReplicaCapacity is the capacity of a replica in analyzer-specific units
+	// (0 if the analyzer does not measure it)
+	PerReplicaCapacity float64
 
 	// --- Pipeline tracking ---
 	// DecisionSteps records each pipeline stage's contribution to the final decision.
@@ -177,12 +114,146 @@ type VariantDecision struct {
 	CurrentAllocation *Allocation
 
 	// --- Resource limiting results ---
-	// GPUsAllocated is the number of GPUs allocated by the resource limiter
-	GPUsAllocated int
+	// GPUsAllocated is the number of GPUs allocated by the resource limiter, fractional
+	// when GPUsPerReplica is
+	GPUsAllocated float64
 	// WasLimited indicates if the target was constrained by resource limits
 	WasLimited bool
 	// LimitedBy identifies which limiter constrained the decision (if any)
 	LimitedBy string
+	// LimitReason is the machine-readable cause of the limit (if any)
+	LimitReason LimitReason
+	// LimitMessage explains the limit in human-readable form (if any)
+	LimitMessage string
+	// CapacityCeiling is the most replicas the cluster could place on the variant's
+	// accelerator type (0 if unknown)
+	CapacityCeiling int
+	// CappedByCapacity indicates if the target was capped at CapacityCeiling
+	CappedByCapacity bool
+
+	// --- Model replica limit ---
+	// PolicyCapped indicates the target was reduced to keep the total replicas of the
+	// model, across all its variants and namespaces, within PolicyMaxReplicas
+	PolicyCapped bool
+	// PolicyMaxReplicas is the replica limit of the model when PolicyCapped is set
+	PolicyMaxReplicas int
+	// PolicyReason explains the replica limit of the model (if any)
+	PolicyReason string
+
+	// --- Schedulability gating results ---
+	// TargetUnschedulable indicates pods of the scale target are Pending for lack of
+	// GPUs, so scale-ups are held
+	TargetUnschedulable bool
+
+	// --- PodDisruptionBudget guard ---
+	// PDBMinReplicas is the fewest replicas keeping the PodDisruptionBudgets of the
+	// scale target satisfiable (0 if unconstrained)
+	PDBMinReplicas int
+	// PDBName is the PodDisruptionBudget requiring PDBMinReplicas (if any)
+	PDBName string
+	// PDBConflict indicates a scale-down was clamped to keep the PodDisruptionBudget
+	// of the scale target satisfiable
+	PDBConflict bool
+
+	// --- HPA bounds check ---
+	// HPAName is the HorizontalPodAutoscaler of the scale target (empty if none)
+	HPAName string
+	// HPAMinReplicas and HPAMaxReplicas are the bounds of the HorizontalPodAutoscaler
+	HPAMinReplicas int
+	HPAMaxReplicas int
+	// BoundsConflict indicates the HorizontalPodAutoscaler bounds clamp the target,
+	// so the scale target will not reach it
+	BoundsConflict bool
+
+	// --- Overload signal ---
+	// ModelOverloaded indicates all the variants of the model are saturated and at the
+	// maxReplicas of their HorizontalPodAutoscaler, so the gateway is signaled to shed load
+	ModelOverloaded bool
+
+	// --- Accelerator fallback ---
+	// FallbackAccelerator is the accelerator type of the fallback class of the variant
+	// (empty without a fallback class)
+	FallbackAccelerator string
+	// FallbackReplicas is the number of replicas recommended on the fallback class while
+	// the accelerator of the variant is exhausted
+	FallbackReplicas int
+
+	// --- Zone constraint ---
+	// Zones are the zones the replicas of the variant are restricted to, where its model
+	// storage is available (empty if unrestricted)
+	Zones []string
+
+	// --- Minimum replicas override ---
+	// MinReplicas is the spec.minReplicas of the VA (0 if unset)
+	MinReplicas int
+
+	// --- Scaling behavior ---
+	// Behavior is the spec.behavior of the VA (nil if unset)
+	Behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior
+
+	// --- Warm-up ---
+	// CreatedAt is the creation time of the VA (zero if unknown)
+	CreatedAt time.Time
+	// WarmingUp indicates the VA is in its warm-up period, so its target is held at
+	// the current replicas
+	WarmingUp bool
+
+	// --- Error rate veto ---
+	// ErrorRate is the fraction of requests of the variant finished by an error or abort (0.0-1.0)
+	ErrorRate float64
+	// ErrorRateVeto indicates a scale-down was vetoed because the error rate is elevated
+	ErrorRateVeto bool
+
+	// --- Scale-down quorum ---
+	// ScaleDownQuorum counts the recent runs that proposed a scale-down of the variant
+	// (nil when the scale-down quorum is disabled)
+	ScaleDownQuorum *ScaleDownQuorum
+
+	// --- Drain surge ---
+	// DrainSurge is the number of replicas added to the target to cover the replicas
+	// about to be evicted from cordoned or draining nodes
+	DrainSurge int
+
+	// --- Traffic weights ---
+	// TrafficWeight is the recommended percentage (0-100) of the traffic of the model
+	// routed to the variant, set when HasTrafficWeight is true
+	TrafficWeight    int
+	HasTrafficWeight bool
+
+	// --- Concurrency tuning ---
+	// MaxNumSeqs is the max-num-seqs set on the replicas at runtime (0 if not tuned)
+	MaxNumSeqs int64
+	// ConcurrencyAbsorbed indicates the replica change was held because the
+	// max-num-seqs of the replicas changed instead
+	ConcurrencyAbsorbed bool
+
+	// --- Scale-up verification results ---
+	// ScaleUpIneffective indicates a previous scale-up did not reduce saturation,
+	// so further scale-ups are held
+	ScaleUpIneffective bool
+	// ScaleUpRolledBack indicates the ineffective scale-up was reverted
+	ScaleUpRolledBack bool
+	// ScaleUpMessage explains the verification outcome in human-readable form (if any)
+	ScaleUpMessage string
+
+	// --- Composite engine ---
+	// EngineOutputs records the decision of each engine of a composite engine (if any)
+	EngineOutputs []EngineOutput
+
+	// --- Latency budget ---
+	// LatencyBudget decomposes the TTFT of the variant against the TTFT SLO of its model
+	// (nil without an SLO, a profile of the accelerator or TTFT metrics)
+	LatencyBudget *LatencyBudget
+
+	// --- Simulation ---
+	// Prediction is the performance the queueing model predicts for TargetReplicas (nil
+	// unless the decision changes replicas by more than one and the accelerator has a profile)
+	Prediction *Prediction
+
+	// --- Scale to zero ---
+	// RequestRate is the request rate of the model seen by idle detection
+	// (nil when scale-to-zero is disabled or the request count is unknown)
+	RequestRate *RequestRate
 
 	// --- Metrics availability ---
 	// MetricsAvailable indicates whether saturation metrics were available for this decision
@@ -191,6 +262,23 @@ type VariantDecision struct {
 	MetricsReason string
 	// MetricsMessage is the human-readable message for the MetricsAvailable condition
 	MetricsMessage string
+
+	// --- Spec drift ---
+	// ObservedGeneration is the generation of the VA spec the decision was based on
+	ObservedGeneration int64
+	// ConfigHash is the fingerprint of the effective configuration the decision was based on
+	ConfigHash string
+
+	// --- Optimization readiness ---
+	// OptimizationReason is the reason for the OptimizationReady condition (if any)
+	OptimizationReason string
+	// OptimizationMessage is the human-readable message for the OptimizationReady condition
+	OptimizationMessage string
+
+	// --- Failure ---
+	// Error is the error that kept the variant from being optimized in this run, if any.
+	// It wraps a sentinel error of pkg/errors, which the controller maps to a condition.
+	Error error
 }
 
 // AddDecisionStep adds a step to the decision pipeline history.
@@ -215,6 +303,93 @@ func (d *VariantDecision) LastStep() *DecisionStep {
 	return &d.DecisionSteps[len(d.DecisionSteps)-1]
 }
 
+// ReplicaGPUs returns the GPUs required per replica, 1 if GPUsPerReplica is not set.
+func (d *VariantDecision) ReplicaGPUs() float64 {
+	if d.GPUsPerReplica <= 0 {
+		return 1
+	}
+	return d.GPUsPerReplica
+}
+
+// ScaleUpGrant returns the replicas the decision asked to add before resource limiting
+// and the replicas it may add after it. Both are 0 for scale-downs and no-ops.
+func (d *VariantDecision) ScaleUpGrant() (requested, granted int) {
+	requested = max(d.OriginalTargetReplicas-d.CurrentReplicas, 0)
+	granted = min(max(d.TargetReplicas-d.CurrentReplicas, 0), requested)
+	return requested, granted
+}
+
+// LatencyBudget decomposes the time to first token of a variant into queueing and prefill time.
+// All times are in msec.
+type LatencyBudget struct {
+	// TargetTTFT is the TTFT SLO of the service class of the model
+	TargetTTFT float64
+	// ObservedTTFT is the average TTFT of the replicas
+	ObservedTTFT float64
+	// QueueingTime is the estimated time requests wait in the queue of a replica
+	QueueingTime float64
+	// PrefillTime is the estimated prefill time of a request
+	PrefillTime float64
+}
+
+// Prediction is the performance the queueing model predicts for a number of replicas of a
+// variant, at the request rate its replicas serve. Times are in msec.
+type Prediction struct {
+	// Replicas is the number of replicas the prediction is for
+	Replicas int
+	// ArrivalRate is the estimated request rate of the variant (requests/sec)
+	ArrivalRate float64
+	// TTFT is the predicted average time to first token (0 when Overloaded)
+	TTFT float64
+	// ITL is the predicted average inter-token latency (0 when Overloaded)
+	ITL float64
+	// Utilization is the predicted utilization of a replica, from 0 to 1
+	Utilization float64
+	// Overloaded indicates the replicas are predicted not to keep up with the request rate
+	Overloaded bool
+	// SLOViolation is the relative violation of the TTFT and ITL SLOs of the model by the
+	// predicted latencies, the larger of TTFT/targetTTFT-1 and ITL/targetITL-1 (0 when
+	// the SLOs are met, the model has none or Overloaded)
+	SLOViolation float64
+}
+
+// RequestRate is the request rate of a model over its scale-to-zero retention period, as
+// seen by idle detection. All rates are in requests/min.
+type RequestRate struct {
+	// Observed is the average request rate
+	Observed float64
+	// NoiseFloor is the rate at or below which the model is considered idle
+	NoiseFloor float64
+	// Filtered is the rate idle detection uses: 0 when Observed is at or below NoiseFloor
+	Filtered float64
+}
+
+// ScaleDownQuorum counts the optimization runs that proposed a scale-down of a variant
+// within a rolling window of runs.
+type ScaleDownQuorum struct {
+	// Approvals is the number of runs of the window that proposed a scale-down
+	Approvals int
+	// Window is the number of most recent runs considered
+	Window int
+	// Required is the number of approvals a scale-down needs to be applied
+	Required int
+}
+
+// LimitReason is the cause of a resource limiter reducing a scale-up.
+type LimitReason string
+
+const (
+	// LimitReasonInsufficientCapacity: not enough free GPUs of the accelerator type
+	LimitReasonInsufficientCapacity LimitReason = "InsufficientCapacity"
+	// LimitReasonTenantQuota: the GPUs went to other tenants under fair sharing
+	LimitReasonTenantQuota LimitReason = "TenantQuota"
+	// LimitReasonPriorityPreemption: the GPUs went to variants allocated first (more saturated or cheaper)
+	LimitReasonPriorityPreemption LimitReason = "PriorityPreemption"
+	// LimitReasonPoolReservation: free GPUs of the accelerator type are held by other controller
+	// instances sharing the pool
+	LimitReasonPoolReservation LimitReason = "PoolReservation"
+)
+
 // SaturationAction represents the scaling action
 type SaturationAction string
 
@@ -224,6 +399,43 @@ const (
 	ActionNoChange  SaturationAction = "no-change"
 )
 
+// ModelSaturationAnalysis holds saturation analysis results for a model (across all variants)
+type ModelSaturationAnalysis struct {
+	ModelID    string
+	Namespace  string
+	AnalyzedAt time.Time // Timestamp when analysis was performed
+
+	// Aggregated metrics across all variants of this model
+	TotalReplicas       int
+	NonSaturatedCount   int // Replicas below saturation thresholds
+	AvgSpareKvCapacity  float64
+	AvgSpareQueueLength float64
+
+	// Scale decision recommendations
+	ShouldScaleUp bool
+
+	ScaleUpReason string
+	ScaleDownSafe bool // Indicates if scale-down simulation passed
+
+	// Detailed variant breakdown
+	VariantAnalyses []VariantSaturationAnalysis
+}
+
+// VariantSaturationAnalysis holds saturation analysis for a single variant
+type VariantSaturationAnalysis struct {
+	VariantName         string
+	AcceleratorName     string
+	Cost                float64 // Cost per replica for this variant
+	ReplicaCount        int
+	NonSaturatedCount   int
+	MaxKvCacheUsage     float64
+	MaxQueueLength      int
+	AvgSpareKvCapacity  float64
+	AvgSpareQueueLength float64
+	SaturatedReplicas   []string // Pod names of saturated replicas
+	FailedReplicas      int      // Replicas on failing GPUs, counted as saturated without spare capacity
+}
+
 // VariantReplicaState holds the current and desired replica counts for a variant
 type VariantReplicaState struct {
 	VariantName     string
@@ -236,10 +448,43 @@ type VariantReplicaState struct {
 	// WVA uses this to prevent cascade scaling - avoiding new scale-up requests
 	// while pending pods are still becoming ready.
 	PendingReplicas int
-	// GPUsPerReplica is the number of GPUs required per replica, extracted from
-	// the deployment's container resource requests (nvidia.com/gpu, amd.com/gpu, etc.).
+	// GPUsPerReplica is the number of physical GPUs required per replica, extracted from
+	// the deployment's container resource requests (nvidia.com/gpu, amd.com/gpu, etc.)
+	// divided by the number of containers each GPU of the accelerator is shared by.
 	// Defaults to 1 if no GPU requests are found.
-	GPUsPerReplica int
+	GPUsPerReplica float64
+	// ReportingReplicas is the number of replicas that reported saturation metrics
+	// in the current optimization run.
+	ReportingReplicas int
+	// UnschedulableReplicas is the number of Pending pods the scheduler could not
+	// place for lack of free GPUs.
+	UnschedulableReplicas int
+	// DrainingReplicas is the number of pods running on cordoned or draining nodes,
+	// about to be evicted (0 unless drain-aware scaling is enabled).
+	DrainingReplicas int
+	// PDBMinReplicas is the fewest replicas the scale target can run while the
+	// PodDisruptionBudgets selecting its pods stay satisfiable (0 if unconstrained).
+	PDBMinReplicas int
+	// PDBName is the name of the PodDisruptionBudget requiring PDBMinReplicas.
+	PDBName string
+	// HPAName is the name of the HorizontalPodAutoscaler of the scale target,
+	// empty if it has none.
+	HPAName string
+	// HPAMinReplicas and HPAMaxReplicas are the bounds of the HorizontalPodAutoscaler.
+	HPAMinReplicas int
+	HPAMaxReplicas int
+	// Zones are the zones the replicas are restricted to, where the storage of the
+	// model is available (empty if unrestricted).
+	Zones []string
+	// MinReplicas is the spec.minReplicas of the VariantAutoscaling (0 if unset).
+	MinReplicas int
+	// Behavior is the spec.behavior of the VariantAutoscaling (nil if unset).
+	Behavior *llmdVariantAutoscalingV1alpha1.ScalingBehavior
+	// CreatedAt is the creation time of the VariantAutoscaling.
+	CreatedAt time.Time
+	// ErrorRate is the average fraction of requests finished by an error or abort
+	// over the replicas that reported metrics (0.0-1.0).
+	ErrorRate float64
 }
 
 // SaturationAnalyzer analyzes replica saturation metrics and recommends scaling decisions
@@ -1,108 +1,21 @@
 package interfaces
 
-import "fmt"
+import "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/api/engines"
 
-// SaturationScalingConfig holds saturation-based scaling thresholds for a model variant.
-// Saturation scaling is enabled by default and uses these thresholds to determine when
-// replicas are saturated and when to scale up.
-type SaturationScalingConfig struct {
-	// ModelID is the model identifier (only used in override entries)
-	ModelID string `yaml:"model_id,omitempty"`
+// SaturationScalingConfig is part of the public engine API.
+type SaturationScalingConfig = engines.SaturationScalingConfig
 
-	// Namespace is the namespace for this override (only used in override entries)
-	Namespace string `yaml:"namespace,omitempty"`
-
-	// KvCacheThreshold: Replica is saturated if KV cache utilization >= this threshold (0.0-1.0)
-	KvCacheThreshold float64 `yaml:"kvCacheThreshold"`
-
-	// QueueLengthThreshold: Replica is saturated if queue length >= this threshold
-	QueueLengthThreshold float64 `yaml:"queueLengthThreshold"`
-
-	// KvSpareTrigger: Scale-up if average spare KV cache capacity < this value (0.0-1.0)
-	KvSpareTrigger float64 `yaml:"kvSpareTrigger"`
-
-	// QueueSpareTrigger: Scale-up if average spare queue capacity < this value
-	QueueSpareTrigger float64 `yaml:"queueSpareTrigger"`
-
-	// EnableLimiter: When true, includes the GPU limiter in the scaling pipeline
-	// to constrain scaling decisions based on available cluster resources.
-	// Default is false (limiter disabled).
-	EnableLimiter bool `yaml:"enableLimiter,omitempty"`
-
-	// AnalyzerName selects which analyzer to use.
-	// "saturation" uses the V2 token-based analyzer.
-	// Empty string (default) uses the V1 percentage-based analyzer.
-	AnalyzerName string `yaml:"analyzerName,omitempty"`
-
-	// ScaleUpThreshold is the utilization threshold above which scale-up is triggered.
-	// Used by V2 analyzer: requiredCapacity = totalDemand / ScaleUpThreshold - anticipatedSupply
-	// Default: 0.85 (85% utilization triggers scale-up)
-	ScaleUpThreshold float64 `yaml:"scaleUpThreshold,omitempty"`
-
-	// ScaleDownBoundary is the utilization boundary below which scale-down is safe.
-	// Used by V2 analyzer: spareCapacity = currentSupply - totalDemand / ScaleDownBoundary
-	// Default: 0.70 (70% utilization allows scale-down)
-	ScaleDownBoundary float64 `yaml:"scaleDownBoundary,omitempty"`
-}
-
-// GetAnalyzerName implements the AnalyzerConfig interface.
-func (c *SaturationScalingConfig) GetAnalyzerName() string {
-	return c.AnalyzerName
-}
-
-// V2 analyzer default thresholds, applied when fields are omitted from YAML config.
+// GPU limiter policies.
 const (
-	DefaultScaleUpThreshold  = 0.85
-	DefaultScaleDownBoundary = 0.70
+	LimiterPolicyGreedyBySaturation = engines.LimiterPolicyGreedyBySaturation
+	LimiterPolicyMaxMinFairness     = engines.LimiterPolicyMaxMinFairness
 )
 
-// ApplyDefaults fills in zero-valued V2 fields with their defaults.
-// Must be called before Validate() to handle omitempty zero-values correctly.
-func (c *SaturationScalingConfig) ApplyDefaults() {
-	if c.AnalyzerName == "saturation" {
-		if c.ScaleUpThreshold == 0 {
-			c.ScaleUpThreshold = DefaultScaleUpThreshold
-		}
-		if c.ScaleDownBoundary == 0 {
-			c.ScaleDownBoundary = DefaultScaleDownBoundary
-		}
-	}
-}
-
-// Validate checks for invalid threshold values.
-// Returns error with descriptive message if validation fails.
-// Call ApplyDefaults() before Validate() to handle zero-valued omitempty fields.
-func (c *SaturationScalingConfig) Validate() error {
-	if c.KvCacheThreshold < 0 || c.KvCacheThreshold > 1 {
-		return fmt.Errorf("kvCacheThreshold must be between 0 and 1, got %.2f", c.KvCacheThreshold)
-	}
-	if c.QueueLengthThreshold < 0 {
-		return fmt.Errorf("queueLengthThreshold must be >= 0, got %.1f", c.QueueLengthThreshold)
-	}
-	if c.KvSpareTrigger < 0 || c.KvSpareTrigger > 1 {
-		return fmt.Errorf("kvSpareTrigger must be between 0 and 1, got %.2f", c.KvSpareTrigger)
-	}
-	if c.QueueSpareTrigger < 0 {
-		return fmt.Errorf("queueSpareTrigger must be >= 0, got %.1f", c.QueueSpareTrigger)
-	}
-	// KV cache threshold should be greater than spare trigger (otherwise contradictory)
-	if c.KvCacheThreshold < c.KvSpareTrigger {
-		return fmt.Errorf("kvCacheThreshold (%.2f) should be >= kvSpareTrigger (%.2f)",
-			c.KvCacheThreshold, c.KvSpareTrigger)
-	}
-
-	// V2 analyzer threshold validation
-	if c.AnalyzerName == "saturation" {
-		if c.ScaleUpThreshold <= 0 || c.ScaleUpThreshold > 1 {
-			return fmt.Errorf("scaleUpThreshold must be in (0, 1], got %.2f", c.ScaleUpThreshold)
-		}
-		if c.ScaleDownBoundary <= 0 || c.ScaleDownBoundary > 1 {
-			return fmt.Errorf("scaleDownBoundary must be in (0, 1], got %.2f", c.ScaleDownBoundary)
-		}
-		if c.ScaleUpThreshold <= c.ScaleDownBoundary {
-			return fmt.Errorf("scaleUpThreshold (%.2f) must be > scaleDownBoundary (%.2f)", c.ScaleUpThreshold, c.ScaleDownBoundary)
-		}
-	}
-
-	return nil
-}
+// Defaults of the saturation scaling config.
+const (
+	DefaultTenantLabel                 = engines.DefaultTenantLabel
+	DefaultScaleDownErrorRateThreshold = engines.DefaultScaleDownErrorRateThreshold
+	DefaultScaleUpThreshold            = engines.DefaultScaleUpThreshold
+	DefaultScaleDownBoundary           = engines.DefaultScaleDownBoundary
+	DefaultMaxLoadSheddingCorrection   = engines.DefaultMaxLoadSheddingCorrection
+)
@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Workload is the scale target of a variant, seen as replicas of a model server.
+// A replica of a Deployment is a pod; a replica of a LeaderWorkerSet is a group of a
+// leader pod and worker pods serving one instance of the model across nodes, e.g. with
+// multi-node tensor parallelism.
+type Workload interface {
+	client.Object
+
+	// WorkloadKind returns the kind of the workload, e.g. Deployment.
+	WorkloadKind() string
+	// SpecReplicas returns spec.replicas, nil if unset.
+	SpecReplicas() *int32
+	// StatusReplicas returns the replicas created, ready or not.
+	StatusReplicas() int32
+	// ReadyReplicas returns the replicas whose pods are all ready.
+	ReadyReplicas() int32
+	// PodTemplate returns the template of the pod of a replica running the model server,
+	// whose arguments configure vLLM and which exposes the metrics of the replica: the
+	// leader of a LeaderWorkerSet.
+	PodTemplate() *corev1.PodTemplateSpec
+	// GPUsPerReplica returns the GPUs requested by all pods of a replica, 0 if none.
+	GPUsPerReplica() int
+	// PodSelector returns the selector of all pods of the workload.
+	PodSelector() (labels.Selector, error)
+	// ReplicaOf returns the replica a pod of the workload belongs to, unique within the
+	// workload.
+	ReplicaOf(pod *corev1.Pod) string
+}
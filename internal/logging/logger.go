@@ -2,7 +2,9 @@ package logging
 
 import (
 	"flag"
+	"math"
 
+	"github.com/go-logr/logr"
 	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -18,6 +20,9 @@ const (
 )
 
 // InitLogging initializes the controller-runtime logger with zap backend.
+// Unless -zap-log-level is set, entries are filtered by the -v verbosity, or by the
+// verbosity of their module if set with SetModuleVerbosity. Names are redacted once
+// enabled with SetRedaction.
 func InitLogging(opts *zap.Options, logVerbosity *int) {
 	// Unless -zap-log-level is explicitly set, use -v
 	useV := true
@@ -26,14 +31,16 @@ func InitLogging(opts *zap.Options, logVerbosity *int) {
 			useV = false
 		}
 	})
+	filterVerbosity.Store(useV)
 	if useV {
+		// zap logs at all verbosities; the module sink filters entries by verbosity.
 		// See https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/log/zap#Options.Level
-		lvl := -1 * (*logVerbosity)
-		opts.Level = uberzap.NewAtomicLevelAt(zapcore.Level(int8(lvl)))
+		defaultVerbosity.Store(int32(*logVerbosity))
+		opts.Level = uberzap.NewAtomicLevelAt(zapcore.Level(math.MinInt8))
 	}
 
 	logger := zap.New(zap.UseFlagOptions(opts), zap.RawZapOpts(uberzap.AddCaller()))
-	ctrl.SetLogger(logger)
+	ctrl.SetLogger(logr.New(newModuleSink(logger.GetSink())))
 }
 
 // Sync flushes any buffered log entries.
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestParseModuleVerbosity(t *testing.T) {
+	levels, err := ParseModuleVerbosity(" solver=5, collector=0,")
+	if err != nil {
+		t.Fatalf("ParseModuleVerbosity() failed: %v", err)
+	}
+	if len(levels) != 2 || levels[ModuleSolver] != 5 || levels[ModuleCollector] != 0 {
+		t.Errorf("Expected solver=5,collector=0, got %v", levels)
+	}
+
+	for _, value := range []string{"planner=3", "solver", "solver=-1", "solver=high"} {
+		if _, err := ParseModuleVerbosity(value); err == nil {
+			t.Errorf("Expected ParseModuleVerbosity(%q) to fail", value)
+		}
+	}
+}
+
+func TestModuleVerbosity(t *testing.T) {
+	defer defaultVerbosity.Store(defaultVerbosity.Load())
+	defer SetModuleVerbosity(ModuleVerbosity())
+	defaultVerbosity.Store(DEFAULT)
+	SetModuleVerbosity(map[string]int{ModuleSolver: TRACE, ModuleCollector: 0})
+
+	var lines []string
+	sink := funcr.New(func(prefix, args string) {
+		lines = append(lines, prefix+" "+args)
+	}, funcr.Options{Verbosity: 10})
+	ctx := ctrl.LoggerInto(context.Background(), logr.New(newModuleSink(sink.GetSink())))
+
+	logged := func(ctx context.Context, level int) bool {
+		lines = nil
+		ctrl.LoggerFrom(ctx).V(level).Info("test")
+		return len(lines) == 1
+	}
+
+	if !logged(ctx, DEFAULT) || logged(ctx, VERBOSE) {
+		t.Error("Expected loggers outside of modules to log at the default verbosity")
+	}
+
+	solverCtx := IntoModule(ctx, ModuleSolver)
+	if !logged(solverCtx, TRACE) {
+		t.Error("Expected the solver to log at its own verbosity")
+	}
+	if !strings.HasPrefix(lines[0], "solver ") {
+		t.Errorf("Expected the solver logger to be named after the module, got %q", lines[0])
+	}
+	if logged(IntoModule(ctx, ModuleSaturation), VERBOSE) {
+		t.Error("Expected modules without verbosity to log at the default verbosity")
+	}
+
+	// the innermost module applies, and re-entering a module does not rename the logger
+	collectorCtx := IntoModule(solverCtx, ModuleCollector)
+	if logged(collectorCtx, DEFAULT) || !logged(collectorCtx, 0) {
+		t.Error("Expected the collector to log at its own verbosity when called by the solver")
+	}
+	if IntoModule(solverCtx, ModuleSolver) != solverCtx {
+		t.Error("Expected re-entering a module to keep the logger")
+	}
+
+	// changes apply to existing loggers
+	SetModuleVerbosity(nil)
+	if logged(solverCtx, TRACE) {
+		t.Error("Expected the solver to log at the default verbosity once its verbosity is unset")
+	}
+}
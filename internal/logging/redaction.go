@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+)
+
+// redactedKeys are the log keys whose values name namespaces or models.
+var redactedKeys = map[string]bool{
+	"namespace": true,
+	"Namespace": true,
+	"model":     true,
+	"modelID":   true,
+	"modelId":   true,
+	"model_id":  true,
+	"modelName": true,
+}
+
+// redact enables the redaction of namespace and model names.
+var redact atomic.Bool
+
+// SetRedaction enables or disables the redaction of the namespace and model names
+// logged under well-known keys (e.g. "namespace", "modelID"). Redacted names are
+// replaced by a short hash, so entries of the same model still correlate. Names in
+// messages and in composite values are not redacted.
+func SetRedaction(enabled bool) {
+	redact.Store(enabled)
+}
+
+// Redact returns the redacted form of a name.
+func Redact(name string) string {
+	if name == "" {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	return "redacted-" + hex.EncodeToString(sum[:4])
+}
+
+// redactKeysAndValues returns the key/value pairs with the names under redacted keys
+// replaced, if redaction is enabled.
+func redactKeysAndValues(keysAndValues []any) []any {
+	if !redact.Load() {
+		return keysAndValues
+	}
+	var redacted []any
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok || !redactedKeys[key] {
+			continue
+		}
+		name, ok := keysAndValues[i+1].(string)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]any, len(keysAndValues))
+			copy(redacted, keysAndValues)
+		}
+		redacted[i+1] = Redact(name)
+	}
+	if redacted == nil {
+		return keysAndValues
+	}
+	return redacted
+}
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestRedaction(t *testing.T) {
+	defer SetRedaction(false)
+
+	var line string
+	sink := funcr.New(func(prefix, args string) {
+		line = args
+	}, funcr.Options{})
+	logger := logr.New(newModuleSink(sink.GetSink()))
+
+	logger.Info("test", "namespace", "team-a", "modelID", "org/model", "pod", "pod-1")
+	if !strings.Contains(line, `"team-a"`) || !strings.Contains(line, `"org/model"`) {
+		t.Errorf("Expected names to be logged without redaction, got %s", line)
+	}
+
+	SetRedaction(true)
+	logger.WithValues("model", "org/model").Info("test", "namespace", "team-a", "pod", "pod-1", "replicas", 3)
+	if strings.Contains(line, "team-a") || strings.Contains(line, "org/model") {
+		t.Errorf("Expected names to be redacted, got %s", line)
+	}
+	if !strings.Contains(line, Redact("team-a")) || !strings.Contains(line, Redact("org/model")) {
+		t.Errorf("Expected redacted names to be logged, got %s", line)
+	}
+	if !strings.Contains(line, `"pod-1"`) || !strings.Contains(line, `"replicas"=3`) {
+		t.Errorf("Expected other values to be kept, got %s", line)
+	}
+
+	if Redact("team-a") != Redact("team-a") || Redact("team-a") == Redact("team-b") {
+		t.Error("Expected redaction to be stable and distinct per name")
+	}
+}
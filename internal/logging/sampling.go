@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type detailKey struct{}
+
+// WithDetail returns a context recording whether the current cycle logs full detail,
+// e.g. one entry per replica, or only summaries.
+func WithDetail(ctx context.Context, detail bool) context.Context {
+	return context.WithValue(ctx, detailKey{}, detail)
+}
+
+// Detailed reports whether the current cycle logs full detail. Contexts outside of
+// sampled cycles always do.
+func Detailed(ctx context.Context) bool {
+	detail, ok := ctx.Value(detailKey{}).(bool)
+	return !ok || detail
+}
+
+// DetailSampler selects the cycles that log full detail: the first one, then one in
+// every N. The others only log summaries.
+type DetailSampler struct {
+	every  uint64
+	cycles atomic.Uint64
+}
+
+// NewDetailSampler creates a sampler logging full detail one in every N cycles;
+// N below 2 logs full detail in every cycle.
+func NewDetailSampler(every int) *DetailSampler {
+	return &DetailSampler{every: uint64(max(every, 1))}
+}
+
+// Next starts a cycle, returning its context.
+func (s *DetailSampler) Next(ctx context.Context) context.Context {
+	cycle := s.cycles.Add(1) - 1
+	return WithDetail(ctx, cycle%s.every == 0)
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDetailSampler(t *testing.T) {
+	if !Detailed(context.Background()) {
+		t.Error("Expected contexts outside of sampled cycles to log full detail")
+	}
+
+	sampler := NewDetailSampler(3)
+	var detailed []bool
+	for range 7 {
+		detailed = append(detailed, Detailed(sampler.Next(context.Background())))
+	}
+	expected := []bool{true, false, false, true, false, false, true}
+	for i := range expected {
+		if detailed[i] != expected[i] {
+			t.Fatalf("Expected detailed cycles %v, got %v", expected, detailed)
+		}
+	}
+
+	sampler = NewDetailSampler(0)
+	for range 3 {
+		if !Detailed(sampler.Next(context.Background())) {
+			t.Fatal("Expected every cycle to log full detail without sampling")
+		}
+	}
+}
@@ -4,20 +4,44 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// scaleLatencyBuckets are the buckets (seconds) of the scale latency histograms, from a
+// fraction of an optimization interval to the stabilization windows of HPAs
+var scaleLatencyBuckets = []float64{5, 10, 15, 30, 45, 60, 90, 120, 180, 300, 600, 900}
+
 // ControllerInstanceEnvVar is the environment variable name for controller instance label
 const ControllerInstanceEnvVar = "CONTROLLER_INSTANCE"
 
 var (
-	replicaScalingTotal *prometheus.CounterVec
-	desiredReplicas     *prometheus.GaugeVec
-	currentReplicas     *prometheus.GaugeVec
-	desiredRatio        *prometheus.GaugeVec
+	replicaScalingTotal       *prometheus.CounterVec
+	desiredReplicas           *prometheus.GaugeVec
+	currentReplicas           *prometheus.GaugeVec
+	desiredRatio              *prometheus.GaugeVec
+	allocationFlaps           *prometheus.CounterVec
+	dampenedChanges           *prometheus.CounterVec
+	tenantGPUShortfall        *prometheus.GaugeVec
+	capacityShortfallReplicas *prometheus.GaugeVec
+	controllerQueueDepth      *prometheus.GaugeVec
+	controllerQueryLatency    *prometheus.GaugeVec
+	backpressureSlowdown      *prometheus.GaugeVec
+	deferredVariants          *prometheus.GaugeVec
+	trackedModels             *prometheus.GaugeVec
+	conditionTransitions      *prometheus.CounterVec
+	conditionStatus           *prometheus.GaugeVec
+	configInvalidEntries      *prometheus.GaugeVec
+	featureEnabled            *prometheus.GaugeVec
+	errorBudgetRemaining      *prometheus.GaugeVec
+	errorBudgetBurnRate       *prometheus.GaugeVec
+	modelOverloaded           *prometheus.GaugeVec
+	fallbackReplicas          *prometheus.GaugeVec
+	scaleDecisionLatency      *prometheus.HistogramVec
+	scaleActuationLatency     *prometheus.HistogramVec
 
 	// controllerInstance stores the optional controller instance identifier.
 	// When set, it's added as a label to all emitted metrics.
@@ -41,10 +65,30 @@ func InitMetrics(registry prometheus.Registerer) error {
 	// Build label sets based on whether controller_instance is configured
 	baseLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelAcceleratorType}
 	scalingLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelDirection, constants.LabelReason}
+	variantLabels := []string{constants.LabelVariantName, constants.LabelNamespace}
+	tenantLabels := []string{constants.LabelTenant}
+	conditionLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelConditionType, constants.LabelStatus, constants.LabelReason}
+	conditionStatusLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelConditionType}
+	configMapLabels := []string{constants.LabelConfigMap, constants.LabelNamespace}
+	controllerLabels := []string{}
+	featureLabels := []string{constants.LabelFeatureName, constants.LabelFeatureStage}
+	serviceClassLabels := []string{constants.LabelServiceClass}
+	modelLabels := []string{constants.LabelModelName, constants.LabelNamespace}
+	latencyLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelDirection}
 
 	if controllerInstance != "" {
 		baseLabels = append(baseLabels, constants.LabelControllerInstance)
 		scalingLabels = append(scalingLabels, constants.LabelControllerInstance)
+		variantLabels = append(variantLabels, constants.LabelControllerInstance)
+		tenantLabels = append(tenantLabels, constants.LabelControllerInstance)
+		conditionLabels = append(conditionLabels, constants.LabelControllerInstance)
+		conditionStatusLabels = append(conditionStatusLabels, constants.LabelControllerInstance)
+		configMapLabels = append(configMapLabels, constants.LabelControllerInstance)
+		controllerLabels = append(controllerLabels, constants.LabelControllerInstance)
+		featureLabels = append(featureLabels, constants.LabelControllerInstance)
+		serviceClassLabels = append(serviceClassLabels, constants.LabelControllerInstance)
+		modelLabels = append(modelLabels, constants.LabelControllerInstance)
+		latencyLabels = append(latencyLabels, constants.LabelControllerInstance)
 	}
 
 	replicaScalingTotal = prometheus.NewCounterVec(
@@ -75,6 +119,141 @@ func InitMetrics(registry prometheus.Registerer) error {
 		},
 		baseLabels,
 	)
+	allocationFlaps = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVAAllocationFlapsTotal,
+			Help: "Total number of pending scaling changes withdrawn or reversed before being applied",
+		},
+		variantLabels,
+	)
+	dampenedChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVADampenedChangesTotal,
+			Help: "Total number of scaling changes held back by the change dampener",
+		},
+		variantLabels,
+	)
+	tenantGPUShortfall = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVATenantGPUShortfall,
+			Help: "GPUs requested by a tenant's scale-up decisions that the GPU limiter could not grant",
+		},
+		tenantLabels,
+	)
+	capacityShortfallReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVACapacityShortfallReplicas,
+			Help: "Scale-up replicas of a variant that the GPU limiter could not grant",
+		},
+		baseLabels,
+	)
+	controllerQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAControllerQueueDepth,
+			Help: "Total depth of the controller's reconcile work queues",
+		},
+		controllerLabels,
+	)
+	controllerQueryLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAControllerQueryLatencySeconds,
+			Help: "Highest latency of the controller's metrics queries in the last optimization cycle",
+		},
+		controllerLabels,
+	)
+	backpressureSlowdown = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAControllerBackpressureSlowdown,
+			Help: "Factor by which back-pressure lengthens the reconcile interval of low-priority variants",
+		},
+		controllerLabels,
+	)
+	deferredVariants = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAControllerDeferredVariants,
+			Help: "Low-priority variants deferred by back-pressure in the last optimization cycle",
+		},
+		controllerLabels,
+	)
+	trackedModels = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAControllerTrackedModels,
+			Help: "Models the controller keeps state for, including unreferenced models until their state is collected",
+		},
+		controllerLabels,
+	)
+	conditionTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVAConditionTransitionsTotal,
+			Help: "Total number of status transitions of the conditions of each variant",
+		},
+		conditionLabels,
+	)
+	conditionStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAConditionStatus,
+			Help: "Whether each condition of each variant is True (1) or not (0)",
+		},
+		conditionStatusLabels,
+	)
+	configInvalidEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAConfigInvalidEntries,
+			Help: "Entries of each saturation scaling ConfigMap that failed to parse or validate",
+		},
+		configMapLabels,
+	)
+	featureEnabled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAFeatureEnabled,
+			Help: "Whether each feature gate is enabled (1) or not (0)",
+		},
+		featureLabels,
+	)
+	errorBudgetRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVASLOErrorBudgetRemaining,
+			Help: "Remaining fraction of the SLO error budget of each service class over the window",
+		},
+		serviceClassLabels,
+	)
+	errorBudgetBurnRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVASLOErrorBudgetBurnRate,
+			Help: "Rate at which the SLO error budget of each service class is consumed (1 = exactly over the window)",
+		},
+		serviceClassLabels,
+	)
+	modelOverloaded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAModelOverloaded,
+			Help: "Whether each model is overloaded (1), with all its variants saturated and at their maxReplicas, or not (0)",
+		},
+		modelLabels,
+	)
+	fallbackReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAFallbackReplicas,
+			Help: "Replicas of a variant recommended on its fallback accelerator class while its accelerator is exhausted",
+		},
+		baseLabels,
+	)
+	scaleDecisionLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    constants.WVAScaleDecisionLatencySeconds,
+			Help:    "Time from the metrics sample that crossed the scaling thresholds of a variant to the update of its desired replicas metric",
+			Buckets: scaleLatencyBuckets,
+		},
+		latencyLabels,
+	)
+	scaleActuationLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    constants.WVAScaleActuationLatencySeconds,
+			Help:    "Time from the metrics sample that crossed the scaling thresholds of a variant to the change of the replicas of its Deployment",
+			Buckets: scaleLatencyBuckets,
+		},
+		latencyLabels,
+	)
 
 	// Register metrics with the registry
 	if err := registry.Register(replicaScalingTotal); err != nil {
@@ -89,6 +268,63 @@ func InitMetrics(registry prometheus.Registerer) error {
 	if err := registry.Register(desiredRatio); err != nil {
 		return fmt.Errorf("failed to register desiredRatio metric: %w", err)
 	}
+	if err := registry.Register(allocationFlaps); err != nil {
+		return fmt.Errorf("failed to register allocationFlaps metric: %w", err)
+	}
+	if err := registry.Register(dampenedChanges); err != nil {
+		return fmt.Errorf("failed to register dampenedChanges metric: %w", err)
+	}
+	if err := registry.Register(tenantGPUShortfall); err != nil {
+		return fmt.Errorf("failed to register tenantGPUShortfall metric: %w", err)
+	}
+	if err := registry.Register(capacityShortfallReplicas); err != nil {
+		return fmt.Errorf("failed to register capacityShortfallReplicas metric: %w", err)
+	}
+	if err := registry.Register(controllerQueueDepth); err != nil {
+		return fmt.Errorf("failed to register controllerQueueDepth metric: %w", err)
+	}
+	if err := registry.Register(controllerQueryLatency); err != nil {
+		return fmt.Errorf("failed to register controllerQueryLatency metric: %w", err)
+	}
+	if err := registry.Register(backpressureSlowdown); err != nil {
+		return fmt.Errorf("failed to register backpressureSlowdown metric: %w", err)
+	}
+	if err := registry.Register(deferredVariants); err != nil {
+		return fmt.Errorf("failed to register deferredVariants metric: %w", err)
+	}
+	if err := registry.Register(trackedModels); err != nil {
+		return fmt.Errorf("failed to register trackedModels metric: %w", err)
+	}
+	if err := registry.Register(conditionTransitions); err != nil {
+		return fmt.Errorf("failed to register conditionTransitions metric: %w", err)
+	}
+	if err := registry.Register(conditionStatus); err != nil {
+		return fmt.Errorf("failed to register conditionStatus metric: %w", err)
+	}
+	if err := registry.Register(configInvalidEntries); err != nil {
+		return fmt.Errorf("failed to register configInvalidEntries metric: %w", err)
+	}
+	if err := registry.Register(featureEnabled); err != nil {
+		return fmt.Errorf("failed to register featureEnabled metric: %w", err)
+	}
+	if err := registry.Register(errorBudgetRemaining); err != nil {
+		return fmt.Errorf("failed to register errorBudgetRemaining metric: %w", err)
+	}
+	if err := registry.Register(errorBudgetBurnRate); err != nil {
+		return fmt.Errorf("failed to register errorBudgetBurnRate metric: %w", err)
+	}
+	if err := registry.Register(modelOverloaded); err != nil {
+		return fmt.Errorf("failed to register modelOverloaded metric: %w", err)
+	}
+	if err := registry.Register(fallbackReplicas); err != nil {
+		return fmt.Errorf("failed to register fallbackReplicas metric: %w", err)
+	}
+	if err := registry.Register(scaleDecisionLatency); err != nil {
+		return fmt.Errorf("failed to register scaleDecisionLatency metric: %w", err)
+	}
+	if err := registry.Register(scaleActuationLatency); err != nil {
+		return fmt.Errorf("failed to register scaleActuationLatency metric: %w", err)
+	}
 
 	return nil
 }
@@ -163,3 +399,294 @@ func (m *MetricsEmitter) EmitReplicaMetrics(ctx context.Context, va *llmdOptv1al
 	desiredRatio.With(baseLabels).Set(float64(desired) / float64(current))
 	return nil
 }
+
+// EmitDampeningMetrics emits the number of flapped and dampened changes of a variant in one optimization run
+func (m *MetricsEmitter) EmitDampeningMetrics(ctx context.Context, variantName, namespace string, flaps, dampened int) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	// These operations are local and should never fail, but we handle errors for debugging
+	if allocationFlaps == nil || dampenedChanges == nil {
+		return fmt.Errorf("dampening metrics not initialized")
+	}
+
+	allocationFlaps.With(labels).Add(float64(flaps))
+	dampenedChanges.With(labels).Add(float64(dampened))
+	return nil
+}
+
+// EmitTenantShortfallMetrics emits the GPUs a tenant was short of after resource limiting
+func (m *MetricsEmitter) EmitTenantShortfallMetrics(ctx context.Context, tenant string, gpus float64) error {
+	labels := prometheus.Labels{
+		constants.LabelTenant: tenant,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if tenantGPUShortfall == nil {
+		return fmt.Errorf("tenant shortfall metric not initialized")
+	}
+
+	tenantGPUShortfall.With(labels).Set(gpus)
+	return nil
+}
+
+// EmitCapacityShortfallMetrics emits the scale-up replicas of a variant that the GPU limiter did not grant
+func (m *MetricsEmitter) EmitCapacityShortfallMetrics(ctx context.Context, variantName, namespace, acceleratorType string, replicas int) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName:     variantName,
+		constants.LabelNamespace:       namespace,
+		constants.LabelAcceleratorType: acceleratorType,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if capacityShortfallReplicas == nil {
+		return fmt.Errorf("capacity shortfall metric not initialized")
+	}
+
+	capacityShortfallReplicas.With(labels).Set(float64(replicas))
+	return nil
+}
+
+// EmitControllerLoadMetrics emits the load of the controller sampled in an optimization cycle
+func (m *MetricsEmitter) EmitControllerLoadMetrics(ctx context.Context, queueDepth int, queryLatency time.Duration, slowdown, deferred int) error {
+	labels := prometheus.Labels{}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if controllerQueueDepth == nil || controllerQueryLatency == nil || backpressureSlowdown == nil || deferredVariants == nil {
+		return fmt.Errorf("controller load metrics not initialized")
+	}
+
+	controllerQueueDepth.With(labels).Set(float64(queueDepth))
+	controllerQueryLatency.With(labels).Set(queryLatency.Seconds())
+	backpressureSlowdown.With(labels).Set(float64(slowdown))
+	deferredVariants.With(labels).Set(float64(deferred))
+	return nil
+}
+
+// EmitTrackedModelsMetrics emits the number of models the controller keeps state for
+func (m *MetricsEmitter) EmitTrackedModelsMetrics(ctx context.Context, models int) error {
+	labels := prometheus.Labels{}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if trackedModels == nil {
+		return fmt.Errorf("tracked models metric not initialized")
+	}
+
+	trackedModels.With(labels).Set(float64(models))
+	return nil
+}
+
+// EmitErrorBudgetMetrics emits the remaining fraction and burn rate of the SLO error budget of a service class
+func (m *MetricsEmitter) EmitErrorBudgetMetrics(ctx context.Context, serviceClass string, remaining, burnRate float64) error {
+	labels := prometheus.Labels{
+		constants.LabelServiceClass: serviceClass,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if errorBudgetRemaining == nil || errorBudgetBurnRate == nil {
+		return fmt.Errorf("error budget metrics not initialized")
+	}
+
+	errorBudgetRemaining.With(labels).Set(remaining)
+	errorBudgetBurnRate.With(labels).Set(burnRate)
+	return nil
+}
+
+// EmitModelOverloadedMetrics emits whether a model is overloaded
+func (m *MetricsEmitter) EmitModelOverloadedMetrics(ctx context.Context, modelID, namespace string, overloaded bool) error {
+	labels := prometheus.Labels{
+		constants.LabelModelName: modelID,
+		constants.LabelNamespace: namespace,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if modelOverloaded == nil {
+		return fmt.Errorf("model overloaded metric not initialized")
+	}
+
+	value := 0.0
+	if overloaded {
+		value = 1
+	}
+	modelOverloaded.With(labels).Set(value)
+	return nil
+}
+
+// EmitFallbackReplicasMetrics emits the replicas of a variant recommended on its fallback accelerator class
+func (m *MetricsEmitter) EmitFallbackReplicasMetrics(ctx context.Context, variantName, namespace, acceleratorType string, replicas int) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName:     variantName,
+		constants.LabelNamespace:       namespace,
+		constants.LabelAcceleratorType: acceleratorType,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if fallbackReplicas == nil {
+		return fmt.Errorf("fallback replicas metric not initialized")
+	}
+
+	fallbackReplicas.With(labels).Set(float64(replicas))
+	return nil
+}
+
+// EmitScaleDecisionLatencyMetrics observes the time from the metrics sample calling for a
+// scaling of a variant to the update of its desired replicas metric
+func (m *MetricsEmitter) EmitScaleDecisionLatencyMetrics(ctx context.Context, variantName, namespace, direction string, latency time.Duration) error {
+	if scaleDecisionLatency == nil {
+		return fmt.Errorf("scale decision latency metric not initialized")
+	}
+	scaleDecisionLatency.With(scaleLatencyLabels(variantName, namespace, direction)).Observe(latency.Seconds())
+	return nil
+}
+
+// EmitScaleActuationLatencyMetrics observes the time from the metrics sample calling for a
+// scaling of a variant to the change of the replicas of its Deployment
+func (m *MetricsEmitter) EmitScaleActuationLatencyMetrics(ctx context.Context, variantName, namespace, direction string, latency time.Duration) error {
+	if scaleActuationLatency == nil {
+		return fmt.Errorf("scale actuation latency metric not initialized")
+	}
+	scaleActuationLatency.With(scaleLatencyLabels(variantName, namespace, direction)).Observe(latency.Seconds())
+	return nil
+}
+
+// scaleLatencyLabels returns the labels of the scale latency histograms
+func scaleLatencyLabels(variantName, namespace, direction string) prometheus.Labels {
+	labels := prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+		constants.LabelDirection:   direction,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+	return labels
+}
+
+// EmitConditionTransitionMetrics counts a status transition of a condition of a variant
+func (m *MetricsEmitter) EmitConditionTransitionMetrics(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling, conditionType, status, reason string) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName:   va.Name,
+		constants.LabelNamespace:     va.Namespace,
+		constants.LabelConditionType: conditionType,
+		constants.LabelStatus:        status,
+		constants.LabelReason:        reason,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if conditionTransitions == nil {
+		return fmt.Errorf("condition transitions metric not initialized")
+	}
+
+	conditionTransitions.With(labels).Inc()
+	return nil
+}
+
+// EmitConditionStatusMetrics emits whether a condition of a variant is True
+func (m *MetricsEmitter) EmitConditionStatusMetrics(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling, conditionType, status string) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName:   va.Name,
+		constants.LabelNamespace:     va.Namespace,
+		constants.LabelConditionType: conditionType,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if conditionStatus == nil {
+		return fmt.Errorf("condition status metric not initialized")
+	}
+
+	value := 0.0
+	if status == "True" {
+		value = 1
+	}
+	conditionStatus.With(labels).Set(value)
+	return nil
+}
+
+// EmitConfigInvalidEntriesMetrics emits the number of invalid entries of a ConfigMap
+func (m *MetricsEmitter) EmitConfigInvalidEntriesMetrics(ctx context.Context, name, namespace string, invalid int) error {
+	labels := prometheus.Labels{
+		constants.LabelConfigMap: name,
+		constants.LabelNamespace: namespace,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if configInvalidEntries == nil {
+		return fmt.Errorf("config invalid entries metric not initialized")
+	}
+
+	configInvalidEntries.With(labels).Set(float64(invalid))
+	return nil
+}
+
+// EmitFeatureGateMetrics emits whether a feature gate is enabled
+func (m *MetricsEmitter) EmitFeatureGateMetrics(ctx context.Context, name, stage string, enabled bool) error {
+	labels := prometheus.Labels{
+		constants.LabelFeatureName:  name,
+		constants.LabelFeatureStage: stage,
+	}
+
+	// Add controller_instance label if configured
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+
+	if featureEnabled == nil {
+		return fmt.Errorf("feature enabled metric not initialized")
+	}
+
+	value := 0.0
+	if enabled {
+		value = 1
+	}
+	featureEnabled.With(labels).Set(value)
+	return nil
+}
@@ -0,0 +1,247 @@
+// Package prober sends low-rate synthetic requests to models while they are idle, so that
+// their latency measurements (TTFT, ITL) stay available to the SLO-driven parts of the
+// controller between bursts of traffic.
+//
+// Models are opted in by annotating their VariantAutoscalings with a probe interval. Each
+// probe is a one-token completion sent through the inference gateway, so that it measures
+// the path real requests take. Probes are only sent to models with desired replicas, so
+// that they never scale a model from zero, and the Prober counts the probes it sent so
+// that scale-to-zero idle detection leaves them out of the request count of the model.
+package prober
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// tickInterval is how often the models due for a probe are looked up
+const tickInterval = 5 * time.Second
+
+// minInterval is the shortest probe interval; shorter annotated intervals are raised to it
+const minInterval = 10 * time.Second
+
+// history is how long the times of sent probes are kept, the longest retention period of
+// idle detection they can be left out of
+const history = 24 * time.Hour
+
+// completionsPath is the path of the OpenAI-compatible completions API of the gateway
+const completionsPath = "/v1/completions"
+
+// probePrompt is the prompt of the probes
+const probePrompt = "ping"
+
+// model is a model opted in to probes.
+type model struct {
+	modelID   string
+	namespace string
+	interval  time.Duration
+	// serving is whether a variant of the model has desired replicas
+	serving bool
+}
+
+// Prober sends synthetic probes to the models of annotated VariantAutoscalings.
+// It is safe for concurrent use.
+type Prober struct {
+	reader     client.Reader
+	httpClient *http.Client
+	gatewayURL string
+	instance   string
+	now        func() time.Time
+
+	mu sync.Mutex
+	// lastProbe is when each namespace/modelID was last probed
+	lastProbe map[string]time.Time
+	// sent are the times of the successful probes of each namespace/modelID, in time order
+	sent map[string][]time.Time
+}
+
+// New creates a Prober listing VariantAutoscalings with reader and sending probes through
+// the gateway at gatewayURL, each within timeout. When instance is set, only the
+// VariantAutoscalings of the controller instance are probed.
+func New(reader client.Reader, gatewayURL string, timeout time.Duration, instance string) *Prober {
+	return &Prober{
+		reader:     reader,
+		httpClient: &http.Client{Timeout: timeout},
+		gatewayURL: strings.TrimSuffix(gatewayURL, "/"),
+		instance:   instance,
+		now:        time.Now,
+		lastProbe:  make(map[string]time.Time),
+		sent:       make(map[string][]time.Time),
+	}
+}
+
+// Start probes the models due for a probe until ctx is done. It implements manager.Runnable
+// and only runs on the leader, whose idle detection leaves the probes out.
+func (p *Prober) Start(ctx context.Context) error {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for {
+		p.ProbeDue(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProbeDue sends a probe to each serving model whose probe interval elapsed since its last
+// probe.
+func (p *Prober) ProbeDue(ctx context.Context) {
+	logger := ctrl.Log.WithName("prober")
+
+	models, err := p.models(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list the VariantAutoscalings to probe")
+		return
+	}
+
+	now := p.now()
+	for key, m := range models {
+		if !m.serving {
+			continue
+		}
+		p.mu.Lock()
+		last, probed := p.lastProbe[key]
+		due := !probed || now.Sub(last) >= m.interval
+		if due {
+			p.lastProbe[key] = now
+		}
+		p.mu.Unlock()
+		if !due {
+			continue
+		}
+
+		start := time.Now()
+		if err := p.probe(ctx, m.modelID); err != nil {
+			logger.V(1).Info("Synthetic probe failed",
+				"modelID", m.modelID, "namespace", m.namespace, "error", err.Error())
+			continue
+		}
+		p.recordProbe(key, now)
+		logger.V(2).Info("Sent synthetic probe",
+			"modelID", m.modelID, "namespace", m.namespace, "latency", time.Since(start))
+	}
+
+	// Forget the models no longer probed
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.lastProbe {
+		if _, ok := models[key]; !ok {
+			delete(p.lastProbe, key)
+		}
+	}
+}
+
+// ProbeCount returns the number of successful probes sent to a model since a point in time.
+func (p *Prober) ProbeCount(modelID, namespace string, since time.Time) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	count := 0
+	for _, t := range p.sent[utils.GetNamespacedKey(namespace, modelID)] {
+		if !t.Before(since) {
+			count++
+		}
+	}
+	return float64(count)
+}
+
+// recordProbe records a successful probe, and drops the probes older than the history.
+func (p *Prober) recordProbe(key string, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent[key] = append(p.sent[key], at)
+
+	cutoff := at.Add(-history)
+	for key, times := range p.sent {
+		i := 0
+		for i < len(times) && times[i].Before(cutoff) {
+			i++
+		}
+		if i == len(times) {
+			delete(p.sent, key)
+			continue
+		}
+		p.sent[key] = times[i:]
+	}
+}
+
+// models returns the models opted in to probes, by namespace/modelID, at the shortest
+// interval of their VariantAutoscalings.
+func (p *Prober) models(ctx context.Context) (map[string]*model, error) {
+	var vas llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := p.reader.List(ctx, &vas); err != nil {
+		return nil, err
+	}
+
+	models := make(map[string]*model)
+	for i := range vas.Items {
+		va := &vas.Items[i]
+		if p.instance != "" && va.Labels[constants.ControllerInstanceLabelKey] != p.instance {
+			continue
+		}
+		value, ok := va.Annotations[constants.ProbeIntervalAnnotationKey]
+		if !ok || va.DeletionTimestamp != nil {
+			continue
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			ctrl.Log.WithName("prober").V(1).Info("Ignoring invalid probe interval",
+				"variantAutoscaling", client.ObjectKeyFromObject(va), "value", value)
+			continue
+		}
+		interval = max(interval, minInterval)
+
+		key := utils.GetNamespacedKey(va.Namespace, va.Spec.ModelID)
+		m, ok := models[key]
+		if !ok {
+			m = &model{modelID: va.Spec.ModelID, namespace: va.Namespace, interval: interval}
+			models[key] = m
+		}
+		m.interval = min(m.interval, interval)
+		m.serving = m.serving || va.Status.DesiredOptimizedAlloc.NumReplicas > 0
+	}
+	return models, nil
+}
+
+// probe sends a one-token completion for a model through the gateway.
+func (p *Prober) probe(ctx context.Context, modelID string) error {
+	body, err := json.Marshal(map[string]any{
+		"model":      modelID,
+		"prompt":     probePrompt,
+		"max_tokens": 1,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.gatewayURL+completionsPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("gateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package prober
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func newVA(name, modelID, interval string, desiredReplicas int) *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+		Spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{ModelID: modelID},
+	}
+	if interval != "" {
+		va.Annotations = map[string]string{constants.ProbeIntervalAnnotationKey: interval}
+	}
+	va.Status.DesiredOptimizedAlloc.NumReplicas = desiredReplicas
+	return va
+}
+
+// gateway records the models of the probes it receives
+type gateway struct {
+	mu     sync.Mutex
+	models []string
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Model     string `json:"model"`
+		MaxTokens int    `json:"max_tokens"`
+	}
+	if r.URL.Path != completionsPath || json.NewDecoder(r.Body).Decode(&body) != nil || body.MaxTokens != 1 {
+		http.Error(w, "bad probe", http.StatusBadRequest)
+		return
+	}
+	g.mu.Lock()
+	g.models = append(g.models, body.Model)
+	g.mu.Unlock()
+	if body.Model == "unrouted" {
+		http.Error(w, "no route", http.StatusNotFound)
+	}
+}
+
+func TestProbeDue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, llmdVariantAutoscalingV1alpha1.AddToScheme(scheme))
+	objs := []client.Object{
+		// The shortest interval of the variants of a model applies
+		newVA("llama-h100", "meta/llama", "5m", 1),
+		newVA("llama-a100", "meta/llama", "1m", 0),
+		// Idle models are not probed, so that probes do not scale them from zero
+		newVA("granite", "ibm/granite", "1m", 0),
+		// Models are opted in by the annotation
+		newVA("mistral", "mistral", "", 1),
+		newVA("invalid", "invalid", "often", 1),
+		newVA("unrouted", "unrouted", "1m", 1),
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	gw := &gateway{}
+	server := httptest.NewServer(gw)
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := New(reader, server.URL+"/", time.Second, "")
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	p.ProbeDue(ctx)
+	assert.ElementsMatch(t, []string{"meta/llama", "unrouted"}, gw.models)
+	assert.Equal(t, 1.0, p.ProbeCount("meta/llama", "prod", now))
+	// Failed probes are not counted
+	assert.Zero(t, p.ProbeCount("unrouted", "prod", now))
+
+	// Not due before the interval elapsed
+	now = now.Add(30 * time.Second)
+	p.ProbeDue(ctx)
+	assert.Len(t, gw.models, 2)
+
+	now = now.Add(30 * time.Second)
+	p.ProbeDue(ctx)
+	assert.Len(t, gw.models, 4)
+	assert.Equal(t, 2.0, p.ProbeCount("meta/llama", "prod", now.Add(-time.Hour)))
+	assert.Equal(t, 1.0, p.ProbeCount("meta/llama", "prod", now))
+}
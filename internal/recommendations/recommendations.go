@@ -0,0 +1,124 @@
+// Package recommendations serves the current recommendations of all VariantAutoscalings
+// as a single JSON document, so that external capacity dashboards can poll one endpoint
+// instead of listing VariantAutoscalings and scraping metrics.
+package recommendations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+)
+
+// Path is the path of the endpoint on the metrics server.
+const Path = "/recommendations"
+
+// Document is the response of the endpoint.
+type Document struct {
+	// GeneratedAt is when the document was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Recommendations are the recommendations of the VariantAutoscalings, sorted by
+	// namespace and name
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// Recommendation is the current recommendation of a VariantAutoscaling.
+type Recommendation struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	ModelID   string            `json:"modelID"`
+	// Accelerator and DesiredReplicas are the desired allocation of the last optimization run
+	Accelerator     string      `json:"accelerator,omitempty"`
+	DesiredReplicas int         `json:"desiredReplicas"`
+	LastRunTime     metav1.Time `json:"lastRunTime,omitempty"`
+	// ConfigHash is the fingerprint of the configuration the desired allocation was computed with
+	ConfigHash string `json:"configHash,omitempty"`
+	// Saturation is the last known saturation of the variant, from 0.0 (idle) to 1.0
+	// (saturated); nil until analyzed by this replica of the controller
+	Saturation *float64           `json:"saturation,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Handler serves the recommendations of the VariantAutoscalings read with reader. The
+// namespace query parameter restricts them to a namespace, and the labelSelector query
+// parameter to the VariantAutoscalings matching a label selector. When instance is set,
+// only the VariantAutoscalings of the controller instance are served.
+func Handler(reader client.Reader, instance string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts := []client.ListOption{}
+		if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+			opts = append(opts, client.InNamespace(namespace))
+		}
+		selector := labels.Everything()
+		if s := r.URL.Query().Get("labelSelector"); s != "" {
+			parsed, err := labels.Parse(s)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid labelSelector: %v", err), http.StatusBadRequest)
+				return
+			}
+			selector = parsed
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+
+		var vas llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+		if err := reader.List(r.Context(), &vas, opts...); err != nil {
+			ctrl.Log.Error(err, "Failed to list VariantAutoscalings for recommendations")
+			http.Error(w, "failed to list VariantAutoscalings", http.StatusInternalServerError)
+			return
+		}
+
+		document := Document{
+			GeneratedAt:     time.Now().UTC(),
+			Recommendations: make([]Recommendation, 0, len(vas.Items)),
+		}
+		for i := range vas.Items {
+			va := &vas.Items[i]
+			if instance != "" && va.Labels[constants.ControllerInstanceLabelKey] != instance {
+				continue
+			}
+			document.Recommendations = append(document.Recommendations, recommendation(va))
+		}
+		sort.Slice(document.Recommendations, func(i, j int) bool {
+			a, b := document.Recommendations[i], document.Recommendations[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.Name < b.Name
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(document)
+	})
+}
+
+// recommendation returns the recommendation of a VariantAutoscaling
+func recommendation(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) Recommendation {
+	rec := Recommendation{
+		Name:            va.Name,
+		Namespace:       va.Namespace,
+		Labels:          va.Labels,
+		ModelID:         va.Spec.ModelID,
+		Accelerator:     va.Status.DesiredOptimizedAlloc.Accelerator,
+		DesiredReplicas: va.Status.DesiredOptimizedAlloc.NumReplicas,
+		LastRunTime:     va.Status.DesiredOptimizedAlloc.LastRunTime,
+		ConfigHash:      va.Status.DesiredOptimizedAlloc.ConfigHash,
+		Conditions:      va.Status.Conditions,
+	}
+	if saturation, ok := common.SaturationCache.Get(va.Name, va.Namespace); ok {
+		rec.Saturation = &saturation
+	}
+	return rec
+}
@@ -0,0 +1,104 @@
+package recommendations
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+)
+
+func newVA(name, namespace string, labels map[string]string, replicas int) *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+	}
+	va.Spec.ModelID = "meta/llama"
+	va.Status.DesiredOptimizedAlloc.Accelerator = "H100"
+	va.Status.DesiredOptimizedAlloc.NumReplicas = replicas
+	va.Status.Conditions = []metav1.Condition{{
+		Type:   llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable,
+		Status: metav1.ConditionTrue,
+		Reason: "MetricsFound",
+	}}
+	return va
+}
+
+func newReader(t *testing.T, objs ...client.Object) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, llmdVariantAutoscalingV1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func get(t *testing.T, handler http.Handler, target string) (*httptest.ResponseRecorder, Document) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var document Document
+	if rec.Code == http.StatusOK {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+	}
+	return rec, document
+}
+
+func names(document Document) []string {
+	var names []string
+	for _, rec := range document.Recommendations {
+		names = append(names, rec.Namespace+"/"+rec.Name)
+	}
+	return names
+}
+
+func TestHandler(t *testing.T) {
+	reader := newReader(t,
+		newVA("llama-h100", "prod", map[string]string{"team": "search"}, 3),
+		newVA("llama-a100", "prod", map[string]string{"team": "ads"}, 1),
+		newVA("llama-l4", "dev", nil, 0),
+	)
+	common.SaturationCache.Set("llama-h100", "prod", 0.72)
+	handler := Handler(reader, "")
+
+	rec, document := get(t, handler, Path)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, document.GeneratedAt.IsZero())
+	assert.Equal(t, []string{"dev/llama-l4", "prod/llama-a100", "prod/llama-h100"}, names(document))
+
+	h100 := document.Recommendations[2]
+	assert.Equal(t, "meta/llama", h100.ModelID)
+	assert.Equal(t, "H100", h100.Accelerator)
+	assert.Equal(t, 3, h100.DesiredReplicas)
+	require.NotNil(t, h100.Saturation)
+	assert.InDelta(t, 0.72, *h100.Saturation, 1e-9)
+	require.Len(t, h100.Conditions, 1)
+	assert.Equal(t, llmdVariantAutoscalingV1alpha1.TypeMetricsAvailable, h100.Conditions[0].Type)
+	assert.Nil(t, document.Recommendations[0].Saturation, "never analyzed")
+
+	_, document = get(t, handler, Path+"?namespace=prod")
+	assert.Equal(t, []string{"prod/llama-a100", "prod/llama-h100"}, names(document))
+
+	_, document = get(t, handler, Path+"?namespace=prod&labelSelector=team%3Dsearch")
+	assert.Equal(t, []string{"prod/llama-h100"}, names(document))
+
+	rec, _ = get(t, handler, Path+"?labelSelector=team%3D%3D%3D")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_ControllerInstance(t *testing.T) {
+	reader := newReader(t,
+		newVA("owned", "prod", map[string]string{constants.ControllerInstanceLabelKey: "team-a"}, 1),
+		newVA("other", "prod", map[string]string{constants.ControllerInstanceLabelKey: "team-b"}, 1),
+		newVA("unlabeled", "prod", nil, 1),
+	)
+
+	_, document := get(t, Handler(reader, "team-a"), Path)
+	assert.Equal(t, []string{"prod/owned"}, names(document))
+}
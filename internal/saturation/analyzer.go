@@ -28,6 +28,7 @@ func NewAnalyzer() *Analyzer {
 // 2. Average spare Saturation across non-saturated replicas
 // 3. Whether to scale up (spare Saturation < trigger)
 // 4. Whether scale-down is safe (worst-case simulation)
+// Replicas with unavailable metrics are left out while others have fresher ones.
 func (a *Analyzer) AnalyzeModelSaturation(
 	ctx context.Context,
 	modelID string,
@@ -35,6 +36,16 @@ func (a *Analyzer) AnalyzeModelSaturation(
 	replicaMetrics []interfaces.ReplicaMetrics,
 	config interfaces.SaturationScalingConfig,
 ) (*interfaces.ModelSaturationAnalysis, error) {
+	ctx = logging.IntoModule(ctx, logging.ModuleSaturation)
+
+	// Replicas whose samples are much older than the others' no longer report their load
+	replicaMetrics, discarded := interfaces.DiscardUnavailableReplicas(replicaMetrics)
+	if discarded > 0 {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Discarded replicas with unavailable metrics",
+			"modelID", modelID,
+			"namespace", namespace,
+			"discarded", discarded)
+	}
 
 	if len(replicaMetrics) == 0 {
 		return &interfaces.ModelSaturationAnalysis{
@@ -77,6 +88,7 @@ func (a *Analyzer) AnalyzeModelSaturation(
 	var totalSpareKv float64
 	var totalSpareQueue float64
 	var nonSaturatedCount int
+	var failedCount int
 
 	variantAnalyses := make([]interfaces.VariantSaturationAnalysis, 0, len(variantMap))
 
@@ -86,6 +98,7 @@ func (a *Analyzer) AnalyzeModelSaturation(
 
 		// Aggregate across variants
 		nonSaturatedCount += variantAnalysis.NonSaturatedCount
+		failedCount += variantAnalysis.FailedReplicas
 		totalSpareKv += variantAnalysis.AvgSpareKvCapacity * float64(variantAnalysis.NonSaturatedCount)
 		totalSpareQueue += variantAnalysis.AvgSpareQueueLength * float64(variantAnalysis.NonSaturatedCount)
 	}
@@ -101,11 +114,21 @@ func (a *Analyzer) AnalyzeModelSaturation(
 	}
 
 	// Step 3: Determine scale-up recommendation
+	// Replicas on failing GPUs count with zero spare capacity, so their load shifting to
+	// the other replicas triggers a compensating scale-up
+	scaleUpSpareKv, scaleUpSpareQueue := analysis.AvgSpareKvCapacity, analysis.AvgSpareQueueLength
+	if failedCount > 0 {
+		scaleUpSpareKv = totalSpareKv / float64(nonSaturatedCount+failedCount)
+		scaleUpSpareQueue = totalSpareQueue / float64(nonSaturatedCount+failedCount)
+	}
 	analysis.ShouldScaleUp, analysis.ScaleUpReason = a.shouldScaleUp(
-		analysis.AvgSpareKvCapacity,
-		analysis.AvgSpareQueueLength,
+		scaleUpSpareKv,
+		scaleUpSpareQueue,
 		config,
 	)
+	if analysis.ShouldScaleUp && failedCount > 0 {
+		analysis.ScaleUpReason = fmt.Sprintf("%s with %d replicas on failing GPUs", analysis.ScaleUpReason, failedCount)
+	}
 
 	// Step 4: Determine if scale-down is safe
 	// Pass pre-calculated average spare capacities to avoid redundant iteration
@@ -159,6 +182,13 @@ func (a *Analyzer) analyzeVariant(
 	var nonSaturatedCount int
 
 	for _, metric := range metrics {
+		// Replicas on failing GPUs have no capacity
+		if metric.GPUFailed {
+			analysis.SaturatedReplicas = append(analysis.SaturatedReplicas, metric.PodName)
+			analysis.FailedReplicas++
+			continue
+		}
+
 		// Check if replica is saturated
 		isSaturated := metric.KvCacheUsage >= config.KvCacheThreshold ||
 			float64(metric.QueueLength) >= config.QueueLengthThreshold
@@ -292,6 +322,7 @@ func (a *Analyzer) CalculateSaturationTargets(
 	saturationAnalysis *interfaces.ModelSaturationAnalysis,
 	variantStates []interfaces.VariantReplicaState,
 ) map[string]int {
+	ctx = logging.IntoModule(ctx, logging.ModuleSaturation)
 
 	targets := make(map[string]int)
 	logger := ctrl.LoggerFrom(ctx)
@@ -322,6 +322,43 @@ func TestAnalyzeModelSaturation_AllSaturated(t *testing.T) {
 	}
 }
 
+func TestAnalyzeModelSaturation_FailedGPUs(t *testing.T) {
+	analyzer := NewAnalyzer()
+	config := interfaces.SaturationScalingConfig{
+		KvCacheThreshold:     0.80,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.10,
+		QueueSpareTrigger:    3,
+	}
+
+	// Healthy replicas alone have enough spare: avg spare KV = 0.15, avg spare queue = 4
+	replicaMetrics := []interfaces.ReplicaMetrics{
+		{PodName: "pod-1", VariantName: "v1", KvCacheUsage: 0.65, QueueLength: 1},
+		{PodName: "pod-2", VariantName: "v1", KvCacheUsage: 0.65, QueueLength: 1},
+		{PodName: "pod-3", VariantName: "v1", KvCacheUsage: 0.10, QueueLength: 0, GPUFailed: true},
+	}
+
+	analysis, err := analyzer.AnalyzeModelSaturation(context.Background(), "test-model", "test-ns", replicaMetrics, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if analysis.NonSaturatedCount != 2 {
+		t.Errorf("expected NonSaturatedCount=2, got %d", analysis.NonSaturatedCount)
+	}
+	if analysis.VariantAnalyses[0].FailedReplicas != 1 {
+		t.Errorf("expected FailedReplicas=1, got %d", analysis.VariantAnalyses[0].FailedReplicas)
+	}
+	// The failed replica counts with zero spare: avg spare KV = 0.10, at the trigger,
+	// and avg spare queue = 8/3 < 3
+	if !analysis.ShouldScaleUp {
+		t.Error("expected ShouldScaleUp=true to compensate the replica on a failing GPU")
+	}
+	if analysis.ScaleDownSafe {
+		t.Error("expected ScaleDownSafe=false with a replica on a failing GPU")
+	}
+}
+
 func TestAnalyzeModelSaturation_TimestampSet(t *testing.T) {
 	analyzer := NewAnalyzer()
 	config := interfaces.SaturationScalingConfig{
@@ -507,3 +544,46 @@ func TestCalculatesaturationTargets_MetricsMismatchBlocksScaling(t *testing.T) {
 		t.Errorf("expected v2-cheap target=2 (blocked by model transition), got %d", targets["v2-cheap"])
 	}
 }
+
+func TestAnalyzeModelSaturation_UnavailableMetrics(t *testing.T) {
+	analyzer := NewAnalyzer()
+	config := interfaces.SaturationScalingConfig{
+		KvCacheThreshold:     0.80,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.10,
+		QueueSpareTrigger:    3,
+	}
+	freshness := func(status string) *interfaces.ReplicaMetricsMetadata {
+		return &interfaces.ReplicaMetricsMetadata{FreshnessStatus: status}
+	}
+
+	// The idle sample of pod-3 is 10 minutes old: with it, avg spare KV = 0.40
+	replicaMetrics := []interfaces.ReplicaMetrics{
+		{PodName: "pod-1", VariantName: "v1", KvCacheUsage: 0.75, QueueLength: 1, Metadata: freshness(interfaces.FreshnessFresh)},
+		{PodName: "pod-2", VariantName: "v1", KvCacheUsage: 0.75, QueueLength: 1, Metadata: freshness(interfaces.FreshnessStale)},
+		{PodName: "pod-3", VariantName: "v1", KvCacheUsage: 0, QueueLength: 0, Metadata: freshness(interfaces.FreshnessUnavailable)},
+	}
+
+	analysis, err := analyzer.AnalyzeModelSaturation(context.Background(), "test-model", "test-ns", replicaMetrics, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if analysis.TotalReplicas != 2 {
+		t.Errorf("expected TotalReplicas=2 without the unavailable replica, got %d", analysis.TotalReplicas)
+	}
+	if !analysis.ShouldScaleUp {
+		t.Error("expected ShouldScaleUp=true from the avg spare KV = 0.05 of the current samples")
+	}
+
+	// Without fresher replicas, the old samples are all there is
+	for i := range replicaMetrics {
+		replicaMetrics[i].Metadata = freshness(interfaces.FreshnessUnavailable)
+	}
+	analysis, err = analyzer.AnalyzeModelSaturation(context.Background(), "test-model", "test-ns", replicaMetrics, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if analysis.TotalReplicas != 3 {
+		t.Errorf("expected TotalReplicas=3, got %d", analysis.TotalReplicas)
+	}
+}
@@ -0,0 +1,379 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// DefaultSyncInterval is how often the virtual cluster applies the desired replicas.
+const DefaultSyncInterval = 5 * time.Second
+
+// gpuResource is the GPU resource requested by the Deployments of simulated variants.
+const gpuResource = corev1.ResourceName("nvidia.com/gpu")
+
+// Cluster is the virtual cluster state of simulator mode. It plays the parts of the
+// external autoscaler and of the Deployment controller and kubelet for the scale targets
+// of VariantAutoscalings:
+//   - the replicas of each scale target are set to the desired replicas of its VA
+//   - a ReplicaSet and Pods are created for the replicas, so that metrics map to VAs
+//   - Pods become ready after the scenario's replica startup time, and the Deployment
+//     status reports them
+//
+// It writes Pods and Deployment status, and must only run against an API server
+// without workload controllers.
+type Cluster struct {
+	client   client.Client
+	scenario *Scenario
+	interval time.Duration
+	now      func() time.Time
+
+	// mu protects targets.
+	mu sync.RWMutex
+	// targets holds the virtual replicas of each scale target (namespace/name).
+	targets map[string]*target
+}
+
+// target holds the virtual replicas of a scale target.
+type target struct {
+	namespace string
+	modelID   string
+	pods      []virtualPod
+	// nextIndex is the index of the next Pod created, for unique names.
+	nextIndex int
+}
+
+type virtualPod struct {
+	name    string
+	created time.Time
+	ready   bool
+}
+
+// NewCluster creates a virtual cluster for the given scenario.
+func NewCluster(k8sClient client.Client, scenario *Scenario) *Cluster {
+	return &Cluster{
+		client:   k8sClient,
+		scenario: scenario,
+		interval: DefaultSyncInterval,
+		now:      time.Now,
+		targets:  make(map[string]*target),
+	}
+}
+
+// Start creates the variants of the scenario, then syncs the virtual replicas until
+// the context is done. Implements manager.Runnable.
+func (c *Cluster) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("simulator")
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	if err := c.createVariants(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.Sync(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReadyPods returns the names of the ready virtual Pods serving a model in a namespace.
+func (c *Cluster) ReadyPods(namespace, modelID string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var names []string
+	for _, t := range c.targets {
+		if t.namespace != namespace || t.modelID != modelID {
+			continue
+		}
+		for _, pod := range t.pods {
+			if pod.ready {
+				names = append(names, pod.name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Sync applies the desired replicas of all VariantAutoscalings to their scale targets.
+func (c *Cluster) Sync(ctx context.Context) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var vaList llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := c.client.List(ctx, &vaList); err != nil {
+		logger.Error(err, "Failed to list VariantAutoscalings")
+		return
+	}
+
+	synced := make(map[string]bool)
+	for i := range vaList.Items {
+		va := &vaList.Items[i]
+		if !va.DeletionTimestamp.IsZero() || va.GetScaleTargetKind() != "Deployment" {
+			continue
+		}
+		key := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
+		if err := c.syncTarget(ctx, va, key); err != nil {
+			logger.Error(err, "Failed to sync virtual replicas",
+				"variant", va.Name,
+				"namespace", va.Namespace)
+		}
+		synced[key] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.targets {
+		if !synced[key] {
+			delete(c.targets, key)
+		}
+	}
+}
+
+// syncTarget scales the Deployment of a VA to its desired replicas and updates its virtual Pods.
+func (c *Cluster) syncTarget(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, key string) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	deploy := &appsv1.Deployment{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: va.Namespace, Name: va.GetScaleTargetName()}, deploy); err != nil {
+		return fmt.Errorf("failed to get Deployment: %w", err)
+	}
+
+	// Act as the external autoscaler: apply the desired replicas once optimized
+	replicas := ptr.Deref(deploy.Spec.Replicas, 1)
+	if !va.Status.DesiredOptimizedAlloc.LastRunTime.IsZero() {
+		desired := int32(va.Status.DesiredOptimizedAlloc.NumReplicas)
+		if desired != replicas {
+			patch := client.MergeFrom(deploy.DeepCopy())
+			deploy.Spec.Replicas = ptr.To(desired)
+			if err := c.client.Patch(ctx, deploy, patch); err != nil {
+				return fmt.Errorf("failed to scale Deployment: %w", err)
+			}
+			logger.Info("Scaled virtual replicas",
+				"deployment", deploy.Name,
+				"namespace", deploy.Namespace,
+				"from", replicas,
+				"to", desired)
+			replicas = desired
+		}
+	}
+
+	c.mu.Lock()
+	t, ok := c.targets[key]
+	if !ok {
+		t = &target{namespace: va.Namespace}
+		c.targets[key] = t
+	}
+	t.modelID = va.Spec.ModelID
+	c.mu.Unlock()
+
+	rs, err := c.ensureReplicaSet(ctx, deploy, replicas)
+	if err != nil {
+		return err
+	}
+	if err := c.syncPods(ctx, t, deploy, rs, int(replicas)); err != nil {
+		return err
+	}
+	return c.updateDeploymentStatus(ctx, t, deploy)
+}
+
+// ensureReplicaSet creates or scales the ReplicaSet owning the virtual Pods of a Deployment.
+func (c *Cluster) ensureReplicaSet(ctx context.Context, deploy *appsv1.Deployment, replicas int32) (*appsv1.ReplicaSet, error) {
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploy.Name + "-sim",
+			Namespace: deploy.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, c.client, rs, func() error {
+		rs.Labels = deploy.Spec.Template.Labels
+		rs.Spec.Replicas = ptr.To(replicas)
+		rs.Spec.Selector = deploy.Spec.Selector
+		rs.Spec.Template = deploy.Spec.Template
+		return controllerutil.SetControllerReference(deploy, rs, c.client.Scheme())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update ReplicaSet: %w", err)
+	}
+	return rs, nil
+}
+
+// syncPods creates or deletes virtual Pods to match the replicas, newest deleted first,
+// and marks Pods ready after the replica startup time.
+func (c *Cluster) syncPods(ctx context.Context, t *target, deploy *appsv1.Deployment, rs *appsv1.ReplicaSet, replicas int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+
+	for len(t.pods) < replicas {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", rs.Name, t.nextIndex),
+				Namespace: deploy.Namespace,
+				Labels:    deploy.Spec.Template.Labels,
+			},
+			Spec: *deploy.Spec.Template.Spec.DeepCopy(),
+		}
+		if err := controllerutil.SetControllerReference(rs, pod, c.client.Scheme()); err != nil {
+			return err
+		}
+		if err := c.client.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Pod %s: %w", pod.Name, err)
+		}
+		t.nextIndex++
+		t.pods = append(t.pods, virtualPod{name: pod.Name, created: now})
+	}
+
+	for len(t.pods) > replicas {
+		last := t.pods[len(t.pods)-1]
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: last.name, Namespace: deploy.Namespace}}
+		if err := c.client.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Pod %s: %w", pod.Name, err)
+		}
+		t.pods = t.pods[:len(t.pods)-1]
+	}
+
+	for i := range t.pods {
+		pod := &t.pods[i]
+		if pod.ready || now.Sub(pod.created) < c.scenario.ReplicaStartup {
+			continue
+		}
+		if err := c.markPodReady(ctx, deploy.Namespace, pod.name); err != nil {
+			return err
+		}
+		pod.ready = true
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Virtual replica ready",
+			"pod", pod.name,
+			"namespace", deploy.Namespace)
+	}
+	return nil
+}
+
+func (c *Cluster) markPodReady(ctx context.Context, namespace, name string) error {
+	pod := &corev1.Pod{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, pod); err != nil {
+		return fmt.Errorf("failed to get Pod %s: %w", name, err)
+	}
+	now := metav1.NewTime(c.now())
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.StartTime = &now
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: now},
+	}
+	if err := c.client.Status().Update(ctx, pod); err != nil {
+		return fmt.Errorf("failed to update status of Pod %s: %w", name, err)
+	}
+	return nil
+}
+
+// updateDeploymentStatus reports the virtual Pods in the Deployment status.
+func (c *Cluster) updateDeploymentStatus(ctx context.Context, t *target, deploy *appsv1.Deployment) error {
+	c.mu.RLock()
+	replicas := int32(len(t.pods))
+	var ready int32
+	for _, pod := range t.pods {
+		if pod.ready {
+			ready++
+		}
+	}
+	c.mu.RUnlock()
+
+	status := deploy.Status
+	if status.Replicas == replicas && status.ReadyReplicas == ready && status.ObservedGeneration == deploy.Generation {
+		return nil
+	}
+	deploy.Status.ObservedGeneration = deploy.Generation
+	deploy.Status.Replicas = replicas
+	deploy.Status.UpdatedReplicas = replicas
+	deploy.Status.ReadyReplicas = ready
+	deploy.Status.AvailableReplicas = ready
+	deploy.Status.UnavailableReplicas = replicas - ready
+	if err := c.client.Status().Update(ctx, deploy); err != nil {
+		return fmt.Errorf("failed to update Deployment status: %w", err)
+	}
+	return nil
+}
+
+// createVariants creates the variants of the scenario that do not exist, with their
+// namespace and Deployment.
+func (c *Cluster) createVariants(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	for _, variant := range c.scenario.Variants {
+		labels := map[string]string{"app": variant.Name}
+		gpus := *resource.NewQuantity(variant.GPUs, resource.DecimalSI)
+		objects := []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: variant.Namespace}},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: variant.Name, Namespace: variant.Namespace, Labels: labels},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: ptr.To(variant.Replicas),
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "vllm",
+								Image: "simulated",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{gpuResource: gpus},
+									Limits:   corev1.ResourceList{gpuResource: gpus},
+								},
+							}},
+						},
+					},
+				},
+			},
+			&llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      variant.Name,
+					Namespace: variant.Namespace,
+					Labels:    map[string]string{utils.AcceleratorNameLabel: variant.Accelerator},
+				},
+				Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+					ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       variant.Name,
+					},
+					ModelID:     variant.ModelID,
+					VariantCost: variant.VariantCost,
+				},
+			},
+		}
+		for _, obj := range objects {
+			if err := c.client.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create %T %s of variant %s: %w", obj, obj.GetName(), variant.Name, err)
+			}
+		}
+		logger.Info("Simulated variant created",
+			"variant", variant.Name,
+			"namespace", variant.Namespace,
+			"modelID", variant.ModelID)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/indexers"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+func newTestCluster(t *testing.T, scenario *Scenario) (*Cluster, client.Client, *time.Time) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, llmdVariantAutoscalingV1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&appsv1.Deployment{}, &corev1.Pod{}, &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}).
+		WithIndex(&llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}, indexers.VAScaleTargetKey, indexers.VAScaleTargetIndexFunc).
+		Build()
+
+	now := time.Now()
+	cluster := NewCluster(k8sClient, scenario)
+	cluster.now = func() time.Time { return now }
+	return cluster, k8sClient, &now
+}
+
+func TestCluster(t *testing.T) {
+	ctx := context.Background()
+	scenario := &Scenario{
+		Models: []ModelTraffic{{
+			ModelID: "llama",
+			Phases:  []Phase{{Duration: time.Hour, StartRate: 10}},
+		}},
+		Variants: []Variant{{Name: "llama-a100", Namespace: "sim", ModelID: "llama", Accelerator: "A100", Replicas: 2}},
+	}
+	scenario.applyDefaults()
+	cluster, k8sClient, now := newTestCluster(t, scenario)
+
+	require.NoError(t, cluster.createVariants(ctx))
+	cluster.Sync(ctx)
+
+	deploy := &appsv1.Deployment{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "sim", Name: "llama-a100"}, deploy))
+	assert.Equal(t, int32(2), deploy.Status.Replicas)
+	assert.Equal(t, int32(0), deploy.Status.ReadyReplicas, "replicas starting")
+	assert.Empty(t, cluster.ReadyPods("sim", "llama"))
+
+	// replicas become ready after the startup time, and map to their VA
+	*now = now.Add(scenario.ReplicaStartup)
+	cluster.Sync(ctx)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "sim", Name: "llama-a100"}, deploy))
+	assert.Equal(t, int32(2), deploy.Status.ReadyReplicas)
+	pods := cluster.ReadyPods("sim", "llama")
+	require.Len(t, pods, 2)
+	mapper := source.NewPodVAMapper(k8sClient)
+	assert.Equal(t, "llama-a100", mapper.FindVAForPod(ctx, pods[0], "sim",
+		map[string]interfaces.Workload{"sim/llama-a100": utils.NewDeploymentWorkload(deploy)}))
+
+	// the desired replicas of the VA are applied
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "sim", Name: "llama-a100"}, va))
+	va.Status.DesiredOptimizedAlloc = llmdVariantAutoscalingV1alpha1.OptimizedAlloc{
+		NumReplicas: 1,
+		LastRunTime: metav1.NewTime(*now),
+	}
+	require.NoError(t, k8sClient.Status().Update(ctx, va))
+	cluster.Sync(ctx)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Namespace: "sim", Name: "llama-a100"}, deploy))
+	assert.Equal(t, ptr.To(int32(1)), deploy.Spec.Replicas)
+	assert.Equal(t, int32(1), deploy.Status.Replicas)
+	assert.Equal(t, []string{pods[0]}, cluster.ReadyPods("sim", "llama"), "newest replica removed")
+}
+
+func TestSource(t *testing.T) {
+	ctx := context.Background()
+	scenario := &Scenario{
+		Models: []ModelTraffic{{
+			ModelID: "llama",
+			Phases:  []Phase{{Duration: time.Hour, StartRate: 10, InputTokens: 400, OutputTokens: 190}},
+			Server:  Server{MaxNumSeqs: 8, NumGPUBlocks: 1000, BlockSize: 16, TTFT: 100 * time.Millisecond, ITL: 10 * time.Millisecond},
+		}},
+		Variants: []Variant{{Name: "llama-a100", Namespace: "sim", ModelID: "llama", Replicas: 1}},
+	}
+	scenario.applyDefaults()
+	cluster, _, now := newTestCluster(t, scenario)
+	require.NoError(t, cluster.createVariants(ctx))
+	cluster.Sync(ctx)
+	*now = now.Add(scenario.ReplicaStartup)
+	cluster.Sync(ctx)
+
+	metricsSource := NewSource(ctx, scenario, cluster)
+	params := map[string]string{source.ParamNamespace: "sim", source.ParamModelID: "llama"}
+	results, err := metricsSource.Refresh(ctx, source.RefreshSpec{
+		Queries: []string{
+			registration.QueryQueueLength,
+			registration.QueryCacheConfigInfo,
+			registration.QueryRequestRate,
+			registration.QueryGPUUtilization,
+		},
+		Params: params,
+	})
+	require.NoError(t, err)
+
+	queue := results[registration.QueryQueueLength]
+	require.Len(t, queue.Values, 1)
+	assert.Equal(t, 12.0, queue.Values[0].Value)
+	assert.Equal(t, "llama-a100-sim-0", queue.Values[0].Labels["pod"])
+	assert.Equal(t, "1000", results[registration.QueryCacheConfigInfo].Values[0].Labels["num_gpu_blocks"])
+	assert.Equal(t, 600.0, results[registration.QueryRequestRate].FirstValue().Value)
+	assert.Empty(t, results[registration.QueryGPUUtilization].Values, "not simulated")
+
+	cached := metricsSource.Get(registration.QueryQueueLength, params)
+	require.NotNil(t, cached)
+	assert.Equal(t, 12.0, cached.Result.FirstValue().Value)
+}
@@ -0,0 +1,248 @@
+// Package simulator runs the controller without Prometheus or real workloads.
+//
+// In simulator mode (--simulator), the Prometheus metrics source is replaced by a
+// Source generating the metrics of vLLM replicas from a traffic Scenario, and the
+// external autoscaler acting on the desired replicas is replaced by a Cluster
+// maintaining virtual replicas of the scale targets. The full chain, from metrics
+// collection to VariantAutoscaling status, then runs against any API server,
+// e.g. a local one started from the envtest binaries.
+package simulator
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults of scenarios
+const (
+	DefaultReplicaStartup = 30 * time.Second
+	DefaultMaxNumSeqs     = 64
+	DefaultNumGPUBlocks   = 8192
+	DefaultBlockSize      = 16
+	DefaultTTFT           = 100 * time.Millisecond
+	DefaultITL            = 20 * time.Millisecond
+	DefaultInputTokens    = 512
+	DefaultOutputTokens   = 256
+)
+
+// Namespace and model of the variant of the default scenario
+const (
+	DefaultNamespace = "wva-simulator"
+	DefaultModelID   = "sim/model"
+)
+
+// Scenario describes the simulated traffic, servers, and variants.
+type Scenario struct {
+	// ReplicaStartup is how long a new replica takes to become ready.
+	ReplicaStartup time.Duration `yaml:"replicaStartup"`
+	// Models are the traffic profiles of models; the first one matching a model applies.
+	Models []ModelTraffic `yaml:"models"`
+	// Variants are created at startup when they do not exist, with their Deployments.
+	Variants []Variant `yaml:"variants"`
+}
+
+// ModelTraffic is the traffic of a model and the behavior of its servers.
+type ModelTraffic struct {
+	// ModelID is the model the traffic applies to; empty matches any model.
+	ModelID string `yaml:"modelID"`
+	// Namespace is the namespace the traffic applies to; empty matches any namespace.
+	Namespace string `yaml:"namespace"`
+	// Phases follow each other from the start of the simulation.
+	Phases []Phase `yaml:"phases"`
+	// Repeat restarts the phases after the last one, which lasts forever otherwise.
+	Repeat bool `yaml:"repeat"`
+	// Server describes the replicas serving the model.
+	Server Server `yaml:"server"`
+}
+
+// Phase is a period of traffic with a rate ramping linearly from StartRate to EndRate.
+type Phase struct {
+	Duration     time.Duration `yaml:"duration"`
+	StartRate    float64       `yaml:"startRate"` // requests per second
+	EndRate      *float64      `yaml:"endRate"`   // requests per second (default StartRate)
+	InputTokens  int           `yaml:"inputTokens"`
+	OutputTokens int           `yaml:"outputTokens"`
+}
+
+// Server describes a replica of a model server.
+type Server struct {
+	MaxNumSeqs   int           `yaml:"maxNumSeqs"`   // maximum concurrent requests
+	NumGPUBlocks int64         `yaml:"numGPUBlocks"` // KV cache blocks
+	BlockSize    int64         `yaml:"blockSize"`    // tokens per KV cache block
+	TTFT         time.Duration `yaml:"ttft"`         // time to first token at low load
+	ITL          time.Duration `yaml:"itl"`          // inter-token latency
+}
+
+// Variant is a VariantAutoscaling created with its Deployment at startup.
+type Variant struct {
+	Name        string `yaml:"name"`
+	Namespace   string `yaml:"namespace"`
+	ModelID     string `yaml:"modelID"`
+	Accelerator string `yaml:"accelerator"`
+	Replicas    int32  `yaml:"replicas"`
+	GPUs        int64  `yaml:"gpus"` // GPUs per replica
+	VariantCost string `yaml:"variantCost"`
+}
+
+// Load is the traffic of a model at a point in time.
+type Load struct {
+	Rate         float64 // requests per second
+	InputTokens  int
+	OutputTokens int
+}
+
+// DefaultScenario returns the built-in scenario: for any model, 5 minutes at 1 req/s,
+// a 10-minute ramp to 20 req/s, 10 minutes at 20 req/s, and a 10-minute ramp back, repeated,
+// served by a single A100 variant.
+func DefaultScenario() *Scenario {
+	peak, low := 20.0, 1.0
+	scenario := &Scenario{
+		Models: []ModelTraffic{{
+			Repeat: true,
+			Phases: []Phase{
+				{Duration: 5 * time.Minute, StartRate: low},
+				{Duration: 10 * time.Minute, StartRate: low, EndRate: &peak},
+				{Duration: 10 * time.Minute, StartRate: peak},
+				{Duration: 10 * time.Minute, StartRate: peak, EndRate: &low},
+			},
+		}},
+		Variants: []Variant{{
+			Name:        "sim-model-a100",
+			Namespace:   DefaultNamespace,
+			ModelID:     DefaultModelID,
+			Accelerator: "A100",
+		}},
+	}
+	scenario.applyDefaults()
+	return scenario
+}
+
+// LoadScenario reads a scenario from a YAML file; an empty path returns the default scenario.
+func LoadScenario(path string) (*Scenario, error) {
+	if path == "" {
+		return DefaultScenario(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario file %s: %w", path, err)
+	}
+	scenario := &Scenario{}
+	if err := yaml.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario file %s: %w", path, err)
+	}
+	if err := scenario.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario file %s: %w", path, err)
+	}
+	scenario.applyDefaults()
+	return scenario, nil
+}
+
+func (s *Scenario) validate() error {
+	for i, model := range s.Models {
+		if len(model.Phases) == 0 {
+			return fmt.Errorf("models[%d]: at least one phase is required", i)
+		}
+		for j, phase := range model.Phases {
+			if phase.Duration <= 0 {
+				return fmt.Errorf("models[%d].phases[%d]: duration must be positive", i, j)
+			}
+			if phase.StartRate < 0 || (phase.EndRate != nil && *phase.EndRate < 0) {
+				return fmt.Errorf("models[%d].phases[%d]: rates must not be negative", i, j)
+			}
+		}
+	}
+	for i, variant := range s.Variants {
+		if variant.Name == "" || variant.Namespace == "" || variant.ModelID == "" {
+			return fmt.Errorf("variants[%d]: name, namespace and modelID are required", i)
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) applyDefaults() {
+	if s.ReplicaStartup <= 0 {
+		s.ReplicaStartup = DefaultReplicaStartup
+	}
+	for i := range s.Models {
+		server := &s.Models[i].Server
+		if server.MaxNumSeqs <= 0 {
+			server.MaxNumSeqs = DefaultMaxNumSeqs
+		}
+		if server.NumGPUBlocks <= 0 {
+			server.NumGPUBlocks = DefaultNumGPUBlocks
+		}
+		if server.BlockSize <= 0 {
+			server.BlockSize = DefaultBlockSize
+		}
+		if server.TTFT <= 0 {
+			server.TTFT = DefaultTTFT
+		}
+		if server.ITL <= 0 {
+			server.ITL = DefaultITL
+		}
+		for j := range s.Models[i].Phases {
+			phase := &s.Models[i].Phases[j]
+			if phase.InputTokens <= 0 {
+				phase.InputTokens = DefaultInputTokens
+			}
+			if phase.OutputTokens <= 0 {
+				phase.OutputTokens = DefaultOutputTokens
+			}
+		}
+	}
+	for i := range s.Variants {
+		if s.Variants[i].Replicas <= 0 {
+			s.Variants[i].Replicas = 1
+		}
+		if s.Variants[i].GPUs <= 0 {
+			s.Variants[i].GPUs = 1
+		}
+	}
+}
+
+// Traffic returns the traffic profile of a model, nil if no profile matches.
+func (s *Scenario) Traffic(namespace, modelID string) *ModelTraffic {
+	for i := range s.Models {
+		model := &s.Models[i]
+		if (model.ModelID == "" || model.ModelID == modelID) && (model.Namespace == "" || model.Namespace == namespace) {
+			return model
+		}
+	}
+	return nil
+}
+
+// LoadAt returns the traffic at the given time since the start of the simulation.
+func (m *ModelTraffic) LoadAt(elapsed time.Duration) Load {
+	var total time.Duration
+	for _, phase := range m.Phases {
+		total += phase.Duration
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if m.Repeat && total > 0 {
+		elapsed %= total
+	}
+
+	for _, phase := range m.Phases {
+		if elapsed < phase.Duration {
+			return phase.loadAt(elapsed)
+		}
+		elapsed -= phase.Duration
+	}
+	// past the last phase: keep its final traffic
+	last := m.Phases[len(m.Phases)-1]
+	return last.loadAt(last.Duration)
+}
+
+func (p Phase) loadAt(elapsed time.Duration) Load {
+	rate := p.StartRate
+	if p.EndRate != nil {
+		progress := min(float64(elapsed)/float64(p.Duration), 1)
+		rate += (*p.EndRate - p.StartRate) * progress
+	}
+	return Load{Rate: rate, InputTokens: p.InputTokens, OutputTokens: p.OutputTokens}
+}
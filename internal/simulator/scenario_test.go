@@ -0,0 +1,106 @@
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAt(t *testing.T) {
+	peak := 10.0
+	traffic := ModelTraffic{
+		Phases: []Phase{
+			{Duration: time.Minute, StartRate: 2, InputTokens: 100, OutputTokens: 50},
+			{Duration: 2 * time.Minute, StartRate: 2, EndRate: &peak, InputTokens: 100, OutputTokens: 50},
+		},
+	}
+
+	assert.Equal(t, 2.0, traffic.LoadAt(30*time.Second).Rate, "constant phase")
+	assert.Equal(t, 6.0, traffic.LoadAt(2*time.Minute).Rate, "middle of the ramp")
+	assert.Equal(t, 10.0, traffic.LoadAt(10*time.Minute).Rate, "past the last phase")
+	assert.Equal(t, 100, traffic.LoadAt(0).InputTokens)
+
+	traffic.Repeat = true
+	assert.Equal(t, 2.0, traffic.LoadAt(3*time.Minute+30*time.Second).Rate, "repeated")
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+replicaStartup: 10s
+models:
+- modelID: llama
+  phases:
+  - duration: 5m
+    startRate: 4
+    endRate: 8
+  server:
+    maxNumSeqs: 32
+variants:
+- name: llama-a100
+  namespace: sim
+  modelID: llama
+  accelerator: A100
+`), 0o600))
+
+	scenario, err := LoadScenario(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, 10*time.Second, scenario.ReplicaStartup)
+	traffic := scenario.Traffic("sim", "llama")
+	require.NotNil(t, traffic)
+	assert.Equal(t, 32, traffic.Server.MaxNumSeqs)
+	assert.Equal(t, DefaultITL, traffic.Server.ITL, "default applied")
+	assert.Equal(t, 6.0, traffic.LoadAt(150*time.Second).Rate)
+	assert.Equal(t, DefaultOutputTokens, traffic.LoadAt(0).OutputTokens, "default applied")
+	assert.Nil(t, scenario.Traffic("sim", "other"))
+	assert.Equal(t, int32(1), scenario.Variants[0].Replicas)
+}
+
+func TestLoadScenario_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+models:
+- modelID: llama
+`), 0o600))
+
+	_, err := LoadScenario(path)
+	assert.ErrorContains(t, err, "at least one phase")
+}
+
+func TestDefaultScenario(t *testing.T) {
+	scenario, err := LoadScenario("")
+	require.NoError(t, err)
+
+	traffic := scenario.Traffic("any", "any")
+	require.NotNil(t, traffic)
+	assert.Equal(t, 1.0, traffic.LoadAt(0).Rate)
+	assert.Equal(t, 20.0, traffic.LoadAt(20*time.Minute).Rate)
+	require.Len(t, scenario.Variants, 1)
+	assert.Equal(t, DefaultModelID, scenario.Variants[0].ModelID)
+}
+
+func TestReplicaLoad(t *testing.T) {
+	server := Server{MaxNumSeqs: 8, NumGPUBlocks: 1000, BlockSize: 16, TTFT: 100 * time.Millisecond, ITL: 10 * time.Millisecond}
+	load := Load{Rate: 10, InputTokens: 400, OutputTokens: 190}
+
+	// service time 2s: each of 4 replicas holds 5 requests, all running
+	light := server.replicaLoad(load, 4)
+	assert.InDelta(t, 5, light.running, 1e-9)
+	assert.Zero(t, light.waiting)
+	assert.InDelta(t, 5*495/16000.0, light.kvCacheUsage, 1e-9)
+	assert.Equal(t, server.TTFT, light.ttft)
+
+	// a single replica holds 20 requests, runs 8 and queues 12
+	heavy := server.replicaLoad(load, 1)
+	assert.InDelta(t, 8, heavy.running, 1e-9)
+	assert.InDelta(t, 12, heavy.waiting, 1e-9)
+	assert.Greater(t, heavy.ttft, server.TTFT)
+
+	// no replicas serve no load
+	assert.Zero(t, server.replicaLoad(load, 0).running)
+}
@@ -0,0 +1,202 @@
+package simulator
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// Cache settings of the synthetic metrics
+const (
+	sourceCacheTTL             = 30 * time.Second
+	sourceCacheCleanupInterval = time.Minute
+)
+
+// Source is a MetricsSource generating the metrics of the ready virtual replicas of a
+// Cluster from the traffic of a Scenario. It answers the registered queries by name,
+// so it is registered in place of the Prometheus source; queries it does not simulate
+// (e.g. node GPU utilization, scheduler flow control) return no values.
+type Source struct {
+	scenario  *Scenario
+	cluster   *Cluster
+	queryList *source.QueryList
+	cache     *source.Cache
+	start     time.Time
+	now       func() time.Time
+
+	// mu serializes refreshes.
+	mu sync.Mutex
+}
+
+var _ source.MetricsSource = (*Source)(nil)
+
+// NewSource creates a synthetic metrics source; the scenario starts now.
+func NewSource(ctx context.Context, scenario *Scenario, cluster *Cluster) *Source {
+	return &Source{
+		scenario:  scenario,
+		cluster:   cluster,
+		queryList: source.NewQueryList(),
+		cache:     source.NewCache(ctx, sourceCacheTTL, sourceCacheCleanupInterval),
+		start:     time.Now(),
+		now:       time.Now,
+	}
+}
+
+// QueryList returns the query registry of this source.
+func (s *Source) QueryList() *source.QueryList {
+	return s.queryList
+}
+
+// Refresh generates the values of the queries for the model and namespace of the params.
+func (s *Source) Refresh(ctx context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queryNames := spec.Queries
+	if len(queryNames) == 0 {
+		queryNames = s.queryList.List()
+	}
+
+	namespace := spec.Params[source.ParamNamespace]
+	modelID := spec.Params[source.ParamModelID]
+	now := s.now()
+
+	var load Load
+	server := Server{}
+	if traffic := s.scenario.Traffic(namespace, modelID); traffic != nil {
+		load = traffic.LoadAt(now.Sub(s.start))
+		server = traffic.Server
+	}
+	pods := s.cluster.ReadyPods(namespace, modelID)
+	replica := server.replicaLoad(load, len(pods))
+
+	ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Simulated metrics",
+		"modelID", modelID,
+		"namespace", namespace,
+		"rate", load.Rate,
+		"readyReplicas", len(pods),
+		"kvCacheUsage", replica.kvCacheUsage,
+		"queueLength", replica.waiting)
+
+	// per-pod values
+	perPod := func(value float64, labels map[string]string) []source.MetricValue {
+		values := make([]source.MetricValue, 0, len(pods))
+		for _, pod := range pods {
+			podLabels := map[string]string{"pod": pod}
+			for k, v := range labels {
+				podLabels[k] = v
+			}
+			values = append(values, source.MetricValue{Value: value, Timestamp: now, Labels: podLabels})
+		}
+		return values
+	}
+	// model-level value, reported only while replicas serve the model
+	model := func(value float64) []source.MetricValue {
+		if len(pods) == 0 {
+			return nil
+		}
+		return []source.MetricValue{{Value: value, Timestamp: now, Labels: map[string]string{}}}
+	}
+
+	results := make(map[string]*source.MetricResult, len(queryNames))
+	for _, name := range queryNames {
+		var values []source.MetricValue
+		switch name {
+		case registration.QueryKvCacheUsage:
+			values = perPod(replica.kvCacheUsage, nil)
+		case registration.QueryQueueLength:
+			values = perPod(math.Round(replica.waiting), nil)
+		case registration.QueryCacheConfigInfo:
+			values = perPod(1, map[string]string{
+				"num_gpu_blocks": strconv.FormatInt(server.NumGPUBlocks, 10),
+				"block_size":     strconv.FormatInt(server.BlockSize, 10),
+			})
+		case registration.QueryAvgInputTokens:
+			values = perPod(float64(load.InputTokens), nil)
+		case registration.QueryAvgOutputTokens:
+			values = perPod(float64(load.OutputTokens), nil)
+		case registration.QueryPrefixCacheHitRate:
+			values = perPod(0, nil)
+		case registration.QueryReplicaTTFT:
+			values = perPod(replica.ttft.Seconds(), nil)
+		case registration.QueryRunningRequests:
+			values = perPod(replica.running, nil)
+		case registration.QueryReplicaErrorRate:
+			values = perPod(0, nil)
+		case registration.QueryRequestRate:
+			values = model(load.Rate * 60)
+		case registration.QueryAvgTTFT:
+			values = model(replica.ttft.Seconds())
+		case registration.QueryAvgITL:
+			values = model(server.ITL.Seconds())
+		case registration.QueryModelRequestCount:
+			retention, _ := time.ParseDuration(spec.Params[registration.ParamRetentionPeriod])
+			values = model(load.Rate * retention.Seconds())
+		}
+
+		result := source.MetricResult{QueryName: name, Values: values, CollectedAt: now}
+		s.cache.Set(source.BuildCacheKey(name, spec.Params), result, 0)
+		results[name] = &result
+	}
+	return results, nil
+}
+
+// Get retrieves the cached values of a query with the given parameters.
+func (s *Source) Get(queryName string, params map[string]string) *source.CachedValue {
+	cached, ok := s.cache.Get(source.BuildCacheKey(queryName, params))
+	if !ok {
+		return nil
+	}
+	return cached
+}
+
+// replicaLoad is the state of each replica of a server sharing a load.
+type replicaLoad struct {
+	running      float64       // requests being processed
+	waiting      float64       // requests queued
+	kvCacheUsage float64       // fraction of the KV cache in use
+	ttft         time.Duration // time to first token, including queueing
+}
+
+// replicaLoad returns the state of each of the given replicas sharing a load equally.
+// It is a fluid approximation: by Little's law a replica holds rate × service time
+// requests, of which it runs as many as its batch and KV cache allow and queues the rest.
+func (s Server) replicaLoad(load Load, replicas int) replicaLoad {
+	if replicas == 0 || load.Rate <= 0 || s.MaxNumSeqs <= 0 {
+		return replicaLoad{ttft: s.TTFT}
+	}
+
+	serviceTime := s.TTFT.Seconds() + float64(load.OutputTokens)*s.ITL.Seconds()
+	inFlight := load.Rate / float64(replicas) * serviceTime
+
+	// tokens held in the KV cache by a running request, on average over its decode
+	tokensPerRequest := float64(load.InputTokens) + float64(load.OutputTokens)/2
+	capacity := float64(s.NumGPUBlocks * s.BlockSize)
+	maxRunning := float64(s.MaxNumSeqs)
+	if tokensPerRequest > 0 && capacity > 0 {
+		maxRunning = math.Min(maxRunning, capacity/tokensPerRequest)
+	}
+
+	running := math.Min(inFlight, maxRunning)
+	waiting := inFlight - running
+	kvCacheUsage := 0.0
+	if capacity > 0 {
+		kvCacheUsage = math.Min(running*tokensPerRequest/capacity, 1)
+	}
+
+	// queued requests wait for running ones to complete
+	ttft := s.TTFT
+	if waiting > 0 && running > 0 {
+		ttft += time.Duration(waiting / running * serviceTime * float64(time.Second))
+	}
+
+	return replicaLoad{running: running, waiting: waiting, kvCacheUsage: kvCacheUsage, ttft: ttft}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// AcceleratorPreferenceNames returns the accelerator types of a variant in order of preference.
+func AcceleratorPreferenceNames(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) []string {
+	if len(va.Spec.AcceleratorPreferences) == 0 {
+		return nil
+	}
+	names := make([]string, len(va.Spec.AcceleratorPreferences))
+	for i, pref := range va.Spec.AcceleratorPreferences {
+		names[i] = pref.Accelerator
+	}
+	return names
+}
+
+// AddVariantProfilesToSystemData adds the per-accelerator profiles declared in the
+// accelerator preferences of a variant as model performance data.
+func AddVariantProfilesToSystemData(
+	sd *infernoConfig.SystemData,
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) error {
+
+	for _, pref := range va.Spec.AcceleratorPreferences {
+		perfData, err := variantProfileToPerfData(va.Spec.ModelID, pref.Accelerator, &pref.Profile)
+		if err != nil {
+			return fmt.Errorf("invalid profile for accelerator %s of variant %s: %w",
+				pref.Accelerator, FullName(va.Name, va.Namespace), err)
+		}
+		sd.Spec.Models.PerfData = append(sd.Spec.Models.PerfData, *perfData)
+	}
+	return nil
+}
+
+func variantProfileToPerfData(modelID, accelerator string,
+	profile *llmdVariantAutoscalingV1alpha1.VariantProfile) (*infernoConfig.ModelAcceleratorPerfData, error) {
+
+	var parms [3]float32
+	for i, val := range []string{profile.Alpha, profile.Beta, profile.Gamma} {
+		parm, err := strconv.ParseFloat(val, 32)
+		if err != nil || !CheckValue(parm) || parm < 0 {
+			return nil, fmt.Errorf("invalid service parameter %q", val)
+		}
+		parms[i] = float32(parm)
+	}
+	if profile.MaxBatchSize <= 0 || profile.AtTokens <= 0 {
+		return nil, fmt.Errorf("maxBatchSize and atTokens must be positive")
+	}
+	return &infernoConfig.ModelAcceleratorPerfData{
+		Name:         modelID,
+		Acc:          accelerator,
+		AccCount:     max(profile.AccCount, 1),
+		MaxBatchSize: profile.MaxBatchSize,
+		AtTokens:     profile.AtTokens,
+		ServiceParms: infernoConfig.ServiceParms{
+			Alpha: parms[0],
+			Beta:  parms[1],
+			Gamma: parms[2],
+		},
+	}, nil
+}
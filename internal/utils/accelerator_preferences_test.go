@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func preferenceTestVA() *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+	profile := func(accCount int) llmdVariantAutoscalingV1alpha1.VariantProfile {
+		return llmdVariantAutoscalingV1alpha1.VariantProfile{
+			AccCount:     accCount,
+			MaxBatchSize: 64,
+			AtTokens:     512,
+			Alpha:        "8.5",
+			Beta:         "0.3",
+			Gamma:        "0.001",
+		}
+	}
+	return &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"},
+		Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+			ModelID: "llama-8b",
+			AcceleratorPreferences: []llmdVariantAutoscalingV1alpha1.AcceleratorPreference{
+				{Accelerator: "H100", Profile: profile(1)},
+				{Accelerator: "A100", Profile: profile(2)},
+			},
+		},
+	}
+}
+
+func TestAddServerInfoToSystemData_AcceleratorPreferences(t *testing.T) {
+	va := preferenceTestVA()
+	sd := CreateSystemData(nil, nil, nil)
+	if err := AddServerInfoToSystemData(sd, va, &interfaces.Allocation{Accelerator: "H100", NumReplicas: 1}, "default"); err != nil {
+		t.Fatalf("AddServerInfoToSystemData() error = %v", err)
+	}
+
+	if len(sd.Spec.Servers.Spec) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(sd.Spec.Servers.Spec))
+	}
+	server := sd.Spec.Servers.Spec[0]
+	if server.KeepAccelerator {
+		t.Error("expected KeepAccelerator to be false with accelerator preferences")
+	}
+	if got := server.AcceleratorPreferences; len(got) != 2 || got[0] != "H100" || got[1] != "A100" {
+		t.Errorf("AcceleratorPreferences = %v, want [H100 A100]", got)
+	}
+
+	perfData := sd.Spec.Models.PerfData
+	if len(perfData) != 2 {
+		t.Fatalf("expected 2 perf data entries, got %d", len(perfData))
+	}
+	a100 := perfData[1]
+	if a100.Name != "llama-8b" || a100.Acc != "A100" || a100.AccCount != 2 || a100.MaxBatchSize != 64 || a100.AtTokens != 512 {
+		t.Errorf("unexpected perf data %+v", a100)
+	}
+	if a100.ServiceParms.Alpha != 8.5 || a100.ServiceParms.Beta != 0.3 || a100.ServiceParms.Gamma != 0.001 {
+		t.Errorf("unexpected service parameters %+v", a100.ServiceParms)
+	}
+}
+
+func TestAddServerInfoToSystemData_InvalidProfile(t *testing.T) {
+	va := preferenceTestVA()
+	va.Spec.AcceleratorPreferences[1].Profile.Beta = "fast"
+	sd := CreateSystemData(nil, nil, nil)
+	if err := AddServerInfoToSystemData(sd, va, nil, "default"); err == nil {
+		t.Error("expected error for invalid profile")
+	}
+}
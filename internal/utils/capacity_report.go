@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// capacityTotals accumulates the raw numbers behind a capacity summary before
+// they are formatted into the CRD's string fields.
+type capacityTotals struct {
+	replicas        int
+	desiredReplicas int
+	gpus            float64 // fractional when replicas share GPUs
+	cost            float64
+	saturation      float64 // sum of per-variant saturation weighted by current replicas
+}
+
+func (t *capacityTotals) add(d interfaces.VariantDecision) {
+	replicas := max(d.CurrentReplicas, 0)
+	t.replicas += replicas
+	t.desiredReplicas += max(d.TargetReplicas, 0)
+	t.gpus += float64(replicas) * math.Max(d.GPUsPerReplica, 0)
+	t.cost += float64(replicas) * math.Max(d.Cost, 0)
+	t.saturation += float64(replicas) * math.Min(1, math.Max(0, 1-d.SpareCapacity))
+}
+
+// wholeGPUs returns the GPUs consumed rounded up to whole GPUs: a GPU with any of its
+// shares in use is consumed.
+func (t *capacityTotals) wholeGPUs() int {
+	return int(math.Ceil(t.gpus - 1e-9))
+}
+
+func (t *capacityTotals) averageSaturation() float64 {
+	if t.replicas == 0 {
+		return 0
+	}
+	return t.saturation / float64(t.replicas)
+}
+
+// BuildNamespaceCapacityReports aggregates the decisions of one optimization run
+// into a NamespaceCapacityReportStatus per namespace, keyed by namespace.
+// Saturation is derived from each decision's SpareCapacity and weighted by current
+// replicas; GPUs and cost are those consumed by the current replicas.
+// LastUpdateTime is left for the caller to set.
+func BuildNamespaceCapacityReports(decisions []interfaces.VariantDecision) map[string]v1alpha1.NamespaceCapacityReportStatus {
+	namespaceTotals := make(map[string]*capacityTotals)
+	modelTotals := make(map[string]map[string]*capacityTotals)
+
+	for _, d := range decisions {
+		if d.Namespace == "" || d.ModelID == "" {
+			continue
+		}
+		if _, ok := namespaceTotals[d.Namespace]; !ok {
+			namespaceTotals[d.Namespace] = &capacityTotals{}
+			modelTotals[d.Namespace] = make(map[string]*capacityTotals)
+		}
+		namespaceTotals[d.Namespace].add(d)
+
+		models := modelTotals[d.Namespace]
+		if _, ok := models[d.ModelID]; !ok {
+			models[d.ModelID] = &capacityTotals{}
+		}
+		models[d.ModelID].add(d)
+	}
+
+	reports := make(map[string]v1alpha1.NamespaceCapacityReportStatus, len(namespaceTotals))
+	for namespace, totals := range namespaceTotals {
+		models := make([]v1alpha1.ModelCapacitySummary, 0, len(modelTotals[namespace]))
+		for modelID, mt := range modelTotals[namespace] {
+			models = append(models, v1alpha1.ModelCapacitySummary{
+				ModelID:         modelID,
+				Replicas:        mt.replicas,
+				DesiredReplicas: mt.desiredReplicas,
+				GPUs:            mt.wholeGPUs(),
+				Saturation:      strconv.FormatFloat(mt.averageSaturation(), 'f', 2, 64),
+				Cost:            strconv.FormatFloat(mt.cost, 'f', 2, 64),
+			})
+		}
+		sort.Slice(models, func(i, j int) bool { return models[i].ModelID < models[j].ModelID })
+
+		reports[namespace] = v1alpha1.NamespaceCapacityReportStatus{
+			TotalGPUs:           totals.wholeGPUs(),
+			TotalReplicas:       totals.replicas,
+			AggregateSaturation: strconv.FormatFloat(totals.averageSaturation(), 'f', 2, 64),
+			Cost:                strconv.FormatFloat(totals.cost, 'f', 2, 64),
+			Models:              models,
+		}
+	}
+	return reports
+}
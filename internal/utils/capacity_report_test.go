@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestBuildNamespaceCapacityReports(t *testing.T) {
+	decisions := []interfaces.VariantDecision{
+		{
+			VariantName:     "llama-a100",
+			Namespace:       "team-a",
+			ModelID:         "llama-8b",
+			Cost:            10,
+			CurrentReplicas: 2,
+			TargetReplicas:  3,
+			GPUsPerReplica:  1,
+			SpareCapacity:   0.2,
+		},
+		{
+			VariantName:     "llama-h100",
+			Namespace:       "team-a",
+			ModelID:         "llama-8b",
+			Cost:            20,
+			CurrentReplicas: 2,
+			TargetReplicas:  2,
+			GPUsPerReplica:  2,
+			SpareCapacity:   0.6,
+		},
+		{
+			VariantName:     "granite-a100",
+			Namespace:       "team-a",
+			ModelID:         "granite-2b",
+			Cost:            5,
+			CurrentReplicas: 0,
+			TargetReplicas:  1,
+			GPUsPerReplica:  1,
+			SpareCapacity:   1,
+		},
+		{
+			VariantName:     "mistral-l40s",
+			Namespace:       "team-b",
+			ModelID:         "mistral-7b",
+			Cost:            2.5,
+			CurrentReplicas: 1,
+			TargetReplicas:  1,
+			GPUsPerReplica:  1,
+			SpareCapacity:   1.5, // out of range, clamped to idle
+		},
+		{
+			VariantName:     "missing-namespace",
+			ModelID:         "llama-8b",
+			CurrentReplicas: 4,
+		},
+	}
+
+	reports := BuildNamespaceCapacityReports(decisions)
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 namespace reports, got %d", len(reports))
+	}
+
+	teamA := reports["team-a"]
+	if teamA.TotalReplicas != 4 {
+		t.Errorf("team-a TotalReplicas = %d, want 4", teamA.TotalReplicas)
+	}
+	if teamA.TotalGPUs != 6 {
+		t.Errorf("team-a TotalGPUs = %d, want 6", teamA.TotalGPUs)
+	}
+	if teamA.Cost != "60.00" {
+		t.Errorf("team-a Cost = %q, want %q", teamA.Cost, "60.00")
+	}
+	// (2*0.8 + 2*0.4) / 4 = 0.6
+	if teamA.AggregateSaturation != "0.60" {
+		t.Errorf("team-a AggregateSaturation = %q, want %q", teamA.AggregateSaturation, "0.60")
+	}
+	if len(teamA.Models) != 2 {
+		t.Fatalf("team-a expected 2 models, got %d", len(teamA.Models))
+	}
+	if teamA.Models[0].ModelID != "granite-2b" || teamA.Models[1].ModelID != "llama-8b" {
+		t.Errorf("team-a models not sorted by ModelID: %q, %q", teamA.Models[0].ModelID, teamA.Models[1].ModelID)
+	}
+	granite := teamA.Models[0]
+	if granite.Replicas != 0 || granite.DesiredReplicas != 1 || granite.GPUs != 0 {
+		t.Errorf("granite summary = %+v, want 0 replicas, 1 desired, 0 GPUs", granite)
+	}
+	if granite.Saturation != "0.00" || granite.Cost != "0.00" {
+		t.Errorf("granite saturation/cost = %q/%q, want 0.00/0.00", granite.Saturation, granite.Cost)
+	}
+	llama := teamA.Models[1]
+	if llama.Replicas != 4 || llama.DesiredReplicas != 5 || llama.GPUs != 6 {
+		t.Errorf("llama summary = %+v, want 4 replicas, 5 desired, 6 GPUs", llama)
+	}
+
+	teamB := reports["team-b"]
+	if teamB.AggregateSaturation != "0.00" {
+		t.Errorf("team-b AggregateSaturation = %q, want %q", teamB.AggregateSaturation, "0.00")
+	}
+	if teamB.Cost != "2.50" {
+		t.Errorf("team-b Cost = %q, want %q", teamB.Cost, "2.50")
+	}
+}
+
+func TestBuildNamespaceCapacityReportsEmpty(t *testing.T) {
+	if reports := BuildNamespaceCapacityReports(nil); len(reports) != 0 {
+		t.Errorf("expected no reports for no decisions, got %d", len(reports))
+	}
+}
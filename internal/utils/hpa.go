@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FindScaleTargetHPA returns the HorizontalPodAutoscaler scaling the given scale target,
+// or nil if there is none. When several do, which is a misconfiguration the HPA controller
+// refuses to act on, the first by name is returned.
+func FindScaleTargetHPA(ctx context.Context, c client.Client, namespace, kind, name string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := c.List(ctx, &hpas, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list HorizontalPodAutoscalers in namespace %s: %w", namespace, err)
+	}
+
+	var found *autoscalingv2.HorizontalPodAutoscaler
+	for i := range hpas.Items {
+		hpa := &hpas.Items[i]
+		if hpa.Spec.ScaleTargetRef.Kind != kind || hpa.Spec.ScaleTargetRef.Name != name {
+			continue
+		}
+		if found == nil || hpa.Name < found.Name {
+			found = hpa
+		}
+	}
+	return found, nil
+}
+
+// HPAMinReplicas returns the minReplicas of a HorizontalPodAutoscaler, which defaults to 1.
+func HPAMinReplicas(hpa *autoscalingv2.HorizontalPodAutoscaler) int {
+	if hpa.Spec.MinReplicas == nil {
+		return 1
+	}
+	return int(*hpa.Spec.MinReplicas)
+}
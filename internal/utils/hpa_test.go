@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func hpa(name, namespace, kind, target string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: kind, Name: target},
+			MaxReplicas:    10,
+		},
+	}
+}
+
+func TestFindScaleTargetHPA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := autoscalingv2.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		hpa("vllm-hpa-b", "ns", "Deployment", "vllm"),
+		hpa("vllm-hpa-a", "ns", "Deployment", "vllm"),
+		hpa("other-hpa", "ns", "Deployment", "other"),
+		hpa("statefulset-hpa", "ns", "StatefulSet", "sts"),
+		hpa("remote-hpa", "other", "Deployment", "remote"),
+	).Build()
+
+	tests := []struct {
+		name   string
+		kind   string
+		target string
+		want   string
+	}{
+		{name: "first of several HPAs by name", kind: "Deployment", target: "vllm", want: "vllm-hpa-a"},
+		{name: "single HPA", kind: "Deployment", target: "other", want: "other-hpa"},
+		{name: "kind must match", kind: "Deployment", target: "sts", want: ""},
+		{name: "namespace must match", kind: "Deployment", target: "remote", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := FindScaleTargetHPA(context.Background(), c, "ns", tt.kind, tt.target)
+			if err != nil {
+				t.Fatalf("FindScaleTargetHPA() failed: %v", err)
+			}
+			got := ""
+			if found != nil {
+				got = found.Name
+			}
+			if got != tt.want {
+				t.Errorf("FindScaleTargetHPA() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHPAMinReplicas(t *testing.T) {
+	h := hpa("vllm-hpa", "ns", "Deployment", "vllm")
+	if got := HPAMinReplicas(h); got != 1 {
+		t.Errorf("Expected minReplicas to default to 1, got %d", got)
+	}
+	h.Spec.MinReplicas = ptr.To(int32(0))
+	if got := HPAMinReplicas(h); got != 0 {
+		t.Errorf("Expected minReplicas 0, got %d", got)
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// PDBMinReplicas returns the fewest replicas a workload can run while the
+// PodDisruptionBudgets selecting the pods of its pod template stay satisfiable, along with
+// the name of the PodDisruptionBudget requiring them. Only an absolute minAvailable
+// constrains the replica count: a percentage or a maxUnavailable scales with the replicas
+// of the workload. It returns 0 when no PodDisruptionBudget constrains the workload.
+func PDBMinReplicas(ctx context.Context, c client.Client, w interfaces.Workload) (int, string, error) {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := c.List(ctx, &pdbs, client.InNamespace(w.GetNamespace())); err != nil {
+		return 0, "", fmt.Errorf("failed to list PodDisruptionBudgets in namespace %s: %w", w.GetNamespace(), err)
+	}
+
+	podLabels := labels.Set(w.PodTemplate().Labels)
+	minReplicas, pdbName := 0, ""
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.Type != intstr.Int {
+			continue
+		}
+		// A nil selector selects no pods, an empty one all pods of the namespace
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(podLabels) {
+			continue
+		}
+		if minAvailable := pdb.Spec.MinAvailable.IntValue(); minAvailable > minReplicas {
+			minReplicas, pdbName = minAvailable, pdb.Name
+		}
+	}
+	return minReplicas, pdbName, nil
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func pdb(name, namespace string, selector *metav1.LabelSelector, minAvailable, maxUnavailable *intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       selector,
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}
+}
+
+func TestPDBMinReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := policyv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	vllm := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}}
+	intValue := func(v int) *intstr.IntOrString { i := intstr.FromInt32(int32(v)); return &i }
+	percent := func(v string) *intstr.IntOrString { i := intstr.FromString(v); return &i }
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "vllm", "tier": "gpu"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pdbs     []*policyv1.PodDisruptionBudget
+		wantMin  int
+		wantName string
+	}{
+		{
+			name: "no PDB",
+		},
+		{
+			name:     "absolute minAvailable",
+			pdbs:     []*policyv1.PodDisruptionBudget{pdb("vllm-pdb", "ns", vllm, intValue(2), nil)},
+			wantMin:  2,
+			wantName: "vllm-pdb",
+		},
+		{
+			name: "strictest of several PDBs",
+			pdbs: []*policyv1.PodDisruptionBudget{
+				pdb("loose", "ns", vllm, intValue(1), nil),
+				pdb("strict", "ns", &metav1.LabelSelector{}, intValue(3), nil),
+			},
+			wantMin:  3,
+			wantName: "strict",
+		},
+		{
+			name: "percentage and maxUnavailable scale with replicas",
+			pdbs: []*policyv1.PodDisruptionBudget{
+				pdb("percent", "ns", vllm, percent("50%"), nil),
+				pdb("max-unavailable", "ns", vllm, nil, intValue(1)),
+			},
+		},
+		{
+			name: "PDBs not selecting the pods",
+			pdbs: []*policyv1.PodDisruptionBudget{
+				pdb("other-app", "ns", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}, intValue(2), nil),
+				pdb("no-selector", "ns", nil, intValue(2), nil),
+				pdb("other-namespace", "other", vllm, intValue(2), nil),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, p := range tt.pdbs {
+				builder = builder.WithObjects(p)
+			}
+
+			minReplicas, name, err := PDBMinReplicas(context.Background(), builder.Build(), NewDeploymentWorkload(deploy))
+			if err != nil {
+				t.Fatalf("PDBMinReplicas() failed: %v", err)
+			}
+			if minReplicas != tt.wantMin || name != tt.wantName {
+				t.Errorf("PDBMinReplicas() = (%d, %q), want (%d, %q)", minReplicas, name, tt.wantMin, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// ToBeDeletedTaint is the taint the cluster autoscaler sets on nodes it drains before removing them.
+const ToBeDeletedTaint = "ToBeDeletedByClusterAutoscaler"
+
+// GPUVendors lists the resource name prefixes for GPU vendors
+var GPUVendors = []string{"nvidia.com", "amd.com", "intel.com"}
+
+// CountUnschedulableGPUReplicas returns the number of replicas of a workload with a
+// Pending pod that the scheduler could not place because no node had enough free GPUs.
+func CountUnschedulableGPUReplicas(ctx context.Context, c client.Client, w interfaces.Workload) (int, error) {
+	pods, err := listWorkloadPods(ctx, c, w)
+	if err != nil {
+		return 0, err
+	}
+
+	unschedulable := make(map[string]bool)
+	for i := range pods {
+		if IsUnschedulableForGPU(&pods[i]) {
+			unschedulable[w.ReplicaOf(&pods[i])] = true
+		}
+	}
+	return len(unschedulable), nil
+}
+
+// listWorkloadPods lists the pods of a workload.
+func listWorkloadPods(ctx context.Context, c client.Client, w interfaces.Workload) ([]corev1.Pod, error) {
+	selector, err := w.PodSelector()
+	if err != nil {
+		return nil, err
+	}
+	if _, selectable := selector.Requirements(); !selectable {
+		return nil, nil
+	}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(w.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of %s %s/%s: %w", w.WorkloadKind(), w.GetNamespace(), w.GetName(), err)
+	}
+	return pods.Items, nil
+}
+
+// IsUnschedulableForGPU returns whether a pod is Pending because the scheduler found
+// no node with enough free GPUs.
+func IsUnschedulableForGPU(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodPending {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionFalse ||
+			cond.Reason != corev1.PodReasonUnschedulable {
+			continue
+		}
+		for _, vendor := range GPUVendors {
+			if strings.Contains(cond.Message, "Insufficient "+vendor+"/gpu") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CountDrainingReplicas returns the number of replicas of a workload with a pod running on
+// a node that is cordoned or being drained by the cluster autoscaler, i.e. a pod about to be
+// evicted. Pods already terminating are not counted: their replacements are already created.
+func CountDrainingReplicas(ctx context.Context, c client.Client, w interfaces.Workload) (int, error) {
+	pods, err := listWorkloadPods(ctx, c, w)
+	if err != nil {
+		return 0, err
+	}
+
+	draining := make(map[string]bool)
+	replicas := make(map[string]bool)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		nodeDraining, ok := draining[pod.Spec.NodeName]
+		if !ok {
+			var node corev1.Node
+			if err := c.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return 0, fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+				}
+			} else {
+				nodeDraining = IsNodeDraining(&node)
+			}
+			draining[pod.Spec.NodeName] = nodeDraining
+		}
+		if nodeDraining {
+			replicas[w.ReplicaOf(pod)] = true
+		}
+	}
+	return len(replicas), nil
+}
+
+// IsNodeDraining returns whether a node is cordoned, as by kubectl cordon or drain, or
+// tainted for deletion by the cluster autoscaler.
+func IsNodeDraining(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == ToBeDeletedTaint || taint.Key == corev1.TaintNodeUnschedulable {
+			return true
+		}
+	}
+	return false
+}
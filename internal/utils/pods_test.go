@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func unschedulablePod(name, app, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{"app": app}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: message,
+			}},
+		},
+	}
+}
+
+func TestIsUnschedulableForGPU(t *testing.T) {
+	running := unschedulablePod("running", "vllm", "")
+	running.Status.Phase = corev1.PodRunning
+	running.Status.Conditions = nil
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "insufficient nvidia GPUs",
+			pod:  unschedulablePod("p", "vllm", "0/4 nodes are available: 4 Insufficient nvidia.com/gpu."),
+			want: true,
+		},
+		{
+			name: "insufficient amd GPUs",
+			pod:  unschedulablePod("p", "vllm", "0/2 nodes are available: 2 Insufficient amd.com/gpu."),
+			want: true,
+		},
+		{
+			name: "insufficient memory only",
+			pod:  unschedulablePod("p", "vllm", "0/4 nodes are available: 4 Insufficient memory."),
+			want: false,
+		},
+		{
+			name: "running pod",
+			pod:  running,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnschedulableForGPU(tt.pod); got != tt.want {
+				t.Errorf("IsUnschedulableForGPU() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountUnschedulableGPUReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	gpuMessage := "0/4 nodes are available: 4 Insufficient nvidia.com/gpu."
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		unschedulablePod("vllm-1", "vllm", gpuMessage),
+		unschedulablePod("vllm-2", "vllm", gpuMessage),
+		unschedulablePod("vllm-3", "vllm", "0/4 nodes are available: 4 Insufficient cpu."),
+		unschedulablePod("other-1", "other", gpuMessage),
+	).Build()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}},
+		},
+	}
+
+	count, err := CountUnschedulableGPUReplicas(context.Background(), c, NewDeploymentWorkload(deploy))
+	if err != nil {
+		t.Fatalf("CountUnschedulableGPUReplicas() failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 unschedulable pods, got %d", count)
+	}
+
+	deploy.Spec.Selector = nil
+	count, err = CountUnschedulableGPUReplicas(context.Background(), c, NewDeploymentWorkload(deploy))
+	if err != nil || count != 0 {
+		t.Errorf("Expected 0 pods without a selector, got %d (err: %v)", count, err)
+	}
+}
+
+func TestCountDrainingReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	node := func(name string, unschedulable bool, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable, Taints: taints},
+		}
+	}
+	pod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{"app": "vllm"}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	terminating := pod("vllm-terminating", "cordoned")
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	terminating.Finalizers = []string{"test"}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		node("cordoned", true),
+		node("scaling-down", false, corev1.Taint{Key: ToBeDeletedTaint, Effect: corev1.TaintEffectNoSchedule}),
+		node("ready", false),
+		pod("vllm-1", "cordoned"),
+		pod("vllm-2", "cordoned"),
+		pod("vllm-3", "scaling-down"),
+		pod("vllm-4", "ready"),
+		pod("vllm-5", ""),
+		pod("vllm-6", "gone"),
+		terminating,
+	).Build()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "vllm", Namespace: "ns"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "vllm"}},
+		},
+	}
+
+	count, err := CountDrainingReplicas(context.Background(), c, NewDeploymentWorkload(deploy))
+	if err != nil {
+		t.Fatalf("CountDrainingReplicas() failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 draining pods, got %d", count)
+	}
+}
@@ -1,10 +1,52 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
+// MaxSourceResolutionParam is the query API parameter that sets the coarsest downsampling
+// resolution Thanos Query may read. Prometheus ignores it.
+const MaxSourceResolutionParam = "max_source_resolution"
+
+// Downsampling resolutions of Thanos.
+const (
+	MaxSourceResolutionRaw = "0s"
+	MaxSourceResolution5m  = "5m"
+	MaxSourceResolution1h  = "1h"
+)
+
+// SelectMaxSourceResolution returns the coarsest downsampling resolution a query over a
+// time window may read while keeping at least 12 samples per series in the window:
+// raw data below 1h, 5m resolution below 12h and 1h resolution beyond. Thanos falls back
+// to finer resolutions where downsampled blocks do not exist yet.
+func SelectMaxSourceResolution(window time.Duration) string {
+	switch {
+	case window >= 12*time.Hour:
+		return MaxSourceResolution1h
+	case window >= time.Hour:
+		return MaxSourceResolution5m
+	default:
+		return MaxSourceResolutionRaw
+	}
+}
+
+type maxSourceResolutionKey struct{}
+
+// WithMaxSourceResolution returns a context whose Prometheus queries ask for data at the
+// given max source resolution. It takes effect with clients created by
+// CreatePrometheusClientConfig.
+func WithMaxSourceResolution(ctx context.Context, resolution string) context.Context {
+	return context.WithValue(ctx, maxSourceResolutionKey{}, resolution)
+}
+
+// MaxSourceResolutionFrom returns the max source resolution set in the context, if any.
+func MaxSourceResolutionFrom(ctx context.Context) (string, bool) {
+	resolution, ok := ctx.Value(maxSourceResolutionKey{}).(string)
+	return resolution, ok && resolution != ""
+}
+
 // FormatPrometheusDuration converts a Go time.Duration to Prometheus duration format.
 // Prometheus uses formats like "5m", "1h", "30s", "1d".
 func FormatPrometheusDuration(d time.Duration) string {
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+const (
+	// defaultGCPMetadataHost is the host of the metadata server, which serves the access
+	// tokens of the Google service account of pods with GKE Workload Identity
+	defaultGCPMetadataHost = "metadata.google.internal"
+	gcpTokenPath           = "/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcpTokenRefreshMargin is how long before their expiration access tokens are renewed
+	gcpTokenRefreshMargin = time.Minute
+)
+
+// gcpTokenSource returns the OAuth access token of the Google service account of the
+// pod from the metadata server, renewing it before it expires.
+type gcpTokenSource struct {
+	mu      sync.Mutex
+	client  *http.Client
+	url     string
+	token   string
+	expires time.Time
+	now     func() time.Time
+}
+
+// newGCPTokenSource returns the token source of the metadata server, at the host of the
+// GCE_METADATA_HOST environment variable if set, like the Google Cloud client libraries.
+func newGCPTokenSource(client *http.Client) *gcpTokenSource {
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = defaultGCPMetadataHost
+	}
+	return &gcpTokenSource{
+		client: client,
+		url:    "http://" + host + gcpTokenPath,
+		now:    time.Now,
+	}
+}
+
+// Token returns the cached access token, retrieving a new one when it is about to expire.
+func (s *gcpTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Add(gcpTokenRefreshMargin).Before(s.expires) {
+		return s.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	body, err := doCredentialsRequest(s.client, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve GCP access token: %w", err)
+	}
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse GCP access token: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("metadata server returned no access token")
+	}
+	s.token = resp.AccessToken
+	s.expires = s.now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	ctrl.Log.V(logging.VERBOSE).Info("GCP access token retrieved", "expires", s.expires)
+	return s.token, nil
+}
+
+// gcpTokenRoundTripper authenticates requests with the access token of the Google service
+// account of the pod, as required by Google Cloud Managed Service for Prometheus
+type gcpTokenRoundTripper struct {
+	base   http.RoundTripper
+	source *gcpTokenSource
+}
+
+// RoundTrip adds the Authorization header with the current access token
+func (g *gcpTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := g.source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return g.base.RoundTrip(req)
+}
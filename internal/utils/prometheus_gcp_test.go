@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCPTokenRoundTripper(t *testing.T) {
+	var tokenCalls int
+	metadata := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenCalls++
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		assert.Equal(t, gcpTokenPath, r.URL.Path)
+		_, _ = w.Write([]byte(`{"access_token":"ya29.token","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer metadata.Close()
+
+	var authorization string
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer prometheus.Close()
+
+	t.Setenv("GCE_METADATA_HOST", metadata.Listener.Addr().String())
+	source := newGCPTokenSource(metadata.Client())
+	client := &http.Client{Transport: &gcpTokenRoundTripper{base: http.DefaultTransport, source: source}}
+	for range 2 {
+		resp, err := client.Get(prometheus.URL + "/api/v1/query?query=up")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, "Bearer ya29.token", authorization)
+	assert.Equal(t, 1, tokenCalls, "token should be cached")
+
+	// renewed shortly before it expires
+	source.now = func() time.Time { return time.Now().Add(59*time.Minute + 30*time.Second) }
+	_, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, tokenCalls)
+}
@@ -0,0 +1,358 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+
+	// awsCredentialsRefreshMargin is how long before their expiration temporary
+	// credentials are renewed
+	awsCredentialsRefreshMargin = 5 * time.Minute
+)
+
+// awsCredentials are the AWS credentials requests are signed with.
+// Expiration is zero for long-term credentials.
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// awsCredentialsProvider returns the credentials requests are signed with,
+// renewing temporary credentials before they expire.
+type awsCredentialsProvider struct {
+	mu          sync.Mutex
+	cached      *awsCredentials
+	retrieve    func(ctx context.Context) (*awsCredentials, error)
+	description string
+	now         func() time.Time
+}
+
+// newAWSCredentialsProvider returns the provider of the credentials of the pod, looked up
+// in the environment like the AWS SDKs do:
+//   - IAM roles for service accounts (IRSA): AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE
+//   - EKS Pod Identity: AWS_CONTAINER_CREDENTIALS_FULL_URI and AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE
+//   - static credentials: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+func newAWSCredentialsProvider(client *http.Client, region string) (*awsCredentialsProvider, error) {
+	provider := &awsCredentialsProvider{now: time.Now}
+	switch {
+	case os.Getenv("AWS_ROLE_ARN") != "" && os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "":
+		roleARN, tokenFile := os.Getenv("AWS_ROLE_ARN"), os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+		sessionName := os.Getenv("AWS_ROLE_SESSION_NAME")
+		if sessionName == "" {
+			sessionName = fmt.Sprintf("wva-%d", time.Now().UnixNano())
+		}
+		endpoint := fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+		provider.description = "web identity"
+		provider.retrieve = func(ctx context.Context) (*awsCredentials, error) {
+			return assumeRoleWithWebIdentity(ctx, client, endpoint, roleARN, sessionName, tokenFile)
+		}
+	case os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI") != "":
+		endpoint, tokenFile := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI"), os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE")
+		provider.description = "container credentials"
+		provider.retrieve = func(ctx context.Context) (*awsCredentials, error) {
+			return containerCredentials(ctx, client, endpoint, tokenFile)
+		}
+	case os.Getenv("AWS_ACCESS_KEY_ID") != "" && os.Getenv("AWS_SECRET_ACCESS_KEY") != "":
+		static := &awsCredentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+		provider.description = "static"
+		provider.retrieve = func(context.Context) (*awsCredentials, error) {
+			return static, nil
+		}
+	default:
+		return nil, fmt.Errorf("no AWS credentials found: set AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE (IRSA), " +
+			"AWS_CONTAINER_CREDENTIALS_FULL_URI (EKS Pod Identity), or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return provider, nil
+}
+
+// Credentials returns the cached credentials, retrieving new ones when they are about to expire.
+func (p *awsCredentialsProvider) Credentials(ctx context.Context) (*awsCredentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cached != nil && (p.cached.Expiration.IsZero() || p.now().Add(awsCredentialsRefreshMargin).Before(p.cached.Expiration)) {
+		return p.cached, nil
+	}
+	creds, err := p.retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s AWS credentials: %w", p.description, err)
+	}
+	p.cached = creds
+	ctrl.Log.V(logging.VERBOSE).Info("AWS credentials retrieved", "source", p.description, "expiration", creds.Expiration)
+	return creds, nil
+}
+
+// assumeRoleWithWebIdentity exchanges the service account token of the pod for temporary
+// credentials of an IAM role with the STS AssumeRoleWithWebIdentity action.
+func assumeRoleWithWebIdentity(ctx context.Context, client *http.Client,
+	endpoint, roleARN, sessionName, tokenFile string) (*awsCredentials, error) {
+
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read web identity token from %s: %w", tokenFile, err)
+	}
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {sessionName},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := doCredentialsRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Credentials struct {
+			AccessKeyID     string    `xml:"AccessKeyId"`
+			SecretAccessKey string    `xml:"SecretAccessKey"`
+			SessionToken    string    `xml:"SessionToken"`
+			Expiration      time.Time `xml:"Expiration"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse STS response: %w", err)
+	}
+	if resp.Credentials.AccessKeyID == "" {
+		return nil, fmt.Errorf("STS response has no credentials")
+	}
+	return &awsCredentials{
+		AccessKeyID:     resp.Credentials.AccessKeyID,
+		SecretAccessKey: resp.Credentials.SecretAccessKey,
+		SessionToken:    resp.Credentials.SessionToken,
+		Expiration:      resp.Credentials.Expiration,
+	}, nil
+}
+
+// containerCredentials retrieves temporary credentials from the container credentials
+// endpoint, e.g. the EKS Pod Identity agent.
+func containerCredentials(ctx context.Context, client *http.Client, endpoint, tokenFile string) (*awsCredentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tokenFile != "" {
+		token, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read authorization token from %s: %w", tokenFile, err)
+		}
+		req.Header.Set("Authorization", strings.TrimSpace(string(token)))
+	}
+	body, err := doCredentialsRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		AccessKeyID     string    `json:"AccessKeyId"`
+		SecretAccessKey string    `json:"SecretAccessKey"`
+		Token           string    `json:"Token"`
+		Expiration      time.Time `json:"Expiration"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse container credentials: %w", err)
+	}
+	if resp.AccessKeyID == "" {
+		return nil, fmt.Errorf("container credentials response has no credentials")
+	}
+	return &awsCredentials{
+		AccessKeyID:     resp.AccessKeyID,
+		SecretAccessKey: resp.SecretAccessKey,
+		SessionToken:    resp.Token,
+		Expiration:      resp.Expiration,
+	}, nil
+}
+
+// doCredentialsRequest sends a request to a credentials endpoint and returns the body of
+// a successful response.
+func doCredentialsRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// sigV4RoundTripper signs requests with AWS Signature Version 4, as required by
+// Amazon Managed Service for Prometheus
+type sigV4RoundTripper struct {
+	base        http.RoundTripper
+	credentials *awsCredentialsProvider
+	region      string
+	service     string
+	now         func() time.Time
+}
+
+// RoundTrip signs a copy of the request with the current credentials
+func (s *sigV4RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	creds, err := s.credentials.Credentials(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body for signing: %w", err)
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	signSigV4(req, body, creds, s.region, s.service, s.now())
+	return s.base.RoundTrip(req)
+}
+
+// signSigV4 sets the X-Amz-Date, X-Amz-Security-Token and Authorization headers of a
+// request signed with AWS Signature Version 4.
+func signSigV4(req *http.Request, body []byte, creds *awsCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(sigV4TimeFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "x-amz-date" || name == "x-amz-security-token" || name == "content-type" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		sigV4CanonicalURI(req.URL),
+		sigV4CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(sigV4DateFormat), region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(sigV4DateFormat))
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// sigV4CanonicalURI returns the path of a URL with each segment encoded twice, as
+// services other than S3 expect.
+func sigV4CanonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = sigV4Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// sigV4CanonicalQuery returns the query parameters encoded and sorted by name, then value.
+func sigV4CanonicalQuery(query url.Values) string {
+	type param struct{ name, value string }
+	var params []param
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, param{sigV4Escape(name), sigV4Escape(value)})
+		}
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].name != params[j].name {
+			return params[i].name < params[j].name
+		}
+		return params[i].value < params[j].value
+	})
+	encoded := make([]string, len(params))
+	for i, p := range params {
+		encoded[i] = p.name + "=" + p.value
+	}
+	return strings.Join(encoded, "&")
+}
+
+// sigV4Escape percent-encodes all bytes but the unreserved characters of RFC 3986.
+func sigV4Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+var sigV4TestCredentials = &awsCredentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+func TestSignSigV4(t *testing.T) {
+	// get-vanilla of the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	signSigV4(req, nil, sigV4TestCredentials, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestSigV4CanonicalQuery(t *testing.T) {
+	query := url.Values{"query": {"sum(rate(x[5m]))"}, "a-b": {"2"}, "a": {"2", "1"}}
+	assert.Equal(t, "a=1&a=2&a-b=2&query=sum%28rate%28x%5B5m%5D%29%29", sigV4CanonicalQuery(query))
+	assert.Equal(t, "/workspaces/ws-1/api/v1/query", sigV4CanonicalURI(&url.URL{Path: "/workspaces/ws-1/api/v1/query"}))
+}
+
+func TestSigV4RoundTripper(t *testing.T) {
+	var authorization, token, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		token = r.Header.Get("X-Amz-Security-Token")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	credentials := &awsCredentialsProvider{
+		now: time.Now,
+		retrieve: func(context.Context) (*awsCredentials, error) {
+			return &awsCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"}, nil
+		},
+	}
+	client := &http.Client{Transport: &sigV4RoundTripper{
+		base:        http.DefaultTransport,
+		credentials: credentials,
+		region:      "us-west-2",
+		service:     "aps",
+		now:         time.Now,
+	}}
+	resp, err := client.PostForm(server.URL+"/api/v1/query", url.Values{"query": {"up"}})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Contains(t, authorization, "Credential=AKID/")
+	assert.Contains(t, authorization, "/us-west-2/aps/aws4_request")
+	assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token")
+	assert.Equal(t, "session", token)
+	assert.Equal(t, "query=up", body)
+}
+
+func TestAssumeRoleWithWebIdentity(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("jwt\n"), 0o600))
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/wva", r.Form.Get("RoleArn"))
+		assert.Equal(t, "jwt", r.Form.Get("WebIdentityToken"))
+		_, _ = fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>ASIA</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>session</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	provider := &awsCredentialsProvider{
+		now:         time.Now,
+		description: "web identity",
+		retrieve: func(ctx context.Context) (*awsCredentials, error) {
+			return assumeRoleWithWebIdentity(ctx, server.Client(), server.URL, "arn:aws:iam::123456789012:role/wva", "wva", tokenFile)
+		},
+	}
+	creds, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA", creds.AccessKeyID)
+	assert.Equal(t, "session", creds.SessionToken)
+
+	// cached until shortly before they expire
+	_, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	provider.now = func() time.Time { return time.Now().Add(58 * time.Minute) }
+	_, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCreatePrometheusClientConfig_ManagedAuth(t *testing.T) {
+	t.Setenv("AWS_ROLE_ARN", "")
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	cfg := testConfigFromEnv(t, map[string]string{
+		"PROMETHEUS_BASE_URL":  "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1",
+		"PROMETHEUS_AUTH_TYPE": config.PrometheusAuthSigV4,
+	})
+	_, err := CreatePrometheusClientConfig(cfg)
+	require.Error(t, err, "expected an error without a region")
+	assert.True(t, strings.Contains(err.Error(), "region"))
+
+	t.Setenv("AWS_REGION", "us-east-1")
+	clientConfig, err := CreatePrometheusClientConfig(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, clientConfig.RoundTripper)
+
+	cfg = testConfigFromEnv(t, map[string]string{
+		"PROMETHEUS_BASE_URL":  "https://monitoring.googleapis.com/v1/projects/p/location/global/prometheus",
+		"PROMETHEUS_AUTH_TYPE": config.PrometheusAuthGCP,
+	})
+	_, err = CreatePrometheusClientConfig(cfg)
+	require.NoError(t, err)
+}
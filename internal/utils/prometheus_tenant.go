@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+// tenantTokenRefreshInterval is how often the bearer token of a tenant is read again from
+// its Secret, so that rotated tokens are picked up
+const tenantTokenRefreshInterval = 5 * time.Minute
+
+// CreateTenantPrometheusClientConfig creates the Prometheus client configuration of the
+// Prometheus of a namespace, set in the Prometheus tenants ConfigMap. It uses the TLS
+// configuration of the global Prometheus and, unless the tenant has a token Secret read
+// with reader, its credentials.
+func CreateTenantPrometheusClientConfig(cfg *config.Config, tenant config.PrometheusTenantEntry, reader client.Reader) (*api.Config, error) {
+	transport, err := CreatePrometheusTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant.TokenSecret.Name != "" {
+		transport = &maxSourceResolutionRoundTripper{base: transport}
+		transport = &secretTokenRoundTripper{
+			base:   transport,
+			reader: reader,
+			secret: types.NamespacedName{Namespace: tenant.Namespace, Name: tenant.TokenSecret.Name},
+			key:    tenant.TokenSecret.Key,
+			now:    time.Now,
+		}
+	} else {
+		transport, err = withPrometheusAuth(cfg, transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tenant.Tenant != "" {
+		// Set before requests are signed
+		transport = &tenantHeaderRoundTripper{base: transport, header: tenant.TenantHeader, tenant: tenant.Tenant}
+	}
+
+	address := tenant.URL
+	if address == "" {
+		address = cfg.PrometheusBaseURL()
+	}
+	return &api.Config{
+		Address:      address,
+		RoundTripper: transport,
+	}, nil
+}
+
+// tenantHeaderRoundTripper sets the tenant of requests to multi-tenant Prometheus backends
+type tenantHeaderRoundTripper struct {
+	base   http.RoundTripper
+	header string
+	tenant string
+}
+
+// RoundTrip adds the tenant header
+func (t *tenantHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.tenant)
+	return t.base.RoundTrip(req)
+}
+
+// secretTokenRoundTripper authenticates requests with a bearer token read from a key of a
+// Secret, read again every tenantTokenRefreshInterval
+type secretTokenRoundTripper struct {
+	base   http.RoundTripper
+	reader client.Reader
+	secret types.NamespacedName
+	key    string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	fetched time.Time
+}
+
+// Token returns the bearer token, reading it from the Secret when it is missing or due
+// for a refresh. A token read before is kept when the Secret cannot be read.
+func (s *secretTokenRoundTripper) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Sub(s.fetched) < tenantTokenRefreshInterval {
+		return s.token, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := s.reader.Get(ctx, s.secret, secret); err != nil {
+		if s.token != "" {
+			return s.token, nil
+		}
+		return "", fmt.Errorf("failed to read Prometheus token Secret %s: %w", s.secret, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[s.key]))
+	if token == "" {
+		return "", fmt.Errorf("prometheus token Secret %s has no token in key %q", s.secret, s.key)
+	}
+	s.token = token
+	s.fetched = s.now()
+	return s.token, nil
+}
+
+// RoundTrip adds the Authorization header with the current bearer token
+func (s *secretTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := s.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return s.base.RoundTrip(req)
+}
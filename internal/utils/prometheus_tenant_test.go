@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+func TestCreateTenantPrometheusClientConfig(t *testing.T) {
+	var authorization, orgID string
+	prometheus := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		orgID = r.Header.Get(config.DefaultPrometheusTenantHeader)
+	}))
+	defer prometheus.Close()
+
+	cfg := testConfigFromEnv(t, map[string]string{
+		"PROMETHEUS_BASE_URL":                 "https://prometheus:9090",
+		"PROMETHEUS_BEARER_TOKEN":             "global-token",
+		"PROMETHEUS_TLS_INSECURE_SKIP_VERIFY": "true",
+	})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-token", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("team-a-token\n")},
+	}).Build()
+
+	tests := []struct {
+		name              string
+		tenant            config.PrometheusTenantEntry
+		wantAddress       string
+		wantAuthorization string
+		wantOrgID         string
+	}{
+		{
+			name: "own Prometheus with token secret",
+			tenant: config.PrometheusTenantEntry{
+				Namespace:   "team-a",
+				URL:         prometheus.URL,
+				TokenSecret: config.SecretKeyRef{Name: "prometheus-token", Key: "token"},
+			},
+			wantAddress:       prometheus.URL,
+			wantAuthorization: "Bearer team-a-token",
+		},
+		{
+			name: "tenant of the global Prometheus with global credentials",
+			tenant: config.PrometheusTenantEntry{
+				Namespace:    "team-b",
+				Tenant:       "b",
+				TenantHeader: config.DefaultPrometheusTenantHeader,
+			},
+			wantAddress:       "https://prometheus:9090",
+			wantAuthorization: "Bearer global-token",
+			wantOrgID:         "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConfig, err := CreateTenantPrometheusClientConfig(cfg, tt.tenant, reader)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, clientConfig.Address)
+
+			client := &http.Client{Transport: clientConfig.RoundTripper}
+			resp, err := client.Get(prometheus.URL + "/api/v1/query?query=up")
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.wantAuthorization, authorization)
+			assert.Equal(t, tt.wantOrgID, orgID)
+		})
+	}
+}
+
+func TestSecretTokenRoundTripper_Refresh(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-token", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("old")},
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	now := time.Now()
+	rt := &secretTokenRoundTripper{
+		reader: reader,
+		secret: client.ObjectKeyFromObject(secret),
+		key:    "token",
+		now:    func() time.Time { return now },
+	}
+	token, err := rt.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "old", token)
+
+	secret.Data["token"] = []byte("rotated")
+	require.NoError(t, reader.Update(t.Context(), secret))
+	token, err = rt.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "old", token, "token should be cached")
+
+	now = now.Add(tenantTokenRefreshInterval)
+	token, err = rt.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", token)
+
+	// The last token is kept while the Secret cannot be read
+	require.NoError(t, reader.Delete(t.Context(), secret))
+	now = now.Add(tenantTokenRefreshInterval)
+	token, err = rt.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", token)
+}
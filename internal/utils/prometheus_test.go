@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -39,3 +42,54 @@ var _ = Describe("FormatPrometheusDuration", func() {
 		Entry("zero", time.Duration(0), "1s"),
 	)
 })
+
+var _ = Describe("SelectMaxSourceResolution", func() {
+	DescribeTable("selects the resolution from the window length",
+		func(window time.Duration, expected string) {
+			Expect(SelectMaxSourceResolution(window)).To(Equal(expected))
+		},
+		Entry("no window", time.Duration(0), MaxSourceResolutionRaw),
+		Entry("10 minutes", 10*time.Minute, MaxSourceResolutionRaw),
+		Entry("1 hour", time.Hour, MaxSourceResolution5m),
+		Entry("6 hours", 6*time.Hour, MaxSourceResolution5m),
+		Entry("12 hours", 12*time.Hour, MaxSourceResolution1h),
+		Entry("7 days", 7*24*time.Hour, MaxSourceResolution1h),
+	)
+})
+
+var _ = Describe("maxSourceResolutionRoundTripper", func() {
+	var (
+		server *httptest.Server
+		query  string
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+		}))
+		client = &http.Client{Transport: &maxSourceResolutionRoundTripper{base: http.DefaultTransport}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/v1/query", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+	}
+
+	It("adds the max source resolution set in the context", func() {
+		send(WithMaxSourceResolution(context.Background(), MaxSourceResolution5m))
+		Expect(query).To(Equal(MaxSourceResolutionParam + "=5m"))
+	})
+
+	It("leaves requests without a max source resolution unchanged", func() {
+		send(context.Background())
+		Expect(query).To(BeEmpty())
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/api"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -13,6 +14,9 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
+// managedAuthTimeout bounds the requests for the credentials of managed Prometheus services
+const managedAuthTimeout = 10 * time.Second
+
 // CreatePrometheusTransport creates a custom HTTPS transport for Prometheus client with TLS support.
 // TLS is always enabled for HTTPS-only support with configurable certificate validation.
 func CreatePrometheusTransport(cfg *config.Config) (http.RoundTripper, error) {
@@ -31,18 +35,29 @@ func CreatePrometheusTransport(cfg *config.Config) (http.RoundTripper, error) {
 }
 
 // CreatePrometheusClientConfig creates a complete Prometheus client configuration with HTTPS support.
-// Supports both direct bearer tokens and token files for flexible authentication.
+// Supports direct bearer tokens, token files, and the workload identity of the pod on
+// managed Prometheus services (PROMETHEUS_AUTH_TYPE) for flexible authentication.
 func CreatePrometheusClientConfig(cfg *config.Config) (*api.Config, error) {
-	clientConfig := &api.Config{
-		Address: cfg.PrometheusBaseURL(),
-	}
-
 	// Create custom HTTPS transport with TLS support
 	transport, err := CreatePrometheusTransport(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	transport, err = withPrometheusAuth(cfg, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Config{
+		Address:      cfg.PrometheusBaseURL(),
+		RoundTripper: transport,
+	}, nil
+}
+
+// withPrometheusAuth wraps a transport with the global Prometheus credentials, and with
+// the max source resolution of long-window queries, which requests are signed with.
+func withPrometheusAuth(cfg *config.Config, transport http.RoundTripper) (http.RoundTripper, error) {
 	// Add bearer token authentication if provided
 	bearerToken := cfg.PrometheusBearerToken()
 
@@ -56,6 +71,16 @@ func CreatePrometheusClientConfig(cfg *config.Config) (*api.Config, error) {
 		ctrl.Log.V(logging.VERBOSE).Info("Bearer token loaded from file", "path", cfg.PrometheusTokenPath())
 	}
 
+	// Authenticate with the workload identity of the pod on managed Prometheus services.
+	// Requests are signed last, once the max source resolution is set.
+	transport, err := withManagedPrometheusAuth(cfg, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pass the max source resolution of long-window queries to Thanos
+	transport = &maxSourceResolutionRoundTripper{base: transport}
+
 	if bearerToken != "" {
 		// Create a custom round tripper that adds the bearer token
 		transport = &bearerTokenRoundTripper{
@@ -64,9 +89,43 @@ func CreatePrometheusClientConfig(cfg *config.Config) (*api.Config, error) {
 		}
 	}
 
-	clientConfig.RoundTripper = transport
+	return transport, nil
+}
 
-	return clientConfig, nil
+// withManagedPrometheusAuth wraps a transport with the authentication of the managed
+// Prometheus service of PROMETHEUS_AUTH_TYPE, if any.
+func withManagedPrometheusAuth(cfg *config.Config, transport http.RoundTripper) (http.RoundTripper, error) {
+	credentialsClient := &http.Client{Timeout: managedAuthTimeout}
+	switch cfg.PrometheusAuthType() {
+	case config.PrometheusAuthSigV4:
+		region := cfg.PrometheusSigV4Region()
+		if region == "" {
+			region = os.Getenv("AWS_REGION")
+		}
+		if region == "" {
+			region = os.Getenv("AWS_DEFAULT_REGION")
+		}
+		if region == "" {
+			return nil, fmt.Errorf("AWS region is required for SigV4 authentication: set PROMETHEUS_SIGV4_REGION or AWS_REGION")
+		}
+		credentials, err := newAWSCredentialsProvider(credentialsClient, region)
+		if err != nil {
+			return nil, err
+		}
+		ctrl.Log.Info("Prometheus requests are signed with AWS SigV4",
+			"region", region, "service", cfg.PrometheusSigV4Service(), "credentials", credentials.description)
+		return &sigV4RoundTripper{
+			base:        transport,
+			credentials: credentials,
+			region:      region,
+			service:     cfg.PrometheusSigV4Service(),
+			now:         time.Now,
+		}, nil
+	case config.PrometheusAuthGCP:
+		ctrl.Log.Info("Prometheus requests are authenticated with the GCP service account of the pod")
+		return &gcpTokenRoundTripper{base: transport, source: newGCPTokenSource(credentialsClient)}, nil
+	}
+	return transport, nil
 }
 
 // bearerTokenRoundTripper adds bearer token authentication to HTTPS requests
@@ -80,3 +139,23 @@ func (b *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response,
 	req.Header.Set("Authorization", "Bearer "+b.token)
 	return b.base.RoundTrip(req)
 }
+
+// maxSourceResolutionRoundTripper adds the max source resolution set in the request
+// context (see WithMaxSourceResolution) to Prometheus API requests
+type maxSourceResolutionRoundTripper struct {
+	base http.RoundTripper
+}
+
+// RoundTrip adds the max_source_resolution query parameter when the context sets one.
+// Thanos reads it from the URL for both GET and POST query requests.
+func (m *maxSourceResolutionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resolution, ok := MaxSourceResolutionFrom(req.Context())
+	if !ok {
+		return m.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	query.Set(MaxSourceResolutionParam, resolution)
+	req.URL.RawQuery = query.Encode()
+	return m.base.RoundTrip(req)
+}
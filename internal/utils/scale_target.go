@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+// ResolveScaleTargetSelector returns the reference of the workload selected by the
+// spec.scaleTargetSelector of a VariantAutoscaling: a Deployment, a StatefulSet or a
+// LeaderWorkerSet, the kinds of scale targets GetWorkloadWithBackoff supports. The selector
+// must select exactly one workload of any of these kinds in the namespace of the variant:
+// it fails with ErrTargetNotFound if none matches and with ErrTargetAmbiguous if several do.
+// LeaderWorkerSets are skipped on clusters without the LeaderWorkerSet CRD.
+func ResolveScaleTargetSelector(ctx context.Context, c client.Client, va *wvav1alpha1.VariantAutoscaling) (autoscalingv1.CrossVersionObjectReference, error) {
+	selector, err := metav1.LabelSelectorAsSelector(va.Spec.ScaleTargetSelector)
+	if err != nil {
+		return autoscalingv1.CrossVersionObjectReference{}, fmt.Errorf("%w: scaleTargetSelector: %w", wvaerrors.ErrInvalidConfiguration, err)
+	}
+
+	matches, err := listSelectedWorkloads(ctx, c, va.Namespace, selector)
+	if err != nil {
+		return autoscalingv1.CrossVersionObjectReference{}, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return autoscalingv1.CrossVersionObjectReference{}, fmt.Errorf("%w: no workload matches selector %s", wvaerrors.ErrTargetNotFound, selector)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, ref := range matches {
+			names = append(names, ref.Kind+"/"+ref.Name)
+		}
+		return autoscalingv1.CrossVersionObjectReference{}, fmt.Errorf("%w: workloads %v match selector %s", wvaerrors.ErrTargetAmbiguous, names, selector)
+	}
+}
+
+// listSelectedWorkloads returns the references of the workloads of a namespace, not being
+// deleted, whose labels match selector.
+func listSelectedWorkloads(ctx context.Context, c client.Client, namespace string, selector labels.Selector) ([]autoscalingv1.CrossVersionObjectReference, error) {
+	opts := []client.ListOption{client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}}
+	var matches []autoscalingv1.CrossVersionObjectReference
+
+	var deployments appsv1.DeploymentList
+	if err := c.List(ctx, &deployments, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list Deployments in namespace %s: %w", namespace, err)
+	}
+	for i := range deployments.Items {
+		if deployments.Items[i].DeletionTimestamp.IsZero() {
+			matches = append(matches, autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1", Kind: DeploymentKind, Name: deployments.Items[i].Name,
+			})
+		}
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := c.List(ctx, &statefulSets, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list StatefulSets in namespace %s: %w", namespace, err)
+	}
+	for i := range statefulSets.Items {
+		if statefulSets.Items[i].DeletionTimestamp.IsZero() {
+			matches = append(matches, autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1", Kind: StatefulSetKind, Name: statefulSets.Items[i].Name,
+			})
+		}
+	}
+
+	leaderWorkerSets := &unstructured.UnstructuredList{}
+	leaderWorkerSets.SetGroupVersionKind(LeaderWorkerSetGroupVersion.WithKind(constants.LeaderWorkerSetKind + "List"))
+	if err := c.List(ctx, leaderWorkerSets, opts...); err != nil {
+		if !meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("failed to list LeaderWorkerSets in namespace %s: %w", namespace, err)
+		}
+	}
+	for i := range leaderWorkerSets.Items {
+		if leaderWorkerSets.Items[i].GetDeletionTimestamp().IsZero() {
+			matches = append(matches, autoscalingv1.CrossVersionObjectReference{
+				APIVersion: LeaderWorkerSetGroupVersion.String(), Kind: constants.LeaderWorkerSetKind, Name: leaderWorkerSets.Items[i].GetName(),
+			})
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+func TestResolveScaleTargetSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	deployment := func(name, namespace, app string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: namespace, Labels: map[string]string{"app": app},
+		}}
+	}
+	statefulSet := func(name, app string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "ns", Labels: map[string]string{"app": app},
+		}}
+	}
+	lws := leaderWorkerSet("llama-lws", 1, 2, 8)
+	lws.SetLabels(map[string]string{"app": "llama"})
+	va := &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "ns"},
+		Spec: wvav1alpha1.VariantAutoscalingSpec{
+			ScaleTargetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "llama"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		workloads []client.Object
+		wantKind  string
+		wantName  string
+		wantErr   error
+	}{
+		{
+			name: "one match",
+			workloads: []client.Object{
+				deployment("llama-v2", "ns", "llama"),
+				deployment("mistral", "ns", "mistral"),
+				deployment("llama", "other", "llama"),
+			},
+			wantKind: DeploymentKind,
+			wantName: "llama-v2",
+		},
+		{
+			name:      "statefulset match",
+			workloads: []client.Object{statefulSet("llama-sts", "llama"), deployment("mistral", "ns", "mistral")},
+			wantKind:  StatefulSetKind,
+			wantName:  "llama-sts",
+		},
+		{
+			name:      "leaderworkerset match",
+			workloads: []client.Object{lws, statefulSet("mistral", "mistral")},
+			wantKind:  constants.LeaderWorkerSetKind,
+			wantName:  "llama-lws",
+		},
+		{
+			name:      "no match",
+			workloads: []client.Object{deployment("mistral", "ns", "mistral")},
+			wantErr:   wvaerrors.ErrTargetNotFound,
+		},
+		{
+			name: "several matches",
+			workloads: []client.Object{
+				deployment("llama-blue", "ns", "llama"),
+				deployment("llama-green", "ns", "llama"),
+			},
+			wantErr: wvaerrors.ErrTargetAmbiguous,
+		},
+		{
+			name:      "matches of different kinds",
+			workloads: []client.Object{deployment("llama", "ns", "llama"), statefulSet("llama-sts", "llama")},
+			wantErr:   wvaerrors.ErrTargetAmbiguous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			builder = builder.WithObjects(tt.workloads...)
+
+			ref, err := ResolveScaleTargetSelector(context.Background(), builder.Build(), va)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("ResolveScaleTargetSelector() error = %v, want %v", err, tt.wantErr)
+			}
+			if ref.Kind != tt.wantKind || ref.Name != tt.wantName {
+				t.Errorf("ResolveScaleTargetSelector() = %s/%s, want %s/%s", ref.Kind, ref.Name, tt.wantKind, tt.wantName)
+			}
+		})
+	}
+}
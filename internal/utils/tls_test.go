@@ -27,6 +27,8 @@ func testConfigFromEnv(t *testing.T, env map[string]string) *config.Config {
 		"PROMETHEUS_CLIENT_CERT_PATH",
 		"PROMETHEUS_CLIENT_KEY_PATH",
 		"PROMETHEUS_SERVER_NAME",
+		"PROMETHEUS_AUTH_TYPE",
+		"PROMETHEUS_SIGV4_REGION",
 	}
 
 	originalValues := make(map[string]string, len(keys))
@@ -14,6 +14,7 @@ import (
 	"github.com/prometheus/common/model"
 
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
 	interfaces "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
@@ -121,10 +122,13 @@ func UpdateStatusWithBackoff[T client.Object](ctx context.Context, c client.Clie
 }
 
 // Adapter to create wva system data types from config maps.
-// Note: WVA operates in unlimited mode, so capacity data is not used.
+// The accelerator multiplicity is taken from the node inventory when one is given (limited mode);
+// otherwise it defaults to 1.
+// Note: capacity data is not set here.
 func CreateSystemData(
 	acceleratorCm map[string]map[string]string,
-	serviceClassCm map[string]string) *infernoConfig.SystemData {
+	serviceClassCm map[string]string,
+	inventory map[string]map[string]discovery.AcceleratorModelInfo) *infernoConfig.SystemData {
 
 	systemData := &infernoConfig.SystemData{
 		Spec: infernoConfig.SystemSpec{
@@ -148,7 +152,7 @@ func CreateSystemData(
 		acceleratorData = append(acceleratorData, infernoConfig.AcceleratorSpec{
 			Name:         key,
 			Type:         val["device"],
-			Multiplicity: 1,                         // TODO: multiplicity should be in the configured accelerator spec
+			Multiplicity: AcceleratorMultiplicity(inventory, val["device"]),
 			Power:        infernoConfig.PowerSpec{}, // Not currently used
 			Cost:         float32(cost),
 		})
@@ -198,6 +202,22 @@ func CreateSystemData(
 	return systemData
 }
 
+// AcceleratorMultiplicity returns the largest number of devices of the given model found on a single node
+// of the inventory, which bounds the cards a replica of that accelerator can use.
+// Returns 1 if the device is not in the inventory.
+func AcceleratorMultiplicity(inventory map[string]map[string]discovery.AcceleratorModelInfo, device string) int {
+	multiplicity := 0
+	for _, accelerators := range inventory {
+		if info, ok := accelerators[device]; ok && info.Count > multiplicity {
+			multiplicity = info.Count
+		}
+	}
+	if multiplicity == 0 {
+		return 1
+	}
+	return multiplicity
+}
+
 // add model accelerator pair profile data to inferno system data
 
 // Add server specs to inferno system data
@@ -290,6 +310,16 @@ func AddServerInfoToSystemData(
 		serverSpec.MaxBatchSize = maxBatchSize
 	}
 
+	// accelerator preferences: the server may move to a secondary accelerator type,
+	// using the profile declared for that type
+	if len(va.Spec.AcceleratorPreferences) > 0 {
+		if err = AddVariantProfilesToSystemData(sd, va); err != nil {
+			return err
+		}
+		serverSpec.KeepAccelerator = false
+		serverSpec.AcceleratorPreferences = AcceleratorPreferenceNames(va)
+	}
+
 	sd.Spec.Servers.Spec = append(sd.Spec.Servers.Spec, *serverSpec)
 	return nil
 }
@@ -311,6 +341,9 @@ func CreateOptimizedAlloc(name string,
 		Accelerator: allocationData.Accelerator,
 		NumReplicas: allocationData.NumReplicas,
 	}
+	if allocationData.SLOViolation > 0 {
+		optimizedAlloc.SLOViolation = strconv.FormatFloat(float64(allocationData.SLOViolation), 'f', 2, 32)
+	}
 	return optimizedAlloc, nil
 }
 
@@ -8,6 +8,8 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 	testutils "github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils"
 )
 
@@ -85,3 +87,56 @@ func TestQueryPrometheusWithBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceleratorMultiplicity(t *testing.T) {
+	inventory := map[string]map[string]discovery.AcceleratorModelInfo{
+		"node-1": {"NVIDIA-A100-PCIE-80GB": {Count: 4}},
+		"node-2": {"NVIDIA-A100-PCIE-80GB": {Count: 8}, "NVIDIA-L4": {Count: 2}},
+		"node-3": {"NVIDIA-H100-SXM5-80GB": {Count: 0}},
+	}
+
+	assert.Equal(t, 8, AcceleratorMultiplicity(inventory, "NVIDIA-A100-PCIE-80GB"))
+	assert.Equal(t, 2, AcceleratorMultiplicity(inventory, "NVIDIA-L4"))
+	assert.Equal(t, 1, AcceleratorMultiplicity(inventory, "NVIDIA-H100-SXM5-80GB"), "no allocatable devices")
+	assert.Equal(t, 1, AcceleratorMultiplicity(inventory, "AMD-MI300X-192G"), "not in inventory")
+	assert.Equal(t, 1, AcceleratorMultiplicity(nil, "NVIDIA-A100-PCIE-80GB"), "no inventory")
+}
+
+func TestCreateSystemData_Multiplicity(t *testing.T) {
+	acceleratorCm := map[string]map[string]string{
+		"A100":   {"device": "NVIDIA-A100-PCIE-80GB", "cost": "40.00"},
+		"MI300X": {"device": "AMD-MI300X-192G", "cost": "65.00"},
+	}
+	inventory := map[string]map[string]discovery.AcceleratorModelInfo{
+		"node-1": {"NVIDIA-A100-PCIE-80GB": {Count: 4}},
+	}
+
+	multiplicity := func(sd *infernoConfig.SystemData) map[string]int {
+		out := make(map[string]int)
+		for _, acc := range sd.Spec.Accelerators.Spec {
+			out[acc.Name] = acc.Multiplicity
+		}
+		return out
+	}
+
+	assert.Equal(t, map[string]int{"A100": 4, "MI300X": 1}, multiplicity(CreateSystemData(acceleratorCm, nil, inventory)))
+	assert.Equal(t, map[string]int{"A100": 1, "MI300X": 1}, multiplicity(CreateSystemData(acceleratorCm, nil, nil)))
+}
+
+func TestCreateOptimizedAlloc_SLOViolation(t *testing.T) {
+	solution := &infernoConfig.AllocationSolution{
+		Spec: map[string]infernoConfig.AllocationData{
+			FullName("met", "default"):      {Accelerator: "A100", NumReplicas: 2, AccCount: 1},
+			FullName("violated", "default"): {Accelerator: "A100", NumReplicas: 4, AccCount: 1, SLOViolation: 0.5},
+		},
+	}
+
+	alloc, err := CreateOptimizedAlloc("met", "default", solution)
+	assert.NoError(t, err)
+	assert.Empty(t, alloc.SLOViolation, "SLOs met")
+
+	alloc, err = CreateOptimizedAlloc("violated", "default", solution)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, alloc.NumReplicas)
+	assert.Equal(t, "0.50", alloc.SLOViolation)
+}
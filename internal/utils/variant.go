@@ -19,18 +19,18 @@ package utils
 import (
 	"context"
 
-	appsv1 "k8s.io/api/apps/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 )
 
 // VariantFilter is a function that determines if a VA should be included.
-type VariantFilter func(deploy *appsv1.Deployment) bool
+type VariantFilter func(workload interfaces.Workload) bool
 
 // ActiveVariantAutoscalingByModel retrieves all VariantAutoscaling resources that are ready for optimization
 // and have at least one target replica.
@@ -91,18 +91,24 @@ func GetAcceleratorType(va *wvav1alpha1.VariantAutoscaling) string {
 // and have at least one target replica.
 // Returns a slice of deep-copied VariantAutoscaling objects.
 func ActiveVariantAutoscaling(ctx context.Context, client client.Client) ([]wvav1alpha1.VariantAutoscaling, error) {
-	return filterVariantsByDeployment(ctx, client, isActive, "active")
+	return filterVariantsByScaleTarget(ctx, client, isActive, "active")
+}
+
+// ReadyVariantAutoscaling retrieves all VariantAutoscaling resources that are ready for optimization,
+// whatever the replicas of their scale target.
+func ReadyVariantAutoscaling(ctx context.Context, client client.Client) ([]wvav1alpha1.VariantAutoscaling, error) {
+	return readyVariantAutoscalings(ctx, client)
 }
 
 // InactiveVariantAutoscaling retrieves all VariantAutoscaling resources that are ready for optimization
 // and have no target replicas.
 // Returns a slice of deep-copied VariantAutoscaling objects.
 func InactiveVariantAutoscaling(ctx context.Context, client client.Client) ([]wvav1alpha1.VariantAutoscaling, error) {
-	return filterVariantsByDeployment(ctx, client, isInactive, "inactive")
+	return filterVariantsByScaleTarget(ctx, client, isInactive, "inactive")
 }
 
-// filterVariantsByDeployment is a generic function to filter VAs based on deployment state.
-func filterVariantsByDeployment(ctx context.Context, client client.Client, filter VariantFilter, filterName string) ([]wvav1alpha1.VariantAutoscaling, error) {
+// filterVariantsByScaleTarget is a generic function to filter VAs based on the state of their scale target.
+func filterVariantsByScaleTarget(ctx context.Context, client client.Client, filter VariantFilter, filterName string) ([]wvav1alpha1.VariantAutoscaling, error) {
 	readyVAs, err := readyVariantAutoscalings(ctx, client)
 	if err != nil {
 		return nil, err
@@ -119,31 +125,29 @@ func filterVariantsByDeployment(ctx context.Context, client client.Client, filte
 		default:
 		}
 
-		// Skip VAs without scaleTargetRef (required to know which deployment to look up)
-		// TODO: Remove this check once scaleTargetRef.name is made a required field in the CRD.
-		// This defensive check exists because the CRD currently allows empty scaleTargetRef,
-		// but it should be enforced at the schema level instead.
-		if va.Spec.ScaleTargetRef.Name == "" {
-			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Skipping VA without scaleTargetRef", "namespace", va.Namespace, "name", va.Name)
+		// Skip VAs without a scale target (required to know which deployment to look up):
+		// a scaleTargetSelector not resolved to exactly one Deployment leaves it empty
+		if va.GetScaleTargetName() == "" {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Skipping VA without resolved scale target", "namespace", va.Namespace, "name", va.Name)
 			continue
 		}
 
-		// TODO: Generalize to other scale target kinds in future
-		deployName := va.Spec.ScaleTargetRef.Name
-		var deploy appsv1.Deployment
-		if err := GetDeploymentWithBackoff(ctx, client, deployName, va.Namespace, &deploy); err != nil {
-			ctrl.LoggerFrom(ctx).Error(err, "Failed to get deployment", "namespace", va.Namespace, "deploymentName", deployName, "vaName", va.Name)
+		workload, err := GetScaleTargetWorkload(ctx, client, &va)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to get scale target", "namespace", va.Namespace,
+				"kind", va.GetScaleTargetKind(), "name", va.GetScaleTargetName(), "vaName", va.Name)
 			continue
 		}
 
-		// Skip deleted deployments
-		if !deploy.DeletionTimestamp.IsZero() {
-			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Skipping deleted deployment", "namespace", va.Namespace, "deploymentName", deployName)
+		// Skip deleted scale targets
+		if !workload.GetDeletionTimestamp().IsZero() {
+			ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Skipping deleted scale target", "namespace", va.Namespace,
+				"kind", workload.WorkloadKind(), "name", workload.GetName())
 			continue
 		}
 
 		// Apply the filter function
-		if filter(&deploy) {
+		if filter(workload) {
 			filteredVAs = append(filteredVAs, va)
 		}
 	}
@@ -195,21 +199,21 @@ func readyVariantAutoscalings(ctx context.Context, k8sClient client.Client) ([]w
 }
 
 // isActive explicitly requires that replicas > 0
-func isActive(deploy *appsv1.Deployment) bool {
-	return GetDesiredReplicas(deploy) > 0
+func isActive(workload interfaces.Workload) bool {
+	return GetDesiredReplicas(workload) > 0
 }
 
 // isInactive explicitly requires that replicas == 0
-func isInactive(deploy *appsv1.Deployment) bool {
-	return GetDesiredReplicas(deploy) == 0
+func isInactive(workload interfaces.Workload) bool {
+	return GetDesiredReplicas(workload) == 0
 }
 
 // Helper function makes behavior explicit
-func GetDesiredReplicas(deploy *appsv1.Deployment) int32 {
-	if deploy == nil || deploy.Spec.Replicas == nil {
+func GetDesiredReplicas(workload interfaces.Workload) int32 {
+	if workload == nil || workload.SpecReplicas() == nil {
 		return 1 // Kubernetes default
 	}
-	return *deploy.Spec.Replicas
+	return *workload.SpecReplicas()
 }
 
 // GetNamespacedKey is a helper for building namespaced resource keys.
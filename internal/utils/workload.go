@@ -0,0 +1,333 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+// Kinds of the scale targets in the apps API.
+const (
+	DeploymentKind  = "Deployment"
+	StatefulSetKind = "StatefulSet"
+)
+
+// LeaderWorkerSetGroupVersion is the API of LeaderWorkerSets. LeaderWorkerSets are read as
+// unstructured objects, so that clusters without the LeaderWorkerSet CRD are unaffected.
+var LeaderWorkerSetGroupVersion = schema.GroupVersion{Group: "leaderworkerset.x-k8s.io", Version: "v1"}
+
+// GetWorkloadWithBackoff gets the scale target of the given kind as a Workload. An empty
+// kind is a Deployment. It fails with ErrInvalidConfiguration for an unsupported kind, and
+// with a NotFound error when the scale target does not exist.
+func GetWorkloadWithBackoff(ctx context.Context, c client.Client, kind, name, namespace string) (interfaces.Workload, error) {
+	switch kind {
+	case "", DeploymentKind:
+		var deploy appsv1.Deployment
+		if err := GetDeploymentWithBackoff(ctx, c, name, namespace, &deploy); err != nil {
+			return nil, err
+		}
+		return NewDeploymentWorkload(&deploy), nil
+	case StatefulSetKind:
+		var sts appsv1.StatefulSet
+		if err := GetResourceWithBackoff(ctx, c, client.ObjectKey{Name: name, Namespace: namespace}, &sts, StandardBackoff, StatefulSetKind); err != nil {
+			return nil, err
+		}
+		return NewStatefulSetWorkload(&sts), nil
+	case constants.LeaderWorkerSetKind:
+		lws := &unstructured.Unstructured{}
+		lws.SetGroupVersionKind(LeaderWorkerSetGroupVersion.WithKind(constants.LeaderWorkerSetKind))
+		if err := GetResourceWithBackoff(ctx, c, client.ObjectKey{Name: name, Namespace: namespace}, lws, StandardBackoff, constants.LeaderWorkerSetKind); err != nil {
+			return nil, err
+		}
+		return NewLeaderWorkerSetWorkload(lws)
+	default:
+		return nil, fmt.Errorf("%w: scale target kind %q is not supported", wvaerrors.ErrInvalidConfiguration, kind)
+	}
+}
+
+// GetScaleTargetWorkload gets the scale target of a VariantAutoscaling as a Workload.
+func GetScaleTargetWorkload(ctx context.Context, c client.Client, va *wvav1alpha1.VariantAutoscaling) (interfaces.Workload, error) {
+	return GetWorkloadWithBackoff(ctx, c, va.GetScaleTargetKind(), va.GetScaleTargetName(), va.Namespace)
+}
+
+// DeploymentWorkload is a Deployment as a Workload: each replica is a pod.
+type DeploymentWorkload struct {
+	*appsv1.Deployment
+}
+
+// NewDeploymentWorkload returns the Workload of a Deployment.
+func NewDeploymentWorkload(deploy *appsv1.Deployment) *DeploymentWorkload {
+	return &DeploymentWorkload{Deployment: deploy}
+}
+
+// WorkloadKind returns Deployment.
+func (d *DeploymentWorkload) WorkloadKind() string {
+	return DeploymentKind
+}
+
+// SpecReplicas returns spec.replicas of the Deployment.
+func (d *DeploymentWorkload) SpecReplicas() *int32 {
+	return d.Spec.Replicas
+}
+
+// StatusReplicas returns status.replicas of the Deployment.
+func (d *DeploymentWorkload) StatusReplicas() int32 {
+	return d.Status.Replicas
+}
+
+// ReadyReplicas returns status.readyReplicas of the Deployment.
+func (d *DeploymentWorkload) ReadyReplicas() int32 {
+	return d.Status.ReadyReplicas
+}
+
+// PodTemplate returns the pod template of the Deployment.
+func (d *DeploymentWorkload) PodTemplate() *corev1.PodTemplateSpec {
+	return &d.Spec.Template
+}
+
+// GPUsPerReplica returns the GPU requests of the pod template of the Deployment.
+func (d *DeploymentWorkload) GPUsPerReplica() int {
+	return PodTemplateGPUs(&d.Spec.Template)
+}
+
+// PodSelector returns the selector of the Deployment; a nil selector selects no pods.
+func (d *DeploymentWorkload) PodSelector() (labels.Selector, error) {
+	if d.Spec.Selector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of Deployment %s/%s: %w", d.Namespace, d.Name, err)
+	}
+	return selector, nil
+}
+
+// ReplicaOf returns the name of the pod, each pod being a replica.
+func (d *DeploymentWorkload) ReplicaOf(pod *corev1.Pod) string {
+	return pod.Name
+}
+
+// StatefulSetWorkload is a StatefulSet as a Workload: each replica is a pod.
+type StatefulSetWorkload struct {
+	*appsv1.StatefulSet
+}
+
+// NewStatefulSetWorkload returns the Workload of a StatefulSet.
+func NewStatefulSetWorkload(sts *appsv1.StatefulSet) *StatefulSetWorkload {
+	return &StatefulSetWorkload{StatefulSet: sts}
+}
+
+// WorkloadKind returns StatefulSet.
+func (s *StatefulSetWorkload) WorkloadKind() string {
+	return StatefulSetKind
+}
+
+// SpecReplicas returns spec.replicas of the StatefulSet.
+func (s *StatefulSetWorkload) SpecReplicas() *int32 {
+	return s.Spec.Replicas
+}
+
+// StatusReplicas returns status.replicas of the StatefulSet.
+func (s *StatefulSetWorkload) StatusReplicas() int32 {
+	return s.Status.Replicas
+}
+
+// ReadyReplicas returns status.readyReplicas of the StatefulSet.
+func (s *StatefulSetWorkload) ReadyReplicas() int32 {
+	return s.Status.ReadyReplicas
+}
+
+// PodTemplate returns the pod template of the StatefulSet.
+func (s *StatefulSetWorkload) PodTemplate() *corev1.PodTemplateSpec {
+	return &s.Spec.Template
+}
+
+// GPUsPerReplica returns the GPU requests of the pod template of the StatefulSet.
+func (s *StatefulSetWorkload) GPUsPerReplica() int {
+	return PodTemplateGPUs(&s.Spec.Template)
+}
+
+// PodSelector returns the selector of the StatefulSet; a nil selector selects no pods.
+func (s *StatefulSetWorkload) PodSelector() (labels.Selector, error) {
+	if s.Spec.Selector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of StatefulSet %s/%s: %w", s.Namespace, s.Name, err)
+	}
+	return selector, nil
+}
+
+// ReplicaOf returns the name of the pod, each pod being a replica.
+func (s *StatefulSetWorkload) ReplicaOf(pod *corev1.Pod) string {
+	return pod.Name
+}
+
+// LeaderWorkerSetWorkload is a LeaderWorkerSet as a Workload: each replica is a group of
+// a leader pod and size-1 worker pods.
+type LeaderWorkerSetWorkload struct {
+	*unstructured.Unstructured
+	size           int32
+	leaderTemplate corev1.PodTemplateSpec
+	workerTemplate corev1.PodTemplateSpec
+}
+
+// NewLeaderWorkerSetWorkload returns the Workload of a LeaderWorkerSet read as an
+// unstructured object. The leader template defaults to the worker template, as in the
+// LeaderWorkerSet API.
+func NewLeaderWorkerSetWorkload(lws *unstructured.Unstructured) (*LeaderWorkerSetWorkload, error) {
+	w := &LeaderWorkerSetWorkload{Unstructured: lws, size: 1}
+
+	size, found, err := unstructured.NestedInt64(lws.Object, "spec", "leaderWorkerTemplate", "size")
+	if err != nil {
+		return nil, fmt.Errorf("invalid size of LeaderWorkerSet %s/%s: %w", lws.GetNamespace(), lws.GetName(), err)
+	}
+	if found && size > 0 {
+		w.size = int32(size)
+	}
+
+	if err := nestedPodTemplate(lws, &w.workerTemplate, "workerTemplate"); err != nil {
+		return nil, err
+	}
+	w.leaderTemplate = w.workerTemplate
+	if err := nestedPodTemplate(lws, &w.leaderTemplate, "leaderTemplate"); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// nestedPodTemplate converts the pod template at spec.leaderWorkerTemplate.<field> of a
+// LeaderWorkerSet, leaving template unchanged when the field is not set.
+func nestedPodTemplate(lws *unstructured.Unstructured, template *corev1.PodTemplateSpec, field string) error {
+	obj, found, err := unstructured.NestedMap(lws.Object, "spec", "leaderWorkerTemplate", field)
+	if err == nil && found {
+		*template = corev1.PodTemplateSpec{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, template)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s of LeaderWorkerSet %s/%s: %w", field, lws.GetNamespace(), lws.GetName(), err)
+	}
+	return nil
+}
+
+// WorkloadKind returns LeaderWorkerSet.
+func (l *LeaderWorkerSetWorkload) WorkloadKind() string {
+	return constants.LeaderWorkerSetKind
+}
+
+// SpecReplicas returns spec.replicas of the LeaderWorkerSet, its number of groups.
+func (l *LeaderWorkerSetWorkload) SpecReplicas() *int32 {
+	replicas, found, err := unstructured.NestedInt64(l.Object, "spec", "replicas")
+	if err != nil || !found {
+		return nil
+	}
+	r := int32(replicas)
+	return &r
+}
+
+// StatusReplicas returns status.replicas of the LeaderWorkerSet, its groups created.
+func (l *LeaderWorkerSetWorkload) StatusReplicas() int32 {
+	replicas, _, _ := unstructured.NestedInt64(l.Object, "status", "replicas")
+	return int32(replicas)
+}
+
+// ReadyReplicas returns status.readyReplicas of the LeaderWorkerSet, its groups whose
+// leader and workers are all ready.
+func (l *LeaderWorkerSetWorkload) ReadyReplicas() int32 {
+	replicas, _, _ := unstructured.NestedInt64(l.Object, "status", "readyReplicas")
+	return int32(replicas)
+}
+
+// PodTemplate returns the leader template of the LeaderWorkerSet: the leader runs the
+// model server and exposes the metrics of its group.
+func (l *LeaderWorkerSetWorkload) PodTemplate() *corev1.PodTemplateSpec {
+	return &l.leaderTemplate
+}
+
+// GPUsPerReplica returns the GPU requests of the leader and the workers of a group.
+func (l *LeaderWorkerSetWorkload) GPUsPerReplica() int {
+	return PodTemplateGPUs(&l.leaderTemplate) + int(l.size-1)*PodTemplateGPUs(&l.workerTemplate)
+}
+
+// PodSelector returns the selector of the pods of the LeaderWorkerSet.
+func (l *LeaderWorkerSetWorkload) PodSelector() (labels.Selector, error) {
+	return labels.SelectorFromSet(labels.Set{constants.LeaderWorkerSetNameLabelKey: l.GetName()}), nil
+}
+
+// ReplicaOf returns the group index of the pod.
+func (l *LeaderWorkerSetWorkload) ReplicaOf(pod *corev1.Pod) string {
+	return pod.Labels[constants.LeaderWorkerSetGroupIndexLabelKey]
+}
+
+// PodTemplateGPUs returns the GPU requests of the containers of a pod template for the
+// supported vendors, 0 if none.
+func PodTemplateGPUs(template *corev1.PodTemplateSpec) int {
+	total := 0
+	for _, container := range template.Spec.Containers {
+		for _, vendor := range GPUVendors {
+			if qty, ok := container.Resources.Requests[corev1.ResourceName(vendor+"/gpu")]; ok {
+				total += int(qty.Value())
+			}
+		}
+	}
+	return total
+}
+
+// PodTemplateAccelerator returns the accelerator a pod template is scheduled on, "" if it
+// cannot be told. It is read from the accelerator label of the template or, if not set there,
+// from the <vendor>/gpu.product node label the template selects a single value of, in its
+// node selector or in a term of its required node affinity.
+func PodTemplateAccelerator(template *corev1.PodTemplateSpec) string {
+	if accelerator := template.Labels[AcceleratorNameLabel]; accelerator != "" {
+		return accelerator
+	}
+	for _, vendor := range GPUVendors {
+		if product := template.Spec.NodeSelector[vendor+"/gpu.product"]; product != "" {
+			return product
+		}
+	}
+
+	affinity := template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	// Terms are ORed, so the accelerator is only known when all terms select the same one
+	accelerator := ""
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		product := ""
+		for _, expr := range term.MatchExpressions {
+			for _, vendor := range GPUVendors {
+				if expr.Key == vendor+"/gpu.product" && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+					product = expr.Values[0]
+				}
+			}
+		}
+		if product == "" || (accelerator != "" && product != accelerator) {
+			return ""
+		}
+		accelerator = product
+	}
+	return accelerator
+}
+
+// Ensure the workloads implement Workload
+var (
+	_ interfaces.Workload = (*DeploymentWorkload)(nil)
+	_ interfaces.Workload = (*StatefulSetWorkload)(nil)
+	_ interfaces.Workload = (*LeaderWorkerSetWorkload)(nil)
+)
@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+// leaderWorkerSet returns a LeaderWorkerSet with the given replicas and group size, whose
+// leader requests leaderGPUs and workers 8 GPUs; leaderGPUs < 0 omits the leader template.
+func leaderWorkerSet(name string, replicas, size int64, leaderGPUs int) *unstructured.Unstructured {
+	template := func(gpus int) map[string]any {
+		return map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"app": name}},
+			"spec": map[string]any{
+				"containers": []any{map[string]any{
+					"name":      "vllm",
+					"image":     "vllm/vllm-openai",
+					"args":      []any{"--tensor-parallel-size=8", "--pipeline-parallel-size=2"},
+					"resources": map[string]any{"requests": map[string]any{"nvidia.com/gpu": int64(gpus)}},
+				}},
+			},
+		}
+	}
+	lwt := map[string]any{
+		"size":           size,
+		"workerTemplate": template(8),
+	}
+	if leaderGPUs >= 0 {
+		lwt["leaderTemplate"] = template(leaderGPUs)
+	}
+	lws := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"replicas":             replicas,
+			"leaderWorkerTemplate": lwt,
+		},
+		"status": map[string]any{
+			"replicas":      replicas,
+			"readyReplicas": replicas - 1,
+		},
+	}}
+	lws.SetGroupVersionKind(LeaderWorkerSetGroupVersion.WithKind(constants.LeaderWorkerSetKind))
+	lws.SetName(name)
+	lws.SetNamespace("ns")
+	return lws
+}
+
+func TestLeaderWorkerSetWorkload(t *testing.T) {
+	w, err := NewLeaderWorkerSetWorkload(leaderWorkerSet("vllm", 3, 2, 4))
+	if err != nil {
+		t.Fatalf("NewLeaderWorkerSetWorkload() failed: %v", err)
+	}
+	if got := w.SpecReplicas(); got == nil || *got != 3 {
+		t.Errorf("SpecReplicas() = %v, want 3", got)
+	}
+	if got := w.StatusReplicas(); got != 3 {
+		t.Errorf("StatusReplicas() = %d, want 3", got)
+	}
+	if got := w.ReadyReplicas(); got != 2 {
+		t.Errorf("ReadyReplicas() = %d, want 2", got)
+	}
+	if got := w.GPUsPerReplica(); got != 12 {
+		t.Errorf("GPUsPerReplica() = %d, want 12 (4 leader + 8 worker)", got)
+	}
+	if got := w.PodTemplate().Spec.Containers[0].Args; len(got) != 2 {
+		t.Errorf("PodTemplate() args = %v, want the vLLM args of the leader", got)
+	}
+
+	// The leader template defaults to the worker template
+	w, err = NewLeaderWorkerSetWorkload(leaderWorkerSet("vllm", 1, 4, -1))
+	if err != nil {
+		t.Fatalf("NewLeaderWorkerSetWorkload() failed: %v", err)
+	}
+	if got := w.GPUsPerReplica(); got != 32 {
+		t.Errorf("GPUsPerReplica() = %d, want 32 (4 pods of 8 GPUs)", got)
+	}
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vllm-1-1", Labels: map[string]string{
+		constants.LeaderWorkerSetNameLabelKey:       "vllm",
+		constants.LeaderWorkerSetGroupIndexLabelKey: "1",
+	}}}
+	selector, err := w.PodSelector()
+	if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+		t.Errorf("PodSelector() = %v (err: %v), want to select the pods of the LeaderWorkerSet", selector, err)
+	}
+	if got := w.ReplicaOf(pod); got != "1" {
+		t.Errorf("ReplicaOf() = %q, want the group index 1", got)
+	}
+}
+
+func TestGetWorkloadWithBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "ns"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "sts", Namespace: "ns"}},
+		leaderWorkerSet("lws", 2, 2, 8),
+	).Build()
+
+	w, err := GetWorkloadWithBackoff(context.Background(), c, "", "deploy", "ns")
+	if err != nil || w.WorkloadKind() != DeploymentKind {
+		t.Errorf("GetWorkloadWithBackoff() = %v (err: %v), want the Deployment", w, err)
+	}
+	w, err = GetWorkloadWithBackoff(context.Background(), c, constants.LeaderWorkerSetKind, "lws", "ns")
+	if err != nil || w.WorkloadKind() != constants.LeaderWorkerSetKind || w.GPUsPerReplica() != 16 {
+		t.Errorf("GetWorkloadWithBackoff() = %v (err: %v), want the LeaderWorkerSet", w, err)
+	}
+	w, err = GetWorkloadWithBackoff(context.Background(), c, StatefulSetKind, "sts", "ns")
+	if err != nil || w.WorkloadKind() != StatefulSetKind {
+		t.Errorf("GetWorkloadWithBackoff() = %v (err: %v), want the StatefulSet", w, err)
+	}
+	if _, err := GetWorkloadWithBackoff(context.Background(), c, "DaemonSet", "ds", "ns"); !errors.Is(err, wvaerrors.ErrInvalidConfiguration) {
+		t.Errorf("GetWorkloadWithBackoff() error = %v, want ErrInvalidConfiguration for an unsupported kind", err)
+	}
+}
+
+func TestCountUnschedulableGPUReplicas_LeaderWorkerSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	pod := func(name, group string) *corev1.Pod {
+		p := unschedulablePod(name, "vllm", "0/4 nodes are available: 4 Insufficient nvidia.com/gpu.")
+		p.Labels = map[string]string{constants.LeaderWorkerSetNameLabelKey: "vllm", constants.LeaderWorkerSetGroupIndexLabelKey: group}
+		return p
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod("vllm-1", "1"),
+		pod("vllm-1-1", "1"),
+		pod("vllm-2-1", "2"),
+	).Build()
+
+	w, err := NewLeaderWorkerSetWorkload(leaderWorkerSet("vllm", 3, 2, 8))
+	if err != nil {
+		t.Fatalf("NewLeaderWorkerSetWorkload() failed: %v", err)
+	}
+	count, err := CountUnschedulableGPUReplicas(context.Background(), c, w)
+	if err != nil {
+		t.Fatalf("CountUnschedulableGPUReplicas() failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 unschedulable groups, got %d", count)
+	}
+}
+
+func TestPodTemplateAccelerator(t *testing.T) {
+	affinity := func(terms ...[]string) *corev1.Affinity {
+		var nodeTerms []corev1.NodeSelectorTerm
+		for _, values := range terms {
+			nodeTerms = append(nodeTerms, corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key: "nvidia.com/gpu.product", Operator: corev1.NodeSelectorOpIn, Values: values,
+			}}})
+		}
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: nodeTerms},
+		}}
+	}
+	tests := []struct {
+		name     string
+		template corev1.PodTemplateSpec
+		want     string
+	}{
+		{
+			name: "label takes precedence",
+			template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{AcceleratorNameLabel: "H100"}},
+				Spec:       corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-80GB"}},
+			},
+			want: "H100",
+		},
+		{
+			name:     "node selector",
+			template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeSelector: map[string]string{"amd.com/gpu.product": "MI300X"}}},
+			want:     "MI300X",
+		},
+		{
+			name:     "node affinity",
+			template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Affinity: affinity([]string{"NVIDIA-H100-80GB-HBM3"}, []string{"NVIDIA-H100-80GB-HBM3"})}},
+			want:     "NVIDIA-H100-80GB-HBM3",
+		},
+		{
+			name:     "node affinity allowing several accelerators",
+			template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Affinity: affinity([]string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"})}},
+		},
+		{
+			name:     "node affinity terms selecting different accelerators",
+			template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Affinity: affinity([]string{"NVIDIA-H100-80GB-HBM3"}, []string{"NVIDIA-A100-SXM4-80GB"})}},
+		},
+		{
+			name: "not scheduled on an accelerator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodTemplateAccelerator(&tt.template); got != tt.want {
+				t.Errorf("PodTemplateAccelerator() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// ModelZones returns the zones the replicas of a VariantAutoscaling are restricted to:
+// the zones listed in its model-zones annotation, and the zones the PersistentVolume
+// bound to the claim of its model-volume annotation is accessible from. When both are
+// set, the zones in both are returned. It returns nil when the replicas are unrestricted,
+// including while the claim is not bound yet or its volume is accessible from any zone.
+func ModelZones(ctx context.Context, c client.Client, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) ([]string, error) {
+	zones := splitZones(va.Annotations[constants.ModelZonesAnnotationKey])
+
+	claimName := strings.TrimSpace(va.Annotations[constants.ModelVolumeAnnotationKey])
+	if claimName == "" {
+		return zones, nil
+	}
+	var pvc corev1.PersistentVolumeClaim
+	if err := c.Get(ctx, client.ObjectKey{Namespace: va.Namespace, Name: claimName}, &pvc); err != nil {
+		return zones, fmt.Errorf("failed to get model PersistentVolumeClaim %s/%s: %w", va.Namespace, claimName, err)
+	}
+	if pvc.Spec.VolumeName == "" {
+		return zones, nil
+	}
+	var pv corev1.PersistentVolume
+	if err := c.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); err != nil {
+		return zones, fmt.Errorf("failed to get model PersistentVolume %s: %w", pvc.Spec.VolumeName, err)
+	}
+
+	volumeZones := PersistentVolumeZones(&pv)
+	switch {
+	case len(volumeZones) == 0:
+		return zones, nil
+	case len(zones) == 0:
+		return volumeZones, nil
+	}
+	var both []string
+	for _, zone := range zones {
+		if slices.Contains(volumeZones, zone) {
+			both = append(both, zone)
+		}
+	}
+	if len(both) == 0 {
+		return zones, fmt.Errorf("model PersistentVolume %s is not accessible from zones %v", pv.Name, zones)
+	}
+	return both, nil
+}
+
+// PersistentVolumeZones returns the sorted zones a PersistentVolume is accessible from,
+// from the zone terms of its node affinity or else its legacy zone labels; nil if it is
+// accessible from any zone.
+func PersistentVolumeZones(pv *corev1.PersistentVolume) []string {
+	var zones []string
+	if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expr := range term.MatchExpressions {
+				if (expr.Key == corev1.LabelTopologyZone || expr.Key == corev1.LabelFailureDomainBetaZone) &&
+					expr.Operator == corev1.NodeSelectorOpIn {
+					zones = append(zones, expr.Values...)
+				}
+			}
+		}
+	}
+	if len(zones) == 0 {
+		// Multi-zone volumes list their zones separated by "__"
+		label := pv.Labels[corev1.LabelTopologyZone]
+		if label == "" {
+			label = pv.Labels[corev1.LabelFailureDomainBetaZone]
+		}
+		if label != "" {
+			zones = strings.Split(label, "__")
+		}
+	}
+	slices.Sort(zones)
+	return slices.Compact(zones)
+}
+
+// splitZones parses a comma-separated list of zones.
+func splitZones(value string) []string {
+	var zones []string
+	for _, zone := range strings.Split(value, ",") {
+		if zone = strings.TrimSpace(zone); zone != "" && !slices.Contains(zones, zone) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func zonalVolume(name string, zones ...string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      corev1.LabelTopologyZone,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   zones,
+						}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestModelZones(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "weights", Namespace: "ns"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-weights"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "ns"},
+		},
+		zonalVolume("pv-weights", "zone-b", "zone-a"),
+	).Build()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+		wantErr     bool
+	}{
+		{name: "No annotations", want: nil},
+		{
+			name:        "Listed zones",
+			annotations: map[string]string{constants.ModelZonesAnnotationKey: " zone-a, zone-c,,zone-a"},
+			want:        []string{"zone-a", "zone-c"},
+		},
+		{
+			name:        "Zones of the bound volume",
+			annotations: map[string]string{constants.ModelVolumeAnnotationKey: "weights"},
+			want:        []string{"zone-a", "zone-b"},
+		},
+		{
+			name: "Listed zones of the volume",
+			annotations: map[string]string{
+				constants.ModelVolumeAnnotationKey: "weights",
+				constants.ModelZonesAnnotationKey:  "zone-b,zone-c",
+			},
+			want: []string{"zone-b"},
+		},
+		{
+			name: "Listed zones out of the volume",
+			annotations: map[string]string{
+				constants.ModelVolumeAnnotationKey: "weights",
+				constants.ModelZonesAnnotationKey:  "zone-c",
+			},
+			want:    []string{"zone-c"},
+			wantErr: true,
+		},
+		{
+			name:        "Unbound claim",
+			annotations: map[string]string{constants.ModelVolumeAnnotationKey: "pending"},
+			want:        nil,
+		},
+		{
+			name:        "Missing claim",
+			annotations: map[string]string{constants.ModelVolumeAnnotationKey: "missing"},
+			want:        nil,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Name: "va", Namespace: "ns", Annotations: tt.annotations},
+			}
+			got, err := ModelZones(context.Background(), c, va)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ModelZones() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ModelZones() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersistentVolumeZones(t *testing.T) {
+	labeled := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelTopologyZone: "zone-b__zone-a"}},
+	}
+	if got, want := PersistentVolumeZones(labeled), []string{"zone-a", "zone-b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PersistentVolumeZones() of labeled volume = %v, want %v", got, want)
+	}
+	if got := PersistentVolumeZones(&corev1.PersistentVolume{}); len(got) != 0 {
+		t.Errorf("PersistentVolumeZones() of unrestricted volume = %v, want none", got)
+	}
+}
@@ -0,0 +1,189 @@
+package engines
+
+import "fmt"
+
+// SaturationScalingConfig holds saturation-based scaling thresholds for a model variant.
+// Saturation scaling is enabled by default and uses these thresholds to determine when
+// replicas are saturated and when to scale up.
+type SaturationScalingConfig struct {
+	// ModelID is the model identifier (only used in override entries)
+	ModelID string `yaml:"model_id,omitempty"`
+
+	// Namespace is the namespace for this override (only used in override entries)
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// KvCacheThreshold: Replica is saturated if KV cache utilization >= this threshold (0.0-1.0)
+	KvCacheThreshold float64 `yaml:"kvCacheThreshold"`
+
+	// QueueLengthThreshold: Replica is saturated if queue length >= this threshold
+	QueueLengthThreshold float64 `yaml:"queueLengthThreshold"`
+
+	// KvSpareTrigger: Scale-up if average spare KV cache capacity < this value (0.0-1.0)
+	KvSpareTrigger float64 `yaml:"kvSpareTrigger"`
+
+	// QueueSpareTrigger: Scale-up if average spare queue capacity < this value
+	QueueSpareTrigger float64 `yaml:"queueSpareTrigger"`
+
+	// EnableLimiter: When true, includes the GPU limiter in the scaling pipeline
+	// to constrain scaling decisions based on available cluster resources.
+	// Default is false (limiter disabled).
+	EnableLimiter bool `yaml:"enableLimiter,omitempty"`
+
+	// LimiterPolicy selects how the GPU limiter shares scarce capacity.
+	// "greedy-by-saturation" (default) serves the most saturated variants first.
+	// "max-min-fairness" shares capacity fairly across tenants (see TenantLabel).
+	LimiterPolicy string `yaml:"limiterPolicy,omitempty"`
+
+	// TenantLabel is the VariantAutoscaling label identifying the tenant of a variant
+	// for the max-min-fairness limiter policy. Variants without the label are
+	// accounted to their namespace. Default: "llm-d.ai/tenant"
+	TenantLabel string `yaml:"tenantLabel,omitempty"`
+
+	// AnalyzerName selects which analyzer to use.
+	// "saturation" uses the V2 token-based analyzer.
+	// Empty string (default) uses the V1 percentage-based analyzer.
+	AnalyzerName string `yaml:"analyzerName,omitempty"`
+
+	// ScaleUpThreshold is the utilization threshold above which scale-up is triggered.
+	// Used by V2 analyzer: requiredCapacity = totalDemand / ScaleUpThreshold - anticipatedSupply
+	// Default: 0.85 (85% utilization triggers scale-up)
+	ScaleUpThreshold float64 `yaml:"scaleUpThreshold,omitempty"`
+
+	// ScaleDownBoundary is the utilization boundary below which scale-down is safe.
+	// Used by V2 analyzer: spareCapacity = currentSupply - totalDemand / ScaleDownBoundary
+	// Default: 0.70 (70% utilization allows scale-down)
+	ScaleDownBoundary float64 `yaml:"scaleDownBoundary,omitempty"`
+
+	// ScaleDownErrorRateThreshold vetoes scale-downs of variants whose fraction of requests
+	// finished by an error or abort exceeds this value (0.0-1.0): errors may indicate
+	// hidden overload. Default: 0.05. Set to 1 to disable the veto.
+	ScaleDownErrorRateThreshold float64 `yaml:"scaleDownErrorRateThreshold,omitempty"`
+
+	// LoadSheddingCorrection: When true, the V2 analyzer scales the demand of a model by
+	// the requests the gateway received over the requests it admitted, so that scale-ups
+	// are sized for the demand the gateway shed during overload. Default is false.
+	LoadSheddingCorrection bool `yaml:"loadSheddingCorrection,omitempty"`
+
+	// MaxLoadSheddingCorrection caps the factor the demand is scaled by with
+	// LoadSheddingCorrection, bounding scale-ups when the gateway rejects most requests
+	// for reasons other than overload. Default: 3.
+	MaxLoadSheddingCorrection float64 `yaml:"maxLoadSheddingCorrection,omitempty"`
+}
+
+// GetAnalyzerName returns the name of the analyzer selected by the config.
+func (c *SaturationScalingConfig) GetAnalyzerName() string {
+	return c.AnalyzerName
+}
+
+// GPU limiter policies.
+const (
+	LimiterPolicyGreedyBySaturation = "greedy-by-saturation"
+	LimiterPolicyMaxMinFairness     = "max-min-fairness"
+)
+
+// DefaultTenantLabel is the label identifying the tenant of a VariantAutoscaling.
+const DefaultTenantLabel = "llm-d.ai/tenant"
+
+// GetTenantLabel returns the configured tenant label, or DefaultTenantLabel if unset.
+func (c *SaturationScalingConfig) GetTenantLabel() string {
+	if c.TenantLabel != "" {
+		return c.TenantLabel
+	}
+	return DefaultTenantLabel
+}
+
+// DefaultScaleDownErrorRateThreshold is the error rate above which scale-downs are vetoed.
+const DefaultScaleDownErrorRateThreshold = 0.05
+
+// GetScaleDownErrorRateThreshold returns the configured scale-down error rate threshold,
+// or DefaultScaleDownErrorRateThreshold if unset.
+func (c *SaturationScalingConfig) GetScaleDownErrorRateThreshold() float64 {
+	if c.ScaleDownErrorRateThreshold > 0 {
+		return c.ScaleDownErrorRateThreshold
+	}
+	return DefaultScaleDownErrorRateThreshold
+}
+
+// DefaultMaxLoadSheddingCorrection is the default cap of the load shedding correction factor.
+const DefaultMaxLoadSheddingCorrection = 3.0
+
+// GetMaxLoadSheddingCorrection returns the configured cap of the load shedding correction
+// factor, or DefaultMaxLoadSheddingCorrection if unset.
+func (c *SaturationScalingConfig) GetMaxLoadSheddingCorrection() float64 {
+	if c.MaxLoadSheddingCorrection > 0 {
+		return c.MaxLoadSheddingCorrection
+	}
+	return DefaultMaxLoadSheddingCorrection
+}
+
+// V2 analyzer default thresholds, applied when fields are omitted from YAML config.
+const (
+	DefaultScaleUpThreshold  = 0.85
+	DefaultScaleDownBoundary = 0.70
+)
+
+// ApplyDefaults fills in zero-valued V2 fields with their defaults.
+// Must be called before Validate() to handle omitempty zero-values correctly.
+func (c *SaturationScalingConfig) ApplyDefaults() {
+	if c.AnalyzerName == "saturation" {
+		if c.ScaleUpThreshold == 0 {
+			c.ScaleUpThreshold = DefaultScaleUpThreshold
+		}
+		if c.ScaleDownBoundary == 0 {
+			c.ScaleDownBoundary = DefaultScaleDownBoundary
+		}
+	}
+}
+
+// Validate checks for invalid threshold values.
+// Returns error with descriptive message if validation fails.
+// Call ApplyDefaults() before Validate() to handle zero-valued omitempty fields.
+func (c *SaturationScalingConfig) Validate() error {
+	if c.KvCacheThreshold < 0 || c.KvCacheThreshold > 1 {
+		return fmt.Errorf("kvCacheThreshold must be between 0 and 1, got %.2f", c.KvCacheThreshold)
+	}
+	if c.QueueLengthThreshold < 0 {
+		return fmt.Errorf("queueLengthThreshold must be >= 0, got %.1f", c.QueueLengthThreshold)
+	}
+	if c.KvSpareTrigger < 0 || c.KvSpareTrigger > 1 {
+		return fmt.Errorf("kvSpareTrigger must be between 0 and 1, got %.2f", c.KvSpareTrigger)
+	}
+	if c.QueueSpareTrigger < 0 {
+		return fmt.Errorf("queueSpareTrigger must be >= 0, got %.1f", c.QueueSpareTrigger)
+	}
+	// KV cache threshold should be greater than spare trigger (otherwise contradictory)
+	if c.KvCacheThreshold < c.KvSpareTrigger {
+		return fmt.Errorf("kvCacheThreshold (%.2f) should be >= kvSpareTrigger (%.2f)",
+			c.KvCacheThreshold, c.KvSpareTrigger)
+	}
+
+	if c.ScaleDownErrorRateThreshold < 0 || c.ScaleDownErrorRateThreshold > 1 {
+		return fmt.Errorf("scaleDownErrorRateThreshold must be between 0 and 1, got %.2f", c.ScaleDownErrorRateThreshold)
+	}
+
+	if c.MaxLoadSheddingCorrection != 0 && c.MaxLoadSheddingCorrection < 1 {
+		return fmt.Errorf("maxLoadSheddingCorrection must be >= 1, got %.2f", c.MaxLoadSheddingCorrection)
+	}
+
+	switch c.LimiterPolicy {
+	case "", LimiterPolicyGreedyBySaturation, LimiterPolicyMaxMinFairness:
+	default:
+		return fmt.Errorf("limiterPolicy must be %q or %q, got %q",
+			LimiterPolicyGreedyBySaturation, LimiterPolicyMaxMinFairness, c.LimiterPolicy)
+	}
+
+	// V2 analyzer threshold validation
+	if c.AnalyzerName == "saturation" {
+		if c.ScaleUpThreshold <= 0 || c.ScaleUpThreshold > 1 {
+			return fmt.Errorf("scaleUpThreshold must be in (0, 1], got %.2f", c.ScaleUpThreshold)
+		}
+		if c.ScaleDownBoundary <= 0 || c.ScaleDownBoundary > 1 {
+			return fmt.Errorf("scaleDownBoundary must be in (0, 1], got %.2f", c.ScaleDownBoundary)
+		}
+		if c.ScaleUpThreshold <= c.ScaleDownBoundary {
+			return fmt.Errorf("scaleUpThreshold (%.2f) must be > scaleDownBoundary (%.2f)", c.ScaleUpThreshold, c.ScaleDownBoundary)
+		}
+	}
+
+	return nil
+}
@@ -1,4 +1,4 @@
-package interfaces
+package engines
 
 import (
 	"testing"
@@ -172,6 +172,52 @@ func TestSaturationScalingConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid max-min-fairness limiter policy",
+			config: SaturationScalingConfig{
+				KvCacheThreshold:     0.80,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.10,
+				QueueSpareTrigger:    3,
+				EnableLimiter:        true,
+				LimiterPolicy:        LimiterPolicyMaxMinFairness,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid limiter policy",
+			config: SaturationScalingConfig{
+				KvCacheThreshold:     0.80,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.10,
+				QueueSpareTrigger:    3,
+				LimiterPolicy:        "round-robin",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid ScaleDownErrorRateThreshold too high",
+			config: SaturationScalingConfig{
+				KvCacheThreshold:            0.80,
+				QueueLengthThreshold:        5,
+				KvSpareTrigger:              0.10,
+				QueueSpareTrigger:           3,
+				ScaleDownErrorRateThreshold: 1.5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid MaxLoadSheddingCorrection below 1",
+			config: SaturationScalingConfig{
+				KvCacheThreshold:          0.80,
+				QueueLengthThreshold:      5,
+				KvSpareTrigger:            0.10,
+				QueueSpareTrigger:         3,
+				LoadSheddingCorrection:    true,
+				MaxLoadSheddingCorrection: 0.5,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -241,3 +287,36 @@ func TestSaturationScalingConfigApplyDefaults(t *testing.T) {
 		}
 	})
 }
+
+func TestSaturationScalingConfigGetTenantLabel(t *testing.T) {
+	config := SaturationScalingConfig{}
+	if got := config.GetTenantLabel(); got != DefaultTenantLabel {
+		t.Errorf("GetTenantLabel() = %q, want %q", got, DefaultTenantLabel)
+	}
+	config.TenantLabel = "example.com/team"
+	if got := config.GetTenantLabel(); got != "example.com/team" {
+		t.Errorf("GetTenantLabel() = %q, want %q", got, "example.com/team")
+	}
+}
+
+func TestSaturationScalingConfigGetScaleDownErrorRateThreshold(t *testing.T) {
+	config := SaturationScalingConfig{}
+	if got := config.GetScaleDownErrorRateThreshold(); got != DefaultScaleDownErrorRateThreshold {
+		t.Errorf("GetScaleDownErrorRateThreshold() = %v, want %v", got, DefaultScaleDownErrorRateThreshold)
+	}
+	config.ScaleDownErrorRateThreshold = 0.2
+	if got := config.GetScaleDownErrorRateThreshold(); got != 0.2 {
+		t.Errorf("GetScaleDownErrorRateThreshold() = %v, want %v", got, 0.2)
+	}
+}
+
+func TestSaturationScalingConfigGetMaxLoadSheddingCorrection(t *testing.T) {
+	config := SaturationScalingConfig{}
+	if got := config.GetMaxLoadSheddingCorrection(); got != DefaultMaxLoadSheddingCorrection {
+		t.Errorf("GetMaxLoadSheddingCorrection() = %v, want %v", got, DefaultMaxLoadSheddingCorrection)
+	}
+	config.MaxLoadSheddingCorrection = 5
+	if got := config.GetMaxLoadSheddingCorrection(); got != 5 {
+		t.Errorf("GetMaxLoadSheddingCorrection() = %v, want %v", got, 5.0)
+	}
+}
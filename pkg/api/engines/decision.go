@@ -0,0 +1,25 @@
+package engines
+
+// VariantDecision is the scaling decision an engine recommends for a VariantAutoscaling.
+// The controller completes it with the identity and current state of the variant, and
+// passes it through the stages of its decision pipeline.
+type VariantDecision struct {
+	// TargetReplicas is the number of replicas the engine recommends
+	TargetReplicas int
+	// Reason explains the decision in human-readable form (optional)
+	Reason string
+	// EngineOutputs records the decision of each engine of a composite engine (if any)
+	EngineOutputs []EngineOutput
+}
+
+// EngineOutput is the decision of one engine of a composite engine.
+type EngineOutput struct {
+	// Engine is the name of the engine
+	Engine string
+	// HasDecision indicates the engine made a decision (TargetReplicas is set)
+	HasDecision bool
+	// TargetReplicas is the number of replicas the engine recommended
+	TargetReplicas int
+	// Message is the reason of the decision, or the error of the engine
+	Message string
+}
@@ -0,0 +1,27 @@
+// Package engines is the public Go API for scaling engines developed out of tree.
+//
+// A scaling engine computes the scaling decision of a VariantAutoscaling from the metrics
+// of its replicas. Custom engines implement Engine, register themselves with Register
+// from an init function of a package imported by the controller binary, and are selected
+// per VariantAutoscaling with spec.engine. The package also holds the types engines
+// exchange with the controller: ReplicaMetrics, VariantDecision and
+// SaturationScalingConfig.
+//
+// # Compatibility
+//
+// The API is versioned by Version. Within a version, exported identifiers are neither
+// removed nor renamed and keep their meaning and type; new types, fields, constants and
+// functions may be added, so engines should use keyed struct literals. An incompatible
+// change bumps Version.
+//
+// # Versions
+//
+//   - v1alpha2: VariantDecision holds only the recommendation of an engine; the state of
+//     the decision pipeline it held in v1alpha1, such as GPUsPerReplica, is internal to
+//     the controller. The token length sketches of ReplicaMetrics are of the public
+//     package pkg/sketch.
+//   - v1alpha1: initial version.
+package engines
+
+// Version is the version of the engine API.
+const Version = "v1alpha2"
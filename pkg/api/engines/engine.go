@@ -0,0 +1,71 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+// Names reserved for the engines of the controller.
+const (
+	// SaturationEngineName is the name of the built-in saturation engine, selected by an
+	// empty spec.engine.
+	SaturationEngineName = "saturation"
+	// CompositeEngineName is the name of composite engines, selected by
+	// spec.engineComposition.
+	CompositeEngineName = "composite"
+)
+
+// Engine computes the scaling decision of a single VariantAutoscaling.
+type Engine interface {
+	// Name returns the engine's identifier, matched against spec.engine.
+	Name() string
+
+	// AnalyzeAndRecommend returns the decision for a VariantAutoscaling from the metrics
+	// of its replicas. A nil decision leaves the variant unchanged.
+	AnalyzeAndRecommend(ctx context.Context, va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, metrics []ReplicaMetrics) (*VariantDecision, error)
+}
+
+var (
+	mu         sync.RWMutex
+	registered = make(map[string]Engine)
+)
+
+// Register adds a custom engine. Panics if the name is empty, reserved for the
+// saturation or composite engines, or already registered.
+func Register(engine Engine) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := engine.Name()
+	if name == "" || name == SaturationEngineName || name == CompositeEngineName {
+		panic(fmt.Sprintf("invalid scaling engine name %q", name))
+	}
+	if _, exists := registered[name]; exists {
+		panic(fmt.Sprintf("scaling engine %q already registered", name))
+	}
+	registered[name] = engine
+}
+
+// Get returns the custom engine registered with the given name.
+func Get(name string) (Engine, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	engine, ok := registered[name]
+	return engine, ok
+}
+
+// Names returns the names of the registered custom engines, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+package engines
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+type fakeEngine struct {
+	name string
+}
+
+func (f *fakeEngine) Name() string { return f.name }
+
+func (f *fakeEngine) AnalyzeAndRecommend(context.Context, *llmdVariantAutoscalingV1alpha1.VariantAutoscaling, []ReplicaMetrics) (*VariantDecision, error) {
+	return &VariantDecision{TargetReplicas: 2}, nil
+}
+
+func resetRegistry(t *testing.T) {
+	t.Cleanup(func() {
+		mu.Lock()
+		registered = make(map[string]Engine)
+		mu.Unlock()
+	})
+}
+
+func TestRegister(t *testing.T) {
+	resetRegistry(t)
+
+	Register(&fakeEngine{name: "queueing"})
+	Register(&fakeEngine{name: "forecast"})
+
+	engine, ok := Get("queueing")
+	if !ok || engine.Name() != "queueing" {
+		t.Errorf("Get(queueing) = %v, %v", engine, ok)
+	}
+	if _, ok := Get("unknown"); ok {
+		t.Error("expected no engine named unknown")
+	}
+	if names := Names(); !reflect.DeepEqual(names, []string{"forecast", "queueing"}) {
+		t.Errorf("Names() = %v, want [forecast queueing]", names)
+	}
+}
+
+func TestRegister_InvalidNames(t *testing.T) {
+	resetRegistry(t)
+
+	Register(&fakeEngine{name: "queueing"})
+
+	for _, name := range []string{"queueing", SaturationEngineName, CompositeEngineName, ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Register to panic for name %q", name)
+				}
+			}()
+			Register(&fakeEngine{name: name})
+		}()
+	}
+}
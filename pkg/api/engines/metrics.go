@@ -0,0 +1,137 @@
+package engines
+
+import (
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/sketch"
+)
+
+// ReplicaMetrics holds capacity-related metrics for a single replica
+type ReplicaMetrics struct {
+	PodName         string
+	KvCacheUsage    float64 // KV cache utilization (0.0-1.0)
+	QueueLength     int     // Number of requests waiting
+	VariantName     string  // Name of the variant this replica belongs to
+	Namespace       string
+	ModelID         string  // Model ID for grouping variants
+	AcceleratorName string  // Accelerator type for this variant
+	Cost            float64 // Cost per replica (from CRD spec, default 10)
+	// Metadata contains freshness information (optional)
+	Metadata *ReplicaMetricsMetadata `json:"metadata,omitempty"`
+
+	// --- New fields for Saturation Analyzer V2 ---
+
+	// NumGpuBlocks is the total number of KV cache blocks allocated on GPU.
+	// Sourced from vllm:cache_config_info label "num_gpu_blocks".
+	// Zero value means cache_config_info metric is not available.
+	NumGpuBlocks int64
+
+	// BlockSize is the number of tokens per KV cache block.
+	// Sourced from vllm:cache_config_info label "block_size".
+	// Zero value means cache_config_info metric is not available.
+	BlockSize int64
+
+	// TotalKvCapacityTokens is NumGpuBlocks × BlockSize (total token slots).
+	// Computed by the collector after parsing cache_config_info labels.
+	// Zero value means capacity data is unavailable.
+	TotalKvCapacityTokens int64
+
+	// TokensInUse is the derived current token demand on this replica.
+	// Computed as KvCacheUsage × TotalKvCapacityTokens.
+	// Zero when TotalKvCapacityTokens is unavailable.
+	TokensInUse int64
+
+	// AvgOutputTokens is the average generation tokens per request on this replica.
+	// Derived from rate(generation_tokens_sum) / rate(generation_tokens_count).
+	// Zero when metrics are unavailable.
+	AvgOutputTokens float64
+
+	// AvgInputTokens is the average prompt tokens per request on this replica.
+	// Derived from rate(prompt_tokens_sum) / rate(prompt_tokens_count).
+	// Zero when metrics are unavailable.
+	AvgInputTokens float64
+
+	// InputTokensSketch is the distribution of the prompt tokens per request on this replica.
+	// Built from the vllm:request_prompt_tokens histogram over the last 5 minutes, or kept
+	// from an earlier cycle when no request completed since. Nil when unavailable.
+	InputTokensSketch *sketch.TDigest
+
+	// OutputTokensSketch is the distribution of the generation tokens per request on this
+	// replica, built like InputTokensSketch. Nil when unavailable.
+	OutputTokensSketch *sketch.TDigest
+
+	// PrefixCacheHitRate is the fraction of prefix cache queries that were hits (0.0-1.0).
+	// Derived from rate(vllm:prefix_cache_hits[5m]) / rate(vllm:prefix_cache_queries[5m]).
+	// Used to reduce estimated input token demand for scheduler-queued requests.
+	// Zero when prefix caching is disabled or metrics are unavailable.
+	PrefixCacheHitRate float64
+
+	// GPUUtilization is the utilization of the GPUs of this replica (0.0-1.0).
+	// Derived from DCGM_FI_DEV_GPU_UTIL, attributed to the replica by pod label or,
+	// when DCGM does not label GPUs by pod, averaged over the GPUs of its node.
+	// Zero when DCGM metrics are unavailable.
+	GPUUtilization float64
+
+	// AvgTTFT is the average time to first token of this replica in seconds.
+	// Derived from rate(time_to_first_token_seconds_sum) / rate(time_to_first_token_seconds_count).
+	// Zero when metrics are unavailable.
+	AvgTTFT float64
+
+	// RunningRequests is the average number of requests running (batch size) on this replica.
+	// Zero when metrics are unavailable.
+	RunningRequests float64
+
+	// ErrorRate is the fraction of requests of this replica finished by an error or abort (0.0-1.0).
+	// Derived from rate(vllm:request_success_total) by finished_reason.
+	// Zero when metrics are unavailable.
+	ErrorRate float64
+
+	// GPUFailed indicates a GPU of this replica reports Xid or ECC errors, or its node a
+	// failing GPU condition, so the replica has no capacity. Set only when GPU failure
+	// detection is enabled.
+	GPUFailed bool
+
+	// Custom holds the fields added by ReplicaMetricsEnrichers, keyed by field name.
+	// Nil when no enricher added any.
+	Custom map[string]float64
+}
+
+// Freshness statuses of replica metrics
+const (
+	FreshnessFresh       = "fresh"
+	FreshnessStale       = "stale"
+	FreshnessUnavailable = "unavailable"
+)
+
+// ReplicaMetricsMetadata contains freshness information for replica metrics
+type ReplicaMetricsMetadata struct {
+	// CollectedAt is when the metrics were sampled: the oldest sample timestamp of the
+	// KV cache and queue metrics of the replica, or the collection time without one
+	CollectedAt time.Time
+	// Age is the age of the metrics at collection
+	Age time.Duration
+	// FreshnessStatus indicates freshness: "fresh", "stale", "unavailable"
+	FreshnessStatus string
+}
+
+// IsUnavailable returns whether the metrics of the replica are older than the unavailable
+// threshold, as when the replica stopped reporting. Metrics without metadata are fresh.
+func (m ReplicaMetrics) IsUnavailable() bool {
+	return m.Metadata != nil && m.Metadata.FreshnessStatus == FreshnessUnavailable
+}
+
+// DiscardUnavailableReplicas returns the replica metrics without those of unavailable
+// freshness, so that old samples do not count as the current load of their replica, and
+// the number of replicas discarded. When no replica is fresher, all metrics are kept.
+func DiscardUnavailableReplicas(replicaMetrics []ReplicaMetrics) ([]ReplicaMetrics, int) {
+	available := make([]ReplicaMetrics, 0, len(replicaMetrics))
+	for _, rm := range replicaMetrics {
+		if !rm.IsUnavailable() {
+			available = append(available, rm)
+		}
+	}
+	if len(available) == 0 || len(available) == len(replicaMetrics) {
+		return replicaMetrics, 0
+	}
+	return available, len(replicaMetrics) - len(available)
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API version and kind of a scaling configuration bundle document
+const (
+	ScalingConfigBundleAPIVersion = "llmd.ai/v1alpha1"
+	ScalingConfigBundleKind       = "ScalingConfigBundle"
+)
+
+// Scaling configuration of an installation in a single document,
+// used to promote a tuned configuration between environments
+type ScalingConfigBundle struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	ModelScaling     map[string]ConfigEntry         `json:"modelScaling,omitempty"`     // saturation scaling entries ("default" and per-model overrides)
+	ScaleToZero      map[string]ConfigEntry         `json:"scaleToZero,omitempty"`      // scale-to-zero entries ("default" and per-model overrides)
+	AcceleratorCosts map[string]AcceleratorCostSpec `json:"acceleratorCosts,omitempty"` // unit costs by accelerator name
+	ServiceClasses   []ServiceClassSpec             `json:"serviceClasses,omitempty"`   // service classes and their SLO targets
+	Optimizer        *OptimizerSpec                 `json:"optimizer,omitempty"`        // optimizer settings
+}
+
+// Entry of a keyed configuration section; its fields are validated by the consumer of the section
+type ConfigEntry map[string]any
+
+// Unit cost of an accelerator
+type AcceleratorCostSpec struct {
+	Device string             `json:"device,omitempty"` // name of the device (card) as reported on the node
+	Cost   float64            `json:"cost"`             // cost of one device (cents/hr)
+	Zones  map[string]float64 `json:"zones,omitempty"`  // cost of one device in zones where it differs (cents/hr)
+}
+
+// Create an empty scaling configuration bundle
+func NewScalingConfigBundle() *ScalingConfigBundle {
+	return &ScalingConfigBundle{
+		APIVersion: ScalingConfigBundleAPIVersion,
+		Kind:       ScalingConfigBundleKind,
+	}
+}
+
+// Load and validate a scaling configuration bundle from a YAML file
+func LoadScalingConfigBundle(path string) (*ScalingConfigBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scaling config bundle: %w", err)
+	}
+	return ParseScalingConfigBundle(data)
+}
+
+// Parse and validate a scaling configuration bundle from YAML; unknown fields are rejected
+func ParseScalingConfigBundle(data []byte) (*ScalingConfigBundle, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse scaling config bundle: %w", err)
+	}
+	// decode through JSON to use the json field names of the spec types
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scaling config bundle: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	bundle := &ScalingConfigBundle{}
+	if err := decoder.Decode(bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse scaling config bundle: %w", err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Marshal a scaling configuration bundle to YAML, keeping the field order of the spec types
+func (b *ScalingConfigBundle) Marshal() ([]byte, error) {
+	jsonData, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML in flow style
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return nil, err
+	}
+	setBlockStyle(&node)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate a scaling configuration bundle, returning all errors found
+func (b *ScalingConfigBundle) Validate() error {
+	var errs []error
+	if b.APIVersion != ScalingConfigBundleAPIVersion || b.Kind != ScalingConfigBundleKind {
+		errs = append(errs, fmt.Errorf("apiVersion and kind must be %s and %s, got %q and %q",
+			ScalingConfigBundleAPIVersion, ScalingConfigBundleKind, b.APIVersion, b.Kind))
+	}
+	for key, entry := range b.ModelScaling {
+		if key == "" || entry == nil {
+			errs = append(errs, fmt.Errorf("modelScaling: empty entry %q", key))
+		}
+	}
+	for key, entry := range b.ScaleToZero {
+		if key == "" || entry == nil {
+			errs = append(errs, fmt.Errorf("scaleToZero: empty entry %q", key))
+		}
+	}
+	for name, cost := range b.AcceleratorCosts {
+		if name == "" || cost.Cost < 0 {
+			errs = append(errs, fmt.Errorf("acceleratorCosts: invalid cost %v of accelerator %q", cost.Cost, name))
+		}
+		for zone, zoneCost := range cost.Zones {
+			if zone == "" || zoneCost < 0 {
+				errs = append(errs, fmt.Errorf("acceleratorCosts: invalid cost %v of accelerator %q in zone %q", zoneCost, name, zone))
+			}
+		}
+	}
+	names := make(map[string]bool, len(b.ServiceClasses))
+	for _, sc := range b.ServiceClasses {
+		if err := validateServiceClass(&sc); err != nil {
+			errs = append(errs, fmt.Errorf("serviceClasses: %w", err))
+		}
+		if names[sc.Name] {
+			errs = append(errs, fmt.Errorf("serviceClasses: duplicate service class %q", sc.Name))
+		}
+		names[sc.Name] = true
+	}
+	if b.Optimizer != nil {
+		if err := validateOptimizer(b.Optimizer); err != nil {
+			errs = append(errs, fmt.Errorf("optimizer: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateServiceClass(sc *ServiceClassSpec) error {
+	if sc.Name == "" {
+		return fmt.Errorf("service class without name")
+	}
+	if sc.Priority < DefaultHighPriority || sc.Priority > DefaultLowPriority {
+		return fmt.Errorf("priority %d of service class %q not in [%d,%d]",
+			sc.Priority, sc.Name, DefaultHighPriority, DefaultLowPriority)
+	}
+	if sc.ViolationPenalty < 0 {
+		return fmt.Errorf("negative violation penalty of service class %q", sc.Name)
+	}
+	for _, target := range sc.ModelTargets {
+		if target.Model == "" {
+			return fmt.Errorf("model target without model in service class %q", sc.Name)
+		}
+		if target.SLO_ITL < 0 || target.SLO_TTFT < 0 || target.SLO_TPS < 0 {
+			return fmt.Errorf("negative SLO of model %q in service class %q", target.Model, sc.Name)
+		}
+	}
+	return nil
+}
+
+func validateOptimizer(spec *OptimizerSpec) error {
+	if _, err := ParseSaturatedAllocationPolicy(spec.SaturationPolicy); err != nil {
+		return err
+	}
+	if spec.MaxChangesPerCycle < 0 {
+		return fmt.Errorf("negative maxChangesPerCycle %d", spec.MaxChangesPerCycle)
+	}
+	if arbitrage := spec.CostArbitrage; arbitrage != nil {
+		if arbitrage.ReplicaFraction < 0 || arbitrage.ReplicaFraction > 1 {
+			return fmt.Errorf("cost arbitrage replica fraction %v not in [0,1]", arbitrage.ReplicaFraction)
+		}
+		if arbitrage.DailyCostTarget < 0 {
+			return fmt.Errorf("negative cost arbitrage daily cost target %v", arbitrage.DailyCostTarget)
+		}
+		for _, w := range arbitrage.OffPeakWindows {
+			for _, s := range []string{w.Start, w.End} {
+				if _, err := time.Parse("15:04", s); err != nil {
+					return fmt.Errorf("invalid off-peak time of day %q, expected HH:MM", s)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// set the block style on all nodes of a YAML document, quoting only the strings that need it
+func setBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		setBlockStyle(child)
+	}
+}
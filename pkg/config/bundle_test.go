@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testBundle = `apiVersion: llmd.ai/v1alpha1
+kind: ScalingConfigBundle
+modelScaling:
+  default:
+    kvCacheThreshold: 0.8
+acceleratorCosts:
+  H100:
+    device: NVIDIA-H100-80GB-HBM3
+    cost: 40
+serviceClasses:
+  - name: Premium
+    priority: 1
+    modelTargets:
+      - model: meta/llama-3.1-8b
+        slo-itl: 24
+        slo-ttft: 500
+optimizer:
+  unlimited: true
+  saturationPolicy: PriorityExhaustive
+`
+
+func TestLoadScalingConfigBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.yaml")
+	if err := os.WriteFile(path, []byte(testBundle), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := LoadScalingConfigBundle(path)
+	if err != nil {
+		t.Fatalf("LoadScalingConfigBundle() error = %v", err)
+	}
+	if got := bundle.ModelScaling["default"]["kvCacheThreshold"]; got != 0.8 {
+		t.Errorf("modelScaling default kvCacheThreshold = %v, want 0.8", got)
+	}
+	if got := bundle.AcceleratorCosts["H100"]; got.Cost != 40 || got.Device != "NVIDIA-H100-80GB-HBM3" {
+		t.Errorf("acceleratorCosts H100 = %+v", got)
+	}
+	if len(bundle.ServiceClasses) != 1 || bundle.ServiceClasses[0].ModelTargets[0].SLO_ITL != 24 {
+		t.Errorf("serviceClasses = %+v", bundle.ServiceClasses)
+	}
+	if bundle.Optimizer == nil || !bundle.Optimizer.Unlimited {
+		t.Errorf("optimizer = %+v", bundle.Optimizer)
+	}
+}
+
+func TestScalingConfigBundleRoundTrip(t *testing.T) {
+	bundle, err := ParseScalingConfigBundle([]byte(testBundle))
+	if err != nil {
+		t.Fatalf("ParseScalingConfigBundle() error = %v", err)
+	}
+	data, err := bundle.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	parsed, err := ParseScalingConfigBundle(data)
+	if err != nil {
+		t.Fatalf("ParseScalingConfigBundle() of marshaled bundle error = %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(bundle, parsed) {
+		t.Errorf("round trip = %+v, want %+v", parsed, bundle)
+	}
+}
+
+func TestParseScalingConfigBundleInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		wantErr string
+	}{
+		{"wrong kind", [2]string{"kind: ScalingConfigBundle", "kind: Other"}, "apiVersion and kind"},
+		{"unknown field", [2]string{"optimizer:", "optimiser:"}, "unknown field"},
+		{"negative cost", [2]string{"cost: 40", "cost: -1"}, "acceleratorCosts"},
+		{"priority out of range", [2]string{"priority: 1", "priority: 0"}, "priority 0"},
+		{"unknown policy", [2]string{"PriorityExhaustive", "Everything"}, "unknown saturation policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScalingConfigBundle([]byte(strings.Replace(testBundle, tt.replace[0], tt.replace[1], 1)))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseScalingConfigBundle() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package config
 
+import "fmt"
+
 // options for allocation under saturated condition
 type SaturatedAllocationPolicy int
 
@@ -8,6 +10,8 @@ const (
 	PriorityExhaustive                                  // 1 : allocating exhaustively to servers in priority ordering
 	PriorityRoundRobin                                  // 2 : allocating in round-robin fashion within priority groups
 	RoundRobin                                          // 3 : allocating in round-robin fashion across all servers
+	ProportionalShare                                   // 4 : allocating in proportion to requested replicas across all servers
+	WeightedPriority                                    // 5 : allocating in round-robin fashion across all servers, weighted by priority
 )
 
 func (p SaturatedAllocationPolicy) String() string {
@@ -20,22 +24,41 @@ func (p SaturatedAllocationPolicy) String() string {
 		return "PriorityRoundRobin"
 	case RoundRobin:
 		return "RoundRobin"
+	case ProportionalShare:
+		return "ProportionalShare"
+	case WeightedPriority:
+		return "WeightedPriority"
 	default:
 		return "Unknown"
 	}
 }
 
 func SaturatedAllocationPolicyEnum(s string) SaturatedAllocationPolicy {
+	policy, err := ParseSaturatedAllocationPolicy(s)
+	if err != nil {
+		return DefaultSaturatedAllocationPolicy
+	}
+	return policy
+}
+
+// Parse a saturated allocation policy name, the empty name selecting the default policy
+func ParseSaturatedAllocationPolicy(s string) (SaturatedAllocationPolicy, error) {
 	switch s {
+	case "":
+		return DefaultSaturatedAllocationPolicy, nil
 	case "None":
-		return None
+		return None, nil
 	case "PriorityExhaustive":
-		return PriorityExhaustive
+		return PriorityExhaustive, nil
 	case "PriorityRoundRobin":
-		return PriorityRoundRobin
+		return PriorityRoundRobin, nil
 	case "RoundRobin":
-		return RoundRobin
+		return RoundRobin, nil
+	case "ProportionalShare":
+		return ProportionalShare, nil
+	case "WeightedPriority":
+		return WeightedPriority, nil
 	default:
-		return DefaultSaturatedAllocationPolicy
+		return DefaultSaturatedAllocationPolicy, fmt.Errorf("unknown saturation policy %q", s)
 	}
 }
@@ -30,6 +30,16 @@ func TestSaturatedAllocationPolicy_String(t *testing.T) {
 			policy: RoundRobin,
 			want:   "RoundRobin",
 		},
+		{
+			name:   "ProportionalShare policy",
+			policy: ProportionalShare,
+			want:   "ProportionalShare",
+		},
+		{
+			name:   "WeightedPriority policy",
+			policy: WeightedPriority,
+			want:   "WeightedPriority",
+		},
 		{
 			name:   "Unknown policy",
 			policy: SaturatedAllocationPolicy(999),
@@ -72,6 +82,16 @@ func TestSaturatedAllocationPolicyEnum(t *testing.T) {
 			input: "RoundRobin",
 			want:  RoundRobin,
 		},
+		{
+			name:  "ProportionalShare string",
+			input: "ProportionalShare",
+			want:  ProportionalShare,
+		},
+		{
+			name:  "WeightedPriority string",
+			input: "WeightedPriority",
+			want:  WeightedPriority,
+		},
 		{
 			name:  "Unknown policy string returns default",
 			input: "InvalidPolicy",
@@ -104,6 +124,8 @@ func TestSaturatedAllocationPolicy_RoundTrip(t *testing.T) {
 		PriorityExhaustive,
 		PriorityRoundRobin,
 		RoundRobin,
+		ProportionalShare,
+		WeightedPriority,
 	}
 
 	for _, policy := range policies {
@@ -116,3 +138,31 @@ func TestSaturatedAllocationPolicy_RoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSaturatedAllocationPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SaturatedAllocationPolicy
+		wantErr bool
+	}{
+		{input: "", want: DefaultSaturatedAllocationPolicy},
+		{input: "None", want: None},
+		{input: "PriorityRoundRobin", want: PriorityRoundRobin},
+		{input: "ProportionalShare", want: ProportionalShare},
+		{input: "WeightedPriority", want: WeightedPriority},
+		{input: "Random", wantErr: true},
+		{input: "roundrobin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSaturatedAllocationPolicy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSaturatedAllocationPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseSaturatedAllocationPolicy(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -18,7 +18,10 @@ var SLOMargin = -float32(math.Log(1 - SLOPercentile))
 var MaxQueueToBatchRatio = 10
 
 // accelerator transition penalty factor
-var AccelPenaltyFactor = float32(0.1)
+var AccelPenaltyFactor = 0.1
+
+// penalty cost (cents/hr) per unit of relative SLO violation in soft SLO mode
+var DefaultSLOViolationPenalty = float64(1000)
 
 // default name of a service class
 const DefaultServiceClassName string = "Free"
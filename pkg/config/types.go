@@ -87,6 +87,10 @@ type ServiceClassSpec struct {
 	Name         string        `json:"name"`         // service class name
 	Priority     int           `json:"priority"`     // [1,100] priority (lower value is higher priority)
 	ModelTargets []ModelTarget `json:"modelTargets"` // target SLOs for models
+
+	// penalty cost (cents/hr) per unit of relative SLO violation, used in soft SLO mode
+	// (0 = DefaultSLOViolationPenalty)
+	ViolationPenalty float32 `json:"violationPenalty,omitempty"`
 }
 
 // Specification of SLO targets for a model
@@ -112,17 +116,27 @@ type ServerSpec struct {
 	MaxBatchSize    int            `json:"maxBatchSize"`    // overriding value for the maximum batch size
 	CurrentAlloc    AllocationData `json:"currentAlloc"`    // current allocation
 	DesiredAlloc    AllocationData `json:"desiredAlloc"`    // desired allocation
+
+	// ordered list of acceptable accelerators, most preferred first (empty = any accelerator)
+	AcceleratorPreferences []string `json:"acceleratorPreferences,omitempty"`
 }
 
 // Data about a server allocation
 type AllocationData struct {
-	Accelerator string         `json:"accelerator"` // accelerator name
-	NumReplicas int            `json:"numReplicas"` // number of replicas
-	MaxBatch    int            `json:"maxBatch"`    // max batch size
-	Cost        float32        `json:"cost"`        // cost of allocation
-	ITLAverage  float32        `json:"itlAverage"`  // average ITL
-	TTFTAverage float32        `json:"ttftAverage"` // average TTFT
-	Load        ServerLoadSpec `json:"load"`        // server load statistics
+	Accelerator string         `json:"accelerator"`        // accelerator name
+	AccCount    int            `json:"accCount,omitempty"` // number of accelerator units per replica (0 = model default)
+	NumReplicas int            `json:"numReplicas"`        // number of replicas
+	MaxBatch    int            `json:"maxBatch"`           // max batch size
+	Cost        float32        `json:"cost"`               // cost of allocation
+	ITLAverage  float32        `json:"itlAverage"`         // average ITL
+	TTFTAverage float32        `json:"ttftAverage"`        // average TTFT
+	Load        ServerLoadSpec `json:"load"`               // server load statistics
+
+	// expected relative SLO violation (0 = SLOs met), e.g. 0.5 for latency 50% above target
+	SLOViolation float32 `json:"sloViolation,omitempty"`
+
+	// expected utilization: average concurrently running requests / max batch size
+	Utilization float32 `json:"utilization,omitempty"`
 }
 
 // Specifications of server load statistics
@@ -146,4 +160,32 @@ type OptimizerSpec struct {
 	Unlimited         bool   `json:"unlimited"`         // unlimited number of accelerator types (for capacity planning and/or cloud)
 	DelayedBestEffort bool   `json:"delayedBestEffort"` // delay best effort allocation after attempting allocation to all priority groups
 	SaturationPolicy  string `json:"saturationPolicy"`  // allocation policy under saturated condition
+
+	// SLO violations incur penalty costs rather than making allocations infeasible
+	SoftSLO bool `json:"softSLO,omitempty"`
+
+	// maximum number of servers whose allocation may change relative to the previous solution
+	// in a single run, in limited mode (0 = unbounded)
+	MaxChangesPerCycle int `json:"maxChangesPerCycle,omitempty"`
+
+	// time-sliced optimization shifting low-priority servers to cheaper allocations off-peak (nil = disabled)
+	CostArbitrage *CostArbitrageSpec `json:"costArbitrage,omitempty"`
+
+	// re-evaluate multi-replica changes with the queueing model before emitting them,
+	// rejecting those predicted to violate SLOs (guards against stale allocations)
+	SimulateChanges bool `json:"simulateChanges,omitempty"`
+}
+
+// Specifications for time-sliced cost arbitrage
+type CostArbitrageSpec struct {
+	OffPeakWindows  []TimeWindowSpec `json:"offPeakWindows"`            // off-peak time windows of the day (UTC)
+	MinPriority     int              `json:"minPriority"`               // servers with priority value >= minPriority may be shifted
+	ReplicaFraction float32          `json:"replicaFraction,omitempty"` // fraction (0,1] of replicas kept when shifted (0 = all)
+	DailyCostTarget float32          `json:"dailyCostTarget,omitempty"` // target cost (cents) per day (0 = shift whenever off-peak)
+}
+
+// Time window within a day, wrapping past midnight if end is before start
+type TimeWindowSpec struct {
+	Start string `json:"start"` // start time (HH:MM)
+	End   string `json:"end"`   // end time (HH:MM)
 }
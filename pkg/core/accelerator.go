@@ -52,8 +52,8 @@ func (g *Accelerator) Type() string {
 	return g.spec.Type
 }
 
-func (g *Accelerator) Cost() float32 {
-	return g.spec.Cost
+func (g *Accelerator) Cost() float64 {
+	return CostFromSpec(g.spec.Cost)
 }
 
 func (g *Accelerator) Multiplicity() int {
@@ -93,7 +93,7 @@ func TestAccelerator_Fields(t *testing.T) {
 	if acc.Type() != spec.Type {
 		t.Errorf("Accelerator.Type() = %v, want %v", acc.Type(), spec.Type)
 	}
-	if acc.Cost() != spec.Cost {
+	if acc.Cost() != CostFromSpec(spec.Cost) {
 		t.Errorf("Accelerator.Cost() = %v, want %v", acc.Cost(), spec.Cost)
 	}
 	if acc.Multiplicity() != spec.Multiplicity {
@@ -12,19 +12,76 @@ import (
 // Allocation details of an accelerator to a server
 type Allocation struct {
 	accelerator string  // name of accelerator
+	accCount    int     // number of accelerator units per replica (0 = model default)
 	numReplicas int     // number of server replicas
 	batchSize   int     // max batch size
-	cost        float32 // cost of this allocation
-	value       float32 // value of this allocation
+	cost        float64 // cost of this allocation
+	value       float64 // value of this allocation
 	itl         float32 // expected average token decode time (msec)
 	ttft        float32 // expected average request queueing and prefill times (msec)
 	rho         float32 // average concurrently running requests / max batch size
+	violation   float32 // expected relative SLO violation (0 = SLOs met)
+	penalty     float64 // cost of SLO violation (soft SLO mode only)
 
 	maxArrvRatePerReplica float32 // maximum arrival rate per replica (req/msec)
 }
 
-// Create an allocation of an accelerator to a server; nil if not feasible
-func CreateAllocation(serverName string, gName string) *Allocation {
+// Create an allocation of an accelerator to a server of this system; nil if not feasible
+//   - in soft SLO mode, an accelerator unable to meet the SLOs yields a best-effort
+//     allocation, penalized by its expected SLO violation
+//   - if the model has performance data for several numbers of accelerator units per
+//     replica, the allocation of least value among them is returned
+func (s *System) CreateAllocation(serverName string, gName string) *Allocation {
+	return s.createAllocation(serverName, gName, 0, 0)
+}
+
+// Create an allocation of an accelerator to a server of this system with a given number
+// of replicas, which may violate the SLOs (penalized in soft SLO mode); nil if not feasible
+func (s *System) CreateAllocationWithReplicas(serverName string, gName string, numReplicas int) *Allocation {
+	if numReplicas <= 0 {
+		return nil
+	}
+	return s.createAllocation(serverName, gName, 0, numReplicas)
+}
+
+// Create an allocation of an accelerator to a server of this system with a given number
+// of accelerator units per replica (0 = any) and of replicas; nil if not feasible
+func (s *System) CreateShardedAllocationWithReplicas(serverName string, gName string, accCount int, numReplicas int) *Allocation {
+	if numReplicas <= 0 {
+		return nil
+	}
+	return s.createAllocation(serverName, gName, accCount, numReplicas)
+}
+
+// Create an allocation with the performance data of the model for accCount accelerator
+// units per replica, or the one of least value among all its performance data if accCount
+// is zero; the number of replicas is sized to the targets if fixedReplicas is zero
+func (s *System) createAllocation(serverName string, gName string, accCount int, fixedReplicas int) *Allocation {
+	server := s.Server(serverName)
+	if server == nil {
+		return nil
+	}
+	model := s.Model(server.ModelName())
+	if model == nil {
+		return nil
+	}
+	var best *Allocation
+	for _, perf := range model.AllPerfData(gName) {
+		if accCount > 0 && model.numInstancesWith(gName, perf) != accCount {
+			continue
+		}
+		if alloc := s.createAllocationWithPerf(serverName, gName, perf, fixedReplicas); alloc != nil {
+			if best == nil || alloc.value < best.value {
+				best = alloc
+			}
+		}
+	}
+	return best
+}
+
+// Create an allocation with given performance data of the model of the server
+func (s *System) createAllocationWithPerf(serverName string, gName string,
+	perf *config.ModelAcceleratorPerfData, fixedReplicas int) *Allocation {
 	var (
 		acc *Accelerator
 
@@ -32,19 +89,18 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 		load   *config.ServerLoadSpec
 
 		model *Model
-		perf  *config.ModelAcceleratorPerfData
 
 		svc    *ServiceClass
 		target *Target
 	)
 
 	// get accelerator info
-	if acc = GetAccelerator(gName); acc == nil {
+	if acc = s.Accelerator(gName); acc == nil {
 		return nil
 	}
 
 	// get server info
-	if server = GetServer(serverName); server == nil {
+	if server = s.Server(serverName); server == nil {
 		return nil
 	}
 	if load = server.Load(); load == nil || load.ArrivalRate < 0 ||
@@ -54,15 +110,12 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 
 	// get model info
 	modelName := server.ModelName()
-	if model = GetModel(modelName); model == nil {
-		return nil
-	}
-	if perf = model.PerfData(gName); perf == nil {
+	if model = s.Model(modelName); model == nil || perf == nil {
 		return nil
 	}
 
 	// get service class info
-	if svc = GetServiceClass(server.ServiceClassName()); svc == nil {
+	if svc = s.ServiceClass(server.ServiceClassName()); svc == nil {
 		return nil
 	}
 	if target = svc.ModelTarget(modelName); target == nil {
@@ -74,35 +127,8 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 		return zeroLoadAllocation(server, model, acc, perf)
 	}
 
-	// calculate max batch size (N) based on average request length (K)
 	K := load.AvgOutTokens
-
-	// use maxBatchSize from configured value or scaled performance data
-	var N int
-	if server.maxBatchSize > 0 {
-		N = server.maxBatchSize
-	} else {
-		N = max(perf.MaxBatchSize*perf.AtTokens/K, 1)
-	}
-	maxQueue := N * config.MaxQueueToBatchRatio
-
-	// create queue analyzer
-	qConfig := &analyzer.Configuration{
-		MaxBatchSize: N,
-		MaxQueueSize: maxQueue,
-		ServiceParms: &analyzer.ServiceParms{
-			Alpha: perf.ServiceParms.Alpha,
-			Beta:  perf.ServiceParms.Beta,
-			Gamma: perf.ServiceParms.Gamma,
-		},
-	}
-
-	requestData := &analyzer.RequestSize{
-		AvgInputTokens:  float32(load.AvgInTokens),
-		AvgOutputTokens: float32(K),
-	}
-
-	queueAnalyzer, err := analyzer.NewQueueAnalyzer(qConfig, requestData)
+	queueAnalyzer, N, err := newQueueAnalyzer(server, perf, load)
 	if err != nil {
 		fmt.Println(err)
 		return nil
@@ -114,13 +140,20 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 		TargetTPS:  target.TPS,
 	}
 
+	// highest stable rate per replica
+	maxStableRate := queueAnalyzer.RateRange.Max * (1 - analyzer.StabilitySafetyFraction)
+
 	// determine max rates to satisfy targets
-	_, metrics, _, err := queueAnalyzer.Size(targetPerf)
-	if err != nil {
+	var rateStar float32
+	if _, metrics, _, err := queueAnalyzer.Size(targetPerf); err == nil {
+		rateStar = metrics.Throughput
+	} else if s.softSLO {
+		// targets not achievable: size replicas to run at the highest stable rate
+		rateStar = maxStableRate
+	} else {
 		// fmt.Println(err)
 		return nil
 	}
-	rateStar := metrics.Throughput
 
 	// calculate number of replicas
 	var totalRate float32
@@ -129,16 +162,25 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 	} else {
 		totalRate = target.TPS / float32(K)
 	}
-	numReplicas := int(math.Ceil(float64(totalRate) / float64(rateStar)))
-	numReplicas = max(numReplicas, server.minNumReplicas)
+	numReplicas := fixedReplicas
+	if numReplicas == 0 {
+		numReplicas = int(math.Ceil(float64(totalRate) / float64(rateStar)))
+		numReplicas = max(numReplicas, server.minNumReplicas)
+	}
 
 	// calculate cost
-	totalNumInstances := model.NumInstances(gName) * numReplicas
-	cost := acc.Cost() * float32(totalNumInstances)
+	totalNumInstances := model.numInstancesWith(gName, perf) * numReplicas
+	cost := acc.Cost() * float64(totalNumInstances)
 
 	// analyze queue of one replica
 	rate := totalRate / float32(numReplicas)
-	metrics, err = queueAnalyzer.Analyze(rate)
+	var overload float32
+	if rate > maxStableRate {
+		// replicas cannot run stably: analyze at the highest stable rate, excess traffic adds to violation
+		overload = rate/maxStableRate - 1
+		rate = maxStableRate
+	}
+	metrics, err := queueAnalyzer.Analyze(rate)
 	if err != nil {
 		fmt.Println(err)
 		return nil
@@ -148,13 +190,64 @@ func CreateAllocation(serverName string, gName string) *Allocation {
 	ttft := metrics.AvgWaitTime + metrics.AvgPrefillTime
 	// fmt.Printf("numReplicas=%d; batchSize=%d; rate=%v, itl=%v; ttft=%v; \n", numReplicas, N, rate, itl, ttft)
 
-	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: N,
-		cost: cost, itl: itl, ttft: ttft, rho: rho, maxArrvRatePerReplica: rateStar / 1000}
-	alloc.SetValue(alloc.cost)
+	violation := excess(itl, target.ITL) + excess(ttft, target.TTFT) + overload
+
+	alloc := &Allocation{accelerator: gName, accCount: model.numInstancesWith(gName, perf), numReplicas: numReplicas, batchSize: N,
+		cost: cost, itl: itl, ttft: ttft, rho: rho, violation: violation, maxArrvRatePerReplica: rateStar / 1000}
+	if s.softSLO {
+		alloc.penalty = svc.ViolationPenalty() * float64(violation)
+	}
+	alloc.SetValue(alloc.cost + alloc.penalty)
 	return alloc
 }
 
-func (a *Allocation) Scale(serverName string) (alloc *Allocation, inc int) {
+// Create the queue analyzer of a replica of a server on an accelerator, returning it with
+// the max batch size of the replica
+func newQueueAnalyzer(server *Server, perf *config.ModelAcceleratorPerfData, load *config.ServerLoadSpec) (*analyzer.QueueAnalyzer, int, error) {
+	// calculate max batch size (N) based on average request length (K)
+	K := load.AvgOutTokens
+
+	// use maxBatchSize from configured value or scaled performance data
+	var N int
+	if server.maxBatchSize > 0 {
+		N = server.maxBatchSize
+	} else {
+		N = max(perf.MaxBatchSize*perf.AtTokens/K, 1)
+	}
+	maxQueue := N * config.MaxQueueToBatchRatio
+
+	// create queue analyzer
+	qConfig := &analyzer.Configuration{
+		MaxBatchSize: N,
+		MaxQueueSize: maxQueue,
+		ServiceParms: &analyzer.ServiceParms{
+			Alpha: perf.ServiceParms.Alpha,
+			Beta:  perf.ServiceParms.Beta,
+			Gamma: perf.ServiceParms.Gamma,
+		},
+	}
+
+	requestData := &analyzer.RequestSize{
+		AvgInputTokens:  float32(load.AvgInTokens),
+		AvgOutputTokens: float32(K),
+	}
+
+	queueAnalyzer, err := analyzer.NewQueueAnalyzer(qConfig, requestData)
+	if err != nil {
+		return nil, 0, err
+	}
+	return queueAnalyzer, N, nil
+}
+
+// Relative excess of a value over its target (0 if within target or no target)
+func excess(value float32, target float32) float32 {
+	if target <= 0 || value <= target {
+		return 0
+	}
+	return value/target - 1
+}
+
+func (a *Allocation) Scale(system *System, serverName string) (alloc *Allocation, inc int) {
 	var (
 		acc    *Accelerator
 		server *Server
@@ -162,7 +255,7 @@ func (a *Allocation) Scale(serverName string) (alloc *Allocation, inc int) {
 	)
 
 	// get server info
-	if server = GetServer(serverName); server == nil {
+	if server = system.Server(serverName); server == nil {
 		return nil, 0
 	}
 	if load = server.Load(); load == nil {
@@ -171,21 +264,21 @@ func (a *Allocation) Scale(serverName string) (alloc *Allocation, inc int) {
 
 	// get accelerator info
 	gName := a.accelerator
-	if acc = GetAccelerator(gName); acc == nil {
+	if acc = system.Accelerator(gName); acc == nil {
 		return nil, 0
 	}
 
 	// create new allocation
-	alloc = CreateAllocation(serverName, gName)
+	alloc = system.CreateAllocation(serverName, gName)
 	inc = alloc.numReplicas - a.numReplicas
 	return alloc, inc
 }
 
-func (a *Allocation) ReAllocate(serverName string) (*Allocation, string) {
-	minVal := float32(0)
+func (a *Allocation) ReAllocate(system *System, serverName string) (*Allocation, string) {
+	minVal := float64(0)
 	var minAlloc *Allocation
-	for gName := range GetAccelerators() {
-		if alloc := CreateAllocation(serverName, gName); alloc != nil {
+	for gName := range system.Accelerators() {
+		if alloc := system.CreateAllocation(serverName, gName); alloc != nil {
 			if minVal == 0 || alloc.value < minVal {
 				minVal = alloc.value
 				minAlloc = alloc
@@ -202,6 +295,11 @@ func (a *Allocation) Accelerator() string {
 	return a.accelerator
 }
 
+// Number of accelerator units per replica (0 if unknown, i.e. the default of the model)
+func (a *Allocation) AccCount() int {
+	return a.accCount
+}
+
 func (a *Allocation) NumReplicas() int {
 	return a.numReplicas
 }
@@ -226,23 +324,48 @@ func (a *Allocation) MaxRPM() float32 {
 	return a.maxArrvRatePerReplica * 1000 * 60
 }
 
-func (a *Allocation) Cost() float32 {
+func (a *Allocation) Cost() float64 {
 	return a.cost
 }
 
-func (a *Allocation) SetCost(cost float32) {
+func (a *Allocation) SetCost(cost float64) {
 	a.cost = cost
 }
 
-func (a *Allocation) Value() float32 {
+func (a *Allocation) Value() float64 {
 	return a.value
 }
 
 // Set the value for this allocation (may depend on cost, performance, ...)
-func (a *Allocation) SetValue(value float32) {
+func (a *Allocation) SetValue(value float64) {
 	a.value = value
 }
 
+// Expected average token decode time (msec)
+func (a *Allocation) ITL() float32 {
+	return a.itl
+}
+
+// Expected average request queueing and prefill times (msec)
+func (a *Allocation) TTFT() float32 {
+	return a.ttft
+}
+
+// Expected utilization: average concurrently running requests / max batch size
+func (a *Allocation) Utilization() float32 {
+	return a.rho
+}
+
+// Expected relative SLO violation, summed over ITL, TTFT, and throughput (0 = SLOs met)
+func (a *Allocation) SLOViolation() float32 {
+	return a.violation
+}
+
+// Cost of the expected SLO violation (non-zero in soft SLO mode only)
+func (a *Allocation) ViolationPenalty() float64 {
+	return a.penalty
+}
+
 func (a *Allocation) Saturated(totalRate float32) bool {
 	return totalRate > float32(a.numReplicas)*a.MaxRPM()
 }
@@ -263,8 +386,8 @@ func zeroLoadAllocation(server *Server, model *Model, acc *Accelerator, perf *co
 	if server.maxBatchSize > 0 {
 		maxBatchSize = server.maxBatchSize
 	}
-	totalNumInstances := model.NumInstances(gName) * numReplicas
-	cost := acc.Cost() * float32(totalNumInstances)
+	totalNumInstances := model.numInstancesWith(gName, perf) * numReplicas
+	cost := acc.Cost() * float64(totalNumInstances)
 
 	//TODO: maxArrvRatePerReplica seems to be meaningless
 	decodeTime := perf.ServiceParms.Alpha + perf.ServiceParms.Beta
@@ -273,15 +396,18 @@ func zeroLoadAllocation(server *Server, model *Model, acc *Accelerator, perf *co
 	maxServTime := prefillTime + maxDecodeTime
 	maxArrvRatePerReplica := float32(maxBatchSize) / maxServTime
 
-	alloc := &Allocation{accelerator: gName, numReplicas: numReplicas, batchSize: maxBatchSize,
+	alloc := &Allocation{accelerator: gName, accCount: model.numInstancesWith(gName, perf), numReplicas: numReplicas, batchSize: maxBatchSize,
 		cost: cost, itl: decodeTime, ttft: prefillTime, rho: 0, maxArrvRatePerReplica: maxArrvRatePerReplica}
 	alloc.SetValue(alloc.cost)
 	return alloc
 }
 
-// Calculate penalty for transitioning from this allocation (a) to another allocation (b)
-func (a *Allocation) TransitionPenalty(b *Allocation) float32 {
-	if a.accelerator == b.accelerator {
+// Calculate penalty for transitioning from this allocation (a) to another allocation (b);
+// re-sharding to another number of accelerator units per replica is penalized as an
+// accelerator change, since all replicas restart (an unknown number matches any)
+func (a *Allocation) TransitionPenalty(b *Allocation) float64 {
+	sameSharding := a.accCount == 0 || b.accCount == 0 || a.accCount == b.accCount
+	if a.accelerator == b.accelerator && sameSharding {
 		if a.numReplicas == b.numReplicas {
 			return 0
 		} else {
@@ -294,6 +420,7 @@ func (a *Allocation) TransitionPenalty(b *Allocation) float32 {
 func (a *Allocation) Clone() *Allocation {
 	return &Allocation{
 		accelerator: a.accelerator,
+		accCount:    a.accCount,
 		numReplicas: a.numReplicas,
 		batchSize:   a.batchSize,
 		cost:        a.cost,
@@ -301,6 +428,8 @@ func (a *Allocation) Clone() *Allocation {
 		itl:         a.itl,
 		ttft:        a.ttft,
 		rho:         a.rho,
+		violation:   a.violation,
+		penalty:     a.penalty,
 
 		maxArrvRatePerReplica: a.maxArrvRatePerReplica,
 	}
@@ -309,28 +438,35 @@ func (a *Allocation) Clone() *Allocation {
 func (a *Allocation) AllocationData() *config.AllocationData {
 	return &config.AllocationData{
 		Accelerator: a.accelerator,
+		AccCount:    a.accCount,
 		NumReplicas: a.numReplicas,
 		MaxBatch:    a.batchSize,
-		Cost:        a.cost,
+		Cost:        CostToSpec(a.cost),
 		ITLAverage:  a.itl,
 		TTFTAverage: a.ttft,
+
+		SLOViolation: a.violation,
+		Utilization:  a.rho,
 	}
 }
 
 func AllocationFromData(data *config.AllocationData) *Allocation {
 	return &Allocation{
 		accelerator: data.Accelerator,
+		accCount:    data.AccCount,
 		numReplicas: data.NumReplicas,
 		batchSize:   data.MaxBatch,
-		cost:        data.Cost,
+		cost:        CostFromSpec(data.Cost),
 		itl:         data.ITLAverage,
 		ttft:        data.TTFTAverage,
+		violation:   data.SLOViolation,
+		rho:         data.Utilization,
 	}
 }
 
 func (a *Allocation) String() string {
-	return fmt.Sprintf("{acc=%s; numRep=%d; maxBatch=%d; cost=%v, val=%v, itl=%v, ttft=%v, rho=%v, maxRPM=%v}",
-		a.accelerator, a.numReplicas, a.batchSize, a.cost, a.value, a.itl, a.ttft, a.rho, a.MaxRPM())
+	return fmt.Sprintf("{acc=%s; numRep=%d; maxBatch=%d; cost=%v, val=%v, itl=%v, ttft=%v, rho=%v, maxRPM=%v, violation=%v}",
+		a.accelerator, a.numReplicas, a.batchSize, a.cost, a.value, a.itl, a.ttft, a.rho, a.MaxRPM(), a.violation)
 }
 
 // Orchestration difference between two allocations
@@ -339,7 +475,7 @@ type AllocationDiff struct {
 	newAccelerator string
 	oldNumReplicas int
 	newNumReplicas int
-	costDiff       float32
+	costDiff       float64
 }
 
 func CreateAllocationDiff(a *Allocation, b *Allocation) *AllocationDiff {
@@ -350,8 +486,8 @@ func CreateAllocationDiff(a *Allocation, b *Allocation) *AllocationDiff {
 	newAccelerator := "none"
 	oldNumReplicas := 0
 	newNumReplicas := 0
-	oldCost := float32(0)
-	newCost := float32(0)
+	oldCost := float64(0)
+	newCost := float64(0)
 	if a != nil {
 		oldAccelerator = a.accelerator
 		oldNumReplicas = a.numReplicas
@@ -377,3 +513,36 @@ func (d *AllocationDiff) String() string {
 		d.oldAccelerator, d.newAccelerator, d.oldNumReplicas, d.newNumReplicas, d.costDiff)
 	return b.String()
 }
+
+func (d *AllocationDiff) OldAccelerator() string {
+	return d.oldAccelerator
+}
+
+func (d *AllocationDiff) NewAccelerator() string {
+	return d.newAccelerator
+}
+
+func (d *AllocationDiff) OldNumReplicas() int {
+	return d.oldNumReplicas
+}
+
+func (d *AllocationDiff) NewNumReplicas() int {
+	return d.newNumReplicas
+}
+
+func (d *AllocationDiff) CostDiff() float64 {
+	return d.costDiff
+}
+
+// Check if the diff changes the accelerator or the number of replicas
+func (d *AllocationDiff) IsChange() bool {
+	return d.oldAccelerator != d.newAccelerator || d.oldNumReplicas != d.newNumReplicas
+}
+
+// Check if two diffs lead to the same target allocation
+func (d *AllocationDiff) SameTarget(other *AllocationDiff) bool {
+	if d == nil || other == nil {
+		return d == nil && other == nil
+	}
+	return d.newAccelerator == other.newAccelerator && d.newNumReplicas == other.newNumReplicas
+}
@@ -4,11 +4,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 )
 
 // Helper function to setup a complete test system
-func setupCompleteTestSystem() {
+func setupCompleteTestSystem() *System {
 	system := &System{
 		accelerators:     make(map[string]*Accelerator),
 		servers:          make(map[string]*Server),
@@ -59,8 +60,7 @@ func setupCompleteTestSystem() {
 		},
 		MinNumReplicas: 1,
 	}
-	server := NewServerFromSpec(serverSpec)
-	system.servers["test-server"] = server
+	system.addServer(NewServerFromSpec(serverSpec))
 
 	// Add test service class
 	serviceClass := NewServiceClass("default", 10)
@@ -72,14 +72,13 @@ func setupCompleteTestSystem() {
 	serviceClass.targets["test-model"] = target
 	system.serviceClasses["default"] = serviceClass
 
-	// Set global system
-	TheSystem = system
+	return system
 }
 
 func TestAllocation_Getters(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -107,12 +106,12 @@ func TestAllocation_Getters(t *testing.T) {
 		{
 			name:     "Cost",
 			getter:   func() any { return alloc.Cost() },
-			expected: float32(100.0),
+			expected: float64(100.0),
 		},
 		{
 			name:     "Value",
 			getter:   func() any { return alloc.Value() },
-			expected: float32(100.0),
+			expected: float64(100.0),
 		},
 		{
 			name:     "MaxArrvRatePerReplica",
@@ -138,8 +137,8 @@ func TestAllocation_Getters(t *testing.T) {
 
 func TestAllocation_Setters(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -166,13 +165,13 @@ func TestAllocation_Setters(t *testing.T) {
 			name:     "SetCost",
 			setter:   func() { alloc.SetCost(250.0) },
 			getter:   func() any { return alloc.Cost() },
-			expected: float32(250.0),
+			expected: float64(250.0),
 		},
 		{
 			name:     "SetValue",
 			setter:   func() { alloc.SetValue(300.0) },
 			getter:   func() any { return alloc.Value() },
-			expected: float32(300.0),
+			expected: float64(300.0),
 		},
 	}
 
@@ -189,8 +188,8 @@ func TestAllocation_Setters(t *testing.T) {
 
 func TestAllocation_Saturated(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -242,7 +241,7 @@ func TestAllocation_TransitionPenalty(t *testing.T) {
 	tests := []struct {
 		name   string
 		allocB *Allocation
-		want   float32
+		want   float64
 	}{
 		{
 			name: "same accelerator same replicas",
@@ -285,8 +284,8 @@ func TestAllocation_TransitionPenalty(t *testing.T) {
 
 func TestAllocation_Clone(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	original := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	original := system.CreateAllocation("test-server", "test-gpu")
 	if original == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -322,8 +321,8 @@ func TestAllocation_Clone(t *testing.T) {
 
 func TestAllocation_AllocationData(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -338,7 +337,7 @@ func TestAllocation_AllocationData(t *testing.T) {
 	if data.MaxBatch != alloc.batchSize {
 		t.Errorf("AllocationData.MaxBatch = %v, want %v", data.MaxBatch, alloc.batchSize)
 	}
-	if data.Cost != alloc.cost {
+	if data.Cost != CostToSpec(alloc.cost) {
 		t.Errorf("AllocationData.Cost = %v, want %v", data.Cost, alloc.cost)
 	}
 	if data.ITLAverage != alloc.itl {
@@ -370,7 +369,7 @@ func TestAllocationFromData(t *testing.T) {
 	if alloc.batchSize != data.MaxBatch {
 		t.Errorf("AllocationFromData batchSize = %v, want %v", alloc.batchSize, data.MaxBatch)
 	}
-	if alloc.cost != data.Cost {
+	if alloc.cost != CostFromSpec(data.Cost) {
 		t.Errorf("AllocationFromData cost = %v, want %v", alloc.cost, data.Cost)
 	}
 	if alloc.itl != data.ITLAverage {
@@ -383,8 +382,8 @@ func TestAllocationFromData(t *testing.T) {
 
 func TestAllocation_String(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -408,8 +407,8 @@ func TestAllocation_String(t *testing.T) {
 
 func TestCreateAllocationDiff(t *testing.T) {
 	// Setup system and create allocations using CreateAllocation
-	setupCompleteTestSystem()
-	testAlloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	testAlloc := system.CreateAllocation("test-server", "test-gpu")
 	if testAlloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -519,8 +518,8 @@ func TestAllocationDiff_String(t *testing.T) {
 
 func TestAllocationDiff_NilHandling(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	testAlloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	testAlloc := system.CreateAllocation("test-server", "test-gpu")
 	if testAlloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -579,7 +578,7 @@ func TestCreateAllocation(t *testing.T) {
 		name       string
 		serverName string
 		gName      string
-		setupFunc  func() // Custom setup for specific test cases
+		setupFunc  func() *System // Custom setup for specific test cases
 		wantNil    bool
 	}{
 		{
@@ -610,12 +609,13 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "server with no performance data",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Remove performance data from model
-				if model, exists := TheSystem.models["test-model"]; exists {
+				if model, exists := system.models["test-model"]; exists {
 					model.perfData = make(map[string]*config.ModelAcceleratorPerfData)
 				}
+				return system
 			},
 			wantNil: true,
 		},
@@ -623,12 +623,13 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "model with no service class target",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Remove target from service class
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					svc.targets = make(map[string]*Target)
 				}
+				return system
 			},
 			wantNil: true,
 		},
@@ -636,10 +637,10 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "server with invalid performance targets",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Set parameters that might cause queue analyzer to fail
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  1200, // Very high arrival rate
 						AvgInTokens:  100,
@@ -647,13 +648,14 @@ func TestCreateAllocation(t *testing.T) {
 					}
 				}
 				// Set very strict performance targets
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					if target, exists := svc.targets["test-model"]; exists {
 						target.TTFT = 1.0 // Very strict TTFT
 						target.ITL = 0.1  // Very strict ITL
 						target.TPS = 0.0
 					}
 				}
+				return system
 			},
 			wantNil: true,
 		},
@@ -661,10 +663,10 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "server with non-zero TPS target (covers TPS branch)",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Set reasonable arrival rate for non-zero load
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  60, // 1 req/second
 						AvgInTokens:  100,
@@ -672,13 +674,14 @@ func TestCreateAllocation(t *testing.T) {
 					}
 				}
 				// Set non-zero TPS to test that branch
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					if target, exists := svc.targets["test-model"]; exists {
 						target.TTFT = 2000.0
 						target.ITL = 500.0
 						target.TPS = 2.0
 					}
 				}
+				return system
 			},
 			wantNil: false, // Should succeed
 		},
@@ -686,10 +689,10 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "server with arrival rate only (covers arrival rate branch)",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Set non-zero arrival rate
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  120, // 2 req/second
 						AvgInTokens:  100,
@@ -697,13 +700,14 @@ func TestCreateAllocation(t *testing.T) {
 					}
 				}
 				// Keep TPS = 0 to test arrival rate branch
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					if target, exists := svc.targets["test-model"]; exists {
 						target.TTFT = 2000.0
 						target.ITL = 500.0
 						target.TPS = 0.0 // Zero TPS
 					}
 				}
+				return system
 			},
 			wantNil: false, // Should succeed
 		},
@@ -711,10 +715,10 @@ func TestCreateAllocation(t *testing.T) {
 			name:       "server with custom max batch size override",
 			serverName: "test-server",
 			gName:      "test-gpu",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// Set non-zero arrival rate
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  60,
 						AvgInTokens:  100,
@@ -722,13 +726,14 @@ func TestCreateAllocation(t *testing.T) {
 					}
 					server.maxBatchSize = 12 // Override max batch size
 				}
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					if target, exists := svc.targets["test-model"]; exists {
 						target.TTFT = 2000.0
 						target.ITL = 500.0
 						target.TPS = 0.0
 					}
 				}
+				return system
 			},
 			wantNil: false, // Should succeed and use custom batch size
 		},
@@ -736,17 +741,15 @@ func TestCreateAllocation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset system before each test
-			TheSystem = nil
-
 			// Setup system with complete test data
+			var system *System
 			if tt.setupFunc != nil {
-				tt.setupFunc()
+				system = tt.setupFunc()
 			} else {
-				setupCompleteTestSystem()
+				system = setupCompleteTestSystem()
 			}
 
-			alloc := CreateAllocation(tt.serverName, tt.gName)
+			alloc := system.CreateAllocation(tt.serverName, tt.gName)
 			if (alloc == nil) != tt.wantNil {
 				t.Errorf("CreateAllocation() = %v, wantNil %v", alloc, tt.wantNil)
 			}
@@ -774,8 +777,8 @@ func TestCreateAllocation(t *testing.T) {
 
 func TestAllocation_Scale(t *testing.T) {
 	// Setup system and create allocation using CreateAllocation
-	setupCompleteTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupCompleteTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -783,7 +786,7 @@ func TestAllocation_Scale(t *testing.T) {
 	tests := []struct {
 		name       string
 		serverName string
-		setupFunc  func() // Custom setup for scaling scenarios
+		setupFunc  func() *System // Custom setup for scaling scenarios
 		wantAlloc  bool
 		wantInc    int
 	}{
@@ -802,10 +805,10 @@ func TestAllocation_Scale(t *testing.T) {
 		{
 			name:       "valid server requiring scale up (inc > 0)",
 			serverName: "test-server",
-			setupFunc: func() {
-				setupCompleteTestSystem()
+			setupFunc: func() *System {
+				system := setupCompleteTestSystem()
 				// First, set up a low load so the original allocation has minimal replicas
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  30, // Low initial load (req/min)
 						AvgInTokens:  100,
@@ -813,13 +816,14 @@ func TestAllocation_Scale(t *testing.T) {
 					}
 				}
 				// Set lenient performance targets
-				if svc, exists := TheSystem.serviceClasses["default"]; exists {
+				if svc, exists := system.serviceClasses["default"]; exists {
 					if target, exists := svc.targets["test-model"]; exists {
 						target.TTFT = 2000.0
 						target.ITL = 500.0
 						target.TPS = 0.0
 					}
 				}
+				return system
 			},
 			wantAlloc: true, // Should succeed with scaling up
 			wantInc:   1,    // Expecting scale up
@@ -828,24 +832,23 @@ func TestAllocation_Scale(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			TheSystem = nil
-
 			// Setup for each test
+			var system *System
 			if tt.setupFunc != nil {
-				tt.setupFunc()
+				system = tt.setupFunc()
 			} else {
-				setupCompleteTestSystem()
+				system = setupCompleteTestSystem()
 			}
 
 			// Create initial allocation with the current setup
-			origAlloc := CreateAllocation(tt.serverName, "test-gpu")
+			origAlloc := system.CreateAllocation(tt.serverName, "test-gpu")
 			if origAlloc == nil && tt.name == "valid server requiring scale up (inc > 0)" {
 				t.Fatal("Failed to create initial allocation for scale up test")
 			}
 
 			// For scale up test, now increase the load after creating initial allocation
 			if tt.name == "valid server requiring scale up (inc > 0)" {
-				if server, exists := TheSystem.servers["test-server"]; exists {
+				if server, exists := system.servers["test-server"]; exists {
 					server.load = &config.ServerLoadSpec{
 						ArrivalRate:  360, // higher load
 						AvgInTokens:  100,
@@ -856,7 +859,7 @@ func TestAllocation_Scale(t *testing.T) {
 				alloc = origAlloc
 			}
 
-			newAlloc, inc := alloc.Scale(tt.serverName)
+			newAlloc, inc := alloc.Scale(system, tt.serverName)
 
 			if (newAlloc != nil) != tt.wantAlloc {
 				t.Errorf("Scale() alloc = %v, wantAlloc %v", newAlloc, tt.wantAlloc)
@@ -885,8 +888,8 @@ func TestAllocation_Scale(t *testing.T) {
 
 func TestAllocation_ReAllocate(t *testing.T) {
 	// Setup system with multiple accelerators for reallocation
-	setupReAllocateTestSystem := func() {
-		setupCompleteTestSystem()
+	setupReAllocateTestSystem := func() *System {
+		system := setupCompleteTestSystem()
 
 		// Add additional accelerators for reallocation testing
 		gpuSpecs := []*config.AcceleratorSpec{
@@ -897,20 +900,21 @@ func TestAllocation_ReAllocate(t *testing.T) {
 
 		for _, spec := range gpuSpecs {
 			acc := NewAcceleratorFromSpec(spec)
-			TheSystem.accelerators[spec.Name] = acc
+			system.accelerators[spec.Name] = acc
 		}
 
 		// Update test model to work with all accelerators
-		if model, exists := TheSystem.models["test-model"]; exists {
+		if model, exists := system.models["test-model"]; exists {
 			model.numInstances["gpu-a"] = 1
 			model.numInstances["gpu-b"] = 1
 			model.numInstances["gpu-c"] = 2
 		}
+		return system
 	}
 
 	// Create allocation using CreateAllocation
-	setupReAllocateTestSystem()
-	alloc := CreateAllocation("test-server", "test-gpu")
+	system := setupReAllocateTestSystem()
+	alloc := system.CreateAllocation("test-server", "test-gpu")
 	if alloc == nil {
 		t.Fatal("CreateAllocation returned nil, setup may be incorrect")
 	}
@@ -937,13 +941,10 @@ func TestAllocation_ReAllocate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset system before each test
-			TheSystem = nil
-
 			// Setup system with multiple accelerators for reallocation
-			setupReAllocateTestSystem()
+			system := setupReAllocateTestSystem()
 
-			newAlloc, gName := alloc.ReAllocate(tt.serverName)
+			newAlloc, gName := alloc.ReAllocate(system, tt.serverName)
 
 			if (newAlloc != nil) != tt.wantAlloc {
 				t.Errorf("ReAllocate() alloc = %v, wantAlloc %v", newAlloc, tt.wantAlloc)
@@ -975,7 +976,7 @@ func TestZeroLoadAllocation(t *testing.T) {
 		wantAccel     string
 		wantReplicas  int
 		wantBatchSize int
-		wantCost      float32
+		wantCost      float64
 	}{
 		{
 			name: "zero replicas",
@@ -1167,3 +1168,181 @@ func TestZeroLoadAllocation_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAllocation_SoftSLO(t *testing.T) {
+	// strict targets that cannot be achieved
+	setupStrict := func() *System {
+		system := setupCompleteTestSystem()
+		system.servers["test-server"].load = &config.ServerLoadSpec{
+			ArrivalRate:  600,
+			AvgInTokens:  100,
+			AvgOutTokens: 200,
+		}
+		target := system.serviceClasses["default"].targets["test-model"]
+		target.TTFT = 1.0
+		target.ITL = 0.1
+		return system
+	}
+
+	system := setupStrict()
+	if alloc := system.CreateAllocation("test-server", "test-gpu"); alloc != nil {
+		t.Fatalf("expected nil allocation for unachievable targets, got %v", alloc)
+	}
+
+	system = setupStrict()
+	system.SetSoftSLO(true)
+	alloc := system.CreateAllocation("test-server", "test-gpu")
+	if alloc == nil {
+		t.Fatal("expected best-effort allocation in soft SLO mode")
+	}
+	if alloc.SLOViolation() <= 0 {
+		t.Errorf("SLOViolation() = %v, want > 0", alloc.SLOViolation())
+	}
+	wantPenalty := config.DefaultSLOViolationPenalty * float64(alloc.SLOViolation())
+	if alloc.ViolationPenalty() != wantPenalty {
+		t.Errorf("ViolationPenalty() = %v, want %v", alloc.ViolationPenalty(), wantPenalty)
+	}
+	if alloc.Value() != alloc.Cost()+alloc.ViolationPenalty() {
+		t.Errorf("Value() = %v, want cost plus penalty %v", alloc.Value(), alloc.Cost()+alloc.ViolationPenalty())
+	}
+	if got := alloc.AllocationData().SLOViolation; got != alloc.SLOViolation() {
+		t.Errorf("AllocationData().SLOViolation = %v, want %v", got, alloc.SLOViolation())
+	}
+	if got := alloc.Clone().SLOViolation(); got != alloc.SLOViolation() {
+		t.Errorf("Clone().SLOViolation() = %v, want %v", got, alloc.SLOViolation())
+	}
+
+	// configured penalty scales the penalty cost
+	system = setupStrict()
+	system.SetSoftSLO(true)
+	system.serviceClasses["default"].SetViolationPenalty(10)
+	if alloc := system.CreateAllocation("test-server", "test-gpu"); alloc == nil ||
+		alloc.ViolationPenalty() != 10*float64(alloc.SLOViolation()) {
+		t.Errorf("expected penalty of 10 per unit violation, got %v", alloc)
+	}
+}
+
+func TestCreateAllocationWithReplicas(t *testing.T) {
+	system := setupCompleteTestSystem()
+	system.servers["test-server"].load = &config.ServerLoadSpec{
+		ArrivalRate:  600,
+		AvgInTokens:  100,
+		AvgOutTokens: 200,
+	}
+	target := system.serviceClasses["default"].targets["test-model"]
+	target.TTFT = 2000
+	target.ITL = 50
+	system.SetSoftSLO(true)
+
+	sized := system.CreateAllocation("test-server", "test-gpu")
+	if sized == nil {
+		t.Fatal("expected feasible allocation")
+	}
+	if sized.SLOViolation() != 0 {
+		t.Errorf("sized allocation SLOViolation() = %v, want 0", sized.SLOViolation())
+	}
+	if sized.NumReplicas() < 2 {
+		t.Fatalf("expected at least 2 replicas for the load, got %d", sized.NumReplicas())
+	}
+
+	reduced := system.CreateAllocationWithReplicas("test-server", "test-gpu", 1)
+	if reduced == nil {
+		t.Fatal("expected allocation with fixed replicas")
+	}
+	if reduced.NumReplicas() != 1 {
+		t.Errorf("NumReplicas() = %d, want 1", reduced.NumReplicas())
+	}
+	if reduced.SLOViolation() <= 0 {
+		t.Errorf("reduced allocation SLOViolation() = %v, want > 0", reduced.SLOViolation())
+	}
+	if reduced.Cost() >= sized.Cost() {
+		t.Errorf("reduced allocation cost %v not lower than sized cost %v", reduced.Cost(), sized.Cost())
+	}
+
+	if alloc := system.CreateAllocationWithReplicas("test-server", "test-gpu", 0); alloc != nil {
+		t.Errorf("expected nil allocation for zero replicas, got %v", alloc)
+	}
+}
+
+func TestCreateAllocationWithReplicas_UnstableRate(t *testing.T) {
+	system := setupCompleteTestSystem()
+	system.servers["test-server"].load = &config.ServerLoadSpec{
+		ArrivalRate:  600,
+		AvgInTokens:  100,
+		AvgOutTokens: 200,
+	}
+	target := system.serviceClasses["default"].targets["test-model"]
+	target.TTFT = 1e6
+	target.ITL = 1e6
+	system.SetSoftSLO(true)
+
+	// load one replica between its highest stable rate and the max rate of its queue
+	server := system.servers["test-server"]
+	perf := system.models["test-model"].AllPerfData("test-gpu")[0]
+	queueAnalyzer, _, err := newQueueAnalyzer(server, perf, server.load)
+	if err != nil {
+		t.Fatalf("newQueueAnalyzer() error = %v", err)
+	}
+	maxStableRate := queueAnalyzer.RateRange.Max * (1 - analyzer.StabilitySafetyFraction)
+	rate := (maxStableRate + queueAnalyzer.RateRange.Max) / 2
+	server.load.ArrivalRate = rate * 60
+
+	alloc := system.CreateAllocationWithReplicas("test-server", "test-gpu", 1)
+	if alloc == nil {
+		t.Fatal("expected allocation with fixed replicas")
+	}
+	wantOverload := rate/maxStableRate - 1
+	if alloc.SLOViolation() < wantOverload*0.99 {
+		t.Errorf("SLOViolation() = %v, want at least the overload above the stable rate %v", alloc.SLOViolation(), wantOverload)
+	}
+}
+
+func TestCreateAllocation_Resharding(t *testing.T) {
+	system := setupCompleteTestSystem()
+	perf := *system.models["test-model"].PerfData("test-gpu")
+
+	// deployed at 2 units per replica, with a profile for 1 unit per replica
+	model := NewModel("test-model")
+	tp2 := perf
+	tp2.AccCount = 2
+	model.AddPerfDataFromSpec(&tp2)
+	tp1 := perf
+	tp1.AccCount = 1
+	model.AddPerfDataFromSpec(&tp1)
+	system.models["test-model"] = model
+
+	if got := model.NumInstances("test-gpu"); got != 2 {
+		t.Errorf("NumInstances() = %d, want the default 2", got)
+	}
+	if got := len(model.AllPerfData("test-gpu")); got != 2 {
+		t.Fatalf("len(AllPerfData()) = %d, want 2", got)
+	}
+
+	// at zero load, a replica on 1 unit costs half a replica on 2 units
+	alloc := system.CreateAllocation("test-server", "test-gpu")
+	if alloc == nil {
+		t.Fatal("expected feasible allocation")
+	}
+	if alloc.AccCount() != 1 || alloc.Cost() != 100 {
+		t.Errorf("CreateAllocation() = %d units per replica at cost %v, want 1 at 100", alloc.AccCount(), alloc.Cost())
+	}
+	if data := alloc.AllocationData(); data.AccCount != 1 {
+		t.Errorf("AllocationData().AccCount = %d, want 1", data.AccCount)
+	}
+
+	sharded := system.CreateShardedAllocationWithReplicas("test-server", "test-gpu", 2, 1)
+	if sharded == nil || sharded.AccCount() != 2 || sharded.Cost() != 200 {
+		t.Fatalf("CreateShardedAllocationWithReplicas(2) = %v, want 2 units per replica at cost 200", sharded)
+	}
+	if got := model.NumInstancesOf(sharded); got != 2 {
+		t.Errorf("NumInstancesOf() = %d, want 2", got)
+	}
+	if alloc := system.CreateShardedAllocationWithReplicas("test-server", "test-gpu", 4, 1); alloc != nil {
+		t.Errorf("expected nil allocation without a profile for 4 units, got %v", alloc)
+	}
+
+	// re-sharding restarts all replicas: penalized as an accelerator change
+	if penalty := sharded.TransitionPenalty(alloc); penalty <= alloc.Cost()-sharded.Cost() {
+		t.Errorf("TransitionPenalty() = %v, want a re-sharding penalty", penalty)
+	}
+}
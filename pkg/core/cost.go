@@ -0,0 +1,20 @@
+package core
+
+import "strconv"
+
+// Costs (cents/hr) are computed and aggregated as float64, while specs carry float32 values.
+
+// Cost of a spec value, keeping its shortest decimal representation
+// (e.g. 0.1 rather than 0.10000000149011612)
+func CostFromSpec(cost float32) float64 {
+	value, err := strconv.ParseFloat(strconv.FormatFloat(float64(cost), 'g', -1, 32), 64)
+	if err != nil {
+		return float64(cost)
+	}
+	return value
+}
+
+// Spec value of a cost
+func CostToSpec(cost float64) float32 {
+	return float32(cost)
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+func TestCostFromSpec(t *testing.T) {
+	tests := []struct {
+		spec float32
+		want float64
+	}{
+		{spec: 0, want: 0},
+		{spec: 0.1, want: 0.1},
+		{spec: 0.07, want: 0.07},
+		{spec: 12.34, want: 12.34},
+		{spec: 1234.56, want: 1234.56},
+		{spec: 100, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.spec), func(t *testing.T) {
+			got := CostFromSpec(tt.spec)
+			if got != tt.want {
+				t.Errorf("CostFromSpec(%v) = %v, want %v", tt.spec, got, tt.want)
+			}
+			if back := CostToSpec(got); back != tt.spec {
+				t.Errorf("CostToSpec(%v) = %v, want %v", got, back, tt.spec)
+			}
+		})
+	}
+}
+
+// Aggregating hundreds of replicas at a fractional price keeps the cost exact to well below a cent
+func TestSystem_AllocateByType_CostPrecision(t *testing.T) {
+	const (
+		numServers  = 500
+		numReplicas = 3
+		unitCost    = 0.07
+	)
+	system := NewSystem()
+	system.AddAcceleratorFromSpec(config.AcceleratorSpec{Name: "gpu", Type: "GPU", Multiplicity: 1, Cost: unitCost})
+	system.AddModel("model").AddPerfDataFromSpec(&config.ModelAcceleratorPerfData{
+		Name: "model", Acc: "gpu", AccCount: 1, MaxBatchSize: 16, AtTokens: 100,
+	})
+	system.AddServiceClass("default", 1)
+	system.ServiceClass("default").AddModelTarget(&config.ModelTarget{Model: "model", SLO_ITL: 100, SLO_TTFT: 1000})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU", Count: numServers * numReplicas})
+	for i := range numServers {
+		name := fmt.Sprintf("server-%d", i)
+		system.AddServerFromSpec(config.ServerSpec{Name: name, Class: "default", Model: "model", MinNumReplicas: numReplicas})
+		// zero load: allocation of the minimum number of replicas
+		alloc := system.CreateAllocation(name, "gpu")
+		if alloc == nil {
+			t.Fatalf("%s: no allocation", name)
+		}
+		system.Server(name).SetAllocation(alloc)
+	}
+
+	system.AllocateByType()
+	byType := system.allocationByType["GPU"]
+	if byType == nil {
+		t.Fatal("missing allocation of type GPU")
+	}
+	if byType.count != numServers*numReplicas {
+		t.Errorf("count = %d, want %d", byType.count, numServers*numReplicas)
+	}
+	want := numServers * numReplicas * unitCost
+	if math.Abs(byType.cost-want) > 1e-9 {
+		t.Errorf("cost = %v, want %v", byType.cost, want)
+	}
+}
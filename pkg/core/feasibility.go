@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// Names of SLOs in feasibility diagnostics
+const (
+	SLOITL  = "ITL"
+	SLOTTFT = "TTFT"
+)
+
+// Diagnostic of an accelerator unable to serve a server within its SLOs
+type Infeasibility struct {
+	Accelerator string  // name of accelerator
+	SLO         string  // unreachable SLO (SLOITL or SLOTTFT), empty if not SLO-related
+	Target      float32 // target of the SLO (msec)
+	Best        float32 // best value of the SLO a replica achieves, at the lowest load (msec)
+	ArrivalRate float32 // arrival rate of the server (req/min)
+	Reason      string  // explanation
+}
+
+func (i *Infeasibility) String() string {
+	if i.SLO == "" {
+		return fmt.Sprintf("acc=%s: %s", i.Accelerator, i.Reason)
+	}
+	return fmt.Sprintf("acc=%s: %s target %vms unreachable, best %vms at the lowest load (rate=%v req/min)",
+		i.Accelerator, i.SLO, i.Target, i.Best, i.ArrivalRate)
+}
+
+// Check whether an accelerator can serve a server of this system within its SLOs,
+// independently of sizing; nil if feasible
+//   - each SLO is checked on its own against the best value a replica achieves, at the
+//     lowest load, so that the diagnostic names the SLO that cannot be met at any scale
+func (s *System) CheckFeasibility(serverName string, gName string) *Infeasibility {
+	infeasible := func(reason string, args ...any) *Infeasibility {
+		return &Infeasibility{Accelerator: gName, Reason: fmt.Sprintf(reason, args...)}
+	}
+
+	if s.Accelerator(gName) == nil {
+		return infeasible("accelerator not found")
+	}
+	server := s.Server(serverName)
+	if server == nil {
+		return infeasible("server %s not found", serverName)
+	}
+	load := server.Load()
+	if load == nil {
+		return infeasible("no load statistics")
+	}
+	if load.ArrivalRate < 0 || load.AvgInTokens < 0 || load.AvgOutTokens < 0 {
+		return infeasible("invalid load statistics (rate=%v, inTokens=%d, outTokens=%d)",
+			load.ArrivalRate, load.AvgInTokens, load.AvgOutTokens)
+	}
+	modelName := server.ModelName()
+	model := s.Model(modelName)
+	if model == nil {
+		return infeasible("model %s not found", modelName)
+	}
+	perf := model.PerfData(gName)
+	if perf == nil {
+		return infeasible("no performance data of model %s", modelName)
+	}
+	svc := s.ServiceClass(server.ServiceClassName())
+	if svc == nil {
+		return infeasible("service class %s not found", server.ServiceClassName())
+	}
+	target := svc.ModelTarget(modelName)
+	if target == nil {
+		return infeasible("no target of model %s in service class %s", modelName, svc.Name())
+	}
+	if load.ArrivalRate == 0 || load.AvgOutTokens == 0 {
+		return nil
+	}
+
+	return checkTargets(server, perf, load, target, gName)
+}
+
+// Check the SLO targets of a server against the best values a replica achieves
+func checkTargets(server *Server, perf *config.ModelAcceleratorPerfData, load *config.ServerLoadSpec,
+	target *Target, gName string) *Infeasibility {
+
+	queueAnalyzer, _, err := newQueueAnalyzer(server, perf, load)
+	if err != nil {
+		return &Infeasibility{Accelerator: gName, ArrivalRate: load.ArrivalRate,
+			Reason: fmt.Sprintf("queue model: %v", err)}
+	}
+	best, err := queueAnalyzer.Analyze(queueAnalyzer.RateRange.Min)
+	if err != nil {
+		return &Infeasibility{Accelerator: gName, ArrivalRate: load.ArrivalRate,
+			Reason: fmt.Sprintf("queue model: %v", err)}
+	}
+
+	unreachable := func(slo string, target, best float32) *Infeasibility {
+		return &Infeasibility{Accelerator: gName, SLO: slo, Target: target, Best: best,
+			ArrivalRate: load.ArrivalRate, Reason: "SLO target below the best achievable value"}
+	}
+	if target.ITL > 0 && best.AvgTokenTime > target.ITL {
+		return unreachable(SLOITL, target.ITL, best.AvgTokenTime)
+	}
+	if target.TTFT > 0 && best.AvgTTFT > target.TTFT {
+		return unreachable(SLOTTFT, target.TTFT, best.AvgTTFT)
+	}
+
+	// targets reachable on their own, check them jointly
+	targetPerf := &analyzer.TargetPerf{
+		TargetTTFT: target.TTFT,
+		TargetITL:  target.ITL,
+		TargetTPS:  target.TPS,
+	}
+	if _, _, _, err := queueAnalyzer.Size(targetPerf); err != nil {
+		return &Infeasibility{Accelerator: gName, ArrivalRate: load.ArrivalRate,
+			Reason: fmt.Sprintf("sizing failed: %v", err)}
+	}
+	return nil
+}
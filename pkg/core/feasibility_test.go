@@ -0,0 +1,130 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// Create a system of one server of a model with performance data on one accelerator
+func newFeasibilityTestSystem(target Target) *System {
+	system := NewSystem()
+	system.AddAcceleratorFromSpec(config.AcceleratorSpec{Name: "test-gpu", Cost: 100.0})
+	system.AddAcceleratorFromSpec(config.AcceleratorSpec{Name: "other-gpu", Cost: 50.0})
+
+	model := system.AddModel("test-model")
+	model.AddPerfDataFromSpec(&config.ModelAcceleratorPerfData{
+		Name:         "test-model",
+		Acc:          "test-gpu",
+		AccCount:     1,
+		MaxBatchSize: 16,
+		AtTokens:     200,
+		ServiceParms: config.ServiceParms{Alpha: 5.0, Beta: 0.2, Gamma: 0.015},
+	})
+
+	system.AddServiceClass("default", 5)
+	system.ServiceClass("default").targets["test-model"] = &target
+
+	system.AddServerFromSpec(config.ServerSpec{
+		Name:  "test-server",
+		Model: "test-model",
+		Class: "default",
+		CurrentAlloc: config.AllocationData{
+			Load: config.ServerLoadSpec{ArrivalRate: 60, AvgInTokens: 100, AvgOutTokens: 200},
+		},
+	})
+	system.Calculate()
+	return system
+}
+
+func TestSystem_CheckFeasibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     Target
+		acc        string
+		feasible   bool
+		wantSLO    string
+		wantReason string
+	}{
+		{
+			name:     "reachable targets",
+			target:   Target{TTFT: 2000, ITL: 500},
+			acc:      "test-gpu",
+			feasible: true,
+		},
+		{
+			name:    "ITL below the decode time of a single request",
+			target:  Target{TTFT: 2000, ITL: 1},
+			acc:     "test-gpu",
+			wantSLO: SLOITL,
+		},
+		{
+			name:    "TTFT below the prefill time of a single request",
+			target:  Target{TTFT: 1, ITL: 500},
+			acc:     "test-gpu",
+			wantSLO: SLOTTFT,
+		},
+		{
+			name:       "no performance data",
+			target:     Target{TTFT: 2000, ITL: 500},
+			acc:        "other-gpu",
+			wantReason: "no performance data of model test-model",
+		},
+		{
+			name:       "unknown accelerator",
+			target:     Target{TTFT: 2000, ITL: 500},
+			acc:        "missing-gpu",
+			wantReason: "accelerator not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system := newFeasibilityTestSystem(tt.target)
+			infeasibility := system.CheckFeasibility("test-server", tt.acc)
+			if tt.feasible {
+				if infeasibility != nil {
+					t.Fatalf("CheckFeasibility() = %v, want feasible", infeasibility)
+				}
+				return
+			}
+			if infeasibility == nil {
+				t.Fatal("CheckFeasibility() = nil, want infeasible")
+			}
+			if infeasibility.Accelerator != tt.acc {
+				t.Errorf("Accelerator = %q, want %q", infeasibility.Accelerator, tt.acc)
+			}
+			if infeasibility.SLO != tt.wantSLO {
+				t.Errorf("SLO = %q, want %q (%v)", infeasibility.SLO, tt.wantSLO, infeasibility)
+			}
+			if tt.wantSLO != "" {
+				if infeasibility.Best <= infeasibility.Target {
+					t.Errorf("Best = %v, want above target %v", infeasibility.Best, infeasibility.Target)
+				}
+				if infeasibility.ArrivalRate != 60 {
+					t.Errorf("ArrivalRate = %v, want 60", infeasibility.ArrivalRate)
+				}
+			}
+			if tt.wantReason != "" && infeasibility.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", infeasibility.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestServer_Infeasibilities(t *testing.T) {
+	system := newFeasibilityTestSystem(Target{TTFT: 2000, ITL: 1})
+	server := system.Server("test-server")
+
+	if len(server.AllAllocations()) != 0 {
+		t.Fatalf("AllAllocations() = %v, want none", server.AllAllocations())
+	}
+	infeasibilities := server.Infeasibilities()
+	if len(infeasibilities) != 2 {
+		t.Fatalf("Infeasibilities() = %v, want one per accelerator", infeasibilities)
+	}
+	if got := infeasibilities["test-gpu"].String(); !strings.Contains(got, "ITL target 1ms unreachable") {
+		t.Errorf("String() = %q, want the unreachable ITL target", got)
+	}
+}
@@ -15,6 +15,9 @@ type Model struct {
 
 	// number of accelerator instances needed to fit a model on a given accelerator
 	numInstances map[string]int
+
+	// performance data for other numbers of accelerator instances per replica (re-sharding)
+	altPerfData map[string][]*config.ModelAcceleratorPerfData
 }
 
 func NewModel(name string) *Model {
@@ -22,6 +25,7 @@ func NewModel(name string) *Model {
 		name:         name,
 		perfData:     make(map[string]*config.ModelAcceleratorPerfData),
 		numInstances: make(map[string]int),
+		altPerfData:  make(map[string][]*config.ModelAcceleratorPerfData),
 	}
 }
 
@@ -42,33 +46,79 @@ func (m *Model) PerfData(acceleratorName string) *config.ModelAcceleratorPerfDat
 	return m.perfData[acceleratorName]
 }
 
+// Performance data for all numbers of accelerator instances per replica on an accelerator,
+// the default number first
+func (m *Model) AllPerfData(acceleratorName string) []*config.ModelAcceleratorPerfData {
+	perf := m.perfData[acceleratorName]
+	if perf == nil {
+		return nil
+	}
+	return append([]*config.ModelAcceleratorPerfData{perf}, m.altPerfData[acceleratorName]...)
+}
+
+// Number of accelerator instances per replica of an allocation: its own if set,
+// the default of the model on its accelerator otherwise
+func (m *Model) NumInstancesOf(alloc *Allocation) int {
+	if alloc.accCount > 0 {
+		return alloc.accCount
+	}
+	return m.numInstances[alloc.accelerator]
+}
+
+// Number of accelerator instances per replica with performance data on an accelerator:
+// the number of the data if set, the default of the model otherwise
+func (m *Model) numInstancesWith(acceleratorName string, perf *config.ModelAcceleratorPerfData) int {
+	if perf.AccCount > 0 {
+		return perf.AccCount
+	}
+	return m.numInstances[acceleratorName]
+}
+
+// Add performance data: the first data of an accelerator sets the default number of
+// accelerator instances per replica; data for other numbers are alternatives the
+// optimizer may re-shard the model to
 func (m *Model) AddPerfDataFromSpec(spec *config.ModelAcceleratorPerfData) {
 	if spec.Name == m.name {
-		m.perfData[spec.Acc] = spec
-		var count int
-		if count = spec.AccCount; count <= 0 {
-			count = 1
+		count := perfDataCount(spec)
+		if cur := m.perfData[spec.Acc]; cur != nil && perfDataCount(cur) != count {
+			alts := m.altPerfData[spec.Acc]
+			for i, alt := range alts {
+				if perfDataCount(alt) == count {
+					alts[i] = spec
+					return
+				}
+			}
+			m.altPerfData[spec.Acc] = append(alts, spec)
+			return
 		}
+		m.perfData[spec.Acc] = spec
 		m.numInstances[spec.Acc] = count
 	}
 }
 
 func (m *Model) RemovePerfData(accName string) {
 	delete(m.perfData, accName)
+	delete(m.altPerfData, accName)
 }
 
 func (m *Model) Spec() *config.ModelData {
 	md := &config.ModelData{
-		PerfData: make([]config.ModelAcceleratorPerfData, len(m.perfData)),
+		PerfData: make([]config.ModelAcceleratorPerfData, 0, len(m.perfData)),
 	}
-	i := 0
-	for _, pd := range m.perfData {
-		md.PerfData[i] = *pd
-		i++
+	for accName, pd := range m.perfData {
+		md.PerfData = append(md.PerfData, *pd)
+		for _, alt := range m.altPerfData[accName] {
+			md.PerfData = append(md.PerfData, *alt)
+		}
 	}
 	return md
 }
 
+// Number of accelerator instances per replica of performance data (at least 1)
+func perfDataCount(spec *config.ModelAcceleratorPerfData) int {
+	return max(spec.AccCount, 1)
+}
+
 func (m *Model) String() string {
 	return fmt.Sprintf("Model: name=%s; numInstances=%v",
 		m.name, m.numInstances)
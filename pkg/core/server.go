@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 )
@@ -15,12 +16,18 @@ type Server struct {
 	minNumReplicas   int
 	maxBatchSize     int
 
+	// acceptable accelerators, most preferred first (empty = any accelerator)
+	preferences []string
+
 	// server load statistics
 	load *config.ServerLoadSpec
 
 	// for all accelerators
 	allAllocations map[string]*Allocation
 
+	// diagnostics of accelerators without feasible allocation
+	infeasibilities map[string]*Infeasibility
+
 	// allocated solution
 	allocation *Allocation
 
@@ -28,6 +35,9 @@ type Server struct {
 	curAllocation *Allocation
 
 	spec *config.ServerSpec
+
+	// system the server belongs to
+	system *System
 }
 
 func NewServerFromSpec(spec *config.ServerSpec) *Server {
@@ -44,6 +54,7 @@ func NewServerFromSpec(spec *config.ServerSpec) *Server {
 		keepAccelerator:  spec.KeepAccelerator,
 		minNumReplicas:   spec.MinNumReplicas,
 		maxBatchSize:     spec.MaxBatchSize,
+		preferences:      spec.AcceleratorPreferences,
 
 		allAllocations: map[string]*Allocation{},
 		curAllocation:  AllocationFromData(&spec.CurrentAlloc),
@@ -51,23 +62,42 @@ func NewServerFromSpec(spec *config.ServerSpec) *Server {
 	}
 }
 
-// Calculate allocations for a set of accelerators
+// Calculate allocations for a set of accelerators (none if the server was not added to a system)
 func (s *Server) Calculate(accelerators map[string]*Accelerator) {
-	candidateAccelerators := s.GetCandidateAccelerators(accelerators)
 	s.allAllocations = make(map[string]*Allocation)
+	s.infeasibilities = make(map[string]*Infeasibility)
+	if s.system == nil {
+		return
+	}
+	candidateAccelerators := s.GetCandidateAccelerators(accelerators)
 	for _, g := range candidateAccelerators {
-		if alloc := CreateAllocation(s.name, g.Name()); alloc != nil {
+		if alloc := s.system.CreateAllocation(s.name, g.Name()); alloc != nil {
 			if s.curAllocation != nil {
 				penalty := s.curAllocation.TransitionPenalty(alloc)
-				alloc.SetValue(penalty)
+				alloc.SetValue(penalty + alloc.ViolationPenalty())
 			}
 			s.allAllocations[g.Name()] = alloc
+		} else {
+			infeasibility := s.system.CheckFeasibility(s.name, g.Name())
+			if infeasibility == nil {
+				infeasibility = &Infeasibility{Accelerator: g.Name(), Reason: "allocation failed"}
+			}
+			s.infeasibilities[g.Name()] = infeasibility
 		}
 	}
 }
 
 // Create a subset of candidate accelerators for a server from a given set
 func (s *Server) GetCandidateAccelerators(accelerators map[string]*Accelerator) map[string]*Accelerator {
+	if len(s.preferences) > 0 {
+		accMap := make(map[string]*Accelerator)
+		for _, accName := range s.preferences {
+			if acc := accelerators[accName]; acc != nil {
+				accMap[accName] = acc
+			}
+		}
+		return accMap
+	}
 	if s.keepAccelerator {
 		if s.curAllocation != nil && s.curAllocation.accelerator != "" {
 			accMap := make(map[string]*Accelerator)
@@ -90,7 +120,10 @@ func (s *Server) ServiceClassName() string {
 }
 
 func (s *Server) Priority() int {
-	if svc := GetServiceClass(s.serviceClassName); svc != nil {
+	if s.system == nil {
+		return config.DefaultServiceClassPriority
+	}
+	if svc := s.system.ServiceClass(s.serviceClassName); svc != nil {
 		return svc.Priority()
 	}
 	return config.DefaultServiceClassPriority
@@ -100,10 +133,35 @@ func (s *Server) ModelName() string {
 	return s.modelName
 }
 
+func (s *Server) MinNumReplicas() int {
+	return s.minNumReplicas
+}
+
 func (s *Server) KeepAccelerator() bool {
 	return s.keepAccelerator
 }
 
+func (s *Server) AcceleratorPreferences() []string {
+	return s.preferences
+}
+
+// Rank of an accelerator in the preference list of the server (0 = most preferred);
+// all accelerators rank equally (0) if the server has no preferences
+func (s *Server) PreferenceRank(accName string) int {
+	if len(s.preferences) == 0 {
+		return 0
+	}
+	if rank := slices.Index(s.preferences, accName); rank >= 0 {
+		return rank
+	}
+	return len(s.preferences)
+}
+
+// Check if an accelerator substitutes for the most preferred accelerator of the server
+func (s *Server) IsSubstitute(accName string) bool {
+	return len(s.preferences) > 0 && accName != s.preferences[0]
+}
+
 func (s *Server) Load() *config.ServerLoadSpec {
 	return s.load
 }
@@ -137,6 +195,11 @@ func (s *Server) AllAllocations() map[string]*Allocation {
 	return s.allAllocations
 }
 
+// Diagnostics of the candidate accelerators without feasible allocation, by accelerator name
+func (s *Server) Infeasibilities() map[string]*Infeasibility {
+	return s.infeasibilities
+}
+
 func (s *Server) Spec() *config.ServerSpec {
 	return s.spec
 }
@@ -210,7 +210,7 @@ func TestServer_Getters(t *testing.T) {
 
 func TestServer_Priority(t *testing.T) {
 	// Setup a test system with service classes
-	setupTestSystemForServerPriority := func() {
+	setupTestSystemForServerPriority := func() *System {
 		system := &System{
 			serviceClasses: make(map[string]*ServiceClass),
 		}
@@ -219,15 +219,16 @@ func TestServer_Priority(t *testing.T) {
 		highPriorityClass := NewServiceClass("high-priority", 1)
 		lowPriorityClass := NewServiceClass("low-priority", 8)
 		system.serviceClasses["high-priority"] = highPriorityClass
+		system.servers = make(map[string]*Server)
 		system.serviceClasses["low-priority"] = lowPriorityClass
 
-		TheSystem = system
+		return system
 	}
 
 	tests := []struct {
 		name             string
 		serviceClassName string
-		setupFunc        func()
+		setupFunc        func() *System
 		expectedPriority int
 	}{
 		{
@@ -251,9 +252,9 @@ func TestServer_Priority(t *testing.T) {
 		{
 			name:             "server with empty system setup",
 			serviceClassName: "any-class",
-			setupFunc: func() {
+			setupFunc: func() *System {
 				// Set up empty system instead of nil
-				TheSystem = &System{serviceClasses: make(map[string]*ServiceClass)}
+				return &System{serviceClasses: make(map[string]*ServiceClass), servers: make(map[string]*Server)}
 			},
 			expectedPriority: config.DefaultServiceClassPriority,
 		},
@@ -261,7 +262,7 @@ func TestServer_Priority(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setupFunc()
+			system := tt.setupFunc()
 
 			spec := &config.ServerSpec{
 				Name:  "test-server",
@@ -272,6 +273,7 @@ func TestServer_Priority(t *testing.T) {
 				},
 			}
 			server := NewServerFromSpec(spec)
+			system.addServer(server)
 
 			priority := server.Priority()
 			if priority != tt.expectedPriority {
@@ -402,6 +404,7 @@ func TestServer_GetCandidateAccelerators(t *testing.T) {
 	tests := []struct {
 		name            string
 		keepAccelerator bool
+		preferences     []string
 		curAllocation   *Allocation
 		expectedCount   int
 		expectedNames   []string
@@ -434,6 +437,20 @@ func TestServer_GetCandidateAccelerators(t *testing.T) {
 			expectedCount:   0,
 			expectedNames:   []string{},
 		},
+		{
+			name:          "accelerator preferences",
+			preferences:   []string{"gpu-c", "gpu-a", "nonexistent-gpu"},
+			expectedCount: 2,
+			expectedNames: []string{"gpu-c", "gpu-a"},
+		},
+		{
+			name:            "accelerator preferences override keep accelerator",
+			keepAccelerator: true,
+			preferences:     []string{"gpu-b", "gpu-c"},
+			curAllocation:   &Allocation{accelerator: "gpu-b"},
+			expectedCount:   2,
+			expectedNames:   []string{"gpu-b", "gpu-c"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -446,6 +463,7 @@ func TestServer_GetCandidateAccelerators(t *testing.T) {
 				CurrentAlloc: config.AllocationData{
 					Load: config.ServerLoadSpec{},
 				},
+				AcceleratorPreferences: tt.preferences,
 			}
 			server := NewServerFromSpec(spec)
 			server.SetCurAllocation(tt.curAllocation)
@@ -465,9 +483,43 @@ func TestServer_GetCandidateAccelerators(t *testing.T) {
 	}
 }
 
+func TestServer_PreferenceRank(t *testing.T) {
+	server := NewServerFromSpec(&config.ServerSpec{
+		Name:                   "test-server",
+		Model:                  "test-model",
+		AcceleratorPreferences: []string{"gpu-a", "gpu-b"},
+	})
+	tests := []struct {
+		accName    string
+		rank       int
+		substitute bool
+	}{
+		{accName: "gpu-a", rank: 0, substitute: false},
+		{accName: "gpu-b", rank: 1, substitute: true},
+		{accName: "gpu-c", rank: 2, substitute: true},
+	}
+	for _, tt := range tests {
+		if got := server.PreferenceRank(tt.accName); got != tt.rank {
+			t.Errorf("PreferenceRank(%s) = %d, want %d", tt.accName, got, tt.rank)
+		}
+		if got := server.IsSubstitute(tt.accName); got != tt.substitute {
+			t.Errorf("IsSubstitute(%s) = %v, want %v", tt.accName, got, tt.substitute)
+		}
+	}
+
+	// no preferences: all accelerators rank equally
+	server = NewServerFromSpec(&config.ServerSpec{Name: "test-server", Model: "test-model"})
+	if got := server.PreferenceRank("gpu-c"); got != 0 {
+		t.Errorf("PreferenceRank() without preferences = %d, want 0", got)
+	}
+	if server.IsSubstitute("gpu-c") {
+		t.Error("IsSubstitute() without preferences = true, want false")
+	}
+}
+
 func TestServer_Calculate(t *testing.T) {
 	// Setup a complete test system with performance data
-	setupCompleteTestSystemForCalculate := func() {
+	setupCompleteTestSystemForCalculate := func() *System {
 		system := &System{
 			accelerators:     make(map[string]*Accelerator),
 			servers:          make(map[string]*Server),
@@ -506,12 +558,12 @@ func TestServer_Calculate(t *testing.T) {
 		serviceClass.targets["test-model"] = target
 		system.serviceClasses["default"] = serviceClass
 
-		TheSystem = system
+		return system
 	}
 
 	tests := []struct {
 		name             string
-		setupFunc        func()
+		setupFunc        func() *System
 		expectAllocs     bool
 		withCurrentAlloc bool
 	}{
@@ -528,9 +580,9 @@ func TestServer_Calculate(t *testing.T) {
 		},
 		{
 			name: "calculate with empty system",
-			setupFunc: func() {
+			setupFunc: func() *System {
 				// Set up minimal empty system
-				TheSystem = &System{
+				return &System{
 					accelerators:   make(map[string]*Accelerator),
 					servers:        make(map[string]*Server),
 					models:         make(map[string]*Model),
@@ -543,7 +595,7 @@ func TestServer_Calculate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setupFunc()
+			system := tt.setupFunc()
 
 			spec := &config.ServerSpec{
 				Name:  "test-server",
@@ -572,10 +624,8 @@ func TestServer_Calculate(t *testing.T) {
 				"test-gpu": NewAcceleratorFromSpec(&config.AcceleratorSpec{Name: "test-gpu", Cost: 100.0}),
 			}
 
-			// Add the server to the system if it exists
-			if TheSystem != nil {
-				TheSystem.servers["test-server"] = server
-			}
+			// Add the server to the system
+			system.addServer(server)
 
 			server.Calculate(accelerators)
 
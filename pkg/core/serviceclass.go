@@ -11,6 +11,8 @@ type ServiceClass struct {
 	name     string             // unique name
 	priority int                // non-negative priority (smaller values for higher priority)
 	targets  map[string]*Target // target SLOs for each model
+
+	violationPenalty float32 // penalty cost per unit of relative SLO violation (0 = default)
 }
 
 // target SLOs for service class
@@ -38,6 +40,7 @@ func NewServiceClass(name string, priority int) *ServiceClass {
 
 func NewServiceClassFromSpec(spec *config.ServiceClassSpec) *ServiceClass {
 	svc := NewServiceClass(spec.Name, spec.Priority)
+	svc.SetViolationPenalty(spec.ViolationPenalty)
 	for _, modelTarget := range spec.ModelTargets {
 		svc.AddModelTarget(&modelTarget)
 	}
@@ -52,6 +55,18 @@ func (c *ServiceClass) Priority() int {
 	return c.priority
 }
 
+// Penalty cost (cents/hr) per unit of relative SLO violation, used in soft SLO mode
+func (c *ServiceClass) ViolationPenalty() float64 {
+	if c.violationPenalty > 0 {
+		return CostFromSpec(c.violationPenalty)
+	}
+	return config.DefaultSLOViolationPenalty
+}
+
+func (c *ServiceClass) SetViolationPenalty(penalty float32) {
+	c.violationPenalty = max(penalty, 0)
+}
+
 func (c *ServiceClass) ModelTarget(modelName string) *Target {
 	return c.targets[modelName]
 }
@@ -99,6 +114,8 @@ func (c *ServiceClass) Spec() config.ServiceClassSpec {
 		Name:         c.name,
 		Priority:     c.priority,
 		ModelTargets: modelTargets,
+
+		ViolationPenalty: c.violationPenalty,
 	}
 }
 
@@ -7,43 +7,6 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 )
 
-var (
-	// a static reference to the singleton system object
-	TheSystem *System
-)
-
-func GetAccelerator(name string) *Accelerator {
-	return TheSystem.Accelerator(name)
-}
-
-func GetModel(name string) *Model {
-	return TheSystem.Model(name)
-}
-
-func GetServiceClass(name string) *ServiceClass {
-	return TheSystem.ServiceClass(name)
-}
-
-func GetServer(name string) *Server {
-	return TheSystem.Server(name)
-}
-
-func GetAccelerators() map[string]*Accelerator {
-	return TheSystem.accelerators
-}
-
-func GetModels() map[string]*Model {
-	return TheSystem.models
-}
-
-func GetServers() map[string]*Server {
-	return TheSystem.servers
-}
-
-func GetCapacities() map[string]int {
-	return TheSystem.capacity
-}
-
 // System comprising all accelerators, models, service classes, and servers
 type System struct {
 	accelerators   map[string]*Accelerator
@@ -54,6 +17,8 @@ type System struct {
 	capacity           map[string]int               // available count of accelerator types
 	allocationByType   map[string]*AllocationByType // number of allocated accelerator types
 	allocationSolution *config.AllocationSolution
+
+	softSLO bool // SLO violations are penalized rather than infeasible
 }
 
 // Allocation data about an accelerator type
@@ -61,7 +26,7 @@ type AllocationByType struct {
 	name  string  // name of accelerator type
 	count int     // total number of this type
 	limit int     // maximum number of this type
-	cost  float32 // total cost of this type
+	cost  float64 // total cost of this type
 }
 
 // Create a new system
@@ -85,9 +50,15 @@ func (s *System) SetFromSpec(d *config.SystemSpec) *config.OptimizerSpec {
 	s.SetServiceClassesFromSpec(&d.ServiceClasses)
 	s.SetServersFromSpec(&d.Servers)
 	s.SetCapacityFromSpec(&d.Capacity)
+	s.SetSoftSLO(d.Optimizer.Spec.SoftSLO)
 	return &d.Optimizer.Spec
 }
 
+// Set whether SLO violations incur penalty costs rather than making allocations infeasible
+func (s *System) SetSoftSLO(enabled bool) {
+	s.softSLO = enabled
+}
+
 // Set accelerators from spec
 func (s *System) SetAcceleratorsFromSpec(d *config.AcceleratorData) {
 	for _, v := range d.Spec {
@@ -152,13 +123,19 @@ func (s *System) RemoveModel(name string) error {
 // Set servers from spec
 func (s *System) SetServersFromSpec(d *config.ServerData) {
 	for _, v := range d.Spec {
-		s.servers[v.Name] = NewServerFromSpec(&v)
+		s.AddServerFromSpec(v)
 	}
 }
 
 // Add a server (replace if already exists)
 func (s *System) AddServerFromSpec(spec config.ServerSpec) {
-	s.servers[spec.Name] = NewServerFromSpec(&spec)
+	s.addServer(NewServerFromSpec(&spec))
+}
+
+// Add a server, making this system its owner
+func (s *System) addServer(server *Server) {
+	server.system = s
+	s.servers[server.name] = server
 }
 
 // Remove a server
@@ -231,6 +208,11 @@ func (s *System) Server(name string) *Server {
 	return s.servers[name]
 }
 
+// Whether SLO violations incur penalty costs rather than making allocations infeasible
+func (s *System) SoftSLO() bool {
+	return s.softSLO
+}
+
 // Get capacities of accelerator types
 func (s *System) Capacities() map[string]int {
 	return s.capacity
@@ -293,7 +275,7 @@ func (s *System) AllocateByType() {
 				cost:  0,
 			}
 		}
-		alloc.count += serverAlloc.numReplicas * model.numInstances[accName] * acc.Multiplicity()
+		alloc.count += serverAlloc.numReplicas * model.NumInstancesOf(serverAlloc) * acc.Multiplicity()
 		alloc.cost += serverAlloc.cost
 		s.allocationByType[nameType] = alloc
 	}
@@ -345,7 +327,7 @@ func (s *System) String() string {
 	// }
 
 	b.WriteString("Solution: \n")
-	totalCost := float32(0)
+	totalCost := float64(0)
 	for serverName, server := range s.Servers() {
 		srvClassName := server.ServiceClassName()
 		modelName := server.ModelName()
@@ -1185,7 +1185,6 @@ func TestSystem_RemoveCapacity(t *testing.T) {
 
 func TestSystem_Calculate(t *testing.T) {
 	system := NewSystem()
-	TheSystem = system
 
 	// Add accelerator
 	system.AddAcceleratorFromSpec(config.AcceleratorSpec{
@@ -1283,7 +1282,6 @@ func TestSystem_Calculate(t *testing.T) {
 
 func TestSystem_AllocateByType(t *testing.T) {
 	system := NewSystem()
-	TheSystem = system // Set global reference
 
 	// Add accelerator
 	system.AddAcceleratorFromSpec(config.AcceleratorSpec{
@@ -1354,7 +1352,7 @@ func TestSystem_AllocateByType(t *testing.T) {
 	// Get the server and create an allocation for it
 	server := system.Server("test-server")
 	if server != nil {
-		alloc := CreateAllocation("test-server", "A100")
+		alloc := system.CreateAllocation("test-server", "A100")
 		if alloc != nil {
 			server.SetAllocation(alloc)
 		}
@@ -1391,7 +1389,6 @@ func TestSystem_AllocateByType(t *testing.T) {
 
 func TestSystem_GenerateSolution(t *testing.T) {
 	system := NewSystem()
-	TheSystem = system // Set global reference
 
 	// Add accelerator
 	system.AddAcceleratorFromSpec(config.AcceleratorSpec{
@@ -1459,7 +1456,7 @@ func TestSystem_GenerateSolution(t *testing.T) {
 	// Get the server and create an allocation for it
 	server := system.Server("test-server")
 	if server != nil {
-		alloc := CreateAllocation("test-server", "A100")
+		alloc := system.CreateAllocation("test-server", "A100")
 		if alloc != nil {
 			server.SetAllocation(alloc)
 		}
@@ -1584,68 +1581,3 @@ func TestAllocationByType_String(t *testing.T) {
 		t.Error("String should contain cost")
 	}
 }
-
-// Test global functions
-func TestGetModels(t *testing.T) {
-	// Create a test system and set it as TheSystem
-	system := NewSystem()
-	system.AddModel("test-model")
-	TheSystem = system
-
-	models := GetModels()
-
-	if len(models) != 1 {
-		t.Errorf("Expected 1 model, got %d", len(models))
-	}
-
-	if models["test-model"] == nil {
-		t.Error("test-model should exist")
-	}
-}
-
-func TestGetServers(t *testing.T) {
-	// Create a test system and set it as TheSystem
-	system := NewSystem()
-	system.AddServerFromSpec(config.ServerSpec{
-		Name:  "test-server",
-		Model: "test-model",
-		Class: "default",
-		CurrentAlloc: config.AllocationData{
-			Load: config.ServerLoadSpec{
-				ArrivalRate:  30,
-				AvgInTokens:  100,
-				AvgOutTokens: 200,
-			},
-		},
-		MinNumReplicas: 1,
-		MaxBatchSize:   16,
-	})
-	TheSystem = system
-
-	servers := GetServers()
-
-	if len(servers) != 1 {
-		t.Errorf("Expected 1 server, got %d", len(servers))
-	}
-
-	if servers["test-server"] == nil {
-		t.Error("test-server should exist")
-	}
-}
-
-func TestGetCapacities(t *testing.T) {
-	// Create a test system and set it as TheSystem
-	system := NewSystem()
-	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_A100", Count: 4})
-	TheSystem = system
-
-	capacities := GetCapacities()
-
-	if len(capacities) != 1 {
-		t.Errorf("Expected 1 capacity entry, got %d", len(capacities))
-	}
-
-	if capacities["GPU_A100"] != 4 {
-		t.Errorf("Expected GPU_A100 capacity 4, got %d", capacities["GPU_A100"])
-	}
-}
@@ -0,0 +1,68 @@
+// Package errors defines the errors shared by the collector, solver, engines and actuator,
+// so that callers tell them apart with errors.Is and errors.As rather than by message.
+//
+// Errors are wrapped with their sentinel, e.g. fmt.Errorf("%w: %w", ErrMetricsQueryFailed, err),
+// keeping the underlying error in the chain.
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMetricsNotAvailable is the error of a variant or model without metrics to analyze,
+	// e.g. no replica is ready or reporting yet.
+	ErrMetricsNotAvailable = errors.New("metrics not available")
+
+	// ErrMetricsQueryFailed is the error of a metrics backend query that failed.
+	ErrMetricsQueryFailed = errors.New("metrics query failed")
+
+	// ErrNoFeasibleSolution is the error of a solution leaving servers without feasible
+	// allocation on any accelerator.
+	ErrNoFeasibleSolution = errors.New("no feasible solution")
+
+	// ErrTargetNotFound is the error of a scale target that does not exist.
+	ErrTargetNotFound = errors.New("scale target not found")
+
+	// ErrTargetAmbiguous is the error of a scale target selector matching several workloads.
+	ErrTargetAmbiguous = errors.New("scale target ambiguous")
+
+	// ErrInvalidConfiguration is the error of a configuration that cannot be used.
+	ErrInvalidConfiguration = errors.New("invalid configuration")
+)
+
+// sentinels are the errors Kind classifies errors into, in order of precedence.
+var sentinels = []error{
+	ErrTargetNotFound,
+	ErrTargetAmbiguous,
+	ErrInvalidConfiguration,
+	ErrMetricsQueryFailed,
+	ErrMetricsNotAvailable,
+	ErrNoFeasibleSolution,
+}
+
+// Kind returns the sentinel error that err matches, or nil if it matches none.
+func Kind(err error) error {
+	for _, sentinel := range sentinels {
+		if errors.Is(err, sentinel) {
+			return sentinel
+		}
+	}
+	return nil
+}
+
+// ModelError is the error of the analysis of a model, in a namespace.
+type ModelError struct {
+	ModelID   string
+	Namespace string
+	Err       error
+}
+
+func (e *ModelError) Error() string {
+	return fmt.Sprintf("model %s in namespace %s: %v", e.ModelID, e.Namespace, e.Err)
+}
+
+func (e *ModelError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	queryErr := errors.New("connection refused")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"unclassified", errors.New("boom"), nil},
+		{"sentinel", ErrTargetNotFound, ErrTargetNotFound},
+		{"wrapped", fmt.Errorf("collect: %w", fmt.Errorf("%w: %w", ErrMetricsQueryFailed, queryErr)), ErrMetricsQueryFailed},
+		{"model error", &ModelError{ModelID: "llama", Namespace: "prod", Err: ErrMetricsNotAvailable}, ErrMetricsNotAvailable},
+		{"precedence", fmt.Errorf("%w: %w", ErrMetricsNotAvailable, ErrTargetNotFound), ErrTargetNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Kind(tt.err); got != tt.want {
+				t.Errorf("Kind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelError(t *testing.T) {
+	queryErr := errors.New("connection refused")
+	err := fmt.Errorf("optimize: %w", &ModelError{
+		ModelID:   "llama",
+		Namespace: "prod",
+		Err:       fmt.Errorf("%w: %w", ErrMetricsQueryFailed, queryErr),
+	})
+
+	var modelErr *ModelError
+	if !errors.As(err, &modelErr) {
+		t.Fatalf("errors.As() = false, want a ModelError in %v", err)
+	}
+	if modelErr.ModelID != "llama" || modelErr.Namespace != "prod" {
+		t.Errorf("ModelError = %+v, want model llama in prod", modelErr)
+	}
+	if !errors.Is(err, queryErr) {
+		t.Errorf("errors.Is() = false, want the query error kept in %v", err)
+	}
+	if want := "optimize: model llama in namespace prod: metrics query failed: connection refused"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,199 @@
+// Package loadgen builds Kubernetes Jobs generating inference load against OpenAI-compatible
+// model servers, for e2e tests and benchmarking tools.
+//
+// Two generators are provided:
+//   - Job runs a guidellm benchmark at a given rate and arrival distribution, on synthetic,
+//     ShareGPT, or custom prompts (see PromptsConfigMap)
+//   - WorkerJob runs a curl worker sending requests in parallel batches, creating the
+//     queue spikes that trigger saturation-based scale-up
+//
+// Builders only return objects; creating them is left to the caller's client.
+package loadgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Default image of benchmark Jobs
+const DefaultGuideLLMImage = "ghcr.io/vllm-project/guidellm:latest"
+
+// Source of the prompts of a benchmark
+type Strategy string
+
+const (
+	// Synthetic prompts of InputTokens tokens, asking for OutputTokens tokens
+	StrategySynthetic Strategy = "synthetic"
+	// Prompts of the ShareGPT dataset, expected at ShareGPTDatasetPath in the image
+	StrategyShareGPT Strategy = "sharegpt"
+	// Custom prompts of the ConfigMap created by PromptsConfigMap
+	StrategyPrompts Strategy = "prompts"
+)
+
+// Distribution of request arrivals of a benchmark
+type Distribution string
+
+const (
+	// Requests sent at a constant rate
+	DistributionConstant Distribution = "constant"
+	// Requests sent as a Poisson process of the given rate
+	DistributionPoisson Distribution = "poisson"
+)
+
+const (
+	// Path of the ShareGPT dataset in the benchmark image
+	ShareGPTDatasetPath = "/datasets/ShareGPT_V3_unfiltered_cleaned_split.json"
+	// Path where the prompts ConfigMap is mounted
+	PromptsMountPath = "/prompts"
+	// Key of the prompts in the prompts ConfigMap
+	PromptsKey = "prompts.jsonl"
+
+	// duration added to the time needed to send all prompts at the given rate
+	durationBuffer = 10 * time.Second
+)
+
+// Configuration of a benchmark Job
+type Config struct {
+	TargetURL    string            // base URL of the model server (e.g., http://service:8000)
+	ModelID      string            // model of the requests
+	Strategy     Strategy          // source of the prompts
+	Rate         int               // requests per second
+	NumPrompts   int               // total number of requests
+	Distribution Distribution      // arrival distribution (default DistributionConstant)
+	Duration     time.Duration     // maximum duration (default: NumPrompts at Rate, plus 10s)
+	InputTokens  int               // average input tokens (StrategySynthetic)
+	OutputTokens int               // average output tokens (StrategySynthetic)
+	PromptsName  string            // name of the prompts ConfigMap (StrategyPrompts)
+	Image        string            // benchmark image (default DefaultGuideLLMImage)
+	Labels       map[string]string // labels of the Job and its pod
+}
+
+// Arguments of the guidellm benchmark command of a benchmark
+func Args(cfg Config) []string {
+	distribution := cfg.Distribution
+	if distribution == "" {
+		distribution = DistributionConstant
+	}
+	args := []string{
+		"benchmark",
+		"--target", cfg.TargetURL,
+		"--rate-type", string(distribution),
+		"--rate", strconv.Itoa(cfg.Rate),
+		"--model", cfg.ModelID,
+	}
+
+	// max-seconds should be enough to send all prompts at the given rate
+	duration := cfg.Duration
+	if duration == 0 && cfg.Rate > 0 {
+		duration = time.Duration(cfg.NumPrompts/cfg.Rate)*time.Second + durationBuffer
+	}
+	if duration > 0 {
+		args = append(args, "--max-seconds", strconv.Itoa(int(duration.Seconds())))
+	}
+
+	switch cfg.Strategy {
+	case StrategySynthetic:
+		// guidellm uses --data format: prompt_tokens=X,output_tokens=Y
+		args = append(args,
+			"--data", fmt.Sprintf("prompt_tokens=%d,output_tokens=%d", cfg.InputTokens, cfg.OutputTokens),
+		)
+	case StrategyShareGPT:
+		args = append(args,
+			"--dataset", "sharegpt",
+			"--dataset-path", ShareGPTDatasetPath,
+		)
+	case StrategyPrompts:
+		args = append(args, "--data", PromptsMountPath+"/"+PromptsKey)
+	}
+
+	// output path is optional but useful for debugging
+	args = append(args, "--output-path", "/tmp/benchmarks.json")
+	return args
+}
+
+// Job running a guidellm benchmark against a model server
+func Job(name, namespace string, cfg Config) *batchv1.Job {
+	image := cfg.Image
+	if image == "" {
+		image = DefaultGuideLLMImage
+	}
+
+	container := corev1.Container{
+		Name:            "load-gen",
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent, // use cached image for faster subsequent runs
+		Command:         []string{"guidellm"},
+		Args:            Args(cfg),
+		Env: []corev1.EnvVar{
+			{Name: "HF_HOME", Value: "/tmp"},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	podSpec := corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever}
+	if cfg.Strategy == StrategyPrompts {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "prompts", MountPath: PromptsMountPath, ReadOnly: true}}
+		podSpec.Volumes = []corev1.Volume{{
+			Name: "prompts",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cfg.PromptsName},
+				},
+			},
+		}}
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	return newJob(name, namespace, maps.Clone(cfg.Labels), podSpec)
+}
+
+// ConfigMap of custom prompts of benchmarks with StrategyPrompts, one JSON object per line
+func PromptsConfigMap(name, namespace string, prompts []string) (*corev1.ConfigMap, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	for _, prompt := range prompts {
+		if err := encoder.Encode(map[string]string{"prompt": prompt}); err != nil {
+			return nil, fmt.Errorf("failed to encode prompt: %w", err)
+		}
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{PromptsKey: b.String()},
+	}, nil
+}
+
+// Job running a pod spec once, with the labels on the Job and its pod
+func newJob(name, namespace string, labels map[string]string, podSpec corev1.PodSpec) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: maps.Clone(labels)},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Value of an argument of a command line, empty if absent
+func argValue(args []string, name string) string {
+	i := slices.Index(args, name)
+	if i < 0 || i+1 >= len(args) {
+		return ""
+	}
+	return args[i+1]
+}
+
+// Value of an environment variable of a container, empty if absent
+func envValue(container corev1.Container, name string) string {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want map[string]string
+	}{
+		{
+			name: "synthetic with default distribution and duration",
+			cfg: Config{TargetURL: "http://svc:8000", ModelID: "m", Strategy: StrategySynthetic,
+				Rate: 8, NumPrompts: 400, InputTokens: 128, OutputTokens: 64},
+			want: map[string]string{
+				"--target":      "http://svc:8000",
+				"--rate-type":   "constant",
+				"--rate":        "8",
+				"--max-seconds": "60",
+				"--data":        "prompt_tokens=128,output_tokens=64",
+			},
+		},
+		{
+			name: "sharegpt with poisson arrivals and explicit duration",
+			cfg: Config{ModelID: "m", Strategy: StrategyShareGPT, Rate: 5, NumPrompts: 100,
+				Distribution: DistributionPoisson, Duration: 5 * time.Minute},
+			want: map[string]string{
+				"--rate-type":    "poisson",
+				"--max-seconds":  "300",
+				"--dataset-path": ShareGPTDatasetPath,
+			},
+		},
+		{
+			name: "custom prompts",
+			cfg:  Config{ModelID: "m", Strategy: StrategyPrompts, Rate: 1, NumPrompts: 10},
+			want: map[string]string{
+				"--data":        PromptsMountPath + "/" + PromptsKey,
+				"--max-seconds": "20",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := Args(tt.cfg)
+			if args[0] != "benchmark" {
+				t.Errorf("first argument = %q, want benchmark", args[0])
+			}
+			for name, want := range tt.want {
+				if got := argValue(args, name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestArgs_ZeroRate(t *testing.T) {
+	args := Args(Config{ModelID: "m", NumPrompts: 100})
+	if slices.Contains(args, "--max-seconds") {
+		t.Errorf("unexpected --max-seconds without rate or duration: %v", args)
+	}
+}
+
+func TestJob_MountsPrompts(t *testing.T) {
+	labels := map[string]string{"app": "bench"}
+	job := Job("bench", "ns", Config{ModelID: "m", Strategy: StrategyPrompts, Rate: 1,
+		PromptsName: "bench-prompts", Labels: labels})
+
+	if job.Name != "bench" || job.Namespace != "ns" {
+		t.Errorf("job = %s/%s, want ns/bench", job.Namespace, job.Name)
+	}
+	if job.Spec.Template.Labels["app"] != "bench" {
+		t.Errorf("pod labels = %v, want app=bench", job.Spec.Template.Labels)
+	}
+	pod := job.Spec.Template.Spec
+	if pod.Containers[0].Image != DefaultGuideLLMImage {
+		t.Errorf("image = %q, want %q", pod.Containers[0].Image, DefaultGuideLLMImage)
+	}
+	if len(pod.Volumes) != 1 || pod.Volumes[0].ConfigMap.Name != "bench-prompts" {
+		t.Fatalf("volumes = %v, want the bench-prompts ConfigMap", pod.Volumes)
+	}
+	if mounts := pod.Containers[0].VolumeMounts; len(mounts) != 1 || mounts[0].MountPath != PromptsMountPath {
+		t.Errorf("volume mounts = %v, want %s", mounts, PromptsMountPath)
+	}
+
+	// the caller's labels are not modified through the Job
+	job.Labels["extra"] = "true"
+	if _, ok := labels["extra"]; ok {
+		t.Error("Job labels share the caller's map")
+	}
+}
+
+func TestPromptsConfigMap(t *testing.T) {
+	cm, err := PromptsConfigMap("prompts", "ns", []string{"hello", `say "hi"`})
+	if err != nil {
+		t.Fatalf("PromptsConfigMap() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(cm.Data[PromptsKey]), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var line map[string]string
+	if err := json.Unmarshal([]byte(lines[1]), &line); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", lines[1], err)
+	}
+	if line["prompt"] != `say "hi"` {
+		t.Errorf("prompt = %q, want %q", line["prompt"], `say "hi"`)
+	}
+}
+
+func TestWorkerJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetURL  string
+		wantHealth string
+		wantField  string
+	}{
+		{
+			name:       "chat completions",
+			targetURL:  "http://svc:8000/v1/chat/completions",
+			wantHealth: "http://svc:8000/v1/models",
+			wantField:  "messages",
+		},
+		{
+			name:       "completions",
+			targetURL:  "http://gateway:80/v1/completions",
+			wantHealth: "http://gateway:80/v1/models",
+			wantField:  "prompt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := WorkerJob(WorkerJobName("load", 2), "ns", 2, WorkerConfig{
+				TargetURL:   tt.targetURL,
+				ModelID:     "m",
+				NumRequests: 100,
+				MaxTokens:   50,
+				Labels:      map[string]string{"experiment": "load"},
+			})
+
+			if job.Name != "load-2" {
+				t.Errorf("name = %q, want load-2", job.Name)
+			}
+			if job.Labels[WorkerLabel] != "2" || job.Labels["experiment"] != "load" {
+				t.Errorf("labels = %v, want worker=2 and experiment=load", job.Labels)
+			}
+			container := job.Spec.Template.Spec.Containers[0]
+			want := map[string]string{
+				"TOTAL_REQUESTS": "100",
+				"BATCH_SIZE":     "10",
+				"BATCH_SLEEP":    "0.5",
+				"CURL_TIMEOUT":   "180",
+				"HEALTH_URL":     tt.wantHealth,
+			}
+			for name, value := range want {
+				if got := envValue(container, name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal([]byte(envValue(container, "REQUEST_BODY")), &body); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if _, ok := body[tt.wantField]; !ok {
+				t.Errorf("request body %v has no %s", body, tt.wantField)
+			}
+			if body["max_tokens"] != float64(50) {
+				t.Errorf("max_tokens = %v, want 50", body["max_tokens"])
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package loadgen
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+//go:embed scripts/worker.sh
+var workerScript string
+
+const (
+	// Default image of worker Jobs
+	DefaultCurlImage = "quay.io/curl/curl:8.11.1"
+	// Default prompt of worker requests
+	DefaultPrompt = "Write a detailed explanation of machine learning algorithms."
+
+	// Label of worker Jobs holding the worker ID
+	WorkerLabel = "worker"
+
+	defaultBatchSize      = 10
+	defaultBatchSleep     = 500 * time.Millisecond
+	defaultRequestTimeout = 180 * time.Second
+)
+
+// Configuration of a worker Job
+type WorkerConfig struct {
+	TargetURL      string            // endpoint of the requests (e.g., http://service:8000/v1/completions)
+	HealthURL      string            // URL answering 200 once the server is ready (default: /v1/models of TargetURL)
+	ModelID        string            // model of the requests
+	NumRequests    int               // total number of requests
+	MaxTokens      int               // maximum output tokens per request
+	Prompt         string            // prompt of the requests (default DefaultPrompt)
+	BatchSize      int               // requests sent in parallel per batch (default 10)
+	BatchSleep     time.Duration     // sleep between batches (default 500ms)
+	RequestTimeout time.Duration     // timeout of each request (default 180s)
+	Image          string            // curl image (default DefaultCurlImage)
+	Labels         map[string]string // labels of the Job and its pod, in addition to WorkerLabel
+}
+
+// Job running a curl worker sending requests in parallel batches, with a sleep between
+// batches; requests use the chat completions body if TargetURL ends with /chat/completions,
+// the completions body otherwise
+func WorkerJob(name, namespace string, workerID int, cfg WorkerConfig) *batchv1.Job {
+	image := cfg.Image
+	if image == "" {
+		image = DefaultCurlImage
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	batchSleep := cfg.BatchSleep
+	if batchSleep <= 0 {
+		batchSleep = defaultBatchSleep
+	}
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+	healthURL := cfg.HealthURL
+	if healthURL == "" {
+		healthURL = modelsURL(cfg.TargetURL)
+	}
+
+	labels := maps.Clone(cfg.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[WorkerLabel] = strconv.Itoa(workerID)
+
+	container := corev1.Container{
+		Name:    "load-generator",
+		Image:   image,
+		Command: []string{"/bin/sh", "-c"},
+		Args:    []string{workerScript},
+		Env: []corev1.EnvVar{
+			{Name: "WORKER_ID", Value: strconv.Itoa(workerID)},
+			{Name: "TOTAL_REQUESTS", Value: strconv.Itoa(cfg.NumRequests)},
+			{Name: "BATCH_SIZE", Value: strconv.Itoa(batchSize)},
+			{Name: "CURL_TIMEOUT", Value: strconv.Itoa(int(requestTimeout.Seconds()))},
+			{Name: "BATCH_SLEEP", Value: strconv.FormatFloat(batchSleep.Seconds(), 'f', -1, 64)},
+			{Name: "TARGET_URL", Value: cfg.TargetURL},
+			{Name: "HEALTH_URL", Value: healthURL},
+			{Name: "REQUEST_BODY", Value: requestBody(cfg)},
+			{Name: "MAX_RETRIES", Value: "24"},
+			{Name: "RETRY_DELAY", Value: "5"},
+		},
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+				corev1.ResourceCPU:    resource.MustParse("2"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	return newJob(name, namespace, labels, corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers:    []corev1.Container{container},
+	})
+}
+
+// Name of the worker Job of the given ID among parallel workers
+func WorkerJobName(baseName string, workerID int) string {
+	return fmt.Sprintf("%s-%d", baseName, workerID)
+}
+
+// JSON body of the requests of a worker
+func requestBody(cfg WorkerConfig) string {
+	prompt := cfg.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+	body := map[string]any{
+		"model":      cfg.ModelID,
+		"max_tokens": cfg.MaxTokens,
+	}
+	if strings.HasSuffix(cfg.TargetURL, "/chat/completions") {
+		body["messages"] = []map[string]string{{"role": "user", "content": prompt}}
+	} else {
+		body["prompt"] = prompt
+	}
+	// marshaling strings and ints cannot fail
+	b, _ := json.Marshal(body)
+	return string(b)
+}
+
+// URL of the models endpoint of the server of an OpenAI-compatible endpoint
+func modelsURL(targetURL string) string {
+	if i := strings.Index(targetURL, "/v1/"); i >= 0 {
+		return targetURL[:i] + "/v1/models"
+	}
+	return strings.TrimSuffix(targetURL, "/") + "/v1/models"
+}
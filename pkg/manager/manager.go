@@ -1,6 +1,8 @@
 package manager
 
 import (
+	"errors"
+
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/solver"
 )
@@ -11,17 +13,19 @@ type Manager struct {
 }
 
 func NewManager(system *core.System, optimizer *solver.Optimizer) *Manager {
-	core.TheSystem = system
 	return &Manager{
 		system:    system,
 		optimizer: optimizer,
 	}
 }
 
+// Optimize the system; a solution leaving servers without feasible allocation is still
+// applied, and its NoFeasibleSolutionError returned
 func (m *Manager) Optimize() error {
-	if err := m.optimizer.Optimize(); err != nil {
+	err := m.optimizer.Optimize()
+	if err != nil && !errors.Is(err, solver.ErrNoFeasibleSolution) {
 		return err
 	}
 	m.system.AllocateByType()
-	return nil
+	return err
 }
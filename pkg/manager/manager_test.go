@@ -49,10 +49,6 @@ func TestNewManager(t *testing.T) {
 				if got.optimizer != tt.optimizer {
 					t.Errorf("NewManager().optimizer = %v, want %v", got.optimizer, tt.optimizer)
 				}
-				// Verify that core.TheSystem is set
-				if core.TheSystem != tt.system {
-					t.Errorf("core.TheSystem = %v, want %v", core.TheSystem, tt.system)
-				}
 			}
 		})
 	}
@@ -142,7 +138,7 @@ func TestManager_Optimize(t *testing.T) {
 					Unlimited:        false,
 					SaturationPolicy: "None",
 				}
-				optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+				optimizer := solver.NewOptimizerFromSpec(system, optimizerSpec)
 				manager := NewManager(system, optimizer)
 				system.Calculate()
 
@@ -197,7 +193,7 @@ func TestManager_Optimize(t *testing.T) {
 					Unlimited:        true,
 					SaturationPolicy: "PriorityExhaustive",
 				}
-				optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+				optimizer := solver.NewOptimizerFromSpec(system, optimizerSpec)
 				manager := NewManager(system, optimizer)
 				system.Calculate()
 
@@ -237,7 +233,7 @@ func TestManager_Optimize(t *testing.T) {
 					Unlimited:        false,
 					SaturationPolicy: "None",
 				}
-				optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+				optimizer := solver.NewOptimizerFromSpec(system, optimizerSpec)
 				return NewManager(system, optimizer)
 			},
 			wantErr: false,
@@ -292,7 +288,7 @@ func TestManager_OptimizeIntegration(t *testing.T) {
 					Unlimited:        false,
 					SaturationPolicy: "None",
 				}
-				optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+				optimizer := solver.NewOptimizerFromSpec(system, optimizerSpec)
 
 				return system, optimizer
 			},
@@ -307,7 +303,7 @@ func TestManager_OptimizeIntegration(t *testing.T) {
 					Unlimited:        true,
 					SaturationPolicy: "PriorityExhaustive",
 				}
-				optimizer := solver.NewOptimizerFromSpec(optimizerSpec)
+				optimizer := solver.NewOptimizerFromSpec(system, optimizerSpec)
 
 				return system, optimizer
 			},
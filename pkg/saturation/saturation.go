@@ -0,0 +1,216 @@
+// Package saturation is the library API of the percentage-based saturation analysis of
+// the autoscaler, so that other llm-d components, such as schedulers and simulators, can
+// reuse its exact algorithms.
+//
+// AnalyzeModelSaturation finds whether the replicas of the variants of a model have
+// enough spare KV cache and queue capacity, and CalculateTargets turns the analysis into
+// target replicas per variant, scaling up the cheapest variant and down the most
+// expensive one. The types of this package are stable; they are converted to and from
+// those of the controller, whose analyzer does the work.
+package saturation
+
+import (
+	"context"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+)
+
+// Config holds the saturation thresholds of a model.
+type Config struct {
+	// KvCacheThreshold: a replica is saturated when its KV cache utilization is at
+	// least this fraction (0.0-1.0)
+	KvCacheThreshold float64
+	// QueueLengthThreshold: a replica is saturated when its queue length is at least this
+	QueueLengthThreshold float64
+	// KvSpareTrigger: scale up when the average spare KV cache capacity of the
+	// non-saturated replicas is below this fraction (0.0-1.0)
+	KvSpareTrigger float64
+	// QueueSpareTrigger: scale up when the average spare queue capacity of the
+	// non-saturated replicas is below this
+	QueueSpareTrigger float64
+}
+
+// DefaultConfig returns the default thresholds of the controller.
+func DefaultConfig() Config {
+	return Config{
+		KvCacheThreshold:     0.80,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.10,
+		QueueSpareTrigger:    3,
+	}
+}
+
+// Validate checks that the thresholds are in range and consistent.
+func (c Config) Validate() error {
+	internal := c.internal()
+	return internal.Validate()
+}
+
+func (c Config) internal() interfaces.SaturationScalingConfig {
+	return interfaces.SaturationScalingConfig{
+		KvCacheThreshold:     c.KvCacheThreshold,
+		QueueLengthThreshold: c.QueueLengthThreshold,
+		KvSpareTrigger:       c.KvSpareTrigger,
+		QueueSpareTrigger:    c.QueueSpareTrigger,
+	}
+}
+
+// ReplicaMetrics holds the load of a replica of a variant.
+type ReplicaMetrics struct {
+	PodName         string
+	VariantName     string
+	AcceleratorName string
+	// Cost is the cost per replica of the variant
+	Cost float64
+	// KvCacheUsage is the KV cache utilization (0.0-1.0)
+	KvCacheUsage float64
+	// QueueLength is the number of requests waiting
+	QueueLength int
+	// GPUFailed indicates a GPU of the replica failed, so that it has no capacity
+	GPUFailed bool
+	// Unavailable indicates the metrics are too old to be the current load of the
+	// replica, as when it stopped reporting; they are left out while others are fresher
+	Unavailable bool
+}
+
+// ModelAnalysis is the saturation analysis of a model across its variants.
+type ModelAnalysis struct {
+	ModelID    string
+	Namespace  string
+	AnalyzedAt time.Time
+
+	// TotalReplicas is the number of replicas analyzed, and NonSaturatedCount those
+	// below the saturation thresholds
+	TotalReplicas     int
+	NonSaturatedCount int
+	// AvgSpareKvCapacity and AvgSpareQueueLength are the average spare capacities of
+	// the non-saturated replicas
+	AvgSpareKvCapacity  float64
+	AvgSpareQueueLength float64
+
+	// ShouldScaleUp is whether the spare capacity is below a trigger, for ScaleUpReason
+	ShouldScaleUp bool
+	ScaleUpReason string
+	// ScaleDownSafe is whether removing a replica keeps the spare capacity above the
+	// triggers
+	ScaleDownSafe bool
+
+	Variants []VariantAnalysis
+}
+
+// VariantAnalysis is the saturation analysis of a variant.
+type VariantAnalysis struct {
+	VariantName         string
+	AcceleratorName     string
+	Cost                float64
+	ReplicaCount        int
+	NonSaturatedCount   int
+	MaxKvCacheUsage     float64
+	MaxQueueLength      int
+	AvgSpareKvCapacity  float64
+	AvgSpareQueueLength float64
+	// SaturatedReplicas are the pod names of the saturated replicas
+	SaturatedReplicas []string
+	// FailedReplicas is the number of replicas on failing GPUs
+	FailedReplicas int
+}
+
+// VariantState is the current state of the replicas of a variant.
+type VariantState struct {
+	VariantName     string
+	CurrentReplicas int
+	// DesiredReplicas is the target of the previous decision, 0 if none
+	DesiredReplicas int
+	// PendingReplicas are replicas not ready yet; variants with some are not scaled up
+	PendingReplicas int
+}
+
+// AnalyzeModelSaturation analyzes the saturation of the replicas of all variants of a
+// model with the thresholds of config.
+func AnalyzeModelSaturation(ctx context.Context, modelID, namespace string, metrics []ReplicaMetrics, config Config) (*ModelAnalysis, error) {
+	replicaMetrics := make([]interfaces.ReplicaMetrics, 0, len(metrics))
+	for _, m := range metrics {
+		rm := interfaces.ReplicaMetrics{
+			PodName:         m.PodName,
+			KvCacheUsage:    m.KvCacheUsage,
+			QueueLength:     m.QueueLength,
+			VariantName:     m.VariantName,
+			Namespace:       namespace,
+			ModelID:         modelID,
+			AcceleratorName: m.AcceleratorName,
+			Cost:            m.Cost,
+			GPUFailed:       m.GPUFailed,
+		}
+		if m.Unavailable {
+			rm.Metadata = &interfaces.ReplicaMetricsMetadata{FreshnessStatus: interfaces.FreshnessUnavailable}
+		}
+		replicaMetrics = append(replicaMetrics, rm)
+	}
+
+	analysis, err := saturation.NewAnalyzer().AnalyzeModelSaturation(ctx, modelID, namespace, replicaMetrics, config.internal())
+	if err != nil {
+		return nil, err
+	}
+	return fromInternal(analysis), nil
+}
+
+// CalculateTargets returns the target replicas of each variant of an analysis. Targets
+// stay at the current or desired replicas while any variant of the model is scaling or
+// has replicas not reporting metrics.
+func CalculateTargets(ctx context.Context, analysis *ModelAnalysis, states []VariantState) map[string]int {
+	variantStates := make([]interfaces.VariantReplicaState, 0, len(states))
+	for _, s := range states {
+		variantStates = append(variantStates, interfaces.VariantReplicaState{
+			VariantName:     s.VariantName,
+			CurrentReplicas: s.CurrentReplicas,
+			DesiredReplicas: s.DesiredReplicas,
+			PendingReplicas: s.PendingReplicas,
+		})
+	}
+	return saturation.NewAnalyzer().CalculateSaturationTargets(ctx, toInternal(analysis), variantStates)
+}
+
+func fromInternal(a *interfaces.ModelSaturationAnalysis) *ModelAnalysis {
+	analysis := &ModelAnalysis{
+		ModelID:             a.ModelID,
+		Namespace:           a.Namespace,
+		AnalyzedAt:          a.AnalyzedAt,
+		TotalReplicas:       a.TotalReplicas,
+		NonSaturatedCount:   a.NonSaturatedCount,
+		AvgSpareKvCapacity:  a.AvgSpareKvCapacity,
+		AvgSpareQueueLength: a.AvgSpareQueueLength,
+		ShouldScaleUp:       a.ShouldScaleUp,
+		ScaleUpReason:       a.ScaleUpReason,
+		ScaleDownSafe:       a.ScaleDownSafe,
+		Variants:            make([]VariantAnalysis, 0, len(a.VariantAnalyses)),
+	}
+	for _, va := range a.VariantAnalyses {
+		analysis.Variants = append(analysis.Variants, VariantAnalysis(va))
+	}
+	return analysis
+}
+
+func toInternal(a *ModelAnalysis) *interfaces.ModelSaturationAnalysis {
+	if a == nil {
+		return nil
+	}
+	analysis := &interfaces.ModelSaturationAnalysis{
+		ModelID:             a.ModelID,
+		Namespace:           a.Namespace,
+		AnalyzedAt:          a.AnalyzedAt,
+		TotalReplicas:       a.TotalReplicas,
+		NonSaturatedCount:   a.NonSaturatedCount,
+		AvgSpareKvCapacity:  a.AvgSpareKvCapacity,
+		AvgSpareQueueLength: a.AvgSpareQueueLength,
+		ShouldScaleUp:       a.ShouldScaleUp,
+		ScaleUpReason:       a.ScaleUpReason,
+		ScaleDownSafe:       a.ScaleDownSafe,
+		VariantAnalyses:     make([]interfaces.VariantSaturationAnalysis, 0, len(a.Variants)),
+	}
+	for _, va := range a.Variants {
+		analysis.VariantAnalyses = append(analysis.VariantAnalyses, interfaces.VariantSaturationAnalysis(va))
+	}
+	return analysis
+}
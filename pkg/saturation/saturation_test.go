@@ -0,0 +1,70 @@
+package saturation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyzeModelSaturation_ScalesUpCheapestVariant(t *testing.T) {
+	ctx := context.Background()
+	metrics := []ReplicaMetrics{
+		{PodName: "cheap-0", VariantName: "cheap", AcceleratorName: "L4", Cost: 10, KvCacheUsage: 0.75, QueueLength: 1},
+		{PodName: "cheap-1", VariantName: "cheap", AcceleratorName: "L4", Cost: 10, KvCacheUsage: 0.78, QueueLength: 2},
+		{PodName: "costly-0", VariantName: "costly", AcceleratorName: "H100", Cost: 40, KvCacheUsage: 0.76, QueueLength: 1},
+	}
+
+	analysis, err := AnalyzeModelSaturation(ctx, "llama", "ns", metrics, DefaultConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !analysis.ShouldScaleUp {
+		t.Fatalf("expected scale-up with %.3f spare KV capacity", analysis.AvgSpareKvCapacity)
+	}
+	if analysis.TotalReplicas != 3 || len(analysis.Variants) != 2 {
+		t.Fatalf("got %d replicas in %d variants, want 3 in 2", analysis.TotalReplicas, len(analysis.Variants))
+	}
+
+	targets := CalculateTargets(ctx, analysis, []VariantState{
+		{VariantName: "cheap", CurrentReplicas: 2},
+		{VariantName: "costly", CurrentReplicas: 1},
+	})
+	if targets["cheap"] != 3 || targets["costly"] != 1 {
+		t.Errorf("targets = %v, want cheap=3 costly=1", targets)
+	}
+}
+
+func TestAnalyzeModelSaturation_DiscardsUnavailableReplicas(t *testing.T) {
+	metrics := []ReplicaMetrics{
+		{PodName: "v-0", VariantName: "v", Cost: 10, KvCacheUsage: 0.2},
+		{PodName: "v-1", VariantName: "v", Cost: 10, KvCacheUsage: 0.95, QueueLength: 10, Unavailable: true},
+	}
+
+	analysis, err := AnalyzeModelSaturation(context.Background(), "llama", "ns", metrics, DefaultConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if analysis.TotalReplicas != 1 {
+		t.Errorf("TotalReplicas = %d, want 1", analysis.TotalReplicas)
+	}
+	if analysis.ShouldScaleUp {
+		t.Error("expected no scale-up from the load of an unavailable replica")
+	}
+}
+
+func TestCalculateTargets_NilAnalysis(t *testing.T) {
+	targets := CalculateTargets(context.Background(), nil, []VariantState{{VariantName: "v", CurrentReplicas: 4}})
+	if targets["v"] != 4 {
+		t.Errorf("target = %d, want the current 4 replicas", targets["v"])
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("default config is invalid: %v", err)
+	}
+	config := DefaultConfig()
+	config.KvSpareTrigger = 0.9
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for a spare trigger above the KV cache threshold")
+	}
+}
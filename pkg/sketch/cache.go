@@ -0,0 +1,47 @@
+package sketch
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache keeps the last sketches of keys, such as the token length sketches of pods,
+// for a retention period. Safe for concurrent use.
+type Cache struct {
+	mu        sync.Mutex
+	retention time.Duration
+	entries   map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	sketch    *TDigest
+	updatedAt time.Time
+}
+
+// NewCache creates a Cache keeping sketches for the given retention period.
+func NewCache(retention time.Duration) *Cache {
+	return &Cache{retention: retention, entries: make(map[string]cacheEntry)}
+}
+
+// Set stores the sketch of a key, and drops the sketches not updated within the retention period.
+func (c *Cache) Set(key string, sketch *TDigest, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if now.Sub(e.updatedAt) > c.retention {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{sketch: sketch, updatedAt: now}
+}
+
+// Get returns the sketch of a key, if it was updated within the retention period.
+func (c *Cache) Get(key string, now time.Time) (*TDigest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || now.Sub(e.updatedAt) > c.retention {
+		return nil, false
+	}
+	return e.sketch, true
+}
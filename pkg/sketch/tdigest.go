@@ -0,0 +1,205 @@
+// Package sketch provides t-digest sketches of value distributions, used to track the
+// distribution of request token lengths rather than just their average.
+//
+// A t-digest summarizes a distribution with a bounded number of weighted centroids,
+// kept small near the median and fine-grained in the tails, so tail quantiles such as
+// the p90 stay accurate. Sketches of different replicas can be merged into the sketch
+// of a model.
+package sketch
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCompression is the compression of the sketches: the number of centroids is
+// bounded by the compression.
+const DefaultCompression = 100
+
+// Centroid is a weighted mean of nearby values of a sketch.
+type Centroid struct {
+	Mean   float64
+	Weight float64
+}
+
+// Bucket is a cumulative histogram bucket: Count values are less than or equal to UpperBound.
+type Bucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// TDigest is a t-digest sketch. Not safe for concurrent use.
+type TDigest struct {
+	compression float64
+	// centroids are compressed and sorted by mean
+	centroids []Centroid
+	// unmerged are added since the last compression
+	unmerged []Centroid
+	count    float64
+	min      float64
+	max      float64
+}
+
+// New creates an empty TDigest with the given compression, or DefaultCompression if not positive.
+func New(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = DefaultCompression
+	}
+	return &TDigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// FromHistogram creates a TDigest from cumulative histogram buckets, such as the rates
+// of the buckets of a Prometheus histogram by le. As histogram_quantile does, values are
+// assumed uniformly spread within a bucket, from the upper bound of the previous bucket
+// (or 0 for the first) to its own. Values of the +Inf bucket are placed at the highest
+// finite bound. The returned TDigest is compressed, so reading its quantiles does not
+// modify it. Returns an empty TDigest when the buckets hold no values.
+func FromHistogram(buckets []Bucket, compression float64) *TDigest {
+	// spread is the number of points each bucket is spread over
+	const spread = 4
+
+	t := New(compression)
+	sorted := make([]Bucket, 0, len(buckets))
+	for _, b := range buckets {
+		if !math.IsNaN(b.UpperBound) && !math.IsNaN(b.Count) && !math.IsInf(b.Count, 0) {
+			sorted = append(sorted, b)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UpperBound < sorted[j].UpperBound })
+
+	var lower, cumulative float64
+	for _, b := range sorted {
+		weight := b.Count - cumulative
+		if weight <= 0 {
+			if !math.IsInf(b.UpperBound, 1) {
+				lower = b.UpperBound
+			}
+			continue
+		}
+		cumulative = b.Count
+		if math.IsInf(b.UpperBound, 1) {
+			t.Add(lower, weight)
+			continue
+		}
+		for i := range spread {
+			t.Add(lower+(b.UpperBound-lower)*(float64(i)+0.5)/spread, weight/spread)
+		}
+		lower = b.UpperBound
+	}
+	t.compress()
+	return t
+}
+
+// Add adds a value with the given weight. Values with a non-positive weight are ignored.
+func (t *TDigest) Add(x, weight float64) {
+	if weight <= 0 || math.IsNaN(x) || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return
+	}
+	t.unmerged = append(t.unmerged, Centroid{Mean: x, Weight: weight})
+	t.count += weight
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+	if float64(len(t.unmerged)) > 4*t.compression {
+		t.compress()
+	}
+}
+
+// Merge adds the values of another sketch.
+func (t *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	t.unmerged = append(t.unmerged, other.centroids...)
+	t.unmerged = append(t.unmerged, other.unmerged...)
+	t.count += other.count
+	t.min = math.Min(t.min, other.min)
+	t.max = math.Max(t.max, other.max)
+	t.compress()
+}
+
+// Count returns the total weight of the values of the sketch.
+func (t *TDigest) Count() float64 {
+	return t.count
+}
+
+// Centroids returns the centroids of the sketch, sorted by mean.
+func (t *TDigest) Centroids() []Centroid {
+	t.compress()
+	return append([]Centroid(nil), t.centroids...)
+}
+
+// Quantile returns the estimated value at quantile q (0.0-1.0), or 0 if the sketch is empty.
+func (t *TDigest) Quantile(q float64) float64 {
+	t.compress()
+	if t.count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return t.min
+	}
+	if q >= 1 {
+		return t.max
+	}
+
+	// interpolate between the centers of the centroids, and between the extremes
+	// and the centers of the first and last centroids
+	target := q * t.count
+	prevPos, prevMean := 0.0, t.min
+	var cumulative float64
+	for _, c := range t.centroids {
+		pos := cumulative + c.Weight/2
+		if target < pos {
+			return interpolate(prevPos, prevMean, pos, c.Mean, target)
+		}
+		prevPos, prevMean = pos, c.Mean
+		cumulative += c.Weight
+	}
+	return interpolate(prevPos, prevMean, t.count, t.max, target)
+}
+
+// interpolate returns the value at position x on the line from (x0, y0) to (x1, y1).
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 <= x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+// compress merges the unmerged centroids into the centroids. Neighbouring centroids are
+// merged while they span at most one unit of the scale function k(q) = δ/2π·asin(2q-1),
+// which keeps the centroids near the extremes small and bounds their number by δ.
+func (t *TDigest) compress() {
+	if len(t.unmerged) == 0 {
+		return
+	}
+	all := append(t.centroids, t.unmerged...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Mean < all[j].Mean })
+
+	merged := make([]Centroid, 0, len(all))
+	current := all[0]
+	var cumulative float64
+	qLimit := t.quantileLimit(0)
+	for _, c := range all[1:] {
+		if (cumulative+current.Weight+c.Weight)/t.count <= qLimit {
+			weight := current.Weight + c.Weight
+			current.Mean += (c.Mean - current.Mean) * c.Weight / weight
+			current.Weight = weight
+			continue
+		}
+		merged = append(merged, current)
+		cumulative += current.Weight
+		qLimit = t.quantileLimit(cumulative / t.count)
+		current = c
+	}
+	t.centroids = append(merged, current)
+	t.unmerged = nil
+}
+
+// quantileLimit returns the quantile one unit of the scale function above quantile q.
+func (t *TDigest) quantileLimit(q float64) float64 {
+	k := t.compression/(2*math.Pi)*math.Asin(2*q-1) + 1
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
@@ -0,0 +1,118 @@
+package sketch
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestQuantileUniform(t *testing.T) {
+	td := New(DefaultCompression)
+	for i := 1; i <= 10000; i++ {
+		td.Add(float64(i), 1)
+	}
+
+	if got := td.Count(); got != 10000 {
+		t.Errorf("Count() = %v, want 10000", got)
+	}
+	if n := len(td.Centroids()); n > DefaultCompression {
+		t.Errorf("got %d centroids, want at most %d", n, DefaultCompression)
+	}
+	for _, tt := range []struct {
+		q    float64
+		want float64
+	}{
+		{0, 1},
+		{0.5, 5000},
+		{0.9, 9000},
+		{0.99, 9900},
+		{1, 10000},
+	} {
+		if got := td.Quantile(tt.q); math.Abs(got-tt.want) > 0.01*10000 {
+			t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestQuantileEmpty(t *testing.T) {
+	if got := New(0).Quantile(0.9); got != 0 {
+		t.Errorf("Quantile(0.9) of empty sketch = %v, want 0", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	short, long := New(DefaultCompression), New(DefaultCompression)
+	for i := 0; i < 900; i++ {
+		short.Add(100, 1)
+	}
+	for i := 0; i < 100; i++ {
+		long.Add(8000, 1)
+	}
+
+	merged := New(DefaultCompression)
+	merged.Merge(short)
+	merged.Merge(long)
+	merged.Merge(nil)
+
+	if got := merged.Count(); got != 1000 {
+		t.Errorf("Count() = %v, want 1000", got)
+	}
+	if got := merged.Quantile(0.5); got != 100 {
+		t.Errorf("Quantile(0.5) = %v, want 100", got)
+	}
+	if got := merged.Quantile(0.95); got != 8000 {
+		t.Errorf("Quantile(0.95) = %v, want 8000", got)
+	}
+}
+
+func TestFromHistogram(t *testing.T) {
+	// 80 requests of up to 100 tokens, 15 of 100-1000 and 5 above 1000
+	buckets := []Bucket{
+		{UpperBound: math.Inf(1), Count: 100},
+		{UpperBound: 100, Count: 80},
+		{UpperBound: 1000, Count: 95},
+		{UpperBound: 10000, Count: 95},
+	}
+	td := FromHistogram(buckets, DefaultCompression)
+
+	if got := td.Count(); got != 100 {
+		t.Errorf("Count() = %v, want 100", got)
+	}
+	if got := td.Quantile(0.5); got <= 0 || got > 100 {
+		t.Errorf("Quantile(0.5) = %v, want within (0, 100]", got)
+	}
+	if got := td.Quantile(0.9); got <= 100 || got > 1000 {
+		t.Errorf("Quantile(0.9) = %v, want within (100, 1000]", got)
+	}
+	// values of the +Inf bucket are placed at the highest finite bound
+	if got := td.Quantile(1); got != 10000 {
+		t.Errorf("Quantile(1) = %v, want 10000", got)
+	}
+
+	if got := FromHistogram([]Bucket{{UpperBound: 100, Count: 0}}, 0).Count(); got != 0 {
+		t.Errorf("Count() of empty histogram = %v, want 0", got)
+	}
+}
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	cache := NewCache(time.Minute)
+	td := New(0)
+	cache.Set("ns/pod-a", td, now)
+
+	if got, ok := cache.Get("ns/pod-a", now.Add(30*time.Second)); !ok || got != td {
+		t.Errorf("Get() = %v, %v, want the stored sketch", got, ok)
+	}
+	if _, ok := cache.Get("ns/pod-a", now.Add(2*time.Minute)); ok {
+		t.Error("Get() returned a sketch past the retention period")
+	}
+	if _, ok := cache.Get("ns/pod-b", now); ok {
+		t.Error("Get() returned a sketch of an unknown key")
+	}
+
+	// setting a key drops the expired ones
+	cache.Set("ns/pod-b", td, now.Add(2*time.Minute))
+	if len(cache.entries) != 1 {
+		t.Errorf("got %d entries, want 1", len(cache.entries))
+	}
+}
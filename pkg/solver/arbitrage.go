@@ -0,0 +1,241 @@
+package solver
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Scheduler of time-sliced cost arbitrage across optimizer runs
+//   - during off-peak windows, low-priority servers are shifted to cheaper accelerators
+//     and/or fewer replicas, lowest priority first
+//   - cost is accounted over the day rather than per run: servers are only shifted while
+//     the projected daily cost exceeds the daily cost target (if any)
+//
+// The scheduler only runs in programs embedding the optimizer: the WVA controller does not
+// run the optimizer, so has no off-peak windows to configure.
+type CostScheduler struct {
+	spec    *config.CostArbitrageSpec
+	windows []timeWindow
+	clock   func() time.Time
+
+	day       time.Time // start (UTC) of the day being accounted
+	lastTime  time.Time // time of the last run (zero if none)
+	costRate  float64   // cost rate (cents/hr) of the solution of the last run
+	dailyCost float64   // cost (cents) accumulated during the day up to the last run
+}
+
+// Time window of the day, as offsets from midnight
+type timeWindow struct {
+	start time.Duration
+	end   time.Duration
+}
+
+func NewCostScheduler(spec *config.CostArbitrageSpec) (*CostScheduler, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("missing cost arbitrage spec")
+	}
+	if spec.ReplicaFraction < 0 || spec.ReplicaFraction > 1 {
+		return nil, fmt.Errorf("replica fraction %v not in [0,1]", spec.ReplicaFraction)
+	}
+	if spec.DailyCostTarget < 0 {
+		return nil, fmt.Errorf("negative daily cost target %v", spec.DailyCostTarget)
+	}
+	windows := make([]timeWindow, len(spec.OffPeakWindows))
+	for i, w := range spec.OffPeakWindows {
+		start, err := parseTimeOfDay(w.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(w.End)
+		if err != nil {
+			return nil, err
+		}
+		windows[i] = timeWindow{start: start, end: end}
+	}
+	return &CostScheduler{
+		spec:    spec,
+		windows: windows,
+		clock:   time.Now,
+	}, nil
+}
+
+// Check if a time falls in an off-peak window
+func (c *CostScheduler) OffPeak(t time.Time) bool {
+	t = t.UTC()
+	offset := t.Sub(t.Truncate(24 * time.Hour))
+	for _, w := range c.windows {
+		if w.contains(offset) {
+			return true
+		}
+	}
+	return false
+}
+
+// Cost (cents) accumulated during the current day up to the last run
+func (c *CostScheduler) DailyCost() float64 {
+	return c.dailyCost
+}
+
+// Projected cost (cents) of the day of time t, if the given cost rate (cents/hr) is kept until midnight
+func (c *CostScheduler) ProjectedDailyCost(t time.Time, costRate float64) float64 {
+	t = t.UTC()
+	remaining := t.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(t)
+	return c.dailyCost + costRate*remaining.Hours()
+}
+
+// Apply cost arbitrage to the current solution, returning the names of shifted servers
+func (c *CostScheduler) Apply(system *core.System, unlimited bool) (shifted []string) {
+	t := c.clock().UTC()
+	c.accumulate(t)
+
+	servers := system.Servers()
+	costRate := float64(0)
+	for _, server := range servers {
+		costRate += allocationCost(server.Allocation())
+	}
+
+	shifted = make([]string, 0)
+	if c.OffPeak(t) {
+		var available map[string]int
+		if !unlimited {
+			available = remainingCapacity(system)
+		}
+		for _, server := range c.lowPriorityServers(servers) {
+			if c.spec.DailyCostTarget > 0 && c.ProjectedDailyCost(t, costRate) <= core.CostFromSpec(c.spec.DailyCostTarget) {
+				break
+			}
+			cur := server.Allocation()
+			alloc := c.cheaperAllocation(system, server, available)
+			if alloc == nil {
+				continue
+			}
+			costRate += alloc.Cost() - cur.Cost()
+			server.SetAllocation(alloc)
+			shifted = append(shifted, server.Name())
+		}
+	}
+	c.costRate = costRate
+	return shifted
+}
+
+// Add the cost of the last solution up to time t to the cost of the day, starting a new day if needed
+func (c *CostScheduler) accumulate(t time.Time) {
+	day := t.Truncate(24 * time.Hour)
+	if !c.lastTime.IsZero() {
+		from := c.lastTime
+		if day.After(c.day) {
+			// cost before midnight belongs to the previous day
+			from = day
+			c.dailyCost = 0
+		}
+		c.dailyCost += c.costRate * t.Sub(from).Hours()
+	}
+	c.day = day
+	c.lastTime = t
+}
+
+// Servers with an allocation that may be shifted, lowest priority first
+func (c *CostScheduler) lowPriorityServers(servers map[string]*core.Server) []*core.Server {
+	candidates := make([]*core.Server, 0)
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		if server.Allocation() != nil && server.Priority() >= c.spec.MinPriority {
+			candidates = append(candidates, server)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b *core.Server) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+	return candidates
+}
+
+// Cheapest candidate allocation of a server, with replicas reduced by the replica fraction,
+// if cheaper than its current allocation and fitting the available capacity (nil map = unlimited);
+// available capacity is updated for the returned allocation
+func (c *CostScheduler) cheaperAllocation(system *core.System, server *core.Server, available map[string]int) *core.Allocation {
+	cur := server.Allocation()
+	curType, curCount, curOk := acceleratorUnits(system, server, cur)
+
+	var (
+		best      *core.Allocation
+		bestType  string
+		bestCount int
+	)
+	for _, accName := range slices.Sorted(maps.Keys(server.AllAllocations())) {
+		alloc := c.reducedAllocation(system, server, server.AllAllocations()[accName])
+		if alloc == nil || alloc.Cost() >= cur.Cost() || (best != nil && alloc.Cost() >= best.Cost()) {
+			continue
+		}
+		accType, count, ok := acceleratorUnits(system, server, alloc)
+		if !ok {
+			continue
+		}
+		if available != nil {
+			free := available[accType]
+			if curOk && curType == accType {
+				free += curCount
+			}
+			if free < count {
+				continue
+			}
+		}
+		best, bestType, bestCount = alloc, accType, count
+	}
+	if best != nil && available != nil {
+		if curOk {
+			available[curType] += curCount
+		}
+		available[bestType] -= bestCount
+	}
+	return best
+}
+
+// Allocation with replicas reduced by the replica fraction (a copy of the allocation if not reduced)
+func (c *CostScheduler) reducedAllocation(system *core.System, server *core.Server, alloc *core.Allocation) *core.Allocation {
+	fraction := c.spec.ReplicaFraction
+	if fraction == 0 || fraction == 1 {
+		return alloc.Clone()
+	}
+	numReplicas := int(math.Ceil(float64(fraction) * float64(alloc.NumReplicas())))
+	numReplicas = max(numReplicas, server.MinNumReplicas(), 1)
+	if numReplicas >= alloc.NumReplicas() {
+		return alloc.Clone()
+	}
+	return system.CreateShardedAllocationWithReplicas(server.Name(), alloc.Accelerator(), alloc.AccCount(), numReplicas)
+}
+
+// Accelerator counts not used by the current allocations of servers
+func remainingCapacity(system *core.System) map[string]int {
+	available := make(map[string]int)
+	maps.Copy(available, system.Capacities())
+	for _, server := range system.Servers() {
+		if alloc := server.Allocation(); alloc != nil {
+			if accType, count, ok := acceleratorUnits(system, server, alloc); ok {
+				available[accType] -= count
+			}
+		}
+	}
+	return available
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w timeWindow) contains(offset time.Duration) bool {
+	if w.start <= w.end {
+		return offset >= w.start && offset < w.end
+	}
+	return offset >= w.start || offset < w.end
+}
@@ -0,0 +1,257 @@
+package solver
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// greedy test system where low-priority server3 needs several replicas and prefers H100
+func setupArbitrageTestSystem() *core.System {
+	system := setupTestSystemForGreedy()
+	system.ServiceClass("low-priority").AddModelTarget(&config.ModelTarget{
+		Model:    "llama-7b",
+		SLO_ITL:  500,
+		SLO_TTFT: 2500,
+		SLO_TPS:  1000,
+	})
+	system.AddServerFromSpec(config.ServerSpec{
+		Name:  "server3",
+		Model: "llama-7b",
+		Class: "low-priority",
+		CurrentAlloc: config.AllocationData{
+			Load: config.ServerLoadSpec{
+				ArrivalRate:  10,
+				AvgInTokens:  80,
+				AvgOutTokens: 150,
+			},
+		},
+		MinNumReplicas:         1,
+		MaxBatchSize:           128,
+		AcceleratorPreferences: []string{"H100", "A100"},
+	})
+	system.Calculate()
+	return system
+}
+
+// cost scheduler with a fixed clock
+func newTestCostScheduler(t *testing.T, spec *config.CostArbitrageSpec, now time.Time) *CostScheduler {
+	c, err := NewCostScheduler(spec)
+	if err != nil {
+		t.Fatalf("NewCostScheduler() error = %v", err)
+	}
+	c.clock = func() time.Time { return now }
+	return c
+}
+
+func TestNewCostScheduler_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *config.CostArbitrageSpec
+	}{
+		{name: "nil spec", spec: nil},
+		{name: "bad time", spec: &config.CostArbitrageSpec{OffPeakWindows: []config.TimeWindowSpec{{Start: "25:00", End: "06:00"}}}},
+		{name: "bad fraction", spec: &config.CostArbitrageSpec{ReplicaFraction: 1.5}},
+		{name: "negative target", spec: &config.CostArbitrageSpec{DailyCostTarget: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCostScheduler(tt.spec); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestCostScheduler_OffPeak(t *testing.T) {
+	c := newTestCostScheduler(t, &config.CostArbitrageSpec{
+		OffPeakWindows: []config.TimeWindowSpec{{Start: "22:00", End: "06:00"}, {Start: "12:00", End: "13:00"}},
+	}, time.Time{})
+	tests := []struct {
+		hour, minute int
+		want         bool
+	}{
+		{hour: 23, minute: 0, want: true},
+		{hour: 5, minute: 59, want: true},
+		{hour: 6, minute: 0, want: false},
+		{hour: 12, minute: 30, want: true},
+		{hour: 18, minute: 0, want: false},
+	}
+	for _, tt := range tests {
+		at := time.Date(2025, 3, 1, tt.hour, tt.minute, 0, 0, time.UTC)
+		if got := c.OffPeak(at); got != tt.want {
+			t.Errorf("OffPeak(%02d:%02d) = %v, want %v", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+}
+
+func TestCostScheduler_DailyCost(t *testing.T) {
+	system := setupArbitrageTestSystem()
+	NewSolver(system, &config.OptimizerSpec{Unlimited: true}).SolveUnlimited()
+	costRate := float64(0)
+	for _, server := range system.Servers() {
+		costRate += allocationCost(server.Allocation())
+	}
+
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := newTestCostScheduler(t, &config.CostArbitrageSpec{}, now)
+	c.clock = func() time.Time { return now }
+	c.Apply(system, true)
+	if got := c.DailyCost(); got != 0 {
+		t.Errorf("DailyCost() after first run = %v, want 0", got)
+	}
+
+	now = now.Add(2 * time.Hour)
+	c.Apply(system, true)
+	if got, want := c.DailyCost(), 2*costRate; got != want {
+		t.Errorf("DailyCost() after 2 hours = %v, want %v", got, want)
+	}
+	if got, want := c.ProjectedDailyCost(now, costRate), 14*costRate; got != want {
+		t.Errorf("ProjectedDailyCost() = %v, want %v", got, want)
+	}
+
+	// cost before midnight belongs to the previous day
+	now = time.Date(2025, 3, 2, 1, 0, 0, 0, time.UTC)
+	c.Apply(system, true)
+	if got := c.DailyCost(); got != costRate {
+		t.Errorf("DailyCost() on new day = %v, want %v", got, costRate)
+	}
+}
+
+// Accounting a fractional cost rate over a day of short runs keeps the daily cost exact to well below a cent
+func TestCostScheduler_DailyCostPrecision(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := newTestCostScheduler(t, &config.CostArbitrageSpec{}, now)
+	c.costRate = 1234.56
+
+	// runs every 5 minutes until the end of the day
+	runs := 0
+	for at := now; at.Day() == now.Day(); at = at.Add(5 * time.Minute) {
+		c.accumulate(at)
+		runs++
+	}
+	want := c.costRate * float64(runs-1) / 12
+	if got := c.DailyCost(); math.Abs(got-want) > 1e-6 {
+		t.Errorf("DailyCost() = %v, want %v", got, want)
+	}
+}
+
+func TestCostScheduler_Apply(t *testing.T) {
+	offPeak := []config.TimeWindowSpec{{Start: "00:00", End: "06:00"}}
+	night := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		spec        config.CostArbitrageSpec
+		now         time.Time
+		wantShifted bool
+		wantAcc     string
+	}{
+		{
+			name:        "peak hours",
+			spec:        config.CostArbitrageSpec{OffPeakWindows: offPeak, MinPriority: 3},
+			now:         day,
+			wantShifted: false,
+			wantAcc:     "H100",
+		},
+		{
+			name:        "off-peak shifts to cheaper accelerator",
+			spec:        config.CostArbitrageSpec{OffPeakWindows: offPeak, MinPriority: 3},
+			now:         night,
+			wantShifted: true,
+			wantAcc:     "A100",
+		},
+		{
+			name:        "off-peak reduces replicas",
+			spec:        config.CostArbitrageSpec{OffPeakWindows: offPeak, MinPriority: 3, ReplicaFraction: 0.5},
+			now:         night,
+			wantShifted: true,
+			wantAcc:     "A100",
+		},
+		{
+			name:        "daily cost target already met",
+			spec:        config.CostArbitrageSpec{OffPeakWindows: offPeak, MinPriority: 3, DailyCostTarget: 1e6},
+			now:         night,
+			wantShifted: false,
+			wantAcc:     "H100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system := setupArbitrageTestSystem()
+			NewSolver(system, &config.OptimizerSpec{Unlimited: true}).SolveUnlimited()
+			before := system.Server("server3").Allocation()
+			if before == nil || before.Accelerator() != "H100" || before.NumReplicas() < 2 {
+				t.Fatalf("unexpected allocation before arbitrage: %v", before)
+			}
+			highPriority := system.Server("server1").Allocation()
+
+			shifted := newTestCostScheduler(t, &tt.spec, tt.now).Apply(system, true)
+
+			if got := len(shifted) == 1 && shifted[0] == "server3"; got != tt.wantShifted {
+				t.Errorf("shifted = %v, want server3 shifted: %v", shifted, tt.wantShifted)
+			}
+			after := system.Server("server3").Allocation()
+			if after.Accelerator() != tt.wantAcc {
+				t.Errorf("accelerator = %s, want %s", after.Accelerator(), tt.wantAcc)
+			}
+			if tt.spec.ReplicaFraction > 0 && tt.wantShifted {
+				if after.NumReplicas() >= system.Server("server3").AllAllocations()["A100"].NumReplicas() {
+					t.Errorf("expected fewer replicas than the A100 candidate, got %d", after.NumReplicas())
+				}
+			}
+			if system.Server("server1").Allocation() != highPriority {
+				t.Error("expected high-priority server not to be shifted")
+			}
+		})
+	}
+}
+
+func TestCostScheduler_ApplyLimitedCapacity(t *testing.T) {
+	system := setupArbitrageTestSystem()
+	NewSolver(system, &config.OptimizerSpec{Unlimited: true}).SolveUnlimited()
+	// no A100 left for the low-priority server
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_A100", Count: 0})
+
+	spec := &config.CostArbitrageSpec{
+		OffPeakWindows: []config.TimeWindowSpec{{Start: "00:00", End: "06:00"}},
+		MinPriority:    3,
+	}
+	shifted := newTestCostScheduler(t, spec, time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)).Apply(system, false)
+	if len(shifted) != 0 {
+		t.Errorf("expected no shift without available capacity, got %v", shifted)
+	}
+}
+
+func TestOptimizer_CostArbitrage(t *testing.T) {
+	system := setupArbitrageTestSystem()
+	optimizer := NewOptimizerFromSpec(system, &config.OptimizerSpec{
+		Unlimited:     true,
+		CostArbitrage: &config.CostArbitrageSpec{ReplicaFraction: 2},
+	})
+	if err := optimizer.Optimize(); err == nil {
+		t.Error("expected error for invalid cost arbitrage spec")
+	}
+
+	optimizer = NewOptimizerFromSpec(system, &config.OptimizerSpec{
+		Unlimited:     true,
+		CostArbitrage: &config.CostArbitrageSpec{MinPriority: 3},
+	})
+	if err := optimizer.Optimize(); err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	scheduler := optimizer.CostScheduler()
+	if scheduler == nil {
+		t.Fatal("expected cost scheduler to be created")
+	}
+	if err := optimizer.Optimize(); err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if optimizer.CostScheduler() != scheduler {
+		t.Error("expected cost scheduler to be kept across runs")
+	}
+}
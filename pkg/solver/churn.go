@@ -0,0 +1,120 @@
+package solver
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Baseline allocation of a server for churn bounding: allocation in the previous solution
+// if any, otherwise the current allocation of the server
+func (s *Solver) baselineAllocation(serverName string) *core.Allocation {
+	if s.previousSolution != nil {
+		return s.previousSolution[serverName]
+	}
+	return s.currentAllocation[serverName]
+}
+
+// Bound the number of servers whose allocation changes relative to the baseline
+// allocation to maxChanges (churn bounding)
+//   - the greedy search itself is not seeded from the baseline: it runs from scratch,
+//     and its solution is then pulled back towards the baseline
+//   - changed servers are ranked by priority, then by magnitude of cost change;
+//     the top maxChanges keep changing, the others are pinned to their baseline allocation
+//   - greedy allocation is repeated for the changing servers on the capacity left by pinned servers
+//   - if the pinned allocations do not fit the capacity, the unbounded solution is kept
+func (s *Solver) boundChurn(maxChanges int) {
+	servers := s.system.Servers()
+
+	// find servers whose allocation changed
+	changed := make([]string, 0)
+	for serverName, server := range servers {
+		if !sameAllocation(s.baselineAllocation(serverName), server.Allocation()) {
+			changed = append(changed, serverName)
+		}
+	}
+	if len(changed) <= maxChanges {
+		return
+	}
+
+	// rank changed servers: higher priority first, then larger cost change
+	costChange := func(serverName string) float64 {
+		return math.Abs(allocationCost(servers[serverName].Allocation()) -
+			allocationCost(s.baselineAllocation(serverName)))
+	}
+	slices.SortFunc(changed, func(a, b string) int {
+		if pa, pb := servers[a].Priority(), servers[b].Priority(); pa != pb {
+			return cmp.Compare(pa, pb)
+		}
+		if ca, cb := costChange(a), costChange(b); ca != cb {
+			return cmp.Compare(cb, ca)
+		}
+		return cmp.Compare(a, b)
+	})
+	changing := make(map[string]*core.Server)
+	for _, serverName := range changed[:maxChanges] {
+		changing[serverName] = servers[serverName]
+	}
+
+	// account for accelerators held by pinned servers
+	available := make(map[string]int)
+	maps.Copy(available, s.system.Capacities())
+	for serverName, server := range servers {
+		if _, ok := changing[serverName]; ok {
+			continue
+		}
+		alloc := s.baselineAllocation(serverName)
+		if alloc == nil {
+			continue
+		}
+		accType, count, ok := acceleratorUnits(s.system, server, alloc)
+		if !ok || available[accType] < count {
+			// baseline no longer feasible, keep unbounded solution
+			return
+		}
+		available[accType] -= count
+	}
+
+	// pin servers to baseline allocation and re-allocate changing servers
+	for serverName, server := range servers {
+		if _, ok := changing[serverName]; ok {
+			continue
+		}
+		if alloc := s.baselineAllocation(serverName); alloc != nil {
+			server.SetAllocation(alloc.Clone())
+		} else {
+			server.RemoveAllocation()
+		}
+	}
+	s.solveGreedy(changing, available)
+}
+
+// Accelerator type and number of accelerator units used by an allocation of a server
+func acceleratorUnits(system *core.System, server *core.Server, alloc *core.Allocation) (accType string, count int, ok bool) {
+	model := system.Model(server.ModelName())
+	acc := system.Accelerator(alloc.Accelerator())
+	if model == nil || acc == nil {
+		return "", 0, false
+	}
+	unitsPerReplica := model.NumInstancesOf(alloc) * acc.Spec().Multiplicity
+	return acc.Type(), alloc.NumReplicas() * unitsPerReplica, true
+}
+
+// Check if two allocations use the same accelerator and number of replicas
+func sameAllocation(a *core.Allocation, b *core.Allocation) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Accelerator() == b.Accelerator() && a.NumReplicas() == b.NumReplicas()
+}
+
+// Cost of an allocation, zero if no allocation
+func allocationCost(alloc *core.Allocation) float64 {
+	if alloc == nil {
+		return 0
+	}
+	return alloc.Cost()
+}
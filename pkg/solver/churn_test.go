@@ -0,0 +1,139 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// count servers whose allocation differs from the given solution
+func countChanges(system *core.System, solution map[string]*core.Allocation) int {
+	changes := 0
+	for serverName, server := range system.Servers() {
+		if !sameAllocation(solution[serverName], server.Allocation()) {
+			changes++
+		}
+	}
+	return changes
+}
+
+func TestSolver_Solve_PreviousSolutionUnchanged(t *testing.T) {
+	system := setupTestSystemForGreedy()
+	spec := &config.OptimizerSpec{SaturationPolicy: "None", MaxChangesPerCycle: 1}
+
+	first := NewSolver(system, spec)
+	if err := first.Solve(); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	previous := first.Solution()
+	if len(previous) == 0 {
+		t.Fatal("expected a non-empty solution")
+	}
+
+	// same system and load: churn-bounded solution should not change
+	second := NewSolver(system, spec)
+	second.SetPreviousSolution(previous)
+	if err := second.Solve(); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if changes := countChanges(system, previous); changes != 0 {
+		t.Errorf("expected no changes from previous solution, got %d", changes)
+	}
+}
+
+func TestSolver_Solve_BoundsChurn(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxChanges int
+		wantMax    int
+	}{
+		{name: "one change per cycle", maxChanges: 1, wantMax: 1},
+		{name: "two changes per cycle", maxChanges: 2, wantMax: 2},
+		{name: "unbounded", maxChanges: 0, wantMax: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system := setupTestSystemForGreedy()
+			spec := &config.OptimizerSpec{SaturationPolicy: "None", MaxChangesPerCycle: tt.maxChanges}
+
+			// previous solution with no allocations: every server would change
+			solver := NewSolver(system, spec)
+			solver.SetPreviousSolution(map[string]*core.Allocation{})
+			if err := solver.Solve(); err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			changes := countChanges(system, map[string]*core.Allocation{})
+			if changes > tt.wantMax {
+				t.Errorf("expected at most %d changes, got %d", tt.wantMax, changes)
+			}
+			if changes == 0 {
+				t.Error("expected at least one server to be allocated")
+			}
+		})
+	}
+}
+
+func TestSolver_Solve_BoundsChurnByPriority(t *testing.T) {
+	system := setupTestSystemForGreedy()
+	spec := &config.OptimizerSpec{SaturationPolicy: "None", MaxChangesPerCycle: 1}
+
+	solver := NewSolver(system, spec)
+	solver.SetPreviousSolution(map[string]*core.Allocation{})
+	if err := solver.Solve(); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+
+	// server1 belongs to the highest priority service class
+	if system.Server("server1").Allocation() == nil {
+		t.Error("expected highest priority server to receive the allowed change")
+	}
+	for _, serverName := range []string{"server2", "server3"} {
+		if alloc := system.Server(serverName).Allocation(); alloc != nil {
+			t.Errorf("expected %s to stay pinned to its previous (empty) allocation, got %v", serverName, alloc)
+		}
+	}
+}
+
+func TestSolver_Solve_InfeasiblePreviousSolution(t *testing.T) {
+	system := setupTestSystemForGreedy()
+	spec := &config.OptimizerSpec{SaturationPolicy: "None", MaxChangesPerCycle: 1}
+
+	// previous solution holding more accelerators than available
+	previous := map[string]*core.Allocation{
+		"server2": core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: 100}),
+		"server3": core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: 100}),
+	}
+	solver := NewSolver(system, spec)
+	solver.SetPreviousSolution(previous)
+	if err := solver.Solve(); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	for serverName, server := range system.Servers() {
+		if alloc := server.Allocation(); alloc != nil && alloc.NumReplicas() == 100 {
+			t.Errorf("expected infeasible previous allocation to be dropped for %s", serverName)
+		}
+	}
+}
+
+func TestOptimizer_KeepsPreviousSolution(t *testing.T) {
+	system := setupTestSystemForGreedy()
+	optimizer := NewOptimizerFromSpec(system, &config.OptimizerSpec{SaturationPolicy: "None", MaxChangesPerCycle: 1})
+
+	if optimizer.PreviousSolution() != nil {
+		t.Fatal("expected no previous solution before first run")
+	}
+	if err := optimizer.Optimize(); err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	previous := optimizer.PreviousSolution()
+	if len(previous) == 0 {
+		t.Fatal("expected previous solution to be kept after first run")
+	}
+	if err := optimizer.Optimize(); err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if changes := countChanges(system, previous); changes != 0 {
+		t.Errorf("expected churn-bounded run to keep previous solution, got %d changes", changes)
+	}
+}
@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Dampener of allocation changes across optimizer runs (anti-flapping)
+//   - a change is applied once the same target allocation has been proposed for
+//     consecutiveRuns consecutive runs
+//   - a change of at least replicaThreshold replicas is applied immediately (0 = disabled)
+//   - a flap is counted when a pending change is withdrawn or reversed before being applied
+//   - an applied change keeps being applied, without dampening, until the current
+//     allocation reaches its target or a different target is proposed
+//   - servers missing from a run, e.g. whose metrics were unavailable, keep their state
+//     until they are proposed again or Remove forgets them
+type Dampener struct {
+	consecutiveRuns  int
+	replicaThreshold int
+
+	// changes proposed but not yet applied, by server name
+	pending map[string]*pendingChange
+
+	// last change applied, until the current allocation reaches its target, by server name
+	applied map[string]*core.AllocationDiff
+
+	// cumulative number of flaps, by server name
+	flaps map[string]int
+}
+
+// Change proposed for a server, with the number of consecutive runs it was proposed
+type pendingChange struct {
+	diff *core.AllocationDiff
+	runs int
+}
+
+func NewDampener(consecutiveRuns int, replicaThreshold int) *Dampener {
+	return &Dampener{
+		consecutiveRuns:  max(consecutiveRuns, 1),
+		replicaThreshold: max(replicaThreshold, 0),
+		pending:          make(map[string]*pendingChange),
+		applied:          make(map[string]*core.AllocationDiff),
+		flaps:            make(map[string]int),
+	}
+}
+
+// Filter the allocation diffs proposed in a run, returning the diffs to apply and
+// the names of servers that flapped in this run
+//   - servers with a nil or no-change diff have no proposed change
+//   - servers missing from diffs were not optimized in this run, and are left as they are
+func (d *Dampener) Dampen(diffs map[string]*core.AllocationDiff) (apply map[string]*core.AllocationDiff, flapped []string) {
+	apply = make(map[string]*core.AllocationDiff)
+	flapped = make([]string, 0)
+
+	for serverName, diff := range diffs {
+		if diff == nil || !diff.IsChange() {
+			// a pending change no longer proposed is withdrawn
+			if _, exists := d.pending[serverName]; exists {
+				delete(d.pending, serverName)
+				d.flaps[serverName]++
+				flapped = append(flapped, serverName)
+			}
+			delete(d.applied, serverName)
+			continue
+		}
+		if a, exists := d.applied[serverName]; exists {
+			if a.SameTarget(diff) {
+				// applied in an earlier run, but not yet reflected in the current allocation
+				apply[serverName] = diff
+				continue
+			}
+			delete(d.applied, serverName)
+		}
+		p, exists := d.pending[serverName]
+		switch {
+		case !exists:
+			p = &pendingChange{diff: diff, runs: 1}
+			d.pending[serverName] = p
+		case p.diff.SameTarget(diff):
+			p.diff = diff
+			p.runs++
+		default:
+			if direction(p.diff)*direction(diff) < 0 {
+				d.flaps[serverName]++
+				flapped = append(flapped, serverName)
+			}
+			p.diff = diff
+			p.runs = 1
+		}
+
+		magnitude := abs(diff.NewNumReplicas() - diff.OldNumReplicas())
+		if p.runs >= d.consecutiveRuns || (d.replicaThreshold > 0 && magnitude >= d.replicaThreshold) {
+			apply[serverName] = diff
+			d.applied[serverName] = diff
+			delete(d.pending, serverName)
+		}
+	}
+	return apply, flapped
+}
+
+// Number of consecutive runs the pending change of a server has been proposed (0 if none)
+func (d *Dampener) PendingRuns(serverName string) int {
+	if p, exists := d.pending[serverName]; exists {
+		return p.runs
+	}
+	return 0
+}
+
+// Cumulative number of flaps of a server
+func (d *Dampener) FlapCount(serverName string) int {
+	return d.flaps[serverName]
+}
+
+// Forget all state kept for a server
+func (d *Dampener) Remove(serverName string) {
+	delete(d.pending, serverName)
+	delete(d.applied, serverName)
+	delete(d.flaps, serverName)
+}
+
+// Direction of change in number of replicas: 1 (up), -1 (down), or 0
+func direction(diff *core.AllocationDiff) int {
+	switch delta := diff.NewNumReplicas() - diff.OldNumReplicas(); {
+	case delta > 0:
+		return 1
+	case delta < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
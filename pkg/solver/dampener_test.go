@@ -0,0 +1,183 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// diff of the number of replicas of a server on A100
+func replicaDiff(from, to int) *core.AllocationDiff {
+	return core.CreateAllocationDiff(
+		core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: from}),
+		core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: to}))
+}
+
+func TestNewDampener_Defaults(t *testing.T) {
+	d := NewDampener(0, -1)
+	if d.consecutiveRuns != 1 {
+		t.Errorf("consecutiveRuns = %d, want 1", d.consecutiveRuns)
+	}
+	if d.replicaThreshold != 0 {
+		t.Errorf("replicaThreshold = %d, want 0", d.replicaThreshold)
+	}
+}
+
+func TestDampener_Disabled(t *testing.T) {
+	d := NewDampener(1, 0)
+	apply, flapped := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 2)})
+	if apply["s1"] == nil {
+		t.Error("expected change to be applied immediately when dampening is disabled")
+	}
+	if len(flapped) != 0 {
+		t.Errorf("expected no flaps, got %v", flapped)
+	}
+}
+
+func TestDampener_ConsecutiveRuns(t *testing.T) {
+	d := NewDampener(3, 0)
+	for run := 1; run <= 3; run++ {
+		apply, _ := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 2)})
+		if run < 3 {
+			if apply["s1"] != nil {
+				t.Fatalf("run %d: change applied before 3 consecutive runs", run)
+			}
+			if got := d.PendingRuns("s1"); got != run {
+				t.Errorf("run %d: PendingRuns = %d, want %d", run, got, run)
+			}
+			continue
+		}
+		if apply["s1"] == nil {
+			t.Fatal("expected change to be applied after 3 consecutive runs")
+		}
+		if got := d.PendingRuns("s1"); got != 0 {
+			t.Errorf("PendingRuns after apply = %d, want 0", got)
+		}
+	}
+}
+
+func TestDampener_MagnitudeThreshold(t *testing.T) {
+	d := NewDampener(5, 3)
+	apply, _ := d.Dampen(map[string]*core.AllocationDiff{
+		"small": replicaDiff(2, 4),
+		"large": replicaDiff(2, 5),
+	})
+	if apply["small"] != nil {
+		t.Error("expected small change to be dampened")
+	}
+	if apply["large"] == nil {
+		t.Error("expected large change to bypass dampening")
+	}
+}
+
+func TestDampener_ChangedTargetRestartsCount(t *testing.T) {
+	d := NewDampener(2, 0)
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 2)})
+	apply, flapped := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	if apply["s1"] != nil {
+		t.Error("expected new target to restart the consecutive run count")
+	}
+	if len(flapped) != 0 {
+		t.Errorf("expected no flap for change in the same direction, got %v", flapped)
+	}
+	apply, _ = d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	if apply["s1"] == nil {
+		t.Error("expected change to be applied after 2 consecutive runs with the same target")
+	}
+}
+
+func TestDampener_Flaps(t *testing.T) {
+	d := NewDampener(3, 0)
+
+	// reversal: up then down
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 3)})
+	_, flapped := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 1)})
+	if len(flapped) != 1 || flapped[0] != "s1" {
+		t.Errorf("expected reversal to flap s1, got %v", flapped)
+	}
+
+	// withdrawal: pending change no longer proposed
+	_, flapped = d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 2)})
+	if len(flapped) != 1 || flapped[0] != "s1" {
+		t.Errorf("expected withdrawal to flap s1, got %v", flapped)
+	}
+	if got := d.FlapCount("s1"); got != 2 {
+		t.Errorf("FlapCount = %d, want 2", got)
+	}
+	if got := d.PendingRuns("s1"); got != 0 {
+		t.Errorf("PendingRuns after withdrawal = %d, want 0", got)
+	}
+
+	d.Remove("s1")
+	if got := d.FlapCount("s1"); got != 0 {
+		t.Errorf("FlapCount after Remove = %d, want 0", got)
+	}
+}
+
+func TestDampener_MissingServerKeepsState(t *testing.T) {
+	d := NewDampener(3, 0)
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 3)})
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 3)})
+
+	// s1 is not optimized in this run, e.g. its metrics are unavailable
+	_, flapped := d.Dampen(map[string]*core.AllocationDiff{"s2": replicaDiff(1, 1)})
+	if len(flapped) != 0 {
+		t.Errorf("expected no flap of a missing server, got %v", flapped)
+	}
+	if got := d.PendingRuns("s1"); got != 2 {
+		t.Errorf("PendingRuns of missing server = %d, want 2", got)
+	}
+
+	apply, _ := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 3)})
+	if apply["s1"] == nil {
+		t.Error("expected change to be applied on the third run proposing it")
+	}
+
+	// an applied change still catching up is kept across a missing run as well
+	d.Dampen(map[string]*core.AllocationDiff{})
+	apply, _ = d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(2, 3)})
+	if apply["s1"] == nil {
+		t.Error("expected applied change to be kept across a run missing the server")
+	}
+	if got := d.FlapCount("s1"); got != 0 {
+		t.Errorf("FlapCount = %d, want 0", got)
+	}
+}
+
+func TestDampener_AppliedChangeNotDampenedAgain(t *testing.T) {
+	d := NewDampener(2, 0)
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	apply, _ := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	if apply["s1"] == nil {
+		t.Fatal("expected change to be applied after 2 consecutive runs")
+	}
+
+	// the current allocation has not caught up yet: the applied target must stand
+	apply, flapped := d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	if apply["s1"] == nil {
+		t.Error("expected applied change to be kept while the current allocation catches up")
+	}
+	if len(flapped) != 0 {
+		t.Errorf("expected no flaps, got %v", flapped)
+	}
+	if got := d.PendingRuns("s1"); got != 0 {
+		t.Errorf("PendingRuns = %d, want 0", got)
+	}
+
+	// once reached, a new change is dampened again
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(3, 3)})
+	apply, _ = d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(3, 4)})
+	if apply["s1"] != nil {
+		t.Error("expected a new change to be dampened after the applied target was reached")
+	}
+
+	// a different target than the applied one is dampened as well
+	d = NewDampener(2, 0)
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 3)})
+	apply, _ = d.Dampen(map[string]*core.AllocationDiff{"s1": replicaDiff(1, 4)})
+	if apply["s1"] != nil {
+		t.Error("expected a different target to be dampened")
+	}
+}
@@ -0,0 +1,55 @@
+package solver
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+	wvaerrors "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/errors"
+)
+
+// Error of a solution leaving servers without feasible allocation on any accelerator;
+// the solution of the other servers is still valid
+var ErrNoFeasibleSolution = wvaerrors.ErrNoFeasibleSolution
+
+// Diagnostics of the servers without feasible allocation, matching ErrNoFeasibleSolution
+type NoFeasibleSolutionError struct {
+	// diagnostics of the candidate accelerators, by server name
+	Servers map[string][]*core.Infeasibility
+}
+
+func (e *NoFeasibleSolutionError) Error() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v for %d server(s)", ErrNoFeasibleSolution, len(e.Servers))
+	for _, serverName := range slices.Sorted(maps.Keys(e.Servers)) {
+		fmt.Fprintf(&b, "; s=%s:", serverName)
+		for _, infeasibility := range e.Servers[serverName] {
+			fmt.Fprintf(&b, " [%v]", infeasibility)
+		}
+	}
+	return b.String()
+}
+
+func (e *NoFeasibleSolutionError) Is(target error) bool {
+	return target == ErrNoFeasibleSolution
+}
+
+// Diagnose the servers without feasible allocation on any candidate accelerator; nil if none
+func noFeasibleSolution(system *core.System) error {
+	servers := make(map[string][]*core.Infeasibility)
+	for serverName, server := range system.Servers() {
+		infeasibilities := server.Infeasibilities()
+		if len(server.AllAllocations()) > 0 || len(infeasibilities) == 0 {
+			continue
+		}
+		for _, accName := range slices.Sorted(maps.Keys(infeasibilities)) {
+			servers[serverName] = append(servers[serverName], infeasibilities[accName])
+		}
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+	return &NoFeasibleSolutionError{Servers: servers}
+}
@@ -0,0 +1,52 @@
+package solver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+func TestSolve_NoFeasibleSolution(t *testing.T) {
+	system := core.NewSystem()
+	system.AddAcceleratorFromSpec(config.AcceleratorSpec{Name: "A100", Cost: 40})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "A100", Count: 8})
+	system.SetModelsFromSpec(&config.ModelData{PerfData: []config.ModelAcceleratorPerfData{{
+		Name:         "llama",
+		Acc:          "A100",
+		AccCount:     1,
+		MaxBatchSize: 16,
+		AtTokens:     200,
+		ServiceParms: config.ServiceParms{Alpha: 5.0, Beta: 0.2, Gamma: 0.015},
+	}}})
+	system.SetServiceClassesFromSpec(&config.ServiceClassData{Spec: []config.ServiceClassSpec{{
+		Name:     "premium",
+		Priority: 1,
+		ModelTargets: []config.ModelTarget{
+			{Model: "llama", SLO_ITL: 1, SLO_TTFT: 2000},
+		},
+	}}})
+	load := config.AllocationData{Load: config.ServerLoadSpec{ArrivalRate: 60, AvgInTokens: 100, AvgOutTokens: 200}}
+	system.SetServersFromSpec(&config.ServerData{Spec: []config.ServerSpec{
+		{Name: "chat", Class: "premium", Model: "llama", CurrentAlloc: load},
+	}})
+	system.Calculate()
+
+	err := NewSolver(system, &config.OptimizerSpec{}).Solve()
+	if !errors.Is(err, ErrNoFeasibleSolution) {
+		t.Fatalf("Solve() error = %v, want ErrNoFeasibleSolution", err)
+	}
+	var noFeasible *NoFeasibleSolutionError
+	if !errors.As(err, &noFeasible) {
+		t.Fatalf("Solve() error = %T, want *NoFeasibleSolutionError", err)
+	}
+	infeasibilities := noFeasible.Servers["chat"]
+	if len(infeasibilities) != 1 || infeasibilities[0].SLO != core.SLOITL || infeasibilities[0].Accelerator != "A100" {
+		t.Errorf("Servers[chat] = %v, want the unreachable ITL on A100", infeasibilities)
+	}
+	if !strings.Contains(err.Error(), "s=chat: [acc=A100: ITL target 1ms unreachable") {
+		t.Errorf("Error() = %q, want the diagnostic of the server", err.Error())
+	}
+}
@@ -18,7 +18,7 @@ type serverEntry struct {
 	priority    int                // priority of service class for server
 	curIndex    int                // current index in allocation list
 	allocations []*core.Allocation // ordered list of allocations
-	delta       float32            // delta penalty if current allocation not allowed and next allocation is allowed
+	delta       float64            // delta penalty if current allocation not allowed and next allocation is allowed
 }
 
 func (e *serverEntry) String() string {
@@ -36,11 +36,19 @@ func (s *Solver) SolveGreedy() {
 
 	// make a copy of count of available accelerator types
 	available := make(map[string]int)
-	maps.Copy(available, core.GetCapacities())
+	maps.Copy(available, s.system.Capacities())
+
+	s.solveGreedy(s.system.Servers(), available)
+}
+
+// Greedy allocation to the given servers, using the given available accelerator counts
+func (s *Solver) solveGreedy(servers map[string]*core.Server, available map[string]int) {
 
 	// create entries for all servers, sorting candidate allocations per server
+	// (visit servers in name order, so that ties are broken the same way in every run)
 	entries := make([]*serverEntry, 0)
-	for serverName, server := range core.GetServers() {
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
 		server.RemoveAllocation()
 		allAllocs := server.AllAllocations()
 		if len(allAllocs) == 0 {
@@ -55,12 +63,11 @@ func (s *Solver) SolveGreedy() {
 		}
 		i := 0
 		for _, alloc := range allAllocs {
-			e.allocations[i] = alloc
+			// best effort allocation adjusts allocations in place, keep candidates intact
+			e.allocations[i] = alloc.Clone()
 			i++
 		}
-		slices.SortFunc(e.allocations, func(a, b *core.Allocation) int {
-			return cmp.Compare(a.Value(), b.Value())
-		})
+		slices.SortFunc(e.allocations, allocationOrder(server))
 		if len(e.allocations) > 1 {
 			// value is difference between this and next allocation
 			e.delta = e.allocations[1].Value() - e.allocations[0].Value()
@@ -76,7 +83,10 @@ func (s *Solver) SolveGreedy() {
 	orderFunc := func(a, b *serverEntry) int {
 		if a.priority == b.priority {
 			if a.delta == b.delta {
-				return cmp.Compare(b.allocations[b.curIndex].Value(), a.allocations[a.curIndex].Value())
+				if va, vb := a.allocations[a.curIndex].Value(), b.allocations[b.curIndex].Value(); va != vb {
+					return cmp.Compare(vb, va)
+				}
+				return cmp.Compare(a.serverName, b.serverName)
 			}
 			return cmp.Compare(b.delta, a.delta)
 		} else {
@@ -89,22 +99,31 @@ func (s *Solver) SolveGreedy() {
 	// allocate
 	if s.optimizerSpec.DelayedBestEffort {
 		// allocate to all servers
-		unallocated := allocate(entries, available, orderFunc)
+		unallocated := allocate(s.system, entries, available, orderFunc)
+		if s.system.SoftSLO() {
+			// least-bad allocation violating SLOs to remaining servers
+			unallocated = allocateLeastBad(s.system, unallocated, available)
+		}
 		// best effort allocation to all remaining servers
-		bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
+		bestEffort(s.system, unallocated, available, s.optimizerSpec.SaturationPolicy)
 	} else {
 		groupEntries := makePriorityGroups(entries)
 		for _, group := range groupEntries {
 			// allocate to servers in priority group
-			unallocated := allocate(group, available, orderFunc)
+			unallocated := allocate(s.system, group, available, orderFunc)
+			if s.system.SoftSLO() {
+				// least-bad allocation violating SLOs to remaining servers in priority group
+				unallocated = allocateLeastBad(s.system, unallocated, available)
+			}
 			// best effort allocation to servers in priority group
-			bestEffort(unallocated, available, s.optimizerSpec.SaturationPolicy)
+			bestEffort(s.system, unallocated, available, s.optimizerSpec.SaturationPolicy)
 		}
 	}
 }
 
 // allocate, satisfying SLO requirements, returning servers that did not receive any allocation
-func allocate(entries []*serverEntry,
+func allocate(system *core.System,
+	entries []*serverEntry,
 	available map[string]int,
 	orderFunc ServerEntriesOrder) (unallocatedEntries []*serverEntry) {
 
@@ -121,22 +140,22 @@ func allocate(entries []*serverEntry,
 
 		// check if current allocation in entry can be satisfied
 		serverName := top.serverName
-		server := core.GetServer(serverName)
+		server := system.Server(serverName)
 		if server == nil {
 			continue
 		}
-		model := core.GetModel(server.ModelName())
+		model := system.Model(server.ModelName())
 		if model == nil {
 			continue
 		}
 		alloc := top.allocations[top.curIndex]
 		gName := alloc.Accelerator()
-		acc := core.GetAccelerator(gName)
+		acc := system.Accelerator(gName)
 		if acc == nil {
 			continue
 		}
 		tName := acc.Type()
-		unitsPerReplica := model.NumInstances(gName) * acc.Spec().Multiplicity
+		unitsPerReplica := model.NumInstancesOf(alloc) * acc.Spec().Multiplicity
 		count := alloc.NumReplicas() * unitsPerReplica
 
 		// check if accelerator type of current allocation is available, allocate
@@ -166,23 +185,31 @@ func allocate(entries []*serverEntry,
 }
 
 // give best effort allocation to unallocated servers according to saturation policy
-func bestEffort(unallocatedServers []*serverEntry, available map[string]int, policy string) {
+func bestEffort(system *core.System, unallocatedServers []*serverEntry, available map[string]int, policy string) {
 	switch config.SaturatedAllocationPolicyEnum(policy) {
 
 	// allocate exhaustively to servers in priority ordering
 	case config.PriorityExhaustive:
-		allocateMaximally(unallocatedServers, available)
+		allocateMaximally(system, unallocatedServers, available)
 
 	// allocate in round-robin fashion within priority groups
 	case config.PriorityRoundRobin:
 		priorityGroups := makePriorityGroups(unallocatedServers)
 		for _, group := range priorityGroups {
-			allocateEqually(group, available)
+			allocateEqually(system, group, available)
 		}
 
 	// allocate in round-robin fashion across all servers
 	case config.RoundRobin:
-		allocateEqually(unallocatedServers, available)
+		allocateEqually(system, unallocatedServers, available)
+
+	// allocate in proportion to the number of replicas requested by servers
+	case config.ProportionalShare:
+		allocateProportionally(system, unallocatedServers, available)
+
+	// allocate in round-robin fashion across all servers, with more replicas per round to higher priorities
+	case config.WeightedPriority:
+		allocateRoundRobin(system, unallocatedServers, available, priorityWeight)
 
 	// do not allocate beyond satisfying SLOs
 	case config.None:
@@ -191,21 +218,21 @@ func bestEffort(unallocatedServers []*serverEntry, available map[string]int, pol
 
 // Allocate remaining accelerators among unallocated servers
 //   - priority ordering: one server at a time exhaustively, until no resources to satisfy requirements
-func allocateMaximally(serverEntries []*serverEntry, available map[string]int) {
+func allocateMaximally(system *core.System, serverEntries []*serverEntry, available map[string]int) {
 	// fmt.Println("Unallocated server entries: ", serverEntries)
 	for _, entry := range serverEntries {
 		for _, alloc := range entry.allocations {
 			accName := alloc.Accelerator()
 			serverName := entry.serverName
-			server := core.GetServer(serverName)
-			model := core.GetModel(server.ModelName())
-			if acc := core.GetAccelerator(accName); acc != nil && model != nil && server != nil {
-				if unitsPerReplica := model.NumInstances(accName) * acc.Spec().Multiplicity; unitsPerReplica > 0 {
+			server := system.Server(serverName)
+			model := system.Model(server.ModelName())
+			if acc := system.Accelerator(accName); acc != nil && model != nil && server != nil {
+				if unitsPerReplica := model.NumInstancesOf(alloc) * acc.Spec().Multiplicity; unitsPerReplica > 0 {
 					maxReplicas := available[acc.Type()] / unitsPerReplica
 					if maxReplicas = min(maxReplicas, alloc.NumReplicas()); maxReplicas > 0 {
 						curNumReplicas := alloc.NumReplicas()
 						// adjust cost and value
-						factor := float32(maxReplicas) / float32(curNumReplicas)
+						factor := float64(maxReplicas) / float64(curNumReplicas)
 						alloc.SetCost(alloc.Cost() * factor)
 						alloc.SetValue(alloc.Value() * factor)
 						alloc.SetNumReplicas(maxReplicas)
@@ -236,15 +263,29 @@ type serverAllocationTicket struct {
 
 // Allocate remaining accelerators among a group of unallocated servers
 //   - round-robin allocation to members in group until no resources to satisfy requirements
-func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
+func allocateEqually(system *core.System, serverEntries []*serverEntry, available map[string]int) {
+	allocateRoundRobin(system, serverEntries, available, func(*serverEntry) int { return 1 })
+}
+
+// Weight of a server in weighted priority allocation: DefaultLowPriority for the highest priority, down to 1 for the lowest
+func priorityWeight(entry *serverEntry) int {
+	priority := min(max(entry.priority, config.DefaultHighPriority), config.DefaultLowPriority)
+	return config.DefaultLowPriority - priority + 1
+}
+
+// Allocate remaining accelerators among a group of unallocated servers
+//   - round-robin allocation to members in group until no resources to satisfy requirements,
+//     a member receiving up to weight(member) replicas per round
+func allocateRoundRobin(system *core.System, serverEntries []*serverEntry, available map[string]int,
+	weight func(*serverEntry) int) {
 	// fmt.Println("Unallocated server entries: ", serverEntries)
 
 	// create allocation tickets for all valid members in group
 	tickets := make(map[string]*serverAllocationTicket)
 	for _, serverEntry := range serverEntries {
 		serverName := serverEntry.serverName
-		server := core.GetServer(serverName)
-		model := core.GetModel(server.ModelName())
+		server := system.Server(serverName)
+		model := system.Model(server.ModelName())
 		if model == nil || server == nil {
 			continue
 		}
@@ -269,8 +310,8 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 			if !ticket.active {
 				for _, alloc := range serverEntry.allocations {
 					accName := alloc.Accelerator()
-					if acc := core.GetAccelerator(accName); acc != nil {
-						unitsPerReplica := ticket.model.NumInstances(accName) * acc.Spec().Multiplicity
+					if acc := system.Accelerator(accName); acc != nil {
+						unitsPerReplica := ticket.model.NumInstancesOf(alloc) * acc.Spec().Multiplicity
 						if unitsPerReplica > 0 && available[acc.Type()] >= unitsPerReplica {
 							ticket.active = true
 							ticket.accType = acc.Type()
@@ -286,11 +327,11 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 					continue
 				}
 			}
-			// make one allocation (replica) to member
+			// make allocation of up to weight replicas to member
 			replicasAvailable := available[ticket.accType] / ticket.unitsPerReplica
-			if replicasAllocatable := min(replicasAvailable, ticket.finalAlloc.NumReplicas()); replicasAllocatable > 0 {
-				ticket.numReplicas++
-				available[ticket.accType] -= ticket.unitsPerReplica
+			if replicasAllocatable := min(replicasAvailable, ticket.finalAlloc.NumReplicas(), max(weight(serverEntry), 1)); replicasAllocatable > 0 {
+				ticket.numReplicas += replicasAllocatable
+				available[ticket.accType] -= replicasAllocatable * ticket.unitsPerReplica
 				allocatedTickets[serverName] = ticket
 			} else {
 				// remove ticket if can no longer allocate
@@ -304,7 +345,7 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 		numReplicas := ticket.numReplicas
 		curNumReplicas := alloc.NumReplicas()
 		// adjust cost and value
-		factor := float32(numReplicas) / float32(curNumReplicas)
+		factor := float64(numReplicas) / float64(curNumReplicas)
 		alloc.SetCost(alloc.Cost() * factor)
 		alloc.SetValue(alloc.Value() * factor)
 		alloc.SetNumReplicas(numReplicas)
@@ -315,6 +356,83 @@ func allocateEqually(serverEntries []*serverEntry, available map[string]int) {
 	}
 }
 
+// Allocate remaining accelerators among a group of unallocated servers
+//   - each member is granted a share of the available units of its accelerator type in proportion
+//     to the units it requests, up to its request; units left over by rounding are then granted
+//     one replica at a time in order of members
+func allocateProportionally(system *core.System, serverEntries []*serverEntry, available map[string]int) {
+	// determine candidate allocation of members, as in round-robin allocation
+	tickets := make([]*serverAllocationTicket, 0, len(serverEntries))
+	requestedByType := make(map[string]int)
+	for _, serverEntry := range serverEntries {
+		server := system.Server(serverEntry.serverName)
+		if server == nil {
+			continue
+		}
+		model := system.Model(server.ModelName())
+		if model == nil {
+			continue
+		}
+		for _, alloc := range serverEntry.allocations {
+			accName := alloc.Accelerator()
+			if acc := system.Accelerator(accName); acc != nil {
+				unitsPerReplica := model.NumInstancesOf(alloc) * acc.Spec().Multiplicity
+				if unitsPerReplica > 0 && available[acc.Type()] >= unitsPerReplica {
+					tickets = append(tickets, &serverAllocationTicket{
+						entry:           serverEntry,
+						active:          true,
+						server:          server,
+						model:           model,
+						accType:         acc.Type(),
+						unitsPerReplica: unitsPerReplica,
+						finalAlloc:      alloc,
+					})
+					requestedByType[acc.Type()] += alloc.NumReplicas() * unitsPerReplica
+					break
+				}
+			}
+		}
+	}
+
+	// grant proportional shares
+	supplyByType := maps.Clone(available)
+	for _, ticket := range tickets {
+		requested := ticket.finalAlloc.NumReplicas() * ticket.unitsPerReplica
+		share := requested
+		if total := requestedByType[ticket.accType]; total > supplyByType[ticket.accType] {
+			share = supplyByType[ticket.accType] * requested / total
+		}
+		ticket.numReplicas = share / ticket.unitsPerReplica
+		available[ticket.accType] -= ticket.numReplicas * ticket.unitsPerReplica
+	}
+
+	// grant units left over by rounding
+	for granted := true; granted; {
+		granted = false
+		for _, ticket := range tickets {
+			if ticket.numReplicas < ticket.finalAlloc.NumReplicas() && available[ticket.accType] >= ticket.unitsPerReplica {
+				ticket.numReplicas++
+				available[ticket.accType] -= ticket.unitsPerReplica
+				granted = true
+			}
+		}
+	}
+
+	// update allocated members
+	for _, ticket := range tickets {
+		if ticket.numReplicas == 0 {
+			continue
+		}
+		alloc := ticket.finalAlloc
+		// adjust cost and value
+		factor := float64(ticket.numReplicas) / float64(alloc.NumReplicas())
+		alloc.SetCost(alloc.Cost() * factor)
+		alloc.SetValue(alloc.Value() * factor)
+		alloc.SetNumReplicas(ticket.numReplicas)
+		ticket.server.SetAllocation(alloc)
+	}
+}
+
 // Partition a list of server entries into groups of same priority
 //   - each group has same server priority
 //   - groups are ordered by priority
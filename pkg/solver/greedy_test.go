@@ -2,6 +2,7 @@ package solver
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -10,9 +11,8 @@ import (
 )
 
 // Helper function to create a basic system for testing
-func setupTestSystemForGreedy() {
+func setupTestSystemForGreedy() *core.System {
 	system := core.NewSystem()
-	core.TheSystem = system
 
 	// Set up accelerators
 	system.AddAcceleratorFromSpec(config.AcceleratorSpec{
@@ -193,6 +193,7 @@ func setupTestSystemForGreedy() {
 	})
 
 	system.Calculate()
+	return system
 }
 
 func TestServerEntry_String(t *testing.T) {
@@ -225,7 +226,6 @@ func TestServerEntry_String(t *testing.T) {
 func TestSolver_SolveGreedy_NoServers(t *testing.T) {
 	// Create empty system
 	system := core.NewSystem()
-	core.TheSystem = system
 
 	optimizerSpec := &config.OptimizerSpec{
 		Unlimited:         false,
@@ -233,15 +233,15 @@ func TestSolver_SolveGreedy_NoServers(t *testing.T) {
 		DelayedBestEffort: false,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 }
 
 func TestSolver_SolveGreedy_BasicAllocation(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers with service class targets
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server1",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -257,7 +257,7 @@ func TestSolver_SolveGreedy_BasicAllocation(t *testing.T) {
 	})
 
 	// Add service class with targets for the model
-	serviceClass := core.GetServiceClass("high-priority")
+	serviceClass := system.ServiceClass("high-priority")
 	if serviceClass != nil {
 		serviceClass.AddModelTarget(&config.ModelTarget{
 			Model:    "llama-7b",
@@ -268,8 +268,8 @@ func TestSolver_SolveGreedy_BasicAllocation(t *testing.T) {
 	}
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -278,11 +278,11 @@ func TestSolver_SolveGreedy_BasicAllocation(t *testing.T) {
 		DelayedBestEffort: false,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Verify allocation occurred
-	server1 := core.GetServer("server1")
+	server1 := system.Server("server1")
 	if server1 == nil {
 		t.Fatal("Server should exist after setup")
 	}
@@ -297,7 +297,7 @@ func TestBestEffort_None(t *testing.T) {
 	entries := []*serverEntry{}
 	available := map[string]int{"GPU_A100": 4}
 
-	bestEffort(entries, available, "None")
+	bestEffort(core.NewSystem(), entries, available, "None")
 
 	// With "None" policy, available should remain unchanged
 	if available["GPU_A100"] != 4 {
@@ -309,7 +309,7 @@ func TestAllocateEqually_EmptyEntries(t *testing.T) {
 	entries := []*serverEntry{}
 	available := map[string]int{"GPU_A100": 4}
 
-	allocateEqually(entries, available)
+	allocateEqually(core.NewSystem(), entries, available)
 
 	if available["GPU_A100"] != 4 {
 		t.Error("Available resources should remain unchanged with empty entries")
@@ -396,10 +396,10 @@ func TestMakePriorityGroups_OrderPreservation(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_PriorityExhaustive(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers that will trigger best effort allocation
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server1",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -414,7 +414,7 @@ func TestSolver_SolveGreedy_PriorityExhaustive(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server2",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -430,8 +430,8 @@ func TestSolver_SolveGreedy_PriorityExhaustive(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -440,12 +440,12 @@ func TestSolver_SolveGreedy_PriorityExhaustive(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Both servers should get allocations due to PriorityExhaustive policy
-	server1 := core.GetServer("server1")
-	server2 := core.GetServer("server2")
+	server1 := system.Server("server1")
+	server2 := system.Server("server2")
 
 	if server1 == nil || server2 == nil {
 		t.Fatal("Both servers should exist")
@@ -471,10 +471,10 @@ func TestSolver_SolveGreedy_PriorityExhaustive(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers in different priority groups
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server1",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -489,7 +489,7 @@ func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server2",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -504,7 +504,7 @@ func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server3",
 		Model: "llama-7b",
 		Class: "medium-priority",
@@ -520,8 +520,8 @@ func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -530,13 +530,13 @@ func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Servers should get allocations according to PriorityRoundRobin policy
-	server1 := core.GetServer("server1")
-	server2 := core.GetServer("server2")
-	server3 := core.GetServer("server3")
+	server1 := system.Server("server1")
+	server2 := system.Server("server2")
+	server3 := system.Server("server3")
 
 	if server1 == nil || server2 == nil || server3 == nil {
 		t.Fatal("All servers should exist")
@@ -560,10 +560,10 @@ func TestSolver_SolveGreedy_PriorityRoundRobin(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers with mixed priorities
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server1",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -578,7 +578,7 @@ func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server2",
 		Model: "llama-7b",
 		Class: "medium-priority",
@@ -593,7 +593,7 @@ func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server3",
 		Model: "llama-7b",
 		Class: "low-priority",
@@ -609,8 +609,8 @@ func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -619,13 +619,13 @@ func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// All servers should have a chance to get allocations with RoundRobin
-	server1 := core.GetServer("server1")
-	server2 := core.GetServer("server2")
-	server3 := core.GetServer("server3")
+	server1 := system.Server("server1")
+	server2 := system.Server("server2")
+	server3 := system.Server("server3")
 
 	if server1 == nil || server2 == nil || server3 == nil {
 		t.Fatal("All servers should exist")
@@ -649,15 +649,15 @@ func TestSolver_SolveGreedy_RoundRobin(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_ResourceExhaustion(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Reduce capacity to force resource exhaustion
-	core.TheSystem.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_A100", Count: 1}) // Very limited
-	core.TheSystem.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_H100", Count: 1})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_A100", Count: 1}) // Very limited
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_H100", Count: 1})
 
 	// Add multiple servers competing for limited resources
 	for i := 1; i <= 5; i++ {
-		core.TheSystem.AddServerFromSpec(config.ServerSpec{
+		system.AddServerFromSpec(config.ServerSpec{
 			Name:  fmt.Sprintf("server%d", i),
 			Model: "llama-7b",
 			Class: "high-priority",
@@ -674,8 +674,8 @@ func TestSolver_SolveGreedy_ResourceExhaustion(t *testing.T) {
 	}
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -684,7 +684,7 @@ func TestSolver_SolveGreedy_ResourceExhaustion(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// With extremely limited resources (1 A100, 1 H100) and 5 competing servers,
@@ -693,7 +693,7 @@ func TestSolver_SolveGreedy_ResourceExhaustion(t *testing.T) {
 
 	for i := 1; i <= 5; i++ {
 		serverName := fmt.Sprintf("server%d", i)
-		server := core.GetServer(serverName)
+		server := system.Server(serverName)
 		if server == nil {
 			t.Fatalf("Server %s should exist", serverName)
 		}
@@ -718,10 +718,10 @@ func TestSolver_SolveGreedy_ResourceExhaustion(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers with high load that will trigger better coverage in allocation algorithms
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server1",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -736,7 +736,7 @@ func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
 		MaxBatchSize:   32,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server2",
 		Model: "llama-7b",
 		Class: "medium-priority",
@@ -751,7 +751,7 @@ func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "server3",
 		Model: "llama-13b", // Different model requiring more resources
 		Class: "low-priority",
@@ -767,8 +767,8 @@ func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -777,13 +777,13 @@ func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Verify the algorithm handled high load scenario correctly
-	server1 := core.GetServer("server1")
-	server2 := core.GetServer("server2")
-	server3 := core.GetServer("server3")
+	server1 := system.Server("server1")
+	server2 := system.Server("server2")
+	server3 := system.Server("server3")
 
 	if server1 == nil || server2 == nil || server3 == nil {
 		t.Fatal("All servers should exist")
@@ -814,10 +814,10 @@ func TestSolver_SolveGreedy_HighLoadScenario(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_MixedModelTypes(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Add servers with different models to trigger different allocation paths
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "llama7b-server",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -832,7 +832,7 @@ func TestSolver_SolveGreedy_MixedModelTypes(t *testing.T) {
 		MaxBatchSize:   16,
 	})
 
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "llama13b-server",
 		Model: "llama-13b",
 		Class: "high-priority",
@@ -848,8 +848,8 @@ func TestSolver_SolveGreedy_MixedModelTypes(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -858,12 +858,12 @@ func TestSolver_SolveGreedy_MixedModelTypes(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Verify both servers exist and received allocations
-	llama7bServer := core.GetServer("llama7b-server")
-	llama13bServer := core.GetServer("llama13b-server")
+	llama7bServer := system.Server("llama7b-server")
+	llama13bServer := system.Server("llama13b-server")
 
 	if llama7bServer == nil || llama13bServer == nil {
 		t.Fatal("Both servers should exist")
@@ -889,10 +889,10 @@ func TestSolver_SolveGreedy_MixedModelTypes(t *testing.T) {
 }
 
 func TestSolver_SolveGreedy_EdgeCases(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Test with server that has no load (edge case)
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "zero-load-server",
 		Model: "llama-7b",
 		Class: "high-priority",
@@ -908,7 +908,7 @@ func TestSolver_SolveGreedy_EdgeCases(t *testing.T) {
 	})
 
 	// Test with server that has very high load
-	core.TheSystem.AddServerFromSpec(config.ServerSpec{
+	system.AddServerFromSpec(config.ServerSpec{
 		Name:  "high-load-server",
 		Model: "llama-7b",
 		Class: "medium-priority",
@@ -924,8 +924,8 @@ func TestSolver_SolveGreedy_EdgeCases(t *testing.T) {
 	})
 
 	// Calculate server allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -934,12 +934,12 @@ func TestSolver_SolveGreedy_EdgeCases(t *testing.T) {
 		DelayedBestEffort: true,
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveGreedy()
 
 	// Verify algorithm handles edge cases (zero load vs very high load)
-	zeroLoadServer := core.GetServer("zero-load-server")
-	highLoadServer := core.GetServer("high-load-server")
+	zeroLoadServer := system.Server("zero-load-server")
+	highLoadServer := system.Server("high-load-server")
 
 	if zeroLoadServer == nil || highLoadServer == nil {
 		t.Fatal("Both servers should exist")
@@ -965,7 +965,7 @@ func TestSolver_SolveGreedy_EdgeCases(t *testing.T) {
 }
 
 func TestAllocateMaximally_EdgeCases(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Test with empty server entries
 	t.Run("EmptyServerEntries", func(t *testing.T) {
@@ -974,7 +974,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 			"GPU_H100": 2,
 		}
 
-		allocateMaximally([]*serverEntry{}, available)
+		allocateMaximally(system, []*serverEntry{}, available)
 
 		// Available resources should remain unchanged
 		if available["GPU_A100"] != 4 || available["GPU_H100"] != 2 {
@@ -998,7 +998,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 			},
 		}
 
-		allocateMaximally(entries, available)
+		allocateMaximally(system, entries, available)
 
 		// available resources should remain unchanged
 		if available["GPU_A100"] != 4 || available["GPU_H100"] != 2 {
@@ -1013,7 +1013,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 			"GPU_H100": 0,
 		}
 
-		server := core.GetServer("server1")
+		server := system.Server("server1")
 		if server == nil {
 			t.Fatal("Could not find server1")
 		}
@@ -1034,7 +1034,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 		}
 
 		originalAllocation := server.Allocation()
-		allocateMaximally(entries, available)
+		allocateMaximally(system, entries, available)
 
 		// Server allocation should not change when no resources available
 		newAllocation := server.Allocation()
@@ -1050,7 +1050,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 			"GPU_H100": 4,
 		}
 
-		server := core.GetServer("server1")
+		server := system.Server("server1")
 		if server == nil {
 			t.Fatal("Could not find server1")
 		}
@@ -1079,7 +1079,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 			initialAvailable[k] = v
 		}
 
-		allocateMaximally(entries, available)
+		allocateMaximally(system, entries, available)
 
 		// Should have allocated some resources if possible
 		allocation := server.Allocation()
@@ -1101,7 +1101,7 @@ func TestAllocateMaximally_EdgeCases(t *testing.T) {
 }
 
 func TestAllocateEqually_EdgeCases(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Test with empty server entries
 	t.Run("EmptyServerEntries", func(t *testing.T) {
@@ -1110,7 +1110,7 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 			"GPU_H100": 2,
 		}
 
-		allocateEqually([]*serverEntry{}, available)
+		allocateEqually(system, []*serverEntry{}, available)
 
 		// Available resources should remain unchanged
 		if available["GPU_A100"] != 4 || available["GPU_H100"] != 2 {
@@ -1134,7 +1134,7 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 			},
 		}
 
-		allocateEqually(entries, available)
+		allocateEqually(system, entries, available)
 
 		// Available resources should remain unchanged since no allocations
 		if available["GPU_A100"] != 4 || available["GPU_H100"] != 2 {
@@ -1147,8 +1147,8 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 			"GPU_H100": 1,
 		}
 
-		server1 := core.GetServer("server1")
-		server2 := core.GetServer("server2")
+		server1 := system.Server("server1")
+		server2 := system.Server("server2")
 		if server1 == nil || server2 == nil {
 			t.Fatal("Could not find required servers")
 		}
@@ -1190,7 +1190,7 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 		initialA100 := available["GPU_A100"]
 		initialH100 := available["GPU_H100"]
 
-		allocateEqually(entries, available)
+		allocateEqually(system, entries, available)
 
 		// Verify that allocations were made
 		alloc1 := server1.Allocation()
@@ -1235,8 +1235,8 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 			"GPU_H100": 3,
 		}
 
-		server1 := core.GetServer("server1")
-		server3 := core.GetServer("server3")
+		server1 := system.Server("server1")
+		server3 := system.Server("server3")
 		if server1 == nil || server3 == nil {
 			t.Fatal("Could not find required servers")
 		}
@@ -1274,7 +1274,7 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 			},
 		}
 
-		allocateEqually(entries, available)
+		allocateEqually(system, entries, available)
 
 		// Both servers should get some allocation through multiple round-robin rounds
 		alloc1 := server1.Allocation()
@@ -1295,7 +1295,7 @@ func TestAllocateEqually_EdgeCases(t *testing.T) {
 }
 
 func TestAllocateEqually_TicketManagement(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Test that tickets are properly managed throughout the allocation process
 	t.Run("TicketLifecycle", func(t *testing.T) {
@@ -1304,7 +1304,7 @@ func TestAllocateEqually_TicketManagement(t *testing.T) {
 			"GPU_H100": 2,
 		}
 
-		server1 := core.GetServer("server1")
+		server1 := system.Server("server1")
 		if server1 == nil {
 			t.Fatal("Could not find server1")
 		}
@@ -1332,7 +1332,7 @@ func TestAllocateEqually_TicketManagement(t *testing.T) {
 		initialH100 := available["GPU_H100"]
 
 		// This tests the ticket creation, activation, and allocation process
-		allocateEqually(entries, available)
+		allocateEqually(system, entries, available)
 
 		// Verify server received an allocation
 		allocation := server1.Allocation()
@@ -1360,7 +1360,7 @@ func TestAllocateEqually_TicketManagement(t *testing.T) {
 			"GPU_H100": 0,
 		}
 
-		server1 := core.GetServer("server1")
+		server1 := system.Server("server1")
 		if server1 == nil {
 			t.Fatal("Could not find server1")
 		}
@@ -1384,7 +1384,7 @@ func TestAllocateEqually_TicketManagement(t *testing.T) {
 		}
 
 		// This tests that tickets are properly removed when no resources are available
-		allocateEqually(entries, available)
+		allocateEqually(system, entries, available)
 
 		// Should complete without panic even with no resources
 		if server1.Allocation() != nil {
@@ -1394,7 +1394,7 @@ func TestAllocateEqually_TicketManagement(t *testing.T) {
 }
 
 func TestBestEffort(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Test bestEffort function with various conditions to improve its coverage
 	t.Run("BestEffortWithMultipleEntries", func(t *testing.T) {
@@ -1404,9 +1404,9 @@ func TestBestEffort(t *testing.T) {
 		}
 
 		// Create multiple server entries with different priorities
-		server1 := core.GetServer("server1")
-		server2 := core.GetServer("server2")
-		server3 := core.GetServer("server3")
+		server1 := system.Server("server1")
+		server2 := system.Server("server2")
+		server3 := system.Server("server3")
 
 		if server1 == nil || server2 == nil || server3 == nil {
 			t.Fatal("Could not find required servers")
@@ -1438,7 +1438,7 @@ func TestBestEffort(t *testing.T) {
 		}
 
 		// Test the bestEffort function which contains the branching logic for saturation policies
-		bestEffort(allEntries, available, "PriorityExhaustive")
+		bestEffort(system, allEntries, available, "PriorityExhaustive")
 
 		// At least some servers should get allocations
 		allocatedCount := 0
@@ -1464,7 +1464,7 @@ func TestBestEffort(t *testing.T) {
 					"GPU_H100": 1,
 				}
 
-				server1 := core.GetServer("server1")
+				server1 := system.Server("server1")
 				if server1 == nil {
 					t.Fatal("Could not find server1")
 				}
@@ -1488,7 +1488,7 @@ func TestBestEffort(t *testing.T) {
 				}
 
 				// Should not panic regardless of policy
-				bestEffort(entries, available, policy)
+				bestEffort(system, entries, available, policy)
 
 				// For None policy, server should not get allocation
 				if policy == "None" {
@@ -1502,7 +1502,7 @@ func TestBestEffort(t *testing.T) {
 }
 
 func TestAllocate_ComprehensiveCoverage(t *testing.T) {
-	setupTestSystemForGreedy()
+	system := setupTestSystemForGreedy()
 
 	// Define a simple ordering function for testing
 	simpleOrder := func(a, b *serverEntry) int {
@@ -1525,7 +1525,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 			"GPU_H100": 2,
 		}
 
-		unallocated := allocate([]*serverEntry{}, available, simpleOrder)
+		unallocated := allocate(system, []*serverEntry{}, available, simpleOrder)
 		if len(unallocated) != 0 {
 			t.Errorf("Expected no unallocated entries with empty input, got %d", len(unallocated))
 		}
@@ -1548,7 +1548,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 			},
 		}
 
-		unallocated := allocate(entries, available, simpleOrder)
+		unallocated := allocate(system, entries, available, simpleOrder)
 		// Server with no allocations should be skipped (continue statement)
 		if len(unallocated) != 0 {
 			t.Errorf("Expected no unallocated entries when entries have no allocations")
@@ -1573,7 +1573,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 			},
 		}
 
-		unallocated := allocate(entries, available, simpleOrder)
+		unallocated := allocate(system, entries, available, simpleOrder)
 
 		// The nonexistent server entry should be skipped (continue statement)
 		// so no unallocated entries should be returned
@@ -1600,7 +1600,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 
 		// Test with empty entries (should not modify available resources)
 		entries := []*serverEntry{}
-		unallocated := allocate(entries, available, simpleOrder)
+		unallocated := allocate(system, entries, available, simpleOrder)
 
 		if len(unallocated) != 0 {
 			t.Errorf("Expected no unallocated entries with empty input, got %d", len(unallocated))
@@ -1614,22 +1614,22 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 
 	// Test allocation failure with resource exhaustion - this tests the else branch
 	t.Run("ResourceExhaustionWithReordering", func(t *testing.T) {
-		setupTestSystemForGreedy()
+		system := setupTestSystemForGreedy()
 
 		available := map[string]int{
 			"GPU_A100": 0, // No resources available to force else branch
 			"GPU_H100": 0,
 		}
 
-		server := core.GetServer("server1")
+		server := system.Server("server1")
 		if server == nil {
 			t.Fatal("Server1 should exist after setupTestSystemForGreedy")
 		}
 
 		// CRITICAL STEP: Calculate server allocations first (this creates the allAllocations map)
-		accelerators := core.GetAccelerators()
+		accelerators := system.Accelerators()
 
-		for _, srv := range core.GetServers() {
+		for _, srv := range system.Servers() {
 			srv.Calculate(accelerators)
 		}
 
@@ -1647,7 +1647,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 
 		for _, alloc := range allocations {
 			alloc.SetNumReplicas(10)               // High replica count to ensure resource failure
-			alloc.SetValue(float32(10 + count*10)) // Values: 10, 20, 30, etc.
+			alloc.SetValue(float64(10 + count*10)) // Values: 10, 20, 30, etc.
 			testAllocs = append(testAllocs, alloc)
 			count++
 			if count >= maxAllocs {
@@ -1665,7 +1665,7 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 			},
 		}
 
-		unallocated := allocate(entries, available, simpleOrder)
+		unallocated := allocate(system, entries, available, simpleOrder)
 
 		// With no resources, this should:
 		// 1. Fail first allocation (curIndex=0), increment to curIndex=1
@@ -1682,3 +1682,122 @@ func TestAllocate_ComprehensiveCoverage(t *testing.T) {
 	})
 
 }
+
+// Create a system with one single-unit accelerator type and a server per given priority,
+// returning entries requesting the given numbers of replicas
+func setupPolicyTestEntries(t *testing.T, priorities []int, requested []int) (*core.System, []*serverEntry) {
+	t.Helper()
+	system := core.NewSystem()
+	system.AddAcceleratorFromSpec(config.AcceleratorSpec{Name: "gpu", Type: "GPU", Multiplicity: 1, Cost: 1})
+	system.AddModel("model").AddPerfDataFromSpec(&config.ModelAcceleratorPerfData{
+		Name: "model", Acc: "gpu", AccCount: 1, MaxBatchSize: 16, AtTokens: 100,
+	})
+	entries := make([]*serverEntry, len(priorities))
+	for i, priority := range priorities {
+		className := fmt.Sprintf("class-%d", priority)
+		if system.ServiceClass(className) == nil {
+			system.AddServiceClass(className, priority)
+			system.ServiceClass(className).AddModelTarget(&config.ModelTarget{Model: "model", SLO_ITL: 100, SLO_TTFT: 1000})
+		}
+		serverName := fmt.Sprintf("server-%d", i)
+		system.AddServerFromSpec(config.ServerSpec{Name: serverName, Class: className, Model: "model", MinNumReplicas: 1})
+		alloc := system.CreateAllocation(serverName, "gpu")
+		if alloc == nil {
+			t.Fatalf("%s: no allocation", serverName)
+		}
+		alloc.SetNumReplicas(requested[i])
+		entries[i] = &serverEntry{serverName: serverName, priority: priority, allocations: []*core.Allocation{alloc}}
+	}
+	return system, entries
+}
+
+// Numbers of replicas allocated to the servers of the entries (0 if none)
+func allocatedReplicas(system *core.System, entries []*serverEntry) []int {
+	replicas := make([]int, len(entries))
+	for i, entry := range entries {
+		if alloc := system.Server(entry.serverName).Allocation(); alloc != nil {
+			replicas[i] = alloc.NumReplicas()
+		}
+	}
+	return replicas
+}
+
+func TestBestEffort_ProportionalShare(t *testing.T) {
+	tests := []struct {
+		name          string
+		requested     []int
+		available     int
+		want          []int
+		wantAvailable int
+	}{
+		{name: "proportional to requests", requested: []int{6, 2}, available: 4, want: []int{3, 1}, wantAvailable: 0},
+		{name: "rounding remainder to first", requested: []int{3, 3}, available: 5, want: []int{3, 2}, wantAvailable: 0},
+		{name: "enough for all requests", requested: []int{1, 2}, available: 10, want: []int{1, 2}, wantAvailable: 7},
+		{name: "small request rounded down", requested: []int{9, 1}, available: 5, want: []int{5, 0}, wantAvailable: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system, entries := setupPolicyTestEntries(t, []int{1, 100}, tt.requested)
+			available := map[string]int{"GPU": tt.available}
+
+			bestEffort(system, entries, available, "ProportionalShare")
+
+			if got := allocatedReplicas(system, entries); !slices.Equal(got, tt.want) {
+				t.Errorf("replicas = %v, want %v", got, tt.want)
+			}
+			if available["GPU"] != tt.wantAvailable {
+				t.Errorf("available = %d, want %d", available["GPU"], tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestBestEffort_WeightedPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []int
+		requested  []int
+		available  int
+		want       []int
+	}{
+		// weights 2 and 1: two replicas to the first server for each replica to the second
+		{name: "weights of adjacent priorities", priorities: []int{99, 100}, requested: []int{10, 10}, available: 9, want: []int{6, 3}},
+		// the highest priority takes up to its request in a single round
+		{name: "highest priority first", priorities: []int{1, 100}, requested: []int{10, 10}, available: 8, want: []int{8, 0}},
+		// equal priorities share equally
+		{name: "equal priorities", priorities: []int{100, 100}, requested: []int{10, 10}, available: 8, want: []int{4, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system, entries := setupPolicyTestEntries(t, tt.priorities, tt.requested)
+			available := map[string]int{"GPU": tt.available}
+
+			bestEffort(system, entries, available, "WeightedPriority")
+
+			if got := allocatedReplicas(system, entries); !slices.Equal(got, tt.want) {
+				t.Errorf("replicas = %v, want %v", got, tt.want)
+			}
+			if available["GPU"] != 0 {
+				t.Errorf("available = %d, want 0", available["GPU"])
+			}
+		})
+	}
+}
+
+func TestPriorityWeight(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     int
+	}{
+		{priority: config.DefaultHighPriority, want: config.DefaultLowPriority},
+		{priority: config.DefaultLowPriority, want: 1},
+		{priority: 50, want: 51},
+		{priority: 0, want: config.DefaultLowPriority},
+		{priority: 1000, want: 1},
+	}
+	for _, tt := range tests {
+		if got := priorityWeight(&serverEntry{priority: tt.priority}); got != tt.want {
+			t.Errorf("priorityWeight(%d) = %d, want %d", tt.priority, got, tt.want)
+		}
+	}
+}
@@ -2,22 +2,32 @@ package solver
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
 )
 
 type Optimizer struct {
+	system           *core.System
 	spec             *config.OptimizerSpec
 	solver           *Solver
 	solutionTimeMsec int64
+
+	// solution of the last run, kept to bound the churn of the next run
+	previousSolution map[string]*core.Allocation
+
+	// scheduler of time-sliced cost arbitrage, keeping daily cost across runs
+	costScheduler *CostScheduler
 }
 
-// Create optimizer from spec
-func NewOptimizerFromSpec(spec *config.OptimizerSpec) *Optimizer {
+// Create optimizer of a system from spec
+func NewOptimizerFromSpec(system *core.System, spec *config.OptimizerSpec) *Optimizer {
 	return &Optimizer{
-		spec: spec,
+		system: system,
+		spec:   spec,
 	}
 }
 
@@ -25,15 +35,43 @@ func (o *Optimizer) Optimize() error {
 	if o.spec == nil {
 		return fmt.Errorf("missing optimizer spec")
 	}
-	o.solver = NewSolver(o.spec)
+	if o.system == nil {
+		return fmt.Errorf("missing system")
+	}
+	if _, err := config.ParseSaturatedAllocationPolicy(o.spec.SaturationPolicy); err != nil {
+		return fmt.Errorf("invalid optimizer spec: %w", err)
+	}
+	if o.spec.CostArbitrage != nil && o.costScheduler == nil {
+		costScheduler, err := NewCostScheduler(o.spec.CostArbitrage)
+		if err != nil {
+			return fmt.Errorf("invalid cost arbitrage spec: %w", err)
+		}
+		o.costScheduler = costScheduler
+	}
+	o.solver = NewSolver(o.system, o.spec)
+	o.solver.SetPreviousSolution(o.previousSolution)
+	o.solver.SetCostScheduler(o.costScheduler)
 
 	startTime := time.Now()
 	err := o.solver.Solve()
 	endTime := time.Now()
 	o.solutionTimeMsec = endTime.Sub(startTime).Milliseconds()
+	if err == nil || errors.Is(err, ErrNoFeasibleSolution) {
+		o.previousSolution = o.solver.Solution()
+	}
 	return err
 }
 
+// Solution of the last run, used to bound the churn of the next run
+func (o *Optimizer) PreviousSolution() map[string]*core.Allocation {
+	return o.previousSolution
+}
+
+// Scheduler of time-sliced cost arbitrage (nil if disabled or not yet run)
+func (o *Optimizer) CostScheduler() *CostScheduler {
+	return o.costScheduler
+}
+
 func (o *Optimizer) SolutionTimeMsec() int64 {
 	return o.solutionTimeMsec
 }
@@ -11,9 +11,7 @@ func TestNewOptimizer(t *testing.T) {
 	tests := []struct {
 		name          string
 		optimizerSpec *config.OptimizerSpec
-		Optimizer     *Optimizer
-		Solver        *Solver
-		setup         func(optimizerSpec *config.OptimizerSpec)
+		setup         func(optimizerSpec *config.OptimizerSpec) *core.System
 		wantErr       bool
 	}{
 		{
@@ -22,12 +20,7 @@ func TestNewOptimizer(t *testing.T) {
 				Unlimited:        false,
 				SaturationPolicy: "None",
 			},
-			Optimizer: NewOptimizerFromSpec(&config.OptimizerSpec{
-				Unlimited:        false,
-				SaturationPolicy: "None",
-			}),
-			Solver: NewSolver(&config.OptimizerSpec{Unlimited: false, SaturationPolicy: "None"}),
-			setup: func(optimizerSpec *config.OptimizerSpec) {
+			setup: func(optimizerSpec *config.OptimizerSpec) *core.System {
 				system := core.NewSystem()
 				system.SetFromSpec(&config.SystemSpec{
 					Accelerators: config.AcceleratorData{
@@ -95,7 +88,7 @@ func TestNewOptimizer(t *testing.T) {
 						Spec: *optimizerSpec,
 					},
 				})
-				core.TheSystem = system
+				return system
 			},
 			wantErr: false,
 		},
@@ -105,12 +98,7 @@ func TestNewOptimizer(t *testing.T) {
 				Unlimited:        true,
 				SaturationPolicy: "None",
 			},
-			Optimizer: NewOptimizerFromSpec(&config.OptimizerSpec{
-				Unlimited:        true,
-				SaturationPolicy: "None",
-			}),
-			Solver: NewSolver(&config.OptimizerSpec{Unlimited: true, SaturationPolicy: "None"}),
-			setup: func(optimizerSpec *config.OptimizerSpec) {
+			setup: func(optimizerSpec *config.OptimizerSpec) *core.System {
 				system := core.NewSystem()
 				system.SetFromSpec(&config.SystemSpec{
 					Accelerators: config.AcceleratorData{
@@ -178,26 +166,33 @@ func TestNewOptimizer(t *testing.T) {
 						Spec: *optimizerSpec,
 					},
 				})
-				core.TheSystem = system
+				return system
 			},
 			wantErr: false,
 		},
 		{
 			name:          "nil optimizer spec",
 			optimizerSpec: nil,
-			Optimizer:     NewOptimizerFromSpec(nil),
-			Solver:        nil,
 			wantErr:       true, // Optimize() should fail
 		},
+		{
+			name:          "unknown saturation policy",
+			optimizerSpec: &config.OptimizerSpec{SaturationPolicy: "Random"},
+			setup: func(optimizerSpec *config.OptimizerSpec) *core.System {
+				return core.NewSystem()
+			},
+			wantErr: true, // Optimize() should reject the policy
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var system *core.System
 			if tt.setup != nil {
-				tt.setup(tt.optimizerSpec)
+				system = tt.setup(tt.optimizerSpec)
 			}
 
-			optimizer := tt.Optimizer
+			optimizer := NewOptimizerFromSpec(system, tt.optimizerSpec)
 			err := optimizer.Optimize()
 
 			if err == nil && tt.wantErr {
@@ -224,7 +219,7 @@ func TestOptimizer_String(t *testing.T) {
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(core.NewSystem(), optimizerSpec)
 	optimizer := &Optimizer{
 		spec:   optimizerSpec,
 		solver: solver,
@@ -0,0 +1,23 @@
+package solver
+
+import (
+	"cmp"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Ordering of candidate allocations of a server
+//   - accelerators earlier in the preference list of the server first,
+//     so that a secondary accelerator is only used when the preferred one is exhausted
+//   - then lower value, then accelerator name (for a deterministic order)
+func allocationOrder(server *core.Server) func(a, b *core.Allocation) int {
+	return func(a, b *core.Allocation) int {
+		if ra, rb := server.PreferenceRank(a.Accelerator()), server.PreferenceRank(b.Accelerator()); ra != rb {
+			return cmp.Compare(ra, rb)
+		}
+		if a.Value() != b.Value() {
+			return cmp.Compare(a.Value(), b.Value())
+		}
+		return cmp.Compare(a.Accelerator(), b.Accelerator())
+	}
+}
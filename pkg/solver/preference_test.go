@@ -0,0 +1,72 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// greedy test system where server1 prefers H100 over A100, with the given H100 capacity
+func setupPreferenceTestSystem(h100Count int) *core.System {
+	system := setupTestSystemForGreedy()
+	system.AddServerFromSpec(config.ServerSpec{
+		Name:  "server1",
+		Model: "llama-7b",
+		Class: "high-priority",
+		CurrentAlloc: config.AllocationData{
+			Load: config.ServerLoadSpec{
+				ArrivalRate:  30,
+				AvgInTokens:  100,
+				AvgOutTokens: 200,
+			},
+		},
+		MinNumReplicas:         1,
+		MaxBatchSize:           512,
+		AcceleratorPreferences: []string{"H100", "A100"},
+	})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_H100", Count: h100Count})
+	system.Calculate()
+	return system
+}
+
+func TestSolveGreedy_AcceleratorPreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		h100Count  int
+		wantAcc    string
+		substitute bool
+	}{
+		{name: "preferred accelerator available", h100Count: 2, wantAcc: "H100", substitute: false},
+		{name: "preferred accelerator exhausted", h100Count: 0, wantAcc: "A100", substitute: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system := setupPreferenceTestSystem(tt.h100Count)
+			NewSolver(system, &config.OptimizerSpec{SaturationPolicy: "None"}).SolveGreedy()
+
+			server := system.Server("server1")
+			alloc := server.Allocation()
+			if alloc == nil {
+				t.Fatal("expected allocation for server1")
+			}
+			if alloc.Accelerator() != tt.wantAcc {
+				t.Errorf("accelerator = %s, want %s", alloc.Accelerator(), tt.wantAcc)
+			}
+			if got := server.IsSubstitute(alloc.Accelerator()); got != tt.substitute {
+				t.Errorf("IsSubstitute() = %v, want %v", got, tt.substitute)
+			}
+		})
+	}
+}
+
+func TestSolveUnlimited_AcceleratorPreferences(t *testing.T) {
+	// preferred H100 is more expensive than A100, but capacity is not a concern
+	system := setupPreferenceTestSystem(0)
+	NewSolver(system, &config.OptimizerSpec{Unlimited: true}).SolveUnlimited()
+
+	alloc := system.Server("server1").Allocation()
+	if alloc == nil || alloc.Accelerator() != "H100" {
+		t.Errorf("allocation = %v, want allocation on preferred H100", alloc)
+	}
+}
@@ -0,0 +1,335 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Scenario of the golden-test corpus in testdata/scenarios
+type scenario struct {
+	Description string                           `json:"description"`
+	System      config.SystemSpec                `json:"system"`   // same keys as the system data of the JSON config
+	Expected    map[string]config.AllocationData `json:"expected"` // accelerator and number of replicas of allocated servers
+}
+
+// Load a scenario from a YAML file, keyed as the JSON config types
+func loadScenario(path string) (*scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(jsonData)))
+	decoder.DisallowUnknownFields()
+	sc := &scenario{}
+	if err := decoder.Decode(sc); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// Solve a system spec on a fresh system
+func solveSpec(t *testing.T, spec *config.SystemSpec) *core.System {
+	t.Helper()
+	system := core.NewSystem()
+	optimizerSpec := system.SetFromSpec(spec)
+	system.Calculate()
+	// servers without feasible allocation are left unallocated
+	if err := NewSolver(system, optimizerSpec).Solve(); err != nil && !errors.Is(err, ErrNoFeasibleSolution) {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	return system
+}
+
+// Paths of the scenarios of the golden-test corpus
+func scenarioPaths(t *testing.T) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "scenarios", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no scenarios found")
+	}
+	return paths
+}
+
+// Solve a scenario and check its solution against the expected allocations
+func checkScenario(t *testing.T, path string) {
+	t.Helper()
+	sc, err := loadScenario(path)
+	if err != nil {
+		t.Fatalf("loadScenario() error = %v", err)
+	}
+	system := solveSpec(t, &sc.System)
+	checkInvariants(t, system, &sc.System)
+
+	got := make(map[string]config.AllocationData)
+	for serverName, server := range system.Servers() {
+		if alloc := server.Allocation(); alloc != nil {
+			got[serverName] = config.AllocationData{Accelerator: alloc.Accelerator(), NumReplicas: alloc.NumReplicas()}
+		}
+	}
+	for _, serverName := range slices.Sorted(maps.Keys(sc.Expected)) {
+		want := sc.Expected[serverName]
+		if g, ok := got[serverName]; !ok {
+			t.Errorf("%s: no allocation, want %s x%d", serverName, want.Accelerator, want.NumReplicas)
+		} else if g.Accelerator != want.Accelerator || g.NumReplicas != want.NumReplicas {
+			t.Errorf("%s: allocation %s x%d, want %s x%d", serverName,
+				g.Accelerator, g.NumReplicas, want.Accelerator, want.NumReplicas)
+		}
+	}
+	for _, serverName := range slices.Sorted(maps.Keys(got)) {
+		if _, ok := sc.Expected[serverName]; !ok {
+			t.Errorf("%s: unexpected allocation %s x%d", serverName, got[serverName].Accelerator, got[serverName].NumReplicas)
+		}
+	}
+}
+
+func TestGoldenScenarios(t *testing.T) {
+	for _, path := range scenarioPaths(t) {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".yaml"), func(t *testing.T) {
+			checkScenario(t, path)
+		})
+	}
+}
+
+// Systems are independent: solving several of them concurrently yields the same solutions
+func TestGoldenScenariosConcurrently(t *testing.T) {
+	for _, path := range scenarioPaths(t) {
+		for i := range 4 {
+			t.Run(fmt.Sprintf("%s/%d", strings.TrimSuffix(filepath.Base(path), ".yaml"), i), func(t *testing.T) {
+				t.Parallel()
+				checkScenario(t, path)
+			})
+		}
+	}
+}
+
+// Check invariants of a solution
+//   - no negative number of replicas or cost
+//   - in limited mode, the accelerator units allocated per type do not exceed the capacity
+func checkInvariants(t *testing.T, system *core.System, spec *config.SystemSpec) {
+	t.Helper()
+	used := make(map[string]int)
+	for serverName, server := range system.Servers() {
+		alloc := server.Allocation()
+		if alloc == nil {
+			continue
+		}
+		if alloc.NumReplicas() < 0 {
+			t.Errorf("%s: negative number of replicas %d", serverName, alloc.NumReplicas())
+		}
+		if alloc.Cost() < 0 {
+			t.Errorf("%s: negative cost %v", serverName, alloc.Cost())
+		}
+		acc := system.Accelerator(alloc.Accelerator())
+		model := system.Model(server.ModelName())
+		if acc == nil || model == nil {
+			t.Errorf("%s: allocation %s on unknown accelerator or model", serverName, alloc.Accelerator())
+			continue
+		}
+		used[acc.Type()] += alloc.NumReplicas() * model.NumInstances(acc.Name()) * acc.Spec().Multiplicity
+	}
+	if spec.Optimizer.Spec.Unlimited {
+		return
+	}
+	for _, tName := range slices.Sorted(maps.Keys(used)) {
+		if capacity, _ := system.Capacity(tName); used[tName] > capacity {
+			t.Errorf("type %s: %d units allocated, exceeding capacity %d", tName, used[tName], capacity)
+		}
+	}
+}
+
+// Total cost of the allocations of a solution, and names of servers without allocation
+func solutionCost(system *core.System) (cost float64, unallocated []string) {
+	for serverName, server := range system.Servers() {
+		if alloc := server.Allocation(); alloc != nil {
+			cost += alloc.Cost()
+		} else {
+			unallocated = append(unallocated, serverName)
+		}
+	}
+	slices.Sort(unallocated)
+	return cost, unallocated
+}
+
+// Copy of a system spec with capacity of every accelerator type increased by the given number of units
+func withAddedCapacity(spec *config.SystemSpec, units int) *config.SystemSpec {
+	added := *spec
+	added.Capacity.Count = make([]config.AcceleratorCount, len(spec.Capacity.Count))
+	for i, count := range spec.Capacity.Count {
+		added.Capacity.Count[i] = config.AcceleratorCount{Type: count.Type, Count: count.Count + units}
+	}
+	return &added
+}
+
+// Generate a random system spec in limited mode, deterministic for a seed
+func randomSpec(seed uint64) *config.SystemSpec {
+	r := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	uniform := func(lo, hi float32) float32 { return lo + r.Float32()*(hi-lo) }
+	policies := []string{"None", "PriorityExhaustive", "PriorityRoundRobin", "RoundRobin", "ProportionalShare", "WeightedPriority"}
+
+	spec := &config.SystemSpec{}
+	accNames := []string{"A100", "H100", "L40S"}
+	for _, name := range accNames {
+		spec.Accelerators.Spec = append(spec.Accelerators.Spec, config.AcceleratorSpec{
+			Name:         name,
+			Type:         "GPU_" + name,
+			Multiplicity: 1 + r.IntN(2),
+			Power:        config.PowerSpec{Idle: 50, MidPower: 150, Full: 350, MidUtil: 0.4},
+			Cost:         float32(10 + r.IntN(90)),
+		})
+		spec.Capacity.Count = append(spec.Capacity.Count, config.AcceleratorCount{
+			Type:  "GPU_" + name,
+			Count: r.IntN(9),
+		})
+	}
+
+	numModels := 1 + r.IntN(3)
+	for m := range numModels {
+		for _, accName := range accNames {
+			if r.IntN(3) == 0 {
+				continue
+			}
+			spec.Models.PerfData = append(spec.Models.PerfData, config.ModelAcceleratorPerfData{
+				Name:         fmt.Sprintf("model-%d", m),
+				Acc:          accName,
+				AccCount:     1 + r.IntN(2),
+				MaxBatchSize: 8 + r.IntN(25),
+				AtTokens:     100,
+				ServiceParms: config.ServiceParms{
+					Alpha: uniform(5, 15),
+					Beta:  uniform(0.1, 0.3),
+					Gamma: uniform(0.005, 0.015),
+				},
+			})
+		}
+	}
+
+	numClasses := 1 + r.IntN(3)
+	for c := range numClasses {
+		class := config.ServiceClassSpec{Name: fmt.Sprintf("class-%d", c), Priority: c + 1}
+		for m := range numModels {
+			class.ModelTargets = append(class.ModelTargets, config.ModelTarget{
+				Model:    fmt.Sprintf("model-%d", m),
+				SLO_ITL:  uniform(300, 600),
+				SLO_TTFT: uniform(1500, 3000),
+			})
+		}
+		spec.ServiceClasses.Spec = append(spec.ServiceClasses.Spec, class)
+	}
+
+	numServers := 1 + r.IntN(6)
+	for i := range numServers {
+		spec.Servers.Spec = append(spec.Servers.Spec, config.ServerSpec{
+			Name:           fmt.Sprintf("server-%d", i),
+			Class:          fmt.Sprintf("class-%d", r.IntN(numClasses)),
+			Model:          fmt.Sprintf("model-%d", r.IntN(numModels)),
+			MinNumReplicas: 1,
+			CurrentAlloc: config.AllocationData{
+				Load: config.ServerLoadSpec{
+					ArrivalRate:  uniform(5, 300),
+					AvgInTokens:  50 + r.IntN(250),
+					AvgOutTokens: 100 + r.IntN(300),
+				},
+			},
+		})
+	}
+
+	spec.Optimizer.Spec = config.OptimizerSpec{
+		DelayedBestEffort: r.IntN(2) == 0,
+		SaturationPolicy:  policies[r.IntN(len(policies))],
+	}
+	return spec
+}
+
+// Copy of a system spec with a single server, without best effort allocation
+func singleServerSpec(spec *config.SystemSpec, server config.ServerSpec) *config.SystemSpec {
+	single := *spec
+	single.Servers.Spec = []config.ServerSpec{server}
+	single.Optimizer.Spec.SaturationPolicy = "None"
+	return &single
+}
+
+// Check the invariants of solutions of a random system, and of the same system with added capacity
+//   - capacity and non-negativity invariants hold for both solutions
+//   - cost is monotonic under added capacity: the cost of a server solved on its own does not increase,
+//     and a server allocated before added capacity stays allocated
+//     (across servers, added capacity may serve a higher-ranked server at the expense of others)
+//   - no server allocation is cheaper than its unlimited optimum, which is reached with ample capacity
+func checkRandomSpec(t *testing.T, seed uint64, units int) {
+	t.Helper()
+	spec := randomSpec(seed)
+	checkInvariants(t, solveSpec(t, spec), spec)
+	added := withAddedCapacity(spec, units)
+	checkInvariants(t, solveSpec(t, added), added)
+
+	for _, server := range spec.Servers.Spec {
+		single := singleServerSpec(spec, server)
+		cost, unallocated := solutionCost(solveSpec(t, single))
+		addedCost, addedUnallocated := solutionCost(solveSpec(t, withAddedCapacity(single, units)))
+		if len(unallocated) == 0 && len(addedUnallocated) > 0 {
+			t.Errorf("seed %d: %s lost its allocation with %d added units", seed, server.Name, units)
+		}
+		if len(unallocated) == 0 && addedCost > cost {
+			t.Errorf("seed %d: cost of %s increased from %v to %v with %d added units",
+				seed, server.Name, cost, addedCost, units)
+		}
+	}
+
+	limited := *spec
+	limited.Optimizer.Spec.SaturationPolicy = "None"
+	unlimited := limited
+	unlimited.Optimizer.Spec.Unlimited = true
+	limitedSystem := solveSpec(t, &limited)
+	unlimitedSystem := solveSpec(t, &unlimited)
+	for serverName, server := range limitedSystem.Servers() {
+		alloc := server.Allocation()
+		optimal := unlimitedSystem.Server(serverName).Allocation()
+		if alloc != nil && (optimal == nil || alloc.Cost() < optimal.Cost()) {
+			t.Errorf("seed %d: %s allocation %v cheaper than unlimited optimum %v", seed, serverName, alloc, optimal)
+		}
+	}
+	ampleCost, ampleUnallocated := solutionCost(solveSpec(t, withAddedCapacity(&limited, 1000)))
+	unlimitedCost, unlimitedUnallocated := solutionCost(unlimitedSystem)
+	if ampleCost != unlimitedCost || !slices.Equal(ampleUnallocated, unlimitedUnallocated) {
+		t.Errorf("seed %d: cost %v with ample capacity (unallocated %v), want unlimited cost %v (unallocated %v)",
+			seed, ampleCost, ampleUnallocated, unlimitedCost, unlimitedUnallocated)
+	}
+}
+
+func TestSolverInvariants(t *testing.T) {
+	for seed := range uint64(200) {
+		checkRandomSpec(t, seed, 1+int(seed%4))
+	}
+}
+
+func FuzzSolverInvariants(f *testing.F) {
+	for seed := range uint64(8) {
+		f.Add(seed, uint8(seed))
+	}
+	f.Fuzz(func(t *testing.T, seed uint64, units uint8) {
+		checkRandomSpec(t, seed, int(units%16))
+	})
+}
@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Simulate the desired allocations of servers changing by more than one replica,
+// running the queueing model forward with the new allocation at the current load
+//   - accepted allocations are replaced by the simulated ones, carrying predicted ITL, TTFT, and utilization
+//   - allocations the solver deemed feasible but predicted to violate SLOs are rejected,
+//     keeping the current allocation (e.g. churn-bounded allocations evaluated
+//     with stale parameters)
+//
+// Returns the names of servers whose allocation change was rejected
+func (s *Solver) simulate() []string {
+	rejected := make([]string, 0)
+	servers := s.system.Servers()
+	for _, serverName := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[serverName]
+		desired := server.Allocation()
+		if desired == nil {
+			continue
+		}
+		current := s.currentAllocation[serverName]
+		if replicaChange(current, desired) <= 1 {
+			continue
+		}
+
+		simulated := s.system.CreateShardedAllocationWithReplicas(serverName, desired.Accelerator(), desired.AccCount(), desired.NumReplicas())
+		if simulated != nil && (simulated.SLOViolation() == 0 || desired.SLOViolation() > 0) {
+			simulated.SetValue(desired.Value())
+			server.SetAllocation(simulated)
+			continue
+		}
+
+		if current != nil {
+			server.SetAllocation(current)
+		} else {
+			server.RemoveAllocation()
+		}
+		rejected = append(rejected, serverName)
+	}
+	return rejected
+}
+
+// Number of replicas started or stopped when changing from one allocation to another
+func replicaChange(from, to *core.Allocation) int {
+	switch {
+	case from == nil && to == nil:
+		return 0
+	case from == nil:
+		return to.NumReplicas()
+	case to == nil:
+		return from.NumReplicas()
+	case from.Accelerator() != to.Accelerator():
+		return max(from.NumReplicas(), to.NumReplicas())
+	default:
+		return abs(to.NumReplicas() - from.NumReplicas())
+	}
+}
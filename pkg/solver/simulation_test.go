@@ -0,0 +1,119 @@
+package solver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// greedy test system where high-priority server1 needs several A100 replicas
+func setupBusyTestSystem() *core.System {
+	system := setupTestSystemForGreedy()
+	system.ServiceClass("high-priority").AddModelTarget(&config.ModelTarget{
+		Model:    "llama-7b",
+		SLO_ITL:  400,
+		SLO_TTFT: 2000,
+		SLO_TPS:  800,
+	})
+	system.Calculate()
+	return system
+}
+
+func TestReplicaChange(t *testing.T) {
+	alloc := func(acc string, n int) *core.Allocation {
+		return core.AllocationFromData(&config.AllocationData{Accelerator: acc, NumReplicas: n})
+	}
+	tests := []struct {
+		name     string
+		from, to *core.Allocation
+		want     int
+	}{
+		{name: "none", want: 0},
+		{name: "new", to: alloc("A100", 3), want: 3},
+		{name: "removed", from: alloc("A100", 2), want: 2},
+		{name: "scale up", from: alloc("A100", 2), to: alloc("A100", 3), want: 1},
+		{name: "scale down", from: alloc("A100", 4), to: alloc("A100", 1), want: 3},
+		{name: "accelerator change", from: alloc("A100", 2), to: alloc("H100", 1), want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicaChange(tt.from, tt.to); got != tt.want {
+				t.Errorf("replicaChange() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolver_SimulateAcceptsFeasibleChange(t *testing.T) {
+	system := setupBusyTestSystem()
+	s := NewSolver(system, &config.OptimizerSpec{Unlimited: true, SimulateChanges: true})
+	if err := s.Solve(); err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if len(s.Rejected()) != 0 {
+		t.Errorf("Rejected() = %v, want none", s.Rejected())
+	}
+	if alloc := system.Server("server1").Allocation(); alloc == nil || alloc.NumReplicas() < 2 {
+		t.Fatalf("expected a multi-replica allocation for server1, got %v", alloc)
+	}
+
+	for serverName, server := range system.Servers() {
+		alloc := server.Allocation()
+		if alloc == nil || alloc.NumReplicas() <= 1 {
+			continue
+		}
+		// simulated allocations carry predictions at the current load
+		if alloc.Utilization() <= 0 || alloc.ITL() <= 0 {
+			t.Errorf("%s: expected predicted utilization and ITL, got %v", serverName, alloc)
+		}
+		data := alloc.AllocationData()
+		if data.Utilization != alloc.Utilization() || data.ITLAverage != alloc.ITL() || data.TTFTAverage != alloc.TTFT() {
+			t.Errorf("%s: AllocationData() = %+v, missing predictions", serverName, data)
+		}
+	}
+}
+
+func TestSolver_SimulateRejectsStaleAllocation(t *testing.T) {
+	system := setupBusyTestSystem()
+	server := system.Server("server1")
+	sized := system.CreateAllocation("server1", "A100")
+	if sized == nil || sized.NumReplicas() < 2 {
+		t.Fatalf("expected server1 to need several A100 replicas, got %v", sized)
+	}
+
+	// current allocation well above the need, stale desired allocation claiming one replica meets SLOs
+	current := system.CreateAllocationWithReplicas("server1", "A100", sized.NumReplicas()+2)
+	stale := core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: 1})
+
+	s := NewSolver(system, &config.OptimizerSpec{SimulateChanges: true})
+	s.currentAllocation = map[string]*core.Allocation{"server1": current}
+	server.SetAllocation(stale)
+
+	rejected := s.simulate()
+	if !slices.Contains(rejected, "server1") {
+		t.Fatalf("simulate() rejected = %v, want server1", rejected)
+	}
+	if got := server.Allocation(); got != current {
+		t.Errorf("Allocation() = %v, want current allocation %v", got, current)
+	}
+}
+
+func TestSolver_SimulateSkipsSingleReplicaChange(t *testing.T) {
+	system := setupTestSystemForGreedy()
+	server := system.Server("server1")
+	current := system.CreateAllocationWithReplicas("server1", "A100", 2)
+	stale := core.AllocationFromData(&config.AllocationData{Accelerator: "A100", NumReplicas: 1})
+
+	s := NewSolver(system, &config.OptimizerSpec{SimulateChanges: true})
+	s.currentAllocation = map[string]*core.Allocation{"server1": current}
+	server.SetAllocation(stale)
+
+	if rejected := s.simulate(); len(rejected) != 0 {
+		t.Errorf("simulate() rejected = %v, want none for a single-replica change", rejected)
+	}
+	if got := server.Allocation(); got != stale {
+		t.Errorf("Allocation() = %v, want unchanged %v", got, stale)
+	}
+}
@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// Allocate to servers that could not be satisfied, in order, the allocation with fewer replicas
+// that fits the available accelerators and has least value (cost plus SLO violation penalty),
+// returning servers that did not receive any allocation (soft SLO mode)
+func allocateLeastBad(system *core.System, entries []*serverEntry, available map[string]int) (unallocatedEntries []*serverEntry) {
+	unallocatedEntries = make([]*serverEntry, 0)
+	for _, entry := range entries {
+		serverName := entry.serverName
+		server := system.Server(serverName)
+		if server == nil {
+			continue
+		}
+		model := system.Model(server.ModelName())
+		if model == nil {
+			continue
+		}
+
+		var (
+			best      *core.Allocation
+			bestType  string
+			bestCount int
+		)
+		for _, alloc := range entry.allocations {
+			accName := alloc.Accelerator()
+			acc := system.Accelerator(accName)
+			if acc == nil {
+				continue
+			}
+			unitsPerReplica := model.NumInstancesOf(alloc) * acc.Spec().Multiplicity
+			if unitsPerReplica <= 0 {
+				continue
+			}
+			numReplicas := min(available[acc.Type()]/unitsPerReplica, alloc.NumReplicas())
+			if numReplicas <= 0 {
+				continue
+			}
+			candidate := system.CreateShardedAllocationWithReplicas(serverName, accName, alloc.AccCount(), numReplicas)
+			if candidate == nil {
+				continue
+			}
+			if best == nil || candidate.Value() < best.Value() {
+				best = candidate
+				bestType = acc.Type()
+				bestCount = numReplicas * unitsPerReplica
+			}
+		}
+
+		if best == nil {
+			unallocatedEntries = append(unallocatedEntries, entry)
+			continue
+		}
+		available[bestType] -= bestCount
+		server.SetAllocation(best)
+	}
+	return unallocatedEntries
+}
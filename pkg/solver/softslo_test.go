@@ -0,0 +1,54 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
+)
+
+// greedy test system with capacity for a single replica, short of the needs of server1
+func setupTightTestSystem(softSLO bool) *core.System {
+	system := setupTestSystemForGreedy()
+	system.ServiceClass("high-priority").AddModelTarget(&config.ModelTarget{
+		Model:    "llama-7b",
+		SLO_ITL:  400,
+		SLO_TTFT: 2000,
+		SLO_TPS:  2000,
+	})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_A100", Count: 0})
+	system.SetCountFromSpec(config.AcceleratorCount{Type: "GPU_H100", Count: 1})
+	system.SetSoftSLO(softSLO)
+	system.Calculate()
+	return system
+}
+
+func TestSolveGreedy_SoftSLO(t *testing.T) {
+	spec := &config.OptimizerSpec{SaturationPolicy: "None"}
+
+	system := setupTightTestSystem(false)
+	NewSolver(system, spec).SolveGreedy()
+	if alloc := system.Server("server1").Allocation(); alloc != nil {
+		t.Fatalf("expected no allocation for server1 without soft SLO mode, got %v", alloc)
+	}
+
+	system = setupTightTestSystem(true)
+	NewSolver(system, spec).SolveGreedy()
+	alloc := system.Server("server1").Allocation()
+	if alloc == nil {
+		t.Fatal("expected least-bad allocation for server1 in soft SLO mode")
+	}
+	if alloc.Accelerator() != "H100" || alloc.NumReplicas() != 1 {
+		t.Errorf("allocation = %v, want 1 replica on H100", alloc)
+	}
+	if alloc.SLOViolation() <= 0 {
+		t.Errorf("SLOViolation() = %v, want > 0", alloc.SLOViolation())
+	}
+
+	// capacity is exhausted by the highest priority server
+	for _, serverName := range []string{"server2", "server3"} {
+		if alloc := system.Server(serverName).Allocation(); alloc != nil {
+			t.Errorf("expected no allocation for %s, got %v", serverName, alloc)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package solver
 import (
 	"bytes"
 	"fmt"
-	"math"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/core"
@@ -11,6 +10,9 @@ import (
 
 // Solver of allocation assignment problem
 type Solver struct {
+	// system of accelerators, models, service classes, and servers to solve for
+	system *core.System
+
 	optimizerSpec *config.OptimizerSpec
 
 	// current allocation for all servers
@@ -18,10 +20,24 @@ type Solver struct {
 
 	// difference in allocation for all servers
 	diffAllocation map[string]*core.AllocationDiff
+
+	// solution of the previous run, which churn bounding keeps allocations close to
+	previousSolution map[string]*core.Allocation
+
+	// scheduler of time-sliced cost arbitrage (nil = disabled)
+	costScheduler *CostScheduler
+
+	// servers shifted to cheaper allocations by cost arbitrage in the last run
+	shifted []string
+
+	// servers whose allocation change was rejected by simulation in the last run
+	rejected []string
 }
 
-func NewSolver(optimizerSpec *config.OptimizerSpec) *Solver {
+// Create a solver for the servers of a system
+func NewSolver(system *core.System, optimizerSpec *config.OptimizerSpec) *Solver {
 	return &Solver{
+		system:            system,
 		optimizerSpec:     optimizerSpec,
 		currentAllocation: make(map[string]*core.Allocation),
 		diffAllocation:    make(map[string]*core.AllocationDiff),
@@ -29,10 +45,12 @@ func NewSolver(optimizerSpec *config.OptimizerSpec) *Solver {
 }
 
 // Find optimal allocation for all service classes
+//   - servers without feasible allocation on any accelerator yield a NoFeasibleSolutionError,
+//     matching ErrNoFeasibleSolution, along with the solution of the other servers
 func (s *Solver) Solve() error {
 	// take snapshot of current allocations
 	s.currentAllocation = make(map[string]*core.Allocation)
-	for serverName, server := range core.GetServers() {
+	for serverName, server := range s.system.Servers() {
 		if alloc := server.CurAllocation(); alloc != nil {
 			s.currentAllocation[serverName] = alloc
 		}
@@ -43,32 +61,46 @@ func (s *Solver) Solve() error {
 		s.SolveUnlimited()
 	} else {
 		s.SolveGreedy()
+		if s.optimizerSpec.MaxChangesPerCycle > 0 {
+			s.boundChurn(s.optimizerSpec.MaxChangesPerCycle)
+		}
+	}
+
+	// time-sliced cost arbitrage
+	s.shifted = nil
+	if s.costScheduler != nil {
+		s.shifted = s.costScheduler.Apply(s.system, s.optimizerSpec.Unlimited)
+	}
+
+	// simulation of multi-replica changes before emitting them
+	s.rejected = nil
+	if s.optimizerSpec.SimulateChanges {
+		s.rejected = s.simulate()
 	}
 
 	// TODO: cleanup after trying MIP solver
 
 	s.diffAllocation = make(map[string]*core.AllocationDiff)
-	for serverName, server := range core.GetServers() {
+	for serverName, server := range s.system.Servers() {
 		curAlloc := s.currentAllocation[serverName]
 		desiredAlloc := server.Allocation()
 		if allocDiff := core.CreateAllocationDiff(curAlloc, desiredAlloc); allocDiff != nil {
 			s.diffAllocation[serverName] = allocDiff
 		}
 	}
-	return nil
+	return noFeasibleSolution(s.system)
 }
 
 // Find optimal allocations assuming unlimited accelerator capacity
 // (separable objective function: best allocation for each server)
 func (s *Solver) SolveUnlimited() {
-	for _, server := range core.GetServers() {
+	for _, server := range s.system.Servers() {
 		server.RemoveAllocation()
-		// select allocation with minimum value
-		minVal := float32(math.MaxFloat32)
+		// select allocation on most preferred accelerator with minimum value
+		order := allocationOrder(server)
 		var minAlloc *core.Allocation
 		for _, alloc := range server.AllAllocations() {
-			if alloc.Value() < minVal {
-				minVal = alloc.Value()
+			if minAlloc == nil || order(alloc, minAlloc) < 0 {
 				minAlloc = alloc
 			}
 		}
@@ -78,6 +110,37 @@ func (s *Solver) SolveUnlimited() {
 	}
 }
 
+// Set the solution of a previous run, the baseline of churn bounding
+func (s *Solver) SetPreviousSolution(solution map[string]*core.Allocation) {
+	s.previousSolution = solution
+}
+
+// Solution of the last run: copy of the allocation of all servers
+func (s *Solver) Solution() map[string]*core.Allocation {
+	solution := make(map[string]*core.Allocation)
+	for serverName, server := range s.system.Servers() {
+		if alloc := server.Allocation(); alloc != nil {
+			solution[serverName] = alloc.Clone()
+		}
+	}
+	return solution
+}
+
+// Set the scheduler of time-sliced cost arbitrage, applied after solving
+func (s *Solver) SetCostScheduler(costScheduler *CostScheduler) {
+	s.costScheduler = costScheduler
+}
+
+// Names of servers shifted to cheaper allocations by cost arbitrage in the last run
+func (s *Solver) Shifted() []string {
+	return s.shifted
+}
+
+// Names of servers whose allocation change was rejected by simulation in the last run
+func (s *Solver) Rejected() []string {
+	return s.rejected
+}
+
 func (s *Solver) AllocationDiff() map[string]*core.AllocationDiff {
 	return s.diffAllocation
 }
@@ -39,7 +39,7 @@ func TestNewSolver(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			solver := NewSolver(tt.optimizerSpec)
+			solver := NewSolver(core.NewSystem(), tt.optimizerSpec)
 			if solver == nil && !tt.wantErr {
 				t.Fatal("NewSolver() returned nil unexpectedly")
 			}
@@ -63,7 +63,7 @@ func TestSolver_Solve(t *testing.T) {
 	tests := []struct {
 		name          string
 		optimizerSpec *config.OptimizerSpec
-		setup         func(optimizerSpec *config.OptimizerSpec)
+		setup         func(optimizerSpec *config.OptimizerSpec) *core.System
 		wantErr       bool
 	}{
 		{
@@ -72,7 +72,7 @@ func TestSolver_Solve(t *testing.T) {
 				Unlimited:        false,
 				SaturationPolicy: "None",
 			},
-			setup: func(optimizerSpec *config.OptimizerSpec) {
+			setup: func(optimizerSpec *config.OptimizerSpec) *core.System {
 				system := core.NewSystem()
 				system.SetFromSpec(&config.SystemSpec{
 					Accelerators: config.AcceleratorData{
@@ -140,7 +140,7 @@ func TestSolver_Solve(t *testing.T) {
 						Spec: *optimizerSpec,
 					},
 				})
-				core.TheSystem = system
+				return system
 			},
 			wantErr: false,
 		},
@@ -150,7 +150,7 @@ func TestSolver_Solve(t *testing.T) {
 				Unlimited:        true,
 				SaturationPolicy: "None",
 			},
-			setup: func(optimizerSpec *config.OptimizerSpec) {
+			setup: func(optimizerSpec *config.OptimizerSpec) *core.System {
 				system := core.NewSystem()
 				system.SetFromSpec(&config.SystemSpec{
 					Accelerators: config.AcceleratorData{
@@ -218,7 +218,7 @@ func TestSolver_Solve(t *testing.T) {
 						Spec: *optimizerSpec,
 					},
 				})
-				core.TheSystem = system
+				return system
 			},
 			wantErr: false,
 		},
@@ -226,11 +226,12 @@ func TestSolver_Solve(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			system := core.NewSystem()
 			if tt.setup != nil {
-				tt.setup(tt.optimizerSpec)
+				system = tt.setup(tt.optimizerSpec)
 			}
 
-			solver := NewSolver(tt.optimizerSpec)
+			solver := NewSolver(system, tt.optimizerSpec)
 			err := solver.Solve()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Solver.Solve() error = %v, wantErr %v", err, tt.wantErr)
@@ -245,7 +246,7 @@ func TestSolver_String(t *testing.T) {
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(core.NewSystem(), optimizerSpec)
 
 	str := solver.String()
 	if str == "" {
@@ -259,7 +260,7 @@ func TestSolver_AllocationDiff(t *testing.T) {
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(core.NewSystem(), optimizerSpec)
 
 	// Initially, AllocationDiff should return empty map
 	diffMap := solver.AllocationDiff()
@@ -373,11 +374,10 @@ func TestSolver_SolveUnlimited(t *testing.T) {
 			},
 		},
 	})
-	core.TheSystem = system
 
 	// Calculate server allocations to populate candidate allocations
-	for _, server := range core.GetServers() {
-		server.Calculate(core.GetAccelerators())
+	for _, server := range system.Servers() {
+		server.Calculate(system.Accelerators())
 	}
 
 	optimizerSpec := &config.OptimizerSpec{
@@ -385,13 +385,13 @@ func TestSolver_SolveUnlimited(t *testing.T) {
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 
 	// Test SolveUnlimited directly
 	solver.SolveUnlimited()
 
 	// Verify that servers received allocations (should select minimum value allocations)
-	servers := core.GetServers()
+	servers := system.Servers()
 	if len(servers) == 0 {
 		t.Fatal("Expected servers to exist in the system")
 	}
@@ -428,13 +428,12 @@ func TestSolver_SolveUnlimited_EdgeCases(t *testing.T) {
 	// Test SolveUnlimited with no servers
 	t.Run("NoServers", func(t *testing.T) {
 		system := core.NewSystem()
-		core.TheSystem = system
 
 		optimizerSpec := &config.OptimizerSpec{
 			Unlimited:        true,
 			SaturationPolicy: "None",
 		}
-		solver := NewSolver(optimizerSpec)
+		solver := NewSolver(system, optimizerSpec)
 
 		solver.SolveUnlimited()
 	})
@@ -493,10 +492,9 @@ func TestSolver_SolveUnlimited_EdgeCases(t *testing.T) {
 				},
 			},
 		})
-		core.TheSystem = system
 
 		// Clear all allocations from servers to test empty allocation case
-		for _, server := range core.GetServers() {
+		for _, server := range system.Servers() {
 			server.RemoveAllocation()
 		}
 
@@ -504,11 +502,11 @@ func TestSolver_SolveUnlimited_EdgeCases(t *testing.T) {
 			Unlimited:        true,
 			SaturationPolicy: "None",
 		}
-		solver := NewSolver(optimizerSpec)
+		solver := NewSolver(system, optimizerSpec)
 		solver.SolveUnlimited()
 
 		// Verify servers still have no allocations
-		for _, server := range core.GetServers() {
+		for _, server := range system.Servers() {
 			if server.Allocation() != nil {
 				t.Errorf("Expected server %s to have no allocation", server.Name())
 			}
@@ -578,17 +576,16 @@ func TestSolver_SolveUnlimited_MinValueSelection(t *testing.T) {
 			},
 		},
 	})
-	core.TheSystem = system
 
 	optimizerSpec := &config.OptimizerSpec{
 		Unlimited:        true,
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 
 	// Get server and its allocations to manipulate values
-	server := core.GetServer("server1")
+	server := system.Server("server1")
 	if server == nil {
 		t.Fatal("Could not find server1")
 	}
@@ -688,14 +685,13 @@ func TestSolver_String_WithDiffs(t *testing.T) {
 			},
 		},
 	})
-	core.TheSystem = system
 
 	optimizerSpec := &config.OptimizerSpec{
 		Unlimited:        false,
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 
 	// Run solve to potentially generate allocation diffs
 	err := solver.Solve()
@@ -783,12 +779,11 @@ func TestSolver_SolveUnlimited_ValueComparison(t *testing.T) {
 			},
 		},
 	})
-	core.TheSystem = system
 
 	// Ensure server has multiple allocations with different values
-	server := core.GetServer("test-server")
+	server := system.Server("test-server")
 	if server != nil {
-		server.Calculate(core.GetAccelerators())
+		server.Calculate(system.Accelerators())
 		allocations := server.AllAllocations()
 
 		if len(allocations) >= 2 {
@@ -810,11 +805,11 @@ func TestSolver_SolveUnlimited_ValueComparison(t *testing.T) {
 		SaturationPolicy: "None",
 	}
 
-	solver := NewSolver(optimizerSpec)
+	solver := NewSolver(system, optimizerSpec)
 	solver.SolveUnlimited()
 
 	// Verify minimum value logic was exercised correctly
-	server = core.GetServer("test-server")
+	server = system.Server("test-server")
 	if server == nil {
 		t.Fatal("Server should exist after solve")
 	}
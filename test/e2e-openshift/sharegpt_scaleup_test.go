@@ -31,12 +31,12 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/loadgen"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils"
 )
 
@@ -67,7 +67,7 @@ func truncateString(s string, n int) string {
 
 // Load generation configuration constants
 // These values were tuned empirically to achieve ~2-3 replica scale-up without excessive scaling.
-// Original values (baseLoadWorkers=10, batchSize=50, batchSleepDuration=0.1) caused cascade
+// Original values (baseLoadWorkers=10, batchSize=50, batchSleepDuration=100ms) caused cascade
 // scaling to 8+ replicas because WVA reconciles frequently (around every 30s with the tested
 // configuration) while pods take 5-7 min to become ready.
 //
@@ -79,9 +79,9 @@ const (
 	baseLoadWorkers         = 2     // Reduced from 10 to limit concurrent load (targets max ~3 replicas)
 	baseReplicas            = 2     // The replica count baseLoadWorkers is tuned for
 	maxSingleReplicaWorkers = 1     // Max workers when deployment has only 1 replica (prevents queue explosion)
-	batchSize               = 10    // Reduced from 50 to limit concurrent requests per batch
-	curlTimeoutSeconds      = 180   // Timeout for each curl request (increased for longer outputs)
-	batchSleepDuration      = "0.5" // Increased from 0.1 to slow request rate between batches
+	batchSize               = 10                     // Reduced from 50 to limit concurrent requests per batch
+	curlTimeout             = 180 * time.Second      // Timeout for each curl request (increased for longer outputs)
+	batchSleepDuration      = 500 * time.Millisecond // Increased from 0.1s to slow request rate between batches
 	// Note: maxTokens and requestsPerWorker are now per-model - see modelTestConfig
 )
 
@@ -676,117 +676,23 @@ exit 1`,
 // The gatewayService parameter should be the Istio gateway service name (port 80)
 // modelMaxTokens specifies max tokens per request (use lower values for fast responses, higher for sustained load)
 func createLoadGenerationJob(name, namespace, gatewayService, experimentLabel string, workerID, numRequests, modelMaxTokens int) *batchv1.Job {
-	backoffLimit := int32(0)
-
-	script := fmt.Sprintf(`#!/bin/sh
-# =============================================================================
-# Load Generator Configuration (injected from Go constants)
-# =============================================================================
-WORKER_ID=%d
-TOTAL_REQUESTS=%d
-BATCH_SIZE=%d
-CURL_TIMEOUT=%d
-MAX_TOKENS=%d
-BATCH_SLEEP=%s
-MODEL_ID="%s"
-GATEWAY_SERVICE="%s"
-MAX_RETRIES=24
-RETRY_DELAY=5
-
-# =============================================================================
-# Script Start
-# =============================================================================
-echo "Load generator worker $WORKER_ID starting..."
-echo "Sending $TOTAL_REQUESTS requests to gateway $GATEWAY_SERVICE:80"
-
-# Wait for gateway to be ready
-echo "Waiting for gateway $GATEWAY_SERVICE to be ready..."
-CONNECTED=false
-for i in $(seq 1 $MAX_RETRIES); do
-  if curl -s -o /dev/null -w "%%{http_code}" http://$GATEWAY_SERVICE:80/v1/models 2>/dev/null | grep -q 200; then
-    echo "Connection test passed on attempt $i"
-    CONNECTED=true
-    break
-  fi
-  echo "Attempt $i failed, retrying in ${RETRY_DELAY}s..."
-  sleep $RETRY_DELAY
-done
-
-if [ "$CONNECTED" != "true" ]; then
-  echo "ERROR: Cannot connect to gateway $GATEWAY_SERVICE after $MAX_RETRIES attempts"
-  exit 1
-fi
-
-# Send requests aggressively in parallel batches (ignore individual curl failures)
-SENT=0
-while [ $SENT -lt $TOTAL_REQUESTS ]; do
-  for i in $(seq 1 $BATCH_SIZE); do
-    if [ $SENT -ge $TOTAL_REQUESTS ]; then break; fi
-    (curl -s -o /dev/null --max-time $CURL_TIMEOUT -X POST http://$GATEWAY_SERVICE:80/v1/completions \
-      -H "Content-Type: application/json" \
-      -d "{\"model\":\"$MODEL_ID\",\"prompt\":\"Write a detailed explanation of machine learning algorithms.\",\"max_tokens\":$MAX_TOKENS}" || true) &
-    SENT=$((SENT + 1))
-  done
-  echo "Worker $WORKER_ID: sent $SENT / $TOTAL_REQUESTS requests..."
-  sleep $BATCH_SLEEP
-done
-
-# Wait for all to complete at the end
-wait || true
-
-echo "Worker $WORKER_ID: completed all $TOTAL_REQUESTS requests"
-exit 0
-`, workerID, numRequests, batchSize, curlTimeoutSeconds, modelMaxTokens, batchSleepDuration, modelID, gatewayService)
-
-	return &batchv1.Job{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"experiment": experimentLabel,
-				"worker":     fmt.Sprintf("%d", workerID),
-			},
-		},
-		Spec: batchv1.JobSpec{
-			BackoffLimit: &backoffLimit,
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"experiment": experimentLabel,
-						"worker":     fmt.Sprintf("%d", workerID),
-					},
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:    "load-generator",
-							Image:   "quay.io/curl/curl:8.11.1",
-							Command: []string{"/bin/sh", "-c"},
-							Args:    []string{script},
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceMemory: resource.MustParse("2Gi"),
-									corev1.ResourceCPU:    resource.MustParse("2"),
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse("500m"),
-									corev1.ResourceMemory: resource.MustParse("1Gi"),
-								},
-							},
-						},
-					},
-					RestartPolicy: corev1.RestartPolicyNever,
-				},
-			},
-		},
-	}
+	return loadgen.WorkerJob(name, namespace, workerID, loadgen.WorkerConfig{
+		TargetURL:      fmt.Sprintf("http://%s:80/v1/completions", gatewayService),
+		ModelID:        modelID,
+		NumRequests:    numRequests,
+		MaxTokens:      modelMaxTokens,
+		BatchSize:      batchSize,
+		BatchSleep:     batchSleepDuration,
+		RequestTimeout: curlTimeout,
+		Labels:         map[string]string{"experiment": experimentLabel},
+	})
 }
 
 // createParallelLoadJobsForModel creates multiple parallel load generation jobs for a specific model
 // Traffic is routed through the gateway service (port 80) to be properly handled by InferencePool/EPP
 func createParallelLoadJobsForModel(ctx context.Context, baseName, namespace, gatewayService string, numWorkers, requestsPerWorker, modelMaxTokens int) error {
 	for i := 1; i <= numWorkers; i++ {
-		jobName := fmt.Sprintf("%s-%d", baseName, i)
+		jobName := loadgen.WorkerJobName(baseName, i)
 		job := createLoadGenerationJob(jobName, namespace, gatewayService, baseName, i, requestsPerWorker, modelMaxTokens)
 		_, err := k8sClient.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
 		if err != nil {
@@ -801,7 +707,7 @@ func createParallelLoadJobsForModel(ctx context.Context, baseName, namespace, ga
 func deleteParallelLoadJobs(ctx context.Context, baseName, namespace string, numWorkers int) {
 	propagationPolicy := metav1.DeletePropagationBackground
 	for i := 1; i <= numWorkers; i++ {
-		jobName := fmt.Sprintf("%s-%d", baseName, i)
+		jobName := loadgen.WorkerJobName(baseName, i)
 		err := k8sClient.BatchV1().Jobs(namespace).Delete(ctx, jobName, metav1.DeleteOptions{
 			PropagationPolicy: &propagationPolicy,
 		})
@@ -0,0 +1,248 @@
+// Package scenario runs data-driven load scenarios for the e2e suites.
+//
+// A Scenario is a sequence of load phases read from YAML. Each phase sends requests at a
+// rate for a duration, and expects the desired replicas of the variant under test to reach
+// a range before the phase ends:
+//
+//	name: step-load
+//	phases:
+//	- name: baseline
+//	  rate: 0
+//	  duration: 2m
+//	  expectedReplicas: {min: 1, max: 1}
+//	- name: peak
+//	  rate: 20
+//	  duration: 8m
+//	  expectedReplicas: {min: 2, max: 6}
+//
+// The Runner drives the phases through a LoadDriver, which generates the load in the
+// cluster, and a ReplicaObserver, which reads the desired replicas, so that new lifecycle
+// scenarios are added as YAML files rather than Ginkgo code.
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a named sequence of load phases.
+type Scenario struct {
+	// Name identifies the scenario; it names the resources of its phases, so it must be a
+	// valid Kubernetes name
+	Name string `yaml:"name"`
+	// Description says what the scenario verifies
+	Description string `yaml:"description,omitempty"`
+	// Phases run in order
+	Phases []Phase `yaml:"phases"`
+}
+
+// Phase sends requests at a rate for a duration and expects the desired replicas in a range.
+type Phase struct {
+	Name string `yaml:"name"`
+	// Rate is the request rate in requests per second; 0 sends no load
+	Rate int `yaml:"rate"`
+	// Duration is how long the load is sent
+	Duration Duration `yaml:"duration"`
+	// ExpectedReplicas is the range the desired replicas must reach before the phase ends
+	ExpectedReplicas ReplicaRange `yaml:"expectedReplicas"`
+}
+
+// ReplicaRange is an inclusive range of replicas.
+type ReplicaRange struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+// Contains returns whether replicas is within the range.
+func (r ReplicaRange) Contains(replicas int) bool {
+	return replicas >= r.Min && replicas <= r.Max
+}
+
+// String returns the range as [min, max].
+func (r ReplicaRange) String() string {
+	return fmt.Sprintf("[%d, %d]", r.Min, r.Max)
+}
+
+// Duration is a time.Duration read from YAML as a Go duration string, e.g. "5m".
+type Duration time.Duration
+
+// UnmarshalYAML parses a Go duration string.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Load reads and validates a scenario from a YAML file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario %s: %w", path, err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse reads and validates a scenario from YAML.
+func Parse(data []byte) (*Scenario, error) {
+	var s Scenario
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that the scenario has phases and that each phase is runnable.
+func (s *Scenario) Validate() error {
+	if s.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(s.Phases) == 0 {
+		return errors.New("at least one phase is required")
+	}
+	names := make(map[string]bool, len(s.Phases))
+	for i, p := range s.Phases {
+		if p.Name == "" {
+			return fmt.Errorf("phase %d: name is required", i)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("phase %d: duplicate name %q", i, p.Name)
+		}
+		names[p.Name] = true
+		if p.Rate < 0 {
+			return fmt.Errorf("phase %q: rate must not be negative, got %d", p.Name, p.Rate)
+		}
+		if p.Duration <= 0 {
+			return fmt.Errorf("phase %q: duration must be positive", p.Name)
+		}
+		if p.ExpectedReplicas.Min < 0 || p.ExpectedReplicas.Max < p.ExpectedReplicas.Min {
+			return fmt.Errorf("phase %q: invalid expected replicas %s", p.Name, p.ExpectedReplicas)
+		}
+	}
+	return nil
+}
+
+// LoadDriver generates the load of phases in the cluster.
+type LoadDriver interface {
+	// Start starts sending the load of a phase with a positive rate.
+	Start(ctx context.Context, phase Phase) error
+	// Stop stops the load of a phase started by Start.
+	Stop(ctx context.Context, phase Phase) error
+}
+
+// ReplicaObserver returns the current desired replicas of the variant under test.
+type ReplicaObserver func(ctx context.Context) (int, error)
+
+// PhaseResult is the outcome of a phase.
+type PhaseResult struct {
+	Phase string
+	// Reached is whether the desired replicas reached the expected range
+	Reached bool
+	// ReachedAfter is how long after the start of the phase the range was reached
+	ReachedAfter time.Duration
+	// Replicas are the desired replicas observed last
+	Replicas int
+}
+
+// Runner runs scenarios.
+type Runner struct {
+	Driver  LoadDriver
+	Observe ReplicaObserver
+	// PollInterval is the interval between observations of the desired replicas
+	PollInterval time.Duration
+	// Logf logs the progress of the phases (optional)
+	Logf func(format string, args ...any)
+}
+
+// Run runs the phases of a scenario in order. The load of each phase is sent for its whole
+// duration, so that the next phase starts from the steady state of this one, and the
+// desired replicas are observed until they reach the expected range. Run stops at the first
+// phase whose range is not reached and returns the results of the phases run so far.
+func (r *Runner) Run(ctx context.Context, s *Scenario) ([]PhaseResult, error) {
+	var results []PhaseResult
+	for _, phase := range s.Phases {
+		result, err := r.runPhase(ctx, phase)
+		results = append(results, result)
+		if err != nil {
+			return results, fmt.Errorf("scenario %s, phase %s: %w", s.Name, phase.Name, err)
+		}
+	}
+	return results, nil
+}
+
+func (r *Runner) runPhase(ctx context.Context, phase Phase) (PhaseResult, error) {
+	result := PhaseResult{Phase: phase.Name}
+	r.logf("Phase %s: %d req/s for %s, expecting %s desired replicas",
+		phase.Name, phase.Rate, time.Duration(phase.Duration), phase.ExpectedReplicas)
+
+	if phase.Rate > 0 {
+		if err := r.Driver.Start(ctx, phase); err != nil {
+			return result, fmt.Errorf("failed to start load: %w", err)
+		}
+		defer func() {
+			if err := r.Driver.Stop(ctx, phase); err != nil {
+				r.logf("Phase %s: failed to stop load: %v", phase.Name, err)
+			}
+		}()
+	}
+
+	start := time.Now()
+	end := start.Add(time.Duration(phase.Duration))
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	for {
+		if !result.Reached {
+			replicas, err := r.Observe(ctx)
+			if err != nil {
+				r.logf("Phase %s: failed to observe desired replicas: %v", phase.Name, err)
+			} else {
+				result.Replicas = replicas
+				if phase.ExpectedReplicas.Contains(replicas) {
+					result.Reached = true
+					result.ReachedAfter = time.Since(start)
+					r.logf("Phase %s: reached %d desired replicas after %s",
+						phase.Name, replicas, result.ReachedAfter.Round(time.Second))
+				}
+			}
+		}
+
+		if !time.Now().Before(end) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	if !result.Reached {
+		return result, fmt.Errorf("desired replicas %d did not reach %s within %s",
+			result.Replicas, phase.ExpectedReplicas, time.Duration(phase.Duration))
+	}
+	return result, nil
+}
+
+func (r *Runner) logf(format string, args ...any) {
+	if r.Logf != nil {
+		r.Logf(format+"\n", args...)
+	}
+}
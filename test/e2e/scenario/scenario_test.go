@@ -0,0 +1,114 @@
+package scenario
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte(`name: step-load
+phases:
+- name: baseline
+  rate: 0
+  duration: 2m
+  expectedReplicas: {min: 1, max: 1}
+- name: peak
+  rate: 20
+  duration: 8m
+  expectedReplicas: {min: 2, max: 6}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "step-load", s.Name)
+	require.Len(t, s.Phases, 2)
+	assert.Equal(t, 20, s.Phases[1].Rate)
+	assert.Equal(t, Duration(8*time.Minute), s.Phases[1].Duration)
+	assert.Equal(t, ReplicaRange{Min: 2, Max: 6}, s.Phases[1].ExpectedReplicas)
+
+	for name, data := range map[string]string{
+		"no phases":        "name: empty",
+		"bad duration":     "name: s\nphases:\n- {name: a, rate: 1, duration: soon, expectedReplicas: {min: 1, max: 1}}",
+		"no duration":      "name: s\nphases:\n- {name: a, rate: 1, expectedReplicas: {min: 1, max: 1}}",
+		"inverted range":   "name: s\nphases:\n- {name: a, rate: 1, duration: 1m, expectedReplicas: {min: 3, max: 1}}",
+		"duplicate phases": "name: s\nphases:\n- {name: a, duration: 1m}\n- {name: a, duration: 1m}",
+	} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+// TestScenarioFiles validates the scenarios run by the e2e suite.
+func TestScenarioFiles(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "scenarios", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		_, err := Load(path)
+		assert.NoError(t, err, path)
+	}
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+type fakeDriver struct {
+	mu       sync.Mutex
+	replicas int
+	started  []string
+	stopped  []string
+}
+
+// Start scales the fake variant to one replica per 10 req/s of load, and Stop back to one.
+func (d *fakeDriver) Start(_ context.Context, phase Phase) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.started = append(d.started, phase.Name)
+	d.replicas = max(1, phase.Rate/10)
+	return nil
+}
+
+func (d *fakeDriver) Stop(_ context.Context, phase Phase) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = append(d.stopped, phase.Name)
+	d.replicas = 1
+	return nil
+}
+
+func (d *fakeDriver) observe(context.Context) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.replicas, nil
+}
+
+func TestRunner(t *testing.T) {
+	driver := &fakeDriver{replicas: 1}
+	runner := &Runner{Driver: driver, Observe: driver.observe, PollInterval: time.Millisecond}
+	s := &Scenario{Name: "step-load", Phases: []Phase{
+		{Name: "baseline", Duration: Duration(5 * time.Millisecond), ExpectedReplicas: ReplicaRange{Min: 1, Max: 1}},
+		{Name: "peak", Rate: 30, Duration: Duration(5 * time.Millisecond), ExpectedReplicas: ReplicaRange{Min: 2, Max: 4}},
+	}}
+
+	results, err := runner.Run(context.Background(), s)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[1].Reached)
+	assert.Equal(t, 3, results[1].Replicas)
+	// Phases without load start no load
+	assert.Equal(t, []string{"peak"}, driver.started)
+	assert.Equal(t, []string{"peak"}, driver.stopped)
+
+	// The scenario stops at the first phase whose range is not reached
+	s.Phases = append(s.Phases[:1:1],
+		Phase{Name: "overload", Rate: 100, Duration: Duration(5 * time.Millisecond), ExpectedReplicas: ReplicaRange{Min: 2, Max: 4}},
+		s.Phases[1])
+	results, err = runner.Run(context.Background(), s)
+	require.Error(t, err)
+	require.Len(t, results, 2)
+	assert.False(t, results[1].Reached)
+	assert.Equal(t, 10, results[1].Replicas)
+}
@@ -0,0 +1,202 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	variantautoscalingv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/e2e/fixtures"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/e2e/scenario"
+)
+
+// scenarioPollInterval is the interval between observations of the desired replicas
+const scenarioPollInterval = 10 * time.Second
+
+// jobLoadDriver sends the load of scenario phases with a load generation Job per phase.
+type jobLoadDriver struct {
+	baseName  string
+	targetURL string
+}
+
+func (d *jobLoadDriver) jobName(phase scenario.Phase) string {
+	return sanitizeK8sName(d.baseName + "-" + phase.Name)
+}
+
+func (d *jobLoadDriver) Start(ctx context.Context, phase scenario.Phase) error {
+	return fixtures.CreateLoadJob(ctx, k8sClient, cfg.LLMDNamespace, d.jobName(phase), d.targetURL, fixtures.LoadConfig{
+		Strategy:     cfg.LoadStrategy,
+		RequestRate:  phase.Rate,
+		NumPrompts:   phase.Rate * int(time.Duration(phase.Duration).Seconds()),
+		InputTokens:  cfg.InputTokens,
+		OutputTokens: cfg.OutputTokens,
+		ModelID:      cfg.ModelID,
+	})
+}
+
+func (d *jobLoadDriver) Stop(ctx context.Context, phase scenario.Phase) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+	err := k8sClient.BatchV1().Jobs(cfg.LLMDNamespace).Delete(ctx, d.jobName(phase)+"-load", metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// ScenarioLifecycleTest runs each scenario of test/e2e/scenarios against its own model
+// service, VariantAutoscaling and HPA. A new lifecycle scenario is added as a YAML file.
+var _ = Describe("Scenario Lifecycle Test", Label("full"), func() {
+	paths, err := filepath.Glob(filepath.Join("scenarios", "*.yaml"))
+	if err != nil {
+		panic(fmt.Sprintf("failed to list scenarios: %v", err))
+	}
+
+	for _, path := range paths {
+		s, err := scenario.Load(path)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		Describe(s.Name, Ordered, func() {
+			var (
+				poolName         = s.Name + "-pool"
+				modelServiceName = s.Name + "-ms"
+				vaName           = s.Name + "-va"
+				hpaName          = s.Name + "-hpa"
+				deploymentName   = modelServiceName + "-decode"
+				serviceName      = modelServiceName + "-service"
+			)
+
+			BeforeAll(func() {
+				By("Creating model service deployment")
+				err := fixtures.CreateModelService(ctx, k8sClient, cfg.LLMDNamespace, modelServiceName, poolName, cfg.ModelID, cfg.UseSimulator, cfg.MaxNumSeqs)
+				Expect(err).NotTo(HaveOccurred(), "Failed to create model service")
+				DeferCleanup(func() {
+					cleanupResource(ctx, "Deployment", cfg.LLMDNamespace, deploymentName,
+						func() error {
+							return k8sClient.AppsV1().Deployments(cfg.LLMDNamespace).Delete(ctx, deploymentName, metav1.DeleteOptions{})
+						},
+						func() bool {
+							_, err := k8sClient.AppsV1().Deployments(cfg.LLMDNamespace).Get(ctx, deploymentName, metav1.GetOptions{})
+							return errors.IsNotFound(err)
+						})
+				})
+
+				By("Creating service to expose model server")
+				err = fixtures.CreateService(ctx, k8sClient, cfg.LLMDNamespace, modelServiceName, deploymentName, 8000)
+				Expect(err).NotTo(HaveOccurred(), "Failed to create service")
+				DeferCleanup(func() {
+					cleanupResource(ctx, "Service", cfg.LLMDNamespace, serviceName,
+						func() error {
+							return k8sClient.CoreV1().Services(cfg.LLMDNamespace).Delete(ctx, serviceName, metav1.DeleteOptions{})
+						},
+						func() bool {
+							_, err := k8sClient.CoreV1().Services(cfg.LLMDNamespace).Get(ctx, serviceName, metav1.GetOptions{})
+							return errors.IsNotFound(err)
+						})
+				})
+
+				By("Creating ServiceMonitor for metrics scraping")
+				err = fixtures.CreateServiceMonitor(ctx, crClient, cfg.MonitoringNS, cfg.LLMDNamespace, modelServiceName, deploymentName)
+				Expect(err).NotTo(HaveOccurred(), "Failed to create ServiceMonitor")
+				DeferCleanup(func() {
+					serviceMonitorName := modelServiceName + "-monitor"
+					cleanupResource(ctx, "ServiceMonitor", cfg.MonitoringNS, serviceMonitorName,
+						func() error {
+							return crClient.Delete(ctx, &promoperator.ServiceMonitor{
+								ObjectMeta: metav1.ObjectMeta{Name: serviceMonitorName, Namespace: cfg.MonitoringNS},
+							})
+						},
+						func() bool {
+							err := crClient.Get(ctx, client.ObjectKey{Name: serviceMonitorName, Namespace: cfg.MonitoringNS}, &promoperator.ServiceMonitor{})
+							return errors.IsNotFound(err)
+						})
+				})
+
+				By("Waiting for model service to be ready")
+				Eventually(func(g Gomega) {
+					deployment, err := k8sClient.AppsV1().Deployments(cfg.LLMDNamespace).Get(ctx, deploymentName, metav1.GetOptions{})
+					g.Expect(err).NotTo(HaveOccurred(), "Should be able to get deployment")
+					g.Expect(deployment.Status.ReadyReplicas).To(BeNumerically(">=", 1), "Deployment should have at least 1 ready replica")
+				}, time.Duration(cfg.PodReadyTimeout)*time.Second, 5*time.Second).Should(Succeed())
+
+				By("Creating VariantAutoscaling resource")
+				err = fixtures.CreateVariantAutoscalingWithDefaults(
+					ctx, crClient, cfg.LLMDNamespace, vaName,
+					deploymentName, cfg.ModelID, cfg.AcceleratorType,
+				)
+				Expect(err).NotTo(HaveOccurred(), "Failed to create VariantAutoscaling")
+				DeferCleanup(func() {
+					va := &variantautoscalingv1alpha1.VariantAutoscaling{
+						ObjectMeta: metav1.ObjectMeta{Name: vaName, Namespace: cfg.LLMDNamespace},
+					}
+					cleanupResource(ctx, "VariantAutoscaling", cfg.LLMDNamespace, vaName,
+						func() error {
+							return crClient.Delete(ctx, va)
+						},
+						func() bool {
+							err := crClient.Get(ctx, client.ObjectKey{Name: vaName, Namespace: cfg.LLMDNamespace}, va)
+							return errors.IsNotFound(err)
+						})
+				})
+
+				By("Creating HPA for the deployment")
+				minReplicas := int32(1)
+				if cfg.ScaleToZeroEnabled {
+					minReplicas = 0
+				}
+				err = fixtures.CreateHPA(ctx, k8sClient, cfg.LLMDNamespace, hpaName, deploymentName, vaName, minReplicas, 10)
+				Expect(err).NotTo(HaveOccurred(), "Failed to create HPA")
+				DeferCleanup(func() {
+					hpaNameFull := hpaName + "-hpa"
+					cleanupResource(ctx, "HPA", cfg.LLMDNamespace, hpaNameFull,
+						func() error {
+							return k8sClient.AutoscalingV2().HorizontalPodAutoscalers(cfg.LLMDNamespace).Delete(ctx, hpaNameFull, metav1.DeleteOptions{})
+						},
+						func() bool {
+							_, err := k8sClient.AutoscalingV2().HorizontalPodAutoscalers(cfg.LLMDNamespace).Get(ctx, hpaNameFull, metav1.GetOptions{})
+							return errors.IsNotFound(err)
+						})
+				})
+			})
+
+			It(fmt.Sprintf("should run the %d phases of the scenario", len(s.Phases)), func() {
+				if s.Description != "" {
+					GinkgoWriter.Printf("Scenario %s: %s\n", s.Name, s.Description)
+				}
+				runner := &scenario.Runner{
+					Driver: &jobLoadDriver{
+						baseName:  s.Name,
+						targetURL: fmt.Sprintf("http://%s.%s.svc.cluster.local:8000/v1/completions", serviceName, cfg.LLMDNamespace),
+					},
+					Observe: func(ctx context.Context) (int, error) {
+						va := &variantautoscalingv1alpha1.VariantAutoscaling{}
+						if err := crClient.Get(ctx, client.ObjectKey{Namespace: cfg.LLMDNamespace, Name: vaName}, va); err != nil {
+							return 0, err
+						}
+						return va.Status.DesiredOptimizedAlloc.NumReplicas, nil
+					},
+					PollInterval: scenarioPollInterval,
+					Logf:         GinkgoWriter.Printf,
+				}
+
+				results, err := runner.Run(ctx, s)
+				for _, result := range results {
+					GinkgoWriter.Printf("Phase %s: reached=%v after %s, desired replicas %d\n",
+						result.Phase, result.Reached, result.ReachedAfter.Round(time.Second), result.Replicas)
+				}
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	}
+})